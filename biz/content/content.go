@@ -6,6 +6,7 @@ import (
 	"ncobase/biz/content/data"
 	"ncobase/biz/content/handler"
 	"ncobase/biz/content/service"
+	"ncobase/internal/manifest"
 	"ncobase/internal/middleware"
 	"sync"
 
@@ -111,6 +112,7 @@ func (m *Module) RegisterRoutes(r *gin.RouterGroup) {
 	{
 		taxonomies.GET("", m.h.Taxonomy.List)
 		taxonomies.POST("", m.h.Taxonomy.Create)
+		taxonomies.POST("/import", m.h.Taxonomy.Import)
 		taxonomies.GET("/:slug", m.h.Taxonomy.Get)
 		taxonomies.PUT("/:slug", m.h.Taxonomy.Update)
 		taxonomies.DELETE("/:slug", m.h.Taxonomy.Delete)
@@ -121,9 +123,15 @@ func (m *Module) RegisterRoutes(r *gin.RouterGroup) {
 	{
 		topics.GET("", m.h.Topic.List)
 		topics.POST("", m.h.Topic.Create)
+		topics.POST("/slug-check", m.h.Topic.CheckSlug)
 		topics.GET("/:slug", m.h.Topic.Get)
 		topics.PUT("/:slug", m.h.Topic.Update)
 		topics.DELETE("/:slug", m.h.Topic.Delete)
+		// Drafts and revisions
+		topics.POST("/:slug/drafts", m.h.Revision.Autosave)
+		topics.GET("/:slug/revisions", m.h.Revision.List)
+		topics.POST("/:slug/revisions/:revision_id/restore", m.h.Revision.Restore)
+		topics.POST("/:slug/revisions/:revision_id/publish", m.h.Revision.Publish)
 	}
 
 	// Channel endpoints
@@ -201,6 +209,15 @@ func (m *Module) GetMetadata() ext.Metadata {
 	}
 }
 
+// Manifest describes what the module needs beyond its metadata, routes are read from the router
+func (m *Module) Manifest() manifest.PluginManifest {
+	return manifest.PluginManifest{
+		Events: manifest.Events{
+			Subscribes: []string{"exts.resource.ready", "exts.all.registered"},
+		},
+	}
+}
+
 // Version returns the version of the module
 func (m *Module) Version() string {
 	return version
@@ -0,0 +1,48 @@
+package content
+
+import (
+	"net/http"
+	"testing"
+
+	"ncobase/biz/content/handler"
+	"ncobase/biz/content/service"
+	"ncobase/internal/manifest"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestManifestReportsRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/health", gin.WrapF(http.NotFound))
+
+	m := New().(*Module)
+	m.h = handler.New(&service.Service{})
+	m.RegisterRoutes(engine.Group("/biz"))
+	manifest.SetRouteSource(engine.Routes)
+	t.Cleanup(func() { manifest.SetRouteSource(nil) })
+
+	got := manifest.Of(m)
+	if got.Name != m.Name() || got.Version != m.Version() {
+		t.Fatalf("manifest %s %s, want the module metadata", got.Name, got.Version)
+	}
+	routes := map[manifest.Route]bool{}
+	for _, r := range got.Routes {
+		routes[r] = true
+	}
+	for _, want := range []manifest.Route{
+		{Method: http.MethodGet, Path: "/biz/cms/topics"},
+		{Method: http.MethodPost, Path: "/biz/cms/topics/:slug/drafts"},
+		{Method: http.MethodDelete, Path: "/biz/cms/taxonomies/:slug"},
+	} {
+		if !routes[want] {
+			t.Errorf("route %s %s missing from %v", want.Method, want.Path, got.Routes)
+		}
+	}
+	if routes[manifest.Route{Method: http.MethodGet, Path: "/health"}] {
+		t.Error("route of another package reported")
+	}
+	if len(got.Events.Subscribes) == 0 {
+		t.Error("event subscriptions missing")
+	}
+}
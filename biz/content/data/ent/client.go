@@ -18,6 +18,7 @@ import (
 	"ncobase/biz/content/data/ent/taxonomyrelation"
 	"ncobase/biz/content/data/ent/topic"
 	"ncobase/biz/content/data/ent/topicmedia"
+	"ncobase/biz/content/data/ent/topicrevision"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
@@ -46,6 +47,8 @@ type Client struct {
 	Topic *TopicClient
 	// TopicMedia is the client for interacting with the TopicMedia builders.
 	TopicMedia *TopicMediaClient
+	// TopicRevision is the client for interacting with the TopicRevision builders.
+	TopicRevision *TopicRevisionClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -64,6 +67,7 @@ func (c *Client) init() {
 	c.TaxonomyRelation = NewTaxonomyRelationClient(c.config)
 	c.Topic = NewTopicClient(c.config)
 	c.TopicMedia = NewTopicMediaClient(c.config)
+	c.TopicRevision = NewTopicRevisionClient(c.config)
 }
 
 type (
@@ -163,6 +167,7 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 		TaxonomyRelation: NewTaxonomyRelationClient(cfg),
 		Topic:            NewTopicClient(cfg),
 		TopicMedia:       NewTopicMediaClient(cfg),
+		TopicRevision:    NewTopicRevisionClient(cfg),
 	}, nil
 }
 
@@ -189,6 +194,7 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 		TaxonomyRelation: NewTaxonomyRelationClient(cfg),
 		Topic:            NewTopicClient(cfg),
 		TopicMedia:       NewTopicMediaClient(cfg),
+		TopicRevision:    NewTopicRevisionClient(cfg),
 	}, nil
 }
 
@@ -219,7 +225,7 @@ func (c *Client) Close() error {
 func (c *Client) Use(hooks ...Hook) {
 	for _, n := range []interface{ Use(...Hook) }{
 		c.CMSChannel, c.Distribution, c.Media, c.Taxonomy, c.TaxonomyRelation, c.Topic,
-		c.TopicMedia,
+		c.TopicMedia, c.TopicRevision,
 	} {
 		n.Use(hooks...)
 	}
@@ -230,7 +236,7 @@ func (c *Client) Use(hooks ...Hook) {
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	for _, n := range []interface{ Intercept(...Interceptor) }{
 		c.CMSChannel, c.Distribution, c.Media, c.Taxonomy, c.TaxonomyRelation, c.Topic,
-		c.TopicMedia,
+		c.TopicMedia, c.TopicRevision,
 	} {
 		n.Intercept(interceptors...)
 	}
@@ -253,6 +259,8 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.Topic.mutate(ctx, m)
 	case *TopicMediaMutation:
 		return c.TopicMedia.mutate(ctx, m)
+	case *TopicRevisionMutation:
+		return c.TopicRevision.mutate(ctx, m)
 	default:
 		return nil, fmt.Errorf("ent: unknown mutation type %T", m)
 	}
@@ -1253,15 +1261,148 @@ func (c *TopicMediaClient) mutate(ctx context.Context, m *TopicMediaMutation) (V
 	}
 }
 
+// TopicRevisionClient is a client for the TopicRevision schema.
+type TopicRevisionClient struct {
+	config
+}
+
+// NewTopicRevisionClient returns a client for the TopicRevision from the given config.
+func NewTopicRevisionClient(c config) *TopicRevisionClient {
+	return &TopicRevisionClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `topicrevision.Hooks(f(g(h())))`.
+func (c *TopicRevisionClient) Use(hooks ...Hook) {
+	c.hooks.TopicRevision = append(c.hooks.TopicRevision, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `topicrevision.Intercept(f(g(h())))`.
+func (c *TopicRevisionClient) Intercept(interceptors ...Interceptor) {
+	c.inters.TopicRevision = append(c.inters.TopicRevision, interceptors...)
+}
+
+// Create returns a builder for creating a TopicRevision entity.
+func (c *TopicRevisionClient) Create() *TopicRevisionCreate {
+	mutation := newTopicRevisionMutation(c.config, OpCreate)
+	return &TopicRevisionCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of TopicRevision entities.
+func (c *TopicRevisionClient) CreateBulk(builders ...*TopicRevisionCreate) *TopicRevisionCreateBulk {
+	return &TopicRevisionCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *TopicRevisionClient) MapCreateBulk(slice any, setFunc func(*TopicRevisionCreate, int)) *TopicRevisionCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &TopicRevisionCreateBulk{err: fmt.Errorf("calling to TopicRevisionClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*TopicRevisionCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &TopicRevisionCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for TopicRevision.
+func (c *TopicRevisionClient) Update() *TopicRevisionUpdate {
+	mutation := newTopicRevisionMutation(c.config, OpUpdate)
+	return &TopicRevisionUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *TopicRevisionClient) UpdateOne(_m *TopicRevision) *TopicRevisionUpdateOne {
+	mutation := newTopicRevisionMutation(c.config, OpUpdateOne, withTopicRevision(_m))
+	return &TopicRevisionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *TopicRevisionClient) UpdateOneID(id string) *TopicRevisionUpdateOne {
+	mutation := newTopicRevisionMutation(c.config, OpUpdateOne, withTopicRevisionID(id))
+	return &TopicRevisionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for TopicRevision.
+func (c *TopicRevisionClient) Delete() *TopicRevisionDelete {
+	mutation := newTopicRevisionMutation(c.config, OpDelete)
+	return &TopicRevisionDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *TopicRevisionClient) DeleteOne(_m *TopicRevision) *TopicRevisionDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *TopicRevisionClient) DeleteOneID(id string) *TopicRevisionDeleteOne {
+	builder := c.Delete().Where(topicrevision.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &TopicRevisionDeleteOne{builder}
+}
+
+// Query returns a query builder for TopicRevision.
+func (c *TopicRevisionClient) Query() *TopicRevisionQuery {
+	return &TopicRevisionQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeTopicRevision},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a TopicRevision entity by its id.
+func (c *TopicRevisionClient) Get(ctx context.Context, id string) (*TopicRevision, error) {
+	return c.Query().Where(topicrevision.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *TopicRevisionClient) GetX(ctx context.Context, id string) *TopicRevision {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *TopicRevisionClient) Hooks() []Hook {
+	return c.hooks.TopicRevision
+}
+
+// Interceptors returns the client interceptors.
+func (c *TopicRevisionClient) Interceptors() []Interceptor {
+	return c.inters.TopicRevision
+}
+
+func (c *TopicRevisionClient) mutate(ctx context.Context, m *TopicRevisionMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&TopicRevisionCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&TopicRevisionUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&TopicRevisionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&TopicRevisionDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown TopicRevision mutation op: %q", m.Op())
+	}
+}
+
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		CMSChannel, Distribution, Media, Taxonomy, TaxonomyRelation, Topic,
-		TopicMedia []ent.Hook
+		CMSChannel, Distribution, Media, Taxonomy, TaxonomyRelation, Topic, TopicMedia,
+		TopicRevision []ent.Hook
 	}
 	inters struct {
-		CMSChannel, Distribution, Media, Taxonomy, TaxonomyRelation, Topic,
-		TopicMedia []ent.Interceptor
+		CMSChannel, Distribution, Media, Taxonomy, TaxonomyRelation, Topic, TopicMedia,
+		TopicRevision []ent.Interceptor
 	}
 )
 
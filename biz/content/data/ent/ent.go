@@ -13,6 +13,7 @@ import (
 	"ncobase/biz/content/data/ent/taxonomyrelation"
 	"ncobase/biz/content/data/ent/topic"
 	"ncobase/biz/content/data/ent/topicmedia"
+	"ncobase/biz/content/data/ent/topicrevision"
 	"reflect"
 	"sync"
 
@@ -86,6 +87,7 @@ func checkColumn(t, c string) error {
 			taxonomyrelation.Table: taxonomyrelation.ValidColumn,
 			topic.Table:            topic.ValidColumn,
 			topicmedia.Table:       topicmedia.ValidColumn,
+			topicrevision.Table:    topicrevision.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
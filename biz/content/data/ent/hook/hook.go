@@ -92,6 +92,18 @@ func (f TopicMediaFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value,
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.TopicMediaMutation", m)
 }
 
+// The TopicRevisionFunc type is an adapter to allow the use of ordinary
+// function as TopicRevision mutator.
+type TopicRevisionFunc func(context.Context, *ent.TopicRevisionMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f TopicRevisionFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.TopicRevisionMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.TopicRevisionMutation", m)
+}
+
 // Condition is a hook condition function.
 type Condition func(context.Context, ent.Mutation) bool
 
@@ -187,6 +187,7 @@ var (
 		{Name: "keywords", Type: field.TypeString, Nullable: true, Comment: "keywords"},
 		{Name: "description", Type: field.TypeString, Nullable: true, Size: 2147483647, Comment: "description"},
 		{Name: "status", Type: field.TypeInt, Comment: "status: 0 activated, 1 unactivated, 2 disabled", Default: 0},
+		{Name: "order", Type: field.TypeInt, Comment: "display order", Default: 0},
 		{Name: "extras", Type: field.TypeJSON, Nullable: true, Comment: "Extend properties"},
 		{Name: "parent_id", Type: field.TypeString, Nullable: true, Comment: "parent id"},
 		{Name: "space_id", Type: field.TypeString, Nullable: true, Comment: "space id, e.g. space id, organization id, store id"},
@@ -214,17 +215,17 @@ var (
 			{
 				Name:    "taxonomy_parent_id",
 				Unique:  false,
-				Columns: []*schema.Column{NcseCmsTaxonomyColumns[13]},
+				Columns: []*schema.Column{NcseCmsTaxonomyColumns[14]},
 			},
 			{
 				Name:    "taxonomy_space_id",
 				Unique:  false,
-				Columns: []*schema.Column{NcseCmsTaxonomyColumns[14]},
+				Columns: []*schema.Column{NcseCmsTaxonomyColumns[15]},
 			},
 			{
 				Name:    "taxonomy_id_created_at",
 				Unique:  true,
-				Columns: []*schema.Column{NcseCmsTaxonomyColumns[0], NcseCmsTaxonomyColumns[17]},
+				Columns: []*schema.Column{NcseCmsTaxonomyColumns[0], NcseCmsTaxonomyColumns[18]},
 			},
 		},
 	}
@@ -271,7 +272,7 @@ var (
 		{Name: "id", Type: field.TypeString, Unique: true, Size: 16, Comment: "primary key"},
 		{Name: "name", Type: field.TypeString, Nullable: true, Comment: "name"},
 		{Name: "title", Type: field.TypeString, Nullable: true, Comment: "title"},
-		{Name: "slug", Type: field.TypeString, Unique: true, Nullable: true, Comment: "slug / alias"},
+		{Name: "slug", Type: field.TypeString, Nullable: true, Comment: "slug / alias"},
 		{Name: "content", Type: field.TypeString, Nullable: true, Comment: "content, big text"},
 		{Name: "thumbnail", Type: field.TypeString, Nullable: true, Comment: "thumbnail"},
 		{Name: "temp", Type: field.TypeBool, Nullable: true, Comment: "is temp", Default: false},
@@ -307,11 +308,6 @@ var (
 				Unique:  true,
 				Columns: []*schema.Column{NcseCmsTopicColumns[0]},
 			},
-			{
-				Name:    "topic_slug",
-				Unique:  true,
-				Columns: []*schema.Column{NcseCmsTopicColumns[3]},
-			},
 			{
 				Name:    "topic_taxonomy_id",
 				Unique:  false,
@@ -327,6 +323,11 @@ var (
 				Unique:  true,
 				Columns: []*schema.Column{NcseCmsTopicColumns[0], NcseCmsTopicColumns[16]},
 			},
+			{
+				Name:    "topic_slug_space_id",
+				Unique:  true,
+				Columns: []*schema.Column{NcseCmsTopicColumns[3], NcseCmsTopicColumns[12]},
+			},
 		},
 	}
 	// NcseCmsTopicMediaColumns holds the columns for the "ncse_cms_topic_media" table.
@@ -378,6 +379,48 @@ var (
 			},
 		},
 	}
+	// NcseCmsTopicRevisionColumns holds the columns for the "ncse_cms_topic_revision" table.
+	NcseCmsTopicRevisionColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, Unique: true, Size: 16, Comment: "primary key"},
+		{Name: "title", Type: field.TypeString, Nullable: true, Comment: "title"},
+		{Name: "content", Type: field.TypeString, Nullable: true, Comment: "content, big text"},
+		{Name: "markdown", Type: field.TypeBool, Nullable: true, Comment: "is markdown", Default: false},
+		{Name: "created_by", Type: field.TypeString, Nullable: true, Comment: "id of the creator"},
+		{Name: "updated_by", Type: field.TypeString, Nullable: true, Comment: "id of the last updater"},
+		{Name: "created_at", Type: field.TypeInt64, Nullable: true, Comment: "created at"},
+		{Name: "updated_at", Type: field.TypeInt64, Nullable: true, Comment: "updated at"},
+		{Name: "topic_id", Type: field.TypeString, Comment: "Topic ID"},
+		{Name: "author_id", Type: field.TypeString, Comment: "Author of the draft"},
+		{Name: "published_at", Type: field.TypeInt64, Nullable: true, Comment: "When the revision was published to the topic"},
+	}
+	// NcseCmsTopicRevisionTable holds the schema information for the "ncse_cms_topic_revision" table.
+	NcseCmsTopicRevisionTable = &schema.Table{
+		Name:       "ncse_cms_topic_revision",
+		Columns:    NcseCmsTopicRevisionColumns,
+		PrimaryKey: []*schema.Column{NcseCmsTopicRevisionColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "topicrevision_id",
+				Unique:  true,
+				Columns: []*schema.Column{NcseCmsTopicRevisionColumns[0]},
+			},
+			{
+				Name:    "topicrevision_id_created_at",
+				Unique:  true,
+				Columns: []*schema.Column{NcseCmsTopicRevisionColumns[0], NcseCmsTopicRevisionColumns[6]},
+			},
+			{
+				Name:    "topicrevision_topic_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{NcseCmsTopicRevisionColumns[8], NcseCmsTopicRevisionColumns[6]},
+			},
+			{
+				Name:    "topicrevision_topic_id_author_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{NcseCmsTopicRevisionColumns[8], NcseCmsTopicRevisionColumns[9], NcseCmsTopicRevisionColumns[6]},
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		NcseCmsChannelTable,
@@ -387,6 +430,7 @@ var (
 		NcseCmsTaxonomyRelationTable,
 		NcseCmsTopicTable,
 		NcseCmsTopicMediaTable,
+		NcseCmsTopicRevisionTable,
 	}
 )
 
@@ -416,4 +460,7 @@ func init() {
 	NcseCmsTopicMediaTable.Annotation = &entsql.Annotation{
 		Table: "ncse_cms_topic_media",
 	}
+	NcseCmsTopicRevisionTable.Annotation = &entsql.Annotation{
+		Table: "ncse_cms_topic_revision",
+	}
 }
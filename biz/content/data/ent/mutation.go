@@ -14,6 +14,7 @@ import (
 	"ncobase/biz/content/data/ent/taxonomyrelation"
 	"ncobase/biz/content/data/ent/topic"
 	"ncobase/biz/content/data/ent/topicmedia"
+	"ncobase/biz/content/data/ent/topicrevision"
 	"sync"
 
 	"entgo.io/ent"
@@ -36,6 +37,7 @@ const (
 	TypeTaxonomyRelation = "TaxonomyRelation"
 	TypeTopic            = "Topic"
 	TypeTopicMedia       = "TopicMedia"
+	TypeTopicRevision    = "TopicRevision"
 )
 
 // CMSChannelMutation represents an operation that mutates the CMSChannel nodes in the graph.
@@ -4442,6 +4444,8 @@ type TaxonomyMutation struct {
 	description   *string
 	status        *int
 	addstatus     *int
+	_order        *int
+	add_order     *int
 	extras        *map[string]interface{}
 	parent_id     *string
 	space_id      *string
@@ -5107,6 +5111,62 @@ func (m *TaxonomyMutation) ResetStatus() {
 	m.addstatus = nil
 }
 
+// SetOrder sets the "order" field.
+func (m *TaxonomyMutation) SetOrder(i int) {
+	m._order = &i
+	m.add_order = nil
+}
+
+// Order returns the value of the "order" field in the mutation.
+func (m *TaxonomyMutation) Order() (r int, exists bool) {
+	v := m._order
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOrder returns the old "order" field's value of the Taxonomy entity.
+// If the Taxonomy object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaxonomyMutation) OldOrder(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOrder is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOrder requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOrder: %w", err)
+	}
+	return oldValue.Order, nil
+}
+
+// AddOrder adds i to the "order" field.
+func (m *TaxonomyMutation) AddOrder(i int) {
+	if m.add_order != nil {
+		*m.add_order += i
+	} else {
+		m.add_order = &i
+	}
+}
+
+// AddedOrder returns the value that was added to the "order" field in this mutation.
+func (m *TaxonomyMutation) AddedOrder() (r int, exists bool) {
+	v := m.add_order
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetOrder resets all changes to the "order" field.
+func (m *TaxonomyMutation) ResetOrder() {
+	m._order = nil
+	m.add_order = nil
+}
+
 // SetExtras sets the "extras" field.
 func (m *TaxonomyMutation) SetExtras(value map[string]interface{}) {
 	m.extras = &value
@@ -5526,7 +5586,7 @@ func (m *TaxonomyMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *TaxonomyMutation) Fields() []string {
-	fields := make([]string, 0, 18)
+	fields := make([]string, 0, 19)
 	if m.name != nil {
 		fields = append(fields, taxonomy.FieldName)
 	}
@@ -5560,6 +5620,9 @@ func (m *TaxonomyMutation) Fields() []string {
 	if m.status != nil {
 		fields = append(fields, taxonomy.FieldStatus)
 	}
+	if m._order != nil {
+		fields = append(fields, taxonomy.FieldOrder)
+	}
 	if m.extras != nil {
 		fields = append(fields, taxonomy.FieldExtras)
 	}
@@ -5611,6 +5674,8 @@ func (m *TaxonomyMutation) Field(name string) (ent.Value, bool) {
 		return m.Description()
 	case taxonomy.FieldStatus:
 		return m.Status()
+	case taxonomy.FieldOrder:
+		return m.Order()
 	case taxonomy.FieldExtras:
 		return m.Extras()
 	case taxonomy.FieldParentID:
@@ -5656,6 +5721,8 @@ func (m *TaxonomyMutation) OldField(ctx context.Context, name string) (ent.Value
 		return m.OldDescription(ctx)
 	case taxonomy.FieldStatus:
 		return m.OldStatus(ctx)
+	case taxonomy.FieldOrder:
+		return m.OldOrder(ctx)
 	case taxonomy.FieldExtras:
 		return m.OldExtras(ctx)
 	case taxonomy.FieldParentID:
@@ -5756,6 +5823,13 @@ func (m *TaxonomyMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetStatus(v)
 		return nil
+	case taxonomy.FieldOrder:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOrder(v)
+		return nil
 	case taxonomy.FieldExtras:
 		v, ok := value.(map[string]interface{})
 		if !ok {
@@ -5816,6 +5890,9 @@ func (m *TaxonomyMutation) AddedFields() []string {
 	if m.addstatus != nil {
 		fields = append(fields, taxonomy.FieldStatus)
 	}
+	if m.add_order != nil {
+		fields = append(fields, taxonomy.FieldOrder)
+	}
 	if m.addcreated_at != nil {
 		fields = append(fields, taxonomy.FieldCreatedAt)
 	}
@@ -5832,6 +5909,8 @@ func (m *TaxonomyMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
 	case taxonomy.FieldStatus:
 		return m.AddedStatus()
+	case taxonomy.FieldOrder:
+		return m.AddedOrder()
 	case taxonomy.FieldCreatedAt:
 		return m.AddedCreatedAt()
 	case taxonomy.FieldUpdatedAt:
@@ -5852,6 +5931,13 @@ func (m *TaxonomyMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddStatus(v)
 		return nil
+	case taxonomy.FieldOrder:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddOrder(v)
+		return nil
 	case taxonomy.FieldCreatedAt:
 		v, ok := value.(int64)
 		if !ok {
@@ -6031,6 +6117,9 @@ func (m *TaxonomyMutation) ResetField(name string) error {
 	case taxonomy.FieldStatus:
 		m.ResetStatus()
 		return nil
+	case taxonomy.FieldOrder:
+		m.ResetOrder()
+		return nil
 	case taxonomy.FieldExtras:
 		m.ResetExtras()
 		return nil
@@ -10176,3 +10265,1081 @@ func (m *TopicMediaMutation) ResetEdge(name string) error {
 	}
 	return fmt.Errorf("unknown TopicMedia edge %s", name)
 }
+
+// TopicRevisionMutation represents an operation that mutates the TopicRevision nodes in the graph.
+type TopicRevisionMutation struct {
+	config
+	op              Op
+	typ             string
+	id              *string
+	title           *string
+	content         *string
+	markdown        *bool
+	created_by      *string
+	updated_by      *string
+	created_at      *int64
+	addcreated_at   *int64
+	updated_at      *int64
+	addupdated_at   *int64
+	topic_id        *string
+	author_id       *string
+	published_at    *int64
+	addpublished_at *int64
+	clearedFields   map[string]struct{}
+	done            bool
+	oldValue        func(context.Context) (*TopicRevision, error)
+	predicates      []predicate.TopicRevision
+}
+
+var _ ent.Mutation = (*TopicRevisionMutation)(nil)
+
+// topicrevisionOption allows management of the mutation configuration using functional options.
+type topicrevisionOption func(*TopicRevisionMutation)
+
+// newTopicRevisionMutation creates new mutation for the TopicRevision entity.
+func newTopicRevisionMutation(c config, op Op, opts ...topicrevisionOption) *TopicRevisionMutation {
+	m := &TopicRevisionMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeTopicRevision,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withTopicRevisionID sets the ID field of the mutation.
+func withTopicRevisionID(id string) topicrevisionOption {
+	return func(m *TopicRevisionMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *TopicRevision
+		)
+		m.oldValue = func(ctx context.Context) (*TopicRevision, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().TopicRevision.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withTopicRevision sets the old TopicRevision of the mutation.
+func withTopicRevision(node *TopicRevision) topicrevisionOption {
+	return func(m *TopicRevisionMutation) {
+		m.oldValue = func(context.Context) (*TopicRevision, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m TopicRevisionMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m TopicRevisionMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of TopicRevision entities.
+func (m *TopicRevisionMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *TopicRevisionMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *TopicRevisionMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().TopicRevision.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetTitle sets the "title" field.
+func (m *TopicRevisionMutation) SetTitle(s string) {
+	m.title = &s
+}
+
+// Title returns the value of the "title" field in the mutation.
+func (m *TopicRevisionMutation) Title() (r string, exists bool) {
+	v := m.title
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTitle returns the old "title" field's value of the TopicRevision entity.
+// If the TopicRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TopicRevisionMutation) OldTitle(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTitle is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTitle requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTitle: %w", err)
+	}
+	return oldValue.Title, nil
+}
+
+// ClearTitle clears the value of the "title" field.
+func (m *TopicRevisionMutation) ClearTitle() {
+	m.title = nil
+	m.clearedFields[topicrevision.FieldTitle] = struct{}{}
+}
+
+// TitleCleared returns if the "title" field was cleared in this mutation.
+func (m *TopicRevisionMutation) TitleCleared() bool {
+	_, ok := m.clearedFields[topicrevision.FieldTitle]
+	return ok
+}
+
+// ResetTitle resets all changes to the "title" field.
+func (m *TopicRevisionMutation) ResetTitle() {
+	m.title = nil
+	delete(m.clearedFields, topicrevision.FieldTitle)
+}
+
+// SetContent sets the "content" field.
+func (m *TopicRevisionMutation) SetContent(s string) {
+	m.content = &s
+}
+
+// Content returns the value of the "content" field in the mutation.
+func (m *TopicRevisionMutation) Content() (r string, exists bool) {
+	v := m.content
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldContent returns the old "content" field's value of the TopicRevision entity.
+// If the TopicRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TopicRevisionMutation) OldContent(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldContent is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldContent requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldContent: %w", err)
+	}
+	return oldValue.Content, nil
+}
+
+// ClearContent clears the value of the "content" field.
+func (m *TopicRevisionMutation) ClearContent() {
+	m.content = nil
+	m.clearedFields[topicrevision.FieldContent] = struct{}{}
+}
+
+// ContentCleared returns if the "content" field was cleared in this mutation.
+func (m *TopicRevisionMutation) ContentCleared() bool {
+	_, ok := m.clearedFields[topicrevision.FieldContent]
+	return ok
+}
+
+// ResetContent resets all changes to the "content" field.
+func (m *TopicRevisionMutation) ResetContent() {
+	m.content = nil
+	delete(m.clearedFields, topicrevision.FieldContent)
+}
+
+// SetMarkdown sets the "markdown" field.
+func (m *TopicRevisionMutation) SetMarkdown(b bool) {
+	m.markdown = &b
+}
+
+// Markdown returns the value of the "markdown" field in the mutation.
+func (m *TopicRevisionMutation) Markdown() (r bool, exists bool) {
+	v := m.markdown
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMarkdown returns the old "markdown" field's value of the TopicRevision entity.
+// If the TopicRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TopicRevisionMutation) OldMarkdown(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMarkdown is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMarkdown requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMarkdown: %w", err)
+	}
+	return oldValue.Markdown, nil
+}
+
+// ClearMarkdown clears the value of the "markdown" field.
+func (m *TopicRevisionMutation) ClearMarkdown() {
+	m.markdown = nil
+	m.clearedFields[topicrevision.FieldMarkdown] = struct{}{}
+}
+
+// MarkdownCleared returns if the "markdown" field was cleared in this mutation.
+func (m *TopicRevisionMutation) MarkdownCleared() bool {
+	_, ok := m.clearedFields[topicrevision.FieldMarkdown]
+	return ok
+}
+
+// ResetMarkdown resets all changes to the "markdown" field.
+func (m *TopicRevisionMutation) ResetMarkdown() {
+	m.markdown = nil
+	delete(m.clearedFields, topicrevision.FieldMarkdown)
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (m *TopicRevisionMutation) SetCreatedBy(s string) {
+	m.created_by = &s
+}
+
+// CreatedBy returns the value of the "created_by" field in the mutation.
+func (m *TopicRevisionMutation) CreatedBy() (r string, exists bool) {
+	v := m.created_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedBy returns the old "created_by" field's value of the TopicRevision entity.
+// If the TopicRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TopicRevisionMutation) OldCreatedBy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedBy: %w", err)
+	}
+	return oldValue.CreatedBy, nil
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (m *TopicRevisionMutation) ClearCreatedBy() {
+	m.created_by = nil
+	m.clearedFields[topicrevision.FieldCreatedBy] = struct{}{}
+}
+
+// CreatedByCleared returns if the "created_by" field was cleared in this mutation.
+func (m *TopicRevisionMutation) CreatedByCleared() bool {
+	_, ok := m.clearedFields[topicrevision.FieldCreatedBy]
+	return ok
+}
+
+// ResetCreatedBy resets all changes to the "created_by" field.
+func (m *TopicRevisionMutation) ResetCreatedBy() {
+	m.created_by = nil
+	delete(m.clearedFields, topicrevision.FieldCreatedBy)
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (m *TopicRevisionMutation) SetUpdatedBy(s string) {
+	m.updated_by = &s
+}
+
+// UpdatedBy returns the value of the "updated_by" field in the mutation.
+func (m *TopicRevisionMutation) UpdatedBy() (r string, exists bool) {
+	v := m.updated_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedBy returns the old "updated_by" field's value of the TopicRevision entity.
+// If the TopicRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TopicRevisionMutation) OldUpdatedBy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedBy: %w", err)
+	}
+	return oldValue.UpdatedBy, nil
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (m *TopicRevisionMutation) ClearUpdatedBy() {
+	m.updated_by = nil
+	m.clearedFields[topicrevision.FieldUpdatedBy] = struct{}{}
+}
+
+// UpdatedByCleared returns if the "updated_by" field was cleared in this mutation.
+func (m *TopicRevisionMutation) UpdatedByCleared() bool {
+	_, ok := m.clearedFields[topicrevision.FieldUpdatedBy]
+	return ok
+}
+
+// ResetUpdatedBy resets all changes to the "updated_by" field.
+func (m *TopicRevisionMutation) ResetUpdatedBy() {
+	m.updated_by = nil
+	delete(m.clearedFields, topicrevision.FieldUpdatedBy)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *TopicRevisionMutation) SetCreatedAt(i int64) {
+	m.created_at = &i
+	m.addcreated_at = nil
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *TopicRevisionMutation) CreatedAt() (r int64, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the TopicRevision entity.
+// If the TopicRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TopicRevisionMutation) OldCreatedAt(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// AddCreatedAt adds i to the "created_at" field.
+func (m *TopicRevisionMutation) AddCreatedAt(i int64) {
+	if m.addcreated_at != nil {
+		*m.addcreated_at += i
+	} else {
+		m.addcreated_at = &i
+	}
+}
+
+// AddedCreatedAt returns the value that was added to the "created_at" field in this mutation.
+func (m *TopicRevisionMutation) AddedCreatedAt() (r int64, exists bool) {
+	v := m.addcreated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearCreatedAt clears the value of the "created_at" field.
+func (m *TopicRevisionMutation) ClearCreatedAt() {
+	m.created_at = nil
+	m.addcreated_at = nil
+	m.clearedFields[topicrevision.FieldCreatedAt] = struct{}{}
+}
+
+// CreatedAtCleared returns if the "created_at" field was cleared in this mutation.
+func (m *TopicRevisionMutation) CreatedAtCleared() bool {
+	_, ok := m.clearedFields[topicrevision.FieldCreatedAt]
+	return ok
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *TopicRevisionMutation) ResetCreatedAt() {
+	m.created_at = nil
+	m.addcreated_at = nil
+	delete(m.clearedFields, topicrevision.FieldCreatedAt)
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *TopicRevisionMutation) SetUpdatedAt(i int64) {
+	m.updated_at = &i
+	m.addupdated_at = nil
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *TopicRevisionMutation) UpdatedAt() (r int64, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the TopicRevision entity.
+// If the TopicRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TopicRevisionMutation) OldUpdatedAt(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (m *TopicRevisionMutation) AddUpdatedAt(i int64) {
+	if m.addupdated_at != nil {
+		*m.addupdated_at += i
+	} else {
+		m.addupdated_at = &i
+	}
+}
+
+// AddedUpdatedAt returns the value that was added to the "updated_at" field in this mutation.
+func (m *TopicRevisionMutation) AddedUpdatedAt() (r int64, exists bool) {
+	v := m.addupdated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (m *TopicRevisionMutation) ClearUpdatedAt() {
+	m.updated_at = nil
+	m.addupdated_at = nil
+	m.clearedFields[topicrevision.FieldUpdatedAt] = struct{}{}
+}
+
+// UpdatedAtCleared returns if the "updated_at" field was cleared in this mutation.
+func (m *TopicRevisionMutation) UpdatedAtCleared() bool {
+	_, ok := m.clearedFields[topicrevision.FieldUpdatedAt]
+	return ok
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *TopicRevisionMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+	m.addupdated_at = nil
+	delete(m.clearedFields, topicrevision.FieldUpdatedAt)
+}
+
+// SetTopicID sets the "topic_id" field.
+func (m *TopicRevisionMutation) SetTopicID(s string) {
+	m.topic_id = &s
+}
+
+// TopicID returns the value of the "topic_id" field in the mutation.
+func (m *TopicRevisionMutation) TopicID() (r string, exists bool) {
+	v := m.topic_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTopicID returns the old "topic_id" field's value of the TopicRevision entity.
+// If the TopicRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TopicRevisionMutation) OldTopicID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTopicID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTopicID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTopicID: %w", err)
+	}
+	return oldValue.TopicID, nil
+}
+
+// ResetTopicID resets all changes to the "topic_id" field.
+func (m *TopicRevisionMutation) ResetTopicID() {
+	m.topic_id = nil
+}
+
+// SetAuthorID sets the "author_id" field.
+func (m *TopicRevisionMutation) SetAuthorID(s string) {
+	m.author_id = &s
+}
+
+// AuthorID returns the value of the "author_id" field in the mutation.
+func (m *TopicRevisionMutation) AuthorID() (r string, exists bool) {
+	v := m.author_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAuthorID returns the old "author_id" field's value of the TopicRevision entity.
+// If the TopicRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TopicRevisionMutation) OldAuthorID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAuthorID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAuthorID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAuthorID: %w", err)
+	}
+	return oldValue.AuthorID, nil
+}
+
+// ResetAuthorID resets all changes to the "author_id" field.
+func (m *TopicRevisionMutation) ResetAuthorID() {
+	m.author_id = nil
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (m *TopicRevisionMutation) SetPublishedAt(i int64) {
+	m.published_at = &i
+	m.addpublished_at = nil
+}
+
+// PublishedAt returns the value of the "published_at" field in the mutation.
+func (m *TopicRevisionMutation) PublishedAt() (r int64, exists bool) {
+	v := m.published_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPublishedAt returns the old "published_at" field's value of the TopicRevision entity.
+// If the TopicRevision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TopicRevisionMutation) OldPublishedAt(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPublishedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPublishedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPublishedAt: %w", err)
+	}
+	return oldValue.PublishedAt, nil
+}
+
+// AddPublishedAt adds i to the "published_at" field.
+func (m *TopicRevisionMutation) AddPublishedAt(i int64) {
+	if m.addpublished_at != nil {
+		*m.addpublished_at += i
+	} else {
+		m.addpublished_at = &i
+	}
+}
+
+// AddedPublishedAt returns the value that was added to the "published_at" field in this mutation.
+func (m *TopicRevisionMutation) AddedPublishedAt() (r int64, exists bool) {
+	v := m.addpublished_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (m *TopicRevisionMutation) ClearPublishedAt() {
+	m.published_at = nil
+	m.addpublished_at = nil
+	m.clearedFields[topicrevision.FieldPublishedAt] = struct{}{}
+}
+
+// PublishedAtCleared returns if the "published_at" field was cleared in this mutation.
+func (m *TopicRevisionMutation) PublishedAtCleared() bool {
+	_, ok := m.clearedFields[topicrevision.FieldPublishedAt]
+	return ok
+}
+
+// ResetPublishedAt resets all changes to the "published_at" field.
+func (m *TopicRevisionMutation) ResetPublishedAt() {
+	m.published_at = nil
+	m.addpublished_at = nil
+	delete(m.clearedFields, topicrevision.FieldPublishedAt)
+}
+
+// Where appends a list predicates to the TopicRevisionMutation builder.
+func (m *TopicRevisionMutation) Where(ps ...predicate.TopicRevision) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the TopicRevisionMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *TopicRevisionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.TopicRevision, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *TopicRevisionMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *TopicRevisionMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (TopicRevision).
+func (m *TopicRevisionMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *TopicRevisionMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.title != nil {
+		fields = append(fields, topicrevision.FieldTitle)
+	}
+	if m.content != nil {
+		fields = append(fields, topicrevision.FieldContent)
+	}
+	if m.markdown != nil {
+		fields = append(fields, topicrevision.FieldMarkdown)
+	}
+	if m.created_by != nil {
+		fields = append(fields, topicrevision.FieldCreatedBy)
+	}
+	if m.updated_by != nil {
+		fields = append(fields, topicrevision.FieldUpdatedBy)
+	}
+	if m.created_at != nil {
+		fields = append(fields, topicrevision.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, topicrevision.FieldUpdatedAt)
+	}
+	if m.topic_id != nil {
+		fields = append(fields, topicrevision.FieldTopicID)
+	}
+	if m.author_id != nil {
+		fields = append(fields, topicrevision.FieldAuthorID)
+	}
+	if m.published_at != nil {
+		fields = append(fields, topicrevision.FieldPublishedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *TopicRevisionMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case topicrevision.FieldTitle:
+		return m.Title()
+	case topicrevision.FieldContent:
+		return m.Content()
+	case topicrevision.FieldMarkdown:
+		return m.Markdown()
+	case topicrevision.FieldCreatedBy:
+		return m.CreatedBy()
+	case topicrevision.FieldUpdatedBy:
+		return m.UpdatedBy()
+	case topicrevision.FieldCreatedAt:
+		return m.CreatedAt()
+	case topicrevision.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case topicrevision.FieldTopicID:
+		return m.TopicID()
+	case topicrevision.FieldAuthorID:
+		return m.AuthorID()
+	case topicrevision.FieldPublishedAt:
+		return m.PublishedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *TopicRevisionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case topicrevision.FieldTitle:
+		return m.OldTitle(ctx)
+	case topicrevision.FieldContent:
+		return m.OldContent(ctx)
+	case topicrevision.FieldMarkdown:
+		return m.OldMarkdown(ctx)
+	case topicrevision.FieldCreatedBy:
+		return m.OldCreatedBy(ctx)
+	case topicrevision.FieldUpdatedBy:
+		return m.OldUpdatedBy(ctx)
+	case topicrevision.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case topicrevision.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case topicrevision.FieldTopicID:
+		return m.OldTopicID(ctx)
+	case topicrevision.FieldAuthorID:
+		return m.OldAuthorID(ctx)
+	case topicrevision.FieldPublishedAt:
+		return m.OldPublishedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown TopicRevision field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TopicRevisionMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case topicrevision.FieldTitle:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTitle(v)
+		return nil
+	case topicrevision.FieldContent:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetContent(v)
+		return nil
+	case topicrevision.FieldMarkdown:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMarkdown(v)
+		return nil
+	case topicrevision.FieldCreatedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedBy(v)
+		return nil
+	case topicrevision.FieldUpdatedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedBy(v)
+		return nil
+	case topicrevision.FieldCreatedAt:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case topicrevision.FieldUpdatedAt:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case topicrevision.FieldTopicID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTopicID(v)
+		return nil
+	case topicrevision.FieldAuthorID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAuthorID(v)
+		return nil
+	case topicrevision.FieldPublishedAt:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPublishedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown TopicRevision field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *TopicRevisionMutation) AddedFields() []string {
+	var fields []string
+	if m.addcreated_at != nil {
+		fields = append(fields, topicrevision.FieldCreatedAt)
+	}
+	if m.addupdated_at != nil {
+		fields = append(fields, topicrevision.FieldUpdatedAt)
+	}
+	if m.addpublished_at != nil {
+		fields = append(fields, topicrevision.FieldPublishedAt)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *TopicRevisionMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case topicrevision.FieldCreatedAt:
+		return m.AddedCreatedAt()
+	case topicrevision.FieldUpdatedAt:
+		return m.AddedUpdatedAt()
+	case topicrevision.FieldPublishedAt:
+		return m.AddedPublishedAt()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *TopicRevisionMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case topicrevision.FieldCreatedAt:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCreatedAt(v)
+		return nil
+	case topicrevision.FieldUpdatedAt:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddUpdatedAt(v)
+		return nil
+	case topicrevision.FieldPublishedAt:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPublishedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown TopicRevision numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *TopicRevisionMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(topicrevision.FieldTitle) {
+		fields = append(fields, topicrevision.FieldTitle)
+	}
+	if m.FieldCleared(topicrevision.FieldContent) {
+		fields = append(fields, topicrevision.FieldContent)
+	}
+	if m.FieldCleared(topicrevision.FieldMarkdown) {
+		fields = append(fields, topicrevision.FieldMarkdown)
+	}
+	if m.FieldCleared(topicrevision.FieldCreatedBy) {
+		fields = append(fields, topicrevision.FieldCreatedBy)
+	}
+	if m.FieldCleared(topicrevision.FieldUpdatedBy) {
+		fields = append(fields, topicrevision.FieldUpdatedBy)
+	}
+	if m.FieldCleared(topicrevision.FieldCreatedAt) {
+		fields = append(fields, topicrevision.FieldCreatedAt)
+	}
+	if m.FieldCleared(topicrevision.FieldUpdatedAt) {
+		fields = append(fields, topicrevision.FieldUpdatedAt)
+	}
+	if m.FieldCleared(topicrevision.FieldPublishedAt) {
+		fields = append(fields, topicrevision.FieldPublishedAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *TopicRevisionMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *TopicRevisionMutation) ClearField(name string) error {
+	switch name {
+	case topicrevision.FieldTitle:
+		m.ClearTitle()
+		return nil
+	case topicrevision.FieldContent:
+		m.ClearContent()
+		return nil
+	case topicrevision.FieldMarkdown:
+		m.ClearMarkdown()
+		return nil
+	case topicrevision.FieldCreatedBy:
+		m.ClearCreatedBy()
+		return nil
+	case topicrevision.FieldUpdatedBy:
+		m.ClearUpdatedBy()
+		return nil
+	case topicrevision.FieldCreatedAt:
+		m.ClearCreatedAt()
+		return nil
+	case topicrevision.FieldUpdatedAt:
+		m.ClearUpdatedAt()
+		return nil
+	case topicrevision.FieldPublishedAt:
+		m.ClearPublishedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown TopicRevision nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *TopicRevisionMutation) ResetField(name string) error {
+	switch name {
+	case topicrevision.FieldTitle:
+		m.ResetTitle()
+		return nil
+	case topicrevision.FieldContent:
+		m.ResetContent()
+		return nil
+	case topicrevision.FieldMarkdown:
+		m.ResetMarkdown()
+		return nil
+	case topicrevision.FieldCreatedBy:
+		m.ResetCreatedBy()
+		return nil
+	case topicrevision.FieldUpdatedBy:
+		m.ResetUpdatedBy()
+		return nil
+	case topicrevision.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case topicrevision.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case topicrevision.FieldTopicID:
+		m.ResetTopicID()
+		return nil
+	case topicrevision.FieldAuthorID:
+		m.ResetAuthorID()
+		return nil
+	case topicrevision.FieldPublishedAt:
+		m.ResetPublishedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown TopicRevision field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *TopicRevisionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *TopicRevisionMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *TopicRevisionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *TopicRevisionMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *TopicRevisionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *TopicRevisionMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *TopicRevisionMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown TopicRevision unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *TopicRevisionMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown TopicRevision edge %s", name)
+}
@@ -26,3 +26,6 @@ type Topic func(*sql.Selector)
 
 // TopicMedia is the predicate function for topicmedia builders.
 type TopicMedia func(*sql.Selector)
+
+// TopicRevision is the predicate function for topicrevision builders.
+type TopicRevision func(*sql.Selector)
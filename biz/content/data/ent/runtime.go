@@ -10,6 +10,7 @@ import (
 	"ncobase/biz/content/data/ent/taxonomyrelation"
 	"ncobase/biz/content/data/ent/topic"
 	"ncobase/biz/content/data/ent/topicmedia"
+	"ncobase/biz/content/data/ent/topicrevision"
 	"ncobase/biz/content/data/schema"
 )
 
@@ -141,24 +142,30 @@ func init() {
 	_ = taxonomyMixinFields11
 	taxonomyMixinFields12 := taxonomyMixin[12].Fields()
 	_ = taxonomyMixinFields12
-	taxonomyMixinFields16 := taxonomyMixin[16].Fields()
-	_ = taxonomyMixinFields16
+	taxonomyMixinFields13 := taxonomyMixin[13].Fields()
+	_ = taxonomyMixinFields13
+	taxonomyMixinFields17 := taxonomyMixin[17].Fields()
+	_ = taxonomyMixinFields17
 	taxonomyFields := schema.Taxonomy{}.Fields()
 	_ = taxonomyFields
 	// taxonomyDescStatus is the schema descriptor for status field.
 	taxonomyDescStatus := taxonomyMixinFields11[0].Descriptor()
 	// taxonomy.DefaultStatus holds the default value on creation for the status field.
 	taxonomy.DefaultStatus = taxonomyDescStatus.Default.(int)
+	// taxonomyDescOrder is the schema descriptor for order field.
+	taxonomyDescOrder := taxonomyMixinFields12[0].Descriptor()
+	// taxonomy.DefaultOrder holds the default value on creation for the order field.
+	taxonomy.DefaultOrder = taxonomyDescOrder.Default.(int)
 	// taxonomyDescExtras is the schema descriptor for extras field.
-	taxonomyDescExtras := taxonomyMixinFields12[0].Descriptor()
+	taxonomyDescExtras := taxonomyMixinFields13[0].Descriptor()
 	// taxonomy.DefaultExtras holds the default value on creation for the extras field.
 	taxonomy.DefaultExtras = taxonomyDescExtras.Default.(map[string]interface{})
 	// taxonomyDescCreatedAt is the schema descriptor for created_at field.
-	taxonomyDescCreatedAt := taxonomyMixinFields16[0].Descriptor()
+	taxonomyDescCreatedAt := taxonomyMixinFields17[0].Descriptor()
 	// taxonomy.DefaultCreatedAt holds the default value on creation for the created_at field.
 	taxonomy.DefaultCreatedAt = taxonomyDescCreatedAt.Default.(func() int64)
 	// taxonomyDescUpdatedAt is the schema descriptor for updated_at field.
-	taxonomyDescUpdatedAt := taxonomyMixinFields16[1].Descriptor()
+	taxonomyDescUpdatedAt := taxonomyMixinFields17[1].Descriptor()
 	// taxonomy.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	taxonomy.DefaultUpdatedAt = taxonomyDescUpdatedAt.Default.(func() int64)
 	// taxonomy.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -294,4 +301,41 @@ func init() {
 	topicmedia.DefaultID = topicmediaDescID.Default.(func() string)
 	// topicmedia.IDValidator is a validator for the "id" field. It is called by the builders before save.
 	topicmedia.IDValidator = topicmediaDescID.Validators[0].(func(string) error)
+	topicrevisionMixin := schema.TopicRevision{}.Mixin()
+	topicrevisionMixinFields0 := topicrevisionMixin[0].Fields()
+	_ = topicrevisionMixinFields0
+	topicrevisionMixinFields3 := topicrevisionMixin[3].Fields()
+	_ = topicrevisionMixinFields3
+	topicrevisionMixinFields5 := topicrevisionMixin[5].Fields()
+	_ = topicrevisionMixinFields5
+	topicrevisionFields := schema.TopicRevision{}.Fields()
+	_ = topicrevisionFields
+	// topicrevisionDescMarkdown is the schema descriptor for markdown field.
+	topicrevisionDescMarkdown := topicrevisionMixinFields3[0].Descriptor()
+	// topicrevision.DefaultMarkdown holds the default value on creation for the markdown field.
+	topicrevision.DefaultMarkdown = topicrevisionDescMarkdown.Default.(bool)
+	// topicrevisionDescCreatedAt is the schema descriptor for created_at field.
+	topicrevisionDescCreatedAt := topicrevisionMixinFields5[0].Descriptor()
+	// topicrevision.DefaultCreatedAt holds the default value on creation for the created_at field.
+	topicrevision.DefaultCreatedAt = topicrevisionDescCreatedAt.Default.(func() int64)
+	// topicrevisionDescUpdatedAt is the schema descriptor for updated_at field.
+	topicrevisionDescUpdatedAt := topicrevisionMixinFields5[1].Descriptor()
+	// topicrevision.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	topicrevision.DefaultUpdatedAt = topicrevisionDescUpdatedAt.Default.(func() int64)
+	// topicrevision.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	topicrevision.UpdateDefaultUpdatedAt = topicrevisionDescUpdatedAt.UpdateDefault.(func() int64)
+	// topicrevisionDescTopicID is the schema descriptor for topic_id field.
+	topicrevisionDescTopicID := topicrevisionFields[0].Descriptor()
+	// topicrevision.TopicIDValidator is a validator for the "topic_id" field. It is called by the builders before save.
+	topicrevision.TopicIDValidator = topicrevisionDescTopicID.Validators[0].(func(string) error)
+	// topicrevisionDescAuthorID is the schema descriptor for author_id field.
+	topicrevisionDescAuthorID := topicrevisionFields[1].Descriptor()
+	// topicrevision.AuthorIDValidator is a validator for the "author_id" field. It is called by the builders before save.
+	topicrevision.AuthorIDValidator = topicrevisionDescAuthorID.Validators[0].(func(string) error)
+	// topicrevisionDescID is the schema descriptor for id field.
+	topicrevisionDescID := topicrevisionMixinFields0[0].Descriptor()
+	// topicrevision.DefaultID holds the default value on creation for the id field.
+	topicrevision.DefaultID = topicrevisionDescID.Default.(func() string)
+	// topicrevision.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	topicrevision.IDValidator = topicrevisionDescID.Validators[0].(func(string) error)
 }
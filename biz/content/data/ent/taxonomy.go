@@ -40,6 +40,8 @@ type Taxonomy struct {
 	Description string `json:"description,omitempty"`
 	// status: 0 activated, 1 unactivated, 2 disabled
 	Status int `json:"status,omitempty"`
+	// display order
+	Order int `json:"order,omitempty"`
 	// Extend properties
 	Extras map[string]interface{} `json:"extras,omitempty"`
 	// parent id
@@ -64,7 +66,7 @@ func (*Taxonomy) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case taxonomy.FieldExtras:
 			values[i] = new([]byte)
-		case taxonomy.FieldStatus, taxonomy.FieldCreatedAt, taxonomy.FieldUpdatedAt:
+		case taxonomy.FieldStatus, taxonomy.FieldOrder, taxonomy.FieldCreatedAt, taxonomy.FieldUpdatedAt:
 			values[i] = new(sql.NullInt64)
 		case taxonomy.FieldID, taxonomy.FieldName, taxonomy.FieldType, taxonomy.FieldSlug, taxonomy.FieldCover, taxonomy.FieldThumbnail, taxonomy.FieldColor, taxonomy.FieldIcon, taxonomy.FieldURL, taxonomy.FieldKeywords, taxonomy.FieldDescription, taxonomy.FieldParentID, taxonomy.FieldSpaceID, taxonomy.FieldCreatedBy, taxonomy.FieldUpdatedBy:
 			values[i] = new(sql.NullString)
@@ -155,6 +157,12 @@ func (_m *Taxonomy) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Status = int(value.Int64)
 			}
+		case taxonomy.FieldOrder:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field order", values[i])
+			} else if value.Valid {
+				_m.Order = int(value.Int64)
+			}
 		case taxonomy.FieldExtras:
 			if value, ok := values[i].(*[]byte); !ok {
 				return fmt.Errorf("unexpected type %T for field extras", values[i])
@@ -268,6 +276,9 @@ func (_m *Taxonomy) String() string {
 	builder.WriteString("status=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Status))
 	builder.WriteString(", ")
+	builder.WriteString("order=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Order))
+	builder.WriteString(", ")
 	builder.WriteString("extras=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Extras))
 	builder.WriteString(", ")
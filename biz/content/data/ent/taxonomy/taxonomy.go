@@ -33,6 +33,8 @@ const (
 	FieldDescription = "description"
 	// FieldStatus holds the string denoting the status field in the database.
 	FieldStatus = "status"
+	// FieldOrder holds the string denoting the order field in the database.
+	FieldOrder = "order"
 	// FieldExtras holds the string denoting the extras field in the database.
 	FieldExtras = "extras"
 	// FieldParentID holds the string denoting the parent_id field in the database.
@@ -65,6 +67,7 @@ var Columns = []string{
 	FieldKeywords,
 	FieldDescription,
 	FieldStatus,
+	FieldOrder,
 	FieldExtras,
 	FieldParentID,
 	FieldSpaceID,
@@ -87,6 +90,8 @@ func ValidColumn(column string) bool {
 var (
 	// DefaultStatus holds the default value on creation for the "status" field.
 	DefaultStatus int
+	// DefaultOrder holds the default value on creation for the "order" field.
+	DefaultOrder int
 	// DefaultExtras holds the default value on creation for the "extras" field.
 	DefaultExtras map[string]interface{}
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
@@ -164,6 +169,11 @@ func ByStatus(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldStatus, opts...).ToFunc()
 }
 
+// ByOrder orders the results by the order field.
+func ByOrder(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOrder, opts...).ToFunc()
+}
+
 // ByParentID orders the results by the parent_id field.
 func ByParentID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldParentID, opts...).ToFunc()
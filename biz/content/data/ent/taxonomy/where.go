@@ -118,6 +118,11 @@ func Status(v int) predicate.Taxonomy {
 	return predicate.Taxonomy(sql.FieldEQ(FieldStatus, v))
 }
 
+// Order applies equality check predicate on the "order" field. It's identical to OrderEQ.
+func Order(v int) predicate.Taxonomy {
+	return predicate.Taxonomy(sql.FieldEQ(FieldOrder, v))
+}
+
 // ParentID applies equality check predicate on the "parent_id" field. It's identical to ParentIDEQ.
 func ParentID(v string) predicate.Taxonomy {
 	return predicate.Taxonomy(sql.FieldEQ(FieldParentID, v))
@@ -938,6 +943,46 @@ func StatusLTE(v int) predicate.Taxonomy {
 	return predicate.Taxonomy(sql.FieldLTE(FieldStatus, v))
 }
 
+// OrderEQ applies the EQ predicate on the "order" field.
+func OrderEQ(v int) predicate.Taxonomy {
+	return predicate.Taxonomy(sql.FieldEQ(FieldOrder, v))
+}
+
+// OrderNEQ applies the NEQ predicate on the "order" field.
+func OrderNEQ(v int) predicate.Taxonomy {
+	return predicate.Taxonomy(sql.FieldNEQ(FieldOrder, v))
+}
+
+// OrderIn applies the In predicate on the "order" field.
+func OrderIn(vs ...int) predicate.Taxonomy {
+	return predicate.Taxonomy(sql.FieldIn(FieldOrder, vs...))
+}
+
+// OrderNotIn applies the NotIn predicate on the "order" field.
+func OrderNotIn(vs ...int) predicate.Taxonomy {
+	return predicate.Taxonomy(sql.FieldNotIn(FieldOrder, vs...))
+}
+
+// OrderGT applies the GT predicate on the "order" field.
+func OrderGT(v int) predicate.Taxonomy {
+	return predicate.Taxonomy(sql.FieldGT(FieldOrder, v))
+}
+
+// OrderGTE applies the GTE predicate on the "order" field.
+func OrderGTE(v int) predicate.Taxonomy {
+	return predicate.Taxonomy(sql.FieldGTE(FieldOrder, v))
+}
+
+// OrderLT applies the LT predicate on the "order" field.
+func OrderLT(v int) predicate.Taxonomy {
+	return predicate.Taxonomy(sql.FieldLT(FieldOrder, v))
+}
+
+// OrderLTE applies the LTE predicate on the "order" field.
+func OrderLTE(v int) predicate.Taxonomy {
+	return predicate.Taxonomy(sql.FieldLTE(FieldOrder, v))
+}
+
 // ExtrasIsNil applies the IsNil predicate on the "extras" field.
 func ExtrasIsNil() predicate.Taxonomy {
 	return predicate.Taxonomy(sql.FieldIsNull(FieldExtras))
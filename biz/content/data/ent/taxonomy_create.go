@@ -176,6 +176,20 @@ func (_c *TaxonomyCreate) SetNillableStatus(v *int) *TaxonomyCreate {
 	return _c
 }
 
+// SetOrder sets the "order" field.
+func (_c *TaxonomyCreate) SetOrder(v int) *TaxonomyCreate {
+	_c.mutation.SetOrder(v)
+	return _c
+}
+
+// SetNillableOrder sets the "order" field if the given value is not nil.
+func (_c *TaxonomyCreate) SetNillableOrder(v *int) *TaxonomyCreate {
+	if v != nil {
+		_c.SetOrder(*v)
+	}
+	return _c
+}
+
 // SetExtras sets the "extras" field.
 func (_c *TaxonomyCreate) SetExtras(v map[string]interface{}) *TaxonomyCreate {
 	_c.mutation.SetExtras(v)
@@ -319,6 +333,10 @@ func (_c *TaxonomyCreate) defaults() {
 		v := taxonomy.DefaultStatus
 		_c.mutation.SetStatus(v)
 	}
+	if _, ok := _c.mutation.Order(); !ok {
+		v := taxonomy.DefaultOrder
+		_c.mutation.SetOrder(v)
+	}
 	if _, ok := _c.mutation.Extras(); !ok {
 		v := taxonomy.DefaultExtras
 		_c.mutation.SetExtras(v)
@@ -342,6 +360,9 @@ func (_c *TaxonomyCreate) check() error {
 	if _, ok := _c.mutation.Status(); !ok {
 		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Taxonomy.status"`)}
 	}
+	if _, ok := _c.mutation.Order(); !ok {
+		return &ValidationError{Name: "order", err: errors.New(`ent: missing required field "Taxonomy.order"`)}
+	}
 	if v, ok := _c.mutation.ID(); ok {
 		if err := taxonomy.IDValidator(v); err != nil {
 			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Taxonomy.id": %w`, err)}
@@ -427,6 +448,10 @@ func (_c *TaxonomyCreate) createSpec() (*Taxonomy, *sqlgraph.CreateSpec) {
 		_spec.SetField(taxonomy.FieldStatus, field.TypeInt, value)
 		_node.Status = value
 	}
+	if value, ok := _c.mutation.Order(); ok {
+		_spec.SetField(taxonomy.FieldOrder, field.TypeInt, value)
+		_node.Order = value
+	}
 	if value, ok := _c.mutation.Extras(); ok {
 		_spec.SetField(taxonomy.FieldExtras, field.TypeJSON, value)
 		_node.Extras = value
@@ -705,6 +730,24 @@ func (u *TaxonomyUpsert) AddStatus(v int) *TaxonomyUpsert {
 	return u
 }
 
+// SetOrder sets the "order" field.
+func (u *TaxonomyUpsert) SetOrder(v int) *TaxonomyUpsert {
+	u.Set(taxonomy.FieldOrder, v)
+	return u
+}
+
+// UpdateOrder sets the "order" field to the value that was provided on create.
+func (u *TaxonomyUpsert) UpdateOrder() *TaxonomyUpsert {
+	u.SetExcluded(taxonomy.FieldOrder)
+	return u
+}
+
+// AddOrder adds v to the "order" field.
+func (u *TaxonomyUpsert) AddOrder(v int) *TaxonomyUpsert {
+	u.Add(taxonomy.FieldOrder, v)
+	return u
+}
+
 // SetExtras sets the "extras" field.
 func (u *TaxonomyUpsert) SetExtras(v map[string]interface{}) *TaxonomyUpsert {
 	u.Set(taxonomy.FieldExtras, v)
@@ -1101,6 +1144,27 @@ func (u *TaxonomyUpsertOne) UpdateStatus() *TaxonomyUpsertOne {
 	})
 }
 
+// SetOrder sets the "order" field.
+func (u *TaxonomyUpsertOne) SetOrder(v int) *TaxonomyUpsertOne {
+	return u.Update(func(s *TaxonomyUpsert) {
+		s.SetOrder(v)
+	})
+}
+
+// AddOrder adds v to the "order" field.
+func (u *TaxonomyUpsertOne) AddOrder(v int) *TaxonomyUpsertOne {
+	return u.Update(func(s *TaxonomyUpsert) {
+		s.AddOrder(v)
+	})
+}
+
+// UpdateOrder sets the "order" field to the value that was provided on create.
+func (u *TaxonomyUpsertOne) UpdateOrder() *TaxonomyUpsertOne {
+	return u.Update(func(s *TaxonomyUpsert) {
+		s.UpdateOrder()
+	})
+}
+
 // SetExtras sets the "extras" field.
 func (u *TaxonomyUpsertOne) SetExtras(v map[string]interface{}) *TaxonomyUpsertOne {
 	return u.Update(func(s *TaxonomyUpsert) {
@@ -1683,6 +1747,27 @@ func (u *TaxonomyUpsertBulk) UpdateStatus() *TaxonomyUpsertBulk {
 	})
 }
 
+// SetOrder sets the "order" field.
+func (u *TaxonomyUpsertBulk) SetOrder(v int) *TaxonomyUpsertBulk {
+	return u.Update(func(s *TaxonomyUpsert) {
+		s.SetOrder(v)
+	})
+}
+
+// AddOrder adds v to the "order" field.
+func (u *TaxonomyUpsertBulk) AddOrder(v int) *TaxonomyUpsertBulk {
+	return u.Update(func(s *TaxonomyUpsert) {
+		s.AddOrder(v)
+	})
+}
+
+// UpdateOrder sets the "order" field to the value that was provided on create.
+func (u *TaxonomyUpsertBulk) UpdateOrder() *TaxonomyUpsertBulk {
+	return u.Update(func(s *TaxonomyUpsert) {
+		s.UpdateOrder()
+	})
+}
+
 // SetExtras sets the "extras" field.
 func (u *TaxonomyUpsertBulk) SetExtras(v map[string]interface{}) *TaxonomyUpsertBulk {
 	return u.Update(func(s *TaxonomyUpsert) {
@@ -248,6 +248,27 @@ func (_u *TaxonomyUpdate) AddStatus(v int) *TaxonomyUpdate {
 	return _u
 }
 
+// SetOrder sets the "order" field.
+func (_u *TaxonomyUpdate) SetOrder(v int) *TaxonomyUpdate {
+	_u.mutation.ResetOrder()
+	_u.mutation.SetOrder(v)
+	return _u
+}
+
+// SetNillableOrder sets the "order" field if the given value is not nil.
+func (_u *TaxonomyUpdate) SetNillableOrder(v *int) *TaxonomyUpdate {
+	if v != nil {
+		_u.SetOrder(*v)
+	}
+	return _u
+}
+
+// AddOrder adds value to the "order" field.
+func (_u *TaxonomyUpdate) AddOrder(v int) *TaxonomyUpdate {
+	_u.mutation.AddOrder(v)
+	return _u
+}
+
 // SetExtras sets the "extras" field.
 func (_u *TaxonomyUpdate) SetExtras(v map[string]interface{}) *TaxonomyUpdate {
 	_u.mutation.SetExtras(v)
@@ -475,6 +496,12 @@ func (_u *TaxonomyUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.AddedStatus(); ok {
 		_spec.AddField(taxonomy.FieldStatus, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.Order(); ok {
+		_spec.SetField(taxonomy.FieldOrder, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedOrder(); ok {
+		_spec.AddField(taxonomy.FieldOrder, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.Extras(); ok {
 		_spec.SetField(taxonomy.FieldExtras, field.TypeJSON, value)
 	}
@@ -758,6 +785,27 @@ func (_u *TaxonomyUpdateOne) AddStatus(v int) *TaxonomyUpdateOne {
 	return _u
 }
 
+// SetOrder sets the "order" field.
+func (_u *TaxonomyUpdateOne) SetOrder(v int) *TaxonomyUpdateOne {
+	_u.mutation.ResetOrder()
+	_u.mutation.SetOrder(v)
+	return _u
+}
+
+// SetNillableOrder sets the "order" field if the given value is not nil.
+func (_u *TaxonomyUpdateOne) SetNillableOrder(v *int) *TaxonomyUpdateOne {
+	if v != nil {
+		_u.SetOrder(*v)
+	}
+	return _u
+}
+
+// AddOrder adds value to the "order" field.
+func (_u *TaxonomyUpdateOne) AddOrder(v int) *TaxonomyUpdateOne {
+	_u.mutation.AddOrder(v)
+	return _u
+}
+
 // SetExtras sets the "extras" field.
 func (_u *TaxonomyUpdateOne) SetExtras(v map[string]interface{}) *TaxonomyUpdateOne {
 	_u.mutation.SetExtras(v)
@@ -1015,6 +1063,12 @@ func (_u *TaxonomyUpdateOne) sqlSave(ctx context.Context) (_node *Taxonomy, err
 	if value, ok := _u.mutation.AddedStatus(); ok {
 		_spec.AddField(taxonomy.FieldStatus, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.Order(); ok {
+		_spec.SetField(taxonomy.FieldOrder, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedOrder(); ok {
+		_spec.AddField(taxonomy.FieldOrder, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.Extras(); ok {
 		_spec.SetField(taxonomy.FieldExtras, field.TypeJSON, value)
 	}
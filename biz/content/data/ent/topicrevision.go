@@ -0,0 +1,205 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"ncobase/biz/content/data/ent/topicrevision"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// TopicRevision is the model entity for the TopicRevision schema.
+type TopicRevision struct {
+	config `json:"-"`
+	// ID of the ent.
+	// primary key
+	ID string `json:"id,omitempty"`
+	// title
+	Title string `json:"title,omitempty"`
+	// content, big text
+	Content string `json:"content,omitempty"`
+	// is markdown
+	Markdown bool `json:"markdown,omitempty"`
+	// id of the creator
+	CreatedBy string `json:"created_by,omitempty"`
+	// id of the last updater
+	UpdatedBy string `json:"updated_by,omitempty"`
+	// created at
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// updated at
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+	// Topic ID
+	TopicID string `json:"topic_id,omitempty"`
+	// Author of the draft
+	AuthorID string `json:"author_id,omitempty"`
+	// When the revision was published to the topic
+	PublishedAt  int64 `json:"published_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*TopicRevision) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case topicrevision.FieldMarkdown:
+			values[i] = new(sql.NullBool)
+		case topicrevision.FieldCreatedAt, topicrevision.FieldUpdatedAt, topicrevision.FieldPublishedAt:
+			values[i] = new(sql.NullInt64)
+		case topicrevision.FieldID, topicrevision.FieldTitle, topicrevision.FieldContent, topicrevision.FieldCreatedBy, topicrevision.FieldUpdatedBy, topicrevision.FieldTopicID, topicrevision.FieldAuthorID:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the TopicRevision fields.
+func (_m *TopicRevision) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case topicrevision.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				_m.ID = value.String
+			}
+		case topicrevision.FieldTitle:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field title", values[i])
+			} else if value.Valid {
+				_m.Title = value.String
+			}
+		case topicrevision.FieldContent:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field content", values[i])
+			} else if value.Valid {
+				_m.Content = value.String
+			}
+		case topicrevision.FieldMarkdown:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field markdown", values[i])
+			} else if value.Valid {
+				_m.Markdown = value.Bool
+			}
+		case topicrevision.FieldCreatedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field created_by", values[i])
+			} else if value.Valid {
+				_m.CreatedBy = value.String
+			}
+		case topicrevision.FieldUpdatedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_by", values[i])
+			} else if value.Valid {
+				_m.UpdatedBy = value.String
+			}
+		case topicrevision.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Int64
+			}
+		case topicrevision.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Int64
+			}
+		case topicrevision.FieldTopicID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field topic_id", values[i])
+			} else if value.Valid {
+				_m.TopicID = value.String
+			}
+		case topicrevision.FieldAuthorID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field author_id", values[i])
+			} else if value.Valid {
+				_m.AuthorID = value.String
+			}
+		case topicrevision.FieldPublishedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field published_at", values[i])
+			} else if value.Valid {
+				_m.PublishedAt = value.Int64
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the TopicRevision.
+// This includes values selected through modifiers, order, etc.
+func (_m *TopicRevision) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this TopicRevision.
+// Note that you need to call TopicRevision.Unwrap() before calling this method if this TopicRevision
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *TopicRevision) Update() *TopicRevisionUpdateOne {
+	return NewTopicRevisionClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the TopicRevision entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *TopicRevision) Unwrap() *TopicRevision {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: TopicRevision is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *TopicRevision) String() string {
+	var builder strings.Builder
+	builder.WriteString("TopicRevision(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("title=")
+	builder.WriteString(_m.Title)
+	builder.WriteString(", ")
+	builder.WriteString("content=")
+	builder.WriteString(_m.Content)
+	builder.WriteString(", ")
+	builder.WriteString("markdown=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Markdown))
+	builder.WriteString(", ")
+	builder.WriteString("created_by=")
+	builder.WriteString(_m.CreatedBy)
+	builder.WriteString(", ")
+	builder.WriteString("updated_by=")
+	builder.WriteString(_m.UpdatedBy)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CreatedAt))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UpdatedAt))
+	builder.WriteString(", ")
+	builder.WriteString("topic_id=")
+	builder.WriteString(_m.TopicID)
+	builder.WriteString(", ")
+	builder.WriteString("author_id=")
+	builder.WriteString(_m.AuthorID)
+	builder.WriteString(", ")
+	builder.WriteString("published_at=")
+	builder.WriteString(fmt.Sprintf("%v", _m.PublishedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// TopicRevisions is a parsable slice of TopicRevision.
+type TopicRevisions []*TopicRevision
@@ -0,0 +1,729 @@
+// Code generated by ent, DO NOT EDIT.
+
+package topicrevision
+
+import (
+	"ncobase/biz/content/data/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLTE(FieldID, id))
+}
+
+// IDEqualFold applies the EqualFold predicate on the ID field.
+func IDEqualFold(id string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEqualFold(FieldID, id))
+}
+
+// IDContainsFold applies the ContainsFold predicate on the ID field.
+func IDContainsFold(id string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContainsFold(FieldID, id))
+}
+
+// Title applies equality check predicate on the "title" field. It's identical to TitleEQ.
+func Title(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldTitle, v))
+}
+
+// Content applies equality check predicate on the "content" field. It's identical to ContentEQ.
+func Content(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldContent, v))
+}
+
+// Markdown applies equality check predicate on the "markdown" field. It's identical to MarkdownEQ.
+func Markdown(v bool) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldMarkdown, v))
+}
+
+// CreatedBy applies equality check predicate on the "created_by" field. It's identical to CreatedByEQ.
+func CreatedBy(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldCreatedBy, v))
+}
+
+// UpdatedBy applies equality check predicate on the "updated_by" field. It's identical to UpdatedByEQ.
+func UpdatedBy(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldUpdatedBy, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// TopicID applies equality check predicate on the "topic_id" field. It's identical to TopicIDEQ.
+func TopicID(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldTopicID, v))
+}
+
+// AuthorID applies equality check predicate on the "author_id" field. It's identical to AuthorIDEQ.
+func AuthorID(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldAuthorID, v))
+}
+
+// PublishedAt applies equality check predicate on the "published_at" field. It's identical to PublishedAtEQ.
+func PublishedAt(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldPublishedAt, v))
+}
+
+// TitleEQ applies the EQ predicate on the "title" field.
+func TitleEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldTitle, v))
+}
+
+// TitleNEQ applies the NEQ predicate on the "title" field.
+func TitleNEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldTitle, v))
+}
+
+// TitleIn applies the In predicate on the "title" field.
+func TitleIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIn(FieldTitle, vs...))
+}
+
+// TitleNotIn applies the NotIn predicate on the "title" field.
+func TitleNotIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotIn(FieldTitle, vs...))
+}
+
+// TitleGT applies the GT predicate on the "title" field.
+func TitleGT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGT(FieldTitle, v))
+}
+
+// TitleGTE applies the GTE predicate on the "title" field.
+func TitleGTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGTE(FieldTitle, v))
+}
+
+// TitleLT applies the LT predicate on the "title" field.
+func TitleLT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLT(FieldTitle, v))
+}
+
+// TitleLTE applies the LTE predicate on the "title" field.
+func TitleLTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLTE(FieldTitle, v))
+}
+
+// TitleContains applies the Contains predicate on the "title" field.
+func TitleContains(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContains(FieldTitle, v))
+}
+
+// TitleHasPrefix applies the HasPrefix predicate on the "title" field.
+func TitleHasPrefix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasPrefix(FieldTitle, v))
+}
+
+// TitleHasSuffix applies the HasSuffix predicate on the "title" field.
+func TitleHasSuffix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasSuffix(FieldTitle, v))
+}
+
+// TitleIsNil applies the IsNil predicate on the "title" field.
+func TitleIsNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIsNull(FieldTitle))
+}
+
+// TitleNotNil applies the NotNil predicate on the "title" field.
+func TitleNotNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotNull(FieldTitle))
+}
+
+// TitleEqualFold applies the EqualFold predicate on the "title" field.
+func TitleEqualFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEqualFold(FieldTitle, v))
+}
+
+// TitleContainsFold applies the ContainsFold predicate on the "title" field.
+func TitleContainsFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContainsFold(FieldTitle, v))
+}
+
+// ContentEQ applies the EQ predicate on the "content" field.
+func ContentEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldContent, v))
+}
+
+// ContentNEQ applies the NEQ predicate on the "content" field.
+func ContentNEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldContent, v))
+}
+
+// ContentIn applies the In predicate on the "content" field.
+func ContentIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIn(FieldContent, vs...))
+}
+
+// ContentNotIn applies the NotIn predicate on the "content" field.
+func ContentNotIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotIn(FieldContent, vs...))
+}
+
+// ContentGT applies the GT predicate on the "content" field.
+func ContentGT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGT(FieldContent, v))
+}
+
+// ContentGTE applies the GTE predicate on the "content" field.
+func ContentGTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGTE(FieldContent, v))
+}
+
+// ContentLT applies the LT predicate on the "content" field.
+func ContentLT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLT(FieldContent, v))
+}
+
+// ContentLTE applies the LTE predicate on the "content" field.
+func ContentLTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLTE(FieldContent, v))
+}
+
+// ContentContains applies the Contains predicate on the "content" field.
+func ContentContains(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContains(FieldContent, v))
+}
+
+// ContentHasPrefix applies the HasPrefix predicate on the "content" field.
+func ContentHasPrefix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasPrefix(FieldContent, v))
+}
+
+// ContentHasSuffix applies the HasSuffix predicate on the "content" field.
+func ContentHasSuffix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasSuffix(FieldContent, v))
+}
+
+// ContentIsNil applies the IsNil predicate on the "content" field.
+func ContentIsNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIsNull(FieldContent))
+}
+
+// ContentNotNil applies the NotNil predicate on the "content" field.
+func ContentNotNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotNull(FieldContent))
+}
+
+// ContentEqualFold applies the EqualFold predicate on the "content" field.
+func ContentEqualFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEqualFold(FieldContent, v))
+}
+
+// ContentContainsFold applies the ContainsFold predicate on the "content" field.
+func ContentContainsFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContainsFold(FieldContent, v))
+}
+
+// MarkdownEQ applies the EQ predicate on the "markdown" field.
+func MarkdownEQ(v bool) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldMarkdown, v))
+}
+
+// MarkdownNEQ applies the NEQ predicate on the "markdown" field.
+func MarkdownNEQ(v bool) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldMarkdown, v))
+}
+
+// MarkdownIsNil applies the IsNil predicate on the "markdown" field.
+func MarkdownIsNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIsNull(FieldMarkdown))
+}
+
+// MarkdownNotNil applies the NotNil predicate on the "markdown" field.
+func MarkdownNotNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotNull(FieldMarkdown))
+}
+
+// CreatedByEQ applies the EQ predicate on the "created_by" field.
+func CreatedByEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldCreatedBy, v))
+}
+
+// CreatedByNEQ applies the NEQ predicate on the "created_by" field.
+func CreatedByNEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldCreatedBy, v))
+}
+
+// CreatedByIn applies the In predicate on the "created_by" field.
+func CreatedByIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIn(FieldCreatedBy, vs...))
+}
+
+// CreatedByNotIn applies the NotIn predicate on the "created_by" field.
+func CreatedByNotIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotIn(FieldCreatedBy, vs...))
+}
+
+// CreatedByGT applies the GT predicate on the "created_by" field.
+func CreatedByGT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGT(FieldCreatedBy, v))
+}
+
+// CreatedByGTE applies the GTE predicate on the "created_by" field.
+func CreatedByGTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGTE(FieldCreatedBy, v))
+}
+
+// CreatedByLT applies the LT predicate on the "created_by" field.
+func CreatedByLT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLT(FieldCreatedBy, v))
+}
+
+// CreatedByLTE applies the LTE predicate on the "created_by" field.
+func CreatedByLTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLTE(FieldCreatedBy, v))
+}
+
+// CreatedByContains applies the Contains predicate on the "created_by" field.
+func CreatedByContains(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContains(FieldCreatedBy, v))
+}
+
+// CreatedByHasPrefix applies the HasPrefix predicate on the "created_by" field.
+func CreatedByHasPrefix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasPrefix(FieldCreatedBy, v))
+}
+
+// CreatedByHasSuffix applies the HasSuffix predicate on the "created_by" field.
+func CreatedByHasSuffix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasSuffix(FieldCreatedBy, v))
+}
+
+// CreatedByIsNil applies the IsNil predicate on the "created_by" field.
+func CreatedByIsNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIsNull(FieldCreatedBy))
+}
+
+// CreatedByNotNil applies the NotNil predicate on the "created_by" field.
+func CreatedByNotNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotNull(FieldCreatedBy))
+}
+
+// CreatedByEqualFold applies the EqualFold predicate on the "created_by" field.
+func CreatedByEqualFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEqualFold(FieldCreatedBy, v))
+}
+
+// CreatedByContainsFold applies the ContainsFold predicate on the "created_by" field.
+func CreatedByContainsFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContainsFold(FieldCreatedBy, v))
+}
+
+// UpdatedByEQ applies the EQ predicate on the "updated_by" field.
+func UpdatedByEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldUpdatedBy, v))
+}
+
+// UpdatedByNEQ applies the NEQ predicate on the "updated_by" field.
+func UpdatedByNEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldUpdatedBy, v))
+}
+
+// UpdatedByIn applies the In predicate on the "updated_by" field.
+func UpdatedByIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIn(FieldUpdatedBy, vs...))
+}
+
+// UpdatedByNotIn applies the NotIn predicate on the "updated_by" field.
+func UpdatedByNotIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotIn(FieldUpdatedBy, vs...))
+}
+
+// UpdatedByGT applies the GT predicate on the "updated_by" field.
+func UpdatedByGT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGT(FieldUpdatedBy, v))
+}
+
+// UpdatedByGTE applies the GTE predicate on the "updated_by" field.
+func UpdatedByGTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGTE(FieldUpdatedBy, v))
+}
+
+// UpdatedByLT applies the LT predicate on the "updated_by" field.
+func UpdatedByLT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLT(FieldUpdatedBy, v))
+}
+
+// UpdatedByLTE applies the LTE predicate on the "updated_by" field.
+func UpdatedByLTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLTE(FieldUpdatedBy, v))
+}
+
+// UpdatedByContains applies the Contains predicate on the "updated_by" field.
+func UpdatedByContains(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContains(FieldUpdatedBy, v))
+}
+
+// UpdatedByHasPrefix applies the HasPrefix predicate on the "updated_by" field.
+func UpdatedByHasPrefix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasPrefix(FieldUpdatedBy, v))
+}
+
+// UpdatedByHasSuffix applies the HasSuffix predicate on the "updated_by" field.
+func UpdatedByHasSuffix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasSuffix(FieldUpdatedBy, v))
+}
+
+// UpdatedByIsNil applies the IsNil predicate on the "updated_by" field.
+func UpdatedByIsNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIsNull(FieldUpdatedBy))
+}
+
+// UpdatedByNotNil applies the NotNil predicate on the "updated_by" field.
+func UpdatedByNotNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotNull(FieldUpdatedBy))
+}
+
+// UpdatedByEqualFold applies the EqualFold predicate on the "updated_by" field.
+func UpdatedByEqualFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEqualFold(FieldUpdatedBy, v))
+}
+
+// UpdatedByContainsFold applies the ContainsFold predicate on the "updated_by" field.
+func UpdatedByContainsFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContainsFold(FieldUpdatedBy, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// CreatedAtIsNil applies the IsNil predicate on the "created_at" field.
+func CreatedAtIsNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIsNull(FieldCreatedAt))
+}
+
+// CreatedAtNotNil applies the NotNil predicate on the "created_at" field.
+func CreatedAtNotNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotNull(FieldCreatedAt))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIsNil applies the IsNil predicate on the "updated_at" field.
+func UpdatedAtIsNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIsNull(FieldUpdatedAt))
+}
+
+// UpdatedAtNotNil applies the NotNil predicate on the "updated_at" field.
+func UpdatedAtNotNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotNull(FieldUpdatedAt))
+}
+
+// TopicIDEQ applies the EQ predicate on the "topic_id" field.
+func TopicIDEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldTopicID, v))
+}
+
+// TopicIDNEQ applies the NEQ predicate on the "topic_id" field.
+func TopicIDNEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldTopicID, v))
+}
+
+// TopicIDIn applies the In predicate on the "topic_id" field.
+func TopicIDIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIn(FieldTopicID, vs...))
+}
+
+// TopicIDNotIn applies the NotIn predicate on the "topic_id" field.
+func TopicIDNotIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotIn(FieldTopicID, vs...))
+}
+
+// TopicIDGT applies the GT predicate on the "topic_id" field.
+func TopicIDGT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGT(FieldTopicID, v))
+}
+
+// TopicIDGTE applies the GTE predicate on the "topic_id" field.
+func TopicIDGTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGTE(FieldTopicID, v))
+}
+
+// TopicIDLT applies the LT predicate on the "topic_id" field.
+func TopicIDLT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLT(FieldTopicID, v))
+}
+
+// TopicIDLTE applies the LTE predicate on the "topic_id" field.
+func TopicIDLTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLTE(FieldTopicID, v))
+}
+
+// TopicIDContains applies the Contains predicate on the "topic_id" field.
+func TopicIDContains(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContains(FieldTopicID, v))
+}
+
+// TopicIDHasPrefix applies the HasPrefix predicate on the "topic_id" field.
+func TopicIDHasPrefix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasPrefix(FieldTopicID, v))
+}
+
+// TopicIDHasSuffix applies the HasSuffix predicate on the "topic_id" field.
+func TopicIDHasSuffix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasSuffix(FieldTopicID, v))
+}
+
+// TopicIDEqualFold applies the EqualFold predicate on the "topic_id" field.
+func TopicIDEqualFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEqualFold(FieldTopicID, v))
+}
+
+// TopicIDContainsFold applies the ContainsFold predicate on the "topic_id" field.
+func TopicIDContainsFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContainsFold(FieldTopicID, v))
+}
+
+// AuthorIDEQ applies the EQ predicate on the "author_id" field.
+func AuthorIDEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldAuthorID, v))
+}
+
+// AuthorIDNEQ applies the NEQ predicate on the "author_id" field.
+func AuthorIDNEQ(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldAuthorID, v))
+}
+
+// AuthorIDIn applies the In predicate on the "author_id" field.
+func AuthorIDIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIn(FieldAuthorID, vs...))
+}
+
+// AuthorIDNotIn applies the NotIn predicate on the "author_id" field.
+func AuthorIDNotIn(vs ...string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotIn(FieldAuthorID, vs...))
+}
+
+// AuthorIDGT applies the GT predicate on the "author_id" field.
+func AuthorIDGT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGT(FieldAuthorID, v))
+}
+
+// AuthorIDGTE applies the GTE predicate on the "author_id" field.
+func AuthorIDGTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGTE(FieldAuthorID, v))
+}
+
+// AuthorIDLT applies the LT predicate on the "author_id" field.
+func AuthorIDLT(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLT(FieldAuthorID, v))
+}
+
+// AuthorIDLTE applies the LTE predicate on the "author_id" field.
+func AuthorIDLTE(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLTE(FieldAuthorID, v))
+}
+
+// AuthorIDContains applies the Contains predicate on the "author_id" field.
+func AuthorIDContains(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContains(FieldAuthorID, v))
+}
+
+// AuthorIDHasPrefix applies the HasPrefix predicate on the "author_id" field.
+func AuthorIDHasPrefix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasPrefix(FieldAuthorID, v))
+}
+
+// AuthorIDHasSuffix applies the HasSuffix predicate on the "author_id" field.
+func AuthorIDHasSuffix(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldHasSuffix(FieldAuthorID, v))
+}
+
+// AuthorIDEqualFold applies the EqualFold predicate on the "author_id" field.
+func AuthorIDEqualFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEqualFold(FieldAuthorID, v))
+}
+
+// AuthorIDContainsFold applies the ContainsFold predicate on the "author_id" field.
+func AuthorIDContainsFold(v string) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldContainsFold(FieldAuthorID, v))
+}
+
+// PublishedAtEQ applies the EQ predicate on the "published_at" field.
+func PublishedAtEQ(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldEQ(FieldPublishedAt, v))
+}
+
+// PublishedAtNEQ applies the NEQ predicate on the "published_at" field.
+func PublishedAtNEQ(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNEQ(FieldPublishedAt, v))
+}
+
+// PublishedAtIn applies the In predicate on the "published_at" field.
+func PublishedAtIn(vs ...int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIn(FieldPublishedAt, vs...))
+}
+
+// PublishedAtNotIn applies the NotIn predicate on the "published_at" field.
+func PublishedAtNotIn(vs ...int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotIn(FieldPublishedAt, vs...))
+}
+
+// PublishedAtGT applies the GT predicate on the "published_at" field.
+func PublishedAtGT(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGT(FieldPublishedAt, v))
+}
+
+// PublishedAtGTE applies the GTE predicate on the "published_at" field.
+func PublishedAtGTE(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldGTE(FieldPublishedAt, v))
+}
+
+// PublishedAtLT applies the LT predicate on the "published_at" field.
+func PublishedAtLT(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLT(FieldPublishedAt, v))
+}
+
+// PublishedAtLTE applies the LTE predicate on the "published_at" field.
+func PublishedAtLTE(v int64) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldLTE(FieldPublishedAt, v))
+}
+
+// PublishedAtIsNil applies the IsNil predicate on the "published_at" field.
+func PublishedAtIsNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldIsNull(FieldPublishedAt))
+}
+
+// PublishedAtNotNil applies the NotNil predicate on the "published_at" field.
+func PublishedAtNotNil() predicate.TopicRevision {
+	return predicate.TopicRevision(sql.FieldNotNull(FieldPublishedAt))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.TopicRevision) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.TopicRevision) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.TopicRevision) predicate.TopicRevision {
+	return predicate.TopicRevision(sql.NotPredicates(p))
+}
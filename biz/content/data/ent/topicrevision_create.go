@@ -0,0 +1,1197 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/biz/content/data/ent/topicrevision"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// TopicRevisionCreate is the builder for creating a TopicRevision entity.
+type TopicRevisionCreate struct {
+	config
+	mutation *TopicRevisionMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetTitle sets the "title" field.
+func (_c *TopicRevisionCreate) SetTitle(v string) *TopicRevisionCreate {
+	_c.mutation.SetTitle(v)
+	return _c
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_c *TopicRevisionCreate) SetNillableTitle(v *string) *TopicRevisionCreate {
+	if v != nil {
+		_c.SetTitle(*v)
+	}
+	return _c
+}
+
+// SetContent sets the "content" field.
+func (_c *TopicRevisionCreate) SetContent(v string) *TopicRevisionCreate {
+	_c.mutation.SetContent(v)
+	return _c
+}
+
+// SetNillableContent sets the "content" field if the given value is not nil.
+func (_c *TopicRevisionCreate) SetNillableContent(v *string) *TopicRevisionCreate {
+	if v != nil {
+		_c.SetContent(*v)
+	}
+	return _c
+}
+
+// SetMarkdown sets the "markdown" field.
+func (_c *TopicRevisionCreate) SetMarkdown(v bool) *TopicRevisionCreate {
+	_c.mutation.SetMarkdown(v)
+	return _c
+}
+
+// SetNillableMarkdown sets the "markdown" field if the given value is not nil.
+func (_c *TopicRevisionCreate) SetNillableMarkdown(v *bool) *TopicRevisionCreate {
+	if v != nil {
+		_c.SetMarkdown(*v)
+	}
+	return _c
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_c *TopicRevisionCreate) SetCreatedBy(v string) *TopicRevisionCreate {
+	_c.mutation.SetCreatedBy(v)
+	return _c
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_c *TopicRevisionCreate) SetNillableCreatedBy(v *string) *TopicRevisionCreate {
+	if v != nil {
+		_c.SetCreatedBy(*v)
+	}
+	return _c
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_c *TopicRevisionCreate) SetUpdatedBy(v string) *TopicRevisionCreate {
+	_c.mutation.SetUpdatedBy(v)
+	return _c
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_c *TopicRevisionCreate) SetNillableUpdatedBy(v *string) *TopicRevisionCreate {
+	if v != nil {
+		_c.SetUpdatedBy(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *TopicRevisionCreate) SetCreatedAt(v int64) *TopicRevisionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *TopicRevisionCreate) SetNillableCreatedAt(v *int64) *TopicRevisionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *TopicRevisionCreate) SetUpdatedAt(v int64) *TopicRevisionCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *TopicRevisionCreate) SetNillableUpdatedAt(v *int64) *TopicRevisionCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetTopicID sets the "topic_id" field.
+func (_c *TopicRevisionCreate) SetTopicID(v string) *TopicRevisionCreate {
+	_c.mutation.SetTopicID(v)
+	return _c
+}
+
+// SetAuthorID sets the "author_id" field.
+func (_c *TopicRevisionCreate) SetAuthorID(v string) *TopicRevisionCreate {
+	_c.mutation.SetAuthorID(v)
+	return _c
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (_c *TopicRevisionCreate) SetPublishedAt(v int64) *TopicRevisionCreate {
+	_c.mutation.SetPublishedAt(v)
+	return _c
+}
+
+// SetNillablePublishedAt sets the "published_at" field if the given value is not nil.
+func (_c *TopicRevisionCreate) SetNillablePublishedAt(v *int64) *TopicRevisionCreate {
+	if v != nil {
+		_c.SetPublishedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *TopicRevisionCreate) SetID(v string) *TopicRevisionCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *TopicRevisionCreate) SetNillableID(v *string) *TopicRevisionCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the TopicRevisionMutation object of the builder.
+func (_c *TopicRevisionCreate) Mutation() *TopicRevisionMutation {
+	return _c.mutation
+}
+
+// Save creates the TopicRevision in the database.
+func (_c *TopicRevisionCreate) Save(ctx context.Context) (*TopicRevision, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *TopicRevisionCreate) SaveX(ctx context.Context) *TopicRevision {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TopicRevisionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TopicRevisionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *TopicRevisionCreate) defaults() {
+	if _, ok := _c.mutation.Markdown(); !ok {
+		v := topicrevision.DefaultMarkdown
+		_c.mutation.SetMarkdown(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := topicrevision.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := topicrevision.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := topicrevision.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *TopicRevisionCreate) check() error {
+	if _, ok := _c.mutation.TopicID(); !ok {
+		return &ValidationError{Name: "topic_id", err: errors.New(`ent: missing required field "TopicRevision.topic_id"`)}
+	}
+	if v, ok := _c.mutation.TopicID(); ok {
+		if err := topicrevision.TopicIDValidator(v); err != nil {
+			return &ValidationError{Name: "topic_id", err: fmt.Errorf(`ent: validator failed for field "TopicRevision.topic_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.AuthorID(); !ok {
+		return &ValidationError{Name: "author_id", err: errors.New(`ent: missing required field "TopicRevision.author_id"`)}
+	}
+	if v, ok := _c.mutation.AuthorID(); ok {
+		if err := topicrevision.AuthorIDValidator(v); err != nil {
+			return &ValidationError{Name: "author_id", err: fmt.Errorf(`ent: validator failed for field "TopicRevision.author_id": %w`, err)}
+		}
+	}
+	if v, ok := _c.mutation.ID(); ok {
+		if err := topicrevision.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "TopicRevision.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *TopicRevisionCreate) sqlSave(ctx context.Context) (*TopicRevision, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected TopicRevision.ID type: %T", _spec.ID.Value)
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *TopicRevisionCreate) createSpec() (*TopicRevision, *sqlgraph.CreateSpec) {
+	var (
+		_node = &TopicRevision{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(topicrevision.Table, sqlgraph.NewFieldSpec(topicrevision.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = _c.conflict
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.Title(); ok {
+		_spec.SetField(topicrevision.FieldTitle, field.TypeString, value)
+		_node.Title = value
+	}
+	if value, ok := _c.mutation.Content(); ok {
+		_spec.SetField(topicrevision.FieldContent, field.TypeString, value)
+		_node.Content = value
+	}
+	if value, ok := _c.mutation.Markdown(); ok {
+		_spec.SetField(topicrevision.FieldMarkdown, field.TypeBool, value)
+		_node.Markdown = value
+	}
+	if value, ok := _c.mutation.CreatedBy(); ok {
+		_spec.SetField(topicrevision.FieldCreatedBy, field.TypeString, value)
+		_node.CreatedBy = value
+	}
+	if value, ok := _c.mutation.UpdatedBy(); ok {
+		_spec.SetField(topicrevision.FieldUpdatedBy, field.TypeString, value)
+		_node.UpdatedBy = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(topicrevision.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(topicrevision.FieldUpdatedAt, field.TypeInt64, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := _c.mutation.TopicID(); ok {
+		_spec.SetField(topicrevision.FieldTopicID, field.TypeString, value)
+		_node.TopicID = value
+	}
+	if value, ok := _c.mutation.AuthorID(); ok {
+		_spec.SetField(topicrevision.FieldAuthorID, field.TypeString, value)
+		_node.AuthorID = value
+	}
+	if value, ok := _c.mutation.PublishedAt(); ok {
+		_spec.SetField(topicrevision.FieldPublishedAt, field.TypeInt64, value)
+		_node.PublishedAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.TopicRevision.Create().
+//		SetTitle(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.TopicRevisionUpsert) {
+//			SetTitle(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *TopicRevisionCreate) OnConflict(opts ...sql.ConflictOption) *TopicRevisionUpsertOne {
+	_c.conflict = opts
+	return &TopicRevisionUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.TopicRevision.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *TopicRevisionCreate) OnConflictColumns(columns ...string) *TopicRevisionUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &TopicRevisionUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// TopicRevisionUpsertOne is the builder for "upsert"-ing
+	//  one TopicRevision node.
+	TopicRevisionUpsertOne struct {
+		create *TopicRevisionCreate
+	}
+
+	// TopicRevisionUpsert is the "OnConflict" setter.
+	TopicRevisionUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetTitle sets the "title" field.
+func (u *TopicRevisionUpsert) SetTitle(v string) *TopicRevisionUpsert {
+	u.Set(topicrevision.FieldTitle, v)
+	return u
+}
+
+// UpdateTitle sets the "title" field to the value that was provided on create.
+func (u *TopicRevisionUpsert) UpdateTitle() *TopicRevisionUpsert {
+	u.SetExcluded(topicrevision.FieldTitle)
+	return u
+}
+
+// ClearTitle clears the value of the "title" field.
+func (u *TopicRevisionUpsert) ClearTitle() *TopicRevisionUpsert {
+	u.SetNull(topicrevision.FieldTitle)
+	return u
+}
+
+// SetContent sets the "content" field.
+func (u *TopicRevisionUpsert) SetContent(v string) *TopicRevisionUpsert {
+	u.Set(topicrevision.FieldContent, v)
+	return u
+}
+
+// UpdateContent sets the "content" field to the value that was provided on create.
+func (u *TopicRevisionUpsert) UpdateContent() *TopicRevisionUpsert {
+	u.SetExcluded(topicrevision.FieldContent)
+	return u
+}
+
+// ClearContent clears the value of the "content" field.
+func (u *TopicRevisionUpsert) ClearContent() *TopicRevisionUpsert {
+	u.SetNull(topicrevision.FieldContent)
+	return u
+}
+
+// SetMarkdown sets the "markdown" field.
+func (u *TopicRevisionUpsert) SetMarkdown(v bool) *TopicRevisionUpsert {
+	u.Set(topicrevision.FieldMarkdown, v)
+	return u
+}
+
+// UpdateMarkdown sets the "markdown" field to the value that was provided on create.
+func (u *TopicRevisionUpsert) UpdateMarkdown() *TopicRevisionUpsert {
+	u.SetExcluded(topicrevision.FieldMarkdown)
+	return u
+}
+
+// ClearMarkdown clears the value of the "markdown" field.
+func (u *TopicRevisionUpsert) ClearMarkdown() *TopicRevisionUpsert {
+	u.SetNull(topicrevision.FieldMarkdown)
+	return u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (u *TopicRevisionUpsert) SetCreatedBy(v string) *TopicRevisionUpsert {
+	u.Set(topicrevision.FieldCreatedBy, v)
+	return u
+}
+
+// UpdateCreatedBy sets the "created_by" field to the value that was provided on create.
+func (u *TopicRevisionUpsert) UpdateCreatedBy() *TopicRevisionUpsert {
+	u.SetExcluded(topicrevision.FieldCreatedBy)
+	return u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (u *TopicRevisionUpsert) ClearCreatedBy() *TopicRevisionUpsert {
+	u.SetNull(topicrevision.FieldCreatedBy)
+	return u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (u *TopicRevisionUpsert) SetUpdatedBy(v string) *TopicRevisionUpsert {
+	u.Set(topicrevision.FieldUpdatedBy, v)
+	return u
+}
+
+// UpdateUpdatedBy sets the "updated_by" field to the value that was provided on create.
+func (u *TopicRevisionUpsert) UpdateUpdatedBy() *TopicRevisionUpsert {
+	u.SetExcluded(topicrevision.FieldUpdatedBy)
+	return u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (u *TopicRevisionUpsert) ClearUpdatedBy() *TopicRevisionUpsert {
+	u.SetNull(topicrevision.FieldUpdatedBy)
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *TopicRevisionUpsert) SetUpdatedAt(v int64) *TopicRevisionUpsert {
+	u.Set(topicrevision.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *TopicRevisionUpsert) UpdateUpdatedAt() *TopicRevisionUpsert {
+	u.SetExcluded(topicrevision.FieldUpdatedAt)
+	return u
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *TopicRevisionUpsert) AddUpdatedAt(v int64) *TopicRevisionUpsert {
+	u.Add(topicrevision.FieldUpdatedAt, v)
+	return u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (u *TopicRevisionUpsert) ClearUpdatedAt() *TopicRevisionUpsert {
+	u.SetNull(topicrevision.FieldUpdatedAt)
+	return u
+}
+
+// SetTopicID sets the "topic_id" field.
+func (u *TopicRevisionUpsert) SetTopicID(v string) *TopicRevisionUpsert {
+	u.Set(topicrevision.FieldTopicID, v)
+	return u
+}
+
+// UpdateTopicID sets the "topic_id" field to the value that was provided on create.
+func (u *TopicRevisionUpsert) UpdateTopicID() *TopicRevisionUpsert {
+	u.SetExcluded(topicrevision.FieldTopicID)
+	return u
+}
+
+// SetAuthorID sets the "author_id" field.
+func (u *TopicRevisionUpsert) SetAuthorID(v string) *TopicRevisionUpsert {
+	u.Set(topicrevision.FieldAuthorID, v)
+	return u
+}
+
+// UpdateAuthorID sets the "author_id" field to the value that was provided on create.
+func (u *TopicRevisionUpsert) UpdateAuthorID() *TopicRevisionUpsert {
+	u.SetExcluded(topicrevision.FieldAuthorID)
+	return u
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (u *TopicRevisionUpsert) SetPublishedAt(v int64) *TopicRevisionUpsert {
+	u.Set(topicrevision.FieldPublishedAt, v)
+	return u
+}
+
+// UpdatePublishedAt sets the "published_at" field to the value that was provided on create.
+func (u *TopicRevisionUpsert) UpdatePublishedAt() *TopicRevisionUpsert {
+	u.SetExcluded(topicrevision.FieldPublishedAt)
+	return u
+}
+
+// AddPublishedAt adds v to the "published_at" field.
+func (u *TopicRevisionUpsert) AddPublishedAt(v int64) *TopicRevisionUpsert {
+	u.Add(topicrevision.FieldPublishedAt, v)
+	return u
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (u *TopicRevisionUpsert) ClearPublishedAt() *TopicRevisionUpsert {
+	u.SetNull(topicrevision.FieldPublishedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.TopicRevision.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(topicrevision.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *TopicRevisionUpsertOne) UpdateNewValues() *TopicRevisionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(topicrevision.FieldID)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(topicrevision.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.TopicRevision.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *TopicRevisionUpsertOne) Ignore() *TopicRevisionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *TopicRevisionUpsertOne) DoNothing() *TopicRevisionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the TopicRevisionCreate.OnConflict
+// documentation for more info.
+func (u *TopicRevisionUpsertOne) Update(set func(*TopicRevisionUpsert)) *TopicRevisionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&TopicRevisionUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetTitle sets the "title" field.
+func (u *TopicRevisionUpsertOne) SetTitle(v string) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetTitle(v)
+	})
+}
+
+// UpdateTitle sets the "title" field to the value that was provided on create.
+func (u *TopicRevisionUpsertOne) UpdateTitle() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateTitle()
+	})
+}
+
+// ClearTitle clears the value of the "title" field.
+func (u *TopicRevisionUpsertOne) ClearTitle() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearTitle()
+	})
+}
+
+// SetContent sets the "content" field.
+func (u *TopicRevisionUpsertOne) SetContent(v string) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetContent(v)
+	})
+}
+
+// UpdateContent sets the "content" field to the value that was provided on create.
+func (u *TopicRevisionUpsertOne) UpdateContent() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateContent()
+	})
+}
+
+// ClearContent clears the value of the "content" field.
+func (u *TopicRevisionUpsertOne) ClearContent() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearContent()
+	})
+}
+
+// SetMarkdown sets the "markdown" field.
+func (u *TopicRevisionUpsertOne) SetMarkdown(v bool) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetMarkdown(v)
+	})
+}
+
+// UpdateMarkdown sets the "markdown" field to the value that was provided on create.
+func (u *TopicRevisionUpsertOne) UpdateMarkdown() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateMarkdown()
+	})
+}
+
+// ClearMarkdown clears the value of the "markdown" field.
+func (u *TopicRevisionUpsertOne) ClearMarkdown() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearMarkdown()
+	})
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (u *TopicRevisionUpsertOne) SetCreatedBy(v string) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetCreatedBy(v)
+	})
+}
+
+// UpdateCreatedBy sets the "created_by" field to the value that was provided on create.
+func (u *TopicRevisionUpsertOne) UpdateCreatedBy() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateCreatedBy()
+	})
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (u *TopicRevisionUpsertOne) ClearCreatedBy() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearCreatedBy()
+	})
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (u *TopicRevisionUpsertOne) SetUpdatedBy(v string) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetUpdatedBy(v)
+	})
+}
+
+// UpdateUpdatedBy sets the "updated_by" field to the value that was provided on create.
+func (u *TopicRevisionUpsertOne) UpdateUpdatedBy() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateUpdatedBy()
+	})
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (u *TopicRevisionUpsertOne) ClearUpdatedBy() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearUpdatedBy()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *TopicRevisionUpsertOne) SetUpdatedAt(v int64) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *TopicRevisionUpsertOne) AddUpdatedAt(v int64) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.AddUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *TopicRevisionUpsertOne) UpdateUpdatedAt() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (u *TopicRevisionUpsertOne) ClearUpdatedAt() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearUpdatedAt()
+	})
+}
+
+// SetTopicID sets the "topic_id" field.
+func (u *TopicRevisionUpsertOne) SetTopicID(v string) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetTopicID(v)
+	})
+}
+
+// UpdateTopicID sets the "topic_id" field to the value that was provided on create.
+func (u *TopicRevisionUpsertOne) UpdateTopicID() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateTopicID()
+	})
+}
+
+// SetAuthorID sets the "author_id" field.
+func (u *TopicRevisionUpsertOne) SetAuthorID(v string) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetAuthorID(v)
+	})
+}
+
+// UpdateAuthorID sets the "author_id" field to the value that was provided on create.
+func (u *TopicRevisionUpsertOne) UpdateAuthorID() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateAuthorID()
+	})
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (u *TopicRevisionUpsertOne) SetPublishedAt(v int64) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetPublishedAt(v)
+	})
+}
+
+// AddPublishedAt adds v to the "published_at" field.
+func (u *TopicRevisionUpsertOne) AddPublishedAt(v int64) *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.AddPublishedAt(v)
+	})
+}
+
+// UpdatePublishedAt sets the "published_at" field to the value that was provided on create.
+func (u *TopicRevisionUpsertOne) UpdatePublishedAt() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdatePublishedAt()
+	})
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (u *TopicRevisionUpsertOne) ClearPublishedAt() *TopicRevisionUpsertOne {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearPublishedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *TopicRevisionUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for TopicRevisionCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *TopicRevisionUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *TopicRevisionUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: TopicRevisionUpsertOne.ID is not supported by MySQL driver. Use TopicRevisionUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *TopicRevisionUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// TopicRevisionCreateBulk is the builder for creating many TopicRevision entities in bulk.
+type TopicRevisionCreateBulk struct {
+	config
+	err      error
+	builders []*TopicRevisionCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the TopicRevision entities in the database.
+func (_c *TopicRevisionCreateBulk) Save(ctx context.Context) ([]*TopicRevision, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*TopicRevision, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TopicRevisionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *TopicRevisionCreateBulk) SaveX(ctx context.Context) []*TopicRevision {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *TopicRevisionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *TopicRevisionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.TopicRevision.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.TopicRevisionUpsert) {
+//			SetTitle(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *TopicRevisionCreateBulk) OnConflict(opts ...sql.ConflictOption) *TopicRevisionUpsertBulk {
+	_c.conflict = opts
+	return &TopicRevisionUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.TopicRevision.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *TopicRevisionCreateBulk) OnConflictColumns(columns ...string) *TopicRevisionUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &TopicRevisionUpsertBulk{
+		create: _c,
+	}
+}
+
+// TopicRevisionUpsertBulk is the builder for "upsert"-ing
+// a bulk of TopicRevision nodes.
+type TopicRevisionUpsertBulk struct {
+	create *TopicRevisionCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.TopicRevision.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(topicrevision.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *TopicRevisionUpsertBulk) UpdateNewValues() *TopicRevisionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(topicrevision.FieldID)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(topicrevision.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.TopicRevision.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *TopicRevisionUpsertBulk) Ignore() *TopicRevisionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *TopicRevisionUpsertBulk) DoNothing() *TopicRevisionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the TopicRevisionCreateBulk.OnConflict
+// documentation for more info.
+func (u *TopicRevisionUpsertBulk) Update(set func(*TopicRevisionUpsert)) *TopicRevisionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&TopicRevisionUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetTitle sets the "title" field.
+func (u *TopicRevisionUpsertBulk) SetTitle(v string) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetTitle(v)
+	})
+}
+
+// UpdateTitle sets the "title" field to the value that was provided on create.
+func (u *TopicRevisionUpsertBulk) UpdateTitle() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateTitle()
+	})
+}
+
+// ClearTitle clears the value of the "title" field.
+func (u *TopicRevisionUpsertBulk) ClearTitle() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearTitle()
+	})
+}
+
+// SetContent sets the "content" field.
+func (u *TopicRevisionUpsertBulk) SetContent(v string) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetContent(v)
+	})
+}
+
+// UpdateContent sets the "content" field to the value that was provided on create.
+func (u *TopicRevisionUpsertBulk) UpdateContent() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateContent()
+	})
+}
+
+// ClearContent clears the value of the "content" field.
+func (u *TopicRevisionUpsertBulk) ClearContent() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearContent()
+	})
+}
+
+// SetMarkdown sets the "markdown" field.
+func (u *TopicRevisionUpsertBulk) SetMarkdown(v bool) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetMarkdown(v)
+	})
+}
+
+// UpdateMarkdown sets the "markdown" field to the value that was provided on create.
+func (u *TopicRevisionUpsertBulk) UpdateMarkdown() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateMarkdown()
+	})
+}
+
+// ClearMarkdown clears the value of the "markdown" field.
+func (u *TopicRevisionUpsertBulk) ClearMarkdown() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearMarkdown()
+	})
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (u *TopicRevisionUpsertBulk) SetCreatedBy(v string) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetCreatedBy(v)
+	})
+}
+
+// UpdateCreatedBy sets the "created_by" field to the value that was provided on create.
+func (u *TopicRevisionUpsertBulk) UpdateCreatedBy() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateCreatedBy()
+	})
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (u *TopicRevisionUpsertBulk) ClearCreatedBy() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearCreatedBy()
+	})
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (u *TopicRevisionUpsertBulk) SetUpdatedBy(v string) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetUpdatedBy(v)
+	})
+}
+
+// UpdateUpdatedBy sets the "updated_by" field to the value that was provided on create.
+func (u *TopicRevisionUpsertBulk) UpdateUpdatedBy() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateUpdatedBy()
+	})
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (u *TopicRevisionUpsertBulk) ClearUpdatedBy() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearUpdatedBy()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *TopicRevisionUpsertBulk) SetUpdatedAt(v int64) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *TopicRevisionUpsertBulk) AddUpdatedAt(v int64) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.AddUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *TopicRevisionUpsertBulk) UpdateUpdatedAt() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (u *TopicRevisionUpsertBulk) ClearUpdatedAt() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearUpdatedAt()
+	})
+}
+
+// SetTopicID sets the "topic_id" field.
+func (u *TopicRevisionUpsertBulk) SetTopicID(v string) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetTopicID(v)
+	})
+}
+
+// UpdateTopicID sets the "topic_id" field to the value that was provided on create.
+func (u *TopicRevisionUpsertBulk) UpdateTopicID() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateTopicID()
+	})
+}
+
+// SetAuthorID sets the "author_id" field.
+func (u *TopicRevisionUpsertBulk) SetAuthorID(v string) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetAuthorID(v)
+	})
+}
+
+// UpdateAuthorID sets the "author_id" field to the value that was provided on create.
+func (u *TopicRevisionUpsertBulk) UpdateAuthorID() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdateAuthorID()
+	})
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (u *TopicRevisionUpsertBulk) SetPublishedAt(v int64) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.SetPublishedAt(v)
+	})
+}
+
+// AddPublishedAt adds v to the "published_at" field.
+func (u *TopicRevisionUpsertBulk) AddPublishedAt(v int64) *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.AddPublishedAt(v)
+	})
+}
+
+// UpdatePublishedAt sets the "published_at" field to the value that was provided on create.
+func (u *TopicRevisionUpsertBulk) UpdatePublishedAt() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.UpdatePublishedAt()
+	})
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (u *TopicRevisionUpsertBulk) ClearPublishedAt() *TopicRevisionUpsertBulk {
+	return u.Update(func(s *TopicRevisionUpsert) {
+		s.ClearPublishedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *TopicRevisionUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the TopicRevisionCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for TopicRevisionCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *TopicRevisionUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
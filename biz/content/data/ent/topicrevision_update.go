@@ -0,0 +1,691 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/biz/content/data/ent/predicate"
+	"ncobase/biz/content/data/ent/topicrevision"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// TopicRevisionUpdate is the builder for updating TopicRevision entities.
+type TopicRevisionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TopicRevisionMutation
+}
+
+// Where appends a list predicates to the TopicRevisionUpdate builder.
+func (_u *TopicRevisionUpdate) Where(ps ...predicate.TopicRevision) *TopicRevisionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetTitle sets the "title" field.
+func (_u *TopicRevisionUpdate) SetTitle(v string) *TopicRevisionUpdate {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *TopicRevisionUpdate) SetNillableTitle(v *string) *TopicRevisionUpdate {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// ClearTitle clears the value of the "title" field.
+func (_u *TopicRevisionUpdate) ClearTitle() *TopicRevisionUpdate {
+	_u.mutation.ClearTitle()
+	return _u
+}
+
+// SetContent sets the "content" field.
+func (_u *TopicRevisionUpdate) SetContent(v string) *TopicRevisionUpdate {
+	_u.mutation.SetContent(v)
+	return _u
+}
+
+// SetNillableContent sets the "content" field if the given value is not nil.
+func (_u *TopicRevisionUpdate) SetNillableContent(v *string) *TopicRevisionUpdate {
+	if v != nil {
+		_u.SetContent(*v)
+	}
+	return _u
+}
+
+// ClearContent clears the value of the "content" field.
+func (_u *TopicRevisionUpdate) ClearContent() *TopicRevisionUpdate {
+	_u.mutation.ClearContent()
+	return _u
+}
+
+// SetMarkdown sets the "markdown" field.
+func (_u *TopicRevisionUpdate) SetMarkdown(v bool) *TopicRevisionUpdate {
+	_u.mutation.SetMarkdown(v)
+	return _u
+}
+
+// SetNillableMarkdown sets the "markdown" field if the given value is not nil.
+func (_u *TopicRevisionUpdate) SetNillableMarkdown(v *bool) *TopicRevisionUpdate {
+	if v != nil {
+		_u.SetMarkdown(*v)
+	}
+	return _u
+}
+
+// ClearMarkdown clears the value of the "markdown" field.
+func (_u *TopicRevisionUpdate) ClearMarkdown() *TopicRevisionUpdate {
+	_u.mutation.ClearMarkdown()
+	return _u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *TopicRevisionUpdate) SetCreatedBy(v string) *TopicRevisionUpdate {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *TopicRevisionUpdate) SetNillableCreatedBy(v *string) *TopicRevisionUpdate {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *TopicRevisionUpdate) ClearCreatedBy() *TopicRevisionUpdate {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_u *TopicRevisionUpdate) SetUpdatedBy(v string) *TopicRevisionUpdate {
+	_u.mutation.SetUpdatedBy(v)
+	return _u
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_u *TopicRevisionUpdate) SetNillableUpdatedBy(v *string) *TopicRevisionUpdate {
+	if v != nil {
+		_u.SetUpdatedBy(*v)
+	}
+	return _u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (_u *TopicRevisionUpdate) ClearUpdatedBy() *TopicRevisionUpdate {
+	_u.mutation.ClearUpdatedBy()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *TopicRevisionUpdate) SetUpdatedAt(v int64) *TopicRevisionUpdate {
+	_u.mutation.ResetUpdatedAt()
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddUpdatedAt adds value to the "updated_at" field.
+func (_u *TopicRevisionUpdate) AddUpdatedAt(v int64) *TopicRevisionUpdate {
+	_u.mutation.AddUpdatedAt(v)
+	return _u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (_u *TopicRevisionUpdate) ClearUpdatedAt() *TopicRevisionUpdate {
+	_u.mutation.ClearUpdatedAt()
+	return _u
+}
+
+// SetTopicID sets the "topic_id" field.
+func (_u *TopicRevisionUpdate) SetTopicID(v string) *TopicRevisionUpdate {
+	_u.mutation.SetTopicID(v)
+	return _u
+}
+
+// SetNillableTopicID sets the "topic_id" field if the given value is not nil.
+func (_u *TopicRevisionUpdate) SetNillableTopicID(v *string) *TopicRevisionUpdate {
+	if v != nil {
+		_u.SetTopicID(*v)
+	}
+	return _u
+}
+
+// SetAuthorID sets the "author_id" field.
+func (_u *TopicRevisionUpdate) SetAuthorID(v string) *TopicRevisionUpdate {
+	_u.mutation.SetAuthorID(v)
+	return _u
+}
+
+// SetNillableAuthorID sets the "author_id" field if the given value is not nil.
+func (_u *TopicRevisionUpdate) SetNillableAuthorID(v *string) *TopicRevisionUpdate {
+	if v != nil {
+		_u.SetAuthorID(*v)
+	}
+	return _u
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (_u *TopicRevisionUpdate) SetPublishedAt(v int64) *TopicRevisionUpdate {
+	_u.mutation.ResetPublishedAt()
+	_u.mutation.SetPublishedAt(v)
+	return _u
+}
+
+// SetNillablePublishedAt sets the "published_at" field if the given value is not nil.
+func (_u *TopicRevisionUpdate) SetNillablePublishedAt(v *int64) *TopicRevisionUpdate {
+	if v != nil {
+		_u.SetPublishedAt(*v)
+	}
+	return _u
+}
+
+// AddPublishedAt adds value to the "published_at" field.
+func (_u *TopicRevisionUpdate) AddPublishedAt(v int64) *TopicRevisionUpdate {
+	_u.mutation.AddPublishedAt(v)
+	return _u
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (_u *TopicRevisionUpdate) ClearPublishedAt() *TopicRevisionUpdate {
+	_u.mutation.ClearPublishedAt()
+	return _u
+}
+
+// Mutation returns the TopicRevisionMutation object of the builder.
+func (_u *TopicRevisionUpdate) Mutation() *TopicRevisionMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *TopicRevisionUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TopicRevisionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *TopicRevisionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TopicRevisionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *TopicRevisionUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok && !_u.mutation.UpdatedAtCleared() {
+		v := topicrevision.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TopicRevisionUpdate) check() error {
+	if v, ok := _u.mutation.TopicID(); ok {
+		if err := topicrevision.TopicIDValidator(v); err != nil {
+			return &ValidationError{Name: "topic_id", err: fmt.Errorf(`ent: validator failed for field "TopicRevision.topic_id": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.AuthorID(); ok {
+		if err := topicrevision.AuthorIDValidator(v); err != nil {
+			return &ValidationError{Name: "author_id", err: fmt.Errorf(`ent: validator failed for field "TopicRevision.author_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *TopicRevisionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(topicrevision.Table, topicrevision.Columns, sqlgraph.NewFieldSpec(topicrevision.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Title(); ok {
+		_spec.SetField(topicrevision.FieldTitle, field.TypeString, value)
+	}
+	if _u.mutation.TitleCleared() {
+		_spec.ClearField(topicrevision.FieldTitle, field.TypeString)
+	}
+	if value, ok := _u.mutation.Content(); ok {
+		_spec.SetField(topicrevision.FieldContent, field.TypeString, value)
+	}
+	if _u.mutation.ContentCleared() {
+		_spec.ClearField(topicrevision.FieldContent, field.TypeString)
+	}
+	if value, ok := _u.mutation.Markdown(); ok {
+		_spec.SetField(topicrevision.FieldMarkdown, field.TypeBool, value)
+	}
+	if _u.mutation.MarkdownCleared() {
+		_spec.ClearField(topicrevision.FieldMarkdown, field.TypeBool)
+	}
+	if value, ok := _u.mutation.CreatedBy(); ok {
+		_spec.SetField(topicrevision.FieldCreatedBy, field.TypeString, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(topicrevision.FieldCreatedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.UpdatedBy(); ok {
+		_spec.SetField(topicrevision.FieldUpdatedBy, field.TypeString, value)
+	}
+	if _u.mutation.UpdatedByCleared() {
+		_spec.ClearField(topicrevision.FieldUpdatedBy, field.TypeString)
+	}
+	if _u.mutation.CreatedAtCleared() {
+		_spec.ClearField(topicrevision.FieldCreatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(topicrevision.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(topicrevision.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.UpdatedAtCleared() {
+		_spec.ClearField(topicrevision.FieldUpdatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.TopicID(); ok {
+		_spec.SetField(topicrevision.FieldTopicID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.AuthorID(); ok {
+		_spec.SetField(topicrevision.FieldAuthorID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.PublishedAt(); ok {
+		_spec.SetField(topicrevision.FieldPublishedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedPublishedAt(); ok {
+		_spec.AddField(topicrevision.FieldPublishedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.PublishedAtCleared() {
+		_spec.ClearField(topicrevision.FieldPublishedAt, field.TypeInt64)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{topicrevision.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// TopicRevisionUpdateOne is the builder for updating a single TopicRevision entity.
+type TopicRevisionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TopicRevisionMutation
+}
+
+// SetTitle sets the "title" field.
+func (_u *TopicRevisionUpdateOne) SetTitle(v string) *TopicRevisionUpdateOne {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *TopicRevisionUpdateOne) SetNillableTitle(v *string) *TopicRevisionUpdateOne {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// ClearTitle clears the value of the "title" field.
+func (_u *TopicRevisionUpdateOne) ClearTitle() *TopicRevisionUpdateOne {
+	_u.mutation.ClearTitle()
+	return _u
+}
+
+// SetContent sets the "content" field.
+func (_u *TopicRevisionUpdateOne) SetContent(v string) *TopicRevisionUpdateOne {
+	_u.mutation.SetContent(v)
+	return _u
+}
+
+// SetNillableContent sets the "content" field if the given value is not nil.
+func (_u *TopicRevisionUpdateOne) SetNillableContent(v *string) *TopicRevisionUpdateOne {
+	if v != nil {
+		_u.SetContent(*v)
+	}
+	return _u
+}
+
+// ClearContent clears the value of the "content" field.
+func (_u *TopicRevisionUpdateOne) ClearContent() *TopicRevisionUpdateOne {
+	_u.mutation.ClearContent()
+	return _u
+}
+
+// SetMarkdown sets the "markdown" field.
+func (_u *TopicRevisionUpdateOne) SetMarkdown(v bool) *TopicRevisionUpdateOne {
+	_u.mutation.SetMarkdown(v)
+	return _u
+}
+
+// SetNillableMarkdown sets the "markdown" field if the given value is not nil.
+func (_u *TopicRevisionUpdateOne) SetNillableMarkdown(v *bool) *TopicRevisionUpdateOne {
+	if v != nil {
+		_u.SetMarkdown(*v)
+	}
+	return _u
+}
+
+// ClearMarkdown clears the value of the "markdown" field.
+func (_u *TopicRevisionUpdateOne) ClearMarkdown() *TopicRevisionUpdateOne {
+	_u.mutation.ClearMarkdown()
+	return _u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *TopicRevisionUpdateOne) SetCreatedBy(v string) *TopicRevisionUpdateOne {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *TopicRevisionUpdateOne) SetNillableCreatedBy(v *string) *TopicRevisionUpdateOne {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *TopicRevisionUpdateOne) ClearCreatedBy() *TopicRevisionUpdateOne {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_u *TopicRevisionUpdateOne) SetUpdatedBy(v string) *TopicRevisionUpdateOne {
+	_u.mutation.SetUpdatedBy(v)
+	return _u
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_u *TopicRevisionUpdateOne) SetNillableUpdatedBy(v *string) *TopicRevisionUpdateOne {
+	if v != nil {
+		_u.SetUpdatedBy(*v)
+	}
+	return _u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (_u *TopicRevisionUpdateOne) ClearUpdatedBy() *TopicRevisionUpdateOne {
+	_u.mutation.ClearUpdatedBy()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *TopicRevisionUpdateOne) SetUpdatedAt(v int64) *TopicRevisionUpdateOne {
+	_u.mutation.ResetUpdatedAt()
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddUpdatedAt adds value to the "updated_at" field.
+func (_u *TopicRevisionUpdateOne) AddUpdatedAt(v int64) *TopicRevisionUpdateOne {
+	_u.mutation.AddUpdatedAt(v)
+	return _u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (_u *TopicRevisionUpdateOne) ClearUpdatedAt() *TopicRevisionUpdateOne {
+	_u.mutation.ClearUpdatedAt()
+	return _u
+}
+
+// SetTopicID sets the "topic_id" field.
+func (_u *TopicRevisionUpdateOne) SetTopicID(v string) *TopicRevisionUpdateOne {
+	_u.mutation.SetTopicID(v)
+	return _u
+}
+
+// SetNillableTopicID sets the "topic_id" field if the given value is not nil.
+func (_u *TopicRevisionUpdateOne) SetNillableTopicID(v *string) *TopicRevisionUpdateOne {
+	if v != nil {
+		_u.SetTopicID(*v)
+	}
+	return _u
+}
+
+// SetAuthorID sets the "author_id" field.
+func (_u *TopicRevisionUpdateOne) SetAuthorID(v string) *TopicRevisionUpdateOne {
+	_u.mutation.SetAuthorID(v)
+	return _u
+}
+
+// SetNillableAuthorID sets the "author_id" field if the given value is not nil.
+func (_u *TopicRevisionUpdateOne) SetNillableAuthorID(v *string) *TopicRevisionUpdateOne {
+	if v != nil {
+		_u.SetAuthorID(*v)
+	}
+	return _u
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (_u *TopicRevisionUpdateOne) SetPublishedAt(v int64) *TopicRevisionUpdateOne {
+	_u.mutation.ResetPublishedAt()
+	_u.mutation.SetPublishedAt(v)
+	return _u
+}
+
+// SetNillablePublishedAt sets the "published_at" field if the given value is not nil.
+func (_u *TopicRevisionUpdateOne) SetNillablePublishedAt(v *int64) *TopicRevisionUpdateOne {
+	if v != nil {
+		_u.SetPublishedAt(*v)
+	}
+	return _u
+}
+
+// AddPublishedAt adds value to the "published_at" field.
+func (_u *TopicRevisionUpdateOne) AddPublishedAt(v int64) *TopicRevisionUpdateOne {
+	_u.mutation.AddPublishedAt(v)
+	return _u
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (_u *TopicRevisionUpdateOne) ClearPublishedAt() *TopicRevisionUpdateOne {
+	_u.mutation.ClearPublishedAt()
+	return _u
+}
+
+// Mutation returns the TopicRevisionMutation object of the builder.
+func (_u *TopicRevisionUpdateOne) Mutation() *TopicRevisionMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the TopicRevisionUpdate builder.
+func (_u *TopicRevisionUpdateOne) Where(ps ...predicate.TopicRevision) *TopicRevisionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *TopicRevisionUpdateOne) Select(field string, fields ...string) *TopicRevisionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated TopicRevision entity.
+func (_u *TopicRevisionUpdateOne) Save(ctx context.Context) (*TopicRevision, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *TopicRevisionUpdateOne) SaveX(ctx context.Context) *TopicRevision {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *TopicRevisionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *TopicRevisionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *TopicRevisionUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok && !_u.mutation.UpdatedAtCleared() {
+		v := topicrevision.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *TopicRevisionUpdateOne) check() error {
+	if v, ok := _u.mutation.TopicID(); ok {
+		if err := topicrevision.TopicIDValidator(v); err != nil {
+			return &ValidationError{Name: "topic_id", err: fmt.Errorf(`ent: validator failed for field "TopicRevision.topic_id": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.AuthorID(); ok {
+		if err := topicrevision.AuthorIDValidator(v); err != nil {
+			return &ValidationError{Name: "author_id", err: fmt.Errorf(`ent: validator failed for field "TopicRevision.author_id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *TopicRevisionUpdateOne) sqlSave(ctx context.Context) (_node *TopicRevision, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(topicrevision.Table, topicrevision.Columns, sqlgraph.NewFieldSpec(topicrevision.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "TopicRevision.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, topicrevision.FieldID)
+		for _, f := range fields {
+			if !topicrevision.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != topicrevision.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Title(); ok {
+		_spec.SetField(topicrevision.FieldTitle, field.TypeString, value)
+	}
+	if _u.mutation.TitleCleared() {
+		_spec.ClearField(topicrevision.FieldTitle, field.TypeString)
+	}
+	if value, ok := _u.mutation.Content(); ok {
+		_spec.SetField(topicrevision.FieldContent, field.TypeString, value)
+	}
+	if _u.mutation.ContentCleared() {
+		_spec.ClearField(topicrevision.FieldContent, field.TypeString)
+	}
+	if value, ok := _u.mutation.Markdown(); ok {
+		_spec.SetField(topicrevision.FieldMarkdown, field.TypeBool, value)
+	}
+	if _u.mutation.MarkdownCleared() {
+		_spec.ClearField(topicrevision.FieldMarkdown, field.TypeBool)
+	}
+	if value, ok := _u.mutation.CreatedBy(); ok {
+		_spec.SetField(topicrevision.FieldCreatedBy, field.TypeString, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(topicrevision.FieldCreatedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.UpdatedBy(); ok {
+		_spec.SetField(topicrevision.FieldUpdatedBy, field.TypeString, value)
+	}
+	if _u.mutation.UpdatedByCleared() {
+		_spec.ClearField(topicrevision.FieldUpdatedBy, field.TypeString)
+	}
+	if _u.mutation.CreatedAtCleared() {
+		_spec.ClearField(topicrevision.FieldCreatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(topicrevision.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(topicrevision.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.UpdatedAtCleared() {
+		_spec.ClearField(topicrevision.FieldUpdatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.TopicID(); ok {
+		_spec.SetField(topicrevision.FieldTopicID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.AuthorID(); ok {
+		_spec.SetField(topicrevision.FieldAuthorID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.PublishedAt(); ok {
+		_spec.SetField(topicrevision.FieldPublishedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedPublishedAt(); ok {
+		_spec.AddField(topicrevision.FieldPublishedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.PublishedAtCleared() {
+		_spec.ClearField(topicrevision.FieldPublishedAt, field.TypeInt64)
+	}
+	_node = &TopicRevision{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{topicrevision.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
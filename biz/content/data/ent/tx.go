@@ -28,6 +28,8 @@ type Tx struct {
 	Topic *TopicClient
 	// TopicMedia is the client for interacting with the TopicMedia builders.
 	TopicMedia *TopicMediaClient
+	// TopicRevision is the client for interacting with the TopicRevision builders.
+	TopicRevision *TopicRevisionClient
 
 	// lazily loaded.
 	client     *Client
@@ -166,6 +168,7 @@ func (tx *Tx) init() {
 	tx.TaxonomyRelation = NewTaxonomyRelationClient(tx.config)
 	tx.Topic = NewTopicClient(tx.config)
 	tx.TopicMedia = NewTopicMediaClient(tx.config)
+	tx.TopicRevision = NewTopicRevisionClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -404,7 +404,7 @@ func (r *channelRepository) FindChannel(ctx context.Context, params *structs.Fin
 	// execute the builder
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
@@ -381,7 +381,7 @@ func (r *distributionRepository) FindDistribution(ctx context.Context, params *s
 	// execute the builder
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
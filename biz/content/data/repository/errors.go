@@ -1,10 +1,26 @@
 package repository
 
-import "ncobase/biz/content/data/ent"
+import (
+	"errors"
+	"ncobase/biz/content/data/ent"
+	"ncobase/internal/dberr"
+)
+
+// ErrNotFound is returned when the requested row does not exist.
+var ErrNotFound = dberr.ErrNotFound
+
+// ErrTaxonomySlugTaken is returned when an import meets a slug it may not update:
+// one that exists outside upsert mode, or one of another space.
+var ErrTaxonomySlugTaken = errors.New("taxonomy slug is taken")
+
+// mapError converts an ent not-found error into ErrNotFound, other errors pass through.
+func mapError(err error) error {
+	return dberr.Map(err, ent.IsNotFound)
+}
 
 // IsNotFound reports whether the error means the row does not exist.
 func IsNotFound(err error) bool {
-	return ent.IsNotFound(err)
+	return dberr.IsNotFound(err) || ent.IsNotFound(err)
 }
 
 // IsConstraintError reports whether the error is a constraint violation.
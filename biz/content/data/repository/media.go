@@ -180,7 +180,7 @@ func (r *mediaRepository) GetByIDs(ctx context.Context, ids []string) ([]*ent.Me
 			go func(m *ent.Media) {
 				cacheKey := fmt.Sprintf("%s", m.ID)
 				if err := r.c.Set(context.Background(), cacheKey, m); err != nil {
-					logger.Debugf(context.Background(), "Failed to cache media %s: %v", m.ID, err)
+					logger.Debugf(ctx, "Failed to cache media %s: %v", m.ID, err)
 				}
 			}(media)
 		}
@@ -412,7 +412,7 @@ func (r *mediaRepository) FindMedia(ctx context.Context, params *structs.FindMed
 
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
@@ -13,6 +13,7 @@ type Repository struct {
 	Distribution      DistributionRepositoryInterface
 	Media             MediaRepositoryInterface
 	TopicMedia        TopicMediaRepositoryInterface
+	TopicRevision     TopicRevisionRepositoryInterface
 }
 
 // New creates a new repository.
@@ -25,5 +26,6 @@ func New(d *data.Data) *Repository {
 		Distribution:      NewDistributionRepository(d),
 		Media:             NewMediaRepository(d),
 		TopicMedia:        NewTopicMediaRepository(d),
+		TopicRevision:     NewTopicRevisionRepository(d),
 	}
 }
@@ -23,6 +23,7 @@ func SerializeTaxonomy(row *ent.Taxonomy) *structs.ReadTaxonomy {
 		Keywords:    row.Keywords,
 		Description: row.Description,
 		Status:      row.Status,
+		Order:       row.Order,
 		Extras:      &row.Extras,
 		ParentID:    &row.ParentID,
 		SpaceID:     row.ParentID,
@@ -282,3 +283,30 @@ func SerializeTaxonomyRelations(rows []*ent.TaxonomyRelation) []*structs.ReadTax
 	}
 	return result
 }
+
+// SerializeTopicRevision converts ent.TopicRevision to structs.ReadTopicRevision.
+func SerializeTopicRevision(row *ent.TopicRevision) *structs.ReadTopicRevision {
+	if row == nil {
+		return nil
+	}
+	return &structs.ReadTopicRevision{
+		ID:          row.ID,
+		TopicID:     row.TopicID,
+		AuthorID:    row.AuthorID,
+		Title:       row.Title,
+		Content:     row.Content,
+		Markdown:    row.Markdown,
+		PublishedAt: row.PublishedAt,
+		CreatedBy:   &row.CreatedBy,
+		CreatedAt:   &row.CreatedAt,
+	}
+}
+
+// SerializeTopicRevisions converts ent.TopicRevision list to structs.ReadTopicRevision list.
+func SerializeTopicRevisions(rows []*ent.TopicRevision) []*structs.ReadTopicRevision {
+	result := make([]*structs.ReadTopicRevision, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, SerializeTopicRevision(row))
+	}
+	return result
+}
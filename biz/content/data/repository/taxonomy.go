@@ -7,6 +7,8 @@ import (
 	"ncobase/biz/content/data/ent"
 	taxonomyEnt "ncobase/biz/content/data/ent/taxonomy"
 	"ncobase/biz/content/structs"
+	"ncobase/internal/utils"
+	"strconv"
 
 	"github.com/ncobase/ncore/data/cache"
 	"github.com/ncobase/ncore/data/paging"
@@ -32,6 +34,9 @@ type TaxonomyRepositoryInterface interface {
 	Delete(ctx context.Context, slug string) error
 	FindTaxonomy(ctx context.Context, params *structs.FindTaxonomy) (*ent.Taxonomy, error)
 	CountX(ctx context.Context, params *structs.ListTaxonomyParams) int
+	GetBySlugs(ctx context.Context, slugs []string) ([]*ent.Taxonomy, error)
+	Import(ctx context.Context, body *structs.ImportTaxonomyBody) (*structs.ImportTaxonomyResult, error)
+	Search(ctx context.Context, query, spaceID string, limit int) ([]*ent.Taxonomy, error)
 }
 
 // taxonomyRepository implements the TaxonomyRepositoryInterface.
@@ -75,6 +80,7 @@ func (r *taxonomyRepository) Create(ctx context.Context, body *structs.CreateTax
 	builder.SetNillableKeywords(&body.Keywords)
 	builder.SetNillableDescription(&body.Description)
 	builder.SetStatus(body.Status)
+	builder.SetOrder(body.Order)
 	builder.SetNillableParentID(body.ParentID)
 	builder.SetNillableCreatedBy(body.CreatedBy)
 
@@ -215,6 +221,8 @@ func (r *taxonomyRepository) Update(ctx context.Context, slug string, updates ty
 			builder.SetNillableDescription(convert.ToPointer(value.(string)))
 		case "status":
 			builder.SetStatus(int(value.(float64)))
+		case "order":
+			builder.SetOrder(int(value.(float64)))
 		case "extras":
 			builder.SetExtras(value.(types.JSON))
 		case "parent_id":
@@ -388,6 +396,162 @@ func (r *taxonomyRepository) Delete(ctx context.Context, slug string) error {
 	return err
 }
 
+// GetBySlugs get taxonomies by slugs
+func (r *taxonomyRepository) GetBySlugs(ctx context.Context, slugs []string) ([]*ent.Taxonomy, error) {
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+	return r.ecr.Taxonomy.Query().Where(taxonomyEnt.SlugIn(slugs...)).All(ctx)
+}
+
+// Import creates a taxonomy tree in a single transaction.
+// In upsert mode taxonomies that already exist by slug are updated and moved into place.
+func (r *taxonomyRepository) Import(ctx context.Context, body *structs.ImportTaxonomyBody) (*structs.ImportTaxonomyResult, error) {
+	result := &structs.ImportTaxonomyResult{Items: make([]*structs.ImportedTaxonomy, 0)}
+	var rows []*ent.Taxonomy
+	err := r.data.WithEntTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+		var err error
+		rows, err = r.importTree(ctx, tx, body, result)
+		return err
+	})
+	if err != nil {
+		logger.Errorf(ctx, "taxonomyRepo.Import error: %v", err)
+		return nil, err
+	}
+
+	// refresh cache and index after commit
+	for _, row := range rows {
+		if err := r.c.Delete(ctx, row.ID); err != nil {
+			logger.Errorf(ctx, "taxonomyRepo.Import cache error: %v", err)
+		}
+		if err := r.c.Delete(ctx, row.Slug); err != nil {
+			logger.Errorf(ctx, "taxonomyRepo.Import cache error: %v", err)
+		}
+		if r.sc != nil {
+			if err := r.sc.Index(ctx, &search.IndexRequest{Index: "taxonomies", Document: row, DocumentID: row.ID}); err != nil {
+				logger.Errorf(ctx, "taxonomyRepo.Import error indexing Meilisearch: %v", err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// importTree writes the nodes of an import in tx, parents before children, recording each in result
+func (r *taxonomyRepository) importTree(ctx context.Context, tx *ent.Tx, body *structs.ImportTaxonomyBody, result *structs.ImportTaxonomyResult) ([]*ent.Taxonomy, error) {
+	var rows []*ent.Taxonomy
+	var walk func(nodes []*structs.ImportTaxonomyNode, parentID *string, prefix string) error
+	walk = func(nodes []*structs.ImportTaxonomyNode, parentID *string, prefix string) error {
+		for i, node := range nodes {
+			position := strconv.Itoa(i)
+			if prefix != "" {
+				position = prefix + "." + position
+			}
+
+			// nested nodes take their parent from the tree, siblings keep input order
+			if parentID != nil {
+				node.ParentID = parentID
+			}
+			if node.Order == 0 {
+				node.Order = i
+			}
+			if node.SpaceID == "" {
+				node.SpaceID = body.SpaceID
+			}
+
+			row, created, err := r.importNode(ctx, tx, &node.TaxonomyBody, body.Upsert)
+			if err != nil {
+				return fmt.Errorf("taxonomy %s at %s: %w", node.Slug, position, err)
+			}
+			rows = append(rows, row)
+
+			if created {
+				result.Created++
+			} else {
+				result.Updated++
+			}
+			result.Items = append(result.Items, &structs.ImportedTaxonomy{
+				Position: position,
+				ID:       row.ID,
+				Slug:     row.Slug,
+				Created:  created,
+			})
+
+			if err := walk(node.Children, &row.ID, position); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return rows, walk(body.Items, nil, "")
+}
+
+// importNode creates a taxonomy, or updates the one with the same slug in upsert mode.
+// Slugs are unique across spaces, a taxonomy of another space is never updated.
+func (r *taxonomyRepository) importNode(ctx context.Context, tx *ent.Tx, body *structs.TaxonomyBody, upsert bool) (*ent.Taxonomy, bool, error) {
+	existing, err := tx.Taxonomy.Query().Where(taxonomyEnt.SlugEQ(body.Slug)).Only(ctx)
+	if err != nil && !IsNotFound(err) {
+		return nil, false, err
+	}
+
+	if existing == nil {
+		builder := tx.Taxonomy.Create()
+		builder.SetNillableName(&body.Name)
+		builder.SetNillableType(&body.Type)
+		builder.SetNillableSlug(&body.Slug)
+		builder.SetNillableCover(&body.Cover)
+		builder.SetNillableThumbnail(&body.Thumbnail)
+		builder.SetNillableColor(&body.Color)
+		builder.SetNillableIcon(&body.Icon)
+		builder.SetNillableURL(&body.URL)
+		builder.SetNillableKeywords(&body.Keywords)
+		builder.SetNillableDescription(&body.Description)
+		builder.SetStatus(body.Status)
+		builder.SetOrder(body.Order)
+		builder.SetNillableParentID(body.ParentID)
+		builder.SetSpaceID(body.SpaceID)
+		builder.SetNillableCreatedBy(body.CreatedBy)
+		if !validator.IsNil(body.Extras) && !validator.IsEmpty(body.Extras) {
+			builder.SetExtras(*body.Extras)
+		}
+
+		row, err := builder.Save(ctx)
+		return row, true, err
+	}
+
+	if !upsert {
+		return nil, false, fmt.Errorf("slug %s already exists: %w", body.Slug, ErrTaxonomySlugTaken)
+	}
+	if existing.SpaceID != body.SpaceID {
+		return nil, false, fmt.Errorf("slug %s belongs to another space: %w", body.Slug, ErrTaxonomySlugTaken)
+	}
+
+	builder := tx.Taxonomy.UpdateOne(existing)
+	builder.SetName(body.Name)
+	builder.SetType(body.Type)
+	builder.SetCover(body.Cover)
+	builder.SetThumbnail(body.Thumbnail)
+	builder.SetColor(body.Color)
+	builder.SetIcon(body.Icon)
+	builder.SetURL(body.URL)
+	builder.SetKeywords(body.Keywords)
+	builder.SetDescription(body.Description)
+	builder.SetStatus(body.Status)
+	builder.SetOrder(body.Order)
+	if body.ParentID != nil {
+		builder.SetParentID(*body.ParentID)
+	} else {
+		builder.ClearParentID()
+	}
+	builder.SetNillableUpdatedBy(body.UpdatedBy)
+	if !validator.IsNil(body.Extras) && !validator.IsEmpty(body.Extras) {
+		builder.SetExtras(*body.Extras)
+	}
+
+	row, err := builder.Save(ctx)
+	return row, false, err
+}
+
 // FindTaxonomy find taxonomy
 func (r *taxonomyRepository) FindTaxonomy(ctx context.Context, params *structs.FindTaxonomy) (*ent.Taxonomy, error) {
 	// create builder.
@@ -407,7 +571,7 @@ func (r *taxonomyRepository) FindTaxonomy(ctx context.Context, params *structs.F
 	// execute the builder.
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
@@ -447,7 +611,7 @@ func (r *taxonomyRepository) List(ctx context.Context, params *structs.ListTaxon
 		if err != nil {
 			return nil, fmt.Errorf("decoding cursor: %w", err)
 		}
-		builder = applyCursorCondition(builder, id, value, params.Direction, params.SortBy)
+		builder = applyCursorCondition(ctx, builder, id, value, params.Direction, params.SortBy)
 	}
 
 	builder.Limit(params.Limit)
@@ -479,7 +643,7 @@ func (r *taxonomyRepository) ListWithCount(ctx context.Context, params *structs.
 		if err != nil {
 			return nil, 0, fmt.Errorf("decoding cursor: %w", err)
 		}
-		builder = applyCursorCondition(builder, id, value, params.Direction, params.SortBy)
+		builder = applyCursorCondition(ctx, builder, id, value, params.Direction, params.SortBy)
 	}
 
 	total, err := builder.Count(ctx)
@@ -506,12 +670,12 @@ func applySorting(builder *ent.TaxonomyQuery, sortBy string) *ent.TaxonomyQuery
 }
 
 // applyCursorCondition applies the cursor-based condition to the query builder.
-func applyCursorCondition(builder *ent.TaxonomyQuery, id string, value any, direction string, sortBy string) *ent.TaxonomyQuery {
+func applyCursorCondition(ctx context.Context, builder *ent.TaxonomyQuery, id string, value any, direction string, sortBy string) *ent.TaxonomyQuery {
 	switch sortBy {
 	case structs.SortByCreatedAt:
 		timestamp, ok := value.(int64)
 		if !ok {
-			logger.Errorf(context.Background(), "Invalid timestamp value for cursor")
+			logger.Errorf(ctx, "Invalid timestamp value for cursor")
 			return builder
 		}
 		if direction == "backward" {
@@ -535,7 +699,7 @@ func applyCursorCondition(builder *ent.TaxonomyQuery, id string, value any, dire
 			),
 		)
 	default:
-		return applyCursorCondition(builder, id, value, direction, structs.SortByCreatedAt)
+		return applyCursorCondition(ctx, builder, id, value, direction, structs.SortByCreatedAt)
 	}
 }
 
@@ -562,3 +726,38 @@ func (r *taxonomyRepository) executeArrayQuery(ctx context.Context, builder *ent
 	}
 	return rows, nil
 }
+
+// Search finds the taxonomies of a space, best match first.
+// The search index is used when available, otherwise the database.
+func (r *taxonomyRepository) Search(ctx context.Context, query, spaceID string, limit int) ([]*ent.Taxonomy, error) {
+	hitIDs, err := utils.SearchIDs(ctx, r.sc, "taxonomies", query, map[string]any{"space_id": spaceID}, limit)
+	if err == nil {
+		rows, err := r.ecr.Taxonomy.Query().
+			Where(taxonomyEnt.IDIn(hitIDs...), taxonomyEnt.SpaceIDEQ(spaceID)).
+			All(ctx)
+		if err != nil {
+			logger.Errorf(ctx, "taxonomyRepo.Search error: %v", err)
+			return nil, err
+		}
+		return utils.OrderByIDs(rows, hitIDs, func(row *ent.Taxonomy) string { return row.ID }), nil
+	}
+	logger.Debugf(ctx, "taxonomyRepo.Search index unavailable, using database: %v", err)
+
+	rows, err := r.ecr.Taxonomy.Query().
+		Where(
+			taxonomyEnt.SpaceIDEQ(spaceID),
+			taxonomyEnt.Or(
+				taxonomyEnt.NameContainsFold(query),
+				taxonomyEnt.SlugContainsFold(query),
+				taxonomyEnt.KeywordsContainsFold(query),
+			),
+		).
+		Order(ent.Desc(taxonomyEnt.FieldUpdatedAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "taxonomyRepo.Search error: %v", err)
+		return nil, err
+	}
+	return rows, nil
+}
@@ -300,7 +300,7 @@ func (r *taxonomyRelationsRepository) FindTaxonomyRelation(ctx context.Context,
 	// execute the builder.
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
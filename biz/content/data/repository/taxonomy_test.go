@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ncobase/biz/content/data/ent"
+	"ncobase/biz/content/structs"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestClient(t *testing.T) *ent.Client {
+	t.Helper()
+	client, err := ent.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return client
+}
+
+// runImport writes an import tree in one transaction
+func runImport(t *testing.T, client *ent.Client, body *structs.ImportTaxonomyBody) (*structs.ImportTaxonomyResult, error) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	result := &structs.ImportTaxonomyResult{}
+	if _, err := (&taxonomyRepository{}).importTree(ctx, tx, body, result); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	return result, nil
+}
+
+func node(name string, children ...*structs.ImportTaxonomyNode) *structs.ImportTaxonomyNode {
+	return &structs.ImportTaxonomyNode{
+		TaxonomyBody: structs.TaxonomyBody{Name: name, Type: "node", Slug: name},
+		Children:     children,
+	}
+}
+
+func threeLevelTree() []*structs.ImportTaxonomyNode {
+	return []*structs.ImportTaxonomyNode{
+		node("news", node("world", node("europe"), node("asia")), node("sports")),
+	}
+}
+
+func TestImportTreeThreeLevels(t *testing.T) {
+	client := openTestClient(t)
+
+	result, err := runImport(t, client, &structs.ImportTaxonomyBody{SpaceID: "s1", Items: threeLevelTree()})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if result.Created != 5 || result.Updated != 0 {
+		t.Fatalf("created %d, updated %d, want 5 and 0", result.Created, result.Updated)
+	}
+
+	ids := map[string]string{}
+	positions := map[string]string{}
+	for _, item := range result.Items {
+		ids[item.Slug] = item.ID
+		positions[item.Slug] = item.Position
+	}
+	if positions["asia"] != "0.0.1" || positions["sports"] != "0.1" {
+		t.Fatalf("positions = %v", positions)
+	}
+
+	parents := map[string]string{"news": "", "world": "news", "sports": "news", "europe": "world", "asia": "world"}
+	for slug, parent := range parents {
+		row, err := client.Taxonomy.Get(context.Background(), ids[slug])
+		if err != nil {
+			t.Fatalf("get %s: %v", slug, err)
+		}
+		if row.ParentID != ids[parent] {
+			t.Errorf("%s parent = %q, want %s", slug, row.ParentID, parent)
+		}
+		if row.SpaceID != "s1" {
+			t.Errorf("%s space = %q, want s1", slug, row.SpaceID)
+		}
+	}
+}
+
+func TestImportTreeUpsert(t *testing.T) {
+	client := openTestClient(t)
+	if _, err := runImport(t, client, &structs.ImportTaxonomyBody{SpaceID: "s1", Items: threeLevelTree()}); err != nil {
+		t.Fatalf("first import: %v", err)
+	}
+
+	if _, err := runImport(t, client, &structs.ImportTaxonomyBody{SpaceID: "s1", Items: threeLevelTree()}); !errors.Is(err, ErrTaxonomySlugTaken) {
+		t.Fatalf("import again without upsert = %v, want ErrTaxonomySlugTaken", err)
+	}
+
+	// asia moves from world to a new root, world is renamed
+	world := node("world", node("europe"))
+	world.Name = "World news"
+	items := []*structs.ImportTaxonomyNode{node("news", world), node("regions", node("asia"))}
+	result, err := runImport(t, client, &structs.ImportTaxonomyBody{SpaceID: "s1", Upsert: true, Items: items})
+	if err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if result.Created != 1 || result.Updated != 4 {
+		t.Fatalf("created %d, updated %d, want 1 and 4", result.Created, result.Updated)
+	}
+
+	ids := map[string]string{}
+	for _, item := range result.Items {
+		ids[item.Slug] = item.ID
+	}
+	asia, err := client.Taxonomy.Get(context.Background(), ids["asia"])
+	if err != nil {
+		t.Fatalf("get asia: %v", err)
+	}
+	if asia.ParentID != ids["regions"] {
+		t.Fatalf("asia parent = %q, want regions %s", asia.ParentID, ids["regions"])
+	}
+	renamed, err := client.Taxonomy.Get(context.Background(), ids["world"])
+	if err != nil {
+		t.Fatalf("get world: %v", err)
+	}
+	if renamed.Name != "World news" {
+		t.Fatalf("world name = %q", renamed.Name)
+	}
+}
+
+func TestImportTreeUpsertKeepsOtherSpaces(t *testing.T) {
+	client := openTestClient(t)
+	if _, err := runImport(t, client, &structs.ImportTaxonomyBody{SpaceID: "s1", Items: []*structs.ImportTaxonomyNode{node("news")}}); err != nil {
+		t.Fatalf("first import: %v", err)
+	}
+
+	_, err := runImport(t, client, &structs.ImportTaxonomyBody{SpaceID: "s2", Upsert: true, Items: []*structs.ImportTaxonomyNode{node("news")}})
+	if !errors.Is(err, ErrTaxonomySlugTaken) {
+		t.Fatalf("upsert into another space = %v, want ErrTaxonomySlugTaken", err)
+	}
+
+	rows, err := client.Taxonomy.Query().All(context.Background())
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(rows) != 1 || rows[0].SpaceID != "s1" {
+		t.Fatalf("taxonomies after rejected upsert = %+v", rows)
+	}
+}
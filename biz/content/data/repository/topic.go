@@ -7,7 +7,9 @@ import (
 	"ncobase/biz/content/data/ent"
 	topicEnt "ncobase/biz/content/data/ent/topic"
 	"ncobase/biz/content/structs"
+	"ncobase/internal/utils"
 
+	"github.com/ncobase/ncore/ctxutil"
 	"github.com/ncobase/ncore/data/cache"
 	"github.com/ncobase/ncore/data/paging"
 	"github.com/ncobase/ncore/logging/logger"
@@ -32,8 +34,13 @@ type TopicRepositoryInterface interface {
 	FindTopic(ctx context.Context, params *structs.FindTopic) (*ent.Topic, error)
 	ListBuilder(ctx context.Context, params *structs.ListTopicParams) (*ent.TopicQuery, error)
 	CountX(ctx context.Context, params *structs.ListTopicParams) int
+	AvailableSlug(ctx context.Context, spaceID, base, excludeID string) (string, error)
+	Search(ctx context.Context, query, spaceID string, limit int) ([]*ent.Topic, error)
 }
 
+// maxSlugAttempts bounds how often a create retries after losing a slug race
+const maxSlugAttempts = 5
+
 // topicRepository implements the TopicRepositoryInterface.
 type topicRepository struct {
 	data *data.Data
@@ -59,14 +66,51 @@ func NewTopicRepository(d *data.Data) TopicRepositoryInterface {
 }
 
 // Create creates a new topic.
+// The slug is suffixed (my-post-2) when taken in the space; a concurrent create
+// winning the same slug fails on the unique index and is retried with the next free one.
 func (r *topicRepository) Create(ctx context.Context, body *structs.CreateTopicBody) (*ent.Topic, error) {
+	slug, err := r.AvailableSlug(ctx, body.SpaceID, body.Slug, "")
+	if err != nil {
+		logger.Errorf(ctx, "topicRepo.Create slug error: %v", err)
+		return nil, err
+	}
+
+	var row *ent.Topic
+	for attempt := 1; ; attempt++ {
+		row, err = r.createBuilder(body, slug).Save(ctx)
+		if err == nil || !ent.IsConstraintError(err) || attempt >= maxSlugAttempts {
+			break
+		}
+
+		logger.Debugf(ctx, "topicRepo.Create slug %s taken concurrently, retrying", slug)
+		if slug, err = r.AvailableSlug(ctx, body.SpaceID, body.Slug, ""); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		logger.Errorf(ctx, "topicRepo.Create error: %v", err)
+		return nil, err
+	}
 
+	// Create the topic in Meilisearch index
+	if r.sc != nil {
+		if err = r.sc.Index(ctx, &search.IndexRequest{Index: "topics", Document: row}); err != nil {
+			logger.Errorf(ctx, "topicRepo.Create error creating Meilisearch index: %v", err)
+			// return nil, err
+		}
+	}
+
+	return row, nil
+}
+
+// createBuilder creates the builder of a topic with the given slug
+func (r *topicRepository) createBuilder(body *structs.CreateTopicBody, slug string) *ent.TopicCreate {
 	// create builder.
 	builder := r.ec.Topic.Create()
 	// set values.
 	builder.SetNillableName(&body.Name)
 	builder.SetNillableTitle(&body.Title)
-	builder.SetNillableSlug(&body.Slug)
+	builder.SetSlug(slug)
 	builder.SetNillableContent(&body.Content)
 	builder.SetNillableThumbnail(&body.Thumbnail)
 	builder.SetTemp(body.Temp)
@@ -78,22 +122,38 @@ func (r *topicRepository) Create(ctx context.Context, body *structs.CreateTopicB
 	builder.SetNillableSpaceID(&body.SpaceID)
 	builder.SetNillableCreatedBy(body.CreatedBy)
 
-	// execute the builder.
-	row, err := builder.Save(ctx)
+	return builder
+}
+
+// AvailableSlug returns base when it is free in the space, otherwise base with the lowest free numeric suffix.
+// excludeID ignores the topic being edited.
+func (r *topicRepository) AvailableSlug(ctx context.Context, spaceID, base, excludeID string) (string, error) {
+	rows, err := r.ec.Topic.Query().
+		Where(
+			topicEnt.SpaceIDEQ(spaceID),
+			topicEnt.Or(topicEnt.SlugEQ(base), topicEnt.SlugHasPrefix(base+"-")),
+		).
+		Select(topicEnt.FieldID, topicEnt.FieldSlug).
+		All(ctx)
 	if err != nil {
-		logger.Errorf(ctx, "topicRepo.Create error: %v", err)
-		return nil, err
+		return "", err
 	}
 
-	// Create the topic in Meilisearch index
-	if r.sc != nil {
-		if err = r.sc.Index(ctx, &search.IndexRequest{Index: "topics", Document: row}); err != nil {
-			logger.Errorf(ctx, "topicRepo.Create error creating Meilisearch index: %v", err)
-			// return nil, err
+	taken := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if row.ID != excludeID {
+			taken[row.Slug] = true
 		}
 	}
 
-	return row, nil
+	if !taken[base] {
+		return base, nil
+	}
+	for n := 2; ; n++ {
+		if candidate := fmt.Sprintf("%s-%d", base, n); !taken[candidate] {
+			return candidate, nil
+		}
+	}
 }
 
 // GetByID gets a topic by ID.
@@ -135,7 +195,7 @@ func (r *topicRepository) GetBySlug(ctx context.Context, slug string) (*ent.Topi
 	// 	}
 	// }
 	// check cache
-	cacheKey := fmt.Sprintf("slug:%s", slug)
+	cacheKey := slugCacheKey(ctxutil.GetSpaceID(ctx), slug)
 	if cached, err := r.c.Get(ctx, cacheKey); err == nil && cached != nil {
 		return cached, nil
 	}
@@ -206,7 +266,7 @@ func (r *topicRepository) Update(ctx context.Context, slug string, updates types
 	// remove from cache
 	cacheKey := fmt.Sprintf("%s", topic.ID)
 	err = r.c.Delete(ctx, cacheKey)
-	err = r.c.Delete(ctx, topic.Slug)
+	err = r.c.Delete(ctx, slugCacheKey(topic.SpaceID, topic.Slug))
 	if err != nil {
 		logger.Errorf(ctx, "topicRepo.Update cache error: %v", err)
 	}
@@ -310,7 +370,7 @@ func (r *topicRepository) Delete(ctx context.Context, slug string) error {
 	// remove from cache
 	cacheKey := fmt.Sprintf("%s", topic.ID)
 	err = r.c.Delete(ctx, cacheKey)
-	err = r.c.Delete(ctx, fmt.Sprintf("topic:slug:%s", topic.Slug))
+	err = r.c.Delete(ctx, slugCacheKey(topic.SpaceID, topic.Slug))
 	if err != nil {
 		logger.Errorf(ctx, "topicRepo.Delete cache error: %v", err)
 	}
@@ -333,10 +393,12 @@ func (r *topicRepository) FindTopic(ctx context.Context, params *structs.FindTop
 	builder := r.ecr.Topic.Query()
 
 	if validator.IsNotEmpty(params.Topic) {
-		builder = builder.Where(topicEnt.Or(
-			topicEnt.ID(params.Topic),
-			topicEnt.SlugEQ(params.Topic),
-		))
+		// slugs are only unique within a space, match them in the current space
+		bySlug := topicEnt.SlugEQ(params.Topic)
+		if spaceID := ctxutil.GetSpaceID(ctx); spaceID != "" {
+			bySlug = topicEnt.And(bySlug, topicEnt.SpaceIDEQ(spaceID))
+		}
+		builder = builder.Where(topicEnt.Or(topicEnt.ID(params.Topic), bySlug))
 	}
 	if validator.IsNotEmpty(params.SpaceID) {
 		builder = builder.Where(topicEnt.SpaceIDEQ(params.SpaceID))
@@ -345,12 +407,17 @@ func (r *topicRepository) FindTopic(ctx context.Context, params *structs.FindTop
 	// execute the builder.
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
 }
 
+// slugCacheKey returns the cache key of a topic slug in a space
+func slugCacheKey(spaceID, slug string) string {
+	return fmt.Sprintf("slug:%s:%s", spaceID, slug)
+}
+
 // ListBuilder creates list builder.
 func (r *topicRepository) ListBuilder(_ context.Context, _ *structs.ListTopicParams) (*ent.TopicQuery, error) {
 	// create builder.
@@ -378,3 +445,38 @@ func (r *topicRepository) Count(ctx context.Context, params *structs.ListTopicPa
 	}
 	return builder.Count(ctx)
 }
+
+// Search finds the topics of a space, best match first.
+// The search index is used when available, otherwise the database.
+func (r *topicRepository) Search(ctx context.Context, query, spaceID string, limit int) ([]*ent.Topic, error) {
+	hitIDs, err := utils.SearchIDs(ctx, r.sc, "topics", query, map[string]any{"space_id": spaceID}, limit)
+	if err == nil {
+		rows, err := r.ecr.Topic.Query().
+			Where(topicEnt.IDIn(hitIDs...), topicEnt.SpaceIDEQ(spaceID)).
+			All(ctx)
+		if err != nil {
+			logger.Errorf(ctx, "topicRepo.Search error: %v", err)
+			return nil, err
+		}
+		return utils.OrderByIDs(rows, hitIDs, func(row *ent.Topic) string { return row.ID }), nil
+	}
+	logger.Debugf(ctx, "topicRepo.Search index unavailable, using database: %v", err)
+
+	rows, err := r.ecr.Topic.Query().
+		Where(
+			topicEnt.SpaceIDEQ(spaceID),
+			topicEnt.Or(
+				topicEnt.TitleContainsFold(query),
+				topicEnt.NameContainsFold(query),
+				topicEnt.SeoKeywordsContainsFold(query),
+			),
+		).
+		Order(ent.Desc(topicEnt.FieldUpdatedAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "topicRepo.Search error: %v", err)
+		return nil, err
+	}
+	return rows, nil
+}
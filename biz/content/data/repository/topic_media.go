@@ -430,7 +430,7 @@ func (r *topicMediaRepository) FindTopicMedia(ctx context.Context, params *struc
 	// execute the builder
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
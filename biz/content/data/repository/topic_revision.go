@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"ncobase/biz/content/data"
+	"ncobase/biz/content/data/ent"
+	topicRevisionEnt "ncobase/biz/content/data/ent/topicrevision"
+	"ncobase/biz/content/structs"
+	"time"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/validation/validator"
+)
+
+// TopicRevisionRepositoryInterface represents the topic revision repository interface.
+type TopicRevisionRepositoryInterface interface {
+	Create(ctx context.Context, topicID, authorID string, body *structs.AutosaveTopicBody) (*ent.TopicRevision, error)
+	GetByID(ctx context.Context, id string) (*ent.TopicRevision, error)
+	Latest(ctx context.Context, topicID, authorID string) (*ent.TopicRevision, error)
+	List(ctx context.Context, params *structs.ListTopicRevisionParams) ([]*ent.TopicRevision, error)
+	MarkPublished(ctx context.Context, id string) (*ent.TopicRevision, error)
+	Prune(ctx context.Context, topicID string, keep int) (int, error)
+}
+
+// topicRevisionRepository implements the TopicRevisionRepositoryInterface.
+type topicRevisionRepository struct {
+	ec  *ent.Client
+	ecr *ent.Client
+}
+
+// NewTopicRevisionRepository creates a new topic revision repository.
+func NewTopicRevisionRepository(d *data.Data) TopicRevisionRepositoryInterface {
+	return &topicRevisionRepository{
+		ec:  d.GetMasterEntClient(),
+		ecr: d.GetSlaveEntClient(),
+	}
+}
+
+// Create stores a revision of a topic for an author.
+func (r *topicRevisionRepository) Create(ctx context.Context, topicID, authorID string, body *structs.AutosaveTopicBody) (*ent.TopicRevision, error) {
+	row, err := r.ec.TopicRevision.Create().
+		SetTopicID(topicID).
+		SetAuthorID(authorID).
+		SetTitle(body.Title).
+		SetContent(body.Content).
+		SetMarkdown(body.Markdown).
+		SetCreatedBy(authorID).
+		Save(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "topicRevisionRepo.Create error: %v", err)
+		return nil, err
+	}
+	return row, nil
+}
+
+// GetByID gets a revision by ID.
+func (r *topicRevisionRepository) GetByID(ctx context.Context, id string) (*ent.TopicRevision, error) {
+	row, err := r.ec.TopicRevision.Get(ctx, id)
+	return row, mapError(err)
+}
+
+// Latest gets the newest revision of a topic by an author.
+func (r *topicRevisionRepository) Latest(ctx context.Context, topicID, authorID string) (*ent.TopicRevision, error) {
+	return r.ec.TopicRevision.Query().
+		Where(
+			topicRevisionEnt.TopicIDEQ(topicID),
+			topicRevisionEnt.AuthorIDEQ(authorID),
+		).
+		Order(ent.Desc(topicRevisionEnt.FieldCreatedAt), ent.Desc(topicRevisionEnt.FieldID)).
+		First(ctx)
+}
+
+// List lists the revisions of a topic, newest first.
+func (r *topicRevisionRepository) List(ctx context.Context, params *structs.ListTopicRevisionParams) ([]*ent.TopicRevision, error) {
+	builder := r.ecr.TopicRevision.Query().
+		Where(topicRevisionEnt.TopicIDEQ(params.TopicID))
+
+	if validator.IsNotEmpty(params.AuthorID) {
+		builder.Where(topicRevisionEnt.AuthorIDEQ(params.AuthorID))
+	}
+	if params.Limit > 0 {
+		builder.Limit(params.Limit)
+	}
+
+	return builder.
+		Order(ent.Desc(topicRevisionEnt.FieldCreatedAt), ent.Desc(topicRevisionEnt.FieldID)).
+		All(ctx)
+}
+
+// MarkPublished records that a revision was published.
+func (r *topicRevisionRepository) MarkPublished(ctx context.Context, id string) (*ent.TopicRevision, error) {
+	return r.ec.TopicRevision.UpdateOneID(id).
+		SetPublishedAt(time.Now().UnixMilli()).
+		Save(ctx)
+}
+
+// Prune deletes all but the newest keep revisions of a topic and returns how many were deleted.
+func (r *topicRevisionRepository) Prune(ctx context.Context, topicID string, keep int) (int, error) {
+	ids, err := r.ec.TopicRevision.Query().
+		Where(topicRevisionEnt.TopicIDEQ(topicID)).
+		Order(ent.Desc(topicRevisionEnt.FieldCreatedAt), ent.Desc(topicRevisionEnt.FieldID)).
+		Offset(keep).
+		IDs(ctx)
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	n, err := r.ec.TopicRevision.Delete().
+		Where(topicRevisionEnt.IDIn(ids...)).
+		Exec(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "topicRevisionRepo.Prune error: %v", err)
+		return 0, err
+	}
+	return n, nil
+}
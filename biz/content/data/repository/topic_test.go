@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"ncobase/biz/content/data/ent"
+	"ncobase/biz/content/data/ent/hook"
+	"ncobase/biz/content/structs"
+)
+
+func createTopic(t *testing.T, r *topicRepository, spaceID, slug string) *ent.Topic {
+	t.Helper()
+	row, err := r.Create(context.Background(), &structs.CreateTopicBody{
+		TopicBody: structs.TopicBody{Name: slug, Title: slug, Slug: slug, SpaceID: spaceID},
+	})
+	if err != nil {
+		t.Fatalf("create %s in %s: %v", slug, spaceID, err)
+	}
+	return row
+}
+
+func TestTopicCreateSuffixesSlugPerSpace(t *testing.T) {
+	r := &topicRepository{ec: openTestClient(t)}
+
+	var first *ent.Topic
+	for _, want := range []string{"my-post", "my-post-2", "my-post-3"} {
+		row := createTopic(t, r, "s1", "my-post")
+		if row.Slug != want {
+			t.Fatalf("slug = %q, want %q", row.Slug, want)
+		}
+		if first == nil {
+			first = row
+		}
+	}
+	if row := createTopic(t, r, "s2", "my-post"); row.Slug != "my-post" {
+		t.Fatalf("slug in another space = %q, want my-post", row.Slug)
+	}
+
+	// the topic being edited keeps its own slug
+	if slug, err := r.AvailableSlug(context.Background(), "s1", "my-post", first.ID); err != nil || slug != "my-post" {
+		t.Fatalf("slug of the edited topic = %q, %v, want my-post", slug, err)
+	}
+	if _, err := r.ec.Topic.Create().SetName("copy").SetSlug("my-post").SetSpaceID("s1").Save(context.Background()); !ent.IsConstraintError(err) {
+		t.Fatalf("duplicate slug in a space = %v, want a constraint error", err)
+	}
+}
+
+func TestTopicCreateRetriesAfterLosingSlugRace(t *testing.T) {
+	client := openTestClient(t)
+	r := &topicRepository{ec: client}
+
+	// a concurrent create takes the slug between the lookup and the insert
+	raced := false
+	client.Topic.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.TopicFunc(func(ctx context.Context, m *ent.TopicMutation) (ent.Value, error) {
+			if slug, _ := m.Slug(); !raced && slug == "news" {
+				raced = true
+				if _, err := client.Topic.Create().SetName("winner").SetSlug("news").SetSpaceID("s1").Save(ctx); err != nil {
+					return nil, err
+				}
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+
+	row := createTopic(t, r, "s1", "news")
+	if !raced {
+		t.Fatal("race not simulated")
+	}
+	if row.Slug != "news-2" {
+		t.Fatalf("slug after losing the race = %q, want news-2", row.Slug)
+	}
+	if n := client.Topic.Query().CountX(context.Background()); n != 2 {
+		t.Fatalf("%d topics, want the winner and the retried create", n)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -32,7 +33,7 @@ func (CMSChannel) Annotations() []schema.Annotation {
 // Mixin of the CMSChannel.
 func (CMSChannel) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.Type,
 		mixin.SlugUnique,
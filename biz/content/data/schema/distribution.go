@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -33,7 +34,7 @@ func (Distribution) Annotations() []schema.Annotation {
 // Mixin of the Distribution.
 func (Distribution) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.ExtraProps,
 		mixin.SpaceID,
 		mixin.OperatorBy{},
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -32,7 +33,7 @@ func (Media) Annotations() []schema.Annotation {
 // Mixin for Media
 func (Media) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Title,
 		mixin.Type,
 		mixin.URL,
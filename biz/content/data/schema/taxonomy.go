@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent/dialect/entsql"
@@ -32,7 +33,7 @@ func (Taxonomy) Annotations() []schema.Annotation {
 // Mixin of the Taxonomy.
 func (Taxonomy) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.Type, // type, default 'node', options: 'node', 'plane', 'event', 'page', 'tag', 'link'
 		mixin.SlugUnique,
@@ -44,6 +45,7 @@ func (Taxonomy) Mixin() []ent.Mixin {
 		mixin.Keywords,
 		mixin.Description,
 		mixin.Status, // status, 0: enabled, 1: disabled, ...
+		mixin.Order,
 		mixin.ExtraProps,
 		mixin.ParentID,
 		mixin.SpaceID,
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -31,7 +32,7 @@ func (TaxonomyRelation) Annotations() []schema.Annotation {
 // Mixin of the TaxonomyRelation.
 func (TaxonomyRelation) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.ObjectID,
 		mixin.TaxonomyID,
 		mixin.Type, // type, topic, comment, other, ...
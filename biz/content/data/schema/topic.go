@@ -5,6 +5,7 @@ import (
 
 	"entgo.io/ent/schema/field"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent/dialect/entsql"
@@ -32,10 +33,10 @@ func (Topic) Annotations() []schema.Annotation {
 // Mixin of the Topic.
 func (Topic) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.Title,
-		mixin.SlugUnique,
+		mixin.Slug,
 		mixin.Content,
 		mixin.Thumbnail,
 		mixin.Temp,
@@ -93,5 +94,6 @@ func (Topic) Edges() []ent.Edge {
 func (Topic) Indexes() []ent.Index {
 	return []ent.Index{
 		index.Fields("id", "created_at").Unique(),
+		index.Fields("slug", "space_id").Unique(), // slugs are unique per space
 	}
 }
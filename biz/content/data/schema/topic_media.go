@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -33,7 +34,7 @@ func (TopicMedia) Annotations() []schema.Annotation {
 // Mixin of the TopicMedia.
 func (TopicMedia) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Type,
 		mixin.Order,
 		mixin.OperatorBy{},
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// TopicRevision holds the schema definition for the TopicRevision entity.
+type TopicRevision struct {
+	ent.Schema
+}
+
+// Annotations of the TopicRevision.
+func (TopicRevision) Annotations() []schema.Annotation {
+	table := strings.Join([]string{"ncse", "cms", "topic_revision"}, "_")
+	return []schema.Annotation{
+		entsql.Annotation{Table: table},
+		entsql.WithComments(true),
+	}
+}
+
+// Mixin of the TopicRevision.
+func (TopicRevision) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		idgen.PrimaryKey,
+		mixin.Title,
+		mixin.Content,
+		mixin.Markdown,
+		mixin.OperatorBy{},
+		mixin.TimeAt{},
+	}
+}
+
+// Fields of the TopicRevision.
+func (TopicRevision) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("topic_id").
+			NotEmpty().
+			Comment("Topic ID"),
+		field.String("author_id").
+			NotEmpty().
+			Comment("Author of the draft"),
+		field.Int64("published_at").
+			Optional().
+			Comment("When the revision was published to the topic"),
+	}
+}
+
+// Edges of the TopicRevision.
+func (TopicRevision) Edges() []ent.Edge {
+	return []ent.Edge{}
+}
+
+// Indexes of the TopicRevision.
+func (TopicRevision) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("id", "created_at").Unique(),
+		index.Fields("topic_id", "created_at"),
+		index.Fields("topic_id", "author_id", "created_at"),
+	}
+}
@@ -3,8 +3,8 @@ package handler
 import (
 	"ncobase/biz/content/service"
 	"ncobase/biz/content/structs"
-
-	"github.com/ncobase/ncore/validation"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
@@ -162,7 +162,7 @@ func (h *channelHandler) Delete(c *gin.Context) {
 // @Tags cms
 // @Produce json
 // @Param params query structs.ListChannelParams true "ListChannelParams object"
-// @Success 200 {array} structs.ReadChannel "success"
+// @Success 200 {object} page.Page[structs.ReadChannel] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /cms/channels [get]
 func (h *channelHandler) List(c *gin.Context) {
@@ -181,5 +181,5 @@ func (h *channelHandler) List(c *gin.Context) {
 		return
 	}
 
-	resp.Success(c.Writer, channels)
+	resp.Success(c.Writer, page.New(channels))
 }
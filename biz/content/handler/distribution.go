@@ -3,8 +3,8 @@ package handler
 import (
 	"ncobase/biz/content/service"
 	"ncobase/biz/content/structs"
-
-	"github.com/ncobase/ncore/validation"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
@@ -164,7 +164,7 @@ func (h *distributionHandler) Delete(c *gin.Context) {
 // @Tags cms
 // @Produce json
 // @Param params query structs.ListDistributionParams true "List distributions parameters"
-// @Success 200 {array} structs.ReadDistribution "success"
+// @Success 200 {object} page.Page[structs.ReadDistribution] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /cms/distributions [get]
 func (h *distributionHandler) List(c *gin.Context) {
@@ -183,7 +183,7 @@ func (h *distributionHandler) List(c *gin.Context) {
 		return
 	}
 
-	resp.Success(c.Writer, distributions)
+	resp.Success(c.Writer, page.New(distributions))
 }
 
 // Publish handles publishing a distribution.
@@ -3,8 +3,8 @@ package handler
 import (
 	"ncobase/biz/content/service"
 	"ncobase/biz/content/structs"
-
-	"github.com/ncobase/ncore/validation"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
@@ -161,7 +161,7 @@ func (h *mediaHandler) Delete(c *gin.Context) {
 // @Tags cms
 // @Produce json
 // @Param params query structs.ListMediaParams true "List media parameters"
-// @Success 200 {array} structs.ReadMedia "success"
+// @Success 200 {object} page.Page[structs.ReadMedia] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /cms/media [get]
 func (h *mediaHandler) List(c *gin.Context) {
@@ -180,5 +180,5 @@ func (h *mediaHandler) List(c *gin.Context) {
 		return
 	}
 
-	resp.Success(c.Writer, media)
+	resp.Success(c.Writer, page.New(media))
 }
@@ -12,6 +12,7 @@ type Handler struct {
 	Distribution DistributionHandlerInterface
 	Media        MediaHandlerInterface
 	TopicMedia   TopicMediaHandlerInterface
+	Revision     TopicRevisionHandlerInterface
 }
 
 // New creates a new handler.
@@ -23,5 +24,6 @@ func New(svc *service.Service) *Handler {
 		Distribution: NewDistributionHandler(svc),
 		Media:        NewMediaHandler(svc),
 		TopicMedia:   NewTopicMediaHandler(svc),
+		Revision:     NewTopicRevisionHandler(svc),
 	}
 }
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"ncobase/biz/content/service"
 	"ncobase/biz/content/structs"
-
-	"github.com/ncobase/ncore/validation"
+	"ncobase/internal/dberr"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
@@ -20,6 +22,7 @@ type TaxonomyHandlerInterface interface {
 	Get(c *gin.Context)
 	Delete(c *gin.Context)
 	List(c *gin.Context)
+	Import(c *gin.Context)
 }
 
 // taxonomyHandler represents the handler.
@@ -65,6 +68,45 @@ func (h *taxonomyHandler) Create(c *gin.Context) {
 	resp.Success(c.Writer, result)
 }
 
+// Import handles importing a taxonomy tree.
+//
+// @Summary Import taxonomies
+// @Description Create a nested taxonomy tree in one transaction, optionally updating existing taxonomies by slug.
+// @Tags cms
+// @Accept json
+// @Produce json
+// @Param body body structs.ImportTaxonomyBody true "ImportTaxonomyBody object"
+// @Success 200 {object} structs.ImportTaxonomyResult "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 404 {object} resp.Exception "parent not found"
+// @Router /cms/taxonomies/import [post]
+// @Security Bearer
+func (h *taxonomyHandler) Import(c *gin.Context) {
+	body := &structs.ImportTaxonomyBody{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	result, err := h.s.Taxonomy.Import(c.Request.Context(), body)
+	switch {
+	case errors.Is(err, service.ErrInvalidTaxonomyImport):
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	case dberr.IsNotFound(err):
+		resp.Fail(c.Writer, resp.NotFound(err.Error()))
+		return
+	case err != nil:
+		resp.Fail(c.Writer, resp.InternalServer(err.Error()))
+		return
+	}
+
+	resp.Success(c.Writer, result)
+}
+
 // Update handles updating a taxonomy.
 //
 // @Summary Update taxonomy
@@ -162,7 +204,7 @@ func (h *taxonomyHandler) Delete(c *gin.Context) {
 // @Tags cms
 // @Produce json
 // @Param params query structs.ListTaxonomyParams true "ListTaxonomyParams object"
-// @Success 200 {array} structs.ReadTaxonomy "success"
+// @Success 200 {object} page.Page[structs.ReadTaxonomy] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /cms/taxonomies [get]
 func (h *taxonomyHandler) List(c *gin.Context) {
@@ -181,5 +223,5 @@ func (h *taxonomyHandler) List(c *gin.Context) {
 		return
 	}
 
-	resp.Success(c.Writer, taxonomies)
+	resp.Success(c.Writer, page.New(taxonomies))
 }
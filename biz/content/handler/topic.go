@@ -3,8 +3,8 @@ package handler
 import (
 	"ncobase/biz/content/service"
 	"ncobase/biz/content/structs"
-
-	"github.com/ncobase/ncore/validation"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
@@ -20,6 +20,7 @@ type TopicHandlerInterface interface {
 	Get(c *gin.Context)
 	List(c *gin.Context)
 	Delete(c *gin.Context)
+	CheckSlug(c *gin.Context)
 }
 
 // topicHandler represents the handler.
@@ -65,6 +66,37 @@ func (h *topicHandler) Create(c *gin.Context) {
 	resp.Success(c.Writer, result)
 }
 
+// CheckSlug handles previewing a topic slug.
+//
+// @Summary Check topic slug
+// @Description Check whether a slug is free in the space and get the slug a create would use.
+// @Tags cms
+// @Accept json
+// @Produce json
+// @Param body body structs.TopicSlugCheckBody true "TopicSlugCheckBody object"
+// @Success 200 {object} structs.TopicSlugCheckResult "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /cms/topics/slug-check [post]
+// @Security Bearer
+func (h *topicHandler) CheckSlug(c *gin.Context) {
+	body := &structs.TopicSlugCheckBody{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	result, err := h.s.Topic.CheckSlug(c.Request.Context(), body)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	resp.Success(c.Writer, result)
+}
+
 // Update  handles updating a topic (full and partial).
 //
 // @Summary Update topic
@@ -162,7 +194,7 @@ func (h *topicHandler) Delete(c *gin.Context) {
 // @Tags cms
 // @Produce json
 // @Param params query structs.ListTopicParams true "List topics parameters"
-// @Success 200 {array} structs.ReadTopic "success"
+// @Success 200 {object} page.Page[structs.ReadTopic] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /cms/topics [get]
 func (h *topicHandler) List(c *gin.Context) {
@@ -181,5 +213,5 @@ func (h *topicHandler) List(c *gin.Context) {
 		return
 	}
 
-	resp.Success(c.Writer, topics)
+	resp.Success(c.Writer, page.New(topics))
 }
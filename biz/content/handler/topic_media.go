@@ -3,8 +3,8 @@ package handler
 import (
 	"ncobase/biz/content/service"
 	"ncobase/biz/content/structs"
-
-	"github.com/ncobase/ncore/validation"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
@@ -183,7 +183,7 @@ func (h *topicMediaHandler) Delete(c *gin.Context) {
 // @Tags cms
 // @Produce json
 // @Param params query structs.ListTopicMediaParams true "List topic media parameters"
-// @Success 200 {array} structs.ReadTopicMedia "success"
+// @Success 200 {object} page.Page[structs.ReadTopicMedia] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /cms/topic-media [get]
 func (h *topicMediaHandler) List(c *gin.Context) {
@@ -202,7 +202,7 @@ func (h *topicMediaHandler) List(c *gin.Context) {
 		return
 	}
 
-	resp.Success(c.Writer, topicMedia)
+	resp.Success(c.Writer, page.New(topicMedia))
 }
 
 // GetByTopicAndMedia handles getting a topic media relation by topic ID and media ID.
@@ -246,7 +246,7 @@ func (h *topicMediaHandler) GetByTopicAndMedia(c *gin.Context) {
 // @Produce json
 // @Param topicId path string true "Topic ID"
 // @Param type query string false "Media Type" Enums(featured, gallery, attachment)
-// @Success 200 {array} structs.ReadTopicMedia "success"
+// @Success 200 {object} page.Page[structs.ReadTopicMedia] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /cms/topic-media/by-topic/{topicId} [get]
 func (h *topicMediaHandler) ListByTopic(c *gin.Context) {
@@ -268,5 +268,5 @@ func (h *topicMediaHandler) ListByTopic(c *gin.Context) {
 		return
 	}
 
-	resp.Success(c.Writer, topicMedia)
+	resp.Success(c.Writer, page.New(topicMedia))
 }
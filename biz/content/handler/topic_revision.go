@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"ncobase/biz/content/service"
+	"ncobase/biz/content/structs"
+	"ncobase/internal/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// TopicRevisionHandlerInterface is the interface for the handler.
+type TopicRevisionHandlerInterface interface {
+	Autosave(c *gin.Context)
+	List(c *gin.Context)
+	Restore(c *gin.Context)
+	Publish(c *gin.Context)
+}
+
+// topicRevisionHandler represents the handler.
+type topicRevisionHandler struct {
+	s *service.Service
+}
+
+// NewTopicRevisionHandler creates a new handler.
+func NewTopicRevisionHandler(s *service.Service) TopicRevisionHandlerInterface {
+	return &topicRevisionHandler{
+		s: s,
+	}
+}
+
+// Autosave handles a draft autosave of a topic.
+//
+// @Summary Autosave topic draft
+// @Description Store a draft revision of a topic for the current user, the published topic is unchanged.
+// @Tags cms
+// @Accept json
+// @Produce json
+// @Param slug path string true "Topic slug"
+// @Param body body structs.AutosaveTopicBody true "AutosaveTopicBody object"
+// @Success 200 {object} structs.ReadTopicRevision "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /cms/topics/{slug}/drafts [post]
+// @Security Bearer
+func (h *topicRevisionHandler) Autosave(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("slug")))
+		return
+	}
+
+	body := &structs.AutosaveTopicBody{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	result, err := h.s.Revision.Autosave(c.Request.Context(), slug, body)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	resp.Success(c.Writer, result)
+}
+
+// List handles listing the revisions of a topic.
+//
+// @Summary List topic revisions
+// @Description Retrieve the revisions of a topic, newest first.
+// @Tags cms
+// @Produce json
+// @Param slug path string true "Topic slug"
+// @Param params query structs.ListTopicRevisionParams false "List revisions parameters"
+// @Success 200 {array} structs.ReadTopicRevision "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /cms/topics/{slug}/revisions [get]
+// @Security Bearer
+func (h *topicRevisionHandler) List(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("slug")))
+		return
+	}
+
+	params := &structs.ListTopicRevisionParams{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, params); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	result, err := h.s.Revision.List(c.Request.Context(), slug, params)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	resp.Success(c.Writer, result)
+}
+
+// Restore handles restoring a topic revision into a new draft.
+//
+// @Summary Restore topic revision
+// @Description Copy a revision into a new draft of the current user, the published topic is unchanged.
+// @Tags cms
+// @Produce json
+// @Param slug path string true "Topic slug"
+// @Param revision_id path string true "Revision ID"
+// @Success 200 {object} structs.ReadTopicRevision "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /cms/topics/{slug}/revisions/{revision_id}/restore [post]
+// @Security Bearer
+func (h *topicRevisionHandler) Restore(c *gin.Context) {
+	result, err := h.s.Revision.Restore(c.Request.Context(), c.Param("slug"), c.Param("revision_id"))
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	resp.Success(c.Writer, result)
+}
+
+// Publish handles publishing a topic revision.
+//
+// @Summary Publish topic revision
+// @Description Apply a revision to the published topic.
+// @Tags cms
+// @Produce json
+// @Param slug path string true "Topic slug"
+// @Param revision_id path string true "Revision ID"
+// @Success 200 {object} structs.ReadTopic "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /cms/topics/{slug}/revisions/{revision_id}/publish [post]
+// @Security Bearer
+func (h *topicRevisionHandler) Publish(c *gin.Context) {
+	result, err := h.s.Revision.Publish(c.Request.Context(), c.Param("slug"), c.Param("revision_id"))
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	resp.Success(c.Writer, result)
+}
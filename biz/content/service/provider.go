@@ -15,6 +15,7 @@ type Service struct {
 	Distribution DistributionServiceInterface
 	Media        MediaServiceInterface
 	TopicMedia   TopicMediaServiceInterface
+	Revision     TopicRevisionServiceInterface
 	rsw          *wrapper.ResourceServiceWrapper
 }
 
@@ -30,6 +31,7 @@ func New(em ext.ManagerInterface, d *data.Data) *Service {
 	ds := NewDistributionService(d, tops, cs)
 	ms := NewMediaService(d, rsw)
 	tms := NewTopicMediaService(d)
+	trs := NewTopicRevisionService(d, tops)
 
 	return &Service{
 		Taxonomy:     ts,
@@ -38,6 +40,7 @@ func New(em ext.ManagerInterface, d *data.Data) *Service {
 		Distribution: ds,
 		Media:        ms,
 		TopicMedia:   tms,
+		Revision:     trs,
 		rsw:          rsw,
 	}
 }
@@ -3,10 +3,13 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"ncobase/biz/content/data"
 	"ncobase/biz/content/data/repository"
 	"ncobase/biz/content/structs"
+	"strconv"
 
+	"github.com/ncobase/ncore/ctxutil"
 	"github.com/ncobase/ncore/data/paging"
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/logging/logger"
@@ -24,6 +27,8 @@ type TaxonomyServiceInterface interface {
 	CountX(ctx context.Context, params *structs.ListTaxonomyParams) int
 	GetTree(ctx context.Context, params *structs.FindTaxonomy) (paging.Result[*structs.ReadTaxonomy], error)
 	Delete(ctx context.Context, slug string) error
+	Import(ctx context.Context, body *structs.ImportTaxonomyBody) (*structs.ImportTaxonomyResult, error)
+	Search(ctx context.Context, query string, limit int) ([]*structs.ReadTaxonomy, error)
 }
 
 // taxonomyService is the struct for the service.
@@ -96,6 +101,131 @@ func (s *taxonomyService) Delete(ctx context.Context, slug string) error {
 	return nil
 }
 
+// maxTaxonomyDepth bounds the ancestor walk of an import
+const maxTaxonomyDepth = 64
+
+// ErrInvalidTaxonomyImport is returned for an import rejected before anything is written
+var ErrInvalidTaxonomyImport = errors.New("invalid taxonomy import")
+
+// importSlug is where a slug sits in an import tree and the space it is imported into
+type importSlug struct {
+	position string
+	spaceID  string
+}
+
+// invalidImport returns an ErrInvalidTaxonomyImport with the reason
+func invalidImport(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidTaxonomyImport, fmt.Sprintf(format, args...))
+}
+
+// Import imports a taxonomy tree.
+// Duplicate slugs, existing slugs outside upsert mode, slugs of another space and cycles
+// are rejected with ErrInvalidTaxonomyImport before anything is written.
+func (s *taxonomyService) Import(ctx context.Context, body *structs.ImportTaxonomyBody) (*structs.ImportTaxonomyResult, error) {
+	if body == nil || len(body.Items) == 0 {
+		return nil, invalidImport("%s", ecode.FieldIsRequired("items"))
+	}
+
+	slugs := make(map[string]importSlug)
+	if err := s.prepareImport(body.Items, "", body.SpaceID, slugs); err != nil {
+		return nil, err
+	}
+
+	// slugs are unique across spaces, upsert only updates taxonomies of the space imported into
+	list := make([]string, 0, len(slugs))
+	for sl := range slugs {
+		list = append(list, sl)
+	}
+	existing, err := s.r.GetBySlugs(ctx, list)
+	if err != nil {
+		return nil, handleEntError(ctx, "Taxonomy", err)
+	}
+	for _, row := range existing {
+		node := slugs[row.Slug]
+		if !body.Upsert {
+			return nil, invalidImport("taxonomy slug %s already exists, use upsert to update it", row.Slug)
+		}
+		if row.SpaceID != node.spaceID {
+			return nil, invalidImport("taxonomy slug %s at item %s belongs to another space", row.Slug, node.position)
+		}
+	}
+
+	// a root attached under an existing taxonomy must not end up below itself
+	for i, node := range body.Items {
+		if node.ParentID == nil || *node.ParentID == "" {
+			node.ParentID = nil
+			continue
+		}
+		if err := s.checkImportParent(ctx, *node.ParentID, slugs); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+
+	result, err := s.r.Import(ctx, body)
+	// a slug taken by a concurrent write since the checks above
+	if errors.Is(err, repository.ErrTaxonomySlugTaken) || repository.IsConstraintError(err) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTaxonomyImport, err)
+	}
+	if err := handleEntError(ctx, "Taxonomy", err); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// prepareImport validates the nodes, fills in slugs and spaces and collects the slugs with their positions
+func (s *taxonomyService) prepareImport(nodes []*structs.ImportTaxonomyNode, prefix, spaceID string, slugs map[string]importSlug) error {
+	for i, node := range nodes {
+		position := strconv.Itoa(i)
+		if prefix != "" {
+			position = prefix + "." + position
+		}
+		if node == nil {
+			return invalidImport("item %s is empty", position)
+		}
+		if validator.IsEmpty(node.Name) {
+			return invalidImport("item %s: %s", position, ecode.FieldIsRequired("name"))
+		}
+		if validator.IsEmpty(node.Type) {
+			return invalidImport("item %s: %s", position, ecode.FieldIsRequired("type"))
+		}
+		if validator.IsEmpty(node.Slug) {
+			node.Slug = slug.Unicode(node.Name)
+		}
+		if node.SpaceID == "" {
+			node.SpaceID = spaceID
+		}
+		if other, ok := slugs[node.Slug]; ok {
+			return invalidImport("duplicate slug %s at items %s and %s", node.Slug, other.position, position)
+		}
+		slugs[node.Slug] = importSlug{position: position, spaceID: node.SpaceID}
+
+		if err := s.prepareImport(node.Children, position, spaceID, slugs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkImportParent rejects a parent that is, or descends from, a taxonomy being imported
+func (s *taxonomyService) checkImportParent(ctx context.Context, parentID string, slugs map[string]importSlug) error {
+	id := parentID
+	for depth := 0; id != "" && id != "root"; depth++ {
+		if depth >= maxTaxonomyDepth {
+			return invalidImport("parent %s is nested too deep or in a cycle", parentID)
+		}
+		row, err := s.r.GetByID(ctx, id)
+		if err != nil {
+			return handleEntError(ctx, "Taxonomy", err)
+		}
+		if node, ok := slugs[row.Slug]; ok {
+			return invalidImport("parent %s would make taxonomy %s at item %s its own ancestor", parentID, row.Slug, node.position)
+		}
+		id = row.ParentID
+	}
+	return nil
+}
+
 // List lists all taxonomies.
 func (s *taxonomyService) List(ctx context.Context, params *structs.ListTaxonomyParams) (paging.Result[*structs.ReadTaxonomy], error) {
 	if params.Children {
@@ -131,6 +261,25 @@ func (s *taxonomyService) List(ctx context.Context, params *structs.ListTaxonomy
 	})
 }
 
+// Search finds taxonomies of the current space.
+func (s *taxonomyService) Search(ctx context.Context, query string, limit int) ([]*structs.ReadTaxonomy, error) {
+	spaceID := ctxutil.GetSpaceID(ctx)
+	if query == "" || spaceID == "" {
+		return []*structs.ReadTaxonomy{}, nil
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	rows, err := s.r.Search(ctx, query, spaceID, limit)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to search taxonomies: %v", err)
+		return nil, err
+	}
+
+	return repository.SerializeTaxonomies(rows), nil
+}
+
 // CountX gets a count of taxonomys.
 func (s *taxonomyService) CountX(ctx context.Context, params *structs.ListTaxonomyParams) int {
 	return s.r.CountX(ctx, params)
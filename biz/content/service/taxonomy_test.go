@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ncobase/biz/content/data/ent"
+	"ncobase/biz/content/data/repository"
+	"ncobase/biz/content/structs"
+	"ncobase/internal/dberr"
+)
+
+// importRepo serves taxonomies from memory and records the imports it is asked to write
+type importRepo struct {
+	repository.TaxonomyRepositoryInterface
+	rows     []*ent.Taxonomy
+	imported *structs.ImportTaxonomyBody
+}
+
+func (r *importRepo) GetBySlugs(_ context.Context, slugs []string) ([]*ent.Taxonomy, error) {
+	var found []*ent.Taxonomy
+	for _, row := range r.rows {
+		for _, sl := range slugs {
+			if row.Slug == sl {
+				found = append(found, row)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (r *importRepo) GetByID(_ context.Context, id string) (*ent.Taxonomy, error) {
+	for _, row := range r.rows {
+		if row.ID == id {
+			return row, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *importRepo) Import(_ context.Context, body *structs.ImportTaxonomyBody) (*structs.ImportTaxonomyResult, error) {
+	r.imported = body
+	return &structs.ImportTaxonomyResult{}, nil
+}
+
+func importNode(name string, children ...*structs.ImportTaxonomyNode) *structs.ImportTaxonomyNode {
+	return &structs.ImportTaxonomyNode{
+		TaxonomyBody: structs.TaxonomyBody{Name: name, Type: "node"},
+		Children:     children,
+	}
+}
+
+func TestPrepareImportFillsSlugsAndSpaces(t *testing.T) {
+	s := &taxonomyService{}
+	items := []*structs.ImportTaxonomyNode{
+		importNode("News", importNode("World", importNode("Europe"))),
+	}
+	items[0].Children[0].SpaceID = "s2"
+
+	slugs := map[string]importSlug{}
+	if err := s.prepareImport(items, "", "s1", slugs); err != nil {
+		t.Fatalf("prepareImport: %v", err)
+	}
+
+	europe := items[0].Children[0].Children[0]
+	if europe.Slug == "" || slugs[europe.Slug].position != "0.0.0" {
+		t.Fatalf("europe slug %q at %q, want a slug at 0.0.0", europe.Slug, slugs[europe.Slug].position)
+	}
+	if items[0].SpaceID != "s1" || items[0].Children[0].SpaceID != "s2" || europe.SpaceID != "s1" {
+		t.Fatalf("spaces = %q, %q, %q", items[0].SpaceID, items[0].Children[0].SpaceID, europe.SpaceID)
+	}
+}
+
+func TestPrepareImportRejects(t *testing.T) {
+	duplicate := []*structs.ImportTaxonomyNode{importNode("News", importNode("News"))}
+	missingType := []*structs.ImportTaxonomyNode{importNode("News")}
+	missingType[0].Type = ""
+	empty := []*structs.ImportTaxonomyNode{importNode("News"), nil}
+
+	for name, items := range map[string][]*structs.ImportTaxonomyNode{
+		"duplicate slug": duplicate,
+		"missing type":   missingType,
+		"empty item":     empty,
+	} {
+		err := (&taxonomyService{}).prepareImport(items, "", "", map[string]importSlug{})
+		if !errors.Is(err, ErrInvalidTaxonomyImport) {
+			t.Errorf("%s: err = %v, want ErrInvalidTaxonomyImport", name, err)
+		}
+	}
+}
+
+func TestImportRejectsExistingSlugWithoutUpsert(t *testing.T) {
+	repo := &importRepo{rows: []*ent.Taxonomy{{ID: "t1", Slug: "news", SpaceID: "s1"}}}
+	s := &taxonomyService{r: repo}
+
+	items := []*structs.ImportTaxonomyNode{importNode("news")}
+	if _, err := s.Import(context.Background(), &structs.ImportTaxonomyBody{SpaceID: "s1", Items: items}); !errors.Is(err, ErrInvalidTaxonomyImport) {
+		t.Fatalf("err = %v, want ErrInvalidTaxonomyImport", err)
+	}
+	if repo.imported != nil {
+		t.Fatal("import written although a slug exists")
+	}
+
+	items = []*structs.ImportTaxonomyNode{importNode("news")}
+	if _, err := s.Import(context.Background(), &structs.ImportTaxonomyBody{SpaceID: "s1", Upsert: true, Items: items}); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if repo.imported == nil {
+		t.Fatal("upsert not written")
+	}
+}
+
+func TestImportRejectsSlugOfAnotherSpace(t *testing.T) {
+	repo := &importRepo{rows: []*ent.Taxonomy{{ID: "t1", Slug: "news", SpaceID: "s1"}}}
+	s := &taxonomyService{r: repo}
+
+	items := []*structs.ImportTaxonomyNode{importNode("news")}
+	_, err := s.Import(context.Background(), &structs.ImportTaxonomyBody{SpaceID: "s2", Upsert: true, Items: items})
+	if !errors.Is(err, ErrInvalidTaxonomyImport) {
+		t.Fatalf("err = %v, want ErrInvalidTaxonomyImport", err)
+	}
+	if repo.imported != nil {
+		t.Fatal("import written over a taxonomy of another space")
+	}
+}
+
+func TestImportRejectsParentCycle(t *testing.T) {
+	// news <- world <- europe already exist; re-importing news under europe would loop
+	repo := &importRepo{rows: []*ent.Taxonomy{
+		{ID: "t1", Slug: "news", SpaceID: "s1"},
+		{ID: "t2", Slug: "world", SpaceID: "s1", ParentID: "t1"},
+		{ID: "t3", Slug: "europe", SpaceID: "s1", ParentID: "t2"},
+	}}
+	s := &taxonomyService{r: repo}
+
+	parent := "t3"
+	items := []*structs.ImportTaxonomyNode{importNode("news")}
+	items[0].ParentID = &parent
+	_, err := s.Import(context.Background(), &structs.ImportTaxonomyBody{SpaceID: "s1", Upsert: true, Items: items})
+	if !errors.Is(err, ErrInvalidTaxonomyImport) {
+		t.Fatalf("err = %v, want ErrInvalidTaxonomyImport", err)
+	}
+}
+
+func TestImportMissingParentIsNotFound(t *testing.T) {
+	s := &taxonomyService{r: &importRepo{}}
+
+	parent := "missing"
+	items := []*structs.ImportTaxonomyNode{importNode("news")}
+	items[0].ParentID = &parent
+	_, err := s.Import(context.Background(), &structs.ImportTaxonomyBody{Items: items})
+	if !dberr.IsNotFound(err) || errors.Is(err, ErrInvalidTaxonomyImport) {
+		t.Fatalf("err = %v, want a not found error", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"ncobase/biz/content/data/repository"
 	"ncobase/biz/content/structs"
 
+	"github.com/ncobase/ncore/ctxutil"
 	"github.com/ncobase/ncore/data/paging"
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/logging/logger"
@@ -23,6 +24,8 @@ type TopicServiceInterface interface {
 	GetByID(ctx context.Context, id string) (*structs.ReadTopic, error) // Add this method
 	List(ctx context.Context, params *structs.ListTopicParams) (paging.Result[*structs.ReadTopic], error)
 	Delete(ctx context.Context, slug string) error
+	CheckSlug(ctx context.Context, body *structs.TopicSlugCheckBody) (*structs.TopicSlugCheckResult, error)
+	Search(ctx context.Context, query string, limit int) ([]*structs.ReadTopic, error)
 }
 
 type topicService struct {
@@ -48,9 +51,17 @@ func (s *topicService) Create(ctx context.Context, body *structs.CreateTopicBody
 		}
 	}
 
-	// Set slug field
+	// Slugs are unique per space
+	if validator.IsEmpty(body.SpaceID) {
+		body.SpaceID = ctxutil.GetSpaceID(ctx)
+	}
+
+	// Set slug field, the repository suffixes it when taken
+	if validator.IsEmpty(body.Slug) {
+		body.Slug = topicSlug(body.Title, body.Name)
+	}
 	if validator.IsEmpty(body.Slug) {
-		body.Slug = slug.Unicode(body.Name)
+		return nil, errors.New(ecode.FieldIsRequired("title"))
 	}
 
 	row, err := s.r.Create(ctx, body)
@@ -61,6 +72,41 @@ func (s *topicService) Create(ctx context.Context, body *structs.CreateTopicBody
 	return s.enrichTopic(ctx, repository.SerializeTopic(row)), nil
 }
 
+// CheckSlug previews whether a slug is free in a space and which slug a create would use
+func (s *topicService) CheckSlug(ctx context.Context, body *structs.TopicSlugCheckBody) (*structs.TopicSlugCheckResult, error) {
+	want := body.Slug
+	if validator.IsEmpty(want) {
+		want = topicSlug(body.Title, "")
+	}
+	if validator.IsEmpty(want) {
+		return nil, errors.New(ecode.FieldIsRequired("slug / title"))
+	}
+
+	spaceID := body.SpaceID
+	if validator.IsEmpty(spaceID) {
+		spaceID = ctxutil.GetSpaceID(ctx)
+	}
+
+	suggestion, err := s.r.AvailableSlug(ctx, spaceID, want, body.TopicID)
+	if err := handleEntError(ctx, "Topic", err); err != nil {
+		return nil, err
+	}
+
+	return &structs.TopicSlugCheckResult{
+		Slug:       want,
+		Available:  suggestion == want,
+		Suggestion: suggestion,
+	}, nil
+}
+
+// topicSlug generates a transliterated slug from the title, or the name when there is no title
+func topicSlug(title, name string) string {
+	if validator.IsNotEmpty(title) {
+		return slug.Unicode(title)
+	}
+	return slug.Unicode(name)
+}
+
 // Update updates existing topic
 func (s *topicService) Update(ctx context.Context, slug string, updates types.JSON) (*structs.ReadTopic, error) {
 	if validator.IsEmpty(slug) {
@@ -148,6 +194,35 @@ func (s *topicService) List(ctx context.Context, params *structs.ListTopicParams
 	})
 }
 
+// Search finds topics of the current space.
+// Private topics are only found by their creator and by admins.
+func (s *topicService) Search(ctx context.Context, query string, limit int) ([]*structs.ReadTopic, error) {
+	spaceID := ctxutil.GetSpaceID(ctx)
+	if query == "" || spaceID == "" {
+		return []*structs.ReadTopic{}, nil
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	rows, err := s.r.Search(ctx, query, spaceID, limit)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to search topics: %v", err)
+		return nil, err
+	}
+
+	userID := ctxutil.GetUserID(ctx)
+	isAdmin := ctxutil.GetUserIsAdmin(ctx)
+	topics := make([]*structs.ReadTopic, 0, len(rows))
+	for _, topic := range repository.SerializeTopics(rows) {
+		if topic.Private && !isAdmin && (topic.CreatedBy == nil || *topic.CreatedBy != userID) {
+			continue
+		}
+		topics = append(topics, topic)
+	}
+	return topics, nil
+}
+
 // enrichTopics enriches topics with related data.
 func (s *topicService) enrichTopics(ctx context.Context, rows []*structs.ReadTopic) []*structs.ReadTopic {
 	rs := make([]*structs.ReadTopic, 0, len(rows))
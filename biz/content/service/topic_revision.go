@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"ncobase/biz/content/data"
+	"ncobase/biz/content/data/repository"
+	"ncobase/biz/content/structs"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/types"
+	"github.com/ncobase/ncore/validation/validator"
+)
+
+// TopicRevisionServiceInterface for topic draft and revision operations
+type TopicRevisionServiceInterface interface {
+	Autosave(ctx context.Context, slug string, body *structs.AutosaveTopicBody) (*structs.ReadTopicRevision, error)
+	List(ctx context.Context, slug string, params *structs.ListTopicRevisionParams) ([]*structs.ReadTopicRevision, error)
+	Restore(ctx context.Context, slug, revisionID string) (*structs.ReadTopicRevision, error)
+	Publish(ctx context.Context, slug, revisionID string) (*structs.ReadTopic, error)
+}
+
+// topicRevisionService keeps drafts apart from the topic,
+// the published topic only changes when a revision is published.
+type topicRevisionService struct {
+	r            repository.TopicRevisionRepositoryInterface
+	ts           TopicServiceInterface
+	maxRevisions int
+}
+
+// NewTopicRevisionService creates new topic revision service
+func NewTopicRevisionService(d *data.Data, ts TopicServiceInterface) TopicRevisionServiceInterface {
+	return &topicRevisionService{
+		r:            repository.NewTopicRevisionRepository(d),
+		ts:           ts,
+		maxRevisions: structs.DefaultMaxTopicRevisions,
+	}
+}
+
+// Autosave stores a draft revision for the current user.
+// An autosave identical to the user's latest revision is not stored again.
+func (s *topicRevisionService) Autosave(ctx context.Context, slug string, body *structs.AutosaveTopicBody) (*structs.ReadTopicRevision, error) {
+	authorID := ctxutil.GetUserID(ctx)
+	if validator.IsEmpty(authorID) {
+		return nil, errors.New(ecode.FieldIsRequired("user"))
+	}
+
+	topic, err := s.ts.Get(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.r.Latest(ctx, topic.ID, authorID)
+	if err != nil && !repository.IsNotFound(err) {
+		return nil, handleEntError(ctx, "TopicRevision", err)
+	}
+	if latest != nil && latest.Title == body.Title && latest.Content == body.Content && latest.Markdown == body.Markdown {
+		return repository.SerializeTopicRevision(latest), nil
+	}
+
+	row, err := s.r.Create(ctx, topic.ID, authorID, body)
+	if err := handleEntError(ctx, "TopicRevision", err); err != nil {
+		return nil, err
+	}
+
+	s.prune(ctx, topic.ID)
+
+	return repository.SerializeTopicRevision(row), nil
+}
+
+// List lists the revisions of a topic, newest first
+func (s *topicRevisionService) List(ctx context.Context, slug string, params *structs.ListTopicRevisionParams) ([]*structs.ReadTopicRevision, error) {
+	topic, err := s.ts.Get(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	params.TopicID = topic.ID
+	rows, err := s.r.List(ctx, params)
+	if err := handleEntError(ctx, "TopicRevision", err); err != nil {
+		return nil, err
+	}
+
+	return repository.SerializeTopicRevisions(rows), nil
+}
+
+// Restore copies a revision into a new draft of the current user, the published topic is unchanged
+func (s *topicRevisionService) Restore(ctx context.Context, slug, revisionID string) (*structs.ReadTopicRevision, error) {
+	authorID := ctxutil.GetUserID(ctx)
+	if validator.IsEmpty(authorID) {
+		return nil, errors.New(ecode.FieldIsRequired("user"))
+	}
+
+	topic, revision, err := s.getRevision(ctx, slug, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.r.Create(ctx, topic.ID, authorID, &structs.AutosaveTopicBody{
+		Title:    revision.Title,
+		Content:  revision.Content,
+		Markdown: revision.Markdown,
+	})
+	if err := handleEntError(ctx, "TopicRevision", err); err != nil {
+		return nil, err
+	}
+
+	s.prune(ctx, topic.ID)
+
+	return repository.SerializeTopicRevision(row), nil
+}
+
+// Publish applies a revision to the topic
+func (s *topicRevisionService) Publish(ctx context.Context, slug, revisionID string) (*structs.ReadTopic, error) {
+	topic, revision, err := s.getRevision(ctx, slug, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := types.JSON{
+		"title":    revision.Title,
+		"content":  revision.Content,
+		"markdown": revision.Markdown,
+	}
+	if userID := ctxutil.GetUserID(ctx); userID != "" {
+		updates["updated_by"] = userID
+	}
+
+	result, err := s.ts.Update(ctx, topic.ID, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.r.MarkPublished(ctx, revision.ID); err != nil {
+		logger.Warnf(ctx, "Failed to mark revision %s published: %v", revision.ID, err)
+	}
+
+	return result, nil
+}
+
+// getRevision gets a topic and one of its revisions
+func (s *topicRevisionService) getRevision(ctx context.Context, slug, revisionID string) (*structs.ReadTopic, *structs.ReadTopicRevision, error) {
+	if validator.IsEmpty(revisionID) {
+		return nil, nil, errors.New(ecode.FieldIsRequired("revision_id"))
+	}
+
+	topic, err := s.ts.Get(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	row, err := s.r.GetByID(ctx, revisionID)
+	if err := handleEntError(ctx, "TopicRevision", err); err != nil {
+		return nil, nil, err
+	}
+	if row.TopicID != topic.ID {
+		return nil, nil, errors.New(ecode.NotExist("TopicRevision"))
+	}
+
+	return topic, repository.SerializeTopicRevision(row), nil
+}
+
+// prune drops the oldest revisions of a topic beyond the cap
+func (s *topicRevisionService) prune(ctx context.Context, topicID string) {
+	if n, err := s.r.Prune(ctx, topicID, s.maxRevisions); err != nil {
+		logger.Warnf(ctx, "Failed to prune revisions of topic %s: %v", topicID, err)
+	} else if n > 0 {
+		logger.Debugf(ctx, "Pruned %d revisions of topic %s", n, topicID)
+	}
+}
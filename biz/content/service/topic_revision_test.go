@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ncobase/biz/content/data/ent"
+	"ncobase/biz/content/data/repository"
+	"ncobase/biz/content/structs"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/types"
+)
+
+// memoryRevisions keeps revisions in memory, in creation order
+type memoryRevisions struct {
+	rows []*ent.TopicRevision
+}
+
+func (r *memoryRevisions) Create(_ context.Context, topicID, authorID string, body *structs.AutosaveTopicBody) (*ent.TopicRevision, error) {
+	row := &ent.TopicRevision{
+		ID: fmt.Sprintf("rev-%d", len(r.rows)+1), TopicID: topicID, AuthorID: authorID,
+		Title: body.Title, Content: body.Content, Markdown: body.Markdown,
+		CreatedBy: authorID, CreatedAt: int64(len(r.rows) + 1),
+	}
+	r.rows = append(r.rows, row)
+	return row, nil
+}
+
+func (r *memoryRevisions) GetByID(_ context.Context, id string) (*ent.TopicRevision, error) {
+	for _, row := range r.rows {
+		if row.ID == id {
+			return row, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *memoryRevisions) Latest(ctx context.Context, topicID, authorID string) (*ent.TopicRevision, error) {
+	rows, _ := r.List(ctx, &structs.ListTopicRevisionParams{TopicID: topicID, AuthorID: authorID, Limit: 1})
+	if len(rows) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return rows[0], nil
+}
+
+func (r *memoryRevisions) List(_ context.Context, params *structs.ListTopicRevisionParams) ([]*ent.TopicRevision, error) {
+	var rows []*ent.TopicRevision
+	for i := len(r.rows) - 1; i >= 0; i-- {
+		row := r.rows[i]
+		if row.TopicID != params.TopicID || (params.AuthorID != "" && row.AuthorID != params.AuthorID) {
+			continue
+		}
+		if params.Limit > 0 && len(rows) == params.Limit {
+			break
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (r *memoryRevisions) MarkPublished(ctx context.Context, id string) (*ent.TopicRevision, error) {
+	row, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	row.PublishedAt = 1
+	return row, nil
+}
+
+func (r *memoryRevisions) Prune(_ context.Context, topicID string, keep int) (int, error) {
+	var kept []*ent.TopicRevision
+	n := 0
+	for i := len(r.rows) - 1; i >= 0; i-- {
+		if r.rows[i].TopicID == topicID {
+			if n++; n > keep {
+				continue
+			}
+		}
+		kept = append([]*ent.TopicRevision{r.rows[i]}, kept...)
+	}
+	pruned := len(r.rows) - len(kept)
+	r.rows = kept
+	return pruned, nil
+}
+
+// publishedTopics serves topics by slug and records the updates applied to them
+type publishedTopics struct {
+	TopicServiceInterface
+	topics map[string]*structs.ReadTopic
+}
+
+func (t *publishedTopics) Get(_ context.Context, slug string) (*structs.ReadTopic, error) {
+	for _, topic := range t.topics {
+		if topic.ID == slug || topic.Slug == slug {
+			return topic, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (t *publishedTopics) Update(ctx context.Context, slug string, updates types.JSON) (*structs.ReadTopic, error) {
+	topic, err := t.Get(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	topic.Title, topic.Content = updates["title"].(string), updates["content"].(string)
+	return topic, nil
+}
+
+func newTestRevisions() (*topicRevisionService, *memoryRevisions, *structs.ReadTopic) {
+	topic := &structs.ReadTopic{ID: "t1", Slug: "launch", Title: "Launch", Content: "published"}
+	revisions := &memoryRevisions{}
+	return &topicRevisionService{
+		r:            revisions,
+		ts:           &publishedTopics{topics: map[string]*structs.ReadTopic{"t1": topic}},
+		maxRevisions: structs.DefaultMaxTopicRevisions,
+	}, revisions, topic
+}
+
+func TestAutosaveListAndRestore(t *testing.T) {
+	s, _, topic := newTestRevisions()
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+
+	for _, content := range []string{"draft 1", "draft 2", "draft 2", "draft 3"} {
+		if _, err := s.Autosave(ctx, "launch", &structs.AutosaveTopicBody{Title: "Launch", Content: content}); err != nil {
+			t.Fatalf("Autosave(%s): %v", content, err)
+		}
+	}
+
+	revisions, err := s.List(ctx, "launch", &structs.ListTopicRevisionParams{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	var contents []string
+	for _, revision := range revisions {
+		contents = append(contents, revision.Content)
+	}
+	if fmt.Sprint(contents) != "[draft 3 draft 2 draft 1]" {
+		t.Fatalf("revisions %v, want three newest first without the repeated autosave", contents)
+	}
+
+	restored, err := s.Restore(ctx, "launch", revisions[1].ID)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if restored.Content != "draft 2" || restored.ID == revisions[1].ID {
+		t.Fatalf("restored %+v, want a new draft of the second revision", restored)
+	}
+	if topic.Content != "published" {
+		t.Fatalf("restoring changed the published topic to %q", topic.Content)
+	}
+
+	if _, err := s.Publish(ctx, "launch", restored.ID); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if topic.Content != "draft 2" {
+		t.Fatalf("published topic content %q, want draft 2", topic.Content)
+	}
+}
+
+func TestAutosaveRequiresUserAndPrunes(t *testing.T) {
+	s, revisions, _ := newTestRevisions()
+	if _, err := s.Autosave(context.Background(), "launch", &structs.AutosaveTopicBody{Content: "draft"}); err == nil {
+		t.Fatal("autosaved without a user")
+	}
+
+	s.maxRevisions = 2
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+	for i := 1; i <= 4; i++ {
+		if _, err := s.Autosave(ctx, "launch", &structs.AutosaveTopicBody{Content: fmt.Sprintf("draft %d", i)}); err != nil {
+			t.Fatalf("Autosave: %v", err)
+		}
+	}
+	if len(revisions.rows) != 2 || revisions.rows[0].Content != "draft 3" {
+		t.Fatalf("%d revisions kept, want the newest 2", len(revisions.rows))
+	}
+
+	if _, err := s.Restore(ctx, "launch", "rev-missing"); err == nil {
+		t.Fatal("restored a missing revision")
+	}
+}
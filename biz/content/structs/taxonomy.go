@@ -29,6 +29,7 @@ type TaxonomyBody struct {
 	Keywords    string      `json:"keywords,omitempty"`
 	Description string      `json:"description,omitempty"`
 	Status      int         `json:"status,omitempty"`
+	Order       int         `json:"order,omitempty"`
 	Extras      *types.JSON `json:"extras,omitempty"`
 	ParentID    *string     `json:"parent_id,omitempty"`
 	SpaceID     string      `json:"space_id,omitempty"`
@@ -47,6 +48,36 @@ type UpdateTaxonomyBody struct {
 	TaxonomyBody
 }
 
+// ImportTaxonomyNode represents a taxonomy and its children in an import tree.
+// The parent of a nested node is the node it is nested in; a root node may
+// set parent_id to attach it under an existing taxonomy.
+type ImportTaxonomyNode struct {
+	TaxonomyBody
+	Children []*ImportTaxonomyNode `json:"children,omitempty"`
+}
+
+// ImportTaxonomyBody represents the body for importing a taxonomy tree.
+type ImportTaxonomyBody struct {
+	SpaceID string                `json:"space_id,omitempty"`
+	Upsert  bool                  `json:"upsert,omitempty"` // Update taxonomies that already exist by slug
+	Items   []*ImportTaxonomyNode `json:"items" validate:"required,min=1"`
+}
+
+// ImportedTaxonomy represents the outcome of one imported node.
+type ImportedTaxonomy struct {
+	Position string `json:"position"` // Index path in the input tree, e.g. "0.2.1"
+	ID       string `json:"id"`
+	Slug     string `json:"slug"`
+	Created  bool   `json:"created"` // False when an existing taxonomy was updated
+}
+
+// ImportTaxonomyResult represents the result of a taxonomy import.
+type ImportTaxonomyResult struct {
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Items   []*ImportedTaxonomy `json:"items"`
+}
+
 // ReadTaxonomy represents the output schema for retrieving a taxonomy.
 type ReadTaxonomy struct {
 	ID          string           `json:"id"`
@@ -61,6 +92,7 @@ type ReadTaxonomy struct {
 	Keywords    string           `json:"keywords"`
 	Description string           `json:"description"`
 	Status      int              `json:"status"`
+	Order       int              `json:"order"`
 	Extras      *types.JSON      `json:"extras,omitempty"`
 	ParentID    *string          `json:"parent_id,omitempty"`
 	SpaceID     string           `json:"space_id,omitempty"`
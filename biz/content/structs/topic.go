@@ -47,6 +47,21 @@ type CreateTopicBody struct {
 	TopicBody
 }
 
+// TopicSlugCheckBody for previewing a topic slug
+type TopicSlugCheckBody struct {
+	Slug    string `json:"slug,omitempty"` // Wanted slug, generated from title when empty
+	Title   string `json:"title,omitempty"`
+	SpaceID string `json:"space_id,omitempty"` // Defaults to the current space
+	TopicID string `json:"topic_id,omitempty"` // Topic being edited, its own slug counts as free
+}
+
+// TopicSlugCheckResult for slug availability
+type TopicSlugCheckResult struct {
+	Slug       string `json:"slug"`
+	Available  bool   `json:"available"`
+	Suggestion string `json:"suggestion"` // Slug a create would use
+}
+
 // UpdateTopicBody for updating topic
 type UpdateTopicBody struct {
 	ID string `json:"id"`
@@ -0,0 +1,31 @@
+package structs
+
+// DefaultMaxTopicRevisions is how many revisions are kept per topic
+const DefaultMaxTopicRevisions = 50
+
+// AutosaveTopicBody represents a draft autosave of a topic.
+type AutosaveTopicBody struct {
+	Title    string `json:"title,omitempty"`
+	Content  string `json:"content,omitempty"`
+	Markdown bool   `json:"markdown,omitempty"`
+}
+
+// ReadTopicRevision represents the output schema for retrieving a topic revision.
+type ReadTopicRevision struct {
+	ID          string  `json:"id"`
+	TopicID     string  `json:"topic_id"`
+	AuthorID    string  `json:"author_id"`
+	Title       string  `json:"title"`
+	Content     string  `json:"content"`
+	Markdown    bool    `json:"markdown"`
+	PublishedAt int64   `json:"published_at,omitempty"`
+	CreatedBy   *string `json:"created_by,omitempty"`
+	CreatedAt   *int64  `json:"created_at,omitempty"`
+}
+
+// ListTopicRevisionParams represents the query parameters for listing topic revisions.
+type ListTopicRevisionParams struct {
+	TopicID  string `form:"-" json:"-"`
+	AuthorID string `form:"author_id,omitempty" json:"author_id,omitempty"`
+	Limit    int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
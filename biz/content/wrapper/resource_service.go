@@ -2,16 +2,27 @@ package wrapper
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"ncobase/internal/servicereg"
 	resourceStructs "ncobase/plugin/resource/structs"
 
+	"github.com/ncobase/ncore/data/paging"
 	ext "github.com/ncobase/ncore/extension/types"
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+const (
+	// resourceFileService is the name the resource plugin publishes its file service under
+	resourceFileService = "resource.File"
+	// resourceFileServiceVersion is the oldest file service version this interface works with
+	resourceFileServiceVersion = "1.0"
 )
 
 // ResourceFileServiceInterface defines resource file service interface for content module
 type ResourceFileServiceInterface interface {
 	Get(ctx context.Context, slug string) (*resourceStructs.ReadFile, error)
-	List(ctx context.Context, params *resourceStructs.ListFileParams) (any, error)
+	List(ctx context.Context, params *resourceStructs.ListFileParams) (paging.Result[*resourceStructs.ReadFile], error)
 	Delete(ctx context.Context, slug string) error
 }
 
@@ -30,12 +41,15 @@ func NewResourceServiceWrapper(em ext.ManagerInterface) *ResourceServiceWrapper
 
 // loadServices loads resource services using extension manager
 func (w *ResourceServiceWrapper) loadServices() {
-	// Try to get resource file service
-	if fileSvc, err := w.em.GetCrossService("resource", "File"); err == nil {
-		if service, ok := fileSvc.(ResourceFileServiceInterface); ok {
-			w.fileService = service
+	fileService, err := servicereg.Resolve[ResourceFileServiceInterface](w.em, resourceFileService, resourceFileServiceVersion)
+	if err != nil {
+		// The resource plugin may load later, only a mismatch needs attention
+		if errors.Is(err, servicereg.ErrServiceIncompatible) {
+			logger.Warnf(context.Background(), "Resource file service unusable: %v", err)
 		}
+		return
 	}
+	w.fileService = fileService
 }
 
 // RefreshServices refreshes service references
@@ -52,11 +66,11 @@ func (w *ResourceServiceWrapper) GetFile(ctx context.Context, slug string) (*res
 }
 
 // ListFiles lists files
-func (w *ResourceServiceWrapper) ListFiles(ctx context.Context, params *resourceStructs.ListFileParams) (any, error) {
+func (w *ResourceServiceWrapper) ListFiles(ctx context.Context, params *resourceStructs.ListFileParams) (paging.Result[*resourceStructs.ReadFile], error) {
 	if w.fileService != nil {
 		return w.fileService.List(ctx, params)
 	}
-	return nil, fmt.Errorf("resource file service not available")
+	return paging.Result[*resourceStructs.ReadFile]{Items: []*resourceStructs.ReadFile{}}, fmt.Errorf("resource file service not available")
 }
 
 // DeleteFile deletes file
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"ncobase/biz/realtime/data/ent"
 	"ncobase/biz/realtime/data/ent/migrate"
+	"ncobase/internal/slowquery"
+	"ncobase/internal/tracing"
+	"ncobase/internal/utils"
 
 	"github.com/ncobase/ncore/config"
 	"github.com/ncobase/ncore/data"
@@ -30,6 +33,9 @@ func New(conf *config.Data, env ...string) (*Data, func(name ...string), error)
 		return nil, nil, err
 	}
 
+	// Trace Redis commands
+	tracing.InstrumentRedis(d.GetRedis())
+
 	ctx := context.Background()
 
 	// get master connection
@@ -71,8 +77,11 @@ func New(conf *config.Data, env ...string) (*Data, func(name ...string), error)
 
 // newEntClient creates a new ent client.
 func newEntClient(db *sql.DB, conf *config.DBNode, enableMigrate bool, env ...string) (*ent.Client, error) {
+	// Apply connection pool limits
+	utils.TunePool(db, conf)
+
 	client := ent.NewClient(ent.Driver(dialect.DebugWithContext(
-		entsql.OpenDB(conf.Driver, db),
+		slowquery.Driver(utils.ContextTxDriver(entsql.OpenDB(conf.Driver, db))),
 		func(ctx context.Context, i ...any) {
 			if conf.Logging {
 				logger.Infof(ctx, "%v", i)
@@ -85,6 +94,10 @@ func newEntClient(db *sql.DB, conf *config.DBNode, enableMigrate bool, env ...st
 		client = client.Debug()
 	}
 
+	// Trace queries and mutations
+	client.Use(tracing.EntHook())
+	client.Intercept(tracing.EntInterceptor())
+
 	// Auto migrate (only for master)
 	if enableMigrate {
 		migrateOpts := []schema.MigrateOption{
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -29,7 +30,7 @@ func (RTChannel) Annotations() []schema.Annotation {
 // Mixin of the  RTChannel.
 func (RTChannel) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.Description,
 		mixin.Type,   // public, private, direct
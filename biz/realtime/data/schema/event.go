@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -31,7 +32,7 @@ func (Event) Annotations() []schema.Annotation {
 // Mixin of the Event.
 func (Event) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Type,    // event type
 		mixin.Payload, // event payload data
 		mixin.CreatedAt,
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -29,7 +30,7 @@ func (Notification) Annotations() []schema.Annotation {
 // Mixin of the Notification.
 func (Notification) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Title,     // notification title
 		mixin.Content,   // notification content
 		mixin.Type,      // info, warning, error
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -29,7 +30,7 @@ func (Subscription) Annotations() []schema.Annotation {
 // Mixin of the Subscription.
 func (Subscription) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.UserID,    // user id
 		mixin.ChannelID, // notification channel
 		mixin.Status,    // 0: disabled, 1: enabled
@@ -5,6 +5,7 @@ import (
 	"ncobase/biz/realtime/data"
 	"ncobase/biz/realtime/handler"
 	"ncobase/biz/realtime/service"
+	"ncobase/internal/drain"
 	"ncobase/internal/middleware"
 	"sync"
 
@@ -36,6 +37,7 @@ type Module struct {
 	s           *service.Service
 	d           *data.Data
 	cleanup     func(name ...string)
+	stopDrain   func() // unregisters the drain hook closing client connections
 
 	discovery
 }
@@ -89,6 +91,9 @@ func (m *Module) PostInit() error {
 	m.s = service.New(m.d, m.em)
 	m.h = handler.New(m.s)
 
+	// Client connections are hijacked, close them when the server drains
+	m.stopDrain = drain.OnDrain(m.s.Close)
+
 	return nil
 }
 
@@ -172,6 +177,12 @@ func (m *Module) GetServices() ext.Service {
 
 // Cleanup cleans up resources
 func (m *Module) Cleanup() error {
+	if m.stopDrain != nil {
+		m.stopDrain()
+	}
+	if m.s != nil {
+		m.s.Close()
+	}
 	if m.cleanup != nil {
 		m.cleanup(m.Name())
 	}
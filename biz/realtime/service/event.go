@@ -7,6 +7,7 @@ import (
 	"ncobase/biz/realtime/data"
 	"ncobase/biz/realtime/data/repository"
 	"ncobase/biz/realtime/structs"
+	"sync"
 	"time"
 
 	"github.com/ncobase/ncore/data/paging"
@@ -32,20 +33,37 @@ type EventService interface {
 
 	UpdateEventStatus(ctx context.Context, eventID string, status string, errorMsg string) error
 	ProcessPendingEvents(ctx context.Context, limit int) ([]*structs.ReadEvent, error)
+
+	// Close drops the scheduled retries, their events stay in retry status
+	Close()
 }
 
 type eventService struct {
 	eventRepo repository.EventRepositoryInterface
 	ws        WebSocketService
+
+	// ctx ends the scheduled retries on Close, retries tracks them
+	ctx     context.Context
+	cancel  context.CancelFunc
+	retries sync.WaitGroup
 }
 
 func NewEventService(d *data.Data, ws WebSocketService) EventService {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &eventService{
 		eventRepo: repository.NewEventRepository(d),
 		ws:        ws,
+		ctx:       ctx,
+		cancel:    cancel,
 	}
 }
 
+// Close drops the scheduled retries and waits for running ones to return
+func (s *eventService) Close() {
+	s.cancel()
+	s.retries.Wait()
+}
+
 // Publish publishes a new event
 func (s *eventService) Publish(ctx context.Context, body *structs.CreateEvent) (*structs.ReadEvent, error) {
 	e := body.Event
@@ -304,7 +322,11 @@ func (s *eventService) RetryEvent(ctx context.Context, eventID string, params *s
 	scheduledAt := time.Now().Add(time.Duration(delay) * time.Second)
 
 	// Schedule retry (in production, this would use a job queue)
-	go s.scheduleRetry(context.Background(), eventID, scheduledAt)
+	s.retries.Add(1)
+	go func() {
+		defer s.retries.Done()
+		s.scheduleRetry(s.ctx, eventID, scheduledAt)
+	}()
 
 	return &structs.RetryResult{
 		RetryID:     nanoid.String(),
@@ -407,7 +429,14 @@ func (s *eventService) processEventAsync(ctx context.Context, eventID string) {
 // scheduleRetry schedules a retry for later execution
 func (s *eventService) scheduleRetry(ctx context.Context, eventID string, scheduledAt time.Time) {
 	// In production, this would use a proper job queue like RabbitMQ delayed messages
-	time.Sleep(time.Until(scheduledAt))
+	timer := time.NewTimer(time.Until(scheduledAt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		logger.Warnf(context.Background(), "Dropped scheduled retry of event %s on shutdown", eventID)
+		return
+	case <-timer.C:
+	}
 
 	event, err := s.eventRepo.Get(ctx, eventID)
 	if err != nil {
@@ -39,6 +39,13 @@ func New(d *data.Data, em ext.ManagerInterface) *Service {
 	return svc
 }
 
+// Close stops the background work of the services: scheduled event retries,
+// then the WebSocket hub with its client connections
+func (s *Service) Close() {
+	s.Event.Close()
+	s.WebSocket.Cleanup()
+}
+
 // subscribeToEvents sets up event subscriptions
 func (s *Service) subscribeToEvents() {
 	// Subscribe to relevant events
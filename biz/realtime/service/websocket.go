@@ -59,6 +59,11 @@ type webSocketService struct {
 	broadcast  chan *WebSocketMessage
 	mu         sync.RWMutex
 	data       *data.Data
+
+	// ctx ends the hub and maintenance loops on Cleanup, loops tracks them
+	ctx    context.Context
+	cancel context.CancelFunc
+	loops  sync.WaitGroup
 }
 
 // NewWebSocketService creates a new WebSocket service
@@ -72,9 +77,14 @@ func NewWebSocketService(d *data.Data) WebSocketService {
 		broadcast:  make(chan *WebSocketMessage),
 		data:       d,
 	}
+	ws.ctx, ws.cancel = context.WithCancel(context.Background())
 
-	go ws.run()
-	go ws.StartMaintenanceRoutine()
+	ws.loops.Add(1)
+	go func() {
+		defer ws.loops.Done()
+		ws.run()
+	}()
+	ws.StartMaintenanceRoutine()
 
 	return ws
 }
@@ -83,6 +93,9 @@ func NewWebSocketService(d *data.Data) WebSocketService {
 func (ws *webSocketService) run() {
 	for {
 		select {
+		case <-ws.ctx.Done():
+			return
+
 		case client := <-ws.register:
 			ws.handleRegister(client)
 
@@ -153,43 +166,62 @@ func (ws *webSocketService) handleBroadcast(msg *WebSocketMessage) {
 		return
 	}
 
+	// If channel specified, broadcast to channel subscribers, otherwise to all clients
 	ws.mu.RLock()
-	defer ws.mu.RUnlock()
-
-	// If channel specified, broadcast to channel subscribers
+	recipients := ws.clients
 	if msg.Channel != "" {
-		if subscribers, ok := ws.channels[msg.Channel]; ok {
-			for _, client := range subscribers {
-				select {
-				case client.Send <- d:
-				default:
-					close(client.Send)
-					ws.unregister <- client
-				}
-			}
-		}
-		return
+		recipients = ws.channels[msg.Channel]
 	}
+	slow := sendAll(recipients, d)
+	ws.mu.RUnlock()
 
-	// Otherwise broadcast to all clients
-	for _, client := range ws.clients {
+	// This runs on the hub loop, so clients that cannot keep up are dropped here
+	// instead of through the unregister channel the loop itself reads
+	for _, client := range slow {
+		ws.handleUnregister(client)
+	}
+}
+
+// sendAll queues d to every client without blocking and returns the clients whose
+// send buffer is full. Unregistering closes their send channel.
+func sendAll(clients map[string]*Client, d []byte) []*Client {
+	var slow []*Client
+	for _, client := range clients {
 		select {
 		case client.Send <- d:
 		default:
-			close(client.Send)
-			ws.unregister <- client
+			slow = append(slow, client)
 		}
 	}
+	return slow
 }
 
 // RegisterClient registers a new client
 func (ws *webSocketService) RegisterClient(client *Client) {
-	ws.register <- client
+	ws.enqueue(ws.register, client)
 }
 
 // UnregisterClient unregisters a client
 func (ws *webSocketService) UnregisterClient(client *Client) {
-	ws.unregister <- client
+	ws.enqueue(ws.unregister, client)
+}
+
+// enqueue hands client to the hub loop, dropping it once the hub has stopped
+func (ws *webSocketService) enqueue(ch chan *Client, client *Client) {
+	select {
+	case ch <- client:
+	case <-ws.ctx.Done():
+	}
+}
+
+// enqueueBroadcast hands a message to the hub loop
+func (ws *webSocketService) enqueueBroadcast(message *WebSocketMessage) error {
+	select {
+	case ws.broadcast <- message:
+		return nil
+	case <-ws.ctx.Done():
+		return fmt.Errorf("websocket service stopped")
+	}
 }
 
 // GetClient gets a client by ID
@@ -234,8 +266,7 @@ func (ws *webSocketService) BroadcastToChannel(channel string, message *WebSocke
 		return fmt.Errorf("channel is required")
 	}
 	message.Channel = channel
-	ws.broadcast <- message
-	return nil
+	return ws.enqueueBroadcast(message)
 }
 
 // BroadcastToUser broadcasts a message to a specific user
@@ -244,26 +275,23 @@ func (ws *webSocketService) BroadcastToUser(userID string, message *WebSocketMes
 		return fmt.Errorf("user ID is required")
 	}
 
+	d, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	// Holding the lock keeps the hub from closing a send channel while queuing to it
 	ws.mu.RLock()
 	userClients, exists := ws.users[userID]
+	slow := sendAll(userClients, d)
 	ws.mu.RUnlock()
 
 	if !exists {
 		return fmt.Errorf("no clients found for user %s", userID)
 	}
 
-	d, err := json.Marshal(message)
-	if err != nil {
-		return err
-	}
-
-	for _, client := range userClients {
-		select {
-		case client.Send <- d:
-		default:
-			close(client.Send)
-			ws.unregister <- client
-		}
+	for _, client := range slow {
+		ws.enqueue(ws.unregister, client)
 	}
 
 	return nil
@@ -271,8 +299,7 @@ func (ws *webSocketService) BroadcastToUser(userID string, message *WebSocketMes
 
 // BroadcastToAll broadcasts a message to all connected clients
 func (ws *webSocketService) BroadcastToAll(message *WebSocketMessage) error {
-	ws.broadcast <- message
-	return nil
+	return ws.enqueueBroadcast(message)
 }
 
 // SubscribeToChannel subscribes a client to a channel
@@ -324,13 +351,18 @@ func (ws *webSocketService) UnsubscribeFromChannel(clientID, channel string) err
 	return nil
 }
 
-// Cleanup performs cleanup of resources
+// Cleanup stops the hub and maintenance loops and closes every client connection
 func (ws *webSocketService) Cleanup() {
+	ws.cancel()
+	ws.loops.Wait()
+
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
 	for _, client := range ws.clients {
 		close(client.Send)
+		_ = client.Conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
 		client.Conn.Close()
 	}
 
@@ -339,29 +371,43 @@ func (ws *webSocketService) Cleanup() {
 	ws.users = make(map[string]map[string]*Client)
 }
 
-// StartMaintenanceRoutine starts the maintenance routine
+// StartMaintenanceRoutine starts the maintenance routine, it runs until Cleanup
 func (ws *webSocketService) StartMaintenanceRoutine() {
-	ticker := time.NewTicker(30 * time.Second)
+	ws.loops.Add(1)
 	go func() {
-		for range ticker.C {
-			ws.performMaintenance()
+		defer ws.loops.Done()
+
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ws.ctx.Done():
+				return
+			case <-ticker.C:
+				ws.performMaintenance()
+			}
 		}
 	}()
 }
 
 // performMaintenance performs periodic maintenance tasks
 func (ws *webSocketService) performMaintenance() {
-	ws.mu.Lock()
-	defer ws.mu.Unlock()
-
+	ws.mu.RLock()
 	now := time.Now()
-	for id, client := range ws.clients {
+	var stale []*Client
+	for _, client := range ws.clients {
 		// Check for stale connections
 		if now.Sub(client.lastPing) > 2*time.Minute {
-			logger.Infof(context.Background(), "Removing stale client %s", id)
-			ws.unregister <- client
+			stale = append(stale, client)
 		}
 	}
+	ws.mu.RUnlock()
+
+	// The hub takes the lock to unregister, so hand clients over without holding it
+	for _, client := range stale {
+		logger.Infof(context.Background(), "Removing stale client %s", client.ID)
+		ws.enqueue(ws.unregister, client)
+	}
 }
 
 // Client methods
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ncobase/biz/realtime/data/ent"
+	"ncobase/biz/realtime/data/repository"
+	"ncobase/biz/realtime/structs"
+
+	"github.com/gorilla/websocket"
+)
+
+// waitGoroutines waits for the number of goroutines to drop back to n
+func waitGoroutines(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > n {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("%d goroutines left, want %d:\n%s", runtime.NumGoroutine(), n, buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// within fails the test when fn does not return within a second
+func within(t *testing.T, what string, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("%s did not return", what)
+	}
+}
+
+// newConn returns the server side of a WebSocket connection, the client side is closed with the test
+func newConn(t *testing.T) *websocket.Conn {
+	t.Helper()
+	conns := make(chan *websocket.Conn, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		conns <- conn
+	}))
+	t.Cleanup(server.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return <-conns
+}
+
+func newClient(t *testing.T, id string, buffer int) *Client {
+	return &Client{ID: id, UserID: "u1", Conn: newConn(t), Send: make(chan []byte, buffer), Subscriptions: map[string]bool{}}
+}
+
+// waitUnregistered waits for the hub to drop a client
+func waitUnregistered(t *testing.T, ws WebSocketService, id string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, ok := ws.GetClient(id); !ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("client %s still registered", id)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestCleanupStopsHubLoops(t *testing.T) {
+	client := newClient(t, "c1", 1)
+	before := runtime.NumGoroutine()
+
+	ws := NewWebSocketService(nil)
+	ws.RegisterClient(client)
+	within(t, "Cleanup", ws.Cleanup)
+	waitGoroutines(t, before)
+
+	if _, ok := <-client.Send; ok {
+		t.Fatal("send channel of a client left open")
+	}
+
+	// the stopped hub refuses work instead of blocking the caller
+	within(t, "RegisterClient", func() { ws.RegisterClient(newClient(t, "c2", 1)) })
+	within(t, "UnregisterClient", func() { ws.UnregisterClient(client) })
+	within(t, "BroadcastToAll", func() {
+		if err := ws.BroadcastToAll(&WebSocketMessage{Type: "ping"}); err == nil {
+			t.Error("broadcast to a stopped hub accepted")
+		}
+	})
+}
+
+func TestSlowClientDoesNotStallHub(t *testing.T) {
+	ws := NewWebSocketService(nil)
+	defer ws.Cleanup()
+
+	slow, fast := newClient(t, "slow", 0), newClient(t, "fast", 4)
+	fast.UserID = "u2"
+	ws.RegisterClient(slow)
+	ws.RegisterClient(fast)
+
+	// the slow client cannot take the message, it is dropped and the hub keeps going
+	within(t, "BroadcastToAll", func() { _ = ws.BroadcastToAll(&WebSocketMessage{Type: "first"}) })
+	waitUnregistered(t, ws, "slow")
+	if _, ok := <-slow.Send; ok {
+		t.Fatal("send channel of the dropped client left open")
+	}
+
+	within(t, "BroadcastToAll", func() { _ = ws.BroadcastToAll(&WebSocketMessage{Type: "second"}) })
+	for _, want := range []string{`"first"`, `"second"`} {
+		select {
+		case msg := <-fast.Send:
+			if !strings.Contains(string(msg), want) {
+				t.Fatalf("fast client got %s, want %s", msg, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("fast client did not get %s", want)
+		}
+	}
+
+	// a user broadcast drops the slow clients of the user through the hub
+	slowUser := newClient(t, "slow-user", 0)
+	ws.RegisterClient(slowUser)
+	within(t, "BroadcastToUser", func() { _ = ws.BroadcastToUser("u1", &WebSocketMessage{Type: "third"}) })
+	waitUnregistered(t, ws, "slow-user")
+}
+
+// retryEvents is an event store holding one failed event
+type retryEvents struct {
+	repository.EventRepositoryInterface
+	gets atomic.Int32
+}
+
+func (r *retryEvents) Get(_ context.Context, id string) (*ent.Event, error) {
+	r.gets.Add(1)
+	return &ent.Event{ID: id, Status: "failed"}, nil
+}
+
+func (r *retryEvents) IncrementRetryCount(context.Context, string) error { return nil }
+
+func (r *retryEvents) UpdateStatus(context.Context, string, string, string) error { return nil }
+
+func TestCloseDropsScheduledRetries(t *testing.T) {
+	before := runtime.NumGoroutine()
+	repo := &retryEvents{}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &eventService{eventRepo: repo, ctx: ctx, cancel: cancel}
+
+	params := &structs.RetryParams{RetryOptions: &structs.RetryOptions{DelaySeconds: 3600}}
+	for i := 0; i < 3; i++ {
+		if _, err := s.RetryEvent(context.Background(), "e1", params); err != nil {
+			t.Fatalf("retry: %v", err)
+		}
+	}
+
+	within(t, "Close", s.Close)
+	waitGoroutines(t, before)
+	if n := repo.gets.Load(); n != 3 {
+		t.Fatalf("%d event reads, want only the 3 of scheduling the retries", n)
+	}
+}
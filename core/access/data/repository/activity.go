@@ -8,6 +8,8 @@ import (
 	"ncobase/core/access/data/ent"
 	activityEnt "ncobase/core/access/data/ent/activity"
 	"ncobase/core/access/structs"
+	"ncobase/internal/idgen"
+	"ncobase/internal/utils"
 	"strconv"
 	"time"
 
@@ -29,6 +31,7 @@ type ActivityRepositoryInterface interface {
 	GetRecentByUserID(ctx context.Context, userID string, limit int) ([]*structs.ActivityDocument, error)
 	Search(ctx context.Context, params *structs.SearchActivityParams) ([]*structs.ActivityDocument, int, error)
 	CountX(ctx context.Context, params *structs.ListActivityParams) int
+	EraseByUserID(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error)
 }
 
 // activityRepository implements ActivityRepositoryInterface
@@ -46,15 +49,15 @@ func NewActivityRepository(d *data.Data) ActivityRepositoryInterface {
 
 	return &activityRepository{
 		data:          d,
-		activityCache: cache.NewCache[structs.ActivityDocument](redisClient, "ncse_activities"),
-		userActCache:  cache.NewCache[[]structs.ActivityDocument](redisClient, "ncse_user_activities"),
+		activityCache: utils.NewRetryCache(cache.NewCache[structs.ActivityDocument](redisClient, "ncse_activities")),
+		userActCache:  utils.NewRetryCache(cache.NewCache[[]structs.ActivityDocument](redisClient, "ncse_user_activities")),
 		activityTTL:   time.Hour * 1, // 1 hour cache TTL
 	}
 }
 
 // Create creates a new activity
 func (r *activityRepository) Create(ctx context.Context, userID string, log *structs.CreateActivityRequest) (*structs.ActivityDocument, error) {
-	id := nanoid.PrimaryKey()()
+	id := idgen.New()
 	now := time.Now().UnixMilli()
 
 	doc := &structs.ActivityDocument{
@@ -216,7 +219,7 @@ func (r *activityRepository) GetRecentByUserID(ctx context.Context, userID strin
 	// Cache for future use
 	go func() {
 		if err := r.userActCache.SetArray(context.Background(), cacheKey, result.Items, r.activityTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache user activities %s: %v", userID, err)
+			logger.Debugf(ctx, "Failed to cache user activities %s: %v", userID, err)
 		}
 	}()
 
@@ -324,6 +327,61 @@ countDatabaseFallback:
 	return builder.CountX(ctx)
 }
 
+// EraseByUserID deletes the activities of a user in one transaction, or with anonymize
+// keeps them without the user and metadata. Erased activities no longer match the user,
+// so erasing again finds nothing.
+func (r *activityRepository) EraseByUserID(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error) {
+	var ids []string
+	err = r.data.WithEntTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+		var err error
+		ids, err = tx.Activity.Query().Where(activityEnt.UserIDEQ(userID)).IDs(ctx)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		if anonymize {
+			_, err = tx.Activity.Update().
+				Where(activityEnt.IDIn(ids...)).
+				ClearUserID().
+				ClearMetadata().
+				SetUpdatedAt(time.Now().UnixMilli()).
+				Save(ctx)
+			return err
+		}
+		_, err = tx.Activity.Delete().Where(activityEnt.IDIn(ids...)).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("erase activities of user %s: %w", userID, err)
+	}
+
+	for _, id := range ids {
+		if err := r.activityCache.Delete(ctx, fmt.Sprintf("id:%s", id)); err != nil {
+			logger.Debugf(ctx, "Failed to invalidate activity cache %s: %v", id, err)
+		}
+	}
+	r.invalidateUserActivitiesCache(ctx, userID)
+
+	if r.sc != nil && len(ids) > 0 {
+		if anonymize {
+			rows, err := r.data.GetMasterEntClient().Activity.Query().Where(activityEnt.IDIn(ids...)).All(ctx)
+			if err == nil {
+				for _, row := range rows {
+					if err := r.indexToSearch(ctx, r.convertEntToDocument(row)); err != nil {
+						logger.Warnf(ctx, "Failed to reindex anonymized activity %s: %v", row.ID, err)
+					}
+				}
+			}
+		} else if err := r.sc.BulkDelete(ctx, "activities", ids); err != nil {
+			logger.Warnf(ctx, "Failed to remove erased activities from search index: %v", err)
+		}
+	}
+
+	if anonymize {
+		return 0, len(ids), nil
+	}
+	return len(ids), 0, nil
+}
+
 // indexToSearch indexes an activity to the search engine
 func (r *activityRepository) indexToSearch(ctx context.Context, doc *structs.ActivityDocument) error {
 	indexDoc := map[string]any{
@@ -395,7 +453,7 @@ func (r *activityRepository) getFromDatabase(ctx context.Context, id string) (*s
 
 	row, err := ec.Activity.Get(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return r.convertEntToDocument(row), nil
@@ -7,6 +7,7 @@ import (
 	"ncobase/core/access/data/ent"
 	casbinRuleEnt "ncobase/core/access/data/ent/casbinrule"
 	"ncobase/core/access/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -46,8 +47,8 @@ func NewCasbinRule(d *data.Data) CasbinRuleRepositoryInterface {
 
 	return &casbinRuleRepository{
 		data:            d,
-		ruleCache:       cache.NewCache[ent.CasbinRule](redisClient, "ncse_access:casbin_rules"),
-		pTypeRulesCache: cache.NewCache[[]string](redisClient, "ncse_access:ptype_rules"),
+		ruleCache:       utils.NewRetryCache(cache.NewCache[ent.CasbinRule](redisClient, "ncse_access:casbin_rules")),
+		pTypeRulesCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_access:ptype_rules")),
 		ruleTTL:         time.Hour * 1, // 1 hour cache TTL
 	}
 }
@@ -200,7 +201,7 @@ func (r *casbinRuleRepository) FindByID(ctx context.Context, id string) (*ent.Ca
 	// Execute the builder
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
@@ -1,10 +1,21 @@
 package repository
 
-import "ncobase/core/access/data/ent"
+import (
+	"ncobase/core/access/data/ent"
+	"ncobase/internal/dberr"
+)
 
-// IsNotFound reports whether err indicates a missing entity.
+// ErrNotFound is returned when the requested row does not exist.
+var ErrNotFound = dberr.ErrNotFound
+
+// mapError converts an ent not-found error into ErrNotFound, other errors pass through.
+func mapError(err error) error {
+	return dberr.Map(err, ent.IsNotFound)
+}
+
+// IsNotFound reports whether the error means the row does not exist.
 func IsNotFound(err error) bool {
-	return ent.IsNotFound(err)
+	return dberr.IsNotFound(err) || ent.IsNotFound(err)
 }
 
 // IsConstraintError reports whether err indicates a constraint violation.
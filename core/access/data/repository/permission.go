@@ -7,6 +7,7 @@ import (
 	"ncobase/core/access/data/ent"
 	permissionEnt "ncobase/core/access/data/ent/permission"
 	"ncobase/core/access/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -48,9 +49,9 @@ func NewPermissionRepository(d *data.Data) PermissionRepositoryInterface {
 
 	return &permissionRepository{
 		ec:                 d.GetMasterEntClient(),
-		permissionCache:    cache.NewCache[ent.Permission](redisClient, "ncse_access:permissions"),
-		nameMappingCache:   cache.NewCache[string](redisClient, "ncse_access:permission_names"),
-		actionSubjectCache: cache.NewCache[string](redisClient, "ncse_access:permission_actions"),
+		permissionCache:    utils.NewRetryCache(cache.NewCache[ent.Permission](redisClient, "ncse_access:permissions")),
+		nameMappingCache:   utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_access:permission_names")),
+		actionSubjectCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_access:permission_actions")),
 		permissionTTL:      time.Hour * 4, // 4 hours cache TTL (permissions change less frequently)
 	}
 }
@@ -226,7 +227,7 @@ func (r *permissionRepository) FindPermission(ctx context.Context, params *struc
 
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
@@ -7,6 +7,7 @@ import (
 	"ncobase/core/access/data/ent"
 	roleEnt "ncobase/core/access/data/ent/role"
 	"ncobase/core/access/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -47,8 +48,8 @@ func NewRoleRepository(d *data.Data) RoleRepositoryInterface {
 
 	return &roleRepository{
 		ec:               d.GetMasterEntClient(),
-		roleCache:        cache.NewCache[ent.Role](redisClient, "ncse_access:roles"),
-		slugMappingCache: cache.NewCache[string](redisClient, "ncse_access:role_mappings"),
+		roleCache:        utils.NewRetryCache(cache.NewCache[ent.Role](redisClient, "ncse_access:roles")),
+		slugMappingCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_access:role_mappings")),
 		roleTTL:          time.Hour * 2, // 2 hours cache TTL
 	}
 }
@@ -72,8 +73,8 @@ func (r *roleRepository) Create(ctx context.Context, body *structs.CreateRoleBod
 		return nil, err
 	}
 
-	// Cache the role
-	go r.cacheRole(context.Background(), role)
+	// Cache the role once committed
+	utils.OnCommit(ctx, func() { go r.cacheRole(context.Background(), role) })
 
 	return role, nil
 }
@@ -299,7 +300,7 @@ func (r *roleRepository) FindRole(ctx context.Context, params *structs.FindRole)
 
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
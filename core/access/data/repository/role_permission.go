@@ -9,6 +9,7 @@ import (
 	roleEnt "ncobase/core/access/data/ent/role"
 	rolePermissionEnt "ncobase/core/access/data/ent/rolepermission"
 	"ncobase/core/access/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -48,9 +49,9 @@ func NewRolePermissionRepository(d *data.Data) RolePermissionRepositoryInterface
 
 	return &rolePermissionRepository{
 		data:                 d,
-		rolePermissionCache:  cache.NewCache[ent.RolePermission](redisClient, "ncse_access:role_permissions"),
-		rolePermissionsCache: cache.NewCache[[]string](redisClient, "ncse_access:role_permission_mappings"),
-		permissionRolesCache: cache.NewCache[[]string](redisClient, "ncse_access:permission_role_mappings"),
+		rolePermissionCache:  utils.NewRetryCache(cache.NewCache[ent.RolePermission](redisClient, "ncse_access:role_permissions")),
+		rolePermissionsCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_access:role_permission_mappings")),
+		permissionRolesCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_access:permission_role_mappings")),
 		relationshipTTL:      time.Hour * 2, // 2 hours cache TTL
 	}
 }
@@ -99,7 +100,7 @@ func (r *rolePermissionRepository) GetByPermissionID(ctx context.Context, id str
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "rolePermissionRepo.GetByPermissionID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -151,7 +152,7 @@ func (r *rolePermissionRepository) GetByRoleID(ctx context.Context, id string) (
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "rolePermissionRepo.GetByRoleID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -288,7 +289,7 @@ func (r *rolePermissionRepository) GetPermissionsByRoleID(ctx context.Context, r
 	// Cache permission IDs for future use
 	go func() {
 		if err := r.rolePermissionsCache.SetArray(context.Background(), cacheKey, permissionIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache role permissions %s: %v", rid, err)
+			logger.Debugf(ctx, "Failed to cache role permissions %s: %v", rid, err)
 		}
 	}()
 
@@ -328,7 +329,7 @@ func (r *rolePermissionRepository) GetRolesByPermissionID(ctx context.Context, p
 	// Cache role IDs for future use
 	go func() {
 		if err := r.permissionRolesCache.SetArray(context.Background(), cacheKey, roleIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache permission roles %s: %v", pid, err)
+			logger.Debugf(ctx, "Failed to cache permission roles %s: %v", pid, err)
 		}
 	}()
 
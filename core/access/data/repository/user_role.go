@@ -8,6 +8,7 @@ import (
 	roleEnt "ncobase/core/access/data/ent/role"
 	userRoleEnt "ncobase/core/access/data/ent/userrole"
 	"ncobase/core/access/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -29,6 +30,8 @@ type UserRoleRepositoryInterface interface {
 	GetRolesByUserID(ctx context.Context, userID string) ([]*ent.Role, error)
 	GetUsersByRoleID(ctx context.Context, roleID string) ([]string, error)
 	IsUserInRole(ctx context.Context, userID string, roleID string) (bool, error)
+	ListAfter(ctx context.Context, afterID string, limit int) ([]*ent.UserRole, error)
+	DeleteRows(ctx context.Context, rows []*ent.UserRole) error
 }
 
 // userRoleRepository implements the UserRoleRepositoryInterface.
@@ -46,9 +49,9 @@ func NewUserRoleRepository(d *data.Data) UserRoleRepositoryInterface {
 
 	return &userRoleRepository{
 		data:            d,
-		userRoleCache:   cache.NewCache[ent.UserRole](redisClient, "ncse_access:user_roles"),
-		userRolesCache:  cache.NewCache[[]string](redisClient, "ncse_access:user_role_mappings"),
-		roleUsersCache:  cache.NewCache[[]string](redisClient, "ncse_access:role_user_mappings"),
+		userRoleCache:   utils.NewRetryCache(cache.NewCache[ent.UserRole](redisClient, "ncse_access:user_roles")),
+		userRolesCache:  utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_access:user_role_mappings")),
+		roleUsersCache:  utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_access:role_user_mappings")),
 		relationshipTTL: time.Hour * 2,
 	}
 }
@@ -115,12 +118,14 @@ func (r *userRoleRepository) Create(ctx context.Context, body *structs.UserRole)
 		return nil, err
 	}
 
-	// Cache the relationship and invalidate related caches
-	go func() {
-		r.cacheUserRole(context.Background(), row)
-		r.invalidateUserRolesCache(context.Background(), body.UserID)
-		r.invalidateRoleUsersCache(context.Background(), body.RoleID)
-	}()
+	// Cache the relationship and invalidate related caches once committed
+	utils.OnCommit(ctx, func() {
+		go func() {
+			r.cacheUserRole(context.Background(), row)
+			r.invalidateUserRolesCache(context.Background(), body.UserID)
+			r.invalidateRoleUsersCache(context.Background(), body.RoleID)
+		}()
+	})
 
 	return row, nil
 }
@@ -143,7 +148,7 @@ func (r *userRoleRepository) GetByIDAndRoleID(ctx context.Context, uid, rid stri
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "userRoleRepo.GetByIDAndRoleID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -189,7 +194,7 @@ func (r *userRoleRepository) GetByRoleID(ctx context.Context, id string) (*ent.U
 	row, err := builder.First(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "userRoleRepo.GetByRoleID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -241,6 +246,43 @@ func (r *userRoleRepository) Delete(ctx context.Context, uid, rid string) error
 	return nil
 }
 
+// ListAfter returns up to limit user roles with an ID after afterID, in ID order.
+// Reads go to the master so maintenance sees every committed row.
+func (r *userRoleRepository) ListAfter(ctx context.Context, afterID string, limit int) ([]*ent.UserRole, error) {
+	builder := r.data.GetMasterEntClient().UserRole.Query()
+	if afterID != "" {
+		builder.Where(userRoleEnt.IDGT(afterID))
+	}
+	return builder.Order(ent.Asc(userRoleEnt.FieldID)).Limit(limit).All(ctx)
+}
+
+// DeleteRows deletes the given user roles by ID, other rows of the same user and role are kept
+func (r *userRoleRepository) DeleteRows(ctx context.Context, rows []*ent.UserRole) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	// Use master for writes
+	if _, err := r.data.GetMasterEntClient().UserRole.Delete().Where(userRoleEnt.IDIn(ids...)).Exec(ctx); err != nil {
+		logger.Errorf(ctx, "userRoleRepo.DeleteRows error: %v", err)
+		return err
+	}
+
+	// Invalidate caches right away, maintenance commands exit once done
+	for _, row := range rows {
+		r.invalidateUserRoleCache(ctx, row.UserID, row.RoleID)
+		r.invalidateUserRolesCache(ctx, row.UserID)
+		r.invalidateRoleUsersCache(ctx, row.RoleID)
+	}
+
+	return nil
+}
+
 // DeleteAllByUserID delete all user roles by user ID
 func (r *userRoleRepository) DeleteAllByUserID(ctx context.Context, id string) error {
 	// Get existing relationships for cache invalidation
@@ -326,7 +368,7 @@ func (r *userRoleRepository) GetRolesByUserID(ctx context.Context, userID string
 	// Cache role IDs for future use
 	go func() {
 		if err := r.userRolesCache.SetArray(context.Background(), cacheKey, roleIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache user roles %s: %v", userID, err)
+			logger.Debugf(ctx, "Failed to cache user roles %s: %v", userID, err)
 		}
 	}()
 
@@ -358,7 +400,7 @@ func (r *userRoleRepository) GetUsersByRoleID(ctx context.Context, roleID string
 	// Cache user IDs for future use
 	go func() {
 		if err := r.roleUsersCache.SetArray(context.Background(), cacheKey, userIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache role users %s: %v", roleID, err)
+			logger.Debugf(ctx, "Failed to cache role users %s: %v", roleID, err)
 		}
 	}()
 
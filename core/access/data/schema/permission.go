@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -30,7 +31,7 @@ func (Permission) Annotations() []schema.Annotation {
 // Mixin of the Permission.
 func (Permission) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.Action,
 		mixin.Subject,
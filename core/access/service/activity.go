@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"ncobase/core/access/data"
 	"ncobase/core/access/data/repository"
 	"ncobase/core/access/structs"
@@ -13,6 +15,9 @@ import (
 	"github.com/ncobase/ncore/logging/logger"
 )
 
+// activityExportBatchSize is the number of entries loaded per page while exporting
+const activityExportBatchSize = 500
+
 // ActivityServiceInterface defines service operations for activity
 type ActivityServiceInterface interface {
 	LogActivity(ctx context.Context, userID string, log *structs.CreateActivityRequest) (*structs.Activity, error)
@@ -23,6 +28,8 @@ type ActivityServiceInterface interface {
 	CountX(ctx context.Context, params *structs.ListActivityParams) int
 	DocumentToEntry(doc *structs.ActivityDocument) *structs.Activity
 	DocumentsToEntries(docs []*structs.ActivityDocument) []*structs.Activity
+	ExportUserData(ctx context.Context, userID string, w io.Writer) error
+	EraseUserData(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error)
 }
 
 type activityService struct {
@@ -116,6 +123,40 @@ func (s *activityService) CountX(ctx context.Context, params *structs.ListActivi
 	return s.activity.CountX(ctx, params)
 }
 
+// ExportUserData writes every activity entry of a user to w as JSON lines, oldest first
+func (s *activityService) ExportUserData(ctx context.Context, userID string, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	params := &structs.ListActivityParams{UserID: userID, Limit: activityExportBatchSize, Direction: "forward"}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		result, err := s.activity.List(ctx, params)
+		if err != nil {
+			return err
+		}
+		for _, doc := range result.Items {
+			if err := enc.Encode(s.DocumentToEntry(doc)); err != nil {
+				return err
+			}
+		}
+
+		if !result.HasNext || result.NextCursor == "" {
+			return nil
+		}
+		params.Cursor = result.NextCursor
+	}
+}
+
+// EraseUserData deletes the activity entries of a user, or with anonymize keeps them without the user
+func (s *activityService) EraseUserData(ctx context.Context, userID string, anonymize bool) (int, int, error) {
+	if userID == "" {
+		return 0, 0, errors.New(ecode.FieldIsInvalid("user_id"))
+	}
+	return s.activity.EraseByUserID(ctx, userID, anonymize)
+}
+
 func (s *activityService) DocumentsToEntries(docs []*structs.ActivityDocument) []*structs.Activity {
 	entries := make([]*structs.Activity, len(docs))
 	for i, doc := range docs {
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ncobase/core/access/data"
+	"ncobase/core/access/data/ent"
+	"ncobase/core/access/data/repository"
+	"ncobase/core/access/structs"
+	"sort"
+
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// roleBindingCheckBatchSize is the number of rows read or deleted per query
+const roleBindingCheckBatchSize = 500
+
+// UserDirectory looks up the users role bindings point at, which are kept by the user module
+type UserDirectory interface {
+	// ExistingUsers returns which of the user IDs exist
+	ExistingUsers(ctx context.Context, ids []string) (map[string]bool, error)
+}
+
+// RoleBindingChecker finds and repairs global role bindings that are duplicated
+// or point at a deleted user or role
+type RoleBindingChecker struct {
+	roles     repository.RoleRepositoryInterface
+	userRoles repository.UserRoleRepositoryInterface
+	users     UserDirectory
+}
+
+// NewRoleBindingChecker creates a new role binding checker
+func NewRoleBindingChecker(d *data.Data, users UserDirectory) *RoleBindingChecker {
+	return &RoleBindingChecker{
+		roles:     repository.NewRoleRepository(d),
+		userRoles: repository.NewUserRoleRepository(d),
+		users:     users,
+	}
+}
+
+// Check reports the duplicated and orphaned role bindings and, unless dryRun, deletes them.
+// The oldest of duplicated bindings is kept.
+func (c *RoleBindingChecker) Check(ctx context.Context, dryRun bool) (*structs.RoleBindingReport, error) {
+	report := &structs.RoleBindingReport{DryRun: dryRun}
+
+	var rows []*ent.UserRole
+	afterID := ""
+	for {
+		batch, err := c.userRoles.ListAfter(ctx, afterID, roleBindingCheckBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list role bindings: %w", err)
+		}
+		rows = append(rows, batch...)
+		if len(batch) < roleBindingCheckBatchSize {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].CreatedAt != rows[j].CreatedAt {
+			return rows[i].CreatedAt < rows[j].CreatedAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+	report.Scanned = len(rows)
+
+	userSet, roleSet := map[string]bool{}, map[string]bool{}
+	for _, row := range rows {
+		userSet[row.UserID], roleSet[row.RoleID] = true, true
+	}
+	users, err := c.users.ExistingUsers(ctx, setIDs(userSet))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up users: %w", err)
+	}
+	roles, err := c.existingRoles(ctx, setIDs(roleSet))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up roles: %w", err)
+	}
+
+	var stale []*ent.UserRole
+	held := map[string]bool{}
+	for _, row := range rows {
+		key := row.UserID + "|" + row.RoleID
+		kind := ""
+		switch {
+		case held[key]:
+			kind = structs.IssueDuplicateUserRole
+		case !users[row.UserID] || !roles[row.RoleID]:
+			kind = structs.IssueOrphanedUserRole
+		default:
+			held[key] = true
+			continue
+		}
+		stale = append(stale, row)
+		report.Issues = append(report.Issues, &structs.RoleBindingIssue{Kind: kind, ID: row.ID, UserID: row.UserID, RoleID: row.RoleID})
+	}
+
+	if !dryRun {
+		for start := 0; start < len(stale); start += roleBindingCheckBatchSize {
+			end := min(start+roleBindingCheckBatchSize, len(stale))
+			if err := c.userRoles.DeleteRows(ctx, stale[start:end]); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to delete role bindings: %v", err))
+				continue
+			}
+			for _, issue := range report.Issues[start:end] {
+				issue.Fixed = true
+			}
+			report.Fixed += end - start
+		}
+	}
+
+	logger.Infof(ctx, "Role binding check found %d issues in %d bindings, fixed %d", len(report.Issues), report.Scanned, report.Fixed)
+	return report, nil
+}
+
+// existingRoles returns which of the role IDs exist
+func (c *RoleBindingChecker) existingRoles(ctx context.Context, ids []string) (map[string]bool, error) {
+	found := make(map[string]bool, len(ids))
+	for start := 0; start < len(ids); start += roleBindingCheckBatchSize {
+		end := min(start+roleBindingCheckBatchSize, len(ids))
+		rows, err := c.roles.GetByIDs(ctx, ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if row != nil {
+				found[row.ID] = true
+			}
+		}
+	}
+	return found, nil
+}
+
+// setIDs returns the non-empty IDs of set in order
+func setIDs(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for id := range set {
+		if id != "" {
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"ncobase/core/access/data"
+	"ncobase/core/access/data/ent"
+	"ncobase/core/access/structs"
+
+	_ "github.com/mattn/go-sqlite3"
+	nd "github.com/ncobase/ncore/data"
+	"github.com/ncobase/ncore/data/connection"
+	"github.com/redis/go-redis/v9"
+)
+
+// knownUsers is a user directory of fixed users
+type knownUsers []string
+
+func (u knownUsers) ExistingUsers(_ context.Context, ids []string) (map[string]bool, error) {
+	found := map[string]bool{}
+	for _, id := range ids {
+		for _, user := range u {
+			if user == id {
+				found[id] = true
+			}
+		}
+	}
+	return found, nil
+}
+
+// seedDriftedRoleBindings stores roles r1 and r2 with bindings that drifted apart.
+// User gone and role r-gone were deleted.
+func seedDriftedRoleBindings(t *testing.T) *data.Data {
+	t.Helper()
+	ctx := context.Background()
+	client, err := ent.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+
+	for _, id := range []string{"r1", "r2"} {
+		client.Role.Create().SetID(id).SetName(id).SetSlug(id).SaveX(ctx)
+	}
+	for _, b := range []struct {
+		id, user, role string
+		at             int64
+	}{
+		{"b1", "u1", "r1", 1},
+		{"b2", "u1", "r1", 2}, // duplicate of b1
+		{"b0", "u1", "r1", 3}, // duplicate of b1 though listed first
+		{"b3", "u1", "r2", 1},
+		{"b4", "gone", "r1", 1},
+		{"b5", "u2", "r-gone", 1},
+		{"b6", "u2", "r2", 1},
+	} {
+		client.UserRole.Create().SetID(b.id).SetUserID(b.user).SetRoleID(b.role).SetCreatedAt(b.at).SaveX(ctx)
+	}
+	return &data.Data{Data: &nd.Data{Conn: &connection.Connections{RC: (*redis.Client)(nil)}}, EC: client}
+}
+
+// bindingIssues renders the issues of a report as "kind:id"
+func bindingIssues(report *structs.RoleBindingReport) []string {
+	var keys []string
+	for _, issue := range report.Issues {
+		keys = append(keys, issue.Kind+":"+issue.ID)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestRoleBindingCheck(t *testing.T) {
+	d := seedDriftedRoleBindings(t)
+	checker := NewRoleBindingChecker(d, knownUsers{"u1", "u2"})
+	ctx := context.Background()
+
+	report, err := checker.Check(ctx, true)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	want := "duplicate_user_role:b0 duplicate_user_role:b2 orphaned_user_role:b4 orphaned_user_role:b5"
+	if got := strings.Join(bindingIssues(report), " "); got != want {
+		t.Fatalf("issues %s, want %s", got, want)
+	}
+	if report.Scanned != 7 || report.Fixed != 0 {
+		t.Fatalf("dry run %+v", report)
+	}
+	if n := d.EC.UserRole.Query().CountX(ctx); n != 7 {
+		t.Fatalf("%d bindings after a dry run, want 7", n)
+	}
+
+	report, err = checker.Check(ctx, false)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if report.Fixed != 4 || len(report.Errors) != 0 {
+		t.Fatalf("fixed %d with errors %v, want 4", report.Fixed, report.Errors)
+	}
+	for _, issue := range report.Issues {
+		if !issue.Fixed {
+			t.Fatalf("issue %s of %s not fixed", issue.Kind, issue.ID)
+		}
+	}
+
+	// the oldest of the duplicates is kept
+	var ids []string
+	for _, row := range d.EC.UserRole.Query().AllX(ctx) {
+		ids = append(ids, row.ID)
+	}
+	sort.Strings(ids)
+	if got := strings.Join(ids, " "); got != "b1 b3 b6" {
+		t.Fatalf("bindings left %s, want b1 b3 b6", got)
+	}
+
+	again, err := checker.Check(ctx, true)
+	if err != nil || len(again.Issues) != 0 || again.Scanned != 3 {
+		t.Fatalf("check after repair %+v, %v", again, err)
+	}
+}
@@ -19,6 +19,7 @@ type UserRoleServiceInterface interface {
 	DeleteUserRoleByUserID(ctx context.Context, u string) error
 	DeleteUserRoleByRoleID(ctx context.Context, roleID string) error
 	RemoveRoleFromUser(ctx context.Context, u string, r string) error
+	EraseUserData(ctx context.Context, u string, anonymize bool) (deleted, anonymized int, err error)
 }
 
 // userRoleService is the struct for the service.
@@ -113,3 +114,22 @@ func (s *userRoleService) RemoveRoleFromUser(ctx context.Context, u string, r st
 	}
 	return nil
 }
+
+// EraseUserData removes all roles of a user.
+// Role bindings carry no history, so they are removed even when anonymizing.
+func (s *userRoleService) EraseUserData(ctx context.Context, u string, _ bool) (int, int, error) {
+	if u == "" {
+		return 0, 0, errors.New(ecode.FieldIsInvalid("user_id"))
+	}
+	bindings, err := s.userRole.GetByUserIDs(ctx, []string{u})
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(bindings) == 0 {
+		return 0, 0, nil
+	}
+	if err := s.userRole.DeleteAllByUserID(ctx, u); err != nil {
+		return 0, 0, err
+	}
+	return len(bindings), 0, nil
+}
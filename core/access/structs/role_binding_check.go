@@ -0,0 +1,25 @@
+package structs
+
+// Role binding issue kinds found by the role binding check
+const (
+	IssueDuplicateUserRole = "duplicate_user_role" // user_role row repeating an earlier one
+	IssueOrphanedUserRole  = "orphaned_user_role"  // user_role row of a deleted user or role
+)
+
+// RoleBindingIssue is an inconsistent global role binding
+type RoleBindingIssue struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	RoleID string `json:"role_id"`
+	Fixed  bool   `json:"fixed"`
+}
+
+// RoleBindingReport describes the drift between global role bindings, users and roles
+type RoleBindingReport struct {
+	DryRun  bool                `json:"dry_run"`
+	Scanned int                 `json:"scanned"`
+	Issues  []*RoleBindingIssue `json:"issues,omitempty"`
+	Fixed   int                 `json:"fixed"`
+	Errors  []string            `json:"errors,omitempty"`
+}
@@ -5,6 +5,7 @@ import (
 	"ncobase/core/auth/data"
 	"ncobase/core/auth/handler"
 	"ncobase/core/auth/service"
+	"ncobase/core/auth/structs"
 	"ncobase/internal/middleware"
 	"sync"
 
@@ -36,6 +37,7 @@ type Module struct {
 	s           *service.Service
 	d           *data.Data
 	jtm         *jwt.TokenManager
+	lockout     *structs.LockoutPolicy
 	cleanup     func(name ...string)
 
 	discovery
@@ -82,6 +84,9 @@ func (m *Module) Init(conf *config.Config, em ext.ManagerInterface) (err error)
 	// token manager
 	m.jtm = jwt.NewTokenManager(conf.Auth.JWT.Secret)
 
+	// login lockout policy
+	m.lockout = service.LockoutFromViper(conf.Viper)
+
 	m.em = em
 	m.initialized = true
 
@@ -91,6 +96,7 @@ func (m *Module) Init(conf *config.Config, em ext.ManagerInterface) (err error)
 // PostInit performs any necessary setup after initialization
 func (m *Module) PostInit() error {
 	m.s = service.New(m.d, m.jtm, m.em)
+	m.s.Lockout.SetPolicy(m.lockout)
 	m.h = handler.New(m.s)
 
 	// Subscribe to extension events for dependency refresh
@@ -150,6 +156,9 @@ func (m *Module) RegisterRoutes(r *gin.RouterGroup) {
 		account.PUT("/password", m.h.Account.UpdatePassword)
 		account.GET("/space", m.h.Account.Space)
 		account.GET("/spaces", m.h.Account.Spaces)
+		account.GET("/sessions", m.h.Session.List)
+		account.DELETE("/sessions", m.h.Session.DeactivateAll)
+		account.DELETE("/sessions/:session_id", m.h.Session.Delete)
 
 		twoFactor := account.Group("/2fa")
 		{
@@ -162,10 +171,25 @@ func (m *Module) RegisterRoutes(r *gin.RouterGroup) {
 		}
 	}
 
+	// 2FA enrollment under the auth prefix
+	enroll := r.Group("/auth/2fa", middleware.AuthenticatedUser)
+	{
+		enroll.POST("/enroll", m.h.MFA.SetupTwoFactor)
+		enroll.POST("/verify", m.h.MFA.VerifyTwoFactor)
+	}
+
 	// Token endpoints
+	r.POST("/auth/refresh", m.h.Account.RefreshToken)
 	r.POST("/refresh-token", m.h.Account.RefreshToken)
 	r.GET("/token-status", m.h.Account.TokenStatus)
 
+	// Login lockout endpoints
+	lockouts := authGroup.Group("/lockouts", middleware.AuthenticatedUser, middleware.RequireAdmin())
+	{
+		lockouts.GET("/:user_id", m.h.Lockout.Status)
+		lockouts.DELETE("/:user_id", m.h.Lockout.Unlock)
+	}
+
 	// Session endpoints
 	sessions := authGroup.Group("/sessions", middleware.AuthenticatedUser)
 	{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"ncobase/core/auth/data"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -36,8 +37,8 @@ func NewCaptchaRepository(d *data.Data) CaptchaRepositoryInterface {
 	redisClient := d.GetRedis().(*redis.Client)
 
 	return &captchaRepository{
-		captchaCache: cache.NewCache[types.JSON](redisClient, "ncse_auth:captchas"),
-		attemptCache: cache.NewCache[int](redisClient, "ncse_auth:captcha_attempts"),
+		captchaCache: utils.NewRetryCache(cache.NewCache[types.JSON](redisClient, "ncse_auth:captchas")),
+		attemptCache: utils.NewRetryCache(cache.NewCache[int](redisClient, "ncse_auth:captcha_attempts")),
 		captchaTTL:   5 * time.Minute, // 5 minutes cache TTL
 		maxAttempts:  3,               // Maximum verification attempts
 	}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ncobase/core/auth/data"
+	"ncobase/core/auth/data/ent"
+	authTokenEnt "ncobase/core/auth/data/ent/authtoken"
+	oauthUserEnt "ncobase/core/auth/data/ent/oauthuser"
+	sessionEnt "ncobase/core/auth/data/ent/session"
+	userMFAEnt "ncobase/core/auth/data/ent/usermfa"
+	"slices"
+)
+
+// ErasureRepositoryInterface erases the sign-in state of a user: sessions, issued tokens,
+// two-factor enrollment and linked OAuth accounts
+type ErasureRepositoryInterface interface {
+	TokenIDs(ctx context.Context, userID string) ([]string, error)
+	Erase(ctx context.Context, userID string) (deleted int, err error)
+}
+
+// erasureRepository erases across the auth tables in one transaction, then drops the session caches
+type erasureRepository struct {
+	data    *data.Data
+	session *sessionRepository
+}
+
+// NewErasureRepository creates a new erasure repository
+func NewErasureRepository(d *data.Data) ErasureRepositoryInterface {
+	return &erasureRepository{
+		data:    d,
+		session: NewSessionRepository(d).(*sessionRepository),
+	}
+}
+
+// TokenIDs returns the identifiers of every token issued to a user, with or without a session
+func (r *erasureRepository) TokenIDs(ctx context.Context, userID string) ([]string, error) {
+	client := r.data.GetMasterEntClient()
+
+	issued, err := client.AuthToken.Query().
+		Where(authTokenEnt.UserIDEQ(userID)).
+		IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := client.Session.Query().
+		Where(sessionEnt.UserIDEQ(userID)).
+		Select(sessionEnt.FieldTokenID).
+		Strings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenIDs := append(issued, sessions...)
+	slices.Sort(tokenIDs)
+	return slices.Compact(tokenIDs), nil
+}
+
+// Erase deletes the sessions, issued tokens, two-factor secret with its recovery codes and
+// linked OAuth accounts of a user. Erasing a user without any reports nothing.
+func (r *erasureRepository) Erase(ctx context.Context, userID string) (deleted int, err error) {
+	var sessions []*ent.Session
+
+	err = r.data.WithEntTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+		deleted = 0
+
+		var err error
+		sessions, err = tx.Session.Query().Where(sessionEnt.UserIDEQ(userID)).All(ctx)
+		if err != nil {
+			return err
+		}
+		n, err := tx.Session.Delete().Where(sessionEnt.UserIDEQ(userID)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		deleted += n
+
+		n, err = tx.AuthToken.Delete().Where(authTokenEnt.UserIDEQ(userID)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		deleted += n
+
+		n, err = tx.UserMFA.Delete().Where(userMFAEnt.UserIDEQ(userID)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		deleted += n
+
+		n, err = tx.OAuthUser.Delete().Where(oauthUserEnt.UserIDEQ(userID)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		deleted += n
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("erase sign-in state of user %s: %w", userID, err)
+	}
+
+	for _, session := range sessions {
+		r.session.invalidateSessionCache(ctx, session.ID)
+		r.session.invalidateTokenSessionCache(ctx, session.TokenID)
+	}
+	r.session.invalidateUserSessionsCache(ctx, userID)
+
+	return deleted, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/core/auth/data"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	loginFailuresKey = "ncse_auth:login_failures:%s" // Hash of failure count and last failure time
+	loginLockKey     = "ncse_auth:login_lock:%s"     // Present while the subject is locked
+)
+
+// LoginAttemptRepositoryInterface tracks failed logins per subject, e.g. user:<id> or ip:<addr>
+type LoginAttemptRepositoryInterface interface {
+	RecordFailure(ctx context.Context, subject string, window time.Duration) (int64, error)
+	GetFailures(ctx context.Context, subject string) (int64, int64, error)
+	ResetFailures(ctx context.Context, subject string) error
+	Lock(ctx context.Context, subject string, cooldown time.Duration) error
+	LockedFor(ctx context.Context, subject string) (time.Duration, error)
+	Unlock(ctx context.Context, subject string) error
+}
+
+// loginAttemptRepository implements LoginAttemptRepositoryInterface
+type loginAttemptRepository struct {
+	rc *redis.Client
+}
+
+// NewLoginAttemptRepository creates a new login attempt repository
+func NewLoginAttemptRepository(d *data.Data) LoginAttemptRepositoryInterface {
+	return &loginAttemptRepository{
+		rc: d.GetRedis().(*redis.Client),
+	}
+}
+
+// RecordFailure counts a failed login and returns the failures within the window
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, subject string, window time.Duration) (int64, error) {
+	key := fmt.Sprintf(loginFailuresKey, subject)
+
+	pipe := r.rc.TxPipeline()
+	count := pipe.HIncrBy(ctx, key, "count", 1)
+	pipe.HSet(ctx, key, "last", time.Now().UnixMilli())
+	pipe.Expire(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
+
+// GetFailures returns the failure count and the last failure time in milliseconds
+func (r *loginAttemptRepository) GetFailures(ctx context.Context, subject string) (int64, int64, error) {
+	values, err := r.rc.HMGet(ctx, fmt.Sprintf(loginFailuresKey, subject), "count", "last").Result()
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseRedisInt(values[0]), parseRedisInt(values[1]), nil
+}
+
+// ResetFailures clears the failure counter
+func (r *loginAttemptRepository) ResetFailures(ctx context.Context, subject string) error {
+	return r.rc.Del(ctx, fmt.Sprintf(loginFailuresKey, subject)).Err()
+}
+
+// Lock locks the subject for the cooldown and clears its counter
+func (r *loginAttemptRepository) Lock(ctx context.Context, subject string, cooldown time.Duration) error {
+	pipe := r.rc.TxPipeline()
+	pipe.Set(ctx, fmt.Sprintf(loginLockKey, subject), time.Now().UnixMilli(), cooldown)
+	pipe.Del(ctx, fmt.Sprintf(loginFailuresKey, subject))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// LockedFor returns the remaining lock time, zero when not locked
+func (r *loginAttemptRepository) LockedFor(ctx context.Context, subject string) (time.Duration, error) {
+	ttl, err := r.rc.PTTL(ctx, fmt.Sprintf(loginLockKey, subject)).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// Unlock removes the lock and the failure counter
+func (r *loginAttemptRepository) Unlock(ctx context.Context, subject string) error {
+	return r.rc.Del(ctx, fmt.Sprintf(loginLockKey, subject), fmt.Sprintf(loginFailuresKey, subject)).Err()
+}
+
+// parseRedisInt parses an integer reply, missing values are zero
+func parseRedisInt(v any) int64 {
+	s, ok := v.(string)
+	if !ok {
+		return 0
+	}
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
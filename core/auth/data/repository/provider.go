@@ -4,20 +4,26 @@ import "ncobase/core/auth/data"
 
 // Repository represents all repositories
 type Repository struct {
-	Captcha   CaptchaRepositoryInterface
-	Session   SessionRepositoryInterface
-	CodeAuth  CodeAuthRepositoryInterface
-	UserMFA   UserMFARepositoryInterface
-	AuthToken AuthTokenRepositoryInterface
+	Captcha      CaptchaRepositoryInterface
+	Session      SessionRepositoryInterface
+	CodeAuth     CodeAuthRepositoryInterface
+	UserMFA      UserMFARepositoryInterface
+	AuthToken    AuthTokenRepositoryInterface
+	RefreshToken RefreshTokenRepositoryInterface
+	LoginAttempt LoginAttemptRepositoryInterface
+	Revocation   TokenRevocationRepositoryInterface
 }
 
 // New creates a new repository
 func New(d *data.Data) *Repository {
 	return &Repository{
-		Captcha:   NewCaptchaRepository(d),
-		Session:   NewSessionRepository(d),
-		CodeAuth:  NewCodeAuthRepository(d),
-		UserMFA:   NewUserMFARepository(d),
-		AuthToken: NewAuthTokenRepository(d),
+		Captcha:      NewCaptchaRepository(d),
+		Session:      NewSessionRepository(d),
+		CodeAuth:     NewCodeAuthRepository(d),
+		UserMFA:      NewUserMFARepository(d),
+		AuthToken:    NewAuthTokenRepository(d),
+		RefreshToken: NewRefreshTokenRepository(d),
+		LoginAttempt: NewLoginAttemptRepository(d),
+		Revocation:   NewTokenRevocationRepository(d),
 	}
 }
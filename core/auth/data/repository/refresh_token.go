@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/core/auth/data"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	refreshUsedKey    = "ncse_auth:refresh_used:%s"    // Marks a refresh token as rotated, value is the use time
+	refreshFamilyKey  = "ncse_auth:refresh_family:%s"  // Token IDs issued within a rotation chain
+	refreshRevokedKey = "ncse_auth:refresh_revoked:%s" // Revoked rotation chains
+)
+
+// RefreshTokenRepositoryInterface tracks refresh token rotation chains
+type RefreshTokenRepositoryInterface interface {
+	Consume(ctx context.Context, tokenID string, ttl time.Duration) (bool, int64, error)
+	AddToFamily(ctx context.Context, family, tokenID string, ttl time.Duration) error
+	GetFamily(ctx context.Context, family string) ([]string, error)
+	RevokeFamily(ctx context.Context, family string, ttl time.Duration) error
+	IsFamilyRevoked(ctx context.Context, family string) (bool, error)
+}
+
+// refreshTokenRepository implements RefreshTokenRepositoryInterface
+type refreshTokenRepository struct {
+	rc *redis.Client
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository
+func NewRefreshTokenRepository(d *data.Data) RefreshTokenRepositoryInterface {
+	return &refreshTokenRepository{
+		rc: d.GetRedis().(*redis.Client),
+	}
+}
+
+// Consume atomically marks a refresh token as used.
+// It returns true for the first use, otherwise false with the time of the first use.
+func (r *refreshTokenRepository) Consume(ctx context.Context, tokenID string, ttl time.Duration) (bool, int64, error) {
+	key := fmt.Sprintf(refreshUsedKey, tokenID)
+	now := time.Now().UnixMilli()
+
+	ok, err := r.rc.SetNX(ctx, key, now, ttl).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	if ok {
+		return true, now, nil
+	}
+
+	raw, err := r.rc.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		// Marker expired between the two calls, the token is past its lifetime anyway
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	usedAt, _ := strconv.ParseInt(raw, 10, 64)
+	return false, usedAt, nil
+}
+
+// AddToFamily records a token ID as part of a rotation chain
+func (r *refreshTokenRepository) AddToFamily(ctx context.Context, family, tokenID string, ttl time.Duration) error {
+	key := fmt.Sprintf(refreshFamilyKey, family)
+	pipe := r.rc.TxPipeline()
+	pipe.SAdd(ctx, key, tokenID)
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetFamily returns the token IDs issued within a rotation chain
+func (r *refreshTokenRepository) GetFamily(ctx context.Context, family string) ([]string, error) {
+	return r.rc.SMembers(ctx, fmt.Sprintf(refreshFamilyKey, family)).Result()
+}
+
+// RevokeFamily revokes a rotation chain, the mark outlives every token issued in it
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, family string, ttl time.Duration) error {
+	if familyTTL, err := r.rc.TTL(ctx, fmt.Sprintf(refreshFamilyKey, family)).Result(); err == nil && familyTTL > ttl {
+		ttl = familyTTL
+	}
+	return r.rc.Set(ctx, fmt.Sprintf(refreshRevokedKey, family), time.Now().UnixMilli(), ttl).Err()
+}
+
+// IsFamilyRevoked reports whether a rotation chain has been revoked
+func (r *refreshTokenRepository) IsFamilyRevoked(ctx context.Context, family string) (bool, error) {
+	n, err := r.rc.Exists(ctx, fmt.Sprintf(refreshRevokedKey, family)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
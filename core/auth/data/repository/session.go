@@ -7,6 +7,7 @@ import (
 	"ncobase/core/auth/data/ent"
 	sessionEnt "ncobase/core/auth/data/ent/session"
 	"ncobase/core/auth/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -26,6 +27,7 @@ type SessionRepositoryInterface interface {
 	List(ctx context.Context, params *structs.ListSessionParams) ([]*ent.Session, error)
 	Delete(ctx context.Context, id string) error
 	DeactivateByUserID(ctx context.Context, userID string) error
+	GetActiveTokenIDs(ctx context.Context, userID string) ([]string, error)
 	DeactivateByTokenID(ctx context.Context, tokenID string) error
 	UpdateLastAccess(ctx context.Context, tokenID string) error
 	CleanupExpiredSessions(ctx context.Context) error
@@ -47,9 +49,9 @@ func NewSessionRepository(d *data.Data) SessionRepositoryInterface {
 
 	return &sessionRepository{
 		data:              d,
-		sessionCache:      cache.NewCache[ent.Session](redisClient, "ncse_auth:sessions"),
-		tokenSessionCache: cache.NewCache[ent.Session](redisClient, "ncse_auth:token_sessions"),
-		userSessionsCache: cache.NewCache[[]string](redisClient, "ncse_auth:user_sessions"),
+		sessionCache:      utils.NewRetryCache(cache.NewCache[ent.Session](redisClient, "ncse_auth:sessions")),
+		tokenSessionCache: utils.NewRetryCache(cache.NewCache[ent.Session](redisClient, "ncse_auth:token_sessions")),
+		userSessionsCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_auth:user_sessions")),
 		sessionTTL:        time.Hour * 2, // 2 hours cache TTL
 	}
 }
@@ -112,7 +114,7 @@ func (r *sessionRepository) GetByID(ctx context.Context, id string) (*ent.Sessio
 	client := r.data.GetSlaveEntClient()
 	session, err := client.Session.Get(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -133,7 +135,7 @@ func (r *sessionRepository) GetByTokenID(ctx context.Context, tokenID string) (*
 	client := r.data.GetSlaveEntClient()
 	session, err := client.Session.Query().Where(sessionEnt.TokenIDEQ(tokenID)).Only(ctx)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -291,6 +293,15 @@ func (r *sessionRepository) DeactivateByUserID(ctx context.Context, userID strin
 	return nil
 }
 
+// GetActiveTokenIDs returns the token IDs of the active sessions of a user
+func (r *sessionRepository) GetActiveTokenIDs(ctx context.Context, userID string) ([]string, error) {
+	client := r.data.GetSlaveEntClient()
+	return client.Session.Query().
+		Where(sessionEnt.UserIDEQ(userID), sessionEnt.IsActiveEQ(true)).
+		Select(sessionEnt.FieldTokenID).
+		Strings(ctx)
+}
+
 // DeactivateByTokenID deactivates a session by token ID
 func (r *sessionRepository) DeactivateByTokenID(ctx context.Context, tokenID string) error {
 	client := r.data.GetMasterEntClient()
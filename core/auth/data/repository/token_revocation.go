@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ncobase/core/auth/data"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// revokedTokenKey marks a token identifier as revoked
+const revokedTokenKey = "ncse_auth:revoked_tokens:%s"
+
+// TokenRevocationRepositoryInterface keeps the revocation list of token identifiers
+type TokenRevocationRepositoryInterface interface {
+	Revoke(ctx context.Context, ttl time.Duration, tokenIDs ...string) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+// tokenRevocationRepository implements TokenRevocationRepositoryInterface
+type tokenRevocationRepository struct {
+	rc *redis.Client
+}
+
+// NewTokenRevocationRepository creates a new token revocation repository
+func NewTokenRevocationRepository(d *data.Data) TokenRevocationRepositoryInterface {
+	return &tokenRevocationRepository{
+		rc: d.GetRedis().(*redis.Client),
+	}
+}
+
+// Revoke adds token identifiers to the revocation list until the tokens would have expired
+func (r *tokenRevocationRepository) Revoke(ctx context.Context, ttl time.Duration, tokenIDs ...string) error {
+	if len(tokenIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	pipe := r.rc.Pipeline()
+	for _, tokenID := range tokenIDs {
+		if tokenID == "" {
+			continue
+		}
+		pipe.Set(ctx, fmt.Sprintf(revokedTokenKey, tokenID), now, ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// IsRevoked reports whether a token identifier has been revoked
+func (r *tokenRevocationRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	n, err := r.rc.Exists(ctx, fmt.Sprintf(revokedTokenKey, tokenID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
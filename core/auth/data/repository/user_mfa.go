@@ -2,11 +2,18 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"ncobase/core/auth/data"
 	"ncobase/core/auth/data/ent"
 	userMFAEnt "ncobase/core/auth/data/ent/usermfa"
+	"time"
+
+	"github.com/redis/go-redis/v9"
 )
 
+// recoveryClaimKey marks a recovery code as being consumed
+const recoveryClaimKey = "ncse_auth:mfa_recovery_claims:%s:%s"
+
 // UserMFARepositoryInterface defines user MFA repository operations
 type UserMFARepositoryInterface interface {
 	GetByUserID(ctx context.Context, userID string) (*ent.UserMFA, error)
@@ -19,6 +26,7 @@ type UserMFARepositoryInterface interface {
 	ResetFailedAttempts(ctx context.Context, userID string, lastUsedAt int64) (int, error)
 	IncrementFailedAttempts(ctx context.Context, userID string, attempts int) (int, error)
 	LockAccount(ctx context.Context, userID string, lockedUntil int64) (int, error)
+	ClaimRecoveryCode(ctx context.Context, userID string, hash string) (bool, error)
 }
 
 // userMFARepository implements UserMFARepositoryInterface
@@ -134,3 +142,10 @@ func (r *userMFARepository) LockAccount(ctx context.Context, userID string, lock
 		SetFailedAttempts(0).
 		Save(ctx)
 }
+
+// ClaimRecoveryCode atomically claims a recovery code hash, only the first caller gets true.
+// The claim covers the window until the hash is removed from the stored list.
+func (r *userMFARepository) ClaimRecoveryCode(ctx context.Context, userID string, hash string) (bool, error) {
+	rc := r.data.GetRedis().(*redis.Client)
+	return rc.SetNX(ctx, fmt.Sprintf(recoveryClaimKey, userID, hash), time.Now().UnixMilli(), time.Hour).Result()
+}
@@ -9,6 +9,7 @@ import (
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/index"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 )
 
 // AuthToken holds the schema definition for the AuthToken entity.
@@ -29,7 +30,7 @@ func (AuthToken) Annotations() []schema.Annotation {
 // Mixin of the AuthToken.
 func (AuthToken) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Disabled,
 		mixin.TimeAt{},
 		mixin.UserID,
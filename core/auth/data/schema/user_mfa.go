@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/entsql"
@@ -29,7 +30,7 @@ func (UserMFA) Annotations() []schema.Annotation {
 // Mixin of the UserMFA.
 func (UserMFA) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.UserID,
 		mixin.TimeAt{},
 	}
@@ -21,4 +21,5 @@ type PublisherInterface interface {
 	PublishAuthCodeSent(ctx context.Context, userID string, metadata *types.JSON)
 	PublishSessionCreated(ctx context.Context, userID, sessionID string, metadata *types.JSON)
 	PublishSessionDestroyed(ctx context.Context, userID, sessionID string, metadata *types.JSON)
+	PublishLockout(ctx context.Context, userID string, metadata *types.JSON)
 }
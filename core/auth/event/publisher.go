@@ -24,6 +24,7 @@ const (
 	UserSessionCreated   = "user.session_created"
 	UserSessionDestroyed = "user.session_destroyed"
 	UserSessionExpired   = "user.session_expired"
+	AuthLockout          = "auth.lockout"
 )
 
 // publisher implements PublisherInterface
@@ -121,6 +122,11 @@ func (p *publisher) PublishSessionDestroyed(ctx context.Context, userID, session
 	p.em.PublishEvent(UserSessionDestroyed, eventData)
 }
 
+// PublishLockout publishes login lockout event, userID is empty for IP lockouts
+func (p *publisher) PublishLockout(ctx context.Context, userID string, metadata *types.JSON) {
+	p.publishEvent(ctx, AuthLockout, userID, "Login locked after repeated failures", metadata)
+}
+
 // publishEvent is helper method to publish events
 func (p *publisher) publishEvent(_ context.Context, eventName, userID, details string, metadata *types.JSON) {
 	if p.em == nil {
@@ -1,14 +1,17 @@
 package handler
 
 import (
+	"errors"
 	"ncobase/core/auth/service"
 	"ncobase/core/auth/structs"
 	userStructs "ncobase/core/user/structs"
+	"ncobase/internal/httpcache"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ctxutil"
 	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
+	"github.com/ncobase/ncore/utils/convert"
 
 	"github.com/gin-gonic/gin"
 )
@@ -85,6 +88,7 @@ func (h *accountHandler) Register(c *gin.Context) {
 // @Param body body structs.LoginBody true "LoginBody object"
 // @Success 200 {object} map[string]any{id=string,access_token=string} "success"
 // @Failure 400 {object} resp.Exception "bad request"
+// @Failure 429 {object} resp.Exception "too many failed attempts"
 // @Router /login [post]
 func (h *accountHandler) Login(c *gin.Context) {
 	body := &structs.LoginBody{}
@@ -105,7 +109,9 @@ func (h *accountHandler) Login(c *gin.Context) {
 	}
 
 	result, err := h.s.Account.Login(c.Request.Context(), body)
-	if err != nil {
+	if failLoginBlocked(c, err) {
+		return
+	} else if err != nil {
 		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
 		return
 	}
@@ -165,14 +171,16 @@ func (h *accountHandler) Logout(c *gin.Context) {
 // RefreshToken handles token refresh.
 //
 // @Summary RefreshToken token
-// @Description Refresh the current user's access token.
+// @Description Refresh the current user's access token. The refresh token is rotated on every use,
+// @Description replaying a rotated token revokes every token issued from the same login.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param body body structs.RefreshTokenBody true "Refresh token"
 // @Success 200 {object} map[string]any{id=string,access_token=string,refresh_token=string} "success"
 // @Failure 400 {object} resp.Exception "bad request"
-// @Router /refresh [post]
+// @Failure 401 {object} resp.Exception "refresh token reused"
+// @Router /auth/refresh [post]
 func (h *accountHandler) RefreshToken(c *gin.Context) {
 	body := &structs.RefreshTokenBody{}
 	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, body); err != nil {
@@ -184,7 +192,10 @@ func (h *accountHandler) RefreshToken(c *gin.Context) {
 	}
 
 	result, err := h.s.Account.RefreshToken(c.Request.Context(), body.RefreshToken)
-	if err != nil {
+	if errors.Is(err, service.ErrRefreshTokenReused) || errors.Is(err, service.ErrRefreshTokenRotated) {
+		resp.Fail(c.Writer, resp.UnAuthorized(err.Error()))
+		return
+	} else if err != nil {
 		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
 		return
 	}
@@ -256,7 +267,9 @@ func (h *accountHandler) UpdatePassword(c *gin.Context) {
 // @Description Retrieve the space associated with the current user.
 // @Tags auth
 // @Produce json
+// @Param If-None-Match header string false "ETag of the cached space"
 // @Success 200 {object} structs.ReadSpace "success"
+// @Success 304 "not modified"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /account/space [get]
 // @Security Bearer
@@ -266,6 +279,10 @@ func (h *accountHandler) Space(c *gin.Context) {
 		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
 		return
 	}
+	updatedAt := convert.ToValue(result.UpdatedAt)
+	if httpcache.NotModified(c, httpcache.ETag(result.ID, updatedAt), updatedAt) {
+		return
+	}
 	resp.Success(c.Writer, result)
 }
 
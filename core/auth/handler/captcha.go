@@ -6,11 +6,11 @@ import (
 	"io"
 	"ncobase/core/auth/service"
 	"ncobase/core/auth/structs"
+	"ncobase/internal/validation"
 	"path/filepath"
 	"strings"
 
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/dchest/captcha"
 	"github.com/gin-gonic/gin"
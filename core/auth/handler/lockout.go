@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"ncobase/core/auth/service"
+	"ncobase/core/auth/structs"
+	"net/http"
+	"strconv"
+
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/net/resp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LockoutHandlerInterface defines the login lockout handler interface
+type LockoutHandlerInterface interface {
+	Status(c *gin.Context)
+	Unlock(c *gin.Context)
+}
+
+// lockoutHandler implements the LockoutHandlerInterface
+type lockoutHandler struct {
+	s *service.Service
+}
+
+// NewLockoutHandler creates a new login lockout handler
+func NewLockoutHandler(svc *service.Service) LockoutHandlerInterface {
+	return &lockoutHandler{
+		s: svc,
+	}
+}
+
+// Status handles reading the login lockout status of a user
+//
+// @Summary Get login lockout status
+// @Description Retrieve the failed login count and lock state of a user
+// @Tags auth
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} structs.LockoutStatus "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /lockouts/{user_id} [get]
+// @Security Bearer
+func (h *lockoutHandler) Status(c *gin.Context) {
+	result, err := h.s.Lockout.Status(c.Request.Context(), c.Param("user_id"))
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
+
+// Unlock handles removing the login lock of a user
+//
+// @Summary Unlock user login
+// @Description Remove the login lock and failure count of a user before the cooldown ends
+// @Tags auth
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} resp.Exception "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /lockouts/{user_id} [delete]
+// @Security Bearer
+func (h *lockoutHandler) Unlock(c *gin.Context) {
+	if err := h.s.Lockout.Unlock(c.Request.Context(), c.Param("user_id")); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer)
+}
+
+// failLoginBlocked responds with 429 and Retry-After when err is a login block
+func failLoginBlocked(c *gin.Context, err error) bool {
+	var blocked *structs.LoginBlockedError
+	if !errors.As(err, &blocked) {
+		return false
+	}
+
+	c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(blocked.RetryAfter.Seconds())), 10))
+	resp.Fail(c.Writer, &resp.Exception{
+		Status:  http.StatusTooManyRequests,
+		Code:    ecode.FailedTooManyTimes,
+		Message: blocked.Error(),
+	})
+	return true
+}
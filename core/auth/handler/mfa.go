@@ -3,9 +3,9 @@ package handler
 import (
 	"ncobase/core/auth/service"
 	"ncobase/core/auth/structs"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -93,6 +93,7 @@ func (h *mfaHandler) GetTwoFactorStatus(c *gin.Context) {
 // @Success 200 {object} structs.TwoFactorSetupResponse "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /account/2fa/setup [post]
+// @Router /auth/2fa/enroll [post]
 // @Security Bearer
 func (h *mfaHandler) SetupTwoFactor(c *gin.Context) {
 	body := &structs.TwoFactorSetupBody{}
@@ -11,6 +11,7 @@ type Handler struct {
 	Captcha  CaptchaHandlerInterface
 	Session  SessionHandlerInterface
 	MFA      MFAHandlerInterface
+	Lockout  LockoutHandlerInterface
 }
 
 // New creates a new auth handler.
@@ -21,5 +22,6 @@ func New(svc *service.Service) *Handler {
 		Captcha:  NewCaptchaHandler(svc),
 		Session:  NewSessionHandler(svc),
 		MFA:      NewMFAHandler(svc),
+		Lockout:  NewLockoutHandler(svc),
 	}
 }
@@ -3,10 +3,10 @@ package handler
 import (
 	"ncobase/core/auth/service"
 	"ncobase/core/auth/structs"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ctxutil"
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -44,6 +44,7 @@ func NewSessionHandler(svc *service.Service) SessionHandlerInterface {
 // @Success 200 {array} structs.ReadSession "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sessions [get]
+// @Router /account/sessions [get]
 // @Security Bearer
 func (h *sessionHandler) List(c *gin.Context) {
 	params := &structs.ListSessionParams{
@@ -99,13 +100,14 @@ func (h *sessionHandler) Get(c *gin.Context) {
 // Delete handles deleting a specific session
 //
 // @Summary Delete session
-// @Description Delete a specific session (logout from device)
+// @Description Delete a specific session (logout from device), its tokens are rejected from then on
 // @Tags auth
 // @Produce json
 // @Param session_id path string true "Session ID"
 // @Success 200 {object} resp.Exception "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sessions/{session_id} [delete]
+// @Router /account/sessions/{session_id} [delete]
 // @Security Bearer
 func (h *sessionHandler) Delete(c *gin.Context) {
 	sessionID := c.Param("session_id")
@@ -140,6 +142,7 @@ func (h *sessionHandler) Delete(c *gin.Context) {
 // @Success 200 {object} resp.Exception "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sessions/deactivate-all [post]
+// @Router /account/sessions [delete]
 // @Security Bearer
 func (h *sessionHandler) DeactivateAll(c *gin.Context) {
 	currentUserID := ctxutil.GetUserID(c.Request.Context())
@@ -39,12 +39,14 @@ type accountService struct {
 	jtm *jwt.TokenManager
 	ep  event.PublisherInterface
 
-	cas           CodeAuthServiceInterface
-	ats           AuthSpaceServiceInterface
-	ss            SessionServiceInterface
-	mfa           MFAServiceInterface
-	codeAuthRepo  repository.CodeAuthRepositoryInterface
-	authTokenRepo repository.AuthTokenRepositoryInterface
+	cas              CodeAuthServiceInterface
+	ats              AuthSpaceServiceInterface
+	ss               SessionServiceInterface
+	mfa              MFAServiceInterface
+	lockout          LockoutServiceInterface
+	codeAuthRepo     repository.CodeAuthRepositoryInterface
+	authTokenRepo    repository.AuthTokenRepositoryInterface
+	refreshTokenRepo repository.RefreshTokenRepositoryInterface
 
 	usw  *wrapper.UserServiceWrapper
 	tsw  *wrapper.SpaceServiceWrapper
@@ -53,34 +55,48 @@ type accountService struct {
 }
 
 // NewAccountService creates a new service.
-func NewAccountService(d *data.Data, jtm *jwt.TokenManager, ep event.PublisherInterface, cas CodeAuthServiceInterface, ats AuthSpaceServiceInterface, ss SessionServiceInterface, mfa MFAServiceInterface,
+func NewAccountService(d *data.Data, jtm *jwt.TokenManager, ep event.PublisherInterface, cas CodeAuthServiceInterface, ats AuthSpaceServiceInterface, ss SessionServiceInterface, mfa MFAServiceInterface, lockout LockoutServiceInterface,
 	usw *wrapper.UserServiceWrapper,
 	tsw *wrapper.SpaceServiceWrapper,
 	asw *wrapper.AccessServiceWrapper,
 	ugsw *wrapper.OrganizationServiceWrapper,
 ) AccountServiceInterface {
 	return &accountService{
-		d:             d,
-		jtm:           jtm,
-		ep:            ep,
-		cas:           cas,
-		ats:           ats,
-		ss:            ss,
-		mfa:           mfa,
-		codeAuthRepo:  repository.NewCodeAuthRepository(d),
-		authTokenRepo: repository.NewAuthTokenRepository(d),
-		usw:           usw,
-		tsw:           tsw,
-		asw:           asw,
-		ugsw:          ugsw,
+		d:                d,
+		jtm:              jtm,
+		ep:               ep,
+		cas:              cas,
+		ats:              ats,
+		ss:               ss,
+		mfa:              mfa,
+		lockout:          lockout,
+		codeAuthRepo:     repository.NewCodeAuthRepository(d),
+		authTokenRepo:    repository.NewAuthTokenRepository(d),
+		refreshTokenRepo: repository.NewRefreshTokenRepository(d),
+		usw:              usw,
+		tsw:              tsw,
+		asw:              asw,
+		ugsw:             ugsw,
 	}
 }
 
 // Login handles user login authentication
 func (s *accountService) Login(ctx context.Context, body *structs.LoginBody) (*AuthResponse, error) {
+	// Reject clients locked after repeated failures
+	ip, _, _ := ctxutil.GetClientInfo(ctx)
+	if err := s.lockout.CheckIP(ctx, ip); err != nil {
+		return nil, err
+	}
+
 	// Verify user credentials
 	user, err := s.usw.FindUser(ctx, &userStructs.FindUser{Username: body.Username})
 	if err = handleEntError(ctx, "User", err); err != nil {
+		s.lockout.RecordFailure(ctx, "", ip)
+		return nil, err
+	}
+
+	// Locked or delayed accounts are rejected before the password is checked
+	if err := s.lockout.CheckAccount(ctx, user.ID); err != nil {
 		return nil, err
 	}
 
@@ -93,6 +109,7 @@ func (s *accountService) Login(ctx context.Context, body *structs.LoginBody) (*A
 	switch v := verifyResult.(type) {
 	case userService.VerifyPasswordResult:
 		if !v.Valid {
+			s.lockout.RecordFailure(ctx, user.ID, ip)
 			return nil, errors.New(v.Error)
 		} else if v.Valid && v.NeedsPasswordSet {
 			if validator.IsEmpty(user.Email) {
@@ -117,6 +134,9 @@ func (s *accountService) Login(ctx context.Context, body *structs.LoginBody) (*A
 		return nil, v
 	}
 
+	// Correct password, start counting afresh
+	s.lockout.Reset(ctx, user.ID)
+
 	// MFA challenge
 	if enabled, err := s.mfaEnabled(ctx, user.ID); err != nil {
 		return nil, err
@@ -220,7 +240,7 @@ func (s *accountService) mfaVerify(ctx context.Context, token string, code strin
 	return s.mfa.VerifyLoginChallenge(ctx, token, code, recoveryCode)
 }
 
-// RefreshToken refreshes access token using refresh token
+// RefreshToken refreshes access token using refresh token, rotating the refresh token on every use
 func (s *accountService) RefreshToken(ctx context.Context, refreshToken string) (*AuthResponse, error) {
 	// Verify refresh token
 	payload, err := s.jtm.DecodeToken(refreshToken)
@@ -268,11 +288,18 @@ func (s *accountService) RefreshToken(ctx context.Context, refreshToken string)
 		return nil, err
 	}
 
+	// Rotate the presented refresh token, a replay revokes the whole chain
+	family, err := s.consumeRefreshToken(ctx, payload, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate new authentication response
-	authResp, err := generateAuthResponse(ctx, s.jtm, s.authTokenRepo, tokenPayload, s.ss, "token_refresh")
+	authResp, err := generateFamilyAuthResponse(ctx, s.jtm, s.authTokenRepo, tokenPayload, s.ss, "token_refresh", family)
 	if err != nil {
 		return nil, err
 	}
+	s.trackRefreshToken(ctx, family, authResp.RefreshToken)
 
 	// Set additional response data
 	authResp.SpaceIDs = spaceIDs
@@ -9,6 +9,7 @@ import (
 	"ncobase/core/auth/structs"
 	"ncobase/core/auth/wrapper"
 	userStructs "ncobase/core/user/structs"
+	"ncobase/internal/dberr"
 	"net/http"
 	"strings"
 	"time"
@@ -364,8 +365,9 @@ func mapKeys(codes map[string]struct{}) []string {
 	return result
 }
 
-// generateUserTokens generates access and refresh tokens for API authentication
-func generateUserTokens(jtm *jwt.TokenManager, payload map[string]any, tokenID string) (string, string) {
+// generateUserTokens generates access and refresh tokens for API authentication,
+// the refresh token carries its rotation family, a new chain starts at tokenID
+func generateUserTokens(jtm *jwt.TokenManager, payload map[string]any, tokenID, family string) (string, string) {
 	userID, ok := payload["user_id"].(string)
 	if !ok || userID == "" {
 		return "", ""
@@ -374,9 +376,14 @@ func generateUserTokens(jtm *jwt.TokenManager, payload map[string]any, tokenID s
 	// Generate access token (shorter expiry for security)
 	accessToken, _ := jtm.GenerateAccessToken(tokenID, payload)
 
+	if family == "" {
+		family = tokenID
+	}
+
 	// Generate refresh token (longer expiry)
 	refreshToken, _ := jtm.GenerateRefreshToken(tokenID, types.JSON{
 		"user_id": userID,
+		"family":  family,
 	})
 
 	return accessToken, refreshToken
@@ -390,6 +397,19 @@ func generateAuthResponse(
 	payload map[string]any,
 	sessionSvc SessionServiceInterface,
 	loginMethod string,
+) (*AuthResponse, error) {
+	return generateFamilyAuthResponse(ctx, jtm, authTokenRepo, payload, sessionSvc, loginMethod, "")
+}
+
+// generateFamilyAuthResponse generates authentication response whose refresh token continues the given rotation family
+func generateFamilyAuthResponse(
+	ctx context.Context,
+	jtm *jwt.TokenManager,
+	authTokenRepo repository.AuthTokenRepositoryInterface,
+	payload map[string]any,
+	sessionSvc SessionServiceInterface,
+	loginMethod string,
+	family string,
 ) (*AuthResponse, error) {
 	userID, ok := payload["user_id"].(string)
 	if !ok || userID == "" {
@@ -406,7 +426,7 @@ func generateAuthResponse(
 	}
 
 	// Generate tokens for API authentication
-	accessToken, refreshToken := generateUserTokens(jtm, payload, authToken.ID)
+	accessToken, refreshToken := generateUserTokens(jtm, payload, authToken.ID, family)
 	if accessToken == "" || refreshToken == "" {
 		return nil, errors.New("failed to generate tokens")
 	}
@@ -491,7 +511,7 @@ func RefreshUserToken(jtm *jwt.TokenManager, userID, tokenID, originalRefreshTok
 func handleEntError(ctx context.Context, k string, err error) error {
 	if repository.IsNotFound(err) {
 		logger.Errorf(ctx, "Error not found in %s: %v", k, err)
-		return errors.New(ecode.NotExist(k))
+		return dberr.NotFound(ecode.NotExist(k))
 	}
 	if repository.IsConstraintError(err) {
 		logger.Errorf(ctx, "Error constraint in %s: %v", k, err)
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"ncobase/core/auth/data"
+	"ncobase/core/auth/data/repository"
+	"ncobase/core/auth/event"
+	"ncobase/core/auth/structs"
+	"sync"
+	"time"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/types"
+	"github.com/spf13/viper"
+)
+
+// LockoutServiceInterface throttles failed logins per account and per client IP
+type LockoutServiceInterface interface {
+	SetPolicy(policy *structs.LockoutPolicy)
+	CheckIP(ctx context.Context, ip string) error
+	CheckAccount(ctx context.Context, userID string) error
+	RecordFailure(ctx context.Context, userID, ip string)
+	Reset(ctx context.Context, userID string)
+	Status(ctx context.Context, userID string) (*structs.LockoutStatus, error)
+	Unlock(ctx context.Context, userID string) error
+}
+
+// lockoutService keeps counters in Redis so every instance shares them.
+// Redis errors never block a login, they are only logged.
+type lockoutService struct {
+	attempts repository.LoginAttemptRepositoryInterface
+	ep       event.PublisherInterface
+
+	mu     sync.RWMutex
+	policy *structs.LockoutPolicy
+}
+
+// NewLockoutService creates a new lockout service
+func NewLockoutService(d *data.Data, ep event.PublisherInterface) LockoutServiceInterface {
+	return &lockoutService{
+		attempts: repository.NewLoginAttemptRepository(d),
+		ep:       ep,
+		policy:   structs.DefaultLockoutPolicy(),
+	}
+}
+
+// LockoutFromViper reads the lockout policy from auth.lockout.*
+func LockoutFromViper(v *viper.Viper) *structs.LockoutPolicy {
+	policy := structs.DefaultLockoutPolicy()
+	if v == nil {
+		return policy
+	}
+
+	if v.IsSet("auth.lockout.enabled") {
+		policy.Enabled = v.GetBool("auth.lockout.enabled")
+	}
+	if v.IsSet("auth.lockout.max_attempts") {
+		policy.MaxAttempts = v.GetInt("auth.lockout.max_attempts")
+	}
+	if v.IsSet("auth.lockout.ip_max_attempts") {
+		policy.IPMaxAttempts = v.GetInt("auth.lockout.ip_max_attempts")
+	}
+	if v.IsSet("auth.lockout.delay_after") {
+		policy.DelayAfter = v.GetInt("auth.lockout.delay_after")
+	}
+	if v.IsSet("auth.lockout.base_delay") {
+		policy.BaseDelay = time.Duration(v.GetInt("auth.lockout.base_delay")) * time.Second
+	}
+	if v.IsSet("auth.lockout.max_delay") {
+		policy.MaxDelay = time.Duration(v.GetInt("auth.lockout.max_delay")) * time.Second
+	}
+	if v.IsSet("auth.lockout.window") {
+		policy.Window = time.Duration(v.GetInt("auth.lockout.window")) * time.Second
+	}
+	if v.IsSet("auth.lockout.cooldown") {
+		policy.Cooldown = time.Duration(v.GetInt("auth.lockout.cooldown")) * time.Second
+	}
+	return policy
+}
+
+// SetPolicy replaces the lockout policy
+func (s *lockoutService) SetPolicy(policy *structs.LockoutPolicy) {
+	if policy == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policy = policy
+}
+
+// getPolicy returns the current policy
+func (s *lockoutService) getPolicy() *structs.LockoutPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.policy
+}
+
+// CheckIP rejects logins from a locked client IP
+func (s *lockoutService) CheckIP(ctx context.Context, ip string) error {
+	if !s.getPolicy().Enabled || ip == "" {
+		return nil
+	}
+	return s.checkLock(ctx, ipSubject(ip))
+}
+
+// CheckAccount rejects logins to a locked account, or before the progressive delay has passed
+func (s *lockoutService) CheckAccount(ctx context.Context, userID string) error {
+	policy := s.getPolicy()
+	if !policy.Enabled || userID == "" {
+		return nil
+	}
+
+	subject := userSubject(userID)
+	if err := s.checkLock(ctx, subject); err != nil {
+		return err
+	}
+
+	failures, last, err := s.attempts.GetFailures(ctx, subject)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to read login failures of %s: %v", subject, err)
+		return nil
+	}
+	if delay := policy.Delay(failures); delay > 0 {
+		if wait := time.Until(time.UnixMilli(last).Add(delay)); wait > 0 {
+			return &structs.LoginBlockedError{RetryAfter: wait}
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed login for the account and the client IP, locking either on its threshold
+func (s *lockoutService) RecordFailure(ctx context.Context, userID, ip string) {
+	policy := s.getPolicy()
+	if !policy.Enabled {
+		return
+	}
+
+	if userID != "" {
+		s.recordFailure(ctx, policy, userSubject(userID), policy.MaxAttempts, userID, ip)
+	}
+	if ip != "" {
+		s.recordFailure(ctx, policy, ipSubject(ip), policy.IPMaxAttempts, "", ip)
+	}
+}
+
+// Reset clears the failure counter of an account after a successful login
+func (s *lockoutService) Reset(ctx context.Context, userID string) {
+	if userID == "" {
+		return
+	}
+	if err := s.attempts.ResetFailures(ctx, userSubject(userID)); err != nil {
+		logger.Warnf(ctx, "Failed to reset login failures of user %s: %v", userID, err)
+	}
+}
+
+// Status returns the lockout status of an account
+func (s *lockoutService) Status(ctx context.Context, userID string) (*structs.LockoutStatus, error) {
+	subject := userSubject(userID)
+
+	lockedFor, err := s.attempts.LockedFor(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	failures, _, err := s.attempts.GetFailures(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return &structs.LockoutStatus{
+		UserID:     userID,
+		Locked:     lockedFor > 0,
+		Failures:   failures,
+		RetryAfter: int64(lockedFor.Seconds()),
+	}, nil
+}
+
+// Unlock unlocks an account before its cooldown ends
+func (s *lockoutService) Unlock(ctx context.Context, userID string) error {
+	if err := s.attempts.Unlock(ctx, userSubject(userID)); err != nil {
+		logger.Errorf(ctx, "lockoutService.Unlock error: %v", err)
+		return err
+	}
+	logger.Infof(ctx, "Login lock of user %s removed", userID)
+	return nil
+}
+
+// checkLock returns an error while the subject is locked
+func (s *lockoutService) checkLock(ctx context.Context, subject string) error {
+	lockedFor, err := s.attempts.LockedFor(ctx, subject)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to read login lock of %s: %v", subject, err)
+		return nil
+	}
+	if lockedFor > 0 {
+		return &structs.LoginBlockedError{Locked: true, RetryAfter: lockedFor}
+	}
+	return nil
+}
+
+// recordFailure counts a failure for one subject and locks it on the threshold
+func (s *lockoutService) recordFailure(ctx context.Context, policy *structs.LockoutPolicy, subject string, threshold int, userID, ip string) {
+	failures, err := s.attempts.RecordFailure(ctx, subject, policy.Window)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to record login failure of %s: %v", subject, err)
+		return
+	}
+	if threshold <= 0 || failures < int64(threshold) {
+		return
+	}
+
+	if err := s.attempts.Lock(ctx, subject, policy.Cooldown); err != nil {
+		logger.Errorf(ctx, "Failed to lock %s: %v", subject, err)
+		return
+	}
+	logger.Warnf(ctx, "Login locked for %s after %d failures", subject, failures)
+
+	if s.ep != nil {
+		s.ep.PublishLockout(ctx, userID, &types.JSON{
+			"subject":    subject,
+			"ip_address": ip,
+			"failures":   failures,
+			"cooldown":   int64(policy.Cooldown.Seconds()),
+			"timestamp":  time.Now().UnixMilli(),
+		})
+	}
+}
+
+// userSubject returns the counter subject of an account
+func userSubject(userID string) string {
+	return "user:" + userID
+}
+
+// ipSubject returns the counter subject of a client IP
+func ipSubject(ip string) string {
+	return "ip:" + ip
+}
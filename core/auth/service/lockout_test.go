@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ncobase/core/auth/data/repository"
+	"ncobase/core/auth/event"
+	"ncobase/core/auth/structs"
+
+	"github.com/ncobase/ncore/types"
+)
+
+// memoryAttempts keeps login failures and locks in memory, locks expire on the fake clock now
+type memoryAttempts struct {
+	repository.LoginAttemptRepositoryInterface
+	now      time.Time
+	failures map[string]int64
+	last     map[string]int64
+	locks    map[string]time.Time
+}
+
+func newMemoryAttempts() *memoryAttempts {
+	return &memoryAttempts{now: time.Now(), failures: map[string]int64{}, last: map[string]int64{}, locks: map[string]time.Time{}}
+}
+
+func (r *memoryAttempts) RecordFailure(_ context.Context, subject string, _ time.Duration) (int64, error) {
+	r.failures[subject]++
+	r.last[subject] = time.Now().UnixMilli()
+	return r.failures[subject], nil
+}
+
+func (r *memoryAttempts) GetFailures(_ context.Context, subject string) (int64, int64, error) {
+	return r.failures[subject], r.last[subject], nil
+}
+
+func (r *memoryAttempts) ResetFailures(_ context.Context, subject string) error {
+	delete(r.failures, subject)
+	delete(r.last, subject)
+	return nil
+}
+
+func (r *memoryAttempts) Lock(_ context.Context, subject string, cooldown time.Duration) error {
+	r.locks[subject] = r.now.Add(cooldown)
+	return nil
+}
+
+func (r *memoryAttempts) LockedFor(_ context.Context, subject string) (time.Duration, error) {
+	if until, ok := r.locks[subject]; ok && until.After(r.now) {
+		return until.Sub(r.now), nil
+	}
+	return 0, nil
+}
+
+func (r *memoryAttempts) Unlock(_ context.Context, subject string) error {
+	delete(r.locks, subject)
+	return r.ResetFailures(context.Background(), subject)
+}
+
+// lockoutEvents records the published lockouts
+type lockoutEvents struct {
+	event.PublisherInterface
+	users []string
+}
+
+func (p *lockoutEvents) PublishLockout(_ context.Context, userID string, _ *types.JSON) {
+	p.users = append(p.users, userID)
+}
+
+func newTestLockout(policy *structs.LockoutPolicy) (*lockoutService, *memoryAttempts, *lockoutEvents) {
+	attempts := newMemoryAttempts()
+	events := &lockoutEvents{}
+	return &lockoutService{attempts: attempts, ep: events, policy: policy}, attempts, events
+}
+
+// attemptLogin checks and records a login attempt in the order Login does
+func attemptLogin(s LockoutServiceInterface, userID, ip string, correct bool) error {
+	ctx := context.Background()
+	if err := s.CheckIP(ctx, ip); err != nil {
+		return err
+	}
+	if err := s.CheckAccount(ctx, userID); err != nil {
+		return err
+	}
+	if !correct {
+		s.RecordFailure(ctx, userID, ip)
+		return errors.New("invalid password")
+	}
+	s.Reset(ctx, userID)
+	return nil
+}
+
+func isLocked(err error) bool {
+	var blocked *structs.LoginBlockedError
+	return errors.As(err, &blocked) && blocked.Locked
+}
+
+func TestLockoutAfterRepeatedFailures(t *testing.T) {
+	policy := structs.DefaultLockoutPolicy()
+	policy.DelayAfter = 0
+	s, attempts, events := newTestLockout(policy)
+
+	for i := 0; i < policy.MaxAttempts; i++ {
+		if err := attemptLogin(s, "u1", "198.51.100.1", false); isLocked(err) {
+			t.Fatalf("locked after %d failures, want %d", i, policy.MaxAttempts)
+		}
+	}
+	if len(events.users) != 1 || events.users[0] != "u1" {
+		t.Fatalf("lockout events %v, want one for u1", events.users)
+	}
+
+	// the correct password is rejected while locked, from any client
+	if err := attemptLogin(s, "u1", "198.51.100.2", true); !isLocked(err) {
+		t.Fatalf("correct password during lockout: err = %v, want locked", err)
+	}
+	status, _ := s.Status(context.Background(), "u1")
+	if !status.Locked || status.RetryAfter <= 0 {
+		t.Fatalf("status = %+v, want locked", status)
+	}
+
+	attempts.now = attempts.now.Add(policy.Cooldown + time.Second)
+	if err := attemptLogin(s, "u1", "198.51.100.2", true); err != nil {
+		t.Fatalf("correct password after cooldown: %v", err)
+	}
+	if attempts.failures[userSubject("u1")] != 0 {
+		t.Fatal("successful login did not reset the counter")
+	}
+}
+
+func TestLockoutAdminUnlock(t *testing.T) {
+	policy := structs.DefaultLockoutPolicy()
+	policy.DelayAfter = 0
+	s, _, _ := newTestLockout(policy)
+
+	for i := 0; i < policy.MaxAttempts; i++ {
+		_ = attemptLogin(s, "u1", "", false)
+	}
+	if err := attemptLogin(s, "u1", "", true); !isLocked(err) {
+		t.Fatalf("err = %v, want locked", err)
+	}
+	if err := s.Unlock(context.Background(), "u1"); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	if err := attemptLogin(s, "u1", "", true); err != nil {
+		t.Fatalf("login after unlock: %v", err)
+	}
+}
+
+func TestLockoutLocksClientIP(t *testing.T) {
+	policy := structs.DefaultLockoutPolicy()
+	policy.DelayAfter = 0
+	policy.IPMaxAttempts = 3
+	s, _, events := newTestLockout(policy)
+
+	// failures spread over accounts lock the client IP, not the accounts
+	for _, user := range []string{"u1", "u2", "u3"} {
+		_ = attemptLogin(s, user, "203.0.113.5", false)
+	}
+	if err := attemptLogin(s, "u4", "203.0.113.5", true); !isLocked(err) {
+		t.Fatalf("login from locked IP: err = %v, want locked", err)
+	}
+	if err := attemptLogin(s, "u4", "203.0.113.6", true); err != nil {
+		t.Fatalf("login from another IP: %v", err)
+	}
+	if len(events.users) != 1 || events.users[0] != "" {
+		t.Fatalf("lockout events %v, want one IP lockout", events.users)
+	}
+}
+
+func TestLockoutProgressiveDelay(t *testing.T) {
+	policy := structs.DefaultLockoutPolicy()
+	policy.DelayAfter = 2
+	policy.BaseDelay = time.Minute
+	policy.MaxDelay = 5 * time.Minute
+	s, _, _ := newTestLockout(policy)
+
+	for i := 0; i < 2; i++ {
+		if err := attemptLogin(s, "u1", "", false); err == nil || isLocked(err) {
+			t.Fatalf("failure %d: err = %v, want a wrong password", i, err)
+		}
+	}
+
+	var blocked *structs.LoginBlockedError
+	if err := attemptLogin(s, "u1", "", true); !errors.As(err, &blocked) || blocked.Locked || blocked.RetryAfter <= 0 {
+		t.Fatalf("err = %v, want a delay before the next attempt", err)
+	}
+
+	for failures, want := range map[int64]time.Duration{1: 0, 2: time.Minute, 3: 2 * time.Minute, 10: policy.MaxDelay} {
+		if got := policy.Delay(failures); got != want {
+			t.Errorf("Delay(%d) = %s, want %s", failures, got, want)
+		}
+	}
+}
+
+func TestLockoutDisabled(t *testing.T) {
+	policy := structs.DefaultLockoutPolicy()
+	policy.Enabled = false
+	s, _, _ := newTestLockout(policy)
+
+	for i := 0; i < policy.MaxAttempts*2; i++ {
+		_ = attemptLogin(s, "u1", "198.51.100.1", false)
+	}
+	if err := attemptLogin(s, "u1", "198.51.100.1", true); err != nil {
+		t.Fatalf("login with lockout disabled: %v", err)
+	}
+}
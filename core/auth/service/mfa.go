@@ -30,6 +30,8 @@ import (
 
 const (
 	mfaTokenSubject          = "mfa"
+	mfaTOTPPeriod            = 30
+	mfaTOTPSkew              = 1 // Accept codes one period before or after to tolerate clock drift
 	mfaTokenExpiry           = 5 * time.Minute
 	mfaMaxFailedAttempts     = 5
 	mfaLockDuration          = 10 * time.Minute
@@ -235,6 +237,9 @@ func (s *mfaService) GetTwoFactorStatus(ctx context.Context) (*structs.TwoFactor
 }
 
 func (s *mfaService) SetupTwoFactor(ctx context.Context, method string) (*structs.TwoFactorSetupResponse, error) {
+	if method == "" {
+		method = "app"
+	}
 	if method != "app" {
 		return nil, errors.New("unsupported 2fa method")
 	}
@@ -258,7 +263,7 @@ func (s *mfaService) SetupTwoFactor(ctx context.Context, method string) (*struct
 		AccountName: user.Username,
 		Algorithm:   otp.AlgorithmSHA1,
 		Digits:      otp.DigitsSix,
-		Period:      30,
+		Period:      mfaTOTPPeriod,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
@@ -316,8 +321,7 @@ func (s *mfaService) VerifyTwoFactor(ctx context.Context, code string, method st
 		return nil, err
 	}
 
-	ok, err := totp.ValidateCustom(code, secret, time.Now(), totp.ValidateOpts{Period: 30, Skew: 1, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1})
-	if err != nil || !ok {
+	if !validateTOTP(code, secret) {
 		return nil, errors.New("invalid authentication code")
 	}
 
@@ -377,8 +381,7 @@ func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, code string) (
 		return nil, err
 	}
 
-	ok, err := totp.ValidateCustom(code, secret, time.Now(), totp.ValidateOpts{Period: 30, Skew: 1, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1})
-	if err != nil || !ok {
+	if !validateTOTP(code, secret) {
 		return nil, errors.New("invalid authentication code")
 	}
 
@@ -433,8 +436,7 @@ func (s *mfaService) verifyUserMFA(ctx context.Context, userID string, code stri
 		return err
 	}
 
-	valid := totp.Validate(code, secret)
-	if !valid {
+	if !validateTOTP(code, secret) {
 		return s.recordFailedAttempt(ctx, userID, row.FailedAttempts)
 	}
 
@@ -463,14 +465,34 @@ func (s *mfaService) verifyRecoveryCode(ctx context.Context, userID string, reco
 		return s.recordFailedAttempt(ctx, userID, failedAttempts)
 	}
 
+	// Concurrent requests with the same code must not both succeed
+	claimed, err := s.mfaRepo.ClaimRecoveryCode(ctx, userID, target)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return errors.New("invalid recovery code")
+	}
+
 	remaining := append([]string{}, list[:idx]...)
 	remaining = append(remaining, list[idx+1:]...)
 
 	now := time.Now().UnixMilli()
-	_, err := s.mfaRepo.UpdateRecoveryCodesAndReset(ctx, userID, remaining, 0, now)
+	_, err = s.mfaRepo.UpdateRecoveryCodesAndReset(ctx, userID, remaining, 0, now)
 	return err
 }
 
+// validateTOTP checks a TOTP code allowing mfaTOTPSkew periods of clock drift
+func validateTOTP(code string, secret string) bool {
+	ok, err := totp.ValidateCustom(code, secret, time.Now(), totp.ValidateOpts{
+		Period:    mfaTOTPPeriod,
+		Skew:      mfaTOTPSkew,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	return err == nil && ok
+}
+
 func (s *mfaService) recordFailedAttempt(ctx context.Context, userID string, current int) error {
 	next := current + 1
 
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ncobase/core/auth/data/ent"
+	"ncobase/core/auth/data/repository"
+	"ncobase/core/auth/wrapper"
+	userStructs "ncobase/core/user/structs"
+	"ncobase/internal/utils"
+
+	"github.com/ncobase/ncore/ctxutil"
+	ext "github.com/ncobase/ncore/extension/types"
+	"github.com/pquerna/otp/totp"
+)
+
+// memoryMFA keeps 2FA settings in memory, claimed recovery codes cannot be claimed again
+type memoryMFA struct {
+	repository.UserMFARepositoryInterface
+	rows    map[string]*ent.UserMFA
+	claimed map[string]bool
+}
+
+func (r *memoryMFA) GetByUserID(_ context.Context, userID string) (*ent.UserMFA, error) {
+	if row, ok := r.rows[userID]; ok {
+		return row, nil
+	}
+	return nil, &ent.NotFoundError{}
+}
+
+func (r *memoryMFA) Create(_ context.Context, userID, totpSecret string) (*ent.UserMFA, error) {
+	row := &ent.UserMFA{UserID: userID, TotpSecret: totpSecret}
+	r.rows[userID] = row
+	return row, nil
+}
+
+func (r *memoryMFA) Enable(_ context.Context, userID string, verifiedAt int64, hashes []string, _ int64) (int, error) {
+	row := r.rows[userID]
+	row.Enabled, row.VerifiedAt, row.RecoveryCodeHashes = true, verifiedAt, hashes
+	return 1, nil
+}
+
+func (r *memoryMFA) ResetFailedAttempts(_ context.Context, userID string, lastUsedAt int64) (int, error) {
+	r.rows[userID].FailedAttempts, r.rows[userID].LastUsedAt = 0, lastUsedAt
+	return 1, nil
+}
+
+func (r *memoryMFA) IncrementFailedAttempts(_ context.Context, userID string, attempts int) (int, error) {
+	r.rows[userID].FailedAttempts = attempts
+	return 1, nil
+}
+
+func (r *memoryMFA) LockAccount(_ context.Context, userID string, lockedUntil int64) (int, error) {
+	r.rows[userID].LockedUntil = lockedUntil
+	return 1, nil
+}
+
+func (r *memoryMFA) ClaimRecoveryCode(_ context.Context, userID, hash string) (bool, error) {
+	key := userID + ":" + hash
+	if r.claimed[key] {
+		return false, nil
+	}
+	r.claimed[key] = true
+	return true, nil
+}
+
+func (r *memoryMFA) UpdateRecoveryCodesAndReset(_ context.Context, userID string, hashes []string, failedAttempts int, lastUsedAt int64) (int, error) {
+	row := r.rows[userID]
+	row.RecoveryCodeHashes, row.FailedAttempts, row.LastUsedAt = hashes, failedAttempts, lastUsedAt
+	return 1, nil
+}
+
+// users serves the user cross service of the auth wrappers
+type users struct {
+	ext.ManagerInterface
+	wrapper.UserServiceInterface
+}
+
+func (u users) GetCrossService(_, servicePath string) (any, error) {
+	if servicePath == "User" {
+		return u, nil
+	}
+	return nil, errors.New("service not found")
+}
+
+func (u users) GetByID(_ context.Context, id string) (*userStructs.ReadUser, error) {
+	return &userStructs.ReadUser{ID: id, Username: "alice"}, nil
+}
+
+func newTestMFA(t *testing.T) (*mfaService, *memoryMFA) {
+	t.Helper()
+	enc, err := utils.NewEncryptionService(&utils.EncryptionConfig{MasterKey: "test-master-key", KeyVersion: 1})
+	if err != nil {
+		t.Fatalf("NewEncryptionService: %v", err)
+	}
+	repo := &memoryMFA{rows: map[string]*ent.UserMFA{}, claimed: map[string]bool{}}
+	return &mfaService{mfaRepo: repo, enc: enc, usw: wrapper.NewUserServiceWrapper(users{})}, repo
+}
+
+// enroll sets up and activates 2FA for u1, returning the secret and recovery codes
+func enroll(t *testing.T, s *mfaService) (string, []string) {
+	t.Helper()
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+
+	setup, err := s.SetupTwoFactor(ctx, "")
+	if err != nil {
+		t.Fatalf("SetupTwoFactor: %v", err)
+	}
+	code, err := totp.GenerateCode(setup.Secret, time.Now())
+	if err != nil {
+		t.Fatalf("GenerateCode: %v", err)
+	}
+	codes, err := s.VerifyTwoFactor(ctx, code, setup.Method)
+	if err != nil {
+		t.Fatalf("VerifyTwoFactor: %v", err)
+	}
+	return setup.Secret, codes.RecoveryCodes
+}
+
+func TestTwoFactorEnrollment(t *testing.T) {
+	s, repo := newTestMFA(t)
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+
+	setup, err := s.SetupTwoFactor(ctx, "")
+	if err != nil {
+		t.Fatalf("SetupTwoFactor: %v", err)
+	}
+	if setup.Method != "app" || setup.QRPNG == "" || !strings.HasPrefix(setup.OTPAuthURI, "otpauth://totp/") || !strings.Contains(setup.OTPAuthURI, "alice") {
+		t.Fatalf("setup = %+v", setup)
+	}
+	if stored := repo.rows["u1"].TotpSecret; stored == "" || stored == setup.Secret {
+		t.Fatal("secret not stored encrypted")
+	}
+
+	// 2FA stays off until a code from the new secret is verified
+	if _, err := s.VerifyTwoFactor(ctx, "000000", "app"); err == nil {
+		t.Fatal("activated with a wrong code")
+	}
+	if status, _ := s.GetTwoFactorStatus(ctx); status.Enabled {
+		t.Fatal("enabled before verification")
+	}
+
+	code, _ := totp.GenerateCode(setup.Secret, time.Now())
+	codes, err := s.VerifyTwoFactor(ctx, code, "app")
+	if err != nil {
+		t.Fatalf("VerifyTwoFactor: %v", err)
+	}
+	status, _ := s.GetTwoFactorStatus(ctx)
+	if !status.Enabled || status.RecoveryCodesRemaining != mfaRecoveryCodesCount || len(codes.RecoveryCodes) != mfaRecoveryCodesCount {
+		t.Fatalf("status = %+v with %d codes", status, len(codes.RecoveryCodes))
+	}
+	for _, hash := range repo.rows["u1"].RecoveryCodeHashes {
+		for _, code := range codes.RecoveryCodes {
+			if hash == code {
+				t.Fatal("recovery code stored in clear")
+			}
+		}
+	}
+}
+
+func TestTwoFactorCodeVerification(t *testing.T) {
+	s, repo := newTestMFA(t)
+	secret, _ := enroll(t, s)
+	ctx := context.Background()
+
+	now := time.Now()
+	for name, at := range map[string]time.Time{
+		"current":       now,
+		"previous step": now.Add(-mfaTOTPPeriod * time.Second),
+		"next step":     now.Add(mfaTOTPPeriod * time.Second),
+	} {
+		code, _ := totp.GenerateCode(secret, at)
+		if err := s.verifyUserMFA(ctx, "u1", code, ""); err != nil {
+			t.Errorf("%s code: %v", name, err)
+		}
+	}
+
+	stale, _ := totp.GenerateCode(secret, now.Add(-3*mfaTOTPPeriod*time.Second))
+	if err := s.verifyUserMFA(ctx, "u1", stale, ""); err == nil {
+		t.Fatal("code outside the skew window accepted")
+	}
+	if repo.rows["u1"].FailedAttempts != 1 {
+		t.Fatalf("failed attempts = %d, want 1", repo.rows["u1"].FailedAttempts)
+	}
+}
+
+func TestTwoFactorLocksAfterFailures(t *testing.T) {
+	s, repo := newTestMFA(t)
+	secret, _ := enroll(t, s)
+	ctx := context.Background()
+
+	for i := 0; i < mfaMaxFailedAttempts; i++ {
+		_ = s.verifyUserMFA(ctx, "u1", "000000", "")
+	}
+	if repo.rows["u1"].LockedUntil <= time.Now().UnixMilli() {
+		t.Fatal("not locked after repeated failures")
+	}
+	code, _ := totp.GenerateCode(secret, time.Now())
+	if err := s.verifyUserMFA(ctx, "u1", code, ""); err == nil {
+		t.Fatal("valid code accepted while locked")
+	}
+}
+
+func TestRecoveryCodeIsSingleUse(t *testing.T) {
+	s, repo := newTestMFA(t)
+	_, codes := enroll(t, s)
+	ctx := context.Background()
+
+	// codes are accepted regardless of case and dashes
+	code := strings.ToLower(strings.ReplaceAll(codes[3], "-", ""))
+	if err := s.verifyUserMFA(ctx, "u1", "", code); err != nil {
+		t.Fatalf("recovery code: %v", err)
+	}
+	if remaining := len(repo.rows["u1"].RecoveryCodeHashes); remaining != mfaRecoveryCodesCount-1 {
+		t.Fatalf("%d recovery codes left, want %d", remaining, mfaRecoveryCodesCount-1)
+	}
+	if err := s.verifyUserMFA(ctx, "u1", "", codes[3]); err == nil {
+		t.Fatal("recovery code used twice")
+	}
+	if err := s.verifyUserMFA(ctx, "u1", "", codes[4]); err != nil {
+		t.Fatalf("another recovery code: %v", err)
+	}
+}
@@ -17,6 +17,7 @@ type Service struct {
 	AuthSpace AuthSpaceServiceInterface
 	Session   SessionServiceInterface
 	MFA       MFAServiceInterface
+	Lockout   LockoutServiceInterface
 
 	usw  *wrapper.UserServiceWrapper
 	tsw  *wrapper.SpaceServiceWrapper
@@ -37,14 +38,16 @@ func New(d *data.Data, jtm *jwt.TokenManager, em ext.ManagerInterface) *Service
 	ats := NewAuthSpaceService(d, usw, tsw, asw)
 	ss := NewSessionService(d)
 	mfa := NewMFAService(d, jtm, usw, asw, tsw, ss)
+	lo := NewLockoutService(d, ep)
 
 	return &Service{
-		Account:   NewAccountService(d, jtm, ep, cas, ats, ss, mfa, usw, tsw, asw, ugsw),
+		Account:   NewAccountService(d, jtm, ep, cas, ats, ss, mfa, lo, usw, tsw, asw, ugsw),
 		AuthSpace: ats,
 		CodeAuth:  cas,
 		Captcha:   NewCaptchaService(d),
 		Session:   ss,
 		MFA:       mfa,
+		Lockout:   lo,
 		usw:       usw,
 		tsw:       tsw,
 		asw:       asw,
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// refreshReuseGrace tolerates concurrent refreshes with the same token,
+// a replay after it is treated as token theft
+const refreshReuseGrace = 10 * time.Second
+
+var (
+	// ErrRefreshTokenRotated is returned when a concurrent request already rotated the refresh token
+	ErrRefreshTokenRotated = errors.New("refresh token already rotated")
+	// ErrRefreshTokenReused is returned when a rotated refresh token is replayed, the whole chain is revoked
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected, please login again")
+)
+
+// consumeRefreshToken marks the presented refresh token as used and returns its rotation family.
+// Exactly one caller can consume a token, a replay outside the grace window revokes the family.
+func (s *accountService) consumeRefreshToken(ctx context.Context, claims map[string]any, userID string) (string, error) {
+	tokenID, _ := claims["jti"].(string)
+	if tokenID == "" {
+		return "", errors.New("invalid refresh token")
+	}
+
+	// Tokens issued before rotation start their own family
+	family := tokenID
+	if payload, ok := claims["payload"].(map[string]any); ok {
+		if f, ok := payload["family"].(string); ok && f != "" {
+			family = f
+		}
+	}
+
+	// Signed out sessions cannot be refreshed
+	if s.ss.IsTokenRevoked(ctx, tokenID) {
+		return "", errors.New("session has been revoked")
+	}
+
+	revoked, err := s.refreshTokenRepo.IsFamilyRevoked(ctx, family)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to check refresh token family %s: %v", family, err)
+		return "", errors.New("failed to verify refresh token")
+	}
+	if revoked {
+		return "", ErrRefreshTokenReused
+	}
+
+	ttl := refreshTokenTTL(claims)
+	first, usedAt, err := s.refreshTokenRepo.Consume(ctx, tokenID, ttl)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to consume refresh token %s: %v", tokenID, err)
+		return "", errors.New("failed to verify refresh token")
+	}
+	if first {
+		return family, nil
+	}
+
+	if usedAt > 0 && time.Now().UnixMilli()-usedAt < refreshReuseGrace.Milliseconds() {
+		return "", ErrRefreshTokenRotated
+	}
+
+	logger.Warnf(ctx, "Refresh token %s of user %s replayed, revoking family %s", tokenID, userID, family)
+	s.revokeRefreshFamily(ctx, family, ttl)
+
+	return "", ErrRefreshTokenReused
+}
+
+// trackRefreshToken records a newly issued refresh token in its family
+func (s *accountService) trackRefreshToken(ctx context.Context, family, refreshToken string) {
+	claims, err := s.jtm.DecodeToken(refreshToken)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to decode issued refresh token: %v", err)
+		return
+	}
+
+	tokenID, _ := claims["jti"].(string)
+	if err := s.refreshTokenRepo.AddToFamily(ctx, family, tokenID, refreshTokenTTL(claims)); err != nil {
+		logger.Warnf(ctx, "Failed to track refresh token %s in family %s: %v", tokenID, family, err)
+	}
+}
+
+// revokeRefreshFamily revokes a rotation family and deactivates the sessions it created
+func (s *accountService) revokeRefreshFamily(ctx context.Context, family string, ttl time.Duration) {
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, family, ttl); err != nil {
+		logger.Errorf(ctx, "Failed to revoke refresh token family %s: %v", family, err)
+	}
+
+	tokenIDs, err := s.refreshTokenRepo.GetFamily(ctx, family)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to list refresh token family %s: %v", family, err)
+	}
+	tokenIDs = append(tokenIDs, family)
+
+	for _, tokenID := range tokenIDs {
+		if err := s.ss.DeactivateByTokenID(ctx, tokenID); err != nil {
+			logger.Debugf(ctx, "Failed to deactivate session of token %s: %v", tokenID, err)
+		}
+	}
+}
+
+// refreshTokenTTL returns the remaining lifetime of a token from its claims
+func refreshTokenTTL(claims map[string]any) time.Duration {
+	if exp, ok := claims["exp"].(float64); ok {
+		if ttl := time.Until(time.Unix(int64(exp), 0)); ttl > 0 {
+			return ttl
+		}
+	}
+	return time.Minute
+}
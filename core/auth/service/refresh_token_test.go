@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"ncobase/core/auth/data/repository"
+
+	"github.com/ncobase/ncore/security/jwt"
+)
+
+// memoryRefreshTokens tracks rotation chains in memory, Consume is atomic like the Redis SETNX
+type memoryRefreshTokens struct {
+	repository.RefreshTokenRepositoryInterface
+	mu       sync.Mutex
+	used     map[string]int64
+	families map[string][]string
+	revoked  map[string]bool
+}
+
+func newMemoryRefreshTokens() *memoryRefreshTokens {
+	return &memoryRefreshTokens{used: map[string]int64{}, families: map[string][]string{}, revoked: map[string]bool{}}
+}
+
+func (r *memoryRefreshTokens) Consume(_ context.Context, tokenID string, _ time.Duration) (bool, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if usedAt, ok := r.used[tokenID]; ok {
+		return false, usedAt, nil
+	}
+	r.used[tokenID] = time.Now().UnixMilli()
+	return true, r.used[tokenID], nil
+}
+
+func (r *memoryRefreshTokens) AddToFamily(_ context.Context, family, tokenID string, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families[family] = append(r.families[family], tokenID)
+	return nil
+}
+
+func (r *memoryRefreshTokens) GetFamily(_ context.Context, family string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.families[family]...), nil
+}
+
+func (r *memoryRefreshTokens) RevokeFamily(_ context.Context, family string, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.revoked[family] = true
+	return nil
+}
+
+func (r *memoryRefreshTokens) IsFamilyRevoked(_ context.Context, family string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.revoked[family], nil
+}
+
+// sessionTokens records the sessions deactivated by token, revoked tokens are signed out
+type sessionTokens struct {
+	SessionServiceInterface
+	mu          sync.Mutex
+	revoked     map[string]bool
+	deactivated []string
+}
+
+func (s *sessionTokens) IsTokenRevoked(_ context.Context, tokenID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.revoked[tokenID]
+}
+
+func (s *sessionTokens) DeactivateByTokenID(_ context.Context, tokenID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deactivated = append(s.deactivated, tokenID)
+	return nil
+}
+
+func newRotationService() (*accountService, *memoryRefreshTokens, *sessionTokens) {
+	tokens := newMemoryRefreshTokens()
+	sessions := &sessionTokens{revoked: map[string]bool{}}
+	return &accountService{
+		jtm:              jwt.NewTokenManager("test-secret"),
+		ss:               sessions,
+		refreshTokenRepo: tokens,
+	}, tokens, sessions
+}
+
+// issue returns the claims of a refresh token tokenID in family, empty to start a chain
+func issue(t *testing.T, s *accountService, tokenID, family string) map[string]any {
+	t.Helper()
+	_, refreshToken := generateUserTokens(s.jtm, map[string]any{"user_id": "u1"}, tokenID, family)
+	claims, err := s.jtm.DecodeToken(refreshToken)
+	if err != nil {
+		t.Fatalf("decode refresh token: %v", err)
+	}
+	if family != "" {
+		s.trackRefreshToken(context.Background(), family, refreshToken)
+	}
+	return claims
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	s, tokens, _ := newRotationService()
+	ctx := context.Background()
+
+	claims := issue(t, s, "t1", "")
+	for i := 2; i <= 4; i++ {
+		family, err := s.consumeRefreshToken(ctx, claims, "u1")
+		if err != nil {
+			t.Fatalf("rotation %d: %v", i, err)
+		}
+		if family != "t1" {
+			t.Fatalf("rotation %d: family %q, want t1", i, family)
+		}
+		claims = issue(t, s, fmt.Sprintf("t%d", i), family)
+	}
+
+	members, _ := tokens.GetFamily(ctx, "t1")
+	sort.Strings(members)
+	if fmt.Sprint(members) != "[t2 t3 t4]" {
+		t.Fatalf("family = %v, want the rotated tokens", members)
+	}
+}
+
+func TestRefreshTokenConcurrentRotation(t *testing.T) {
+	s, tokens, sessions := newRotationService()
+	claims := issue(t, s, "t1", "")
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		rotated int
+		lost    int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.consumeRefreshToken(context.Background(), claims, "u1")
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				rotated++
+			case errors.Is(err, ErrRefreshTokenRotated):
+				lost++
+			default:
+				t.Errorf("concurrent refresh: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if rotated != 1 || lost != 19 {
+		t.Fatalf("%d rotated, %d told already rotated, want 1 and 19", rotated, lost)
+	}
+	if tokens.revoked["t1"] || len(sessions.deactivated) != 0 {
+		t.Fatal("concurrent refresh treated as reuse")
+	}
+}
+
+func TestRefreshTokenReuseRevokesChain(t *testing.T) {
+	s, tokens, sessions := newRotationService()
+	ctx := context.Background()
+
+	stolen := issue(t, s, "t1", "")
+	if _, err := s.consumeRefreshToken(ctx, stolen, "u1"); err != nil {
+		t.Fatalf("first rotation: %v", err)
+	}
+	current := issue(t, s, "t2", "t1")
+
+	// the replay comes after the grace window
+	tokens.used["t1"] = time.Now().Add(-2 * refreshReuseGrace).UnixMilli()
+	if _, err := s.consumeRefreshToken(ctx, stolen, "u1"); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("replay: err = %v, want ErrRefreshTokenReused", err)
+	}
+	if !tokens.revoked["t1"] {
+		t.Fatal("family not revoked")
+	}
+	sort.Strings(sessions.deactivated)
+	if fmt.Sprint(sessions.deactivated) != "[t1 t2]" {
+		t.Fatalf("deactivated sessions %v, want the whole chain", sessions.deactivated)
+	}
+
+	// the legitimate holder is locked out too
+	if _, err := s.consumeRefreshToken(ctx, current, "u1"); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("current token: err = %v, want ErrRefreshTokenReused", err)
+	}
+}
+
+func TestRefreshTokenOfRevokedSession(t *testing.T) {
+	s, _, sessions := newRotationService()
+	claims := issue(t, s, "t1", "")
+	sessions.revoked["t1"] = true
+
+	if _, err := s.consumeRefreshToken(context.Background(), claims, "u1"); err == nil {
+		t.Fatal("signed out session refreshed")
+	}
+}
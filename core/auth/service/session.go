@@ -2,14 +2,22 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"ncobase/core/auth/data"
 	"ncobase/core/auth/data/repository"
 	"ncobase/core/auth/structs"
 
 	"github.com/ncobase/ncore/data/paging"
+	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/security/jwt"
+	"github.com/ncobase/ncore/validation/validator"
 )
 
+// revokedTokenTTL keeps a revoked token identifier until its refresh token would have expired
+const revokedTokenTTL = jwt.DefaultRefreshTokenExpire
+
 // SessionServiceInterface defines the session service interface
 type SessionServiceInterface interface {
 	Create(ctx context.Context, body *structs.SessionBody, tokenID string) (*structs.ReadSession, error)
@@ -20,20 +28,28 @@ type SessionServiceInterface interface {
 	Delete(ctx context.Context, id string) error
 	DeactivateByUserID(ctx context.Context, userID string) error
 	DeactivateByTokenID(ctx context.Context, tokenID string) error
+	IsTokenRevoked(ctx context.Context, tokenID string) bool
 	UpdateLastAccess(ctx context.Context, tokenID string) error
 	CleanupExpiredSessions(ctx context.Context) error
 	GetActiveSessionsCount(ctx context.Context, userID string) int
+	EraseUserData(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error)
 }
 
 // sessionService implements the SessionServiceInterface
 type sessionService struct {
-	r repository.SessionRepositoryInterface
+	r          repository.SessionRepositoryInterface
+	revocation repository.TokenRevocationRepositoryInterface
+	refresh    repository.RefreshTokenRepositoryInterface
+	erasure    repository.ErasureRepositoryInterface
 }
 
 // NewSessionService creates a new session service
 func NewSessionService(d *data.Data) SessionServiceInterface {
 	return &sessionService{
-		r: repository.NewSessionRepository(d),
+		r:          repository.NewSessionRepository(d),
+		revocation: repository.NewTokenRevocationRepository(d),
+		refresh:    repository.NewRefreshTokenRepository(d),
+		erasure:    repository.NewErasureRepository(d),
 	}
 }
 
@@ -98,19 +114,60 @@ func (s *sessionService) List(ctx context.Context, params *structs.ListSessionPa
 	})
 }
 
-// Delete deletes a session
+// Delete deletes a session and revokes its token
 func (s *sessionService) Delete(ctx context.Context, id string) error {
-	return s.r.Delete(ctx, id)
+	row, err := s.r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.r.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.revokeTokens(ctx, row.TokenID)
+	return nil
 }
 
-// DeactivateByUserID deactivates all sessions for a user
+// DeactivateByUserID deactivates all sessions for a user and revokes their tokens
 func (s *sessionService) DeactivateByUserID(ctx context.Context, userID string) error {
-	return s.r.DeactivateByUserID(ctx, userID)
+	tokenIDs, err := s.r.GetActiveTokenIDs(ctx, userID)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to list active tokens of user %s: %v", userID, err)
+	}
+	if err := s.r.DeactivateByUserID(ctx, userID); err != nil {
+		return err
+	}
+	s.revokeTokens(ctx, tokenIDs...)
+	return nil
 }
 
-// DeactivateByTokenID deactivates a session by token ID
+// DeactivateByTokenID deactivates a session by token ID and revokes the token
 func (s *sessionService) DeactivateByTokenID(ctx context.Context, tokenID string) error {
-	return s.r.DeactivateByTokenID(ctx, tokenID)
+	if err := s.r.DeactivateByTokenID(ctx, tokenID); err != nil {
+		return err
+	}
+	s.revokeTokens(ctx, tokenID)
+	return nil
+}
+
+// IsTokenRevoked reports whether the session of a token has been revoked.
+// Lookup failures are logged and treated as not revoked.
+func (s *sessionService) IsTokenRevoked(ctx context.Context, tokenID string) bool {
+	if tokenID == "" {
+		return false
+	}
+	revoked, err := s.revocation.IsRevoked(ctx, tokenID)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to check revocation of token %s: %v", tokenID, err)
+		return false
+	}
+	return revoked
+}
+
+// revokeTokens adds token IDs to the revocation list checked by the auth middleware
+func (s *sessionService) revokeTokens(ctx context.Context, tokenIDs ...string) {
+	if err := s.revocation.Revoke(ctx, revokedTokenTTL, tokenIDs...); err != nil {
+		logger.Errorf(ctx, "Failed to revoke tokens %v: %v", tokenIDs, err)
+	}
 }
 
 // UpdateLastAccess updates the last access time for a session
@@ -132,3 +189,33 @@ func (s *sessionService) GetActiveSessionsCount(ctx context.Context, userID stri
 	}
 	return s.r.CountX(ctx, params)
 }
+
+// EraseUserData signs a user out everywhere and erases their sign-in state. Every token issued
+// to the user is revoked with the refresh chain it started before the sessions, issued tokens,
+// two-factor secret with recovery codes and linked OAuth accounts are deleted, so a failed run
+// still finds the tokens when resumed. Nothing of it is kept when anonymizing.
+func (s *sessionService) EraseUserData(ctx context.Context, userID string, _ bool) (int, int, error) {
+	if validator.IsEmpty(userID) {
+		return 0, 0, errors.New(ecode.FieldIsRequired("user_id"))
+	}
+
+	tokenIDs, err := s.erasure.TokenIDs(ctx, userID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list tokens: %w", err)
+	}
+	if err := s.revocation.Revoke(ctx, revokedTokenTTL, tokenIDs...); err != nil {
+		return 0, 0, fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	// A refresh chain is named after the token that started it
+	for _, tokenID := range tokenIDs {
+		if err := s.refresh.RevokeFamily(ctx, tokenID, revokedTokenTTL); err != nil {
+			return 0, 0, fmt.Errorf("failed to revoke refresh chain %s: %w", tokenID, err)
+		}
+	}
+
+	deleted, err := s.erasure.Erase(ctx, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return deleted, 0, nil
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ncobase/core/auth/data/ent"
+	"ncobase/core/auth/data/repository"
+	"ncobase/core/auth/structs"
+
+	"github.com/ncobase/ncore/security/jwt"
+)
+
+// memorySessions keeps sessions in memory, in creation order
+type memorySessions struct {
+	repository.SessionRepositoryInterface
+	rows []*ent.Session
+}
+
+func (r *memorySessions) Create(_ context.Context, body *structs.SessionBody, tokenID string) (*ent.Session, error) {
+	row := &ent.Session{ID: fmt.Sprintf("sess-%d", len(r.rows)+1), UserID: body.UserID, TokenID: tokenID, IsActive: true}
+	r.rows = append(r.rows, row)
+	return row, nil
+}
+
+func (r *memorySessions) GetByID(_ context.Context, id string) (*ent.Session, error) {
+	for _, row := range r.rows {
+		if row.ID == id {
+			return row, nil
+		}
+	}
+	return nil, &ent.NotFoundError{}
+}
+
+func (r *memorySessions) Delete(_ context.Context, id string) error {
+	for i, row := range r.rows {
+		if row.ID == id {
+			r.rows = append(r.rows[:i], r.rows[i+1:]...)
+			return nil
+		}
+	}
+	return &ent.NotFoundError{}
+}
+
+func (r *memorySessions) GetActiveTokenIDs(_ context.Context, userID string) ([]string, error) {
+	var tokenIDs []string
+	for _, row := range r.rows {
+		if row.UserID == userID && row.IsActive {
+			tokenIDs = append(tokenIDs, row.TokenID)
+		}
+	}
+	return tokenIDs, nil
+}
+
+func (r *memorySessions) DeactivateByUserID(_ context.Context, userID string) error {
+	for _, row := range r.rows {
+		if row.UserID == userID {
+			row.IsActive = false
+		}
+	}
+	return nil
+}
+
+func (r *memorySessions) DeactivateByTokenID(_ context.Context, tokenID string) error {
+	for _, row := range r.rows {
+		if row.TokenID == tokenID {
+			row.IsActive = false
+		}
+	}
+	return nil
+}
+
+// revocationList is the revocation list without Redis
+type revocationList map[string]time.Duration
+
+func (l revocationList) Revoke(_ context.Context, ttl time.Duration, tokenIDs ...string) error {
+	for _, tokenID := range tokenIDs {
+		l[tokenID] = ttl
+	}
+	return nil
+}
+
+func (l revocationList) IsRevoked(_ context.Context, tokenID string) (bool, error) {
+	_, ok := l[tokenID]
+	return ok, nil
+}
+
+// memoryErasure erases the sessions and two-factor enrollments kept in memory
+type memoryErasure struct {
+	sessions *memorySessions
+	mfa      map[string]bool
+}
+
+func (e *memoryErasure) TokenIDs(_ context.Context, userID string) ([]string, error) {
+	var tokenIDs []string
+	for _, row := range e.sessions.rows {
+		if row.UserID == userID {
+			tokenIDs = append(tokenIDs, row.TokenID)
+		}
+	}
+	return tokenIDs, nil
+}
+
+func (e *memoryErasure) Erase(_ context.Context, userID string) (int, error) {
+	deleted := 0
+	kept := e.sessions.rows[:0]
+	for _, row := range e.sessions.rows {
+		if row.UserID == userID {
+			deleted++
+			continue
+		}
+		kept = append(kept, row)
+	}
+	e.sessions.rows = kept
+	if e.mfa[userID] {
+		delete(e.mfa, userID)
+		deleted++
+	}
+	return deleted, nil
+}
+
+func newTestSessions() (*sessionService, revocationList) {
+	revoked := revocationList{}
+	return &sessionService{r: &memorySessions{}, revocation: revoked}, revoked
+}
+
+func TestDeleteSessionRevokesOnlyItsToken(t *testing.T) {
+	s, revoked := newTestSessions()
+	ctx := context.Background()
+
+	laptop, err := s.Create(ctx, &structs.SessionBody{UserID: "u1", UserAgent: "laptop"}, "t1")
+	if err != nil {
+		t.Fatalf("Create laptop: %v", err)
+	}
+	if _, err := s.Create(ctx, &structs.SessionBody{UserID: "u1", UserAgent: "phone"}, "t2"); err != nil {
+		t.Fatalf("Create phone: %v", err)
+	}
+
+	if err := s.Delete(ctx, laptop.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if !s.IsTokenRevoked(ctx, "t1") {
+		t.Fatal("token of the deleted session still accepted")
+	}
+	if s.IsTokenRevoked(ctx, "t2") {
+		t.Fatal("token of the other session revoked")
+	}
+	if revoked["t1"] != revokedTokenTTL {
+		t.Fatalf("revoked for %v, want %v", revoked["t1"], revokedTokenTTL)
+	}
+
+	if err := s.Delete(ctx, laptop.ID); err == nil {
+		t.Fatal("deleted a missing session")
+	}
+	if s.IsTokenRevoked(ctx, "") {
+		t.Fatal("empty token reported revoked")
+	}
+}
+
+func TestDeactivateSessions(t *testing.T) {
+	s, _ := newTestSessions()
+	ctx := context.Background()
+	for i, userID := range []string{"u1", "u1", "u2"} {
+		if _, err := s.Create(ctx, &structs.SessionBody{UserID: userID}, fmt.Sprintf("t%d", i+1)); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	if err := s.DeactivateByTokenID(ctx, "t2"); err != nil {
+		t.Fatalf("DeactivateByTokenID: %v", err)
+	}
+	if !s.IsTokenRevoked(ctx, "t2") || s.IsTokenRevoked(ctx, "t1") {
+		t.Fatal("signing out one session revoked the wrong tokens")
+	}
+
+	// signing out everywhere revokes every session of the user only
+	if err := s.DeactivateByUserID(ctx, "u1"); err != nil {
+		t.Fatalf("DeactivateByUserID: %v", err)
+	}
+	if !s.IsTokenRevoked(ctx, "t1") {
+		t.Fatal("token kept after signing out everywhere")
+	}
+	if s.IsTokenRevoked(ctx, "t3") {
+		t.Fatal("token of another user revoked")
+	}
+}
+
+func TestEraseUserDataSignsOutEverywhere(t *testing.T) {
+	sessions := &memorySessions{}
+	revoked := revocationList{}
+	tokens := newMemoryRefreshTokens()
+	erasure := &memoryErasure{sessions: sessions, mfa: map[string]bool{"u1": true, "u2": true}}
+	s := &sessionService{r: sessions, revocation: revoked, refresh: tokens, erasure: erasure}
+	account := &accountService{jtm: jwt.NewTokenManager("test-secret"), ss: s, refreshTokenRepo: tokens}
+	ctx := context.Background()
+
+	// u1 signed in once and refreshed, u2 is signed in elsewhere
+	first := issue(t, account, "t1", "")
+	if _, err := s.Create(ctx, &structs.SessionBody{UserID: "u1"}, "t1"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	family, err := account.consumeRefreshToken(ctx, first, "u1")
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	current := issue(t, account, "t2", family)
+	for tokenID, userID := range map[string]string{"t2": "u1", "t3": "u2"} {
+		if _, err := s.Create(ctx, &structs.SessionBody{UserID: userID}, tokenID); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	deleted, anonymized, err := s.EraseUserData(ctx, "u1", true)
+	if err != nil {
+		t.Fatalf("EraseUserData: %v", err)
+	}
+	if deleted != 3 || anonymized != 0 {
+		t.Fatalf("erased %d deleted, %d anonymized, want both sessions and the 2FA enrollment deleted", deleted, anonymized)
+	}
+
+	// the auth middleware rejects the access tokens, the refresh token no longer rotates
+	for _, tokenID := range []string{"t1", "t2"} {
+		if !s.IsTokenRevoked(ctx, tokenID) {
+			t.Errorf("token %s still accepted after erasure", tokenID)
+		}
+	}
+	if _, err := account.consumeRefreshToken(ctx, current, "u1"); err == nil {
+		t.Fatal("refresh token still rotates after erasure")
+	}
+	if !tokens.revoked["t1"] {
+		t.Fatal("refresh chain not revoked")
+	}
+	if erasure.mfa["u1"] {
+		t.Fatal("2FA enrollment kept")
+	}
+
+	if s.IsTokenRevoked(ctx, "t3") || !erasure.mfa["u2"] {
+		t.Fatal("erasure touched another user")
+	}
+	if deleted, _, err := s.EraseUserData(ctx, "u1", false); err != nil || deleted != 0 {
+		t.Fatalf("second erasure = %d, %v, want nothing left", deleted, err)
+	}
+}
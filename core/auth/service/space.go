@@ -8,6 +8,7 @@ import (
 	"ncobase/core/auth/wrapper"
 	spaceStructs "ncobase/core/space/structs"
 	userStructs "ncobase/core/user/structs"
+	"ncobase/internal/utils"
 
 	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/utils/slug"
@@ -38,8 +39,26 @@ func NewAuthSpaceService(d *data.Data, usw *wrapper.UserServiceWrapper, tsw *wra
 	}
 }
 
-// CreateInitialSpace creates the initial space and sets up roles and user relationships
+// CreateInitialSpace creates the initial space and sets up roles and user relationships.
+// Everything is created in one transaction, a failure at any step leaves nothing behind,
+// and the transaction is retried when it loses a conflict with a concurrent one.
 func (s *authSpaceService) CreateInitialSpace(ctx context.Context, body *spaceStructs.CreateSpaceBody) (*spaceStructs.ReadSpace, error) {
+	var space *spaceStructs.ReadSpace
+	err := utils.WithTxRetry(ctx, s.d.Data, func(ctx context.Context) error {
+		var err error
+		space, err = s.createInitialSpace(ctx, body)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof(ctx, "Successfully created initial space '%s' with user assignments", space.Name)
+	return space, nil
+}
+
+// createInitialSpace runs the steps of CreateInitialSpace
+func (s *authSpaceService) createInitialSpace(ctx context.Context, body *spaceStructs.CreateSpaceBody) (*spaceStructs.ReadSpace, error) {
 	// Create the space
 	space, err := s.tsw.CreateSpace(ctx, body)
 	if err != nil {
@@ -67,15 +86,16 @@ func (s *authSpaceService) CreateInitialSpace(ctx context.Context, body *spaceSt
 
 	// Assign global role to user
 	if err := s.asw.AddRoleToUser(ctx, *body.CreatedBy, superAdminRole.ID); err != nil {
-		logger.Warnf(ctx, "Failed to assign global role to user: %v", err)
+		logger.Errorf(ctx, "Failed to assign global role to user: %v", err)
+		return nil, err
 	}
 
 	// Assign space-specific role
 	if _, err := s.tsw.AddRoleToUserInSpace(ctx, *body.CreatedBy, space.ID, superAdminRole.ID); err != nil {
-		logger.Warnf(ctx, "Failed to assign space role to user: %v", err)
+		logger.Errorf(ctx, "Failed to assign space role to user: %v", err)
+		return nil, err
 	}
 
-	logger.Infof(ctx, "Successfully created initial space '%s' with user assignments", space.Name)
 	return space, nil
 }
 
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	accessStructs "ncobase/core/access/structs"
+	"ncobase/core/auth/data"
+	"ncobase/core/auth/wrapper"
+	spaceStructs "ncobase/core/space/structs"
+	"ncobase/internal/utils"
+
+	entsql "entgo.io/ent/dialect/sql"
+	ncoreData "github.com/ncobase/ncore/data"
+	dataConfig "github.com/ncobase/ncore/data/config"
+	ext "github.com/ncobase/ncore/extension/types"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriver connects the data layer to a sqlite file
+type sqliteDriver struct{}
+
+func (sqliteDriver) Name() string { return "sqlite3" }
+
+func (sqliteDriver) Connect(_ context.Context, cfg any) (any, error) {
+	return sql.Open("sqlite3", cfg.(*dataConfig.DBNode).Source)
+}
+
+func (sqliteDriver) Close(conn any) error { return conn.(*sql.DB).Close() }
+
+func (sqliteDriver) Ping(ctx context.Context, conn any) error {
+	return conn.(*sql.DB).PingContext(ctx)
+}
+
+func init() {
+	ncoreData.RegisterDatabaseDriver(sqliteDriver{})
+}
+
+// setupSteps records each step of the initial space setup as a row, failing the step named fail
+type setupSteps struct {
+	wrapper.SpaceServiceInterface
+	wrapper.UserSpaceServiceInterface
+	wrapper.UserSpaceRoleServiceInterface
+	wrapper.RoleServiceInterface
+	wrapper.UserRoleServiceInterface
+	db   *sql.DB
+	fail string
+}
+
+func (s *setupSteps) record(ctx context.Context, step string) error {
+	if step == s.fail {
+		return errors.New(step + " failed")
+	}
+	drv := utils.ContextTxDriver(entsql.OpenDB("sqlite3", s.db))
+	return drv.Exec(ctx, "INSERT INTO steps (step) VALUES (?)", []any{step}, nil)
+}
+
+func (s *setupSteps) Create(ctx context.Context, body *spaceStructs.CreateSpaceBody) (*spaceStructs.ReadSpace, error) {
+	if err := s.record(ctx, "space"); err != nil {
+		return nil, err
+	}
+	return &spaceStructs.ReadSpace{ID: "s1", Name: body.Name}, nil
+}
+
+func (s *setupSteps) Find(_ context.Context, _ *accessStructs.FindRole) (*accessStructs.ReadRole, error) {
+	return nil, errors.New("role not found")
+}
+
+func (s *setupSteps) CreateSuperAdminRole(ctx context.Context) (*accessStructs.ReadRole, error) {
+	if err := s.record(ctx, "role"); err != nil {
+		return nil, err
+	}
+	return &accessStructs.ReadRole{ID: "r1", Slug: "super-admin"}, nil
+}
+
+func (s *setupSteps) AddUserToSpace(ctx context.Context, _, _ string) (*spaceStructs.UserSpace, error) {
+	return &spaceStructs.UserSpace{}, s.record(ctx, "member")
+}
+
+func (s *setupSteps) AddRoleToUser(ctx context.Context, _, _ string) error {
+	return s.record(ctx, "global role")
+}
+
+func (s *setupSteps) AddRoleToUserInSpace(ctx context.Context, _, _, _ string) (*spaceStructs.UserSpaceRole, error) {
+	return &spaceStructs.UserSpaceRole{}, s.record(ctx, "space role")
+}
+
+// setupServices serves setupSteps as every cross service of the initial space setup
+type setupServices struct {
+	ext.ManagerInterface
+	steps *setupSteps
+}
+
+func (m setupServices) GetCrossService(_, _ string) (any, error) {
+	return m.steps, nil
+}
+
+func newTestSpaceSetup(t *testing.T) (*authSpaceService, *setupSteps) {
+	t.Helper()
+	d, cleanup, err := ncoreData.New(&dataConfig.Config{
+		Database: &dataConfig.Database{
+			Master: &dataConfig.DBNode{Driver: "sqlite3", Source: filepath.Join(t.TempDir(), "test.db")},
+		},
+		Search: &dataConfig.Search{},
+	}, true)
+	if err != nil {
+		t.Fatalf("open data: %v", err)
+	}
+	t.Cleanup(func() { cleanup() })
+	if _, err := d.GetMasterDB().Exec("CREATE TABLE steps (step TEXT)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	steps := &setupSteps{db: d.GetMasterDB()}
+	em := setupServices{steps: steps}
+	return &authSpaceService{
+		d:   &data.Data{Data: d},
+		tsw: wrapper.NewSpaceServiceWrapper(em),
+		asw: wrapper.NewAccessServiceWrapper(em),
+	}, steps
+}
+
+func countSteps(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM steps").Scan(&n); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	return n
+}
+
+func TestCreateInitialSpaceRollsBackOnFailure(t *testing.T) {
+	s, steps := newTestSpaceSetup(t)
+	steps.fail = "space role"
+	userID := "u1"
+
+	if _, err := s.CreateInitialSpace(context.Background(), &spaceStructs.CreateSpaceBody{
+		SpaceBody: spaceStructs.SpaceBody{Name: "Acme", CreatedBy: &userID},
+	}); err == nil {
+		t.Fatal("setup succeeded, want the last step to fail it")
+	}
+	if n := countSteps(t, steps.db); n != 0 {
+		t.Fatalf("%d steps left after the failed setup, want none", n)
+	}
+
+	steps.fail = ""
+	space, err := s.CreateInitialSpace(context.Background(), &spaceStructs.CreateSpaceBody{
+		SpaceBody: spaceStructs.SpaceBody{Name: "Acme", CreatedBy: &userID},
+	})
+	if err != nil || space.ID != "s1" {
+		t.Fatalf("CreateInitialSpace = %v, %v", space, err)
+	}
+	if n := countSteps(t, steps.db); n != 5 {
+		t.Fatalf("%d steps committed, want 5", n)
+	}
+}
@@ -0,0 +1,80 @@
+package structs
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Default lockout policy values.
+const (
+	DefaultLockoutMaxAttempts   = 5
+	DefaultLockoutIPMaxAttempts = 20
+	DefaultLockoutDelayAfter    = 3
+	DefaultLockoutBaseDelay     = time.Second
+	DefaultLockoutMaxDelay      = 30 * time.Second
+	DefaultLockoutWindow        = 15 * time.Minute
+	DefaultLockoutCooldown      = 15 * time.Minute
+)
+
+// LockoutPolicy configures failed login throttling.
+type LockoutPolicy struct {
+	Enabled       bool          `json:"enabled"`
+	MaxAttempts   int           `json:"max_attempts"`    // Account failures before locking
+	IPMaxAttempts int           `json:"ip_max_attempts"` // IP failures before locking
+	DelayAfter    int           `json:"delay_after"`     // Account failures before delays start
+	BaseDelay     time.Duration `json:"base_delay"`      // First delay, doubled on every further failure
+	MaxDelay      time.Duration `json:"max_delay"`
+	Window        time.Duration `json:"window"`   // How long failures are remembered
+	Cooldown      time.Duration `json:"cooldown"` // How long a lock lasts
+}
+
+// DefaultLockoutPolicy returns the default lockout policy.
+func DefaultLockoutPolicy() *LockoutPolicy {
+	return &LockoutPolicy{
+		Enabled:       true,
+		MaxAttempts:   DefaultLockoutMaxAttempts,
+		IPMaxAttempts: DefaultLockoutIPMaxAttempts,
+		DelayAfter:    DefaultLockoutDelayAfter,
+		BaseDelay:     DefaultLockoutBaseDelay,
+		MaxDelay:      DefaultLockoutMaxDelay,
+		Window:        DefaultLockoutWindow,
+		Cooldown:      DefaultLockoutCooldown,
+	}
+}
+
+// Delay returns how long to wait after the given number of failures.
+func (p *LockoutPolicy) Delay(failures int64) time.Duration {
+	if p.DelayAfter <= 0 || failures < int64(p.DelayAfter) {
+		return 0
+	}
+	exp := float64(failures - int64(p.DelayAfter))
+	delay := time.Duration(float64(p.BaseDelay) * math.Pow(2, exp))
+	if delay <= 0 || delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// LockoutStatus reports the failed login state of an account.
+type LockoutStatus struct {
+	UserID     string `json:"user_id"`
+	Locked     bool   `json:"locked"`
+	Failures   int64  `json:"failures"`
+	RetryAfter int64  `json:"retry_after,omitempty"` // Seconds until login is allowed again
+}
+
+// LoginBlockedError is returned while login attempts are delayed or locked.
+type LoginBlockedError struct {
+	Locked     bool
+	RetryAfter time.Duration
+}
+
+// Error implements error.
+func (e *LoginBlockedError) Error() string {
+	seconds := int64(math.Ceil(e.RetryAfter.Seconds()))
+	if e.Locked {
+		return fmt.Sprintf("too many failed login attempts, account locked, retry in %d seconds", seconds)
+	}
+	return fmt.Sprintf("too many failed login attempts, retry in %d seconds", seconds)
+}
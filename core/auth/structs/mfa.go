@@ -9,7 +9,7 @@ type MFALoginVerifyBody struct {
 
 // TwoFactorSetupBody starts 2FA setup.
 type TwoFactorSetupBody struct {
-	Method string `json:"method" validate:"required"` // "app"
+	Method string `json:"method,omitempty"` // "app", the default
 	Phone  string `json:"phone,omitempty"`
 }
 
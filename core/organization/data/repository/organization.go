@@ -7,6 +7,7 @@ import (
 	"ncobase/core/organization/data/ent"
 	organizationEnt "ncobase/core/organization/data/ent/organization"
 	"ncobase/core/organization/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	nd "github.com/ncobase/ncore/data"
@@ -56,8 +57,8 @@ func NewOrganizationRepository(d *data.Data) OrganizationRepositoryInterface {
 	return &organizationRepository{
 		data:              d,
 		ec:                d.GetMasterEntClient(),
-		organizationCache: cache.NewCache[ent.Organization](redisClient, "ncse_organization:organizations"),
-		slugMappingCache:  cache.NewCache[string](redisClient, "ncse_organization:organization_mappings"),
+		organizationCache: utils.NewRetryCache(cache.NewCache[ent.Organization](redisClient, "ncse_organization:organizations")),
+		slugMappingCache:  utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_organization:organization_mappings")),
 		organizationTTL:   time.Hour * 2,
 		sc:                nd.NewSearchClient(d.Data),
 	}
@@ -262,7 +263,7 @@ func (r *organizationRepository) ListWithCount(ctx context.Context, params *stru
 		if err != nil {
 			return nil, 0, err
 		}
-		builder = applyCursorCondition(builder, id, timestamp, params.Direction, params.SortBy)
+		builder = applyCursorCondition(ctx, builder, id, timestamp, params.Direction, params.SortBy)
 	}
 
 	// Execute count query
@@ -291,12 +292,12 @@ func applySorting(builder *ent.OrganizationQuery, sortBy string) *ent.Organizati
 	}
 }
 
-func applyCursorCondition(builder *ent.OrganizationQuery, id string, value any, direction string, sortBy string) *ent.OrganizationQuery {
+func applyCursorCondition(ctx context.Context, builder *ent.OrganizationQuery, id string, value any, direction string, sortBy string) *ent.OrganizationQuery {
 	switch sortBy {
 	case structs.SortByCreatedAt:
 		timestamp, ok := value.(int64)
 		if !ok {
-			logger.Errorf(context.Background(), "Invalid timestamp value for cursor")
+			logger.Errorf(ctx, "Invalid timestamp value for cursor")
 			return builder
 		}
 		if direction == "backward" {
@@ -320,7 +321,7 @@ func applyCursorCondition(builder *ent.OrganizationQuery, id string, value any,
 			),
 		)
 	default:
-		return applyCursorCondition(builder, id, value, direction, structs.SortByCreatedAt)
+		return applyCursorCondition(ctx, builder, id, value, direction, structs.SortByCreatedAt)
 	}
 }
 
@@ -366,7 +367,7 @@ func (r *organizationRepository) FindOrganization(ctx context.Context, params *s
 	// execute the builder.
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
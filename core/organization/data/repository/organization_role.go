@@ -8,6 +8,7 @@ import (
 	organizationEnt "ncobase/core/organization/data/ent/organization"
 	organizationRoleEnt "ncobase/core/organization/data/ent/organizationrole"
 	"ncobase/core/organization/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -47,9 +48,9 @@ func NewOrganizationRoleRepository(d *data.Data) OrganizationRoleRepositoryInter
 
 	return &organizationRoleRepository{
 		data:                   d,
-		organizationRoleCache:  cache.NewCache[ent.OrganizationRole](redisClient, "ncse_organization:organization_roles"),
-		organizationRolesCache: cache.NewCache[[]string](redisClient, "ncse_organization:organization_role_mappings"),
-		roleOrganizationsCache: cache.NewCache[[]string](redisClient, "ncse_organization:role_organization_mappings"),
+		organizationRoleCache:  utils.NewRetryCache(cache.NewCache[ent.OrganizationRole](redisClient, "ncse_organization:organization_roles")),
+		organizationRolesCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_organization:organization_role_mappings")),
+		roleOrganizationsCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_organization:role_organization_mappings")),
 		relationshipTTL:        time.Hour * 2, // 2 hours cache TTL
 	}
 }
@@ -98,7 +99,7 @@ func (r *organizationRoleRepository) GetByOrgID(ctx context.Context, id string)
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "organizationRoleRepo.GetByOrgID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -150,7 +151,7 @@ func (r *organizationRoleRepository) GetByRoleID(ctx context.Context, id string)
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "organizationRoleRepo.GetByRoleID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -283,7 +284,7 @@ func (r *organizationRoleRepository) GetRolesByOrgID(ctx context.Context, organi
 	// Cache role IDs for future use
 	go func() {
 		if err := r.organizationRolesCache.SetArray(context.Background(), cacheKey, roleIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache organization roles %s: %v", organizationID, err)
+			logger.Debugf(ctx, "Failed to cache organization roles %s: %v", organizationID, err)
 		}
 	}()
 
@@ -324,7 +325,7 @@ func (r *organizationRoleRepository) GetOrganizationsByRoleID(ctx context.Contex
 	// Cache organization IDs for future use
 	go func() {
 		if err := r.roleOrganizationsCache.SetArray(context.Background(), cacheKey, organizationIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache role organizations %s: %v", roleID, err)
+			logger.Debugf(ctx, "Failed to cache role organizations %s: %v", roleID, err)
 		}
 	}()
 
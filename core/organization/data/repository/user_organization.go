@@ -7,6 +7,7 @@ import (
 	"ncobase/core/organization/data/ent"
 	userOrganizationEnt "ncobase/core/organization/data/ent/userorganization"
 	"ncobase/core/organization/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -49,10 +50,10 @@ func NewUserOrganizationRepository(d *data.Data) UserOrganizationRepositoryInter
 
 	return &userOrganizationRepository{
 		data:                       d,
-		userOrganizationCache:      cache.NewCache[ent.UserOrganization](redisClient, "ncse_organization:user_organizations"),
-		userOrganizationsCache:     cache.NewCache[[]string](redisClient, "ncse_organization:user_organization_mappings"),
-		organizationUsersCache:     cache.NewCache[[]string](redisClient, "ncse_organization:organization_user_mappings"),
-		organizationRoleUsersCache: cache.NewCache[[]string](redisClient, "ncse_organization:organization_role_user_mappings"),
+		userOrganizationCache:      utils.NewRetryCache(cache.NewCache[ent.UserOrganization](redisClient, "ncse_organization:user_organizations")),
+		userOrganizationsCache:     utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_organization:user_organization_mappings")),
+		organizationUsersCache:     utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_organization:organization_user_mappings")),
+		organizationRoleUsersCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_organization:organization_role_user_mappings")),
 		relationshipTTL:            time.Hour * 2, // 2 hours cache TTL
 	}
 }
@@ -228,7 +229,7 @@ func (r *userOrganizationRepository) GetByOrgIDAndRole(ctx context.Context, id s
 			r.cacheUserOrganization(context.Background(), uo)
 		}
 		if err := r.organizationRoleUsersCache.SetArray(context.Background(), cacheKey, userIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache organization role users %s:%s: %v", id, string(role), err)
+			logger.Debugf(ctx, "Failed to cache organization role users %s:%s: %v", id, string(role), err)
 		}
 	}()
 
@@ -256,7 +257,7 @@ func (r *userOrganizationRepository) GetUserOrganization(ctx context.Context, ui
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "userOrganizationRepo.GetUserOrganization error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -375,7 +376,7 @@ func (r *userOrganizationRepository) GetOrganizationsByUserID(ctx context.Contex
 	// Cache organization IDs for future use
 	go func() {
 		if err := r.userOrganizationsCache.SetArray(context.Background(), cacheKey, organizationIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache user organizations %s: %v", userID, err)
+			logger.Debugf(ctx, "Failed to cache user organizations %s: %v", userID, err)
 		}
 	}()
 
@@ -408,7 +409,7 @@ func (r *userOrganizationRepository) GetUsersByOrgID(ctx context.Context, organi
 	// Cache user IDs for future use
 	go func() {
 		if err := r.organizationUsersCache.SetArray(context.Background(), cacheKey, userIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache organization users %s: %v", organizationID, err)
+			logger.Debugf(ctx, "Failed to cache organization users %s: %v", organizationID, err)
 		}
 	}()
 
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -30,7 +31,7 @@ func (OrganizationRole) Annotations() []schema.Annotation {
 // Mixin of the OrganizationRole.
 func (OrganizationRole) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.OrgID,
 		mixin.RoleID,
 		mixin.OperatorBy{},
@@ -5,6 +5,7 @@ import (
 
 	"entgo.io/ent/schema/field"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/entsql"
@@ -29,7 +30,7 @@ func (UserOrganization) Annotations() []schema.Annotation {
 // Mixin of the UserOrganization.
 func (UserOrganization) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.UserID,
 		mixin.OrgID,
 		mixin.OperatorBy{},
@@ -3,10 +3,10 @@ package handler
 import (
 	"ncobase/core/organization/service"
 	"ncobase/core/organization/structs"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/net/resp"
 	"github.com/ncobase/ncore/types"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"ncobase/core/organization/data/repository"
+	"ncobase/internal/dberr"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/logging/logger"
@@ -14,7 +15,7 @@ import (
 func handleEntError(ctx context.Context, k string, err error) error {
 	if repository.IsNotFound(err) {
 		logger.Errorf(ctx, "Error not found in %s: %v", k, err)
-		return errors.New(ecode.NotExist(k))
+		return dberr.NotFound(ecode.NotExist(k))
 	}
 	if repository.IsConstraintError(err) {
 		logger.Errorf(ctx, "Error constraint in %s: %v", k, err)
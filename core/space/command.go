@@ -0,0 +1,146 @@
+package space
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	accessData "ncobase/core/access/data"
+	accessRepo "ncobase/core/access/data/repository"
+	accessService "ncobase/core/access/service"
+	accessStructs "ncobase/core/access/structs"
+	"ncobase/core/space/data"
+	"ncobase/core/space/service"
+	"ncobase/core/space/structs"
+	userData "ncobase/core/user/data"
+	userRepo "ncobase/core/user/data/repository"
+	"ncobase/internal/command"
+	"os"
+
+	"github.com/ncobase/ncore/config"
+)
+
+// directoryBatchSize is the number of users or roles looked up per query
+const directoryBatchSize = 500
+
+func init() {
+	command.Register(&command.Command{
+		Name:  "check-memberships",
+		Usage: "check-memberships [--dry-run=false] [--default-role=<slug>]",
+		Run:   runCheckMemberships,
+	})
+}
+
+// runCheckMemberships reports and optionally repairs user space memberships, space role
+// bindings and global role bindings that drifted apart
+func runCheckMemberships(ctx context.Context, conf *config.Config, args []string) error {
+	fs := flag.NewFlagSet("check-memberships", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", true, "only report issues, pass --dry-run=false to repair")
+	defaultRole := fs.String("default-role", "", "role slug given to space members without a role, they are only reported when empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	d, cleanup, err := data.New(conf.Data, conf.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to connect space data: %w", err)
+	}
+	defer cleanup()
+
+	ad, accessCleanup, err := accessData.New(conf.Data, conf.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to connect access data: %w", err)
+	}
+	defer accessCleanup()
+
+	ud, userCleanup, err := userData.New(conf.Data, conf.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to connect user data: %w", err)
+	}
+	defer userCleanup()
+
+	directory := &membershipDirectory{
+		users:     userRepo.NewUserRepository(ud),
+		roles:     accessRepo.NewRoleRepository(ad),
+		userRoles: accessRepo.NewUserRoleRepository(ad),
+	}
+
+	opts := &structs.MembershipCheckOptions{DryRun: *dryRun}
+	if *defaultRole != "" {
+		role, err := directory.roles.GetBySlug(ctx, *defaultRole)
+		if err != nil {
+			return fmt.Errorf("default role %s: %w", *defaultRole, err)
+		}
+		opts.DefaultRoleID = role.ID
+	}
+
+	memberships, err := service.NewMembershipChecker(d, directory).Check(ctx, opts)
+	if err != nil {
+		return err
+	}
+	userRoles, err := accessService.NewRoleBindingChecker(ad, directory).Check(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Memberships *structs.MembershipReport        `json:"memberships"`
+		UserRoles   *accessStructs.RoleBindingReport `json:"user_roles"`
+	}{memberships, userRoles})
+}
+
+// membershipDirectory looks up users and roles for the membership checks
+type membershipDirectory struct {
+	users     userRepo.UserRepositoryInterface
+	roles     accessRepo.RoleRepositoryInterface
+	userRoles accessRepo.UserRoleRepositoryInterface
+}
+
+// ExistingUsers returns which of the user IDs exist
+func (m *membershipDirectory) ExistingUsers(ctx context.Context, ids []string) (map[string]bool, error) {
+	found := make(map[string]bool, len(ids))
+	for start := 0; start < len(ids); start += directoryBatchSize {
+		rows, err := m.users.GetByIDs(ctx, ids[start:min(start+directoryBatchSize, len(ids))])
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			found[row.ID] = true
+		}
+	}
+	return found, nil
+}
+
+// ExistingRoles returns the slug of each of the role IDs that exists
+func (m *membershipDirectory) ExistingRoles(ctx context.Context, ids []string) (map[string]string, error) {
+	found := make(map[string]string, len(ids))
+	for start := 0; start < len(ids); start += directoryBatchSize {
+		rows, err := m.roles.GetByIDs(ctx, ids[start:min(start+directoryBatchSize, len(ids))])
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if row != nil {
+				found[row.ID] = row.Slug
+			}
+		}
+	}
+	return found, nil
+}
+
+// GlobalRoles returns the IDs of the roles each user holds outside of spaces
+func (m *membershipDirectory) GlobalRoles(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	held := make(map[string][]string, len(userIDs))
+	for start := 0; start < len(userIDs); start += directoryBatchSize {
+		rows, err := m.userRoles.GetByUserIDs(ctx, userIDs[start:min(start+directoryBatchSize, len(userIDs))])
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			held[row.UserID] = append(held[row.UserID], row.RoleID)
+		}
+	}
+	return held, nil
+}
@@ -3,13 +3,30 @@ package repository
 import (
 	"ncobase/core/space/data/ent"
 	"ncobase/core/space/structs"
+	"ncobase/internal/fieldcrypt"
 )
 
+// Schema names in encryption.schemas
+const (
+	EncryptionSchema        = "space"
+	SettingEncryptionSchema = "space_setting"
+	QuotaEncryptionSchema   = "space_quota"
+	BillingEncryptionSchema = "space_billing"
+)
+
+func init() {
+	fieldcrypt.RegisterJSON(EncryptionSchema, "extras")
+	fieldcrypt.RegisterJSON(SettingEncryptionSchema, "extras")
+	fieldcrypt.RegisterJSON(QuotaEncryptionSchema, "extras")
+	fieldcrypt.RegisterJSON(BillingEncryptionSchema, "extras")
+}
+
 // SerializeSpace converts ent.Space to structs.ReadSpace.
 func SerializeSpace(row *ent.Space) *structs.ReadSpace {
 	if row == nil {
 		return nil
 	}
+	extras := fieldcrypt.For(EncryptionSchema).DecryptJSON("extras", row.Extras)
 	return &structs.ReadSpace{
 		ID:          row.ID,
 		Name:        row.Name,
@@ -24,7 +41,7 @@ func SerializeSpace(row *ent.Space) *structs.ReadSpace {
 		Description: row.Description,
 		Order:       &row.Order,
 		Disabled:    row.Disabled,
-		Extras:      &row.Extras,
+		Extras:      &extras,
 		ExpiredAt:   &row.ExpiredAt,
 		CreatedBy:   &row.CreatedBy,
 		CreatedAt:   &row.CreatedAt,
@@ -100,6 +117,7 @@ func SerializeSpaceQuota(row *ent.SpaceQuota) *structs.ReadSpaceQuota {
 	if row == nil {
 		return nil
 	}
+	extras := fieldcrypt.For(QuotaEncryptionSchema).DecryptJSON("extras", row.Extras)
 	result := &structs.ReadSpaceQuota{
 		ID:          row.ID,
 		SpaceID:     row.SpaceID,
@@ -110,7 +128,7 @@ func SerializeSpaceQuota(row *ent.SpaceQuota) *structs.ReadSpaceQuota {
 		Unit:        structs.QuotaUnit(row.Unit),
 		Description: row.Description,
 		Enabled:     row.Enabled,
-		Extras:      &row.Extras,
+		Extras:      &extras,
 		CreatedBy:   &row.CreatedBy,
 		CreatedAt:   &row.CreatedAt,
 		UpdatedBy:   &row.UpdatedBy,
@@ -134,6 +152,7 @@ func SerializeSpaceBilling(row *ent.SpaceBilling) *structs.ReadSpaceBilling {
 	if row == nil {
 		return nil
 	}
+	extras := fieldcrypt.For(BillingEncryptionSchema).DecryptJSON("extras", row.Extras)
 	result := &structs.ReadSpaceBilling{
 		ID:            row.ID,
 		SpaceID:       row.SpaceID,
@@ -149,7 +168,7 @@ func SerializeSpaceBilling(row *ent.SpaceBilling) *structs.ReadSpaceBilling {
 		PaidAt:        &row.PaidAt,
 		DueDate:       &row.DueDate,
 		UsageDetails:  &row.UsageDetails,
-		Extras:        &row.Extras,
+		Extras:        &extras,
 		CreatedBy:     &row.CreatedBy,
 		CreatedAt:     &row.CreatedAt,
 		UpdatedBy:     &row.UpdatedBy,
@@ -173,6 +192,7 @@ func SerializeSpaceSetting(row *ent.SpaceSetting) *structs.ReadSpaceSetting {
 	if row == nil {
 		return nil
 	}
+	extras := fieldcrypt.For(SettingEncryptionSchema).DecryptJSON("extras", row.Extras)
 	return &structs.ReadSpaceSetting{
 		ID:           row.ID,
 		SpaceID:      row.SpaceID,
@@ -188,7 +208,7 @@ func SerializeSpaceSetting(row *ent.SpaceSetting) *structs.ReadSpaceSetting {
 		IsRequired:   row.IsRequired,
 		IsReadonly:   row.IsReadonly,
 		Validation:   &row.Validation,
-		Extras:       &row.Extras,
+		Extras:       &extras,
 		CreatedBy:    &row.CreatedBy,
 		CreatedAt:    &row.CreatedAt,
 		UpdatedBy:    &row.UpdatedBy,
@@ -7,12 +7,14 @@ import (
 	"ncobase/core/space/data/ent"
 	spaceEnt "ncobase/core/space/data/ent/space"
 	"ncobase/core/space/structs"
+	"ncobase/internal/fieldcrypt"
+	"ncobase/internal/page"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 
 	"github.com/ncobase/ncore/data/cache"
-	"github.com/ncobase/ncore/data/paging"
 	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/types"
 	"github.com/ncobase/ncore/utils/convert"
@@ -26,10 +28,14 @@ import (
 // SpaceRepositoryInterface represents the space repository interface.
 type SpaceRepositoryInterface interface {
 	Create(ctx context.Context, body *structs.CreateSpaceBody) (*ent.Space, error)
+	CreateBatch(ctx context.Context, bodies []*structs.CreateSpaceBody) ([]*ent.Space, error)
+	GetByNamesOrSlugs(ctx context.Context, names, slugs []string) ([]*ent.Space, error)
 	GetBySlug(ctx context.Context, slug string) (*ent.Space, error)
 	GetByUser(ctx context.Context, user string) (*ent.Space, error)
 	GetIDByUser(ctx context.Context, user string) (string, error)
+	GetIDByHost(ctx context.Context, host string) (string, error)
 	GetByIDs(ctx context.Context, ids []string) ([]*ent.Space, error)
+	Search(ctx context.Context, query string, ids []string, limit int) ([]*ent.Space, error)
 	Update(ctx context.Context, slug string, updates types.JSON) (*ent.Space, error)
 	List(ctx context.Context, params *structs.ListSpaceParams) ([]*ent.Space, error)
 	Delete(ctx context.Context, id string) error
@@ -45,7 +51,9 @@ type spaceRepository struct {
 	spaceCache       cache.ICache[ent.Space]
 	slugMappingCache cache.ICache[string] // Maps slug to space ID
 	userMappingCache cache.ICache[string] // Maps user ID to space ID
+	hostMappingCache cache.ICache[string] // Maps URL host to space ID, "" for unknown hosts
 	spaceTTL         time.Duration
+	hostMissTTL      time.Duration
 }
 
 // NewSpaceRepository creates a new space repository.
@@ -57,16 +65,76 @@ func NewSpaceRepository(d *data.Data) SpaceRepositoryInterface {
 		data:             d,
 		sc:               sc,
 		ec:               d.GetMasterEntClient(),
-		spaceCache:       cache.NewCache[ent.Space](redisClient, "ncse_space:spaces"),
-		slugMappingCache: cache.NewCache[string](redisClient, "ncse_space:slug_mappings"),
-		userMappingCache: cache.NewCache[string](redisClient, "ncse_space:user_mappings"),
-		spaceTTL:         time.Hour * 4, // 4 hours cache TTL
+		spaceCache:       utils.NewRetryCache(cache.NewCache[ent.Space](redisClient, "ncse_space:spaces")),
+		slugMappingCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_space:slug_mappings")),
+		userMappingCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_space:user_mappings")),
+		hostMappingCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_space:host_mappings")),
+		spaceTTL:         time.Hour * 4,   // 4 hours cache TTL
+		hostMissTTL:      time.Minute * 5, // unknown hosts are retried after 5 minutes
 	}
 }
 
 // Create create space
 func (r *spaceRepository) Create(ctx context.Context, body *structs.CreateSpaceBody) (*ent.Space, error) {
-	builder := r.ec.Space.Create()
+	builder, err := r.createBuilder(r.ec.Space, body)
+	if err != nil {
+		return nil, err
+	}
+	space, err := builder.Save(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "spaceRepo.Create error: %v", err)
+		return nil, err
+	}
+
+	r.afterCreate(ctx, space)
+
+	return space, nil
+}
+
+// CreateBatch creates spaces in one transaction, none are created when one fails
+func (r *spaceRepository) CreateBatch(ctx context.Context, bodies []*structs.CreateSpaceBody) ([]*ent.Space, error) {
+	spaces := make([]*ent.Space, 0, len(bodies))
+
+	err := r.data.WithEntTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+		for _, body := range bodies {
+			builder, err := r.createBuilder(tx.Space, body)
+			if err != nil {
+				return fmt.Errorf("space %s: %w", body.Name, err)
+			}
+			space, err := builder.Save(ctx)
+			if err != nil {
+				return fmt.Errorf("space %s: %w", body.Name, err)
+			}
+			spaces = append(spaces, space)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Errorf(ctx, "spaceRepo.CreateBatch error: %v", err)
+		return nil, err
+	}
+
+	for _, space := range spaces {
+		r.afterCreate(ctx, space)
+	}
+
+	return spaces, nil
+}
+
+// GetByNamesOrSlugs gets the spaces matching any of the names or slugs
+func (r *spaceRepository) GetByNamesOrSlugs(ctx context.Context, names, slugs []string) ([]*ent.Space, error) {
+	if len(names) == 0 && len(slugs) == 0 {
+		return nil, nil
+	}
+
+	return r.ec.Space.Query().
+		Where(spaceEnt.Or(spaceEnt.NameIn(names...), spaceEnt.SlugIn(slugs...))).
+		All(ctx)
+}
+
+// createBuilder prepares the create builder of a space on the given client
+func (r *spaceRepository) createBuilder(client *ent.SpaceClient, body *structs.CreateSpaceBody) (*ent.SpaceCreate, error) {
+	builder := client.Create()
 	builder.SetNillableName(&body.Name)
 	builder.SetNillableSlug(&body.Slug)
 	builder.SetNillableType(&body.Type)
@@ -86,26 +154,32 @@ func (r *spaceRepository) Create(ctx context.Context, body *structs.CreateSpaceB
 	}
 
 	if !validator.IsNil(body.Extras) && !validator.IsEmpty(body.Extras) {
-		builder.SetExtras(*body.Extras)
+		extras, err := fieldcrypt.For(EncryptionSchema).EncryptJSON("extras", *body.Extras)
+		if err != nil {
+			return nil, err
+		}
+		builder.SetExtras(extras)
 	}
 
-	space, err := builder.Save(ctx)
-	if err != nil {
-		logger.Errorf(ctx, "spaceRepo.Create error: %v", err)
-		return nil, err
-	}
+	return builder, nil
+}
 
-	// Create the space in Meilisearch index
-	if r.sc != nil {
-		if err = r.sc.Index(ctx, &search.IndexRequest{Index: "spaces", Document: space}); err != nil {
-			logger.Errorf(ctx, "spaceRepo.Create error creating Meilisearch index: %v", err)
+// afterCreate indexes and caches a created space once it is committed
+func (r *spaceRepository) afterCreate(ctx context.Context, space *ent.Space) {
+	utils.OnCommit(ctx, func() {
+		// Create the space in Meilisearch index
+		if r.sc != nil {
+			if err := r.sc.Index(ctx, &search.IndexRequest{Index: "spaces", Document: space}); err != nil {
+				logger.Errorf(ctx, "spaceRepo.Create error creating Meilisearch index: %v", err)
+			}
 		}
-	}
-
-	// Cache the space
-	go r.cacheSpace(context.Background(), space)
 
-	return space, nil
+		// Cache the space, dropping any cached miss for its host
+		go func() {
+			r.invalidateHostMapping(context.Background(), space.URL)
+			r.cacheSpace(context.Background(), space)
+		}()
+	})
 }
 
 // GetBySlug get space by slug or id
@@ -178,7 +252,53 @@ func (r *spaceRepository) GetIDByUser(ctx context.Context, userID string) (strin
 	go func() {
 		userKey := fmt.Sprintf("user:%s", userID)
 		if err := r.userMappingCache.Set(context.Background(), userKey, &id, r.spaceTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache user mapping %s: %v", userID, err)
+			logger.Debugf(ctx, "Failed to cache user mapping %s: %v", userID, err)
+		}
+	}()
+
+	return id, nil
+}
+
+// GetIDByHost get the id of the enabled space whose URL has the given host.
+// An unknown host returns an empty id without error.
+func (r *spaceRepository) GetIDByHost(ctx context.Context, host string) (string, error) {
+	host = structs.SpaceHost(host)
+	if host == "" {
+		return "", nil
+	}
+
+	// Try cache first, misses are cached as an empty id
+	hostKey := fmt.Sprintf("host:%s", host)
+	if spaceID, err := r.hostMappingCache.Get(ctx, hostKey); err == nil && spaceID != nil {
+		return *spaceID, nil
+	}
+
+	// Fallback to database, the URL may carry a scheme, port or path
+	rows, err := r.ec.Space.
+		Query().
+		Where(spaceEnt.URLContainsFold(host), spaceEnt.DisabledEQ(false)).
+		Order(ent.Asc(spaceEnt.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "spaceRepo.GetIDByHost error: %v", err)
+		return "", err
+	}
+
+	id := ""
+	for _, row := range rows {
+		if structs.SpaceHost(row.URL) == host {
+			id = row.ID
+			break
+		}
+	}
+
+	ttl := r.spaceTTL
+	if id == "" {
+		ttl = r.hostMissTTL
+	}
+	go func() {
+		if err := r.hostMappingCache.Set(context.Background(), hostKey, &id, ttl); err != nil {
+			logger.Debugf(ctx, "Failed to cache host mapping %s: %v", host, err)
 		}
 	}()
 
@@ -226,6 +346,45 @@ func (r *spaceRepository) GetByIDs(ctx context.Context, ids []string) ([]*ent.Sp
 	return spaces, nil
 }
 
+// Search finds spaces by name, title, slug or keywords, best match first.
+// A nil ids searches every space through the search index when available;
+// otherwise only the listed spaces are searched, which the database does directly.
+func (r *spaceRepository) Search(ctx context.Context, query string, ids []string, limit int) ([]*ent.Space, error) {
+	if ids != nil && len(ids) == 0 {
+		return []*ent.Space{}, nil
+	}
+
+	if ids == nil {
+		hitIDs, err := utils.SearchIDs(ctx, r.sc, "spaces", query, nil, limit)
+		if err == nil {
+			rows, err := r.GetByIDs(ctx, hitIDs)
+			if err != nil {
+				return nil, err
+			}
+			return utils.OrderByIDs(rows, hitIDs, func(row *ent.Space) string { return row.ID }), nil
+		}
+		logger.Debugf(ctx, "spaceRepo.Search index unavailable, using database: %v", err)
+	}
+
+	builder := r.ec.Space.Query().
+		Where(spaceEnt.Or(
+			spaceEnt.NameContainsFold(query),
+			spaceEnt.TitleContainsFold(query),
+			spaceEnt.SlugContainsFold(query),
+			spaceEnt.KeywordsContainsFold(query),
+		))
+	if ids != nil {
+		builder.Where(spaceEnt.IDIn(ids...))
+	}
+
+	rows, err := builder.Order(ent.Desc(spaceEnt.FieldUpdatedAt)).Limit(limit).All(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "spaceRepo.Search error: %v", err)
+		return nil, err
+	}
+	return rows, nil
+}
+
 // Update update space
 func (r *spaceRepository) Update(ctx context.Context, slug string, updates types.JSON) (*ent.Space, error) {
 	space, err := r.FindSpace(ctx, &structs.FindSpace{Slug: slug})
@@ -263,7 +422,11 @@ func (r *spaceRepository) Update(ctx context.Context, slug string, updates types
 		case "order":
 			builder.SetOrder(int(value.(float64)))
 		case "extras":
-			builder.SetExtras(value.(types.JSON))
+			extras, err := fieldcrypt.For(EncryptionSchema).EncryptJSON("extras", value.(types.JSON))
+			if err != nil {
+				return nil, err
+			}
+			builder.SetExtras(extras)
 		case "updated_by":
 			builder.SetNillableUpdatedBy(convert.ToPointer(value.(string)))
 		case "expired_at":
@@ -288,6 +451,7 @@ func (r *spaceRepository) Update(ctx context.Context, slug string, updates types
 	// Invalidate and re-cache
 	go func() {
 		r.invalidateSpaceCache(context.Background(), space)
+		r.invalidateHostMapping(context.Background(), updatedSpace.URL)
 		r.cacheSpace(context.Background(), updatedSpace)
 	}()
 
@@ -305,13 +469,14 @@ func (r *spaceRepository) List(ctx context.Context, params *structs.ListSpacePar
 	builder.Where(spaceEnt.DisabledEQ(false))
 
 	if params.Cursor != "" {
-		id, timestamp, err := paging.DecodeCursor(params.Cursor)
+		cursor, err := page.DecodeCursor(params.Cursor)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor: %v", err)
+			return nil, err
 		}
+		id, timestamp := cursor.ID, cursor.Key
 
 		if !nanoid.IsPrimaryKey(id) {
-			return nil, fmt.Errorf("invalid id in cursor: %s", id)
+			return nil, page.ErrInvalidCursor
 		}
 
 		if params.Direction == "backward" {
@@ -439,7 +604,7 @@ func (r *spaceRepository) FindSpace(ctx context.Context, params *structs.FindSpa
 
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
@@ -503,6 +668,20 @@ func (r *spaceRepository) invalidateSpaceCache(ctx context.Context, space *ent.S
 			logger.Debugf(ctx, "Failed to invalidate user mapping cache %s: %v", space.CreatedBy, err)
 		}
 	}
+
+	// Invalidate host mapping
+	r.invalidateHostMapping(ctx, space.URL)
+}
+
+func (r *spaceRepository) invalidateHostMapping(ctx context.Context, rawURL string) {
+	host := structs.SpaceHost(rawURL)
+	if host == "" {
+		return
+	}
+	hostKey := fmt.Sprintf("host:%s", host)
+	if err := r.hostMappingCache.Delete(ctx, hostKey); err != nil {
+		logger.Debugf(ctx, "Failed to invalidate host mapping cache %s: %v", host, err)
+	}
 }
 
 func (r *spaceRepository) getSpaceIDBySlug(ctx context.Context, slug string) (string, error) {
@@ -7,6 +7,8 @@ import (
 	"ncobase/core/space/data/ent"
 	spaceBillingEnt "ncobase/core/space/data/ent/spacebilling"
 	"ncobase/core/space/structs"
+	"ncobase/internal/fieldcrypt"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -52,11 +54,11 @@ func NewSpaceBillingRepository(d *data.Data) SpaceBillingRepositoryInterface {
 
 	return &spaceBillingRepository{
 		data:                      d,
-		billingCache:              cache.NewCache[ent.SpaceBilling](redisClient, "ncse_space:billings"),
-		spaceBillingsCache:        cache.NewCache[[]string](redisClient, "ncse_space:space_billing_mappings"),
-		statusBillingsCache:       cache.NewCache[[]string](redisClient, "ncse_space:status_billing_mappings"),
-		invoiceNumberBillingCache: cache.NewCache[string](redisClient, "ncse_space:invoice_billing_mappings"),
-		overdueBillingsCache:      cache.NewCache[[]string](redisClient, "ncse_space:overdue_billing_mappings"),
+		billingCache:              utils.NewRetryCache(cache.NewCache[ent.SpaceBilling](redisClient, "ncse_space:billings")),
+		spaceBillingsCache:        utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:space_billing_mappings")),
+		statusBillingsCache:       utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:status_billing_mappings")),
+		invoiceNumberBillingCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_space:invoice_billing_mappings")),
+		overdueBillingsCache:      utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:overdue_billing_mappings")),
 		billingTTL:                time.Hour * 2, // 2 hours cache TTL (billing data changes frequently)
 	}
 }
@@ -97,7 +99,11 @@ func (r *spaceBillingRepository) Create(ctx context.Context, body *structs.Creat
 	}
 
 	if !validator.IsNil(body.Extras) && !validator.IsEmpty(body.Extras) {
-		builder.SetExtras(*body.Extras)
+		extras, err := fieldcrypt.For(BillingEncryptionSchema).EncryptJSON("extras", *body.Extras)
+		if err != nil {
+			return nil, err
+		}
+		builder.SetExtras(extras)
 	}
 
 	row, err := builder.Save(ctx)
@@ -131,7 +137,7 @@ func (r *spaceBillingRepository) GetByID(ctx context.Context, id string) (*ent.S
 	row, err := r.data.GetSlaveEntClient().SpaceBilling.Get(ctx, id)
 	if err != nil {
 		logger.Errorf(ctx, "spaceBillingRepo.GetByID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -175,7 +181,7 @@ func (r *spaceBillingRepository) GetBySpaceID(ctx context.Context, spaceID strin
 		}
 
 		if err := r.spaceBillingsCache.SetArray(context.Background(), cacheKey, billingIDs, r.billingTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache space billings %s: %v", spaceID, err)
+			logger.Debugf(ctx, "Failed to cache space billings %s: %v", spaceID, err)
 		}
 	}()
 
@@ -220,7 +226,7 @@ func (r *spaceBillingRepository) GetOverdueBySpace(ctx context.Context, spaceID
 		}
 
 		if err := r.overdueBillingsCache.SetArray(context.Background(), cacheKey, billingIDs, r.billingTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache overdue billings %s: %v", spaceID, err)
+			logger.Debugf(ctx, "Failed to cache overdue billings %s: %v", spaceID, err)
 		}
 	}()
 
@@ -262,7 +268,7 @@ func (r *spaceBillingRepository) GetByStatus(ctx context.Context, status structs
 		}
 
 		if err := r.statusBillingsCache.SetArray(context.Background(), cacheKey, billingIDs, r.billingTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache status billings %s: %v", string(status), err)
+			logger.Debugf(ctx, "Failed to cache status billings %s: %v", string(status), err)
 		}
 	}()
 
@@ -329,7 +335,11 @@ func (r *spaceBillingRepository) Update(ctx context.Context, id string, updates
 		case "usage_details":
 			builder.SetUsageDetails(value.(types.JSON))
 		case "extras":
-			builder.SetExtras(value.(types.JSON))
+			extras, err := fieldcrypt.For(BillingEncryptionSchema).EncryptJSON("extras", value.(types.JSON))
+			if err != nil {
+				return nil, err
+			}
+			builder.SetExtras(extras)
 		case "updated_by":
 			builder.SetUpdatedBy(value.(string))
 		}
@@ -7,6 +7,7 @@ import (
 	"ncobase/core/space/data/ent"
 	spaceDictionaryEnt "ncobase/core/space/data/ent/spacedictionary"
 	"ncobase/core/space/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -21,7 +22,7 @@ type SpaceDictionaryRepositoryInterface interface {
 	GetBySpaceID(ctx context.Context, spaceID string) ([]*ent.SpaceDictionary, error)
 	GetByDictionaryID(ctx context.Context, dictionaryID string) ([]*ent.SpaceDictionary, error)
 	DeleteBySpaceIDAndDictionaryID(ctx context.Context, spaceID, dictionaryID string) error
-	DeleteAllBySpaceID(ctx context.Context, spaceID string) error
+	DeleteAllBySpaceID(ctx context.Context, spaceID string) (int, error)
 	DeleteAllByDictionaryID(ctx context.Context, dictionaryID string) error
 	IsDictionaryInSpace(ctx context.Context, spaceID, dictionaryID string) (bool, error)
 	GetSpaceDictionaries(ctx context.Context, spaceID string) ([]string, error)
@@ -42,9 +43,9 @@ func NewSpaceDictionaryRepository(d *data.Data) SpaceDictionaryRepositoryInterfa
 
 	return &spaceDictionaryRepository{
 		data:                   d,
-		spaceDictionaryCache:   cache.NewCache[ent.SpaceDictionary](redisClient, "ncse_space:space_dictionaries"),
-		spaceDictionariesCache: cache.NewCache[[]string](redisClient, "ncse_space:space_dict_mappings"),
-		dictionarySpacesCache:  cache.NewCache[[]string](redisClient, "ncse_space:dict_space_mappings"),
+		spaceDictionaryCache:   utils.NewRetryCache(cache.NewCache[ent.SpaceDictionary](redisClient, "ncse_space:space_dictionaries")),
+		spaceDictionariesCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:space_dict_mappings")),
+		dictionarySpacesCache:  utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:dict_space_mappings")),
 		relationshipTTL:        time.Hour * 2,
 	}
 }
@@ -132,8 +133,8 @@ func (r *spaceDictionaryRepository) DeleteBySpaceIDAndDictionaryID(ctx context.C
 	return nil
 }
 
-// DeleteAllBySpaceID deletes all space dictionaries by space ID.
-func (r *spaceDictionaryRepository) DeleteAllBySpaceID(ctx context.Context, spaceID string) error {
+// DeleteAllBySpaceID deletes all space dictionaries by space ID, returning the number deleted
+func (r *spaceDictionaryRepository) DeleteAllBySpaceID(ctx context.Context, spaceID string) (int, error) {
 	// Get existing relationships for cache invalidation
 	relationships, err := r.data.GetSlaveEntClient().SpaceDictionary.Query().
 		Where(spaceDictionaryEnt.SpaceIDEQ(spaceID)).All(ctx)
@@ -141,10 +142,11 @@ func (r *spaceDictionaryRepository) DeleteAllBySpaceID(ctx context.Context, spac
 		logger.Debugf(ctx, "Failed to get relationships for cache invalidation: %v", err)
 	}
 
-	if _, err := r.data.GetMasterEntClient().SpaceDictionary.Delete().
-		Where(spaceDictionaryEnt.SpaceIDEQ(spaceID)).Exec(ctx); err != nil {
+	deleted, err := r.data.GetMasterEntClient().SpaceDictionary.Delete().
+		Where(spaceDictionaryEnt.SpaceIDEQ(spaceID)).Exec(ctx)
+	if err != nil {
 		logger.Errorf(ctx, "spaceDictionaryRepo.DeleteAllBySpaceID error: %v", err)
-		return err
+		return 0, err
 	}
 
 	// Invalidate caches
@@ -156,7 +158,7 @@ func (r *spaceDictionaryRepository) DeleteAllBySpaceID(ctx context.Context, spac
 		}
 	}()
 
-	return nil
+	return deleted, nil
 }
 
 // DeleteAllByDictionaryID deletes all space dictionaries by dictionary ID.
@@ -243,7 +245,7 @@ func (r *spaceDictionaryRepository) GetSpaceDictionaries(ctx context.Context, sp
 	// Cache dictionary IDs for future use
 	go func() {
 		if err := r.spaceDictionariesCache.SetArray(context.Background(), cacheKey, dictionaryIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache space dictionaries %s: %v", spaceID, err)
+			logger.Debugf(ctx, "Failed to cache space dictionaries %s: %v", spaceID, err)
 		}
 	}()
 
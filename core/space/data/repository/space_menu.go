@@ -7,6 +7,7 @@ import (
 	"ncobase/core/space/data/ent"
 	spaceMenuEnt "ncobase/core/space/data/ent/spacemenu"
 	"ncobase/core/space/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -21,7 +22,7 @@ type SpaceMenuRepositoryInterface interface {
 	GetBySpaceID(ctx context.Context, spaceID string) ([]*ent.SpaceMenu, error)
 	GetByMenuID(ctx context.Context, menuID string) ([]*ent.SpaceMenu, error)
 	DeleteBySpaceIDAndMenuID(ctx context.Context, spaceID, menuID string) error
-	DeleteAllBySpaceID(ctx context.Context, spaceID string) error
+	DeleteAllBySpaceID(ctx context.Context, spaceID string) (int, error)
 	DeleteAllByMenuID(ctx context.Context, menuID string) error
 	IsMenuInSpace(ctx context.Context, spaceID, menuID string) (bool, error)
 	GetSpaceMenus(ctx context.Context, spaceID string) ([]string, error)
@@ -42,9 +43,9 @@ func NewSpaceMenuRepository(d *data.Data) SpaceMenuRepositoryInterface {
 
 	return &spaceMenuRepository{
 		data:            d,
-		spaceMenuCache:  cache.NewCache[ent.SpaceMenu](redisClient, "ncse_space:space_menus"),
-		spaceMenusCache: cache.NewCache[[]string](redisClient, "ncse_space:space_menu_mappings"),
-		menuSpacesCache: cache.NewCache[[]string](redisClient, "ncse_space:menu_space_mappings"),
+		spaceMenuCache:  utils.NewRetryCache(cache.NewCache[ent.SpaceMenu](redisClient, "ncse_space:space_menus")),
+		spaceMenusCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:space_menu_mappings")),
+		menuSpacesCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:menu_space_mappings")),
 		relationshipTTL: time.Hour * 2,
 	}
 }
@@ -132,8 +133,8 @@ func (r *spaceMenuRepository) DeleteBySpaceIDAndMenuID(ctx context.Context, spac
 	return nil
 }
 
-// DeleteAllBySpaceID deletes all space menus by space ID.
-func (r *spaceMenuRepository) DeleteAllBySpaceID(ctx context.Context, spaceID string) error {
+// DeleteAllBySpaceID deletes all space menus by space ID, returning the number deleted
+func (r *spaceMenuRepository) DeleteAllBySpaceID(ctx context.Context, spaceID string) (int, error) {
 	// Get existing relationships for cache invalidation
 	relationships, err := r.data.GetSlaveEntClient().SpaceMenu.Query().
 		Where(spaceMenuEnt.SpaceIDEQ(spaceID)).All(ctx)
@@ -141,10 +142,11 @@ func (r *spaceMenuRepository) DeleteAllBySpaceID(ctx context.Context, spaceID st
 		logger.Debugf(ctx, "Failed to get relationships for cache invalidation: %v", err)
 	}
 
-	if _, err := r.data.GetMasterEntClient().SpaceMenu.Delete().
-		Where(spaceMenuEnt.SpaceIDEQ(spaceID)).Exec(ctx); err != nil {
+	deleted, err := r.data.GetMasterEntClient().SpaceMenu.Delete().
+		Where(spaceMenuEnt.SpaceIDEQ(spaceID)).Exec(ctx)
+	if err != nil {
 		logger.Errorf(ctx, "spaceMenuRepo.DeleteAllBySpaceID error: %v", err)
-		return err
+		return 0, err
 	}
 
 	// Invalidate caches
@@ -156,7 +158,7 @@ func (r *spaceMenuRepository) DeleteAllBySpaceID(ctx context.Context, spaceID st
 		}
 	}()
 
-	return nil
+	return deleted, nil
 }
 
 // DeleteAllByMenuID deletes all space menus by menu ID.
@@ -243,7 +245,7 @@ func (r *spaceMenuRepository) GetSpaceMenus(ctx context.Context, spaceID string)
 	// Cache menu IDs for future use
 	go func() {
 		if err := r.spaceMenusCache.SetArray(context.Background(), cacheKey, menuIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache space menus %s: %v", spaceID, err)
+			logger.Debugf(ctx, "Failed to cache space menus %s: %v", spaceID, err)
 		}
 	}()
 
@@ -7,6 +7,7 @@ import (
 	"ncobase/core/space/data/ent"
 	spaceOptionEnt "ncobase/core/space/data/ent/spaceoption"
 	"ncobase/core/space/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -21,7 +22,7 @@ type SpaceOptionRepositoryInterface interface {
 	GetBySpaceID(ctx context.Context, spaceID string) ([]*ent.SpaceOption, error)
 	GetByOptionID(ctx context.Context, optionsID string) ([]*ent.SpaceOption, error)
 	DeleteBySpaceIDAndOptionID(ctx context.Context, spaceID, optionsID string) error
-	DeleteAllBySpaceID(ctx context.Context, spaceID string) error
+	DeleteAllBySpaceID(ctx context.Context, spaceID string) (int, error)
 	DeleteAllByOptionID(ctx context.Context, optionsID string) error
 	IsOptionsInSpace(ctx context.Context, spaceID, optionsID string) (bool, error)
 	GetSpaceOption(ctx context.Context, spaceID string) ([]string, error)
@@ -42,9 +43,9 @@ func NewSpaceOptionRepository(d *data.Data) SpaceOptionRepositoryInterface {
 
 	return &spaceOptionRepository{
 		data:                 d,
-		spaceOptionCache:     cache.NewCache[ent.SpaceOption](redisClient, "ncse_space:space_options"),
-		spaceOptionListCache: cache.NewCache[[]string](redisClient, "ncse_space:space_options_mappings"),
-		optionsSpacesCache:   cache.NewCache[[]string](redisClient, "ncse_space:options_space_mappings"),
+		spaceOptionCache:     utils.NewRetryCache(cache.NewCache[ent.SpaceOption](redisClient, "ncse_space:space_options")),
+		spaceOptionListCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:space_options_mappings")),
+		optionsSpacesCache:   utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:options_space_mappings")),
 		relationshipTTL:      time.Hour * 2,
 	}
 }
@@ -132,8 +133,8 @@ func (r *spaceOptionRepository) DeleteBySpaceIDAndOptionID(ctx context.Context,
 	return nil
 }
 
-// DeleteAllBySpaceID deletes all space option by space ID.
-func (r *spaceOptionRepository) DeleteAllBySpaceID(ctx context.Context, spaceID string) error {
+// DeleteAllBySpaceID deletes all space option by space ID, returning the number deleted
+func (r *spaceOptionRepository) DeleteAllBySpaceID(ctx context.Context, spaceID string) (int, error) {
 	// Get existing relationships for cache invalidation
 	relationships, err := r.data.GetSlaveEntClient().SpaceOption.Query().
 		Where(spaceOptionEnt.SpaceIDEQ(spaceID)).All(ctx)
@@ -141,10 +142,11 @@ func (r *spaceOptionRepository) DeleteAllBySpaceID(ctx context.Context, spaceID
 		logger.Debugf(ctx, "Failed to get relationships for cache invalidation: %v", err)
 	}
 
-	if _, err := r.data.GetMasterEntClient().SpaceOption.Delete().
-		Where(spaceOptionEnt.SpaceIDEQ(spaceID)).Exec(ctx); err != nil {
+	deleted, err := r.data.GetMasterEntClient().SpaceOption.Delete().
+		Where(spaceOptionEnt.SpaceIDEQ(spaceID)).Exec(ctx)
+	if err != nil {
 		logger.Errorf(ctx, "spaceOptionRepo.DeleteAllBySpaceID error: %v", err)
-		return err
+		return 0, err
 	}
 
 	// Invalidate caches
@@ -156,7 +158,7 @@ func (r *spaceOptionRepository) DeleteAllBySpaceID(ctx context.Context, spaceID
 		}
 	}()
 
-	return nil
+	return deleted, nil
 }
 
 // DeleteAllByOptionID deletes all space option by options ID.
@@ -243,7 +245,7 @@ func (r *spaceOptionRepository) GetSpaceOption(ctx context.Context, spaceID stri
 	// Cache options IDs for future use
 	go func() {
 		if err := r.spaceOptionListCache.SetArray(context.Background(), cacheKey, optionsIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache space option %s: %v", spaceID, err)
+			logger.Debugf(ctx, "Failed to cache space option %s: %v", spaceID, err)
 		}
 	}()
 
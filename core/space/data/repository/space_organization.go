@@ -7,6 +7,7 @@ import (
 	"ncobase/core/space/data/ent"
 	spaceOrgEnt "ncobase/core/space/data/ent/spaceorganization"
 	"ncobase/core/space/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -23,7 +24,7 @@ type SpaceOrganizationRepositoryInterface interface {
 	GetBySpaceIDs(ctx context.Context, ids []string) ([]*ent.SpaceOrganization, error)
 	GetByOrgIDs(ctx context.Context, ids []string) ([]*ent.SpaceOrganization, error)
 	Delete(ctx context.Context, tid, gid string) error
-	DeleteAllBySpaceID(ctx context.Context, id string) error
+	DeleteAllBySpaceID(ctx context.Context, id string) (int, error)
 	DeleteAllByOrgID(ctx context.Context, id string) error
 	GetOrgsBySpaceID(ctx context.Context, spaceID string) ([]string, error)
 	GetSpacesByOrgID(ctx context.Context, orgID string) ([]string, error)
@@ -45,9 +46,9 @@ func NewSpaceOrganizationRepository(d *data.Data) SpaceOrganizationRepositoryInt
 
 	return &spaceGroupRepository{
 		data:             d,
-		spaceGroupCache:  cache.NewCache[ent.SpaceOrganization](redisClient, "ncse_space:space_orgs"),
-		spaceGroupsCache: cache.NewCache[[]string](redisClient, "ncse_space:space_group_mappings"),
-		groupSpacesCache: cache.NewCache[[]string](redisClient, "ncse_space:group_space_mappings"),
+		spaceGroupCache:  utils.NewRetryCache(cache.NewCache[ent.SpaceOrganization](redisClient, "ncse_space:space_orgs")),
+		spaceGroupsCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:space_group_mappings")),
+		groupSpacesCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:group_space_mappings")),
 		relationshipTTL:  time.Hour * 2, // 2 hours cache TTL
 	}
 }
@@ -177,17 +178,18 @@ func (r *spaceGroupRepository) Delete(ctx context.Context, tid, gid string) erro
 	return nil
 }
 
-// DeleteAllBySpaceID deletes all space group by space id
-func (r *spaceGroupRepository) DeleteAllBySpaceID(ctx context.Context, id string) error {
+// DeleteAllBySpaceID deletes all space group by space id, returning the number deleted
+func (r *spaceGroupRepository) DeleteAllBySpaceID(ctx context.Context, id string) (int, error) {
 	relationships, err := r.GetBySpaceID(ctx, id)
 	if err != nil {
 		logger.Debugf(ctx, "Failed to get relationships for cache invalidation: %v", err)
 	}
 
-	if _, err := r.data.GetMasterEntClient().SpaceOrganization.Delete().
-		Where(spaceOrgEnt.SpaceIDEQ(id)).Exec(ctx); err != nil {
+	deleted, err := r.data.GetMasterEntClient().SpaceOrganization.Delete().
+		Where(spaceOrgEnt.SpaceIDEQ(id)).Exec(ctx)
+	if err != nil {
 		logger.Errorf(ctx, "spaceGroupRepo.DeleteAllBySpaceID error: %v", err)
-		return err
+		return 0, err
 	}
 
 	// Invalidate caches
@@ -199,7 +201,7 @@ func (r *spaceGroupRepository) DeleteAllBySpaceID(ctx context.Context, id string
 		}
 	}()
 
-	return nil
+	return deleted, nil
 }
 
 // DeleteAllByOrgID deletes all space group by group id
@@ -251,7 +253,7 @@ func (r *spaceGroupRepository) GetOrgsBySpaceID(ctx context.Context, spaceID str
 	// Cache for future use
 	go func() {
 		if err := r.spaceGroupsCache.SetArray(context.Background(), cacheKey, orgIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache space orgs %s: %v", spaceID, err)
+			logger.Debugf(ctx, "Failed to cache space orgs %s: %v", spaceID, err)
 		}
 	}()
 
@@ -282,7 +284,7 @@ func (r *spaceGroupRepository) GetSpacesByOrgID(ctx context.Context, orgID strin
 	// Cache for future use
 	go func() {
 		if err := r.groupSpacesCache.SetArray(context.Background(), cacheKey, spaceIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache group spaces %s: %v", orgID, err)
+			logger.Debugf(ctx, "Failed to cache group spaces %s: %v", orgID, err)
 		}
 	}()
 
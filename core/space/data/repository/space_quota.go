@@ -7,6 +7,8 @@ import (
 	"ncobase/core/space/data/ent"
 	spaceQuotaEnt "ncobase/core/space/data/ent/spacequota"
 	"ncobase/core/space/structs"
+	"ncobase/internal/fieldcrypt"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -48,10 +50,10 @@ func NewSpaceQuotaRepository(d *data.Data) SpaceQuotaRepositoryInterface {
 
 	return &spaceQuotaRepository{
 		data:                d,
-		quotaCache:          cache.NewCache[ent.SpaceQuota](redisClient, "ncse_space:quotas"),
-		spaceQuotasCache:    cache.NewCache[[]string](redisClient, "ncse_space:space_quota_mappings"),
-		spaceTypeQuotaCache: cache.NewCache[string](redisClient, "ncse_space:space_type_quota_mappings"),
-		quotaTypeCache:      cache.NewCache[[]string](redisClient, "ncse_space:quota_type_mappings"),
+		quotaCache:          utils.NewRetryCache(cache.NewCache[ent.SpaceQuota](redisClient, "ncse_space:quotas")),
+		spaceQuotasCache:    utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:space_quota_mappings")),
+		spaceTypeQuotaCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_space:space_type_quota_mappings")),
+		quotaTypeCache:      utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:quota_type_mappings")),
 		quotaTTL:            time.Hour * 4, // 4 hours cache TTL (quotas change less frequently)
 	}
 }
@@ -72,7 +74,11 @@ func (r *spaceQuotaRepository) Create(ctx context.Context, body *structs.CreateS
 	builder.SetNillableCreatedBy(body.CreatedBy)
 
 	if !validator.IsNil(body.Extras) && !validator.IsEmpty(body.Extras) {
-		builder.SetExtras(*body.Extras)
+		extras, err := fieldcrypt.For(QuotaEncryptionSchema).EncryptJSON("extras", *body.Extras)
+		if err != nil {
+			return nil, err
+		}
+		builder.SetExtras(extras)
 	}
 
 	row, err := builder.Save(ctx)
@@ -103,7 +109,7 @@ func (r *spaceQuotaRepository) GetByID(ctx context.Context, id string) (*ent.Spa
 	row, err := r.data.GetSlaveEntClient().SpaceQuota.Get(ctx, id)
 	if err != nil {
 		logger.Errorf(ctx, "spaceQuotaRepo.GetByID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -146,7 +152,7 @@ func (r *spaceQuotaRepository) GetBySpaceID(ctx context.Context, spaceID string)
 		}
 
 		if err := r.spaceQuotasCache.SetArray(context.Background(), cacheKey, quotaIDs, r.quotaTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache space quotas %s: %v", spaceID, err)
+			logger.Debugf(ctx, "Failed to cache space quotas %s: %v", spaceID, err)
 		}
 	}()
 
@@ -204,7 +210,11 @@ func (r *spaceQuotaRepository) Update(ctx context.Context, id string, updates ty
 		case "enabled":
 			builder.SetEnabled(value.(bool))
 		case "extras":
-			builder.SetExtras(value.(types.JSON))
+			extras, err := fieldcrypt.For(QuotaEncryptionSchema).EncryptJSON("extras", value.(types.JSON))
+			if err != nil {
+				return nil, err
+			}
+			builder.SetExtras(extras)
 		case "updated_by":
 			builder.SetUpdatedBy(value.(string))
 		}
@@ -7,6 +7,8 @@ import (
 	"ncobase/core/space/data/ent"
 	spaceSettingEnt "ncobase/core/space/data/ent/spacesetting"
 	"ncobase/core/space/structs"
+	"ncobase/internal/fieldcrypt"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -50,11 +52,11 @@ func NewSpaceSettingRepository(d *data.Data) SpaceSettingRepositoryInterface {
 
 	return &spaceSettingRepository{
 		data:                  d,
-		settingCache:          cache.NewCache[ent.SpaceSetting](redisClient, "ncse_space:settings"),
-		spaceSettingsCache:    cache.NewCache[[]string](redisClient, "ncse_space:space_setting_mappings"),
-		spaceKeySettingCache:  cache.NewCache[string](redisClient, "ncse_space:space_key_setting_mappings"),
-		categorySettingsCache: cache.NewCache[[]string](redisClient, "ncse_space:category_setting_mappings"),
-		scopeSettingsCache:    cache.NewCache[[]string](redisClient, "ncse_space:scope_setting_mappings"),
+		settingCache:          utils.NewRetryCache(cache.NewCache[ent.SpaceSetting](redisClient, "ncse_space:settings")),
+		spaceSettingsCache:    utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:space_setting_mappings")),
+		spaceKeySettingCache:  utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_space:space_key_setting_mappings")),
+		categorySettingsCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:category_setting_mappings")),
+		scopeSettingsCache:    utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:scope_setting_mappings")),
 		settingTTL:            time.Hour * 6, // 6 hours cache TTL (settings change less frequently)
 	}
 }
@@ -83,7 +85,11 @@ func (r *spaceSettingRepository) Create(ctx context.Context, body *structs.Creat
 	}
 
 	if !validator.IsNil(body.Extras) && !validator.IsEmpty(body.Extras) {
-		builder.SetExtras(*body.Extras)
+		extras, err := fieldcrypt.For(SettingEncryptionSchema).EncryptJSON("extras", *body.Extras)
+		if err != nil {
+			return nil, err
+		}
+		builder.SetExtras(extras)
 	}
 
 	row, err := builder.Save(ctx)
@@ -115,7 +121,7 @@ func (r *spaceSettingRepository) GetByID(ctx context.Context, id string) (*ent.S
 	row, err := r.data.GetSlaveEntClient().SpaceSetting.Get(ctx, id)
 	if err != nil {
 		logger.Errorf(ctx, "spaceSettingRepo.GetByID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -183,7 +189,7 @@ func (r *spaceSettingRepository) GetBySpaceID(ctx context.Context, spaceID strin
 		}
 
 		if err := r.spaceSettingsCache.SetArray(context.Background(), cacheKey, settingIDs, r.settingTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache space settings %s: %v", spaceID, err)
+			logger.Debugf(ctx, "Failed to cache space settings %s: %v", spaceID, err)
 		}
 	}()
 
@@ -227,7 +233,7 @@ func (r *spaceSettingRepository) GetByCategory(ctx context.Context, spaceID, cat
 		}
 
 		if err := r.categorySettingsCache.SetArray(context.Background(), cacheKey, settingIDs, r.settingTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache category settings %s:%s: %v", spaceID, category, err)
+			logger.Debugf(ctx, "Failed to cache category settings %s:%s: %v", spaceID, category, err)
 		}
 	}()
 
@@ -270,7 +276,11 @@ func (r *spaceSettingRepository) Update(ctx context.Context, id string, updates
 		case "validation":
 			builder.SetValidation(value.(types.JSON))
 		case "extras":
-			builder.SetExtras(value.(types.JSON))
+			extras, err := fieldcrypt.For(SettingEncryptionSchema).EncryptJSON("extras", value.(types.JSON))
+			if err != nil {
+				return nil, err
+			}
+			builder.SetExtras(extras)
 		case "updated_by":
 			builder.SetUpdatedBy(value.(string))
 		}
@@ -8,6 +8,7 @@ import (
 	spaceEnt "ncobase/core/space/data/ent/space"
 	userSpaceEnt "ncobase/core/space/data/ent/userspace"
 	"ncobase/core/space/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/ncobase/ncore/data/cache"
@@ -25,9 +26,12 @@ type UserSpaceRepositoryInterface interface {
 	GetBySpaceIDs(ctx context.Context, ids []string) ([]*ent.UserSpace, error)
 	Delete(ctx context.Context, uid, did string) error
 	DeleteAllByUserID(ctx context.Context, id string) error
-	DeleteAllBySpaceID(ctx context.Context, id string) error
+	DeleteAllBySpaceID(ctx context.Context, id string) (int, error)
 	GetSpacesByUserID(ctx context.Context, userID string) ([]*ent.Space, error)
 	IsSpaceInUser(ctx context.Context, spaceID, userID string) (bool, error)
+	CountByUserID(ctx context.Context, userID string) (int, error)
+	ListAfter(ctx context.Context, afterID string, limit int) ([]*ent.UserSpace, error)
+	DeleteRows(ctx context.Context, rows []*ent.UserSpace) error
 }
 
 // userSpaceRepository implements the UserSpaceRepositoryInterface.
@@ -37,6 +41,7 @@ type userSpaceRepository struct {
 	userSpacesCache cache.ICache[[]string] // Maps user ID to space IDs
 	spaceUsersCache cache.ICache[[]string] // Maps space ID to user IDs
 	relationshipTTL time.Duration
+	loader          *utils.Loader[ent.UserSpace]
 }
 
 // NewUserSpaceRepository creates a new user space repository.
@@ -45,10 +50,12 @@ func NewUserSpaceRepository(d *data.Data) UserSpaceRepositoryInterface {
 
 	return &userSpaceRepository{
 		data:            d,
-		userSpaceCache:  cache.NewCache[ent.UserSpace](redisClient, "ncse_space:user_spaces"),
-		userSpacesCache: cache.NewCache[[]string](redisClient, "ncse_space:user_space_mappings"),
-		spaceUsersCache: cache.NewCache[[]string](redisClient, "ncse_space:space_user_mappings"),
+		userSpaceCache:  utils.NewRetryCache(cache.NewCache[ent.UserSpace](redisClient, "ncse_space:user_spaces")),
+		userSpacesCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:user_space_mappings")),
+		spaceUsersCache: utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_space:space_user_mappings")),
 		relationshipTTL: time.Hour * 3, // 3 hours cache TTL (space relationships change less frequently)
+		// Misses are remembered much shorter than hits
+		loader: utils.NewLoader[ent.UserSpace](time.Second*30, IsNotFound),
 	}
 }
 
@@ -68,12 +75,17 @@ func (r *userSpaceRepository) Create(ctx context.Context, body *structs.UserSpac
 		return nil, err
 	}
 
-	// Cache the relationship and invalidate related caches
-	go func() {
-		r.cacheUserSpace(context.Background(), row)
-		r.invalidateUserSpacesCache(context.Background(), body.UserID)
-		r.invalidateSpaceUsersCache(context.Background(), body.SpaceID)
-	}()
+	// Forget remembered misses for this relationship
+	r.loader.Forget(fmt.Sprintf("user:%s", body.UserID), fmt.Sprintf("space:%s", body.SpaceID))
+
+	// Cache the relationship and invalidate related caches once committed
+	utils.OnCommit(ctx, func() {
+		go func() {
+			r.cacheUserSpace(context.Background(), row)
+			r.invalidateUserSpacesCache(context.Background(), body.UserID)
+			r.invalidateSpaceUsersCache(context.Background(), body.SpaceID)
+		}()
+	})
 
 	return row, nil
 }
@@ -86,23 +98,26 @@ func (r *userSpaceRepository) GetByUserID(ctx context.Context, id string) (*ent.
 		return cached, nil
 	}
 
-	// Use slave for reads
-	builder := r.data.GetSlaveEntClient().UserSpace.Query()
+	// Coalesce concurrent misses into a single query
+	return r.loader.Load(ctx, cacheKey, func(ctx context.Context) (*ent.UserSpace, error) {
+		// Use slave for reads
+		builder := r.data.GetSlaveEntClient().UserSpace.Query()
 
-	// Set conditions
-	builder.Where(userSpaceEnt.UserIDEQ(id))
+		// Set conditions
+		builder.Where(userSpaceEnt.UserIDEQ(id))
 
-	// Execute the builder
-	row, err := builder.Only(ctx)
-	if err != nil {
-		logger.Errorf(ctx, "userSpaceRepo.GetByUserID error: %v", err)
-		return nil, err
-	}
+		// Execute the builder
+		row, err := builder.Only(ctx)
+		if err != nil {
+			logger.Errorf(ctx, "userSpaceRepo.GetByUserID error: %v", err)
+			return nil, mapError(err)
+		}
 
-	// Cache for future use
-	go r.cacheUserSpace(context.Background(), row)
+		// Cache for future use
+		go r.cacheUserSpace(context.Background(), row)
 
-	return row, nil
+		return row, nil
+	})
 }
 
 // GetByUserIDs find spaces by user ids
@@ -138,23 +153,26 @@ func (r *userSpaceRepository) GetBySpaceID(ctx context.Context, id string) (*ent
 		return cached, nil
 	}
 
-	// Use slave for reads
-	builder := r.data.GetSlaveEntClient().UserSpace.Query()
+	// Coalesce concurrent misses into a single query
+	return r.loader.Load(ctx, cacheKey, func(ctx context.Context) (*ent.UserSpace, error) {
+		// Use slave for reads
+		builder := r.data.GetSlaveEntClient().UserSpace.Query()
 
-	// Set conditions
-	builder.Where(userSpaceEnt.SpaceIDEQ(id))
+		// Set conditions
+		builder.Where(userSpaceEnt.SpaceIDEQ(id))
 
-	// Execute the builder
-	row, err := builder.Only(ctx)
-	if err != nil {
-		logger.Errorf(ctx, "userSpaceRepo.GetBySpaceID error: %v", err)
-		return nil, err
-	}
+		// Execute the builder
+		row, err := builder.Only(ctx)
+		if err != nil {
+			logger.Errorf(ctx, "userSpaceRepo.GetBySpaceID error: %v", err)
+			return nil, mapError(err)
+		}
 
-	// Cache for future use
-	go r.cacheUserSpace(context.Background(), row)
+		// Cache for future use
+		go r.cacheUserSpace(context.Background(), row)
 
-	return row, nil
+		return row, nil
+	})
 }
 
 // GetBySpaceIDs find spaces by space ids
@@ -201,6 +219,50 @@ func (r *userSpaceRepository) Delete(ctx context.Context, uid, did string) error
 	return nil
 }
 
+// CountByUserID counts the spaces a user belongs to
+func (r *userSpaceRepository) CountByUserID(ctx context.Context, userID string) (int, error) {
+	return r.data.GetMasterEntClient().UserSpace.Query().
+		Where(userSpaceEnt.UserIDEQ(userID)).Count(ctx)
+}
+
+// ListAfter returns up to limit user spaces with an ID after afterID, in ID order.
+// Reads go to the master so maintenance sees every committed row.
+func (r *userSpaceRepository) ListAfter(ctx context.Context, afterID string, limit int) ([]*ent.UserSpace, error) {
+	builder := r.data.GetMasterEntClient().UserSpace.Query()
+	if afterID != "" {
+		builder.Where(userSpaceEnt.IDGT(afterID))
+	}
+	return builder.Order(ent.Asc(userSpaceEnt.FieldID)).Limit(limit).All(ctx)
+}
+
+// DeleteRows deletes the given user spaces by ID, other rows of the same user and space are kept
+func (r *userSpaceRepository) DeleteRows(ctx context.Context, rows []*ent.UserSpace) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	// Use master for writes
+	if _, err := r.data.GetMasterEntClient().UserSpace.Delete().
+		Where(userSpaceEnt.IDIn(ids...)).Exec(ctx); err != nil {
+		logger.Errorf(ctx, "userSpaceRepo.DeleteRows error: %v", err)
+		return err
+	}
+
+	// Invalidate caches right away, maintenance commands exit once done
+	for _, row := range rows {
+		r.invalidateUserSpaceCache(ctx, row.UserID, row.SpaceID)
+		r.invalidateUserSpacesCache(ctx, row.UserID)
+		r.invalidateSpaceUsersCache(ctx, row.SpaceID)
+	}
+
+	return nil
+}
+
 // DeleteAllByUserID delete all user space
 func (r *userSpaceRepository) DeleteAllByUserID(ctx context.Context, id string) error {
 	// Get existing relationships for cache invalidation
@@ -228,8 +290,8 @@ func (r *userSpaceRepository) DeleteAllByUserID(ctx context.Context, id string)
 	return nil
 }
 
-// DeleteAllBySpaceID delete all user space
-func (r *userSpaceRepository) DeleteAllBySpaceID(ctx context.Context, id string) error {
+// DeleteAllBySpaceID delete all user space, returning the number deleted
+func (r *userSpaceRepository) DeleteAllBySpaceID(ctx context.Context, id string) (int, error) {
 	// Get existing relationships for cache invalidation
 	relationships, err := r.GetBySpaceIDs(ctx, []string{id})
 	if err != nil {
@@ -237,10 +299,11 @@ func (r *userSpaceRepository) DeleteAllBySpaceID(ctx context.Context, id string)
 	}
 
 	// Use master for writes
-	if _, err := r.data.GetMasterEntClient().UserSpace.Delete().
-		Where(userSpaceEnt.SpaceIDEQ(id)).Exec(ctx); err != nil {
+	deleted, err := r.data.GetMasterEntClient().UserSpace.Delete().
+		Where(userSpaceEnt.SpaceIDEQ(id)).Exec(ctx)
+	if err != nil {
 		logger.Errorf(ctx, "userSpaceRepo.DeleteAllBySpaceID error: %v", err)
-		return err
+		return 0, err
 	}
 
 	// Invalidate caches
@@ -252,7 +315,7 @@ func (r *userSpaceRepository) DeleteAllBySpaceID(ctx context.Context, id string)
 		}
 	}()
 
-	return nil
+	return deleted, nil
 }
 
 // GetSpacesByUserID retrieves all spaces a user belongs to.
@@ -289,7 +352,7 @@ func (r *userSpaceRepository) GetSpacesByUserID(ctx context.Context, userID stri
 	// Cache space IDs for future use
 	go func() {
 		if err := r.userSpacesCache.SetArray(context.Background(), cacheKey, spaceIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache user spaces %s: %v", userID, err)
+			logger.Debugf(ctx, "Failed to cache user spaces %s: %v", userID, err)
 		}
 	}()
 
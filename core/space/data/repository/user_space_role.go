@@ -7,6 +7,7 @@ import (
 	"ncobase/core/space/data/ent"
 	userSpaceRoleEnt "ncobase/core/space/data/ent/userspacerole"
 	"ncobase/core/space/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -26,10 +27,13 @@ type UserSpaceRoleRepositoryInterface interface {
 	DeleteBySpaceIDAndRoleID(ctx context.Context, t, r string) error
 	DeleteByUserIDAndSpaceIDAndRoleID(ctx context.Context, u, t, r string) error
 	DeleteAllByUserID(ctx context.Context, u string) error
-	DeleteAllBySpaceID(ctx context.Context, t string) error
+	DeleteAllBySpaceID(ctx context.Context, t string) (int, error)
 	DeleteAllByRoleID(ctx context.Context, r string) error
 	GetRolesByUserAndSpace(ctx context.Context, u, t string) ([]string, error)
 	IsUserInRoleInSpace(ctx context.Context, u, t, r string) (bool, error)
+	CountByUserID(ctx context.Context, u string) (int, error)
+	ListAfter(ctx context.Context, afterID string, limit int) ([]*ent.UserSpaceRole, error)
+	DeleteRows(ctx context.Context, rows []*ent.UserSpaceRole) error
 }
 
 // userSpaceRoleRepository implements the UserSpaceRoleRepositoryInterface.
@@ -49,10 +53,10 @@ func NewUserSpaceRoleRepository(d *data.Data) UserSpaceRoleRepositoryInterface {
 
 	return &userSpaceRoleRepository{
 		data:                d,
-		userSpaceRoleCache:  cache.NewCache[ent.UserSpaceRole](redis, "ncse_access:user_space_roles"),
-		userSpaceRolesCache: cache.NewCache[[]string](redis, "ncse_access:user_space_role_mappings"),
-		spaceUserRolesCache: cache.NewCache[[]string](redis, "ncse_access:space_user_role_mappings"),
-		roleUserSpacesCache: cache.NewCache[[]string](redis, "ncse_access:role_user_space_mappings"),
+		userSpaceRoleCache:  utils.NewRetryCache(cache.NewCache[ent.UserSpaceRole](redis, "ncse_access:user_space_roles")),
+		userSpaceRolesCache: utils.NewRetryCache(cache.NewCache[[]string](redis, "ncse_access:user_space_role_mappings")),
+		spaceUserRolesCache: utils.NewRetryCache(cache.NewCache[[]string](redis, "ncse_access:space_user_role_mappings")),
+		roleUserSpacesCache: utils.NewRetryCache(cache.NewCache[[]string](redis, "ncse_access:role_user_space_mappings")),
 		relationshipTTL:     time.Hour * 2, // 2 hours cache TTL
 	}
 }
@@ -74,13 +78,15 @@ func (r *userSpaceRoleRepository) Create(ctx context.Context, body *structs.User
 		return nil, err
 	}
 
-	// Cache the relationship and invalidate related caches
-	go func() {
-		r.cacheUserSpaceRole(context.Background(), row)
-		r.invalidateUserSpaceRolesCache(context.Background(), body.UserID, body.SpaceID)
-		r.invalidateSpaceUserRolesCache(context.Background(), body.SpaceID)
-		r.invalidateRoleUserSpacesCache(context.Background(), body.RoleID)
-	}()
+	// Cache the relationship and invalidate related caches once committed
+	utils.OnCommit(ctx, func() {
+		go func() {
+			r.cacheUserSpaceRole(context.Background(), row)
+			r.invalidateUserSpaceRolesCache(context.Background(), body.UserID, body.SpaceID)
+			r.invalidateSpaceUserRolesCache(context.Background(), body.SpaceID)
+			r.invalidateRoleUserSpacesCache(context.Background(), body.RoleID)
+		}()
+	})
 
 	return row, nil
 }
@@ -103,7 +109,7 @@ func (r *userSpaceRoleRepository) GetByUserID(ctx context.Context, u string) (*e
 	row, err := builder.First(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "userSpaceRoleRepo.GetByUserID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -304,8 +310,53 @@ func (r *userSpaceRoleRepository) DeleteAllByUserID(ctx context.Context, u strin
 	return nil
 }
 
-// DeleteAllBySpaceID deletes all user space roles by space ID.
-func (r *userSpaceRoleRepository) DeleteAllBySpaceID(ctx context.Context, t string) error {
+// CountByUserID counts the roles of a user across all spaces.
+func (r *userSpaceRoleRepository) CountByUserID(ctx context.Context, u string) (int, error) {
+	return r.data.GetMasterEntClient().UserSpaceRole.Query().
+		Where(userSpaceRoleEnt.UserIDEQ(u)).Count(ctx)
+}
+
+// ListAfter returns up to limit user space roles with an ID after afterID, in ID order.
+// Reads go to the master so maintenance sees every committed row.
+func (r *userSpaceRoleRepository) ListAfter(ctx context.Context, afterID string, limit int) ([]*ent.UserSpaceRole, error) {
+	builder := r.data.GetMasterEntClient().UserSpaceRole.Query()
+	if afterID != "" {
+		builder.Where(userSpaceRoleEnt.IDGT(afterID))
+	}
+	return builder.Order(ent.Asc(userSpaceRoleEnt.FieldID)).Limit(limit).All(ctx)
+}
+
+// DeleteRows deletes the given user space roles by ID, other rows of the same binding are kept
+func (r *userSpaceRoleRepository) DeleteRows(ctx context.Context, rows []*ent.UserSpaceRole) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+
+	// Use master for writes
+	if _, err := r.data.GetMasterEntClient().UserSpaceRole.Delete().
+		Where(userSpaceRoleEnt.IDIn(ids...)).Exec(ctx); err != nil {
+		logger.Errorf(ctx, "userSpaceRoleRepo.DeleteRows error: %v", err)
+		return err
+	}
+
+	// Invalidate caches right away, maintenance commands exit once done
+	for _, row := range rows {
+		r.invalidateUserSpaceRoleCache(ctx, row)
+		r.invalidateUserSpaceRolesCache(ctx, row.UserID, row.SpaceID)
+		r.invalidateSpaceUserRolesCache(ctx, row.SpaceID)
+		r.invalidateRoleUserSpacesCache(ctx, row.RoleID)
+	}
+
+	return nil
+}
+
+// DeleteAllBySpaceID deletes all user space roles by space ID, returning the number deleted
+func (r *userSpaceRoleRepository) DeleteAllBySpaceID(ctx context.Context, t string) (int, error) {
 	// Get existing relationships for cache invalidation
 	relationships, err := r.data.GetSlaveEntClient().UserSpaceRole.Query().
 		Where(userSpaceRoleEnt.SpaceID(t)).All(ctx)
@@ -314,10 +365,11 @@ func (r *userSpaceRoleRepository) DeleteAllBySpaceID(ctx context.Context, t stri
 	}
 
 	// Use master for writes
-	if _, err := r.data.GetMasterEntClient().UserSpaceRole.Delete().
-		Where(userSpaceRoleEnt.SpaceID(t)).Exec(ctx); err != nil {
+	deleted, err := r.data.GetMasterEntClient().UserSpaceRole.Delete().
+		Where(userSpaceRoleEnt.SpaceID(t)).Exec(ctx)
+	if err != nil {
 		logger.Errorf(ctx, "userSpaceRoleRepo.DeleteAllBySpaceID error: %v", err)
-		return err
+		return 0, err
 	}
 
 	// Invalidate caches
@@ -330,7 +382,7 @@ func (r *userSpaceRoleRepository) DeleteAllBySpaceID(ctx context.Context, t stri
 		}
 	}()
 
-	return nil
+	return deleted, nil
 }
 
 // DeleteAllByRoleID deletes all user space roles by role ID.
@@ -388,7 +440,7 @@ func (r *userSpaceRoleRepository) GetRolesByUserAndSpace(ctx context.Context, u
 	// Cache role IDs for future use
 	go func() {
 		if err := r.userSpaceRolesCache.SetArray(context.Background(), cacheKey, roleIDs, r.relationshipTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache user space roles %s:%s: %v", u, t, err)
+			logger.Debugf(ctx, "Failed to cache user space roles %s:%s: %v", u, t, err)
 		}
 	}()
 
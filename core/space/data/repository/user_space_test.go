@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ncobase/core/space/data"
+	"ncobase/core/space/data/ent"
+
+	_ "github.com/mattn/go-sqlite3"
+	nd "github.com/ncobase/ncore/data"
+	"github.com/ncobase/ncore/data/cache"
+	"github.com/ncobase/ncore/data/connection"
+	"github.com/redis/go-redis/v9"
+)
+
+// missingCache misses every lookup and counts them, as a cold cache does
+type missingCache[T any] struct {
+	cache.ICache[T]
+	gets atomic.Int32
+}
+
+func (c *missingCache[T]) Get(context.Context, string) (*T, error) {
+	c.gets.Add(1)
+	return nil, redis.Nil
+}
+
+func (c *missingCache[T]) Set(context.Context, string, *T, ...time.Duration) error { return nil }
+
+func TestGetByUserIDCoalescesConcurrentMisses(t *testing.T) {
+	client, err := ent.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	if _, err := client.UserSpace.Create().SetUserID("u1").SetSpaceID("s1").Save(ctx); err != nil {
+		t.Fatalf("create user space: %v", err)
+	}
+
+	// Count the user space queries and hold them until every caller is waiting
+	var queries atomic.Int32
+	release := make(chan struct{})
+	client.UserSpace.Intercept(ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			queries.Add(1)
+			<-release
+			return next.Query(ctx, q)
+		})
+	}))
+
+	d := &data.Data{Data: &nd.Data{Conn: &connection.Connections{RC: (*redis.Client)(nil)}}, EC: client}
+	r := NewUserSpaceRepository(d).(*userSpaceRepository)
+	cold := &missingCache[ent.UserSpace]{}
+	r.userSpaceCache = cold
+
+	const callers = 50
+	var done sync.WaitGroup
+	done.Add(callers)
+	rows := make([]*ent.UserSpace, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		go func() {
+			defer done.Done()
+			rows[i], errs[i] = r.GetByUserID(ctx, "u1")
+		}()
+	}
+	for cold.gets.Load() < callers {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond) // let every caller join the pending load
+	close(release)
+	done.Wait()
+
+	if n := queries.Load(); n != 1 {
+		t.Fatalf("%d queries for %d concurrent callers, want 1", n, callers)
+	}
+	for i := range callers {
+		if errs[i] != nil {
+			t.Fatalf("GetByUserID caller %d: %v", i, errs[i])
+		}
+		if rows[i] != rows[0] || rows[i].SpaceID != "s1" {
+			t.Fatalf("caller %d got %+v, want the user space loaded for the first caller", i, rows[i])
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/entsql"
@@ -28,7 +29,7 @@ func (SpaceDictionary) Annotations() []schema.Annotation {
 // Mixin of the SpaceDictionary.
 func (SpaceDictionary) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.SpaceID,
 		mixin.DictionaryID,
 		mixin.OperatorBy{},
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/entsql"
@@ -28,7 +29,7 @@ func (SpaceMenu) Annotations() []schema.Annotation {
 // Mixin of the SpaceMenu.
 func (SpaceMenu) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.SpaceID,
 		mixin.MenuID,
 		mixin.OperatorBy{},
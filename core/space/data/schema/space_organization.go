@@ -5,6 +5,7 @@ import (
 
 	"entgo.io/ent/schema/field"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/entsql"
@@ -30,7 +31,7 @@ func (SpaceOrganization) Annotations() []schema.Annotation {
 // Mixin of the SpaceOrganization.
 func (SpaceOrganization) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.SpaceID,
 		mixin.OrgID,
 		mixin.OperatorBy{},
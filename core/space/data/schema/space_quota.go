@@ -9,6 +9,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 )
 
 // SpaceQuota holds the schema definition for the SpaceQuota entity
@@ -28,7 +29,7 @@ func (SpaceQuota) Annotations() []schema.Annotation {
 // Mixin of the SpaceQuota
 func (SpaceQuota) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.SpaceID,
 		mixin.Description,
 		mixin.ExtraProps,
@@ -9,6 +9,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 )
 
 // SpaceSetting holds the schema definition for the SpaceSetting entity
@@ -28,7 +29,7 @@ func (SpaceSetting) Annotations() []schema.Annotation {
 // Mixin of the SpaceSetting
 func (SpaceSetting) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.SpaceID,
 		mixin.Description,
 		mixin.ExtraProps,
@@ -3,12 +3,15 @@ package handler
 import (
 	"ncobase/core/space/service"
 	"ncobase/core/space/structs"
+	"ncobase/internal/httpcache"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 	resourceStructs "ncobase/plugin/resource/structs"
 	"strings"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
+	"github.com/ncobase/ncore/utils/convert"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,6 +19,7 @@ import (
 // SpaceHandlerInterface is the interface for the handler.
 type SpaceHandlerInterface interface {
 	Create(c *gin.Context)
+	Import(c *gin.Context)
 	UserOwn(c *gin.Context)
 	Update(c *gin.Context)
 	Get(c *gin.Context)
@@ -62,6 +66,10 @@ func (h *SpaceHandler) Create(c *gin.Context) {
 		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
 		return
 	}
+	if err := body.Validate(); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
 
 	result, err := h.s.Space.Create(c.Request.Context(), body)
 	if err != nil {
@@ -71,6 +79,34 @@ func (h *SpaceHandler) Create(c *gin.Context) {
 	resp.Success(c.Writer, result)
 }
 
+// Import handles creating spaces from a CSV file.
+//
+// @Summary Import spaces
+// @Description Create spaces from a CSV with the columns name, title, url, keywords and created_by. Rows with errors or duplicate names are reported per row, the rest are created.
+// @Tags sys
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file"
+// @Success 200 {object} structs.SpaceImportResult "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /sys/spaces/import [post]
+// @Security Bearer
+func (h *SpaceHandler) Import(c *gin.Context) {
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("file")))
+		return
+	}
+	defer file.Close()
+
+	result, err := h.s.Space.Import(c.Request.Context(), file)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
+
 // UserOwn handles reading a user's space.
 //
 // @Summary Get user owned space
@@ -78,7 +114,10 @@ func (h *SpaceHandler) Create(c *gin.Context) {
 // @Tags sys
 // @Produce json
 // @Param username path string true "Username"
+// @Param locale query string false "Locale of title, description and copyright, e.g. zh-CN"
+// @Param If-None-Match header string false "ETag of the cached space"
 // @Success 200 {object} structs.ReadSpace "success"
+// @Success 304 "not modified"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sys/users/{username}/space [get]
 // @Security Bearer
@@ -88,6 +127,11 @@ func (h *SpaceHandler) UserOwn(c *gin.Context) {
 		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
 		return
 	}
+	result.Localize(c.Query("locale"))
+	updatedAt := convert.ToValue(result.UpdatedAt)
+	if httpcache.NotModified(c, httpcache.ETag(result.ID, updatedAt, result.Locale), updatedAt) {
+		return
+	}
 	resp.Success(c.Writer, result)
 }
 
@@ -134,7 +178,10 @@ func (h *SpaceHandler) Update(c *gin.Context) {
 // @Tags sys
 // @Produce json
 // @Param spaceId path string true "Space ID"
+// @Param locale query string false "Locale of title, description and copyright, e.g. zh-CN"
+// @Param If-None-Match header string false "ETag of the cached space"
 // @Success 200 {object} structs.ReadSpace "success"
+// @Success 304 "not modified"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sys/spaces/{spaceId} [get]
 // @Security Bearer
@@ -144,6 +191,11 @@ func (h *SpaceHandler) Get(c *gin.Context) {
 		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
 		return
 	}
+	result.Localize(c.Query("locale"))
+	updatedAt := convert.ToValue(result.UpdatedAt)
+	if httpcache.NotModified(c, httpcache.ETag(result.ID, updatedAt, result.Locale), updatedAt) {
+		return
+	}
 	resp.Success(c.Writer, result)
 }
 
@@ -190,20 +242,28 @@ func (h *SpaceHandler) GetSpaceSetting(c *gin.Context) {
 // Delete handles deleting a space.
 //
 // @Summary Delete space
-// @Description Delete a specific space.
+// @Description Delete a specific space and the records bound to it. With dry_run=true nothing is deleted and the response counts what would be removed.
 // @Tags sys
 // @Produce json
 // @Param spaceId path string true "Space ID"
-// @Success 200 {object} resp.Exception "success"
+// @Param params query structs.DeleteSpaceParams false "Delete space parameters"
+// @Success 200 {object} structs.SpaceDeletion "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sys/spaces/{spaceId} [delete]
 // @Security Bearer
 func (h *SpaceHandler) Delete(c *gin.Context) {
-	if err := h.s.Space.Delete(c.Request.Context(), c.Param("spaceId")); err != nil {
+	params := &structs.DeleteSpaceParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
 		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
 		return
 	}
-	resp.Success(c.Writer)
+
+	result, err := h.s.Space.Delete(c.Request.Context(), c.Param("spaceId"), params.DryRun)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
 }
 
 // List handles listing spaces.
@@ -213,7 +273,7 @@ func (h *SpaceHandler) Delete(c *gin.Context) {
 // @Tags sys
 // @Produce json
 // @Param params query structs.ListSpaceParams true "List space parameters"
-// @Success 200 {array} structs.ReadSpace"success"
+// @Success 200 {object} page.Page[structs.ReadSpace] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sys/spaces [get]
 // @Security Bearer
@@ -232,8 +292,11 @@ func (h *SpaceHandler) List(c *gin.Context) {
 		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
 		return
 	}
+	for _, item := range result.Items {
+		item.Localize(params.Locale)
+	}
 
-	resp.Success(c.Writer, result)
+	resp.Success(c.Writer, page.New(result))
 }
 
 // ListAttachments handles listing space attachments.
@@ -3,11 +3,12 @@ package handler
 import (
 	"ncobase/core/space/service"
 	"ncobase/core/space/structs"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
 	"github.com/ncobase/ncore/types"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -164,7 +165,7 @@ func (h *spaceBillingHandler) Delete(c *gin.Context) {
 // @Tags sys
 // @Produce json
 // @Param params query structs.ListSpaceBillingParams true "List parameters"
-// @Success 200 {array} structs.ReadSpaceBilling "success"
+// @Success 200 {object} page.Page[structs.ReadSpaceBilling] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sys/spaces/billing [get]
 // @Security Bearer
@@ -184,7 +185,7 @@ func (h *spaceBillingHandler) List(c *gin.Context) {
 		return
 	}
 
-	resp.Success(c.Writer, result)
+	resp.Success(c.Writer, page.New(result))
 }
 
 // ProcessPayment handles processing payment for billing
@@ -3,12 +3,13 @@ package handler
 import (
 	"ncobase/core/space/service"
 	"ncobase/core/space/structs"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
 	"github.com/ncobase/ncore/types"
 	"github.com/ncobase/ncore/utils/convert"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -164,7 +165,7 @@ func (h *spaceQuotaHandler) Delete(c *gin.Context) {
 // @Tags sys
 // @Produce json
 // @Param params query structs.ListSpaceQuotaParams true "List parameters"
-// @Success 200 {array} structs.ReadSpaceQuota "success"
+// @Success 200 {object} page.Page[structs.ReadSpaceQuota] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sys/spaces/quotas [get]
 // @Security Bearer
@@ -184,7 +185,7 @@ func (h *spaceQuotaHandler) List(c *gin.Context) {
 		return
 	}
 
-	resp.Success(c.Writer, result)
+	resp.Success(c.Writer, page.New(result))
 }
 
 // UpdateUsage handles updating quota usage
@@ -1,13 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"ncobase/core/space/service"
 	"ncobase/core/space/structs"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
 	"github.com/ncobase/ncore/types"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -165,7 +167,7 @@ func (h *spaceSettingHandler) Delete(c *gin.Context) {
 // @Tags sys
 // @Produce json
 // @Param params query structs.ListSpaceSettingParams true "List parameters"
-// @Success 200 {array} structs.ReadSpaceSetting "success"
+// @Success 200 {object} page.Page[structs.ReadSpaceSetting] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sys/spaces/settings [get]
 // @Security Bearer
@@ -185,7 +187,7 @@ func (h *spaceSettingHandler) List(c *gin.Context) {
 		return
 	}
 
-	resp.Success(c.Writer, result)
+	resp.Success(c.Writer, page.New(result))
 }
 
 // BulkUpdate handles bulk updating space settings
@@ -211,6 +213,10 @@ func (h *spaceSettingHandler) BulkUpdate(c *gin.Context) {
 	}
 
 	if err := h.s.SpaceSetting.BulkUpdate(c.Request.Context(), body); err != nil {
+		if errors.Is(err, service.ErrInvalidSetting) {
+			resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+			return
+		}
 		resp.Fail(c.Writer, resp.InternalServer(err.Error()))
 		return
 	}
@@ -274,14 +280,14 @@ func (h *spaceSettingHandler) GetPublicSettings(c *gin.Context) {
 // SetSetting handles setting a specific space setting
 //
 // @Summary Set space setting
-// @Description Set a specific setting for a space
+// @Description Create or update a specific setting of a space. Known keys take values of their type, other keys must be under "custom." and are typed by their first value.
 // @Tags sys
 // @Accept json
 // @Produce json
 // @Param spaceId path string true "Space ID"
 // @Param key path string true "Setting Key"
-// @Param body body map[string]string true "Setting value"
-// @Success 200 {object} resp.Exception "success"
+// @Param body body map[string]interface{} true "Setting value under the value key"
+// @Success 200 {object} structs.ReadSpaceSetting "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /sys/spaces/{spaceId}/settings/{key} [put]
 // @Security Bearer
@@ -293,7 +299,7 @@ func (h *spaceSettingHandler) SetSetting(c *gin.Context) {
 		return
 	}
 
-	var body map[string]string
+	var body map[string]any
 	if err := c.ShouldBindJSON(&body); err != nil {
 		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
 		return
@@ -305,12 +311,17 @@ func (h *spaceSettingHandler) SetSetting(c *gin.Context) {
 		return
 	}
 
-	if err := h.s.SpaceSetting.SetSetting(c.Request.Context(), spaceID, key, value); err != nil {
+	result, err := h.s.SpaceSetting.UpsertSetting(c.Request.Context(), spaceID, key, value)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSetting) {
+			resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+			return
+		}
 		resp.Fail(c.Writer, resp.InternalServer(err.Error()))
 		return
 	}
 
-	resp.Success(c.Writer)
+	resp.Success(c.Writer, result)
 }
 
 // GetSetting handles retrieving a specific space setting
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ncobase/core/space/data"
+	"ncobase/core/space/data/ent"
+	"ncobase/core/space/data/repository"
+	"ncobase/core/space/structs"
+	"sort"
+
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// membershipCheckBatchSize is the number of rows read or deleted per query
+const membershipCheckBatchSize = 500
+
+// globalAdminRoles are granted both globally and in the space when a space is
+// created, a space binding without the global one is a half-done assignment
+var globalAdminRoles = map[string]bool{"super-admin": true, "system-admin": true}
+
+// MembershipDirectory looks up the users and roles memberships point at,
+// which are kept by the user and access modules
+type MembershipDirectory interface {
+	// ExistingUsers returns which of the user IDs exist
+	ExistingUsers(ctx context.Context, ids []string) (map[string]bool, error)
+	// ExistingRoles returns the slug of each of the role IDs that exists
+	ExistingRoles(ctx context.Context, ids []string) (map[string]string, error)
+	// GlobalRoles returns the IDs of the roles each user holds outside of spaces
+	GlobalRoles(ctx context.Context, userIDs []string) (map[string][]string, error)
+}
+
+// MembershipChecker finds and repairs user space memberships and space role bindings
+// that drifted apart: duplicates, rows of deleted users, spaces or roles, role bindings
+// without a membership, members without a role and admin roles granted in a space only
+type MembershipChecker struct {
+	spaces     repository.SpaceRepositoryInterface
+	userSpaces repository.UserSpaceRepositoryInterface
+	spaceRoles repository.UserSpaceRoleRepositoryInterface
+	directory  MembershipDirectory
+}
+
+// NewMembershipChecker creates a new membership checker
+func NewMembershipChecker(d *data.Data, directory MembershipDirectory) *MembershipChecker {
+	return &MembershipChecker{
+		spaces:     repository.NewSpaceRepository(d),
+		userSpaces: repository.NewUserSpaceRepository(d),
+		spaceRoles: repository.NewUserSpaceRoleRepository(d),
+		directory:  directory,
+	}
+}
+
+// Check reports the inconsistent memberships and, unless opts.DryRun, repairs them.
+// Duplicates and orphaned rows are deleted, missing memberships are added, and members
+// without a role get opts.DefaultRoleID when set. Admin roles missing their global
+// binding are only reported, granting them is left to an administrator.
+func (c *MembershipChecker) Check(ctx context.Context, opts *structs.MembershipCheckOptions) (*structs.MembershipReport, error) {
+	report := &structs.MembershipReport{DryRun: opts.DryRun}
+
+	memberships, err := c.loadMemberships(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := c.loadRoleBindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Memberships = len(memberships)
+	report.RoleBindings = len(bindings)
+
+	// Look up everything the rows point at
+	userIDs, spaceIDs, roleIDs := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	for _, row := range memberships {
+		userIDs[row.UserID], spaceIDs[row.SpaceID] = true, true
+	}
+	for _, row := range bindings {
+		userIDs[row.UserID], spaceIDs[row.SpaceID], roleIDs[row.RoleID] = true, true, true
+	}
+	if opts.DefaultRoleID != "" {
+		roleIDs[opts.DefaultRoleID] = true
+	}
+
+	users, err := c.directory.ExistingUsers(ctx, sortedIDs(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up users: %w", err)
+	}
+	roles, err := c.directory.ExistingRoles(ctx, sortedIDs(roleIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up roles: %w", err)
+	}
+	spaces, err := c.existingSpaces(ctx, sortedIDs(spaceIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up spaces: %w", err)
+	}
+	if _, ok := roles[opts.DefaultRoleID]; opts.DefaultRoleID != "" && !ok {
+		return nil, fmt.Errorf("default role %s does not exist", opts.DefaultRoleID)
+	}
+
+	// Keep the oldest of duplicate rows, drop rows pointing at deleted records
+	var staleMemberships []*ent.UserSpace
+	var staleIssues []*structs.MembershipIssue
+	members := map[string]*ent.UserSpace{}
+	for _, row := range memberships {
+		key := row.UserID + "|" + row.SpaceID
+		kind := ""
+		switch {
+		case members[key] != nil:
+			kind = structs.IssueDuplicateMembership
+		case !users[row.UserID] || !spaces[row.SpaceID]:
+			kind = structs.IssueOrphanedMembership
+		default:
+			members[key] = row
+			continue
+		}
+		staleMemberships = append(staleMemberships, row)
+		staleIssues = append(staleIssues, &structs.MembershipIssue{Kind: kind, ID: row.ID, UserID: row.UserID, SpaceID: row.SpaceID})
+	}
+
+	var staleBindings []*ent.UserSpaceRole
+	var staleBindingIssues []*structs.MembershipIssue
+	held := map[string]bool{}
+	var kept []*ent.UserSpaceRole
+	for _, row := range bindings {
+		key := row.UserID + "|" + row.SpaceID + "|" + row.RoleID
+		_, roleExists := roles[row.RoleID]
+		kind := ""
+		switch {
+		case held[key]:
+			kind = structs.IssueDuplicateRoleBinding
+		case !users[row.UserID] || !spaces[row.SpaceID] || !roleExists:
+			kind = structs.IssueOrphanedRoleBinding
+		default:
+			held[key] = true
+			kept = append(kept, row)
+			continue
+		}
+		staleBindings = append(staleBindings, row)
+		staleBindingIssues = append(staleBindingIssues, &structs.MembershipIssue{Kind: kind, ID: row.ID, UserID: row.UserID, SpaceID: row.SpaceID, RoleID: row.RoleID})
+	}
+
+	// Role bindings need a membership, memberships need a role
+	withRole := map[string]bool{}
+	var missingMembers []*structs.MembershipIssue
+	var admins []*ent.UserSpaceRole
+	for _, row := range kept {
+		key := row.UserID + "|" + row.SpaceID
+		if members[key] == nil && !withRole[key] {
+			missingMembers = append(missingMembers, &structs.MembershipIssue{Kind: structs.IssueRoleWithoutMembership, UserID: row.UserID, SpaceID: row.SpaceID, RoleID: row.RoleID})
+		}
+		withRole[key] = true
+		if globalAdminRoles[roles[row.RoleID]] {
+			admins = append(admins, row)
+		}
+	}
+
+	var roleless []*structs.MembershipIssue
+	for _, row := range memberships {
+		key := row.UserID + "|" + row.SpaceID
+		if members[key] == row && !withRole[key] {
+			roleless = append(roleless, &structs.MembershipIssue{Kind: structs.IssueMemberWithoutRole, ID: row.ID, UserID: row.UserID, SpaceID: row.SpaceID, RoleID: opts.DefaultRoleID})
+		}
+	}
+
+	adminIssues, err := c.checkGlobalAdmins(ctx, admins)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repair in dependency order: deletions first, then the missing rows
+	if !opts.DryRun {
+		c.deleteMemberships(ctx, report, staleMemberships, staleIssues)
+		c.deleteRoleBindings(ctx, report, staleBindings, staleBindingIssues)
+		for _, issue := range missingMembers {
+			if _, err := c.userSpaces.Create(ctx, &structs.UserSpace{UserID: issue.UserID, SpaceID: issue.SpaceID}); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to add user %s to space %s: %v", issue.UserID, issue.SpaceID, err))
+				continue
+			}
+			issue.Fixed = true
+			report.Fixed++
+		}
+		if opts.DefaultRoleID != "" {
+			for _, issue := range roleless {
+				if _, err := c.spaceRoles.Create(ctx, &structs.UserSpaceRole{UserID: issue.UserID, SpaceID: issue.SpaceID, RoleID: opts.DefaultRoleID}); err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("failed to give user %s a role in space %s: %v", issue.UserID, issue.SpaceID, err))
+					continue
+				}
+				issue.Fixed = true
+				report.Fixed++
+			}
+		}
+	}
+
+	for _, issues := range [][]*structs.MembershipIssue{staleIssues, staleBindingIssues, missingMembers, roleless, adminIssues} {
+		report.Issues = append(report.Issues, issues...)
+	}
+
+	logger.Infof(ctx, "Membership check found %d issues in %d memberships and %d role bindings, fixed %d",
+		len(report.Issues), report.Memberships, report.RoleBindings, report.Fixed)
+	return report, nil
+}
+
+// checkGlobalAdmins reports the admin role bindings of spaces whose user lacks the global binding
+func (c *MembershipChecker) checkGlobalAdmins(ctx context.Context, admins []*ent.UserSpaceRole) ([]*structs.MembershipIssue, error) {
+	if len(admins) == 0 {
+		return nil, nil
+	}
+
+	userIDs := map[string]bool{}
+	for _, row := range admins {
+		userIDs[row.UserID] = true
+	}
+	global, err := c.directory.GlobalRoles(ctx, sortedIDs(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up global roles: %w", err)
+	}
+
+	var issues []*structs.MembershipIssue
+	for _, row := range admins {
+		found := false
+		for _, roleID := range global[row.UserID] {
+			if roleID == row.RoleID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			issues = append(issues, &structs.MembershipIssue{Kind: structs.IssueAdminWithoutGlobal, ID: row.ID, UserID: row.UserID, SpaceID: row.SpaceID, RoleID: row.RoleID})
+		}
+	}
+	return issues, nil
+}
+
+// deleteMemberships deletes stale memberships batch by batch and marks their issues fixed
+func (c *MembershipChecker) deleteMemberships(ctx context.Context, report *structs.MembershipReport, rows []*ent.UserSpace, issues []*structs.MembershipIssue) {
+	for start := 0; start < len(rows); start += membershipCheckBatchSize {
+		end := min(start+membershipCheckBatchSize, len(rows))
+		if err := c.userSpaces.DeleteRows(ctx, rows[start:end]); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to delete memberships: %v", err))
+			continue
+		}
+		for _, issue := range issues[start:end] {
+			issue.Fixed = true
+		}
+		report.Fixed += end - start
+	}
+}
+
+// deleteRoleBindings deletes stale space role bindings batch by batch and marks their issues fixed
+func (c *MembershipChecker) deleteRoleBindings(ctx context.Context, report *structs.MembershipReport, rows []*ent.UserSpaceRole, issues []*structs.MembershipIssue) {
+	for start := 0; start < len(rows); start += membershipCheckBatchSize {
+		end := min(start+membershipCheckBatchSize, len(rows))
+		if err := c.spaceRoles.DeleteRows(ctx, rows[start:end]); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to delete role bindings: %v", err))
+			continue
+		}
+		for _, issue := range issues[start:end] {
+			issue.Fixed = true
+		}
+		report.Fixed += end - start
+	}
+}
+
+// loadMemberships reads every user space, oldest first
+func (c *MembershipChecker) loadMemberships(ctx context.Context) ([]*ent.UserSpace, error) {
+	var rows []*ent.UserSpace
+	afterID := ""
+	for {
+		batch, err := c.userSpaces.ListAfter(ctx, afterID, membershipCheckBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list memberships: %w", err)
+		}
+		rows = append(rows, batch...)
+		if len(batch) < membershipCheckBatchSize {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].CreatedAt != rows[j].CreatedAt {
+			return rows[i].CreatedAt < rows[j].CreatedAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+	return rows, nil
+}
+
+// loadRoleBindings reads every user space role, oldest first
+func (c *MembershipChecker) loadRoleBindings(ctx context.Context) ([]*ent.UserSpaceRole, error) {
+	var rows []*ent.UserSpaceRole
+	afterID := ""
+	for {
+		batch, err := c.spaceRoles.ListAfter(ctx, afterID, membershipCheckBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list role bindings: %w", err)
+		}
+		rows = append(rows, batch...)
+		if len(batch) < membershipCheckBatchSize {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].CreatedAt != rows[j].CreatedAt {
+			return rows[i].CreatedAt < rows[j].CreatedAt
+		}
+		return rows[i].ID < rows[j].ID
+	})
+	return rows, nil
+}
+
+// existingSpaces returns which of the space IDs exist
+func (c *MembershipChecker) existingSpaces(ctx context.Context, ids []string) (map[string]bool, error) {
+	found := make(map[string]bool, len(ids))
+	for start := 0; start < len(ids); start += membershipCheckBatchSize {
+		end := min(start+membershipCheckBatchSize, len(ids))
+		rows, err := c.spaces.GetByIDs(ctx, ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			found[row.ID] = true
+		}
+	}
+	return found, nil
+}
+
+// sortedIDs returns the non-empty IDs of set in order
+func sortedIDs(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		if k != "" {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
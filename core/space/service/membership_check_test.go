@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"ncobase/core/space/data"
+	"ncobase/core/space/structs"
+)
+
+// memberDirectory knows the users, the slugs of the roles and the global roles of users
+type memberDirectory struct {
+	users  []string
+	roles  map[string]string
+	global map[string][]string
+}
+
+func (d *memberDirectory) ExistingUsers(_ context.Context, ids []string) (map[string]bool, error) {
+	found := map[string]bool{}
+	for _, id := range ids {
+		for _, user := range d.users {
+			if user == id {
+				found[id] = true
+			}
+		}
+	}
+	return found, nil
+}
+
+func (d *memberDirectory) ExistingRoles(_ context.Context, ids []string) (map[string]string, error) {
+	found := map[string]string{}
+	for _, id := range ids {
+		if slug, ok := d.roles[id]; ok {
+			found[id] = slug
+		}
+	}
+	return found, nil
+}
+
+func (d *memberDirectory) GlobalRoles(_ context.Context, userIDs []string) (map[string][]string, error) {
+	found := map[string][]string{}
+	for _, id := range userIDs {
+		found[id] = d.global[id]
+	}
+	return found, nil
+}
+
+// seedDriftedMemberships stores spaces s1 and s2 with memberships and role bindings that
+// drifted apart. Space s3, user gone and role r-gone were deleted.
+func seedDriftedMemberships(t *testing.T, d *data.Data) {
+	t.Helper()
+	ctx := context.Background()
+	client := d.EC
+	for _, id := range []string{"s1", "s2"} {
+		client.Space.Create().SetID(id).SetName(id).SaveX(ctx)
+	}
+	for _, m := range []struct {
+		id, user, space string
+		at              int64
+	}{
+		{"m1", "u1", "s1", 1},
+		{"m2", "u1", "s1", 2}, // duplicate of m1
+		{"m0", "u1", "s1", 3}, // duplicate of m1 though listed first
+		{"m3", "gone", "s1", 1},
+		{"m4", "u2", "s3", 1},
+		{"m5", "u3", "s1", 1}, // member without a role
+		{"m6", "u4", "s2", 1},
+	} {
+		client.UserSpace.Create().SetID(m.id).SetUserID(m.user).SetSpaceID(m.space).SetCreatedAt(m.at).SaveX(ctx)
+	}
+	for _, b := range []struct {
+		id, user, space, role string
+		at                    int64
+	}{
+		{"b1", "u1", "s1", "r-member", 1},
+		{"b2", "u1", "s1", "r-member", 2}, // duplicate of b1
+		{"b3", "u1", "s1", "r-gone", 1},
+		{"b4", "u5", "s2", "r-member", 1}, // role without membership
+		{"b5", "u4", "s2", "r-admin", 1},  // admin without the global role
+		{"b6", "u1", "s1", "r-admin", 1},
+	} {
+		client.UserSpaceRole.Create().SetID(b.id).SetUserID(b.user).SetSpaceID(b.space).SetRoleID(b.role).SetCreatedAt(b.at).SaveX(ctx)
+	}
+}
+
+func newTestDirectory() *memberDirectory {
+	return &memberDirectory{
+		users:  []string{"u1", "u2", "u3", "u4", "u5"},
+		roles:  map[string]string{"r-member": "member", "r-admin": "super-admin"},
+		global: map[string][]string{"u1": {"r-admin"}},
+	}
+}
+
+// issueKeys renders the issues of a report as "kind:row" or "kind:user/space" when no row is at fault
+func issueKeys(report *structs.MembershipReport, fixed bool) []string {
+	var keys []string
+	for _, issue := range report.Issues {
+		if issue.Fixed != fixed {
+			continue
+		}
+		at := issue.ID
+		if at == "" {
+			at = issue.UserID + "/" + issue.SpaceID
+		}
+		keys = append(keys, issue.Kind+":"+at)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestMembershipCheckReportsDrift(t *testing.T) {
+	d := openTestData(t)
+	seedDriftedMemberships(t, d)
+	checker := NewMembershipChecker(d, newTestDirectory())
+
+	report, err := checker.Check(context.Background(), &structs.MembershipCheckOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	want := []string{
+		"admin_without_global:b5",
+		"duplicate_membership:m0",
+		"duplicate_membership:m2",
+		"duplicate_role_binding:b2",
+		"member_without_role:m5",
+		"orphaned_membership:m3",
+		"orphaned_membership:m4",
+		"orphaned_role_binding:b3",
+		"role_without_membership:u5/s2",
+	}
+	if got := issueKeys(report, false); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("issues\n%v\nwant\n%v", got, want)
+	}
+	if report.Memberships != 7 || report.RoleBindings != 6 || report.Fixed != 0 {
+		t.Fatalf("report %+v", report)
+	}
+
+	// a dry run leaves the rows alone
+	if n := d.EC.UserSpace.Query().CountX(context.Background()); n != 7 {
+		t.Fatalf("%d memberships after a dry run, want 7", n)
+	}
+}
+
+func TestMembershipCheckRepairsDrift(t *testing.T) {
+	d := openTestData(t)
+	seedDriftedMemberships(t, d)
+	checker := NewMembershipChecker(d, newTestDirectory())
+	ctx := context.Background()
+
+	report, err := checker.Check(ctx, &structs.MembershipCheckOptions{DefaultRoleID: "r-member"})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if report.Fixed != 8 || len(report.Errors) != 0 {
+		t.Fatalf("fixed %d with errors %v, want 8", report.Fixed, report.Errors)
+	}
+	// admin roles are left to an administrator
+	if got := issueKeys(report, false); strings.Join(got, " ") != "admin_without_global:b5" {
+		t.Fatalf("issues left %v", got)
+	}
+
+	// the oldest duplicates are kept, the missing rows added
+	for _, id := range []string{"m0", "m2"} {
+		if _, err := d.EC.UserSpace.Get(ctx, id); err == nil {
+			t.Fatalf("duplicate membership %s kept", id)
+		}
+	}
+	if _, err := d.EC.UserSpace.Get(ctx, "m1"); err != nil {
+		t.Fatalf("oldest membership: %v", err)
+	}
+	if _, err := d.EC.UserSpaceRole.Get(ctx, "b1"); err != nil {
+		t.Fatalf("oldest role binding: %v", err)
+	}
+	again, err := checker.Check(ctx, &structs.MembershipCheckOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Check after repair: %v", err)
+	}
+	if got := issueKeys(again, false); strings.Join(got, " ") != "admin_without_global:b5" {
+		t.Fatalf("issues after repair %v", got)
+	}
+	if again.Memberships != 4 || again.RoleBindings != 5 {
+		t.Fatalf("after repair %d memberships and %d bindings, want 4 and 5", again.Memberships, again.RoleBindings)
+	}
+}
+
+func TestMembershipCheckWithoutDefaultRole(t *testing.T) {
+	d := openTestData(t)
+	seedDriftedMemberships(t, d)
+	checker := NewMembershipChecker(d, newTestDirectory())
+	ctx := context.Background()
+
+	if _, err := checker.Check(ctx, &structs.MembershipCheckOptions{DefaultRoleID: "r-gone"}); err == nil {
+		t.Fatal("deleted default role accepted")
+	}
+
+	// without a default role, roleless members are only reported
+	report, err := checker.Check(ctx, &structs.MembershipCheckOptions{})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if got := issueKeys(report, false); strings.Join(got, " ") != "admin_without_global:b5 member_without_role:m5" {
+		t.Fatalf("issues left %v", got)
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"ncobase/core/space/data"
 	"ncobase/core/space/data/repository"
 	"ncobase/core/space/structs"
+	"ncobase/internal/page"
+	"ncobase/internal/utils"
 
 	"github.com/ncobase/ncore/ctxutil"
 	"github.com/ncobase/ncore/data/paging"
@@ -20,19 +24,23 @@ import (
 type SpaceServiceInterface interface {
 	UserOwn(ctx context.Context, uid string) (*structs.ReadSpace, error)
 	Create(ctx context.Context, body *structs.CreateSpaceBody) (*structs.ReadSpace, error)
+	Import(ctx context.Context, r io.Reader) (*structs.SpaceImportResult, error)
 	Update(ctx context.Context, body *structs.UpdateSpaceBody) (*structs.ReadSpace, error)
 	Get(ctx context.Context, id string) (*structs.ReadSpace, error)
 	GetBySlug(ctx context.Context, id string) (*structs.ReadSpace, error)
 	GetByUser(ctx context.Context, uid string) (*structs.ReadSpace, error)
+	GetIDByHost(ctx context.Context, host string) (string, error)
 	GetByIDs(ctx context.Context, ids []string) ([]*structs.ReadSpace, error)
+	Search(ctx context.Context, query string, limit int) ([]*structs.ReadSpace, error)
 	Find(ctx context.Context, id string) (*structs.ReadSpace, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, dryRun bool) (*structs.SpaceDeletion, error)
 	CountX(ctx context.Context, params *structs.ListSpaceParams) int
 	List(ctx context.Context, params *structs.ListSpaceParams) (paging.Result[*structs.ReadSpace], error)
 }
 
 // spaceService is the struct for the service.
 type spaceService struct {
+	d                 *data.Data
 	space             repository.SpaceRepositoryInterface
 	userSpace         repository.UserSpaceRepositoryInterface
 	userSpaceRole     repository.UserSpaceRoleRepositoryInterface
@@ -48,6 +56,7 @@ type spaceService struct {
 // NewSpaceService creates a new service.
 func NewSpaceService(d *data.Data) SpaceServiceInterface {
 	return &spaceService{
+		d:                 d,
 		space:             repository.NewSpaceRepository(d),
 		userSpace:         repository.NewUserSpaceRepository(d),
 		userSpaceRole:     repository.NewUserSpaceRoleRepository(d),
@@ -88,17 +97,23 @@ func (s *spaceService) Create(ctx context.Context, body *structs.CreateSpaceBody
 	}
 
 	// Ensure creator is added to the space relationship
-	if creatorID := convert.ToValue(body.CreatedBy); creatorID != "" {
-		if exists, err := s.userSpace.IsSpaceInUser(ctx, space.ID, creatorID); err == nil && !exists {
-			if _, createErr := s.userSpace.Create(ctx, &structs.UserSpace{UserID: creatorID, SpaceID: space.ID}); createErr != nil {
-				logger.Warnf(ctx, "Failed to create user space relation for creator %s in space %s: %v", creatorID, space.ID, createErr)
-			}
-		}
-	}
+	s.addCreator(ctx, space.ID, convert.ToValue(body.CreatedBy))
 
 	return repository.SerializeSpace(space), nil
 }
 
+// addCreator adds the creator of a space to it
+func (s *spaceService) addCreator(ctx context.Context, spaceID, creatorID string) {
+	if creatorID == "" {
+		return
+	}
+	if exists, err := s.userSpace.IsSpaceInUser(ctx, spaceID, creatorID); err == nil && !exists {
+		if _, createErr := s.userSpace.Create(ctx, &structs.UserSpace{UserID: creatorID, SpaceID: spaceID}); createErr != nil {
+			logger.Warnf(ctx, "Failed to create user space relation for creator %s in space %s: %v", creatorID, spaceID, createErr)
+		}
+	}
+}
+
 // Update updates space service (full and partial).
 func (s *spaceService) Update(ctx context.Context, body *structs.UpdateSpaceBody) (*structs.ReadSpace, error) {
 	userID := ctxutil.GetUserID(ctx)
@@ -200,6 +215,11 @@ func (s *spaceService) GetBySlug(ctx context.Context, slug string) (*structs.Rea
 	return repository.SerializeSpace(space), nil
 }
 
+// GetIDByHost returns the id of the space served on host, empty if none
+func (s *spaceService) GetIDByHost(ctx context.Context, host string) (string, error) {
+	return s.space.GetIDByHost(ctx, host)
+}
+
 // GetByUser returns the space for the created by user
 func (s *spaceService) GetByUser(ctx context.Context, uid string) (*structs.ReadSpace, error) {
 	if uid == "" {
@@ -227,6 +247,32 @@ func (s *spaceService) GetByIDs(ctx context.Context, ids []string) ([]*structs.R
 	return repository.SerializeSpaces(spaces), nil
 }
 
+// Search finds the spaces the current user may access, admins search every space
+func (s *spaceService) Search(ctx context.Context, query string, limit int) ([]*structs.ReadSpace, error) {
+	if query == "" {
+		return []*structs.ReadSpace{}, nil
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	var ids []string
+	if !ctxutil.GetUserIsAdmin(ctx) {
+		ids = ctxutil.GetUserSpaceIDs(ctx)
+		if ids == nil {
+			ids = []string{}
+		}
+	}
+
+	rows, err := s.space.Search(ctx, query, ids, limit)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to search spaces: %v", err)
+		return nil, err
+	}
+
+	return repository.SerializeSpaces(rows), nil
+}
+
 // Find finds space service.
 func (s *spaceService) Find(ctx context.Context, id string) (*structs.ReadSpace, error) {
 	space, err := s.space.GetBySlug(ctx, id)
@@ -236,81 +282,223 @@ func (s *spaceService) Find(ctx context.Context, id string) (*structs.ReadSpace,
 	return repository.SerializeSpace(space), nil
 }
 
-// Delete deletes space service.
-func (s *spaceService) Delete(ctx context.Context, id string) error {
-	err := s.space.Delete(ctx, id)
+// Delete deletes a space and the records bound to it in one transaction, returning the
+// records removed. With dryRun the same records are only counted and nothing is changed.
+// A failed step rolls the whole deletion back and is returned as the error.
+func (s *spaceService) Delete(ctx context.Context, id string, dryRun bool) (*structs.SpaceDeletion, error) {
+	row, err := s.space.GetBySlug(ctx, id)
+	if err := handleEntError(ctx, "Space", err); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.planDeletion(ctx, row.ID)
 	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return plan.summary(row.ID, true), nil
+	}
+
+	var deleted *structs.SpaceDeletion
+	err = utils.WithTx(ctx, s.d.Data, func(ctx context.Context) error {
+		var err error
+		deleted, err = s.deleteCascade(ctx, row.ID, plan)
 		return err
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Failed to delete space %s, nothing was removed: %v", row.ID, err)
+		return nil, err
 	}
+	return deleted, nil
+}
 
-	// Cleanup associated records
-	if err := s.userSpaceRole.DeleteAllBySpaceID(ctx, id); err != nil {
-		logger.Warnf(ctx, "Failed to delete user space roles for space %s: %v", id, err)
+// deleteCascade deletes a space and the records of its plan, counting the rows each step removed.
+// It stops at the first failed step, the caller's transaction undoes the steps before it.
+func (s *spaceService) deleteCascade(ctx context.Context, spaceID string, plan *spaceDeletionPlan) (*structs.SpaceDeletion, error) {
+	deleted := &structs.SpaceDeletion{SpaceID: spaceID, Users: len(plan.users)}
+
+	if err := s.space.Delete(ctx, spaceID); err != nil {
+		return nil, fmt.Errorf("delete space: %w", err)
+	}
+
+	steps := []struct {
+		name   string
+		delete func(ctx context.Context, spaceID string) (int, error)
+		count  *int
+	}{
+		{"role bindings", s.userSpaceRole.DeleteAllBySpaceID, &deleted.RoleBindings},
+		{"memberships", s.userSpace.DeleteAllBySpaceID, &deleted.Memberships},
+		{"organizations", s.spaceOrganization.DeleteAllBySpaceID, &deleted.Organizations},
+		{"menus", s.spaceMenu.DeleteAllBySpaceID, &deleted.Menus},
+		{"dictionaries", s.spaceDictionary.DeleteAllBySpaceID, &deleted.Dictionaries},
+		{"options", s.spaceOption.DeleteAllBySpaceID, &deleted.Options},
+	}
+	for _, step := range steps {
+		n, err := step.delete(ctx, spaceID)
+		if err != nil {
+			return nil, fmt.Errorf("delete space %s: %w", step.name, err)
+		}
+		*step.count = n
+	}
+
+	records := []struct {
+		name   string
+		ids    []string
+		delete func(ctx context.Context, id string) error
+		count  *int
+	}{
+		{"setting", plan.settings, s.spaceSetting.Delete, &deleted.Settings},
+		{"quota", plan.quotas, s.spaceQuota.Delete, &deleted.Quotas},
+		{"billing", plan.billings, s.spaceBilling.Delete, &deleted.Billings},
+	}
+	for _, record := range records {
+		for _, id := range record.ids {
+			err := record.delete(ctx, id)
+			if repository.IsNotFound(err) {
+				continue // removed since the plan was made
+			}
+			if err != nil {
+				return nil, fmt.Errorf("delete space %s %s: %w", record.name, id, err)
+			}
+			*record.count++
+		}
 	}
-	if err := s.userSpace.DeleteAllBySpaceID(ctx, id); err != nil {
-		logger.Warnf(ctx, "Failed to delete user space relations for space %s: %v", id, err)
+
+	return deleted, nil
+}
+
+// spaceDeletionPlan holds the records bound to a space, enumerated once for
+// both the dry run and the real deletion so their counts agree
+type spaceDeletionPlan struct {
+	users         map[string]struct{}
+	memberships   int
+	roleBindings  int
+	organizations int
+	menus         int
+	dictionaries  int
+	options       int
+	settings      []string
+	quotas        []string
+	billings      []string
+}
+
+// planDeletion enumerates the records bound to a space
+func (s *spaceService) planDeletion(ctx context.Context, spaceID string) (*spaceDeletionPlan, error) {
+	plan := &spaceDeletionPlan{users: make(map[string]struct{})}
+
+	memberships, err := s.userSpace.GetBySpaceIDs(ctx, []string{spaceID})
+	if err != nil {
+		return nil, fmt.Errorf("list space members: %w", err)
+	}
+	for _, m := range memberships {
+		plan.users[m.UserID] = struct{}{}
+	}
+	plan.memberships = len(memberships)
+
+	roles, err := s.userSpaceRole.GetBySpaceID(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("list space roles: %w", err)
 	}
-	if err := s.spaceOrganization.DeleteAllBySpaceID(ctx, id); err != nil {
-		logger.Warnf(ctx, "Failed to delete space organizations for space %s: %v", id, err)
+	for _, r := range roles {
+		plan.users[r.UserID] = struct{}{}
 	}
-	if err := s.spaceMenu.DeleteAllBySpaceID(ctx, id); err != nil {
-		logger.Warnf(ctx, "Failed to delete space menus for space %s: %v", id, err)
+	plan.roleBindings = len(roles)
+
+	orgs, err := s.spaceOrganization.GetBySpaceID(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("list space organizations: %w", err)
 	}
-	if err := s.spaceDictionary.DeleteAllBySpaceID(ctx, id); err != nil {
-		logger.Warnf(ctx, "Failed to delete space dictionaries for space %s: %v", id, err)
+	plan.organizations = len(orgs)
+
+	menus, err := s.spaceMenu.GetBySpaceID(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("list space menus: %w", err)
 	}
-	if err := s.spaceOption.DeleteAllBySpaceID(ctx, id); err != nil {
-		logger.Warnf(ctx, "Failed to delete space options for space %s: %v", id, err)
+	plan.menus = len(menus)
+
+	dictionaries, err := s.spaceDictionary.GetBySpaceID(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("list space dictionaries: %w", err)
 	}
+	plan.dictionaries = len(dictionaries)
 
-	if settings, err := s.spaceSetting.GetBySpaceID(ctx, id); err == nil {
-		for _, setting := range settings {
-			if setting != nil {
-				if delErr := s.spaceSetting.Delete(ctx, setting.ID); delErr != nil {
-					logger.Warnf(ctx, "Failed to delete space setting %s: %v", setting.ID, delErr)
-				}
-			}
+	options, err := s.spaceOption.GetBySpaceID(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("list space options: %w", err)
+	}
+	plan.options = len(options)
+
+	settings, err := s.spaceSetting.GetBySpaceID(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("list space settings: %w", err)
+	}
+	for _, setting := range settings {
+		if setting != nil {
+			plan.settings = append(plan.settings, setting.ID)
 		}
-	} else {
-		logger.Warnf(ctx, "Failed to load space settings for cleanup %s: %v", id, err)
 	}
 
-	if quotas, err := s.spaceQuota.GetBySpaceID(ctx, id); err == nil {
-		for _, quota := range quotas {
-			if quota != nil {
-				if delErr := s.spaceQuota.Delete(ctx, quota.ID); delErr != nil {
-					logger.Warnf(ctx, "Failed to delete space quota %s: %v", quota.ID, delErr)
-				}
-			}
+	quotas, err := s.spaceQuota.GetBySpaceID(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("list space quotas: %w", err)
+	}
+	for _, quota := range quotas {
+		if quota != nil {
+			plan.quotas = append(plan.quotas, quota.ID)
 		}
-	} else {
-		logger.Warnf(ctx, "Failed to load space quotas for cleanup %s: %v", id, err)
 	}
 
-	if billings, err := s.spaceBilling.GetBySpaceID(ctx, id); err == nil {
-		for _, billing := range billings {
-			if billing != nil {
-				if delErr := s.spaceBilling.Delete(ctx, billing.ID); delErr != nil {
-					logger.Warnf(ctx, "Failed to delete space billing %s: %v", billing.ID, delErr)
-				}
-			}
+	billings, err := s.spaceBilling.GetBySpaceID(ctx, spaceID)
+	if err != nil {
+		return nil, fmt.Errorf("list space billing: %w", err)
+	}
+	for _, billing := range billings {
+		if billing != nil {
+			plan.billings = append(plan.billings, billing.ID)
 		}
-	} else {
-		logger.Warnf(ctx, "Failed to load space billing for cleanup %s: %v", id, err)
 	}
 
-	return nil
+	return plan, nil
+}
+
+// summary reports the counts of a deletion plan
+func (p *spaceDeletionPlan) summary(spaceID string, dryRun bool) *structs.SpaceDeletion {
+	return &structs.SpaceDeletion{
+		SpaceID:       spaceID,
+		DryRun:        dryRun,
+		Users:         len(p.users),
+		Memberships:   p.memberships,
+		RoleBindings:  p.roleBindings,
+		Organizations: p.organizations,
+		Menus:         p.menus,
+		Dictionaries:  p.dictionaries,
+		Options:       p.options,
+		Settings:      len(p.settings),
+		Quotas:        len(p.quotas),
+		Billings:      len(p.billings),
+	}
 }
 
 // List lists space service.
 func (s *spaceService) List(ctx context.Context, params *structs.ListSpaceParams) (paging.Result[*structs.ReadSpace], error) {
+	if params.Cursor != "" {
+		if _, err := page.DecodeCursor(params.Cursor); err != nil {
+			return paging.Result[*structs.ReadSpace]{}, err
+		}
+	}
+
+	limit, err := page.Limit("space", params.Limit)
+	if err != nil {
+		return paging.Result[*structs.ReadSpace]{}, err
+	}
+
 	pp := paging.Params{
 		Cursor:    params.Cursor,
-		Limit:     params.Limit,
+		Limit:     limit,
 		Direction: params.Direction,
 	}
 
-	return paging.Paginate(pp, func(cursor string, limit int, direction string) ([]*structs.ReadSpace, int, error) {
+	return page.SignCursors(paging.Paginate(pp, func(cursor string, limit int, direction string) ([]*structs.ReadSpace, int, error) {
 		lp := *params
 		lp.Cursor = cursor
 		lp.Limit = limit
@@ -328,7 +516,7 @@ func (s *spaceService) List(ctx context.Context, params *structs.ListSpaceParams
 		total := s.CountX(ctx, params)
 
 		return repository.SerializeSpaces(rows), total, nil
-	})
+	}))
 }
 
 // CountX gets a count of spaces.
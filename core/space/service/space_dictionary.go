@@ -86,7 +86,7 @@ func (s *spaceDictionaryService) GetDictionarySpaces(ctx context.Context, dictio
 
 // RemoveAllDictionariesFromSpace removes all dictionaries from a space.
 func (s *spaceDictionaryService) RemoveAllDictionariesFromSpace(ctx context.Context, spaceID string) error {
-	err := s.spaceDictionary.DeleteAllBySpaceID(ctx, spaceID)
+	_, err := s.spaceDictionary.DeleteAllBySpaceID(ctx, spaceID)
 	if err := handleEntError(ctx, "SpaceDictionary", err); err != nil {
 		return err
 	}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"ncobase/core/space/structs"
+	"sort"
+	"strings"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/utils/convert"
+	"github.com/ncobase/ncore/utils/slug"
+)
+
+// pendingSpace is a valid import row waiting to be created
+type pendingSpace struct {
+	row  *structs.SpaceImportRow
+	body *structs.CreateSpaceBody
+}
+
+// Import creates spaces from a CSV with the columns in structs.SpaceImportColumns.
+// Every row gets a result: rows that fail validation or repeat a name, in the
+// file or among existing spaces, are reported and the rest are still created.
+// Spaces are created one batch per transaction, a failed batch fails all of its rows.
+func (s *spaceService) Import(ctx context.Context, r io.Reader) (*structs.SpaceImportResult, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("import file is empty")
+		}
+		return nil, fmt.Errorf("invalid import header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, fmt.Errorf("import header must include the columns %s", strings.Join(structs.SpaceImportColumns, ", "))
+	}
+
+	result := &structs.SpaceImportResult{Rows: make([]*structs.SpaceImportRow, 0)}
+	seen := make(map[string]int) // name or slug to the line that claimed it
+	var pending []*pendingSpace
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				line = parseErr.StartLine
+			}
+			result.Add(&structs.SpaceImportRow{Line: line, Status: structs.SpaceImportError, Error: err.Error()})
+			continue
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		body := &structs.CreateSpaceBody{SpaceBody: structs.SpaceBody{
+			Name:     field("name"),
+			Title:    field("title"),
+			URL:      field("url"),
+			Keywords: field("keywords"),
+		}}
+		if createdBy := field("created_by"); createdBy != "" {
+			body.CreatedBy = convert.ToPointer(createdBy)
+		}
+
+		row := &structs.SpaceImportRow{Line: line, Name: body.Name}
+		if err := body.Validate(); err != nil {
+			row.Status, row.Error = structs.SpaceImportError, err.Error()
+			result.Add(row)
+			continue
+		}
+
+		body.Slug = slug.Unicode(body.Name)
+		if first, ok := seen[body.Name]; ok {
+			row.Status, row.Error = structs.SpaceImportSkipped, fmt.Sprintf("duplicate of line %d", first)
+			result.Add(row)
+			continue
+		}
+		if first, ok := seen[body.Slug]; ok {
+			row.Status, row.Error = structs.SpaceImportSkipped, fmt.Sprintf("slug %s duplicates line %d", body.Slug, first)
+			result.Add(row)
+			continue
+		}
+		seen[body.Name], seen[body.Slug] = line, line
+
+		pending = append(pending, &pendingSpace{row: row, body: body})
+	}
+
+	pending, err = s.skipExisting(ctx, pending, result)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := ctxutil.GetUserID(ctx)
+	for start := 0; start < len(pending); start += structs.DefaultSpaceImportBatchSize {
+		end := min(start+structs.DefaultSpaceImportBatchSize, len(pending))
+		s.importBatch(ctx, pending[start:end], userID, result)
+	}
+
+	sort.SliceStable(result.Rows, func(i, j int) bool { return result.Rows[i].Line < result.Rows[j].Line })
+	return result, nil
+}
+
+// skipExisting reports the rows whose name or slug is already taken and returns the rest
+func (s *spaceService) skipExisting(ctx context.Context, pending []*pendingSpace, result *structs.SpaceImportResult) ([]*pendingSpace, error) {
+	if len(pending) == 0 {
+		return pending, nil
+	}
+
+	names := make([]string, 0, len(pending))
+	slugs := make([]string, 0, len(pending))
+	for _, p := range pending {
+		names = append(names, p.body.Name)
+		slugs = append(slugs, p.body.Slug)
+	}
+
+	existing, err := s.space.GetByNamesOrSlugs(ctx, names, slugs)
+	if err := handleEntError(ctx, "Space", err); err != nil {
+		return nil, err
+	}
+	taken := make(map[string]struct{}, len(existing)*2)
+	for _, space := range existing {
+		taken[space.Name] = struct{}{}
+		taken[space.Slug] = struct{}{}
+	}
+
+	remaining := pending[:0]
+	for _, p := range pending {
+		_, nameTaken := taken[p.body.Name]
+		_, slugTaken := taken[p.body.Slug]
+		if nameTaken || slugTaken {
+			p.row.Status, p.row.Error = structs.SpaceImportSkipped, "space already exists"
+			result.Add(p.row)
+			continue
+		}
+		remaining = append(remaining, p)
+	}
+	return remaining, nil
+}
+
+// importBatch creates a batch of spaces in one transaction and records the row results
+func (s *spaceService) importBatch(ctx context.Context, batch []*pendingSpace, userID string, result *structs.SpaceImportResult) {
+	bodies := make([]*structs.CreateSpaceBody, 0, len(batch))
+	for _, p := range batch {
+		if p.body.CreatedBy == nil && userID != "" {
+			p.body.CreatedBy = convert.ToPointer(userID)
+		}
+		bodies = append(bodies, p.body)
+	}
+
+	spaces, err := s.space.CreateBatch(ctx, bodies)
+	if err != nil {
+		err = handleEntError(ctx, "Space", err)
+		for _, p := range batch {
+			p.row.Status, p.row.Error = structs.SpaceImportError, err.Error()
+			result.Add(p.row)
+		}
+		return
+	}
+
+	for i, space := range spaces {
+		p := batch[i]
+		p.row.Status, p.row.ID = structs.SpaceImportCreated, space.ID
+		result.Add(p.row)
+		s.addCreator(ctx, space.ID, convert.ToValue(p.body.CreatedBy))
+	}
+}
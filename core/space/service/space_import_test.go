@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"ncobase/core/space/data/ent"
+	"ncobase/core/space/data/repository"
+	"ncobase/core/space/structs"
+
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+// importSpaces keeps spaces in memory, failing batches that contain failName
+type importSpaces struct {
+	repository.SpaceRepositoryInterface
+	rows     []*ent.Space
+	batches  int
+	failName string
+}
+
+func (r *importSpaces) GetByNamesOrSlugs(_ context.Context, names, slugs []string) ([]*ent.Space, error) {
+	var found []*ent.Space
+	for _, row := range r.rows {
+		for _, v := range append(append([]string{}, names...), slugs...) {
+			if row.Name == v || row.Slug == v {
+				found = append(found, row)
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+func (r *importSpaces) CreateBatch(_ context.Context, bodies []*structs.CreateSpaceBody) ([]*ent.Space, error) {
+	r.batches++
+	var created []*ent.Space
+	for _, body := range bodies {
+		if body.Name == r.failName {
+			return nil, errors.New("connection reset")
+		}
+		created = append(created, &ent.Space{
+			ID: fmt.Sprintf("s%d", len(r.rows)+len(created)+1), Name: body.Name, Slug: body.Slug, CreatedBy: *body.CreatedBy,
+		})
+	}
+	r.rows = append(r.rows, created...)
+	return created, nil
+}
+
+// creators records the memberships added for space creators
+type creators struct {
+	repository.UserSpaceRepositoryInterface
+	added []string
+}
+
+func (c *creators) IsSpaceInUser(_ context.Context, _, _ string) (bool, error) {
+	return false, nil
+}
+
+func (c *creators) Create(_ context.Context, body *structs.UserSpace) (*ent.UserSpace, error) {
+	c.added = append(c.added, body.UserID+"@"+body.SpaceID)
+	return &ent.UserSpace{UserID: body.UserID, SpaceID: body.SpaceID}, nil
+}
+
+func newImportService(existing ...*ent.Space) (*spaceService, *importSpaces, *creators) {
+	spaces := &importSpaces{rows: existing}
+	members := &creators{}
+	return &spaceService{space: spaces, userSpace: members}, spaces, members
+}
+
+func TestImportReportsEachRow(t *testing.T) {
+	s, spaces, members := newImportService(&ent.Space{ID: "s0", Name: "Globex", Slug: "globex"})
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+
+	csv := strings.Join([]string{
+		"Name,Title,URL",
+		"Acme,Acme Inc,https://acme.example.com",
+		"Globex,Globex Corp,",
+		",No Name,",
+		"Acme,Acme again,",
+		"Initech,Initech,not a url",
+	}, "\n")
+	result, err := s.Import(ctx, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	if result.Created != 1 || result.Skipped != 2 || result.Failed != 2 {
+		t.Fatalf("created %d, skipped %d, failed %d, want 1, 2 and 2", result.Created, result.Skipped, result.Failed)
+	}
+	want := []struct {
+		line   int
+		status string
+		error  string
+	}{
+		{2, structs.SpaceImportCreated, ""},
+		{3, structs.SpaceImportSkipped, "space already exists"},
+		{4, structs.SpaceImportError, "name"},
+		{5, structs.SpaceImportSkipped, "duplicate of line 2"},
+		{6, structs.SpaceImportError, "url"},
+	}
+	for i, row := range result.Rows {
+		if row.Line != want[i].line || row.Status != want[i].status || !strings.Contains(row.Error, want[i].error) {
+			t.Errorf("row %+v, want line %d %s (%s)", row, want[i].line, want[i].status, want[i].error)
+		}
+	}
+
+	created := spaces.rows[len(spaces.rows)-1]
+	if created.Name != "Acme" || created.Slug != "acme" || created.CreatedBy != "u1" || result.Rows[0].ID != created.ID {
+		t.Fatalf("created %+v", created)
+	}
+	if len(members.added) != 1 || members.added[0] != "u1@"+created.ID {
+		t.Fatalf("creator memberships %v", members.added)
+	}
+}
+
+func TestImportFailedBatchFailsItsRows(t *testing.T) {
+	s, spaces, _ := newImportService()
+	spaces.failName = "Beta"
+
+	result, err := s.Import(ctxutil.SetUserID(context.Background(), "u1"), strings.NewReader("name\nAlpha\nBeta\n"))
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.Failed != 2 || result.Created != 0 || len(spaces.rows) != 0 {
+		t.Fatalf("created %d, failed %d, want the whole batch failed", result.Created, result.Failed)
+	}
+}
+
+func TestImportRejectsInvalidFiles(t *testing.T) {
+	s, _, _ := newImportService()
+	for name, csv := range map[string]string{
+		"empty":        "",
+		"missing name": "title,url\nAcme,\n",
+	} {
+		if _, err := s.Import(context.Background(), strings.NewReader(csv)); err == nil {
+			t.Errorf("%s file accepted", name)
+		}
+	}
+}
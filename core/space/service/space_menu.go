@@ -86,7 +86,7 @@ func (s *spaceMenuService) GetMenuSpaces(ctx context.Context, menuID string) ([]
 
 // RemoveAllMenusFromSpace removes all menus from a space.
 func (s *spaceMenuService) RemoveAllMenusFromSpace(ctx context.Context, spaceID string) error {
-	err := s.spaceMenu.DeleteAllBySpaceID(ctx, spaceID)
+	_, err := s.spaceMenu.DeleteAllBySpaceID(ctx, spaceID)
 	if err := handleEntError(ctx, "SpaceMenu", err); err != nil {
 		return err
 	}
@@ -86,7 +86,7 @@ func (s *spaceOptionService) GetOptionsSpaces(ctx context.Context, optionsID str
 
 // RemoveAllOptionsFromSpace removes all options from a space.
 func (s *spaceOptionService) RemoveAllOptionsFromSpace(ctx context.Context, spaceID string) error {
-	err := s.spaceOption.DeleteAllBySpaceID(ctx, spaceID)
+	_, err := s.spaceOption.DeleteAllBySpaceID(ctx, spaceID)
 	if err := handleEntError(ctx, "SpaceOption", err); err != nil {
 		return err
 	}
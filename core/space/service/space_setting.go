@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"ncobase/core/space/data"
 	"ncobase/core/space/data/repository"
 	"ncobase/core/space/structs"
@@ -24,9 +25,13 @@ type SpaceSettingServiceInterface interface {
 	BulkUpdate(ctx context.Context, req *structs.BulkUpdateSettingsRequest) error
 	GetSpaceSettings(ctx context.Context, spaceID string, publicOnly bool) (map[string]any, error)
 	SetSetting(ctx context.Context, spaceID, key, value string) error
+	UpsertSetting(ctx context.Context, spaceID, key string, value any) (*structs.ReadSpaceSetting, error)
 	GetSettingValue(ctx context.Context, spaceID, key string) (any, error)
 }
 
+// ErrInvalidSetting is returned when a setting key or value is rejected
+var ErrInvalidSetting = errors.New("invalid setting")
+
 // spaceSettingService implements SpaceSettingServiceInterface
 type spaceSettingService struct {
 	repo repository.SpaceSettingRepositoryInterface
@@ -154,35 +159,72 @@ func (s *spaceSettingService) GetSpaceSettings(ctx context.Context, spaceID stri
 	return result, nil
 }
 
-// SetSetting creates or updates a setting
+// SetSetting creates or updates a setting from the stored form of its value, see UpsertSetting
 func (s *spaceSettingService) SetSetting(ctx context.Context, spaceID, key, value string) error {
+	_, err := s.UpsertSetting(ctx, spaceID, key, value)
+	return err
+}
+
+// UpsertSetting creates or updates the setting key of a space. Values of known keys must match
+// their type and options, values of settings defined on the space their stored type. Other keys
+// are only accepted under structs.CustomSettingPrefix, typed by their first value.
+func (s *spaceSettingService) UpsertSetting(ctx context.Context, spaceID, key string, value any) (*structs.ReadSpaceSetting, error) {
+	if spaceID == "" {
+		return nil, errors.New(ecode.FieldIsRequired("space_id"))
+	}
+	if key == "" {
+		return nil, errors.New(ecode.FieldIsRequired("setting_key"))
+	}
+
 	existing, err := s.repo.GetByKey(ctx, spaceID, key)
 	if err != nil && !repository.IsNotFound(err) {
-		return err
+		return nil, err
+	}
+	if existing != nil && existing.IsReadonly {
+		return nil, fmt.Errorf("%w: %s is read-only", ErrInvalidSetting, key)
+	}
+
+	def, known := structs.LookupSetting(key)
+	switch {
+	case known:
+	case existing != nil:
+		def = structs.SettingDefinition{Type: structs.SettingType(existing.SettingType)}
+	case structs.IsCustomSettingKey(key):
+		def = structs.SettingDefinition{Type: structs.InferSettingType(value)}
+	default:
+		return nil, fmt.Errorf("%w: unknown key %s, custom settings go under %s", ErrInvalidSetting, key, structs.CustomSettingPrefix)
+	}
+
+	raw, err := def.Format(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidSetting, key, err)
 	}
 
 	if existing != nil {
-		// Update existing setting
-		updates := types.JSON{
-			"setting_value": value,
+		row, err := s.repo.Update(ctx, existing.ID, types.JSON{
+			"setting_value": raw,
+			"setting_type":  string(def.Type),
+		})
+		if err := handleEntError(ctx, "SpaceSetting", err); err != nil {
+			return nil, err
 		}
-		_, err = s.repo.Update(ctx, existing.ID, updates)
-		return err
+		return repository.SerializeSpaceSetting(row), nil
 	}
 
-	// Create new setting
-	body := &structs.CreateSpaceSettingBody{
+	row, err := s.repo.Create(ctx, &structs.CreateSpaceSettingBody{
 		SpaceSettingBody: structs.SpaceSettingBody{
 			SpaceID:      spaceID,
 			SettingKey:   key,
-			SettingValue: value,
+			SettingValue: raw,
 			SettingName:  key, // Use key as name for simplicity
-			SettingType:  structs.TypeString,
+			SettingType:  def.Type,
 			Scope:        structs.Scope,
 		},
+	})
+	if err := handleEntError(ctx, "SpaceSetting", err); err != nil {
+		return nil, err
 	}
-	_, err = s.repo.Create(ctx, body)
-	return err
+	return repository.SerializeSpaceSetting(row), nil
 }
 
 // GetSettingValue retrieves a setting value with type conversion
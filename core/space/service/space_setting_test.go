@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"ncobase/core/space/data"
+	"ncobase/core/space/data/ent"
+	"ncobase/core/space/data/repository"
+	"ncobase/core/space/structs"
+
+	_ "github.com/mattn/go-sqlite3"
+	nd "github.com/ncobase/ncore/data"
+	"github.com/ncobase/ncore/data/connection"
+	"github.com/redis/go-redis/v9"
+)
+
+// openTestData returns data over an in-memory database of the test, without a cache
+func openTestData(t *testing.T) *data.Data {
+	t.Helper()
+	client, err := ent.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return &data.Data{Data: &nd.Data{Conn: &connection.Connections{RC: (*redis.Client)(nil)}}, EC: client}
+}
+
+// newSettingService returns a setting service over an in-memory database
+func newSettingService(t *testing.T) *spaceSettingService {
+	t.Helper()
+	return &spaceSettingService{repo: repository.NewSpaceSettingRepository(openTestData(t))}
+}
+
+func TestUpsertSettingStoresTypedValues(t *testing.T) {
+	s := newSettingService(t)
+	ctx := context.Background()
+
+	for key, value := range map[string]any{
+		"enable_sso":                    true,
+		"max_file_size":                 "200",
+		"working_hours":                 map[string]any{"start": "09:00"},
+		"resource.default_access_level": "shared",
+		"custom.banner":                 "hello",
+		"custom.retries":                float64(3),
+	} {
+		if _, err := s.UpsertSetting(ctx, "s1", key, value); err != nil {
+			t.Fatalf("upsert %s: %v", key, err)
+		}
+	}
+	// an upsert of a stored key updates it in place
+	setting, err := s.UpsertSetting(ctx, "s1", "custom.retries", float64(5))
+	if err != nil || setting.SettingType != structs.TypeNumber {
+		t.Fatalf("update custom.retries: %+v, %v", setting, err)
+	}
+
+	settings, err := s.GetSpaceSettings(ctx, "s1", false)
+	if err != nil {
+		t.Fatalf("GetSpaceSettings: %v", err)
+	}
+	want := map[string]string{
+		"enable_sso":                    "true",
+		"max_file_size":                 "200",
+		"working_hours":                 "map[start:09:00]",
+		"resource.default_access_level": "shared",
+		"custom.banner":                 "hello",
+		"custom.retries":                "5",
+	}
+	if len(settings) != len(want) {
+		t.Fatalf("settings %v, want %d", settings, len(want))
+	}
+	for key, value := range want {
+		if got := fmt.Sprint(settings[key]); got != value {
+			t.Fatalf("setting %s = %s, want %s", key, got, value)
+		}
+	}
+	if value, err := s.GetSettingValue(ctx, "s1", "enable_sso"); err != nil || value != true {
+		t.Fatalf("enable_sso = %v, %v, want a boolean", value, err)
+	}
+}
+
+func TestUpsertSettingRejectsInvalidSettings(t *testing.T) {
+	s := newSettingService(t)
+	ctx := context.Background()
+	if _, err := s.UpsertSetting(ctx, "s1", "custom.flag", true); err != nil {
+		t.Fatalf("upsert custom.flag: %v", err)
+	}
+	if _, err := s.Create(ctx, &structs.CreateSpaceSettingBody{SpaceSettingBody: structs.SpaceSettingBody{
+		SpaceID: "s1", SettingKey: "company_name", SettingName: "Company", SettingValue: "Acme",
+		SettingType: structs.TypeString, Scope: structs.Scope, IsReadonly: true,
+	}}); err != nil {
+		t.Fatalf("create company_name: %v", err)
+	}
+
+	for _, tt := range []struct {
+		key   string
+		value any
+	}{
+		{"enable_sso", "maybe"},
+		{"max_file_size", true},
+		{"resource.default_access_level", "everyone"},
+		{"custom.flag", "yes"}, // keeps the type of its first value
+		{"custom.", "empty name"},
+		{"custom.Bad Key", "x"},
+		{"favourite_color", "blue"},
+		{"company_name", "Other"}, // read-only
+	} {
+		if _, err := s.UpsertSetting(ctx, "s1", tt.key, tt.value); !errors.Is(err, ErrInvalidSetting) {
+			t.Fatalf("upsert %s = %v: %v, want ErrInvalidSetting", tt.key, tt.value, err)
+		}
+	}
+
+	if err := s.BulkUpdate(ctx, &structs.BulkUpdateSettingsRequest{SpaceID: "s1", Settings: map[string]string{"enable_sso": "1.5"}}); !errors.Is(err, ErrInvalidSetting) {
+		t.Fatalf("bulk update with an invalid value: %v", err)
+	}
+}
+
+func TestSettingsAreIsolatedBetweenSpaces(t *testing.T) {
+	s := newSettingService(t)
+	ctx := context.Background()
+
+	if _, err := s.UpsertSetting(ctx, "s1", "site_name", "First"); err != nil {
+		t.Fatalf("upsert s1: %v", err)
+	}
+	if _, err := s.UpsertSetting(ctx, "s2", "site_name", "Second"); err != nil {
+		t.Fatalf("upsert s2: %v", err)
+	}
+	if _, err := s.UpsertSetting(ctx, "s2", "custom.only_here", true); err != nil {
+		t.Fatalf("upsert s2 custom: %v", err)
+	}
+	// a key typed in one space can be typed differently in another
+	if _, err := s.UpsertSetting(ctx, "s1", "custom.only_here", "text"); err != nil {
+		t.Fatalf("upsert s1 custom: %v", err)
+	}
+
+	first, err := s.GetSpaceSettings(ctx, "s1", false)
+	if err != nil {
+		t.Fatalf("settings of s1: %v", err)
+	}
+	second, err := s.GetSpaceSettings(ctx, "s2", false)
+	if err != nil {
+		t.Fatalf("settings of s2: %v", err)
+	}
+	if first["site_name"] != "First" || first["custom.only_here"] != "text" {
+		t.Fatalf("settings of s1 = %v", first)
+	}
+	if second["site_name"] != "Second" || second["custom.only_here"] != true {
+		t.Fatalf("settings of s2 = %v", second)
+	}
+	if _, err := s.GetSettingValue(ctx, "s3", "site_name"); err == nil {
+		t.Fatal("setting of another space read from s3")
+	}
+}
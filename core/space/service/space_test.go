@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"ncobase/core/space/data/ent"
+	"ncobase/core/space/data/repository"
+	"ncobase/core/space/structs"
+	"ncobase/internal/page"
+)
+
+// spaceRows counts the rows bound to a space per table, fail names a table whose delete fails
+type spaceRows struct {
+	rows map[string]int
+	fail string
+}
+
+func (s *spaceRows) deleteAll(table string) (int, error) {
+	if s.fail == table {
+		return 0, errors.New("connection reset")
+	}
+	n := s.rows[table]
+	s.rows[table] = 0
+	return n, nil
+}
+
+func (s *spaceRows) ids(table string) []string {
+	ids := make([]string, s.rows[table])
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%s-%d", table, i)
+	}
+	return ids
+}
+
+func (s *spaceRows) deleteOne(table string) error {
+	if s.fail == table {
+		return errors.New("connection reset")
+	}
+	if s.rows[table] == 0 {
+		return &ent.NotFoundError{}
+	}
+	s.rows[table]--
+	return nil
+}
+
+type fakeSpaceRepo struct {
+	repository.SpaceRepositoryInterface
+	*spaceRows
+}
+
+func (r fakeSpaceRepo) GetBySlug(_ context.Context, _ string) (*ent.Space, error) {
+	return &ent.Space{ID: "s1"}, nil
+}
+
+func (r fakeSpaceRepo) Delete(_ context.Context, _ string) error {
+	_, err := r.deleteAll("space")
+	return err
+}
+
+type fakeUserSpaceRepo struct {
+	repository.UserSpaceRepositoryInterface
+	*spaceRows
+}
+
+func (r fakeUserSpaceRepo) GetBySpaceIDs(_ context.Context, _ []string) ([]*ent.UserSpace, error) {
+	var rows []*ent.UserSpace
+	for _, id := range r.ids("memberships") {
+		rows = append(rows, &ent.UserSpace{UserID: id})
+	}
+	return rows, nil
+}
+
+func (r fakeUserSpaceRepo) DeleteAllBySpaceID(_ context.Context, _ string) (int, error) {
+	return r.deleteAll("memberships")
+}
+
+type fakeUserSpaceRoleRepo struct {
+	repository.UserSpaceRoleRepositoryInterface
+	*spaceRows
+}
+
+func (r fakeUserSpaceRoleRepo) GetBySpaceID(_ context.Context, _ string) ([]*ent.UserSpaceRole, error) {
+	var rows []*ent.UserSpaceRole
+	for i := range r.ids("role_bindings") {
+		// the first role binding belongs to a member, the others to users bound only by role
+		rows = append(rows, &ent.UserSpaceRole{UserID: fmt.Sprintf("memberships-%d", i*10)})
+	}
+	return rows, nil
+}
+
+func (r fakeUserSpaceRoleRepo) DeleteAllBySpaceID(_ context.Context, _ string) (int, error) {
+	return r.deleteAll("role_bindings")
+}
+
+type fakeSpaceOrganizationRepo struct {
+	repository.SpaceOrganizationRepositoryInterface
+	*spaceRows
+}
+
+func (r fakeSpaceOrganizationRepo) GetBySpaceID(_ context.Context, _ string) ([]*ent.SpaceOrganization, error) {
+	return make([]*ent.SpaceOrganization, r.rows["organizations"]), nil
+}
+
+func (r fakeSpaceOrganizationRepo) DeleteAllBySpaceID(_ context.Context, _ string) (int, error) {
+	return r.deleteAll("organizations")
+}
+
+type fakeSpaceMenuRepo struct {
+	repository.SpaceMenuRepositoryInterface
+	*spaceRows
+}
+
+func (r fakeSpaceMenuRepo) GetBySpaceID(_ context.Context, _ string) ([]*ent.SpaceMenu, error) {
+	return make([]*ent.SpaceMenu, r.rows["menus"]), nil
+}
+
+func (r fakeSpaceMenuRepo) DeleteAllBySpaceID(_ context.Context, _ string) (int, error) {
+	return r.deleteAll("menus")
+}
+
+type fakeSpaceDictionaryRepo struct {
+	repository.SpaceDictionaryRepositoryInterface
+	*spaceRows
+}
+
+func (r fakeSpaceDictionaryRepo) GetBySpaceID(_ context.Context, _ string) ([]*ent.SpaceDictionary, error) {
+	return make([]*ent.SpaceDictionary, r.rows["dictionaries"]), nil
+}
+
+func (r fakeSpaceDictionaryRepo) DeleteAllBySpaceID(_ context.Context, _ string) (int, error) {
+	return r.deleteAll("dictionaries")
+}
+
+type fakeSpaceOptionRepo struct {
+	repository.SpaceOptionRepositoryInterface
+	*spaceRows
+}
+
+func (r fakeSpaceOptionRepo) GetBySpaceID(_ context.Context, _ string) ([]*ent.SpaceOption, error) {
+	return make([]*ent.SpaceOption, r.rows["options"]), nil
+}
+
+func (r fakeSpaceOptionRepo) DeleteAllBySpaceID(_ context.Context, _ string) (int, error) {
+	return r.deleteAll("options")
+}
+
+type fakeSpaceSettingRepo struct {
+	repository.SpaceSettingRepositoryInterface
+	*spaceRows
+}
+
+func (r fakeSpaceSettingRepo) GetBySpaceID(_ context.Context, _ string) ([]*ent.SpaceSetting, error) {
+	var rows []*ent.SpaceSetting
+	for _, id := range r.ids("settings") {
+		rows = append(rows, &ent.SpaceSetting{ID: id})
+	}
+	return rows, nil
+}
+
+func (r fakeSpaceSettingRepo) Delete(_ context.Context, _ string) error {
+	return r.deleteOne("settings")
+}
+
+type fakeSpaceQuotaRepo struct {
+	repository.SpaceQuotaRepositoryInterface
+	*spaceRows
+}
+
+func (r fakeSpaceQuotaRepo) GetBySpaceID(_ context.Context, _ string) ([]*ent.SpaceQuota, error) {
+	var rows []*ent.SpaceQuota
+	for _, id := range r.ids("quotas") {
+		rows = append(rows, &ent.SpaceQuota{ID: id})
+	}
+	return rows, nil
+}
+
+func (r fakeSpaceQuotaRepo) Delete(_ context.Context, _ string) error {
+	return r.deleteOne("quotas")
+}
+
+type fakeSpaceBillingRepo struct {
+	repository.SpaceBillingRepositoryInterface
+	*spaceRows
+}
+
+func (r fakeSpaceBillingRepo) GetBySpaceID(_ context.Context, _ string) ([]*ent.SpaceBilling, error) {
+	var rows []*ent.SpaceBilling
+	for _, id := range r.ids("billings") {
+		rows = append(rows, &ent.SpaceBilling{ID: id})
+	}
+	return rows, nil
+}
+
+func (r fakeSpaceBillingRepo) Delete(_ context.Context, _ string) error {
+	return r.deleteOne("billings")
+}
+
+func newFakeSpaceService(rows *spaceRows) *spaceService {
+	return &spaceService{
+		space:             fakeSpaceRepo{spaceRows: rows},
+		userSpace:         fakeUserSpaceRepo{spaceRows: rows},
+		userSpaceRole:     fakeUserSpaceRoleRepo{spaceRows: rows},
+		spaceOrganization: fakeSpaceOrganizationRepo{spaceRows: rows},
+		spaceMenu:         fakeSpaceMenuRepo{spaceRows: rows},
+		spaceDictionary:   fakeSpaceDictionaryRepo{spaceRows: rows},
+		spaceOption:       fakeSpaceOptionRepo{spaceRows: rows},
+		spaceSetting:      fakeSpaceSettingRepo{spaceRows: rows},
+		spaceQuota:        fakeSpaceQuotaRepo{spaceRows: rows},
+		spaceBilling:      fakeSpaceBillingRepo{spaceRows: rows},
+	}
+}
+
+func newSpaceRows() *spaceRows {
+	return &spaceRows{rows: map[string]int{
+		"space": 1, "memberships": 3, "role_bindings": 2, "organizations": 2, "menus": 4,
+		"dictionaries": 1, "options": 5, "settings": 3, "quotas": 2, "billings": 1,
+	}}
+}
+
+func TestSpaceDeleteDryRunMatchesDeletion(t *testing.T) {
+	ctx := context.Background()
+	rows := newSpaceRows()
+	s := newFakeSpaceService(rows)
+
+	dryRun, err := s.Delete(ctx, "acme", true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if rows.rows["menus"] != 4 || rows.rows["space"] != 1 {
+		t.Fatalf("dry run deleted records: %v", rows.rows)
+	}
+	want := structs.SpaceDeletion{
+		SpaceID: "s1", Users: 4, Memberships: 3, RoleBindings: 2, Organizations: 2, Menus: 4,
+		Dictionaries: 1, Options: 5, Settings: 3, Quotas: 2, Billings: 1,
+	}
+	if !dryRun.DryRun {
+		t.Fatal("dry run not flagged")
+	}
+	dryRun.DryRun = false
+	if *dryRun != want {
+		t.Fatalf("dry run = %+v, want %+v", *dryRun, want)
+	}
+
+	plan, err := s.planDeletion(ctx, "s1")
+	if err != nil {
+		t.Fatalf("planDeletion: %v", err)
+	}
+	deleted, err := s.deleteCascade(ctx, "s1", plan)
+	if err != nil {
+		t.Fatalf("deleteCascade: %v", err)
+	}
+	if *deleted != want {
+		t.Fatalf("deleted = %+v, want %+v", *deleted, want)
+	}
+	for table, n := range rows.rows {
+		if n != 0 {
+			t.Errorf("%d %s left", n, table)
+		}
+	}
+}
+
+func TestSpaceDeleteCascadeCountsRowsRemoved(t *testing.T) {
+	ctx := context.Background()
+	rows := newSpaceRows()
+	s := newFakeSpaceService(rows)
+
+	plan, err := s.planDeletion(ctx, "s1")
+	if err != nil {
+		t.Fatalf("planDeletion: %v", err)
+	}
+	// records removed between planning and deleting are not reported
+	rows.rows["options"] = 2
+	rows.rows["settings"] = 1
+
+	deleted, err := s.deleteCascade(ctx, "s1", plan)
+	if err != nil {
+		t.Fatalf("deleteCascade: %v", err)
+	}
+	if deleted.Options != 2 || deleted.Settings != 1 {
+		t.Fatalf("deleted %d options, %d settings, want 2 and 1", deleted.Options, deleted.Settings)
+	}
+}
+
+func TestSpaceDeleteCascadeReportsFailedStep(t *testing.T) {
+	ctx := context.Background()
+	rows := newSpaceRows()
+	rows.fail = "menus"
+	s := newFakeSpaceService(rows)
+
+	plan, err := s.planDeletion(ctx, "s1")
+	if err != nil {
+		t.Fatalf("planDeletion: %v", err)
+	}
+	deleted, err := s.deleteCascade(ctx, "s1", plan)
+	if err == nil || !strings.Contains(err.Error(), "menus") {
+		t.Fatalf("err = %v, want the failed menus step", err)
+	}
+	if deleted != nil {
+		t.Fatalf("partial summary %+v returned with the error", *deleted)
+	}
+	if rows.rows["dictionaries"] != 1 {
+		t.Fatal("cascade continued past the failed step")
+	}
+}
+
+// listedSpaces serves n spaces, recording the limit each list asks for
+type listedSpaces struct {
+	repository.SpaceRepositoryInterface
+	n      int
+	limits []int
+}
+
+func (r *listedSpaces) List(_ context.Context, params *structs.ListSpaceParams) ([]*ent.Space, error) {
+	r.limits = append(r.limits, params.Limit)
+	rows := make([]*ent.Space, 0, params.Limit)
+	for i := 0; i < r.n && i < params.Limit; i++ {
+		rows = append(rows, &ent.Space{ID: fmt.Sprintf("s%02d", i)})
+	}
+	return rows, nil
+}
+
+func (r *listedSpaces) CountX(_ context.Context, _ *structs.ListSpaceParams) int {
+	return r.n
+}
+
+func TestListAppliesPageSizes(t *testing.T) {
+	spaces := &listedSpaces{n: 30}
+	s := &spaceService{space: spaces}
+
+	result, err := s.List(context.Background(), &structs.ListSpaceParams{})
+	if err != nil || len(result.Items) != 20 || !result.HasNext {
+		t.Fatalf("List without a limit = %d items, %v, want the default of 20", len(result.Items), err)
+	}
+	if _, err := s.List(context.Background(), &structs.ListSpaceParams{Limit: 101}); !errors.Is(err, page.ErrInvalidLimit) {
+		t.Fatalf("List with limit 101 = %v, want ErrInvalidLimit", err)
+	}
+	if len(spaces.limits) != 1 {
+		t.Fatalf("%d queries, want none for the rejected limit", len(spaces.limits))
+	}
+}
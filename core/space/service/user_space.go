@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"ncobase/core/space/data"
 	"ncobase/core/space/data/repository"
 	"ncobase/core/space/structs"
+	"ncobase/internal/utils"
 
 	"github.com/ncobase/ncore/ecode"
 )
@@ -17,19 +20,25 @@ type UserSpaceServiceInterface interface {
 	AddUserToSpace(ctx context.Context, u, t string) (*structs.UserSpace, error)
 	RemoveUserFromSpace(ctx context.Context, u, t string) error
 	IsSpaceInUser(ctx context.Context, t, u string) (bool, error)
+	ExportUserData(ctx context.Context, uid string, w io.Writer) error
+	EraseUserData(ctx context.Context, uid string, anonymize bool) (deleted, anonymized int, err error)
 }
 
 // userSpaceService is the struct for the service.
 type userSpaceService struct {
-	ts        SpaceServiceInterface
-	userSpace repository.UserSpaceRepositoryInterface
+	d             *data.Data
+	ts            SpaceServiceInterface
+	userSpace     repository.UserSpaceRepositoryInterface
+	userSpaceRole repository.UserSpaceRoleRepositoryInterface
 }
 
 // NewUserSpaceService creates a new service.
 func NewUserSpaceService(d *data.Data, ts SpaceServiceInterface) UserSpaceServiceInterface {
 	return &userSpaceService{
-		ts:        ts,
-		userSpace: repository.NewUserSpaceRepository(d),
+		d:             d,
+		ts:            ts,
+		userSpace:     repository.NewUserSpaceRepository(d),
+		userSpaceRole: repository.NewUserSpaceRoleRepository(d),
 	}
 }
 
@@ -119,3 +128,67 @@ func (s *userSpaceService) IsSpaceInUser(ctx context.Context, t, u string) (bool
 	}
 	return isValid, nil
 }
+
+// ExportUserData writes the spaces a user belongs to or created to w as JSON lines.
+// Space extras are left out, they hold settings of the space rather than data of the user.
+func (s *userSpaceService) ExportUserData(ctx context.Context, uid string, w io.Writer) error {
+	spaces, err := s.UserBelongSpaces(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if created, err := s.ts.GetByUser(ctx, uid); err == nil {
+		spaces = append(spaces, created)
+	}
+
+	enc := json.NewEncoder(w)
+	seen := make(map[string]bool, len(spaces))
+	for _, space := range spaces {
+		if space == nil || seen[space.ID] {
+			continue
+		}
+		seen[space.ID] = true
+
+		record := *space
+		record.Extras = nil
+		if err := enc.Encode(&record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EraseUserData removes a user from all spaces with their space roles.
+// Memberships are always deleted, there is nothing left to anonymize once the user is gone;
+// spaces the user created stay with their members.
+func (s *userSpaceService) EraseUserData(ctx context.Context, uid string, _ bool) (deleted, anonymized int, err error) {
+	if uid == "" {
+		return 0, 0, errors.New(ecode.FieldIsInvalid("User ID"))
+	}
+
+	err = utils.WithTxRetry(ctx, s.d.Data, func(ctx context.Context) error {
+		deleted = 0
+
+		roles, err := s.userSpaceRole.CountByUserID(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if err := s.userSpaceRole.DeleteAllByUserID(ctx, uid); err != nil {
+			return err
+		}
+		deleted += roles
+
+		memberships, err := s.userSpace.CountByUserID(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if err := s.userSpace.DeleteAllByUserID(ctx, uid); err != nil {
+			return err
+		}
+		deleted += memberships
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return deleted, 0, nil
+}
@@ -8,6 +8,7 @@ import (
 	"ncobase/core/space/structs"
 	"ncobase/core/space/wrapper"
 	userStructs "ncobase/core/user/structs"
+	"ncobase/internal/utils"
 	"sort"
 	"strings"
 
@@ -30,6 +31,7 @@ type UserSpaceRoleServiceInterface interface {
 
 // userSpaceRoleService is the struct for the service.
 type userSpaceRoleService struct {
+	d             *data.Data
 	userSpaceRole repository.UserSpaceRoleRepositoryInterface
 	userSpace     repository.UserSpaceRepositoryInterface
 	usw           *wrapper.UserServiceWrapper
@@ -38,6 +40,7 @@ type userSpaceRoleService struct {
 // NewUserSpaceRoleService creates a new service.
 func NewUserSpaceRoleService(d *data.Data, usw *wrapper.UserServiceWrapper) UserSpaceRoleServiceInterface {
 	return &userSpaceRoleService{
+		d:             d,
 		userSpaceRole: repository.NewUserSpaceRoleRepository(d),
 		userSpace:     repository.NewUserSpaceRepository(d),
 		usw:           usw,
@@ -148,6 +151,18 @@ func (s *userSpaceRoleService) ListSpaceUsers(ctx context.Context, spaceID strin
 		}
 	}
 
+	// Load all users in one query instead of one per row
+	if s.usw != nil && s.usw.HasUserService() && len(userRolesMap) > 0 {
+		ctx = s.usw.WithUserLoader(ctx)
+		userIDs := make([]string, 0, len(userRolesMap))
+		for userID := range userRolesMap {
+			userIDs = append(userIDs, userID)
+		}
+		if _, err := s.usw.LoadUsers(ctx, userIDs); err != nil {
+			logger.Warnf(ctx, "Failed to batch load space users: %v", err)
+		}
+	}
+
 	// Convert to response format with user info
 	var users []structs.SpaceUserInfo
 	for userID, roleIDs := range userRolesMap {
@@ -167,7 +182,7 @@ func (s *userSpaceRoleService) ListSpaceUsers(ctx context.Context, spaceID strin
 
 		// Try to enrich user details
 		if s.usw != nil && s.usw.HasUserService() {
-			if user, err := s.usw.GetUserByID(ctx, userID); err == nil && user != nil {
+			if user, err := s.usw.LoadUser(ctx, userID); err == nil && user != nil {
 				userInfo.Username = user.Username
 				userInfo.Email = user.Email
 				userInfo.IsActive = user.Status == 0
@@ -306,16 +321,23 @@ func (s *userSpaceRoleService) ListSpaceRoleIDs(ctx context.Context, spaceID str
 
 // UpdateUserSpaceRole updates a user's role in a space.
 func (s *userSpaceRoleService) UpdateUserSpaceRole(ctx context.Context, userID, spaceID string, req *structs.UpdateUserSpaceRoleRequest) (*structs.UserSpaceRoleResponse, error) {
-	// Remove old role
-	if err := s.userSpaceRole.DeleteByUserIDAndSpaceIDAndRoleID(ctx, userID, spaceID, req.OldRoleID); err != nil {
-		logger.Warnf(ctx, "Failed to remove old role %s for user %s in space %s: %v", req.OldRoleID, userID, spaceID, err)
-	}
+	// Swap the roles in one transaction, retried on conflicts with concurrent assignments
+	err := utils.WithTxRetry(ctx, s.d.Data, func(ctx context.Context) error {
+		// Remove old role
+		if err := s.userSpaceRole.DeleteByUserIDAndSpaceIDAndRoleID(ctx, userID, spaceID, req.OldRoleID); err != nil {
+			if utils.IsRetriableTxError(err) {
+				return err
+			}
+			logger.Warnf(ctx, "Failed to remove old role %s for user %s in space %s: %v", req.OldRoleID, userID, spaceID, err)
+		}
 
-	// Add new role
-	_, err := s.userSpaceRole.Create(ctx, &structs.UserSpaceRole{
-		UserID:  userID,
-		SpaceID: spaceID,
-		RoleID:  req.NewRoleID,
+		// Add new role
+		_, err := s.userSpaceRole.Create(ctx, &structs.UserSpaceRole{
+			UserID:  userID,
+			SpaceID: spaceID,
+			RoleID:  req.NewRoleID,
+		})
+		return err
 	})
 	if err := handleEntError(ctx, "UserSpaceRole", err); err != nil {
 		return nil, err
@@ -117,6 +117,7 @@ func (m *Module) RegisterRoutes(r *gin.RouterGroup) {
 		// Basic space management
 		spaces.GET("", m.h.Space.List)
 		spaces.POST("", m.h.Space.Create)
+		spaces.POST("/import", m.h.Space.Import)
 		spaces.GET("/:spaceId", m.h.Space.Get)
 		spaces.PUT("/:spaceId", m.h.Space.Update)
 		spaces.DELETE("/:spaceId", m.h.Space.Delete)
@@ -0,0 +1,40 @@
+package structs
+
+// Membership issue kinds found by the membership check
+const (
+	IssueDuplicateMembership   = "duplicate_membership"    // user_space row repeating an earlier one
+	IssueDuplicateRoleBinding  = "duplicate_role_binding"  // user_space_role row repeating an earlier one
+	IssueOrphanedMembership    = "orphaned_membership"     // user_space row of a deleted user or space
+	IssueOrphanedRoleBinding   = "orphaned_role_binding"   // user_space_role row of a deleted user, space or role
+	IssueRoleWithoutMembership = "role_without_membership" // space role of a user who is not a member of the space
+	IssueMemberWithoutRole     = "member_without_role"     // space member holding no role in the space
+	IssueAdminWithoutGlobal    = "admin_without_global"    // admin role in a space without the global binding
+)
+
+// MembershipCheckOptions for checking and repairing user space memberships
+type MembershipCheckOptions struct {
+	DryRun bool `json:"dry_run"`
+	// DefaultRoleID is given to members without a role in their space,
+	// they are only reported when empty
+	DefaultRoleID string `json:"default_role_id,omitempty"`
+}
+
+// MembershipIssue is an inconsistent membership or space role binding
+type MembershipIssue struct {
+	Kind    string `json:"kind"`
+	ID      string `json:"id,omitempty"` // row at fault, empty when a row is missing
+	UserID  string `json:"user_id"`
+	SpaceID string `json:"space_id"`
+	RoleID  string `json:"role_id,omitempty"`
+	Fixed   bool   `json:"fixed"`
+}
+
+// MembershipReport describes the drift between user spaces, space roles, users and roles
+type MembershipReport struct {
+	DryRun       bool               `json:"dry_run"`
+	Memberships  int                `json:"memberships"`   // user_space rows scanned
+	RoleBindings int                `json:"role_bindings"` // user_space_role rows scanned
+	Issues       []*MembershipIssue `json:"issues,omitempty"`
+	Fixed        int                `json:"fixed"`
+	Errors       []string           `json:"errors,omitempty"`
+}
@@ -2,6 +2,9 @@ package structs
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"strings"
 
 	"github.com/ncobase/ncore/types"
 	"github.com/ncobase/ncore/utils/convert"
@@ -59,6 +62,7 @@ type ReadSpace struct {
 	CreatedAt   *int64      `json:"created_at,omitempty"`
 	UpdatedBy   *string     `json:"updated_by,omitempty"`
 	UpdatedAt   *int64      `json:"updated_at,omitempty"`
+	Locale      string      `json:"locale,omitempty"` // Locale of title, description and copyright
 }
 
 // GetCursorValue returns the cursor value.
@@ -78,4 +82,30 @@ type ListSpaceParams struct {
 	Limit     int    `form:"limit,omitempty" json:"limit,omitempty"`
 	Direction string `form:"direction,omitempty" json:"direction,omitempty"`
 	User      string `form:"user,omitempty" json:"user,omitempty"`
+	Locale    string `form:"locale,omitempty" json:"locale,omitempty"`
+}
+
+// SpaceHost returns the lower cased host name of a space URL or a Host header,
+// without scheme, port or path. It returns "" when raw has no host.
+func SpaceHost(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	// X-Forwarded-Host may carry a list, the first entry is the client facing host
+	if i := strings.IndexByte(raw, ','); i >= 0 {
+		raw = strings.TrimSpace(raw[:i])
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "//" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	host := u.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
 }
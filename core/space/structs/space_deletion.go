@@ -0,0 +1,23 @@
+package structs
+
+// DeleteSpaceParams represents the query parameters for deleting a space.
+type DeleteSpaceParams struct {
+	DryRun bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+}
+
+// SpaceDeletion reports what deleting a space removes.
+// For a dry run nothing is deleted and the counts are what a real run would remove.
+type SpaceDeletion struct {
+	SpaceID       string `json:"space_id"`
+	DryRun        bool   `json:"dry_run"`
+	Users         int    `json:"users"`         // Users losing their membership or roles in the space
+	Memberships   int    `json:"memberships"`   // User space relations
+	RoleBindings  int    `json:"role_bindings"` // User roles in the space
+	Organizations int    `json:"organizations"` // Organizations (groups) linked to the space
+	Menus         int    `json:"menus"`
+	Dictionaries  int    `json:"dictionaries"`
+	Options       int    `json:"options"`
+	Settings      int    `json:"settings"`
+	Quotas        int    `json:"quotas"`
+	Billings      int    `json:"billings"`
+}
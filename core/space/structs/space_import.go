@@ -0,0 +1,62 @@
+package structs
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ncobase/ncore/ecode"
+)
+
+// SpaceImportColumns are the CSV columns a space import reads, matched by header name
+var SpaceImportColumns = []string{"name", "title", "url", "keywords", "created_by"}
+
+// DefaultSpaceImportBatchSize is the number of spaces created per transaction
+const DefaultSpaceImportBatchSize = 100
+
+// Space import row statuses
+const (
+	SpaceImportCreated = "created"
+	SpaceImportSkipped = "skipped"
+	SpaceImportError   = "error"
+)
+
+// SpaceImportRow is the outcome of one CSV row
+type SpaceImportRow struct {
+	Line   int    `json:"line"` // CSV line, the header is line 1
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`    // Created space
+	Error  string `json:"error,omitempty"` // Why the row was skipped or failed
+}
+
+// SpaceImportResult reports a space import
+type SpaceImportResult struct {
+	Created int               `json:"created"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []*SpaceImportRow `json:"rows"`
+}
+
+// Add records a row outcome
+func (r *SpaceImportResult) Add(row *SpaceImportRow) {
+	switch row.Status {
+	case SpaceImportCreated:
+		r.Created++
+	case SpaceImportSkipped:
+		r.Skipped++
+	default:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+// Validate checks the fields a new space requires
+func (b *CreateSpaceBody) Validate() error {
+	if strings.TrimSpace(b.Name) == "" {
+		return errors.New(ecode.FieldIsRequired("name"))
+	}
+	if b.URL != "" && SpaceHost(b.URL) == "" {
+		return errors.New(ecode.FieldIsInvalid("url"))
+	}
+	return nil
+}
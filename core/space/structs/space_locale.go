@@ -0,0 +1,111 @@
+package structs
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	// SpaceTranslationsKey is the extras key holding the translations of a space, keyed by locale,
+	// e.g. {"i18n": {"zh": {"title": "..."}}}
+	SpaceTranslationsKey = "i18n"
+	// SpaceDefaultLocaleKey is the extras key naming the locale of the untranslated title,
+	// description and copyright
+	SpaceDefaultLocaleKey = "default_locale"
+)
+
+// SpaceTranslation holds the localized strings of a space in one locale
+type SpaceTranslation struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Copyright   string `json:"copyright,omitempty"`
+}
+
+// Translations returns the translations stored in the extras of the space
+func (r *ReadSpace) Translations() map[string]SpaceTranslation {
+	if r == nil || r.Extras == nil {
+		return nil
+	}
+	raw, ok := (*r.Extras)[SpaceTranslationsKey]
+	if !ok || raw == nil {
+		return nil
+	}
+
+	// Extras decoded from the database are generic maps, convert them through JSON
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var translations map[string]SpaceTranslation
+	if err := json.Unmarshal(b, &translations); err != nil {
+		return nil
+	}
+
+	normalized := make(map[string]SpaceTranslation, len(translations))
+	for locale, t := range translations {
+		normalized[NormalizeLocale(locale)] = t
+	}
+	return normalized
+}
+
+// DefaultLocale returns the locale of the untranslated strings, empty when not set
+func (r *ReadSpace) DefaultLocale() string {
+	if r == nil || r.Extras == nil {
+		return ""
+	}
+	locale, _ := (*r.Extras)[SpaceDefaultLocaleKey].(string)
+	return NormalizeLocale(locale)
+}
+
+// Localize replaces the title, description and copyright with their translation in locale.
+// A missing locale falls back to its base language (zh-CN to zh), then to the default
+// locale of the space; fields without a translation keep the untranslated value.
+// Locale is set to the locale of the strings served.
+func (r *ReadSpace) Localize(locale string) {
+	if r == nil {
+		return
+	}
+
+	defaultLocale := r.DefaultLocale()
+	r.Locale = defaultLocale
+
+	locale = NormalizeLocale(locale)
+	if locale == "" {
+		return
+	}
+
+	translations := r.Translations()
+	for _, candidate := range []string{locale, baseLanguage(locale), defaultLocale} {
+		if candidate == "" {
+			continue
+		}
+		t, ok := translations[candidate]
+		if !ok {
+			continue
+		}
+		if t.Title != "" {
+			r.Title = t.Title
+		}
+		if t.Description != "" {
+			r.Description = t.Description
+		}
+		if t.Copyright != "" {
+			r.Copyright = t.Copyright
+		}
+		r.Locale = candidate
+		return
+	}
+}
+
+// NormalizeLocale returns locale in the form "zh-cn", lower cased with hyphens
+func NormalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// baseLanguage returns the language of a regional locale, empty when locale has no region
+func baseLanguage(locale string) string {
+	if i := strings.IndexByte(locale, '-'); i > 0 {
+		return locale[:i]
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/ncobase/ncore/types"
+)
+
+func newLocalizedSpace() *ReadSpace {
+	extras := types.JSON{
+		SpaceDefaultLocaleKey: "en",
+		SpaceTranslationsKey: map[string]any{
+			"zh_CN": map[string]any{"title": "示例空间", "description": "示例描述"},
+			"en":    map[string]any{"copyright": "Acme Ltd."},
+		},
+	}
+	return &ReadSpace{Title: "Acme", Description: "An example", Copyright: "Acme", Extras: &extras}
+}
+
+func TestLocalizeSpace(t *testing.T) {
+	for _, tc := range []struct {
+		locale, title, description, copyright, served string
+	}{
+		{"zh-CN", "示例空间", "示例描述", "Acme", "zh-cn"},
+		{"en", "Acme", "An example", "Acme Ltd.", "en"},
+		// a missing locale falls back to the default one
+		{"fr", "Acme", "An example", "Acme Ltd.", "en"},
+		{"", "Acme", "An example", "Acme", "en"},
+	} {
+		r := newLocalizedSpace()
+		r.Localize(tc.locale)
+		if r.Title != tc.title || r.Description != tc.description || r.Copyright != tc.copyright || r.Locale != tc.served {
+			t.Errorf("locale %q served %q/%q/%q in %q", tc.locale, r.Title, r.Description, r.Copyright, r.Locale)
+		}
+	}
+}
+
+func TestLocalizeFallsBackToBaseLanguage(t *testing.T) {
+	extras := types.JSON{SpaceTranslationsKey: map[string]any{"zh": map[string]any{"title": "示例空间"}}}
+	r := &ReadSpace{Title: "Acme", Extras: &extras}
+	r.Localize("zh_TW")
+	if r.Title != "示例空间" || r.Locale != "zh" {
+		t.Fatalf("zh-TW served %q in %q, want the zh title", r.Title, r.Locale)
+	}
+
+	// without translations or a default locale the untranslated title stays
+	r = &ReadSpace{Title: "Acme"}
+	r.Localize("zh")
+	if r.Title != "Acme" || r.Locale != "" {
+		t.Fatalf("untranslated space served %q in %q", r.Title, r.Locale)
+	}
+}
@@ -1,7 +1,11 @@
 package structs
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/ncobase/ncore/types"
 	"github.com/ncobase/ncore/utils/convert"
@@ -128,3 +132,121 @@ type ListSpaceSettingParams struct {
 	Limit      int          `form:"limit,omitempty" json:"limit,omitempty"`
 	Direction  string       `form:"direction,omitempty" json:"direction,omitempty"`
 }
+
+// CustomSettingPrefix namespaces the settings clients define for themselves.
+// Any key under it is accepted and typed by its first value.
+const CustomSettingPrefix = "custom."
+
+// SettingDefinition describes a setting key the platform knows
+type SettingDefinition struct {
+	Type    SettingType
+	Options []string // allowed values of a string setting, any value when empty
+}
+
+// knownSettings are the setting keys read by the platform and seeded with spaces
+var knownSettings = map[string]SettingDefinition{
+	"company_name":                  {Type: TypeString},
+	"site_name":                     {Type: TypeString},
+	"theme_color":                   {Type: TypeString},
+	"max_file_size":                 {Type: TypeNumber},
+	"enable_sso":                    {Type: TypeBoolean},
+	"enable_notifications":          {Type: TypeBoolean},
+	"enable_comments":               {Type: TypeBoolean},
+	"departments":                   {Type: TypeArray},
+	"compliance_settings":           {Type: TypeJSON},
+	"working_hours":                 {Type: TypeJSON},
+	"resource.default_access_level": {Type: TypeString, Options: []string{"public", "private", "shared"}},
+	"resource.access_sample_rate":   {Type: TypeNumber},
+	"menu.customization":            {Type: TypeJSON},
+}
+
+// LookupSetting returns the definition of a known setting key
+func LookupSetting(key string) (SettingDefinition, bool) {
+	def, ok := knownSettings[key]
+	return def, ok
+}
+
+// IsCustomSettingKey reports whether key is a valid key in the custom namespace
+func IsCustomSettingKey(key string) bool {
+	name, ok := strings.CutPrefix(key, CustomSettingPrefix)
+	if !ok || name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// InferSettingType returns the setting type of a value decoded from JSON
+func InferSettingType(value any) SettingType {
+	switch value.(type) {
+	case bool:
+		return TypeBoolean
+	case float64, float32, int, int32, int64, json.Number:
+		return TypeNumber
+	case map[string]any:
+		return TypeJSON
+	case []any:
+		return TypeArray
+	default:
+		return TypeString
+	}
+}
+
+// FormatSettingValue checks value has the setting type t and returns its stored form.
+// Values of non-string types may also be given in their stored form, "true" or "200" for example.
+func FormatSettingValue(t SettingType, value any) (string, error) {
+	if s, ok := value.(string); ok && t != TypeString {
+		var decoded any
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			return "", fmt.Errorf("value is not a valid %s", t)
+		}
+		value = decoded
+	}
+
+	switch t {
+	case TypeString:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case TypeBoolean:
+		if b, ok := value.(bool); ok {
+			return strconv.FormatBool(b), nil
+		}
+	case TypeNumber:
+		switch n := value.(type) {
+		case float64:
+			return strconv.FormatFloat(n, 'f', -1, 64), nil
+		case float32, int, int32, int64, json.Number:
+			return fmt.Sprint(n), nil
+		}
+	case TypeJSON:
+		if m, ok := value.(map[string]any); ok {
+			raw, err := json.Marshal(m)
+			return string(raw), err
+		}
+	case TypeArray:
+		if a, ok := value.([]any); ok {
+			raw, err := json.Marshal(a)
+			return string(raw), err
+		}
+	default:
+		return "", fmt.Errorf("unknown setting type %s", t)
+	}
+	return "", fmt.Errorf("value is not a valid %s", t)
+}
+
+// Format checks value against the definition and returns its stored form
+func (d SettingDefinition) Format(value any) (string, error) {
+	raw, err := FormatSettingValue(d.Type, value)
+	if err != nil {
+		return "", err
+	}
+	if len(d.Options) > 0 && !slices.Contains(d.Options, raw) {
+		return "", fmt.Errorf("value must be one of %s", strings.Join(d.Options, ", "))
+	}
+	return raw, nil
+}
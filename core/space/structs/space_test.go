@@ -0,0 +1,19 @@
+package structs
+
+import "testing"
+
+func TestSpaceHost(t *testing.T) {
+	for raw, want := range map[string]string{
+		"https://Acme.Example.com/portal": "acme.example.com",
+		"acme.example.com:8443":           "acme.example.com",
+		"acme.example.com.":               "acme.example.com",
+		"acme.example.com, proxy.local":   "acme.example.com",
+		"http://[::1]:8080":               "::1",
+		" ":                               "",
+		"":                                "",
+	} {
+		if got := SpaceHost(raw); got != want {
+			t.Errorf("SpaceHost(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
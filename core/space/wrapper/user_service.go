@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	userStructs "ncobase/core/user/structs"
+	"ncobase/internal/utils"
 
 	ext "github.com/ncobase/ncore/extension/types"
 )
@@ -49,6 +50,70 @@ func (w *UserServiceWrapper) GetUserByID(ctx context.Context, id string) (*userS
 	return nil, fmt.Errorf("user service not available")
 }
 
+// GetUsersByIDs gets users by IDs in a single call when the user service supports it
+func (w *UserServiceWrapper) GetUsersByIDs(ctx context.Context, ids []string) (map[string]*userStructs.ReadUser, error) {
+	if w.userService == nil {
+		return nil, fmt.Errorf("user service not available")
+	}
+
+	result := make(map[string]*userStructs.ReadUser, len(ids))
+	if batch, ok := w.userService.(interface {
+		GetByIDs(ctx context.Context, ids []string) ([]*userStructs.ReadUser, error)
+	}); ok {
+		users, err := batch.GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			result[user.ID] = user
+		}
+		return result, nil
+	}
+
+	// Fallback to single lookups
+	for _, id := range ids {
+		if user, err := w.userService.GetByID(ctx, id); err == nil && user != nil {
+			result[id] = user
+		}
+	}
+	return result, nil
+}
+
+// userLoaderName is the name of the request scoped user loader
+const userLoaderName = "space.users"
+
+// WithUserLoader attaches a request scoped user loader to ctx.
+// Lookups through LoadUser on the returned context are batched and memoized.
+func (w *UserServiceWrapper) WithUserLoader(ctx context.Context) context.Context {
+	if _, ok := utils.GetBatchLoader[*userStructs.ReadUser](ctx, userLoaderName); ok {
+		return ctx
+	}
+	return utils.WithBatchLoader(ctx, userLoaderName, utils.NewBatchLoader(w.GetUsersByIDs))
+}
+
+// LoadUsers primes the request scoped loader with ids and returns the users found
+func (w *UserServiceWrapper) LoadUsers(ctx context.Context, ids []string) (map[string]*userStructs.ReadUser, error) {
+	if loader, ok := utils.GetBatchLoader[*userStructs.ReadUser](ctx, userLoaderName); ok {
+		return loader.LoadMany(ctx, ids)
+	}
+	return w.GetUsersByIDs(ctx, ids)
+}
+
+// LoadUser gets a user through the request scoped loader, falling back to a direct lookup
+func (w *UserServiceWrapper) LoadUser(ctx context.Context, id string) (*userStructs.ReadUser, error) {
+	if loader, ok := utils.GetBatchLoader[*userStructs.ReadUser](ctx, userLoaderName); ok {
+		user, found, err := loader.Load(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("user %s not found", id)
+		}
+		return user, nil
+	}
+	return w.GetUserByID(ctx, id)
+}
+
 // FindUser finds user
 func (w *UserServiceWrapper) FindUser(ctx context.Context, m *userStructs.FindUser) (*userStructs.ReadUser, error) {
 	if w.userService != nil {
@@ -0,0 +1,97 @@
+package wrapper
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	userStructs "ncobase/core/user/structs"
+)
+
+// countingUsers serves users from memory, counting batch and single lookups
+type countingUsers struct {
+	UserServiceInterface
+	users   map[string]*userStructs.ReadUser
+	batches int
+	singles int
+}
+
+func (u *countingUsers) GetByID(_ context.Context, id string) (*userStructs.ReadUser, error) {
+	u.singles++
+	if user, ok := u.users[id]; ok {
+		return user, nil
+	}
+	return nil, fmt.Errorf("user %s not found", id)
+}
+
+func (u *countingUsers) GetByIDs(_ context.Context, ids []string) ([]*userStructs.ReadUser, error) {
+	u.batches++
+	var users []*userStructs.ReadUser
+	for _, id := range ids {
+		if user, ok := u.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func newCountingUsers(n int) *countingUsers {
+	u := &countingUsers{users: map[string]*userStructs.ReadUser{}}
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("u%d", i)
+		u.users[id] = &userStructs.ReadUser{ID: id, Username: "user-" + id}
+	}
+	return u
+}
+
+func TestLoadUserBatchesThroughRequestLoader(t *testing.T) {
+	users := newCountingUsers(20)
+	w := &UserServiceWrapper{userService: users}
+	ctx := w.WithUserLoader(context.Background())
+	if w.WithUserLoader(ctx) != ctx {
+		t.Fatal("second loader attached to the same request")
+	}
+
+	ids := make([]string, 0, 20)
+	for id := range users.users {
+		ids = append(ids, id)
+	}
+	if _, err := w.LoadUsers(ctx, ids); err != nil {
+		t.Fatalf("LoadUsers: %v", err)
+	}
+	for _, id := range ids {
+		user, err := w.LoadUser(ctx, id)
+		if err != nil || user.ID != id {
+			t.Fatalf("LoadUser(%s) = %v, %v", id, user, err)
+		}
+	}
+	if users.batches != 1 || users.singles != 0 {
+		t.Fatalf("%d batch and %d single lookups for 20 users, want one batch", users.batches, users.singles)
+	}
+
+	// a missing user is looked up once and reported as not found
+	for i := 0; i < 2; i++ {
+		if _, err := w.LoadUser(ctx, "ghost"); err == nil {
+			t.Fatal("missing user found")
+		}
+	}
+	if users.batches != 2 {
+		t.Fatalf("%d batch lookups after a missing user, want 2", users.batches)
+	}
+}
+
+func TestLoadUserWithoutLoader(t *testing.T) {
+	users := newCountingUsers(2)
+	w := &UserServiceWrapper{userService: users}
+
+	if user, err := w.LoadUser(context.Background(), "u1"); err != nil || user.ID != "u1" {
+		t.Fatalf("LoadUser = %v, %v", user, err)
+	}
+	if users.singles != 1 || users.batches != 0 {
+		t.Fatalf("%d single and %d batch lookups, want a direct lookup", users.singles, users.batches)
+	}
+
+	if _, err := (&UserServiceWrapper{}).LoadUsers(context.Background(), []string{"u1"}); err == nil {
+		t.Fatal("lookup without a user service succeeded")
+	}
+}
@@ -0,0 +1,669 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/core/system/data/ent/emailtemplate"
+	"ncobase/core/system/data/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// EmailTemplateUpdate is the builder for updating EmailTemplate entities.
+type EmailTemplateUpdate struct {
+	config
+	hooks    []Hook
+	mutation *EmailTemplateMutation
+}
+
+// Where appends a list predicates to the EmailTemplateUpdate builder.
+func (_u *EmailTemplateUpdate) Where(ps ...predicate.EmailTemplate) *EmailTemplateUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *EmailTemplateUpdate) SetName(v string) *EmailTemplateUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *EmailTemplateUpdate) SetNillableName(v *string) *EmailTemplateUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// ClearName clears the value of the "name" field.
+func (_u *EmailTemplateUpdate) ClearName() *EmailTemplateUpdate {
+	_u.mutation.ClearName()
+	return _u
+}
+
+// SetSpaceID sets the "space_id" field.
+func (_u *EmailTemplateUpdate) SetSpaceID(v string) *EmailTemplateUpdate {
+	_u.mutation.SetSpaceID(v)
+	return _u
+}
+
+// SetNillableSpaceID sets the "space_id" field if the given value is not nil.
+func (_u *EmailTemplateUpdate) SetNillableSpaceID(v *string) *EmailTemplateUpdate {
+	if v != nil {
+		_u.SetSpaceID(*v)
+	}
+	return _u
+}
+
+// ClearSpaceID clears the value of the "space_id" field.
+func (_u *EmailTemplateUpdate) ClearSpaceID() *EmailTemplateUpdate {
+	_u.mutation.ClearSpaceID()
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *EmailTemplateUpdate) SetDescription(v string) *EmailTemplateUpdate {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *EmailTemplateUpdate) SetNillableDescription(v *string) *EmailTemplateUpdate {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *EmailTemplateUpdate) ClearDescription() *EmailTemplateUpdate {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetDisabled sets the "disabled" field.
+func (_u *EmailTemplateUpdate) SetDisabled(v bool) *EmailTemplateUpdate {
+	_u.mutation.SetDisabled(v)
+	return _u
+}
+
+// SetNillableDisabled sets the "disabled" field if the given value is not nil.
+func (_u *EmailTemplateUpdate) SetNillableDisabled(v *bool) *EmailTemplateUpdate {
+	if v != nil {
+		_u.SetDisabled(*v)
+	}
+	return _u
+}
+
+// ClearDisabled clears the value of the "disabled" field.
+func (_u *EmailTemplateUpdate) ClearDisabled() *EmailTemplateUpdate {
+	_u.mutation.ClearDisabled()
+	return _u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *EmailTemplateUpdate) SetCreatedBy(v string) *EmailTemplateUpdate {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *EmailTemplateUpdate) SetNillableCreatedBy(v *string) *EmailTemplateUpdate {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *EmailTemplateUpdate) ClearCreatedBy() *EmailTemplateUpdate {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_u *EmailTemplateUpdate) SetUpdatedBy(v string) *EmailTemplateUpdate {
+	_u.mutation.SetUpdatedBy(v)
+	return _u
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_u *EmailTemplateUpdate) SetNillableUpdatedBy(v *string) *EmailTemplateUpdate {
+	if v != nil {
+		_u.SetUpdatedBy(*v)
+	}
+	return _u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (_u *EmailTemplateUpdate) ClearUpdatedBy() *EmailTemplateUpdate {
+	_u.mutation.ClearUpdatedBy()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *EmailTemplateUpdate) SetUpdatedAt(v int64) *EmailTemplateUpdate {
+	_u.mutation.ResetUpdatedAt()
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddUpdatedAt adds value to the "updated_at" field.
+func (_u *EmailTemplateUpdate) AddUpdatedAt(v int64) *EmailTemplateUpdate {
+	_u.mutation.AddUpdatedAt(v)
+	return _u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (_u *EmailTemplateUpdate) ClearUpdatedAt() *EmailTemplateUpdate {
+	_u.mutation.ClearUpdatedAt()
+	return _u
+}
+
+// SetSubject sets the "subject" field.
+func (_u *EmailTemplateUpdate) SetSubject(v string) *EmailTemplateUpdate {
+	_u.mutation.SetSubject(v)
+	return _u
+}
+
+// SetNillableSubject sets the "subject" field if the given value is not nil.
+func (_u *EmailTemplateUpdate) SetNillableSubject(v *string) *EmailTemplateUpdate {
+	if v != nil {
+		_u.SetSubject(*v)
+	}
+	return _u
+}
+
+// SetBody sets the "body" field.
+func (_u *EmailTemplateUpdate) SetBody(v string) *EmailTemplateUpdate {
+	_u.mutation.SetBody(v)
+	return _u
+}
+
+// SetNillableBody sets the "body" field if the given value is not nil.
+func (_u *EmailTemplateUpdate) SetNillableBody(v *string) *EmailTemplateUpdate {
+	if v != nil {
+		_u.SetBody(*v)
+	}
+	return _u
+}
+
+// SetHTML sets the "html" field.
+func (_u *EmailTemplateUpdate) SetHTML(v bool) *EmailTemplateUpdate {
+	_u.mutation.SetHTML(v)
+	return _u
+}
+
+// SetNillableHTML sets the "html" field if the given value is not nil.
+func (_u *EmailTemplateUpdate) SetNillableHTML(v *bool) *EmailTemplateUpdate {
+	if v != nil {
+		_u.SetHTML(*v)
+	}
+	return _u
+}
+
+// Mutation returns the EmailTemplateMutation object of the builder.
+func (_u *EmailTemplateUpdate) Mutation() *EmailTemplateMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *EmailTemplateUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *EmailTemplateUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *EmailTemplateUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *EmailTemplateUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *EmailTemplateUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok && !_u.mutation.UpdatedAtCleared() {
+		v := emailtemplate.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *EmailTemplateUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(emailtemplate.Table, emailtemplate.Columns, sqlgraph.NewFieldSpec(emailtemplate.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(emailtemplate.FieldName, field.TypeString, value)
+	}
+	if _u.mutation.NameCleared() {
+		_spec.ClearField(emailtemplate.FieldName, field.TypeString)
+	}
+	if value, ok := _u.mutation.SpaceID(); ok {
+		_spec.SetField(emailtemplate.FieldSpaceID, field.TypeString, value)
+	}
+	if _u.mutation.SpaceIDCleared() {
+		_spec.ClearField(emailtemplate.FieldSpaceID, field.TypeString)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(emailtemplate.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(emailtemplate.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Disabled(); ok {
+		_spec.SetField(emailtemplate.FieldDisabled, field.TypeBool, value)
+	}
+	if _u.mutation.DisabledCleared() {
+		_spec.ClearField(emailtemplate.FieldDisabled, field.TypeBool)
+	}
+	if value, ok := _u.mutation.CreatedBy(); ok {
+		_spec.SetField(emailtemplate.FieldCreatedBy, field.TypeString, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(emailtemplate.FieldCreatedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.UpdatedBy(); ok {
+		_spec.SetField(emailtemplate.FieldUpdatedBy, field.TypeString, value)
+	}
+	if _u.mutation.UpdatedByCleared() {
+		_spec.ClearField(emailtemplate.FieldUpdatedBy, field.TypeString)
+	}
+	if _u.mutation.CreatedAtCleared() {
+		_spec.ClearField(emailtemplate.FieldCreatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(emailtemplate.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(emailtemplate.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.UpdatedAtCleared() {
+		_spec.ClearField(emailtemplate.FieldUpdatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.Subject(); ok {
+		_spec.SetField(emailtemplate.FieldSubject, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Body(); ok {
+		_spec.SetField(emailtemplate.FieldBody, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.HTML(); ok {
+		_spec.SetField(emailtemplate.FieldHTML, field.TypeBool, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{emailtemplate.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// EmailTemplateUpdateOne is the builder for updating a single EmailTemplate entity.
+type EmailTemplateUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *EmailTemplateMutation
+}
+
+// SetName sets the "name" field.
+func (_u *EmailTemplateUpdateOne) SetName(v string) *EmailTemplateUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *EmailTemplateUpdateOne) SetNillableName(v *string) *EmailTemplateUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// ClearName clears the value of the "name" field.
+func (_u *EmailTemplateUpdateOne) ClearName() *EmailTemplateUpdateOne {
+	_u.mutation.ClearName()
+	return _u
+}
+
+// SetSpaceID sets the "space_id" field.
+func (_u *EmailTemplateUpdateOne) SetSpaceID(v string) *EmailTemplateUpdateOne {
+	_u.mutation.SetSpaceID(v)
+	return _u
+}
+
+// SetNillableSpaceID sets the "space_id" field if the given value is not nil.
+func (_u *EmailTemplateUpdateOne) SetNillableSpaceID(v *string) *EmailTemplateUpdateOne {
+	if v != nil {
+		_u.SetSpaceID(*v)
+	}
+	return _u
+}
+
+// ClearSpaceID clears the value of the "space_id" field.
+func (_u *EmailTemplateUpdateOne) ClearSpaceID() *EmailTemplateUpdateOne {
+	_u.mutation.ClearSpaceID()
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *EmailTemplateUpdateOne) SetDescription(v string) *EmailTemplateUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *EmailTemplateUpdateOne) SetNillableDescription(v *string) *EmailTemplateUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *EmailTemplateUpdateOne) ClearDescription() *EmailTemplateUpdateOne {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetDisabled sets the "disabled" field.
+func (_u *EmailTemplateUpdateOne) SetDisabled(v bool) *EmailTemplateUpdateOne {
+	_u.mutation.SetDisabled(v)
+	return _u
+}
+
+// SetNillableDisabled sets the "disabled" field if the given value is not nil.
+func (_u *EmailTemplateUpdateOne) SetNillableDisabled(v *bool) *EmailTemplateUpdateOne {
+	if v != nil {
+		_u.SetDisabled(*v)
+	}
+	return _u
+}
+
+// ClearDisabled clears the value of the "disabled" field.
+func (_u *EmailTemplateUpdateOne) ClearDisabled() *EmailTemplateUpdateOne {
+	_u.mutation.ClearDisabled()
+	return _u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *EmailTemplateUpdateOne) SetCreatedBy(v string) *EmailTemplateUpdateOne {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *EmailTemplateUpdateOne) SetNillableCreatedBy(v *string) *EmailTemplateUpdateOne {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *EmailTemplateUpdateOne) ClearCreatedBy() *EmailTemplateUpdateOne {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_u *EmailTemplateUpdateOne) SetUpdatedBy(v string) *EmailTemplateUpdateOne {
+	_u.mutation.SetUpdatedBy(v)
+	return _u
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_u *EmailTemplateUpdateOne) SetNillableUpdatedBy(v *string) *EmailTemplateUpdateOne {
+	if v != nil {
+		_u.SetUpdatedBy(*v)
+	}
+	return _u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (_u *EmailTemplateUpdateOne) ClearUpdatedBy() *EmailTemplateUpdateOne {
+	_u.mutation.ClearUpdatedBy()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *EmailTemplateUpdateOne) SetUpdatedAt(v int64) *EmailTemplateUpdateOne {
+	_u.mutation.ResetUpdatedAt()
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddUpdatedAt adds value to the "updated_at" field.
+func (_u *EmailTemplateUpdateOne) AddUpdatedAt(v int64) *EmailTemplateUpdateOne {
+	_u.mutation.AddUpdatedAt(v)
+	return _u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (_u *EmailTemplateUpdateOne) ClearUpdatedAt() *EmailTemplateUpdateOne {
+	_u.mutation.ClearUpdatedAt()
+	return _u
+}
+
+// SetSubject sets the "subject" field.
+func (_u *EmailTemplateUpdateOne) SetSubject(v string) *EmailTemplateUpdateOne {
+	_u.mutation.SetSubject(v)
+	return _u
+}
+
+// SetNillableSubject sets the "subject" field if the given value is not nil.
+func (_u *EmailTemplateUpdateOne) SetNillableSubject(v *string) *EmailTemplateUpdateOne {
+	if v != nil {
+		_u.SetSubject(*v)
+	}
+	return _u
+}
+
+// SetBody sets the "body" field.
+func (_u *EmailTemplateUpdateOne) SetBody(v string) *EmailTemplateUpdateOne {
+	_u.mutation.SetBody(v)
+	return _u
+}
+
+// SetNillableBody sets the "body" field if the given value is not nil.
+func (_u *EmailTemplateUpdateOne) SetNillableBody(v *string) *EmailTemplateUpdateOne {
+	if v != nil {
+		_u.SetBody(*v)
+	}
+	return _u
+}
+
+// SetHTML sets the "html" field.
+func (_u *EmailTemplateUpdateOne) SetHTML(v bool) *EmailTemplateUpdateOne {
+	_u.mutation.SetHTML(v)
+	return _u
+}
+
+// SetNillableHTML sets the "html" field if the given value is not nil.
+func (_u *EmailTemplateUpdateOne) SetNillableHTML(v *bool) *EmailTemplateUpdateOne {
+	if v != nil {
+		_u.SetHTML(*v)
+	}
+	return _u
+}
+
+// Mutation returns the EmailTemplateMutation object of the builder.
+func (_u *EmailTemplateUpdateOne) Mutation() *EmailTemplateMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the EmailTemplateUpdate builder.
+func (_u *EmailTemplateUpdateOne) Where(ps ...predicate.EmailTemplate) *EmailTemplateUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *EmailTemplateUpdateOne) Select(field string, fields ...string) *EmailTemplateUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated EmailTemplate entity.
+func (_u *EmailTemplateUpdateOne) Save(ctx context.Context) (*EmailTemplate, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *EmailTemplateUpdateOne) SaveX(ctx context.Context) *EmailTemplate {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *EmailTemplateUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *EmailTemplateUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *EmailTemplateUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok && !_u.mutation.UpdatedAtCleared() {
+		v := emailtemplate.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *EmailTemplateUpdateOne) sqlSave(ctx context.Context) (_node *EmailTemplate, err error) {
+	_spec := sqlgraph.NewUpdateSpec(emailtemplate.Table, emailtemplate.Columns, sqlgraph.NewFieldSpec(emailtemplate.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "EmailTemplate.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, emailtemplate.FieldID)
+		for _, f := range fields {
+			if !emailtemplate.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != emailtemplate.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(emailtemplate.FieldName, field.TypeString, value)
+	}
+	if _u.mutation.NameCleared() {
+		_spec.ClearField(emailtemplate.FieldName, field.TypeString)
+	}
+	if value, ok := _u.mutation.SpaceID(); ok {
+		_spec.SetField(emailtemplate.FieldSpaceID, field.TypeString, value)
+	}
+	if _u.mutation.SpaceIDCleared() {
+		_spec.ClearField(emailtemplate.FieldSpaceID, field.TypeString)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(emailtemplate.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(emailtemplate.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Disabled(); ok {
+		_spec.SetField(emailtemplate.FieldDisabled, field.TypeBool, value)
+	}
+	if _u.mutation.DisabledCleared() {
+		_spec.ClearField(emailtemplate.FieldDisabled, field.TypeBool)
+	}
+	if value, ok := _u.mutation.CreatedBy(); ok {
+		_spec.SetField(emailtemplate.FieldCreatedBy, field.TypeString, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(emailtemplate.FieldCreatedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.UpdatedBy(); ok {
+		_spec.SetField(emailtemplate.FieldUpdatedBy, field.TypeString, value)
+	}
+	if _u.mutation.UpdatedByCleared() {
+		_spec.ClearField(emailtemplate.FieldUpdatedBy, field.TypeString)
+	}
+	if _u.mutation.CreatedAtCleared() {
+		_spec.ClearField(emailtemplate.FieldCreatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(emailtemplate.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(emailtemplate.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.UpdatedAtCleared() {
+		_spec.ClearField(emailtemplate.FieldUpdatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.Subject(); ok {
+		_spec.SetField(emailtemplate.FieldSubject, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Body(); ok {
+		_spec.SetField(emailtemplate.FieldBody, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.HTML(); ok {
+		_spec.SetField(emailtemplate.FieldHTML, field.TypeBool, value)
+	}
+	_node = &EmailTemplate{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{emailtemplate.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"ncobase/core/system/data/ent/dictionary"
+	"ncobase/core/system/data/ent/emailtemplate"
 	"ncobase/core/system/data/ent/menu"
 	"ncobase/core/system/data/ent/options"
 	"reflect"
@@ -75,9 +76,10 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			dictionary.Table: dictionary.ValidColumn,
-			menu.Table:       menu.ValidColumn,
-			options.Table:    options.ValidColumn,
+			dictionary.Table:    dictionary.ValidColumn,
+			emailtemplate.Table: emailtemplate.ValidColumn,
+			menu.Table:          menu.ValidColumn,
+			options.Table:       options.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -40,6 +40,49 @@ var (
 			},
 		},
 	}
+	// NcseSysEmailTemplateColumns holds the columns for the "ncse_sys_email_template" table.
+	NcseSysEmailTemplateColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, Unique: true, Size: 16, Comment: "primary key"},
+		{Name: "name", Type: field.TypeString, Nullable: true, Comment: "name"},
+		{Name: "space_id", Type: field.TypeString, Nullable: true, Comment: "space id, e.g. space id, organization id, store id"},
+		{Name: "description", Type: field.TypeString, Nullable: true, Size: 2147483647, Comment: "description"},
+		{Name: "disabled", Type: field.TypeBool, Nullable: true, Comment: "is disabled", Default: false},
+		{Name: "created_by", Type: field.TypeString, Nullable: true, Comment: "id of the creator"},
+		{Name: "updated_by", Type: field.TypeString, Nullable: true, Comment: "id of the last updater"},
+		{Name: "created_at", Type: field.TypeInt64, Nullable: true, Comment: "created at"},
+		{Name: "updated_at", Type: field.TypeInt64, Nullable: true, Comment: "updated at"},
+		{Name: "subject", Type: field.TypeString, Comment: "subject template", Default: ""},
+		{Name: "body", Type: field.TypeString, Size: 2147483647, Comment: "body template", Default: ""},
+		{Name: "html", Type: field.TypeBool, Comment: "body is HTML, interpolated values are escaped", Default: false},
+	}
+	// NcseSysEmailTemplateTable holds the schema information for the "ncse_sys_email_template" table.
+	NcseSysEmailTemplateTable = &schema.Table{
+		Name:       "ncse_sys_email_template",
+		Columns:    NcseSysEmailTemplateColumns,
+		PrimaryKey: []*schema.Column{NcseSysEmailTemplateColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "emailtemplate_id",
+				Unique:  true,
+				Columns: []*schema.Column{NcseSysEmailTemplateColumns[0]},
+			},
+			{
+				Name:    "emailtemplate_space_id",
+				Unique:  false,
+				Columns: []*schema.Column{NcseSysEmailTemplateColumns[2]},
+			},
+			{
+				Name:    "emailtemplate_id_created_at",
+				Unique:  true,
+				Columns: []*schema.Column{NcseSysEmailTemplateColumns[0], NcseSysEmailTemplateColumns[7]},
+			},
+			{
+				Name:    "emailtemplate_space_id_name",
+				Unique:  true,
+				Columns: []*schema.Column{NcseSysEmailTemplateColumns[2], NcseSysEmailTemplateColumns[1]},
+			},
+		},
+	}
 	// NcseSysMenuColumns holds the columns for the "ncse_sys_menu" table.
 	NcseSysMenuColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeString, Unique: true, Size: 16, Comment: "primary key"},
@@ -122,6 +165,7 @@ var (
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		NcseSysDictionaryTable,
+		NcseSysEmailTemplateTable,
 		NcseSysMenuTable,
 		NcseSysOptionTable,
 	}
@@ -131,6 +175,9 @@ func init() {
 	NcseSysDictionaryTable.Annotation = &entsql.Annotation{
 		Table: "ncse_sys_dictionary",
 	}
+	NcseSysEmailTemplateTable.Annotation = &entsql.Annotation{
+		Table: "ncse_sys_email_template",
+	}
 	NcseSysMenuTable.Annotation = &entsql.Annotation{
 		Table: "ncse_sys_menu",
 	}
@@ -9,6 +9,9 @@ import (
 // Dictionary is the predicate function for dictionary builders.
 type Dictionary func(*sql.Selector)
 
+// EmailTemplate is the predicate function for emailtemplate builders.
+type EmailTemplate func(*sql.Selector)
+
 // Menu is the predicate function for menu builders.
 type Menu func(*sql.Selector)
 
@@ -4,6 +4,7 @@ package ent
 
 import (
 	"ncobase/core/system/data/ent/dictionary"
+	"ncobase/core/system/data/ent/emailtemplate"
 	"ncobase/core/system/data/ent/menu"
 	"ncobase/core/system/data/ent/options"
 	"ncobase/core/system/data/schema"
@@ -36,6 +37,47 @@ func init() {
 	dictionary.DefaultID = dictionaryDescID.Default.(func() string)
 	// dictionary.IDValidator is a validator for the "id" field. It is called by the builders before save.
 	dictionary.IDValidator = dictionaryDescID.Validators[0].(func(string) error)
+	emailtemplateMixin := schema.EmailTemplate{}.Mixin()
+	emailtemplateMixinFields0 := emailtemplateMixin[0].Fields()
+	_ = emailtemplateMixinFields0
+	emailtemplateMixinFields4 := emailtemplateMixin[4].Fields()
+	_ = emailtemplateMixinFields4
+	emailtemplateMixinFields6 := emailtemplateMixin[6].Fields()
+	_ = emailtemplateMixinFields6
+	emailtemplateFields := schema.EmailTemplate{}.Fields()
+	_ = emailtemplateFields
+	// emailtemplateDescDisabled is the schema descriptor for disabled field.
+	emailtemplateDescDisabled := emailtemplateMixinFields4[0].Descriptor()
+	// emailtemplate.DefaultDisabled holds the default value on creation for the disabled field.
+	emailtemplate.DefaultDisabled = emailtemplateDescDisabled.Default.(bool)
+	// emailtemplateDescCreatedAt is the schema descriptor for created_at field.
+	emailtemplateDescCreatedAt := emailtemplateMixinFields6[0].Descriptor()
+	// emailtemplate.DefaultCreatedAt holds the default value on creation for the created_at field.
+	emailtemplate.DefaultCreatedAt = emailtemplateDescCreatedAt.Default.(func() int64)
+	// emailtemplateDescUpdatedAt is the schema descriptor for updated_at field.
+	emailtemplateDescUpdatedAt := emailtemplateMixinFields6[1].Descriptor()
+	// emailtemplate.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	emailtemplate.DefaultUpdatedAt = emailtemplateDescUpdatedAt.Default.(func() int64)
+	// emailtemplate.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	emailtemplate.UpdateDefaultUpdatedAt = emailtemplateDescUpdatedAt.UpdateDefault.(func() int64)
+	// emailtemplateDescSubject is the schema descriptor for subject field.
+	emailtemplateDescSubject := emailtemplateFields[0].Descriptor()
+	// emailtemplate.DefaultSubject holds the default value on creation for the subject field.
+	emailtemplate.DefaultSubject = emailtemplateDescSubject.Default.(string)
+	// emailtemplateDescBody is the schema descriptor for body field.
+	emailtemplateDescBody := emailtemplateFields[1].Descriptor()
+	// emailtemplate.DefaultBody holds the default value on creation for the body field.
+	emailtemplate.DefaultBody = emailtemplateDescBody.Default.(string)
+	// emailtemplateDescHTML is the schema descriptor for html field.
+	emailtemplateDescHTML := emailtemplateFields[2].Descriptor()
+	// emailtemplate.DefaultHTML holds the default value on creation for the html field.
+	emailtemplate.DefaultHTML = emailtemplateDescHTML.Default.(bool)
+	// emailtemplateDescID is the schema descriptor for id field.
+	emailtemplateDescID := emailtemplateMixinFields0[0].Descriptor()
+	// emailtemplate.DefaultID holds the default value on creation for the id field.
+	emailtemplate.DefaultID = emailtemplateDescID.Default.(func() string)
+	// emailtemplate.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	emailtemplate.IDValidator = emailtemplateDescID.Validators[0].(func(string) error)
 	menuMixin := schema.Menu{}.Mixin()
 	menuMixinFields0 := menuMixin[0].Fields()
 	_ = menuMixinFields0
@@ -16,6 +16,8 @@ type Tx struct {
 	config
 	// Dictionary is the client for interacting with the Dictionary builders.
 	Dictionary *DictionaryClient
+	// EmailTemplate is the client for interacting with the EmailTemplate builders.
+	EmailTemplate *EmailTemplateClient
 	// Menu is the client for interacting with the Menu builders.
 	Menu *MenuClient
 	// Options is the client for interacting with the Options builders.
@@ -152,6 +154,7 @@ func (tx *Tx) Client() *Client {
 
 func (tx *Tx) init() {
 	tx.Dictionary = NewDictionaryClient(tx.config)
+	tx.EmailTemplate = NewEmailTemplateClient(tx.config)
 	tx.Menu = NewMenuClient(tx.config)
 	tx.Options = NewOptionsClient(tx.config)
 }
@@ -7,6 +7,7 @@ import (
 	"ncobase/core/system/data/ent"
 	dictionaryEnt "ncobase/core/system/data/ent/dictionary"
 	"ncobase/core/system/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	nd "github.com/ncobase/ncore/data"
@@ -47,8 +48,8 @@ func NewDictionaryRepository(d *data.Data) DictionaryRepositoryInterface {
 	return &dictionaryRepository{
 		data:             d,
 		sc:               sc,
-		dictionaryCache:  cache.NewCache[ent.Dictionary](redisClient, "ncse_system:dictionaries"),
-		slugMappingCache: cache.NewCache[string](redisClient, "ncse_system:dict_mappings"),
+		dictionaryCache:  utils.NewRetryCache(cache.NewCache[ent.Dictionary](redisClient, "ncse_system:dictionaries")),
+		slugMappingCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_system:dict_mappings")),
 		dictionaryTTL:    time.Hour * 4, // 4 hours cache TTL
 	}
 }
@@ -317,7 +318,7 @@ func (r *dictionaryRepository) getDictionary(ctx context.Context, params *struct
 	// Execute the builder
 	row, err := builder.First(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"ncobase/core/system/data"
+	"ncobase/core/system/data/ent"
+	emailTemplateEnt "ncobase/core/system/data/ent/emailtemplate"
+	"ncobase/core/system/structs"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/validation/validator"
+)
+
+// EmailTemplateRepositoryInterface represents the email template repository interface.
+type EmailTemplateRepositoryInterface interface {
+	Create(ctx context.Context, body *structs.EmailTemplateBody) (*ent.EmailTemplate, error)
+	GetByID(ctx context.Context, id string) (*ent.EmailTemplate, error)
+	GetByName(ctx context.Context, spaceID, name string) (*ent.EmailTemplate, error)
+	Update(ctx context.Context, body *structs.UpdateEmailTemplateBody) (*ent.EmailTemplate, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, params *structs.ListEmailTemplateParams) ([]*ent.EmailTemplate, error)
+}
+
+// emailTemplateRepository implements the EmailTemplateRepositoryInterface.
+type emailTemplateRepository struct {
+	data *data.Data
+}
+
+// NewEmailTemplateRepository creates a new email template repository.
+func NewEmailTemplateRepository(d *data.Data) EmailTemplateRepositoryInterface {
+	return &emailTemplateRepository{data: d}
+}
+
+// Create creates a new email template.
+func (r *emailTemplateRepository) Create(ctx context.Context, body *structs.EmailTemplateBody) (*ent.EmailTemplate, error) {
+	// Use master for writes
+	builder := r.data.GetMasterEntClient().EmailTemplate.Create()
+
+	builder.SetName(body.Name)
+	// Always set, shared templates are stored with an empty space ID
+	builder.SetSpaceID(body.SpaceID)
+	builder.SetSubject(body.Subject)
+	builder.SetBody(body.Body)
+	builder.SetHTML(body.HTML)
+	builder.SetDisabled(body.Disabled)
+
+	if validator.IsNotEmpty(body.Description) {
+		builder.SetNillableDescription(&body.Description)
+	}
+	if validator.IsNotEmpty(body.CreatedBy) {
+		builder.SetNillableCreatedBy(body.CreatedBy)
+	}
+
+	row, err := builder.Save(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "emailTemplateRepo.Create error: %v", err)
+		return nil, err
+	}
+
+	return row, nil
+}
+
+// GetByID retrieves an email template by ID.
+func (r *emailTemplateRepository) GetByID(ctx context.Context, id string) (*ent.EmailTemplate, error) {
+	// Use slave for reads
+	return r.data.GetSlaveEntClient().EmailTemplate.Query().
+		Where(emailTemplateEnt.IDEQ(id)).
+		Only(ctx)
+}
+
+// GetByName retrieves an email template by name within a space.
+func (r *emailTemplateRepository) GetByName(ctx context.Context, spaceID, name string) (*ent.EmailTemplate, error) {
+	// Use slave for reads
+	return r.data.GetSlaveEntClient().EmailTemplate.Query().
+		Where(
+			emailTemplateEnt.SpaceIDEQ(spaceID),
+			emailTemplateEnt.NameEQ(name),
+		).
+		Only(ctx)
+}
+
+// Update updates an existing email template.
+func (r *emailTemplateRepository) Update(ctx context.Context, body *structs.UpdateEmailTemplateBody) (*ent.EmailTemplate, error) {
+	row, err := r.GetByID(ctx, body.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use master for writes
+	builder := r.data.GetMasterEntClient().EmailTemplate.UpdateOne(row)
+
+	if validator.IsNotEmpty(body.Name) {
+		builder.SetName(body.Name)
+	}
+	if validator.IsNotEmpty(body.Subject) {
+		builder.SetSubject(body.Subject)
+	}
+	if validator.IsNotEmpty(body.Body) {
+		builder.SetBody(body.Body)
+	}
+	if validator.IsNotEmpty(body.Description) {
+		builder.SetDescription(body.Description)
+	}
+	builder.SetHTML(body.HTML)
+	builder.SetDisabled(body.Disabled)
+
+	if validator.IsNotEmpty(body.UpdatedBy) {
+		builder.SetNillableUpdatedBy(body.UpdatedBy)
+	}
+
+	row, err = builder.Save(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "emailTemplateRepo.Update error: %v", err)
+		return nil, err
+	}
+
+	return row, nil
+}
+
+// Delete deletes an email template.
+func (r *emailTemplateRepository) Delete(ctx context.Context, id string) error {
+	// Use master for writes
+	_, err := r.data.GetMasterEntClient().EmailTemplate.Delete().
+		Where(emailTemplateEnt.IDEQ(id)).
+		Exec(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "emailTemplateRepo.Delete error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// List returns the email templates of a space, ordered by name.
+func (r *emailTemplateRepository) List(ctx context.Context, params *structs.ListEmailTemplateParams) ([]*ent.EmailTemplate, error) {
+	// Use slave for reads
+	return r.data.GetSlaveEntClient().EmailTemplate.Query().
+		Where(emailTemplateEnt.SpaceIDEQ(params.SpaceID)).
+		Order(ent.Asc(emailTemplateEnt.FieldName)).
+		All(ctx)
+}
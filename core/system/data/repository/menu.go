@@ -7,6 +7,7 @@ import (
 	"ncobase/core/system/data/ent"
 	menuEnt "ncobase/core/system/data/ent/menu"
 	"ncobase/core/system/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	nd "github.com/ncobase/ncore/data"
@@ -50,9 +51,9 @@ func NewMenuRepository(d *data.Data) MenuRepositoryInterface {
 	return &menuRepository{
 		data:             d,
 		sc:               sc,
-		menuCache:        cache.NewCache[ent.Menu](redisClient, "ncse_system:menus"),
-		slugMappingCache: cache.NewCache[string](redisClient, "ncse_system:menu_mappings"),
-		menuTreeCache:    cache.NewCache[[]ent.Menu](redisClient, "ncse_system:menu_trees"),
+		menuCache:        utils.NewRetryCache(cache.NewCache[ent.Menu](redisClient, "ncse_system:menus")),
+		slugMappingCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_system:menu_mappings")),
+		menuTreeCache:    utils.NewRetryCache(cache.NewCache[[]ent.Menu](redisClient, "ncse_system:menu_trees")),
 		menuTTL:          time.Hour * 6, // 6 hours cache TTL
 	}
 }
@@ -157,7 +158,7 @@ func (r *menuRepository) GetMenuTree(ctx context.Context, params *structs.FindMe
 		// Cache the result
 		go func() {
 			if err := r.menuTreeCache.SetArray(context.Background(), cacheKey, menus, r.menuTTL); err != nil {
-				logger.Debugf(context.Background(), "Failed to cache menu tree %s: %v", cacheKey, err)
+				logger.Debugf(ctx, "Failed to cache menu tree %s: %v", cacheKey, err)
 			}
 		}()
 
@@ -173,7 +174,7 @@ func (r *menuRepository) GetMenuTree(ctx context.Context, params *structs.FindMe
 	// Cache the result
 	go func() {
 		if err := r.menuTreeCache.SetArray(context.Background(), cacheKey, menus, r.menuTTL); err != nil {
-			logger.Debugf(context.Background(), "Failed to cache menu tree %s: %v", cacheKey, err)
+			logger.Debugf(ctx, "Failed to cache menu tree %s: %v", cacheKey, err)
 		}
 	}()
 
@@ -343,7 +344,7 @@ func (r *menuRepository) List(ctx context.Context, params *structs.ListMenuParam
 			return nil, fmt.Errorf("invalid id in cursor: %s", id)
 		}
 
-		builder = r.applyCursorCondition(builder, id, value, params.Direction, params.SortBy)
+		builder = r.applyCursorCondition(ctx, builder, id, value, params.Direction, params.SortBy)
 	}
 
 	builder.Limit(params.Limit)
@@ -392,7 +393,7 @@ func (r *menuRepository) ListWithCount(ctx context.Context, params *structs.List
 			return nil, 0, fmt.Errorf("invalid id in cursor: %s", id)
 		}
 
-		builder = r.applyCursorCondition(builder, id, value, params.Direction, params.SortBy)
+		builder = r.applyCursorCondition(ctx, builder, id, value, params.Direction, params.SortBy)
 	}
 
 	total, err := builder.Count(ctx)
@@ -446,12 +447,12 @@ func (r *menuRepository) applySorting(builder *ent.MenuQuery, sortBy string) *en
 }
 
 // applyCursorCondition applies the cursor-based condition to the query builder.
-func (r *menuRepository) applyCursorCondition(builder *ent.MenuQuery, id string, value any, direction string, sortBy string) *ent.MenuQuery {
+func (r *menuRepository) applyCursorCondition(ctx context.Context, builder *ent.MenuQuery, id string, value any, direction string, sortBy string) *ent.MenuQuery {
 	switch sortBy {
 	case structs.SortByCreatedAt:
 		timestamp, ok := value.(int64)
 		if !ok {
-			logger.Errorf(context.Background(), "Invalid timestamp value for cursor")
+			logger.Errorf(ctx, "Invalid timestamp value for cursor")
 			return builder
 		}
 		if direction == "backward" {
@@ -477,7 +478,7 @@ func (r *menuRepository) applyCursorCondition(builder *ent.MenuQuery, id string,
 	case structs.SortByOrder:
 		order, ok := value.(int)
 		if !ok {
-			logger.Errorf(context.Background(), "Invalid order value for cursor")
+			logger.Errorf(ctx, "Invalid order value for cursor")
 			return builder
 		}
 		if direction == "backward" {
@@ -503,7 +504,7 @@ func (r *menuRepository) applyCursorCondition(builder *ent.MenuQuery, id string,
 	case structs.SortByName:
 		name, ok := value.(string)
 		if !ok {
-			logger.Errorf(context.Background(), "Invalid name value for cursor")
+			logger.Errorf(ctx, "Invalid name value for cursor")
 			return builder
 		}
 		if direction == "backward" {
@@ -527,7 +528,7 @@ func (r *menuRepository) applyCursorCondition(builder *ent.MenuQuery, id string,
 			),
 		)
 	default:
-		return r.applyCursorCondition(builder, id, value, direction, structs.SortByCreatedAt)
+		return r.applyCursorCondition(ctx, builder, id, value, direction, structs.SortByCreatedAt)
 	}
 }
 
@@ -584,7 +585,7 @@ func (r *menuRepository) getMenu(ctx context.Context, params *structs.FindMenu)
 	// execute the builder.
 	row, err := builder.First(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
@@ -7,6 +7,7 @@ import (
 	"ncobase/core/system/data/ent"
 	optionsEnt "ncobase/core/system/data/ent/options"
 	"ncobase/core/system/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/ncobase/ncore/data/cache"
@@ -49,8 +50,8 @@ func NewOptionRepository(d *data.Data) OptionRepositoryInterface {
 	return &optionRepository{
 		data:             d,
 		sc:               sc,
-		optionsCache:     cache.NewCache[ent.Options](redisClient, "ncse_system:options"),
-		nameMappingCache: cache.NewCache[string](redisClient, "ncse_system:option_mappings"),
+		optionsCache:     utils.NewRetryCache(cache.NewCache[ent.Options](redisClient, "ncse_system:options")),
+		nameMappingCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_system:option_mappings")),
 		optionsTTL:       time.Hour * 6, // 6 hours cache TTL
 	}
 }
@@ -252,7 +253,7 @@ func (r *optionRepository) List(ctx context.Context, params *structs.ListOptionP
 			return nil, fmt.Errorf("invalid id in cursor: %s", id)
 		}
 
-		builder = r.applyCursorCondition(builder, id, value, params.Direction, params.SortBy)
+		builder = r.applyCursorCondition(ctx, builder, id, value, params.Direction, params.SortBy)
 	}
 
 	builder.Limit(params.Limit)
@@ -301,7 +302,7 @@ func (r *optionRepository) ListWithCount(ctx context.Context, params *structs.Li
 			return nil, 0, fmt.Errorf("invalid id in cursor: %s", id)
 		}
 
-		builder = r.applyCursorCondition(builder, id, value, params.Direction, params.SortBy)
+		builder = r.applyCursorCondition(ctx, builder, id, value, params.Direction, params.SortBy)
 	}
 
 	total, err := builder.Count(ctx)
@@ -337,12 +338,12 @@ func (r *optionRepository) applySorting(builder *ent.OptionsQuery, sortBy string
 }
 
 // applyCursorCondition applies the cursor-based condition to the query builder.
-func (r *optionRepository) applyCursorCondition(builder *ent.OptionsQuery, id string, value any, direction string, sortBy string) *ent.OptionsQuery {
+func (r *optionRepository) applyCursorCondition(ctx context.Context, builder *ent.OptionsQuery, id string, value any, direction string, sortBy string) *ent.OptionsQuery {
 	switch sortBy {
 	case structs.SortByCreatedAt:
 		timestamp, ok := value.(int64)
 		if !ok {
-			logger.Errorf(context.Background(), "Invalid timestamp value for cursor")
+			logger.Errorf(ctx, "Invalid timestamp value for cursor")
 			return builder
 		}
 		if direction == "backward" {
@@ -368,7 +369,7 @@ func (r *optionRepository) applyCursorCondition(builder *ent.OptionsQuery, id st
 	case structs.SortByName:
 		name, ok := value.(string)
 		if !ok {
-			logger.Errorf(context.Background(), "Invalid name value for cursor")
+			logger.Errorf(ctx, "Invalid name value for cursor")
 			return builder
 		}
 		if direction == "backward" {
@@ -392,7 +393,7 @@ func (r *optionRepository) applyCursorCondition(builder *ent.OptionsQuery, id st
 			),
 		)
 	default:
-		return r.applyCursorCondition(builder, id, value, direction, structs.SortByCreatedAt)
+		return r.applyCursorCondition(ctx, builder, id, value, direction, structs.SortByCreatedAt)
 	}
 }
 
@@ -431,7 +432,7 @@ func (r *optionRepository) getOption(ctx context.Context, params *structs.FindOp
 
 	row, err := builder.First(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
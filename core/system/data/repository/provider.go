@@ -7,6 +7,7 @@ type Repository struct {
 	Menu       MenuRepositoryInterface
 	Dictionary DictionaryRepositoryInterface
 	Options    OptionRepositoryInterface
+	Email      EmailTemplateRepositoryInterface
 }
 
 // New creates a new repository.
@@ -15,5 +16,6 @@ func New(d *data.Data) *Repository {
 		Menu:       NewMenuRepository(d),
 		Dictionary: NewDictionaryRepository(d),
 		Options:    NewOptionRepository(d),
+		Email:      NewEmailTemplateRepository(d),
 	}
 }
@@ -94,3 +94,33 @@ func SerializeOptions(rows []*ent.Options) []*structs.ReadOption {
 	}
 	return rs
 }
+
+// SerializeEmailTemplate converts ent.EmailTemplate to structs.ReadEmailTemplate.
+func SerializeEmailTemplate(row *ent.EmailTemplate) *structs.ReadEmailTemplate {
+	if row == nil {
+		return nil
+	}
+	return &structs.ReadEmailTemplate{
+		ID:          row.ID,
+		Name:        row.Name,
+		SpaceID:     row.SpaceID,
+		Subject:     row.Subject,
+		Body:        row.Body,
+		HTML:        row.HTML,
+		Description: row.Description,
+		Disabled:    row.Disabled,
+		CreatedBy:   &row.CreatedBy,
+		CreatedAt:   &row.CreatedAt,
+		UpdatedBy:   &row.UpdatedBy,
+		UpdatedAt:   &row.UpdatedAt,
+	}
+}
+
+// SerializeEmailTemplates converts []*ent.EmailTemplate to []*structs.ReadEmailTemplate.
+func SerializeEmailTemplates(rows []*ent.EmailTemplate) []*structs.ReadEmailTemplate {
+	rs := make([]*structs.ReadEmailTemplate, 0, len(rows))
+	for _, row := range rows {
+		rs = append(rs, SerializeEmailTemplate(row))
+	}
+	return rs
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent/dialect/entsql"
@@ -30,7 +31,7 @@ func (Dictionary) Annotations() []schema.Annotation {
 // Mixin of the Dictionary.
 func (Dictionary) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.SlugUnique,
 		mixin.Type,  // type, object, string, number, ...
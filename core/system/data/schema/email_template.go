@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"strings"
+
+	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
+
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// EmailTemplate holds the schema definition for the EmailTemplate entity.
+type EmailTemplate struct {
+	ent.Schema
+}
+
+// Annotations of the EmailTemplate.
+func (EmailTemplate) Annotations() []schema.Annotation {
+	table := strings.Join([]string{"ncse", "sys", "email_template"}, "_")
+	return []schema.Annotation{
+		entsql.Annotation{Table: table},
+		entgql.Mutations(entgql.MutationCreate(), entgql.MutationUpdate()),
+		entsql.WithComments(true),
+	}
+}
+
+// Mixin of the EmailTemplate.
+func (EmailTemplate) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		idgen.PrimaryKey,
+		mixin.Name,    // template name, unique per space
+		mixin.SpaceID, // empty for templates shared by every space
+		mixin.Description,
+		mixin.Disabled,
+		mixin.OperatorBy{},
+		mixin.TimeAt{},
+	}
+}
+
+// Fields of the EmailTemplate.
+func (EmailTemplate) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("subject").Default("").Comment("subject template"),
+		field.Text("body").Default("").Comment("body template"),
+		field.Bool("html").Default(false).Comment("body is HTML, interpolated values are escaped"),
+	}
+}
+
+// Edges of the EmailTemplate.
+func (EmailTemplate) Edges() []ent.Edge {
+	return nil
+}
+
+// Indexes of the EmailTemplate.
+func (EmailTemplate) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("id", "created_at").Unique(),
+		index.Fields("space_id", "name").Unique(),
+	}
+}
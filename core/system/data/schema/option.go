@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -29,7 +30,7 @@ func (Options) Annotations() []schema.Annotation {
 // Mixin of the Options.
 func (Options) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.NameUnique, // name unique
 		mixin.Type,       // type, object, string, number, boolean, ...
 		mixin.Value,      // value
@@ -1,12 +1,12 @@
 package handler
 
 import (
+	"ncobase/internal/validation"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
 
 	"ncobase/core/system/service"
 	"ncobase/core/system/structs"
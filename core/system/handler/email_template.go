@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"ncobase/core/system/service"
+	"ncobase/core/system/structs"
+	"ncobase/internal/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// EmailTemplateHandlerInterface represents the email template handler interface.
+type EmailTemplateHandlerInterface interface {
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Get(c *gin.Context)
+	Delete(c *gin.Context)
+	List(c *gin.Context)
+	Render(c *gin.Context)
+}
+
+// emailTemplateHandler represents the email template handler.
+type emailTemplateHandler struct {
+	s *service.Service
+}
+
+// NewEmailTemplateHandler creates new email template handler.
+func NewEmailTemplateHandler(svc *service.Service) EmailTemplateHandlerInterface {
+	return &emailTemplateHandler{s: svc}
+}
+
+// Create handles the creation of an email template.
+//
+// @Summary Create email template
+// @Description Create a named email template for a space, or a shared template when no space is given.
+// @Tags sys
+// @Accept json
+// @Produce json
+// @Param body body structs.CreateEmailTemplateBody true "CreateEmailTemplateBody object"
+// @Success 200 {object} structs.ReadEmailTemplate "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /sys/email-templates [post]
+// @Security Bearer
+func (h *emailTemplateHandler) Create(c *gin.Context) {
+	body := &structs.CreateEmailTemplateBody{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	result, err := h.s.Email.Create(c.Request.Context(), body)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
+
+// Update handles updating an email template.
+//
+// @Summary Update email template
+// @Description Update an existing email template.
+// @Tags sys
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param body body structs.UpdateEmailTemplateBody true "UpdateEmailTemplateBody object"
+// @Success 200 {object} structs.ReadEmailTemplate "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /sys/email-templates/{id} [put]
+// @Security Bearer
+func (h *emailTemplateHandler) Update(c *gin.Context) {
+	body := &structs.UpdateEmailTemplateBody{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+	body.ID = c.Param("id")
+
+	result, err := h.s.Email.Update(c.Request.Context(), body)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
+
+// Get handles retrieving an email template by ID.
+//
+// @Summary Get email template
+// @Description Retrieve an email template by its ID.
+// @Tags sys
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} structs.ReadEmailTemplate "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /sys/email-templates/{id} [get]
+// @Security Bearer
+func (h *emailTemplateHandler) Get(c *gin.Context) {
+	result, err := h.s.Email.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
+
+// Delete handles deleting an email template.
+//
+// @Summary Delete email template
+// @Description Delete an email template by its ID.
+// @Tags sys
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} resp.Exception "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /sys/email-templates/{id} [delete]
+// @Security Bearer
+func (h *emailTemplateHandler) Delete(c *gin.Context) {
+	if err := h.s.Email.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, nil)
+}
+
+// List handles listing the email templates of a space.
+//
+// @Summary List email templates
+// @Description List the email templates of a space, the current space by default.
+// @Tags sys
+// @Produce json
+// @Param params query structs.ListEmailTemplateParams true "List email template parameters"
+// @Success 200 {array} structs.ReadEmailTemplate "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /sys/email-templates [get]
+// @Security Bearer
+func (h *emailTemplateHandler) List(c *gin.Context) {
+	params := &structs.ListEmailTemplateParams{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, params); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	result, err := h.s.Email.List(c.Request.Context(), params)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
+
+// Render handles previewing an email template with sample data.
+//
+// @Summary Render email template
+// @Description Render an email template with the given data without sending it.
+// @Tags sys
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param body body structs.RenderEmailTemplateBody true "RenderEmailTemplateBody object"
+// @Success 200 {object} structs.RenderedEmail "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /sys/email-templates/{id}/render [post]
+// @Security Bearer
+func (h *emailTemplateHandler) Render(c *gin.Context) {
+	body := &structs.RenderEmailTemplateBody{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	result, err := h.s.Email.Preview(c.Request.Context(), c.Param("id"), body)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"ncobase/core/system/service"
+	"ncobase/core/system/structs"
+	"ncobase/internal/validation"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// DataErasureHandlerInterface defines user data erasure operations
+type DataErasureHandlerInterface interface {
+	Create(c *gin.Context)
+	Get(c *gin.Context)
+}
+
+type dataErasureHandler struct {
+	s *service.Service
+}
+
+// NewDataErasureHandler creates data erasure handler
+func NewDataErasureHandler(svc *service.Service) DataErasureHandlerInterface {
+	return &dataErasureHandler{s: svc}
+}
+
+// Create starts or resumes the erasure of all data of a user
+//
+// @Summary Create data erasure
+// @Description Erase the files, space memberships, roles, activity, payments and account of a user in the background. Activity and payments are anonymized rather than deleted by default. Starting a failed erasure again resumes it.
+// @Tags sys
+// @Accept json
+// @Produce json
+// @Param body body structs.CreateDataErasureBody true "User to erase"
+// @Success 202 {object} structs.DataErasure "Erasure"
+// @Failure 400 {object} resp.Exception "Bad request"
+// @Failure 403 {object} resp.Exception "Forbidden"
+// @Security Bearer
+// @Router /sys/admin/erasures [post]
+func (h *dataErasureHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var body structs.CreateDataErasureBody
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, &body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	job, err := h.s.DataErasure.Start(ctx, body.UserID)
+	if errors.Is(err, service.ErrDataErasureForbidden) {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+	if err != nil {
+		logger.Errorf(ctx, "Failed to start data erasure: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to start data erasure"))
+		return
+	}
+
+	resp.WithStatusCode(c.Writer, http.StatusAccepted, job)
+}
+
+// Get returns the erasure of a user with its report
+//
+// @Summary Get data erasure
+// @Description Get the status of the erasure of a user, with the records deleted and anonymized per section
+// @Tags sys
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} structs.DataErasure "Erasure"
+// @Failure 403 {object} resp.Exception "Forbidden"
+// @Failure 404 {object} resp.Exception "Not found"
+// @Security Bearer
+// @Router /sys/admin/erasures/{user_id} [get]
+func (h *dataErasureHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	job, err := h.s.DataErasure.Get(ctx, c.Param("user_id"))
+	switch {
+	case errors.Is(err, service.ErrDataErasureForbidden):
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+	case errors.Is(err, service.ErrDataErasureNotFound):
+		resp.Fail(c.Writer, resp.NotFound(err.Error()))
+	case err != nil:
+		logger.Errorf(ctx, "Failed to get data erasure: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to get data erasure"))
+	default:
+		resp.Success(c.Writer, job)
+	}
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"ncobase/core/system/service"
+	"ncobase/core/system/structs"
+	"ncobase/internal/validation"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// DataExportHandlerInterface defines user data export operations
+type DataExportHandlerInterface interface {
+	Create(c *gin.Context)
+	Get(c *gin.Context)
+	Download(c *gin.Context)
+}
+
+type dataExportHandler struct {
+	s *service.Service
+}
+
+// NewDataExportHandler creates data export handler
+func NewDataExportHandler(svc *service.Service) DataExportHandlerInterface {
+	return &dataExportHandler{s: svc}
+}
+
+// Create starts an export of all data of a user
+//
+// @Summary Create data export
+// @Description Export the account, spaces, file metadata and activity of a user into a zip archive in the background. Without a user ID the current user is exported; only admins may export other users.
+// @Tags sys
+// @Accept json
+// @Produce json
+// @Param body body structs.CreateDataExportBody false "User to export"
+// @Success 202 {object} structs.DataExport "Export job"
+// @Failure 400 {object} resp.Exception "Bad request"
+// @Failure 403 {object} resp.Exception "Forbidden"
+// @Security Bearer
+// @Router /sys/exports [post]
+func (h *dataExportHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var body structs.CreateDataExportBody
+	if c.Request.ContentLength != 0 {
+		if validationErrors, err := validation.ShouldBindAndValidateStruct(c, &body); err != nil {
+			resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+			return
+		} else if len(validationErrors) > 0 {
+			resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+			return
+		}
+	}
+
+	job, err := h.s.DataExport.Start(ctx, body.UserID)
+	if errors.Is(err, service.ErrDataExportForbidden) {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+	if err != nil {
+		logger.Errorf(ctx, "Failed to start data export: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to start data export"))
+		return
+	}
+
+	resp.WithStatusCode(c.Writer, http.StatusAccepted, job)
+}
+
+// Get returns the status of an export
+//
+// @Summary Get data export
+// @Description Get the status of a data export, with its download link once completed
+// @Tags sys
+// @Produce json
+// @Param id path string true "Export ID"
+// @Success 200 {object} structs.DataExport "Export job"
+// @Failure 404 {object} resp.Exception "Not found"
+// @Security Bearer
+// @Router /sys/exports/{id} [get]
+func (h *dataExportHandler) Get(c *gin.Context) {
+	job, err := h.s.DataExport.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		resp.Fail(c.Writer, resp.NotFound(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, job)
+}
+
+// Download streams the archive of a completed export
+//
+// @Summary Download data export
+// @Description Download the zip archive of a completed data export, one JSON lines file per module and a manifest
+// @Tags sys
+// @Produce application/zip
+// @Param id path string true "Export ID"
+// @Success 200 {file} file "Zip archive"
+// @Failure 404 {object} resp.Exception "Not found"
+// @Failure 409 {object} resp.Exception "Export not completed"
+// @Security Bearer
+// @Router /sys/exports/{id}/download [get]
+func (h *dataExportHandler) Download(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	f, job, err := h.s.DataExport.Open(ctx, c.Param("id"))
+	switch {
+	case errors.Is(err, service.ErrDataExportNotReady):
+		resp.Fail(c.Writer, resp.Conflict(err.Error()))
+		return
+	case errors.Is(err, service.ErrDataExportNotFound):
+		resp.Fail(c.Writer, resp.NotFound(err.Error()))
+		return
+	case err != nil:
+		logger.Errorf(ctx, "Failed to open data export: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to open data export"))
+		return
+	}
+	defer f.Close()
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=export-%s.zip", job.UserID))
+	c.Header("Content-Length", strconv.FormatInt(job.Size, 10))
+	c.Status(http.StatusOK)
+
+	if _, err := io.Copy(c.Writer, f); err != nil {
+		logger.Errorf(ctx, "Error sending data export %s: %v", job.ID, err)
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"ncobase/core/system/service"
+	"ncobase/core/system/structs"
+	"ncobase/internal/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// FeatureFlagHandlerInterface defines feature flag operations
+type FeatureFlagHandlerInterface interface {
+	List(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type featureFlagHandler struct {
+	s *service.Service
+}
+
+// NewFeatureFlagHandler creates feature flag handler
+func NewFeatureFlagHandler(svc *service.Service) FeatureFlagHandlerInterface {
+	return &featureFlagHandler{s: svc}
+}
+
+// List returns the flags of a space, or the global flags
+//
+// @Summary List feature flags
+// @Description List the feature flags stored for a space, or the global flags when no space is given
+// @Tags admin
+// @Produce json
+// @Param space_id query string false "Space ID"
+// @Success 200 {array} structs.FeatureFlag "Feature flags"
+// @Failure 500 {object} resp.Exception "Internal server error"
+// @Security Bearer
+// @Router /sys/admin/feature-flags [get]
+func (h *featureFlagHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	flags, err := h.s.FeatureFlag.List(ctx, c.Query("space_id"))
+	if err != nil {
+		logger.Errorf(ctx, "Failed to list feature flags: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to retrieve feature flags"))
+		return
+	}
+
+	resp.Success(c.Writer, flags)
+}
+
+// Update creates or updates a flag without a restart
+//
+// @Summary Update feature flag
+// @Description Turn a flag on or off, or roll it out to a percentage of users, for a space or globally
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param flag path string true "Flag key"
+// @Param body body structs.UpdateFeatureFlagBody true "Flag value"
+// @Success 200 {object} structs.FeatureFlag "Feature flag"
+// @Failure 400 {object} resp.Exception "Bad request"
+// @Failure 500 {object} resp.Exception "Internal server error"
+// @Security Bearer
+// @Router /sys/admin/feature-flags/{flag} [put]
+func (h *featureFlagHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var body structs.UpdateFeatureFlagBody
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, &body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid feature flag", validationErrors))
+		return
+	}
+
+	flag, err := h.s.FeatureFlag.Set(ctx, c.Param("flag"), &body)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to update feature flag: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to update feature flag"))
+		return
+	}
+
+	resp.Success(c.Writer, flag)
+}
+
+// Delete removes a flag from a space, or the global value
+//
+// @Summary Delete feature flag
+// @Description Remove a flag from a space so the global value applies, or remove the global value
+// @Tags admin
+// @Produce json
+// @Param flag path string true "Flag key"
+// @Param space_id query string false "Space ID"
+// @Success 200 {object} resp.Exception "Success"
+// @Failure 500 {object} resp.Exception "Internal server error"
+// @Security Bearer
+// @Router /sys/admin/feature-flags/{flag} [delete]
+func (h *featureFlagHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if err := h.s.FeatureFlag.Delete(ctx, c.Query("space_id"), c.Param("flag")); err != nil {
+		logger.Errorf(ctx, "Failed to delete feature flag: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to delete feature flag"))
+		return
+	}
+
+	resp.Success(c.Writer, nil)
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"ncobase/core/system/service"
+	"ncobase/core/system/structs"
+	"ncobase/internal/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// MaintenanceHandlerInterface defines maintenance mode operations
+type MaintenanceHandlerInterface interface {
+	Get(c *gin.Context)
+	Update(c *gin.Context)
+}
+
+type maintenanceHandler struct {
+	s *service.Service
+}
+
+// NewMaintenanceHandler creates maintenance handler
+func NewMaintenanceHandler(svc *service.Service) MaintenanceHandlerInterface {
+	return &maintenanceHandler{s: svc}
+}
+
+// Get returns the maintenance state
+//
+// @Summary Get maintenance mode
+// @Description Get the current maintenance mode and allowlist
+// @Tags admin
+// @Produce json
+// @Success 200 {object} structs.MaintenanceState "Maintenance state"
+// @Failure 500 {object} resp.Exception "Internal server error"
+// @Security Bearer
+// @Router /admin/maintenance [get]
+func (h *maintenanceHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	state, err := h.s.Maintenance.Get(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to get maintenance state: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to retrieve maintenance state"))
+		return
+	}
+
+	resp.Success(c.Writer, state)
+}
+
+// Update switches maintenance mode without a restart
+//
+// @Summary Update maintenance mode
+// @Description Take the API read-only or offline, or bring it back, on all instances
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param body body structs.UpdateMaintenanceBody true "Maintenance state"
+// @Success 200 {object} structs.MaintenanceState "Maintenance state"
+// @Failure 400 {object} resp.Exception "Bad request"
+// @Failure 500 {object} resp.Exception "Internal server error"
+// @Security Bearer
+// @Router /admin/maintenance [put]
+func (h *maintenanceHandler) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var body structs.UpdateMaintenanceBody
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, &body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid maintenance state", validationErrors))
+		return
+	}
+
+	state, err := h.s.Maintenance.Set(ctx, &body)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to update maintenance state: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to update maintenance state"))
+		return
+	}
+
+	resp.Success(c.Writer, state)
+}
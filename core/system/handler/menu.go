@@ -1,11 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"ncobase/core/system/service"
 	"ncobase/core/system/structs"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,6 +20,7 @@ type MenuHandlerInterface interface {
 	GetNavigationMenus(c *gin.Context)
 	GetMenuTree(c *gin.Context)
 	GetUserAuthorizedMenus(c *gin.Context)
+	GetSpaceMenuTree(c *gin.Context)
 	MoveMenu(c *gin.Context)
 	ReorderMenus(c *gin.Context)
 	ToggleMenuStatus(c *gin.Context)
@@ -246,6 +248,39 @@ func (h *menuHandler) GetUserAuthorizedMenus(c *gin.Context) {
 	resp.Success(c.Writer, result)
 }
 
+// GetSpaceMenuTree handles retrieving the menu tree of a space.
+//
+// @Summary Get space menu tree
+// @Description Retrieve the menu tree of a space, customized by the space and filtered by the permissions of the current user.
+// @Tags sys
+// @Produce json
+// @Param spaceId path string true "Space ID"
+// @Param params query structs.SpaceMenuParams true "SpaceMenuParams parameters"
+// @Success 200 {array} structs.ReadMenu "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 403 {object} resp.Exception "forbidden"
+// @Router /sys/menus/spaces/{spaceId} [get]
+// @Security Bearer
+func (h *menuHandler) GetSpaceMenuTree(c *gin.Context) {
+	params := &structs.SpaceMenuParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.s.Menu.GetSpaceMenuTree(c.Request.Context(), c.Param("spaceId"), params)
+	if errors.Is(err, service.ErrSpaceMenuDenied) {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	resp.Success(c.Writer, result)
+}
+
 // MoveMenu handles moving a menu to a new parent and/or changing its order.
 //
 // @Summary Move menu
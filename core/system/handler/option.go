@@ -3,9 +3,9 @@ package handler
 import (
 	"ncobase/core/system/service"
 	"ncobase/core/system/structs"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"ncobase/core/system/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// PluginHandlerInterface represents the plugin handler interface.
+type PluginHandlerInterface interface {
+	List(c *gin.Context)
+}
+
+// pluginHandler represents the plugin handler.
+type pluginHandler struct {
+	s *service.Service
+}
+
+// NewPluginHandler creates new plugin handler.
+func NewPluginHandler(svc *service.Service) PluginHandlerInterface {
+	return &pluginHandler{s: svc}
+}
+
+// List handles listing the loaded plugins.
+//
+// @Summary List plugins
+// @Description List the loaded modules and plugins with their manifests: version, routes, required permissions, events and configuration.
+// @Tags sys
+// @Produce json
+// @Success 200 {array} manifest.PluginManifest "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /sys/admin/plugins [get]
+// @Security Bearer
+func (h *pluginHandler) List(c *gin.Context) {
+	result, err := h.s.Plugin.List(c.Request.Context())
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
@@ -4,18 +4,32 @@ import "ncobase/core/system/service"
 
 // Handler represents the system handler.
 type Handler struct {
-	Menu       MenuHandlerInterface
-	Dictionary DictionaryHandlerInterface
-	Option     OptionHandlerInterface
-	Admin      AdminHandlerInterface
+	Menu        MenuHandlerInterface
+	Dictionary  DictionaryHandlerInterface
+	Option      OptionHandlerInterface
+	Admin       AdminHandlerInterface
+	Maintenance MaintenanceHandlerInterface
+	FeatureFlag FeatureFlagHandlerInterface
+	Email       EmailTemplateHandlerInterface
+	Plugin      PluginHandlerInterface
+	Search      SearchHandlerInterface
+	DataExport  DataExportHandlerInterface
+	DataErasure DataErasureHandlerInterface
 }
 
 // New creates new system handler.
 func New(svc *service.Service) *Handler {
 	return &Handler{
-		Menu:       NewMenuHandler(svc),
-		Dictionary: NewDictionaryHandler(svc),
-		Option:     NewOptionHandler(svc),
-		Admin:      NewAdminHandler(svc),
+		Menu:        NewMenuHandler(svc),
+		Dictionary:  NewDictionaryHandler(svc),
+		Option:      NewOptionHandler(svc),
+		Admin:       NewAdminHandler(svc),
+		Maintenance: NewMaintenanceHandler(svc),
+		FeatureFlag: NewFeatureFlagHandler(svc),
+		Email:       NewEmailTemplateHandler(svc),
+		Plugin:      NewPluginHandler(svc),
+		Search:      NewSearchHandler(svc),
+		DataExport:  NewDataExportHandler(svc),
+		DataErasure: NewDataErasureHandler(svc),
 	}
 }
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"ncobase/core/system/service"
+	"ncobase/core/system/structs"
+	"ncobase/internal/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// SearchHandlerInterface represents the search handler interface.
+type SearchHandlerInterface interface {
+	Search(c *gin.Context)
+}
+
+// searchHandler represents the search handler.
+type searchHandler struct {
+	s *service.Service
+}
+
+// NewSearchHandler creates new search handler.
+func NewSearchHandler(svc *service.Service) SearchHandlerInterface {
+	return &searchHandler{s: svc}
+}
+
+// Search handles searching across modules.
+//
+// @Summary Search
+// @Description Search spaces, files, topics and taxonomies of the current space at once, ranked by relevance.
+// @Tags sys
+// @Produce json
+// @Param params query structs.SearchParams true "Search parameters"
+// @Success 200 {object} structs.SearchResult "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /sys/search [get]
+// @Security Bearer
+func (h *searchHandler) Search(c *gin.Context) {
+	params := &structs.SearchParams{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, params); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	result, err := h.s.Search.Search(c.Request.Context(), params)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
@@ -54,10 +54,20 @@ func (svc *adminService) GetSystemHealth(ctx context.Context) (*structs.SystemHe
 		Status:      "healthy",
 		Message:     "Database connection active",
 		LastChecked: time.Now(),
-		Metrics: map[string]string{
-			"connections":    "5/100",
-			"avg_query_time": "15ms",
-		},
+		Metrics:     map[string]string{},
+	}
+
+	// Connection pool statistics
+	for name, stats := range svc.s.d.Stats() {
+		dbHealth.Metrics[name+"_open"] = fmt.Sprintf("%d/%d", stats.Open, stats.MaxOpen)
+		dbHealth.Metrics[name+"_in_use"] = strconv.Itoa(stats.InUse)
+		dbHealth.Metrics[name+"_idle"] = strconv.Itoa(stats.Idle)
+		dbHealth.Metrics[name+"_wait_count"] = strconv.FormatInt(stats.WaitCount, 10)
+		dbHealth.Metrics[name+"_wait_duration"] = stats.WaitDuration
+		if stats.Saturated() {
+			dbHealth.Status = "warning"
+			dbHealth.Message = "Database connection pool exhausted"
+		}
 	}
 
 	// Check database connectivity
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"ncobase/core/system/data"
+	"ncobase/core/system/data/repository"
+	"ncobase/core/system/structs"
+	"strings"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/messaging/email"
+	"github.com/ncobase/ncore/validation/validator"
+)
+
+var (
+	// ErrTemplateSyntax is returned for a template with a malformed placeholder
+	ErrTemplateSyntax = errors.New("invalid template syntax")
+	// ErrUndefinedVariable is returned by a strict render for a placeholder missing from the data
+	ErrUndefinedVariable = errors.New("undefined template variable")
+	// ErrTemplateDisabled is returned when rendering a disabled template
+	ErrTemplateDisabled = errors.New("email template is disabled")
+)
+
+// EmailSender delivers a rendered email
+type EmailSender interface {
+	Send(ctx context.Context, to string, message *structs.RenderedEmail) error
+}
+
+// EmailTemplateServiceInterface represents the email template service interface.
+type EmailTemplateServiceInterface interface {
+	Create(ctx context.Context, body *structs.CreateEmailTemplateBody) (*structs.ReadEmailTemplate, error)
+	Update(ctx context.Context, body *structs.UpdateEmailTemplateBody) (*structs.ReadEmailTemplate, error)
+	Get(ctx context.Context, id string) (*structs.ReadEmailTemplate, error)
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context, params *structs.ListEmailTemplateParams) ([]*structs.ReadEmailTemplate, error)
+	Render(ctx context.Context, name string, body *structs.RenderEmailTemplateBody) (*structs.RenderedEmail, error)
+	Preview(ctx context.Context, id string, body *structs.RenderEmailTemplateBody) (*structs.RenderedEmail, error)
+	Send(ctx context.Context, name string, body *structs.SendEmailTemplateBody) error
+	SetSender(sender EmailSender)
+}
+
+// emailTemplateService stores named templates per space and renders them.
+// A space template overrides the shared template of the same name.
+type emailTemplateService struct {
+	repo   repository.EmailTemplateRepositoryInterface
+	sender EmailSender
+}
+
+// NewEmailTemplateService creates a new email template service.
+func NewEmailTemplateService(d *data.Data) EmailTemplateServiceInterface {
+	return &emailTemplateService{
+		repo:   repository.NewEmailTemplateRepository(d),
+		sender: providerEmailSender{},
+	}
+}
+
+// SetSender replaces the sender used by Send, e.g. with a queue or a test double
+func (s *emailTemplateService) SetSender(sender EmailSender) {
+	if sender == nil {
+		sender = providerEmailSender{}
+	}
+	s.sender = sender
+}
+
+// Create creates a new email template.
+func (s *emailTemplateService) Create(ctx context.Context, body *structs.CreateEmailTemplateBody) (*structs.ReadEmailTemplate, error) {
+	if validator.IsEmpty(body.Name) {
+		return nil, errors.New(ecode.FieldIsRequired("name"))
+	}
+	if err := validateEmailTemplate(&body.EmailTemplateBody); err != nil {
+		return nil, err
+	}
+
+	row, err := s.repo.Create(ctx, &body.EmailTemplateBody)
+	if err := handleEntError(ctx, "EmailTemplate", err); err != nil {
+		return nil, err
+	}
+
+	return repository.SerializeEmailTemplate(row), nil
+}
+
+// Update updates an existing email template.
+func (s *emailTemplateService) Update(ctx context.Context, body *structs.UpdateEmailTemplateBody) (*structs.ReadEmailTemplate, error) {
+	if validator.IsEmpty(body.ID) {
+		return nil, errors.New(ecode.FieldIsRequired("id"))
+	}
+	if err := validateEmailTemplate(&body.EmailTemplateBody); err != nil {
+		return nil, err
+	}
+
+	row, err := s.repo.Update(ctx, body)
+	if err := handleEntError(ctx, "EmailTemplate", err); err != nil {
+		return nil, err
+	}
+
+	return repository.SerializeEmailTemplate(row), nil
+}
+
+// Get retrieves an email template by ID.
+func (s *emailTemplateService) Get(ctx context.Context, id string) (*structs.ReadEmailTemplate, error) {
+	row, err := s.repo.GetByID(ctx, id)
+	if err := handleEntError(ctx, "EmailTemplate", err); err != nil {
+		return nil, err
+	}
+
+	return repository.SerializeEmailTemplate(row), nil
+}
+
+// Delete deletes an email template.
+func (s *emailTemplateService) Delete(ctx context.Context, id string) error {
+	if validator.IsEmpty(id) {
+		return errors.New(ecode.FieldIsRequired("id"))
+	}
+	return handleEntError(ctx, "EmailTemplate", s.repo.Delete(ctx, id))
+}
+
+// List returns the email templates of a space, the current space by default.
+func (s *emailTemplateService) List(ctx context.Context, params *structs.ListEmailTemplateParams) ([]*structs.ReadEmailTemplate, error) {
+	if params == nil {
+		params = &structs.ListEmailTemplateParams{}
+	}
+	if params.SpaceID == "" {
+		params.SpaceID = ctxutil.GetSpaceID(ctx)
+	}
+
+	rows, err := s.repo.List(ctx, params)
+	if err := handleEntError(ctx, "EmailTemplate", err); err != nil {
+		return nil, err
+	}
+
+	return repository.SerializeEmailTemplates(rows), nil
+}
+
+// Render renders a template of the current space by name,
+// falling back to the shared template of that name.
+func (s *emailTemplateService) Render(ctx context.Context, name string, body *structs.RenderEmailTemplateBody) (*structs.RenderedEmail, error) {
+	if validator.IsEmpty(name) {
+		return nil, errors.New(ecode.FieldIsRequired("name"))
+	}
+
+	tpl, err := s.lookup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderEmail(tpl, body)
+}
+
+// Preview renders a template by ID, disabled templates included
+func (s *emailTemplateService) Preview(ctx context.Context, id string, body *structs.RenderEmailTemplateBody) (*structs.RenderedEmail, error) {
+	tpl, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderEmail(tpl, body)
+}
+
+// Send renders a template once and delivers it to every recipient
+func (s *emailTemplateService) Send(ctx context.Context, name string, body *structs.SendEmailTemplateBody) error {
+	if body == nil || len(body.To) == 0 {
+		return errors.New(ecode.FieldIsRequired("to"))
+	}
+
+	message, err := s.Render(ctx, name, &body.RenderEmailTemplateBody)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, to := range body.To {
+		if err := s.sender.Send(ctx, to, message); err != nil {
+			logger.Errorf(ctx, "Failed to send email template %s to %s: %v", name, to, err)
+			errs = append(errs, fmt.Errorf("%s: %w", to, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// lookup returns the enabled template of a name for the current space or the shared one
+func (s *emailTemplateService) lookup(ctx context.Context, name string) (*structs.ReadEmailTemplate, error) {
+	spaceID := ctxutil.GetSpaceID(ctx)
+
+	row, err := s.repo.GetByName(ctx, spaceID, name)
+	if repository.IsNotFound(err) && spaceID != "" {
+		row, err = s.repo.GetByName(ctx, "", name)
+	}
+	if err := handleEntError(ctx, "EmailTemplate", err); err != nil {
+		return nil, err
+	}
+	if row.Disabled {
+		return nil, ErrTemplateDisabled
+	}
+
+	return repository.SerializeEmailTemplate(row), nil
+}
+
+// validateEmailTemplate checks the placeholders of a template before it is stored
+func validateEmailTemplate(body *structs.EmailTemplateBody) error {
+	if _, err := parseTemplate(body.Subject); err != nil {
+		return fmt.Errorf("subject: %w", err)
+	}
+	if _, err := parseTemplate(body.Body); err != nil {
+		return fmt.Errorf("body: %w", err)
+	}
+	return nil
+}
+
+// renderEmail renders the subject and body of a template
+func renderEmail(tpl *structs.ReadEmailTemplate, body *structs.RenderEmailTemplateBody) (*structs.RenderedEmail, error) {
+	if body == nil {
+		body = &structs.RenderEmailTemplateBody{}
+	}
+
+	subject, err := renderTemplate(tpl.Subject, body.Data, body.Strict, false)
+	if err != nil {
+		return nil, fmt.Errorf("subject: %w", err)
+	}
+	content, err := renderTemplate(tpl.Body, body.Data, body.Strict, tpl.HTML)
+	if err != nil {
+		return nil, fmt.Errorf("body: %w", err)
+	}
+
+	return &structs.RenderedEmail{
+		Template: tpl.Name,
+		// Line breaks in a subject would start new mail headers
+		Subject: strings.Join(strings.Fields(subject), " "),
+		Body:    content,
+		HTML:    tpl.HTML,
+	}, nil
+}
+
+// templatePart is a literal text, or a variable path when isVar is set
+type templatePart struct {
+	text  string
+	isVar bool
+}
+
+// parseTemplate splits a template into literal text and {{ variable }} placeholders.
+// Placeholders only hold a dotted variable path; there are no expressions,
+// functions or nested templates, so data can never be evaluated as template code.
+func parseTemplate(src string) ([]templatePart, error) {
+	var parts []templatePart
+	for {
+		start := strings.Index(src, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(src[start+2:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unclosed placeholder", ErrTemplateSyntax)
+		}
+
+		path := strings.TrimSpace(src[start+2 : start+2+end])
+		if !isVariablePath(path) {
+			return nil, fmt.Errorf("%w: invalid placeholder {{%s}}", ErrTemplateSyntax, src[start+2:start+2+end])
+		}
+
+		if start > 0 {
+			parts = append(parts, templatePart{text: src[:start]})
+		}
+		parts = append(parts, templatePart{text: path, isVar: true})
+		src = src[start+2+end+2:]
+	}
+	if src != "" {
+		parts = append(parts, templatePart{text: src})
+	}
+	return parts, nil
+}
+
+// isVariablePath reports whether s is a dotted path of identifiers, e.g. user.name
+func isVariablePath(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, seg := range strings.Split(s, ".") {
+		if seg == "" {
+			return false
+		}
+		for i, r := range seg {
+			switch {
+			case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			case i > 0 && r >= '0' && r <= '9':
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// renderTemplate substitutes the placeholders of a template.
+// Values are inserted as text and never parsed again. Missing variables render
+// empty, or fail with ErrUndefinedVariable when strict.
+func renderTemplate(src string, vars map[string]any, strict, escapeHTML bool) (string, error) {
+	parts, err := parseTemplate(src)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, part := range parts {
+		if !part.isVar {
+			b.WriteString(part.text)
+			continue
+		}
+
+		value, ok := lookupVariable(vars, part.text)
+		if !ok {
+			if strict {
+				return "", fmt.Errorf("%w: %s", ErrUndefinedVariable, part.text)
+			}
+			continue
+		}
+
+		text := ""
+		if value != nil {
+			text = fmt.Sprint(value)
+		}
+		if escapeHTML {
+			text = html.EscapeString(text)
+		}
+		b.WriteString(text)
+	}
+	return b.String(), nil
+}
+
+// lookupVariable resolves a dotted path through nested maps
+func lookupVariable(vars map[string]any, path string) (any, bool) {
+	var current any = vars
+	for _, seg := range strings.Split(path, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[seg]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// providerEmailSender sends through the email provider configured for the application
+type providerEmailSender struct{}
+
+// Send sends a rendered email with the configured provider
+func (providerEmailSender) Send(ctx context.Context, to string, message *structs.RenderedEmail) error {
+	_, err := ctxutil.SendEmailWithTemplate(ctx, to, email.Template{
+		Subject:  message.Subject,
+		Template: message.Template,
+		Data: map[string]any{
+			"body": message.Body,
+			"html": message.HTML,
+		},
+	})
+	return err
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"ncobase/core/system/data/ent"
+	"ncobase/core/system/data/repository"
+	"ncobase/core/system/structs"
+
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+// namedTemplates serves templates by space and name
+type namedTemplates struct {
+	repository.EmailTemplateRepositoryInterface
+	rows []*ent.EmailTemplate
+}
+
+func (r *namedTemplates) GetByName(_ context.Context, spaceID, name string) (*ent.EmailTemplate, error) {
+	for _, row := range r.rows {
+		if row.SpaceID == spaceID && row.Name == name {
+			return row, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+// sentEmails records the emails handed to the sender, failing for one recipient
+type sentEmails struct {
+	to     []string
+	failTo string
+}
+
+func (s *sentEmails) Send(_ context.Context, to string, message *structs.RenderedEmail) error {
+	if to == s.failTo {
+		return errors.New("mailbox unavailable")
+	}
+	s.to = append(s.to, to+": "+message.Subject)
+	return nil
+}
+
+func newTestTemplates(rows ...*ent.EmailTemplate) *emailTemplateService {
+	return &emailTemplateService{repo: &namedTemplates{rows: rows}, sender: &sentEmails{}}
+}
+
+var quotaWarning = &ent.EmailTemplate{
+	ID: "t1", Name: "quota-warning",
+	Subject: "{{ space.name }} is at {{ usage }}%",
+	Body:    "Hi {{user.name}},\nyour space {{ space.name }} uses {{ usage }}% of its quota.",
+}
+
+func TestRenderWithFullAndPartialData(t *testing.T) {
+	s := newTestTemplates(quotaWarning)
+	ctx := context.Background()
+
+	full := map[string]any{"user": map[string]any{"name": "Ada"}, "space": map[string]any{"name": "Acme"}, "usage": 92}
+	email, err := s.Render(ctx, "quota-warning", &structs.RenderEmailTemplateBody{Data: full})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if email.Subject != "Acme is at 92%" || email.Body != "Hi Ada,\nyour space Acme uses 92% of its quota." {
+		t.Fatalf("rendered %q / %q", email.Subject, email.Body)
+	}
+
+	// missing variables render empty unless strict
+	partial := map[string]any{"space": map[string]any{"name": "Acme"}}
+	email, err = s.Render(ctx, "quota-warning", &structs.RenderEmailTemplateBody{Data: partial})
+	if err != nil {
+		t.Fatalf("Render partial: %v", err)
+	}
+	if email.Subject != "Acme is at %" || !strings.HasPrefix(email.Body, "Hi ,") {
+		t.Fatalf("rendered partial %q / %q", email.Subject, email.Body)
+	}
+
+	_, err = s.Render(ctx, "quota-warning", &structs.RenderEmailTemplateBody{Data: partial, Strict: true})
+	if !errors.Is(err, ErrUndefinedVariable) || !strings.Contains(err.Error(), "usage") {
+		t.Fatalf("strict render = %v, want the undefined variable usage", err)
+	}
+	if _, err := s.Render(ctx, "quota-warning", &structs.RenderEmailTemplateBody{Data: full, Strict: true}); err != nil {
+		t.Fatalf("strict render with full data: %v", err)
+	}
+}
+
+func TestRenderDoesNotEvaluateData(t *testing.T) {
+	s := newTestTemplates(&ent.EmailTemplate{
+		ID: "t1", Name: "welcome", HTML: true,
+		Subject: "Welcome {{ name }}",
+		Body:    "<p>Hello {{ name }}</p>",
+	})
+
+	email, err := s.Render(context.Background(), "welcome", &structs.RenderEmailTemplateBody{
+		Data: map[string]any{"name": "{{ secret }}<script>\r\nBcc: x@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(email.Body, "{{ secret }}&lt;script&gt;") {
+		t.Fatalf("body %q, want the value inserted as escaped text", email.Body)
+	}
+	if strings.ContainsAny(email.Subject, "\r\n") {
+		t.Fatalf("subject %q keeps line breaks", email.Subject)
+	}
+}
+
+func TestTemplateSyntaxIsValidated(t *testing.T) {
+	for _, src := range []string{"Hi {{ name", "{{ }}", "{{ .Exec \"rm\" }}", "{{ user..name }}", "{{ 1st }}"} {
+		if err := validateEmailTemplate(&structs.EmailTemplateBody{Body: src}); !errors.Is(err, ErrTemplateSyntax) {
+			t.Errorf("template %q = %v, want a syntax error", src, err)
+		}
+	}
+	if err := validateEmailTemplate(&structs.EmailTemplateBody{Subject: "{{ user.first_name2 }}", Body: "no placeholders"}); err != nil {
+		t.Fatalf("valid template rejected: %v", err)
+	}
+}
+
+func TestRenderLooksUpSpaceThenSharedTemplate(t *testing.T) {
+	s := newTestTemplates(
+		&ent.EmailTemplate{ID: "t1", Name: "welcome", Subject: "shared"},
+		&ent.EmailTemplate{ID: "t2", Name: "welcome", SpaceID: "s1", Subject: "space"},
+		&ent.EmailTemplate{ID: "t3", Name: "retired", Subject: "old", Disabled: true},
+	)
+
+	for spaceID, want := range map[string]string{"s1": "space", "s2": "shared"} {
+		email, err := s.Render(ctxutil.SetSpaceID(context.Background(), spaceID), "welcome", nil)
+		if err != nil || email.Subject != want {
+			t.Errorf("space %s rendered %v, %v, want the %s template", spaceID, email, err, want)
+		}
+	}
+	if _, err := s.Render(context.Background(), "retired", nil); !errors.Is(err, ErrTemplateDisabled) {
+		t.Fatalf("disabled template rendered: %v", err)
+	}
+}
+
+func TestSendDeliversToEachRecipient(t *testing.T) {
+	s := newTestTemplates(quotaWarning)
+	sender := &sentEmails{failTo: "b@example.com"}
+	s.SetSender(sender)
+
+	err := s.Send(context.Background(), "quota-warning", &structs.SendEmailTemplateBody{
+		To:                      []string{"a@example.com", "b@example.com", "c@example.com"},
+		RenderEmailTemplateBody: structs.RenderEmailTemplateBody{Data: map[string]any{"usage": 80}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "b@example.com") {
+		t.Fatalf("Send = %v, want the failed recipient reported", err)
+	}
+	if len(sender.to) != 2 || sender.to[1] != "c@example.com: is at 80%" {
+		t.Fatalf("sent %q, want the other recipients delivered", sender.to)
+	}
+
+	if err := s.Send(context.Background(), "quota-warning", &structs.SendEmailTemplateBody{}); err == nil {
+		t.Fatal("sent without recipients")
+	}
+}
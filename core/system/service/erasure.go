@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ncobase/core/system/data"
+	"ncobase/core/system/structs"
+	"ncobase/core/system/wrapper"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+var (
+	// ErrDataErasureNotFound is returned for a user without erasure
+	ErrDataErasureNotFound = errors.New("data erasure not found")
+	// ErrDataErasureForbidden is returned when a non-admin starts or reads an erasure
+	ErrDataErasureForbidden = errors.New("you don't have permission to erase user data")
+)
+
+const (
+	// dataErasureKey holds the erasure of one user
+	dataErasureKey = "ncse_system:erasures:%s"
+	// dataErasureRetention is how long the report of an erasure is kept
+	dataErasureRetention = 30 * 24 * time.Hour
+	// dataErasureStaleAfter is how long a running erasure may go without progress
+	// before it is taken as interrupted and resumed by the next start
+	dataErasureStaleAfter = 15 * time.Minute
+)
+
+// defaultAnonymizedSections are kept anonymized rather than deleted: activity is the audit
+// trail and payments are financial records, both must outlive the user
+var defaultAnonymizedSections = []string{"activity", "payments"}
+
+// DataErasureConfig configures which sections are anonymized instead of deleted,
+// and which may be skipped when their module is not loaded
+type DataErasureConfig struct {
+	Anonymize []string // Sections whose records are kept without personal data
+	Optional  []string // Sections of modules the deployment does without, skipped when not loaded
+}
+
+// DataErasureConfigFromViper reads the data erasure config from erasure.*
+func DataErasureConfigFromViper(v *viper.Viper) *DataErasureConfig {
+	cfg := &DataErasureConfig{Anonymize: slices.Clone(defaultAnonymizedSections)}
+	if v != nil && v.IsSet("erasure.anonymize") {
+		cfg.Anonymize = v.GetStringSlice("erasure.anonymize")
+	}
+	if v != nil {
+		cfg.Optional = v.GetStringSlice("erasure.optional")
+	}
+	return cfg
+}
+
+// DataErasureServiceInterface erases all data of a user across modules
+type DataErasureServiceInterface interface {
+	Start(ctx context.Context, userID string) (*structs.DataErasure, error)
+	Get(ctx context.Context, userID string) (*structs.DataErasure, error)
+	SetConfig(cfg *DataErasureConfig)
+}
+
+// dataErasureService runs erasures in the background, one module section after another.
+// The erasure of a user is stored in Redis after every section, so a failed or interrupted
+// erasure is resumed where it stopped; without Redis it is kept in memory.
+type dataErasureService struct {
+	erasers  func() map[string]wrapper.DataEraser
+	sections []string
+	rc       *redis.Client
+
+	mu      sync.Mutex
+	cfg     DataErasureConfig
+	memory  map[string][]byte
+	running map[string]bool
+}
+
+// NewDataErasureService creates a new data erasure service
+func NewDataErasureService(d *data.Data, esw *wrapper.EraseServiceWrapper) DataErasureServiceInterface {
+	rc, _ := d.GetRedis().(*redis.Client)
+	return newDataErasureService(esw.Erasers, wrapper.EraseSections(), rc, DataErasureConfigFromViper(nil))
+}
+
+// newDataErasureService creates a data erasure service running the given erasers in section order
+func newDataErasureService(erasers func() map[string]wrapper.DataEraser, sections []string, rc *redis.Client, cfg *DataErasureConfig) *dataErasureService {
+	return &dataErasureService{
+		erasers:  erasers,
+		sections: sections,
+		rc:       rc,
+		cfg:      *cfg,
+		memory:   make(map[string][]byte),
+		running:  make(map[string]bool),
+	}
+}
+
+// SetConfig sets the anonymize policy, applied to sections not erased yet
+func (s *dataErasureService) SetConfig(cfg *DataErasureConfig) {
+	if cfg == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = *cfg
+}
+
+// Start erases the data of a user in the background. Starting an erasure that is running
+// returns it; starting one that failed or was interrupted resumes it, and a completed
+// erasure is returned as is unless a section was skipped.
+func (s *dataErasureService) Start(ctx context.Context, userID string) (*structs.DataErasure, error) {
+	if !ctxutil.GetUserIsAdmin(ctx) {
+		return nil, ErrDataErasureForbidden
+	}
+	if userID == "" {
+		return nil, errors.New(ecode.FieldIsRequired("user_id"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.load(ctx, userID)
+	if err != nil && !errors.Is(err, ErrDataErasureNotFound) {
+		return nil, err
+	}
+	if s.running[userID] || (job != nil && !s.resumable(job)) {
+		return job, nil
+	}
+
+	now := time.Now().UnixMilli()
+	if job == nil {
+		job = &structs.DataErasure{UserID: userID, CreatedAt: now}
+		for _, section := range s.sections {
+			job.Sections = append(job.Sections, &structs.DataErasureSection{Section: section})
+		}
+	}
+	job.RequestedBy = ctxutil.GetUserID(ctx)
+	job.Status = structs.DataErasurePending
+	job.Error = ""
+	job.UpdatedAt = now
+	job.CompletedAt = 0
+	for _, section := range job.Sections {
+		if section.Status == structs.DataErasureCompleted {
+			continue
+		}
+		section.Status = structs.DataErasurePending
+		section.Error = ""
+		section.Action = structs.DataErasureDelete
+		if slices.Contains(s.cfg.Anonymize, section.Section) {
+			section.Action = structs.DataErasureAnonymize
+		}
+	}
+	if err := s.save(ctx, job); err != nil {
+		return nil, err
+	}
+	s.running[userID] = true
+
+	view := cloneDataErasure(job)
+	// The erasure outlives the request, but keeps the admin in context for the module access checks
+	go s.run(context.WithoutCancel(ctx), job)
+
+	return view, nil
+}
+
+// Get returns the erasure of a user with its report
+func (s *dataErasureService) Get(ctx context.Context, userID string) (*structs.DataErasure, error) {
+	if !ctxutil.GetUserIsAdmin(ctx) {
+		return nil, ErrDataErasureForbidden
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(ctx, userID)
+}
+
+// resumable reports whether an erasure not running on this instance should be run again
+func (s *dataErasureService) resumable(job *structs.DataErasure) bool {
+	switch job.Status {
+	case structs.DataErasureCompleted:
+		for _, section := range job.Sections {
+			if section.Status == structs.DataErasureSkipped {
+				return true
+			}
+		}
+		return false
+	case structs.DataErasurePending, structs.DataErasureRunning:
+		// Still making progress on another instance
+		return time.Since(time.UnixMilli(job.UpdatedAt)) > dataErasureStaleAfter
+	default:
+		return true
+	}
+}
+
+// run erases the sections in order, saving the erasure after each one.
+// A failed section stops the run so later sections, the account last, are not erased before it,
+// and so does a section whose module is not loaded unless it is optional.
+func (s *dataErasureService) run(ctx context.Context, job *structs.DataErasure) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, job.UserID)
+		s.mu.Unlock()
+	}()
+
+	erasers := s.erasers()
+	job.Status = structs.DataErasureRunning
+	s.update(ctx, job)
+
+	for _, section := range job.Sections {
+		if section.Status == structs.DataErasureCompleted {
+			continue
+		}
+
+		eraser := erasers[section.Section]
+		if eraser == nil {
+			section.UpdatedAt = time.Now().UnixMilli()
+			if s.optional(section.Section) {
+				section.Status = structs.DataErasureSkipped
+				s.update(ctx, job)
+				continue
+			}
+			logger.Errorf(ctx, "Data erasure of user %s: section %s has no loaded module", job.UserID, section.Section)
+			section.Status = structs.DataErasureFailed
+			section.Error = "module not loaded"
+			job.Status = structs.DataErasureFailed
+			job.Error = fmt.Sprintf("section %s was not erased as its module is not loaded, start the erasure again once it is", section.Section)
+			s.update(ctx, job)
+			return
+		}
+
+		deleted, anonymized, err := eraser.EraseUserData(ctx, job.UserID, section.Action == structs.DataErasureAnonymize)
+		section.Deleted += deleted
+		section.Anonymized += anonymized
+		section.UpdatedAt = time.Now().UnixMilli()
+		if err != nil {
+			logger.Errorf(ctx, "Data erasure of user %s: section %s failed: %v", job.UserID, section.Section, err)
+			section.Status = structs.DataErasureFailed
+			section.Error = err.Error()
+			job.Status = structs.DataErasureFailed
+			job.Error = fmt.Sprintf("section %s failed, start the erasure again to resume", section.Section)
+			s.update(ctx, job)
+			return
+		}
+		section.Status = structs.DataErasureCompleted
+		s.update(ctx, job)
+	}
+
+	job.Status = structs.DataErasureCompleted
+	job.CompletedAt = time.Now().UnixMilli()
+	s.update(ctx, job)
+	logger.Infof(ctx, "Data erasure of user %s completed: %d deleted, %d anonymized", job.UserID, job.Deleted, job.Anonymized)
+}
+
+// optional reports whether a section may be skipped when its module is not loaded
+func (s *dataErasureService) optional(section string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Contains(s.cfg.Optional, section)
+}
+
+// update totals the sections of an erasure and saves it, a failed save is retried by the next section
+func (s *dataErasureService) update(ctx context.Context, job *structs.DataErasure) {
+	job.Deleted, job.Anonymized = 0, 0
+	for _, section := range job.Sections {
+		job.Deleted += section.Deleted
+		job.Anonymized += section.Anonymized
+	}
+	job.UpdatedAt = time.Now().UnixMilli()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.save(ctx, job); err != nil {
+		logger.Errorf(ctx, "Failed to save data erasure of user %s: %v", job.UserID, err)
+	}
+}
+
+// load reads the erasure of a user, the caller holds s.mu
+func (s *dataErasureService) load(ctx context.Context, userID string) (*structs.DataErasure, error) {
+	var raw []byte
+	if s.rc != nil {
+		var err error
+		raw, err = s.rc.Get(ctx, fmt.Sprintf(dataErasureKey, userID)).Bytes()
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrDataErasureNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read data erasure: %w", err)
+		}
+	} else if raw = s.memory[userID]; raw == nil {
+		return nil, ErrDataErasureNotFound
+	}
+
+	job := &structs.DataErasure{}
+	if err := json.Unmarshal(raw, job); err != nil {
+		return nil, fmt.Errorf("failed to decode data erasure: %w", err)
+	}
+	return job, nil
+}
+
+// save stores the erasure of a user, the caller holds s.mu
+func (s *dataErasureService) save(ctx context.Context, job *structs.DataErasure) error {
+	raw, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	if s.rc == nil {
+		s.memory[job.UserID] = raw
+		return nil
+	}
+	if err := s.rc.Set(ctx, fmt.Sprintf(dataErasureKey, job.UserID), raw, dataErasureRetention).Err(); err != nil {
+		return fmt.Errorf("failed to store data erasure: %w", err)
+	}
+	return nil
+}
+
+// cloneDataErasure copies an erasure with its sections
+func cloneDataErasure(job *structs.DataErasure) *structs.DataErasure {
+	view := *job
+	view.Sections = make([]*structs.DataErasureSection, len(job.Sections))
+	for i, section := range job.Sections {
+		copied := *section
+		view.Sections[i] = &copied
+	}
+	return &view
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"ncobase/core/system/structs"
+	"ncobase/core/system/wrapper"
+
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+// stubEraser records the erasures it ran and reports n records removed or anonymized
+type stubEraser struct {
+	mu    sync.Mutex
+	n     int
+	err   error
+	calls []bool // anonymize flag of each call
+}
+
+func (e *stubEraser) EraseUserData(_ context.Context, _ string, anonymize bool) (deleted, anonymized int, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calls = append(e.calls, anonymize)
+	if e.err != nil {
+		return 0, 0, e.err
+	}
+	if anonymize {
+		return 0, e.n, nil
+	}
+	return e.n, 0, nil
+}
+
+func (e *stubEraser) anonymizeFlags() []bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]bool(nil), e.calls...)
+}
+
+func adminContext() context.Context {
+	ctx := ctxutil.SetUserID(context.Background(), "admin")
+	return ctxutil.SetUserIsAdmin(ctx, true)
+}
+
+// runErasure starts the erasure of a user and waits until it stops running
+func runErasure(t *testing.T, s *dataErasureService, userID string) *structs.DataErasure {
+	t.Helper()
+	ctx := adminContext()
+	if _, err := s.Start(ctx, userID); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		running := s.running[userID]
+		s.mu.Unlock()
+		if !running {
+			job, err := s.Get(ctx, userID)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("erasure did not finish")
+	return nil
+}
+
+func newStubErasureService(erasers map[string]wrapper.DataEraser, cfg *DataErasureConfig) *dataErasureService {
+	var mu sync.Mutex
+	return newDataErasureService(func() map[string]wrapper.DataEraser {
+		mu.Lock()
+		defer mu.Unlock()
+		loaded := make(map[string]wrapper.DataEraser, len(erasers))
+		for section, eraser := range erasers {
+			loaded[section] = eraser
+		}
+		return loaded
+	}, []string{"files", "activity", "payments", "user"}, nil, cfg)
+}
+
+func section(job *structs.DataErasure, name string) *structs.DataErasureSection {
+	for _, s := range job.Sections {
+		if s.Section == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func TestDataErasureDeletesAndAnonymizesBySection(t *testing.T) {
+	files, activity, payments, user := &stubEraser{n: 3}, &stubEraser{n: 5}, &stubEraser{n: 2}, &stubEraser{n: 1}
+	s := newStubErasureService(map[string]wrapper.DataEraser{
+		"files": files, "activity": activity, "payments": payments, "user": user,
+	}, DataErasureConfigFromViper(nil))
+
+	job := runErasure(t, s, "u1")
+	if job.Status != structs.DataErasureCompleted {
+		t.Fatalf("status = %s (%s), want completed", job.Status, job.Error)
+	}
+
+	want := map[string]bool{"files": false, "activity": true, "payments": true, "user": false}
+	erasers := map[string]*stubEraser{"files": files, "activity": activity, "payments": payments, "user": user}
+	for name, anonymize := range want {
+		calls := erasers[name].anonymizeFlags()
+		if len(calls) != 1 || calls[0] != anonymize {
+			t.Errorf("%s erased with anonymize %v, want [%v]", name, calls, anonymize)
+		}
+	}
+	if job.Deleted != 4 || job.Anonymized != 7 {
+		t.Fatalf("report %d deleted, %d anonymized, want 4 and 7", job.Deleted, job.Anonymized)
+	}
+	if got := section(job, "activity"); got.Action != structs.DataErasureAnonymize || got.Anonymized != 5 {
+		t.Fatalf("activity section = %+v", got)
+	}
+}
+
+func TestDataErasureResumesAfterFailure(t *testing.T) {
+	files, payments, user := &stubEraser{n: 3}, &stubEraser{err: errors.New("gateway down")}, &stubEraser{n: 1}
+	erasers := map[string]wrapper.DataEraser{"files": files, "activity": &stubEraser{}, "payments": payments, "user": user}
+	s := newStubErasureService(erasers, DataErasureConfigFromViper(nil))
+
+	job := runErasure(t, s, "u1")
+	if job.Status != structs.DataErasureFailed {
+		t.Fatalf("status = %s, want failed", job.Status)
+	}
+	if len(user.anonymizeFlags()) != 0 {
+		t.Fatal("account erased although an earlier section failed")
+	}
+
+	payments.mu.Lock()
+	payments.err = nil
+	payments.mu.Unlock()
+	job = runErasure(t, s, "u1")
+	if job.Status != structs.DataErasureCompleted {
+		t.Fatalf("status = %s (%s), want completed", job.Status, job.Error)
+	}
+	if n := len(files.anonymizeFlags()); n != 1 {
+		t.Fatalf("completed files section ran %d times, want once", n)
+	}
+	if n := len(user.anonymizeFlags()); n != 1 {
+		t.Fatalf("account erased %d times, want once", n)
+	}
+}
+
+func TestDataErasureFailsOnMissingModule(t *testing.T) {
+	user := &stubEraser{n: 1}
+	s := newStubErasureService(map[string]wrapper.DataEraser{
+		"activity": &stubEraser{}, "payments": &stubEraser{}, "user": user,
+	}, DataErasureConfigFromViper(nil))
+
+	job := runErasure(t, s, "u1")
+	if job.Status != structs.DataErasureFailed {
+		t.Fatalf("status = %s, want failed", job.Status)
+	}
+	if got := section(job, "files"); got.Status != structs.DataErasureFailed {
+		t.Fatalf("files section = %s, want failed", got.Status)
+	}
+	if len(user.anonymizeFlags()) != 0 {
+		t.Fatal("account erased although the files module is not loaded")
+	}
+}
+
+func TestDataErasureSkipsOptionalModule(t *testing.T) {
+	user := &stubEraser{n: 1}
+	s := newStubErasureService(map[string]wrapper.DataEraser{
+		"files": &stubEraser{}, "activity": &stubEraser{}, "user": user,
+	}, &DataErasureConfig{Anonymize: defaultAnonymizedSections, Optional: []string{"payments"}})
+
+	job := runErasure(t, s, "u1")
+	if job.Status != structs.DataErasureCompleted {
+		t.Fatalf("status = %s (%s), want completed", job.Status, job.Error)
+	}
+	if got := section(job, "payments"); got.Status != structs.DataErasureSkipped {
+		t.Fatalf("payments section = %s, want skipped", got.Status)
+	}
+	if len(user.anonymizeFlags()) != 1 {
+		t.Fatal("account not erased")
+	}
+}
+
+func TestDataErasureRequiresAdmin(t *testing.T) {
+	s := newStubErasureService(map[string]wrapper.DataEraser{}, DataErasureConfigFromViper(nil))
+	if _, err := s.Start(context.Background(), "u1"); !errors.Is(err, ErrDataErasureForbidden) {
+		t.Fatalf("Start without admin = %v, want ErrDataErasureForbidden", err)
+	}
+}
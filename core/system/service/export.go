@@ -0,0 +1,336 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ncobase/core/system/structs"
+	"ncobase/core/system/wrapper"
+	"ncobase/internal/idgen"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/spf13/viper"
+)
+
+var (
+	// ErrDataExportNotFound is returned for an unknown or expired export
+	ErrDataExportNotFound = errors.New("data export not found")
+	// ErrDataExportForbidden is returned when exporting or reading another user's export without being admin
+	ErrDataExportForbidden = errors.New("you don't have permission to access this data export")
+	// ErrDataExportNotReady is returned when downloading an export that has not completed
+	ErrDataExportNotReady = errors.New("data export is not completed")
+)
+
+const (
+	// dataExportDefaultTTL is how long a completed archive stays downloadable
+	dataExportDefaultTTL = 24 * time.Hour
+	// dataExportConcurrency is the number of exports written at once, others wait
+	dataExportConcurrency = 2
+)
+
+// DataExportConfig configures where user data exports are written and how long they are kept
+type DataExportConfig struct {
+	Dir string        // Directory archives are written to
+	TTL time.Duration // Time a completed archive stays downloadable
+}
+
+// DataExportConfigFromViper reads the data export config from export.*
+func DataExportConfigFromViper(v *viper.Viper) *DataExportConfig {
+	cfg := &DataExportConfig{
+		Dir: filepath.Join(os.TempDir(), "ncobase-exports"),
+		TTL: dataExportDefaultTTL,
+	}
+	if v == nil {
+		return cfg
+	}
+	if dir := v.GetString("export.dir"); dir != "" {
+		cfg.Dir = dir
+	}
+	if ttl := v.GetDuration("export.ttl"); ttl > 0 {
+		cfg.TTL = ttl
+	}
+	return cfg
+}
+
+// DataExportServiceInterface exports all data of a user into a downloadable archive
+type DataExportServiceInterface interface {
+	Start(ctx context.Context, userID string) (*structs.DataExport, error)
+	Get(ctx context.Context, id string) (*structs.DataExport, error)
+	Open(ctx context.Context, id string) (*os.File, *structs.DataExport, error)
+	SetConfig(cfg *DataExportConfig)
+}
+
+// dataExportService writes exports in the background, one zip entry per module section.
+// Jobs are kept in memory, archives on disk; both are removed once expired.
+type dataExportService struct {
+	exporters func() map[string]wrapper.DataExporter
+
+	mu   sync.Mutex
+	cfg  DataExportConfig
+	jobs map[string]*structs.DataExport
+	sem  chan struct{}
+}
+
+// NewDataExportService creates a new data export service
+func NewDataExportService(esw *wrapper.ExportServiceWrapper) DataExportServiceInterface {
+	return newDataExportService(esw.Exporters, DataExportConfigFromViper(nil))
+}
+
+// newDataExportService creates a data export service collecting from the given exporters
+func newDataExportService(exporters func() map[string]wrapper.DataExporter, cfg *DataExportConfig) *dataExportService {
+	return &dataExportService{
+		exporters: exporters,
+		cfg:       *cfg,
+		jobs:      make(map[string]*structs.DataExport),
+		sem:       make(chan struct{}, dataExportConcurrency),
+	}
+}
+
+// SetConfig sets the archive directory and retention
+func (s *dataExportService) SetConfig(cfg *DataExportConfig) {
+	if cfg == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = *cfg
+}
+
+// Start queues an export of a user, the current user when userID is empty.
+// A pending or running export of the same user is returned instead of starting another.
+func (s *dataExportService) Start(ctx context.Context, userID string) (*structs.DataExport, error) {
+	requester := ctxutil.GetUserID(ctx)
+	if userID == "" {
+		userID = requester
+	}
+	if userID == "" {
+		return nil, ErrDataExportForbidden
+	}
+	if userID != requester && !ctxutil.GetUserIsAdmin(ctx) {
+		return nil, ErrDataExportForbidden
+	}
+
+	s.sweep(ctx)
+
+	s.mu.Lock()
+	for _, job := range s.jobs {
+		if job.UserID == userID && (job.Status == structs.DataExportPending || job.Status == structs.DataExportRunning) {
+			view := *job
+			s.mu.Unlock()
+			return &view, nil
+		}
+	}
+	job := &structs.DataExport{
+		ID:          idgen.New(),
+		UserID:      userID,
+		RequestedBy: requester,
+		Status:      structs.DataExportPending,
+		CreatedAt:   time.Now().UnixMilli(),
+	}
+	s.jobs[job.ID] = job
+	view := *job
+	s.mu.Unlock()
+
+	// The export outlives the request, but keeps its user and space for the module access checks
+	go s.run(context.WithoutCancel(ctx), job.ID)
+
+	return &view, nil
+}
+
+// Get returns an export of the current user, admins may read any export
+func (s *dataExportService) Get(ctx context.Context, id string) (*structs.DataExport, error) {
+	s.sweep(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrDataExportNotFound
+	}
+	if job.RequestedBy != ctxutil.GetUserID(ctx) && job.UserID != ctxutil.GetUserID(ctx) && !ctxutil.GetUserIsAdmin(ctx) {
+		return nil, ErrDataExportNotFound
+	}
+	view := *job
+	return &view, nil
+}
+
+// Open opens the archive of a completed export for download, the caller closes it
+func (s *dataExportService) Open(ctx context.Context, id string) (*os.File, *structs.DataExport, error) {
+	job, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != structs.DataExportCompleted {
+		return nil, job, ErrDataExportNotReady
+	}
+
+	f, err := os.Open(s.archivePath(job.ID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, job, ErrDataExportNotFound
+	}
+	if err != nil {
+		return nil, job, err
+	}
+	return f, job, nil
+}
+
+// run writes the archive of an export once a slot is free
+func (s *dataExportService) run(ctx context.Context, id string) {
+	s.sem <- struct{}{}
+	defer func() { <-s.sem }()
+
+	s.mu.Lock()
+	job := s.jobs[id]
+	job.Status = structs.DataExportRunning
+	userID := job.UserID
+	s.mu.Unlock()
+
+	sections, sectionErrors, size, err := s.write(ctx, id, userID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	job.CompletedAt = now.UnixMilli()
+	job.Sections = sections
+	if len(sectionErrors) > 0 {
+		job.Errors = sectionErrors
+	}
+	if err != nil {
+		logger.Errorf(ctx, "Data export %s of user %s failed: %v", id, userID, err)
+		job.Status = structs.DataExportFailed
+		job.Error = "failed to write export archive"
+		job.ExpiresAt = now.Add(s.cfg.TTL).UnixMilli()
+		return
+	}
+	job.Status = structs.DataExportCompleted
+	job.Size = size
+	job.DownloadURL = fmt.Sprintf("/sys/exports/%s/download", id)
+	job.ExpiresAt = now.Add(s.cfg.TTL).UnixMilli()
+	logger.Infof(ctx, "Data export %s of user %s completed, %d bytes", id, userID, size)
+}
+
+// write streams every section into a zip archive, followed by its manifest.
+// A failing section is recorded and the others are still written.
+func (s *dataExportService) write(ctx context.Context, id, userID string) ([]string, map[string]string, int64, error) {
+	s.mu.Lock()
+	dir := s.cfg.Dir
+	s.mu.Unlock()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, nil, 0, err
+	}
+
+	tmp := s.archivePath(id) + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer os.Remove(tmp)
+
+	exporters := s.exporters()
+	sections := make([]string, 0, len(exporters))
+	for section := range exporters {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+
+	zw := zip.NewWriter(f)
+	sectionErrors := make(map[string]string)
+	for _, section := range sections {
+		w, err := zw.Create(section + ".jsonl")
+		if err != nil {
+			f.Close()
+			return nil, nil, 0, err
+		}
+		if err := exporters[section].ExportUserData(ctx, userID, w); err != nil {
+			logger.Warnf(ctx, "Data export %s: section %s failed: %v", id, section, err)
+			sectionErrors[section] = err.Error()
+		}
+	}
+
+	manifest := &structs.DataExportManifest{
+		UserID:     userID,
+		ExportedAt: time.Now().UnixMilli(),
+		Sections:   sections,
+	}
+	if len(sectionErrors) > 0 {
+		manifest.Errors = sectionErrors
+	}
+	w, err := zw.Create("manifest.json")
+	if err == nil {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(manifest)
+	}
+	if err == nil {
+		err = zw.Close()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return sections, sectionErrors, 0, err
+	}
+
+	info, err := os.Stat(tmp)
+	if err != nil {
+		return sections, sectionErrors, 0, err
+	}
+	if err := os.Rename(tmp, s.archivePath(id)); err != nil {
+		return sections, sectionErrors, 0, err
+	}
+	return sections, sectionErrors, info.Size(), nil
+}
+
+// sweep removes expired exports with their archives, and archives left behind by a restart
+func (s *dataExportService) sweep(ctx context.Context) {
+	now := time.Now()
+
+	s.mu.Lock()
+	dir, ttl := s.cfg.Dir, s.cfg.TTL
+	var expired []string
+	for id, job := range s.jobs {
+		if job.ExpiresAt > 0 && job.ExpiresAt <= now.UnixMilli() {
+			delete(s.jobs, id)
+			expired = append(expired, id)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, id := range expired {
+		if err := os.Remove(s.archivePath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf(ctx, "Failed to remove expired data export %s: %v", id, err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".zip") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < ttl {
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, entry.Name()))
+	}
+}
+
+// archivePath returns the path of the archive of an export
+func (s *dataExportService) archivePath(id string) string {
+	s.mu.Lock()
+	dir := s.cfg.Dir
+	s.mu.Unlock()
+	return filepath.Join(dir, id+".zip")
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"ncobase/core/system/structs"
+	"ncobase/core/system/wrapper"
+
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+// stubExporter writes one JSON line per record of the user, or fails with err
+type stubExporter struct {
+	records []string
+	err     error
+}
+
+func (e stubExporter) ExportUserData(_ context.Context, userID string, w io.Writer) error {
+	if e.err != nil {
+		return e.err
+	}
+	for _, r := range e.records {
+		if _, err := fmt.Fprintf(w, "{\"user_id\":%q,\"record\":%q}\n", userID, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestExports(t *testing.T, exporters map[string]wrapper.DataExporter) *dataExportService {
+	return newDataExportService(func() map[string]wrapper.DataExporter { return exporters },
+		&DataExportConfig{Dir: t.TempDir(), TTL: time.Hour})
+}
+
+// waitExport polls an export until it is no longer pending or running
+func waitExport(t *testing.T, ctx context.Context, s *dataExportService, id string) *structs.DataExport {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := s.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if job.Status != structs.DataExportPending && job.Status != structs.DataExportRunning {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("export %s did not finish", id)
+	return nil
+}
+
+// readArchive returns the entries of a zip archive by name
+func readArchive(t *testing.T, ctx context.Context, s *dataExportService, id string) map[string]string {
+	t.Helper()
+	f, _, err := s.Open(ctx, id)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+	info, _ := f.Stat()
+	zr, err := zip.NewReader(f, info.Size())
+	if err != nil {
+		t.Fatalf("read zip: %v", err)
+	}
+	entries := map[string]string{}
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		entries[file.Name] = string(b)
+	}
+	return entries
+}
+
+func TestExportArchiveHasEachSection(t *testing.T) {
+	s := newTestExports(t, map[string]wrapper.DataExporter{
+		"user":   stubExporter{records: []string{"profile"}},
+		"spaces": stubExporter{records: []string{"acme", "globex"}},
+		"files":  stubExporter{},
+		"tasks":  stubExporter{err: errors.New("task store offline")},
+	})
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+
+	started, err := s.Start(ctx, "")
+	if err != nil || started.UserID != "u1" {
+		t.Fatalf("Start = %+v, %v", started, err)
+	}
+	job := waitExport(t, ctx, s, started.ID)
+	if job.Status != structs.DataExportCompleted || job.DownloadURL == "" || job.Size == 0 {
+		t.Fatalf("export %+v, want completed with a download link", job)
+	}
+
+	entries := readArchive(t, ctx, s, job.ID)
+	for name, want := range map[string]string{
+		"user.jsonl":   "{\"user_id\":\"u1\",\"record\":\"profile\"}\n",
+		"spaces.jsonl": "{\"user_id\":\"u1\",\"record\":\"acme\"}\n{\"user_id\":\"u1\",\"record\":\"globex\"}\n",
+		"files.jsonl":  "",
+	} {
+		if got, ok := entries[name]; !ok || got != want {
+			t.Errorf("entry %s = %q (present %v), want %q", name, got, ok, want)
+		}
+	}
+
+	var manifest structs.DataExportManifest
+	if err := json.Unmarshal([]byte(entries["manifest.json"]), &manifest); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	if manifest.UserID != "u1" || len(manifest.Sections) != 4 || manifest.Errors["tasks"] != "task store offline" {
+		t.Fatalf("manifest %+v, want four sections and the failed one", manifest)
+	}
+	if job.Errors["tasks"] == "" {
+		t.Fatalf("job errors %v, want the failed section", job.Errors)
+	}
+}
+
+func TestExportAccess(t *testing.T) {
+	s := newTestExports(t, map[string]wrapper.DataExporter{"user": stubExporter{}})
+	user := ctxutil.SetUserID(context.Background(), "u1")
+
+	if _, err := s.Start(user, "u2"); !errors.Is(err, ErrDataExportForbidden) {
+		t.Fatalf("export of another user = %v, want ErrDataExportForbidden", err)
+	}
+
+	admin := ctxutil.SetUserIsAdmin(ctxutil.SetUserID(context.Background(), "admin"), true)
+	started, err := s.Start(admin, "u2")
+	if err != nil {
+		t.Fatalf("admin export: %v", err)
+	}
+	waitExport(t, admin, s, started.ID)
+	if _, err := s.Get(user, started.ID); !errors.Is(err, ErrDataExportNotFound) {
+		t.Fatalf("export read by another user = %v, want ErrDataExportNotFound", err)
+	}
+	if _, err := s.Get(ctxutil.SetUserID(context.Background(), "u2"), started.ID); err != nil {
+		t.Fatalf("export read by its user: %v", err)
+	}
+}
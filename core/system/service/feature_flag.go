@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ncobase/core/system/data"
+	"ncobase/core/system/structs"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// featureFlagKey holds the flags of one scope, a space ID or the global scope
+const featureFlagKey = "ncse_system:feature_flags:%s"
+
+// featureFlagRefresh is how long an instance reuses the flags of a scope before reading them again
+const featureFlagRefresh = 5 * time.Second
+
+// FeatureFlagServiceInterface manages feature flags per space
+type FeatureFlagServiceInterface interface {
+	IsEnabled(ctx context.Context, flag string) bool
+	List(ctx context.Context, spaceID string) ([]*structs.FeatureFlag, error)
+	Set(ctx context.Context, flag string, body *structs.UpdateFeatureFlagBody) (*structs.FeatureFlag, error)
+	Delete(ctx context.Context, spaceID, flag string) error
+}
+
+// flagSet is the cached flags of one scope
+type flagSet struct {
+	flags    map[string]*structs.FeatureFlag
+	loadedAt time.Time
+}
+
+// featureFlagService keeps flags in Redis so they can be changed at runtime
+// on every instance. A space value overrides the global value of a flag.
+type featureFlagService struct {
+	rc *redis.Client
+
+	mu    sync.RWMutex
+	cache map[string]*flagSet
+}
+
+// NewFeatureFlagService creates a new feature flag service
+func NewFeatureFlagService(d *data.Data) FeatureFlagServiceInterface {
+	rc, _ := d.GetRedis().(*redis.Client)
+	return &featureFlagService{
+		rc:    rc,
+		cache: make(map[string]*flagSet),
+	}
+}
+
+// IsEnabled reports whether a flag is on for the space and user in the context.
+// Unknown flags and unreadable stores are off.
+func (s *featureFlagService) IsEnabled(ctx context.Context, flag string) bool {
+	spaceID := ctxutil.GetSpaceID(ctx)
+
+	f := s.lookup(ctx, structs.FlagScope(spaceID), flag)
+	if f == nil && spaceID != "" {
+		f = s.lookup(ctx, structs.GlobalFlagScope, flag)
+	}
+
+	subject := ctxutil.GetUserID(ctx)
+	if subject == "" {
+		subject = spaceID
+	}
+	return f.EnabledFor(subject)
+}
+
+// List returns the flags stored for a space, or the global flags for an empty space ID
+func (s *featureFlagService) List(ctx context.Context, spaceID string) ([]*structs.FeatureFlag, error) {
+	flags, err := s.load(ctx, structs.FlagScope(spaceID))
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*structs.FeatureFlag, 0, len(flags))
+	for _, f := range flags {
+		list = append(list, f)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list, nil
+}
+
+// Set stores a flag and applies it to this instance immediately
+func (s *featureFlagService) Set(ctx context.Context, flag string, body *structs.UpdateFeatureFlagBody) (*structs.FeatureFlag, error) {
+	if flag == "" || body == nil {
+		return nil, errors.New("flag key is required")
+	}
+	if s.rc == nil {
+		return nil, errors.New("redis is required to change feature flags at runtime")
+	}
+
+	f := &structs.FeatureFlag{
+		Key:         flag,
+		SpaceID:     body.SpaceID,
+		Enabled:     body.Enabled,
+		Rollout:     100,
+		Description: body.Description,
+		UpdatedBy:   ctxutil.GetUserID(ctx),
+		UpdatedAt:   time.Now().UnixMilli(),
+	}
+	if body.Rollout != nil {
+		f.Rollout = *body.Rollout
+	}
+
+	raw, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	scope := structs.FlagScope(body.SpaceID)
+	if err := s.rc.HSet(ctx, fmt.Sprintf(featureFlagKey, scope), flag, raw).Err(); err != nil {
+		return nil, fmt.Errorf("failed to store feature flag: %w", err)
+	}
+	s.invalidate(scope)
+
+	logger.Infof(ctx, "Feature flag %s in scope %s set to %v at %d%% by %s", flag, scope, f.Enabled, f.Rollout, f.UpdatedBy)
+	return f, nil
+}
+
+// Delete removes a flag from a space, the global value applies again
+func (s *featureFlagService) Delete(ctx context.Context, spaceID, flag string) error {
+	if s.rc == nil {
+		return errors.New("redis is required to change feature flags at runtime")
+	}
+
+	scope := structs.FlagScope(spaceID)
+	if err := s.rc.HDel(ctx, fmt.Sprintf(featureFlagKey, scope), flag).Err(); err != nil {
+		return fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+	s.invalidate(scope)
+
+	logger.Infof(ctx, "Feature flag %s removed from scope %s by %s", flag, scope, ctxutil.GetUserID(ctx))
+	return nil
+}
+
+// lookup returns a cached flag of a scope.
+// Redis is read at most once per refresh interval; when it fails the last
+// known flags are kept so an outage does not flip flags.
+func (s *featureFlagService) lookup(ctx context.Context, scope, flag string) *structs.FeatureFlag {
+	s.mu.RLock()
+	set, ok := s.cache[scope]
+	if ok && time.Since(set.loadedAt) < featureFlagRefresh {
+		f := set.flags[flag]
+		s.mu.RUnlock()
+		return f
+	}
+	s.mu.RUnlock()
+
+	flags, err := s.load(ctx, scope)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		logger.Warnf(ctx, "Failed to load feature flags of %s: %v", scope, err)
+		if set == nil {
+			set = &flagSet{}
+		}
+	} else {
+		set = &flagSet{flags: flags}
+	}
+	set.loadedAt = time.Now()
+	s.cache[scope] = set
+	return set.flags[flag]
+}
+
+// invalidate drops the cached flags of a scope
+func (s *featureFlagService) invalidate(scope string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.cache, scope)
+}
+
+// load reads the flags of a scope
+func (s *featureFlagService) load(ctx context.Context, scope string) (map[string]*structs.FeatureFlag, error) {
+	flags := make(map[string]*structs.FeatureFlag)
+	if s.rc == nil {
+		return flags, nil
+	}
+
+	values, err := s.rc.HGetAll(ctx, fmt.Sprintf(featureFlagKey, scope)).Result()
+	if err != nil {
+		return nil, err
+	}
+	for key, raw := range values {
+		var f structs.FeatureFlag
+		if err := json.Unmarshal([]byte(raw), &f); err != nil {
+			logger.Warnf(ctx, "Invalid feature flag %s in %s: %v", key, scope, err)
+			continue
+		}
+		flags[key] = &f
+	}
+	return flags, nil
+}
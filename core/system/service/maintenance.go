@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ncobase/core/system/data"
+	"ncobase/core/system/structs"
+	"sync"
+	"time"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+// maintenanceKey holds the runtime maintenance state shared by all instances
+const maintenanceKey = "ncse_system:maintenance"
+
+// maintenanceRefresh is how long an instance reuses the state before reading it again
+const maintenanceRefresh = 5 * time.Second
+
+// MaintenanceServiceInterface manages maintenance mode
+type MaintenanceServiceInterface interface {
+	Get(ctx context.Context) (*structs.MaintenanceState, error)
+	Set(ctx context.Context, body *structs.UpdateMaintenanceBody) (*structs.MaintenanceState, error)
+	SetDefault(state *structs.MaintenanceState)
+}
+
+// maintenanceService keeps the state in Redis so it can be flipped at runtime
+// on every instance, falling back to the configured state when unset
+type maintenanceService struct {
+	rc *redis.Client
+
+	mu       sync.RWMutex
+	fallback *structs.MaintenanceState
+	current  *structs.MaintenanceState
+	loadedAt time.Time
+}
+
+// NewMaintenanceService creates a new maintenance service
+func NewMaintenanceService(d *data.Data) MaintenanceServiceInterface {
+	rc, _ := d.GetRedis().(*redis.Client)
+	return &maintenanceService{
+		rc:       rc,
+		fallback: &structs.MaintenanceState{Mode: structs.MaintenanceOff, RetryAfter: structs.DefaultMaintenanceRetryAfter},
+	}
+}
+
+// MaintenanceFromViper reads the startup maintenance state from maintenance.*
+func MaintenanceFromViper(v *viper.Viper) *structs.MaintenanceState {
+	state := &structs.MaintenanceState{Mode: structs.MaintenanceOff, RetryAfter: structs.DefaultMaintenanceRetryAfter}
+	if v == nil {
+		return state
+	}
+
+	if mode := structs.MaintenanceMode(v.GetString("maintenance.mode")); mode.IsValid() {
+		state.Mode = mode
+	}
+	if v.IsSet("maintenance.retry_after") {
+		state.RetryAfter = v.GetInt("maintenance.retry_after")
+	}
+	state.Message = v.GetString("maintenance.message")
+	state.AllowIPs = v.GetStringSlice("maintenance.allow_ips")
+	state.AllowUsers = v.GetStringSlice("maintenance.allow_users")
+	return state
+}
+
+// SetDefault sets the state used while none was stored at runtime
+func (s *maintenanceService) SetDefault(state *structs.MaintenanceState) {
+	if state == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fallback = state
+	s.current = nil
+}
+
+// Get returns the current maintenance state.
+// Redis is read at most once per refresh interval; when it fails the last
+// known state is kept so an outage does not flip maintenance on or off.
+func (s *maintenanceService) Get(ctx context.Context) (*structs.MaintenanceState, error) {
+	s.mu.RLock()
+	if s.current != nil && time.Since(s.loadedAt) < maintenanceRefresh {
+		state := s.current
+		s.mu.RUnlock()
+		return state, nil
+	}
+	s.mu.RUnlock()
+
+	state, err := s.load(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		logger.Warnf(ctx, "Failed to load maintenance state: %v", err)
+		if s.current == nil {
+			s.current = s.fallback
+		}
+	} else {
+		s.current = state
+	}
+	s.loadedAt = time.Now()
+	return s.current, nil
+}
+
+// Set stores a new maintenance state and applies it to this instance immediately
+func (s *maintenanceService) Set(ctx context.Context, body *structs.UpdateMaintenanceBody) (*structs.MaintenanceState, error) {
+	if body == nil || !body.Mode.IsValid() {
+		return nil, errors.New("invalid maintenance mode")
+	}
+	if s.rc == nil {
+		return nil, errors.New("redis is required to change maintenance mode at runtime")
+	}
+
+	state := &structs.MaintenanceState{
+		Mode:       body.Mode,
+		Message:    body.Message,
+		RetryAfter: body.RetryAfter,
+		AllowIPs:   body.AllowIPs,
+		AllowUsers: body.AllowUsers,
+		UpdatedBy:  ctxutil.GetUserID(ctx),
+		UpdatedAt:  time.Now().UnixMilli(),
+	}
+	if state.RetryAfter == 0 {
+		state.RetryAfter = structs.DefaultMaintenanceRetryAfter
+	}
+
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rc.Set(ctx, maintenanceKey, raw, 0).Err(); err != nil {
+		return nil, fmt.Errorf("failed to store maintenance state: %w", err)
+	}
+
+	s.mu.Lock()
+	s.current = state
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+
+	logger.Infof(ctx, "Maintenance mode set to %s by %s", state.Mode, state.UpdatedBy)
+	return state, nil
+}
+
+// load reads the stored state, returning the configured one when none is stored
+func (s *maintenanceService) load(ctx context.Context) (*structs.MaintenanceState, error) {
+	s.mu.RLock()
+	fallback := s.fallback
+	s.mu.RUnlock()
+
+	if s.rc == nil {
+		return fallback, nil
+	}
+
+	raw, err := s.rc.Get(ctx, maintenanceKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return fallback, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state structs.MaintenanceState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("invalid maintenance state: %w", err)
+	}
+	return &state, nil
+}
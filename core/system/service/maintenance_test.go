@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ncobase/core/system/structs"
+
+	"github.com/spf13/viper"
+)
+
+func TestMaintenanceFromViper(t *testing.T) {
+	v := viper.New()
+	v.Set("maintenance.mode", "read_only")
+	v.Set("maintenance.retry_after", 60)
+	v.Set("maintenance.allow_ips", []string{"10.0.0.0/8"})
+
+	state := MaintenanceFromViper(v)
+	if state.Mode != structs.MaintenanceReadOnly || state.RetryAfter != 60 || len(state.AllowIPs) != 1 {
+		t.Fatalf("state = %+v", state)
+	}
+
+	v.Set("maintenance.mode", "sideways")
+	if state := MaintenanceFromViper(v); state.Mode != structs.MaintenanceOff {
+		t.Fatalf("unknown mode read as %s, want off", state.Mode)
+	}
+}
+
+func TestMaintenanceWithoutRedisUsesConfiguredState(t *testing.T) {
+	ctx := context.Background()
+	s := &maintenanceService{fallback: &structs.MaintenanceState{Mode: structs.MaintenanceOff}}
+
+	s.SetDefault(&structs.MaintenanceState{Mode: structs.MaintenanceOffline})
+	state, err := s.Get(ctx)
+	if err != nil || state.Mode != structs.MaintenanceOffline {
+		t.Fatalf("Get = %+v, %v, want the configured offline state", state, err)
+	}
+
+	if _, err := s.Set(ctx, &structs.UpdateMaintenanceBody{Mode: structs.MaintenanceOff}); err == nil {
+		t.Fatal("runtime change accepted without redis")
+	}
+	if _, err := s.Set(ctx, &structs.UpdateMaintenanceBody{Mode: "sideways"}); err == nil {
+		t.Fatal("unknown mode accepted")
+	}
+}
@@ -30,6 +30,7 @@ type MenuServiceInterface interface {
 	GetMenusByTypes(ctx context.Context, types []string, opts structs.MenuQueryParams) ([]*structs.ReadMenu, error)
 	GetNavigationMenus(ctx context.Context, sortBy string) (*structs.NavigationMenus, error)
 	GetUserAuthorizedMenus(ctx context.Context, userID string) ([]*structs.ReadMenu, error)
+	GetSpaceMenuTree(ctx context.Context, spaceID string, params *structs.SpaceMenuParams) ([]*structs.ReadMenu, error)
 	BatchGetByID(ctx context.Context, menuIDs []string) (map[string]*structs.ReadMenu, error)
 	MoveMenu(ctx context.Context, menuID string, newParentID string, newOrder int) (*structs.ReadMenu, error)
 	ReorderMenus(ctx context.Context, menuIDs []string) error
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"ncobase/internal/manifest"
+	"sort"
+
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+// PluginServiceInterface describes the loaded extensions
+type PluginServiceInterface interface {
+	List(ctx context.Context) ([]manifest.PluginManifest, error)
+}
+
+// pluginService reads the manifests of the extensions known to the extension manager
+type pluginService struct {
+	em ext.ManagerInterface
+}
+
+// NewPluginService creates a new plugin service
+func NewPluginService(em ext.ManagerInterface) PluginServiceInterface {
+	return &pluginService{em: em}
+}
+
+// List returns the manifests of all loaded modules and plugins, sorted by name
+func (s *pluginService) List(_ context.Context) ([]manifest.PluginManifest, error) {
+	extensions := s.em.ListExtensions()
+
+	manifests := make([]manifest.PluginManifest, 0, len(extensions))
+	for _, wrapper := range extensions {
+		if wrapper == nil || wrapper.Instance == nil {
+			continue
+		}
+		manifests = append(manifests, manifest.Of(wrapper.Instance))
+	}
+
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].Name < manifests[j].Name
+	})
+	return manifests, nil
+}
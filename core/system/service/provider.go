@@ -9,12 +9,19 @@ import (
 
 // Service represents the system service.
 type Service struct {
-	Menu       MenuServiceInterface
-	Dictionary DictionaryServiceInterface
-	Option     OptionServiceInterface
-	Admin      AdminServiceInterface
-	d          *data.Data
-	em         ext.ManagerInterface
+	Menu        MenuServiceInterface
+	Dictionary  DictionaryServiceInterface
+	Option      OptionServiceInterface
+	Admin       AdminServiceInterface
+	Maintenance MaintenanceServiceInterface
+	FeatureFlag FeatureFlagServiceInterface
+	Email       EmailTemplateServiceInterface
+	Plugin      PluginServiceInterface
+	Search      SearchServiceInterface
+	DataExport  DataExportServiceInterface
+	DataErasure DataErasureServiceInterface
+	d           *data.Data
+	em          ext.ManagerInterface
 }
 
 // New creates a new service.
@@ -22,11 +29,18 @@ func New(d *data.Data, em ext.ManagerInterface) *Service {
 	tsw := wrapper.NewSpaceServiceWrapper(em)
 
 	s := &Service{
-		Menu:       NewMenuService(d, em, tsw),
-		Dictionary: NewDictionaryService(d),
-		Option:     NewOptionService(d),
-		d:          d,
-		em:         em,
+		Menu:        NewMenuService(d, em, tsw),
+		Dictionary:  NewDictionaryService(d),
+		Option:      NewOptionService(d),
+		Maintenance: NewMaintenanceService(d),
+		FeatureFlag: NewFeatureFlagService(d),
+		Email:       NewEmailTemplateService(d),
+		Plugin:      NewPluginService(em),
+		Search:      NewSearchService(wrapper.NewSearchServiceWrapper(em)),
+		DataExport:  NewDataExportService(wrapper.NewExportServiceWrapper(em)),
+		DataErasure: NewDataErasureService(d, wrapper.NewEraseServiceWrapper(em)),
+		d:           d,
+		em:          em,
 	}
 
 	// Initialize admin service with reference to the main service
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/core/system/structs"
+	"ncobase/core/system/wrapper"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/validation/validator"
+)
+
+const (
+	// searchModuleTimeout bounds each module search, a slow module does not hold back the others
+	searchModuleTimeout = 2 * time.Second
+	// searchDefaultLimit is the page size when none is requested
+	searchDefaultLimit = 20
+	// searchMaxLimit is the largest page size
+	searchMaxLimit = 50
+	// searchMaxFetch is the most results taken from one module, so pages end at this offset
+	searchMaxFetch = 100
+)
+
+// SearchServiceInterface searches spaces, files, topics and taxonomies at once
+type SearchServiceInterface interface {
+	Search(ctx context.Context, params *structs.SearchParams) (*structs.SearchResult, error)
+}
+
+// searchFunc searches one module, returning at most limit hits, best match first
+type searchFunc func(ctx context.Context, query string, limit int) ([]*structs.SearchHit, error)
+
+// searchOutcome is the result of one module search
+type searchOutcome struct {
+	typ  string
+	hits []*structs.SearchHit
+	err  error
+}
+
+// searchService fans a query out to the modules that are loaded.
+// Each module applies its own access rules and space scope.
+type searchService struct {
+	ssw     *wrapper.SearchServiceWrapper
+	timeout time.Duration
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(ssw *wrapper.SearchServiceWrapper) SearchServiceInterface {
+	return &searchService{
+		ssw:     ssw,
+		timeout: searchModuleTimeout,
+	}
+}
+
+// Search queries every requested module in parallel and merges the results by relevance.
+// Modules that are not loaded are skipped; modules that fail or time out are
+// reported in the result errors and the other results are still returned.
+func (s *searchService) Search(ctx context.Context, params *structs.SearchParams) (*structs.SearchResult, error) {
+	query := strings.TrimSpace(params.Q)
+	if validator.IsEmpty(query) {
+		return nil, errors.New(ecode.FieldIsRequired("q"))
+	}
+	if params.Offset < 0 {
+		return nil, errors.New(ecode.FieldIsInvalid("offset"))
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = searchDefaultLimit
+	}
+	if limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+
+	types, err := parseSearchTypes(params.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &structs.SearchResult{
+		Items:  []*structs.SearchHit{},
+		Limit:  limit,
+		Offset: params.Offset,
+	}
+
+	fetch := params.Offset + limit
+	if fetch > searchMaxFetch {
+		fetch = searchMaxFetch
+	}
+	if params.Offset >= fetch {
+		return result, nil
+	}
+
+	sources := s.sources(types)
+	outcomes := make(chan searchOutcome, len(sources))
+	for typ, search := range sources {
+		go func(typ string, search searchFunc) {
+			mctx, cancel := context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+
+			hits, err := search(mctx, query, fetch)
+			outcomes <- searchOutcome{typ: typ, hits: hits, err: err}
+		}(typ, search)
+	}
+
+	// A module that ignores its context is given up on once the timeout passes
+	deadline := time.NewTimer(s.timeout)
+	defer deadline.Stop()
+
+	collected := make(map[string][]*structs.SearchHit, len(sources))
+	failed := make(map[string]string)
+collect:
+	for pending := len(sources); pending > 0; pending-- {
+		select {
+		case outcome := <-outcomes:
+			if outcome.err != nil {
+				logger.Warnf(ctx, "Search of %s failed: %v", outcome.typ, outcome.err)
+				if errors.Is(outcome.err, context.DeadlineExceeded) {
+					failed[outcome.typ] = "timed out"
+				} else {
+					failed[outcome.typ] = "search failed"
+				}
+				continue
+			}
+			collected[outcome.typ] = outcome.hits
+		case <-deadline.C:
+			for typ := range sources {
+				if _, ok := collected[typ]; !ok && failed[typ] == "" {
+					logger.Warnf(ctx, "Search of %s timed out", typ)
+					failed[typ] = "timed out"
+				}
+			}
+			break collect
+		}
+	}
+	if len(failed) > 0 {
+		result.Errors = failed
+	}
+
+	// Merge in type order, so equal scores keep the type order and then the module order
+	var hits []*structs.SearchHit
+	for _, typ := range structs.SearchTypes {
+		hits = append(hits, collected[typ]...)
+	}
+	for _, hit := range hits {
+		hit.Score = searchScore(query, hit.Title, hit.Description)
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+
+	result.Total = len(hits)
+	if params.Offset < len(hits) {
+		end := params.Offset + limit
+		if end > len(hits) {
+			end = len(hits)
+		}
+		result.Items = hits[params.Offset:end]
+		result.HasMore = end < len(hits)
+	}
+
+	return result, nil
+}
+
+// sources returns the search of each requested type whose module is loaded
+func (s *searchService) sources(types map[string]bool) map[string]searchFunc {
+	s.ssw.RefreshServices()
+
+	sources := make(map[string]searchFunc, len(types))
+	if types[structs.SearchTypeSpace] && s.ssw.HasSpaceService() {
+		sources[structs.SearchTypeSpace] = s.searchSpaces
+	}
+	if types[structs.SearchTypeFile] && s.ssw.HasFileService() {
+		sources[structs.SearchTypeFile] = s.searchFiles
+	}
+	if types[structs.SearchTypeTopic] && s.ssw.HasTopicService() {
+		sources[structs.SearchTypeTopic] = s.searchTopics
+	}
+	if types[structs.SearchTypeTaxonomy] && s.ssw.HasTaxonomyService() {
+		sources[structs.SearchTypeTaxonomy] = s.searchTaxonomies
+	}
+	return sources
+}
+
+// searchSpaces searches the spaces of the current user
+func (s *searchService) searchSpaces(ctx context.Context, query string, limit int) ([]*structs.SearchHit, error) {
+	rows, err := s.ssw.SearchSpaces(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]*structs.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		title := row.Title
+		if title == "" {
+			title = row.Name
+		}
+		hits = append(hits, &structs.SearchHit{
+			Type:        structs.SearchTypeSpace,
+			ID:          row.ID,
+			Title:       title,
+			Description: row.Description,
+			Data:        row,
+		})
+	}
+	return hits, nil
+}
+
+// searchFiles searches the files of the current space
+func (s *searchService) searchFiles(ctx context.Context, query string, limit int) ([]*structs.SearchHit, error) {
+	rows, err := s.ssw.SearchFiles(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]*structs.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		title := row.OriginalName
+		if title == "" {
+			title = row.Name
+		}
+		hits = append(hits, &structs.SearchHit{
+			Type:        structs.SearchTypeFile,
+			ID:          row.ID,
+			Title:       title,
+			Description: row.Path,
+			Data:        row,
+		})
+	}
+	return hits, nil
+}
+
+// searchTopics searches the topics of the current space
+func (s *searchService) searchTopics(ctx context.Context, query string, limit int) ([]*structs.SearchHit, error) {
+	rows, err := s.ssw.SearchTopics(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]*structs.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		title := row.Title
+		if title == "" {
+			title = row.Name
+		}
+		hits = append(hits, &structs.SearchHit{
+			Type:  structs.SearchTypeTopic,
+			ID:    row.ID,
+			Title: title,
+			Data:  row,
+		})
+	}
+	return hits, nil
+}
+
+// searchTaxonomies searches the taxonomies of the current space
+func (s *searchService) searchTaxonomies(ctx context.Context, query string, limit int) ([]*structs.SearchHit, error) {
+	rows, err := s.ssw.SearchTaxonomies(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]*structs.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, &structs.SearchHit{
+			Type:        structs.SearchTypeTaxonomy,
+			ID:          row.ID,
+			Title:       row.Name,
+			Description: row.Description,
+			Data:        row,
+		})
+	}
+	return hits, nil
+}
+
+// parseSearchTypes parses a comma separated list of types, all types when empty
+func parseSearchTypes(value string) (map[string]bool, error) {
+	types := make(map[string]bool, len(structs.SearchTypes))
+	if strings.TrimSpace(value) == "" {
+		for _, typ := range structs.SearchTypes {
+			types[typ] = true
+		}
+		return types, nil
+	}
+
+	for _, typ := range strings.Split(value, ",") {
+		typ = strings.ToLower(strings.TrimSpace(typ))
+		if typ == "" {
+			continue
+		}
+		known := false
+		for _, t := range structs.SearchTypes {
+			if t == typ {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("%s: %s", ecode.FieldIsInvalid("types"), typ)
+		}
+		types[typ] = true
+	}
+	return types, nil
+}
+
+// searchScore rates how well a hit matches the query by its title:
+// 3 for an exact title, 2 for a title prefix, 1 for a match inside the title,
+// 0.75 for a match in the description and 0.5 for a hit found through another field,
+// such as keywords.
+func searchScore(query, title, description string) float64 {
+	q := strings.ToLower(query)
+	t := strings.ToLower(title)
+	switch {
+	case t == q:
+		return 3
+	case strings.HasPrefix(t, q):
+		return 2
+	case strings.Contains(t, q):
+		return 1
+	case strings.Contains(strings.ToLower(description), q):
+		return 0.75
+	default:
+		return 0.5
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	contentStructs "ncobase/biz/content/structs"
+	spaceStructs "ncobase/core/space/structs"
+	"ncobase/core/system/structs"
+	"ncobase/core/system/wrapper"
+	resourceStructs "ncobase/plugin/resource/structs"
+
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+type spaceSearch []*spaceStructs.ReadSpace
+
+func (s spaceSearch) Search(context.Context, string, int) ([]*spaceStructs.ReadSpace, error) {
+	return s, nil
+}
+
+type fileSearch []*resourceStructs.ReadFile
+
+func (s fileSearch) Search(context.Context, string, int) ([]*resourceStructs.ReadFile, error) {
+	return s, nil
+}
+
+type topicSearch []*contentStructs.ReadTopic
+
+func (s topicSearch) Search(context.Context, string, int) ([]*contentStructs.ReadTopic, error) {
+	return s, nil
+}
+
+// slowTaxonomies answers after the search has given up on it
+type slowTaxonomies struct{}
+
+func (slowTaxonomies) Search(ctx context.Context, _ string, _ int) ([]*contentStructs.ReadTaxonomy, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// searchModules serves the search services of the loaded modules, keyed by "module.service"
+type searchModules struct {
+	ext.ManagerInterface
+	services map[string]any
+}
+
+func (m searchModules) GetCrossService(name, service string) (any, error) {
+	if svc, ok := m.services[name+"."+service]; ok {
+		return svc, nil
+	}
+	return nil, errors.New("extension not found")
+}
+
+func newTestSearch(services map[string]any) *searchService {
+	return &searchService{
+		ssw:     wrapper.NewSearchServiceWrapper(searchModules{services: services}),
+		timeout: 100 * time.Millisecond,
+	}
+}
+
+func TestSearchMergesModules(t *testing.T) {
+	s := newTestSearch(map[string]any{
+		"space.Space":   spaceSearch{{ID: "s1", Name: "acme", Title: "Acme Roadmap"}},
+		"resource.File": fileSearch{{ID: "f1", Name: "roadmap.pdf", Path: "docs/roadmap.pdf"}},
+		"content.Topic": topicSearch{{ID: "t1", Title: "Roadmap"}, {ID: "t2", Title: "Launch", Name: "launch"}},
+	})
+
+	result, err := s.Search(context.Background(), &structs.SearchParams{Q: "roadmap"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	want := []string{"topic:t1", "file:f1", "space:s1", "topic:t2"}
+	if result.Total != len(want) || len(result.Errors) != 0 {
+		t.Fatalf("%d hits with errors %v, want %d", result.Total, result.Errors, len(want))
+	}
+	for i, hit := range result.Items {
+		if got := hit.Type + ":" + hit.ID; got != want[i] {
+			t.Errorf("hit %d is %s, want %s", i, got, want[i])
+		}
+	}
+
+	// pages and types
+	result, err = s.Search(context.Background(), &structs.SearchParams{Q: "roadmap", Types: "topic", Limit: 1})
+	if err != nil || len(result.Items) != 1 || result.Items[0].ID != "t1" || !result.HasMore {
+		t.Fatalf("topic page = %+v, %v", result, err)
+	}
+	if _, err := s.Search(context.Background(), &structs.SearchParams{Q: "roadmap", Types: "user"}); err == nil {
+		t.Fatal("unknown type accepted")
+	}
+}
+
+func TestSearchOmitsDisabledAndSlowModules(t *testing.T) {
+	s := newTestSearch(map[string]any{
+		"content.Topic":    topicSearch{{ID: "t1", Title: "Roadmap"}},
+		"content.Taxonomy": slowTaxonomies{},
+	})
+
+	result, err := s.Search(context.Background(), &structs.SearchParams{Q: "roadmap"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].Type != structs.SearchTypeTopic {
+		t.Fatalf("hits %+v, want the topic only", result.Items)
+	}
+	if result.Errors[structs.SearchTypeTaxonomy] != "timed out" || len(result.Errors) != 1 {
+		t.Fatalf("errors %v, want the taxonomy timeout and nothing for the modules not loaded", result.Errors)
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"ncobase/core/system/structs"
+	"slices"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/types"
+	"github.com/ncobase/ncore/utils/convert"
+)
+
+// ErrSpaceMenuDenied is returned when the menu of a space is requested outside of that space
+var ErrSpaceMenuDenied = errors.New("menu of another space requested")
+
+// GetSpaceMenuTree builds the navigation menu of a space as a tree, higher order first.
+// It holds the active menus assigned to the space, or all active menus when none are,
+// adjusted by the menu customization setting of the space. Menus the current user lacks
+// the permission for are left out unless one of their children is kept. The permissions
+// are those resolved for the current space, so other users than admins only get the menu
+// of their current space.
+func (s *menuService) GetSpaceMenuTree(ctx context.Context, spaceID string, params *structs.SpaceMenuParams) ([]*structs.ReadMenu, error) {
+	if spaceID == "" {
+		return nil, errors.New(ecode.FieldIsRequired("spaceId"))
+	}
+
+	isAdmin := ctxutil.GetUserIsAdmin(ctx)
+	if !isAdmin && ctxutil.GetSpaceID(ctx) != spaceID {
+		return nil, ErrSpaceMenuDenied
+	}
+
+	opts := structs.MenuQueryParams{
+		SortBy:     structs.SortByOrder,
+		ActiveOnly: true,
+		Limit:      1000,
+	}
+	if params != nil {
+		opts.Type = params.Type
+	}
+	menus, err := s.GetMenus(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.tsw != nil && s.tsw.HasSpaceMenuService() {
+		spaceMenuIDs, err := s.tsw.GetSpaceMenus(ctx, spaceID)
+		if err != nil {
+			logger.Warnf(ctx, "Failed to get space menus: %v", err)
+		}
+		menus = withAncestors(menus, s.filterMenusBySpace(menus, spaceMenuIDs))
+	}
+
+	custom := s.getSpaceMenuCustomization(ctx, spaceID)
+	applyMenuCustomization(menus, custom)
+
+	tree := s.buildMenuTree(menus)
+	tree = s.pruneMenuTree(ctx, tree, custom, ctxutil.GetUserPermissions(ctx), isAdmin)
+	s.sortMenusByField(tree, structs.SortByOrder)
+
+	return tree, nil
+}
+
+// getSpaceMenuCustomization reads the menu customization of a space, none when unset or invalid
+func (s *menuService) getSpaceMenuCustomization(ctx context.Context, spaceID string) *structs.SpaceMenuCustomization {
+	custom := &structs.SpaceMenuCustomization{}
+	if s.tsw == nil || !s.tsw.HasSpaceSettingService() {
+		return custom
+	}
+
+	value, err := s.tsw.GetSpaceSetting(ctx, spaceID, structs.SpaceMenuSettingKey)
+	if err != nil || value == nil {
+		// Most spaces keep the default menu
+		return custom
+	}
+
+	raw, err := convert.ToJSON(value)
+	if err == nil && !convert.JSONUnmarshal(raw, custom) {
+		err = errors.New("not a menu customization")
+	}
+	if err != nil {
+		logger.Warnf(ctx, "Ignoring menu customization of space %s: %v", spaceID, err)
+		return &structs.SpaceMenuCustomization{}
+	}
+
+	return custom
+}
+
+// withAncestors returns the menus of all that are in kept or are an ancestor of one,
+// so the menus of a space keep their place in the tree even when their parents are shared
+func withAncestors(all, kept []*structs.ReadMenu) []*structs.ReadMenu {
+	byID := make(map[string]*structs.ReadMenu, len(all))
+	for _, menu := range all {
+		byID[menu.ID] = menu
+	}
+
+	keep := make(map[string]bool, len(kept))
+	for _, menu := range kept {
+		for id := menu.ID; id != "" && !keep[id]; {
+			keep[id] = true
+			parent, ok := byID[id]
+			if !ok {
+				break
+			}
+			id = parent.ParentID
+		}
+	}
+
+	result := make([]*structs.ReadMenu, 0, len(keep))
+	for _, menu := range all {
+		if keep[menu.ID] {
+			result = append(result, menu)
+		}
+	}
+	return result
+}
+
+// applyMenuCustomization applies the order and label overrides of a space to its menus
+func applyMenuCustomization(menus []*structs.ReadMenu, custom *structs.SpaceMenuCustomization) {
+	for _, menu := range menus {
+		if order, ok := lookupMenuOverride(custom.Order, menu); ok {
+			menu.Order = order
+		}
+		if label, ok := lookupMenuOverride(custom.Labels, menu); ok {
+			menu.Label = label
+		}
+	}
+}
+
+// lookupMenuOverride finds the override of a menu by its ID, then by its slug
+func lookupMenuOverride[T any](overrides map[string]T, menu *structs.ReadMenu) (T, bool) {
+	if v, ok := overrides[menu.ID]; ok {
+		return v, true
+	}
+	if menu.Slug != "" {
+		if v, ok := overrides[menu.Slug]; ok {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// pruneMenuTree drops the menus hidden by the space with their children, and the menus
+// the user may not access unless a child is kept, which then keeps its parent in place
+func (s *menuService) pruneMenuTree(ctx context.Context, menus []*structs.ReadMenu, custom *structs.SpaceMenuCustomization, userPermissions []string, isAdmin bool) []*structs.ReadMenu {
+	var pruned []*structs.ReadMenu
+	for _, menu := range menus {
+		if slices.Contains(custom.Hidden, menu.ID) || menu.Slug != "" && slices.Contains(custom.Hidden, menu.Slug) {
+			continue
+		}
+
+		childMenus := make([]*structs.ReadMenu, 0, len(menu.Children))
+		for _, child := range menu.Children {
+			if childMenu, ok := child.(*structs.ReadMenu); ok {
+				childMenus = append(childMenus, childMenu)
+			}
+		}
+		childMenus = s.pruneMenuTree(ctx, childMenus, custom, userPermissions, isAdmin)
+
+		if !s.hasMenuPermission(ctx, menu, userPermissions, isAdmin) && len(childMenus) == 0 {
+			continue
+		}
+
+		menu.Children = make([]types.TreeNode, len(childMenus))
+		for i, child := range childMenus {
+			menu.Children[i] = child
+		}
+		pruned = append(pruned, menu)
+	}
+	return pruned
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"ncobase/core/system/data/ent"
+	"ncobase/core/system/data/repository"
+	"ncobase/core/system/structs"
+	"ncobase/core/system/wrapper"
+
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+// menuRows serves a fixed list of menus
+type menuRows struct {
+	repository.MenuRepositoryInterface
+	rows []*ent.Menu
+}
+
+func (r menuRows) ListWithCount(context.Context, *structs.ListMenuParams) ([]*ent.Menu, int, error) {
+	return r.rows, len(r.rows), nil
+}
+
+// spaceMenus assigns menus to spaces
+type spaceMenus map[string][]string
+
+func (m spaceMenus) GetSpaceMenus(_ context.Context, spaceID string) ([]string, error) {
+	return m[spaceID], nil
+}
+
+func (m spaceMenus) IsMenuInSpace(_ context.Context, spaceID, menuID string) (bool, error) {
+	for _, id := range m[spaceID] {
+		if id == menuID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// menuSettings holds the menu customization of spaces
+type menuSettings map[string]any
+
+func (m menuSettings) GetSettingValue(_ context.Context, spaceID, key string) (any, error) {
+	if value, ok := m[spaceID]; ok && key == structs.SpaceMenuSettingKey {
+		return value, nil
+	}
+	return nil, errors.New("setting not found")
+}
+
+// newTestMenus returns a menu service over this menu tree, listed in shuffled order:
+//
+//	dashboard (1)
+//	reports (2, read:reports)
+//	  sales (1)
+//	settings (3, read:settings)
+//	  roles (1, read:roles)
+//	  users (2, read:users)
+//	audit (4, read:audit)
+//	archive (5, hidden)
+//
+// Menu trees list higher orders first.
+func newTestMenus(services map[string]any) *menuService {
+	rows := []*ent.Menu{
+		{ID: "users", Label: "Users", Order: 2, Perms: "read:users", ParentID: "settings"},
+		{ID: "audit", Label: "Audit", Order: 4, Perms: "read:audit"},
+		{ID: "settings", Label: "Settings", Slug: "settings", Order: 3, Perms: "read:settings"},
+		{ID: "sales", Label: "Sales", Order: 1, ParentID: "reports"},
+		{ID: "dash", Label: "Dashboard", Slug: "dashboard", Order: 1},
+		{ID: "roles", Label: "Roles", Order: 1, Perms: "read:roles", ParentID: "settings"},
+		{ID: "archive", Label: "Archive", Order: 5, Hidden: true},
+		{ID: "reports", Label: "Reports", Order: 2, Perms: "read:reports"},
+	}
+	return &menuService{menu: menuRows{rows: rows}, tsw: wrapper.NewSpaceServiceWrapper(searchModules{services: services})}
+}
+
+// outline renders a menu tree as "label[children]" in order
+func outline(menus []*structs.ReadMenu) string {
+	parts := make([]string, len(menus))
+	for i, menu := range menus {
+		var children []*structs.ReadMenu
+		for _, child := range menu.Children {
+			children = append(children, child.(*structs.ReadMenu))
+		}
+		parts[i] = menu.Label
+		if len(children) > 0 {
+			parts[i] += "[" + outline(children) + "]"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func userContext(spaceID string, permissions ...string) context.Context {
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+	ctx = ctxutil.SetSpaceID(ctx, spaceID)
+	return ctxutil.SetUserPermissions(ctx, permissions)
+}
+
+func TestSpaceMenuTreeFollowsPermissions(t *testing.T) {
+	s := newTestMenus(nil)
+
+	for _, tt := range []struct {
+		permissions []string
+		want        string
+	}{
+		// settings stays as the parent of the users menu
+		{[]string{"read:users"}, "Settings[Users] Reports[Sales] Dashboard"},
+		{[]string{"read:settings", "*:roles", "read:audit"}, "Audit Settings[Roles] Reports[Sales] Dashboard"},
+		{nil, "Reports[Sales] Dashboard"},
+	} {
+		tree, err := s.GetSpaceMenuTree(userContext("s1", tt.permissions...), "s1", nil)
+		if err != nil {
+			t.Fatalf("menu tree: %v", err)
+		}
+		if got := outline(tree); got != tt.want {
+			t.Fatalf("menu tree of %v = %s, want %s", tt.permissions, got, tt.want)
+		}
+	}
+
+	admin := ctxutil.SetUserIsAdmin(userContext("s2"), true)
+	tree, err := s.GetSpaceMenuTree(admin, "s1", nil)
+	if err != nil {
+		t.Fatalf("admin menu tree: %v", err)
+	}
+	if got := outline(tree); got != "Audit Settings[Users Roles] Reports[Sales] Dashboard" {
+		t.Fatalf("admin menu tree = %s", got)
+	}
+}
+
+func TestSpaceMenuTreeOfSpace(t *testing.T) {
+	s := newTestMenus(map[string]any{
+		"space.SpaceMenu": spaceMenus{"s1": {"dash", "users", "sales"}},
+		"space.SpaceSetting": menuSettings{"s1": map[string]any{
+			"hidden": []any{"dashboard"},
+			"order":  map[string]any{"settings": 0},
+			"labels": map[string]any{"users": "People"},
+		}},
+	})
+	ctx := userContext("s1", "read:users", "read:roles", "read:reports")
+
+	tree, err := s.GetSpaceMenuTree(ctx, "s1", nil)
+	if err != nil {
+		t.Fatalf("menu tree: %v", err)
+	}
+	// the assigned menus keep their parents, the customization hides, moves and renames
+	if got := outline(tree); got != "Reports[Sales] Settings[People]" {
+		t.Fatalf("menu tree of s1 = %s, want Reports[Sales] Settings[People]", got)
+	}
+
+	// a space without assigned menus or customization gets all active menus
+	tree, err = s.GetSpaceMenuTree(userContext("s2", "read:users", "read:roles", "read:reports"), "s2", nil)
+	if err != nil {
+		t.Fatalf("menu tree of s2: %v", err)
+	}
+	if got := outline(tree); got != "Settings[Users Roles] Reports[Sales] Dashboard" {
+		t.Fatalf("menu tree of s2 = %s", got)
+	}
+}
+
+func TestSpaceMenuTreeOfAnotherSpaceDenied(t *testing.T) {
+	s := newTestMenus(nil)
+	if _, err := s.GetSpaceMenuTree(userContext("s1"), "s2", nil); !errors.Is(err, ErrSpaceMenuDenied) {
+		t.Fatalf("menu of another space: %v, want ErrSpaceMenuDenied", err)
+	}
+	if _, err := s.GetSpaceMenuTree(userContext("s1"), "", nil); err == nil {
+		t.Fatal("menu without a space accepted")
+	}
+}
+
+func TestBrokenMenuCustomizationIsIgnored(t *testing.T) {
+	s := newTestMenus(map[string]any{"space.SpaceSetting": menuSettings{"s1": "not json"}})
+	tree, err := s.GetSpaceMenuTree(userContext("s1"), "s1", nil)
+	if err != nil {
+		t.Fatalf("menu tree: %v", err)
+	}
+	if got := outline(tree); got != "Reports[Sales] Dashboard" {
+		t.Fatalf("menu tree = %s, want the default menu", got)
+	}
+}
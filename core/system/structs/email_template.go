@@ -0,0 +1,69 @@
+package structs
+
+// EmailTemplateBody represents an email template entity.
+//
+// Subject and body interpolate variables written as {{ name }} or {{ user.name }},
+// resolved from the render data. No other template logic is evaluated.
+type EmailTemplateBody struct {
+	Name        string  `json:"name,omitempty"`
+	SpaceID     string  `json:"space_id,omitempty"` // Empty for a template shared by every space
+	Subject     string  `json:"subject,omitempty"`
+	Body        string  `json:"body,omitempty"`
+	HTML        bool    `json:"html,omitempty"` // Escape interpolated values for HTML
+	Description string  `json:"description,omitempty"`
+	Disabled    bool    `json:"disabled,omitempty"`
+	CreatedBy   *string `json:"created_by,omitempty"`
+	UpdatedBy   *string `json:"updated_by,omitempty"`
+}
+
+// CreateEmailTemplateBody represents the body for creating an email template.
+type CreateEmailTemplateBody struct {
+	EmailTemplateBody
+}
+
+// UpdateEmailTemplateBody represents the body for updating an email template.
+type UpdateEmailTemplateBody struct {
+	ID string `json:"id,omitempty"`
+	EmailTemplateBody
+}
+
+// ReadEmailTemplate represents the output schema for retrieving an email template.
+type ReadEmailTemplate struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	SpaceID     string  `json:"space_id,omitempty"`
+	Subject     string  `json:"subject"`
+	Body        string  `json:"body"`
+	HTML        bool    `json:"html"`
+	Description string  `json:"description,omitempty"`
+	Disabled    bool    `json:"disabled"`
+	CreatedBy   *string `json:"created_by,omitempty"`
+	CreatedAt   *int64  `json:"created_at,omitempty"`
+	UpdatedBy   *string `json:"updated_by,omitempty"`
+	UpdatedAt   *int64  `json:"updated_at,omitempty"`
+}
+
+// ListEmailTemplateParams represents the query parameters for listing email templates.
+type ListEmailTemplateParams struct {
+	SpaceID string `form:"space_id,omitempty" json:"space_id,omitempty"` // Defaults to the current space
+}
+
+// RenderEmailTemplateBody represents the body for rendering an email template.
+type RenderEmailTemplateBody struct {
+	Data   map[string]any `json:"data,omitempty"`
+	Strict bool           `json:"strict,omitempty"` // Fail on variables missing from data instead of leaving them empty
+}
+
+// SendEmailTemplateBody represents the body for sending an email template.
+type SendEmailTemplateBody struct {
+	To []string `json:"to" validate:"required,min=1,dive,email"`
+	RenderEmailTemplateBody
+}
+
+// RenderedEmail is a rendered email template
+type RenderedEmail struct {
+	Template string `json:"template"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+	HTML     bool   `json:"html"`
+}
@@ -0,0 +1,54 @@
+package structs
+
+// DataErasureStatus is the progress of a user data erasure or one of its sections
+type DataErasureStatus string
+
+// Data erasure statuses
+const (
+	DataErasurePending   DataErasureStatus = "pending"
+	DataErasureRunning   DataErasureStatus = "running"
+	DataErasureCompleted DataErasureStatus = "completed"
+	DataErasureFailed    DataErasureStatus = "failed"
+	// DataErasureSkipped marks an optional section whose module is not loaded, it is tried again on the next run
+	DataErasureSkipped DataErasureStatus = "skipped"
+)
+
+// DataErasureAction is what happens to the records of a section
+type DataErasureAction string
+
+// Data erasure actions
+const (
+	DataErasureDelete    DataErasureAction = "delete"
+	DataErasureAnonymize DataErasureAction = "anonymize"
+)
+
+// DataErasureSection reports the erasure of one module section
+type DataErasureSection struct {
+	Section    string            `json:"section"`
+	Action     DataErasureAction `json:"action"`
+	Status     DataErasureStatus `json:"status"`
+	Deleted    int               `json:"deleted"`
+	Anonymized int               `json:"anonymized"`
+	Error      string            `json:"error,omitempty"`
+	UpdatedAt  int64             `json:"updated_at,omitempty"`
+}
+
+// DataErasure is a user data erasure and its report.
+// Sections run in order; a failed run is resumed by starting it again, completed sections are kept.
+type DataErasure struct {
+	UserID      string                `json:"user_id"`
+	RequestedBy string                `json:"requested_by"`
+	Status      DataErasureStatus     `json:"status"`
+	Sections    []*DataErasureSection `json:"sections"`
+	Deleted     int                   `json:"deleted"`
+	Anonymized  int                   `json:"anonymized"`
+	Error       string                `json:"error,omitempty"`
+	CreatedAt   int64                 `json:"created_at"`
+	UpdatedAt   int64                 `json:"updated_at"`
+	CompletedAt int64                 `json:"completed_at,omitempty"`
+}
+
+// CreateDataErasureBody requests the erasure of the data of a user
+type CreateDataErasureBody struct {
+	UserID string `json:"user_id" binding:"required"`
+}
@@ -0,0 +1,44 @@
+package structs
+
+// DataExportStatus is the progress of a user data export
+type DataExportStatus string
+
+// Data export statuses
+const (
+	DataExportPending   DataExportStatus = "pending"
+	DataExportRunning   DataExportStatus = "running"
+	DataExportCompleted DataExportStatus = "completed"
+	DataExportFailed    DataExportStatus = "failed"
+)
+
+// DataExport is a user data export job.
+// Once completed, the archive is downloaded from DownloadURL until ExpiresAt.
+type DataExport struct {
+	ID          string           `json:"id"`
+	UserID      string           `json:"user_id"`
+	RequestedBy string           `json:"requested_by"`
+	Status      DataExportStatus `json:"status"`
+	Sections    []string         `json:"sections,omitempty"`
+	// Errors lists the sections that failed, the archive holds what they wrote before failing
+	Errors      map[string]string `json:"errors,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	Size        int64             `json:"size,omitempty"`
+	DownloadURL string            `json:"download_url,omitempty"`
+	CreatedAt   int64             `json:"created_at"`
+	CompletedAt int64             `json:"completed_at,omitempty"`
+	ExpiresAt   int64             `json:"expires_at,omitempty"`
+}
+
+// CreateDataExportBody requests an export of the data of a user
+type CreateDataExportBody struct {
+	// UserID is the user to export, the current user when empty; only admins may export other users
+	UserID string `json:"user_id,omitempty"`
+}
+
+// DataExportManifest describes the contents of an export archive, stored as manifest.json
+type DataExportManifest struct {
+	UserID     string            `json:"user_id"`
+	ExportedAt int64             `json:"exported_at"`
+	Sections   []string          `json:"sections"`
+	Errors     map[string]string `json:"errors,omitempty"`
+}
@@ -0,0 +1,60 @@
+package structs
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRolloutBucketIsStable(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		subject := fmt.Sprintf("user-%d", i)
+		bucket := RolloutBucket("new-editor", subject)
+		if bucket < 0 || bucket > 99 {
+			t.Fatalf("bucket of %s = %d, want 0-99", subject, bucket)
+		}
+		if again := RolloutBucket("new-editor", subject); again != bucket {
+			t.Fatalf("bucket of %s changed from %d to %d", subject, bucket, again)
+		}
+	}
+}
+
+func TestEnabledForRollout(t *testing.T) {
+	const subjects = 10000
+	flag := &FeatureFlag{Key: "new-editor", Enabled: true, Rollout: 25}
+
+	on := 0
+	for i := 0; i < subjects; i++ {
+		subject := fmt.Sprintf("user-%d", i)
+		enabled := flag.EnabledFor(subject)
+		if enabled {
+			on++
+		}
+		// raising the rollout keeps everyone who already had the flag
+		wider := &FeatureFlag{Key: flag.Key, Enabled: true, Rollout: 50}
+		if enabled && !wider.EnabledFor(subject) {
+			t.Fatalf("%s lost the flag when the rollout grew", subject)
+		}
+	}
+	if on < subjects*20/100 || on > subjects*30/100 {
+		t.Fatalf("flag on for %d of %d subjects, want about 25%%", on, subjects)
+	}
+}
+
+func TestEnabledForBounds(t *testing.T) {
+	for _, tc := range []struct {
+		flag *FeatureFlag
+		want bool
+	}{
+		{nil, false},
+		{&FeatureFlag{Key: "f", Enabled: false, Rollout: 100}, false},
+		{&FeatureFlag{Key: "f", Enabled: true, Rollout: 0}, false},
+		{&FeatureFlag{Key: "f", Enabled: true, Rollout: 100}, true},
+		{&FeatureFlag{Key: "f", Enabled: true, Rollout: 150}, true},
+	} {
+		for _, subject := range []string{"", "u1", "u2"} {
+			if got := tc.flag.EnabledFor(subject); got != tc.want {
+				t.Errorf("%+v.EnabledFor(%q) = %v, want %v", tc.flag, subject, got, tc.want)
+			}
+		}
+	}
+}
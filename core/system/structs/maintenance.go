@@ -0,0 +1,92 @@
+package structs
+
+import (
+	"net"
+	"net/http"
+)
+
+// MaintenanceMode is how far the API is taken down during maintenance
+type MaintenanceMode string
+
+// Maintenance modes
+const (
+	MaintenanceOff      MaintenanceMode = "off"
+	MaintenanceReadOnly MaintenanceMode = "read_only"
+	MaintenanceOffline  MaintenanceMode = "offline"
+)
+
+// DefaultMaintenanceRetryAfter is the default retry hint in seconds
+const DefaultMaintenanceRetryAfter = 300
+
+// MaintenanceState is the current maintenance state
+type MaintenanceState struct {
+	Mode       MaintenanceMode `json:"mode"`
+	Message    string          `json:"message,omitempty"`
+	RetryAfter int             `json:"retry_after"`
+	AllowIPs   []string        `json:"allow_ips,omitempty"`
+	AllowUsers []string        `json:"allow_users,omitempty"`
+	UpdatedBy  string          `json:"updated_by,omitempty"`
+	UpdatedAt  int64           `json:"updated_at,omitempty"`
+}
+
+// UpdateMaintenanceBody switches maintenance mode at runtime
+type UpdateMaintenanceBody struct {
+	Mode       MaintenanceMode `json:"mode" validate:"required,oneof=off read_only offline"`
+	Message    string          `json:"message,omitempty"`
+	RetryAfter int             `json:"retry_after,omitempty" validate:"omitempty,min=0"`
+	AllowIPs   []string        `json:"allow_ips,omitempty"`
+	AllowUsers []string        `json:"allow_users,omitempty"`
+}
+
+// IsValid reports whether m is a known mode
+func (m MaintenanceMode) IsValid() bool {
+	switch m {
+	case MaintenanceOff, MaintenanceReadOnly, MaintenanceOffline:
+		return true
+	}
+	return false
+}
+
+// Blocks reports whether the state blocks a request with the given method
+func (s *MaintenanceState) Blocks(method string) bool {
+	if s == nil {
+		return false
+	}
+	switch s.Mode {
+	case MaintenanceOffline:
+		return true
+	case MaintenanceReadOnly:
+		return method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+	}
+	return false
+}
+
+// Allows reports whether the client IP or user is on the allowlist.
+// IP entries may be single addresses or CIDR ranges.
+func (s *MaintenanceState) Allows(clientIP, userID string) bool {
+	if s == nil {
+		return false
+	}
+	if userID != "" {
+		for _, u := range s.AllowUsers {
+			if u == userID {
+				return true
+			}
+		}
+	}
+
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	for _, entry := range s.AllowIPs {
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+		} else if allowed := net.ParseIP(entry); allowed != nil && allowed.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -138,3 +138,18 @@ type ListMenuParams struct {
 	Children  bool   `form:"children,omitempty" json:"children,omitempty"`
 	SortBy    string `form:"sort_by,omitempty" json:"sort_by,omitempty"`
 }
+
+// SpaceMenuSettingKey is the space setting holding the menu customization of a space
+const SpaceMenuSettingKey = "menu.customization"
+
+// SpaceMenuCustomization adjusts the menu tree of a space. Menus are referenced by ID or slug.
+type SpaceMenuCustomization struct {
+	Hidden []string          `json:"hidden,omitempty"` // menus left out with their children
+	Order  map[string]int    `json:"order,omitempty"`  // order overrides
+	Labels map[string]string `json:"labels,omitempty"` // label overrides
+}
+
+// SpaceMenuParams represents the query parameters for the menu tree of a space.
+type SpaceMenuParams struct {
+	Type string `form:"type,omitempty" json:"type,omitempty"`
+}
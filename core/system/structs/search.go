@@ -0,0 +1,41 @@
+package structs
+
+// Search result types
+const (
+	SearchTypeSpace    = "space"
+	SearchTypeFile     = "file"
+	SearchTypeTopic    = "topic"
+	SearchTypeTaxonomy = "taxonomy"
+)
+
+// SearchTypes lists the searchable types in ranking order, used to break score ties
+var SearchTypes = []string{SearchTypeSpace, SearchTypeTopic, SearchTypeTaxonomy, SearchTypeFile}
+
+// SearchParams represents the query parameters for searching across modules.
+type SearchParams struct {
+	Q      string `form:"q" json:"q" validate:"required"`
+	Types  string `form:"types,omitempty" json:"types,omitempty"` // Comma separated, e.g. "topic,file"; all types by default
+	Limit  int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset int    `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// SearchHit is a single search result, tagged with the type of record found.
+type SearchHit struct {
+	Type        string  `json:"type"`
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	Score       float64 `json:"score"`
+	Data        any     `json:"data"`
+}
+
+// SearchResult represents a page of search results merged from all modules.
+type SearchResult struct {
+	Items   []*SearchHit `json:"items"`
+	Total   int          `json:"total"`
+	Limit   int          `json:"limit"`
+	Offset  int          `json:"offset"`
+	HasMore bool         `json:"has_more"`
+	// Errors lists the types that failed or timed out, their results are missing
+	Errors map[string]string `json:"errors,omitempty"`
+}
@@ -5,6 +5,7 @@ import (
 	"ncobase/core/system/data"
 	"ncobase/core/system/handler"
 	"ncobase/core/system/service"
+	"ncobase/core/system/structs"
 	"ncobase/internal/middleware"
 	"sync"
 
@@ -36,6 +37,10 @@ type Module struct {
 	s *service.Service
 	d *data.Data
 
+	maintenance *structs.MaintenanceState
+	export      *service.DataExportConfig
+	erasure     *service.DataErasureConfig
+
 	discovery
 }
 
@@ -77,6 +82,10 @@ func (m *Module) Init(conf *config.Config, em ext.ManagerInterface) (err error)
 		m.discovery.meta = conf.Consul.Discovery.DefaultMeta
 	}
 
+	m.maintenance = service.MaintenanceFromViper(conf.Viper)
+	m.export = service.DataExportConfigFromViper(conf.Viper)
+	m.erasure = service.DataErasureConfigFromViper(conf.Viper)
+
 	m.em = em
 	m.initialized = true
 
@@ -86,6 +95,9 @@ func (m *Module) Init(conf *config.Config, em ext.ManagerInterface) (err error)
 // PostInit performs any necessary setup after initialization
 func (m *Module) PostInit() error {
 	m.s = service.New(m.d, m.em)
+	m.s.Maintenance.SetDefault(m.maintenance)
+	m.s.DataExport.SetConfig(m.export)
+	m.s.DataErasure.SetConfig(m.erasure)
 	m.h = handler.New(m.s)
 	// Subscribe to relevant events
 	m.subscribeEvents(m.em)
@@ -111,6 +123,7 @@ func (m *Module) RegisterRoutes(r *gin.RouterGroup) {
 		menus.GET("/navigation", m.h.Menu.GetNavigationMenus)
 		menus.GET("/tree", m.h.Menu.GetMenuTree)
 		menus.GET("/authorized/:user_id", m.h.Menu.GetUserAuthorizedMenus)
+		menus.GET("/spaces/:spaceId", m.h.Menu.GetSpaceMenuTree)
 		menus.GET("/:slug", m.h.Menu.Get)
 		menus.GET("/slug/:slug", m.h.Menu.GetBySlug)
 
@@ -161,6 +174,28 @@ func (m *Module) RegisterRoutes(r *gin.RouterGroup) {
 		options.DELETE("/prefix", middleware.HasPermission("manage:system"), m.h.Option.DeleteByPrefix)
 	}
 
+	// Email template endpoints
+	emailTemplates := sysGroup.Group("/email-templates", middleware.HasPermission("manage:system"))
+	{
+		emailTemplates.GET("", m.h.Email.List)
+		emailTemplates.POST("", m.h.Email.Create)
+		emailTemplates.GET("/:id", m.h.Email.Get)
+		emailTemplates.PUT("/:id", m.h.Email.Update)
+		emailTemplates.DELETE("/:id", m.h.Email.Delete)
+		emailTemplates.POST("/:id/render", m.h.Email.Render)
+	}
+
+	// Search across modules - results are limited to what the user may access
+	sysGroup.GET("/search", m.h.Search.Search)
+
+	// Data exports - users export their own data, admins any user's
+	exports := sysGroup.Group("/exports")
+	{
+		exports.POST("", m.h.DataExport.Create)
+		exports.GET("/:id", m.h.DataExport.Get)
+		exports.GET("/:id/download", m.h.DataExport.Download)
+	}
+
 	// Admin endpoints - requires admin permission
 	admin := sysGroup.Group("/admin", middleware.HasPermission("admin:system"))
 	{
@@ -171,12 +206,24 @@ func (m *Module) RegisterRoutes(r *gin.RouterGroup) {
 		admin.GET("/config", m.h.Admin.GetSystemConfig)
 		admin.PUT("/config", m.h.Admin.UpdateSystemConfig)
 
+		admin.GET("/maintenance", m.h.Maintenance.Get)
+		admin.PUT("/maintenance", m.h.Maintenance.Update)
+
+		admin.GET("/plugins", m.h.Plugin.List)
+
+		admin.GET("/feature-flags", m.h.FeatureFlag.List)
+		admin.PUT("/feature-flags/:flag", m.h.FeatureFlag.Update)
+		admin.DELETE("/feature-flags/:flag", m.h.FeatureFlag.Delete)
+
 		admin.GET("/dashboard/stats", m.h.Admin.GetDashboardStats)
 		admin.GET("/activity", m.h.Admin.GetUserActivity)
 
 		admin.GET("/users", m.h.Admin.ManageUsers)
 		admin.GET("/users/:user_id", m.h.Admin.GetUserDetails)
 		admin.PUT("/users/:user_id/status", m.h.Admin.UpdateUserStatus)
+
+		admin.POST("/erasures", m.h.DataErasure.Create)
+		admin.GET("/erasures/:user_id", m.h.DataErasure.Get)
 	}
 }
 
@@ -0,0 +1,95 @@
+package wrapper
+
+import (
+	"context"
+	"sync"
+
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+// DataEraser is implemented by the services of modules holding data of a user.
+// EraseUserData deletes the records of the user, or with anonymize keeps them without
+// personal data, in a transaction of the module. It reports the records removed and
+// anonymized, and erasing data already erased reports nothing.
+type DataEraser interface {
+	EraseUserData(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error)
+}
+
+// eraseSource is a module service erasing one section of a user data erasure
+type eraseSource struct {
+	section string
+	module  string
+	service string
+}
+
+// eraseSources lists the services erasing user data, in the order they run.
+// Sessions go first so the user is signed out before their data goes,
+// the account goes last so an interrupted erasure can still be found by user.
+var eraseSources = []eraseSource{
+	{section: "sessions", module: "auth", service: "Session"},
+	{section: "files", module: "resource", service: "File"},
+	{section: "spaces", module: "space", service: "UserSpace"},
+	{section: "roles", module: "access", service: "UserRole"},
+	{section: "activity", module: "access", service: "Activity"},
+	{section: "payments", module: "payment", service: "Order"},
+	{section: "user", module: "user", service: "User"},
+}
+
+// EraseSections returns the erasure sections in the order they run
+func EraseSections() []string {
+	sections := make([]string, len(eraseSources))
+	for i, source := range eraseSources {
+		sections[i] = source.section
+	}
+	return sections
+}
+
+// EraseServiceWrapper wraps the data erasers of other modules.
+// Modules may be loaded after the system module, so missing erasers are looked up again on use.
+type EraseServiceWrapper struct {
+	em      ext.ManagerInterface
+	mu      sync.RWMutex
+	erasers map[string]DataEraser
+}
+
+// NewEraseServiceWrapper creates a new erase service wrapper
+func NewEraseServiceWrapper(em ext.ManagerInterface) *EraseServiceWrapper {
+	wrapper := &EraseServiceWrapper{em: em, erasers: map[string]DataEraser{}}
+	wrapper.loadServices()
+	return wrapper
+}
+
+// loadServices loads the erasers that are not loaded yet
+func (w *EraseServiceWrapper) loadServices() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, source := range eraseSources {
+		if w.erasers[source.section] != nil {
+			continue
+		}
+		if svc, err := w.em.GetCrossService(source.module, source.service); err == nil {
+			if eraser, ok := svc.(DataEraser); ok {
+				w.erasers[source.section] = eraser
+			}
+		}
+	}
+}
+
+// RefreshServices refreshes service references
+func (w *EraseServiceWrapper) RefreshServices() {
+	w.loadServices()
+}
+
+// Erasers returns the loaded erasers by section
+func (w *EraseServiceWrapper) Erasers() map[string]DataEraser {
+	w.loadServices()
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	erasers := make(map[string]DataEraser, len(w.erasers))
+	for section, eraser := range w.erasers {
+		erasers[section] = eraser
+	}
+	return erasers
+}
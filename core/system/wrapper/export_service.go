@@ -0,0 +1,80 @@
+package wrapper
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+// DataExporter is implemented by the services of modules holding data of a user.
+// ExportUserData writes the records of the user to w as JSON lines.
+type DataExporter interface {
+	ExportUserData(ctx context.Context, userID string, w io.Writer) error
+}
+
+// exportSource is a module service exporting one section of a user data export
+type exportSource struct {
+	section string
+	module  string
+	service string
+}
+
+// exportSources lists the services asked for user data, by archive section
+var exportSources = []exportSource{
+	{section: "user", module: "user", service: "UserMeshes"},
+	{section: "spaces", module: "space", service: "UserSpace"},
+	{section: "files", module: "resource", service: "File"},
+	{section: "activity", module: "access", service: "Activity"},
+}
+
+// ExportServiceWrapper wraps the data exporters of other modules.
+// Modules may be loaded after the system module, so missing exporters are looked up again on use.
+type ExportServiceWrapper struct {
+	em        ext.ManagerInterface
+	mu        sync.RWMutex
+	exporters map[string]DataExporter
+}
+
+// NewExportServiceWrapper creates a new export service wrapper
+func NewExportServiceWrapper(em ext.ManagerInterface) *ExportServiceWrapper {
+	wrapper := &ExportServiceWrapper{em: em, exporters: map[string]DataExporter{}}
+	wrapper.loadServices()
+	return wrapper
+}
+
+// loadServices loads the exporters that are not loaded yet
+func (w *ExportServiceWrapper) loadServices() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, source := range exportSources {
+		if w.exporters[source.section] != nil {
+			continue
+		}
+		if svc, err := w.em.GetCrossService(source.module, source.service); err == nil {
+			if exporter, ok := svc.(DataExporter); ok {
+				w.exporters[source.section] = exporter
+			}
+		}
+	}
+}
+
+// RefreshServices refreshes service references
+func (w *ExportServiceWrapper) RefreshServices() {
+	w.loadServices()
+}
+
+// Exporters returns the loaded exporters by section
+func (w *ExportServiceWrapper) Exporters() map[string]DataExporter {
+	w.loadServices()
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	exporters := make(map[string]DataExporter, len(w.exporters))
+	for section, exporter := range w.exporters {
+		exporters[section] = exporter
+	}
+	return exporters
+}
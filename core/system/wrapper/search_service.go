@@ -0,0 +1,166 @@
+package wrapper
+
+import (
+	"context"
+	"fmt"
+	contentStructs "ncobase/biz/content/structs"
+	spaceStructs "ncobase/core/space/structs"
+	resourceStructs "ncobase/plugin/resource/structs"
+	"sync"
+
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+// SpaceSearchServiceInterface defines space search for system module
+type SpaceSearchServiceInterface interface {
+	Search(ctx context.Context, query string, limit int) ([]*spaceStructs.ReadSpace, error)
+}
+
+// FileSearchServiceInterface defines file search for system module
+type FileSearchServiceInterface interface {
+	Search(ctx context.Context, query string, limit int) ([]*resourceStructs.ReadFile, error)
+}
+
+// TopicSearchServiceInterface defines topic search for system module
+type TopicSearchServiceInterface interface {
+	Search(ctx context.Context, query string, limit int) ([]*contentStructs.ReadTopic, error)
+}
+
+// TaxonomySearchServiceInterface defines taxonomy search for system module
+type TaxonomySearchServiceInterface interface {
+	Search(ctx context.Context, query string, limit int) ([]*contentStructs.ReadTaxonomy, error)
+}
+
+// SearchServiceWrapper wraps the search services of other modules.
+// Modules may be loaded after the system module, so missing services are looked up again on use.
+type SearchServiceWrapper struct {
+	em              ext.ManagerInterface
+	mu              sync.RWMutex
+	spaceService    SpaceSearchServiceInterface
+	fileService     FileSearchServiceInterface
+	topicService    TopicSearchServiceInterface
+	taxonomyService TaxonomySearchServiceInterface
+}
+
+// NewSearchServiceWrapper creates a new search service wrapper
+func NewSearchServiceWrapper(em ext.ManagerInterface) *SearchServiceWrapper {
+	wrapper := &SearchServiceWrapper{em: em}
+	wrapper.loadServices()
+	return wrapper
+}
+
+// loadServices loads the search services that are not loaded yet
+func (w *SearchServiceWrapper) loadServices() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.spaceService == nil {
+		if svc, err := w.em.GetCrossService("space", "Space"); err == nil {
+			if service, ok := svc.(SpaceSearchServiceInterface); ok {
+				w.spaceService = service
+			}
+		}
+	}
+	if w.fileService == nil {
+		if svc, err := w.em.GetCrossService("resource", "File"); err == nil {
+			if service, ok := svc.(FileSearchServiceInterface); ok {
+				w.fileService = service
+			}
+		}
+	}
+	if w.topicService == nil {
+		if svc, err := w.em.GetCrossService("content", "Topic"); err == nil {
+			if service, ok := svc.(TopicSearchServiceInterface); ok {
+				w.topicService = service
+			}
+		}
+	}
+	if w.taxonomyService == nil {
+		if svc, err := w.em.GetCrossService("content", "Taxonomy"); err == nil {
+			if service, ok := svc.(TaxonomySearchServiceInterface); ok {
+				w.taxonomyService = service
+			}
+		}
+	}
+}
+
+// RefreshServices refreshes service references
+func (w *SearchServiceWrapper) RefreshServices() {
+	w.loadServices()
+}
+
+// SearchSpaces searches spaces via space service
+func (w *SearchServiceWrapper) SearchSpaces(ctx context.Context, query string, limit int) ([]*spaceStructs.ReadSpace, error) {
+	w.mu.RLock()
+	service := w.spaceService
+	w.mu.RUnlock()
+
+	if service != nil {
+		return service.Search(ctx, query, limit)
+	}
+	return nil, fmt.Errorf("space service not available")
+}
+
+// SearchFiles searches files via resource service
+func (w *SearchServiceWrapper) SearchFiles(ctx context.Context, query string, limit int) ([]*resourceStructs.ReadFile, error) {
+	w.mu.RLock()
+	service := w.fileService
+	w.mu.RUnlock()
+
+	if service != nil {
+		return service.Search(ctx, query, limit)
+	}
+	return nil, fmt.Errorf("resource file service not available")
+}
+
+// SearchTopics searches topics via content service
+func (w *SearchServiceWrapper) SearchTopics(ctx context.Context, query string, limit int) ([]*contentStructs.ReadTopic, error) {
+	w.mu.RLock()
+	service := w.topicService
+	w.mu.RUnlock()
+
+	if service != nil {
+		return service.Search(ctx, query, limit)
+	}
+	return nil, fmt.Errorf("content topic service not available")
+}
+
+// SearchTaxonomies searches taxonomies via content service
+func (w *SearchServiceWrapper) SearchTaxonomies(ctx context.Context, query string, limit int) ([]*contentStructs.ReadTaxonomy, error) {
+	w.mu.RLock()
+	service := w.taxonomyService
+	w.mu.RUnlock()
+
+	if service != nil {
+		return service.Search(ctx, query, limit)
+	}
+	return nil, fmt.Errorf("content taxonomy service not available")
+}
+
+// HasSpaceService checks if space search is available
+func (w *SearchServiceWrapper) HasSpaceService() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.spaceService != nil
+}
+
+// HasFileService checks if file search is available
+func (w *SearchServiceWrapper) HasFileService() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.fileService != nil
+}
+
+// HasTopicService checks if topic search is available
+func (w *SearchServiceWrapper) HasTopicService() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.topicService != nil
+}
+
+// HasTaxonomyService checks if taxonomy search is available
+func (w *SearchServiceWrapper) HasTaxonomyService() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.taxonomyService != nil
+}
@@ -13,10 +13,16 @@ type SpaceMenuServiceInterface interface {
 	IsMenuInSpace(ctx context.Context, spaceID, menuID string) (bool, error)
 }
 
+// SpaceSettingServiceInterface defines space setting service interface for system module
+type SpaceSettingServiceInterface interface {
+	GetSettingValue(ctx context.Context, spaceID, key string) (any, error)
+}
+
 // SpaceServiceWrapper wraps space service access
 type SpaceServiceWrapper struct {
-	em               ext.ManagerInterface
-	spaceMenuService SpaceMenuServiceInterface
+	em                  ext.ManagerInterface
+	spaceMenuService    SpaceMenuServiceInterface
+	spaceSettingService SpaceSettingServiceInterface
 }
 
 // NewSpaceServiceWrapper creates a new space service wrapper
@@ -33,6 +39,11 @@ func (w *SpaceServiceWrapper) loadServices() {
 			w.spaceMenuService = service
 		}
 	}
+	if spaceSvc, err := w.em.GetCrossService("space", "SpaceSetting"); err == nil {
+		if service, ok := spaceSvc.(SpaceSettingServiceInterface); ok {
+			w.spaceSettingService = service
+		}
+	}
 }
 
 // RefreshServices refreshes service references
@@ -60,3 +71,16 @@ func (w *SpaceServiceWrapper) IsMenuInSpace(ctx context.Context, spaceID, menuID
 func (w *SpaceServiceWrapper) HasSpaceMenuService() bool {
 	return w.spaceMenuService != nil
 }
+
+// GetSpaceSetting gets the typed value of a space setting
+func (w *SpaceServiceWrapper) GetSpaceSetting(ctx context.Context, spaceID, key string) (any, error) {
+	if w.spaceSettingService != nil {
+		return w.spaceSettingService.GetSettingValue(ctx, spaceID, key)
+	}
+	return nil, fmt.Errorf("space setting service not available")
+}
+
+// HasSpaceSettingService checks if space setting service is available
+func (w *SpaceServiceWrapper) HasSpaceSettingService() bool {
+	return w.spaceSettingService != nil
+}
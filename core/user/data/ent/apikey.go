@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"ncobase/core/user/data/ent/apikey"
 	"strings"
@@ -25,10 +26,20 @@ type ApiKey struct {
 	UpdatedAt int64 `json:"updated_at,omitempty"`
 	// user id
 	UserID string `json:"user_id,omitempty"`
+	// space id, e.g. space id, organization id, store id
+	SpaceID string `json:"space_id,omitempty"`
+	// expired at
+	ExpiredAt int64 `json:"expired_at,omitempty"`
 	// Key holds the value of the "key" field.
 	Key string `json:"key,omitempty"`
+	// Clear text lookup prefix of the key
+	Prefix string `json:"prefix,omitempty"`
+	// Permissions granted to the key, empty for all of the user's
+	Scopes []string `json:"scopes,omitempty"`
 	// LastUsed holds the value of the "last_used" field.
-	LastUsed     int64 `json:"last_used,omitempty"`
+	LastUsed int64 `json:"last_used,omitempty"`
+	// Revocation time, revoked keys are kept for audit
+	RevokedAt    int64 `json:"revoked_at,omitempty"`
 	selectValues sql.SelectValues
 }
 
@@ -37,9 +48,11 @@ func (*ApiKey) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case apikey.FieldCreatedAt, apikey.FieldUpdatedAt, apikey.FieldLastUsed:
+		case apikey.FieldScopes:
+			values[i] = new([]byte)
+		case apikey.FieldCreatedAt, apikey.FieldUpdatedAt, apikey.FieldExpiredAt, apikey.FieldLastUsed, apikey.FieldRevokedAt:
 			values[i] = new(sql.NullInt64)
-		case apikey.FieldID, apikey.FieldName, apikey.FieldUserID, apikey.FieldKey:
+		case apikey.FieldID, apikey.FieldName, apikey.FieldUserID, apikey.FieldSpaceID, apikey.FieldKey, apikey.FieldPrefix:
 			values[i] = new(sql.NullString)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -86,18 +99,50 @@ func (_m *ApiKey) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.UserID = value.String
 			}
+		case apikey.FieldSpaceID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field space_id", values[i])
+			} else if value.Valid {
+				_m.SpaceID = value.String
+			}
+		case apikey.FieldExpiredAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field expired_at", values[i])
+			} else if value.Valid {
+				_m.ExpiredAt = value.Int64
+			}
 		case apikey.FieldKey:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field key", values[i])
 			} else if value.Valid {
 				_m.Key = value.String
 			}
+		case apikey.FieldPrefix:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field prefix", values[i])
+			} else if value.Valid {
+				_m.Prefix = value.String
+			}
+		case apikey.FieldScopes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field scopes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Scopes); err != nil {
+					return fmt.Errorf("unmarshal field scopes: %w", err)
+				}
+			}
 		case apikey.FieldLastUsed:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field last_used", values[i])
 			} else if value.Valid {
 				_m.LastUsed = value.Int64
 			}
+		case apikey.FieldRevokedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field revoked_at", values[i])
+			} else if value.Valid {
+				_m.RevokedAt = value.Int64
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -146,11 +191,26 @@ func (_m *ApiKey) String() string {
 	builder.WriteString("user_id=")
 	builder.WriteString(_m.UserID)
 	builder.WriteString(", ")
+	builder.WriteString("space_id=")
+	builder.WriteString(_m.SpaceID)
+	builder.WriteString(", ")
+	builder.WriteString("expired_at=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ExpiredAt))
+	builder.WriteString(", ")
 	builder.WriteString("key=")
 	builder.WriteString(_m.Key)
 	builder.WriteString(", ")
+	builder.WriteString("prefix=")
+	builder.WriteString(_m.Prefix)
+	builder.WriteString(", ")
+	builder.WriteString("scopes=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Scopes))
+	builder.WriteString(", ")
 	builder.WriteString("last_used=")
 	builder.WriteString(fmt.Sprintf("%v", _m.LastUsed))
+	builder.WriteString(", ")
+	builder.WriteString("revoked_at=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RevokedAt))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -78,12 +78,60 @@ func (_c *ApiKeyCreate) SetNillableUserID(v *string) *ApiKeyCreate {
 	return _c
 }
 
+// SetSpaceID sets the "space_id" field.
+func (_c *ApiKeyCreate) SetSpaceID(v string) *ApiKeyCreate {
+	_c.mutation.SetSpaceID(v)
+	return _c
+}
+
+// SetNillableSpaceID sets the "space_id" field if the given value is not nil.
+func (_c *ApiKeyCreate) SetNillableSpaceID(v *string) *ApiKeyCreate {
+	if v != nil {
+		_c.SetSpaceID(*v)
+	}
+	return _c
+}
+
+// SetExpiredAt sets the "expired_at" field.
+func (_c *ApiKeyCreate) SetExpiredAt(v int64) *ApiKeyCreate {
+	_c.mutation.SetExpiredAt(v)
+	return _c
+}
+
+// SetNillableExpiredAt sets the "expired_at" field if the given value is not nil.
+func (_c *ApiKeyCreate) SetNillableExpiredAt(v *int64) *ApiKeyCreate {
+	if v != nil {
+		_c.SetExpiredAt(*v)
+	}
+	return _c
+}
+
 // SetKey sets the "key" field.
 func (_c *ApiKeyCreate) SetKey(v string) *ApiKeyCreate {
 	_c.mutation.SetKey(v)
 	return _c
 }
 
+// SetPrefix sets the "prefix" field.
+func (_c *ApiKeyCreate) SetPrefix(v string) *ApiKeyCreate {
+	_c.mutation.SetPrefix(v)
+	return _c
+}
+
+// SetNillablePrefix sets the "prefix" field if the given value is not nil.
+func (_c *ApiKeyCreate) SetNillablePrefix(v *string) *ApiKeyCreate {
+	if v != nil {
+		_c.SetPrefix(*v)
+	}
+	return _c
+}
+
+// SetScopes sets the "scopes" field.
+func (_c *ApiKeyCreate) SetScopes(v []string) *ApiKeyCreate {
+	_c.mutation.SetScopes(v)
+	return _c
+}
+
 // SetLastUsed sets the "last_used" field.
 func (_c *ApiKeyCreate) SetLastUsed(v int64) *ApiKeyCreate {
 	_c.mutation.SetLastUsed(v)
@@ -98,6 +146,20 @@ func (_c *ApiKeyCreate) SetNillableLastUsed(v *int64) *ApiKeyCreate {
 	return _c
 }
 
+// SetRevokedAt sets the "revoked_at" field.
+func (_c *ApiKeyCreate) SetRevokedAt(v int64) *ApiKeyCreate {
+	_c.mutation.SetRevokedAt(v)
+	return _c
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_c *ApiKeyCreate) SetNillableRevokedAt(v *int64) *ApiKeyCreate {
+	if v != nil {
+		_c.SetRevokedAt(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *ApiKeyCreate) SetID(v string) *ApiKeyCreate {
 	_c.mutation.SetID(v)
@@ -228,14 +290,34 @@ func (_c *ApiKeyCreate) createSpec() (*ApiKey, *sqlgraph.CreateSpec) {
 		_spec.SetField(apikey.FieldUserID, field.TypeString, value)
 		_node.UserID = value
 	}
+	if value, ok := _c.mutation.SpaceID(); ok {
+		_spec.SetField(apikey.FieldSpaceID, field.TypeString, value)
+		_node.SpaceID = value
+	}
+	if value, ok := _c.mutation.ExpiredAt(); ok {
+		_spec.SetField(apikey.FieldExpiredAt, field.TypeInt64, value)
+		_node.ExpiredAt = value
+	}
 	if value, ok := _c.mutation.Key(); ok {
 		_spec.SetField(apikey.FieldKey, field.TypeString, value)
 		_node.Key = value
 	}
+	if value, ok := _c.mutation.Prefix(); ok {
+		_spec.SetField(apikey.FieldPrefix, field.TypeString, value)
+		_node.Prefix = value
+	}
+	if value, ok := _c.mutation.Scopes(); ok {
+		_spec.SetField(apikey.FieldScopes, field.TypeJSON, value)
+		_node.Scopes = value
+	}
 	if value, ok := _c.mutation.LastUsed(); ok {
 		_spec.SetField(apikey.FieldLastUsed, field.TypeInt64, value)
 		_node.LastUsed = value
 	}
+	if value, ok := _c.mutation.RevokedAt(); ok {
+		_spec.SetField(apikey.FieldRevokedAt, field.TypeInt64, value)
+		_node.RevokedAt = value
+	}
 	return _node, _spec
 }
 
@@ -348,6 +430,48 @@ func (u *ApiKeyUpsert) ClearUserID() *ApiKeyUpsert {
 	return u
 }
 
+// SetSpaceID sets the "space_id" field.
+func (u *ApiKeyUpsert) SetSpaceID(v string) *ApiKeyUpsert {
+	u.Set(apikey.FieldSpaceID, v)
+	return u
+}
+
+// UpdateSpaceID sets the "space_id" field to the value that was provided on create.
+func (u *ApiKeyUpsert) UpdateSpaceID() *ApiKeyUpsert {
+	u.SetExcluded(apikey.FieldSpaceID)
+	return u
+}
+
+// ClearSpaceID clears the value of the "space_id" field.
+func (u *ApiKeyUpsert) ClearSpaceID() *ApiKeyUpsert {
+	u.SetNull(apikey.FieldSpaceID)
+	return u
+}
+
+// SetExpiredAt sets the "expired_at" field.
+func (u *ApiKeyUpsert) SetExpiredAt(v int64) *ApiKeyUpsert {
+	u.Set(apikey.FieldExpiredAt, v)
+	return u
+}
+
+// UpdateExpiredAt sets the "expired_at" field to the value that was provided on create.
+func (u *ApiKeyUpsert) UpdateExpiredAt() *ApiKeyUpsert {
+	u.SetExcluded(apikey.FieldExpiredAt)
+	return u
+}
+
+// AddExpiredAt adds v to the "expired_at" field.
+func (u *ApiKeyUpsert) AddExpiredAt(v int64) *ApiKeyUpsert {
+	u.Add(apikey.FieldExpiredAt, v)
+	return u
+}
+
+// ClearExpiredAt clears the value of the "expired_at" field.
+func (u *ApiKeyUpsert) ClearExpiredAt() *ApiKeyUpsert {
+	u.SetNull(apikey.FieldExpiredAt)
+	return u
+}
+
 // SetKey sets the "key" field.
 func (u *ApiKeyUpsert) SetKey(v string) *ApiKeyUpsert {
 	u.Set(apikey.FieldKey, v)
@@ -360,6 +484,42 @@ func (u *ApiKeyUpsert) UpdateKey() *ApiKeyUpsert {
 	return u
 }
 
+// SetPrefix sets the "prefix" field.
+func (u *ApiKeyUpsert) SetPrefix(v string) *ApiKeyUpsert {
+	u.Set(apikey.FieldPrefix, v)
+	return u
+}
+
+// UpdatePrefix sets the "prefix" field to the value that was provided on create.
+func (u *ApiKeyUpsert) UpdatePrefix() *ApiKeyUpsert {
+	u.SetExcluded(apikey.FieldPrefix)
+	return u
+}
+
+// ClearPrefix clears the value of the "prefix" field.
+func (u *ApiKeyUpsert) ClearPrefix() *ApiKeyUpsert {
+	u.SetNull(apikey.FieldPrefix)
+	return u
+}
+
+// SetScopes sets the "scopes" field.
+func (u *ApiKeyUpsert) SetScopes(v []string) *ApiKeyUpsert {
+	u.Set(apikey.FieldScopes, v)
+	return u
+}
+
+// UpdateScopes sets the "scopes" field to the value that was provided on create.
+func (u *ApiKeyUpsert) UpdateScopes() *ApiKeyUpsert {
+	u.SetExcluded(apikey.FieldScopes)
+	return u
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (u *ApiKeyUpsert) ClearScopes() *ApiKeyUpsert {
+	u.SetNull(apikey.FieldScopes)
+	return u
+}
+
 // SetLastUsed sets the "last_used" field.
 func (u *ApiKeyUpsert) SetLastUsed(v int64) *ApiKeyUpsert {
 	u.Set(apikey.FieldLastUsed, v)
@@ -384,6 +544,30 @@ func (u *ApiKeyUpsert) ClearLastUsed() *ApiKeyUpsert {
 	return u
 }
 
+// SetRevokedAt sets the "revoked_at" field.
+func (u *ApiKeyUpsert) SetRevokedAt(v int64) *ApiKeyUpsert {
+	u.Set(apikey.FieldRevokedAt, v)
+	return u
+}
+
+// UpdateRevokedAt sets the "revoked_at" field to the value that was provided on create.
+func (u *ApiKeyUpsert) UpdateRevokedAt() *ApiKeyUpsert {
+	u.SetExcluded(apikey.FieldRevokedAt)
+	return u
+}
+
+// AddRevokedAt adds v to the "revoked_at" field.
+func (u *ApiKeyUpsert) AddRevokedAt(v int64) *ApiKeyUpsert {
+	u.Add(apikey.FieldRevokedAt, v)
+	return u
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (u *ApiKeyUpsert) ClearRevokedAt() *ApiKeyUpsert {
+	u.SetNull(apikey.FieldRevokedAt)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
 // Using this option is equivalent to using:
 //
@@ -505,6 +689,55 @@ func (u *ApiKeyUpsertOne) ClearUserID() *ApiKeyUpsertOne {
 	})
 }
 
+// SetSpaceID sets the "space_id" field.
+func (u *ApiKeyUpsertOne) SetSpaceID(v string) *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.SetSpaceID(v)
+	})
+}
+
+// UpdateSpaceID sets the "space_id" field to the value that was provided on create.
+func (u *ApiKeyUpsertOne) UpdateSpaceID() *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.UpdateSpaceID()
+	})
+}
+
+// ClearSpaceID clears the value of the "space_id" field.
+func (u *ApiKeyUpsertOne) ClearSpaceID() *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.ClearSpaceID()
+	})
+}
+
+// SetExpiredAt sets the "expired_at" field.
+func (u *ApiKeyUpsertOne) SetExpiredAt(v int64) *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.SetExpiredAt(v)
+	})
+}
+
+// AddExpiredAt adds v to the "expired_at" field.
+func (u *ApiKeyUpsertOne) AddExpiredAt(v int64) *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.AddExpiredAt(v)
+	})
+}
+
+// UpdateExpiredAt sets the "expired_at" field to the value that was provided on create.
+func (u *ApiKeyUpsertOne) UpdateExpiredAt() *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.UpdateExpiredAt()
+	})
+}
+
+// ClearExpiredAt clears the value of the "expired_at" field.
+func (u *ApiKeyUpsertOne) ClearExpiredAt() *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.ClearExpiredAt()
+	})
+}
+
 // SetKey sets the "key" field.
 func (u *ApiKeyUpsertOne) SetKey(v string) *ApiKeyUpsertOne {
 	return u.Update(func(s *ApiKeyUpsert) {
@@ -519,6 +752,48 @@ func (u *ApiKeyUpsertOne) UpdateKey() *ApiKeyUpsertOne {
 	})
 }
 
+// SetPrefix sets the "prefix" field.
+func (u *ApiKeyUpsertOne) SetPrefix(v string) *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.SetPrefix(v)
+	})
+}
+
+// UpdatePrefix sets the "prefix" field to the value that was provided on create.
+func (u *ApiKeyUpsertOne) UpdatePrefix() *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.UpdatePrefix()
+	})
+}
+
+// ClearPrefix clears the value of the "prefix" field.
+func (u *ApiKeyUpsertOne) ClearPrefix() *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.ClearPrefix()
+	})
+}
+
+// SetScopes sets the "scopes" field.
+func (u *ApiKeyUpsertOne) SetScopes(v []string) *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.SetScopes(v)
+	})
+}
+
+// UpdateScopes sets the "scopes" field to the value that was provided on create.
+func (u *ApiKeyUpsertOne) UpdateScopes() *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.UpdateScopes()
+	})
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (u *ApiKeyUpsertOne) ClearScopes() *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.ClearScopes()
+	})
+}
+
 // SetLastUsed sets the "last_used" field.
 func (u *ApiKeyUpsertOne) SetLastUsed(v int64) *ApiKeyUpsertOne {
 	return u.Update(func(s *ApiKeyUpsert) {
@@ -547,6 +822,34 @@ func (u *ApiKeyUpsertOne) ClearLastUsed() *ApiKeyUpsertOne {
 	})
 }
 
+// SetRevokedAt sets the "revoked_at" field.
+func (u *ApiKeyUpsertOne) SetRevokedAt(v int64) *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.SetRevokedAt(v)
+	})
+}
+
+// AddRevokedAt adds v to the "revoked_at" field.
+func (u *ApiKeyUpsertOne) AddRevokedAt(v int64) *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.AddRevokedAt(v)
+	})
+}
+
+// UpdateRevokedAt sets the "revoked_at" field to the value that was provided on create.
+func (u *ApiKeyUpsertOne) UpdateRevokedAt() *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.UpdateRevokedAt()
+	})
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (u *ApiKeyUpsertOne) ClearRevokedAt() *ApiKeyUpsertOne {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.ClearRevokedAt()
+	})
+}
+
 // Exec executes the query.
 func (u *ApiKeyUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -835,6 +1138,55 @@ func (u *ApiKeyUpsertBulk) ClearUserID() *ApiKeyUpsertBulk {
 	})
 }
 
+// SetSpaceID sets the "space_id" field.
+func (u *ApiKeyUpsertBulk) SetSpaceID(v string) *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.SetSpaceID(v)
+	})
+}
+
+// UpdateSpaceID sets the "space_id" field to the value that was provided on create.
+func (u *ApiKeyUpsertBulk) UpdateSpaceID() *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.UpdateSpaceID()
+	})
+}
+
+// ClearSpaceID clears the value of the "space_id" field.
+func (u *ApiKeyUpsertBulk) ClearSpaceID() *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.ClearSpaceID()
+	})
+}
+
+// SetExpiredAt sets the "expired_at" field.
+func (u *ApiKeyUpsertBulk) SetExpiredAt(v int64) *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.SetExpiredAt(v)
+	})
+}
+
+// AddExpiredAt adds v to the "expired_at" field.
+func (u *ApiKeyUpsertBulk) AddExpiredAt(v int64) *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.AddExpiredAt(v)
+	})
+}
+
+// UpdateExpiredAt sets the "expired_at" field to the value that was provided on create.
+func (u *ApiKeyUpsertBulk) UpdateExpiredAt() *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.UpdateExpiredAt()
+	})
+}
+
+// ClearExpiredAt clears the value of the "expired_at" field.
+func (u *ApiKeyUpsertBulk) ClearExpiredAt() *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.ClearExpiredAt()
+	})
+}
+
 // SetKey sets the "key" field.
 func (u *ApiKeyUpsertBulk) SetKey(v string) *ApiKeyUpsertBulk {
 	return u.Update(func(s *ApiKeyUpsert) {
@@ -849,6 +1201,48 @@ func (u *ApiKeyUpsertBulk) UpdateKey() *ApiKeyUpsertBulk {
 	})
 }
 
+// SetPrefix sets the "prefix" field.
+func (u *ApiKeyUpsertBulk) SetPrefix(v string) *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.SetPrefix(v)
+	})
+}
+
+// UpdatePrefix sets the "prefix" field to the value that was provided on create.
+func (u *ApiKeyUpsertBulk) UpdatePrefix() *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.UpdatePrefix()
+	})
+}
+
+// ClearPrefix clears the value of the "prefix" field.
+func (u *ApiKeyUpsertBulk) ClearPrefix() *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.ClearPrefix()
+	})
+}
+
+// SetScopes sets the "scopes" field.
+func (u *ApiKeyUpsertBulk) SetScopes(v []string) *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.SetScopes(v)
+	})
+}
+
+// UpdateScopes sets the "scopes" field to the value that was provided on create.
+func (u *ApiKeyUpsertBulk) UpdateScopes() *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.UpdateScopes()
+	})
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (u *ApiKeyUpsertBulk) ClearScopes() *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.ClearScopes()
+	})
+}
+
 // SetLastUsed sets the "last_used" field.
 func (u *ApiKeyUpsertBulk) SetLastUsed(v int64) *ApiKeyUpsertBulk {
 	return u.Update(func(s *ApiKeyUpsert) {
@@ -877,6 +1271,34 @@ func (u *ApiKeyUpsertBulk) ClearLastUsed() *ApiKeyUpsertBulk {
 	})
 }
 
+// SetRevokedAt sets the "revoked_at" field.
+func (u *ApiKeyUpsertBulk) SetRevokedAt(v int64) *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.SetRevokedAt(v)
+	})
+}
+
+// AddRevokedAt adds v to the "revoked_at" field.
+func (u *ApiKeyUpsertBulk) AddRevokedAt(v int64) *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.AddRevokedAt(v)
+	})
+}
+
+// UpdateRevokedAt sets the "revoked_at" field to the value that was provided on create.
+func (u *ApiKeyUpsertBulk) UpdateRevokedAt() *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.UpdateRevokedAt()
+	})
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (u *ApiKeyUpsertBulk) ClearRevokedAt() *ApiKeyUpsertBulk {
+	return u.Update(func(s *ApiKeyUpsert) {
+		s.ClearRevokedAt()
+	})
+}
+
 // Exec executes the query.
 func (u *ApiKeyUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
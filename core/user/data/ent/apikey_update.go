@@ -11,6 +11,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 )
 
@@ -86,6 +87,53 @@ func (_u *ApiKeyUpdate) ClearUserID() *ApiKeyUpdate {
 	return _u
 }
 
+// SetSpaceID sets the "space_id" field.
+func (_u *ApiKeyUpdate) SetSpaceID(v string) *ApiKeyUpdate {
+	_u.mutation.SetSpaceID(v)
+	return _u
+}
+
+// SetNillableSpaceID sets the "space_id" field if the given value is not nil.
+func (_u *ApiKeyUpdate) SetNillableSpaceID(v *string) *ApiKeyUpdate {
+	if v != nil {
+		_u.SetSpaceID(*v)
+	}
+	return _u
+}
+
+// ClearSpaceID clears the value of the "space_id" field.
+func (_u *ApiKeyUpdate) ClearSpaceID() *ApiKeyUpdate {
+	_u.mutation.ClearSpaceID()
+	return _u
+}
+
+// SetExpiredAt sets the "expired_at" field.
+func (_u *ApiKeyUpdate) SetExpiredAt(v int64) *ApiKeyUpdate {
+	_u.mutation.ResetExpiredAt()
+	_u.mutation.SetExpiredAt(v)
+	return _u
+}
+
+// SetNillableExpiredAt sets the "expired_at" field if the given value is not nil.
+func (_u *ApiKeyUpdate) SetNillableExpiredAt(v *int64) *ApiKeyUpdate {
+	if v != nil {
+		_u.SetExpiredAt(*v)
+	}
+	return _u
+}
+
+// AddExpiredAt adds value to the "expired_at" field.
+func (_u *ApiKeyUpdate) AddExpiredAt(v int64) *ApiKeyUpdate {
+	_u.mutation.AddExpiredAt(v)
+	return _u
+}
+
+// ClearExpiredAt clears the value of the "expired_at" field.
+func (_u *ApiKeyUpdate) ClearExpiredAt() *ApiKeyUpdate {
+	_u.mutation.ClearExpiredAt()
+	return _u
+}
+
 // SetKey sets the "key" field.
 func (_u *ApiKeyUpdate) SetKey(v string) *ApiKeyUpdate {
 	_u.mutation.SetKey(v)
@@ -100,6 +148,44 @@ func (_u *ApiKeyUpdate) SetNillableKey(v *string) *ApiKeyUpdate {
 	return _u
 }
 
+// SetPrefix sets the "prefix" field.
+func (_u *ApiKeyUpdate) SetPrefix(v string) *ApiKeyUpdate {
+	_u.mutation.SetPrefix(v)
+	return _u
+}
+
+// SetNillablePrefix sets the "prefix" field if the given value is not nil.
+func (_u *ApiKeyUpdate) SetNillablePrefix(v *string) *ApiKeyUpdate {
+	if v != nil {
+		_u.SetPrefix(*v)
+	}
+	return _u
+}
+
+// ClearPrefix clears the value of the "prefix" field.
+func (_u *ApiKeyUpdate) ClearPrefix() *ApiKeyUpdate {
+	_u.mutation.ClearPrefix()
+	return _u
+}
+
+// SetScopes sets the "scopes" field.
+func (_u *ApiKeyUpdate) SetScopes(v []string) *ApiKeyUpdate {
+	_u.mutation.SetScopes(v)
+	return _u
+}
+
+// AppendScopes appends value to the "scopes" field.
+func (_u *ApiKeyUpdate) AppendScopes(v []string) *ApiKeyUpdate {
+	_u.mutation.AppendScopes(v)
+	return _u
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (_u *ApiKeyUpdate) ClearScopes() *ApiKeyUpdate {
+	_u.mutation.ClearScopes()
+	return _u
+}
+
 // SetLastUsed sets the "last_used" field.
 func (_u *ApiKeyUpdate) SetLastUsed(v int64) *ApiKeyUpdate {
 	_u.mutation.ResetLastUsed()
@@ -127,6 +213,33 @@ func (_u *ApiKeyUpdate) ClearLastUsed() *ApiKeyUpdate {
 	return _u
 }
 
+// SetRevokedAt sets the "revoked_at" field.
+func (_u *ApiKeyUpdate) SetRevokedAt(v int64) *ApiKeyUpdate {
+	_u.mutation.ResetRevokedAt()
+	_u.mutation.SetRevokedAt(v)
+	return _u
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_u *ApiKeyUpdate) SetNillableRevokedAt(v *int64) *ApiKeyUpdate {
+	if v != nil {
+		_u.SetRevokedAt(*v)
+	}
+	return _u
+}
+
+// AddRevokedAt adds value to the "revoked_at" field.
+func (_u *ApiKeyUpdate) AddRevokedAt(v int64) *ApiKeyUpdate {
+	_u.mutation.AddRevokedAt(v)
+	return _u
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (_u *ApiKeyUpdate) ClearRevokedAt() *ApiKeyUpdate {
+	_u.mutation.ClearRevokedAt()
+	return _u
+}
+
 // Mutation returns the ApiKeyMutation object of the builder.
 func (_u *ApiKeyUpdate) Mutation() *ApiKeyMutation {
 	return _u.mutation
@@ -214,9 +327,41 @@ func (_u *ApiKeyUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.UserIDCleared() {
 		_spec.ClearField(apikey.FieldUserID, field.TypeString)
 	}
+	if value, ok := _u.mutation.SpaceID(); ok {
+		_spec.SetField(apikey.FieldSpaceID, field.TypeString, value)
+	}
+	if _u.mutation.SpaceIDCleared() {
+		_spec.ClearField(apikey.FieldSpaceID, field.TypeString)
+	}
+	if value, ok := _u.mutation.ExpiredAt(); ok {
+		_spec.SetField(apikey.FieldExpiredAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedExpiredAt(); ok {
+		_spec.AddField(apikey.FieldExpiredAt, field.TypeInt64, value)
+	}
+	if _u.mutation.ExpiredAtCleared() {
+		_spec.ClearField(apikey.FieldExpiredAt, field.TypeInt64)
+	}
 	if value, ok := _u.mutation.Key(); ok {
 		_spec.SetField(apikey.FieldKey, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Prefix(); ok {
+		_spec.SetField(apikey.FieldPrefix, field.TypeString, value)
+	}
+	if _u.mutation.PrefixCleared() {
+		_spec.ClearField(apikey.FieldPrefix, field.TypeString)
+	}
+	if value, ok := _u.mutation.Scopes(); ok {
+		_spec.SetField(apikey.FieldScopes, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedScopes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apikey.FieldScopes, value)
+		})
+	}
+	if _u.mutation.ScopesCleared() {
+		_spec.ClearField(apikey.FieldScopes, field.TypeJSON)
+	}
 	if value, ok := _u.mutation.LastUsed(); ok {
 		_spec.SetField(apikey.FieldLastUsed, field.TypeInt64, value)
 	}
@@ -226,6 +371,15 @@ func (_u *ApiKeyUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.LastUsedCleared() {
 		_spec.ClearField(apikey.FieldLastUsed, field.TypeInt64)
 	}
+	if value, ok := _u.mutation.RevokedAt(); ok {
+		_spec.SetField(apikey.FieldRevokedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedRevokedAt(); ok {
+		_spec.AddField(apikey.FieldRevokedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.RevokedAtCleared() {
+		_spec.ClearField(apikey.FieldRevokedAt, field.TypeInt64)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{apikey.Label}
@@ -305,6 +459,53 @@ func (_u *ApiKeyUpdateOne) ClearUserID() *ApiKeyUpdateOne {
 	return _u
 }
 
+// SetSpaceID sets the "space_id" field.
+func (_u *ApiKeyUpdateOne) SetSpaceID(v string) *ApiKeyUpdateOne {
+	_u.mutation.SetSpaceID(v)
+	return _u
+}
+
+// SetNillableSpaceID sets the "space_id" field if the given value is not nil.
+func (_u *ApiKeyUpdateOne) SetNillableSpaceID(v *string) *ApiKeyUpdateOne {
+	if v != nil {
+		_u.SetSpaceID(*v)
+	}
+	return _u
+}
+
+// ClearSpaceID clears the value of the "space_id" field.
+func (_u *ApiKeyUpdateOne) ClearSpaceID() *ApiKeyUpdateOne {
+	_u.mutation.ClearSpaceID()
+	return _u
+}
+
+// SetExpiredAt sets the "expired_at" field.
+func (_u *ApiKeyUpdateOne) SetExpiredAt(v int64) *ApiKeyUpdateOne {
+	_u.mutation.ResetExpiredAt()
+	_u.mutation.SetExpiredAt(v)
+	return _u
+}
+
+// SetNillableExpiredAt sets the "expired_at" field if the given value is not nil.
+func (_u *ApiKeyUpdateOne) SetNillableExpiredAt(v *int64) *ApiKeyUpdateOne {
+	if v != nil {
+		_u.SetExpiredAt(*v)
+	}
+	return _u
+}
+
+// AddExpiredAt adds value to the "expired_at" field.
+func (_u *ApiKeyUpdateOne) AddExpiredAt(v int64) *ApiKeyUpdateOne {
+	_u.mutation.AddExpiredAt(v)
+	return _u
+}
+
+// ClearExpiredAt clears the value of the "expired_at" field.
+func (_u *ApiKeyUpdateOne) ClearExpiredAt() *ApiKeyUpdateOne {
+	_u.mutation.ClearExpiredAt()
+	return _u
+}
+
 // SetKey sets the "key" field.
 func (_u *ApiKeyUpdateOne) SetKey(v string) *ApiKeyUpdateOne {
 	_u.mutation.SetKey(v)
@@ -319,6 +520,44 @@ func (_u *ApiKeyUpdateOne) SetNillableKey(v *string) *ApiKeyUpdateOne {
 	return _u
 }
 
+// SetPrefix sets the "prefix" field.
+func (_u *ApiKeyUpdateOne) SetPrefix(v string) *ApiKeyUpdateOne {
+	_u.mutation.SetPrefix(v)
+	return _u
+}
+
+// SetNillablePrefix sets the "prefix" field if the given value is not nil.
+func (_u *ApiKeyUpdateOne) SetNillablePrefix(v *string) *ApiKeyUpdateOne {
+	if v != nil {
+		_u.SetPrefix(*v)
+	}
+	return _u
+}
+
+// ClearPrefix clears the value of the "prefix" field.
+func (_u *ApiKeyUpdateOne) ClearPrefix() *ApiKeyUpdateOne {
+	_u.mutation.ClearPrefix()
+	return _u
+}
+
+// SetScopes sets the "scopes" field.
+func (_u *ApiKeyUpdateOne) SetScopes(v []string) *ApiKeyUpdateOne {
+	_u.mutation.SetScopes(v)
+	return _u
+}
+
+// AppendScopes appends value to the "scopes" field.
+func (_u *ApiKeyUpdateOne) AppendScopes(v []string) *ApiKeyUpdateOne {
+	_u.mutation.AppendScopes(v)
+	return _u
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (_u *ApiKeyUpdateOne) ClearScopes() *ApiKeyUpdateOne {
+	_u.mutation.ClearScopes()
+	return _u
+}
+
 // SetLastUsed sets the "last_used" field.
 func (_u *ApiKeyUpdateOne) SetLastUsed(v int64) *ApiKeyUpdateOne {
 	_u.mutation.ResetLastUsed()
@@ -346,6 +585,33 @@ func (_u *ApiKeyUpdateOne) ClearLastUsed() *ApiKeyUpdateOne {
 	return _u
 }
 
+// SetRevokedAt sets the "revoked_at" field.
+func (_u *ApiKeyUpdateOne) SetRevokedAt(v int64) *ApiKeyUpdateOne {
+	_u.mutation.ResetRevokedAt()
+	_u.mutation.SetRevokedAt(v)
+	return _u
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_u *ApiKeyUpdateOne) SetNillableRevokedAt(v *int64) *ApiKeyUpdateOne {
+	if v != nil {
+		_u.SetRevokedAt(*v)
+	}
+	return _u
+}
+
+// AddRevokedAt adds value to the "revoked_at" field.
+func (_u *ApiKeyUpdateOne) AddRevokedAt(v int64) *ApiKeyUpdateOne {
+	_u.mutation.AddRevokedAt(v)
+	return _u
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (_u *ApiKeyUpdateOne) ClearRevokedAt() *ApiKeyUpdateOne {
+	_u.mutation.ClearRevokedAt()
+	return _u
+}
+
 // Mutation returns the ApiKeyMutation object of the builder.
 func (_u *ApiKeyUpdateOne) Mutation() *ApiKeyMutation {
 	return _u.mutation
@@ -463,9 +729,41 @@ func (_u *ApiKeyUpdateOne) sqlSave(ctx context.Context) (_node *ApiKey, err erro
 	if _u.mutation.UserIDCleared() {
 		_spec.ClearField(apikey.FieldUserID, field.TypeString)
 	}
+	if value, ok := _u.mutation.SpaceID(); ok {
+		_spec.SetField(apikey.FieldSpaceID, field.TypeString, value)
+	}
+	if _u.mutation.SpaceIDCleared() {
+		_spec.ClearField(apikey.FieldSpaceID, field.TypeString)
+	}
+	if value, ok := _u.mutation.ExpiredAt(); ok {
+		_spec.SetField(apikey.FieldExpiredAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedExpiredAt(); ok {
+		_spec.AddField(apikey.FieldExpiredAt, field.TypeInt64, value)
+	}
+	if _u.mutation.ExpiredAtCleared() {
+		_spec.ClearField(apikey.FieldExpiredAt, field.TypeInt64)
+	}
 	if value, ok := _u.mutation.Key(); ok {
 		_spec.SetField(apikey.FieldKey, field.TypeString, value)
 	}
+	if value, ok := _u.mutation.Prefix(); ok {
+		_spec.SetField(apikey.FieldPrefix, field.TypeString, value)
+	}
+	if _u.mutation.PrefixCleared() {
+		_spec.ClearField(apikey.FieldPrefix, field.TypeString)
+	}
+	if value, ok := _u.mutation.Scopes(); ok {
+		_spec.SetField(apikey.FieldScopes, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedScopes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, apikey.FieldScopes, value)
+		})
+	}
+	if _u.mutation.ScopesCleared() {
+		_spec.ClearField(apikey.FieldScopes, field.TypeJSON)
+	}
 	if value, ok := _u.mutation.LastUsed(); ok {
 		_spec.SetField(apikey.FieldLastUsed, field.TypeInt64, value)
 	}
@@ -475,6 +773,15 @@ func (_u *ApiKeyUpdateOne) sqlSave(ctx context.Context) (_node *ApiKey, err erro
 	if _u.mutation.LastUsedCleared() {
 		_spec.ClearField(apikey.FieldLastUsed, field.TypeInt64)
 	}
+	if value, ok := _u.mutation.RevokedAt(); ok {
+		_spec.SetField(apikey.FieldRevokedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedRevokedAt(); ok {
+		_spec.AddField(apikey.FieldRevokedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.RevokedAtCleared() {
+		_spec.ClearField(apikey.FieldRevokedAt, field.TypeInt64)
+	}
 	_node = &ApiKey{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -16,8 +16,13 @@ var (
 		{Name: "created_at", Type: field.TypeInt64, Nullable: true, Comment: "created at"},
 		{Name: "updated_at", Type: field.TypeInt64, Nullable: true, Comment: "updated at"},
 		{Name: "user_id", Type: field.TypeString, Nullable: true, Comment: "user id"},
+		{Name: "space_id", Type: field.TypeString, Nullable: true, Comment: "space id, e.g. space id, organization id, store id"},
+		{Name: "expired_at", Type: field.TypeInt64, Nullable: true, Comment: "expired at"},
 		{Name: "key", Type: field.TypeString, Unique: true},
+		{Name: "prefix", Type: field.TypeString, Nullable: true, Comment: "Clear text lookup prefix of the key"},
+		{Name: "scopes", Type: field.TypeJSON, Nullable: true, Comment: "Permissions granted to the key, empty for all of the user's"},
 		{Name: "last_used", Type: field.TypeInt64, Nullable: true},
+		{Name: "revoked_at", Type: field.TypeInt64, Nullable: true, Comment: "Revocation time, revoked keys are kept for audit"},
 	}
 	// NcseUserAPIKeyTable holds the schema information for the "ncse_user_api_key" table.
 	NcseUserAPIKeyTable = &schema.Table{
@@ -35,6 +40,11 @@ var (
 				Unique:  false,
 				Columns: []*schema.Column{NcseUserAPIKeyColumns[4]},
 			},
+			{
+				Name:    "apikey_space_id",
+				Unique:  false,
+				Columns: []*schema.Column{NcseUserAPIKeyColumns[5]},
+			},
 			{
 				Name:    "apikey_id_created_at",
 				Unique:  true,
@@ -48,7 +58,12 @@ var (
 			{
 				Name:    "apikey_key",
 				Unique:  true,
-				Columns: []*schema.Column{NcseUserAPIKeyColumns[5]},
+				Columns: []*schema.Column{NcseUserAPIKeyColumns[7]},
+			},
+			{
+				Name:    "apikey_prefix",
+				Unique:  false,
+				Columns: []*schema.Column{NcseUserAPIKeyColumns[8]},
 			},
 		},
 	}
@@ -45,9 +45,17 @@ type ApiKeyMutation struct {
 	updated_at    *int64
 	addupdated_at *int64
 	user_id       *string
+	space_id      *string
+	expired_at    *int64
+	addexpired_at *int64
 	key           *string
+	prefix        *string
+	scopes        *[]string
+	appendscopes  []string
 	last_used     *int64
 	addlast_used  *int64
+	revoked_at    *int64
+	addrevoked_at *int64
 	clearedFields map[string]struct{}
 	done          bool
 	oldValue      func(context.Context) (*ApiKey, error)
@@ -396,6 +404,125 @@ func (m *ApiKeyMutation) ResetUserID() {
 	delete(m.clearedFields, apikey.FieldUserID)
 }
 
+// SetSpaceID sets the "space_id" field.
+func (m *ApiKeyMutation) SetSpaceID(s string) {
+	m.space_id = &s
+}
+
+// SpaceID returns the value of the "space_id" field in the mutation.
+func (m *ApiKeyMutation) SpaceID() (r string, exists bool) {
+	v := m.space_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSpaceID returns the old "space_id" field's value of the ApiKey entity.
+// If the ApiKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ApiKeyMutation) OldSpaceID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSpaceID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSpaceID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSpaceID: %w", err)
+	}
+	return oldValue.SpaceID, nil
+}
+
+// ClearSpaceID clears the value of the "space_id" field.
+func (m *ApiKeyMutation) ClearSpaceID() {
+	m.space_id = nil
+	m.clearedFields[apikey.FieldSpaceID] = struct{}{}
+}
+
+// SpaceIDCleared returns if the "space_id" field was cleared in this mutation.
+func (m *ApiKeyMutation) SpaceIDCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldSpaceID]
+	return ok
+}
+
+// ResetSpaceID resets all changes to the "space_id" field.
+func (m *ApiKeyMutation) ResetSpaceID() {
+	m.space_id = nil
+	delete(m.clearedFields, apikey.FieldSpaceID)
+}
+
+// SetExpiredAt sets the "expired_at" field.
+func (m *ApiKeyMutation) SetExpiredAt(i int64) {
+	m.expired_at = &i
+	m.addexpired_at = nil
+}
+
+// ExpiredAt returns the value of the "expired_at" field in the mutation.
+func (m *ApiKeyMutation) ExpiredAt() (r int64, exists bool) {
+	v := m.expired_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiredAt returns the old "expired_at" field's value of the ApiKey entity.
+// If the ApiKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ApiKeyMutation) OldExpiredAt(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiredAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiredAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiredAt: %w", err)
+	}
+	return oldValue.ExpiredAt, nil
+}
+
+// AddExpiredAt adds i to the "expired_at" field.
+func (m *ApiKeyMutation) AddExpiredAt(i int64) {
+	if m.addexpired_at != nil {
+		*m.addexpired_at += i
+	} else {
+		m.addexpired_at = &i
+	}
+}
+
+// AddedExpiredAt returns the value that was added to the "expired_at" field in this mutation.
+func (m *ApiKeyMutation) AddedExpiredAt() (r int64, exists bool) {
+	v := m.addexpired_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearExpiredAt clears the value of the "expired_at" field.
+func (m *ApiKeyMutation) ClearExpiredAt() {
+	m.expired_at = nil
+	m.addexpired_at = nil
+	m.clearedFields[apikey.FieldExpiredAt] = struct{}{}
+}
+
+// ExpiredAtCleared returns if the "expired_at" field was cleared in this mutation.
+func (m *ApiKeyMutation) ExpiredAtCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldExpiredAt]
+	return ok
+}
+
+// ResetExpiredAt resets all changes to the "expired_at" field.
+func (m *ApiKeyMutation) ResetExpiredAt() {
+	m.expired_at = nil
+	m.addexpired_at = nil
+	delete(m.clearedFields, apikey.FieldExpiredAt)
+}
+
 // SetKey sets the "key" field.
 func (m *ApiKeyMutation) SetKey(s string) {
 	m.key = &s
@@ -432,6 +559,120 @@ func (m *ApiKeyMutation) ResetKey() {
 	m.key = nil
 }
 
+// SetPrefix sets the "prefix" field.
+func (m *ApiKeyMutation) SetPrefix(s string) {
+	m.prefix = &s
+}
+
+// Prefix returns the value of the "prefix" field in the mutation.
+func (m *ApiKeyMutation) Prefix() (r string, exists bool) {
+	v := m.prefix
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPrefix returns the old "prefix" field's value of the ApiKey entity.
+// If the ApiKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ApiKeyMutation) OldPrefix(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPrefix is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPrefix requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPrefix: %w", err)
+	}
+	return oldValue.Prefix, nil
+}
+
+// ClearPrefix clears the value of the "prefix" field.
+func (m *ApiKeyMutation) ClearPrefix() {
+	m.prefix = nil
+	m.clearedFields[apikey.FieldPrefix] = struct{}{}
+}
+
+// PrefixCleared returns if the "prefix" field was cleared in this mutation.
+func (m *ApiKeyMutation) PrefixCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldPrefix]
+	return ok
+}
+
+// ResetPrefix resets all changes to the "prefix" field.
+func (m *ApiKeyMutation) ResetPrefix() {
+	m.prefix = nil
+	delete(m.clearedFields, apikey.FieldPrefix)
+}
+
+// SetScopes sets the "scopes" field.
+func (m *ApiKeyMutation) SetScopes(s []string) {
+	m.scopes = &s
+	m.appendscopes = nil
+}
+
+// Scopes returns the value of the "scopes" field in the mutation.
+func (m *ApiKeyMutation) Scopes() (r []string, exists bool) {
+	v := m.scopes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldScopes returns the old "scopes" field's value of the ApiKey entity.
+// If the ApiKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ApiKeyMutation) OldScopes(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldScopes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldScopes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldScopes: %w", err)
+	}
+	return oldValue.Scopes, nil
+}
+
+// AppendScopes adds s to the "scopes" field.
+func (m *ApiKeyMutation) AppendScopes(s []string) {
+	m.appendscopes = append(m.appendscopes, s...)
+}
+
+// AppendedScopes returns the list of values that were appended to the "scopes" field in this mutation.
+func (m *ApiKeyMutation) AppendedScopes() ([]string, bool) {
+	if len(m.appendscopes) == 0 {
+		return nil, false
+	}
+	return m.appendscopes, true
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (m *ApiKeyMutation) ClearScopes() {
+	m.scopes = nil
+	m.appendscopes = nil
+	m.clearedFields[apikey.FieldScopes] = struct{}{}
+}
+
+// ScopesCleared returns if the "scopes" field was cleared in this mutation.
+func (m *ApiKeyMutation) ScopesCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldScopes]
+	return ok
+}
+
+// ResetScopes resets all changes to the "scopes" field.
+func (m *ApiKeyMutation) ResetScopes() {
+	m.scopes = nil
+	m.appendscopes = nil
+	delete(m.clearedFields, apikey.FieldScopes)
+}
+
 // SetLastUsed sets the "last_used" field.
 func (m *ApiKeyMutation) SetLastUsed(i int64) {
 	m.last_used = &i
@@ -502,6 +743,76 @@ func (m *ApiKeyMutation) ResetLastUsed() {
 	delete(m.clearedFields, apikey.FieldLastUsed)
 }
 
+// SetRevokedAt sets the "revoked_at" field.
+func (m *ApiKeyMutation) SetRevokedAt(i int64) {
+	m.revoked_at = &i
+	m.addrevoked_at = nil
+}
+
+// RevokedAt returns the value of the "revoked_at" field in the mutation.
+func (m *ApiKeyMutation) RevokedAt() (r int64, exists bool) {
+	v := m.revoked_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRevokedAt returns the old "revoked_at" field's value of the ApiKey entity.
+// If the ApiKey object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ApiKeyMutation) OldRevokedAt(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRevokedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRevokedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRevokedAt: %w", err)
+	}
+	return oldValue.RevokedAt, nil
+}
+
+// AddRevokedAt adds i to the "revoked_at" field.
+func (m *ApiKeyMutation) AddRevokedAt(i int64) {
+	if m.addrevoked_at != nil {
+		*m.addrevoked_at += i
+	} else {
+		m.addrevoked_at = &i
+	}
+}
+
+// AddedRevokedAt returns the value that was added to the "revoked_at" field in this mutation.
+func (m *ApiKeyMutation) AddedRevokedAt() (r int64, exists bool) {
+	v := m.addrevoked_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (m *ApiKeyMutation) ClearRevokedAt() {
+	m.revoked_at = nil
+	m.addrevoked_at = nil
+	m.clearedFields[apikey.FieldRevokedAt] = struct{}{}
+}
+
+// RevokedAtCleared returns if the "revoked_at" field was cleared in this mutation.
+func (m *ApiKeyMutation) RevokedAtCleared() bool {
+	_, ok := m.clearedFields[apikey.FieldRevokedAt]
+	return ok
+}
+
+// ResetRevokedAt resets all changes to the "revoked_at" field.
+func (m *ApiKeyMutation) ResetRevokedAt() {
+	m.revoked_at = nil
+	m.addrevoked_at = nil
+	delete(m.clearedFields, apikey.FieldRevokedAt)
+}
+
 // Where appends a list predicates to the ApiKeyMutation builder.
 func (m *ApiKeyMutation) Where(ps ...predicate.ApiKey) {
 	m.predicates = append(m.predicates, ps...)
@@ -536,7 +847,7 @@ func (m *ApiKeyMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *ApiKeyMutation) Fields() []string {
-	fields := make([]string, 0, 6)
+	fields := make([]string, 0, 11)
 	if m.name != nil {
 		fields = append(fields, apikey.FieldName)
 	}
@@ -549,12 +860,27 @@ func (m *ApiKeyMutation) Fields() []string {
 	if m.user_id != nil {
 		fields = append(fields, apikey.FieldUserID)
 	}
+	if m.space_id != nil {
+		fields = append(fields, apikey.FieldSpaceID)
+	}
+	if m.expired_at != nil {
+		fields = append(fields, apikey.FieldExpiredAt)
+	}
 	if m.key != nil {
 		fields = append(fields, apikey.FieldKey)
 	}
+	if m.prefix != nil {
+		fields = append(fields, apikey.FieldPrefix)
+	}
+	if m.scopes != nil {
+		fields = append(fields, apikey.FieldScopes)
+	}
 	if m.last_used != nil {
 		fields = append(fields, apikey.FieldLastUsed)
 	}
+	if m.revoked_at != nil {
+		fields = append(fields, apikey.FieldRevokedAt)
+	}
 	return fields
 }
 
@@ -571,10 +897,20 @@ func (m *ApiKeyMutation) Field(name string) (ent.Value, bool) {
 		return m.UpdatedAt()
 	case apikey.FieldUserID:
 		return m.UserID()
+	case apikey.FieldSpaceID:
+		return m.SpaceID()
+	case apikey.FieldExpiredAt:
+		return m.ExpiredAt()
 	case apikey.FieldKey:
 		return m.Key()
+	case apikey.FieldPrefix:
+		return m.Prefix()
+	case apikey.FieldScopes:
+		return m.Scopes()
 	case apikey.FieldLastUsed:
 		return m.LastUsed()
+	case apikey.FieldRevokedAt:
+		return m.RevokedAt()
 	}
 	return nil, false
 }
@@ -592,10 +928,20 @@ func (m *ApiKeyMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldUpdatedAt(ctx)
 	case apikey.FieldUserID:
 		return m.OldUserID(ctx)
+	case apikey.FieldSpaceID:
+		return m.OldSpaceID(ctx)
+	case apikey.FieldExpiredAt:
+		return m.OldExpiredAt(ctx)
 	case apikey.FieldKey:
 		return m.OldKey(ctx)
+	case apikey.FieldPrefix:
+		return m.OldPrefix(ctx)
+	case apikey.FieldScopes:
+		return m.OldScopes(ctx)
 	case apikey.FieldLastUsed:
 		return m.OldLastUsed(ctx)
+	case apikey.FieldRevokedAt:
+		return m.OldRevokedAt(ctx)
 	}
 	return nil, fmt.Errorf("unknown ApiKey field %s", name)
 }
@@ -633,6 +979,20 @@ func (m *ApiKeyMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetUserID(v)
 		return nil
+	case apikey.FieldSpaceID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSpaceID(v)
+		return nil
+	case apikey.FieldExpiredAt:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiredAt(v)
+		return nil
 	case apikey.FieldKey:
 		v, ok := value.(string)
 		if !ok {
@@ -640,6 +1000,20 @@ func (m *ApiKeyMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetKey(v)
 		return nil
+	case apikey.FieldPrefix:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPrefix(v)
+		return nil
+	case apikey.FieldScopes:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetScopes(v)
+		return nil
 	case apikey.FieldLastUsed:
 		v, ok := value.(int64)
 		if !ok {
@@ -647,6 +1021,13 @@ func (m *ApiKeyMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetLastUsed(v)
 		return nil
+	case apikey.FieldRevokedAt:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRevokedAt(v)
+		return nil
 	}
 	return fmt.Errorf("unknown ApiKey field %s", name)
 }
@@ -661,9 +1042,15 @@ func (m *ApiKeyMutation) AddedFields() []string {
 	if m.addupdated_at != nil {
 		fields = append(fields, apikey.FieldUpdatedAt)
 	}
+	if m.addexpired_at != nil {
+		fields = append(fields, apikey.FieldExpiredAt)
+	}
 	if m.addlast_used != nil {
 		fields = append(fields, apikey.FieldLastUsed)
 	}
+	if m.addrevoked_at != nil {
+		fields = append(fields, apikey.FieldRevokedAt)
+	}
 	return fields
 }
 
@@ -676,8 +1063,12 @@ func (m *ApiKeyMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedCreatedAt()
 	case apikey.FieldUpdatedAt:
 		return m.AddedUpdatedAt()
+	case apikey.FieldExpiredAt:
+		return m.AddedExpiredAt()
 	case apikey.FieldLastUsed:
 		return m.AddedLastUsed()
+	case apikey.FieldRevokedAt:
+		return m.AddedRevokedAt()
 	}
 	return nil, false
 }
@@ -701,6 +1092,13 @@ func (m *ApiKeyMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddUpdatedAt(v)
 		return nil
+	case apikey.FieldExpiredAt:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddExpiredAt(v)
+		return nil
 	case apikey.FieldLastUsed:
 		v, ok := value.(int64)
 		if !ok {
@@ -708,6 +1106,13 @@ func (m *ApiKeyMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddLastUsed(v)
 		return nil
+	case apikey.FieldRevokedAt:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRevokedAt(v)
+		return nil
 	}
 	return fmt.Errorf("unknown ApiKey numeric field %s", name)
 }
@@ -728,9 +1133,24 @@ func (m *ApiKeyMutation) ClearedFields() []string {
 	if m.FieldCleared(apikey.FieldUserID) {
 		fields = append(fields, apikey.FieldUserID)
 	}
+	if m.FieldCleared(apikey.FieldSpaceID) {
+		fields = append(fields, apikey.FieldSpaceID)
+	}
+	if m.FieldCleared(apikey.FieldExpiredAt) {
+		fields = append(fields, apikey.FieldExpiredAt)
+	}
+	if m.FieldCleared(apikey.FieldPrefix) {
+		fields = append(fields, apikey.FieldPrefix)
+	}
+	if m.FieldCleared(apikey.FieldScopes) {
+		fields = append(fields, apikey.FieldScopes)
+	}
 	if m.FieldCleared(apikey.FieldLastUsed) {
 		fields = append(fields, apikey.FieldLastUsed)
 	}
+	if m.FieldCleared(apikey.FieldRevokedAt) {
+		fields = append(fields, apikey.FieldRevokedAt)
+	}
 	return fields
 }
 
@@ -757,9 +1177,24 @@ func (m *ApiKeyMutation) ClearField(name string) error {
 	case apikey.FieldUserID:
 		m.ClearUserID()
 		return nil
+	case apikey.FieldSpaceID:
+		m.ClearSpaceID()
+		return nil
+	case apikey.FieldExpiredAt:
+		m.ClearExpiredAt()
+		return nil
+	case apikey.FieldPrefix:
+		m.ClearPrefix()
+		return nil
+	case apikey.FieldScopes:
+		m.ClearScopes()
+		return nil
 	case apikey.FieldLastUsed:
 		m.ClearLastUsed()
 		return nil
+	case apikey.FieldRevokedAt:
+		m.ClearRevokedAt()
+		return nil
 	}
 	return fmt.Errorf("unknown ApiKey nullable field %s", name)
 }
@@ -780,12 +1215,27 @@ func (m *ApiKeyMutation) ResetField(name string) error {
 	case apikey.FieldUserID:
 		m.ResetUserID()
 		return nil
+	case apikey.FieldSpaceID:
+		m.ResetSpaceID()
+		return nil
+	case apikey.FieldExpiredAt:
+		m.ResetExpiredAt()
+		return nil
 	case apikey.FieldKey:
 		m.ResetKey()
 		return nil
+	case apikey.FieldPrefix:
+		m.ResetPrefix()
+		return nil
+	case apikey.FieldScopes:
+		m.ResetScopes()
+		return nil
 	case apikey.FieldLastUsed:
 		m.ResetLastUsed()
 		return nil
+	case apikey.FieldRevokedAt:
+		m.ResetRevokedAt()
+		return nil
 	}
 	return fmt.Errorf("unknown ApiKey field %s", name)
 }
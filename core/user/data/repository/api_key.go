@@ -7,6 +7,9 @@ import (
 	"ncobase/core/user/data/ent"
 	apiKeyEnt "ncobase/core/user/data/ent/apikey"
 	"ncobase/core/user/structs"
+	"ncobase/internal/idgen"
+	"ncobase/internal/utils"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -24,9 +27,15 @@ type ApiKeyRepositoryInterface interface {
 	GetByKey(ctx context.Context, key string) (*ent.ApiKey, error)
 	GetByUserID(ctx context.Context, userID string) ([]*ent.ApiKey, error)
 	Delete(ctx context.Context, id string) error
+	Revoke(ctx context.Context, id string, timestamp int64) (*ent.ApiKey, error)
 	UpdateLastUsed(ctx context.Context, id string, timestamp int64) error
 }
 
+const (
+	apiKeyPrefixSize = 8
+	apiKeySeparator  = "."
+)
+
 // apiKeyRepository implements ApiKeyRepositoryInterface
 type apiKeyRepository struct {
 	data            *data.Data
@@ -42,20 +51,21 @@ func NewApiKeyRepository(d *data.Data) ApiKeyRepositoryInterface {
 
 	return &apiKeyRepository{
 		data:            d,
-		apiKeyCache:     cache.NewCache[ent.ApiKey](redisClient, "ncse_api_keys"),
-		keyMappingCache: cache.NewCache[string](redisClient, "ncse_api_key_mappings"),
-		userKeysCache:   cache.NewCache[[]string](redisClient, "ncse_user_api_keys"),
+		apiKeyCache:     utils.NewRetryCache(cache.NewCache[ent.ApiKey](redisClient, "ncse_api_keys")),
+		keyMappingCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_api_key_mappings")),
+		userKeysCache:   utils.NewRetryCache(cache.NewCache[[]string](redisClient, "ncse_user_api_keys")),
 		apiKeyTTL:       time.Hour * 6, // 6 hours cache TTL
 	}
 }
 
 // Create creates a new API key
 func (r *apiKeyRepository) Create(ctx context.Context, userID string, request *structs.CreateApiKeyRequest) (*ent.ApiKey, string, error) {
-	id := nanoid.PrimaryKey()()
+	id := idgen.New()
 	now := time.Now().UnixMilli()
 
-	// Generate API key
-	apiKeyValue := nanoid.String(32)
+	// Generate API key, the clear prefix locates the row without scanning every hash
+	prefix := nanoid.String(apiKeyPrefixSize)
+	apiKeyValue := prefix + apiKeySeparator + nanoid.String(32)
 	hashedKey, err := crypto.HashPassword(ctx, apiKeyValue)
 	if err != nil {
 		return nil, "", err
@@ -66,7 +76,11 @@ func (r *apiKeyRepository) Create(ctx context.Context, userID string, request *s
 		SetID(id).
 		SetName(request.Name).
 		SetKey(hashedKey).
+		SetPrefix(prefix).
 		SetUserID(userID).
+		SetSpaceID(request.SpaceID).
+		SetScopes(request.Scopes).
+		SetNillableExpiredAt(request.ExpiredAt).
 		SetCreatedAt(now).
 		SetLastUsed(now).
 		Save(ctx)
@@ -97,7 +111,7 @@ func (r *apiKeyRepository) GetByID(ctx context.Context, id string) (*ent.ApiKey,
 	client := r.data.GetSlaveEntClient()
 	apiKey, err := client.ApiKey.Get(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -108,10 +122,17 @@ func (r *apiKeyRepository) GetByID(ctx context.Context, id string) (*ent.ApiKey,
 
 // GetByKey retrieves an API key by the actual key value
 func (r *apiKeyRepository) GetByKey(ctx context.Context, key string) (*ent.ApiKey, error) {
-	// First, we need to find which API key this belongs to
-	// We'll need to check all API keys (this is expensive, but API key validation should be cached)
 	client := r.data.GetSlaveEntClient()
-	apiKeys, err := client.ApiKey.Query().All(ctx)
+	query := client.ApiKey.Query()
+
+	// Narrow candidates by prefix, legacy keys without one need a hash scan
+	if prefix, _, ok := strings.Cut(key, apiKeySeparator); ok && prefix != "" {
+		query = query.Where(apiKeyEnt.PrefixEQ(prefix))
+	} else {
+		query = query.Where(apiKeyEnt.Or(apiKeyEnt.PrefixIsNil(), apiKeyEnt.PrefixEQ("")))
+	}
+
+	apiKeys, err := query.All(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -174,6 +195,27 @@ func (r *apiKeyRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// Revoke marks an API key as revoked
+func (r *apiKeyRepository) Revoke(ctx context.Context, id string, timestamp int64) (*ent.ApiKey, error) {
+	client := r.data.GetMasterEntClient()
+	apiKey, err := client.ApiKey.UpdateOneID(id).
+		SetRevokedAt(timestamp).
+		Save(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidate caches
+	go func() {
+		r.invalidateApiKeyCache(context.Background(), id)
+		r.invalidateKeyMappingCache(context.Background(), apiKey.Key)
+		r.invalidateUserKeysCache(context.Background(), apiKey.UserID)
+	}()
+
+	return apiKey, nil
+}
+
 // UpdateLastUsed updates the last used timestamp
 func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, id string, timestamp int64) error {
 	client := r.data.GetMasterEntClient()
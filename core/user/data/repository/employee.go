@@ -7,6 +7,7 @@ import (
 	"ncobase/core/user/data/ent"
 	employeeEnt "ncobase/core/user/data/ent/employee"
 	"ncobase/core/user/structs"
+	"ncobase/internal/utils"
 
 	"github.com/ncobase/ncore/data/cache"
 	"github.com/ncobase/ncore/data/paging"
@@ -32,14 +33,14 @@ type EmployeeRepositoryInterface interface {
 type employeeRepository struct {
 	ec *ent.Client
 	rc *redis.Client
-	c  *cache.Cache[ent.Employee]
+	c  cache.ICache[ent.Employee]
 }
 
 // NewEmployeeRepository creates a new employee repository
 func NewEmployeeRepository(d *data.Data) EmployeeRepositoryInterface {
 	ec := d.GetMasterEntClient()
 	rc := d.GetRedis().(*redis.Client)
-	return &employeeRepository{ec, rc, cache.NewCache[ent.Employee](rc, "ncse_employee")}
+	return &employeeRepository{ec, rc, utils.NewRetryCache(cache.NewCache[ent.Employee](rc, "ncse_employee"))}
 }
 
 // Create creates a new employee record
@@ -187,7 +188,7 @@ func (r *employeeRepository) GetByUserID(ctx context.Context, userID string) (*e
 	row, err := r.ec.Employee.Query().Where(employeeEnt.IDEQ(userID)).Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "employeeRepo.GetByUserID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache the result
@@ -208,7 +209,7 @@ func (r *employeeRepository) GetByEmployeeID(ctx context.Context, employeeID str
 	row, err := r.ec.Employee.Query().Where(employeeEnt.EmployeeIDEQ(employeeID)).Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "employeeRepo.GetByEmployeeID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache the result
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ncobase/core/user/data"
+	"ncobase/core/user/data/ent"
+	apiKeyEnt "ncobase/core/user/data/ent/apikey"
+	employeeEnt "ncobase/core/user/data/ent/employee"
+	userProfileEnt "ncobase/core/user/data/ent/userprofile"
+	"time"
+
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// ErasedUserStatus is the status of an anonymized user, the account cannot sign in
+const ErasedUserStatus = 2
+
+// ErasureRepositoryInterface erases a user with their profile, employee record and API keys
+type ErasureRepositoryInterface interface {
+	Erase(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error)
+}
+
+// erasureRepository erases across the user tables in one transaction, then drops their caches
+type erasureRepository struct {
+	data     *data.Data
+	user     *userRepository
+	profile  *userProfileRepository
+	employee *employeeRepository
+	apiKey   *apiKeyRepository
+}
+
+// NewErasureRepository creates a new erasure repository
+func NewErasureRepository(d *data.Data) ErasureRepositoryInterface {
+	return &erasureRepository{
+		data:     d,
+		user:     NewUserRepository(d).(*userRepository),
+		profile:  NewUserProfileRepository(d).(*userProfileRepository),
+		employee: NewEmployeeRepository(d).(*employeeRepository),
+		apiKey:   NewApiKeyRepository(d).(*apiKeyRepository),
+	}
+}
+
+// ErasedUsername returns the username an anonymized user keeps, unique like the username it replaces
+func ErasedUsername(userID string) string {
+	return "erased-" + userID
+}
+
+// Erase deletes the profile, employee record and API keys of a user. The account is deleted,
+// or with anonymize kept under an erased username without contact details, password or extras.
+// Erasing an erased user changes nothing and reports nothing.
+func (r *erasureRepository) Erase(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error) {
+	var (
+		user      *ent.User
+		keys      []*ent.ApiKey
+		employees []*ent.Employee
+	)
+
+	err = r.data.WithEntTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+		deleted, anonymized = 0, 0
+
+		var err error
+		user, err = tx.User.Get(ctx, userID)
+		if IsNotFound(err) {
+			user = nil
+		} else if err != nil {
+			return err
+		}
+
+		keys, err = tx.ApiKey.Query().Where(apiKeyEnt.UserIDEQ(userID)).All(ctx)
+		if err != nil {
+			return err
+		}
+		n, err := tx.ApiKey.Delete().Where(apiKeyEnt.UserIDEQ(userID)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		deleted += n
+
+		employees, err = tx.Employee.Query().Where(employeeEnt.IDEQ(userID)).All(ctx)
+		if err != nil {
+			return err
+		}
+		n, err = tx.Employee.Delete().Where(employeeEnt.IDEQ(userID)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		deleted += n
+
+		n, err = tx.UserProfile.Delete().Where(userProfileEnt.IDEQ(userID)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		deleted += n
+
+		switch {
+		case user == nil:
+		case !anonymize:
+			if err := tx.User.DeleteOneID(userID).Exec(ctx); err != nil {
+				return err
+			}
+			deleted++
+		case user.Username != ErasedUsername(userID):
+			_, err := tx.User.UpdateOneID(userID).
+				SetUsername(ErasedUsername(userID)).
+				ClearEmail().
+				ClearPhone().
+				ClearPassword().
+				ClearExtras().
+				SetIsAdmin(false).
+				SetIsCertified(false).
+				SetStatus(ErasedUserStatus).
+				SetUpdatedAt(time.Now().UnixMilli()).
+				Save(ctx)
+			if err != nil {
+				return err
+			}
+			anonymized++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("erase user %s: %w", userID, err)
+	}
+
+	if user != nil {
+		r.user.invalidateUserCache(ctx, user)
+		if r.user.sc != nil {
+			if err := r.user.sc.Delete(ctx, "users", userID); err != nil {
+				logger.Warnf(ctx, "Failed to remove erased user %s from search index: %v", userID, err)
+			}
+		}
+	}
+	r.profile.invalidateProfileCache(ctx, userID)
+	for _, key := range keys {
+		r.apiKey.invalidateApiKeyCache(ctx, key.ID)
+		r.apiKey.invalidateKeyMappingCache(ctx, key.Key)
+	}
+	r.apiKey.invalidateUserKeysCache(ctx, userID)
+	for _, employee := range employees {
+		_ = r.employee.c.Delete(ctx, fmt.Sprintf("user:%s", employee.ID))
+		if employee.EmployeeID != "" {
+			_ = r.employee.c.Delete(ctx, fmt.Sprintf("emp:%s", employee.EmployeeID))
+		}
+	}
+
+	return deleted, anonymized, nil
+}
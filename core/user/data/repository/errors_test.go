@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ncobase/core/user/data"
+	"ncobase/internal/dberr"
+
+	nd "github.com/ncobase/ncore/data"
+	"github.com/ncobase/ncore/data/connection"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestLookupsReturnTypedNotFound(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	d := &data.Data{Data: &nd.Data{Conn: &connection.Connections{RC: (*redis.Client)(nil)}}, EC: client}
+	users, employees := NewUserRepository(d), NewEmployeeRepository(d)
+
+	_, err := users.GetByID(ctx, "missing")
+	if !errors.Is(err, ErrNotFound) || !dberr.IsNotFound(err) || !IsNotFound(err) {
+		t.Fatalf("missing user: %v, want ErrNotFound", err)
+	}
+	if _, err := employees.GetByUserID(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing employee: %v, want ErrNotFound", err)
+	}
+
+	// a failing driver is an error, not a missing row
+	_ = client.Close()
+	_, err = users.GetByID(ctx, "u1")
+	if err == nil || IsNotFound(err) || dberr.IsNotFound(err) {
+		t.Fatalf("closed database: %v, want a generic error", err)
+	}
+	if _, err := employees.GetByUserID(ctx, "u1"); err == nil || IsNotFound(err) {
+		t.Fatalf("closed database: %v, want a generic error", err)
+	}
+}
@@ -8,6 +8,7 @@ type Repository struct {
 	UserProfile UserProfileRepositoryInterface
 	Employee    EmployeeRepositoryInterface
 	ApiKey      ApiKeyRepositoryInterface
+	Erasure     ErasureRepositoryInterface
 }
 
 // New creates a new repository
@@ -17,5 +18,6 @@ func New(d *data.Data) *Repository {
 		UserProfile: NewUserProfileRepository(d),
 		Employee:    NewEmployeeRepository(d),
 		ApiKey:      NewApiKeyRepository(d),
+		Erasure:     NewErasureRepository(d),
 	}
 }
@@ -93,14 +93,24 @@ func SerializeApiKey(apiKey *ent.ApiKey) *structs.ApiKey {
 	if apiKey == nil {
 		return nil
 	}
-	return &structs.ApiKey{
+	result := &structs.ApiKey{
 		ID:        apiKey.ID,
 		Name:      apiKey.Name,
 		Key:       "",
+		Prefix:    apiKey.Prefix,
 		UserID:    apiKey.UserID,
+		SpaceID:   apiKey.SpaceID,
+		Scopes:    apiKey.Scopes,
 		CreatedAt: apiKey.CreatedAt,
 		LastUsed:  &apiKey.LastUsed,
 	}
+	if apiKey.ExpiredAt > 0 {
+		result.ExpiredAt = &apiKey.ExpiredAt
+	}
+	if apiKey.RevokedAt > 0 {
+		result.RevokedAt = &apiKey.RevokedAt
+	}
+	return result
 }
 
 // SerializeApiKeys converts ent.ApiKey list to structs.ApiKey list.
@@ -5,17 +5,21 @@ import (
 	"fmt"
 	"ncobase/core/user/data"
 	ent "ncobase/core/user/data/ent"
+	"ncobase/core/user/data/ent/predicate"
 	userEnt "ncobase/core/user/data/ent/user"
 	"ncobase/core/user/structs"
+	"ncobase/internal/identifier"
+	"ncobase/internal/idgen"
+	"ncobase/internal/passhash"
+	"ncobase/internal/utils"
+	"strings"
 	"time"
 
 	nd "github.com/ncobase/ncore/data"
 	"github.com/ncobase/ncore/data/cache"
 	"github.com/ncobase/ncore/data/paging"
 	"github.com/ncobase/ncore/logging/logger"
-	"github.com/ncobase/ncore/security/crypto"
 	"github.com/ncobase/ncore/types"
-	"github.com/ncobase/ncore/utils/nanoid"
 
 	"github.com/ncobase/ncore/data/search"
 	"github.com/redis/go-redis/v9"
@@ -26,6 +30,7 @@ type UserRepositoryInterface interface {
 	Create(ctx context.Context, body *structs.UserBody) (*ent.User, error)
 	Update(ctx context.Context, id string, updates types.JSON) (*ent.User, error)
 	GetByID(ctx context.Context, id string) (*ent.User, error)
+	GetByIDs(ctx context.Context, ids []string) ([]*ent.User, error)
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, params *structs.ListUserParams) ([]*ent.User, error)
 	Find(ctx context.Context, filter *structs.FindUser) (*ent.User, error)
@@ -33,6 +38,7 @@ type UserRepositoryInterface interface {
 	UpdatePassword(ctx context.Context, body *structs.UserPassword) error
 	UpdatePasswordByID(ctx context.Context, userID, hashedPassword string) error
 	CountX(ctx context.Context, params *structs.ListUserParams) int
+	SyncIndex(ctx context.Context, id string) error
 }
 
 // userRepository implements UserRepositoryInterface
@@ -53,27 +59,27 @@ func NewUserRepository(d *data.Data) UserRepositoryInterface {
 	return &userRepository{
 		data:                 d,
 		sc:                   sc,
-		userCache:            cache.NewCache[ent.User](redisClient, "ncse_users"),
-		usernameMappingCache: cache.NewCache[string](redisClient, "ncse_user_mappings:username"),
-		emailMappingCache:    cache.NewCache[string](redisClient, "ncse_user_mappings:email"),
+		userCache:            utils.NewRetryCache(cache.NewCache[ent.User](redisClient, "ncse_users")),
+		usernameMappingCache: utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_user_mappings:username")),
+		emailMappingCache:    utils.NewRetryCache(cache.NewCache[string](redisClient, "ncse_user_mappings:email")),
 		userTTL:              time.Hour * 2, // 2 hours cache TTL
 	}
 }
 
 // Create creates a new user
 func (r *userRepository) Create(ctx context.Context, body *structs.UserBody) (*ent.User, error) {
-	id := nanoid.PrimaryKey()()
+	id := idgen.New()
 	now := time.Now().UnixMilli()
 
 	client := r.data.GetMasterEntClient()
 	builder := client.User.Create()
 	builder.SetID(id)
-	builder.SetUsername(body.Username)
+	builder.SetUsername(identifier.Username(body.Username))
 	builder.SetCreatedAt(now)
 	builder.SetUpdatedAt(now)
 
-	if body.Email != "" {
-		builder.SetEmail(body.Email)
+	if email := identifier.Email(body.Email); email != "" {
+		builder.SetEmail(email)
 	}
 	if body.Phone != "" {
 		builder.SetPhone(body.Phone)
@@ -112,7 +118,7 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*ent.User, err
 	client := r.data.GetSlaveEntClient()
 	user, err := client.User.Get(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -121,21 +127,47 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*ent.User, err
 	return user, nil
 }
 
+// GetByIDs retrieves multiple users by their IDs in a single query
+func (r *userRepository) GetByIDs(ctx context.Context, ids []string) ([]*ent.User, error) {
+	if len(ids) == 0 {
+		return []*ent.User{}, nil
+	}
+
+	client := r.data.GetSlaveEntClient()
+	users, err := client.User.Query().Where(userEnt.IDIn(ids...)).All(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "userRepo.GetByIDs error: %v", err)
+		return nil, err
+	}
+
+	// Cache each user
+	go func() {
+		for _, user := range users {
+			r.cacheUser(context.Background(), user)
+		}
+	}()
+
+	return users, nil
+}
+
 // Find retrieves a user by various filters
 func (r *userRepository) Find(ctx context.Context, filter *structs.FindUser) (*ent.User, error) {
 	// Try to find user ID from cache mappings first
 	var userID string
 	var err error
 
-	if filter.Username != "" {
-		userID, err = r.getUserIDByUsername(ctx, filter.Username)
+	username := identifier.Username(filter.Username)
+	email := identifier.Email(filter.Email)
+
+	if username != "" {
+		userID, err = r.getUserIDByUsername(ctx, username)
 		if err == nil && userID != "" {
 			return r.GetByID(ctx, userID)
 		}
 	}
 
-	if filter.Email != "" {
-		userID, err = r.getUserIDByEmail(ctx, filter.Email)
+	if email != "" {
+		userID, err = r.getUserIDByEmail(ctx, email)
 		if err == nil && userID != "" {
 			return r.GetByID(ctx, userID)
 		}
@@ -148,18 +180,22 @@ func (r *userRepository) Find(ctx context.Context, filter *structs.FindUser) (*e
 	if filter.ID != "" {
 		builder = builder.Where(userEnt.IDEQ(filter.ID))
 	}
-	if filter.Username != "" {
+	if username != "" {
+		value := strings.TrimSpace(filter.Username)
 		builder = builder.Where(userEnt.Or(
-			userEnt.IDEQ(filter.Username),
-			userEnt.UsernameEQ(filter.Username),
-			userEnt.EmailEQ(filter.Username),
-			userEnt.PhoneEQ(filter.Username),
+			userEnt.IDEQ(value),
+			usernameEQ(username),
+			emailEQ(identifier.Email(filter.Username)),
+			userEnt.PhoneEQ(value),
 		))
 	}
+	if email != "" {
+		builder = builder.Where(emailEQ(email))
+	}
 
 	user, err := builder.Only(ctx)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -184,8 +220,8 @@ func (r *userRepository) Update(ctx context.Context, id string, updates types.JS
 	client := r.data.GetMasterEntClient()
 	builder := client.User.UpdateOneID(id)
 
-	if email, ok := updates["email"].(string); ok && email != "" {
-		builder = builder.SetEmail(email)
+	if email, ok := updates["email"].(string); ok && identifier.Email(email) != "" {
+		builder = builder.SetEmail(identifier.Email(email))
 	}
 	if phone, ok := updates["phone"].(string); ok && phone != "" {
 		builder = builder.SetPhone(phone)
@@ -226,6 +262,24 @@ func (r *userRepository) Update(ctx context.Context, id string, updates types.JS
 	return user, nil
 }
 
+// SyncIndex brings the search document of a user in line with the database: the user is indexed
+// as they are now, or removed from the index when deleted or erased. It reads the master so a
+// write that just committed is seen. It is a no-op without a search engine.
+func (r *userRepository) SyncIndex(ctx context.Context, id string) error {
+	if r.sc == nil {
+		return nil
+	}
+
+	user, err := r.data.GetMasterEntClient().User.Get(ctx, id)
+	if IsNotFound(err) || (err == nil && user.Username == ErasedUsername(id)) {
+		return r.sc.Delete(ctx, "users", id)
+	}
+	if err != nil {
+		return err
+	}
+	return r.sc.Index(ctx, &search.IndexRequest{Index: "users", Document: user, DocumentID: user.ID})
+}
+
 // Delete deletes a user
 func (r *userRepository) Delete(ctx context.Context, id string) error {
 	// Get user first for cache invalidation
@@ -330,7 +384,7 @@ func (r *userRepository) List(ctx context.Context, params *structs.ListUserParam
 
 // UpdatePassword updates user password
 func (r *userRepository) UpdatePassword(ctx context.Context, body *structs.UserPassword) error {
-	hashedPassword, err := crypto.HashPassword(ctx, body.NewPassword)
+	hashedPassword, err := passhash.Hash(ctx, body.NewPassword)
 	if err != nil {
 		return err
 	}
@@ -360,7 +414,7 @@ func (r *userRepository) UpdatePasswordByID(ctx context.Context, userID, hashedP
 	go func() {
 		cacheKey := fmt.Sprintf("id:%s", userID)
 		if err := r.userCache.Delete(context.Background(), cacheKey); err != nil {
-			logger.Debugf(context.Background(), "Failed to invalidate user cache %s: %v", userID, err)
+			logger.Debugf(ctx, "Failed to invalidate user cache %s: %v", userID, err)
 		}
 	}()
 
@@ -395,15 +449,15 @@ func (r *userRepository) cacheUser(ctx context.Context, user *ent.User) {
 		logger.Debugf(ctx, "Failed to cache user by ID %s: %v", user.ID, err)
 	}
 
-	// Cache username to ID mapping
-	usernameKey := fmt.Sprintf("username:%s", user.Username)
+	// Cache username to ID mapping, keyed as lookups normalize it
+	usernameKey := fmt.Sprintf("username:%s", identifier.Username(user.Username))
 	if err := r.usernameMappingCache.Set(ctx, usernameKey, &user.ID, r.userTTL); err != nil {
 		logger.Debugf(ctx, "Failed to cache username mapping %s: %v", user.Username, err)
 	}
 
 	// Cache email to ID mapping if email exists
 	if user.Email != "" {
-		emailKey := fmt.Sprintf("email:%s", user.Email)
+		emailKey := fmt.Sprintf("email:%s", identifier.Email(user.Email))
 		if err := r.emailMappingCache.Set(ctx, emailKey, &user.ID, r.userTTL); err != nil {
 			logger.Debugf(ctx, "Failed to cache email mapping %s: %v", user.Email, err)
 		}
@@ -419,14 +473,14 @@ func (r *userRepository) invalidateUserCache(ctx context.Context, user *ent.User
 	}
 
 	// Invalidate username mapping
-	usernameKey := fmt.Sprintf("username:%s", user.Username)
+	usernameKey := fmt.Sprintf("username:%s", identifier.Username(user.Username))
 	if err := r.usernameMappingCache.Delete(ctx, usernameKey); err != nil {
 		logger.Debugf(ctx, "Failed to invalidate username mapping cache %s: %v", user.Username, err)
 	}
 
 	// Invalidate email mapping if email exists
 	if user.Email != "" {
-		emailKey := fmt.Sprintf("email:%s", user.Email)
+		emailKey := fmt.Sprintf("email:%s", identifier.Email(user.Email))
 		if err := r.emailMappingCache.Delete(ctx, emailKey); err != nil {
 			logger.Debugf(ctx, "Failed to invalidate email mapping cache %s: %v", user.Email, err)
 		}
@@ -452,3 +506,20 @@ func (r *userRepository) getUserIDByEmail(ctx context.Context, email string) (st
 	}
 	return *userID, nil
 }
+
+// usernameEQ matches a normalized username. When usernames fold, case is ignored so
+// users stored before usernames were normalized still match.
+func usernameEQ(username string) predicate.User {
+	if identifier.UsernamesFold() {
+		return userEnt.UsernameEqualFold(username)
+	}
+	return userEnt.UsernameEQ(username)
+}
+
+// emailEQ matches a normalized email, ignoring case when emails fold
+func emailEQ(email string) predicate.User {
+	if identifier.EmailsFold() {
+		return userEnt.EmailEqualFold(email)
+	}
+	return userEnt.EmailEQ(email)
+}
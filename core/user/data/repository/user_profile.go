@@ -6,6 +6,7 @@ import (
 	"ncobase/core/user/data"
 	"ncobase/core/user/data/ent"
 	"ncobase/core/user/structs"
+	"ncobase/internal/utils"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -36,7 +37,7 @@ func NewUserProfileRepository(d *data.Data) UserProfileRepositoryInterface {
 
 	return &userProfileRepository{
 		data:         d,
-		profileCache: cache.NewCache[ent.UserProfile](redisClient, "ncse_user_profiles"),
+		profileCache: utils.NewRetryCache(cache.NewCache[ent.UserProfile](redisClient, "ncse_user_profiles")),
 		profileTTL:   time.Hour * 1, // 1 hour cache TTL
 	}
 }
@@ -98,7 +99,7 @@ func (r *userProfileRepository) Get(ctx context.Context, userID string) (*ent.Us
 	client := r.data.GetSlaveEntClient()
 	profile, err := client.UserProfile.Get(ctx, userID)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache for future use
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"ncobase/core/user/data/ent"
+	"ncobase/internal/identifier"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestClient(t *testing.T) *ent.Client {
+	t.Helper()
+	client, err := ent.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return client
+}
+
+// withIdentifiers makes cfg the identifier config for the rest of the test
+func withIdentifiers(t *testing.T, cfg *identifier.Config) {
+	t.Helper()
+	identifier.Set(cfg)
+	t.Cleanup(func() { identifier.Set(&identifier.Config{UnicodeForm: identifier.FormNone}) })
+}
+
+func TestLookupMatchesMixedCaseUsers(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	// stored before identifiers were normalized
+	client.User.Create().SetID("u1").SetUsername("Alice").SetEmail("Alice@Example.com").SaveX(ctx)
+
+	withIdentifiers(t, &identifier.Config{UnicodeForm: identifier.FormNone})
+	row, err := client.User.Query().Where(usernameEQ(identifier.Username(" ALICE "))).Only(ctx)
+	if err != nil || row.ID != "u1" {
+		t.Fatalf("username lookup = %v, %v, want u1", row, err)
+	}
+	row, err = client.User.Query().Where(emailEQ(identifier.Email("alice@EXAMPLE.com"))).Only(ctx)
+	if err != nil || row.ID != "u1" {
+		t.Fatalf("email lookup = %v, %v, want u1", row, err)
+	}
+}
+
+func TestLookupCaseSensitive(t *testing.T) {
+	ctx := context.Background()
+	client := openTestClient(t)
+	client.User.Create().SetID("u1").SetUsername("Alice").SaveX(ctx)
+	client.User.Create().SetID("u2").SetUsername("alice").SaveX(ctx)
+
+	withIdentifiers(t, &identifier.Config{CaseSensitiveUsernames: true, UnicodeForm: identifier.FormNone})
+	for username, want := range map[string]string{"Alice": "u1", " alice": "u2"} {
+		row, err := client.User.Query().Where(usernameEQ(identifier.Username(username))).Only(ctx)
+		if err != nil || row.ID != want {
+			t.Fatalf("lookup of %q = %v, %v, want %s", username, row, err, want)
+		}
+	}
+	if n := client.User.Query().Where(usernameEQ(identifier.Username("ALICE"))).CountX(ctx); n != 0 {
+		t.Fatalf("case-sensitive lookup of ALICE matched %d users", n)
+	}
+}
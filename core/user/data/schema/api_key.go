@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -31,10 +32,12 @@ func (ApiKey) Annotations() []schema.Annotation {
 // Mixin of the ApiKey
 func (ApiKey) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.TimeAt{},
 		mixin.UserID,
+		mixin.SpaceID,
+		mixin.ExpiredAt,
 	}
 }
 
@@ -42,7 +45,10 @@ func (ApiKey) Mixin() []ent.Mixin {
 func (ApiKey) Fields() []ent.Field {
 	return []ent.Field{
 		field.String("key").NotEmpty().Unique(),
+		field.String("prefix").Optional().Comment("Clear text lookup prefix of the key"),
+		field.JSON("scopes", []string{}).Optional().Comment("Permissions granted to the key, empty for all of the user's"),
 		field.Int64("last_used").Optional(),
+		field.Int64("revoked_at").Optional().Comment("Revocation time, revoked keys are kept for audit"),
 	}
 }
 
@@ -52,5 +58,6 @@ func (ApiKey) Indexes() []ent.Index {
 		index.Fields("id", "created_at").Unique(),
 		index.Fields("user_id", "created_at"),
 		index.Fields("key").Unique(),
+		index.Fields("prefix"),
 	}
 }
@@ -3,10 +3,10 @@ package handler
 import (
 	"ncobase/core/user/service"
 	"ncobase/core/user/structs"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/ctxutil"
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,6 +18,7 @@ type ApiKeyHandlerInterface interface {
 	GetUserApiKeys(c *gin.Context)
 	GetMyApiKeys(c *gin.Context)
 	DeleteApiKey(c *gin.Context)
+	RevokeApiKey(c *gin.Context)
 }
 
 // apiKeyHandler implements ApiKeyHandlerInterface
@@ -162,3 +163,23 @@ func (h *apiKeyHandler) DeleteApiKey(c *gin.Context) {
 	}
 	resp.Success(c.Writer)
 }
+
+// RevokeApiKey revokes an API key
+//
+// @Summary Revoke API key
+// @Description Revoke an API key by its ID, the key stops authenticating immediately
+// @Tags sys
+// @Produce json
+// @Param id path string true "API key ID"
+// @Success 200 {object} structs.ApiKey "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /sys/users/api-keys/{id}/revoke [post]
+// @Security Bearer
+func (h *apiKeyHandler) RevokeApiKey(c *gin.Context) {
+	result, err := h.s.ApiKey.RevokeApiKey(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
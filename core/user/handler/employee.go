@@ -3,9 +3,9 @@ package handler
 import (
 	"ncobase/core/user/service"
 	"ncobase/core/user/structs"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
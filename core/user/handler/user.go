@@ -3,11 +3,11 @@ package handler
 import (
 	"ncobase/core/user/service"
 	"ncobase/core/user/structs"
+	"ncobase/internal/validation"
 
 	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/net/resp"
 	"github.com/ncobase/ncore/types"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
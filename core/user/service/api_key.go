@@ -19,6 +19,7 @@ type ApiKeyServiceInterface interface {
 	GetApiKey(ctx context.Context, id string) (*structs.ApiKey, error)
 	GetUserApiKeys(ctx context.Context, userID string) ([]*structs.ApiKey, error)
 	DeleteApiKey(ctx context.Context, id string) error
+	RevokeApiKey(ctx context.Context, id string) (*structs.ApiKey, error)
 	ValidateApiKey(ctx context.Context, key string) (*structs.ApiKey, error)
 }
 
@@ -42,6 +43,15 @@ func (s *apiKeyService) GenerateApiKey(ctx context.Context, userID string, reque
 		return nil, errors.New("API key name is required")
 	}
 
+	if request.ExpiredAt != nil && *request.ExpiredAt <= time.Now().UnixMilli() {
+		return nil, errors.New("API key expiry must be in the future")
+	}
+
+	// Bind the key to the current space unless one is given
+	if request.SpaceID == "" {
+		request.SpaceID = ctxutil.GetSpaceID(ctx)
+	}
+
 	// currentUserID := ctxutil.GetUserID(ctx)
 	// if currentUserID != userID && !ctxutil.IsAdmin(ctx) {
 	// 	return nil, errors.New("unauthorized access to create API key")
@@ -136,16 +146,63 @@ func (s *apiKeyService) DeleteApiKey(ctx context.Context, id string) error {
 	return nil
 }
 
+// RevokeApiKey revokes an API key, keeping the record for auditing
+func (s *apiKeyService) RevokeApiKey(ctx context.Context, id string) (*structs.ApiKey, error) {
+	// Get API key first to check ownership
+	row, err := s.apiKey.GetByID(ctx, id)
+	if err := handleEntError(ctx, "ApiKey", err); err != nil {
+		return nil, err
+	}
+
+	// Check if current user is authorized to revoke this API key
+	currentUserID := ctxutil.GetUserID(ctx)
+	isAdmin := ctxutil.GetUserIsAdmin(ctx)
+	if !isAdmin && currentUserID != row.UserID {
+		return nil, errors.New("unauthorized access to revoke API key")
+	}
+
+	// Revoking twice keeps the original timestamp
+	if row.RevokedAt > 0 {
+		return repository.SerializeApiKey(row), nil
+	}
+
+	row, err = s.apiKey.Revoke(ctx, id, time.Now().UnixMilli())
+	if err != nil {
+		logger.Errorf(ctx, "apiKeyService.RevokeApiKey error: %v", err)
+		return nil, err
+	}
+
+	// Publish API key deleted event
+	if s.ep != nil {
+		s.ep.PublishApiKeyDeleted(ctx, row.UserID, &types.JSON{
+			"key_id":   id,
+			"key_name": row.Name,
+			"revoked":  true,
+		})
+	}
+
+	return repository.SerializeApiKey(row), nil
+}
+
 // ValidateApiKey validates an API key and updates last used timestamp
 func (s *apiKeyService) ValidateApiKey(ctx context.Context, key string) (*structs.ApiKey, error) {
 	row, err := s.apiKey.GetByKey(ctx, key)
 	if err != nil {
-		return nil, errors.New("invalid API key")
+		return nil, structs.ErrApiKeyInvalid
 	}
 
-	// Update last used timestamp
+	apiKey := repository.SerializeApiKey(row)
 	now := time.Now().UnixMilli()
+
+	if apiKey.IsRevoked() {
+		return nil, structs.ErrApiKeyRevoked
+	}
+	if apiKey.IsExpired(now) {
+		return nil, structs.ErrApiKeyExpired
+	}
+
+	// Update last used timestamp
 	_ = s.apiKey.UpdateLastUsed(ctx, row.ID, now)
 
-	return repository.SerializeApiKey(row), nil
+	return apiKey, nil
 }
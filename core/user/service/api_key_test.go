@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ncobase/core/user/data/ent"
+	"ncobase/core/user/data/repository"
+	"ncobase/core/user/structs"
+
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+// memoryApiKeys keeps API keys in memory, indexed by their clear value
+type memoryApiKeys struct {
+	repository.ApiKeyRepositoryInterface
+	rows map[string]*ent.ApiKey
+}
+
+func (r *memoryApiKeys) GetByKey(_ context.Context, key string) (*ent.ApiKey, error) {
+	if row, ok := r.rows[key]; ok {
+		return row, nil
+	}
+	return nil, &ent.NotFoundError{}
+}
+
+func (r *memoryApiKeys) GetByID(_ context.Context, id string) (*ent.ApiKey, error) {
+	for _, row := range r.rows {
+		if row.ID == id {
+			return row, nil
+		}
+	}
+	return nil, &ent.NotFoundError{}
+}
+
+func (r *memoryApiKeys) Revoke(ctx context.Context, id string, timestamp int64) (*ent.ApiKey, error) {
+	row, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	row.RevokedAt = timestamp
+	return row, nil
+}
+
+func (r *memoryApiKeys) UpdateLastUsed(ctx context.Context, id string, timestamp int64) error {
+	row, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	row.LastUsed = timestamp
+	return nil
+}
+
+func newApiKeyService() (*apiKeyService, *memoryApiKeys) {
+	hour := time.Hour.Milliseconds()
+	now := time.Now().UnixMilli()
+	repo := &memoryApiKeys{rows: map[string]*ent.ApiKey{
+		"valid":   {ID: "k1", UserID: "u1", ExpiredAt: now + hour},
+		"expired": {ID: "k2", UserID: "u1", ExpiredAt: now - hour},
+		"revoked": {ID: "k3", UserID: "u1", RevokedAt: now - hour},
+	}}
+	return &apiKeyService{apiKey: repo}, repo
+}
+
+func TestValidateApiKey(t *testing.T) {
+	s, repo := newApiKeyService()
+	ctx := context.Background()
+
+	key, err := s.ValidateApiKey(ctx, "valid")
+	if err != nil || key.UserID != "u1" {
+		t.Fatalf("valid key = %+v, %v", key, err)
+	}
+	if repo.rows["valid"].LastUsed == 0 {
+		t.Fatal("last used not updated")
+	}
+
+	for value, want := range map[string]error{
+		"expired": structs.ErrApiKeyExpired,
+		"revoked": structs.ErrApiKeyRevoked,
+		"unknown": structs.ErrApiKeyInvalid,
+	} {
+		if _, err := s.ValidateApiKey(ctx, value); !errors.Is(err, want) {
+			t.Errorf("%s key: err = %v, want %v", value, err, want)
+		}
+	}
+	if repo.rows["expired"].LastUsed != 0 || repo.rows["revoked"].LastUsed != 0 {
+		t.Fatal("rejected key recorded as used")
+	}
+}
+
+func TestRevokedApiKeyStopsValidating(t *testing.T) {
+	s, repo := newApiKeyService()
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+
+	revoked, err := s.RevokeApiKey(ctx, "k1")
+	if err != nil || !revoked.IsRevoked() {
+		t.Fatalf("RevokeApiKey = %+v, %v", revoked, err)
+	}
+	if _, err := s.ValidateApiKey(ctx, "valid"); !errors.Is(err, structs.ErrApiKeyRevoked) {
+		t.Fatalf("err = %v, want ErrApiKeyRevoked", err)
+	}
+
+	// revoking again keeps the first revocation time
+	first := repo.rows["valid"].RevokedAt
+	if _, err := s.RevokeApiKey(ctx, "k1"); err != nil || repo.rows["valid"].RevokedAt != first {
+		t.Fatalf("second revoke changed the time to %d, %v", repo.rows["valid"].RevokedAt, err)
+	}
+
+	other := ctxutil.SetUserID(context.Background(), "u2")
+	if _, err := s.RevokeApiKey(other, "k2"); err == nil {
+		t.Fatal("another user revoked the key")
+	}
+}
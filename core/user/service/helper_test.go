@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ncobase/core/user/data/repository"
+	"ncobase/internal/dberr"
+
+	"github.com/ncobase/ncore/ecode"
+)
+
+func TestHandleEntErrorKeepsNotFoundTyped(t *testing.T) {
+	ctx := context.Background()
+
+	err := handleEntError(ctx, "User", repository.ErrNotFound)
+	if !dberr.IsNotFound(err) || err.Error() != ecode.NotExist("User") {
+		t.Fatalf("missing row = %v, want a typed %q", err, ecode.NotExist("User"))
+	}
+
+	failure := errors.New("driver: bad connection")
+	if err := handleEntError(ctx, "User", failure); err != failure || dberr.IsNotFound(err) {
+		t.Fatalf("driver failure = %v, want it unchanged", err)
+	}
+	if err := handleEntError(ctx, "User", nil); err != nil {
+		t.Fatalf("no error = %v", err)
+	}
+}
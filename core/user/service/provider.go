@@ -16,6 +16,7 @@ type Service struct {
 	ApiKey      ApiKeyServiceInterface
 	UserMeshes  UserMeshesServiceInterface
 	Events      event.PublisherInterface
+	SearchIndex *SearchIndexer
 }
 
 // New creates a new service.
@@ -37,5 +38,6 @@ func New(em ext.ManagerInterface, d *data.Data) *Service {
 		ApiKey:      apiKeyService,
 		UserMeshes:  userMeshesService,
 		Events:      ep,
+		SearchIndex: NewSearchIndexer(repo),
 	}
 }
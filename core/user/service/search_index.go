@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"ncobase/core/user/data/repository"
+	"ncobase/internal/eventlog"
+	"time"
+
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// SearchIndexConsumer is the event log consumer keeping the users search index in line
+const SearchIndexConsumer = "user.search_index"
+
+// searchIndexInterval is how often the indexer replays the user events logged since its checkpoint
+const searchIndexInterval = 10 * time.Second
+
+// searchIndexEvents are the user events after which the search document of the user is synced
+var searchIndexEvents = []string{"user.created", "user.updated", "user.deleted", "user.status_updated"}
+
+// EventLog is the part of the durable event log the search indexer reads
+type EventLog interface {
+	Durable(eventType string) bool
+	Replay(ctx context.Context, consumer, eventType string, h eventlog.Handler) (int, error)
+}
+
+// SearchIndexer syncs the users search index from the durable event log. The repository
+// indexes users as they are written, but those updates are lost while the search engine is
+// down; the indexer replays the user events from its checkpoint and syncs them once it is back.
+type SearchIndexer struct {
+	user repository.UserRepositoryInterface
+}
+
+// NewSearchIndexer creates a new search indexer
+func NewSearchIndexer(repo *repository.Repository) *SearchIndexer {
+	return &SearchIndexer{user: repo.User}
+}
+
+// Start replays the logged user events every searchIndexInterval until ctx is done.
+// It does nothing when none of the user events are durable.
+func (i *SearchIndexer) Start(ctx context.Context, log EventLog) {
+	durable := false
+	for _, eventType := range searchIndexEvents {
+		durable = durable || log.Durable(eventType)
+	}
+	if !durable {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(searchIndexInterval)
+		defer ticker.Stop()
+		for {
+			if _, err := i.Sync(ctx, log); err != nil && ctx.Err() == nil {
+				logger.Warnf(ctx, "Users search index is behind the event log, retrying: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Sync replays the durable user events logged since the checkpoint of the indexer and syncs
+// the users they are about. An event that fails to sync stops its type, it is replayed by the
+// next sync. It returns the events synced.
+func (i *SearchIndexer) Sync(ctx context.Context, log EventLog) (int, error) {
+	synced := 0
+	var errs []error
+	for _, eventType := range searchIndexEvents {
+		if !log.Durable(eventType) {
+			continue
+		}
+		n, err := log.Replay(ctx, SearchIndexConsumer, eventType, i.Handle)
+		synced += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return synced, errors.Join(errs...)
+}
+
+// Handle syncs the search document of the user an event is about. The user is read as they are
+// now, so an event delivered twice or after a later one leaves the same document.
+func (i *SearchIndexer) Handle(ctx context.Context, entry *eventlog.Entry) error {
+	var data struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.Unmarshal(entry.Data, &data); err != nil || data.UserID == "" {
+		logger.Warnf(ctx, "Skipping %s event %s without user", entry.EventType, entry.ID)
+		return nil
+	}
+	return i.user.SyncIndex(ctx, data.UserID)
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"ncobase/core/user/data/repository"
+	"ncobase/internal/eventlog"
+)
+
+// memoryLog keeps durable events in memory, a consumer replays them from its own checkpoint
+// and the checkpoint only moves past an event once it was handled
+type memoryLog struct {
+	entries     map[string][]*eventlog.Entry
+	checkpoints map[string]int
+}
+
+func newMemoryLog(types ...string) *memoryLog {
+	l := &memoryLog{entries: map[string][]*eventlog.Entry{}, checkpoints: map[string]int{}}
+	for _, eventType := range types {
+		l.entries[eventType] = nil
+	}
+	return l
+}
+
+func (l *memoryLog) Durable(eventType string) bool {
+	_, ok := l.entries[eventType]
+	return ok
+}
+
+func (l *memoryLog) publish(t *testing.T, eventType, userID string) {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{"user_id": userID})
+	if err != nil {
+		t.Fatalf("encode event: %v", err)
+	}
+	id := fmt.Sprintf("%d-0", len(l.entries[eventType])+1)
+	l.entries[eventType] = append(l.entries[eventType], &eventlog.Entry{ID: id, EventType: eventType, Data: data})
+}
+
+func (l *memoryLog) Replay(ctx context.Context, consumer, eventType string, h eventlog.Handler) (int, error) {
+	key := consumer + ":" + eventType
+	handled := 0
+	for _, entry := range l.entries[eventType][l.checkpoints[key]:] {
+		if err := h(ctx, entry); err != nil {
+			return handled, err
+		}
+		l.checkpoints[key]++
+		handled++
+	}
+	return handled, nil
+}
+
+// indexedUsers records the users synced to the search index, failing while the index is down
+type indexedUsers struct {
+	repository.UserRepositoryInterface
+	down   bool
+	synced []string
+}
+
+func (r *indexedUsers) SyncIndex(_ context.Context, id string) error {
+	if r.down {
+		return errors.New("search engine unavailable")
+	}
+	r.synced = append(r.synced, id)
+	return nil
+}
+
+func TestSearchIndexerReplaysEventsMissedDuringOutage(t *testing.T) {
+	log := newMemoryLog("user.created", "user.updated")
+	users := &indexedUsers{down: true}
+	indexer := &SearchIndexer{user: users}
+	ctx := context.Background()
+
+	// the search engine is down while users are written
+	log.publish(t, "user.created", "u1")
+	log.publish(t, "user.created", "u2")
+	log.publish(t, "user.updated", "u1")
+	if n, err := indexer.Sync(ctx, log); err == nil || n != 0 {
+		t.Fatalf("Sync during outage = %d, %v, want nothing synced and an error", n, err)
+	}
+
+	users.down = false
+	if n, err := indexer.Sync(ctx, log); err != nil || n != 3 {
+		t.Fatalf("Sync after outage = %d, %v, want the 3 missed events", n, err)
+	}
+	if got := strings.Join(users.synced, ","); got != "u1,u2,u1" {
+		t.Fatalf("synced %s, want every missed event in order", got)
+	}
+
+	// events already synced are not replayed, new ones are
+	log.publish(t, "user.updated", "u2")
+	if n, err := indexer.Sync(ctx, log); err != nil || n != 1 {
+		t.Fatalf("Sync of a new event = %d, %v, want only that event", n, err)
+	}
+}
+
+func TestSearchIndexerSkipsEventsWithoutUser(t *testing.T) {
+	users := &indexedUsers{}
+	indexer := &SearchIndexer{user: users}
+
+	entry := &eventlog.Entry{ID: "1-0", EventType: "user.updated", Data: json.RawMessage(`{"details":"User updated"}`)}
+	if err := indexer.Handle(context.Background(), entry); err != nil || len(users.synced) != 0 {
+		t.Fatalf("Handle = %v with %v synced, want the event skipped", err, users.synced)
+	}
+}
@@ -7,13 +7,13 @@ import (
 	"ncobase/core/user/data/repository"
 	"ncobase/core/user/event"
 	"ncobase/core/user/structs"
+	"ncobase/internal/passhash"
 
 	"github.com/ncobase/ncore/ctxutil"
 	"github.com/ncobase/ncore/data/paging"
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/messaging/email"
-	"github.com/ncobase/ncore/security/crypto"
 	"github.com/ncobase/ncore/types"
 	"github.com/ncobase/ncore/utils/nanoid"
 )
@@ -25,6 +25,7 @@ type UserServiceInterface interface {
 	CreateUser(ctx context.Context, body *structs.UserBody) (*structs.ReadUser, error)
 	UpdateUser(ctx context.Context, user string, updates types.JSON) (*structs.ReadUser, error)
 	GetByID(ctx context.Context, u string) (*structs.ReadUser, error)
+	GetByIDs(ctx context.Context, ids []string) ([]*structs.ReadUser, error)
 	Delete(ctx context.Context, u string) error
 	List(ctx context.Context, params *structs.ListUserParams) (paging.Result[*structs.ReadUser], error)
 	FindByID(ctx context.Context, id string) (*structs.ReadUser, error)
@@ -37,19 +38,22 @@ type UserServiceInterface interface {
 	GetUserByUsername(ctx context.Context, username string) (*structs.ReadUser, error)
 	UpdateStatus(ctx context.Context, userID string, status int) (*structs.ReadUser, error)
 	SendPasswordResetEmail(ctx context.Context, userID string) error
+	EraseUserData(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error)
 }
 
 // userService is the struct for the service.
 type userService struct {
-	user repository.UserRepositoryInterface
-	ep   event.PublisherInterface
+	user    repository.UserRepositoryInterface
+	erasure repository.ErasureRepositoryInterface
+	ep      event.PublisherInterface
 }
 
 // NewUserService creates a new service.
 func NewUserService(repo *repository.Repository, ep event.PublisherInterface) UserServiceInterface {
 	return &userService{
-		user: repo.User,
-		ep:   ep,
+		user:    repo.User,
+		erasure: repo.Erasure,
+		ep:      ep,
 	}
 }
 
@@ -105,6 +109,10 @@ func (s *userService) CreateUser(ctx context.Context, body *structs.UserBody) (*
 		return nil, err
 	}
 
+	if s.ep != nil {
+		s.ep.PublishUserCreated(ctx, row.ID, nil)
+	}
+
 	return repository.SerializeUser(row), nil
 }
 
@@ -114,6 +122,11 @@ func (s *userService) UpdateUser(ctx context.Context, u string, updates types.JS
 	if err != nil {
 		return nil, err
 	}
+
+	if s.ep != nil {
+		s.ep.PublishUserUpdated(ctx, user.ID, nil)
+	}
+
 	return repository.SerializeUser(user), nil
 }
 
@@ -127,12 +140,27 @@ func (s *userService) GetByID(ctx context.Context, u string) (*structs.ReadUser,
 	return repository.SerializeUser(row), nil
 }
 
+// GetByIDs retrieves multiple users by their IDs.
+func (s *userService) GetByIDs(ctx context.Context, ids []string) ([]*structs.ReadUser, error) {
+	rows, err := s.user.GetByIDs(ctx, ids)
+	if err := handleEntError(ctx, "User", err); err != nil {
+		return nil, err
+	}
+
+	return repository.SerializeUsers(rows), nil
+}
+
 // Delete deletes a user by their ID.
 func (s *userService) Delete(ctx context.Context, u string) error {
 	err := s.user.Delete(ctx, u)
 	if err := handleEntError(ctx, "User", err); err != nil {
 		return err
 	}
+
+	if s.ep != nil {
+		s.ep.PublishUserDeleted(ctx, u, nil)
+	}
+
 	return nil
 }
 
@@ -177,13 +205,30 @@ func (s *userService) VerifyPassword(ctx context.Context, u string, password str
 		return VerifyPasswordResult{Valid: true, NeedsPasswordSet: true, Error: "user password not set"}
 	}
 
-	if crypto.ComparePassword(user.Password, password) {
+	if ok, rehash := passhash.Verify(user.Password, password); ok {
+		if rehash {
+			s.upgradePasswordHash(ctx, user.ID, password)
+		}
 		return VerifyPasswordResult{Valid: true, NeedsPasswordSet: false, Error: ""}
 	}
 
 	return VerifyPasswordResult{Valid: false, NeedsPasswordSet: false, Error: "wrong password"}
 }
 
+// upgradePasswordHash rehashes a verified password with the current algorithm and settings.
+// A failure is only logged, the old hash still verifies and is upgraded on a later login.
+func (s *userService) upgradePasswordHash(ctx context.Context, userID, password string) {
+	hashedPassword, err := passhash.Hash(ctx, password)
+	if err != nil {
+		return
+	}
+	if err := s.user.UpdatePasswordByID(ctx, userID, hashedPassword); err != nil {
+		logger.Warnf(ctx, "Failed to upgrade password hash of user %s: %v", userID, err)
+		return
+	}
+	logger.Infof(ctx, "Upgraded password hash of user %s to %s", userID, passhash.Current().Algorithm())
+}
+
 // updatePassword update user password
 func (s *userService) updatePassword(ctx context.Context, body *structs.UserPassword) error {
 	err := s.user.UpdatePassword(ctx, body)
@@ -340,7 +385,7 @@ func (s *userService) SendPasswordResetEmail(ctx context.Context, userID string)
 	// Generate a temporary password
 	tempPassword := nanoid.String(12)
 	// Hash the temporary password
-	hashedPassword, err := crypto.HashPassword(ctx, tempPassword)
+	hashedPassword, err := passhash.Hash(ctx, tempPassword)
 	if err != nil {
 		return err
 	}
@@ -393,3 +438,13 @@ func (s *userService) SendPasswordResetEmail(ctx context.Context, userID string)
 // 		RoleID: row.RoleID,
 // 	}
 // }
+
+// EraseUserData deletes a user with their profile, employee record and API keys.
+// With anonymize the account is kept without personal data, so records of other
+// modules still referring to it stay valid.
+func (s *userService) EraseUserData(ctx context.Context, userID string, anonymize bool) (int, int, error) {
+	if userID == "" {
+		return 0, 0, errors.New(ecode.FieldIsInvalid("User ID"))
+	}
+	return s.erasure.Erase(ctx, userID, anonymize)
+}
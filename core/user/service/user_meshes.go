@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"ncobase/core/user/structs"
 
 	"github.com/ncobase/ncore/logging/logger"
@@ -11,6 +13,7 @@ import (
 type UserMeshesServiceInterface interface {
 	GetUserMeshes(ctx context.Context, username string, includeApiKeys bool) (*structs.UserMeshes, error)
 	UpdateUserMeshes(ctx context.Context, username string, updates *structs.UserMeshes) (*structs.UserMeshes, error)
+	ExportUserData(ctx context.Context, userID string, w io.Writer) error
 }
 
 // userMeshesService implements UserMeshesServiceInterface
@@ -229,3 +232,13 @@ func (s *userMeshesService) UpdateUserMeshes(ctx context.Context, username strin
 		Employee: employee,
 	}, nil
 }
+
+// ExportUserData writes the account, profile and employee record of a user to w as a JSON line.
+// API keys are left out, they are credentials rather than personal data.
+func (s *userMeshesService) ExportUserData(ctx context.Context, userID string, w io.Writer) error {
+	meshes, err := s.GetUserMeshes(ctx, userID, false)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(meshes)
+}
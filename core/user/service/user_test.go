@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ncobase/core/user/data/ent"
+	"ncobase/core/user/data/repository"
+	"ncobase/core/user/structs"
+	"ncobase/internal/passhash"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordUsers keeps users by username and counts password updates
+type passwordUsers struct {
+	repository.UserRepositoryInterface
+	rows    map[string]*ent.User
+	updates int
+}
+
+func (r *passwordUsers) FindUser(_ context.Context, filter *structs.FindUser) (*ent.User, error) {
+	if row, ok := r.rows[filter.Username]; ok {
+		return row, nil
+	}
+	return nil, &ent.NotFoundError{}
+}
+
+func (r *passwordUsers) UpdatePasswordByID(_ context.Context, userID, hashedPassword string) error {
+	for _, row := range r.rows {
+		if row.ID == userID {
+			row.Password = hashedPassword
+			r.updates++
+		}
+	}
+	return nil
+}
+
+// withArgon2id makes argon2id, at a cheap cost, the hashing algorithm for the rest of the test
+func withArgon2id(t *testing.T) {
+	t.Helper()
+	previous := passhash.Current()
+	passhash.SetHasher(passhash.NewArgon2id(passhash.Argon2Config{Time: 1, Memory: 1024, Threads: 1}))
+	t.Cleanup(func() { passhash.SetHasher(previous) })
+}
+
+func TestLoginUpgradesOldPasswordHash(t *testing.T) {
+	old, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt: %v", err)
+	}
+	withArgon2id(t)
+	users := &passwordUsers{rows: map[string]*ent.User{"alice": {ID: "u1", Username: "alice", Password: string(old)}}}
+	s := &userService{user: users}
+	ctx := context.Background()
+
+	if res := s.VerifyPassword(ctx, "alice", "wrong").(VerifyPasswordResult); res.Valid || users.updates != 0 {
+		t.Fatalf("wrong password = %+v with %d updates", res, users.updates)
+	}
+	if res := s.VerifyPassword(ctx, "alice", "secret").(VerifyPasswordResult); !res.Valid {
+		t.Fatalf("old hash = %+v, want a valid login", res)
+	}
+	upgraded := users.rows["alice"].Password
+	if users.updates != 1 || !passhash.Current().Identifies(upgraded) {
+		t.Fatalf("stored hash %q after %d updates, want an argon2id hash", upgraded, users.updates)
+	}
+
+	// the upgraded hash authenticates and is current
+	if res := s.VerifyPassword(ctx, "alice", "secret").(VerifyPasswordResult); !res.Valid {
+		t.Fatalf("upgraded hash = %+v, want a valid login", res)
+	}
+	if users.updates != 1 || users.rows["alice"].Password != upgraded {
+		t.Fatalf("current hash rewritten, %d updates", users.updates)
+	}
+}
+
+func TestLoginKeepsCurrentPasswordHash(t *testing.T) {
+	withArgon2id(t)
+	current, err := passhash.Hash(context.Background(), "secret")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	users := &passwordUsers{rows: map[string]*ent.User{"bob": {ID: "u2", Username: "bob", Password: current}}}
+	s := &userService{user: users}
+
+	for i := 0; i < 2; i++ {
+		if res := s.VerifyPassword(context.Background(), "bob", "secret").(VerifyPasswordResult); !res.Valid {
+			t.Fatalf("current hash = %+v, want a valid login", res)
+		}
+	}
+	if users.updates != 0 || users.rows["bob"].Password != current {
+		t.Fatalf("current hash changed after %d updates", users.updates)
+	}
+}
@@ -1,16 +1,43 @@
 package structs
 
+import "errors"
+
+// API key validation errors
+var (
+	ErrApiKeyInvalid = errors.New("invalid API key")
+	ErrApiKeyExpired = errors.New("API key expired")
+	ErrApiKeyRevoked = errors.New("API key revoked")
+)
+
 // ApiKey represents an API key
 type ApiKey struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Key       string `json:"key"`
-	UserID    string `json:"user_id"`
-	CreatedAt int64  `json:"created_at"`
-	LastUsed  *int64 `json:"last_used,omitempty"`
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Key       string   `json:"key"`
+	Prefix    string   `json:"prefix,omitempty"`
+	UserID    string   `json:"user_id"`
+	SpaceID   string   `json:"space_id,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	ExpiredAt *int64   `json:"expired_at,omitempty"`
+	RevokedAt *int64   `json:"revoked_at,omitempty"`
+	CreatedAt int64    `json:"created_at"`
+	LastUsed  *int64   `json:"last_used,omitempty"`
+}
+
+// IsRevoked reports whether the key has been revoked
+func (k *ApiKey) IsRevoked() bool {
+	return k.RevokedAt != nil && *k.RevokedAt > 0
+}
+
+// IsExpired reports whether the key expired before now (unix milliseconds)
+func (k *ApiKey) IsExpired(now int64) bool {
+	return k.ExpiredAt != nil && *k.ExpiredAt > 0 && *k.ExpiredAt <= now
 }
 
 // CreateApiKeyRequest represents a request to create an API key
 type CreateApiKeyRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name      string   `json:"name" validate:"required"`
+	SpaceID   string   `json:"space_id,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	ExpiredAt *int64   `json:"expired_at,omitempty"`
 }
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"fmt"
 	"ncobase/core/user/data"
 	"ncobase/core/user/handler"
@@ -36,6 +37,8 @@ type Module struct {
 	s           *service.Service
 	d           *data.Data
 	cleanup     func(name ...string)
+	// stopIndexer stops the search indexer started in PostInit
+	stopIndexer context.CancelFunc
 
 	discovery
 }
@@ -90,6 +93,15 @@ func (m *Module) PostInit() error {
 	m.s = service.New(m.em, m.d)
 	m.h = handler.New(m.s)
 
+	// Keep the search index in line from the durable event log, when it is enabled
+	if svc, err := m.em.GetCrossService("eventlog", "Log"); err == nil {
+		if log, ok := svc.(service.EventLog); ok {
+			var ctx context.Context
+			ctx, m.stopIndexer = context.WithCancel(context.Background())
+			m.s.SearchIndex.Start(ctx, log)
+		}
+	}
+
 	return nil
 }
 
@@ -124,6 +136,7 @@ func (m *Module) RegisterRoutes(r *gin.RouterGroup) {
 		users.POST("/api-keys", middleware.HasPermission("create:users"), m.h.ApiKey.GenerateApiKey)
 		users.GET("/api-keys/:id", middleware.HasPermission("read:users"), m.h.ApiKey.GetApiKey)
 		users.DELETE("/api-keys/:id", middleware.HasPermission("delete:users"), m.h.ApiKey.DeleteApiKey)
+		users.POST("/api-keys/:id/revoke", middleware.HasPermission("update:users"), m.h.ApiKey.RevokeApiKey)
 		users.GET("/:username/meshes", middleware.HasAnyPermission("read:users", "manage:profile"), m.h.UserMeshes.GetUserMeshes)
 		users.PUT("/:username/meshes", middleware.HasAnyPermission("update:users", "manage:profile"), m.h.UserMeshes.UpdateUserMeshes)
 	}
@@ -153,6 +166,9 @@ func (m *Module) GetServices() ext.Service {
 
 // Cleanup cleans up the module
 func (m *Module) Cleanup() error {
+	if m.stopIndexer != nil {
+		m.stopIndexer()
+	}
 	if m.cleanup != nil {
 		m.cleanup(m.Name())
 	}
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"ncobase/internal/fieldcrypt"
+	"ncobase/internal/identifier"
+	"ncobase/internal/idgen"
+	"ncobase/internal/passhash"
+	"ncobase/internal/slowquery"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ncobase/ncore/config"
+)
+
+// Command is a maintenance task run from the CLI instead of the server,
+// e.g. `ncobase reconcile-storage --prefix=files/ --dry-run`.
+type Command struct {
+	Name  string
+	Usage string
+	Run   func(ctx context.Context, conf *config.Config, args []string) error
+}
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[string]*Command)
+)
+
+// Register adds a command, usually from an init function.
+// Registering the same name twice panics.
+func Register(cmd *Command) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, exists := registry[cmd.Name]; exists {
+		panic(fmt.Sprintf("command %s already registered", cmd.Name))
+	}
+	registry[cmd.Name] = cmd
+}
+
+// Lookup returns the command registered under name
+func Lookup(name string) (*Command, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	cmd, ok := registry[name]
+	return cmd, ok
+}
+
+// Run dispatches args[0] to its command with the remaining args
+func Run(ctx context.Context, conf *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no command given")
+	}
+
+	cmd, ok := Lookup(args[0])
+	if !ok {
+		return fmt.Errorf("unknown command %q, available: %s", args[0], strings.Join(Names(), ", "))
+	}
+
+	// Commands read and write through the same repositories as the server
+	if err := fieldcrypt.Setup(conf); err != nil {
+		return fmt.Errorf("field encryption: %w", err)
+	}
+	if err := idgen.Setup(conf); err != nil {
+		return fmt.Errorf("ID generation: %w", err)
+	}
+	if err := passhash.Setup(conf); err != nil {
+		return fmt.Errorf("password hashing: %w", err)
+	}
+	if err := identifier.Setup(conf); err != nil {
+		return fmt.Errorf("identifier normalization: %w", err)
+	}
+	slowquery.Setup(conf)
+
+	return cmd.Run(ctx, conf, args[1:])
+}
+
+// Names returns the registered command names in order
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ncobase/ncore/config"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is the prefix of environment overrides, e.g. NCOBASE_DATA_REDIS_ADDR
+const EnvPrefix = "NCOBASE"
+
+// BindEnv enables environment overrides on v.
+// Nested keys map to upper case names joined by underscores,
+// so data.redis.addr is read from NCOBASE_DATA_REDIS_ADDR.
+func BindEnv(v *viper.Viper) {
+	if v == nil {
+		return
+	}
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	v.AutomaticEnv()
+}
+
+// Load loads the configuration file and applies environment overrides on top.
+// Extensions reading their sub-config through conf.Viper see the overrides too.
+func Load() (*config.Config, error) {
+	conf, err := config.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-read with env binding so typed fields pick up overrides
+	BindEnv(conf.Viper)
+	if err := config.Reload(); err != nil {
+		return nil, fmt.Errorf("failed to apply environment overrides: %w", err)
+	}
+
+	return config.GetConfig()
+}
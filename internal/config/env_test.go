@@ -0,0 +1,57 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const fileConfig = `
+server:
+  port: 3000
+data:
+  redis:
+    addr: file-redis:6379
+  database:
+    master:
+      driver: postgres
+      source: postgres://file/ncobase
+auth:
+  jwt:
+    secret: file-secret
+`
+
+func TestLoadAppliesEnvironmentOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(fileConfig), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := flag.Set("conf", path); err != nil {
+		t.Fatalf("set -conf: %v", err)
+	}
+	t.Setenv("NCOBASE_SERVER_PORT", "4000")
+	t.Setenv("NCOBASE_DATA_REDIS_ADDR", "env-redis:6379")
+	t.Setenv("NCOBASE_AUTH_JWT_SECRET", "env-secret")
+
+	conf, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if conf.Port != 4000 {
+		t.Errorf("port = %d, want the override 4000", conf.Port)
+	}
+	if conf.Data.Redis.Addr != "env-redis:6379" {
+		t.Errorf("redis addr = %q, want the override", conf.Data.Redis.Addr)
+	}
+	if conf.Auth.JWT.Secret != "env-secret" {
+		t.Errorf("jwt secret = %q, want the override", conf.Auth.JWT.Secret)
+	}
+	if conf.Data.Database.Master.Source != "postgres://file/ncobase" {
+		t.Errorf("database source = %q, want the file value without an override", conf.Data.Database.Master.Source)
+	}
+	// extensions reading their own keys see the overrides too
+	if got := conf.Viper.GetString("data.redis.addr"); got != "env-redis:6379" {
+		t.Errorf("viper data.redis.addr = %q, want the override", got)
+	}
+}
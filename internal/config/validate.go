@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ncobase/ncore/config"
+)
+
+// ValidationError aggregates every problem found in the configuration
+type ValidationError struct {
+	Problems []string
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration (%d problems):\n  - %s", len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+// add records a problem
+func (e *ValidationError) add(format string, args ...any) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
+}
+
+// Validate checks required settings and reports all problems at once,
+// so a missing dependency fails at startup instead of as a late runtime panic.
+func Validate(conf *config.Config) error {
+	if conf == nil {
+		return &ValidationError{Problems: []string{"configuration is nil"}}
+	}
+
+	verr := &ValidationError{}
+
+	// Server
+	if conf.Port < 0 || conf.Port > 65535 {
+		verr.add("server.port %d is out of range (0-65535)", conf.Port)
+	}
+
+	// Data
+	if conf.Data == nil {
+		verr.add("data section is required")
+	} else {
+		if conf.Data.Database == nil || conf.Data.Database.Master == nil {
+			verr.add("data.database.master is required")
+		} else {
+			if conf.Data.Database.Master.Driver == "" {
+				verr.add("data.database.master.driver is required")
+			}
+			if conf.Data.Database.Master.Source == "" {
+				verr.add("data.database.master.source is required")
+			}
+		}
+		if conf.Data.Database != nil {
+			for i, slave := range conf.Data.Database.Slaves {
+				if slave == nil || slave.Source == "" {
+					verr.add("data.database.slaves[%d].source is required", i)
+				}
+			}
+		}
+
+		// Repositories cache through redis and sessions are stored there
+		if conf.Data.Redis == nil || conf.Data.Redis.Addr == "" {
+			verr.add("data.redis.addr is required")
+		}
+	}
+
+	// Auth
+	if conf.Auth == nil || conf.Auth.JWT == nil || conf.Auth.JWT.Secret == "" {
+		verr.add("auth.jwt.secret is required")
+	}
+	if conf.Auth != nil && conf.Auth.MaxSessions < 0 {
+		verr.add("auth.max_sessions must not be negative")
+	}
+
+	// Storage
+	if conf.Storage == nil {
+		verr.add("storage section is required")
+	} else if err := conf.Storage.Validate(); err != nil {
+		verr.add("storage: %v", err)
+	}
+
+	if len(verr.Problems) > 0 {
+		return verr
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ncobase/ncore/config"
+	dc "github.com/ncobase/ncore/data/config"
+	"github.com/ncobase/ncore/oss"
+)
+
+func validConfig() *config.Config {
+	return &config.Config{
+		Port: 3000,
+		Data: &config.Data{
+			Database: &dc.Database{Master: &dc.DBNode{Driver: "postgres", Source: "postgres://localhost/ncobase"}},
+			Redis:    &dc.Redis{Addr: "localhost:6379"},
+		},
+		Auth:    &config.Auth{JWT: &config.JWT{Secret: "secret"}},
+		Storage: &oss.Config{Provider: "filesystem"},
+	}
+}
+
+func TestValidateAcceptsValidConfig(t *testing.T) {
+	if err := Validate(validConfig()); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	conf := validConfig()
+	conf.Port = 70000
+	conf.Data.Database.Master.Source = ""
+	conf.Data.Database.Slaves = []*dc.DBNode{{Driver: "postgres"}}
+	conf.Data.Redis = nil
+	conf.Auth.JWT.Secret = ""
+	conf.Auth.MaxSessions = -1
+	conf.Storage = &oss.Config{Provider: "s3"}
+
+	err := Validate(conf)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Validate = %v, want a ValidationError", err)
+	}
+	want := []string{
+		"server.port 70000 is out of range (0-65535)",
+		"data.database.master.source is required",
+		"data.database.slaves[0].source is required",
+		"data.redis.addr is required",
+		"auth.jwt.secret is required",
+		"auth.max_sessions must not be negative",
+		"storage: id, secret, and bucket are required for AWS S3",
+	}
+	if len(verr.Problems) != len(want) {
+		t.Fatalf("problems = %q, want %q", verr.Problems, want)
+	}
+	for i, problem := range want {
+		if verr.Problems[i] != problem {
+			t.Errorf("problem %d = %q, want %q", i, verr.Problems[i], problem)
+		}
+	}
+}
+
+func TestValidateMissingSections(t *testing.T) {
+	err := Validate(&config.Config{})
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Problems) != 3 {
+		t.Fatalf("empty config = %v, want data, auth and storage reported", err)
+	}
+	if err := Validate(nil); err == nil {
+		t.Fatal("nil config accepted")
+	}
+}
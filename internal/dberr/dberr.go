@@ -0,0 +1,39 @@
+package dberr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned by repositories when the row asked for does not exist.
+// The store error stays in the chain, errors.As still finds it.
+var ErrNotFound = errors.New("not found")
+
+// Map returns err marked as ErrNotFound when isNotFound reports a missing row,
+// and any other error, or nil, unchanged.
+func Map(err error, isNotFound func(error) bool) error {
+	if err == nil || errors.Is(err, ErrNotFound) || !isNotFound(err) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ErrNotFound, err)
+}
+
+// NotFound returns an error with msg matching ErrNotFound, for layers that replace
+// the message of a not-found error but must keep it distinguishable
+func NotFound(msg string) error {
+	return &notFoundError{msg: msg}
+}
+
+// notFoundError is a not-found error with a message of its own
+type notFoundError struct {
+	msg string
+}
+
+func (e *notFoundError) Error() string { return e.msg }
+
+func (e *notFoundError) Unwrap() error { return ErrNotFound }
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
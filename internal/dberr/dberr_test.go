@@ -0,0 +1,53 @@
+package dberr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// rowMissing stands in for the not-found error of a store
+type rowMissing struct{}
+
+func (rowMissing) Error() string { return "row missing" }
+
+func isRowMissing(err error) bool {
+	var missing rowMissing
+	return errors.As(err, &missing)
+}
+
+func TestMapMarksMissingRows(t *testing.T) {
+	err := Map(fmt.Errorf("query user: %w", rowMissing{}), isRowMissing)
+	if !IsNotFound(err) || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("mapped error %v does not match ErrNotFound", err)
+	}
+	var missing rowMissing
+	if !errors.As(err, &missing) {
+		t.Fatalf("mapped error %v lost the store error", err)
+	}
+
+	// mapping twice keeps one marker
+	if again := Map(err, isRowMissing); again != err {
+		t.Fatalf("mapped again = %v, want %v unchanged", again, err)
+	}
+}
+
+func TestMapPassesOtherErrorsThrough(t *testing.T) {
+	failure := errors.New("driver: bad connection")
+	if err := Map(failure, isRowMissing); err != failure || IsNotFound(err) {
+		t.Fatalf("mapped failure = %v, want it unchanged", err)
+	}
+	if err := Map(nil, isRowMissing); err != nil {
+		t.Fatalf("mapped nil = %v", err)
+	}
+}
+
+func TestNotFoundKeepsMessage(t *testing.T) {
+	err := NotFound("user does not exist")
+	if err.Error() != "user does not exist" || !IsNotFound(err) {
+		t.Fatalf("NotFound = %q, not found %v", err, IsNotFound(err))
+	}
+	if IsNotFound(errors.New("user does not exist")) {
+		t.Fatal("a plain error with the same message matches ErrNotFound")
+	}
+}
@@ -0,0 +1,100 @@
+package drain
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/net/resp"
+	"github.com/spf13/viper"
+)
+
+// DefaultTimeout bounds the drain when server.drain_timeout is not set
+const DefaultTimeout = 30 * time.Second
+
+// drainingMessage is returned to requests arriving while the server drains
+const drainingMessage = "Server is shutting down, please retry"
+
+var (
+	draining atomic.Bool
+
+	mu    sync.Mutex
+	hooks = map[int]func(){}
+	next  int
+)
+
+// Timeout returns how long in-flight requests may take to finish on shutdown, from server.drain_timeout
+func Timeout(v *viper.Viper) time.Duration {
+	if v != nil {
+		if d := v.GetDuration("server.drain_timeout"); d > 0 {
+			return d
+		}
+	}
+	return DefaultTimeout
+}
+
+// OnDrain registers f to run when the drain begins, e.g. to close long-lived connections
+// the HTTP server does not track. The returned func unregisters f.
+func OnDrain(f func()) func() {
+	mu.Lock()
+	defer mu.Unlock()
+	id := next
+	next++
+	hooks[id] = f
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(hooks, id)
+	}
+}
+
+// Begin starts the drain: new requests are refused and the drain hooks run, in-flight
+// requests carry on. It runs once, later calls do nothing.
+func Begin() {
+	if !draining.CompareAndSwap(false, true) {
+		return
+	}
+
+	mu.Lock()
+	fs := make([]func(), 0, len(hooks))
+	for _, f := range hooks {
+		fs = append(fs, f)
+	}
+	mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, f := range fs {
+		wg.Add(1)
+		go func(f func()) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Errorf(context.Background(), "Drain hook panicked: %v", r)
+				}
+			}()
+			f()
+		}(f)
+	}
+	wg.Wait()
+}
+
+// Draining reports whether the drain has begun
+func Draining() bool {
+	return draining.Load()
+}
+
+// Middleware refuses requests with 503 once the drain has begun, so requests arriving on
+// kept-alive connections do not start work the shutdown would cut off
+func Middleware(c *gin.Context) {
+	if !draining.Load() {
+		c.Next()
+		return
+	}
+	c.Header("Connection", "close")
+	c.Header("Retry-After", "1")
+	resp.Fail(c.Writer, resp.ServiceUnavailable(drainingMessage))
+	c.Abort()
+}
@@ -0,0 +1,123 @@
+package drain
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// reset ends the drain so the next test starts serving again
+func reset(t *testing.T) {
+	t.Cleanup(func() { draining.Store(false) })
+}
+
+func TestDrainFinishesInFlightRequests(t *testing.T) {
+	reset(t)
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Middleware)
+	started, release := make(chan struct{}), make(chan struct{})
+	engine.POST("/upload", func(c *gin.Context) {
+		close(started)
+		<-release
+		c.String(http.StatusOK, "stored")
+	})
+	engine.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := &http.Server{Handler: engine}
+	go srv.Serve(ln)
+	base := "http://" + ln.Addr().String()
+
+	// a slow upload is in flight when the drain begins
+	uploaded := make(chan string, 1)
+	go func() {
+		res, err := http.Post(base+"/upload", "text/plain", nil)
+		if err != nil {
+			uploaded <- err.Error()
+			return
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		uploaded <- res.Status + " " + string(body)
+	}()
+	<-started
+	Begin()
+
+	// requests arriving during the drain are refused
+	res, err := http.Get(base + "/ping")
+	if err != nil {
+		t.Fatalf("request during the drain: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusServiceUnavailable || res.Header.Get("Retry-After") == "" {
+		t.Fatalf("request during the drain: %s, want 503 with Retry-After", res.Status)
+	}
+
+	shutdown := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdown <- srv.Shutdown(ctx)
+	}()
+
+	// once the listener is closed new connections are refused outright
+	deadline := time.Now().Add(time.Second)
+	for {
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			break
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("listener still accepting during shutdown")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	close(release)
+	if got := <-uploaded; got != "200 OK stored" {
+		t.Fatalf("in-flight upload = %q, want it completed", got)
+	}
+	if err := <-shutdown; err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+func TestBeginRunsHooksOnce(t *testing.T) {
+	reset(t)
+	var closed, removed atomic.Int32
+	defer OnDrain(func() { closed.Add(1) })()
+	OnDrain(func() { removed.Add(1) })()
+	defer OnDrain(func() { panic("close failed") })()
+
+	if Draining() {
+		t.Fatal("draining before Begin")
+	}
+	Begin()
+	Begin()
+	if !Draining() || closed.Load() != 1 || removed.Load() != 0 {
+		t.Fatalf("draining %v, hook ran %d times, removed hook %d times", Draining(), closed.Load(), removed.Load())
+	}
+}
+
+func TestTimeoutFromConfig(t *testing.T) {
+	if got := Timeout(nil); got != DefaultTimeout {
+		t.Fatalf("default timeout %s", got)
+	}
+	v := viper.New()
+	v.Set("server.drain_timeout", "45s")
+	if got := Timeout(v); got != 45*time.Second {
+		t.Fatalf("configured timeout %s", got)
+	}
+}
@@ -0,0 +1,49 @@
+package eventlog
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Event log defaults
+const (
+	DefaultMaxLen       = 100000
+	DefaultProcessedTTL = 7 * 24 * time.Hour
+	DefaultBatchSize    = 100
+)
+
+// Config configures the durable event log.
+// Durability is opt-in per event type, events not listed are never persisted.
+type Config struct {
+	Enabled      bool
+	Types        []string      // Event types persisted to the log
+	MaxLen       int64         // Approximate entries kept per event type
+	ProcessedTTL time.Duration // How long idempotency keys are remembered per consumer
+}
+
+// DefaultConfig returns the default event log config, disabled
+func DefaultConfig() *Config {
+	return &Config{
+		MaxLen:       DefaultMaxLen,
+		ProcessedTTL: DefaultProcessedTTL,
+	}
+}
+
+// FromViper reads the event log config from events.log.*
+func FromViper(v *viper.Viper) *Config {
+	cfg := DefaultConfig()
+	if v == nil {
+		return cfg
+	}
+
+	cfg.Enabled = v.GetBool("events.log.enabled")
+	cfg.Types = v.GetStringSlice("events.log.types")
+	if v.IsSet("events.log.max_len") {
+		cfg.MaxLen = v.GetInt64("events.log.max_len")
+	}
+	if v.IsSet("events.log.processed_ttl") {
+		cfg.ProcessedTTL = time.Duration(v.GetInt("events.log.processed_ttl")) * time.Second
+	}
+	return cfg
+}
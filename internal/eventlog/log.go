@@ -0,0 +1,282 @@
+package eventlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	ext "github.com/ncobase/ncore/extension/types"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	streamKey     = "ncse_events:log:%s"           // Stream of persisted events per event type
+	checkpointKey = "ncse_events:checkpoint:%s:%s" // Last entry a consumer handled, per event type
+	processedKey  = "ncse_events:processed:%s:%s"  // Idempotency keys a consumer already handled
+
+	// retryDelay is the pause before a failed entry is delivered again
+	retryDelay = 5 * time.Second
+	// pollBlock is how long a consumer waits for new entries before checking again
+	pollBlock = 5 * time.Second
+)
+
+// ErrNotDurable is returned for event types that are not configured as durable
+var ErrNotDurable = errors.New("event type is not durable")
+
+// Entry is a persisted event
+type Entry struct {
+	ID        string          `json:"id"`  // Stream entry ID, ordered
+	Key       string          `json:"key"` // Idempotency key, stable across redeliveries
+	EventType string          `json:"event_type"`
+	Source    string          `json:"source"`
+	Time      time.Time       `json:"time"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Handler handles a replayed entry, returning an error keeps the entry for redelivery
+type Handler func(ctx context.Context, entry *Entry) error
+
+// Log persists published events and replays them from per consumer checkpoints.
+//
+// Delivery is at-least-once: a checkpoint only moves past an entry after its
+// handler succeeded. Handled idempotency keys are remembered per consumer so
+// an entry is not handed to the same consumer twice while the key is kept.
+type Log struct {
+	rc      *redis.Client
+	cfg     *Config
+	durable map[string]struct{}
+}
+
+// New creates a new event log
+func New(rc *redis.Client, cfg *Config) *Log {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	durable := make(map[string]struct{}, len(cfg.Types))
+	for _, t := range cfg.Types {
+		if t = strings.TrimSpace(t); t != "" {
+			durable[t] = struct{}{}
+		}
+	}
+	return &Log{rc: rc, cfg: cfg, durable: durable}
+}
+
+// Durable reports whether events of the type are persisted
+func (l *Log) Durable(eventType string) bool {
+	_, ok := l.durable[eventType]
+	return ok
+}
+
+// Attach subscribes the log to every durable event type on the extension event bus
+func (l *Log) Attach(em ext.ManagerInterface) {
+	for eventType := range l.durable {
+		em.SubscribeEvent(eventType, func(data any) {
+			if _, err := l.Append(context.Background(), eventType, data); err != nil {
+				logger.Errorf(context.Background(), "Failed to persist event %s: %v", eventType, err)
+			}
+		})
+	}
+}
+
+// Append persists an event and returns its entry
+func (l *Log) Append(ctx context.Context, eventType string, data any) (*Entry, error) {
+	if !l.Durable(eventType) {
+		return nil, ErrNotDurable
+	}
+
+	entry := &Entry{
+		Key:       uuid.NewString(),
+		EventType: eventType,
+		Time:      time.Now(),
+	}
+	switch ed := data.(type) {
+	case ext.EventData:
+		entry.Source, entry.Time, data = ed.Source, ed.Time, ed.Data
+	case *ext.EventData:
+		if ed != nil {
+			entry.Source, entry.Time, data = ed.Source, ed.Time, ed.Data
+		}
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event %s: %w", eventType, err)
+	}
+	entry.Data = payload
+
+	id, err := l.rc.XAdd(ctx, &redis.XAddArgs{
+		Stream: fmt.Sprintf(streamKey, eventType),
+		MaxLen: l.cfg.MaxLen,
+		Approx: true,
+		Values: map[string]any{
+			"key":    entry.Key,
+			"source": entry.Source,
+			"time":   entry.Time.UnixMilli(),
+			"data":   string(payload),
+		},
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	entry.ID = id
+	return entry, nil
+}
+
+// Checkpoint returns the last entry ID a consumer handled, empty when it never ran
+func (l *Log) Checkpoint(ctx context.Context, consumer, eventType string) (string, error) {
+	id, err := l.rc.Get(ctx, fmt.Sprintf(checkpointKey, consumer, eventType)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return id, err
+}
+
+// SetCheckpoint moves the checkpoint of a consumer, e.g. to skip a backlog
+func (l *Log) SetCheckpoint(ctx context.Context, consumer, eventType, id string) error {
+	return l.rc.Set(ctx, fmt.Sprintf(checkpointKey, consumer, eventType), id, 0).Err()
+}
+
+// Replay hands every entry after the consumer checkpoint to the handler, in order.
+// It stops at the first handler error, the failed entry is replayed on the next call.
+func (l *Log) Replay(ctx context.Context, consumer, eventType string, h Handler) (int, error) {
+	if !l.Durable(eventType) {
+		return 0, ErrNotDurable
+	}
+
+	last, err := l.Checkpoint(ctx, consumer, eventType)
+	if err != nil {
+		return 0, err
+	}
+
+	stream := fmt.Sprintf(streamKey, eventType)
+	handled := 0
+	for {
+		start := "-"
+		if last != "" {
+			start = "(" + last
+		}
+
+		messages, err := l.rc.XRangeN(ctx, stream, start, "+", DefaultBatchSize).Result()
+		if err != nil {
+			return handled, err
+		}
+
+		for _, msg := range messages {
+			if err := l.deliver(ctx, consumer, eventType, msg, h); err != nil {
+				return handled, err
+			}
+			last = msg.ID
+			if err := l.SetCheckpoint(ctx, consumer, eventType, last); err != nil {
+				return handled, err
+			}
+			handled++
+		}
+
+		if len(messages) < DefaultBatchSize {
+			return handled, nil
+		}
+	}
+}
+
+// Consume replays the backlog of a consumer and keeps delivering new entries until ctx is done.
+// Failed entries are retried after a delay.
+func (l *Log) Consume(ctx context.Context, consumer, eventType string, h Handler) error {
+	if !l.Durable(eventType) {
+		return ErrNotDurable
+	}
+
+	stream := fmt.Sprintf(streamKey, eventType)
+	for {
+		if _, err := l.Replay(ctx, consumer, eventType, h); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Warnf(ctx, "Event consumer %s failed on %s, retrying: %v", consumer, eventType, err)
+			if !sleep(ctx, retryDelay) {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		last, err := l.Checkpoint(ctx, consumer, eventType)
+		if err != nil || last == "" {
+			last = "0"
+		}
+
+		// Wait for entries after the checkpoint, they are delivered by the next replay
+		_, err = l.rc.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{stream, last},
+			Count:   1,
+			Block:   pollBlock,
+		}).Result()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && !errors.Is(err, redis.Nil) {
+			logger.Warnf(ctx, "Event consumer %s failed to poll %s: %v", consumer, eventType, err)
+			if !sleep(ctx, retryDelay) {
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// deliver hands one entry to the handler unless the consumer already handled its idempotency key
+func (l *Log) deliver(ctx context.Context, consumer, eventType string, msg redis.XMessage, h Handler) error {
+	entry := toEntry(eventType, msg)
+
+	key := fmt.Sprintf(processedKey, consumer, entry.Key)
+	if n, err := l.rc.Exists(ctx, key).Result(); err == nil && n > 0 {
+		return nil
+	}
+
+	if err := h(ctx, entry); err != nil {
+		return fmt.Errorf("entry %s: %w", entry.ID, err)
+	}
+
+	if err := l.rc.Set(ctx, key, entry.ID, l.cfg.ProcessedTTL).Err(); err != nil {
+		logger.Warnf(ctx, "Failed to record event %s handled by %s: %v", entry.Key, consumer, err)
+	}
+	return nil
+}
+
+// toEntry converts a stream message to an entry
+func toEntry(eventType string, msg redis.XMessage) *Entry {
+	entry := &Entry{ID: msg.ID, EventType: eventType}
+	if v, ok := msg.Values["key"].(string); ok {
+		entry.Key = v
+	}
+	if entry.Key == "" {
+		entry.Key = msg.ID
+	}
+	if v, ok := msg.Values["source"].(string); ok {
+		entry.Source = v
+	}
+	if v, ok := msg.Values["time"].(string); ok {
+		if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+			entry.Time = time.UnixMilli(ms)
+		}
+	}
+	if v, ok := msg.Values["data"].(string); ok {
+		entry.Data = json.RawMessage(v)
+	}
+	return entry
+}
+
+// sleep waits for d, returning false when ctx is done first
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
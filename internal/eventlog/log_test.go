@@ -0,0 +1,210 @@
+package eventlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ext "github.com/ncobase/ncore/extension/types"
+	"github.com/redis/go-redis/v9"
+)
+
+// memoryRedis answers the stream and key commands of the log from memory, in place of a Redis server
+type memoryRedis struct {
+	mu      sync.Mutex
+	seq     int
+	streams map[string][]redis.XMessage
+	keys    map[string]string
+}
+
+func (m *memoryRedis) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (m *memoryRedis) ProcessHook(_ redis.ProcessHook) redis.ProcessHook {
+	return func(_ context.Context, cmd redis.Cmder) error {
+		err := m.process(cmd)
+		cmd.SetErr(err)
+		return err
+	}
+}
+
+func (m *memoryRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+// seqOf returns the sequence of a stream entry ID
+func seqOf(id string) int {
+	n, _ := strconv.Atoi(strings.SplitN(id, "-", 2)[0])
+	return n
+}
+
+func (m *memoryRedis) process(cmd redis.Cmder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	args := cmd.Args()
+	switch strings.ToLower(cmd.Name()) {
+	case "xadd":
+		i := 2
+		for fmt.Sprint(args[i]) != "*" {
+			i++
+		}
+		values := map[string]any{}
+		for i++; i+1 < len(args); i += 2 {
+			values[fmt.Sprint(args[i])] = fmt.Sprint(args[i+1])
+		}
+		m.seq++
+		id := fmt.Sprintf("%d-0", m.seq)
+		stream := args[1].(string)
+		m.streams[stream] = append(m.streams[stream], redis.XMessage{ID: id, Values: values})
+		cmd.(*redis.StringCmd).SetVal(id)
+	case "xrange":
+		start, after := args[2].(string), 0
+		if strings.HasPrefix(start, "(") {
+			after = seqOf(start[1:])
+		}
+		var messages []redis.XMessage
+		for _, msg := range m.streams[args[1].(string)] {
+			if seqOf(msg.ID) > after && int64(len(messages)) < args[5].(int64) {
+				messages = append(messages, msg)
+			}
+		}
+		cmd.(*redis.XMessageSliceCmd).SetVal(messages)
+	case "get":
+		v, ok := m.keys[args[1].(string)]
+		if !ok {
+			return redis.Nil
+		}
+		cmd.(*redis.StringCmd).SetVal(v)
+	case "set":
+		m.keys[args[1].(string)] = fmt.Sprint(args[2])
+	case "exists":
+		var n int64
+		for _, key := range args[1:] {
+			if _, ok := m.keys[key.(string)]; ok {
+				n++
+			}
+		}
+		cmd.(*redis.IntCmd).SetVal(n)
+	default:
+		return fmt.Errorf("unexpected command %s", cmd.Name())
+	}
+	return nil
+}
+
+func newTestLog(types ...string) (*Log, *memoryRedis) {
+	m := &memoryRedis{streams: map[string][]redis.XMessage{}, keys: map[string]string{}}
+	client := redis.NewClient(&redis.Options{Addr: "memory:6379"})
+	client.AddHook(m)
+	cfg := DefaultConfig()
+	cfg.Types = types
+	return New(client, cfg), m
+}
+
+// collect records the data of handled entries
+type collect struct {
+	seen   []string
+	failOn string
+}
+
+func (c *collect) handle(_ context.Context, entry *Entry) error {
+	var data string
+	if err := json.Unmarshal(entry.Data, &data); err != nil {
+		return err
+	}
+	if data == c.failOn {
+		return errors.New("handler failed")
+	}
+	c.seen = append(c.seen, data)
+	return nil
+}
+
+func TestReplayDeliversEventsPublishedWhileOffline(t *testing.T) {
+	log, _ := newTestLog("file.created")
+	ctx := context.Background()
+
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := log.Append(ctx, "file.created", name); err != nil {
+			t.Fatalf("Append(%s): %v", name, err)
+		}
+	}
+
+	// the consumer comes online after the events were published, the second one fails once
+	c := &collect{failOn: "b"}
+	if n, err := log.Replay(ctx, "indexer", "file.created", c.handle); err == nil || n != 1 {
+		t.Fatalf("Replay = %d, %v, want a failure after one entry", n, err)
+	}
+	c.failOn = ""
+	if n, err := log.Replay(ctx, "indexer", "file.created", c.handle); err != nil || n != 2 {
+		t.Fatalf("Replay = %d, %v, want the remaining 2 entries", n, err)
+	}
+	if got := strings.Join(c.seen, ","); got != "a,b,c" {
+		t.Fatalf("handled %s, want a,b,c", got)
+	}
+
+	if _, err := log.Append(ctx, "file.created", "d"); err != nil {
+		t.Fatalf("Append(d): %v", err)
+	}
+	if n, err := log.Replay(ctx, "indexer", "file.created", c.handle); err != nil || n != 1 {
+		t.Fatalf("Replay = %d, %v, want only the new entry", n, err)
+	}
+
+	// another consumer keeps its own checkpoint
+	other := &collect{}
+	if n, err := log.Replay(ctx, "audit", "file.created", other.handle); err != nil || n != 4 {
+		t.Fatalf("Replay of another consumer = %d, %v, want 4", n, err)
+	}
+}
+
+func TestReplaySkipsHandledKeys(t *testing.T) {
+	log, _ := newTestLog("file.created")
+	ctx := context.Background()
+	for _, name := range []string{"a", "b"} {
+		if _, err := log.Append(ctx, "file.created", name); err != nil {
+			t.Fatalf("Append(%s): %v", name, err)
+		}
+	}
+
+	c := &collect{}
+	if _, err := log.Replay(ctx, "indexer", "file.created", c.handle); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	// rewinding the checkpoint redelivers the entries, their idempotency keys are already handled
+	if err := log.SetCheckpoint(ctx, "indexer", "file.created", "0"); err != nil {
+		t.Fatalf("SetCheckpoint: %v", err)
+	}
+	if _, err := log.Replay(ctx, "indexer", "file.created", c.handle); err != nil {
+		t.Fatalf("Replay after rewind: %v", err)
+	}
+	if got := strings.Join(c.seen, ","); got != "a,b" {
+		t.Fatalf("handled %s, want each entry once", got)
+	}
+}
+
+func TestAppendOnlyDurableTypes(t *testing.T) {
+	log, m := newTestLog("file.created")
+	ctx := context.Background()
+
+	if _, err := log.Append(ctx, "file.viewed", "a"); !errors.Is(err, ErrNotDurable) {
+		t.Fatalf("Append of a non durable type = %v, want ErrNotDurable", err)
+	}
+	if _, err := log.Replay(ctx, "indexer", "file.viewed", (&collect{}).handle); !errors.Is(err, ErrNotDurable) {
+		t.Fatalf("Replay of a non durable type = %v, want ErrNotDurable", err)
+	}
+
+	published := time.UnixMilli(time.Now().UnixMilli())
+	entry, err := log.Append(ctx, "file.created", ext.EventData{Source: "resource", Time: published, Data: "a"})
+	if err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	stored := toEntry("file.created", m.streams[fmt.Sprintf(streamKey, "file.created")][0])
+	if stored.Key != entry.Key || stored.Source != "resource" || !stored.Time.Equal(published) || string(stored.Data) != `"a"` {
+		t.Fatalf("stored %+v, want the unwrapped event data", stored)
+	}
+}
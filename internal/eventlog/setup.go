@@ -0,0 +1,47 @@
+package eventlog
+
+import (
+	"context"
+
+	"github.com/ncobase/ncore/config"
+	ext "github.com/ncobase/ncore/extension/types"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// CrossServiceKey is the key the log is registered under,
+// consumers look it up with em.GetCrossService("eventlog", "Log")
+const CrossServiceKey = "eventlog.Log"
+
+// Setup creates the event log when enabled, attaches it to the event bus
+// and registers it as a cross service. It returns nil when the log is off.
+func Setup(conf *config.Config, em ext.ManagerInterface) (*Log, func()) {
+	cfg := FromViper(conf.Viper)
+	if !cfg.Enabled || len(cfg.Types) == 0 {
+		return nil, func() {}
+	}
+
+	if conf.Data == nil || conf.Data.Redis == nil || conf.Data.Redis.Addr == "" {
+		logger.Warnf(context.Background(), "Event log enabled but redis is not configured, durable events disabled")
+		return nil, func() {}
+	}
+
+	rc := redis.NewClient(&redis.Options{
+		Addr:         conf.Data.Redis.Addr,
+		Username:     conf.Data.Redis.Username,
+		Password:     conf.Data.Redis.Password,
+		DB:           conf.Data.Redis.Db,
+		ReadTimeout:  conf.Data.Redis.ReadTimeout,
+		WriteTimeout: conf.Data.Redis.WriteTimeout,
+		DialTimeout:  conf.Data.Redis.DialTimeout,
+	})
+
+	l := New(rc, cfg)
+	l.Attach(em)
+	em.RegisterCrossService(CrossServiceKey, l)
+	logger.Infof(context.Background(), "Event log enabled for %d event types", len(l.durable))
+
+	return l, func() {
+		_ = rc.Close()
+	}
+}
@@ -0,0 +1,33 @@
+package fieldcrypt
+
+import (
+	"github.com/spf13/viper"
+)
+
+// Config configures field-level encryption.
+// Encryption is opt-in per schema, fields not listed are stored as they are.
+type Config struct {
+	CurrentKey string              // ID of the key new values are encrypted with
+	Keys       map[string]string   // Base64 encoded 32 byte AES keys by key ID, old keys are kept for decryption
+	Schemas    map[string][]string // Encrypted fields by schema, "column" or "column.key" for a key of a JSON column
+}
+
+// FromViper reads the field encryption config from encryption.*
+func FromViper(v *viper.Viper) *Config {
+	cfg := &Config{
+		Keys:    map[string]string{},
+		Schemas: map[string][]string{},
+	}
+	if v == nil {
+		return cfg
+	}
+
+	cfg.CurrentKey = v.GetString("encryption.current_key")
+	for id, key := range v.GetStringMapString("encryption.keys") {
+		cfg.Keys[id] = key
+	}
+	for schema := range v.GetStringMap("encryption.schemas") {
+		cfg.Schemas[schema] = v.GetStringSlice("encryption.schemas." + schema)
+	}
+	return cfg
+}
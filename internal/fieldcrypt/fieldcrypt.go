@@ -0,0 +1,233 @@
+package fieldcrypt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ncobase/ncore/config"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/types"
+)
+
+var (
+	mu      sync.RWMutex
+	keyring *Keyring
+	fields  = map[string]map[string][]string{} // schema -> column -> JSON keys, nil keys for a whole column
+
+	jsonColumns = map[string]map[string]bool{} // schema -> JSON columns, declared by the repositories
+)
+
+// RegisterJSON declares JSON columns of a schema. Only keys of a JSON column can be
+// encrypted, Setup rejects a whole JSON column so its values are not left in plaintext.
+// Repositories register their columns from init, before Setup runs.
+func RegisterJSON(schema string, columns ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if jsonColumns[schema] == nil {
+		jsonColumns[schema] = map[string]bool{}
+	}
+	for _, column := range columns {
+		jsonColumns[schema][column] = true
+	}
+}
+
+// Setup configures the keyring and the encrypted fields of each schema from encryption.*.
+// It fails when fields are configured without a usable current key, so secrets are
+// never written in plaintext by accident.
+func Setup(conf *config.Config) error {
+	cfg := FromViper(conf.Viper)
+
+	k, err := NewKeyring(cfg.CurrentKey, cfg.Keys)
+	if err != nil {
+		return err
+	}
+
+	parsed := make(map[string]map[string][]string, len(cfg.Schemas))
+	for schema, paths := range cfg.Schemas {
+		for _, path := range paths {
+			column, key, _ := strings.Cut(strings.TrimSpace(path), ".")
+			if column == "" {
+				continue
+			}
+			if parsed[schema] == nil {
+				parsed[schema] = map[string][]string{}
+			}
+			if key == "" {
+				parsed[schema][column] = nil
+				continue
+			}
+			// A whole column is encrypted already, keys of it need nothing more
+			if existing, ok := parsed[schema][column]; ok && existing == nil {
+				continue
+			}
+			parsed[schema][column] = append(parsed[schema][column], key)
+		}
+	}
+	if len(parsed) > 0 && cfg.CurrentKey == "" {
+		return errors.New("encryption.schemas is set but encryption.current_key is not")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for schema, columns := range parsed {
+		for column, keys := range columns {
+			if keys == nil && jsonColumns[schema][column] {
+				return fmt.Errorf("encryption.schemas.%s: %s is a JSON column, list the keys to encrypt, e.g. %s.<key>", schema, column, column)
+			}
+		}
+	}
+	keyring, fields = k, parsed
+
+	if len(parsed) > 0 {
+		logger.Infof(context.Background(), "Field encryption enabled for %d schemas with key %s", len(parsed), cfg.CurrentKey)
+	}
+	return nil
+}
+
+// Schema encrypts and decrypts the configured fields of one schema, e.g. "file" or "space"
+type Schema struct {
+	name    string
+	k       *Keyring
+	columns map[string][]string
+}
+
+// For returns the encryption of a schema as currently configured.
+// A schema without configured fields stores values as they are, but still decrypts
+// values written before its fields were removed from the config.
+func For(schema string) *Schema {
+	mu.RLock()
+	defer mu.RUnlock()
+	return &Schema{name: schema, k: keyring, columns: fields[schema]}
+}
+
+// EncryptJSON returns a copy of a JSON column with its configured keys encrypted.
+// Values already encrypted with the current key are kept, values encrypted with an
+// older key are re-encrypted with the current one. A JSON column configured as a
+// whole, which Setup could not reject because its repository was not registered
+// yet, fails the write rather than storing it in plaintext.
+func (s *Schema) EncryptJSON(column string, data types.JSON) (types.JSON, error) {
+	keys, ok := s.columns[column]
+	if !ok || len(data) == 0 {
+		return data, nil
+	}
+	if keys == nil {
+		return nil, fmt.Errorf("encrypt %s.%s: a JSON column cannot be encrypted as a whole, list its keys", s.name, column)
+	}
+
+	result := make(types.JSON, len(data))
+	for k, v := range data {
+		result[k] = v
+	}
+	for _, key := range keys {
+		v, exists := data[key]
+		if !exists || v == nil {
+			continue
+		}
+		if str, isStr := v.(string); isStr && IsEncrypted(str) {
+			rotated, err := s.rotate(str)
+			if err != nil {
+				return nil, fmt.Errorf("encrypt %s.%s.%s: %w", s.name, column, key, err)
+			}
+			result[key] = rotated
+			continue
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt %s.%s.%s: %w", s.name, column, key, err)
+		}
+		encrypted, err := s.k.Encrypt(raw)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt %s.%s.%s: %w", s.name, column, key, err)
+		}
+		result[key] = encrypted
+	}
+	return result, nil
+}
+
+// DecryptJSON returns a copy of a JSON column with every encrypted value decrypted.
+// Values that cannot be decrypted are left out, ciphertext is never returned.
+func (s *Schema) DecryptJSON(column string, data types.JSON) types.JSON {
+	var result types.JSON
+	for k, v := range data {
+		str, ok := v.(string)
+		if !ok || !IsEncrypted(str) {
+			continue
+		}
+		if result == nil {
+			result = make(types.JSON, len(data))
+			for k, v := range data {
+				result[k] = v
+			}
+		}
+
+		var value any
+		raw, err := s.k.Decrypt(str)
+		if err == nil {
+			err = json.Unmarshal(raw, &value)
+		}
+		if err != nil {
+			logger.Warnf(context.Background(), "Failed to decrypt %s.%s.%s, value omitted: %v", s.name, column, k, err)
+			delete(result, k)
+			continue
+		}
+		result[k] = value
+	}
+	if result == nil {
+		return data
+	}
+	return result
+}
+
+// EncryptString encrypts a string column when it is configured as a whole
+func (s *Schema) EncryptString(column, value string) (string, error) {
+	keys, ok := s.columns[column]
+	if !ok || keys != nil || value == "" {
+		return value, nil
+	}
+	if IsEncrypted(value) {
+		rotated, err := s.rotate(value)
+		if err != nil {
+			return "", fmt.Errorf("encrypt %s.%s: %w", s.name, column, err)
+		}
+		return rotated, nil
+	}
+	encrypted, err := s.k.Encrypt([]byte(value))
+	if err != nil {
+		return "", fmt.Errorf("encrypt %s.%s: %w", s.name, column, err)
+	}
+	return encrypted, nil
+}
+
+// DecryptString decrypts an encrypted string column, returning an empty string when it cannot
+func (s *Schema) DecryptString(column, value string) string {
+	if !IsEncrypted(value) {
+		return value
+	}
+	raw, err := s.k.Decrypt(value)
+	if err != nil {
+		logger.Warnf(context.Background(), "Failed to decrypt %s.%s, value omitted: %v", s.name, column, err)
+		return ""
+	}
+	return string(raw)
+}
+
+// rotate re-encrypts a value with the current key unless it already uses it.
+// A value of a removed key is kept as it is, it cannot be read but must not block the write.
+func (s *Schema) rotate(value string) (string, error) {
+	if s.k.IsCurrent(value) {
+		return value, nil
+	}
+	raw, err := s.k.Decrypt(value)
+	if errors.Is(err, ErrUnknownKey) {
+		logger.Warnf(context.Background(), "Keeping %s value encrypted with a removed key: %v", s.name, err)
+		return value, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return s.k.Encrypt(raw)
+}
@@ -0,0 +1,184 @@
+package fieldcrypt
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/ncobase/ncore/config"
+	"github.com/ncobase/ncore/types"
+	"github.com/spf13/viper"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+// setup configures the package from an encryption.* config and restores it after the test
+func setup(t *testing.T, current string, keys map[string]string, schemas map[string][]string) error {
+	t.Helper()
+	mu.RLock()
+	prevKeyring, prevFields := keyring, fields
+	mu.RUnlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		keyring, fields = prevKeyring, prevFields
+		mu.Unlock()
+	})
+
+	// Shaped as viper reads a config file
+	schemaMap := make(map[string]any, len(schemas))
+	for schema, paths := range schemas {
+		schemaMap[schema] = paths
+	}
+	v := viper.New()
+	v.Set("encryption.current_key", current)
+	v.Set("encryption.keys", keys)
+	v.Set("encryption.schemas", schemaMap)
+	return Setup(&config.Config{Viper: v})
+}
+
+func TestEncryptJSONRoundTrip(t *testing.T) {
+	if err := setup(t, "k1", map[string]string{"k1": testKey('a')}, map[string][]string{
+		"file": {"extras.api_key"},
+	}); err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	stored, err := For("file").EncryptJSON("extras", types.JSON{"api_key": "secret", "name": "report"})
+	if err != nil {
+		t.Fatalf("EncryptJSON: %v", err)
+	}
+	str, ok := stored["api_key"].(string)
+	if !ok || !IsEncrypted(str) || strings.Contains(str, "secret") {
+		t.Fatalf("api_key stored as %v, want ciphertext", stored["api_key"])
+	}
+	if stored["name"] != "report" {
+		t.Fatalf("name stored as %v, want it unchanged", stored["name"])
+	}
+
+	read := For("file").DecryptJSON("extras", stored)
+	if read["api_key"] != "secret" || read["name"] != "report" {
+		t.Fatalf("DecryptJSON = %v", read)
+	}
+}
+
+func TestEncryptJSONUnconfiguredSchema(t *testing.T) {
+	if err := setup(t, "k1", map[string]string{"k1": testKey('a')}, map[string][]string{
+		"file": {"extras.api_key"},
+	}); err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	data := types.JSON{"api_key": "secret"}
+	stored, err := For("space").EncryptJSON("extras", data)
+	if err != nil {
+		t.Fatalf("EncryptJSON: %v", err)
+	}
+	if stored["api_key"] != "secret" {
+		t.Fatalf("api_key stored as %v, want it unchanged", stored["api_key"])
+	}
+}
+
+func TestSetupRejectsWholeJSONColumn(t *testing.T) {
+	RegisterJSON("test_json", "extras")
+
+	err := setup(t, "k1", map[string]string{"k1": testKey('a')}, map[string][]string{
+		"test_json": {"extras"},
+	})
+	if err == nil {
+		t.Fatal("Setup accepted a whole JSON column")
+	}
+}
+
+func TestEncryptJSONRejectsWholeColumn(t *testing.T) {
+	// The schema is not registered, as with a plugin loaded after Setup
+	if err := setup(t, "k1", map[string]string{"k1": testKey('a')}, map[string][]string{
+		"late_plugin": {"extras"},
+	}); err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	if _, err := For("late_plugin").EncryptJSON("extras", types.JSON{"token": "secret"}); err == nil {
+		t.Fatal("EncryptJSON stored a whole JSON column in plaintext")
+	}
+}
+
+func TestSetupRequiresCurrentKey(t *testing.T) {
+	if err := setup(t, "", map[string]string{}, map[string][]string{
+		"endpoint": {"auth_config"},
+	}); err == nil {
+		t.Fatal("Setup accepted schemas without a current key")
+	}
+}
+
+func TestEncryptStringWholeColumn(t *testing.T) {
+	if err := setup(t, "k1", map[string]string{"k1": testKey('a')}, map[string][]string{
+		"endpoint": {"auth_config"},
+	}); err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	stored, err := For("endpoint").EncryptString("auth_config", `{"token":"secret"}`)
+	if err != nil {
+		t.Fatalf("EncryptString: %v", err)
+	}
+	if !IsEncrypted(stored) {
+		t.Fatalf("auth_config stored as %q, want ciphertext", stored)
+	}
+	if got := For("endpoint").DecryptString("auth_config", stored); got != `{"token":"secret"}` {
+		t.Fatalf("DecryptString = %q", got)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	schemas := map[string][]string{"file": {"extras.api_key"}}
+	if err := setup(t, "k1", map[string]string{"k1": testKey('a')}, schemas); err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	old, err := For("file").EncryptJSON("extras", types.JSON{"api_key": "secret"})
+	if err != nil {
+		t.Fatalf("EncryptJSON: %v", err)
+	}
+
+	keys := map[string]string{"k1": testKey('a'), "k2": testKey('b')}
+	if err := setup(t, "k2", keys, schemas); err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	if got := For("file").DecryptJSON("extras", old); got["api_key"] != "secret" {
+		t.Fatalf("value of the old key read as %v", got["api_key"])
+	}
+
+	rewritten, err := For("file").EncryptJSON("extras", old)
+	if err != nil {
+		t.Fatalf("EncryptJSON: %v", err)
+	}
+	if rewritten["api_key"] == old["api_key"] || !keyring.IsCurrent(rewritten["api_key"].(string)) {
+		t.Fatalf("value was not re-encrypted with the current key: %v", rewritten["api_key"])
+	}
+	if got := For("file").DecryptJSON("extras", rewritten); got["api_key"] != "secret" {
+		t.Fatalf("rewritten value read as %v", got["api_key"])
+	}
+}
+
+func TestDecryptJSONOmitsUnreadableValues(t *testing.T) {
+	schemas := map[string][]string{"file": {"extras.api_key"}}
+	if err := setup(t, "k1", map[string]string{"k1": testKey('a')}, schemas); err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	stored, err := For("file").EncryptJSON("extras", types.JSON{"api_key": "secret", "name": "report"})
+	if err != nil {
+		t.Fatalf("EncryptJSON: %v", err)
+	}
+
+	if err := setup(t, "k2", map[string]string{"k2": testKey('b')}, schemas); err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	read := For("file").DecryptJSON("extras", stored)
+	if _, ok := read["api_key"]; ok {
+		t.Fatalf("value of a removed key returned as %v", read["api_key"])
+	}
+	if read["name"] != "report" {
+		t.Fatalf("name read as %v", read["name"])
+	}
+}
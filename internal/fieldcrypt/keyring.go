@@ -0,0 +1,122 @@
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// prefix marks an encrypted value, the full form is "enc:<key id>:<base64 nonce and ciphertext>"
+const prefix = "enc:"
+
+var (
+	// ErrNoKey is returned when encrypting without a current key
+	ErrNoKey = errors.New("no encryption key configured")
+	// ErrUnknownKey is returned when a value was encrypted with a key that is not configured
+	ErrUnknownKey = errors.New("unknown encryption key")
+	// ErrMalformed is returned for a value that looks encrypted but cannot be parsed or authenticated
+	ErrMalformed = errors.New("malformed encrypted value")
+)
+
+// Keyring encrypts with the current key and decrypts with any configured key,
+// so keys can be rotated without rewriting stored values at once
+type Keyring struct {
+	current string
+	aeads   map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring from base64 encoded 32 byte keys by ID
+func NewKeyring(current string, keys map[string]string) (*Keyring, error) {
+	k := &Keyring{current: current, aeads: make(map[string]cipher.AEAD, len(keys))}
+	for id, encoded := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid encryption key id %q", id)
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(raw) != 32 {
+			return nil, fmt.Errorf("encryption key %s must be 32 bytes, base64 encoded", id)
+		}
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %s: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %s: %w", id, err)
+		}
+		k.aeads[id] = aead
+	}
+	if current != "" && k.aeads[current] == nil {
+		return nil, fmt.Errorf("current encryption key %s is not configured", current)
+	}
+	return k, nil
+}
+
+// Encrypt encrypts plaintext with the current key and tags it with the key ID
+func (k *Keyring) Encrypt(plaintext []byte) (string, error) {
+	if k == nil || k.current == "" {
+		return "", ErrNoKey
+	}
+	aead := k.aeads[k.current]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(k.current))
+
+	return prefix + k.current + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value produced by Encrypt with the key it is tagged with
+func (k *Keyring) Decrypt(value string) ([]byte, error) {
+	id, data, ok := split(value)
+	if !ok {
+		return nil, ErrMalformed
+	}
+	if k == nil || k.aeads[id] == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+	aead := k.aeads[id]
+
+	sealed, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(id))
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	return plaintext, nil
+}
+
+// IsCurrent reports whether value is encrypted with the current key
+func (k *Keyring) IsCurrent(value string) bool {
+	id, _, ok := split(value)
+	return ok && k != nil && id == k.current
+}
+
+// IsEncrypted reports whether value has the form of an encrypted value
+func IsEncrypted(value string) bool {
+	_, _, ok := split(value)
+	return ok
+}
+
+// split returns the key ID and the encoded data of an encrypted value
+func split(value string) (id, data string, ok bool) {
+	rest, found := strings.CutPrefix(value, prefix)
+	if !found {
+		return "", "", false
+	}
+	id, data, found = strings.Cut(rest, ":")
+	if !found || id == "" || data == "" {
+		return "", "", false
+	}
+	return id, data, true
+}
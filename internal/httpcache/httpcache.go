@@ -0,0 +1,71 @@
+package httpcache
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag returns the entity tag of a resource version, e.g. the ID and update time of a record
+func ETag(parts ...any) string {
+	s := make([]string, len(parts))
+	for i, p := range parts {
+		s[i] = fmt.Sprint(p)
+	}
+	return `"` + strings.Join(s, "-") + `"`
+}
+
+// NotModified sets the validators of a response and answers a conditional GET.
+// It writes 304 Not Modified and returns true when the client copy is current,
+// in which case the handler must not write a body.
+//
+// If-None-Match takes precedence over If-Modified-Since. Responses are marked
+// private and must be revalidated, as they usually depend on the requesting user.
+func NotModified(c *gin.Context, etag string, updatedAt int64) bool {
+	header := c.Writer.Header()
+	header.Set("Cache-Control", "private, no-cache")
+	header.Set("ETag", etag)
+
+	var modified time.Time
+	if updatedAt > 0 {
+		modified = time.UnixMilli(updatedAt).UTC().Truncate(time.Second)
+		header.Set("Last-Modified", modified.Format(http.TimeFormat))
+	}
+
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		if !etagMatches(match, etag) {
+			return false
+		}
+	} else if since := c.GetHeader("If-Modified-Since"); since != "" && !modified.IsZero() {
+		t, err := http.ParseTime(since)
+		if err != nil || modified.After(t) {
+			return false
+		}
+	} else {
+		return false
+	}
+
+	c.Status(http.StatusNotModified)
+	c.Writer.WriteHeaderNow()
+	c.Abort()
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, compared weakly
+func etagMatches(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
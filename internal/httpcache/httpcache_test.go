@@ -0,0 +1,75 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serveSpace serves a space whose update time is read on each request
+func serveSpace(updatedAt *int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/spaces/s1", func(c *gin.Context) {
+		if NotModified(c, ETag("s1", *updatedAt), *updatedAt) {
+			return
+		}
+		c.String(http.StatusOK, "space")
+	})
+	return r
+}
+
+func get(r http.Handler, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/spaces/s1", nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestConditionalGet(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC).UnixMilli()
+	r := serveSpace(&updatedAt)
+
+	first := get(r, nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Header().Get("Last-Modified") != "Fri, 02 Jan 2026 03:04:05 GMT" {
+		t.Fatalf("first GET %d with ETag %q and Last-Modified %q", first.Code, etag, first.Header().Get("Last-Modified"))
+	}
+
+	unchanged := get(r, map[string]string{"If-None-Match": etag})
+	if unchanged.Code != http.StatusNotModified || unchanged.Body.Len() != 0 {
+		t.Fatalf("unchanged GET %d with body %q, want 304 without a body", unchanged.Code, unchanged.Body)
+	}
+	if w := get(r, map[string]string{"If-Modified-Since": first.Header().Get("Last-Modified")}); w.Code != http.StatusNotModified {
+		t.Fatalf("If-Modified-Since GET %d, want 304", w.Code)
+	}
+
+	// an update bumps the ETag
+	updatedAt += 60_000
+	updated := get(r, map[string]string{"If-None-Match": etag, "If-Modified-Since": first.Header().Get("Last-Modified")})
+	if updated.Code != http.StatusOK || updated.Header().Get("ETag") == etag || updated.Body.String() != "space" {
+		t.Fatalf("GET after update %d with ETag %q, want 200 with a new ETag", updated.Code, updated.Header().Get("ETag"))
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := ETag("s1", 42)
+	for header, want := range map[string]bool{
+		`"s1-42"`:          true,
+		`W/"s1-42"`:        true,
+		`"other", "s1-42"`: true,
+		`*`:                true,
+		`"s1-41"`:          false,
+		`"s1-42`:           false,
+	} {
+		if got := etagMatches(header, etag); got != want {
+			t.Errorf("etagMatches(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
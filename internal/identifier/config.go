@@ -0,0 +1,36 @@
+package identifier
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Unicode normalization forms of identifiers
+const (
+	FormNone = "none" // identifiers are kept as typed, the default
+	FormNFC  = "nfc"  // composed characters, "é" typed as e and an accent matches "é"
+	FormNFKC = "nfkc" // compatibility composition, also folds full-width and ligature characters
+)
+
+// Config configures how usernames and emails are normalized
+type Config struct {
+	CaseSensitiveUsernames bool   // usernames differing only in case are different accounts
+	CaseSensitiveEmails    bool   // emails differing only in case are different accounts
+	UnicodeForm            string // FormNone, FormNFC or FormNFKC
+}
+
+// FromViper reads the identifier config from auth.identifiers.*
+func FromViper(v *viper.Viper) *Config {
+	cfg := &Config{UnicodeForm: FormNone}
+	if v == nil {
+		return cfg
+	}
+
+	cfg.CaseSensitiveUsernames = v.GetBool("auth.identifiers.case_sensitive_usernames")
+	cfg.CaseSensitiveEmails = v.GetBool("auth.identifiers.case_sensitive_emails")
+	if s := strings.ToLower(v.GetString("auth.identifiers.unicode_form")); s != "" {
+		cfg.UnicodeForm = s
+	}
+	return cfg
+}
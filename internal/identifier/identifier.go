@@ -0,0 +1,74 @@
+package identifier
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ncobase/ncore/config"
+	"golang.org/x/text/unicode/norm"
+)
+
+var current atomic.Pointer[Config]
+
+func init() {
+	current.Store(&Config{UnicodeForm: FormNone})
+}
+
+// Set replaces the identifier config
+func Set(cfg *Config) {
+	current.Store(cfg)
+}
+
+// Setup configures identifier normalization from auth.identifiers.*. It fails on an
+// unknown unicode form instead of storing identifiers some other way.
+func Setup(conf *config.Config) error {
+	cfg := FromViper(conf.Viper)
+
+	switch cfg.UnicodeForm {
+	case FormNone, FormNFC, FormNFKC:
+	default:
+		return fmt.Errorf("unknown identifier unicode form %q", cfg.UnicodeForm)
+	}
+	Set(cfg)
+	return nil
+}
+
+// Username normalizes a username as it is stored and looked up: trimmed, in the
+// configured unicode form, and lower-cased unless usernames are case-sensitive
+func Username(s string) string {
+	cfg := current.Load()
+	return normalize(cfg, s, !cfg.CaseSensitiveUsernames)
+}
+
+// Email normalizes an email as it is stored and looked up: trimmed, in the
+// configured unicode form, and lower-cased unless emails are case-sensitive
+func Email(s string) string {
+	cfg := current.Load()
+	return normalize(cfg, s, !cfg.CaseSensitiveEmails)
+}
+
+// UsernamesFold reports whether usernames differing only in case are the same
+func UsernamesFold() bool {
+	return !current.Load().CaseSensitiveUsernames
+}
+
+// EmailsFold reports whether emails differing only in case are the same
+func EmailsFold() bool {
+	return !current.Load().CaseSensitiveEmails
+}
+
+// normalize trims s, brings it to the configured unicode form and lower-cases it when fold is set
+func normalize(cfg *Config, s string, fold bool) string {
+	s = strings.TrimSpace(s)
+	switch cfg.UnicodeForm {
+	case FormNFC:
+		s = norm.NFC.String(s)
+	case FormNFKC:
+		s = norm.NFKC.String(s)
+	}
+	if fold {
+		s = strings.ToLower(s)
+	}
+	return s
+}
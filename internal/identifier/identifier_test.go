@@ -0,0 +1,56 @@
+package identifier
+
+import "testing"
+
+// withConfig makes cfg the identifier config for the rest of the test
+func withConfig(t *testing.T, cfg *Config) {
+	t.Helper()
+	previous := current.Load()
+	Set(cfg)
+	t.Cleanup(func() { Set(previous) })
+}
+
+func TestNormalizeFoldsByDefault(t *testing.T) {
+	withConfig(t, &Config{UnicodeForm: FormNone})
+
+	if got := Username("  Alice "); got != "alice" {
+		t.Errorf("Username = %q, want alice", got)
+	}
+	if got := Email(" Alice@Example.COM"); got != "alice@example.com" {
+		t.Errorf("Email = %q, want alice@example.com", got)
+	}
+	if !UsernamesFold() || !EmailsFold() {
+		t.Error("identifiers do not fold by default")
+	}
+}
+
+func TestNormalizeCaseSensitive(t *testing.T) {
+	withConfig(t, &Config{CaseSensitiveUsernames: true, UnicodeForm: FormNone})
+
+	if got := Username(" Alice "); got != "Alice" {
+		t.Errorf("case-sensitive Username = %q, want Alice", got)
+	}
+	if got := Email("Alice@Example.com"); got != "alice@example.com" {
+		t.Errorf("Email = %q, want it folded while only usernames are case-sensitive", got)
+	}
+	if UsernamesFold() || !EmailsFold() {
+		t.Error("fold flags do not follow the config")
+	}
+}
+
+func TestNormalizeUnicodeForms(t *testing.T) {
+	decomposed := "José" // e followed by a combining acute accent
+	for _, tc := range []struct {
+		form, in, want string
+	}{
+		{FormNone, decomposed, "josé"},
+		{FormNFC, decomposed, "josé"},
+		{FormNFC, "ａlice", "ａlice"}, // full-width a is kept by NFC
+		{FormNFKC, "ａlice", "alice"},
+	} {
+		withConfig(t, &Config{UnicodeForm: tc.form})
+		if got := Username(tc.in); got != tc.want {
+			t.Errorf("%s: Username(%q) = %q, want %q", tc.form, tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package idgen
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Strategies of primary key generation
+const (
+	StrategyNanoID    = "nanoid"    // random alphanumeric keys, the default
+	StrategyUUIDv7    = "uuidv7"    // time-ordered keys in the UUIDv7 layout
+	StrategySnowflake = "snowflake" // keys from an external snowflake service
+)
+
+// Config configures primary key generation
+type Config struct {
+	Strategy  string
+	Snowflake SnowflakeConfig
+}
+
+// SnowflakeConfig locates the external snowflake service
+type SnowflakeConfig struct {
+	URL     string        // GET endpoint answering a JSON number or array of numbers, passed ?count=<batch>
+	Batch   int           // IDs fetched per request
+	Timeout time.Duration // per request
+}
+
+// FromViper reads the primary key config from ids.*
+func FromViper(v *viper.Viper) *Config {
+	cfg := &Config{
+		Strategy: StrategyNanoID,
+		Snowflake: SnowflakeConfig{
+			Batch:   100,
+			Timeout: 2 * time.Second,
+		},
+	}
+	if v == nil {
+		return cfg
+	}
+
+	if s := v.GetString("ids.strategy"); s != "" {
+		cfg.Strategy = s
+	}
+	cfg.Snowflake.URL = v.GetString("ids.snowflake.url")
+	if n := v.GetInt("ids.snowflake.batch"); n > 0 {
+		cfg.Snowflake.Batch = n
+	}
+	if d := v.GetDuration("ids.snowflake.timeout"); d > 0 {
+		cfg.Snowflake.Timeout = d
+	}
+	return cfg
+}
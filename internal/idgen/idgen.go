@@ -0,0 +1,93 @@
+package idgen
+
+import (
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ncobase/ncore/config"
+	"github.com/ncobase/ncore/consts"
+	"github.com/ncobase/ncore/data/entgo/mixin"
+	"github.com/ncobase/ncore/utils/nanoid"
+)
+
+// Generator generates primary keys. Every strategy returns keys of consts.PrimaryKeySize
+// characters of 0-9, A-Z and a-z, so they fit the id columns and pass nanoid.IsPrimaryKey.
+type Generator interface {
+	NewID() string
+}
+
+// GeneratorFunc adapts a function to a Generator
+type GeneratorFunc func() string
+
+// NewID calls f
+func (f GeneratorFunc) NewID() string { return f() }
+
+// NanoID generates random keys, the scheme keys had before strategies were configurable
+var NanoID Generator = GeneratorFunc(nanoid.PrimaryKey())
+
+// current is the configured strategy. Strategies are of different types, which an
+// atomic.Value refuses to swap between, so the interface is stored behind a pointer.
+var current atomic.Pointer[Generator]
+
+func init() {
+	SetGenerator(NanoID)
+}
+
+// PrimaryKey is the id field of a schema, generated with the configured strategy.
+// It replaces mixin.PrimaryKey and keeps its column definition.
+var PrimaryKey = mixin.StringMixin{
+	Field:       "id",
+	Comment:     "primary key",
+	Immutable:   true,
+	Unique:      true,
+	MaxLen:      consts.PrimaryKeySize,
+	DefaultFunc: New,
+}
+
+// New generates a primary key with the configured strategy
+func New() string {
+	return (*current.Load()).NewID()
+}
+
+// SetGenerator replaces the strategy keys are generated with
+func SetGenerator(g Generator) {
+	current.Store(&g)
+}
+
+// Setup configures the strategy from ids.*. It fails on an unknown strategy, or a snowflake
+// strategy without a service, instead of silently generating keys some other way.
+func Setup(conf *config.Config) error {
+	cfg := FromViper(conf.Viper)
+
+	switch cfg.Strategy {
+	case StrategyNanoID:
+		SetGenerator(NanoID)
+	case StrategyUUIDv7:
+		SetGenerator(NewUUIDv7())
+	case StrategySnowflake:
+		if cfg.Snowflake.URL == "" {
+			return fmt.Errorf("ids.snowflake.url is required by the %s strategy", StrategySnowflake)
+		}
+		SetGenerator(NewSnowflake(NewHTTPSource(cfg.Snowflake)))
+	default:
+		return fmt.Errorf("unknown ID strategy %q", cfg.Strategy)
+	}
+	return nil
+}
+
+// sortable is the key alphabet in byte order, so encoded numbers compare as strings do.
+// It holds the characters of consts.PrimaryKey, only ordered differently.
+const sortable = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// encodeSortable writes n in base 62 over sortable, left padded to consts.PrimaryKeySize
+func encodeSortable(n *big.Int) string {
+	buf := make([]byte, consts.PrimaryKeySize)
+	base := big.NewInt(int64(len(sortable)))
+	v, r := new(big.Int).Set(n), new(big.Int)
+	for i := len(buf) - 1; i >= 0; i-- {
+		v.QuoRem(v, base, r)
+		buf[i] = sortable[r.Int64()]
+	}
+	return string(buf)
+}
@@ -0,0 +1,245 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ncobase/ncore/config"
+	"github.com/ncobase/ncore/utils/nanoid"
+	"github.com/spf13/viper"
+)
+
+// assertValid generates n keys, checking each is a unique primary key, and returns them
+func assertValid(t *testing.T, g Generator, n int) []string {
+	t.Helper()
+	seen := make(map[string]bool, n)
+	ids := make([]string, n)
+	for i := range ids {
+		id := g.NewID()
+		if !nanoid.IsPrimaryKey(id) {
+			t.Fatalf("key %q is not a primary key", id)
+		}
+		if seen[id] {
+			t.Fatalf("key %q generated twice", id)
+		}
+		seen[id] = true
+		ids[i] = id
+	}
+	return ids
+}
+
+// assertSorted checks the keys increase in generation order
+func assertSorted(t *testing.T, ids []string) {
+	t.Helper()
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Fatalf("key %d %q does not sort after %q", i, ids[i], ids[i-1])
+		}
+	}
+}
+
+func TestNanoIDKeys(t *testing.T) {
+	assertValid(t, NanoID, 10000)
+}
+
+func TestUUIDv7KeysSortByCreation(t *testing.T) {
+	g := NewUUIDv7()
+	ids := assertValid(t, g, 10000)
+	assertSorted(t, ids)
+
+	// keys of later milliseconds sort after, even with the clock going back
+	clock := time.UnixMilli(1700000000000)
+	g.now = func() time.Time { return clock }
+	first := g.NewID()
+	clock = clock.Add(time.Millisecond)
+	second := g.NewID()
+	clock = clock.Add(-time.Second)
+	third := g.NewID()
+	assertSorted(t, []string{ids[len(ids)-1], first, second, third})
+}
+
+func TestUUIDv7KeysAreUniqueAcrossGoroutines(t *testing.T) {
+	g := NewUUIDv7()
+	var (
+		mu   sync.Mutex
+		seen = make(map[string]bool)
+		wg   sync.WaitGroup
+	)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				id := g.NewID()
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("key %q generated twice", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestEncodeSortableFitsKeySize(t *testing.T) {
+	largest := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 48+uuidv7RandBits), big.NewInt(1))
+	for _, n := range []*big.Int{big.NewInt(0), big.NewInt(61), big.NewInt(62), big.NewInt(math.MaxInt64), largest} {
+		if id := encodeSortable(n); !nanoid.IsPrimaryKey(id) {
+			t.Fatalf("encoding of %s = %q, not a primary key", n, id)
+		}
+	}
+	if encodeSortable(big.NewInt(61)) >= encodeSortable(big.NewInt(62)) {
+		t.Fatal("encoded numbers do not sort numerically")
+	}
+}
+
+// counterSource hands out increasing IDs, failing while failing is set
+type counterSource struct {
+	next    atomic.Int64
+	failing atomic.Bool
+}
+
+func (s *counterSource) Next(context.Context) (int64, error) {
+	if s.failing.Load() {
+		return 0, errors.New("snowflake service unavailable")
+	}
+	return s.next.Add(1 << 22), nil
+}
+
+func TestSnowflakeKeysFollowSource(t *testing.T) {
+	source := &counterSource{}
+	g := NewSnowflake(source)
+	assertSorted(t, assertValid(t, g, 1000))
+
+	// a failing source falls back to random keys
+	source.failing.Store(true)
+	assertValid(t, g, 10)
+}
+
+func TestHTTPSourceFetchesBatches(t *testing.T) {
+	var (
+		requests atomic.Int32
+		next     atomic.Int64
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		if count == 1 {
+			fmt.Fprint(w, next.Add(1))
+			return
+		}
+		fmt.Fprint(w, "[")
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprint(w, next.Add(1))
+		}
+		fmt.Fprint(w, "]")
+	}))
+	defer server.Close()
+
+	g := NewSnowflake(NewHTTPSource(SnowflakeConfig{URL: server.URL, Batch: 50, Timeout: time.Second}))
+	assertSorted(t, assertValid(t, g, 120))
+	if requests.Load() != 3 {
+		t.Fatalf("%d requests for 120 keys in batches of 50", requests.Load())
+	}
+
+	// a service answering single numbers works with a batch of one
+	source := NewHTTPSource(SnowflakeConfig{URL: server.URL, Timeout: time.Second})
+	if id, err := source.Next(context.Background()); err != nil || id != next.Load() {
+		t.Fatalf("single ID = %d, %v", id, err)
+	}
+}
+
+func TestHTTPSourceReportsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/empty":
+			fmt.Fprint(w, "[]")
+		case "/text":
+			fmt.Fprint(w, "ok")
+		default:
+			http.Error(w, "down", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	for _, path := range []string{"/empty", "/text", "/down"} {
+		source := NewHTTPSource(SnowflakeConfig{URL: server.URL + path, Timeout: time.Second})
+		if _, err := source.Next(context.Background()); err == nil {
+			t.Fatalf("%s: no error", path)
+		}
+	}
+}
+
+// generator returns the configured strategy
+func generator() Generator {
+	return *current.Load()
+}
+
+func TestSetupSelectsStrategy(t *testing.T) {
+	defer SetGenerator(NanoID)
+
+	setup := func(values map[string]any) error {
+		v := viper.New()
+		for key, value := range values {
+			v.Set(key, value)
+		}
+		return Setup(&config.Config{Viper: v})
+	}
+
+	if err := setup(map[string]any{"ids.strategy": StrategyUUIDv7}); err != nil {
+		t.Fatalf("uuidv7: %v", err)
+	}
+	if _, ok := generator().(*UUIDv7); !ok {
+		t.Fatalf("generator %T, want UUIDv7", generator())
+	}
+	assertSorted(t, []string{New(), New(), New()})
+
+	if err := setup(map[string]any{"ids.strategy": StrategySnowflake}); err == nil {
+		t.Fatal("snowflake without a URL accepted")
+	}
+	if err := setup(map[string]any{"ids.strategy": "ulid"}); err == nil {
+		t.Fatal("unknown strategy accepted")
+	}
+	if err := setup(map[string]any{"ids.strategy": StrategySnowflake, "ids.snowflake.url": "http://ids.local"}); err != nil {
+		t.Fatalf("snowflake: %v", err)
+	}
+	if _, ok := generator().(*Snowflake); !ok {
+		t.Fatalf("generator %T, want Snowflake", generator())
+	}
+
+	if err := setup(nil); err != nil {
+		t.Fatalf("default strategy: %v", err)
+	}
+	if _, ok := generator().(GeneratorFunc); !ok {
+		t.Fatalf("generator %T, want NanoID", generator())
+	}
+}
+
+func TestFromViperDefaults(t *testing.T) {
+	cfg := FromViper(nil)
+	if cfg.Strategy != StrategyNanoID || cfg.Snowflake.Batch != 100 || cfg.Snowflake.Timeout != 2*time.Second {
+		t.Fatalf("defaults %+v", cfg)
+	}
+
+	v := viper.New()
+	v.Set("ids.snowflake.batch", 500)
+	v.Set("ids.snowflake.timeout", "5s")
+	if cfg := FromViper(v); cfg.Snowflake.Batch != 500 || cfg.Snowflake.Timeout != 5*time.Second {
+		t.Fatalf("configured %+v", cfg)
+	}
+}
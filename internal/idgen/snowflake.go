@@ -0,0 +1,117 @@
+package idgen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// SnowflakeSource hands out snowflake IDs, unique and increasing across instances
+type SnowflakeSource interface {
+	Next(ctx context.Context) (int64, error)
+}
+
+// Snowflake generates keys from the IDs of a snowflake source, encoded so they sort as the
+// IDs do. While the source fails, keys fall back to NanoID, unique but not ordered.
+type Snowflake struct {
+	source SnowflakeSource
+}
+
+// NewSnowflake creates a snowflake generator over source
+func NewSnowflake(source SnowflakeSource) *Snowflake {
+	return &Snowflake{source: source}
+}
+
+// NewID generates the next key
+func (g *Snowflake) NewID() string {
+	id, err := g.source.Next(context.Background())
+	if err != nil || id < 0 {
+		logger.Warnf(context.Background(), "Failed to get snowflake ID, generating a random key: %v", err)
+		return NanoID.NewID()
+	}
+	return encodeSortable(big.NewInt(id))
+}
+
+// HTTPSource fetches snowflake IDs from a service in batches
+type HTTPSource struct {
+	conf   SnowflakeConfig
+	client *http.Client
+
+	mu  sync.Mutex
+	ids []int64
+}
+
+// NewHTTPSource creates a snowflake source reading from conf.URL
+func NewHTTPSource(conf SnowflakeConfig) *HTTPSource {
+	if conf.Batch <= 0 {
+		conf.Batch = 1
+	}
+	return &HTTPSource{conf: conf, client: &http.Client{Timeout: conf.Timeout}}
+}
+
+// Next returns the next buffered ID, fetching a batch when none is left
+func (s *HTTPSource) Next(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.ids) == 0 {
+		ids, err := s.fetch(ctx)
+		if err != nil {
+			return 0, err
+		}
+		s.ids = ids
+	}
+	id := s.ids[0]
+	s.ids = s.ids[1:]
+	return id, nil
+}
+
+// fetch requests a batch of IDs, answered as a JSON number or array of numbers
+func (s *HTTPSource) fetch(ctx context.Context) ([]int64, error) {
+	u, err := url.Parse(s.conf.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snowflake URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("count", strconv.Itoa(s.conf.Batch))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("snowflake service answered %s", res.Status)
+	}
+
+	var ids []int64
+	if err := json.Unmarshal(body, &ids); err != nil {
+		var id int64
+		if err := json.Unmarshal(body, &id); err != nil {
+			return nil, fmt.Errorf("invalid snowflake response: %w", err)
+		}
+		ids = []int64{id}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("snowflake service answered no IDs")
+	}
+	return ids, nil
+}
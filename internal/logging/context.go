@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// Context field keys
+const (
+	UserIDKey   = "user_id"
+	SpaceIDKey  = "space_id"
+	ClientIPKey = "client_ip"
+)
+
+// unknownClientIP is the client IP of a context without one
+const unknownClientIP = "unknown"
+
+// ContextFields extracts the request scoped identifiers carried by ctx.
+// The trace ID is not included, the logger adds it to every entry already.
+func ContextFields(ctx context.Context) logrus.Fields {
+	fields := logrus.Fields{}
+	if ctx == nil {
+		return fields
+	}
+
+	if userID := ctxutil.GetUserID(ctx); userID != "" {
+		fields[UserIDKey] = userID
+	}
+	if spaceID := ctxutil.GetSpaceID(ctx); spaceID != "" {
+		fields[SpaceIDKey] = spaceID
+	}
+	// GetClientIP reports unknown for contexts outside a request
+	if clientIP := ctxutil.GetClientIP(ctx); clientIP != "" && clientIP != unknownClientIP {
+		fields[ClientIPKey] = clientIP
+	}
+	return fields
+}
+
+// FromContext returns a log entry carrying the identifiers of ctx
+func FromContext(ctx context.Context) *logrus.Entry {
+	return logger.WithFields(ctx, ContextFields(ctx))
+}
+
+// ForModule returns a log entry for module carrying the identifiers of ctx,
+// skipping the stack lookup of the module formatter
+func ForModule(ctx context.Context, module string) *logrus.Entry {
+	return FromContext(ctx).WithField(ModuleKey, module)
+}
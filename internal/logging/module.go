@@ -0,0 +1,141 @@
+package logging
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// ModuleKey is the field holding the module an entry was logged from
+const ModuleKey = "module"
+
+// modulePrefix is the import path prefix of the application packages
+const modulePrefix = "ncobase/"
+
+// moduleGroups are the package roots whose children are modules
+var moduleGroups = map[string]struct{}{
+	"core":   {},
+	"biz":    {},
+	"plugin": {},
+}
+
+// ParseModuleLevels parses logger.modules, a map of module name to level name,
+// e.g. {resource: info, space: debug}
+func ParseModuleLevels(v *viper.Viper) (map[string]logrus.Level, error) {
+	if v == nil || !v.IsSet("logger.modules") {
+		return nil, nil
+	}
+
+	levels := make(map[string]logrus.Level)
+	for module, name := range v.GetStringMapString("logger.modules") {
+		level, err := logrus.ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level for module %s: %w", module, err)
+		}
+		levels[strings.ToLower(module)] = level
+	}
+	return levels, nil
+}
+
+// SetupModuleLevels applies per-module level overrides on top of the global logger.
+//
+// The logger level is raised to the most verbose override so those entries reach
+// the formatter, which then drops entries below the level of their module.
+// Every entry is tagged with its module, so JSON output can be filtered by it.
+func SetupModuleLevels(v *viper.Viper) error {
+	levels, err := ParseModuleLevels(v)
+	if err != nil {
+		return err
+	}
+
+	l := logger.StdLogger()
+	base := l.GetLevel()
+	for _, level := range levels {
+		if level > l.GetLevel() {
+			l.SetLevel(level)
+		}
+	}
+
+	l.SetFormatter(&ModuleFormatter{
+		Formatter: l.Formatter,
+		Base:      base,
+		Levels:    levels,
+	})
+	return nil
+}
+
+// ModuleFormatter tags entries with their module and filters them by module level
+type ModuleFormatter struct {
+	logrus.Formatter
+	// Base is the level of modules without an override
+	Base logrus.Level
+	// Levels holds the per-module overrides
+	Levels map[string]logrus.Level
+}
+
+// Format formats entry, returning no output when its module level filters it out
+func (f *ModuleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	module, ok := entry.Data[ModuleKey].(string)
+	if !ok {
+		module = callerModule()
+	}
+
+	level, ok := f.Levels[module]
+	if !ok {
+		level = f.Base
+	}
+	if entry.Level > level {
+		return nil, nil
+	}
+
+	if module != "" {
+		entry.Data[ModuleKey] = module
+	}
+	return f.Formatter.Format(entry)
+}
+
+// callerModule returns the module of the first application frame on the stack
+func callerModule() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if module, ok := ModuleOf(frame.Function); ok {
+			return module
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// ModuleOf returns the module of a fully qualified function name,
+// e.g. ncobase/plugin/resource/service.(*fileService).Create is resource.
+// Functions outside the application, or in this package, are reported as not found.
+func ModuleOf(function string) (string, bool) {
+	path, ok := strings.CutPrefix(function, modulePrefix)
+	if !ok || strings.HasPrefix(path, "internal/logging.") {
+		return "", false
+	}
+
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) >= 2 {
+		if _, grouped := moduleGroups[parts[0]]; grouped {
+			return packageName(parts[1]), true
+		}
+	}
+	return packageName(parts[0]), true
+}
+
+// packageName strips the function part from a path element
+func packageName(element string) string {
+	if i := strings.IndexByte(element, '.'); i >= 0 {
+		return element[:i]
+	}
+	return element
+}
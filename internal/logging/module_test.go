@@ -0,0 +1,139 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+func moduleViper(modules map[string]any) *viper.Viper {
+	v := viper.New()
+	v.Set("logger.modules", modules)
+	return v
+}
+
+// captureLogger sends the standard logger to a buffer at level info,
+// restoring its output, level and formatter when the test ends
+func captureLogger(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	l := logger.StdLogger()
+	out, level, formatter := l.Out, l.GetLevel(), l.Formatter
+	t.Cleanup(func() {
+		l.SetOutput(out)
+		l.SetLevel(level)
+		l.SetFormatter(formatter)
+	})
+
+	var buf bytes.Buffer
+	l.SetOutput(&buf)
+	l.SetLevel(logrus.InfoLevel)
+	l.SetFormatter(&logrus.JSONFormatter{})
+	return &buf
+}
+
+// entries decodes the JSON lines written to buf
+func entries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var decoded []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		decoded = append(decoded, entry)
+	}
+	return decoded
+}
+
+func TestModuleOf(t *testing.T) {
+	for function, want := range map[string]string{
+		"ncobase/plugin/resource/service.(*fileService).Create": "resource",
+		"ncobase/core/space/data/repository.NewSpace":           "space",
+		"ncobase/biz/content/handler.(*topic).List":             "content",
+		"ncobase/internal/metrics.Register":                     "internal",
+		"ncobase/main.main":                                     "main",
+	} {
+		if got, ok := ModuleOf(function); !ok || got != want {
+			t.Errorf("ModuleOf(%s) = %q, %v, want %q", function, got, ok, want)
+		}
+	}
+	for _, function := range []string{"github.com/gin-gonic/gin.(*Context).Next", "ncobase/internal/logging.ForModule"} {
+		if module, ok := ModuleOf(function); ok {
+			t.Errorf("ModuleOf(%s) = %q, want not found", function, module)
+		}
+	}
+}
+
+func TestParseModuleLevels(t *testing.T) {
+	levels, err := ParseModuleLevels(moduleViper(map[string]any{"Resource": "debug", "workflow": "warn"}))
+	if err != nil {
+		t.Fatalf("ParseModuleLevels: %v", err)
+	}
+	if levels["resource"] != logrus.DebugLevel || levels["workflow"] != logrus.WarnLevel || len(levels) != 2 {
+		t.Fatalf("levels = %v", levels)
+	}
+
+	if _, err := ParseModuleLevels(moduleViper(map[string]any{"resource": "loud"})); err == nil {
+		t.Fatal("invalid level accepted")
+	}
+	if levels, err := ParseModuleLevels(viper.New()); err != nil || levels != nil {
+		t.Fatalf("unset modules = %v, %v, want none", levels, err)
+	}
+}
+
+func TestModuleLevelsFilterEntries(t *testing.T) {
+	buf := captureLogger(t)
+	if err := SetupModuleLevels(moduleViper(map[string]any{"resource": "debug", "space": "warn"})); err != nil {
+		t.Fatalf("SetupModuleLevels: %v", err)
+	}
+
+	ctx := context.Background()
+	ForModule(ctx, "resource").Debug("resource debug")
+	ForModule(ctx, "space").Info("space info")
+	ForModule(ctx, "space").Warn("space warn")
+	ForModule(ctx, "user").Debug("user debug")
+	ForModule(ctx, "user").Info("user info")
+
+	var logged []string
+	for _, entry := range entries(t, buf) {
+		logged = append(logged, entry["msg"].(string))
+		if module := entry[ModuleKey]; !strings.HasPrefix(entry["msg"].(string), module.(string)) {
+			t.Errorf("entry %q tagged with module %v", entry["msg"], module)
+		}
+	}
+	if got, want := strings.Join(logged, ","), "resource debug,space warn,user info"; got != want {
+		t.Fatalf("logged %s, want %s", got, want)
+	}
+}
+
+func TestContextFieldsInOutput(t *testing.T) {
+	buf := captureLogger(t)
+
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+	ctx = ctxutil.SetSpaceID(ctx, "s1")
+	ctx = ctxutil.SetClientIP(ctx, "10.0.0.1")
+	FromContext(ctx).Info("request handled")
+
+	logged := entries(t, buf)
+	if len(logged) != 1 {
+		t.Fatalf("%d entries logged, want 1", len(logged))
+	}
+	entry := logged[0]
+	if entry[UserIDKey] != "u1" || entry[SpaceIDKey] != "s1" || entry[ClientIPKey] != "10.0.0.1" {
+		t.Fatalf("entry = %v, want the context fields", entry)
+	}
+
+	if fields := ContextFields(context.Background()); len(fields) != 0 {
+		t.Fatalf("fields of an empty context = %v, want none", fields)
+	}
+}
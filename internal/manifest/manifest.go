@@ -0,0 +1,143 @@
+package manifest
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+// PluginManifest describes what an extension provides and what it needs,
+// so operators can audit an install.
+type PluginManifest struct {
+	Name         string        `json:"name"`
+	Version      string        `json:"version,omitempty"`
+	Description  string        `json:"description,omitempty"`
+	Type         string        `json:"type,omitempty"`
+	Group        string        `json:"group,omitempty"`
+	Status       string        `json:"status,omitempty"`
+	Dependencies []string      `json:"dependencies,omitempty"`
+	Routes       []Route       `json:"routes"`
+	Permissions  []string      `json:"permissions,omitempty"`
+	Events       Events        `json:"events"`
+	Config       []ConfigField `json:"config,omitempty"`
+}
+
+// Route is an HTTP route served by an extension
+type Route struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// Events lists the events an extension publishes and subscribes to
+type Events struct {
+	Publishes  []string `json:"publishes,omitempty"`
+	Subscribes []string `json:"subscribes,omitempty"`
+}
+
+// ConfigField describes a configuration key read by an extension
+type ConfigField struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"`
+	Default     any    `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Provider is implemented by extensions that describe more than their metadata,
+// such as the permissions they check, their events and their configuration.
+type Provider interface {
+	Manifest() PluginManifest
+}
+
+var (
+	mu     sync.RWMutex
+	routes func() gin.RoutesInfo
+)
+
+// SetRouteSource sets where the registered routes are read from, usually gin.Engine.Routes
+func SetRouteSource(source func() gin.RoutesInfo) {
+	mu.Lock()
+	defer mu.Unlock()
+	routes = source
+}
+
+// Of returns the manifest of an extension.
+// Extensions that do not implement Provider get a default manifest built from their metadata.
+// Missing metadata is taken from the extension, and routes from the route source
+// unless the extension lists them itself.
+func Of(e ext.Interface) PluginManifest {
+	var m PluginManifest
+	if p, ok := e.(Provider); ok {
+		m = p.Manifest()
+	}
+
+	meta := e.GetMetadata()
+	if m.Name == "" {
+		m.Name = meta.Name
+	}
+	if m.Version == "" {
+		m.Version = meta.Version
+	}
+	if m.Description == "" {
+		m.Description = meta.Description
+	}
+	if m.Type == "" {
+		m.Type = meta.Type
+	}
+	if m.Group == "" {
+		m.Group = meta.Group
+	}
+	if m.Dependencies == nil {
+		m.Dependencies = meta.Dependencies
+	}
+	if m.Status == "" {
+		m.Status = e.Status()
+	}
+	if m.Routes == nil {
+		m.Routes = routesOf(e)
+	}
+	return m
+}
+
+// routesOf returns the registered routes whose handler is declared in the package of
+// the extension or one of its subpackages, sorted by path and method
+func routesOf(e ext.Interface) []Route {
+	mu.RLock()
+	source := routes
+	mu.RUnlock()
+
+	result := []Route{}
+	pkg := packageOf(e)
+	if source == nil || pkg == "" {
+		return result
+	}
+
+	for _, r := range source() {
+		if strings.HasPrefix(r.Handler, pkg+".") || strings.HasPrefix(r.Handler, pkg+"/") {
+			result = append(result, Route{Method: r.Method, Path: r.Path})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+		return result[i].Method < result[j].Method
+	})
+	return result
+}
+
+// packageOf returns the import path of the package declaring an extension
+func packageOf(e ext.Interface) string {
+	t := reflect.TypeOf(e)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return ""
+	}
+	return t.PkgPath()
+}
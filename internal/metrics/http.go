@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"net/http"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	httpRequests = Default.CounterVec("http_requests_total",
+		"Total HTTP requests by method, route and status code.", "method", "route", "status")
+	httpDuration = Default.HistogramVec("http_request_duration_seconds",
+		"HTTP request latency in seconds by method and route.", DefaultBuckets, "method", "route")
+)
+
+func init() {
+	Default.GaugeFunc("go_goroutines", "Number of goroutines that currently exist.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+}
+
+// Middleware records request count and latency per route
+func Middleware(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	// Use the route template to keep label cardinality bounded
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	method := c.Request.Method
+
+	httpRequests.Inc(method, route, strconv.Itoa(c.Writer.Status()))
+	httpDuration.Observe(time.Since(start).Seconds(), method, route)
+}
+
+// Handler serves the default registry in the Prometheus text format
+func Handler(c *gin.Context) {
+	c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Status(http.StatusOK)
+	Default.WriteText(c.Writer)
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRegistrationIsIdempotent(t *testing.T) {
+	r := NewRegistry()
+	first := r.CounterVec("jobs_total", "Jobs.", "state")
+	again := r.CounterVec("jobs_total", "Jobs.", "state")
+	if first != again {
+		t.Fatal("registering the same counter twice created a second collector")
+	}
+	first.Inc("done")
+	again.Inc("done")
+
+	var out bytes.Buffer
+	r.WriteText(&out)
+	if strings.Count(out.String(), "# TYPE jobs_total counter") != 1 {
+		t.Fatalf("counter written more than once:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), `jobs_total{state="done"} 2`) {
+		t.Fatalf("counter value missing:\n%s", out.String())
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	r := NewRegistry()
+	h := r.HistogramVec("latency_seconds", "Latency.", []float64{1, 0.1}, "op")
+	h.Observe(0.05, "read")
+	h.Observe(0.5, "read")
+	h.Observe(5, "read")
+
+	var out bytes.Buffer
+	r.WriteText(&out)
+	for _, line := range []string{
+		`latency_seconds_bucket{op="read",le="0.1"} 1`,
+		`latency_seconds_bucket{op="read",le="1"} 2`,
+		`latency_seconds_bucket{op="read",le="+Inf"} 3`,
+		`latency_seconds_sum{op="read"} 5.55`,
+		`latency_seconds_count{op="read"} 3`,
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("missing %q in:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestLabelValuesAreEscaped(t *testing.T) {
+	r := NewRegistry()
+	r.CounterVec("errors_total", "Errors.", "message").Inc("say \"hi\"\n")
+
+	var out bytes.Buffer
+	r.WriteText(&out)
+	if !strings.Contains(out.String(), `errors_total{message="say \"hi\"\n"} 1`) {
+		t.Fatalf("label not escaped:\n%s", out.String())
+	}
+}
+
+func TestEndpointServesRequestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Middleware)
+	engine.GET("/files/:slug", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	engine.GET("/metrics", Handler)
+
+	for _, path := range []string{"/files/a", "/files/b", "/missing"} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Fatalf("metrics response %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="/files/:slug",status="204"} 2`,
+		`http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="/files/:slug"} 2`,
+		"# TYPE go_goroutines gauge",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+}
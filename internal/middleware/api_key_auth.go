@@ -1,14 +1,31 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	userStructs "ncobase/core/user/structs"
+	"strings"
+
+	"github.com/ncobase/ncore/consts"
 	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/net/resp"
 
 	"github.com/gin-gonic/gin"
 )
 
-// ApiKeyAuth middleware for API key authentication
-func ApiKeyAuth(sm *ServiceManager) gin.HandlerFunc {
+const (
+	// apiKeyHeader carries an API key
+	apiKeyHeader = "X-API-Key"
+	// apiKeyScheme is the Authorization scheme for API keys
+	apiKeyScheme = "ApiKey "
+	// apiKeyQuery is the query parameter carrying an API key when allowed
+	apiKeyQuery = "api_key"
+)
+
+// ApiKeyAuth middleware for API key authentication. Keys are read from the X-API-Key header
+// or the ApiKey Authorization scheme, and from the api_key query parameter only when allowQuery is set.
+func ApiKeyAuth(sm *ServiceManager, allowQuery bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Check if already authenticated
 		if ctxutil.GetUserID(c.Request.Context()) != "" {
@@ -16,32 +33,96 @@ func ApiKeyAuth(sm *ServiceManager) gin.HandlerFunc {
 			return
 		}
 
-		// Get API key from header or query parameter
-		apiKey := c.GetHeader("X-API-Key")
-		if apiKey == "" {
-			apiKey = c.Query("api_key")
-		}
-
 		// If no API key, proceed to next middleware
+		apiKey := extractApiKey(c, allowQuery)
 		if apiKey == "" {
 			c.Next()
 			return
 		}
 
-		// Get user service
-		usw := sm.UserServiceWrapper()
-		// Validate API key
-		key, err := usw.ValidateApiKey(c.Request.Context(), apiKey)
-		if err != nil {
-			resp.Fail(c.Writer, resp.UnAuthorized("Invalid API key"))
+		if err := handleApiKeyAuth(c, sm, apiKey); err != nil {
+			resp.Fail(c.Writer, resp.UnAuthorized(apiKeyFailure(err)))
 			c.Abort()
 			return
 		}
 
-		// Set user ID in context
-		ctx := ctxutil.SetUserID(c.Request.Context(), key.UserID)
+		c.Next()
+	}
+}
+
+// extractApiKey extracts API key from request. Query parameters end up in access logs,
+// proxies and browser history, so the query is only read when allowQuery is set.
+func extractApiKey(c *gin.Context, allowQuery bool) string {
+	if apiKey := c.GetHeader(apiKeyHeader); apiKey != "" {
+		return strings.TrimSpace(apiKey)
+	}
+
+	if authHeader := c.GetHeader(consts.AuthorizationKey); strings.HasPrefix(authHeader, apiKeyScheme) {
+		return strings.TrimSpace(strings.TrimPrefix(authHeader, apiKeyScheme))
+	}
+
+	if allowQuery {
+		return strings.TrimSpace(c.Query(apiKeyQuery))
+	}
+	return ""
+}
+
+// handleApiKeyAuth validates the API key and sets the same user context as a session,
+// bound to the key's space and limited to its scopes
+func handleApiKeyAuth(c *gin.Context, sm *ServiceManager, apiKey string) error {
+	ctx := c.Request.Context()
+	usw := sm.UserServiceWrapper()
+
+	key, err := usw.ValidateApiKey(ctx, apiKey)
+	if err != nil {
+		logger.Debugf(ctx, "API key validation failed: %v", err)
+		return err
+	}
+
+	// The key's space wins over the request header
+	if key.SpaceID != "" {
+		if ok, err := sm.SpaceServiceWrapper().IsSpaceInUser(ctx, key.SpaceID, key.UserID); err != nil || !ok {
+			logger.Warnf(ctx, "API key %s space %s no longer belongs to user %s", key.ID, key.SpaceID, key.UserID)
+			return userStructs.ErrApiKeyInvalid
+		}
+		ctx = ctxutil.SetSpaceID(ctx, key.SpaceID)
 		c.Request = c.Request.WithContext(ctx)
+		c.Set("api_key_space_id", key.SpaceID)
+	}
 
-		c.Next()
+	ctx = setCompleteUserContext(c, key.UserID, key.Scopes, sm)
+	c.Request = c.Request.WithContext(ctx)
+
+	c.Set("api_key_id", key.ID)
+	c.Set("auth_method", "api_key")
+
+	return nil
+}
+
+// apiKeyFailure returns the client message for an API key validation error
+func apiKeyFailure(err error) string {
+	switch {
+	case errors.Is(err, userStructs.ErrApiKeyExpired):
+		return "API key expired"
+	case errors.Is(err, userStructs.ErrApiKeyRevoked):
+		return "API key revoked"
+	default:
+		return "Invalid API key"
+	}
+}
+
+// restrictToScopes keeps the scopes the principal holds, so a key never
+// grants more than its owner currently has
+func restrictToScopes(ctx context.Context, permissions []string, isAdmin bool, scopes []string) []string {
+	unrestricted := isAdmin || hasWildcardPermission(permissions)
+
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if unrestricted || hasSpecificPermission(permissions, scope) {
+			result = append(result, scope)
+			continue
+		}
+		logger.Debugf(ctx, "API key scope %s not held by owner", scope)
 	}
+	return result
 }
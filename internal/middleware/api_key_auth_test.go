@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	userStructs "ncobase/core/user/structs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/consts"
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+// apiKeys validates API keys from memory
+type apiKeys map[string]*userStructs.ApiKey
+
+func (k apiKeys) ValidateApiKey(_ context.Context, key string) (*userStructs.ApiKey, error) {
+	apiKey, ok := k[key]
+	if !ok {
+		return nil, userStructs.ErrApiKeyInvalid
+	}
+	now := int64(1_700_000_000_000)
+	if apiKey.IsRevoked() {
+		return nil, userStructs.ErrApiKeyRevoked
+	}
+	if apiKey.IsExpired(now) {
+		return nil, userStructs.ErrApiKeyExpired
+	}
+	return apiKey, nil
+}
+
+// spaceMembers reports the spaces users belong to
+type spaceMembers map[string]string
+
+func (m spaceMembers) IsSpaceInUser(_ context.Context, spaceID, userID string) (bool, error) {
+	return m[userID] == spaceID, nil
+}
+
+// apiKeyRequest sends a request with the given header through ApiKeyAuth,
+// returning the response and the user and space the handler saw
+func apiKeyRequest(sm *ServiceManager, header, value string) (w *httptest.ResponseRecorder, userID, spaceID string) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ApiKeyAuth(sm, false))
+	engine.GET("/api/files", func(c *gin.Context) {
+		userID = ctxutil.GetUserID(c.Request.Context())
+		spaceID = ctxutil.GetSpaceID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/api/files", nil)
+	if header != "" {
+		r.Header.Set(header, value)
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, r)
+	return w, userID, spaceID
+}
+
+func newApiKeyManager() *ServiceManager {
+	past, future, revoked := int64(1_600_000_000_000), int64(1_800_000_000_000), int64(1_650_000_000_000)
+	return &ServiceManager{em: crossServices{services: map[string]any{
+		"ApiKey": apiKeys{
+			"valid":   {ID: "k1", UserID: "u1", ExpiredAt: &future},
+			"bound":   {ID: "k2", UserID: "u1", SpaceID: "s1"},
+			"foreign": {ID: "k3", UserID: "u1", SpaceID: "s2"},
+			"expired": {ID: "k4", UserID: "u1", ExpiredAt: &past},
+			"revoked": {ID: "k5", UserID: "u1", RevokedAt: &revoked},
+		},
+		"UserSpace": spaceMembers{"u1": "s1"},
+	}}}
+}
+
+func TestApiKeyAuthAcceptsValidKey(t *testing.T) {
+	sm := newApiKeyManager()
+
+	for header, value := range map[string]string{
+		apiKeyHeader:            "valid",
+		consts.AuthorizationKey: "ApiKey valid",
+	} {
+		w, userID, _ := apiKeyRequest(sm, header, value)
+		if w.Code != http.StatusOK || userID != "u1" {
+			t.Errorf("%s: status %d, user %q, want u1 authenticated", header, w.Code, userID)
+		}
+	}
+
+	w, userID, spaceID := apiKeyRequest(sm, apiKeyHeader, "bound")
+	if w.Code != http.StatusOK || userID != "u1" || spaceID != "s1" {
+		t.Fatalf("space bound key: status %d, user %q, space %q, want u1 in s1", w.Code, userID, spaceID)
+	}
+}
+
+func TestApiKeyAuthRejects(t *testing.T) {
+	sm := newApiKeyManager()
+
+	for key, message := range map[string]string{
+		"expired": "API key expired",
+		"revoked": "API key revoked",
+		"unknown": "Invalid API key",
+		"foreign": "Invalid API key",
+	} {
+		w, userID, _ := apiKeyRequest(sm, apiKeyHeader, key)
+		if w.Code != http.StatusUnauthorized || userID != "" {
+			t.Errorf("%s key: status %d, user %q, want 401", key, w.Code, userID)
+		}
+		if !strings.Contains(w.Body.String(), message) {
+			t.Errorf("%s key: body %s, want %q", key, w.Body.String(), message)
+		}
+	}
+}
+
+func TestApiKeyAuthWithoutKeyPasses(t *testing.T) {
+	w, userID, _ := apiKeyRequest(newApiKeyManager(), consts.AuthorizationKey, "Bearer token")
+	if w.Code != http.StatusOK || userID != "" {
+		t.Fatalf("status %d, user %q, want the request passed on unauthenticated", w.Code, userID)
+	}
+}
+
+func TestApiKeyAuthQueryNeedsOptIn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	for allowQuery, want := range map[bool]string{false: "", true: "u1"} {
+		engine := gin.New()
+		engine.Use(ApiKeyAuth(newApiKeyManager(), allowQuery))
+		var userID string
+		engine.GET("/api/files", func(c *gin.Context) {
+			userID = ctxutil.GetUserID(c.Request.Context())
+		})
+
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/files?api_key=valid", nil))
+		if w.Code != http.StatusOK || userID != want {
+			t.Errorf("query key with allowQuery %v: status %d, user %q, want %q", allowQuery, w.Code, userID, want)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/ncobase/ncore/consts"
 	"github.com/ncobase/ncore/ctxutil"
 	ext "github.com/ncobase/ncore/extension/types"
@@ -9,8 +12,19 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// ConsumeSpace consumes space information from request header or user spaces
-func ConsumeSpace(em ext.ManagerInterface, whiteList []string) gin.HandlerFunc {
+// Host resolution modes of ConsumeSpace
+const (
+	// SpaceHostOff ignores the request host
+	SpaceHostOff = ""
+	// SpaceHostFallback uses the host when no space header is sent
+	SpaceHostFallback = "fallback"
+	// SpaceHostPreferred uses the host over the space header
+	SpaceHostPreferred = "preferred"
+)
+
+// ConsumeSpace consumes space information from request header, request host or user spaces.
+// hostMode controls whether the X-Forwarded-Host or Host header is matched against space URLs.
+func ConsumeSpace(em ext.ManagerInterface, whiteList []string, hostMode string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if shouldSkipPath(c.Request, whiteList) {
 			c.Next()
@@ -26,6 +40,19 @@ func ConsumeSpace(em ext.ManagerInterface, whiteList []string) gin.HandlerFunc {
 		// Get space wrapper
 		tsw := sw.SpaceServiceWrapper()
 
+		// A space-bound API key pins the request space
+		keySpaceID := c.GetString("api_key_space_id")
+		if keySpaceID != "" {
+			spaceID = keySpaceID
+		}
+
+		// Resolve space from the request host
+		if keySpaceID == "" && (hostMode == SpaceHostPreferred || (hostMode == SpaceHostFallback && spaceID == "")) {
+			if hostSpaceID := resolveHostSpace(ctx, tsw, c.Request); hostSpaceID != "" {
+				spaceID = hostSpaceID
+			}
+		}
+
 		// Validate space ID belongs to user if both provided
 		if spaceID != "" && userID != "" {
 			if isValid, err := tsw.IsSpaceInUser(ctx, spaceID, userID); err != nil || !isValid {
@@ -40,16 +67,7 @@ func ConsumeSpace(em ext.ManagerInterface, whiteList []string) gin.HandlerFunc {
 
 			if spaceID == "" {
 				logger.Info(ctx, "space not found in header or context, trying to fetch from user spaces")
-
-				// Try to get default space first
-				if space, err := tsw.GetUserDefaultSpace(ctx, userID); err == nil && space != nil {
-					spaceID = space.ID
-				} else {
-					// Get any space user belongs to
-					if spaces, err := tsw.GetUserSpaces(ctx, userID); err == nil && len(spaces) > 0 {
-						spaceID = spaces[0].ID
-					}
-				}
+				spaceID = resolveUserSpace(ctx, tsw, userID)
 			}
 		}
 
@@ -64,3 +82,64 @@ func ConsumeSpace(em ext.ManagerInterface, whiteList []string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// resolveUserSpace returns the default space of userID, falling back to any space
+// the user belongs to. Lookup failures, nil results and panics in the space
+// service are logged once and yield no space instead of failing the request.
+func resolveUserSpace(ctx context.Context, tsw *SpaceServiceWrapper, userID string) (spaceID string) {
+	var lookupErr error
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf(ctx, "Space lookup panicked for user %s: %v", userID, r)
+			spaceID = ""
+			return
+		}
+		if spaceID == "" && lookupErr != nil {
+			logger.Warnf(ctx, "Failed to resolve space for user %s: %v", userID, lookupErr)
+		}
+	}()
+
+	if tsw == nil {
+		return ""
+	}
+
+	// Try to get default space first
+	space, err := tsw.GetUserDefaultSpace(ctx, userID)
+	if err != nil {
+		lookupErr = err
+	} else if space != nil && space.ID != "" {
+		return space.ID
+	}
+
+	// Get any space user belongs to
+	spaces, err := tsw.GetUserSpaces(ctx, userID)
+	if err != nil {
+		lookupErr = err
+		return ""
+	}
+	for _, space := range spaces {
+		if space != nil && space.ID != "" {
+			return space.ID
+		}
+	}
+	return ""
+}
+
+// resolveHostSpace returns the space served on the request host, preferring
+// X-Forwarded-Host set by a proxy. An unknown host yields no space.
+func resolveHostSpace(ctx context.Context, tsw *SpaceServiceWrapper, r *http.Request) string {
+	host := r.Header.Get("X-Forwarded-Host")
+	if host == "" {
+		host = r.Host
+	}
+	if host == "" || tsw == nil {
+		return ""
+	}
+
+	spaceID, err := tsw.GetSpaceIDByHost(ctx, host)
+	if err != nil {
+		logger.Warnf(ctx, "Failed to resolve space for host %s: %v", host, err)
+		return ""
+	}
+	return spaceID
+}
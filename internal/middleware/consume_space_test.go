@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	spaceStructs "ncobase/core/space/structs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/consts"
+	"github.com/ncobase/ncore/ctxutil"
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+// crossServices serves the cross services of the space extension, keyed by service path
+type crossServices struct {
+	ext.ManagerInterface
+	services map[string]any
+}
+
+func (m crossServices) GetCrossService(_, servicePath string) (any, error) {
+	if svc, ok := m.services[servicePath]; ok {
+		return svc, nil
+	}
+	return nil, errors.New("service not found")
+}
+
+// userSpaces answers user space lookups, panicking when asked to
+type userSpaces struct {
+	defaultSpace *spaceStructs.ReadSpace
+	defaultErr   error
+	spaces       []*spaceStructs.ReadSpace
+	spacesErr    error
+	panics       bool
+}
+
+func (u userSpaces) UserBelongSpace(_ context.Context, _ string) (*spaceStructs.ReadSpace, error) {
+	if u.panics {
+		panic("nil map")
+	}
+	return u.defaultSpace, u.defaultErr
+}
+
+func (u userSpaces) UserBelongSpaces(_ context.Context, _ string) ([]*spaceStructs.ReadSpace, error) {
+	return u.spaces, u.spacesErr
+}
+
+type hostSpaces map[string]string
+
+func (h hostSpaces) GetIDByHost(_ context.Context, host string) (string, error) {
+	if id, ok := h[host]; ok {
+		return id, nil
+	}
+	return "", errors.New("not found")
+}
+
+func spaceWrapper(services map[string]any) *SpaceServiceWrapper {
+	return &SpaceServiceWrapper{em: crossServices{services: services}}
+}
+
+func TestResolveUserSpace(t *testing.T) {
+	failed := errors.New("connection refused")
+	for _, tc := range []struct {
+		name    string
+		service any
+		want    string
+	}{
+		{"default space", userSpaces{defaultSpace: &spaceStructs.ReadSpace{ID: "s1"}}, "s1"},
+		{"nil default falls back", userSpaces{spaces: []*spaceStructs.ReadSpace{nil, {ID: ""}, {ID: "s2"}}}, "s2"},
+		{"failed default falls back", userSpaces{defaultErr: failed, spaces: []*spaceStructs.ReadSpace{{ID: "s2"}}}, "s2"},
+		{"both lookups fail", userSpaces{defaultErr: failed, spacesErr: failed}, ""},
+		{"no spaces", userSpaces{}, ""},
+		{"panicking service", userSpaces{panics: true}, ""},
+		{"unexpected service type", "not a service", ""},
+	} {
+		tsw := spaceWrapper(map[string]any{"UserSpace": tc.service})
+		if got := resolveUserSpace(context.Background(), tsw, "u1"); got != tc.want {
+			t.Errorf("%s: space %q, want %q", tc.name, got, tc.want)
+		}
+	}
+
+	if got := resolveUserSpace(context.Background(), nil, "u1"); got != "" {
+		t.Fatalf("space %q without a space service, want none", got)
+	}
+}
+
+func TestResolveHostSpace(t *testing.T) {
+	tsw := spaceWrapper(map[string]any{"Space": hostSpaces{"acme.example.com": "s1"}})
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Host = "proxy.internal"
+	r.Header.Set("X-Forwarded-Host", "acme.example.com")
+	if got := resolveHostSpace(context.Background(), tsw, r); got != "s1" {
+		t.Fatalf("forwarded host space %q, want s1", got)
+	}
+
+	r.Header.Del("X-Forwarded-Host")
+	if got := resolveHostSpace(context.Background(), tsw, r); got != "" {
+		t.Fatalf("unknown host space %q, want none", got)
+	}
+	if got := resolveHostSpace(context.Background(), spaceWrapper(nil), r); got != "" {
+		t.Fatalf("space %q without a space service, want none", got)
+	}
+}
+
+// withServiceManager makes em serve the service wrappers for the rest of the test
+func withServiceManager(t *testing.T, em ext.ManagerInterface) {
+	t.Helper()
+	managerOnce.Do(func() {})
+	previous := serviceManager
+	serviceManager = &ServiceManager{em: em}
+	t.Cleanup(func() { serviceManager = previous })
+}
+
+// requestSpace runs a request through ConsumeSpace and returns the space it resolved
+func requestSpace(hostMode, host, header, userID string) string {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if userID != "" {
+			c.Request = c.Request.WithContext(ctxutil.SetUserID(c.Request.Context(), userID))
+		}
+	})
+	engine.Use(ConsumeSpace(nil, nil, hostMode))
+
+	var spaceID string
+	engine.GET("/api/files", func(c *gin.Context) {
+		spaceID = ctxutil.GetSpaceID(c.Request.Context())
+	})
+
+	r := httptest.NewRequest("GET", "/api/files", nil)
+	r.Host = host
+	if header != "" {
+		r.Header.Set(consts.SpaceKey, header)
+	}
+	engine.ServeHTTP(httptest.NewRecorder(), r)
+	return spaceID
+}
+
+func TestConsumeSpaceFromHost(t *testing.T) {
+	withServiceManager(t, crossServices{services: map[string]any{
+		"Space":     hostSpaces{"acme.example.com": "s1"},
+		"UserSpace": userSpaces{defaultSpace: &spaceStructs.ReadSpace{ID: "s2"}},
+	}})
+
+	for _, tc := range []struct {
+		name, mode, host, header, user, want string
+	}{
+		{"known host", SpaceHostFallback, "acme.example.com", "", "", "s1"},
+		{"header overrides host", SpaceHostFallback, "acme.example.com", "s9", "", "s9"},
+		{"host overrides header", SpaceHostPreferred, "acme.example.com", "s9", "", "s1"},
+		{"unknown host keeps header", SpaceHostPreferred, "other.example.com", "s9", "", "s9"},
+		{"unknown host falls back to user space", SpaceHostFallback, "other.example.com", "", "u1", "s2"},
+		{"host resolution off", SpaceHostOff, "acme.example.com", "", "", ""},
+	} {
+		if got := requestSpace(tc.mode, tc.host, tc.header, tc.user); got != tc.want {
+			t.Errorf("%s: space %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
@@ -20,8 +20,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// ConsumeUser middleware supports both JWT token and session cookie authentication
-func ConsumeUser(em ext.ManagerInterface, whiteList []string) gin.HandlerFunc {
+// ConsumeUser middleware supports JWT token, API key and session cookie authentication.
+// API keys in the query are only accepted when allowQueryApiKey is set.
+func ConsumeUser(em ext.ManagerInterface, whiteList []string, allowQueryApiKey bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if shouldSkipPath(c.Request, whiteList) {
 			c.Next()
@@ -35,13 +36,24 @@ func ConsumeUser(em ext.ManagerInterface, whiteList []string) gin.HandlerFunc {
 		if token := extractToken(c); token != "" {
 			asw := sm.AuthServiceWrapper()
 			if jtm := asw.GetTokenManager(); jtm != nil {
-				if handleTokenAuth(c, jtm, token) {
+				if handleTokenAuth(c, asw, jtm, token) {
 					c.Next()
 					return
 				}
 			}
 		}
 
+		// Try API key authentication, a presented but unusable key is rejected
+		if apiKey := extractApiKey(c, allowQueryApiKey); apiKey != "" {
+			if err := handleApiKeyAuth(c, sm, apiKey); err != nil {
+				resp.Fail(c.Writer, resp.UnAuthorized(apiKeyFailure(err)))
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
 		// Try session cookie authentication
 		if sessionID, err := cookie.GetSessionID(c.Request); err == nil && sessionID != "" {
 			if handleSessionAuth(c, sm, sessionID) {
@@ -72,14 +84,19 @@ func extractToken(c *gin.Context) string {
 	return ""
 }
 
-// handleTokenAuth handles JWT token authentication
-func handleTokenAuth(c *gin.Context, jtm *jwt.TokenManager, token string) bool {
+// handleTokenAuth handles JWT token authentication, tokens of revoked sessions are rejected
+func handleTokenAuth(c *gin.Context, asw *AuthServiceWrapper, jtm *jwt.TokenManager, token string) bool {
 	claims, err := jtm.DecodeToken(token)
 	if err != nil {
 		logger.Debugf(c.Request.Context(), "Token validation failed: %v", err)
 		return false
 	}
 
+	if tokenID, _ := claims["jti"].(string); asw.IsTokenRevoked(c.Request.Context(), tokenID) {
+		logger.Debugf(c.Request.Context(), "Token %s belongs to a revoked session", tokenID)
+		return false
+	}
+
 	// Set user context from token claims
 	ctx := setUserContextFromToken(c, claims)
 	c.Request = c.Request.WithContext(ctx)
@@ -194,12 +211,21 @@ func setUserContextFromToken(c *gin.Context, claims map[string]any) context.Cont
 
 // setCompleteUserContextFromSession sets complete user context from session data
 func setCompleteUserContextFromSession(c *gin.Context, session *authStructs.ReadSession, sm *ServiceManager) context.Context {
+	ctx := setCompleteUserContext(c, session.UserID, nil, sm)
+
+	c.Set("session_id", session.ID)
+	c.Set("auth_method", "session")
+	return ctx
+}
+
+// setCompleteUserContext loads user details, spaces, roles and permissions into the context,
+// non-empty scopes limit the permissions and drop admin status
+func setCompleteUserContext(c *gin.Context, userID string, scopes []string, sm *ServiceManager) context.Context {
 	ctx := c.Request.Context()
 	if _, ok := ctxutil.GetGinContext(ctx); !ok {
 		ctx = ctxutil.WithGinContext(ctx, c)
 	}
 
-	userID := session.UserID
 	ctx = ctxutil.SetUserID(ctx, userID)
 
 	// Get service wrappers
@@ -291,6 +317,12 @@ func setCompleteUserContextFromSession(c *gin.Context, session *authStructs.Read
 		}
 	}
 
+	// Scoped credentials only keep the granted permissions
+	if len(scopes) > 0 {
+		permissions = restrictToScopes(ctx, permissions, isAdmin, scopes)
+		isAdmin = false
+	}
+
 	// Set context values
 	ctx = ctxutil.SetUserRoles(ctx, roles)
 	ctx = ctxutil.SetUserPermissions(ctx, permissions)
@@ -298,8 +330,6 @@ func setCompleteUserContextFromSession(c *gin.Context, session *authStructs.Read
 
 	// Set in Gin context for compatibility
 	c.Set("user_id", userID)
-	c.Set("session_id", session.ID)
-	c.Set("auth_method", "session")
 	c.Set("roles", roles)
 	c.Set("permissions", permissions)
 	c.Set("is_admin", isAdmin)
@@ -540,7 +570,7 @@ func RequireTokenAuth(em ext.ManagerInterface) gin.HandlerFunc {
 		// get access wrapper
 		asw := sm.AuthServiceWrapper()
 		if jtm := asw.GetTokenManager(); jtm != nil {
-			if !handleTokenAuth(c, jtm, token) {
+			if !handleTokenAuth(c, asw, jtm, token) {
 				resp.Fail(c.Writer, resp.UnAuthorized("Invalid JWT token"))
 				c.Abort()
 				return
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/consts"
+	"github.com/ncobase/ncore/ctxutil"
+	ext "github.com/ncobase/ncore/extension/types"
+	"github.com/ncobase/ncore/security/jwt"
+)
+
+// authExtension provides the token manager of the auth extension
+type authExtension struct {
+	ext.Interface
+	jtm *jwt.TokenManager
+}
+
+func (a authExtension) GetTokenManager() *jwt.TokenManager { return a.jtm }
+
+// authServices serves the auth extension besides the cross services
+type authServices struct {
+	crossServices
+	auth authExtension
+}
+
+func (m authServices) GetExtensionByName(_ string) (ext.Interface, error) {
+	return m.auth, nil
+}
+
+// revokedTokens answers revocation checks of the session service
+type revokedTokens map[string]bool
+
+func (r revokedTokens) IsTokenRevoked(_ context.Context, tokenID string) bool {
+	return r[tokenID]
+}
+
+// requestUser runs a request with token through ConsumeUser and returns the user it resolved
+func requestUser(token string) string {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(ConsumeUser(nil, nil, false))
+
+	var userID string
+	engine.GET("/api/files", func(c *gin.Context) {
+		userID = ctxutil.GetUserID(c.Request.Context())
+	})
+
+	r := httptest.NewRequest("GET", "/api/files", nil)
+	r.Header.Set(consts.AuthorizationKey, consts.BearerKey+token)
+	engine.ServeHTTP(httptest.NewRecorder(), r)
+	return userID
+}
+
+func TestConsumeUserRejectsRevokedTokens(t *testing.T) {
+	jtm := jwt.NewTokenManager("test-secret")
+	withServiceManager(t, authServices{
+		crossServices: crossServices{services: map[string]any{"Session": revokedTokens{"t1": true}}},
+		auth:          authExtension{jtm: jtm},
+	})
+
+	tokens := map[string]string{}
+	for _, tokenID := range []string{"t1", "t2"} {
+		token, err := jtm.GenerateAccessToken(tokenID, map[string]any{"user_id": "u1"})
+		if err != nil {
+			t.Fatalf("GenerateAccessToken: %v", err)
+		}
+		tokens[tokenID] = token
+	}
+
+	if got := requestUser(tokens["t1"]); got != "" {
+		t.Fatalf("revoked token authenticated %q", got)
+	}
+	if got := requestUser(tokens["t2"]); got != "u1" {
+		t.Fatalf("other session authenticated %q, want u1", got)
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/ecode"
+	ext "github.com/ncobase/ncore/extension/types"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// FeatureFlag hides a route behind a feature flag.
+// While the flag is off for the current space and user the route answers 404,
+// as if it did not exist. Must run after the user and space are consumed.
+func FeatureFlag(em ext.ManagerInterface, flag string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		enabled, err := GetServiceManager(em).SystemServiceWrapper().IsFeatureEnabled(ctx, flag)
+		if err != nil {
+			logger.Debugf(ctx, "Feature flag %s unavailable: %v", flag, err)
+		}
+		if !enabled {
+			resp.Fail(c.Writer, resp.NotFound(ecode.Text(http.StatusNotFound)))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/ctxutil"
+	ext "github.com/ncobase/ncore/extension/types"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// maintenanceEndpoint stays reachable so maintenance can always be turned off
+const maintenanceEndpoint = "/sys/admin/maintenance"
+
+// defaultMaintenanceMessage is returned when the state has no message
+const defaultMaintenanceMessage = "Service is under maintenance, please retry later"
+
+// MaintenanceMode rejects requests with 503 while maintenance is on.
+// In read_only mode only mutating requests are rejected, in offline mode all are.
+// Admins and allowlisted IPs or users pass through, as do whitelisted paths.
+func MaintenanceMode(em ext.ManagerInterface, whiteList []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.URL.Path == maintenanceEndpoint || shouldSkipPath(c.Request, whiteList) {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		state, err := GetServiceManager(em).SystemServiceWrapper().GetMaintenanceState(ctx)
+		if err != nil {
+			logger.Debugf(ctx, "Maintenance state unavailable: %v", err)
+			c.Next()
+			return
+		}
+		if !state.Blocks(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if ctxutil.GetUserIsAdmin(ctx) || state.Allows(ctxutil.GetClientIP(ctx), ctxutil.GetUserID(ctx)) {
+			c.Next()
+			return
+		}
+
+		message := state.Message
+		if message == "" {
+			message = defaultMaintenanceMessage
+		}
+		if state.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(state.RetryAfter))
+		}
+		resp.Fail(c.Writer, resp.ServiceUnavailable(message, map[string]any{
+			"mode":        state.Mode,
+			"retry_after": state.RetryAfter,
+		}))
+		c.Abort()
+	}
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	systemStructs "ncobase/core/system/structs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+// maintenanceState serves a fixed maintenance state
+type maintenanceState struct {
+	state *systemStructs.MaintenanceState
+}
+
+func (m maintenanceState) Get(_ context.Context) (*systemStructs.MaintenanceState, error) {
+	return m.state, nil
+}
+
+// requester sets the user, admin flag and client IP of a request
+type requester struct {
+	userID, clientIP string
+	admin            bool
+}
+
+// maintenanceRequest runs a request by who through MaintenanceMode and returns the response
+func maintenanceRequest(method, path string, who requester) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if who.userID != "" {
+			ctx = ctxutil.SetUserID(ctx, who.userID)
+		}
+		if who.clientIP != "" {
+			ctx = ctxutil.SetClientIP(ctx, who.clientIP)
+		}
+		c.Request = c.Request.WithContext(ctxutil.SetUserIsAdmin(ctx, who.admin))
+	})
+	engine.Use(MaintenanceMode(nil, nil))
+	engine.Handle(method, path, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func withMaintenance(t *testing.T, state *systemStructs.MaintenanceState) {
+	t.Helper()
+	withServiceManager(t, crossServices{services: map[string]any{"Maintenance": maintenanceState{state: state}}})
+}
+
+func TestMaintenanceBlocksNormalUser(t *testing.T) {
+	withMaintenance(t, &systemStructs.MaintenanceState{Mode: systemStructs.MaintenanceOffline, RetryAfter: 120})
+
+	w := maintenanceRequest(http.MethodGet, "/api/files", requester{userID: "u1", clientIP: "203.0.113.9"})
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status %d, want 503", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "120" {
+		t.Fatalf("Retry-After = %q, want 120", w.Header().Get("Retry-After"))
+	}
+
+	// the endpoint turning maintenance off stays reachable
+	if w := maintenanceRequest(http.MethodPut, maintenanceEndpoint, requester{userID: "u1"}); w.Code != http.StatusOK {
+		t.Fatalf("maintenance endpoint status %d, want 200", w.Code)
+	}
+}
+
+func TestMaintenanceReadOnlyBlocksWrites(t *testing.T) {
+	withMaintenance(t, &systemStructs.MaintenanceState{Mode: systemStructs.MaintenanceReadOnly})
+
+	if w := maintenanceRequest(http.MethodGet, "/api/files", requester{userID: "u1"}); w.Code != http.StatusOK {
+		t.Fatalf("read status %d, want 200", w.Code)
+	}
+	if w := maintenanceRequest(http.MethodPost, "/api/files", requester{userID: "u1"}); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("write status %d, want 503", w.Code)
+	}
+}
+
+func TestMaintenanceAllowsAllowlisted(t *testing.T) {
+	withMaintenance(t, &systemStructs.MaintenanceState{
+		Mode:       systemStructs.MaintenanceOffline,
+		AllowIPs:   []string{"10.0.0.0/8", "192.0.2.7"},
+		AllowUsers: []string{"ops"},
+	})
+
+	for name, who := range map[string]requester{
+		"admin":          {userID: "root", admin: true},
+		"allowed user":   {userID: "ops"},
+		"allowed range":  {userID: "u1", clientIP: "10.1.2.3"},
+		"allowed single": {clientIP: "192.0.2.7"},
+	} {
+		if w := maintenanceRequest(http.MethodPost, "/api/files", who); w.Code != http.StatusOK {
+			t.Errorf("%s: status %d, want 200", name, w.Code)
+		}
+	}
+	if w := maintenanceRequest(http.MethodPost, "/api/files", requester{userID: "u1", clientIP: "192.0.2.8"}); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("other client status %d, want 503", w.Code)
+	}
+}
+
+func TestMaintenanceOffPassesEverything(t *testing.T) {
+	withMaintenance(t, &systemStructs.MaintenanceState{Mode: systemStructs.MaintenanceOff})
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+		if w := maintenanceRequest(method, "/api/files", requester{userID: "u1"}); w.Code != http.StatusOK {
+			t.Errorf("%s status %d, want 200", method, w.Code)
+		}
+	}
+}
+
+func TestMaintenanceUnavailablePasses(t *testing.T) {
+	withServiceManager(t, crossServices{})
+
+	if w := maintenanceRequest(http.MethodPost, "/api/files", requester{userID: "u1"}); w.Code != http.StatusOK {
+		t.Fatalf("status %d without a maintenance service, want 200", w.Code)
+	}
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MIMEMsgPack is the media type clients accept to receive MessagePack responses
+const MIMEMsgPack = "application/msgpack"
+
+// msgpackTypes are the media types accepted as MessagePack, the x- form is still common
+var msgpackTypes = []string{MIMEMsgPack, "application/x-msgpack"}
+
+// ResponseEncoding negotiates the response encoding from the Accept header.
+// JSON stays the default; clients preferring MessagePack get JSON bodies re-encoded,
+// so handlers, resp.Success and resp.Fail need no changes. Other bodies such as
+// downloads and event streams pass through untouched.
+func ResponseEncoding(c *gin.Context) {
+	c.Writer.Header().Add("Vary", "Accept")
+
+	if !prefersMsgPack(c.GetHeader("Accept")) {
+		c.Next()
+		return
+	}
+
+	c.Writer = &msgpackWriter{ResponseWriter: c.Writer}
+	c.Next()
+}
+
+// msgpackWriter re-encodes a JSON body written in one piece as MessagePack.
+// Only the first write is considered, a body written in chunks is streamed as JSON.
+type msgpackWriter struct {
+	gin.ResponseWriter
+	started bool
+}
+
+// Write re-encodes b when it is a complete JSON body
+func (w *msgpackWriter) Write(b []byte) (int, error) {
+	if w.started {
+		return w.ResponseWriter.Write(b)
+	}
+	w.started = true
+
+	if !isJSON(w.Header().Get("Content-Type")) {
+		return w.ResponseWriter.Write(b)
+	}
+	packed, err := jsonToMsgPack(b)
+	if err != nil {
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.Header().Set("Content-Type", MIMEMsgPack)
+	w.Header().Del("Content-Length")
+	if _, err := w.ResponseWriter.Write(packed); err != nil {
+		return 0, err
+	}
+	// Report the JSON length, callers check it against what they wrote
+	return len(b), nil
+}
+
+// WriteString re-encodes s like Write
+func (w *msgpackWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// prefersMsgPack reports whether the Accept header ranks MessagePack at least as high as JSON
+func prefersMsgPack(accept string) bool {
+	if accept == "" {
+		return false
+	}
+
+	var packQ, jsonQ float64
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		switch {
+		case slices.Contains(msgpackTypes, mediaType):
+			packQ = max(packQ, q)
+		case mediaType == gin.MIMEJSON:
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return packQ > 0 && packQ >= jsonQ
+}
+
+// isJSON reports whether a content type is JSON
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == gin.MIMEJSON
+}
+
+// jsonToMsgPack decodes one JSON value and encodes it as MessagePack.
+// It fails when b holds anything but a single complete value.
+func jsonToMsgPack(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.UseCompactInts(true)
+	if err := enc.Encode(packNumbers(v)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// packNumbers replaces JSON numbers by integers where they fit and floats otherwise
+func packNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, item := range v {
+			v[k] = packNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = packNumbers(item)
+		}
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/net/resp"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// encodedRequest runs a request through ResponseEncoding to a handler answering with resp
+func encodedRequest(path, accept string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ResponseEncoding)
+	r.GET("/ok", func(c *gin.Context) {
+		resp.Success(c.Writer, map[string]any{"id": "s1", "count": 3})
+	})
+	r.GET("/fail", func(c *gin.Context) {
+		resp.Fail(c.Writer, resp.BadRequest("name is required"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMsgPackResponses(t *testing.T) {
+	w := encodedRequest("/ok", "application/msgpack")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != MIMEMsgPack {
+		t.Fatalf("msgpack request got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	var body map[string]any
+	if err := msgpack.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode msgpack body: %v", err)
+	}
+	if body["id"] != "s1" || body["count"] != int8(3) {
+		t.Fatalf("msgpack body %v", body)
+	}
+
+	// errors are negotiated the same way
+	w = encodedRequest("/fail", "application/x-msgpack")
+	body = nil
+	if err := msgpack.Unmarshal(w.Body.Bytes(), &body); err != nil || w.Code != http.StatusBadRequest {
+		t.Fatalf("msgpack error %d: %v", w.Code, err)
+	}
+	if body["message"] != "name is required" {
+		t.Fatalf("msgpack error body %v", body)
+	}
+}
+
+func TestJSONStaysDefault(t *testing.T) {
+	for _, accept := range []string{"", "application/json", "application/json, application/msgpack;q=0.5", "*/*"} {
+		w := encodedRequest("/ok", accept)
+		var body map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Errorf("Accept %q got a body that is not JSON: %v", accept, err)
+		}
+		if w.Header().Get("Vary") != "Accept" {
+			t.Errorf("Accept %q response does not vary on Accept", accept)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	accessStructs "ncobase/core/access/structs"
 	authStructs "ncobase/core/auth/structs"
 	spaceStructs "ncobase/core/space/structs"
+	systemStructs "ncobase/core/system/structs"
 	userStructs "ncobase/core/user/structs"
 	"sync"
 
@@ -22,6 +23,7 @@ type ServiceManager struct {
 	userSvc   *UserServiceWrapper
 	accessSvc *AccessServiceWrapper
 	spaceSvc  *SpaceServiceWrapper
+	systemSvc *SystemServiceWrapper
 	once      sync.Once
 }
 
@@ -62,12 +64,19 @@ func (sm *ServiceManager) SpaceServiceWrapper() *SpaceServiceWrapper {
 	return sm.spaceSvc
 }
 
+// SystemServiceWrapper returns system service wrapper
+func (sm *ServiceManager) SystemServiceWrapper() *SystemServiceWrapper {
+	sm.once.Do(sm.initServices)
+	return sm.systemSvc
+}
+
 // initServices initializes all service wrappers
 func (sm *ServiceManager) initServices() {
 	sm.authSvc = &AuthServiceWrapper{em: sm.em}
 	sm.userSvc = &UserServiceWrapper{em: sm.em}
 	sm.accessSvc = &AccessServiceWrapper{em: sm.em}
 	sm.spaceSvc = &SpaceServiceWrapper{em: sm.em}
+	sm.systemSvc = &SystemServiceWrapper{em: sm.em}
 }
 
 // AuthServiceWrapper wraps auth service calls
@@ -147,6 +156,18 @@ func (w *AuthServiceWrapper) DeactivateSessionByTokenID(ctx context.Context, tok
 	return fmt.Errorf("session service not available")
 }
 
+// IsTokenRevoked reports whether the session of a token has been revoked
+func (w *AuthServiceWrapper) IsTokenRevoked(ctx context.Context, tokenID string) bool {
+	if svc, err := w.em.GetCrossService("auth", "Session"); err == nil {
+		if service, ok := svc.(interface {
+			IsTokenRevoked(context.Context, string) bool
+		}); ok {
+			return service.IsTokenRevoked(ctx, tokenID)
+		}
+	}
+	return false
+}
+
 // CleanupExpiredSessions cleans up expired sessions
 func (w *AuthServiceWrapper) CleanupExpiredSessions(ctx context.Context) error {
 	if svc, err := w.em.GetCrossService("auth", "Session"); err == nil {
@@ -294,6 +315,18 @@ func (w *SpaceServiceWrapper) GetUserDefaultSpace(ctx context.Context, userID st
 	return nil, fmt.Errorf("user space service not available")
 }
 
+// GetSpaceIDByHost gets the id of the space served on host
+func (w *SpaceServiceWrapper) GetSpaceIDByHost(ctx context.Context, host string) (string, error) {
+	if svc, err := w.em.GetCrossService("space", "Space"); err == nil {
+		if service, ok := svc.(interface {
+			GetIDByHost(context.Context, string) (string, error)
+		}); ok {
+			return service.GetIDByHost(ctx, host)
+		}
+	}
+	return "", fmt.Errorf("space service not available")
+}
+
 // IsSpaceInUser checks if space belongs to user
 func (w *SpaceServiceWrapper) IsSpaceInUser(ctx context.Context, spaceID, userID string) (bool, error) {
 	if svc, err := w.em.GetCrossService("space", "UserSpace"); err == nil {
@@ -317,3 +350,32 @@ func (w *SpaceServiceWrapper) ListSpaces(ctx context.Context, params *spaceStruc
 	}
 	return paging.Result[*spaceStructs.ReadSpace]{}, fmt.Errorf("space service not available")
 }
+
+// SystemServiceWrapper wraps system service calls
+type SystemServiceWrapper struct {
+	em ext.ManagerInterface
+}
+
+// GetMaintenanceState gets the current maintenance state
+func (w *SystemServiceWrapper) GetMaintenanceState(ctx context.Context) (*systemStructs.MaintenanceState, error) {
+	if svc, err := w.em.GetCrossService("system", "Maintenance"); err == nil {
+		if service, ok := svc.(interface {
+			Get(context.Context) (*systemStructs.MaintenanceState, error)
+		}); ok {
+			return service.Get(ctx)
+		}
+	}
+	return nil, fmt.Errorf("maintenance service not available")
+}
+
+// IsFeatureEnabled reports whether a feature flag is on for the current space and user
+func (w *SystemServiceWrapper) IsFeatureEnabled(ctx context.Context, flag string) (bool, error) {
+	if svc, err := w.em.GetCrossService("system", "FeatureFlag"); err == nil {
+		if service, ok := svc.(interface {
+			IsEnabled(context.Context, string) bool
+		}); ok {
+			return service.IsEnabled(ctx, flag), nil
+		}
+	}
+	return false, fmt.Errorf("feature flag service not available")
+}
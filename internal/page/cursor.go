@@ -0,0 +1,112 @@
+package page
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ncobase/ncore/data/paging"
+	"github.com/ncobase/ncore/ecode"
+)
+
+// ErrInvalidCursor is returned for a cursor that is malformed or was not issued by this server
+var ErrInvalidCursor = errors.New(ecode.FieldIsInvalid("cursor"))
+
+var (
+	cursorMu  sync.RWMutex
+	cursorKey = randomKey()
+)
+
+// Cursor is the position of an item in a list ordered by a sort key, then by ID
+type Cursor struct {
+	Key int64  // Sort key, usually the creation time
+	ID  string // ID of the item, breaking ties of the sort key
+}
+
+// SetCursorSecret sets the secret cursors are signed with.
+// Without one a random key is used, cursors then stop working on restart.
+func SetCursorSecret(secret string) {
+	if secret == "" {
+		return
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("ncobase page cursor"))
+
+	cursorMu.Lock()
+	cursorKey = mac.Sum(nil)
+	cursorMu.Unlock()
+}
+
+// EncodeCursor returns the opaque, signed form of a cursor
+func EncodeCursor(c Cursor) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(c.Key, 10) + ":" + c.ID))
+	return payload + "." + sign(payload)
+}
+
+// DecodeCursor verifies and decodes a cursor from EncodeCursor.
+// Any malformed or tampered cursor fails with ErrInvalidCursor.
+func DecodeCursor(s string) (Cursor, error) {
+	payload, signature, ok := strings.Cut(s, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(sign(payload))) {
+		return Cursor{}, ErrInvalidCursor
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	key, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{Key: n, ID: id}, nil
+}
+
+// SignCursors replaces the cursors paging.Paginate builds from GetCursorValue ("id:key")
+// by signed ones. Services return it in place of the paginated result.
+func SignCursors[T paging.CursorProvider](result paging.Result[T], err error) (paging.Result[T], error) {
+	if err != nil {
+		return result, err
+	}
+	result.Cursor = resign(result.Cursor)
+	result.NextCursor = resign(result.NextCursor)
+	result.PrevCursor = resign(result.PrevCursor)
+	return result, nil
+}
+
+// resign converts a cursor of paging.EncodeCursor into a signed cursor
+func resign(cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+	id, key, err := paging.DecodeCursor(cursor)
+	if err != nil {
+		return ""
+	}
+	return EncodeCursor(Cursor{Key: key, ID: id})
+}
+
+// sign returns the signature of a cursor payload
+func sign(payload string) string {
+	cursorMu.RLock()
+	mac := hmac.New(sha256.New, cursorKey)
+	cursorMu.RUnlock()
+
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// randomKey returns a process local signing key, used until SetCursorSecret is called
+func randomKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
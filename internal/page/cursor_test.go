@@ -0,0 +1,68 @@
+package page
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ncobase/ncore/data/paging"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	want := Cursor{Key: 1767225600000, ID: "f1:with-colon"}
+	got, err := DecodeCursor(EncodeCursor(want))
+	if err != nil || got != want {
+		t.Fatalf("DecodeCursor = %+v, %v, want %+v", got, err, want)
+	}
+}
+
+func TestCursorRejectsTampering(t *testing.T) {
+	cursor := EncodeCursor(Cursor{Key: 100, ID: "f1"})
+	payload, signature, _ := strings.Cut(cursor, ".")
+
+	// the same signature on another position
+	forged := base64.RawURLEncoding.EncodeToString([]byte("100:f2")) + "." + signature
+	// one character of the payload changed
+	mutated := []byte(payload)
+	mutated[0] ^= 1
+
+	for name, c := range map[string]string{
+		"forged id":         forged,
+		"mutated payload":   string(mutated) + "." + signature,
+		"missing signature": payload,
+		"legacy cursor":     paging.EncodeCursor("f1:100"),
+		"empty":             "",
+	} {
+		if _, err := DecodeCursor(c); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s cursor = %v, want ErrInvalidCursor", name, err)
+		}
+	}
+	if ErrInvalidCursor.Error() != "cursor invalid" {
+		t.Errorf("error %q, want the invalid field message", ErrInvalidCursor)
+	}
+}
+
+func TestCursorSecretIsShared(t *testing.T) {
+	SetCursorSecret("one")
+	cursor := EncodeCursor(Cursor{Key: 1, ID: "s1"})
+	SetCursorSecret("two")
+	if _, err := DecodeCursor(cursor); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("cursor of another secret = %v, want ErrInvalidCursor", err)
+	}
+	SetCursorSecret("one")
+	if _, err := DecodeCursor(cursor); err != nil {
+		t.Fatalf("cursor of the same secret: %v", err)
+	}
+}
+
+func TestSignCursors(t *testing.T) {
+	result, err := SignCursors(paging.Result[item]{NextCursor: paging.EncodeCursor("b:42")}, nil)
+	if err != nil {
+		t.Fatalf("SignCursors: %v", err)
+	}
+	c, err := DecodeCursor(result.NextCursor)
+	if err != nil || c.ID != "b" || c.Key != 42 || result.PrevCursor != "" {
+		t.Fatalf("signed cursor %+v, %v", c, err)
+	}
+}
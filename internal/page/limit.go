@@ -0,0 +1,83 @@
+package page
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ncobase/ncore/ecode"
+	"github.com/spf13/viper"
+)
+
+// ErrInvalidLimit is returned for a list limit above the maximum of its module
+var ErrInvalidLimit = errors.New(ecode.FieldIsInvalid("limit"))
+
+// Limits are the default and maximum page size of a module
+type Limits struct {
+	Default int // Page size when the request sets none
+	Max     int // Largest page size a request may ask for
+}
+
+// builtinLimits apply when pagination.* is not configured
+var builtinLimits = Limits{Default: 20, Max: 100}
+
+var (
+	limitsMu      sync.RWMutex
+	defaultLimits = builtinLimits
+	moduleLimits  = map[string]Limits{}
+)
+
+// SetLimits reads page sizes from pagination.*: default_limit and max_limit apply to
+// every module, pagination.modules.<name> overrides them for one module.
+func SetLimits(v *viper.Viper) {
+	if v == nil {
+		return
+	}
+
+	base := readLimits(v, "pagination", builtinLimits)
+	modules := map[string]Limits{}
+	for name := range v.GetStringMap("pagination.modules") {
+		modules[name] = readLimits(v, "pagination.modules."+name, base)
+	}
+
+	limitsMu.Lock()
+	defaultLimits, moduleLimits = base, modules
+	limitsMu.Unlock()
+}
+
+// LimitsFor returns the page sizes of a module, e.g. "space" or "file"
+func LimitsFor(module string) Limits {
+	limitsMu.RLock()
+	defer limitsMu.RUnlock()
+	if l, ok := moduleLimits[module]; ok {
+		return l
+	}
+	return defaultLimits
+}
+
+// Limit returns the page size to use for a requested limit of a module.
+// An unset limit gets the module default, one above the maximum fails with ErrInvalidLimit.
+func Limit(module string, limit int) (int, error) {
+	l := LimitsFor(module)
+	if limit <= 0 {
+		return l.Default, nil
+	}
+	if limit > l.Max {
+		return 0, ErrInvalidLimit
+	}
+	return limit, nil
+}
+
+// readLimits reads default_limit and max_limit under prefix, keeping fallback for unset values
+func readLimits(v *viper.Viper, prefix string, fallback Limits) Limits {
+	l := fallback
+	if n := v.GetInt(prefix + ".default_limit"); n > 0 {
+		l.Default = n
+	}
+	if n := v.GetInt(prefix + ".max_limit"); n > 0 {
+		l.Max = n
+	}
+	if l.Default > l.Max {
+		l.Default = l.Max
+	}
+	return l
+}
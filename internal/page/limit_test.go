@@ -0,0 +1,59 @@
+package page
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestLimitDefaultsAndMaximum(t *testing.T) {
+	t.Cleanup(func() { SetLimits(viper.New()) })
+
+	SetLimits(viper.New())
+	for _, tc := range []struct {
+		requested, want int
+		err             error
+	}{
+		{0, 20, nil},
+		{-1, 20, nil},
+		{50, 50, nil},
+		{100, 100, nil},
+		{101, 0, ErrInvalidLimit},
+	} {
+		got, err := Limit("file", tc.requested)
+		if got != tc.want || !errors.Is(err, tc.err) {
+			t.Errorf("Limit(%d) = %d, %v, want %d, %v", tc.requested, got, err, tc.want, tc.err)
+		}
+	}
+	if ErrInvalidLimit.Error() != "limit invalid" {
+		t.Errorf("error %q, want the invalid field message", ErrInvalidLimit)
+	}
+}
+
+func TestLimitsPerModule(t *testing.T) {
+	t.Cleanup(func() { SetLimits(viper.New()) })
+
+	v := viper.New()
+	v.Set("pagination.default_limit", 10)
+	v.Set("pagination.max_limit", 50)
+	v.Set("pagination.modules.file.max_limit", 500)
+	v.Set("pagination.modules.space.default_limit", 80)
+	SetLimits(v)
+
+	for module, want := range map[string]Limits{
+		"file":  {Default: 10, Max: 500},
+		"space": {Default: 50, Max: 50}, // the default is capped by the maximum
+		"topic": {Default: 10, Max: 50},
+	} {
+		if got := LimitsFor(module); got != want {
+			t.Errorf("LimitsFor(%s) = %+v, want %+v", module, got, want)
+		}
+	}
+	if _, err := Limit("space", 51); !errors.Is(err, ErrInvalidLimit) {
+		t.Errorf("space limit 51 = %v, want ErrInvalidLimit", err)
+	}
+	if n, err := Limit("file", 300); n != 300 || err != nil {
+		t.Errorf("file limit 300 = %d, %v", n, err)
+	}
+}
@@ -0,0 +1,35 @@
+package page
+
+import "github.com/ncobase/ncore/data/paging"
+
+// Page is the list response envelope shared by all modules
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// New builds a page from a cursor paginated result
+func New[T paging.CursorProvider](result paging.Result[T]) *Page[T] {
+	items := result.Items
+	if items == nil {
+		items = []T{}
+	}
+	return &Page[T]{
+		Items:      items,
+		Total:      result.Total,
+		NextCursor: result.NextCursor,
+		PrevCursor: result.PrevCursor,
+		HasMore:    result.NextCursor != "",
+	}
+}
+
+// FromSlice builds a final page from an unpaginated list
+func FromSlice[T any](items []T) *Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return &Page[T]{Items: items, Total: len(items)}
+}
@@ -0,0 +1,76 @@
+package page
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ncobase/ncore/data/paging"
+)
+
+type item string
+
+// GetCursorValue returns the "id:timestamp" value paging cursors are built from
+func (i item) GetCursorValue() string { return string(i) + ":0" }
+
+// list pages through five items after the cursor
+func list(t *testing.T, cursor string, limit int) *Page[item] {
+	t.Helper()
+	all := []item{"a", "b", "c", "d", "e"}
+	result, err := paging.Paginate(paging.Params{Cursor: cursor, Limit: limit}, func(cursor string, limit int, _ string) ([]item, int, error) {
+		start := 0
+		if cursor != "" {
+			after, _, err := paging.DecodeCursor(cursor)
+			if err != nil {
+				return nil, 0, err
+			}
+			for i, it := range all {
+				if string(it) == after {
+					start = i + 1
+				}
+			}
+		}
+		end := min(start+limit, len(all))
+		return all[start:end], len(all), nil
+	})
+	if err != nil {
+		t.Fatalf("Paginate: %v", err)
+	}
+	return New(result)
+}
+
+func TestNewPartialPage(t *testing.T) {
+	p := list(t, "", 2)
+	if len(p.Items) != 2 || p.Total != 5 || !p.HasMore || p.NextCursor == "" {
+		t.Fatalf("page = %+v, want 2 of 5 items with more to come", *p)
+	}
+}
+
+func TestNewFinalPage(t *testing.T) {
+	first := list(t, "", 3)
+	last := list(t, first.NextCursor, 3)
+	if len(last.Items) != 2 || last.Items[0] != "d" || last.Total != 5 {
+		t.Fatalf("final page = %+v, want d and e of 5", *last)
+	}
+	if last.HasMore || last.NextCursor != "" || last.PrevCursor == "" {
+		t.Fatalf("final page = %+v, want no next cursor and a previous one", *last)
+	}
+}
+
+func TestEnvelopeFields(t *testing.T) {
+	body, err := json.Marshal(FromSlice[string](nil))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(body) != `{"items":[],"total":0,"has_more":false}` {
+		t.Fatalf("empty page = %s", body)
+	}
+
+	body, _ = json.Marshal(list(t, "", 4))
+	var fields map[string]any
+	_ = json.Unmarshal(body, &fields)
+	for _, key := range []string{"items", "total", "next_cursor", "has_more"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("envelope lacks %s: %s", key, body)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package passhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2id defaults, the parameters recommended by RFC 9106 for constrained memory
+const (
+	defaultArgon2Time    = 3
+	defaultArgon2Memory  = 64 * 1024
+	defaultArgon2Threads = 2
+)
+
+// Argon2id output sizes
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// argon2Prefix identifies argon2id hashes, stored in the PHC string format
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>
+const argon2Prefix = "$argon2id$"
+
+// Argon2id hashes passwords with argon2id
+type Argon2id struct {
+	params Argon2Config
+}
+
+// argon2Hash is a parsed argon2id hash
+type argon2Hash struct {
+	version int
+	params  Argon2Config
+	salt    []byte
+	key     []byte
+}
+
+// NewArgon2id creates an argon2id hasher, unset parameters fall back to the defaults
+func NewArgon2id(params Argon2Config) *Argon2id {
+	if params.Time == 0 {
+		params.Time = defaultArgon2Time
+	}
+	if params.Memory == 0 {
+		params.Memory = defaultArgon2Memory
+	}
+	if params.Threads == 0 {
+		params.Threads = defaultArgon2Threads
+	}
+	return &Argon2id{params: params}
+}
+
+// Algorithm returns AlgorithmArgon2id
+func (a *Argon2id) Algorithm() string { return AlgorithmArgon2id }
+
+// Identifies reports whether hash is an argon2id hash
+func (a *Argon2id) Identifies(hash string) bool {
+	return strings.HasPrefix(hash, argon2Prefix)
+}
+
+// Hash hashes a password with a random salt and the parameters of the hasher
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, a.params.Time, a.params.Memory, a.params.Threads, argon2KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2Prefix, argon2.Version,
+		a.params.Memory, a.params.Time, a.params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify reports whether a password matches an argon2id hash, using the parameters stored in it
+func (a *Argon2id) Verify(hash, password string) bool {
+	h, err := parseArgon2(hash)
+	if err != nil || h.version != argon2.Version {
+		return false
+	}
+	key := argon2.IDKey([]byte(password), h.salt, h.params.Time, h.params.Memory, h.params.Threads, uint32(len(h.key)))
+	return subtle.ConstantTimeCompare(key, h.key) == 1
+}
+
+// Outdated reports whether a hash was made with lower parameters than the hasher's
+func (a *Argon2id) Outdated(hash string) bool {
+	h, err := parseArgon2(hash)
+	if err != nil {
+		return true
+	}
+	return h.version != argon2.Version ||
+		h.params.Time < a.params.Time ||
+		h.params.Memory < a.params.Memory ||
+		h.params.Threads < a.params.Threads ||
+		len(h.key) < argon2KeyLength
+}
+
+// parseArgon2 splits an argon2id hash into its version, parameters, salt and key
+func parseArgon2(hash string) (*argon2Hash, error) {
+	parts := strings.Split(strings.TrimPrefix(hash, argon2Prefix), "$")
+	if !strings.HasPrefix(hash, argon2Prefix) || len(parts) != 4 {
+		return nil, errors.New("malformed argon2id hash")
+	}
+
+	h := &argon2Hash{}
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &h.version); err != nil {
+		return nil, fmt.Errorf("argon2id version: %w", err)
+	}
+	if _, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &h.params.Memory, &h.params.Time, &h.params.Threads); err != nil {
+		return nil, fmt.Errorf("argon2id parameters: %w", err)
+	}
+
+	var err error
+	if h.salt, err = base64.RawStdEncoding.DecodeString(parts[2]); err != nil {
+		return nil, fmt.Errorf("argon2id salt: %w", err)
+	}
+	if h.key, err = base64.RawStdEncoding.DecodeString(parts[3]); err != nil {
+		return nil, fmt.Errorf("argon2id key: %w", err)
+	}
+	if len(h.key) == 0 || h.params.Time == 0 || h.params.Threads == 0 {
+		return nil, errors.New("malformed argon2id hash")
+	}
+	return h, nil
+}
@@ -0,0 +1,59 @@
+package passhash
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// defaultBcryptCost is the cost hashes had before hashing was configurable
+const defaultBcryptCost = bcrypt.DefaultCost
+
+// bcryptPrefixes identify the bcrypt versions verifiable by golang.org/x/crypto/bcrypt
+var bcryptPrefixes = []string{"$2a$", "$2b$", "$2y$"}
+
+// Bcrypt hashes passwords with bcrypt
+type Bcrypt struct {
+	cost int
+}
+
+// NewBcrypt creates a bcrypt hasher, a cost out of the bcrypt range falls back to the default
+func NewBcrypt(cost int) *Bcrypt {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = defaultBcryptCost
+	}
+	return &Bcrypt{cost: cost}
+}
+
+// Algorithm returns AlgorithmBcrypt
+func (b *Bcrypt) Algorithm() string { return AlgorithmBcrypt }
+
+// Identifies reports whether hash is a bcrypt hash
+func (b *Bcrypt) Identifies(hash string) bool {
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(hash, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hash hashes a password at the cost of the hasher
+func (b *Bcrypt) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify reports whether a password matches a bcrypt hash
+func (b *Bcrypt) Verify(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// Outdated reports whether a hash was made at a lower cost than the hasher's
+func (b *Bcrypt) Outdated(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost < b.cost
+}
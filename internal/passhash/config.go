@@ -0,0 +1,52 @@
+package passhash
+
+import (
+	"github.com/spf13/viper"
+)
+
+// Config configures password hashing
+type Config struct {
+	Algorithm  string // algorithm new hashes are made with, AlgorithmBcrypt or AlgorithmArgon2id
+	BcryptCost int
+	Argon2     Argon2Config
+}
+
+// Argon2Config sets the cost of argon2id hashes
+type Argon2Config struct {
+	Time    uint32 // passes over the memory
+	Memory  uint32 // KiB
+	Threads uint8
+}
+
+// FromViper reads the password hashing config from auth.password.*
+func FromViper(v *viper.Viper) *Config {
+	cfg := &Config{
+		Algorithm:  AlgorithmBcrypt,
+		BcryptCost: defaultBcryptCost,
+		Argon2: Argon2Config{
+			Time:    defaultArgon2Time,
+			Memory:  defaultArgon2Memory,
+			Threads: defaultArgon2Threads,
+		},
+	}
+	if v == nil {
+		return cfg
+	}
+
+	if s := v.GetString("auth.password.algorithm"); s != "" {
+		cfg.Algorithm = s
+	}
+	if n := v.GetInt("auth.password.bcrypt_cost"); n > 0 {
+		cfg.BcryptCost = n
+	}
+	if n := v.GetUint32("auth.password.argon2.time"); n > 0 {
+		cfg.Argon2.Time = n
+	}
+	if n := v.GetUint32("auth.password.argon2.memory"); n > 0 {
+		cfg.Argon2.Memory = n
+	}
+	if n := v.GetUint("auth.password.argon2.threads"); n > 0 && n <= 255 {
+		cfg.Argon2.Threads = uint8(n)
+	}
+	return cfg
+}
@@ -0,0 +1,106 @@
+package passhash
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ncobase/ncore/config"
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// Algorithms of password hashing
+const (
+	AlgorithmBcrypt   = "bcrypt"   // the default, and the algorithm of every hash made before hashing was configurable
+	AlgorithmArgon2id = "argon2id" // memory-hard, recommended for new deployments
+)
+
+// Hasher hashes passwords with one algorithm. Its hashes start with an identifier
+// prefix, so the algorithm of a stored hash is known when verifying it.
+type Hasher interface {
+	// Algorithm returns the name of the algorithm
+	Algorithm() string
+	// Identifies reports whether a stored hash was made with the algorithm
+	Identifies(hash string) bool
+	// Hash hashes a password with the current settings of the hasher
+	Hash(password string) (string, error)
+	// Verify reports whether a password matches a hash made with the algorithm
+	Verify(hash, password string) bool
+	// Outdated reports whether a hash made with the algorithm is weaker than the current settings
+	Outdated(hash string) bool
+}
+
+var current atomic.Pointer[Hasher] // the hasher new hashes are made with
+
+func init() {
+	SetHasher(NewBcrypt(defaultBcryptCost))
+}
+
+// SetHasher replaces the hasher new hashes are made with
+func SetHasher(h Hasher) {
+	current.Store(&h)
+}
+
+// Current returns the hasher new hashes are made with
+func Current() Hasher {
+	return *current.Load()
+}
+
+// Setup configures the hasher from auth.password.*. It fails on an unknown algorithm
+// instead of silently hashing passwords some other way.
+func Setup(conf *config.Config) error {
+	cfg := FromViper(conf.Viper)
+
+	switch cfg.Algorithm {
+	case AlgorithmBcrypt:
+		SetHasher(NewBcrypt(cfg.BcryptCost))
+	case AlgorithmArgon2id:
+		SetHasher(NewArgon2id(cfg.Argon2))
+	default:
+		return fmt.Errorf("unknown password hashing algorithm %q", cfg.Algorithm)
+	}
+	return nil
+}
+
+// Hash hashes a password with the current hasher
+func Hash(ctx context.Context, password string) (string, error) {
+	hash, err := Current().Hash(password)
+	if err != nil {
+		logger.Errorf(ctx, "passhash.Hash error: %v", err)
+		return "", err
+	}
+	return hash, nil
+}
+
+// Verify reports whether a password matches a stored hash, whatever algorithm made it.
+// On a match, rehash reports whether the hash should be replaced by a new one of the
+// current hasher, because it was made with another algorithm or weaker settings.
+func Verify(hash, password string) (ok, rehash bool) {
+	h := Current()
+	if h.Identifies(hash) {
+		if !h.Verify(hash, password) {
+			return false, false
+		}
+		return true, h.Outdated(hash)
+	}
+
+	for _, other := range known() {
+		if other.Algorithm() == h.Algorithm() || !other.Identifies(hash) {
+			continue
+		}
+		if !other.Verify(hash, password) {
+			return false, false
+		}
+		return true, true
+	}
+	return false, false
+}
+
+// known returns a hasher of each supported algorithm, used to verify hashes made
+// with an algorithm other than the current one
+func known() []Hasher {
+	return []Hasher{
+		NewBcrypt(defaultBcryptCost),
+		NewArgon2id(Argon2Config{Time: defaultArgon2Time, Memory: defaultArgon2Memory, Threads: defaultArgon2Threads}),
+	}
+}
@@ -0,0 +1,89 @@
+package passhash
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// cheap settings keep the tests fast, the stored parameters make them verifiable anyway
+var (
+	testArgon2 = Argon2Config{Time: 1, Memory: 1024, Threads: 1}
+	testBcrypt = bcrypt.MinCost
+)
+
+// withHasher makes h the current hasher for the rest of the test
+func withHasher(t *testing.T, h Hasher) {
+	t.Helper()
+	previous := Current()
+	SetHasher(h)
+	t.Cleanup(func() { SetHasher(previous) })
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, h := range []Hasher{NewBcrypt(testBcrypt), NewArgon2id(testArgon2)} {
+		t.Run(h.Algorithm(), func(t *testing.T) {
+			withHasher(t, h)
+			hash, err := Hash(context.Background(), "correct horse")
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+			if !h.Identifies(hash) {
+				t.Fatalf("%s does not identify its own hash %q", h.Algorithm(), hash)
+			}
+			if ok, rehash := Verify(hash, "correct horse"); !ok || rehash {
+				t.Fatalf("Verify = %v, %v, want a match without rehash", ok, rehash)
+			}
+			if ok, _ := Verify(hash, "wrong horse"); ok {
+				t.Fatal("wrong password verified")
+			}
+		})
+	}
+}
+
+func TestVerifyUpgradesOtherAlgorithm(t *testing.T) {
+	old, err := NewBcrypt(testBcrypt).Hash("secret")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	withHasher(t, NewArgon2id(testArgon2))
+
+	if ok, rehash := Verify(old, "secret"); !ok || !rehash {
+		t.Fatalf("bcrypt hash under argon2id = %v, %v, want a match to rehash", ok, rehash)
+	}
+	if ok, rehash := Verify(old, "other"); ok || rehash {
+		t.Fatalf("wrong password = %v, %v, want no match", ok, rehash)
+	}
+}
+
+func TestVerifyUpgradesWeakerSettings(t *testing.T) {
+	weak, err := NewArgon2id(testArgon2).Hash("secret")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	stronger := testArgon2
+	stronger.Time++
+	withHasher(t, NewArgon2id(stronger))
+
+	if ok, rehash := Verify(weak, "secret"); !ok || !rehash {
+		t.Fatalf("weaker argon2id hash = %v, %v, want a match to rehash", ok, rehash)
+	}
+
+	cheap, err := NewBcrypt(testBcrypt).Hash("secret")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	withHasher(t, NewBcrypt(testBcrypt+1))
+	if ok, rehash := Verify(cheap, "secret"); !ok || !rehash {
+		t.Fatalf("lower cost bcrypt hash = %v, %v, want a match to rehash", ok, rehash)
+	}
+}
+
+func TestVerifyRejectsUnknownHash(t *testing.T) {
+	for _, hash := range []string{"", "plaintext", "$argon2id$v=19$m=1024,t=1,p=1$bad", "$1$md5$hash"} {
+		if ok, rehash := Verify(hash, "plaintext"); ok || rehash {
+			t.Errorf("Verify(%q) = %v, %v, want no match", hash, ok, rehash)
+		}
+	}
+}
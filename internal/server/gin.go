@@ -2,6 +2,9 @@ package server
 
 import (
 	"context"
+	"ncobase/internal/drain"
+	"ncobase/internal/manifest"
+	"ncobase/internal/metrics"
 	"ncobase/internal/middleware"
 	"net/http"
 	"time"
@@ -15,8 +18,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// ginServer creates and initializes server
-func ginServer(conf *config.Config, em ext.ManagerInterface) (*gin.Engine, error) {
+// ginServer creates and initializes server, its background tasks run until ctx is done
+func ginServer(ctx context.Context, conf *config.Config, em ext.ManagerInterface) (*gin.Engine, error) {
 	// Set gin mode
 	if conf.IsProd() {
 		conf.Environment = gin.ReleaseMode
@@ -31,6 +34,18 @@ func ginServer(conf *config.Config, em ext.ManagerInterface) (*gin.Engine, error
 	// 0. Panic recovery (MUST be first to catch all panics)
 	engine.Use(middleware.Recovery())
 
+	// Refuse new requests once shutdown starts draining
+	engine.Use(drain.Middleware)
+
+	// Response encoding, early so error responses of later middleware are negotiated too
+	if msgpackEnabled(conf) {
+		engine.Use(middleware.ResponseEncoding)
+	}
+
+	// Metrics, registered before auth so scrapers don't need credentials
+	engine.Use(metrics.Middleware)
+	engine.GET("/metrics", metrics.Handler)
+
 	// 1. Basic infrastructure
 	engine.Use(middleware.CORSHandler)
 	engine.Use(middleware.Trace)
@@ -39,15 +54,18 @@ func ginServer(conf *config.Config, em ext.ManagerInterface) (*gin.Engine, error
 	engine.Use(middleware.OtelTrace)
 
 	// 2. Authentication
-	engine.Use(middleware.ConsumeUser(em, conf.Auth.Whitelist))
+	engine.Use(middleware.ConsumeUser(em, conf.Auth.Whitelist, apiKeyQueryAllowed(conf)))
 
 	// 3. Session management
-	if err := sessionMiddleware(conf, engine, em); err != nil {
+	if err := sessionMiddleware(ctx, conf, engine, em); err != nil {
 		logger.Warnf(context.Background(), "Failed to setup session middleware: %v", err)
 	}
 
 	// 4. Space context
-	engine.Use(middleware.ConsumeSpace(em, conf.Auth.Whitelist))
+	engine.Use(middleware.ConsumeSpace(em, conf.Auth.Whitelist, spaceHostMode(conf)))
+
+	// Maintenance mode, after user context so admins and allowlisted users pass
+	engine.Use(middleware.MaintenanceMode(em, conf.Auth.Whitelist))
 
 	// 5. Authorization
 	engine.Use(middleware.CasbinAuthorized(em, conf.Auth.Whitelist))
@@ -55,6 +73,7 @@ func ginServer(conf *config.Config, em ext.ManagerInterface) (*gin.Engine, error
 	// Register routes
 	registerRest(engine, conf)
 	em.RegisterRoutes(engine)
+	manifest.SetRouteSource(engine.Routes)
 
 	// Extension management routes
 	if conf.Extension.HotReload {
@@ -70,8 +89,8 @@ func ginServer(conf *config.Config, em ext.ManagerInterface) (*gin.Engine, error
 	return engine, nil
 }
 
-// sessionMiddleware sets up session management
-func sessionMiddleware(conf *config.Config, engine *gin.Engine, em ext.ManagerInterface) error {
+// sessionMiddleware sets up session management, the cleanup task runs until ctx is done
+func sessionMiddleware(ctx context.Context, conf *config.Config, engine *gin.Engine, em ext.ManagerInterface) error {
 	// Session tracking and validation
 	engine.Use(middleware.SessionMiddleware(em))
 	engine.Use(middleware.ValidateSessionMiddleware(em))
@@ -87,6 +106,36 @@ func sessionMiddleware(conf *config.Config, engine *gin.Engine, em ext.ManagerIn
 		cleanupInterval = time.Duration(conf.Auth.SessionCleanupInterval) * time.Minute
 	}
 
-	go middleware.SessionCleanupTask(context.Background(), em, cleanupInterval)
+	go middleware.SessionCleanupTask(ctx, em, cleanupInterval)
 	return nil
 }
+
+// spaceHostMode returns how the request host resolves the space, see middleware.ConsumeSpace
+func spaceHostMode(conf *config.Config) string {
+	if conf.Viper == nil {
+		return middleware.SpaceHostOff
+	}
+	switch mode := conf.Viper.GetString("space.host_resolution"); mode {
+	case middleware.SpaceHostFallback, middleware.SpaceHostPreferred:
+		return mode
+	case "", "off":
+		return middleware.SpaceHostOff
+	default:
+		logger.Warnf(context.Background(), "Unknown space.host_resolution %q, host resolution disabled", mode)
+		return middleware.SpaceHostOff
+	}
+}
+
+// apiKeyQueryAllowed reports whether API keys are accepted in the api_key query parameter,
+// off unless auth.api_key_query is true
+func apiKeyQueryAllowed(conf *config.Config) bool {
+	return conf.Viper != nil && conf.Viper.GetBool("auth.api_key_query")
+}
+
+// msgpackEnabled reports whether clients may ask for MessagePack responses, on unless server.msgpack is false
+func msgpackEnabled(conf *config.Config) bool {
+	if conf.Viper == nil || !conf.Viper.IsSet("server.msgpack") {
+		return true
+	}
+	return conf.Viper.GetBool("server.msgpack")
+}
@@ -1,11 +1,13 @@
 package server
 
 import (
+	dbpool "ncobase/internal/utils"
 	"net/http"
 
 	"github.com/ncobase/ncore/utils"
 
 	"github.com/ncobase/ncore/config"
+	"github.com/ncobase/ncore/net/resp"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -24,8 +26,25 @@ func registerRest(e *gin.Engine, conf *config.Config) {
 		c.String(http.StatusOK, "It's working.")
 	})
 
+	// Readiness, with the database connection pools of the instance
+	e.GET("/health", readiness)
+
 	// Swagger documentation endpoint
 	if !conf.IsProd() {
 		e.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	}
 }
+
+// readiness reports whether the instance can take traffic, with the statistics of its database
+// connection pools. While a pool has every connection in use it answers 503, so the load
+// balancer sends new requests elsewhere until connections are returned.
+func readiness(c *gin.Context) {
+	pools := dbpool.Pools()
+	for _, stats := range pools {
+		if stats.Saturated() {
+			resp.Fail(c.Writer, resp.ServiceUnavailable("Database connection pool saturated", gin.H{"db_pools": pools}))
+			return
+		}
+	}
+	resp.Success(c.Writer, gin.H{"status": "ready", "db_pools": pools})
+}
@@ -2,6 +2,13 @@ package server
 
 import (
 	"context"
+	"ncobase/internal/eventlog"
+	"ncobase/internal/fieldcrypt"
+	"ncobase/internal/identifier"
+	"ncobase/internal/idgen"
+	"ncobase/internal/page"
+	"ncobase/internal/passhash"
+	"ncobase/internal/slowquery"
 	"net/http"
 
 	"github.com/ncobase/ncore/config"
@@ -12,6 +19,41 @@ import (
 // New creates a new server.
 func New(conf *config.Config) (http.Handler, func(), error) {
 
+	// Field encryption keys, needed before any repository reads or writes
+	if err := fieldcrypt.Setup(conf); err != nil {
+		logger.Fatalf(context.Background(), "Failed configuring field encryption: %+v", err)
+		return nil, nil, err
+	}
+
+	// Primary key strategy, set before any record is created
+	if err := idgen.Setup(conf); err != nil {
+		logger.Fatalf(context.Background(), "Failed configuring ID generation: %+v", err)
+		return nil, nil, err
+	}
+
+	// Password hashing algorithm, set before any password is hashed or verified
+	if err := passhash.Setup(conf); err != nil {
+		logger.Fatalf(context.Background(), "Failed configuring password hashing: %+v", err)
+		return nil, nil, err
+	}
+
+	// Username and email normalization, set before any user is stored or looked up
+	if err := identifier.Setup(conf); err != nil {
+		logger.Fatalf(context.Background(), "Failed configuring identifier normalization: %+v", err)
+		return nil, nil, err
+	}
+
+	// Slow query logging, set before the extensions create their ent clients
+	slowquery.Setup(conf)
+
+	// List cursors are signed with a key derived from the JWT secret, so any instance accepts them
+	if conf.Auth != nil && conf.Auth.JWT != nil {
+		page.SetCursorSecret(conf.Auth.JWT.Secret)
+	}
+
+	// Default and maximum page sizes of list endpoints
+	page.SetLimits(conf.Viper)
+
 	// Initialize Extension Manager
 	em, err := extm.NewManager(conf)
 	if err != nil {
@@ -19,6 +61,9 @@ func New(conf *config.Config) (http.Handler, func(), error) {
 		return nil, nil, err
 	}
 
+	// Durable event log, attached before extensions start publishing
+	_, closeEventLog := eventlog.Setup(conf, em)
+
 	// Register built-in extensions
 	registerExtensions(em)
 
@@ -27,14 +72,19 @@ func New(conf *config.Config) (http.Handler, func(), error) {
 		logger.Fatalf(context.Background(), "Failed loading plugins: %+v", err)
 	}
 
+	// Background tasks of the server stop with it
+	ctx, stop := context.WithCancel(context.Background())
+
 	// New server
-	h, err := ginServer(conf, em)
+	h, err := ginServer(ctx, conf, em)
 	if err != nil {
 		logger.Fatalf(context.Background(), "Failed initializing http: %+v", err)
 		// panic(err)
 	}
 
 	return h, func() {
+		stop()
 		em.Cleanup()
+		closeEventLog()
 	}, nil
 }
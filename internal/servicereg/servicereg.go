@@ -0,0 +1,154 @@
+package servicereg
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+// keyPrefix namespaces published services among the cross services of the extension manager
+const keyPrefix = "services"
+
+var (
+	// ErrServiceNotFound is returned when no service is published under a name
+	ErrServiceNotFound = errors.New("service not registered")
+	// ErrServiceIncompatible is returned when a published service has an unsuitable version or interface
+	ErrServiceIncompatible = errors.New("service incompatible")
+	// ErrInvalidVersion is returned for a version that is not in the form major[.minor[.patch]]
+	ErrInvalidVersion = errors.New("invalid version")
+)
+
+// entry is a published service with the version of its interface
+type entry struct {
+	name    string
+	version string
+	service any
+}
+
+// Publish registers a service of an extension under a name, e.g. "resource.File",
+// so other extensions can resolve it. version is the version of the service interface;
+// it changes major version when the interface changes incompatibly.
+func Publish(em ext.ManagerInterface, name, version string, service any) error {
+	if name == "" || service == nil {
+		return fmt.Errorf("publish service %q: name and service are required", name)
+	}
+	if _, err := parseVersion(version); err != nil {
+		return fmt.Errorf("publish service %s: %w", name, err)
+	}
+
+	em.RegisterCrossService(keyPrefix+"."+name, &entry{name: name, version: version, service: service})
+	return nil
+}
+
+// Withdraw removes a published service, e.g. when its extension is unloaded
+func Withdraw(em ext.ManagerInterface, name string) {
+	em.RegisterCrossService(keyPrefix+"."+name, (*entry)(nil))
+}
+
+// Resolve returns the service published under name as T, usually an interface declared
+// by the consumer. minVersion is the oldest compatible version: the published version
+// must have the same major version and must not be older. An empty minVersion accepts any.
+//
+// It fails with ErrServiceNotFound when nothing is published under the name and with
+// ErrServiceIncompatible when the version or the methods of the service do not match.
+func Resolve[T any](em ext.ManagerInterface, name, minVersion string) (T, error) {
+	var zero T
+
+	raw, err := em.GetCrossService(keyPrefix, name)
+	if err != nil {
+		return zero, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+	e, ok := raw.(*entry)
+	if !ok || e == nil {
+		return zero, fmt.Errorf("%w: %s", ErrServiceNotFound, name)
+	}
+
+	if minVersion != "" {
+		if err := checkVersion(e.version, minVersion); err != nil {
+			return zero, fmt.Errorf("%w: %s %v", ErrServiceIncompatible, name, err)
+		}
+	}
+
+	service, ok := e.service.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s %s", ErrServiceIncompatible, name, mismatch(e.service, reflect.TypeOf((*T)(nil)).Elem()))
+	}
+	return service, nil
+}
+
+// checkVersion reports why version does not satisfy minVersion
+func checkVersion(version, minVersion string) error {
+	have, err := parseVersion(version)
+	if err != nil {
+		return err
+	}
+	want, err := parseVersion(minVersion)
+	if err != nil {
+		return err
+	}
+
+	if have[0] != want[0] {
+		return fmt.Errorf("version %s, want major version %d", version, want[0])
+	}
+	for i := 1; i < len(have); i++ {
+		if have[i] != want[i] {
+			if have[i] < want[i] {
+				return fmt.Errorf("version %s, want %s or newer", version, minVersion)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// parseVersion parses "1", "1.2" or "1.2.3", with an optional "v" prefix
+func parseVersion(version string) ([3]int, error) {
+	var v [3]int
+
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if version == "" || len(parts) > 3 {
+		return v, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("%w: %q", ErrInvalidVersion, version)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+// mismatch describes why a service does not implement the wanted type
+func mismatch(service any, want reflect.Type) string {
+	have := reflect.TypeOf(service)
+	if want.Kind() != reflect.Interface {
+		return fmt.Sprintf("is %s, want %s", have, want)
+	}
+
+	for i := 0; i < want.NumMethod(); i++ {
+		wm := want.Method(i)
+		hm, ok := have.MethodByName(wm.Name)
+		if !ok {
+			return fmt.Sprintf("%s has no method %s", have, wm.Name)
+		}
+		// Drop the receiver to compare with the interface method
+		in := make([]reflect.Type, 0, hm.Type.NumIn()-1)
+		for j := 1; j < hm.Type.NumIn(); j++ {
+			in = append(in, hm.Type.In(j))
+		}
+		out := make([]reflect.Type, 0, hm.Type.NumOut())
+		for j := 0; j < hm.Type.NumOut(); j++ {
+			out = append(out, hm.Type.Out(j))
+		}
+		got := reflect.FuncOf(in, out, hm.Type.IsVariadic())
+		if got != wm.Type {
+			return fmt.Sprintf("method %s is %s, want %s", wm.Name, got, wm.Type)
+		}
+	}
+	return fmt.Sprintf("%s does not implement %s", have, want)
+}
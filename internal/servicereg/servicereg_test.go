@@ -0,0 +1,117 @@
+package servicereg
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+// crossServices keeps cross services by key, like the extension manager
+type crossServices struct {
+	ext.ManagerInterface
+	services map[string]any
+}
+
+func (m *crossServices) RegisterCrossService(key string, service any) {
+	m.services[key] = service
+}
+
+func (m *crossServices) GetCrossService(extensionName, servicePath string) (any, error) {
+	if svc, ok := m.services[extensionName+"."+servicePath]; ok {
+		return svc, nil
+	}
+	return nil, errors.New("cross service not found")
+}
+
+// files is the service a fake plugin publishes
+type files struct{}
+
+func (files) Get(_ context.Context, slug string) (string, error) { return "file " + slug, nil }
+
+// fileGetter is the interface a consumer declares for the files it needs
+type fileGetter interface {
+	Get(ctx context.Context, slug string) (string, error)
+}
+
+// fileLister is a consumer interface the service does not implement
+type fileLister interface {
+	Get(ctx context.Context, slug string) (string, error)
+	List(ctx context.Context) ([]string, error)
+}
+
+// legacyGetter has a different signature for Get
+type legacyGetter interface {
+	Get(slug string) string
+}
+
+func TestResolvePublishedService(t *testing.T) {
+	em := &crossServices{services: map[string]any{}}
+	if err := Publish(em, "resource.File", "1.2.0", files{}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	svc, err := Resolve[fileGetter](em, "resource.File", "1.1")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if got, _ := svc.Get(context.Background(), "a"); got != "file a" {
+		t.Fatalf("resolved service returned %q", got)
+	}
+	if _, err := Resolve[fileGetter](em, "resource.File", ""); err != nil {
+		t.Fatalf("Resolve without a version: %v", err)
+	}
+}
+
+func TestResolveMissingService(t *testing.T) {
+	em := &crossServices{services: map[string]any{}}
+	if _, err := Resolve[fileGetter](em, "resource.File", "1.0"); !errors.Is(err, ErrServiceNotFound) {
+		t.Fatalf("Resolve = %v, want ErrServiceNotFound", err)
+	}
+
+	// a withdrawn service is missing again
+	if err := Publish(em, "resource.File", "1.0.0", files{}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	Withdraw(em, "resource.File")
+	if _, err := Resolve[fileGetter](em, "resource.File", "1.0"); !errors.Is(err, ErrServiceNotFound) {
+		t.Fatalf("Resolve after withdraw = %v, want ErrServiceNotFound", err)
+	}
+}
+
+func TestResolveIncompatibleService(t *testing.T) {
+	em := &crossServices{services: map[string]any{}}
+	if err := Publish(em, "resource.File", "1.2.0", files{}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	for _, tc := range []struct {
+		resolve func() error
+		reason  string
+	}{
+		{func() error { _, err := Resolve[fileGetter](em, "resource.File", "2.0"); return err }, "major version 2"},
+		{func() error { _, err := Resolve[fileGetter](em, "resource.File", "1.3"); return err }, "1.3 or newer"},
+		{func() error { _, err := Resolve[fileLister](em, "resource.File", "1.0"); return err }, "no method List"},
+		{func() error { _, err := Resolve[legacyGetter](em, "resource.File", "1.0"); return err }, "method Get"},
+	} {
+		err := tc.resolve()
+		if !errors.Is(err, ErrServiceIncompatible) || !strings.Contains(err.Error(), tc.reason) {
+			t.Errorf("Resolve = %v, want an incompatible service (%s)", err, tc.reason)
+		}
+	}
+}
+
+func TestPublishValidates(t *testing.T) {
+	em := &crossServices{services: map[string]any{}}
+	if err := Publish(em, "resource.File", "one", files{}); !errors.Is(err, ErrInvalidVersion) {
+		t.Fatalf("Publish with version one = %v, want ErrInvalidVersion", err)
+	}
+	if err := Publish(em, "", "1.0", files{}); err == nil {
+		t.Fatal("service without a name published")
+	}
+	if len(em.services) != 0 {
+		t.Fatalf("invalid services registered: %v", em.services)
+	}
+}
@@ -0,0 +1,37 @@
+package slowquery
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultThreshold is the duration a query takes before it is logged as slow
+const DefaultThreshold = 200 * time.Millisecond
+
+// Config configures slow query logging
+type Config struct {
+	Enabled   bool
+	Threshold time.Duration // queries taking longer are logged
+	MaxLength int           // statements are truncated to this many characters in the log
+}
+
+// FromViper reads the slow query config from data.slow_query.*
+func FromViper(v *viper.Viper) *Config {
+	cfg := &Config{
+		Threshold: DefaultThreshold,
+		MaxLength: 1000,
+	}
+	if v == nil {
+		return cfg
+	}
+
+	cfg.Enabled = v.GetBool("data.slow_query.enabled")
+	if d := v.GetDuration("data.slow_query.threshold"); d > 0 {
+		cfg.Threshold = d
+	}
+	if n := v.GetInt("data.slow_query.max_length"); n > 0 {
+		cfg.MaxLength = n
+	}
+	return cfg
+}
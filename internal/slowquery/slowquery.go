@@ -0,0 +1,182 @@
+package slowquery
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+
+	"entgo.io/ent/dialect"
+	"github.com/ncobase/ncore/config"
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// current holds the active config, disabled until Setup
+var current atomic.Pointer[Config]
+
+// logf writes slow query entries, the context carries the trace ID into the log
+var logf = logger.Warnf
+
+// Setup applies the slow query config, call it before the ent clients are created
+func Setup(conf *config.Config) {
+	SetConfig(FromViper(conf.Viper))
+}
+
+// SetConfig replaces the active config
+func SetConfig(cfg *Config) {
+	current.Store(cfg)
+}
+
+// enabled returns the active config when slow query logging is on
+func enabled() (*Config, bool) {
+	cfg := current.Load()
+	return cfg, cfg != nil && cfg.Enabled
+}
+
+// Driver wraps an ent driver so statements taking longer than the configured threshold
+// are logged. With slow query logging off, drv is returned as is and costs nothing.
+func Driver(drv dialect.Driver) dialect.Driver {
+	if _, ok := enabled(); !ok {
+		return drv
+	}
+	return &driver{Driver: drv}
+}
+
+// driver times the statements of the wrapped driver
+type driver struct {
+	dialect.Driver
+}
+
+// Exec executes a statement and logs it when slow
+func (d *driver) Exec(ctx context.Context, query string, args, v any) error {
+	defer observe(ctx, "exec", query, time.Now())
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+// Query runs a query and logs it when slow
+func (d *driver) Query(ctx context.Context, query string, args, v any) error {
+	defer observe(ctx, "query", query, time.Now())
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+// Tx starts a transaction whose statements are timed too
+func (d *driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &txn{Tx: tx}, nil
+}
+
+// BeginTx starts a transaction with options whose statements are timed too
+func (d *driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return d.Tx(ctx)
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &txn{Tx: tx}, nil
+}
+
+// txn times the statements of a transaction
+type txn struct {
+	dialect.Tx
+}
+
+// Exec executes a statement in the transaction and logs it when slow
+func (t *txn) Exec(ctx context.Context, query string, args, v any) error {
+	defer observe(ctx, "exec", query, time.Now())
+	return t.Tx.Exec(ctx, query, args, v)
+}
+
+// Query runs a query in the transaction and logs it when slow
+func (t *txn) Query(ctx context.Context, query string, args, v any) error {
+	defer observe(ctx, "query", query, time.Now())
+	return t.Tx.Query(ctx, query, args, v)
+}
+
+// observe logs the statement when it ran longer than the threshold
+func observe(ctx context.Context, kind, query string, start time.Time) {
+	cfg, ok := enabled()
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed < cfg.Threshold {
+		return
+	}
+	logf(ctx, "Slow query: %s %s took %s (threshold %s): %s",
+		kind, operation(query), elapsed.Round(time.Microsecond), cfg.Threshold, Sanitize(query, cfg.MaxLength))
+}
+
+// operation returns the leading keyword of a statement, "SELECT" or "UPDATE" for example
+func operation(query string) string {
+	query = strings.TrimLeftFunc(query, unicode.IsSpace)
+	end := strings.IndexFunc(query, func(r rune) bool { return !unicode.IsLetter(r) })
+	if end < 0 {
+		end = len(query)
+	}
+	if end == 0 {
+		return "UNKNOWN"
+	}
+	return strings.ToUpper(query[:end])
+}
+
+// Sanitize prepares a statement for the log: string literals are replaced by a placeholder
+// so values inlined by hand do not leak, whitespace is collapsed and the result is cut to
+// maxLength characters when maxLength is positive. Arguments are never logged.
+func Sanitize(query string, maxLength int) string {
+	var b strings.Builder
+	b.Grow(len(query))
+
+	inLiteral, space := false, false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		if inLiteral {
+			if c == '\'' {
+				// A doubled quote escapes a quote inside the literal
+				if i+1 < len(query) && query[i+1] == '\'' {
+					i++
+					continue
+				}
+				inLiteral = false
+			}
+			continue
+		}
+		switch {
+		case c == '\'':
+			inLiteral = true
+			if space {
+				b.WriteByte(' ')
+				space = false
+			}
+			b.WriteString("'?'")
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = b.Len() > 0
+		default:
+			if space {
+				b.WriteByte(' ')
+				space = false
+			}
+			b.WriteByte(c)
+		}
+	}
+
+	s := b.String()
+	if maxLength > 0 && len(s) > maxLength {
+		cut := maxLength
+		// Do not split a multi-byte character
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		s = s[:cut] + "..."
+	}
+	return s
+}
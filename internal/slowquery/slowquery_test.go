@@ -0,0 +1,167 @@
+package slowquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/spf13/viper"
+)
+
+// sleepyDriver takes delay to run every statement
+type sleepyDriver struct {
+	dialect.Driver
+	delay time.Duration
+}
+
+func (d *sleepyDriver) Exec(context.Context, string, any, any) error {
+	time.Sleep(d.delay)
+	return nil
+}
+
+func (d *sleepyDriver) Query(context.Context, string, any, any) error {
+	time.Sleep(d.delay)
+	return nil
+}
+
+func (d *sleepyDriver) Tx(context.Context) (dialect.Tx, error) {
+	return &sleepyTx{delay: d.delay}, nil
+}
+
+// sleepyTx takes delay to run every statement
+type sleepyTx struct {
+	dialect.Tx
+	delay time.Duration
+}
+
+func (t *sleepyTx) Query(context.Context, string, any, any) error {
+	time.Sleep(t.delay)
+	return nil
+}
+
+// capture enables slow query logging with cfg and records the entries logged
+func capture(t *testing.T, cfg *Config) *[]string {
+	t.Helper()
+	var entries []string
+	prev := logf
+	SetConfig(cfg)
+	logf = func(_ context.Context, format string, args ...any) {
+		entries = append(entries, fmt.Sprintf(format, args...))
+	}
+	t.Cleanup(func() {
+		SetConfig(nil)
+		logf = prev
+	})
+	return &entries
+}
+
+func TestSlowQueriesAreLogged(t *testing.T) {
+	entries := capture(t, &Config{Enabled: true, Threshold: 20 * time.Millisecond, MaxLength: 1000})
+	ctx := context.Background()
+
+	fast := Driver(&sleepyDriver{})
+	if err := fast.Query(ctx, "SELECT 1", nil, nil); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(*entries) != 0 {
+		t.Fatalf("fast query logged: %v", *entries)
+	}
+
+	slow := Driver(&sleepyDriver{delay: 30 * time.Millisecond})
+	if err := slow.Query(ctx, "SELECT * FROM users WHERE name = 'alice'", nil, nil); err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if err := slow.Exec(ctx, "\n  update users set name = $1", nil, nil); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	tx, err := slow.Tx(ctx)
+	if err != nil {
+		t.Fatalf("tx: %v", err)
+	}
+	if err := tx.Query(ctx, "SELECT count(*) FROM files", nil, nil); err != nil {
+		t.Fatalf("tx query: %v", err)
+	}
+
+	if len(*entries) != 3 {
+		t.Fatalf("logged %d slow statements, want 3: %v", len(*entries), *entries)
+	}
+	for i, want := range []string{
+		"Slow query: query SELECT took ",
+		"Slow query: exec UPDATE took ",
+		"Slow query: query SELECT took ",
+	} {
+		if !strings.HasPrefix((*entries)[i], want) || !strings.Contains((*entries)[i], "(threshold 20ms)") {
+			t.Fatalf("entry %d = %q, want %q with the threshold", i, (*entries)[i], want)
+		}
+	}
+	if strings.Contains((*entries)[0], "alice") || !strings.HasSuffix((*entries)[0], ": SELECT * FROM users WHERE name = '?'") {
+		t.Fatalf("entry %q does not carry the sanitized statement", (*entries)[0])
+	}
+}
+
+func TestDisabledLoggingKeepsDriver(t *testing.T) {
+	entries := capture(t, &Config{Threshold: time.Nanosecond})
+	drv := &sleepyDriver{delay: time.Millisecond}
+	if got := Driver(drv); got != dialect.Driver(drv) {
+		t.Fatalf("driver wrapped while disabled: %T", got)
+	}
+
+	// a driver wrapped before logging was turned off stops logging too
+	SetConfig(&Config{Enabled: true, Threshold: time.Nanosecond})
+	wrapped := Driver(drv)
+	SetConfig(&Config{Threshold: time.Nanosecond})
+	_ = wrapped.Query(context.Background(), "SELECT 1", nil, nil)
+	if len(*entries) != 0 {
+		t.Fatalf("logged while disabled: %v", *entries)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		query string
+		max   int
+		want  string
+	}{
+		{"SELECT  *\n\tFROM t", 0, "SELECT * FROM t"},
+		{"  SELECT 1  ", 0, "SELECT 1"},
+		{"WHERE a = 'x' AND b='it''s'", 0, "WHERE a = '?' AND b='?'"},
+		{"SELECT 'unterminated", 0, "SELECT '?'"},
+		{"SELECT abcdef", 8, "SELECT a..."},
+		{"SELECT é", 8, "SELECT ..."},
+	}
+	for _, tt := range tests {
+		if got := Sanitize(tt.query, tt.max); got != tt.want {
+			t.Fatalf("Sanitize(%q, %d) = %q, want %q", tt.query, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestOperation(t *testing.T) {
+	for query, want := range map[string]string{
+		"select * from t":    "SELECT",
+		"\n INSERT INTO t":   "INSERT",
+		"WITH x AS (SELECT)": "WITH",
+		"(SELECT 1)":         "UNKNOWN",
+		"":                   "UNKNOWN",
+	} {
+		if got := operation(query); got != want {
+			t.Fatalf("operation(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestFromViper(t *testing.T) {
+	if cfg := FromViper(nil); cfg.Enabled || cfg.Threshold != DefaultThreshold || cfg.MaxLength != 1000 {
+		t.Fatalf("defaults %+v", cfg)
+	}
+	v := viper.New()
+	v.Set("data.slow_query.enabled", true)
+	v.Set("data.slow_query.threshold", "50ms")
+	v.Set("data.slow_query.max_length", 200)
+	if cfg := FromViper(v); !cfg.Enabled || cfg.Threshold != 50*time.Millisecond || cfg.MaxLength != 200 {
+		t.Fatalf("configured %+v", cfg)
+	}
+}
@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// EntHook returns an ent hook creating a span per mutation
+func EntHook() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			ctx, span := Start(ctx, "ent."+m.Type()+"."+m.Op().String(),
+				attribute.String("db.system", "ent"),
+				attribute.String("db.operation", m.Op().String()),
+			)
+			v, err := next.Mutate(ctx, m)
+			End(span, err)
+			return v, err
+		})
+	}
+}
+
+// EntInterceptor returns an ent interceptor creating a span per query
+func EntInterceptor() ent.Interceptor {
+	return ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			// "*ent.FileQuery" -> "FileQuery"
+			name := fmt.Sprintf("%T", q)
+			if i := strings.LastIndex(name, "."); i >= 0 {
+				name = name[i+1:]
+			}
+			ctx, span := Start(ctx, "ent."+name,
+				attribute.String("db.system", "ent"),
+				attribute.String("db.operation", "query"),
+			)
+			v, err := next.Query(ctx, q)
+			End(span, err)
+			return v, err
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// instrumented tracks clients that already carry the hook,
+// modules share one Redis client and must not add it twice
+var instrumented sync.Map
+
+// InstrumentRedis adds a span per command to rc, rc may be the untyped value from data.GetRedis
+func InstrumentRedis(rc any) {
+	client, ok := rc.(*redis.Client)
+	if !ok || client == nil {
+		return
+	}
+	if _, loaded := instrumented.LoadOrStore(client, struct{}{}); loaded {
+		return
+	}
+	client.AddHook(redisHook{})
+}
+
+// redisHook implements redis.Hook
+type redisHook struct{}
+
+func (redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := Start(ctx, "redis."+cmd.Name(),
+			attribute.String("db.system", "redis"),
+			attribute.String("db.operation", cmd.Name()),
+		)
+		err := next(ctx, cmd)
+		End(span, ignoreNil(err))
+		return err
+	}
+}
+
+func (redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := Start(ctx, "redis.pipeline",
+			attribute.String("db.system", "redis"),
+			attribute.Int("db.redis.num_cmd", len(cmds)),
+		)
+		err := next(ctx, cmds)
+		End(span, ignoreNil(err))
+		return err
+	}
+}
+
+// ignoreNil treats a cache miss as success
+func ignoreNil(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
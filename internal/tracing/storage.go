@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/ncobase/ncore/oss"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Storage wraps s so every call becomes a child span of ctx.
+// oss.Interface carries no context, so wrap per request.
+func Storage(ctx context.Context, s oss.Interface) oss.Interface {
+	if s == nil {
+		return nil
+	}
+	if traced, ok := s.(*tracedStorage); ok {
+		s = traced.Interface
+	}
+	return &tracedStorage{Interface: s, ctx: ctx}
+}
+
+type tracedStorage struct {
+	oss.Interface
+	ctx context.Context
+}
+
+func (t *tracedStorage) span(op, path string) func(error) {
+	_, span := Start(t.ctx, "storage."+op, attribute.String("storage.path", path))
+	return func(err error) { End(span, err) }
+}
+
+func (t *tracedStorage) Get(path string) (f *os.File, err error) {
+	end := t.span("get", path)
+	defer func() { end(err) }()
+	return t.Interface.Get(path)
+}
+
+func (t *tracedStorage) GetStream(path string) (r io.ReadCloser, err error) {
+	end := t.span("get_stream", path)
+	defer func() { end(err) }()
+	return t.Interface.GetStream(path)
+}
+
+func (t *tracedStorage) Put(path string, reader io.Reader) (o *oss.Object, err error) {
+	end := t.span("put", path)
+	defer func() { end(err) }()
+	return t.Interface.Put(path, reader)
+}
+
+func (t *tracedStorage) Delete(path string) (err error) {
+	end := t.span("delete", path)
+	defer func() { end(err) }()
+	return t.Interface.Delete(path)
+}
+
+func (t *tracedStorage) List(path string) (objects []*oss.Object, err error) {
+	end := t.span("list", path)
+	defer func() { end(err) }()
+	return t.Interface.List(path)
+}
+
+func (t *tracedStorage) Exists(path string) (ok bool, err error) {
+	end := t.span("exists", path)
+	defer func() { end(err) }()
+	return t.Interface.Exists(path)
+}
+
+func (t *tracedStorage) Stat(path string) (o *oss.Object, err error) {
+	end := t.span("stat", path)
+	defer func() { end(err) }()
+	return t.Interface.Stat(path)
+}
@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ncobase/ncore/oss"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// record installs a recording tracer provider for the rest of the test
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+// failingStorage fails every delete
+type failingStorage struct {
+	oss.Interface
+}
+
+func (failingStorage) Put(string, io.Reader) (*oss.Object, error) { return &oss.Object{}, nil }
+func (failingStorage) Delete(string) error                        { return errors.New("bucket gone") }
+
+func TestStorageSpansAreChildrenOfRequest(t *testing.T) {
+	recorder := record(t)
+	ctx, parent := Start(context.Background(), "request")
+
+	s := Storage(ctx, failingStorage{})
+	if _, err := s.Put("a.txt", nil); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := s.Delete("a.txt"); err == nil {
+		t.Fatal("Delete error swallowed")
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("%d spans, want put, delete and the request", len(spans))
+	}
+	put, del := spans[0], spans[1]
+	if put.Name() != "storage.put" || del.Name() != "storage.delete" {
+		t.Fatalf("spans = %s, %s", put.Name(), del.Name())
+	}
+	for _, span := range []sdktrace.ReadOnlySpan{put, del} {
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("%s is not a child of the request span", span.Name())
+		}
+	}
+	if put.Status().Code == codes.Error || del.Status().Code != codes.Error {
+		t.Fatalf("statuses = %v, %v, want only the failed delete marked", put.Status(), del.Status())
+	}
+}
+
+func TestStorageDoesNotWrapTwice(t *testing.T) {
+	once := Storage(context.Background(), failingStorage{})
+	twice := Storage(context.Background(), once).(*tracedStorage)
+	if _, ok := twice.Interface.(*tracedStorage); ok {
+		t.Fatal("storage wrapped in two tracing layers")
+	}
+	if Storage(context.Background(), nil) != nil {
+		t.Fatal("nil storage wrapped")
+	}
+}
+
+func TestInjectHeaders(t *testing.T) {
+	record(t)
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	ctx, span := Start(context.Background(), "request")
+	defer span.End()
+
+	header := http.Header{}
+	InjectHeaders(ctx, header)
+	if got := header.Get("traceparent"); got == "" || got[3:35] != span.SpanContext().TraceID().String() {
+		t.Fatalf("traceparent = %q, want trace %s", got, span.SpanContext().TraceID())
+	}
+}
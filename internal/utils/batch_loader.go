@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc fetches values for a set of keys in one round trip.
+// Keys missing from the returned map are treated as not found.
+type BatchFunc[V any] func(ctx context.Context, keys []string) (map[string]V, error)
+
+// BatchLoader collects keys and resolves them with a single BatchFunc call,
+// memoizing results for the lifetime of the loader.
+//
+// A loader is meant to live for one request, see WithBatchLoader.
+type BatchLoader[V any] struct {
+	mu     sync.Mutex
+	fetch  BatchFunc[V]
+	values map[string]V
+	tried  map[string]struct{}
+}
+
+// NewBatchLoader creates a new batch loader
+func NewBatchLoader[V any](fetch BatchFunc[V]) *BatchLoader[V] {
+	return &BatchLoader[V]{
+		fetch:  fetch,
+		values: make(map[string]V),
+		tried:  make(map[string]struct{}),
+	}
+}
+
+// LoadMany resolves keys, fetching only those not loaded yet in a single batch
+func (l *BatchLoader[V]) LoadMany(ctx context.Context, keys []string) (map[string]V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pending := make([]string, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if _, ok := l.tried[key]; ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		pending = append(pending, key)
+	}
+
+	if len(pending) > 0 {
+		fetched, err := l.fetch(ctx, pending)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range pending {
+			l.tried[key] = struct{}{}
+		}
+		for key, v := range fetched {
+			l.values[key] = v
+		}
+	}
+
+	result := make(map[string]V, len(keys))
+	for _, key := range keys {
+		if v, ok := l.values[key]; ok {
+			result[key] = v
+		}
+	}
+	return result, nil
+}
+
+// Load resolves a single key, reusing anything already loaded
+func (l *BatchLoader[V]) Load(ctx context.Context, key string) (V, bool, error) {
+	values, err := l.LoadMany(ctx, []string{key})
+	if err != nil {
+		var zero V
+		return zero, false, err
+	}
+	v, ok := values[key]
+	return v, ok, nil
+}
+
+// batchLoaderKey is the context key type for request scoped loaders
+type batchLoaderKey struct{ name string }
+
+// WithBatchLoader attaches a loader to ctx under name
+func WithBatchLoader[V any](ctx context.Context, name string, loader *BatchLoader[V]) context.Context {
+	return context.WithValue(ctx, batchLoaderKey{name: name}, loader)
+}
+
+// GetBatchLoader returns the loader attached to ctx under name, if any
+func GetBatchLoader[V any](ctx context.Context, name string) (*BatchLoader[V], bool) {
+	loader, ok := ctx.Value(batchLoaderKey{name: name}).(*BatchLoader[V])
+	return loader, ok
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"database/sql"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ncobase/ncore/config"
+)
+
+// Pool defaults applied when the node config leaves a setting unset
+const (
+	DefaultMaxOpenConns    = 50
+	DefaultMaxIdleConns    = 10
+	DefaultConnMaxLifetime = 30 * time.Minute
+	DefaultConnMaxIdleTime = 5 * time.Minute
+)
+
+// pools are the connection pools tuned by TunePool, reported by Pools
+var (
+	poolsMu sync.Mutex
+	pools   []*sql.DB
+)
+
+// PoolStats is a JSON friendly view of sql.DBStats
+type PoolStats struct {
+	MaxOpen      int    `json:"max_open"`
+	Open         int    `json:"open"`
+	InUse        int    `json:"in_use"`
+	Idle         int    `json:"idle"`
+	WaitCount    int64  `json:"wait_count"`
+	WaitDuration string `json:"wait_duration"`
+	MaxIdleClose int64  `json:"max_idle_closed"`
+	MaxLifeClose int64  `json:"max_lifetime_closed"`
+}
+
+// TunePool applies connection pool limits from node to db,
+// falling back to defaults so the pool is never unbounded.
+// The pool is then reported by Pools.
+func TunePool(db *sql.DB, node *config.DBNode) {
+	if db == nil {
+		return
+	}
+
+	maxOpen, maxIdle, lifetime := DefaultMaxOpenConns, DefaultMaxIdleConns, DefaultConnMaxLifetime
+	if node != nil {
+		if node.MaxOpenConn > 0 {
+			maxOpen = node.MaxOpenConn
+		}
+		if node.MaxIdleConn > 0 {
+			maxIdle = node.MaxIdleConn
+		}
+		if node.ConnMaxLifeTime > 0 {
+			lifetime = node.ConnMaxLifeTime
+		}
+	}
+	if maxIdle > maxOpen {
+		maxIdle = maxOpen
+	}
+
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxLifetime(lifetime)
+	db.SetConnMaxIdleTime(DefaultConnMaxIdleTime)
+
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+	if !slices.Contains(pools, db) {
+		pools = append(pools, db)
+	}
+}
+
+// Pools returns the statistics of every connection pool tuned by TunePool
+func Pools() []PoolStats {
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+	stats := make([]PoolStats, 0, len(pools))
+	for _, db := range pools {
+		stats = append(stats, GetPoolStats(db))
+	}
+	return stats
+}
+
+// Saturated reports whether every connection the pool may open is in use,
+// further queries wait for one to be returned
+func (s PoolStats) Saturated() bool {
+	return s.MaxOpen > 0 && s.InUse >= s.MaxOpen
+}
+
+// GetPoolStats returns the current pool statistics of db
+func GetPoolStats(db *sql.DB) PoolStats {
+	if db == nil {
+		return PoolStats{}
+	}
+	s := db.Stats()
+	return PoolStats{
+		MaxOpen:      s.MaxOpenConnections,
+		Open:         s.OpenConnections,
+		InUse:        s.InUse,
+		Idle:         s.Idle,
+		WaitCount:    s.WaitCount,
+		WaitDuration: s.WaitDuration.String(),
+		MaxIdleClose: s.MaxIdleClosed,
+		MaxLifeClose: s.MaxLifetimeClosed,
+	}
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/ncobase/ncore/config"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// holdConns checks out n connections and returns them to the pool
+func holdConns(t *testing.T, db *sql.DB, n int) {
+	t.Helper()
+	conns := make([]*sql.Conn, 0, n)
+	for i := 0; i < n; i++ {
+		conn, err := db.Conn(context.Background())
+		if err != nil {
+			t.Fatalf("conn %d: %v", i, err)
+		}
+		conns = append(conns, conn)
+	}
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+}
+
+func TestTunePoolAppliesNodeLimits(t *testing.T) {
+	db := openTestDB(t)
+	TunePool(db, &config.DBNode{MaxOpenConn: 8, MaxIdleConn: 2})
+
+	holdConns(t, db, 5)
+	stats := GetPoolStats(db)
+	if stats.MaxOpen != 8 {
+		t.Fatalf("max open = %d, want 8", stats.MaxOpen)
+	}
+	if stats.Idle != 2 || stats.MaxIdleClose != 3 {
+		t.Fatalf("idle %d, closed over the idle limit %d, want 2 and 3", stats.Idle, stats.MaxIdleClose)
+	}
+}
+
+func TestTunePoolDefaults(t *testing.T) {
+	db := openTestDB(t)
+	TunePool(db, nil)
+	if stats := GetPoolStats(db); stats.MaxOpen != DefaultMaxOpenConns {
+		t.Fatalf("max open = %d, want the default %d", stats.MaxOpen, DefaultMaxOpenConns)
+	}
+
+	// the idle limit never exceeds the open limit
+	db = openTestDB(t)
+	TunePool(db, &config.DBNode{MaxOpenConn: 2})
+	holdConns(t, db, 2)
+	if stats := GetPoolStats(db); stats.MaxOpen != 2 || stats.Idle != 2 {
+		t.Fatalf("pool = %+v, want 2 open and idle", stats)
+	}
+
+	if stats := GetPoolStats(nil); stats != (PoolStats{}) {
+		t.Fatalf("stats of no pool = %+v", stats)
+	}
+}
+
+func TestPoolsReportSaturation(t *testing.T) {
+	db := openTestDB(t)
+	TunePool(db, &config.DBNode{MaxOpenConn: 3})
+	TunePool(db, &config.DBNode{MaxOpenConn: 3})
+
+	find := func() PoolStats {
+		t.Helper()
+		found := 0
+		var stats PoolStats
+		for _, s := range Pools() {
+			if s.MaxOpen == 3 {
+				stats = s
+				found++
+			}
+		}
+		if found != 1 {
+			t.Fatalf("pool reported %d times, want once", found)
+		}
+		return stats
+	}
+
+	conns := make([]*sql.Conn, 0, 3)
+	for i := 0; i < 3; i++ {
+		if stats := find(); stats.Saturated() {
+			t.Fatalf("pool = %+v saturated with a connection free", stats)
+		}
+		conn, err := db.Conn(context.Background())
+		if err != nil {
+			t.Fatalf("conn %d: %v", i, err)
+		}
+		conns = append(conns, conn)
+	}
+	if stats := find(); !stats.Saturated() {
+		t.Fatalf("pool = %+v not saturated with every connection in use", stats)
+	}
+
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+	if stats := find(); stats.Saturated() {
+		t.Fatalf("pool = %+v still saturated after the connections were returned", stats)
+	}
+}
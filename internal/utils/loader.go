@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultNegativeTTL is the default lifetime of a remembered miss
+const DefaultNegativeTTL = 30 * time.Second
+
+// Loader coalesces concurrent loads of the same key into a single call
+// and remembers not-found results for a short period.
+//
+// Positive results are expected to be cached by the caller (usually in Redis),
+// the loader only guards the path between a cache miss and the database.
+type Loader[T any] struct {
+	group       singleflight.Group
+	misses      sync.Map // key -> negativeEntry
+	negativeTTL time.Duration
+	isNotFound  func(error) bool
+}
+
+type negativeEntry struct {
+	err       error
+	expiresAt time.Time
+}
+
+// NewLoader creates a new loader.
+// isNotFound decides which errors are remembered as misses, nil disables negative caching.
+func NewLoader[T any](negativeTTL time.Duration, isNotFound func(error) bool) *Loader[T] {
+	if negativeTTL <= 0 {
+		negativeTTL = DefaultNegativeTTL
+	}
+	return &Loader[T]{
+		negativeTTL: negativeTTL,
+		isNotFound:  isNotFound,
+	}
+}
+
+// Load returns the value for key, calling fn at most once for concurrent callers.
+// fn runs with a context detached from the caller's cancellation so one
+// cancelled request does not fail every caller waiting on the same key.
+func (l *Loader[T]) Load(ctx context.Context, key string, fn func(ctx context.Context) (*T, error)) (*T, error) {
+	if err := l.lookupMiss(key); err != nil {
+		return nil, err
+	}
+
+	ch := l.group.DoChan(key, func() (any, error) {
+		row, err := fn(context.WithoutCancel(ctx))
+		if err != nil && l.isNotFound != nil && l.isNotFound(err) {
+			l.misses.Store(key, negativeEntry{err: err, expiresAt: time.Now().Add(l.negativeTTL)})
+		}
+		return row, err
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		row, _ := res.Val.(*T)
+		return row, nil
+	}
+}
+
+// Forget drops a remembered miss, call it after creating the entity for key.
+func (l *Loader[T]) Forget(keys ...string) {
+	for _, key := range keys {
+		l.misses.Delete(key)
+	}
+}
+
+// lookupMiss returns the remembered error for key if it has not expired
+func (l *Loader[T]) lookupMiss(key string) error {
+	v, ok := l.misses.Load(key)
+	if !ok {
+		return nil
+	}
+	entry := v.(negativeEntry)
+	if time.Now().After(entry.expiresAt) {
+		l.misses.Delete(key)
+		return nil
+	}
+	return entry.err
+}
@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errMissing = errors.New("not found")
+
+func isMissing(err error) bool { return errors.Is(err, errMissing) }
+
+func TestLoaderCoalescesConcurrentMisses(t *testing.T) {
+	l := NewLoader[string](time.Minute, isMissing)
+	var queries atomic.Int32
+	release := make(chan struct{})
+
+	const callers = 50
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer done.Done()
+			started.Done()
+			row, err := l.Load(context.Background(), "user:u1", func(context.Context) (*string, error) {
+				queries.Add(1)
+				<-release
+				value := "space-1"
+				return &value, nil
+			})
+			if err == nil && (row == nil || *row != "space-1") {
+				err = errors.New("wrong value")
+			}
+			errs <- err
+		}()
+	}
+	started.Wait()
+	time.Sleep(20 * time.Millisecond) // let every caller join the pending load
+	close(release)
+	done.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+	}
+	if n := queries.Load(); n != 1 {
+		t.Fatalf("%d queries for %d concurrent callers, want 1", n, callers)
+	}
+}
+
+func TestLoaderRemembersMisses(t *testing.T) {
+	l := NewLoader[string](50*time.Millisecond, isMissing)
+	var queries atomic.Int32
+	load := func(context.Context) (*string, error) {
+		queries.Add(1)
+		return nil, errMissing
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := l.Load(context.Background(), "user:u1", load); !isMissing(err) {
+			t.Fatalf("Load = %v, want the miss", err)
+		}
+	}
+	if n := queries.Load(); n != 1 {
+		t.Fatalf("%d queries for a remembered miss, want 1", n)
+	}
+
+	l.Forget("user:u1")
+	_, _ = l.Load(context.Background(), "user:u1", load)
+	if n := queries.Load(); n != 2 {
+		t.Fatalf("%d queries after Forget, want 2", n)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	_, _ = l.Load(context.Background(), "user:u1", load)
+	if n := queries.Load(); n != 3 {
+		t.Fatalf("%d queries after the miss expired, want 3", n)
+	}
+}
+
+func TestLoaderDoesNotRememberFailures(t *testing.T) {
+	l := NewLoader[string](time.Minute, isMissing)
+	var queries atomic.Int32
+	for i := 0; i < 2; i++ {
+		_, err := l.Load(context.Background(), "user:u1", func(context.Context) (*string, error) {
+			queries.Add(1)
+			return nil, errors.New("connection refused")
+		})
+		if err == nil {
+			t.Fatal("failure not returned")
+		}
+	}
+	if n := queries.Load(); n != 2 {
+		t.Fatalf("%d queries, want a failure retried", n)
+	}
+}
+
+func TestLoaderCancelledCallerDoesNotCancelLoad(t *testing.T) {
+	l := NewLoader[string](time.Minute, isMissing)
+	release := make(chan struct{})
+	loaded := make(chan error, 1)
+	load := func(ctx context.Context) (*string, error) {
+		<-release
+		loaded <- ctx.Err()
+		value := "space-1"
+		return &value, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := l.Load(ctx, "user:u1", load); !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled caller = %v, want context.Canceled", err)
+	}
+
+	waiting := make(chan error, 1)
+	go func() {
+		_, err := l.Load(context.Background(), "user:u1", load)
+		waiting <- err
+	}()
+	close(release)
+	if err := <-loaded; err != nil {
+		t.Fatalf("load saw %v, want it detached from the cancelled caller", err)
+	}
+	if err := <-waiting; err != nil {
+		t.Fatalf("other caller: %v", err)
+	}
+}
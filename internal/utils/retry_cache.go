@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ncobase/ncore/data/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache retry defaults
+const (
+	DefaultCacheRetries   = 2
+	DefaultCacheBaseDelay = 20 * time.Millisecond
+	DefaultCacheMaxDelay  = 200 * time.Millisecond
+)
+
+// RetryCache wraps a cache and retries operations that failed on transient
+// redis errors with a bounded, jittered backoff.
+//
+// Misses are not errors and are never retried. Once retries are exhausted the
+// last error is returned so callers keep failing open to the database.
+type RetryCache[T any] struct {
+	cache.ICache[T]
+	retries   int
+	baseDelay time.Duration
+	maxDelay  time.Duration
+}
+
+// NewRetryCache wraps c with the default retry policy
+func NewRetryCache[T any](c cache.ICache[T]) *RetryCache[T] {
+	return &RetryCache[T]{
+		ICache:    c,
+		retries:   DefaultCacheRetries,
+		baseDelay: DefaultCacheBaseDelay,
+		maxDelay:  DefaultCacheMaxDelay,
+	}
+}
+
+// Get retrieves a single item from cache
+func (c *RetryCache[T]) Get(ctx context.Context, field string) (row *T, err error) {
+	err = c.do(ctx, func() error {
+		row, err = c.ICache.Get(ctx, field)
+		return err
+	})
+	return row, err
+}
+
+// Set saves a single item into cache
+func (c *RetryCache[T]) Set(ctx context.Context, field string, data *T, expire ...time.Duration) error {
+	return c.do(ctx, func() error {
+		return c.ICache.Set(ctx, field, data, expire...)
+	})
+}
+
+// Delete removes a single item from cache
+func (c *RetryCache[T]) Delete(ctx context.Context, field string) error {
+	return c.do(ctx, func() error {
+		return c.ICache.Delete(ctx, field)
+	})
+}
+
+// GetArray retrieves an array of items from cache
+func (c *RetryCache[T]) GetArray(ctx context.Context, field string, dest any) error {
+	return c.do(ctx, func() error {
+		return c.ICache.GetArray(ctx, field, dest)
+	})
+}
+
+// SetArray saves an array of items into cache
+func (c *RetryCache[T]) SetArray(ctx context.Context, field string, data any, expire ...time.Duration) error {
+	return c.do(ctx, func() error {
+		return c.ICache.SetArray(ctx, field, data, expire...)
+	})
+}
+
+// do runs fn, retrying while the error is transient
+func (c *RetryCache[T]) do(ctx context.Context, fn func() error) error {
+	err := fn()
+	for attempt := 0; attempt < c.retries && IsRetriableCacheError(err); attempt++ {
+		delay := c.baseDelay << attempt
+		if delay > c.maxDelay {
+			delay = c.maxDelay
+		}
+		// Full jitter keeps concurrent retries from lining up
+		delay = time.Duration(rand.Int64N(int64(delay) + 1))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		err = fn()
+	}
+	return err
+}
+
+// IsRetriableCacheError reports whether err is a transient redis or network failure
+func IsRetriableCacheError(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, redis.ErrPoolTimeout) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	// Server side conditions that clear on their own
+	msg := err.Error()
+	return strings.Contains(msg, "LOADING") || strings.Contains(msg, "TRYAGAIN") || strings.Contains(msg, "CLUSTERDOWN")
+}
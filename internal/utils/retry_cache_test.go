@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ncobase/ncore/data/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// flakyCache fails the first failures calls with err, then serves from memory
+type flakyCache struct {
+	cache.ICache[string]
+	failures int
+	err      error
+	calls    int
+	values   map[string]string
+}
+
+func (c *flakyCache) fail() error {
+	c.calls++
+	if c.failures > 0 {
+		c.failures--
+		return c.err
+	}
+	return nil
+}
+
+func (c *flakyCache) Get(_ context.Context, field string) (*string, error) {
+	if err := c.fail(); err != nil {
+		return nil, err
+	}
+	value, ok := c.values[field]
+	if !ok {
+		return nil, redis.Nil
+	}
+	return &value, nil
+}
+
+func (c *flakyCache) Set(_ context.Context, field string, data *string, _ ...time.Duration) error {
+	if err := c.fail(); err != nil {
+		return err
+	}
+	c.values[field] = *data
+	return nil
+}
+
+func newTestRetryCache(c *flakyCache) *RetryCache[string] {
+	r := NewRetryCache[string](c)
+	r.baseDelay, r.maxDelay = time.Millisecond, 2*time.Millisecond
+	return r
+}
+
+func TestRetryCacheRetriesTransientFailure(t *testing.T) {
+	flaky := &flakyCache{failures: 1, err: syscall.ECONNRESET, values: map[string]string{}}
+	c := newTestRetryCache(flaky)
+
+	value := "u1"
+	if err := c.Set(context.Background(), "user", &value); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if flaky.calls != 2 {
+		t.Fatalf("%d calls, want a failure then a success", flaky.calls)
+	}
+
+	flaky.failures, flaky.calls = 1, 0
+	got, err := c.Get(context.Background(), "user")
+	if err != nil || *got != "u1" {
+		t.Fatalf("Get = %v, %v, want u1", got, err)
+	}
+}
+
+func TestRetryCacheGivesUp(t *testing.T) {
+	flaky := &flakyCache{failures: 10, err: io.EOF, values: map[string]string{}}
+	c := newTestRetryCache(flaky)
+
+	if _, err := c.Get(context.Background(), "user"); !errors.Is(err, io.EOF) {
+		t.Fatalf("Get = %v, want the last error", err)
+	}
+	if flaky.calls != DefaultCacheRetries+1 {
+		t.Fatalf("%d calls, want %d", flaky.calls, DefaultCacheRetries+1)
+	}
+}
+
+func TestRetryCacheDoesNotRetryMisses(t *testing.T) {
+	flaky := &flakyCache{values: map[string]string{}}
+	c := newTestRetryCache(flaky)
+
+	if _, err := c.Get(context.Background(), "missing"); !errors.Is(err, redis.Nil) {
+		t.Fatalf("Get = %v, want redis.Nil", err)
+	}
+	if flaky.calls != 1 {
+		t.Fatalf("miss tried %d times, want once", flaky.calls)
+	}
+}
+
+func TestIsRetriableCacheError(t *testing.T) {
+	for err, want := range map[error]bool{
+		nil:                               false,
+		redis.Nil:                         false,
+		context.Canceled:                  false,
+		errors.New("WRONGTYPE Operation"): false,
+		io.EOF:                            true,
+		fmt.Errorf("dial: %w", syscall.ECONNREFUSED): true,
+		redis.ErrPoolTimeout:                         true,
+		errors.New("LOADING Redis is loading"):       true,
+	} {
+		if got := IsRetriableCacheError(err); got != want {
+			t.Errorf("IsRetriableCacheError(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ncobase/ncore/data/search"
+)
+
+// ErrNoSearchClient is returned by SearchIDs when no search client is configured
+var ErrNoSearchClient = errors.New("search client not available")
+
+// SearchIDs returns the IDs of the documents in index matching query, best match first.
+// filter narrows hits to exact field values, e.g. {"space_id": id}.
+//
+// It fails when no search engine is available or the index rejects the query,
+// so callers can fall back to the database.
+func SearchIDs(ctx context.Context, sc *search.Client, index, query string, filter map[string]any, size int) ([]string, error) {
+	if sc == nil {
+		return nil, ErrNoSearchClient
+	}
+
+	res, err := sc.Search(ctx, &search.Request{
+		Index:  index,
+		Query:  query,
+		Filter: filter,
+		Size:   size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		if hit.ID != "" {
+			ids = append(ids, hit.ID)
+		}
+	}
+	return ids, nil
+}
+
+// OrderByIDs sorts rows into the order of ids, dropping rows whose ID is not listed
+func OrderByIDs[T any](rows []T, ids []string, id func(T) string) []T {
+	byID := make(map[string]T, len(rows))
+	for _, row := range rows {
+		byID[id(row)] = row
+	}
+
+	ordered := make([]T, 0, len(rows))
+	for _, key := range ids {
+		if row, ok := byID[key]; ok {
+			ordered = append(ordered, row)
+			delete(byID, key)
+		}
+	}
+	return ordered
+}
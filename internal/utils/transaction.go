@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/ncobase/ncore/data"
+)
+
+// Transaction retry defaults
+const (
+	DefaultTxRetries   = 3
+	DefaultTxBaseDelay = 20 * time.Millisecond
+	DefaultTxMaxDelay  = 500 * time.Millisecond
+)
+
+// retriableTxStates are the SQLSTATE codes of conflicts the database resolved by aborting one transaction
+var retriableTxStates = []string{
+	"40001", // serialization_failure
+	"40P01", // deadlock_detected
+}
+
+// retriableTxMessages match conflicts of drivers that do not expose a SQLSTATE
+var retriableTxMessages = []string{
+	"could not serialize access",
+	"deadlock detected",
+	"Deadlock found when trying to get lock", // MySQL 1213
+	"Lock wait timeout exceeded",             // MySQL 1205
+	"database is locked",                     // SQLite busy
+}
+
+// txHooksKey is the context key of the commit hooks of a request-scoped transaction
+type txHooksKey struct{}
+
+// txHooks are the functions to run once a transaction commits
+type txHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// WithTx runs fn in a request-scoped database transaction.
+//
+// The transaction is stored in the context, every ent client whose driver is
+// wrapped with ContextTxDriver runs its queries in it, so repositories of any
+// module join without changes. A nested call joins the outer transaction.
+// Side effects registered with OnCommit run only after the commit.
+func WithTx(ctx context.Context, d *data.Data, fn func(ctx context.Context) error) error {
+	if InTx(ctx) {
+		return fn(ctx)
+	}
+
+	hooks := &txHooks{}
+	if err := d.WithTx(context.WithValue(ctx, txHooksKey{}, hooks), fn); err != nil {
+		return err
+	}
+
+	hooks.mu.Lock()
+	fns := hooks.fns
+	hooks.mu.Unlock()
+	for _, f := range fns {
+		f()
+	}
+	return nil
+}
+
+// WithTxRetry runs fn like WithTx and runs it again in a new transaction when
+// the database aborted it on a serialization failure or deadlock, up to
+// DefaultTxRetries times with a jittered backoff. Other errors are returned
+// unchanged on the first attempt, so fn must be safe to run more than once.
+// Inside an outer transaction fn runs once, the outer caller owns the retry.
+func WithTxRetry(ctx context.Context, d *data.Data, fn func(ctx context.Context) error) error {
+	if InTx(ctx) {
+		return fn(ctx)
+	}
+
+	err := WithTx(ctx, d, fn)
+	for attempt := 0; attempt < DefaultTxRetries && IsRetriableTxError(err); attempt++ {
+		delay := min(DefaultTxBaseDelay<<attempt, DefaultTxMaxDelay)
+		// Full jitter keeps the conflicting transactions from colliding again
+		delay = time.Duration(rand.Int64N(int64(delay) + 1))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		err = WithTx(ctx, d, fn)
+	}
+	return err
+}
+
+// IsRetriableTxError reports whether err is a serialization failure or deadlock
+// that a new attempt of the whole transaction can succeed after
+func IsRetriableTxError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var coded interface{ SQLState() string }
+	if errors.As(err, &coded) {
+		for _, state := range retriableTxStates {
+			if coded.SQLState() == state {
+				return true
+			}
+		}
+	}
+
+	msg := err.Error()
+	for _, state := range retriableTxStates {
+		if strings.Contains(msg, "SQLSTATE "+state) {
+			return true
+		}
+	}
+	for _, m := range retriableTxMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// InTx reports whether the context carries a transaction
+func InTx(ctx context.Context) bool {
+	_, err := data.GetTx(ctx)
+	return err == nil
+}
+
+// OnCommit runs f after the transaction in the context commits, or right away outside a transaction.
+// Use it for cache writes and index updates that must not outlive a rollback.
+func OnCommit(ctx context.Context, f func()) {
+	if hooks, ok := ctx.Value(txHooksKey{}).(*txHooks); ok && InTx(ctx) {
+		hooks.mu.Lock()
+		hooks.fns = append(hooks.fns, f)
+		hooks.mu.Unlock()
+		return
+	}
+	f()
+}
+
+// ContextTxDriver wraps an ent driver so queries run in the transaction stored
+// in the context by WithTx, and fall back to the driver otherwise.
+func ContextTxDriver(drv dialect.Driver) dialect.Driver {
+	return &txDriver{Driver: drv}
+}
+
+// txDriver routes queries to the contextual transaction
+type txDriver struct {
+	dialect.Driver
+}
+
+// Exec executes a statement, in the contextual transaction when present
+func (d *txDriver) Exec(ctx context.Context, query string, args, v any) error {
+	if tx, err := data.GetTx(ctx); err == nil {
+		return entsql.Conn{ExecQuerier: tx}.Exec(ctx, query, args, v)
+	}
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+// Query runs a query, in the contextual transaction when present
+func (d *txDriver) Query(ctx context.Context, query string, args, v any) error {
+	if tx, err := data.GetTx(ctx); err == nil {
+		return entsql.Conn{ExecQuerier: tx}.Query(ctx, query, args, v)
+	}
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+// Tx starts an ent transaction. Inside a contextual transaction it joins it:
+// commit and rollback are left to WithTx, which sees the error of the caller.
+func (d *txDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	if InTx(ctx) {
+		return dialect.NopTx(d), nil
+	}
+	return d.Driver.Tx(ctx)
+}
+
+// BeginTx starts an ent transaction with options, joining the contextual one like Tx
+func (d *txDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	if InTx(ctx) {
+		return dialect.NopTx(d), nil
+	}
+	if drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	}); ok {
+		return drv.BeginTx(ctx, opts)
+	}
+	return d.Driver.Tx(ctx)
+}
+
+// WithoutTx returns ctx detached from its transaction, for work that runs after the
+// commit, such as an OnCommit hook, and must not use the finished transaction
+func WithoutTx(ctx context.Context) context.Context {
+	if !InTx(ctx) {
+		return ctx
+	}
+	return context.WithValue(ctx, data.ContextKeyTransaction, nil)
+}
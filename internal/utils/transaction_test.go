@@ -0,0 +1,300 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/ncobase/ncore/data"
+	dataConfig "github.com/ncobase/ncore/data/config"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriver connects the data layer to a sqlite file through the named database/sql driver
+type sqliteDriver string
+
+func (d sqliteDriver) Name() string { return string(d) }
+
+func (d sqliteDriver) Connect(_ context.Context, cfg any) (any, error) {
+	return sql.Open(string(d), cfg.(*dataConfig.DBNode).Source)
+}
+
+func (sqliteDriver) Close(conn any) error { return conn.(*sql.DB).Close() }
+
+func (sqliteDriver) Ping(ctx context.Context, conn any) error {
+	return conn.(*sql.DB).PingContext(ctx)
+}
+
+// conflicts is how many statements the conflicting driver still aborts
+var conflicts atomic.Int32
+
+// serializationError is the error a database reports for a transaction it aborted on a conflict
+type serializationError struct{}
+
+func (serializationError) Error() string {
+	return "ERROR: could not serialize access due to concurrent update (SQLSTATE 40001)"
+}
+
+func (serializationError) SQLState() string { return "40001" }
+
+// conflictingDriver is sqlite whose statements fail with a serialization error while conflicts remain
+type conflictingDriver struct {
+	driver.Driver
+}
+
+func (d conflictingDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return conflictingConn{conn}, nil
+}
+
+type conflictingConn struct {
+	driver.Conn
+}
+
+func (c conflictingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if conflicts.Add(-1) >= 0 {
+		return nil, serializationError{}
+	}
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func init() {
+	data.RegisterDatabaseDriver(sqliteDriver("sqlite3"))
+
+	db, _ := sql.Open("sqlite3", "")
+	sql.Register("sqlite3-conflicting", conflictingDriver{db.Driver()})
+	data.RegisterDatabaseDriver(sqliteDriver("sqlite3-conflicting"))
+}
+
+// openTestData opens a data layer on a fresh sqlite file with a table of names
+func openTestData(t *testing.T) *data.Data {
+	return openTestDataWith(t, "sqlite3")
+}
+
+// openTestDataWith opens the data layer through a registered driver
+func openTestDataWith(t *testing.T, driverName string) *data.Data {
+	t.Helper()
+	d, cleanup, err := data.New(&dataConfig.Config{
+		Database: &dataConfig.Database{
+			Master: &dataConfig.DBNode{Driver: driverName, Source: filepath.Join(t.TempDir(), "test.db")},
+		},
+		Search: &dataConfig.Search{},
+	}, true)
+	if err != nil {
+		t.Fatalf("open data: %v", err)
+	}
+	t.Cleanup(func() { cleanup() })
+	if _, err := d.GetMasterDB().Exec("CREATE TABLE names (name TEXT)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	return d
+}
+
+// insertName inserts a name through a driver that joins the contextual transaction
+func insertName(ctx context.Context, d *data.Data, name string) error {
+	drv := ContextTxDriver(entsql.OpenDB("sqlite3", d.GetMasterDB()))
+	return drv.Exec(ctx, "INSERT INTO names (name) VALUES (?)", []any{name}, nil)
+}
+
+func countNames(t *testing.T, d *data.Data) int {
+	t.Helper()
+	var n int
+	if err := d.GetMasterDB().QueryRow("SELECT COUNT(*) FROM names").Scan(&n); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	return n
+}
+
+func TestWithTxRollsBackEveryStep(t *testing.T) {
+	d := openTestData(t)
+	ctx := context.Background()
+
+	committed := false
+	failed := errors.New("last step failed")
+	err := WithTx(ctx, d, func(ctx context.Context) error {
+		for _, name := range []string{"space", "role", "member"} {
+			if err := insertName(ctx, d, name); err != nil {
+				return err
+			}
+		}
+		OnCommit(ctx, func() { committed = true })
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("WithTx = %v, want the step error", err)
+	}
+	if n := countNames(t, d); n != 0 {
+		t.Fatalf("%d rows left after the rollback, want none", n)
+	}
+	if committed {
+		t.Fatal("commit hook ran after a rollback")
+	}
+
+	if err := WithTx(ctx, d, func(ctx context.Context) error {
+		OnCommit(ctx, func() { committed = true })
+		return insertName(ctx, d, "space")
+	}); err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	if n := countNames(t, d); n != 1 || !committed {
+		t.Fatalf("%d rows and commit hook run %v, want 1 and true", n, committed)
+	}
+}
+
+func TestNestedTransactionsJoinTheOuterOne(t *testing.T) {
+	d := openTestData(t)
+	ctx := context.Background()
+
+	err := WithTx(ctx, d, func(ctx context.Context) error {
+		if err := WithTx(ctx, d, func(ctx context.Context) error { return insertName(ctx, d, "inner") }); err != nil {
+			return err
+		}
+
+		// an ent transaction started inside joins too, its commit is left to the outer one
+		tx, err := ContextTxDriver(entsql.OpenDB("sqlite3", d.GetMasterDB())).Tx(ctx)
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec(ctx, "INSERT INTO names (name) VALUES (?)", []any{"ent"}, nil); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		return errors.New("outer step failed")
+	})
+	if err == nil {
+		t.Fatal("WithTx succeeded, want the outer error")
+	}
+	if n := countNames(t, d); n != 0 {
+		t.Fatalf("%d rows left after the outer rollback, want none", n)
+	}
+}
+
+func TestOnCommitOutsideTransactionRunsNow(t *testing.T) {
+	ran := false
+	OnCommit(context.Background(), func() { ran = true })
+	if !ran {
+		t.Fatal("hook outside a transaction did not run")
+	}
+
+	d := openTestData(t)
+	if err := WithTx(context.Background(), d, func(ctx context.Context) error {
+		if !InTx(ctx) || InTx(WithoutTx(ctx)) {
+			t.Error("WithoutTx did not detach the transaction")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+}
+
+// withConflicts makes the conflicting driver abort the next n statements
+func withConflicts(t *testing.T, n int32) {
+	t.Helper()
+	conflicts.Store(n)
+	t.Cleanup(func() { conflicts.Store(0) })
+}
+
+func TestWithTxRetryRetriesSerializationFailures(t *testing.T) {
+	d := openTestDataWith(t, "sqlite3-conflicting")
+	withConflicts(t, 1)
+
+	attempts := 0
+	err := WithTxRetry(context.Background(), d, func(ctx context.Context) error {
+		attempts++
+		return insertName(ctx, d, "space")
+	})
+	if err != nil {
+		t.Fatalf("WithTxRetry: %v", err)
+	}
+	if attempts != 2 || countNames(t, d) != 1 {
+		t.Fatalf("%d attempts and %d rows, want 2 and 1", attempts, countNames(t, d))
+	}
+
+	// conflicts on every attempt give up after the retries
+	withConflicts(t, 100)
+	attempts = 0
+	err = WithTxRetry(context.Background(), d, func(ctx context.Context) error {
+		attempts++
+		return insertName(ctx, d, "space")
+	})
+	if !IsRetriableTxError(err) || attempts != DefaultTxRetries+1 {
+		t.Fatalf("WithTxRetry = %v after %d attempts, want the conflict after %d", err, attempts, DefaultTxRetries+1)
+	}
+}
+
+func TestWithTxRetryPassesOtherErrorsThrough(t *testing.T) {
+	d := openTestData(t)
+	failed := errors.New("space name taken")
+
+	attempts := 0
+	err := WithTxRetry(context.Background(), d, func(ctx context.Context) error {
+		attempts++
+		return failed
+	})
+	if err != failed || attempts != 1 {
+		t.Fatalf("WithTxRetry = %v after %d attempts, want the error unchanged after 1", err, attempts)
+	}
+
+	// inside an outer transaction the outer caller owns the retry
+	attempts = 0
+	err = WithTx(context.Background(), d, func(ctx context.Context) error {
+		return WithTxRetry(ctx, d, func(ctx context.Context) error {
+			attempts++
+			return serializationError{}
+		})
+	})
+	if !IsRetriableTxError(err) || attempts != 1 {
+		t.Fatalf("WithTx = %v after %d inner attempts, want the conflict after 1", err, attempts)
+	}
+}
+
+func TestWithTxRetryStopsWhenCancelled(t *testing.T) {
+	d := openTestData(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	attempts := 0
+	err := WithTxRetry(ctx, d, func(ctx context.Context) error {
+		attempts++
+		return serializationError{}
+	})
+	if err == nil || attempts > 1 || time.Since(start) > time.Second {
+		t.Fatalf("WithTxRetry = %v after %d attempts, want to stop once cancelled", err, attempts)
+	}
+}
+
+func TestIsRetriableTxError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{serializationError{}, true},
+		{fmt.Errorf("assign role: %w", serializationError{}), true},
+		{errors.New("pq: deadlock detected"), true},
+		{errors.New("Error 1213: Deadlock found when trying to get lock; try restarting"), true},
+		{errors.New("ERROR: could not obtain lock (SQLSTATE 40P01)"), true},
+		{errors.New("database is locked"), true},
+		{errors.New("duplicate key value violates unique constraint"), false},
+		{context.Canceled, false},
+		{fmt.Errorf("query: %w", context.DeadlineExceeded), false},
+		{nil, false},
+	} {
+		if got := IsRetriableTxError(tc.err); got != tc.want {
+			t.Errorf("IsRetriableTxError(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultParallelism is the number of items a batch works on at once when not configured.
+// It is kept low so batches share storage and the database with live traffic.
+const DefaultParallelism = 2
+
+// WorkerOptions bound a batch run
+type WorkerOptions struct {
+	Parallelism int                   // items in flight at once, DefaultParallelism when not positive
+	ItemTimeout time.Duration         // deadline of the context of each item, none when not positive
+	Progress    func(done, total int) // called after each item, never concurrently
+}
+
+// ForEach calls fn for every item with at most opts.Parallelism calls running at once,
+// and waits for them. Once ctx is done no further item is started; ForEach then returns
+// the context error after the running calls finish. fn handles the errors of its item.
+func ForEach[T any](ctx context.Context, items []T, opts WorkerOptions, fn func(ctx context.Context, item T)) error {
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int
+	)
+	sem := make(chan struct{}, parallelism)
+
+	for _, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(item T) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			itemCtx, cancel := ctx, context.CancelFunc(func() {})
+			if opts.ItemTimeout > 0 {
+				itemCtx, cancel = context.WithTimeout(ctx, opts.ItemTimeout)
+			}
+			fn(itemCtx, item)
+			cancel()
+
+			if opts.Progress != nil {
+				mu.Lock()
+				done++
+				opts.Progress(done, len(items))
+				mu.Unlock()
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	return ctx.Err()
+}
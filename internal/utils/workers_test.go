@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrency tracks the calls running at once and the most seen
+type concurrency struct {
+	running, peak atomic.Int32
+}
+
+// run holds a call slot for a moment
+func (c *concurrency) run() {
+	n := c.running.Add(1)
+	for {
+		p := c.peak.Load()
+		if n <= p || c.peak.CompareAndSwap(p, n) {
+			break
+		}
+	}
+	time.Sleep(2 * time.Millisecond)
+	c.running.Add(-1)
+}
+
+func TestForEachBoundsConcurrency(t *testing.T) {
+	items := make([]int, 40)
+	var (
+		c        concurrency
+		calls    atomic.Int32
+		progress []int
+	)
+
+	err := ForEach(context.Background(), items, WorkerOptions{
+		Parallelism: 3,
+		Progress:    func(done, total int) { progress = append(progress, done) },
+	}, func(context.Context, int) {
+		c.run()
+		calls.Add(1)
+	})
+	if err != nil {
+		t.Fatalf("ForEach: %v", err)
+	}
+	if calls.Load() != 40 {
+		t.Fatalf("%d calls, want 40", calls.Load())
+	}
+	if c.peak.Load() != 3 {
+		t.Fatalf("%d items ran at once, want 3", c.peak.Load())
+	}
+	if len(progress) != 40 || progress[39] != 40 {
+		t.Fatalf("progress reported %v", progress)
+	}
+}
+
+func TestForEachDefaultsToConservativeParallelism(t *testing.T) {
+	var c concurrency
+	_ = ForEach(context.Background(), make([]int, 10), WorkerOptions{}, func(context.Context, int) { c.run() })
+	if c.peak.Load() != DefaultParallelism {
+		t.Fatalf("%d items ran at once, want %d", c.peak.Load(), DefaultParallelism)
+	}
+}
+
+func TestForEachStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		started []int
+	)
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	err := ForEach(ctx, items, WorkerOptions{Parallelism: 2}, func(ctx context.Context, item int) {
+		mu.Lock()
+		started = append(started, item)
+		mu.Unlock()
+		if item == 1 {
+			cancel()
+		}
+		<-ctx.Done()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if len(started) != 2 {
+		t.Fatalf("items %v started, want only the first two", started)
+	}
+}
+
+func TestForEachItemTimeout(t *testing.T) {
+	var expired atomic.Int32
+	err := ForEach(context.Background(), []int{1, 2, 3}, WorkerOptions{ItemTimeout: 10 * time.Millisecond}, func(ctx context.Context, _ int) {
+		<-ctx.Done()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			expired.Add(1)
+		}
+	})
+	if err != nil {
+		t.Fatalf("ForEach: %v, the batch itself did not time out", err)
+	}
+	if expired.Load() != 3 {
+		t.Fatalf("%d items hit their deadline, want 3", expired.Load())
+	}
+}
@@ -0,0 +1,63 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// messages holds the friendly messages of the validation rules per language. A message
+// takes the field path and, when it has a second placeholder, the rule parameter.
+var messages = map[string]map[string]string{
+	"en": {
+		"required":    "The field '%s' is required.",
+		"required_if": "The field '%s' is required.",
+		"email":       "The field '%s' must be a valid email address.",
+		"url":         "The field '%s' must be a valid URL.",
+		"min":         "The field '%s' must be at least %s.",
+		"max":         "The field '%s' must be at most %s.",
+		"len":         "The field '%s' must have a length of %s.",
+		"lte":         "The field '%s' must be less than or equal to %s.",
+		"gte":         "The field '%s' must be greater than or equal to %s.",
+		"lt":          "The field '%s' must be less than %s.",
+		"gt":          "The field '%s' must be greater than %s.",
+		"oneof":       "The field '%s' must be one of %s.",
+		"eqfield":     "The field '%s' must match %s.",
+		"unique":      "The field '%s' must be unique.",
+		"type":        "The field '%s' must be of type %s.",
+	},
+	"zh": {
+		"required":    "字段 '%s' 为必填项。",
+		"required_if": "字段 '%s' 为必填项。",
+		"email":       "字段 '%s' 必须是有效的电子邮箱地址。",
+		"url":         "字段 '%s' 必须是有效的 URL。",
+		"min":         "字段 '%s' 不能小于 %s。",
+		"max":         "字段 '%s' 不能大于 %s。",
+		"len":         "字段 '%s' 的长度必须为 %s。",
+		"lte":         "字段 '%s' 的值必须小于或等于 %s。",
+		"gte":         "字段 '%s' 的值必须大于或等于 %s。",
+		"lt":          "字段 '%s' 的值必须小于 %s。",
+		"gt":          "字段 '%s' 的值必须大于 %s。",
+		"oneof":       "字段 '%s' 的值必须是 %s 之一。",
+		"eqfield":     "字段 '%s' 必须与 %s 一致。",
+		"unique":      "字段 '%s' 的值必须唯一。",
+		"type":        "字段 '%s' 的类型必须是 %s。",
+	},
+}
+
+// message returns the friendly message of a failed rule, in English unless lang names another known language
+func message(code, path, param string, lang ...string) string {
+	msgs := messages["en"]
+	if len(lang) > 0 {
+		if m, ok := messages[lang[0]]; ok {
+			msgs = m
+		}
+	}
+	msg, ok := msgs[code]
+	if !ok {
+		return fmt.Sprintf("Field '%s' is invalid: %s", path, code)
+	}
+	if strings.Count(msg, "%s") == 2 {
+		return fmt.Sprintf(msg, path, param)
+	}
+	return fmt.Sprintf(msg, path)
+}
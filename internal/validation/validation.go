@@ -0,0 +1,151 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// FieldError describes why a field of a request body is invalid
+type FieldError struct {
+	Code    string `json:"code"`            // the failed rule, "required" or "max" for example
+	Param   string `json:"param,omitempty"` // the rule parameter, the limit of "max=100" for example
+	Message string `json:"message"`
+}
+
+// Errors maps the dotted JSON path of each invalid field, "items.0.name" for example, to its error
+type Errors map[string]FieldError
+
+// ShouldBindAndValidateStruct binds the request into obj and validates it. Invalid fields, including
+// those rejected while binding, are returned together as Errors keyed by their full JSON path; the
+// error is only set when the request could not be read at all.
+func ShouldBindAndValidateStruct(c *gin.Context, obj any, lang ...string) (Errors, error) {
+	errs := Errors{}
+	if err := c.ShouldBind(obj); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			// The body was not decoded, its rules cannot be checked
+			errs[typeErr.Field] = FieldError{
+				Code:    "type",
+				Param:   typeErr.Value,
+				Message: message("type", typeErr.Field, typeErr.Type.String(), lang...),
+			}
+			return errs, nil
+		}
+		if !errs.add(obj, err, lang...) {
+			return nil, err
+		}
+	}
+	for path, e := range Validate(obj, lang...) {
+		errs[path] = e
+	}
+	return errs, nil
+}
+
+// Validate validates obj, embedded and nested structs included, and returns its invalid fields
+func Validate(obj any, lang ...string) Errors {
+	errs := Errors{}
+	if err := validate.Struct(obj); err != nil {
+		errs.add(obj, err, lang...)
+	}
+	return errs
+}
+
+// add records the field errors of a validation error of obj, it reports whether err was one
+func (errs Errors) add(obj any, err error, lang ...string) bool {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return false
+	}
+	root := reflect.TypeOf(obj)
+	for _, e := range fieldErrs {
+		path := fieldPath(root, e.StructNamespace())
+		errs[path] = FieldError{
+			Code:    e.Tag(),
+			Param:   e.Param(),
+			Message: message(e.Tag(), path, e.Param(), lang...),
+		}
+	}
+	return true
+}
+
+// fieldPath converts a validator namespace of Go field names, "CreateBody.Body.Items[0].Name",
+// into the JSON path of the field, "items.0.name". Embedded structs without a JSON name are
+// flattened like encoding/json does, so their fields sit at the level of the parent.
+func fieldPath(root reflect.Type, namespace string) string {
+	segments := strings.Split(namespace, ".")
+	if len(segments) > 1 {
+		segments = segments[1:] // the root type name
+	}
+
+	t := indirect(root)
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		name, indexes := splitIndexes(segment)
+
+		var field reflect.StructField
+		found := false
+		if t != nil && t.Kind() == reflect.Struct {
+			field, found = t.FieldByName(name)
+		}
+		if !found {
+			// Unknown shape, keep the Go names rather than guess
+			path = append(path, name)
+			path = append(path, indexes...)
+			t = nil
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch {
+		case field.Anonymous && jsonName == "":
+			// Embedded struct, its fields are promoted
+		case jsonName == "" || jsonName == "-":
+			path = append(path, name)
+		default:
+			path = append(path, jsonName)
+		}
+		path = append(path, indexes...)
+
+		t = indirect(field.Type)
+		for range indexes {
+			if t == nil {
+				break
+			}
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				t = indirect(t.Elem())
+			default:
+				t = nil
+			}
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+// splitIndexes splits "Items[0][key]" into "Items" and its indexes "0" and "key"
+func splitIndexes(segment string) (string, []string) {
+	name, rest, ok := strings.Cut(segment, "[")
+	if !ok {
+		return segment, nil
+	}
+	var indexes []string
+	for _, part := range strings.Split(rest, "[") {
+		indexes = append(indexes, strings.TrimSuffix(part, "]"))
+	}
+	return name, indexes
+}
+
+// indirect returns the type a chain of pointers points to
+func indirect(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
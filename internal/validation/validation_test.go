@@ -0,0 +1,126 @@
+package validation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"len=5"`
+}
+
+type item struct {
+	Name  string `json:"name" validate:"required"`
+	Count int    `json:"count" validate:"gte=1,lte=100"`
+}
+
+type orderBody struct {
+	Title   string           `json:"title" validate:"required,max=10"`
+	Email   string           `json:"email" validate:"omitempty,email"`
+	Address address          `json:"address"`
+	Billing *address         `json:"billing,omitempty"`
+	Items   []*item          `json:"items" validate:"required,dive"`
+	Labels  map[string]*item `json:"labels" validate:"dive"`
+	Note    string           `validate:"max=3"`
+}
+
+// createOrderBody embeds the body like the create bodies of the modules do
+type createOrderBody struct {
+	orderBody
+	SpaceID string `json:"space_id" validate:"required"`
+}
+
+// bind binds body into a createOrderBody through a gin request
+func bind(t *testing.T, body string, lang ...string) (Errors, error) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return ShouldBindAndValidateStruct(c, &createOrderBody{}, lang...)
+}
+
+func TestNestedErrorsCarryJSONPaths(t *testing.T) {
+	errs, err := bind(t, `{
+		"title": "a title too long",
+		"email": "nope",
+		"address": {"zip": "123"},
+		"billing": {"city": "Paris", "zip": "7500"},
+		"items": [{"name": "a", "count": 1}, {"count": 0}],
+		"labels": {"gift": {"name": "g", "count": 101}},
+		"Note": "long"
+	}`)
+	if err != nil {
+		t.Fatalf("bind: %v", err)
+	}
+
+	want := map[string]FieldError{
+		"space_id":          {Code: "required"},
+		"title":             {Code: "max", Param: "10"},
+		"email":             {Code: "email"},
+		"address.city":      {Code: "required"},
+		"address.zip":       {Code: "len", Param: "5"},
+		"billing.zip":       {Code: "len", Param: "5"},
+		"items.1.name":      {Code: "required"},
+		"items.1.count":     {Code: "gte", Param: "1"},
+		"labels.gift.count": {Code: "lte", Param: "100"},
+		"Note":              {Code: "max", Param: "3"},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors %v, want %d", len(errs), errs, len(want))
+	}
+	for path, w := range want {
+		got, ok := errs[path]
+		if !ok || got.Code != w.Code || got.Param != w.Param {
+			t.Fatalf("error of %s = %+v, want %+v", path, got, w)
+		}
+	}
+	if msg := errs["items.1.count"].Message; msg != "The field 'items.1.count' must be greater than or equal to 1." {
+		t.Fatalf("message %q", msg)
+	}
+}
+
+func TestTypeErrorsStopValidation(t *testing.T) {
+	errs, err := bind(t, `{"title": "t", "space_id": "s1", "items": [{"name": "a", "count": "many"}]}`)
+	if err != nil {
+		t.Fatalf("bind: %v", err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("errors %v, want only the type error", errs)
+	}
+	for path, e := range errs {
+		if !strings.HasSuffix(path, "count") || e.Code != "type" || e.Param != "string" {
+			t.Fatalf("type error %s = %+v", path, e)
+		}
+	}
+}
+
+func TestValidBodyAndUnreadableRequest(t *testing.T) {
+	errs, err := bind(t, `{"title": "t", "space_id": "s1", "address": {"city": "Oslo", "zip": "01500"}, "items": [{"name": "a", "count": 2}]}`)
+	if err != nil || len(errs) != 0 {
+		t.Fatalf("valid body: %v, %v", errs, err)
+	}
+
+	if _, err := bind(t, `{"title": `); err == nil {
+		t.Fatal("truncated body accepted")
+	}
+}
+
+func TestMessagesFollowLanguage(t *testing.T) {
+	errs, err := bind(t, `{"title": "t", "address": {"city": "Oslo", "zip": "01500"}, "items": []}`, "zh")
+	if err != nil {
+		t.Fatalf("bind: %v", err)
+	}
+	if msg := errs["space_id"].Message; msg != "字段 'space_id' 为必填项。" {
+		t.Fatalf("zh message %q", msg)
+	}
+	if got := message("uuid", "id", ""); got != "Field 'id' is invalid: uuid" {
+		t.Fatalf("message of an unknown rule %q", got)
+	}
+}
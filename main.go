@@ -14,8 +14,13 @@ import (
 	"syscall"
 	"time"
 
+	"ncobase/internal/command"
+	"ncobase/internal/drain"
+	"ncobase/internal/logging"
 	"ncobase/internal/version"
 
+	appConfig "ncobase/internal/config"
+
 	"github.com/ncobase/ncore/config"
 	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/logging/observes"
@@ -30,10 +35,6 @@ import (
 	_ "github.com/ncobase/ncore/logging/hooks/meilisearch"
 )
 
-const (
-	shutdownTimeout = 3 * time.Second // service shutdown timeout
-)
-
 // @title Ncobase
 // @version 0.1.0
 // @description Base Development Framework
@@ -70,6 +71,14 @@ func main() {
 	cleanupLogger := initializeLogger(conf)
 	defer cleanupLogger()
 
+	// run maintenance command instead of the server when one is given
+	if args := flag.Args(); len(args) > 0 {
+		if err := command.Run(context.Background(), conf, args); err != nil {
+			logger.Fatalf(context.Background(), "Command error: %v", err)
+		}
+		return
+	}
+
 	logger.Infof(context.Background(), "Starting %s", appName)
 
 	if err := runServer(conf); err != nil {
@@ -118,7 +127,7 @@ func runServer(conf *config.Config) error {
 		}
 	}()
 
-	return gracefulShutdown(srv, errChan)
+	return gracefulShutdown(srv, errChan, drain.Timeout(conf.Viper))
 }
 
 // createListener creates network listener
@@ -157,10 +166,13 @@ func createListener(conf *config.Config) (net.Listener, error) {
 
 // loadConfig loads the application configuration
 func loadConfig() *config.Config {
-	conf, err := config.Init()
+	conf, err := appConfig.Load()
 	if err != nil {
 		logger.Fatalf(context.Background(), "[Config] Initialization error: %+v", err)
 	}
+	if err := appConfig.Validate(conf); err != nil {
+		logger.Fatalf(context.Background(), "[Config] Validation error: %v", err)
+	}
 	return conf
 }
 
@@ -206,6 +218,9 @@ func initializeLogger(conf *config.Config) func() {
 	if err != nil {
 		logger.Fatalf(context.Background(), "[Logger] Initialization error: %+v", err)
 	}
+	if err := logging.SetupModuleLevels(conf.Viper); err != nil {
+		logger.Fatalf(context.Background(), "[Logger] Module levels error: %+v", err)
+	}
 	return l
 }
 
@@ -238,8 +253,10 @@ func createServer(conf *config.Config) (http.Handler, func(), error) {
 // 	os.Exit(0)
 // }
 
-// gracefulShutdown gracefully shuts down the server
-func gracefulShutdown(srv *http.Server, errChan chan error) error {
+// gracefulShutdown drains the server on SIGINT or SIGTERM: new requests are refused and
+// long-lived connections closed, then in-flight requests get up to drainTimeout to finish.
+// Extension cleanup runs after, when runServer returns.
+func gracefulShutdown(srv *http.Server, errChan chan error, drainTimeout time.Duration) error {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
@@ -248,23 +265,25 @@ func gracefulShutdown(srv *http.Server, errChan chan error) error {
 		return fmt.Errorf("server error: %w", err)
 
 	case <-quit:
-		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		logger.Infof(context.Background(), "Draining in-flight requests for up to %s", drainTimeout)
+		start := time.Now()
+		drain.Begin()
+
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
 		defer cancel()
 
-		// Execute shutdown logic
+		// Shutdown closes the listener and waits for active requests to complete
 		if err := srv.Shutdown(ctx); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				logger.Warnf(context.Background(), "Drain timed out after %s, closing remaining connections", drainTimeout)
+				_ = srv.Close()
+				return nil
+			}
 			logger.Errorf(context.Background(), "Shutdown error: %v", err)
 			return fmt.Errorf("shutdown error: %w", err)
 		}
 
-		// wait for server to shutdown
-		<-ctx.Done()
-		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-			logger.Debugf(context.Background(), "Shutdown timed out after %s", shutdownTimeout)
-		} else {
-			logger.Debugf(context.Background(), "Shutdown completed within %s", shutdownTimeout)
-		}
-
+		logger.Debugf(context.Background(), "Drain completed in %s", time.Since(start).Round(time.Millisecond))
 		return nil
 	}
 }
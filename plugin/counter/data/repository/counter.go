@@ -106,14 +106,14 @@ func (r *counterRepository) Create(ctx context.Context, body *structs.CreateCoun
 	// execute the builder.
 	row, err := builder.Save(ctx)
 	if err != nil {
-		logger.Errorf(context.Background(), "counterRepo.Create error: %v", err)
+		logger.Errorf(ctx, "counterRepo.Create error: %v", err)
 		return nil, err
 	}
 
 	// Create the counter in Meilisearch index
 	if r.sc != nil {
 		if err = r.sc.Index(ctx, &search.IndexRequest{Index: "counters", Document: row}); err != nil {
-			logger.Errorf(context.Background(), "counterRepo.Create error creating Meilisearch index: %v", err)
+			logger.Errorf(ctx, "counterRepo.Create error creating Meilisearch index: %v", err)
 			// return nil, err
 		}
 	}
@@ -138,14 +138,14 @@ func (r *counterRepository) GetByID(ctx context.Context, id string) (*ent.Counte
 	// If not found in cache, query the database
 	row, err := r.FindCounter(ctx, &structs.FindCounter{Counter: id})
 	if err != nil {
-		logger.Errorf(context.Background(), "counterRepo.GetByID error: %v", err)
+		logger.Errorf(ctx, "counterRepo.GetByID error: %v", err)
 		return nil, err
 	}
 
 	// cache the result
 	err = r.c.Set(ctx, cacheKey, row)
 	if err != nil {
-		logger.Errorf(context.Background(), "counterRepo.GetByID cache error: %v", err)
+		logger.Errorf(ctx, "counterRepo.GetByID cache error: %v", err)
 	}
 
 	return row, nil
@@ -161,7 +161,7 @@ func (r *counterRepository) GetByIDs(ctx context.Context, counterIDs []string) (
 	// execute the builder.
 	rows, err := builder.All(ctx)
 	if err != nil {
-		logger.Errorf(context.Background(), "counterRepo.GetByIDs error: %v", err)
+		logger.Errorf(ctx, "counterRepo.GetByIDs error: %v", err)
 		return nil, err
 	}
 
@@ -209,7 +209,7 @@ func (r *counterRepository) Update(ctx context.Context, slug string, updates typ
 	// execute the builder.
 	row, err := builder.Save(ctx)
 	if err != nil {
-		logger.Errorf(context.Background(), "counterRepo.Update error: %v", err)
+		logger.Errorf(ctx, "counterRepo.Update error: %v", err)
 		return nil, err
 	}
 
@@ -218,19 +218,19 @@ func (r *counterRepository) Update(ctx context.Context, slug string, updates typ
 	err = r.c.Delete(ctx, cacheKey)
 	err = r.c.Delete(ctx, counter.ID)
 	if err != nil {
-		logger.Errorf(context.Background(), "counterRepo.Update cache error: %v", err)
+		logger.Errorf(ctx, "counterRepo.Update cache error: %v", err)
 	}
 
 	// Update the counter in Meilisearch index
 	if r.sc != nil {
 		if err = r.sc.Delete(ctx, "counters", slug); err != nil {
-			logger.Errorf(context.Background(), "counterRepo.Update error deleting Meilisearch index: %v", err)
+			logger.Errorf(ctx, "counterRepo.Update error deleting Meilisearch index: %v", err)
 			// return nil, err
 		}
 	}
 	if r.sc != nil {
 		if err = r.sc.Index(ctx, &search.IndexRequest{Index: "counters", Document: row, DocumentID: row.ID}); err != nil {
-			logger.Errorf(context.Background(), "counterRepo.Update error updating Meilisearch index: %v", err)
+			logger.Errorf(ctx, "counterRepo.Update error updating Meilisearch index: %v", err)
 			// return nil, err
 		}
 	}
@@ -294,7 +294,7 @@ func (r *counterRepository) List(ctx context.Context, params *structs.ListCounte
 
 	rows, err := builder.All(ctx)
 	if err != nil {
-		logger.Errorf(context.Background(), "counterRepo.List error: %v", err)
+		logger.Errorf(ctx, "counterRepo.List error: %v", err)
 		return nil, err
 	}
 
@@ -313,7 +313,7 @@ func (r *counterRepository) Delete(ctx context.Context, slug string) error {
 
 	// execute the builder and verify the result.
 	if _, err = builder.Where(counterEnt.IDEQ(counter.ID)).Exec(ctx); err != nil {
-		logger.Errorf(context.Background(), "counterRepo.Delete error: %v", err)
+		logger.Errorf(ctx, "counterRepo.Delete error: %v", err)
 		return err
 	}
 
@@ -322,13 +322,13 @@ func (r *counterRepository) Delete(ctx context.Context, slug string) error {
 	err = r.c.Delete(ctx, cacheKey)
 	err = r.c.Delete(ctx, fmt.Sprintf("counter:slug:%s", counter.ID))
 	if err != nil {
-		logger.Errorf(context.Background(), "counterRepo.Delete cache error: %v", err)
+		logger.Errorf(ctx, "counterRepo.Delete cache error: %v", err)
 	}
 
 	// delete from Meilisearch index
 	if r.sc != nil {
 		if err = r.sc.Delete(ctx, "counters", counter.ID); err != nil {
-			logger.Errorf(context.Background(), "counterRepo.Delete index error: %v", err)
+			logger.Errorf(ctx, "counterRepo.Delete index error: %v", err)
 			// return nil, err
 		}
 	}
@@ -354,7 +354,7 @@ func (r *counterRepository) FindCounter(ctx context.Context, params *structs.Fin
 	// execute the builder.
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
@@ -1,12 +1,12 @@
 package handler
 
 import (
+	"ncobase/internal/validation"
 	"ncobase/plugin/counter/service"
 	"ncobase/plugin/counter/structs"
 
 	"github.com/ncobase/ncore/net/resp"
 	"github.com/ncobase/ncore/types"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"ncobase/internal/dberr"
 	"ncobase/plugin/counter/data/repository"
 
 	"github.com/ncobase/ncore/ecode"
@@ -14,7 +15,7 @@ import (
 func handleEntError(ctx context.Context, k string, err error) error {
 	if repository.IsNotFound(err) {
 		logger.Errorf(context.Background(), "Error not found in %s: %v", k, err)
-		return errors.New(ecode.NotExist(k))
+		return dberr.NotFound(ecode.NotExist(k))
 	}
 	if repository.IsConstraintError(err) {
 		logger.Errorf(context.Background(), "Error constraint in %s: %v", k, err)
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"ncobase/plugin/payment/data"
+	"ncobase/plugin/payment/data/ent"
+	paymentLogEnt "ncobase/plugin/payment/data/ent/paymentlog"
+	paymentOrderEnt "ncobase/plugin/payment/data/ent/paymentorder"
+	paymentSubscriptionEnt "ncobase/plugin/payment/data/ent/paymentsubscription"
+	"time"
+)
+
+// ErasureRepositoryInterface erases the orders, subscriptions and payment logs of a user
+type ErasureRepositoryInterface interface {
+	Erase(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error)
+}
+
+// erasureRepository erases across the payment tables in one transaction
+type erasureRepository struct {
+	data *data.Data
+}
+
+// NewErasureRepository creates a new erasure repository
+func NewErasureRepository(d *data.Data) ErasureRepositoryInterface {
+	return &erasureRepository{data: d}
+}
+
+// Erase deletes the payment records of a user. With anonymize, orders and subscriptions
+// are kept for bookkeeping without their extras, description and operators, and the logs
+// of the user lose their user, client and provider payloads.
+func (r *erasureRepository) Erase(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error) {
+	err = r.data.WithEntTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+		deleted, anonymized = 0, 0
+
+		orderIDs, err := tx.PaymentOrder.Query().Where(paymentOrderEnt.UserIDEQ(userID)).IDs(ctx)
+		if err != nil {
+			return err
+		}
+		logs := paymentLogEnt.Or(paymentLogEnt.UserIDEQ(userID), paymentLogEnt.OrderIDIn(orderIDs...))
+		now := time.Now().UnixMilli()
+
+		if anonymize {
+			n, err := tx.PaymentLog.Update().Where(logs).
+				ClearUserID().
+				ClearIP().
+				ClearUserAgent().
+				ClearRequestData().
+				ClearResponseData().
+				ClearExtras().
+				SetUpdatedAt(now).
+				Save(ctx)
+			if err != nil {
+				return err
+			}
+			anonymized += n
+
+			n, err = tx.PaymentOrder.Update().Where(paymentOrderEnt.UserIDEQ(userID)).
+				ClearExtras().
+				ClearDescription().
+				ClearCreatedBy().
+				ClearUpdatedBy().
+				SetUpdatedAt(now).
+				Save(ctx)
+			if err != nil {
+				return err
+			}
+			anonymized += n
+
+			n, err = tx.PaymentSubscription.Update().Where(paymentSubscriptionEnt.UserIDEQ(userID)).
+				ClearExtras().
+				ClearCreatedBy().
+				ClearUpdatedBy().
+				SetUpdatedAt(now).
+				Save(ctx)
+			if err != nil {
+				return err
+			}
+			anonymized += n
+			return nil
+		}
+
+		// Logs reference orders, so they go first
+		n, err := tx.PaymentLog.Delete().Where(logs).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		deleted += n
+
+		n, err = tx.PaymentOrder.Delete().Where(paymentOrderEnt.UserIDEQ(userID)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		deleted += n
+
+		n, err = tx.PaymentSubscription.Delete().Where(paymentSubscriptionEnt.UserIDEQ(userID)).Exec(ctx)
+		if err != nil {
+			return err
+		}
+		deleted += n
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("erase payments of user %s: %w", userID, err)
+	}
+	return deleted, anonymized, nil
+}
@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 )
 
 // PaymentLog holds the schema definition for the PaymentLog entity.
@@ -31,7 +32,7 @@ func (PaymentLog) Annotations() []schema.Annotation {
 // Mixin of the PaymentLog.
 func (PaymentLog) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.ExtraProps,
 		mixin.TimeAt{},
 	}
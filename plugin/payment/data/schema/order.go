@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 )
 
 // PaymentOrder holds the schema definition for the PaymentOrder entity.
@@ -31,7 +32,7 @@ func (PaymentOrder) Annotations() []schema.Annotation {
 // Mixin of the PaymentOrder.
 func (PaymentOrder) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.ExtraProps,
 		mixin.OperatorBy{},
 		mixin.TimeAt{},
@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 )
 
 // PaymentProduct holds the schema definition for the PaymentProduct entity.
@@ -31,7 +32,7 @@ func (PaymentProduct) Annotations() []schema.Annotation {
 // Mixin of the PaymentProduct.
 func (PaymentProduct) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.Description,
 		mixin.ExtraProps,
@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 )
 
 // PaymentSubscription holds the schema definition for the PaymentSubscription entity.
@@ -31,7 +32,7 @@ func (PaymentSubscription) Annotations() []schema.Annotation {
 // Mixin of the PaymentSubscription.
 func (PaymentSubscription) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.ExtraProps,
 		mixin.OperatorBy{},
 		mixin.TimeAt{},
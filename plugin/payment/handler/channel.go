@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"ncobase/internal/validation"
 	"ncobase/plugin/payment/service"
 	"ncobase/plugin/payment/structs"
 
@@ -8,7 +9,6 @@ import (
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
 )
 
 // ChannelHandlerInterface defines the interface for channel handler operations
@@ -27,6 +27,7 @@ type OrderServiceInterface interface {
 	ProcessWebhook(ctx context.Context, channelID string, payload []byte, headers map[string]string) error
 	Serialize(order *structs.Order) *structs.Order
 	Serializes(orders []*structs.Order) []*structs.Order
+	EraseUserData(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error)
 }
 
 // orderService provides operations for payment orders
@@ -34,6 +35,7 @@ type orderService struct {
 	repo        repository.OrderRepositoryInterface
 	channelRepo repository.ChannelRepositoryInterface
 	logRepo     repository.LogRepositoryInterface
+	erasureRepo repository.ErasureRepositoryInterface
 	publisher   event.PublisherInterface
 	providerSvc ProviderServiceInterface
 }
@@ -43,6 +45,7 @@ func NewOrderService(
 	repo repository.OrderRepositoryInterface,
 	channelRepo repository.ChannelRepositoryInterface,
 	logRepo repository.LogRepositoryInterface,
+	erasureRepo repository.ErasureRepositoryInterface,
 	publisher event.PublisherInterface,
 	providerSvc ProviderServiceInterface,
 ) OrderServiceInterface {
@@ -50,6 +53,7 @@ func NewOrderService(
 		repo:        repo,
 		channelRepo: channelRepo,
 		logRepo:     logRepo,
+		erasureRepo: erasureRepo,
 		publisher:   publisher,
 		providerSvc: providerSvc,
 	}
@@ -807,3 +811,11 @@ func (s *orderService) generateOrderNumber() string {
 	// Format: YYYYMMDDHHmmss + 6 random characters
 	return time.Now().Format("20060102150405") + nanoid.String(6)
 }
+
+// EraseUserData deletes or anonymizes the orders, subscriptions and payment logs of a user
+func (s *orderService) EraseUserData(ctx context.Context, userID string, anonymize bool) (int, int, error) {
+	if userID == "" {
+		return 0, 0, errors.New(ecode.FieldIsInvalid("user_id"))
+	}
+	return s.erasureRepo.Erase(ctx, userID, anonymize)
+}
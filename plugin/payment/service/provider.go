@@ -138,6 +138,7 @@ func New(d *data.Data, publisher event.PublisherInterface) *Service {
 	logRepo := repository.NewLogRepository(d)
 	productRepo := repository.NewProductRepository(d)
 	subscriptionRepo := repository.NewSubscriptionRepository(d)
+	erasureRepo := repository.NewErasureRepository(d)
 
 	// Provider service
 	providerSvc := NewProviderService()
@@ -147,7 +148,7 @@ func New(d *data.Data, publisher event.PublisherInterface) *Service {
 	logSvc := NewLogService(logRepo)
 	productSvc := NewProductService(productRepo, publisher)
 	subscriptionSvc := NewSubscriptionService(subscriptionRepo, productRepo, channelRepo, orderRepo, publisher, providerSvc)
-	orderSvc := NewOrderService(orderRepo, channelRepo, logRepo, publisher, providerSvc)
+	orderSvc := NewOrderService(orderRepo, channelRepo, logRepo, erasureRepo, publisher, providerSvc)
 
 	return &Service{
 		Channel:      channelSvc,
@@ -54,7 +54,19 @@ type Endpoint struct {
 	LogRequests bool `json:"log_requests,omitempty"`
 	// Whether to log response details
 	LogResponses bool `json:"log_responses,omitempty"`
-	selectValues sql.SelectValues
+	// Requests per second sent to the upstream, 0 for no limit
+	RateLimitRps float64 `json:"rate_limit_rps,omitempty"`
+	// Requests sent at once above the rate, defaults to the rate rounded up
+	RateLimitBurst int `json:"rate_limit_burst,omitempty"`
+	// Milliseconds a request waits for the rate limit before it is rejected, 0 to reject at once
+	RateLimitWait int `json:"rate_limit_wait,omitempty"`
+	// JSON Schema the upstream JSON responses are expected to match
+	ResponseSchema string `json:"response_schema,omitempty"`
+	// Whether upstream responses are validated against the response schema
+	ValidateResponse bool `json:"validate_response,omitempty"`
+	// Whether a response violating the schema fails the request instead of being returned
+	RejectInvalidResponse bool `json:"reject_invalid_response,omitempty"`
+	selectValues          sql.SelectValues
 }
 
 // scanValues returns the types for scanning values from sql.Rows.
@@ -64,11 +76,13 @@ func (*Endpoint) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case endpoint.FieldExtras:
 			values[i] = new([]byte)
-		case endpoint.FieldDisabled, endpoint.FieldUseCircuitBreaker, endpoint.FieldValidateSsl, endpoint.FieldLogRequests, endpoint.FieldLogResponses:
+		case endpoint.FieldDisabled, endpoint.FieldUseCircuitBreaker, endpoint.FieldValidateSsl, endpoint.FieldLogRequests, endpoint.FieldLogResponses, endpoint.FieldValidateResponse, endpoint.FieldRejectInvalidResponse:
 			values[i] = new(sql.NullBool)
-		case endpoint.FieldCreatedAt, endpoint.FieldUpdatedAt, endpoint.FieldTimeout, endpoint.FieldRetryCount:
+		case endpoint.FieldRateLimitRps:
+			values[i] = new(sql.NullFloat64)
+		case endpoint.FieldCreatedAt, endpoint.FieldUpdatedAt, endpoint.FieldTimeout, endpoint.FieldRetryCount, endpoint.FieldRateLimitBurst, endpoint.FieldRateLimitWait:
 			values[i] = new(sql.NullInt64)
-		case endpoint.FieldID, endpoint.FieldName, endpoint.FieldDescription, endpoint.FieldCreatedBy, endpoint.FieldUpdatedBy, endpoint.FieldBaseURL, endpoint.FieldProtocol, endpoint.FieldAuthType, endpoint.FieldAuthConfig:
+		case endpoint.FieldID, endpoint.FieldName, endpoint.FieldDescription, endpoint.FieldCreatedBy, endpoint.FieldUpdatedBy, endpoint.FieldBaseURL, endpoint.FieldProtocol, endpoint.FieldAuthType, endpoint.FieldAuthConfig, endpoint.FieldResponseSchema:
 			values[i] = new(sql.NullString)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -201,6 +215,42 @@ func (_m *Endpoint) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.LogResponses = value.Bool
 			}
+		case endpoint.FieldRateLimitRps:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field rate_limit_rps", values[i])
+			} else if value.Valid {
+				_m.RateLimitRps = value.Float64
+			}
+		case endpoint.FieldRateLimitBurst:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field rate_limit_burst", values[i])
+			} else if value.Valid {
+				_m.RateLimitBurst = int(value.Int64)
+			}
+		case endpoint.FieldRateLimitWait:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field rate_limit_wait", values[i])
+			} else if value.Valid {
+				_m.RateLimitWait = int(value.Int64)
+			}
+		case endpoint.FieldResponseSchema:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field response_schema", values[i])
+			} else if value.Valid {
+				_m.ResponseSchema = value.String
+			}
+		case endpoint.FieldValidateResponse:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field validate_response", values[i])
+			} else if value.Valid {
+				_m.ValidateResponse = value.Bool
+			}
+		case endpoint.FieldRejectInvalidResponse:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field reject_invalid_response", values[i])
+			} else if value.Valid {
+				_m.RejectInvalidResponse = value.Bool
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -290,6 +340,24 @@ func (_m *Endpoint) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("log_responses=")
 	builder.WriteString(fmt.Sprintf("%v", _m.LogResponses))
+	builder.WriteString(", ")
+	builder.WriteString("rate_limit_rps=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RateLimitRps))
+	builder.WriteString(", ")
+	builder.WriteString("rate_limit_burst=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RateLimitBurst))
+	builder.WriteString(", ")
+	builder.WriteString("rate_limit_wait=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RateLimitWait))
+	builder.WriteString(", ")
+	builder.WriteString("response_schema=")
+	builder.WriteString(_m.ResponseSchema)
+	builder.WriteString(", ")
+	builder.WriteString("validate_response=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ValidateResponse))
+	builder.WriteString(", ")
+	builder.WriteString("reject_invalid_response=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RejectInvalidResponse))
 	builder.WriteByte(')')
 	return builder.String()
 }
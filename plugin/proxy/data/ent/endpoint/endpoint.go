@@ -47,6 +47,18 @@ const (
 	FieldLogRequests = "log_requests"
 	// FieldLogResponses holds the string denoting the log_responses field in the database.
 	FieldLogResponses = "log_responses"
+	// FieldRateLimitRps holds the string denoting the rate_limit_rps field in the database.
+	FieldRateLimitRps = "rate_limit_rps"
+	// FieldRateLimitBurst holds the string denoting the rate_limit_burst field in the database.
+	FieldRateLimitBurst = "rate_limit_burst"
+	// FieldRateLimitWait holds the string denoting the rate_limit_wait field in the database.
+	FieldRateLimitWait = "rate_limit_wait"
+	// FieldResponseSchema holds the string denoting the response_schema field in the database.
+	FieldResponseSchema = "response_schema"
+	// FieldValidateResponse holds the string denoting the validate_response field in the database.
+	FieldValidateResponse = "validate_response"
+	// FieldRejectInvalidResponse holds the string denoting the reject_invalid_response field in the database.
+	FieldRejectInvalidResponse = "reject_invalid_response"
 	// Table holds the table name of the endpoint in the database.
 	Table = "ncse_proxy_endpoint"
 )
@@ -72,6 +84,12 @@ var Columns = []string{
 	FieldValidateSsl,
 	FieldLogRequests,
 	FieldLogResponses,
+	FieldRateLimitRps,
+	FieldRateLimitBurst,
+	FieldRateLimitWait,
+	FieldResponseSchema,
+	FieldValidateResponse,
+	FieldRejectInvalidResponse,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -113,6 +131,16 @@ var (
 	DefaultLogRequests bool
 	// DefaultLogResponses holds the default value on creation for the "log_responses" field.
 	DefaultLogResponses bool
+	// DefaultRateLimitRps holds the default value on creation for the "rate_limit_rps" field.
+	DefaultRateLimitRps float64
+	// DefaultRateLimitBurst holds the default value on creation for the "rate_limit_burst" field.
+	DefaultRateLimitBurst int
+	// DefaultRateLimitWait holds the default value on creation for the "rate_limit_wait" field.
+	DefaultRateLimitWait int
+	// DefaultValidateResponse holds the default value on creation for the "validate_response" field.
+	DefaultValidateResponse bool
+	// DefaultRejectInvalidResponse holds the default value on creation for the "reject_invalid_response" field.
+	DefaultRejectInvalidResponse bool
 	// DefaultID holds the default value on creation for the "id" field.
 	DefaultID func() string
 	// IDValidator is a validator for the "id" field. It is called by the builders before save.
@@ -211,3 +239,33 @@ func ByLogRequests(opts ...sql.OrderTermOption) OrderOption {
 func ByLogResponses(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldLogResponses, opts...).ToFunc()
 }
+
+// ByRateLimitRps orders the results by the rate_limit_rps field.
+func ByRateLimitRps(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRateLimitRps, opts...).ToFunc()
+}
+
+// ByRateLimitBurst orders the results by the rate_limit_burst field.
+func ByRateLimitBurst(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRateLimitBurst, opts...).ToFunc()
+}
+
+// ByRateLimitWait orders the results by the rate_limit_wait field.
+func ByRateLimitWait(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRateLimitWait, opts...).ToFunc()
+}
+
+// ByResponseSchema orders the results by the response_schema field.
+func ByResponseSchema(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResponseSchema, opts...).ToFunc()
+}
+
+// ByValidateResponse orders the results by the validate_response field.
+func ByValidateResponse(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldValidateResponse, opts...).ToFunc()
+}
+
+// ByRejectInvalidResponse orders the results by the reject_invalid_response field.
+func ByRejectInvalidResponse(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRejectInvalidResponse, opts...).ToFunc()
+}
@@ -148,6 +148,36 @@ func LogResponses(v bool) predicate.Endpoint {
 	return predicate.Endpoint(sql.FieldEQ(FieldLogResponses, v))
 }
 
+// RateLimitRps applies equality check predicate on the "rate_limit_rps" field. It's identical to RateLimitRpsEQ.
+func RateLimitRps(v float64) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldRateLimitRps, v))
+}
+
+// RateLimitBurst applies equality check predicate on the "rate_limit_burst" field. It's identical to RateLimitBurstEQ.
+func RateLimitBurst(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldRateLimitBurst, v))
+}
+
+// RateLimitWait applies equality check predicate on the "rate_limit_wait" field. It's identical to RateLimitWaitEQ.
+func RateLimitWait(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldRateLimitWait, v))
+}
+
+// ResponseSchema applies equality check predicate on the "response_schema" field. It's identical to ResponseSchemaEQ.
+func ResponseSchema(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldResponseSchema, v))
+}
+
+// ValidateResponse applies equality check predicate on the "validate_response" field. It's identical to ValidateResponseEQ.
+func ValidateResponse(v bool) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldValidateResponse, v))
+}
+
+// RejectInvalidResponse applies equality check predicate on the "reject_invalid_response" field. It's identical to RejectInvalidResponseEQ.
+func RejectInvalidResponse(v bool) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldRejectInvalidResponse, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.Endpoint {
 	return predicate.Endpoint(sql.FieldEQ(FieldName, v))
@@ -968,6 +998,221 @@ func LogResponsesNEQ(v bool) predicate.Endpoint {
 	return predicate.Endpoint(sql.FieldNEQ(FieldLogResponses, v))
 }
 
+// RateLimitRpsEQ applies the EQ predicate on the "rate_limit_rps" field.
+func RateLimitRpsEQ(v float64) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldRateLimitRps, v))
+}
+
+// RateLimitRpsNEQ applies the NEQ predicate on the "rate_limit_rps" field.
+func RateLimitRpsNEQ(v float64) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNEQ(FieldRateLimitRps, v))
+}
+
+// RateLimitRpsIn applies the In predicate on the "rate_limit_rps" field.
+func RateLimitRpsIn(vs ...float64) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldIn(FieldRateLimitRps, vs...))
+}
+
+// RateLimitRpsNotIn applies the NotIn predicate on the "rate_limit_rps" field.
+func RateLimitRpsNotIn(vs ...float64) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNotIn(FieldRateLimitRps, vs...))
+}
+
+// RateLimitRpsGT applies the GT predicate on the "rate_limit_rps" field.
+func RateLimitRpsGT(v float64) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldGT(FieldRateLimitRps, v))
+}
+
+// RateLimitRpsGTE applies the GTE predicate on the "rate_limit_rps" field.
+func RateLimitRpsGTE(v float64) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldGTE(FieldRateLimitRps, v))
+}
+
+// RateLimitRpsLT applies the LT predicate on the "rate_limit_rps" field.
+func RateLimitRpsLT(v float64) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldLT(FieldRateLimitRps, v))
+}
+
+// RateLimitRpsLTE applies the LTE predicate on the "rate_limit_rps" field.
+func RateLimitRpsLTE(v float64) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldLTE(FieldRateLimitRps, v))
+}
+
+// RateLimitBurstEQ applies the EQ predicate on the "rate_limit_burst" field.
+func RateLimitBurstEQ(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldRateLimitBurst, v))
+}
+
+// RateLimitBurstNEQ applies the NEQ predicate on the "rate_limit_burst" field.
+func RateLimitBurstNEQ(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNEQ(FieldRateLimitBurst, v))
+}
+
+// RateLimitBurstIn applies the In predicate on the "rate_limit_burst" field.
+func RateLimitBurstIn(vs ...int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldIn(FieldRateLimitBurst, vs...))
+}
+
+// RateLimitBurstNotIn applies the NotIn predicate on the "rate_limit_burst" field.
+func RateLimitBurstNotIn(vs ...int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNotIn(FieldRateLimitBurst, vs...))
+}
+
+// RateLimitBurstGT applies the GT predicate on the "rate_limit_burst" field.
+func RateLimitBurstGT(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldGT(FieldRateLimitBurst, v))
+}
+
+// RateLimitBurstGTE applies the GTE predicate on the "rate_limit_burst" field.
+func RateLimitBurstGTE(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldGTE(FieldRateLimitBurst, v))
+}
+
+// RateLimitBurstLT applies the LT predicate on the "rate_limit_burst" field.
+func RateLimitBurstLT(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldLT(FieldRateLimitBurst, v))
+}
+
+// RateLimitBurstLTE applies the LTE predicate on the "rate_limit_burst" field.
+func RateLimitBurstLTE(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldLTE(FieldRateLimitBurst, v))
+}
+
+// RateLimitWaitEQ applies the EQ predicate on the "rate_limit_wait" field.
+func RateLimitWaitEQ(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldRateLimitWait, v))
+}
+
+// RateLimitWaitNEQ applies the NEQ predicate on the "rate_limit_wait" field.
+func RateLimitWaitNEQ(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNEQ(FieldRateLimitWait, v))
+}
+
+// RateLimitWaitIn applies the In predicate on the "rate_limit_wait" field.
+func RateLimitWaitIn(vs ...int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldIn(FieldRateLimitWait, vs...))
+}
+
+// RateLimitWaitNotIn applies the NotIn predicate on the "rate_limit_wait" field.
+func RateLimitWaitNotIn(vs ...int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNotIn(FieldRateLimitWait, vs...))
+}
+
+// RateLimitWaitGT applies the GT predicate on the "rate_limit_wait" field.
+func RateLimitWaitGT(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldGT(FieldRateLimitWait, v))
+}
+
+// RateLimitWaitGTE applies the GTE predicate on the "rate_limit_wait" field.
+func RateLimitWaitGTE(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldGTE(FieldRateLimitWait, v))
+}
+
+// RateLimitWaitLT applies the LT predicate on the "rate_limit_wait" field.
+func RateLimitWaitLT(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldLT(FieldRateLimitWait, v))
+}
+
+// RateLimitWaitLTE applies the LTE predicate on the "rate_limit_wait" field.
+func RateLimitWaitLTE(v int) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldLTE(FieldRateLimitWait, v))
+}
+
+// ResponseSchemaEQ applies the EQ predicate on the "response_schema" field.
+func ResponseSchemaEQ(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldResponseSchema, v))
+}
+
+// ResponseSchemaNEQ applies the NEQ predicate on the "response_schema" field.
+func ResponseSchemaNEQ(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNEQ(FieldResponseSchema, v))
+}
+
+// ResponseSchemaIn applies the In predicate on the "response_schema" field.
+func ResponseSchemaIn(vs ...string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldIn(FieldResponseSchema, vs...))
+}
+
+// ResponseSchemaNotIn applies the NotIn predicate on the "response_schema" field.
+func ResponseSchemaNotIn(vs ...string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNotIn(FieldResponseSchema, vs...))
+}
+
+// ResponseSchemaGT applies the GT predicate on the "response_schema" field.
+func ResponseSchemaGT(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldGT(FieldResponseSchema, v))
+}
+
+// ResponseSchemaGTE applies the GTE predicate on the "response_schema" field.
+func ResponseSchemaGTE(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldGTE(FieldResponseSchema, v))
+}
+
+// ResponseSchemaLT applies the LT predicate on the "response_schema" field.
+func ResponseSchemaLT(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldLT(FieldResponseSchema, v))
+}
+
+// ResponseSchemaLTE applies the LTE predicate on the "response_schema" field.
+func ResponseSchemaLTE(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldLTE(FieldResponseSchema, v))
+}
+
+// ResponseSchemaContains applies the Contains predicate on the "response_schema" field.
+func ResponseSchemaContains(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldContains(FieldResponseSchema, v))
+}
+
+// ResponseSchemaHasPrefix applies the HasPrefix predicate on the "response_schema" field.
+func ResponseSchemaHasPrefix(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldHasPrefix(FieldResponseSchema, v))
+}
+
+// ResponseSchemaHasSuffix applies the HasSuffix predicate on the "response_schema" field.
+func ResponseSchemaHasSuffix(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldHasSuffix(FieldResponseSchema, v))
+}
+
+// ResponseSchemaIsNil applies the IsNil predicate on the "response_schema" field.
+func ResponseSchemaIsNil() predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldIsNull(FieldResponseSchema))
+}
+
+// ResponseSchemaNotNil applies the NotNil predicate on the "response_schema" field.
+func ResponseSchemaNotNil() predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNotNull(FieldResponseSchema))
+}
+
+// ResponseSchemaEqualFold applies the EqualFold predicate on the "response_schema" field.
+func ResponseSchemaEqualFold(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEqualFold(FieldResponseSchema, v))
+}
+
+// ResponseSchemaContainsFold applies the ContainsFold predicate on the "response_schema" field.
+func ResponseSchemaContainsFold(v string) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldContainsFold(FieldResponseSchema, v))
+}
+
+// ValidateResponseEQ applies the EQ predicate on the "validate_response" field.
+func ValidateResponseEQ(v bool) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldValidateResponse, v))
+}
+
+// ValidateResponseNEQ applies the NEQ predicate on the "validate_response" field.
+func ValidateResponseNEQ(v bool) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNEQ(FieldValidateResponse, v))
+}
+
+// RejectInvalidResponseEQ applies the EQ predicate on the "reject_invalid_response" field.
+func RejectInvalidResponseEQ(v bool) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldEQ(FieldRejectInvalidResponse, v))
+}
+
+// RejectInvalidResponseNEQ applies the NEQ predicate on the "reject_invalid_response" field.
+func RejectInvalidResponseNEQ(v bool) predicate.Endpoint {
+	return predicate.Endpoint(sql.FieldNEQ(FieldRejectInvalidResponse, v))
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Endpoint) predicate.Endpoint {
 	return predicate.Endpoint(sql.AndPredicates(predicates...))
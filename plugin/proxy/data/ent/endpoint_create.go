@@ -258,6 +258,90 @@ func (_c *EndpointCreate) SetNillableLogResponses(v *bool) *EndpointCreate {
 	return _c
 }
 
+// SetRateLimitRps sets the "rate_limit_rps" field.
+func (_c *EndpointCreate) SetRateLimitRps(v float64) *EndpointCreate {
+	_c.mutation.SetRateLimitRps(v)
+	return _c
+}
+
+// SetNillableRateLimitRps sets the "rate_limit_rps" field if the given value is not nil.
+func (_c *EndpointCreate) SetNillableRateLimitRps(v *float64) *EndpointCreate {
+	if v != nil {
+		_c.SetRateLimitRps(*v)
+	}
+	return _c
+}
+
+// SetRateLimitBurst sets the "rate_limit_burst" field.
+func (_c *EndpointCreate) SetRateLimitBurst(v int) *EndpointCreate {
+	_c.mutation.SetRateLimitBurst(v)
+	return _c
+}
+
+// SetNillableRateLimitBurst sets the "rate_limit_burst" field if the given value is not nil.
+func (_c *EndpointCreate) SetNillableRateLimitBurst(v *int) *EndpointCreate {
+	if v != nil {
+		_c.SetRateLimitBurst(*v)
+	}
+	return _c
+}
+
+// SetRateLimitWait sets the "rate_limit_wait" field.
+func (_c *EndpointCreate) SetRateLimitWait(v int) *EndpointCreate {
+	_c.mutation.SetRateLimitWait(v)
+	return _c
+}
+
+// SetNillableRateLimitWait sets the "rate_limit_wait" field if the given value is not nil.
+func (_c *EndpointCreate) SetNillableRateLimitWait(v *int) *EndpointCreate {
+	if v != nil {
+		_c.SetRateLimitWait(*v)
+	}
+	return _c
+}
+
+// SetResponseSchema sets the "response_schema" field.
+func (_c *EndpointCreate) SetResponseSchema(v string) *EndpointCreate {
+	_c.mutation.SetResponseSchema(v)
+	return _c
+}
+
+// SetNillableResponseSchema sets the "response_schema" field if the given value is not nil.
+func (_c *EndpointCreate) SetNillableResponseSchema(v *string) *EndpointCreate {
+	if v != nil {
+		_c.SetResponseSchema(*v)
+	}
+	return _c
+}
+
+// SetValidateResponse sets the "validate_response" field.
+func (_c *EndpointCreate) SetValidateResponse(v bool) *EndpointCreate {
+	_c.mutation.SetValidateResponse(v)
+	return _c
+}
+
+// SetNillableValidateResponse sets the "validate_response" field if the given value is not nil.
+func (_c *EndpointCreate) SetNillableValidateResponse(v *bool) *EndpointCreate {
+	if v != nil {
+		_c.SetValidateResponse(*v)
+	}
+	return _c
+}
+
+// SetRejectInvalidResponse sets the "reject_invalid_response" field.
+func (_c *EndpointCreate) SetRejectInvalidResponse(v bool) *EndpointCreate {
+	_c.mutation.SetRejectInvalidResponse(v)
+	return _c
+}
+
+// SetNillableRejectInvalidResponse sets the "reject_invalid_response" field if the given value is not nil.
+func (_c *EndpointCreate) SetNillableRejectInvalidResponse(v *bool) *EndpointCreate {
+	if v != nil {
+		_c.SetRejectInvalidResponse(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *EndpointCreate) SetID(v string) *EndpointCreate {
 	_c.mutation.SetID(v)
@@ -355,6 +439,26 @@ func (_c *EndpointCreate) defaults() {
 		v := endpoint.DefaultLogResponses
 		_c.mutation.SetLogResponses(v)
 	}
+	if _, ok := _c.mutation.RateLimitRps(); !ok {
+		v := endpoint.DefaultRateLimitRps
+		_c.mutation.SetRateLimitRps(v)
+	}
+	if _, ok := _c.mutation.RateLimitBurst(); !ok {
+		v := endpoint.DefaultRateLimitBurst
+		_c.mutation.SetRateLimitBurst(v)
+	}
+	if _, ok := _c.mutation.RateLimitWait(); !ok {
+		v := endpoint.DefaultRateLimitWait
+		_c.mutation.SetRateLimitWait(v)
+	}
+	if _, ok := _c.mutation.ValidateResponse(); !ok {
+		v := endpoint.DefaultValidateResponse
+		_c.mutation.SetValidateResponse(v)
+	}
+	if _, ok := _c.mutation.RejectInvalidResponse(); !ok {
+		v := endpoint.DefaultRejectInvalidResponse
+		_c.mutation.SetRejectInvalidResponse(v)
+	}
 	if _, ok := _c.mutation.ID(); !ok {
 		v := endpoint.DefaultID()
 		_c.mutation.SetID(v)
@@ -395,6 +499,21 @@ func (_c *EndpointCreate) check() error {
 	if _, ok := _c.mutation.LogResponses(); !ok {
 		return &ValidationError{Name: "log_responses", err: errors.New(`ent: missing required field "Endpoint.log_responses"`)}
 	}
+	if _, ok := _c.mutation.RateLimitRps(); !ok {
+		return &ValidationError{Name: "rate_limit_rps", err: errors.New(`ent: missing required field "Endpoint.rate_limit_rps"`)}
+	}
+	if _, ok := _c.mutation.RateLimitBurst(); !ok {
+		return &ValidationError{Name: "rate_limit_burst", err: errors.New(`ent: missing required field "Endpoint.rate_limit_burst"`)}
+	}
+	if _, ok := _c.mutation.RateLimitWait(); !ok {
+		return &ValidationError{Name: "rate_limit_wait", err: errors.New(`ent: missing required field "Endpoint.rate_limit_wait"`)}
+	}
+	if _, ok := _c.mutation.ValidateResponse(); !ok {
+		return &ValidationError{Name: "validate_response", err: errors.New(`ent: missing required field "Endpoint.validate_response"`)}
+	}
+	if _, ok := _c.mutation.RejectInvalidResponse(); !ok {
+		return &ValidationError{Name: "reject_invalid_response", err: errors.New(`ent: missing required field "Endpoint.reject_invalid_response"`)}
+	}
 	if v, ok := _c.mutation.ID(); ok {
 		if err := endpoint.IDValidator(v); err != nil {
 			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Endpoint.id": %w`, err)}
@@ -508,6 +627,30 @@ func (_c *EndpointCreate) createSpec() (*Endpoint, *sqlgraph.CreateSpec) {
 		_spec.SetField(endpoint.FieldLogResponses, field.TypeBool, value)
 		_node.LogResponses = value
 	}
+	if value, ok := _c.mutation.RateLimitRps(); ok {
+		_spec.SetField(endpoint.FieldRateLimitRps, field.TypeFloat64, value)
+		_node.RateLimitRps = value
+	}
+	if value, ok := _c.mutation.RateLimitBurst(); ok {
+		_spec.SetField(endpoint.FieldRateLimitBurst, field.TypeInt, value)
+		_node.RateLimitBurst = value
+	}
+	if value, ok := _c.mutation.RateLimitWait(); ok {
+		_spec.SetField(endpoint.FieldRateLimitWait, field.TypeInt, value)
+		_node.RateLimitWait = value
+	}
+	if value, ok := _c.mutation.ResponseSchema(); ok {
+		_spec.SetField(endpoint.FieldResponseSchema, field.TypeString, value)
+		_node.ResponseSchema = value
+	}
+	if value, ok := _c.mutation.ValidateResponse(); ok {
+		_spec.SetField(endpoint.FieldValidateResponse, field.TypeBool, value)
+		_node.ValidateResponse = value
+	}
+	if value, ok := _c.mutation.RejectInvalidResponse(); ok {
+		_spec.SetField(endpoint.FieldRejectInvalidResponse, field.TypeBool, value)
+		_node.RejectInvalidResponse = value
+	}
 	return _node, _spec
 }
 
@@ -830,6 +973,102 @@ func (u *EndpointUpsert) UpdateLogResponses() *EndpointUpsert {
 	return u
 }
 
+// SetRateLimitRps sets the "rate_limit_rps" field.
+func (u *EndpointUpsert) SetRateLimitRps(v float64) *EndpointUpsert {
+	u.Set(endpoint.FieldRateLimitRps, v)
+	return u
+}
+
+// UpdateRateLimitRps sets the "rate_limit_rps" field to the value that was provided on create.
+func (u *EndpointUpsert) UpdateRateLimitRps() *EndpointUpsert {
+	u.SetExcluded(endpoint.FieldRateLimitRps)
+	return u
+}
+
+// AddRateLimitRps adds v to the "rate_limit_rps" field.
+func (u *EndpointUpsert) AddRateLimitRps(v float64) *EndpointUpsert {
+	u.Add(endpoint.FieldRateLimitRps, v)
+	return u
+}
+
+// SetRateLimitBurst sets the "rate_limit_burst" field.
+func (u *EndpointUpsert) SetRateLimitBurst(v int) *EndpointUpsert {
+	u.Set(endpoint.FieldRateLimitBurst, v)
+	return u
+}
+
+// UpdateRateLimitBurst sets the "rate_limit_burst" field to the value that was provided on create.
+func (u *EndpointUpsert) UpdateRateLimitBurst() *EndpointUpsert {
+	u.SetExcluded(endpoint.FieldRateLimitBurst)
+	return u
+}
+
+// AddRateLimitBurst adds v to the "rate_limit_burst" field.
+func (u *EndpointUpsert) AddRateLimitBurst(v int) *EndpointUpsert {
+	u.Add(endpoint.FieldRateLimitBurst, v)
+	return u
+}
+
+// SetRateLimitWait sets the "rate_limit_wait" field.
+func (u *EndpointUpsert) SetRateLimitWait(v int) *EndpointUpsert {
+	u.Set(endpoint.FieldRateLimitWait, v)
+	return u
+}
+
+// UpdateRateLimitWait sets the "rate_limit_wait" field to the value that was provided on create.
+func (u *EndpointUpsert) UpdateRateLimitWait() *EndpointUpsert {
+	u.SetExcluded(endpoint.FieldRateLimitWait)
+	return u
+}
+
+// AddRateLimitWait adds v to the "rate_limit_wait" field.
+func (u *EndpointUpsert) AddRateLimitWait(v int) *EndpointUpsert {
+	u.Add(endpoint.FieldRateLimitWait, v)
+	return u
+}
+
+// SetResponseSchema sets the "response_schema" field.
+func (u *EndpointUpsert) SetResponseSchema(v string) *EndpointUpsert {
+	u.Set(endpoint.FieldResponseSchema, v)
+	return u
+}
+
+// UpdateResponseSchema sets the "response_schema" field to the value that was provided on create.
+func (u *EndpointUpsert) UpdateResponseSchema() *EndpointUpsert {
+	u.SetExcluded(endpoint.FieldResponseSchema)
+	return u
+}
+
+// ClearResponseSchema clears the value of the "response_schema" field.
+func (u *EndpointUpsert) ClearResponseSchema() *EndpointUpsert {
+	u.SetNull(endpoint.FieldResponseSchema)
+	return u
+}
+
+// SetValidateResponse sets the "validate_response" field.
+func (u *EndpointUpsert) SetValidateResponse(v bool) *EndpointUpsert {
+	u.Set(endpoint.FieldValidateResponse, v)
+	return u
+}
+
+// UpdateValidateResponse sets the "validate_response" field to the value that was provided on create.
+func (u *EndpointUpsert) UpdateValidateResponse() *EndpointUpsert {
+	u.SetExcluded(endpoint.FieldValidateResponse)
+	return u
+}
+
+// SetRejectInvalidResponse sets the "reject_invalid_response" field.
+func (u *EndpointUpsert) SetRejectInvalidResponse(v bool) *EndpointUpsert {
+	u.Set(endpoint.FieldRejectInvalidResponse, v)
+	return u
+}
+
+// UpdateRejectInvalidResponse sets the "reject_invalid_response" field to the value that was provided on create.
+func (u *EndpointUpsert) UpdateRejectInvalidResponse() *EndpointUpsert {
+	u.SetExcluded(endpoint.FieldRejectInvalidResponse)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
 // Using this option is equivalent to using:
 //
@@ -1196,6 +1435,118 @@ func (u *EndpointUpsertOne) UpdateLogResponses() *EndpointUpsertOne {
 	})
 }
 
+// SetRateLimitRps sets the "rate_limit_rps" field.
+func (u *EndpointUpsertOne) SetRateLimitRps(v float64) *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetRateLimitRps(v)
+	})
+}
+
+// AddRateLimitRps adds v to the "rate_limit_rps" field.
+func (u *EndpointUpsertOne) AddRateLimitRps(v float64) *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.AddRateLimitRps(v)
+	})
+}
+
+// UpdateRateLimitRps sets the "rate_limit_rps" field to the value that was provided on create.
+func (u *EndpointUpsertOne) UpdateRateLimitRps() *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateRateLimitRps()
+	})
+}
+
+// SetRateLimitBurst sets the "rate_limit_burst" field.
+func (u *EndpointUpsertOne) SetRateLimitBurst(v int) *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetRateLimitBurst(v)
+	})
+}
+
+// AddRateLimitBurst adds v to the "rate_limit_burst" field.
+func (u *EndpointUpsertOne) AddRateLimitBurst(v int) *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.AddRateLimitBurst(v)
+	})
+}
+
+// UpdateRateLimitBurst sets the "rate_limit_burst" field to the value that was provided on create.
+func (u *EndpointUpsertOne) UpdateRateLimitBurst() *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateRateLimitBurst()
+	})
+}
+
+// SetRateLimitWait sets the "rate_limit_wait" field.
+func (u *EndpointUpsertOne) SetRateLimitWait(v int) *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetRateLimitWait(v)
+	})
+}
+
+// AddRateLimitWait adds v to the "rate_limit_wait" field.
+func (u *EndpointUpsertOne) AddRateLimitWait(v int) *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.AddRateLimitWait(v)
+	})
+}
+
+// UpdateRateLimitWait sets the "rate_limit_wait" field to the value that was provided on create.
+func (u *EndpointUpsertOne) UpdateRateLimitWait() *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateRateLimitWait()
+	})
+}
+
+// SetResponseSchema sets the "response_schema" field.
+func (u *EndpointUpsertOne) SetResponseSchema(v string) *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetResponseSchema(v)
+	})
+}
+
+// UpdateResponseSchema sets the "response_schema" field to the value that was provided on create.
+func (u *EndpointUpsertOne) UpdateResponseSchema() *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateResponseSchema()
+	})
+}
+
+// ClearResponseSchema clears the value of the "response_schema" field.
+func (u *EndpointUpsertOne) ClearResponseSchema() *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.ClearResponseSchema()
+	})
+}
+
+// SetValidateResponse sets the "validate_response" field.
+func (u *EndpointUpsertOne) SetValidateResponse(v bool) *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetValidateResponse(v)
+	})
+}
+
+// UpdateValidateResponse sets the "validate_response" field to the value that was provided on create.
+func (u *EndpointUpsertOne) UpdateValidateResponse() *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateValidateResponse()
+	})
+}
+
+// SetRejectInvalidResponse sets the "reject_invalid_response" field.
+func (u *EndpointUpsertOne) SetRejectInvalidResponse(v bool) *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetRejectInvalidResponse(v)
+	})
+}
+
+// UpdateRejectInvalidResponse sets the "reject_invalid_response" field to the value that was provided on create.
+func (u *EndpointUpsertOne) UpdateRejectInvalidResponse() *EndpointUpsertOne {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateRejectInvalidResponse()
+	})
+}
+
 // Exec executes the query.
 func (u *EndpointUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -1729,6 +2080,118 @@ func (u *EndpointUpsertBulk) UpdateLogResponses() *EndpointUpsertBulk {
 	})
 }
 
+// SetRateLimitRps sets the "rate_limit_rps" field.
+func (u *EndpointUpsertBulk) SetRateLimitRps(v float64) *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetRateLimitRps(v)
+	})
+}
+
+// AddRateLimitRps adds v to the "rate_limit_rps" field.
+func (u *EndpointUpsertBulk) AddRateLimitRps(v float64) *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.AddRateLimitRps(v)
+	})
+}
+
+// UpdateRateLimitRps sets the "rate_limit_rps" field to the value that was provided on create.
+func (u *EndpointUpsertBulk) UpdateRateLimitRps() *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateRateLimitRps()
+	})
+}
+
+// SetRateLimitBurst sets the "rate_limit_burst" field.
+func (u *EndpointUpsertBulk) SetRateLimitBurst(v int) *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetRateLimitBurst(v)
+	})
+}
+
+// AddRateLimitBurst adds v to the "rate_limit_burst" field.
+func (u *EndpointUpsertBulk) AddRateLimitBurst(v int) *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.AddRateLimitBurst(v)
+	})
+}
+
+// UpdateRateLimitBurst sets the "rate_limit_burst" field to the value that was provided on create.
+func (u *EndpointUpsertBulk) UpdateRateLimitBurst() *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateRateLimitBurst()
+	})
+}
+
+// SetRateLimitWait sets the "rate_limit_wait" field.
+func (u *EndpointUpsertBulk) SetRateLimitWait(v int) *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetRateLimitWait(v)
+	})
+}
+
+// AddRateLimitWait adds v to the "rate_limit_wait" field.
+func (u *EndpointUpsertBulk) AddRateLimitWait(v int) *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.AddRateLimitWait(v)
+	})
+}
+
+// UpdateRateLimitWait sets the "rate_limit_wait" field to the value that was provided on create.
+func (u *EndpointUpsertBulk) UpdateRateLimitWait() *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateRateLimitWait()
+	})
+}
+
+// SetResponseSchema sets the "response_schema" field.
+func (u *EndpointUpsertBulk) SetResponseSchema(v string) *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetResponseSchema(v)
+	})
+}
+
+// UpdateResponseSchema sets the "response_schema" field to the value that was provided on create.
+func (u *EndpointUpsertBulk) UpdateResponseSchema() *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateResponseSchema()
+	})
+}
+
+// ClearResponseSchema clears the value of the "response_schema" field.
+func (u *EndpointUpsertBulk) ClearResponseSchema() *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.ClearResponseSchema()
+	})
+}
+
+// SetValidateResponse sets the "validate_response" field.
+func (u *EndpointUpsertBulk) SetValidateResponse(v bool) *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetValidateResponse(v)
+	})
+}
+
+// UpdateValidateResponse sets the "validate_response" field to the value that was provided on create.
+func (u *EndpointUpsertBulk) UpdateValidateResponse() *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateValidateResponse()
+	})
+}
+
+// SetRejectInvalidResponse sets the "reject_invalid_response" field.
+func (u *EndpointUpsertBulk) SetRejectInvalidResponse(v bool) *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.SetRejectInvalidResponse(v)
+	})
+}
+
+// UpdateRejectInvalidResponse sets the "reject_invalid_response" field to the value that was provided on create.
+func (u *EndpointUpsertBulk) UpdateRejectInvalidResponse() *EndpointUpsertBulk {
+	return u.Update(func(s *EndpointUpsert) {
+		s.UpdateRejectInvalidResponse()
+	})
+}
+
 // Exec executes the query.
 func (u *EndpointUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
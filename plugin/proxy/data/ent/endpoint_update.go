@@ -318,6 +318,117 @@ func (_u *EndpointUpdate) SetNillableLogResponses(v *bool) *EndpointUpdate {
 	return _u
 }
 
+// SetRateLimitRps sets the "rate_limit_rps" field.
+func (_u *EndpointUpdate) SetRateLimitRps(v float64) *EndpointUpdate {
+	_u.mutation.ResetRateLimitRps()
+	_u.mutation.SetRateLimitRps(v)
+	return _u
+}
+
+// SetNillableRateLimitRps sets the "rate_limit_rps" field if the given value is not nil.
+func (_u *EndpointUpdate) SetNillableRateLimitRps(v *float64) *EndpointUpdate {
+	if v != nil {
+		_u.SetRateLimitRps(*v)
+	}
+	return _u
+}
+
+// AddRateLimitRps adds value to the "rate_limit_rps" field.
+func (_u *EndpointUpdate) AddRateLimitRps(v float64) *EndpointUpdate {
+	_u.mutation.AddRateLimitRps(v)
+	return _u
+}
+
+// SetRateLimitBurst sets the "rate_limit_burst" field.
+func (_u *EndpointUpdate) SetRateLimitBurst(v int) *EndpointUpdate {
+	_u.mutation.ResetRateLimitBurst()
+	_u.mutation.SetRateLimitBurst(v)
+	return _u
+}
+
+// SetNillableRateLimitBurst sets the "rate_limit_burst" field if the given value is not nil.
+func (_u *EndpointUpdate) SetNillableRateLimitBurst(v *int) *EndpointUpdate {
+	if v != nil {
+		_u.SetRateLimitBurst(*v)
+	}
+	return _u
+}
+
+// AddRateLimitBurst adds value to the "rate_limit_burst" field.
+func (_u *EndpointUpdate) AddRateLimitBurst(v int) *EndpointUpdate {
+	_u.mutation.AddRateLimitBurst(v)
+	return _u
+}
+
+// SetRateLimitWait sets the "rate_limit_wait" field.
+func (_u *EndpointUpdate) SetRateLimitWait(v int) *EndpointUpdate {
+	_u.mutation.ResetRateLimitWait()
+	_u.mutation.SetRateLimitWait(v)
+	return _u
+}
+
+// SetNillableRateLimitWait sets the "rate_limit_wait" field if the given value is not nil.
+func (_u *EndpointUpdate) SetNillableRateLimitWait(v *int) *EndpointUpdate {
+	if v != nil {
+		_u.SetRateLimitWait(*v)
+	}
+	return _u
+}
+
+// AddRateLimitWait adds value to the "rate_limit_wait" field.
+func (_u *EndpointUpdate) AddRateLimitWait(v int) *EndpointUpdate {
+	_u.mutation.AddRateLimitWait(v)
+	return _u
+}
+
+// SetResponseSchema sets the "response_schema" field.
+func (_u *EndpointUpdate) SetResponseSchema(v string) *EndpointUpdate {
+	_u.mutation.SetResponseSchema(v)
+	return _u
+}
+
+// SetNillableResponseSchema sets the "response_schema" field if the given value is not nil.
+func (_u *EndpointUpdate) SetNillableResponseSchema(v *string) *EndpointUpdate {
+	if v != nil {
+		_u.SetResponseSchema(*v)
+	}
+	return _u
+}
+
+// ClearResponseSchema clears the value of the "response_schema" field.
+func (_u *EndpointUpdate) ClearResponseSchema() *EndpointUpdate {
+	_u.mutation.ClearResponseSchema()
+	return _u
+}
+
+// SetValidateResponse sets the "validate_response" field.
+func (_u *EndpointUpdate) SetValidateResponse(v bool) *EndpointUpdate {
+	_u.mutation.SetValidateResponse(v)
+	return _u
+}
+
+// SetNillableValidateResponse sets the "validate_response" field if the given value is not nil.
+func (_u *EndpointUpdate) SetNillableValidateResponse(v *bool) *EndpointUpdate {
+	if v != nil {
+		_u.SetValidateResponse(*v)
+	}
+	return _u
+}
+
+// SetRejectInvalidResponse sets the "reject_invalid_response" field.
+func (_u *EndpointUpdate) SetRejectInvalidResponse(v bool) *EndpointUpdate {
+	_u.mutation.SetRejectInvalidResponse(v)
+	return _u
+}
+
+// SetNillableRejectInvalidResponse sets the "reject_invalid_response" field if the given value is not nil.
+func (_u *EndpointUpdate) SetNillableRejectInvalidResponse(v *bool) *EndpointUpdate {
+	if v != nil {
+		_u.SetRejectInvalidResponse(*v)
+	}
+	return _u
+}
+
 // Mutation returns the EndpointMutation object of the builder.
 func (_u *EndpointUpdate) Mutation() *EndpointMutation {
 	return _u.mutation
@@ -468,6 +579,36 @@ func (_u *EndpointUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.LogResponses(); ok {
 		_spec.SetField(endpoint.FieldLogResponses, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.RateLimitRps(); ok {
+		_spec.SetField(endpoint.FieldRateLimitRps, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedRateLimitRps(); ok {
+		_spec.AddField(endpoint.FieldRateLimitRps, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.RateLimitBurst(); ok {
+		_spec.SetField(endpoint.FieldRateLimitBurst, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRateLimitBurst(); ok {
+		_spec.AddField(endpoint.FieldRateLimitBurst, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.RateLimitWait(); ok {
+		_spec.SetField(endpoint.FieldRateLimitWait, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRateLimitWait(); ok {
+		_spec.AddField(endpoint.FieldRateLimitWait, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.ResponseSchema(); ok {
+		_spec.SetField(endpoint.FieldResponseSchema, field.TypeString, value)
+	}
+	if _u.mutation.ResponseSchemaCleared() {
+		_spec.ClearField(endpoint.FieldResponseSchema, field.TypeString)
+	}
+	if value, ok := _u.mutation.ValidateResponse(); ok {
+		_spec.SetField(endpoint.FieldValidateResponse, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RejectInvalidResponse(); ok {
+		_spec.SetField(endpoint.FieldRejectInvalidResponse, field.TypeBool, value)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{endpoint.Label}
@@ -779,6 +920,117 @@ func (_u *EndpointUpdateOne) SetNillableLogResponses(v *bool) *EndpointUpdateOne
 	return _u
 }
 
+// SetRateLimitRps sets the "rate_limit_rps" field.
+func (_u *EndpointUpdateOne) SetRateLimitRps(v float64) *EndpointUpdateOne {
+	_u.mutation.ResetRateLimitRps()
+	_u.mutation.SetRateLimitRps(v)
+	return _u
+}
+
+// SetNillableRateLimitRps sets the "rate_limit_rps" field if the given value is not nil.
+func (_u *EndpointUpdateOne) SetNillableRateLimitRps(v *float64) *EndpointUpdateOne {
+	if v != nil {
+		_u.SetRateLimitRps(*v)
+	}
+	return _u
+}
+
+// AddRateLimitRps adds value to the "rate_limit_rps" field.
+func (_u *EndpointUpdateOne) AddRateLimitRps(v float64) *EndpointUpdateOne {
+	_u.mutation.AddRateLimitRps(v)
+	return _u
+}
+
+// SetRateLimitBurst sets the "rate_limit_burst" field.
+func (_u *EndpointUpdateOne) SetRateLimitBurst(v int) *EndpointUpdateOne {
+	_u.mutation.ResetRateLimitBurst()
+	_u.mutation.SetRateLimitBurst(v)
+	return _u
+}
+
+// SetNillableRateLimitBurst sets the "rate_limit_burst" field if the given value is not nil.
+func (_u *EndpointUpdateOne) SetNillableRateLimitBurst(v *int) *EndpointUpdateOne {
+	if v != nil {
+		_u.SetRateLimitBurst(*v)
+	}
+	return _u
+}
+
+// AddRateLimitBurst adds value to the "rate_limit_burst" field.
+func (_u *EndpointUpdateOne) AddRateLimitBurst(v int) *EndpointUpdateOne {
+	_u.mutation.AddRateLimitBurst(v)
+	return _u
+}
+
+// SetRateLimitWait sets the "rate_limit_wait" field.
+func (_u *EndpointUpdateOne) SetRateLimitWait(v int) *EndpointUpdateOne {
+	_u.mutation.ResetRateLimitWait()
+	_u.mutation.SetRateLimitWait(v)
+	return _u
+}
+
+// SetNillableRateLimitWait sets the "rate_limit_wait" field if the given value is not nil.
+func (_u *EndpointUpdateOne) SetNillableRateLimitWait(v *int) *EndpointUpdateOne {
+	if v != nil {
+		_u.SetRateLimitWait(*v)
+	}
+	return _u
+}
+
+// AddRateLimitWait adds value to the "rate_limit_wait" field.
+func (_u *EndpointUpdateOne) AddRateLimitWait(v int) *EndpointUpdateOne {
+	_u.mutation.AddRateLimitWait(v)
+	return _u
+}
+
+// SetResponseSchema sets the "response_schema" field.
+func (_u *EndpointUpdateOne) SetResponseSchema(v string) *EndpointUpdateOne {
+	_u.mutation.SetResponseSchema(v)
+	return _u
+}
+
+// SetNillableResponseSchema sets the "response_schema" field if the given value is not nil.
+func (_u *EndpointUpdateOne) SetNillableResponseSchema(v *string) *EndpointUpdateOne {
+	if v != nil {
+		_u.SetResponseSchema(*v)
+	}
+	return _u
+}
+
+// ClearResponseSchema clears the value of the "response_schema" field.
+func (_u *EndpointUpdateOne) ClearResponseSchema() *EndpointUpdateOne {
+	_u.mutation.ClearResponseSchema()
+	return _u
+}
+
+// SetValidateResponse sets the "validate_response" field.
+func (_u *EndpointUpdateOne) SetValidateResponse(v bool) *EndpointUpdateOne {
+	_u.mutation.SetValidateResponse(v)
+	return _u
+}
+
+// SetNillableValidateResponse sets the "validate_response" field if the given value is not nil.
+func (_u *EndpointUpdateOne) SetNillableValidateResponse(v *bool) *EndpointUpdateOne {
+	if v != nil {
+		_u.SetValidateResponse(*v)
+	}
+	return _u
+}
+
+// SetRejectInvalidResponse sets the "reject_invalid_response" field.
+func (_u *EndpointUpdateOne) SetRejectInvalidResponse(v bool) *EndpointUpdateOne {
+	_u.mutation.SetRejectInvalidResponse(v)
+	return _u
+}
+
+// SetNillableRejectInvalidResponse sets the "reject_invalid_response" field if the given value is not nil.
+func (_u *EndpointUpdateOne) SetNillableRejectInvalidResponse(v *bool) *EndpointUpdateOne {
+	if v != nil {
+		_u.SetRejectInvalidResponse(*v)
+	}
+	return _u
+}
+
 // Mutation returns the EndpointMutation object of the builder.
 func (_u *EndpointUpdateOne) Mutation() *EndpointMutation {
 	return _u.mutation
@@ -959,6 +1211,36 @@ func (_u *EndpointUpdateOne) sqlSave(ctx context.Context) (_node *Endpoint, err
 	if value, ok := _u.mutation.LogResponses(); ok {
 		_spec.SetField(endpoint.FieldLogResponses, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.RateLimitRps(); ok {
+		_spec.SetField(endpoint.FieldRateLimitRps, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedRateLimitRps(); ok {
+		_spec.AddField(endpoint.FieldRateLimitRps, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.RateLimitBurst(); ok {
+		_spec.SetField(endpoint.FieldRateLimitBurst, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRateLimitBurst(); ok {
+		_spec.AddField(endpoint.FieldRateLimitBurst, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.RateLimitWait(); ok {
+		_spec.SetField(endpoint.FieldRateLimitWait, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRateLimitWait(); ok {
+		_spec.AddField(endpoint.FieldRateLimitWait, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.ResponseSchema(); ok {
+		_spec.SetField(endpoint.FieldResponseSchema, field.TypeString, value)
+	}
+	if _u.mutation.ResponseSchemaCleared() {
+		_spec.ClearField(endpoint.FieldResponseSchema, field.TypeString)
+	}
+	if value, ok := _u.mutation.ValidateResponse(); ok {
+		_spec.SetField(endpoint.FieldValidateResponse, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.RejectInvalidResponse(); ok {
+		_spec.SetField(endpoint.FieldRejectInvalidResponse, field.TypeBool, value)
+	}
 	_node = &Endpoint{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -30,6 +30,12 @@ var (
 		{Name: "validate_ssl", Type: field.TypeBool, Comment: "Whether to validate SSL certificates", Default: true},
 		{Name: "log_requests", Type: field.TypeBool, Comment: "Whether to log request details", Default: true},
 		{Name: "log_responses", Type: field.TypeBool, Comment: "Whether to log response details", Default: true},
+		{Name: "rate_limit_rps", Type: field.TypeFloat64, Comment: "Requests per second sent to the upstream, 0 for no limit", Default: 0},
+		{Name: "rate_limit_burst", Type: field.TypeInt, Comment: "Requests sent at once above the rate, defaults to the rate rounded up", Default: 0},
+		{Name: "rate_limit_wait", Type: field.TypeInt, Comment: "Milliseconds a request waits for the rate limit before it is rejected, 0 to reject at once", Default: 0},
+		{Name: "response_schema", Type: field.TypeString, Nullable: true, Size: 2147483647, Comment: "JSON Schema the upstream JSON responses are expected to match"},
+		{Name: "validate_response", Type: field.TypeBool, Comment: "Whether upstream responses are validated against the response schema", Default: false},
+		{Name: "reject_invalid_response", Type: field.TypeBool, Comment: "Whether a response violating the schema fails the request instead of being returned", Default: false},
 	}
 	// NcseProxyEndpointTable holds the schema information for the "ncse_proxy_endpoint" table.
 	NcseProxyEndpointTable = &schema.Table{
@@ -110,13 +116,14 @@ var (
 		{Name: "endpoint_id", Type: field.TypeString, Comment: "ID of the associated endpoint"},
 		{Name: "path_pattern", Type: field.TypeString, Comment: "Path pattern for this route (e.g., /api/users/:id)"},
 		{Name: "target_path", Type: field.TypeString, Comment: "Target path on the remote API"},
-		{Name: "method", Type: field.TypeString, Comment: "HTTP method (GET, POST, PUT, DELETE, etc.)", Default: "GET"},
+		{Name: "method", Type: field.TypeString, Comment: "HTTP methods, comma separated (GET, POST, PUT, DELETE, etc.) or ANY", Default: "GET"},
 		{Name: "input_transformer_id", Type: field.TypeString, Nullable: true, Comment: "ID of the transformer to apply to incoming requests"},
 		{Name: "output_transformer_id", Type: field.TypeString, Nullable: true, Comment: "ID of the transformer to apply to outgoing responses"},
 		{Name: "cache_enabled", Type: field.TypeBool, Comment: "Whether to cache responses", Default: false},
 		{Name: "cache_ttl", Type: field.TypeInt, Comment: "Time to live for cached responses in seconds", Default: 300},
 		{Name: "rate_limit", Type: field.TypeString, Nullable: true, Comment: "Rate limit expression (e.g., 100/minute)"},
 		{Name: "strip_auth_header", Type: field.TypeBool, Comment: "Whether to strip authentication header when forwarding", Default: false},
+		{Name: "auth_required", Type: field.TypeBool, Comment: "Whether only authenticated users may call this route", Default: false},
 	}
 	// NcseProxyRouteTable holds the schema information for the "ncse_proxy_route" table.
 	NcseProxyRouteTable = &schema.Table{
@@ -35,35 +35,44 @@ const (
 // EndpointMutation represents an operation that mutates the Endpoint nodes in the graph.
 type EndpointMutation struct {
 	config
-	op                  Op
-	typ                 string
-	id                  *string
-	name                *string
-	description         *string
-	disabled            *bool
-	extras              *map[string]interface{}
-	created_by          *string
-	updated_by          *string
-	created_at          *int64
-	addcreated_at       *int64
-	updated_at          *int64
-	addupdated_at       *int64
-	base_url            *string
-	protocol            *string
-	auth_type           *string
-	auth_config         *string
-	timeout             *int
-	addtimeout          *int
-	use_circuit_breaker *bool
-	retry_count         *int
-	addretry_count      *int
-	validate_ssl        *bool
-	log_requests        *bool
-	log_responses       *bool
-	clearedFields       map[string]struct{}
-	done                bool
-	oldValue            func(context.Context) (*Endpoint, error)
-	predicates          []predicate.Endpoint
+	op                      Op
+	typ                     string
+	id                      *string
+	name                    *string
+	description             *string
+	disabled                *bool
+	extras                  *map[string]interface{}
+	created_by              *string
+	updated_by              *string
+	created_at              *int64
+	addcreated_at           *int64
+	updated_at              *int64
+	addupdated_at           *int64
+	base_url                *string
+	protocol                *string
+	auth_type               *string
+	auth_config             *string
+	timeout                 *int
+	addtimeout              *int
+	use_circuit_breaker     *bool
+	retry_count             *int
+	addretry_count          *int
+	validate_ssl            *bool
+	log_requests            *bool
+	log_responses           *bool
+	rate_limit_rps          *float64
+	addrate_limit_rps       *float64
+	rate_limit_burst        *int
+	addrate_limit_burst     *int
+	rate_limit_wait         *int
+	addrate_limit_wait      *int
+	response_schema         *string
+	validate_response       *bool
+	reject_invalid_response *bool
+	clearedFields           map[string]struct{}
+	done                    bool
+	oldValue                func(context.Context) (*Endpoint, error)
+	predicates              []predicate.Endpoint
 }
 
 var _ ent.Mutation = (*EndpointMutation)(nil)
@@ -1017,6 +1026,295 @@ func (m *EndpointMutation) ResetLogResponses() {
 	m.log_responses = nil
 }
 
+// SetRateLimitRps sets the "rate_limit_rps" field.
+func (m *EndpointMutation) SetRateLimitRps(f float64) {
+	m.rate_limit_rps = &f
+	m.addrate_limit_rps = nil
+}
+
+// RateLimitRps returns the value of the "rate_limit_rps" field in the mutation.
+func (m *EndpointMutation) RateLimitRps() (r float64, exists bool) {
+	v := m.rate_limit_rps
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRateLimitRps returns the old "rate_limit_rps" field's value of the Endpoint entity.
+// If the Endpoint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EndpointMutation) OldRateLimitRps(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRateLimitRps is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRateLimitRps requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRateLimitRps: %w", err)
+	}
+	return oldValue.RateLimitRps, nil
+}
+
+// AddRateLimitRps adds f to the "rate_limit_rps" field.
+func (m *EndpointMutation) AddRateLimitRps(f float64) {
+	if m.addrate_limit_rps != nil {
+		*m.addrate_limit_rps += f
+	} else {
+		m.addrate_limit_rps = &f
+	}
+}
+
+// AddedRateLimitRps returns the value that was added to the "rate_limit_rps" field in this mutation.
+func (m *EndpointMutation) AddedRateLimitRps() (r float64, exists bool) {
+	v := m.addrate_limit_rps
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetRateLimitRps resets all changes to the "rate_limit_rps" field.
+func (m *EndpointMutation) ResetRateLimitRps() {
+	m.rate_limit_rps = nil
+	m.addrate_limit_rps = nil
+}
+
+// SetRateLimitBurst sets the "rate_limit_burst" field.
+func (m *EndpointMutation) SetRateLimitBurst(i int) {
+	m.rate_limit_burst = &i
+	m.addrate_limit_burst = nil
+}
+
+// RateLimitBurst returns the value of the "rate_limit_burst" field in the mutation.
+func (m *EndpointMutation) RateLimitBurst() (r int, exists bool) {
+	v := m.rate_limit_burst
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRateLimitBurst returns the old "rate_limit_burst" field's value of the Endpoint entity.
+// If the Endpoint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EndpointMutation) OldRateLimitBurst(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRateLimitBurst is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRateLimitBurst requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRateLimitBurst: %w", err)
+	}
+	return oldValue.RateLimitBurst, nil
+}
+
+// AddRateLimitBurst adds i to the "rate_limit_burst" field.
+func (m *EndpointMutation) AddRateLimitBurst(i int) {
+	if m.addrate_limit_burst != nil {
+		*m.addrate_limit_burst += i
+	} else {
+		m.addrate_limit_burst = &i
+	}
+}
+
+// AddedRateLimitBurst returns the value that was added to the "rate_limit_burst" field in this mutation.
+func (m *EndpointMutation) AddedRateLimitBurst() (r int, exists bool) {
+	v := m.addrate_limit_burst
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetRateLimitBurst resets all changes to the "rate_limit_burst" field.
+func (m *EndpointMutation) ResetRateLimitBurst() {
+	m.rate_limit_burst = nil
+	m.addrate_limit_burst = nil
+}
+
+// SetRateLimitWait sets the "rate_limit_wait" field.
+func (m *EndpointMutation) SetRateLimitWait(i int) {
+	m.rate_limit_wait = &i
+	m.addrate_limit_wait = nil
+}
+
+// RateLimitWait returns the value of the "rate_limit_wait" field in the mutation.
+func (m *EndpointMutation) RateLimitWait() (r int, exists bool) {
+	v := m.rate_limit_wait
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRateLimitWait returns the old "rate_limit_wait" field's value of the Endpoint entity.
+// If the Endpoint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EndpointMutation) OldRateLimitWait(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRateLimitWait is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRateLimitWait requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRateLimitWait: %w", err)
+	}
+	return oldValue.RateLimitWait, nil
+}
+
+// AddRateLimitWait adds i to the "rate_limit_wait" field.
+func (m *EndpointMutation) AddRateLimitWait(i int) {
+	if m.addrate_limit_wait != nil {
+		*m.addrate_limit_wait += i
+	} else {
+		m.addrate_limit_wait = &i
+	}
+}
+
+// AddedRateLimitWait returns the value that was added to the "rate_limit_wait" field in this mutation.
+func (m *EndpointMutation) AddedRateLimitWait() (r int, exists bool) {
+	v := m.addrate_limit_wait
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetRateLimitWait resets all changes to the "rate_limit_wait" field.
+func (m *EndpointMutation) ResetRateLimitWait() {
+	m.rate_limit_wait = nil
+	m.addrate_limit_wait = nil
+}
+
+// SetResponseSchema sets the "response_schema" field.
+func (m *EndpointMutation) SetResponseSchema(s string) {
+	m.response_schema = &s
+}
+
+// ResponseSchema returns the value of the "response_schema" field in the mutation.
+func (m *EndpointMutation) ResponseSchema() (r string, exists bool) {
+	v := m.response_schema
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResponseSchema returns the old "response_schema" field's value of the Endpoint entity.
+// If the Endpoint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EndpointMutation) OldResponseSchema(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResponseSchema is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResponseSchema requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResponseSchema: %w", err)
+	}
+	return oldValue.ResponseSchema, nil
+}
+
+// ClearResponseSchema clears the value of the "response_schema" field.
+func (m *EndpointMutation) ClearResponseSchema() {
+	m.response_schema = nil
+	m.clearedFields[endpoint.FieldResponseSchema] = struct{}{}
+}
+
+// ResponseSchemaCleared returns if the "response_schema" field was cleared in this mutation.
+func (m *EndpointMutation) ResponseSchemaCleared() bool {
+	_, ok := m.clearedFields[endpoint.FieldResponseSchema]
+	return ok
+}
+
+// ResetResponseSchema resets all changes to the "response_schema" field.
+func (m *EndpointMutation) ResetResponseSchema() {
+	m.response_schema = nil
+	delete(m.clearedFields, endpoint.FieldResponseSchema)
+}
+
+// SetValidateResponse sets the "validate_response" field.
+func (m *EndpointMutation) SetValidateResponse(b bool) {
+	m.validate_response = &b
+}
+
+// ValidateResponse returns the value of the "validate_response" field in the mutation.
+func (m *EndpointMutation) ValidateResponse() (r bool, exists bool) {
+	v := m.validate_response
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldValidateResponse returns the old "validate_response" field's value of the Endpoint entity.
+// If the Endpoint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EndpointMutation) OldValidateResponse(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldValidateResponse is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldValidateResponse requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldValidateResponse: %w", err)
+	}
+	return oldValue.ValidateResponse, nil
+}
+
+// ResetValidateResponse resets all changes to the "validate_response" field.
+func (m *EndpointMutation) ResetValidateResponse() {
+	m.validate_response = nil
+}
+
+// SetRejectInvalidResponse sets the "reject_invalid_response" field.
+func (m *EndpointMutation) SetRejectInvalidResponse(b bool) {
+	m.reject_invalid_response = &b
+}
+
+// RejectInvalidResponse returns the value of the "reject_invalid_response" field in the mutation.
+func (m *EndpointMutation) RejectInvalidResponse() (r bool, exists bool) {
+	v := m.reject_invalid_response
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRejectInvalidResponse returns the old "reject_invalid_response" field's value of the Endpoint entity.
+// If the Endpoint object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *EndpointMutation) OldRejectInvalidResponse(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRejectInvalidResponse is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRejectInvalidResponse requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRejectInvalidResponse: %w", err)
+	}
+	return oldValue.RejectInvalidResponse, nil
+}
+
+// ResetRejectInvalidResponse resets all changes to the "reject_invalid_response" field.
+func (m *EndpointMutation) ResetRejectInvalidResponse() {
+	m.reject_invalid_response = nil
+}
+
 // Where appends a list predicates to the EndpointMutation builder.
 func (m *EndpointMutation) Where(ps ...predicate.Endpoint) {
 	m.predicates = append(m.predicates, ps...)
@@ -1051,7 +1349,7 @@ func (m *EndpointMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *EndpointMutation) Fields() []string {
-	fields := make([]string, 0, 18)
+	fields := make([]string, 0, 24)
 	if m.name != nil {
 		fields = append(fields, endpoint.FieldName)
 	}
@@ -1106,6 +1404,24 @@ func (m *EndpointMutation) Fields() []string {
 	if m.log_responses != nil {
 		fields = append(fields, endpoint.FieldLogResponses)
 	}
+	if m.rate_limit_rps != nil {
+		fields = append(fields, endpoint.FieldRateLimitRps)
+	}
+	if m.rate_limit_burst != nil {
+		fields = append(fields, endpoint.FieldRateLimitBurst)
+	}
+	if m.rate_limit_wait != nil {
+		fields = append(fields, endpoint.FieldRateLimitWait)
+	}
+	if m.response_schema != nil {
+		fields = append(fields, endpoint.FieldResponseSchema)
+	}
+	if m.validate_response != nil {
+		fields = append(fields, endpoint.FieldValidateResponse)
+	}
+	if m.reject_invalid_response != nil {
+		fields = append(fields, endpoint.FieldRejectInvalidResponse)
+	}
 	return fields
 }
 
@@ -1150,6 +1466,18 @@ func (m *EndpointMutation) Field(name string) (ent.Value, bool) {
 		return m.LogRequests()
 	case endpoint.FieldLogResponses:
 		return m.LogResponses()
+	case endpoint.FieldRateLimitRps:
+		return m.RateLimitRps()
+	case endpoint.FieldRateLimitBurst:
+		return m.RateLimitBurst()
+	case endpoint.FieldRateLimitWait:
+		return m.RateLimitWait()
+	case endpoint.FieldResponseSchema:
+		return m.ResponseSchema()
+	case endpoint.FieldValidateResponse:
+		return m.ValidateResponse()
+	case endpoint.FieldRejectInvalidResponse:
+		return m.RejectInvalidResponse()
 	}
 	return nil, false
 }
@@ -1195,6 +1523,18 @@ func (m *EndpointMutation) OldField(ctx context.Context, name string) (ent.Value
 		return m.OldLogRequests(ctx)
 	case endpoint.FieldLogResponses:
 		return m.OldLogResponses(ctx)
+	case endpoint.FieldRateLimitRps:
+		return m.OldRateLimitRps(ctx)
+	case endpoint.FieldRateLimitBurst:
+		return m.OldRateLimitBurst(ctx)
+	case endpoint.FieldRateLimitWait:
+		return m.OldRateLimitWait(ctx)
+	case endpoint.FieldResponseSchema:
+		return m.OldResponseSchema(ctx)
+	case endpoint.FieldValidateResponse:
+		return m.OldValidateResponse(ctx)
+	case endpoint.FieldRejectInvalidResponse:
+		return m.OldRejectInvalidResponse(ctx)
 	}
 	return nil, fmt.Errorf("unknown Endpoint field %s", name)
 }
@@ -1330,6 +1670,48 @@ func (m *EndpointMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetLogResponses(v)
 		return nil
+	case endpoint.FieldRateLimitRps:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRateLimitRps(v)
+		return nil
+	case endpoint.FieldRateLimitBurst:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRateLimitBurst(v)
+		return nil
+	case endpoint.FieldRateLimitWait:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRateLimitWait(v)
+		return nil
+	case endpoint.FieldResponseSchema:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResponseSchema(v)
+		return nil
+	case endpoint.FieldValidateResponse:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetValidateResponse(v)
+		return nil
+	case endpoint.FieldRejectInvalidResponse:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRejectInvalidResponse(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Endpoint field %s", name)
 }
@@ -1350,6 +1732,15 @@ func (m *EndpointMutation) AddedFields() []string {
 	if m.addretry_count != nil {
 		fields = append(fields, endpoint.FieldRetryCount)
 	}
+	if m.addrate_limit_rps != nil {
+		fields = append(fields, endpoint.FieldRateLimitRps)
+	}
+	if m.addrate_limit_burst != nil {
+		fields = append(fields, endpoint.FieldRateLimitBurst)
+	}
+	if m.addrate_limit_wait != nil {
+		fields = append(fields, endpoint.FieldRateLimitWait)
+	}
 	return fields
 }
 
@@ -1366,6 +1757,12 @@ func (m *EndpointMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedTimeout()
 	case endpoint.FieldRetryCount:
 		return m.AddedRetryCount()
+	case endpoint.FieldRateLimitRps:
+		return m.AddedRateLimitRps()
+	case endpoint.FieldRateLimitBurst:
+		return m.AddedRateLimitBurst()
+	case endpoint.FieldRateLimitWait:
+		return m.AddedRateLimitWait()
 	}
 	return nil, false
 }
@@ -1403,6 +1800,27 @@ func (m *EndpointMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddRetryCount(v)
 		return nil
+	case endpoint.FieldRateLimitRps:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRateLimitRps(v)
+		return nil
+	case endpoint.FieldRateLimitBurst:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRateLimitBurst(v)
+		return nil
+	case endpoint.FieldRateLimitWait:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRateLimitWait(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Endpoint numeric field %s", name)
 }
@@ -1438,6 +1856,9 @@ func (m *EndpointMutation) ClearedFields() []string {
 	if m.FieldCleared(endpoint.FieldAuthConfig) {
 		fields = append(fields, endpoint.FieldAuthConfig)
 	}
+	if m.FieldCleared(endpoint.FieldResponseSchema) {
+		fields = append(fields, endpoint.FieldResponseSchema)
+	}
 	return fields
 }
 
@@ -1479,6 +1900,9 @@ func (m *EndpointMutation) ClearField(name string) error {
 	case endpoint.FieldAuthConfig:
 		m.ClearAuthConfig()
 		return nil
+	case endpoint.FieldResponseSchema:
+		m.ClearResponseSchema()
+		return nil
 	}
 	return fmt.Errorf("unknown Endpoint nullable field %s", name)
 }
@@ -1541,6 +1965,24 @@ func (m *EndpointMutation) ResetField(name string) error {
 	case endpoint.FieldLogResponses:
 		m.ResetLogResponses()
 		return nil
+	case endpoint.FieldRateLimitRps:
+		m.ResetRateLimitRps()
+		return nil
+	case endpoint.FieldRateLimitBurst:
+		m.ResetRateLimitBurst()
+		return nil
+	case endpoint.FieldRateLimitWait:
+		m.ResetRateLimitWait()
+		return nil
+	case endpoint.FieldResponseSchema:
+		m.ResetResponseSchema()
+		return nil
+	case endpoint.FieldValidateResponse:
+		m.ResetValidateResponse()
+		return nil
+	case endpoint.FieldRejectInvalidResponse:
+		m.ResetRejectInvalidResponse()
+		return nil
 	}
 	return fmt.Errorf("unknown Endpoint field %s", name)
 }
@@ -3019,6 +3461,7 @@ type RouteMutation struct {
 	addcache_ttl          *int
 	rate_limit            *string
 	strip_auth_header     *bool
+	auth_required         *bool
 	clearedFields         map[string]struct{}
 	done                  bool
 	oldValue              func(context.Context) (*Route, error)
@@ -3982,6 +4425,42 @@ func (m *RouteMutation) ResetStripAuthHeader() {
 	m.strip_auth_header = nil
 }
 
+// SetAuthRequired sets the "auth_required" field.
+func (m *RouteMutation) SetAuthRequired(b bool) {
+	m.auth_required = &b
+}
+
+// AuthRequired returns the value of the "auth_required" field in the mutation.
+func (m *RouteMutation) AuthRequired() (r bool, exists bool) {
+	v := m.auth_required
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAuthRequired returns the old "auth_required" field's value of the Route entity.
+// If the Route object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RouteMutation) OldAuthRequired(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAuthRequired is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAuthRequired requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAuthRequired: %w", err)
+	}
+	return oldValue.AuthRequired, nil
+}
+
+// ResetAuthRequired resets all changes to the "auth_required" field.
+func (m *RouteMutation) ResetAuthRequired() {
+	m.auth_required = nil
+}
+
 // Where appends a list predicates to the RouteMutation builder.
 func (m *RouteMutation) Where(ps ...predicate.Route) {
 	m.predicates = append(m.predicates, ps...)
@@ -4016,7 +4495,7 @@ func (m *RouteMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *RouteMutation) Fields() []string {
-	fields := make([]string, 0, 18)
+	fields := make([]string, 0, 19)
 	if m.name != nil {
 		fields = append(fields, route.FieldName)
 	}
@@ -4071,6 +4550,9 @@ func (m *RouteMutation) Fields() []string {
 	if m.strip_auth_header != nil {
 		fields = append(fields, route.FieldStripAuthHeader)
 	}
+	if m.auth_required != nil {
+		fields = append(fields, route.FieldAuthRequired)
+	}
 	return fields
 }
 
@@ -4115,6 +4597,8 @@ func (m *RouteMutation) Field(name string) (ent.Value, bool) {
 		return m.RateLimit()
 	case route.FieldStripAuthHeader:
 		return m.StripAuthHeader()
+	case route.FieldAuthRequired:
+		return m.AuthRequired()
 	}
 	return nil, false
 }
@@ -4160,6 +4644,8 @@ func (m *RouteMutation) OldField(ctx context.Context, name string) (ent.Value, e
 		return m.OldRateLimit(ctx)
 	case route.FieldStripAuthHeader:
 		return m.OldStripAuthHeader(ctx)
+	case route.FieldAuthRequired:
+		return m.OldAuthRequired(ctx)
 	}
 	return nil, fmt.Errorf("unknown Route field %s", name)
 }
@@ -4295,6 +4781,13 @@ func (m *RouteMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetStripAuthHeader(v)
 		return nil
+	case route.FieldAuthRequired:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAuthRequired(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Route field %s", name)
 }
@@ -4506,6 +4999,9 @@ func (m *RouteMutation) ResetField(name string) error {
 	case route.FieldStripAuthHeader:
 		m.ResetStripAuthHeader()
 		return nil
+	case route.FieldAuthRequired:
+		m.ResetAuthRequired()
+		return nil
 	}
 	return fmt.Errorf("unknown Route field %s", name)
 }
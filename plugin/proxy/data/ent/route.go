@@ -40,7 +40,7 @@ type Route struct {
 	PathPattern string `json:"path_pattern,omitempty"`
 	// Target path on the remote API
 	TargetPath string `json:"target_path,omitempty"`
-	// HTTP method (GET, POST, PUT, DELETE, etc.)
+	// HTTP methods, comma separated (GET, POST, PUT, DELETE, etc.) or ANY
 	Method string `json:"method,omitempty"`
 	// ID of the transformer to apply to incoming requests
 	InputTransformerID string `json:"input_transformer_id,omitempty"`
@@ -54,7 +54,9 @@ type Route struct {
 	RateLimit string `json:"rate_limit,omitempty"`
 	// Whether to strip authentication header when forwarding
 	StripAuthHeader bool `json:"strip_auth_header,omitempty"`
-	selectValues    sql.SelectValues
+	// Whether only authenticated users may call this route
+	AuthRequired bool `json:"auth_required,omitempty"`
+	selectValues sql.SelectValues
 }
 
 // scanValues returns the types for scanning values from sql.Rows.
@@ -64,7 +66,7 @@ func (*Route) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case route.FieldExtras:
 			values[i] = new([]byte)
-		case route.FieldDisabled, route.FieldCacheEnabled, route.FieldStripAuthHeader:
+		case route.FieldDisabled, route.FieldCacheEnabled, route.FieldStripAuthHeader, route.FieldAuthRequired:
 			values[i] = new(sql.NullBool)
 		case route.FieldCreatedAt, route.FieldUpdatedAt, route.FieldCacheTTL:
 			values[i] = new(sql.NullInt64)
@@ -201,6 +203,12 @@ func (_m *Route) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.StripAuthHeader = value.Bool
 			}
+		case route.FieldAuthRequired:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field auth_required", values[i])
+			} else if value.Valid {
+				_m.AuthRequired = value.Bool
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -290,6 +298,9 @@ func (_m *Route) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("strip_auth_header=")
 	builder.WriteString(fmt.Sprintf("%v", _m.StripAuthHeader))
+	builder.WriteString(", ")
+	builder.WriteString("auth_required=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AuthRequired))
 	builder.WriteByte(')')
 	return builder.String()
 }
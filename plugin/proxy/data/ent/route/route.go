@@ -47,6 +47,8 @@ const (
 	FieldRateLimit = "rate_limit"
 	// FieldStripAuthHeader holds the string denoting the strip_auth_header field in the database.
 	FieldStripAuthHeader = "strip_auth_header"
+	// FieldAuthRequired holds the string denoting the auth_required field in the database.
+	FieldAuthRequired = "auth_required"
 	// Table holds the table name of the route in the database.
 	Table = "ncse_proxy_route"
 )
@@ -72,6 +74,7 @@ var Columns = []string{
 	FieldCacheTTL,
 	FieldRateLimit,
 	FieldStripAuthHeader,
+	FieldAuthRequired,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -107,6 +110,8 @@ var (
 	DefaultCacheTTL int
 	// DefaultStripAuthHeader holds the default value on creation for the "strip_auth_header" field.
 	DefaultStripAuthHeader bool
+	// DefaultAuthRequired holds the default value on creation for the "auth_required" field.
+	DefaultAuthRequired bool
 	// DefaultID holds the default value on creation for the "id" field.
 	DefaultID func() string
 	// IDValidator is a validator for the "id" field. It is called by the builders before save.
@@ -205,3 +210,8 @@ func ByRateLimit(opts ...sql.OrderTermOption) OrderOption {
 func ByStripAuthHeader(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldStripAuthHeader, opts...).ToFunc()
 }
+
+// ByAuthRequired orders the results by the auth_required field.
+func ByAuthRequired(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAuthRequired, opts...).ToFunc()
+}
@@ -148,6 +148,11 @@ func StripAuthHeader(v bool) predicate.Route {
 	return predicate.Route(sql.FieldEQ(FieldStripAuthHeader, v))
 }
 
+// AuthRequired applies equality check predicate on the "auth_required" field. It's identical to AuthRequiredEQ.
+func AuthRequired(v bool) predicate.Route {
+	return predicate.Route(sql.FieldEQ(FieldAuthRequired, v))
+}
+
 // NameEQ applies the EQ predicate on the "name" field.
 func NameEQ(v string) predicate.Route {
 	return predicate.Route(sql.FieldEQ(FieldName, v))
@@ -1123,6 +1128,16 @@ func StripAuthHeaderNEQ(v bool) predicate.Route {
 	return predicate.Route(sql.FieldNEQ(FieldStripAuthHeader, v))
 }
 
+// AuthRequiredEQ applies the EQ predicate on the "auth_required" field.
+func AuthRequiredEQ(v bool) predicate.Route {
+	return predicate.Route(sql.FieldEQ(FieldAuthRequired, v))
+}
+
+// AuthRequiredNEQ applies the NEQ predicate on the "auth_required" field.
+func AuthRequiredNEQ(v bool) predicate.Route {
+	return predicate.Route(sql.FieldNEQ(FieldAuthRequired, v))
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Route) predicate.Route {
 	return predicate.Route(sql.AndPredicates(predicates...))
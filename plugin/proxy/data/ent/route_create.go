@@ -242,6 +242,20 @@ func (_c *RouteCreate) SetNillableStripAuthHeader(v *bool) *RouteCreate {
 	return _c
 }
 
+// SetAuthRequired sets the "auth_required" field.
+func (_c *RouteCreate) SetAuthRequired(v bool) *RouteCreate {
+	_c.mutation.SetAuthRequired(v)
+	return _c
+}
+
+// SetNillableAuthRequired sets the "auth_required" field if the given value is not nil.
+func (_c *RouteCreate) SetNillableAuthRequired(v *bool) *RouteCreate {
+	if v != nil {
+		_c.SetAuthRequired(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *RouteCreate) SetID(v string) *RouteCreate {
 	_c.mutation.SetID(v)
@@ -323,6 +337,10 @@ func (_c *RouteCreate) defaults() {
 		v := route.DefaultStripAuthHeader
 		_c.mutation.SetStripAuthHeader(v)
 	}
+	if _, ok := _c.mutation.AuthRequired(); !ok {
+		v := route.DefaultAuthRequired
+		_c.mutation.SetAuthRequired(v)
+	}
 	if _, ok := _c.mutation.ID(); !ok {
 		v := route.DefaultID()
 		_c.mutation.SetID(v)
@@ -362,6 +380,9 @@ func (_c *RouteCreate) check() error {
 	if _, ok := _c.mutation.StripAuthHeader(); !ok {
 		return &ValidationError{Name: "strip_auth_header", err: errors.New(`ent: missing required field "Route.strip_auth_header"`)}
 	}
+	if _, ok := _c.mutation.AuthRequired(); !ok {
+		return &ValidationError{Name: "auth_required", err: errors.New(`ent: missing required field "Route.auth_required"`)}
+	}
 	if v, ok := _c.mutation.ID(); ok {
 		if err := route.IDValidator(v); err != nil {
 			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Route.id": %w`, err)}
@@ -475,6 +496,10 @@ func (_c *RouteCreate) createSpec() (*Route, *sqlgraph.CreateSpec) {
 		_spec.SetField(route.FieldStripAuthHeader, field.TypeBool, value)
 		_node.StripAuthHeader = value
 	}
+	if value, ok := _c.mutation.AuthRequired(); ok {
+		_spec.SetField(route.FieldAuthRequired, field.TypeBool, value)
+		_node.AuthRequired = value
+	}
 	return _node, _spec
 }
 
@@ -803,6 +828,18 @@ func (u *RouteUpsert) UpdateStripAuthHeader() *RouteUpsert {
 	return u
 }
 
+// SetAuthRequired sets the "auth_required" field.
+func (u *RouteUpsert) SetAuthRequired(v bool) *RouteUpsert {
+	u.Set(route.FieldAuthRequired, v)
+	return u
+}
+
+// UpdateAuthRequired sets the "auth_required" field to the value that was provided on create.
+func (u *RouteUpsert) UpdateAuthRequired() *RouteUpsert {
+	u.SetExcluded(route.FieldAuthRequired)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
 // Using this option is equivalent to using:
 //
@@ -1176,6 +1213,20 @@ func (u *RouteUpsertOne) UpdateStripAuthHeader() *RouteUpsertOne {
 	})
 }
 
+// SetAuthRequired sets the "auth_required" field.
+func (u *RouteUpsertOne) SetAuthRequired(v bool) *RouteUpsertOne {
+	return u.Update(func(s *RouteUpsert) {
+		s.SetAuthRequired(v)
+	})
+}
+
+// UpdateAuthRequired sets the "auth_required" field to the value that was provided on create.
+func (u *RouteUpsertOne) UpdateAuthRequired() *RouteUpsertOne {
+	return u.Update(func(s *RouteUpsert) {
+		s.UpdateAuthRequired()
+	})
+}
+
 // Exec executes the query.
 func (u *RouteUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -1716,6 +1767,20 @@ func (u *RouteUpsertBulk) UpdateStripAuthHeader() *RouteUpsertBulk {
 	})
 }
 
+// SetAuthRequired sets the "auth_required" field.
+func (u *RouteUpsertBulk) SetAuthRequired(v bool) *RouteUpsertBulk {
+	return u.Update(func(s *RouteUpsert) {
+		s.SetAuthRequired(v)
+	})
+}
+
+// UpdateAuthRequired sets the "auth_required" field to the value that was provided on create.
+func (u *RouteUpsertBulk) UpdateAuthRequired() *RouteUpsertBulk {
+	return u.Update(func(s *RouteUpsert) {
+		s.UpdateAuthRequired()
+	})
+}
+
 // Exec executes the query.
 func (u *RouteUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
@@ -323,6 +323,20 @@ func (_u *RouteUpdate) SetNillableStripAuthHeader(v *bool) *RouteUpdate {
 	return _u
 }
 
+// SetAuthRequired sets the "auth_required" field.
+func (_u *RouteUpdate) SetAuthRequired(v bool) *RouteUpdate {
+	_u.mutation.SetAuthRequired(v)
+	return _u
+}
+
+// SetNillableAuthRequired sets the "auth_required" field if the given value is not nil.
+func (_u *RouteUpdate) SetNillableAuthRequired(v *bool) *RouteUpdate {
+	if v != nil {
+		_u.SetAuthRequired(*v)
+	}
+	return _u
+}
+
 // Mutation returns the RouteMutation object of the builder.
 func (_u *RouteUpdate) Mutation() *RouteMutation {
 	return _u.mutation
@@ -481,6 +495,9 @@ func (_u *RouteUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.StripAuthHeader(); ok {
 		_spec.SetField(route.FieldStripAuthHeader, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.AuthRequired(); ok {
+		_spec.SetField(route.FieldAuthRequired, field.TypeBool, value)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{route.Label}
@@ -797,6 +814,20 @@ func (_u *RouteUpdateOne) SetNillableStripAuthHeader(v *bool) *RouteUpdateOne {
 	return _u
 }
 
+// SetAuthRequired sets the "auth_required" field.
+func (_u *RouteUpdateOne) SetAuthRequired(v bool) *RouteUpdateOne {
+	_u.mutation.SetAuthRequired(v)
+	return _u
+}
+
+// SetNillableAuthRequired sets the "auth_required" field if the given value is not nil.
+func (_u *RouteUpdateOne) SetNillableAuthRequired(v *bool) *RouteUpdateOne {
+	if v != nil {
+		_u.SetAuthRequired(*v)
+	}
+	return _u
+}
+
 // Mutation returns the RouteMutation object of the builder.
 func (_u *RouteUpdateOne) Mutation() *RouteMutation {
 	return _u.mutation
@@ -985,6 +1016,9 @@ func (_u *RouteUpdateOne) sqlSave(ctx context.Context) (_node *Route, err error)
 	if value, ok := _u.mutation.StripAuthHeader(); ok {
 		_spec.SetField(route.FieldStripAuthHeader, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.AuthRequired(); ok {
+		_spec.SetField(route.FieldAuthRequired, field.TypeBool, value)
+	}
 	_node = &Route{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
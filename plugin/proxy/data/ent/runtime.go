@@ -79,6 +79,26 @@ func init() {
 	endpointDescLogResponses := endpointFields[9].Descriptor()
 	// endpoint.DefaultLogResponses holds the default value on creation for the log_responses field.
 	endpoint.DefaultLogResponses = endpointDescLogResponses.Default.(bool)
+	// endpointDescRateLimitRps is the schema descriptor for rate_limit_rps field.
+	endpointDescRateLimitRps := endpointFields[10].Descriptor()
+	// endpoint.DefaultRateLimitRps holds the default value on creation for the rate_limit_rps field.
+	endpoint.DefaultRateLimitRps = endpointDescRateLimitRps.Default.(float64)
+	// endpointDescRateLimitBurst is the schema descriptor for rate_limit_burst field.
+	endpointDescRateLimitBurst := endpointFields[11].Descriptor()
+	// endpoint.DefaultRateLimitBurst holds the default value on creation for the rate_limit_burst field.
+	endpoint.DefaultRateLimitBurst = endpointDescRateLimitBurst.Default.(int)
+	// endpointDescRateLimitWait is the schema descriptor for rate_limit_wait field.
+	endpointDescRateLimitWait := endpointFields[12].Descriptor()
+	// endpoint.DefaultRateLimitWait holds the default value on creation for the rate_limit_wait field.
+	endpoint.DefaultRateLimitWait = endpointDescRateLimitWait.Default.(int)
+	// endpointDescValidateResponse is the schema descriptor for validate_response field.
+	endpointDescValidateResponse := endpointFields[14].Descriptor()
+	// endpoint.DefaultValidateResponse holds the default value on creation for the validate_response field.
+	endpoint.DefaultValidateResponse = endpointDescValidateResponse.Default.(bool)
+	// endpointDescRejectInvalidResponse is the schema descriptor for reject_invalid_response field.
+	endpointDescRejectInvalidResponse := endpointFields[15].Descriptor()
+	// endpoint.DefaultRejectInvalidResponse holds the default value on creation for the reject_invalid_response field.
+	endpoint.DefaultRejectInvalidResponse = endpointDescRejectInvalidResponse.Default.(bool)
 	// endpointDescID is the schema descriptor for id field.
 	endpointDescID := endpointMixinFields0[0].Descriptor()
 	// endpoint.DefaultID holds the default value on creation for the id field.
@@ -161,6 +181,10 @@ func init() {
 	routeDescStripAuthHeader := routeFields[9].Descriptor()
 	// route.DefaultStripAuthHeader holds the default value on creation for the strip_auth_header field.
 	route.DefaultStripAuthHeader = routeDescStripAuthHeader.Default.(bool)
+	// routeDescAuthRequired is the schema descriptor for auth_required field.
+	routeDescAuthRequired := routeFields[10].Descriptor()
+	// route.DefaultAuthRequired holds the default value on creation for the auth_required field.
+	route.DefaultAuthRequired = routeDescAuthRequired.Default.(bool)
 	// routeDescID is the schema descriptor for id field.
 	routeDescID := routeMixinFields0[0].Descriptor()
 	// route.DefaultID holds the default value on creation for the id field.
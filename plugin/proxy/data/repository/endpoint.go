@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"ncobase/internal/fieldcrypt"
 	"ncobase/plugin/proxy/data"
 	"ncobase/plugin/proxy/data/ent"
 	ednpointEnt "ncobase/plugin/proxy/data/ent/endpoint"
@@ -57,8 +58,14 @@ func (r *endpointRepository) Create(ctx context.Context, body *structs.CreateEnd
 	builder.SetNillableProtocol(&body.Protocol)
 	builder.SetNillableAuthType(&body.AuthType)
 
+	crypt := fieldcrypt.For(EncryptionSchema)
+
 	if body.AuthConfig != nil {
-		builder.SetAuthConfig(*body.AuthConfig)
+		authConfig, err := crypt.EncryptString("auth_config", *body.AuthConfig)
+		if err != nil {
+			return nil, err
+		}
+		builder.SetAuthConfig(authConfig)
 	}
 
 	builder.SetTimeout(body.Timeout)
@@ -67,10 +74,20 @@ func (r *endpointRepository) Create(ctx context.Context, body *structs.CreateEnd
 	builder.SetValidateSsl(body.ValidateSSL)
 	builder.SetLogRequests(body.LogRequests)
 	builder.SetLogResponses(body.LogResponses)
+	builder.SetRateLimitRps(body.RateLimitRPS)
+	builder.SetRateLimitBurst(body.RateLimitBurst)
+	builder.SetRateLimitWait(body.RateLimitWait)
+	builder.SetResponseSchema(body.ResponseSchema)
+	builder.SetValidateResponse(body.ValidateResponse)
+	builder.SetRejectInvalidResponse(body.RejectInvalid)
 	builder.SetDisabled(body.Disabled)
 
 	if !validator.IsNil(body.Extras) && !validator.IsEmpty(body.Extras) {
-		builder.SetExtras(*body.Extras)
+		extras, err := crypt.EncryptJSON("extras", *body.Extras)
+		if err != nil {
+			return nil, err
+		}
+		builder.SetExtras(extras)
 	}
 
 	builder.SetNillableCreatedBy(body.CreatedBy)
@@ -103,7 +120,7 @@ func (r *endpointRepository) GetByID(ctx context.Context, id string) (*ent.Endpo
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "endpointRepo.GetByID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache the result
@@ -133,7 +150,7 @@ func (r *endpointRepository) GetByName(ctx context.Context, name string) (*ent.E
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "endpointRepo.GetByName error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache the result
@@ -155,6 +172,7 @@ func (r *endpointRepository) Update(ctx context.Context, id string, updates type
 
 	// Create builder
 	builder := endpoint.Update()
+	crypt := fieldcrypt.For(EncryptionSchema)
 
 	// Apply updates
 	for field, value := range updates {
@@ -170,7 +188,11 @@ func (r *endpointRepository) Update(ctx context.Context, id string, updates type
 		case "auth_type":
 			builder.SetNillableAuthType(convert.ToPointer(value.(string)))
 		case "auth_config":
-			builder.SetAuthConfig(value.(string))
+			authConfig, err := crypt.EncryptString("auth_config", value.(string))
+			if err != nil {
+				return nil, err
+			}
+			builder.SetAuthConfig(authConfig)
 		case "timeout":
 			builder.SetTimeout(int(value.(float64)))
 		case "use_circuit_breaker":
@@ -183,10 +205,26 @@ func (r *endpointRepository) Update(ctx context.Context, id string, updates type
 			builder.SetLogRequests(value.(bool))
 		case "log_responses":
 			builder.SetLogResponses(value.(bool))
+		case "rate_limit_rps":
+			builder.SetRateLimitRps(value.(float64))
+		case "rate_limit_burst":
+			builder.SetRateLimitBurst(int(value.(float64)))
+		case "rate_limit_wait":
+			builder.SetRateLimitWait(int(value.(float64)))
+		case "response_schema":
+			builder.SetResponseSchema(value.(string))
+		case "validate_response":
+			builder.SetValidateResponse(value.(bool))
+		case "reject_invalid_response":
+			builder.SetRejectInvalidResponse(value.(bool))
 		case "disabled":
 			builder.SetDisabled(value.(bool))
 		case "extras":
-			builder.SetExtras(value.(types.JSON))
+			extras, err := crypt.EncryptJSON("extras", value.(types.JSON))
+			if err != nil {
+				return nil, err
+			}
+			builder.SetExtras(extras)
 		case "updated_by":
 			builder.SetUpdatedBy(value.(string))
 		}
@@ -102,7 +102,7 @@ func (r *logRepository) GetByID(ctx context.Context, id string) (*ent.Logs, erro
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "logRepo.GetByID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
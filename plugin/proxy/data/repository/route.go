@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"ncobase/internal/fieldcrypt"
 	"ncobase/plugin/proxy/data"
 	"ncobase/plugin/proxy/data/ent"
 	routeEnt "ncobase/plugin/proxy/data/ent/route"
@@ -26,6 +27,7 @@ type RouteRepositoryInterface interface {
 	GetByID(ctx context.Context, id string) (*ent.Route, error)
 	GetByName(ctx context.Context, name string) (*ent.Route, error)
 	FindByPathPattern(ctx context.Context, path string) ([]*ent.Route, error)
+	ListEnabled(ctx context.Context) ([]*ent.Route, error)
 	Update(ctx context.Context, id string, updates types.JSON) (*ent.Route, error)
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, params *structs.ListRouteParams) ([]*ent.Route, error)
@@ -75,10 +77,15 @@ func (r *routeRepository) Create(ctx context.Context, body *structs.CreateRouteB
 	}
 
 	builder.SetStripAuthHeader(body.StripAuthHeader)
+	builder.SetAuthRequired(body.AuthRequired)
 	builder.SetDisabled(body.Disabled)
 
 	if !validator.IsNil(body.Extras) && !validator.IsEmpty(body.Extras) {
-		builder.SetExtras(*body.Extras)
+		extras, err := fieldcrypt.For(RouteEncryptionSchema).EncryptJSON("extras", *body.Extras)
+		if err != nil {
+			return nil, err
+		}
+		builder.SetExtras(extras)
 	}
 
 	builder.SetNillableCreatedBy(body.CreatedBy)
@@ -111,7 +118,7 @@ func (r *routeRepository) GetByID(ctx context.Context, id string) (*ent.Route, e
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "routeRepo.GetByID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache the result
@@ -141,7 +148,7 @@ func (r *routeRepository) GetByName(ctx context.Context, name string) (*ent.Rout
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "routeRepo.GetByName error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache the result
@@ -160,7 +167,7 @@ func (r *routeRepository) FindByPathPattern(ctx context.Context, path string) ([
 		Where(routeEnt.PathPatternEQ(path)).
 		All(ctx)
 
-	if err != nil && !ent.IsNotFound(err) {
+	if err != nil && !IsNotFound(err) {
 		logger.Errorf(ctx, "routeRepo.FindByPathPattern error: %v", err)
 		return nil, err
 	}
@@ -199,6 +206,18 @@ func (r *routeRepository) FindByPathPattern(ctx context.Context, path string) ([
 	return matches, nil
 }
 
+// ListEnabled lists all routes that are not disabled.
+func (r *routeRepository) ListEnabled(ctx context.Context) ([]*ent.Route, error) {
+	routes, err := r.ec.Route.Query().
+		Where(routeEnt.DisabledEQ(false)).
+		All(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "routeRepo.ListEnabled error: %v", err)
+		return nil, err
+	}
+	return routes, nil
+}
+
 // Update updates a routeEnt.
 func (r *routeRepository) Update(ctx context.Context, id string, updates types.JSON) (*ent.Route, error) {
 	// Get the route
@@ -249,10 +268,16 @@ func (r *routeRepository) Update(ctx context.Context, id string, updates types.J
 			}
 		case "strip_auth_header":
 			builder.SetStripAuthHeader(value.(bool))
+		case "auth_required":
+			builder.SetAuthRequired(value.(bool))
 		case "disabled":
 			builder.SetDisabled(value.(bool))
 		case "extras":
-			builder.SetExtras(value.(types.JSON))
+			extras, err := fieldcrypt.For(RouteEncryptionSchema).EncryptJSON("extras", value.(types.JSON))
+			if err != nil {
+				return nil, err
+			}
+			builder.SetExtras(extras)
 		case "updated_by":
 			builder.SetUpdatedBy(value.(string))
 		}
@@ -1,15 +1,32 @@
 package repository
 
 import (
+	"ncobase/internal/fieldcrypt"
 	"ncobase/plugin/proxy/data/ent"
 	"ncobase/plugin/proxy/structs"
 )
 
+// Schema names in encryption.schemas
+const (
+	EncryptionSchema            = "endpoint"
+	RouteEncryptionSchema       = "route"
+	TransformerEncryptionSchema = "transformer"
+)
+
+func init() {
+	fieldcrypt.RegisterJSON(EncryptionSchema, "extras")
+	fieldcrypt.RegisterJSON(RouteEncryptionSchema, "extras")
+	fieldcrypt.RegisterJSON(TransformerEncryptionSchema, "extras")
+}
+
 // SerializeEndpoint converts ent.Endpoint to structs.ReadEndpoint.
 func SerializeEndpoint(row *ent.Endpoint) *structs.ReadEndpoint {
 	if row == nil {
 		return nil
 	}
+	crypt := fieldcrypt.For(EncryptionSchema)
+	authConfig := crypt.DecryptString("auth_config", row.AuthConfig)
+	extras := crypt.DecryptJSON("extras", row.Extras)
 	return &structs.ReadEndpoint{
 		ID:                row.ID,
 		Name:              row.Name,
@@ -17,15 +34,21 @@ func SerializeEndpoint(row *ent.Endpoint) *structs.ReadEndpoint {
 		BaseURL:           row.BaseURL,
 		Protocol:          row.Protocol,
 		AuthType:          row.AuthType,
-		AuthConfig:        &row.AuthConfig,
+		AuthConfig:        &authConfig,
 		Timeout:           row.Timeout,
 		UseCircuitBreaker: row.UseCircuitBreaker,
 		RetryCount:        row.RetryCount,
 		ValidateSSL:       row.ValidateSsl,
 		LogRequests:       row.LogRequests,
 		LogResponses:      row.LogResponses,
+		RateLimitRPS:      row.RateLimitRps,
+		RateLimitBurst:    row.RateLimitBurst,
+		RateLimitWait:     row.RateLimitWait,
+		ResponseSchema:    row.ResponseSchema,
+		ValidateResponse:  row.ValidateResponse,
+		RejectInvalid:     row.RejectInvalidResponse,
 		Disabled:          row.Disabled,
-		Extras:            &row.Extras,
+		Extras:            &extras,
 		CreatedBy:         &row.CreatedBy,
 		CreatedAt:         &row.CreatedAt,
 		UpdatedBy:         &row.UpdatedBy,
@@ -47,6 +70,7 @@ func SerializeRoute(row *ent.Route) *structs.ReadRoute {
 	if row == nil {
 		return nil
 	}
+	extras := fieldcrypt.For(RouteEncryptionSchema).DecryptJSON("extras", row.Extras)
 	return &structs.ReadRoute{
 		ID:                  row.ID,
 		Name:                row.Name,
@@ -61,8 +85,9 @@ func SerializeRoute(row *ent.Route) *structs.ReadRoute {
 		CacheTTL:            row.CacheTTL,
 		RateLimit:           &row.RateLimit,
 		StripAuthHeader:     row.StripAuthHeader,
+		AuthRequired:        row.AuthRequired,
 		Disabled:            row.Disabled,
-		Extras:              &row.Extras,
+		Extras:              &extras,
 		CreatedBy:           &row.CreatedBy,
 		CreatedAt:           &row.CreatedAt,
 		UpdatedBy:           &row.UpdatedBy,
@@ -84,6 +109,7 @@ func SerializeTransformer(row *ent.Transformer) *structs.ReadTransformer {
 	if row == nil {
 		return nil
 	}
+	extras := fieldcrypt.For(TransformerEncryptionSchema).DecryptJSON("extras", row.Extras)
 	return &structs.ReadTransformer{
 		ID:          row.ID,
 		Name:        row.Name,
@@ -92,7 +118,7 @@ func SerializeTransformer(row *ent.Transformer) *structs.ReadTransformer {
 		Content:     row.Content,
 		ContentType: row.ContentType,
 		Disabled:    row.Disabled,
-		Extras:      &row.Extras,
+		Extras:      &extras,
 		CreatedBy:   &row.CreatedBy,
 		CreatedAt:   &row.CreatedAt,
 		UpdatedBy:   &row.UpdatedBy,
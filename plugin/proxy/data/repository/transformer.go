@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"ncobase/internal/fieldcrypt"
 	"ncobase/plugin/proxy/data"
 	"ncobase/plugin/proxy/data/ent"
 	transformerEnt "ncobase/plugin/proxy/data/ent/transformer"
@@ -59,7 +60,11 @@ func (r *transformerRepository) Create(ctx context.Context, body *structs.Create
 	builder.SetDisabled(body.Disabled)
 
 	if !validator.IsNil(body.Extras) && !validator.IsEmpty(body.Extras) {
-		builder.SetExtras(*body.Extras)
+		extras, err := fieldcrypt.For(TransformerEncryptionSchema).EncryptJSON("extras", *body.Extras)
+		if err != nil {
+			return nil, err
+		}
+		builder.SetExtras(extras)
 	}
 
 	builder.SetNillableCreatedBy(body.CreatedBy)
@@ -92,7 +97,7 @@ func (r *transformerRepository) GetByID(ctx context.Context, id string) (*ent.Tr
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "transformerRepo.GetByID error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache the result
@@ -122,7 +127,7 @@ func (r *transformerRepository) GetByName(ctx context.Context, name string) (*en
 	row, err := builder.Only(ctx)
 	if err != nil {
 		logger.Errorf(ctx, "transformerRepo.GetByName error: %v", err)
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	// Cache the result
@@ -161,7 +166,11 @@ func (r *transformerRepository) Update(ctx context.Context, id string, updates t
 		case "disabled":
 			builder.SetDisabled(value.(bool))
 		case "extras":
-			builder.SetExtras(value.(types.JSON))
+			extras, err := fieldcrypt.For(TransformerEncryptionSchema).EncryptJSON("extras", value.(types.JSON))
+			if err != nil {
+				return nil, err
+			}
+			builder.SetExtras(extras)
 		case "updated_by":
 			builder.SetUpdatedBy(value.(string))
 		}
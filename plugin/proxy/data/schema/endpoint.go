@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -31,7 +32,7 @@ func (Endpoint) Annotations() []schema.Annotation {
 // Mixin of the Endpoint.
 func (Endpoint) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.Description,
 		mixin.Disabled,
@@ -74,6 +75,24 @@ func (Endpoint) Fields() []ent.Field {
 		field.Bool("log_responses").
 			Comment("Whether to log response details").
 			Default(true),
+		field.Float("rate_limit_rps").
+			Comment("Requests per second sent to the upstream, 0 for no limit").
+			Default(0),
+		field.Int("rate_limit_burst").
+			Comment("Requests sent at once above the rate, defaults to the rate rounded up").
+			Default(0),
+		field.Int("rate_limit_wait").
+			Comment("Milliseconds a request waits for the rate limit before it is rejected, 0 to reject at once").
+			Default(0),
+		field.Text("response_schema").
+			Comment("JSON Schema the upstream JSON responses are expected to match").
+			Optional(),
+		field.Bool("validate_response").
+			Comment("Whether upstream responses are validated against the response schema").
+			Default(false),
+		field.Bool("reject_invalid_response").
+			Comment("Whether a response violating the schema fails the request instead of being returned").
+			Default(false),
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 )
 
 // Logs holds the schema definition for the Logs entity.
@@ -30,7 +31,7 @@ func (Logs) Annotations() []schema.Annotation {
 // Mixin of the Logs.
 func (Logs) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.TimeAt{},
 	}
 }
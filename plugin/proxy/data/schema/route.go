@@ -10,6 +10,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 )
 
 // Route holds the schema definition for the Route entity.
@@ -30,7 +31,7 @@ func (Route) Annotations() []schema.Annotation {
 // Mixin of the Route.
 func (Route) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.Description,
 		mixin.Disabled,
@@ -52,7 +53,7 @@ func (Route) Fields() []ent.Field {
 			Comment("Target path on the remote API").
 			NotEmpty(),
 		field.String("method").
-			Comment("HTTP method (GET, POST, PUT, DELETE, etc.)").
+			Comment("HTTP methods, comma separated (GET, POST, PUT, DELETE, etc.) or ANY").
 			Default("GET"),
 		field.String("input_transformer_id").
 			Comment("ID of the transformer to apply to incoming requests").
@@ -72,6 +73,9 @@ func (Route) Fields() []ent.Field {
 		field.Bool("strip_auth_header").
 			Comment("Whether to strip authentication header when forwarding").
 			Default(false),
+		field.Bool("auth_required").
+			Comment("Whether only authenticated users may call this route").
+			Default(false),
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 )
 
 // Transformer holds the schema definition for the Transformer entity.
@@ -30,7 +31,7 @@ func (Transformer) Annotations() []schema.Annotation {
 // Mixin of the Transformer.
 func (Transformer) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.Description,
 		mixin.Disabled,
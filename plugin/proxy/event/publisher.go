@@ -28,6 +28,7 @@ const (
 	EventResponseError         = "proxy.response.error"
 	EventCircuitBreakerTripped = "proxy.circuit_breaker.tripped"
 	EventCircuitBreakerReset   = "proxy.circuit_breaker.reset"
+	EventSchemaViolation       = "proxy.schema_violation"
 )
 
 // ProxyEventData represents event data specific to proxy operations
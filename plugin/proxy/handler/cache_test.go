@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ncobase/plugin/proxy/structs"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// memoryRedis answers the string, set and expiry commands of the proxy from memory,
+// in place of a Redis server. Expiries are kept but never applied.
+type memoryRedis struct {
+	mu      sync.Mutex
+	strings map[string]string
+	sets    map[string]map[string]bool
+	ttls    map[string]time.Duration
+}
+
+func newMemoryRedis() *redis.Client {
+	m := &memoryRedis{strings: map[string]string{}, sets: map[string]map[string]bool{}, ttls: map[string]time.Duration{}}
+	client := redis.NewClient(&redis.Options{Addr: "memory:6379"})
+	client.AddHook(m)
+	return client
+}
+
+func (m *memoryRedis) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (m *memoryRedis) ProcessHook(_ redis.ProcessHook) redis.ProcessHook {
+	return func(_ context.Context, cmd redis.Cmder) error {
+		return m.process(cmd)
+	}
+}
+
+func (m *memoryRedis) ProcessPipelineHook(_ redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(_ context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := m.process(cmd); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (m *memoryRedis) process(cmd redis.Cmder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	args := cmd.Args()
+	name := strings.ToLower(cmd.Name())
+	if name == "multi" || name == "exec" {
+		return nil
+	}
+	key := fmt.Sprint(args[1])
+	switch name {
+	case "get":
+		value, ok := m.strings[key]
+		if !ok {
+			cmd.SetErr(redis.Nil)
+			return redis.Nil
+		}
+		cmd.(*redis.StringCmd).SetVal(value)
+	case "set":
+		value, ok := args[2].([]byte)
+		if !ok {
+			value = []byte(fmt.Sprint(args[2]))
+		}
+		m.strings[key] = string(value)
+		if len(args) > 4 {
+			m.ttls[key] = time.Duration(args[4].(int64)) * time.Millisecond
+			if strings.EqualFold(fmt.Sprint(args[3]), "ex") {
+				m.ttls[key] = time.Duration(args[4].(int64)) * time.Second
+			}
+		}
+	case "sadd":
+		if m.sets[key] == nil {
+			m.sets[key] = map[string]bool{}
+		}
+		for _, member := range args[2:] {
+			m.sets[key][fmt.Sprint(member)] = true
+		}
+	case "smembers":
+		var members []string
+		for member := range m.sets[key] {
+			members = append(members, member)
+		}
+		cmd.(*redis.StringSliceCmd).SetVal(members)
+	case "del":
+		for _, k := range args[1:] {
+			delete(m.strings, fmt.Sprint(k))
+			delete(m.sets, fmt.Sprint(k))
+			delete(m.ttls, fmt.Sprint(k))
+		}
+	case "ttl":
+		cmd.(*redis.DurationCmd).SetVal(m.ttls[key])
+	case "expire":
+		m.ttls[key] = time.Duration(args[2].(int64)) * time.Second
+	default:
+		return fmt.Errorf("unexpected command %s", cmd.Name())
+	}
+	return nil
+}
+
+// countingUpstream answers GETs with the number of calls it served
+func countingUpstream(calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, n))
+		fmt.Fprintf(w, `{"call":%d,"path":%q}`, n, r.URL.Path)
+	}))
+}
+
+func TestProxyCachesGets(t *testing.T) {
+	var calls atomic.Int32
+	upstream := countingUpstream(&calls)
+	defer upstream.Close()
+	f := newProxyFixture(t, upstream.URL, newMemoryRedis())
+	f.route.CacheEnabled, f.route.CacheTTL = true, 60
+
+	first := f.do(http.MethodGet, "/items/1", "")
+	second := f.do(http.MethodGet, "/items/1", "")
+	if first.Header().Get(structs.ProxyCacheHeader) != "MISS" || second.Header().Get(structs.ProxyCacheHeader) != "HIT" {
+		t.Fatalf("cache status %q then %q, want MISS then HIT",
+			first.Header().Get(structs.ProxyCacheHeader), second.Header().Get(structs.ProxyCacheHeader))
+	}
+	if second.Body.String() != first.Body.String() || calls.Load() != 1 {
+		t.Fatalf("second GET %q after %d upstream calls, want the cached %q", second.Body, calls.Load(), first.Body)
+	}
+
+	// the query and the Accept header are part of the key
+	if w := f.do(http.MethodGet, "/items/1?v=2", ""); w.Header().Get(structs.ProxyCacheHeader) != "MISS" {
+		t.Fatal("GET with another query served from the cache")
+	}
+	if w := f.do(http.MethodGet, "/items/1", "", "Accept", "text/csv"); w.Header().Get(structs.ProxyCacheHeader) != "MISS" {
+		t.Fatal("GET with another Accept served from the cache")
+	}
+
+	// the cached ETag answers a conditional GET, a bypass goes upstream
+	if w := f.do(http.MethodGet, "/items/1", "", "If-None-Match", `"v1"`); w.Code != http.StatusNotModified {
+		t.Fatalf("conditional GET %d, want 304", w.Code)
+	}
+	calls.Store(10)
+	if w := f.do(http.MethodGet, "/items/1", "", structs.ProxyCacheHeader, "bypass"); w.Header().Get(structs.ProxyCacheHeader) != "BYPASS" || !strings.Contains(w.Body.String(), `"call":11`) {
+		t.Fatalf("bypass GET %s %q", w.Header().Get(structs.ProxyCacheHeader), w.Body)
+	}
+}
+
+func TestProxyWriteInvalidatesCache(t *testing.T) {
+	var calls atomic.Int32
+	upstream := countingUpstream(&calls)
+	defer upstream.Close()
+	f := newProxyFixture(t, upstream.URL, newMemoryRedis())
+	f.route.CacheEnabled, f.route.CacheTTL = true, 60
+
+	f.do(http.MethodGet, "/items/1", "")
+	f.do(http.MethodGet, "/items", "")
+	f.do(http.MethodGet, "/other", "")
+
+	if w := f.do(http.MethodPost, "/items/1", `{"name":"a"}`); w.Code != http.StatusNoContent {
+		t.Fatalf("POST %d", w.Code)
+	}
+	for path, want := range map[string]string{"/items/1": "MISS", "/items": "MISS", "/other": "HIT"} {
+		if got := f.do(http.MethodGet, path, "").Header().Get(structs.ProxyCacheHeader); got != want {
+			t.Errorf("GET %s after the POST = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestProxyCacheHonorsCacheControl(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", r.URL.Query().Get("cc"))
+		w.Write([]byte("{}"))
+	}))
+	defer upstream.Close()
+	f := newProxyFixture(t, upstream.URL, newMemoryRedis())
+	f.route.CacheEnabled, f.route.CacheTTL = true, 60
+
+	for cc, want := range map[string]string{"no-store": "MISS", "private": "MISS", "max-age=30": "HIT"} {
+		f.do(http.MethodGet, "/items?cc="+cc, "")
+		if got := f.do(http.MethodGet, "/items?cc="+cc, "").Header().Get(structs.ProxyCacheHeader); got != want {
+			t.Errorf("second GET with Cache-Control %s = %s, want %s", cc, got, want)
+		}
+	}
+}
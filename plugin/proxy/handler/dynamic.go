@@ -3,22 +3,28 @@ package handler
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"math"
+	"ncobase/internal/tracing"
 	"ncobase/plugin/proxy/event"
 	"ncobase/plugin/proxy/service"
 	"ncobase/plugin/proxy/structs"
 	"net/http"
 	"net/url"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/ecode"
 	ext "github.com/ncobase/ncore/extension/types"
 	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/utils/convert"
-	"github.com/ncobase/ncore/validation/validator"
 	"github.com/sony/gobreaker"
 )
 
@@ -32,7 +38,6 @@ type DynamicHandlerInterface interface {
 // dynamicHandler represents the dynamic handler.
 type dynamicHandler struct {
 	s                *service.Service
-	circuitBreakers  map[string]*gobreaker.CircuitBreaker
 	httpClient       *http.Client
 	transformerCache map[string]service.TransformerFunc
 	manager          ext.ManagerInterface
@@ -40,9 +45,8 @@ type dynamicHandler struct {
 
 // NewDynamicHandler creates a new dynamic handler.
 func NewDynamicHandler(svc *service.Service) DynamicHandlerInterface {
-	return &dynamicHandler{
-		s:               svc,
-		circuitBreakers: make(map[string]*gobreaker.CircuitBreaker),
+	h := &dynamicHandler{
+		s: svc,
 		httpClient: &http.Client{
 			Timeout: time.Second * 30,
 			Transport: &http.Transport{
@@ -55,6 +59,8 @@ func NewDynamicHandler(svc *service.Service) DynamicHandlerInterface {
 		transformerCache: make(map[string]service.TransformerFunc),
 		manager:          nil, // Will be set later via SetManager
 	}
+	svc.Breaker.OnStateChange(h.publishBreakerStateChange)
+	return h
 }
 
 // SetExtensionManager sets the extension manager for event publishing
@@ -74,13 +80,25 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 	// Extract route path from the full path (removing "/proxy" prefix)
 	routePath := strings.TrimPrefix(path, "/proxy")
 
-	// Find the route configuration
-	route, err := h.s.Route.FindByPathAndMethod(ctx, routePath, method)
+	// Resolve the route the path maps to
+	match, err := h.s.Route.Resolve(ctx, routePath, method)
+	if errors.Is(err, structs.ErrUnsafeRoutePath) {
+		logger.Warnf(ctx, "Refused proxy path %s: %v", routePath, err)
+		resp.Fail(c.Writer, resp.BadRequest("Invalid proxy path"))
+		return
+	}
 	if err != nil {
 		logger.Errorf(ctx, "Failed to find route for path %s and method %s: %v", routePath, method, err)
 		resp.Fail(c.Writer, resp.NotFound("Route not found"))
 		return
 	}
+	route := match.Route
+
+	// Routes requiring auth are only proxied for signed-in users
+	if route.AuthRequired && ctxutil.GetUserID(ctx) == "" {
+		resp.Fail(c.Writer, resp.UnAuthorized(ecode.Text(ecode.Unauthorized)))
+		return
+	}
 
 	// Get the associated endpoint
 	endpoint, err := h.s.Endpoint.GetByID(ctx, route.EndpointID)
@@ -90,6 +108,12 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 		return
 	}
 
+	// Metrics are collected for every call, logging is up to the endpoint
+	outcome, callErr := structs.CallSuccess, error(nil)
+	defer func() {
+		h.s.Metrics.Record(endpoint.ID, outcome, time.Since(startTime), callErr)
+	}()
+
 	// Create event data for tracking this request
 	eventData := &event.ProxyEventData{
 		Timestamp:   time.Now(),
@@ -111,25 +135,72 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 	if err != nil {
 		logger.Errorf(ctx, "Invalid endpoint URL %s: %v", endpoint.BaseURL, err)
 		resp.Fail(c.Writer, resp.InternalServer("Invalid endpoint configuration"))
+		outcome, callErr = structs.CallProxyError, err
 		h.handleRequestError(ctx, eventData, err)
 		return
 	}
 
-	// Apply path parameters from route
-	targetPath := route.TargetPath
-	for _, param := range c.Params {
-		targetPath = strings.Replace(targetPath, ":"+param.Key, param.Value, -1)
+	// Rewrite the path onto the endpoint base path, passing path parameters and query through
+	for key, value := range match.Params {
 		// Add parameters to event metadata for easier tracking
-		eventData.Metadata[param.Key] = param.Value
+		eventData.Metadata[key] = value
+	}
+	targetURL.Path, err = joinUpstreamPath(targetURL.Path, match.TargetPath)
+	if err != nil {
+		logger.Warnf(ctx, "Refused proxy path %s: %v", routePath, err)
+		resp.Fail(c.Writer, resp.BadRequest("Invalid proxy path"))
+		outcome, callErr = structs.CallProxyError, err
+		return
+	}
+	if query := c.Request.URL.RawQuery; query != "" {
+		if targetURL.RawQuery != "" {
+			targetURL.RawQuery += "&" + query
+		} else {
+			targetURL.RawQuery = query
+		}
 	}
 
-	targetURL.Path = targetPath
+	// Serve idempotent GETs from the response cache when the route caches them
+	cacheable := route.CacheEnabled && method == http.MethodGet
+	var cacheKey, cacheStatus string
+	if cacheable {
+		keyHeader := c.Request.Header
+		if route.StripAuthHeader {
+			// Credentials never reach the upstream, so responses are shared between callers
+			keyHeader = keyHeader.Clone()
+			keyHeader.Del("Authorization")
+			keyHeader.Del("Cookie")
+		}
+		cacheKey = h.s.Cache.Key(endpoint.ID, targetURL.Path, c.Request.URL.RawQuery, keyHeader)
+		cacheStatus = "MISS"
+
+		if strings.EqualFold(c.GetHeader(structs.ProxyCacheHeader), "bypass") {
+			cacheStatus = "BYPASS"
+		} else if cached, err := h.s.Cache.Get(ctx, cacheKey); err != nil {
+			logger.Warnf(ctx, "Failed to read response cache of route %s: %v", route.ID, err)
+		} else if cached != nil {
+			h.writeCached(c, cached)
+			return
+		}
+	}
+
+	// Protect the upstream from more calls than the endpoint allows
+	if err := h.s.RateLimit.Acquire(ctx, endpoint); err != nil {
+		outcome, callErr = structs.CallRateLimited, err
+		if !failRateLimited(c, err) {
+			// The caller went away while waiting for the limit
+			outcome = structs.CallProxyError
+			resp.Fail(c.Writer, resp.ServiceUnavailable("Request cancelled while rate limited"))
+		}
+		return
+	}
 
 	// Clone the request
 	proxyReq, err := http.NewRequestWithContext(ctx, c.Request.Method, targetURL.String(), c.Request.Body)
 	if err != nil {
 		logger.Errorf(ctx, "Failed to create request: %v", err)
 		resp.Fail(c.Writer, resp.InternalServer("Failed to create request"))
+		outcome, callErr = structs.CallProxyError, err
 		h.handleRequestError(ctx, eventData, err)
 		return
 	}
@@ -145,23 +216,16 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 		}
 	}
 
-	// Add custom headers based on endpoint configuration
-	if endpoint.AuthType != "None" {
-		// Handle authentication based on endpoint configuration
-		switch endpoint.AuthType {
-		case "Basic":
-			// Parse auth config and add Basic auth header
-			// Implement as needed
-		case "Bearer":
-			// Parse auth config and add Bearer token
-			// Implement as needed
-		case "ApiKey":
-			// Parse auth config and add API key
-			// Implement as needed
-		case "OAuth":
-			// Handle OAuth auth
-			// Implement as needed
-		}
+	// Propagate trace context to the upstream
+	tracing.InjectHeaders(ctx, proxyReq.Header)
+
+	// Authenticate against the upstream with the endpoint credentials
+	if err := service.ApplyEndpointAuth(endpoint, proxyReq.Header); err != nil {
+		logger.Errorf(ctx, "Failed to apply endpoint auth: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Invalid endpoint configuration"))
+		outcome, callErr = structs.CallProxyError, err
+		h.handleRequestError(ctx, eventData, err)
+		return
 	}
 
 	// Read and store the original request body for potential pre-processing
@@ -171,6 +235,7 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 		if err != nil {
 			logger.Errorf(ctx, "Failed to read request body: %v", err)
 			resp.Fail(c.Writer, resp.InternalServer("Failed to read request body"))
+			outcome, callErr = structs.CallProxyError, err
 			h.handleRequestError(ctx, eventData, err)
 			return
 		}
@@ -181,14 +246,15 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 	}
 
 	// Apply input transformer if configured
-	if validator.IsNotEmpty(route.InputTransformerID) {
-		transformer, exists := h.transformerCache[convert.ToString(route.InputTransformerID)]
+	if id := transformerID(route.InputTransformerID); id != "" {
+		transformer, exists := h.transformerCache[id]
 		if exists && requestBody != nil {
 			// Apply transformer
 			transformedBody, err := transformer(requestBody)
 			if err != nil {
 				logger.Errorf(ctx, "Failed to transform request: %v", err)
 				resp.Fail(c.Writer, resp.InternalServer("Failed to transform request"))
+				outcome, callErr = structs.CallProxyError, err
 				h.handleRequestError(ctx, eventData, err)
 				return
 			}
@@ -200,7 +266,7 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 				h.s.Processor.PublishEvent(h.manager, event.EventRequestTransformed, eventData)
 			}
 		} else if !exists {
-			logger.Warnf(ctx, "Input transformer %v not found in cache", route.InputTransformerID)
+			logger.Warnf(ctx, "Input transformer %s not found in cache", id)
 		}
 	}
 
@@ -210,6 +276,7 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 		if err != nil {
 			logger.Errorf(ctx, "Failed to pre-process request: %v", err)
 			resp.Fail(c.Writer, resp.InternalServer("Failed to pre-process request"))
+			outcome, callErr = structs.CallProxyError, err
 			h.handleRequestError(ctx, eventData, err)
 			return
 		}
@@ -238,35 +305,42 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 	var circuitErr error
 
 	if endpoint.UseCircuitBreaker {
-		if cb, exists := h.circuitBreakers[endpoint.ID]; exists {
-			result, err := cb.Execute(func() (any, error) {
-				return h.httpClient.Do(proxyReq)
-			})
+		result, err := h.s.Breaker.Execute(ctx, endpoint, func() (any, error) {
+			return h.httpClient.Do(proxyReq)
+		})
 
-			if err != nil {
-				circuitErr = err
+		if err != nil {
+			circuitErr = err
 
-				// If circuit breaker was tripped, publish an event
-				if h.manager != nil && strings.Contains(err.Error(), "circuit breaker is open") {
-					h.s.Processor.PublishEvent(h.manager, event.EventCircuitBreakerTripped, eventData)
-				}
-			} else {
-				stdResp = result.(*http.Response)
+			// If circuit breaker was tripped, publish an event
+			if h.manager != nil && errors.Is(err, gobreaker.ErrOpenState) {
+				h.s.Processor.PublishEvent(h.manager, event.EventCircuitBreakerTripped, eventData)
 			}
 		} else {
-			// Circuit breaker not found, execute directly
-			stdResp, err = h.httpClient.Do(proxyReq)
+			stdResp = result.(*http.Response)
 		}
 	} else {
 		// Execute without circuit breaker
 		stdResp, err = h.httpClient.Do(proxyReq)
 	}
 
+	// Failed calls are always logged, their request body only when the endpoint logs requests
+	var loggedRequestBody string
+	if endpoint.LogRequests {
+		loggedRequestBody = string(requestBody)
+	}
+
 	// Handle error cases
 	if circuitErr != nil {
 		logger.Errorf(ctx, "Circuit breaker error: %v", circuitErr)
 		resp.Fail(c.Writer, resp.InternalServer("Service unavailable: circuit breaker open"))
 
+		// A rejected call never reached the upstream, a failed one did
+		outcome, callErr = structs.CallUpstreamError, circuitErr
+		if errors.Is(circuitErr, gobreaker.ErrOpenState) || errors.Is(circuitErr, gobreaker.ErrTooManyRequests) {
+			outcome = structs.CallProxyError
+		}
+
 		eventData.Error = circuitErr.Error()
 		eventData.StatusCode = http.StatusServiceUnavailable
 		h.handleRequestError(ctx, eventData, circuitErr)
@@ -279,7 +353,7 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 				RequestMethod:  c.Request.Method,
 				RequestPath:    c.Request.URL.Path,
 				RequestHeaders: c.Request.Header,
-				RequestBody:    string(requestBody),
+				RequestBody:    loggedRequestBody,
 				StatusCode:     http.StatusServiceUnavailable,
 				Error:          circuitErr.Error(),
 				Duration:       int(time.Since(startTime).Milliseconds()),
@@ -293,6 +367,7 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 	if err != nil {
 		logger.Errorf(ctx, "Request failed: %v", err)
 		resp.Fail(c.Writer, resp.InternalServer("Request to third-party API failed"))
+		outcome, callErr = structs.CallUpstreamError, err
 
 		eventData.Error = err.Error()
 		eventData.StatusCode = http.StatusInternalServerError
@@ -306,7 +381,7 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 				RequestMethod:  c.Request.Method,
 				RequestPath:    c.Request.URL.Path,
 				RequestHeaders: c.Request.Header,
-				RequestBody:    string(requestBody),
+				RequestBody:    loggedRequestBody,
 				StatusCode:     http.StatusInternalServerError,
 				Error:          err.Error(),
 				Duration:       int(time.Since(startTime).Milliseconds()),
@@ -321,6 +396,16 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 
 	// Update event data with response info
 	eventData.StatusCode = stdResp.StatusCode
+	if stdResp.StatusCode >= http.StatusInternalServerError {
+		outcome, callErr = structs.CallUpstreamError, fmt.Errorf("upstream responded %s", stdResp.Status)
+	}
+
+	// A successful write makes the cached reads of the same upstream path stale
+	if isWriteMethod(method) && stdResp.StatusCode < http.StatusBadRequest {
+		if err := h.s.Cache.Invalidate(ctx, endpoint.ID, targetURL.Path); err != nil {
+			logger.Warnf(ctx, "Failed to invalidate response cache of %s: %v", targetURL.Path, err)
+		}
+	}
 
 	// Publish response received event
 	if h.manager != nil {
@@ -332,20 +417,47 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 	if err != nil {
 		logger.Errorf(ctx, "Failed to read response body: %v", err)
 		resp.Fail(c.Writer, resp.InternalServer("Failed to read response from third-party API"))
+		outcome, callErr = structs.CallUpstreamError, err
 
 		eventData.Error = err.Error()
 		h.handleRequestError(ctx, eventData, err)
 		return
 	}
 
+	// Check successful, uncompressed responses against the endpoint response schema
+	if stdResp.StatusCode < http.StatusMultipleChoices && isIdentityEncoded(stdResp.Header) {
+		var violation *structs.SchemaViolationError
+		if err := h.s.Schema.Validate(ctx, endpoint, responseBody); errors.As(err, &violation) {
+			logger.Warnf(ctx, "%v", violation)
+			if h.manager != nil {
+				violationData := *eventData
+				violationData.Error = violation.Error()
+				violationData.Metadata = maps.Clone(eventData.Metadata)
+				violationData.Metadata["violations"] = violation.Violations
+				h.s.Processor.PublishEvent(h.manager, event.EventSchemaViolation, &violationData)
+			}
+			if endpoint.RejectInvalid {
+				resp.Fail(c.Writer, &resp.Exception{
+					Status:  http.StatusBadGateway,
+					Code:    ecode.ServerErr,
+					Message: "Upstream response does not match the endpoint schema",
+					Errors:  violation.Violations,
+				})
+				outcome, callErr = structs.CallUpstreamError, violation
+				return
+			}
+		}
+	}
+
 	// Apply output transformer if configured
-	if validator.IsNotEmpty(route.OutputTransformerID) {
-		transformer, exists := h.transformerCache[convert.ToString(route.OutputTransformerID)]
+	if id := transformerID(route.OutputTransformerID); id != "" {
+		transformer, exists := h.transformerCache[id]
 		if exists {
 			transformedBody, err := transformer(responseBody)
 			if err != nil {
 				logger.Errorf(ctx, "Failed to transform response: %v", err)
 				resp.Fail(c.Writer, resp.InternalServer("Failed to transform response"))
+				outcome, callErr = structs.CallProxyError, err
 
 				eventData.Error = err.Error()
 				h.handleRequestError(ctx, eventData, err)
@@ -358,7 +470,7 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 				h.s.Processor.PublishEvent(h.manager, event.EventResponseTransformed, eventData)
 			}
 		} else {
-			logger.Warnf(ctx, "Output transformer %v not found in cache", route.OutputTransformerID)
+			logger.Warnf(ctx, "Output transformer %s not found in cache", id)
 		}
 	}
 
@@ -367,6 +479,7 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 	if err != nil {
 		logger.Errorf(ctx, "Failed to post-process response: %v", err)
 		resp.Fail(c.Writer, resp.InternalServer("Failed to post-process response"))
+		outcome, callErr = structs.CallProxyError, err
 
 		eventData.Error = err.Error()
 		h.handleRequestError(ctx, eventData, err)
@@ -383,12 +496,31 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 		}
 	}
 
+	// Cache the response as sent to the client
+	if cacheable {
+		if ttl := service.ResponseCacheTTL(route.CacheTTL, stdResp.StatusCode, stdResp.Header); ttl > 0 {
+			cached := &structs.CachedResponse{
+				StatusCode: stdResp.StatusCode,
+				Header:     stdResp.Header.Clone(),
+				Body:       responseBody,
+				ETag:       stdResp.Header.Get("ETag"),
+				StoredAt:   time.Now().UnixMilli(),
+			}
+			if err := h.s.Cache.Set(ctx, cacheKey, endpoint.ID, targetURL.Path, cached, ttl); err != nil {
+				logger.Warnf(ctx, "Failed to store response cache of route %s: %v", route.ID, err)
+			}
+		}
+	}
+
 	// Copy response headers
 	for name, values := range stdResp.Header {
 		for _, value := range values {
 			c.Writer.Header().Add(name, value)
 		}
 	}
+	if cacheable {
+		c.Writer.Header().Set(structs.ProxyCacheHeader, cacheStatus)
+	}
 
 	// Set status code and write response body
 	c.Writer.WriteHeader(stdResp.StatusCode)
@@ -433,6 +565,36 @@ func (h *dynamicHandler) ProxyRequest(c *gin.Context) {
 	}
 }
 
+// writeCached writes a cached response, or 304 when the client already holds its ETag
+func (h *dynamicHandler) writeCached(c *gin.Context, cached *structs.CachedResponse) {
+	for name, values := range cached.Header {
+		if name == "Content-Length" {
+			continue
+		}
+		for _, value := range values {
+			c.Writer.Header().Add(name, value)
+		}
+	}
+	c.Writer.Header().Set(structs.ProxyCacheHeader, "HIT")
+
+	if cached.ETag != "" && c.GetHeader("If-None-Match") == cached.ETag {
+		c.Writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	c.Writer.WriteHeader(cached.StatusCode)
+	c.Writer.Write(cached.Body)
+}
+
+// isWriteMethod reports whether a method changes upstream state
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
 // handleRequestError handles errors and publishes appropriate events
 func (h *dynamicHandler) handleRequestError(ctx context.Context, eventData *event.ProxyEventData, err error) {
 	eventData.Error = err.Error()
@@ -443,56 +605,38 @@ func (h *dynamicHandler) handleRequestError(ctx context.Context, eventData *even
 	}
 }
 
-// registerCircuitBreaker creates and registers a circuit breaker for an endpoint
-func (h *dynamicHandler) registerCircuitBreaker(endpointID, endpointName string) {
-	cb := gobreaker.NewCircuitBreaker(gobreaker.Settings{
-		Name:        endpointName,
-		MaxRequests: 100,
-		Interval:    5 * time.Second,
-		Timeout:     30 * time.Second,
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
-			return counts.Requests >= 3 && failureRatio >= 0.6
-		},
-		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
-			logger.Infof(context.Background(), "Circuit breaker %s state changed from %s to %s", name, from, to)
-
-			// If circuit breaker state changes, publish an event
-			if h.manager != nil {
-				eventData := &event.ProxyEventData{
-					Timestamp:  time.Now(),
-					EndpointID: endpointID,
-					Method:     "STATE_CHANGE",
-					Metadata: map[string]any{
-						"from_state": from.String(),
-						"to_state":   to.String(),
-					},
-				}
+// publishBreakerStateChange publishes the trips and resets of endpoint circuit breakers
+func (h *dynamicHandler) publishBreakerStateChange(endpointID string, from, to gobreaker.State) {
+	if h.manager == nil {
+		return
+	}
 
-				if to == gobreaker.StateOpen {
-					h.s.Processor.PublishEvent(h.manager, event.EventCircuitBreakerTripped, eventData)
-				} else if from == gobreaker.StateOpen {
-					h.s.Processor.PublishEvent(h.manager, event.EventCircuitBreakerReset, eventData)
-				}
-			}
+	eventData := &event.ProxyEventData{
+		Timestamp:  time.Now(),
+		EndpointID: endpointID,
+		Method:     "STATE_CHANGE",
+		Metadata: map[string]any{
+			"from_state": from.String(),
+			"to_state":   to.String(),
 		},
-	})
-	h.circuitBreakers[endpointID] = cb
+	}
+
+	if to == gobreaker.StateOpen {
+		h.s.Processor.PublishEvent(h.manager, event.EventCircuitBreakerTripped, eventData)
+	} else if from == gobreaker.StateOpen {
+		h.s.Processor.PublishEvent(h.manager, event.EventCircuitBreakerReset, eventData)
+	}
 }
 
-// RegisterDynamicRoutes registers dynamic routes based on configured proxy routes.
+// RegisterDynamicRoutes registers the catch-all proxy route. Requests are resolved against
+// the configured routes on every call, so route changes apply without a restart.
 func (h *dynamicHandler) RegisterDynamicRoutes(r *gin.RouterGroup) {
 	ctx := context.Background()
 
-	// Get all active routes
-	params := &structs.ListRouteParams{}
-	routes, err := h.s.Route.List(ctx, params)
-	if err != nil {
-		logger.Errorf(ctx, "Failed to load proxy routes: %v", err)
-		return
-	}
+	// Resolve every path and method through the route table
+	r.Any("/*path", h.ProxyRequest)
 
-	// Register circuit breakers for each endpoint
+	// Register circuit breakers for each endpoint, restoring the ones open before a restart
 	endpoints, err := h.s.Endpoint.List(ctx, &structs.ListEndpointParams{})
 	if err != nil {
 		logger.Errorf(ctx, "Failed to load endpoints: %v", err)
@@ -501,7 +645,7 @@ func (h *dynamicHandler) RegisterDynamicRoutes(r *gin.RouterGroup) {
 
 	for _, endpoint := range endpoints.Items {
 		if endpoint.UseCircuitBreaker {
-			h.registerCircuitBreaker(endpoint.ID, endpoint.Name)
+			h.s.Breaker.Register(ctx, endpoint)
 		}
 	}
 
@@ -521,38 +665,48 @@ func (h *dynamicHandler) RegisterDynamicRoutes(r *gin.RouterGroup) {
 			}
 		}
 	}
+}
 
-	// Register dynamic routes
-	for _, route := range routes.Items {
-		if route.Disabled {
-			continue
-		}
+// transformerID returns the ID of a route transformer, empty when the route has none
+func transformerID(id *string) string {
+	if id == nil {
+		return ""
+	}
+	return *id
+}
 
-		// Determine HTTP method and register appropriate handler
-		method := strings.ToUpper(route.Method)
-		path := route.PathPattern
-
-		switch method {
-		case "GET":
-			r.GET(path, h.ProxyRequest)
-		case "POST":
-			r.POST(path, h.ProxyRequest)
-		case "PUT":
-			r.PUT(path, h.ProxyRequest)
-		case "DELETE":
-			r.DELETE(path, h.ProxyRequest)
-		case "PATCH":
-			r.PATCH(path, h.ProxyRequest)
-		case "HEAD":
-			r.HEAD(path, h.ProxyRequest)
-		case "OPTIONS":
-			r.OPTIONS(path, h.ProxyRequest)
-		case "ANY", "*":
-			r.Any(path, h.ProxyRequest)
-		default:
-			r.GET(path, h.ProxyRequest) // Default to GET if method is unrecognized
-		}
+// joinUpstreamPath appends a rewritten route path to the base path of an endpoint,
+// keeping the trailing slash of the route path. The cleaned result must stay under the base path.
+func joinUpstreamPath(basePath, routePath string) (string, error) {
+	base := path.Clean("/" + basePath)
+	joined := path.Clean(base + "/" + routePath)
+	if joined != base && !strings.HasPrefix(joined, strings.TrimSuffix(base, "/")+"/") {
+		return "", structs.ErrUnsafeRoutePath
+	}
+	if strings.HasSuffix(routePath, "/") && joined != "/" {
+		joined += "/"
+	}
+	return joined, nil
+}
 
-		logger.Infof(ctx, "Registered dynamic proxy route: %s %s", method, path)
+// failRateLimited responds with 429 and Retry-After when err is a rate limit rejection
+func failRateLimited(c *gin.Context, err error) bool {
+	var limited *structs.RateLimitedError
+	if !errors.As(err, &limited) {
+		return false
 	}
+
+	c.Header("Retry-After", strconv.FormatInt(int64(math.Ceil(limited.RetryAfter.Seconds())), 10))
+	resp.Fail(c.Writer, &resp.Exception{
+		Status:  http.StatusTooManyRequests,
+		Code:    ecode.LimitExceed,
+		Message: "Upstream rate limit exceeded",
+	})
+	return true
+}
+
+// isIdentityEncoded reports whether a response body is not compressed by the upstream
+func isIdentityEncoded(header http.Header) bool {
+	encoding := header.Get("Content-Encoding")
+	return encoding == "" || strings.EqualFold(encoding, "identity")
 }
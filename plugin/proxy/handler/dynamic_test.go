@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ncobase/plugin/proxy/data"
+	"ncobase/plugin/proxy/event"
+	"ncobase/plugin/proxy/service"
+	"ncobase/plugin/proxy/structs"
+
+	"github.com/gin-gonic/gin"
+	nd "github.com/ncobase/ncore/data"
+	"github.com/ncobase/ncore/data/connection"
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+// fixedRoute resolves every path to one route, with the path as its target
+type fixedRoute struct {
+	service.RouteServiceInterface
+	route *structs.ReadRoute
+}
+
+func (r fixedRoute) Resolve(_ context.Context, path, _ string) (*structs.RouteMatch, error) {
+	return &structs.RouteMatch{Route: r.route, TargetPath: path}, nil
+}
+
+// fixedEndpoint serves one endpoint
+type fixedEndpoint struct {
+	service.EndpointServiceInterface
+	endpoint *structs.ReadEndpoint
+}
+
+func (e fixedEndpoint) GetByID(_ context.Context, id string) (*structs.ReadEndpoint, error) {
+	if id != e.endpoint.ID {
+		return nil, errors.New("endpoint not found")
+	}
+	return e.endpoint, nil
+}
+
+// droppedLogs discards the request logs
+type droppedLogs struct {
+	service.LogServiceInterface
+}
+
+func (droppedLogs) Create(_ context.Context, _ *structs.CreateLogBody) (*structs.ReadLog, error) {
+	return &structs.ReadLog{}, nil
+}
+
+// proxyFixture proxies /proxy/* to an upstream through one route of one endpoint
+type proxyFixture struct {
+	engine   *gin.Engine
+	handler  *dynamicHandler
+	svc      *service.Service
+	route    *structs.ReadRoute
+	endpoint *structs.ReadEndpoint
+}
+
+// newProxyFixture proxies to baseURL, using rc as Redis when it is not nil
+func newProxyFixture(t *testing.T, baseURL string, rc any) *proxyFixture {
+	t.Helper()
+	d := &data.Data{Data: &nd.Data{Conn: &connection.Connections{RC: rc}}}
+	f := &proxyFixture{
+		route:    &structs.ReadRoute{ID: "r1", EndpointID: "e1"},
+		endpoint: &structs.ReadEndpoint{ID: "e1", BaseURL: baseURL},
+	}
+	f.svc = &service.Service{
+		Endpoint:  fixedEndpoint{endpoint: f.endpoint},
+		Route:     fixedRoute{route: f.route},
+		Log:       droppedLogs{},
+		Processor: service.NewProcessorService(),
+		Metrics:   service.NewMetricsService(),
+		Cache:     service.NewResponseCacheService(d),
+		Breaker:   service.NewBreakerService(d),
+		RateLimit: service.NewRateLimitService(d),
+		Schema:    service.NewSchemaService(),
+	}
+	f.handler = NewDynamicHandler(f.svc).(*dynamicHandler)
+
+	gin.SetMode(gin.TestMode)
+	f.engine = gin.New()
+	f.engine.Any("/proxy/*path", f.handler.ProxyRequest)
+	return f
+}
+
+// do sends a request through the proxy
+func (f *proxyFixture) do(method, path, body string, header ...string) *httptest.ResponseRecorder {
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, "/proxy"+path, r)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	f.engine.ServeHTTP(w, req)
+	return w
+}
+
+func TestProxyRecordsEndpointMetrics(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer upstream.Close()
+	f := newProxyFixture(t, upstream.URL, nil)
+
+	for i := 0; i < 6; i++ {
+		if w := f.do(http.MethodGet, "/items", ""); w.Code != http.StatusOK {
+			t.Fatalf("call %d: %d %s", i, w.Code, w.Body)
+		}
+	}
+	f.do(http.MethodGet, "/fail", "")
+
+	// an output transformer that fails is the proxy's error, not the upstream's
+	transformer := "t1"
+	f.handler.transformerCache[transformer] = func([]byte) ([]byte, error) { return nil, errors.New("bad template") }
+	f.route.OutputTransformerID = &transformer
+	f.do(http.MethodGet, "/items", "")
+	f.route.OutputTransformerID = nil
+
+	m := f.svc.Metrics.Get("e1")
+	if m.Requests != 8 || m.UpstreamErrors != 1 || m.ProxyErrors != 1 || m.Errors != 2 {
+		t.Fatalf("metrics %+v, want 8 requests with one upstream and one proxy error", m)
+	}
+	if m.ErrorRate != 0.25 || m.LatencySamples != 8 || m.LatencyP95 < m.LatencyP50 {
+		t.Fatalf("error rate %v over %d samples, p50 %v, p95 %v", m.ErrorRate, m.LatencySamples, m.LatencyP50, m.LatencyP95)
+	}
+	if m.LastError == nil || m.LastError.Outcome != structs.CallProxyError || m.LastError.Message != "bad template" {
+		t.Fatalf("last error %+v, want the transformer error", m.LastError)
+	}
+
+	// an unreachable upstream is an upstream error
+	upstream.Close()
+	if w := f.do(http.MethodGet, "/items", ""); w.Code != http.StatusInternalServerError {
+		t.Fatalf("call to a closed upstream: %d", w.Code)
+	}
+	if m := f.svc.Metrics.Get("e1"); m.UpstreamErrors != 2 || m.LastError.Outcome != structs.CallUpstreamError {
+		t.Fatalf("metrics after the upstream closed %+v", m)
+	}
+}
+
+// rewrittenRoute resolves every path to one route with a fixed target path
+type rewrittenRoute struct {
+	service.RouteServiceInterface
+	match *structs.RouteMatch
+}
+
+func (r rewrittenRoute) Resolve(_ context.Context, _, _ string) (*structs.RouteMatch, error) {
+	return r.match, nil
+}
+
+func TestProxyRewritesToUpstreamBaseURL(t *testing.T) {
+	var got string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.RequestURI()
+		w.Write([]byte(`{}`))
+	}))
+	defer upstream.Close()
+	f := newProxyFixture(t, upstream.URL+"/base/?key=k1", nil)
+	f.svc.Route = rewrittenRoute{match: &structs.RouteMatch{
+		Route:      f.route,
+		Params:     map[string]string{"id": "42"},
+		TargetPath: "/v2/users/42",
+	}}
+
+	if w := f.do(http.MethodGet, "/api/users/42?fields=name", ""); w.Code != http.StatusOK {
+		t.Fatalf("proxy: %d %s", w.Code, w.Body)
+	}
+	if got != "/base/v2/users/42?key=k1&fields=name" {
+		t.Fatalf("upstream request %q, want the target path under the base path with both queries", got)
+	}
+
+	f.route.AuthRequired = true
+	got = ""
+	if w := f.do(http.MethodGet, "/api/users/42", ""); w.Code != http.StatusUnauthorized || got != "" {
+		t.Fatalf("anonymous call to an auth route: %d, upstream %q", w.Code, got)
+	}
+}
+
+func TestJoinUpstreamPath(t *testing.T) {
+	tests := []struct{ base, route, want string }{
+		{"", "/users/42", "/users/42"},
+		{"/", "/users/42", "/users/42"},
+		{"/base/", "/users/42", "/base/users/42"},
+		{"/base", "users/", "/base/users/"},
+		{"/base", "", "/base"},
+		{"", "/", "/"},
+	}
+	for _, tt := range tests {
+		if got, err := joinUpstreamPath(tt.base, tt.route); err != nil || got != tt.want {
+			t.Fatalf("joinUpstreamPath(%q, %q) = %q, %v, want %q", tt.base, tt.route, got, err, tt.want)
+		}
+	}
+	for _, route := range []string{"/../admin", "../../etc/passwd", "/users/../../admin"} {
+		if got, err := joinUpstreamPath("/base", route); !errors.Is(err, structs.ErrUnsafeRoutePath) {
+			t.Fatalf("joinUpstreamPath(/base, %q) = %q, %v, want it refused", route, got, err)
+		}
+	}
+}
+
+// publishedEvents records the events published through the extension manager
+type publishedEvents struct {
+	ext.ManagerInterface
+	events map[string][]*event.ProxyEventData
+}
+
+func (m *publishedEvents) PublishEvent(eventName string, data any, _ ...ext.EventTarget) {
+	if eventData, ok := data.(*event.ProxyEventData); ok {
+		m.events[eventName] = append(m.events[eventName], eventData)
+	}
+}
+
+func TestProxyValidatesResponseSchema(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/drifted" {
+			w.Write([]byte(`{"id":"1"}`))
+			return
+		}
+		w.Write([]byte(`{"id":1,"name":"ada"}`))
+	}))
+	defer upstream.Close()
+	f := newProxyFixture(t, upstream.URL, nil)
+	f.endpoint.ResponseSchema = `{"type":"object","required":["id","name"],"properties":{"id":{"type":"integer"}}}`
+	f.endpoint.ValidateResponse = true
+	manager := &publishedEvents{events: make(map[string][]*event.ProxyEventData)}
+	f.handler.SetExtensionManager(manager)
+
+	if w := f.do(http.MethodGet, "/users", ""); w.Code != http.StatusOK {
+		t.Fatalf("matching response: %d %s", w.Code, w.Body)
+	}
+	if n := len(manager.events[event.EventSchemaViolation]); n != 0 {
+		t.Fatalf("%d violations published for a matching response", n)
+	}
+
+	// a violating response is published and still returned
+	w := f.do(http.MethodGet, "/drifted", "")
+	if w.Code != http.StatusOK || w.Body.String() != `{"id":"1"}` {
+		t.Fatalf("violating response: %d %s, want it passed through", w.Code, w.Body)
+	}
+	violations := manager.events[event.EventSchemaViolation]
+	if len(violations) != 1 || violations[0].EndpointID != "e1" || violations[0].Error == "" {
+		t.Fatalf("published violations %+v, want one for e1", violations)
+	}
+	if checks, _ := violations[0].Metadata["violations"].([]string); len(checks) != 2 {
+		t.Fatalf("published checks %v, want the missing name and the wrong id type", violations[0].Metadata["violations"])
+	}
+
+	// rejecting invalid responses answers 502 and counts an upstream error
+	f.endpoint.RejectInvalid = true
+	if w := f.do(http.MethodGet, "/drifted", ""); w.Code != http.StatusBadGateway {
+		t.Fatalf("rejected response: %d %s", w.Code, w.Body)
+	}
+	if m := f.svc.Metrics.Get("e1"); m.UpstreamErrors != 1 || m.LastError.Outcome != structs.CallUpstreamError {
+		t.Fatalf("metrics after a rejected response %+v", m)
+	}
+}
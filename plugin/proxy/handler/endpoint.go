@@ -1,13 +1,13 @@
 package handler
 
 import (
+	"ncobase/internal/validation"
 	"ncobase/plugin/proxy/service"
 	"ncobase/plugin/proxy/structs"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
 	"github.com/ncobase/ncore/types"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,6 +19,9 @@ type EndpointHandlerInterface interface {
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
 	List(c *gin.Context)
+	Metrics(c *gin.Context)
+	Breaker(c *gin.Context)
+	ResetBreaker(c *gin.Context)
 }
 
 // endpointHandler represents the endpoint handler.
@@ -185,3 +188,95 @@ func (h *endpointHandler) List(c *gin.Context) {
 
 	resp.Success(c.Writer, endpoints)
 }
+
+// Metrics handles retrieving the call statistics of an endpoint.
+//
+// @Summary Get endpoint metrics
+// @Description Retrieve request count, error rate, p50/p95 latency and last error of an endpoint on this instance
+// @Tags proxy
+// @Produce json
+// @Param id path string true "Endpoint ID or name"
+// @Success 200 {object} structs.EndpointMetrics "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 404 {object} resp.Exception "not found"
+// @Router /tbp/endpoints/{id}/metrics [get]
+// @Security Bearer
+func (h *endpointHandler) Metrics(c *gin.Context) {
+	endpoint, ok := h.findEndpoint(c)
+	if !ok {
+		return
+	}
+
+	resp.Success(c.Writer, h.s.Metrics.Get(endpoint.ID))
+}
+
+// Breaker handles retrieving the circuit breaker state of an endpoint.
+//
+// @Summary Get endpoint circuit breaker
+// @Description Retrieve the circuit breaker state (closed, half-open or open) and failure counts of an endpoint
+// @Tags proxy
+// @Produce json
+// @Param id path string true "Endpoint ID or name"
+// @Success 200 {object} structs.BreakerState "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 404 {object} resp.Exception "not found"
+// @Failure 409 {object} resp.Exception "circuit breaker not enabled"
+// @Router /tbp/endpoints/{id}/breaker [get]
+// @Security Bearer
+func (h *endpointHandler) Breaker(c *gin.Context) {
+	endpoint, ok := h.findEndpoint(c)
+	if !ok {
+		return
+	}
+	if !endpoint.UseCircuitBreaker {
+		resp.Fail(c.Writer, resp.Conflict("Circuit breaker is not enabled for this endpoint"))
+		return
+	}
+
+	resp.Success(c.Writer, h.s.Breaker.State(c.Request.Context(), endpoint))
+}
+
+// ResetBreaker handles force-closing the circuit breaker of an endpoint.
+//
+// @Summary Reset endpoint circuit breaker
+// @Description Close the circuit breaker of an endpoint and clear its counts, after the upstream is known to be fixed
+// @Tags proxy
+// @Produce json
+// @Param id path string true "Endpoint ID or name"
+// @Success 200 {object} structs.BreakerState "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 404 {object} resp.Exception "not found"
+// @Failure 409 {object} resp.Exception "circuit breaker not enabled"
+// @Router /tbp/endpoints/{id}/breaker/reset [post]
+// @Security Bearer
+func (h *endpointHandler) ResetBreaker(c *gin.Context) {
+	endpoint, ok := h.findEndpoint(c)
+	if !ok {
+		return
+	}
+	if !endpoint.UseCircuitBreaker {
+		resp.Fail(c.Writer, resp.Conflict("Circuit breaker is not enabled for this endpoint"))
+		return
+	}
+
+	resp.Success(c.Writer, h.s.Breaker.Reset(c.Request.Context(), endpoint))
+}
+
+// findEndpoint resolves the endpoint of the request by ID or name, writing the failure response
+func (h *endpointHandler) findEndpoint(c *gin.Context) (*structs.ReadEndpoint, bool) {
+	slug := c.Param("id")
+	if slug == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("id")))
+		return nil, false
+	}
+
+	endpoint, err := h.s.Endpoint.GetByID(c.Request.Context(), slug)
+	if err != nil {
+		endpoint, err = h.s.Endpoint.GetByName(c.Request.Context(), slug)
+	}
+	if err != nil {
+		resp.Fail(c.Writer, resp.NotFound(err.Error()))
+		return nil, false
+	}
+	return endpoint, true
+}
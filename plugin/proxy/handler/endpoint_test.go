@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ncobase/plugin/proxy/data"
+	"ncobase/plugin/proxy/service"
+	"ncobase/plugin/proxy/structs"
+
+	nd "github.com/ncobase/ncore/data"
+	"github.com/ncobase/ncore/data/connection"
+)
+
+// breakerState reads the breaker of e1 through the endpoint API
+func (f *proxyFixture) breakerState(t *testing.T, method, path string) *structs.BreakerState {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	f.engine.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("%s %s: %d %s", method, path, w.Code, w.Body)
+	}
+	state := &structs.BreakerState{}
+	if err := json.Unmarshal(w.Body.Bytes(), state); err != nil {
+		t.Fatalf("decode breaker state: %v", err)
+	}
+	return state
+}
+
+func TestBreakerTripsReportsAndResets(t *testing.T) {
+	// an upstream that is down fails every call
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	rc := newMemoryRedis()
+	f := newProxyFixture(t, down.URL, rc)
+	f.endpoint.UseCircuitBreaker = true
+
+	endpoints := NewEndpointHandler(f.svc)
+	f.engine.GET("/endpoints/:id/breaker", endpoints.Breaker)
+	f.engine.POST("/endpoints/:id/breaker/reset", endpoints.ResetBreaker)
+
+	if state := f.breakerState(t, http.MethodGet, "/endpoints/e1/breaker"); state.State != "closed" {
+		t.Fatalf("breaker before any call is %s", state.State)
+	}
+	for i := 0; i < 3; i++ {
+		f.do(http.MethodGet, "/items", "")
+	}
+	state := f.breakerState(t, http.MethodGet, "/endpoints/e1/breaker")
+	if state.State != "open" || state.TripFailures != 3 || state.TripRequests != 3 || state.OpenedAt == 0 || state.OpenUntil <= state.OpenedAt {
+		t.Fatalf("breaker after three failures %+v, want open after 3 of 3", state)
+	}
+
+	// an open breaker rejects calls without reaching the upstream, as the proxy's error
+	f.do(http.MethodGet, "/items", "")
+	if m := f.svc.Metrics.Get("e1"); m.UpstreamErrors != 3 || m.ProxyErrors != 1 {
+		t.Fatalf("metrics %+v, want 3 upstream errors and 1 rejected call", m)
+	}
+
+	// a restarted instance restores the open breaker from Redis
+	restarted := service.NewBreakerService(&data.Data{Data: &nd.Data{Conn: &connection.Connections{RC: rc}}})
+	if state := restarted.State(t.Context(), f.endpoint); state.State != "open" || state.TripFailures != 3 {
+		t.Fatalf("restored breaker %+v, want open", state)
+	}
+
+	state = f.breakerState(t, http.MethodPost, "/endpoints/e1/breaker/reset")
+	if state.State != "closed" || state.Requests != 0 || state.TripFailures != 0 {
+		t.Fatalf("breaker after reset %+v, want closed without counts", state)
+	}
+	restarted = service.NewBreakerService(&data.Data{Data: &nd.Data{Conn: &connection.Connections{RC: rc}}})
+	if state := restarted.State(t.Context(), f.endpoint); state.State != "closed" {
+		t.Fatalf("breaker restored after the reset is %s, want closed", state.State)
+	}
+}
+
+func TestBreakerRequiresEnabledEndpoint(t *testing.T) {
+	f := newProxyFixture(t, "http://upstream.invalid", nil)
+	f.engine.GET("/endpoints/:id/breaker", NewEndpointHandler(f.svc).Breaker)
+
+	w := httptest.NewRecorder()
+	f.engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/endpoints/e1/breaker", nil))
+	if w.Code != http.StatusConflict {
+		t.Fatalf("breaker of an endpoint without one: %d, want 409", w.Code)
+	}
+}
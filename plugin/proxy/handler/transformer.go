@@ -1,13 +1,13 @@
 package handler
 
 import (
+	"ncobase/internal/validation"
 	"ncobase/plugin/proxy/service"
 	"ncobase/plugin/proxy/structs"
 
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/net/resp"
 	"github.com/ncobase/ncore/types"
-	"github.com/ncobase/ncore/validation"
 
 	"github.com/gin-gonic/gin"
 )
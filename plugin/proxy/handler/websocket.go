@@ -2,30 +2,51 @@ package handler
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"ncobase/plugin/proxy/service"
 	"ncobase/plugin/proxy/structs"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/logging/logger"
-	"github.com/ncobase/ncore/utils/convert"
-	"github.com/ncobase/ncore/validation/validator"
+	"github.com/ncobase/ncore/net/resp"
 )
 
 // WebSocketHandlerInterface is the interface for the WebSocket handler.
 type WebSocketHandlerInterface interface {
 	RegisterWebSocketRoutes(r *gin.RouterGroup)
 	HandleWebSocket(c *gin.Context)
+	CloseAll()
+}
+
+// wsDefaultTimeout is the handshake and write timeout of endpoints without a timeout
+const wsDefaultTimeout = 30 * time.Second
+
+// wsHandshakeHeaders are the client headers the dialer sets itself for the upstream handshake
+var wsHandshakeHeaders = map[string]bool{
+	"Host":                     true,
+	"Upgrade":                  true,
+	"Connection":               true,
+	"Sec-Websocket-Key":        true,
+	"Sec-Websocket-Version":    true,
+	"Sec-Websocket-Extensions": true,
+	"Sec-Websocket-Protocol":   true,
 }
 
 // webSocketHandler represents the WebSocket handler.
 type webSocketHandler struct {
 	s                 *service.Service
 	upgrader          websocket.Upgrader
+	mu                sync.Mutex
 	activeConnections sync.Map // map[string][]*websocket.Conn
 	transformerCache  map[string]service.TransformerFunc
 }
@@ -46,20 +67,11 @@ func NewWebSocketHandler(svc *service.Service) WebSocketHandlerInterface {
 	}
 }
 
-// RegisterWebSocketRoutes registers WebSocket routes based on configured endpoints.
+// RegisterWebSocketRoutes registers the catch-all WebSocket route. Connections are resolved
+// against the configured routes when they are opened, like the HTTP proxy routes.
 func (h *webSocketHandler) RegisterWebSocketRoutes(r *gin.RouterGroup) {
 	ctx := context.Background()
 
-	// Get all active endpoints with WebSocket protocol
-	params := &structs.ListEndpointParams{
-		Protocol: "WS,WSS", // Filter to only websocket protocols
-	}
-	endpoints, err := h.s.Endpoint.List(ctx, params)
-	if err != nil {
-		logger.Errorf(ctx, "Failed to load WebSocket endpoints: %v", err)
-		return
-	}
-
 	// Load all transformers into cache
 	transformers, err := h.s.Transformer.List(ctx, &structs.ListTransformerParams{})
 	if err != nil {
@@ -77,55 +89,37 @@ func (h *webSocketHandler) RegisterWebSocketRoutes(r *gin.RouterGroup) {
 		}
 	}
 
-	// Register routes for WebSocket endpoints
-	for _, endpoint := range endpoints.Items {
-		if endpoint.Disabled {
-			continue
-		}
-
-		// Get routes for this endpoint
-		routeParams := &structs.ListRouteParams{
-			EndpointID: endpoint.ID,
-		}
-		routes, err := h.s.Route.List(ctx, routeParams)
-		if err != nil {
-			logger.Errorf(ctx, "Failed to load routes for endpoint %s: %v", endpoint.ID, err)
-			continue
-		}
-
-		// Register each WebSocket route
-		for _, route := range routes.Items {
-			if route.Disabled {
-				continue
-			}
-
-			path := route.PathPattern
-			r.GET(path, h.HandleWebSocket)
-			logger.Infof(ctx, "Registered WebSocket route: %s", path)
-		}
-	}
+	// WebSocket handshakes are GET requests
+	r.GET("/*path", h.HandleWebSocket)
 }
 
-// HandleWebSocket handles WebSocket connections.
+// HandleWebSocket handles WebSocket connections. The upstream is dialed first, so a failing
+// upstream is answered with 502 instead of an upgraded connection that closes right away.
 func (h *webSocketHandler) HandleWebSocket(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	// Find the matching route
-	path := c.Request.URL.Path
-
 	// Extract route path from the full path (removing "/ws" prefix)
-	routePath := path
-	if path[:3] == "/ws" {
-		routePath = path[3:]
-	}
+	routePath := strings.TrimPrefix(c.Request.URL.Path, "/ws")
 
-	// Find the route configuration
-	route, err := h.s.Route.FindByPathAndMethod(ctx, routePath, "GET") // WebSockets use GET
+	// Resolve the route the path maps to
+	match, err := h.s.Route.Resolve(ctx, routePath, http.MethodGet)
+	if errors.Is(err, structs.ErrUnsafeRoutePath) {
+		logger.Warnf(ctx, "Refused WebSocket path %s: %v", routePath, err)
+		c.String(http.StatusBadRequest, "Invalid proxy path")
+		return
+	}
 	if err != nil {
 		logger.Errorf(ctx, "Failed to find WebSocket route for path %s: %v", routePath, err)
 		c.String(http.StatusNotFound, "WebSocket route not found")
 		return
 	}
+	route := match.Route
+
+	// Routes requiring auth are only proxied for signed-in users
+	if route.AuthRequired && ctxutil.GetUserID(ctx) == "" {
+		resp.Fail(c.Writer, resp.UnAuthorized(ecode.Text(ecode.Unauthorized)))
+		return
+	}
 
 	// Get the associated endpoint
 	endpoint, err := h.s.Endpoint.GetByID(ctx, route.EndpointID)
@@ -136,97 +130,126 @@ func (h *webSocketHandler) HandleWebSocket(c *gin.Context) {
 	}
 
 	// Verify endpoint is WebSocket
-	if endpoint.Protocol != "WS" && endpoint.Protocol != "WSS" {
+	protocol := strings.ToLower(endpoint.Protocol)
+	if protocol != "ws" && protocol != "wss" {
 		logger.Errorf(ctx, "Endpoint %s is not a WebSocket endpoint", endpoint.ID)
 		c.String(http.StatusBadRequest, "Not a WebSocket endpoint")
 		return
 	}
-
-	// Upgrade the HTTP connection to a WebSocket connection
-	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		logger.Errorf(ctx, "Failed to upgrade to WebSocket: %v", err)
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		c.String(http.StatusBadRequest, "WebSocket upgrade required")
 		return
 	}
 
-	// Store the connection
-	// connID := fmt.Sprintf("%s_%s", endpoint.ID, time.Now().Format("20060102150405"))
-	connections, _ := h.activeConnections.LoadOrStore(endpoint.ID, make([]*websocket.Conn, 0))
-	connectionsList := connections.([]*websocket.Conn)
-	connectionsList = append(connectionsList, conn)
-	h.activeConnections.Store(endpoint.ID, connectionsList)
-
-	// Ensure connection is closed and removed when done
-	defer func() {
-		conn.Close()
-		h.removeConnection(endpoint.ID, conn)
-	}()
-
-	// Connect to target WebSocket
+	// Build the upstream URL from the endpoint base URL and the rewritten route path
 	targetURL, err := url.Parse(endpoint.BaseURL)
 	if err != nil {
 		logger.Errorf(ctx, "Invalid endpoint URL %s: %v", endpoint.BaseURL, err)
-		conn.WriteMessage(websocket.TextMessage, []byte("Error: Invalid endpoint configuration"))
+		c.String(http.StatusInternalServerError, "Invalid endpoint configuration")
 		return
 	}
-
-	// Apply path parameters from route
-	targetPath := route.TargetPath
-	for _, param := range c.Params {
-		targetPath = strings.Replace(targetPath, ":"+param.Key, param.Value, -1)
+	targetURL.Scheme = protocol
+	targetURL.Path, err = joinUpstreamPath(targetURL.Path, match.TargetPath)
+	if err != nil {
+		logger.Warnf(ctx, "Refused WebSocket path %s: %v", routePath, err)
+		c.String(http.StatusBadRequest, "Invalid proxy path")
+		return
 	}
-
-	targetURL.Path = targetPath
-
-	// Determine the protocol
-	var wsProtocol string
-	if endpoint.Protocol == "WSS" {
-		wsProtocol = "wss"
-	} else {
-		wsProtocol = "ws"
+	if query := c.Request.URL.RawQuery; query != "" {
+		if targetURL.RawQuery != "" {
+			targetURL.RawQuery += "&" + query
+		} else {
+			targetURL.RawQuery = query
+		}
 	}
 
-	// Set the scheme to ws or wss
-	targetURL.Scheme = wsProtocol
-
-	// Create header for target connection
+	// Forward the client headers except the ones of the handshake itself
 	header := http.Header{}
 	for k, v := range c.Request.Header {
-		if k != "Upgrade" && k != "Connection" && k != "Sec-Websocket-Key" &&
-			k != "Sec-Websocket-Version" && k != "Sec-Websocket-Extensions" && k != "Sec-Websocket-Protocol" {
-			header[k] = v
+		if wsHandshakeHeaders[k] {
+			continue
 		}
+		if route.StripAuthHeader && (k == "Authorization" || k == "Cookie") {
+			continue
+		}
+		header[k] = v
+	}
+	if err := service.ApplyEndpointAuth(endpoint, header); err != nil {
+		logger.Errorf(ctx, "Failed to apply endpoint auth: %v", err)
+		c.String(http.StatusInternalServerError, "Invalid endpoint configuration")
+		return
 	}
 
-	// Add authentication if configured
-	if endpoint.AuthType != "None" {
-		// Handle authentication based on endpoint configuration
-		switch endpoint.AuthType {
-		case "Basic", "Bearer", "ApiKey", "OAuth":
-			// Implement as needed
+	// A connection counts as one call against the rate limit of the endpoint
+	if err := h.s.RateLimit.Acquire(ctx, endpoint); err != nil {
+		if !failRateLimited(c, err) {
+			c.String(http.StatusServiceUnavailable, "Request cancelled while rate limited")
 		}
+		return
 	}
 
-	// Connect to target WebSocket
-	targetConn, _, err := websocket.DefaultDialer.Dial(targetURL.String(), header)
+	// Connect to target WebSocket within the endpoint timeout
+	timeout := time.Duration(endpoint.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = wsDefaultTimeout
+	}
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: timeout,
+		Subprotocols:     websocket.Subprotocols(c.Request),
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: !endpoint.ValidateSSL},
+	}
+	targetConn, targetResp, err := dialer.DialContext(ctx, targetURL.String(), header)
 	if err != nil {
-		logger.Errorf(ctx, "Failed to connect to target WebSocket %s: %v", targetURL.String(), err)
-		conn.WriteMessage(websocket.TextMessage, []byte("Error: Failed to connect to target WebSocket"))
+		status := 0
+		if targetResp != nil {
+			status = targetResp.StatusCode
+		}
+		logger.Errorf(ctx, "Failed to connect to target WebSocket %s (status %d): %v", targetURL.Redacted(), status, err)
+		c.String(http.StatusBadGateway, "Failed to connect to target WebSocket")
 		return
 	}
 	defer targetConn.Close()
 
-	// Create a context with cancel for goroutines
-	ctxWithCancel, cancel := context.WithCancel(ctx)
-	defer cancel()
+	// Upgrade the client connection, agreeing on the subprotocol the upstream selected
+	var upgradeHeader http.Header
+	if sub := targetConn.Subprotocol(); sub != "" {
+		upgradeHeader = http.Header{"Sec-Websocket-Protocol": {sub}}
+	}
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, upgradeHeader)
+	if err != nil {
+		logger.Errorf(ctx, "Failed to upgrade to WebSocket: %v", err)
+		return
+	}
+
+	// Store the connection
+	h.addConnection(endpoint.ID, conn)
+
+	// Ensure connection is closed and removed when done
+	defer func() {
+		conn.Close()
+		h.removeConnection(endpoint.ID, conn)
+	}()
+
+	// Pump both ways; the first side to close closes the other one
+	done := make(chan struct{}, 2)
+	go func() {
+		h.proxyClientToServer(ctx, conn, targetConn, route, timeout)
+		done <- struct{}{}
+	}()
+	go func() {
+		h.proxyServerToClient(ctx, targetConn, conn, route, timeout)
+		done <- struct{}{}
+	}()
 
-	// Bidirectional communication
-	go h.proxyClientToServer(ctxWithCancel, conn, targetConn, route)
-	h.proxyServerToClient(ctxWithCancel, targetConn, conn, route)
+	<-done
+	conn.Close()
+	targetConn.Close()
+	<-done
 }
 
 // proxyClientToServer forwards messages from client to server
-func (h *webSocketHandler) proxyClientToServer(ctx context.Context, clientConn, serverConn *websocket.Conn, route *structs.ReadRoute) {
+func (h *webSocketHandler) proxyClientToServer(ctx context.Context, clientConn, serverConn *websocket.Conn, route *structs.ReadRoute, timeout time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -235,13 +258,13 @@ func (h *webSocketHandler) proxyClientToServer(ctx context.Context, clientConn,
 			// Read message from client
 			messageType, message, err := clientConn.ReadMessage()
 			if err != nil {
-				logger.Errorf(ctx, "Error reading from client: %v", err)
+				forwardClose(ctx, serverConn, err, timeout, "client")
 				return
 			}
 
 			// Apply input transformer if configured
-			if validator.IsNotEmpty(route.InputTransformerID) {
-				transformer, exists := h.transformerCache[convert.ToString(route.InputTransformerID)]
+			if id := transformerID(route.InputTransformerID); id != "" {
+				transformer, exists := h.transformerCache[id]
 				if exists {
 					transformedMessage, err := transformer(message)
 					if err != nil {
@@ -253,6 +276,7 @@ func (h *webSocketHandler) proxyClientToServer(ctx context.Context, clientConn,
 			}
 
 			// Send message to server
+			_ = serverConn.SetWriteDeadline(time.Now().Add(timeout))
 			if err := serverConn.WriteMessage(messageType, message); err != nil {
 				logger.Errorf(ctx, "Error writing to server: %v", err)
 				return
@@ -262,7 +286,7 @@ func (h *webSocketHandler) proxyClientToServer(ctx context.Context, clientConn,
 }
 
 // proxyServerToClient forwards messages from server to client
-func (h *webSocketHandler) proxyServerToClient(ctx context.Context, serverConn, clientConn *websocket.Conn, route *structs.ReadRoute) {
+func (h *webSocketHandler) proxyServerToClient(ctx context.Context, serverConn, clientConn *websocket.Conn, route *structs.ReadRoute, timeout time.Duration) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -271,13 +295,13 @@ func (h *webSocketHandler) proxyServerToClient(ctx context.Context, serverConn,
 			// Read message from server
 			messageType, message, err := serverConn.ReadMessage()
 			if err != nil {
-				logger.Errorf(ctx, "Error reading from server: %v", err)
+				forwardClose(ctx, clientConn, err, timeout, "server")
 				return
 			}
 
 			// Apply output transformer if configured
-			if validator.IsNotEmpty(route.OutputTransformerID) {
-				transformer, exists := h.transformerCache[convert.ToString(route.OutputTransformerID)]
+			if id := transformerID(route.OutputTransformerID); id != "" {
+				transformer, exists := h.transformerCache[id]
 				if exists {
 					transformedMessage, err := transformer(message)
 					if err != nil {
@@ -289,6 +313,7 @@ func (h *webSocketHandler) proxyServerToClient(ctx context.Context, serverConn,
 			}
 
 			// Send message to client
+			_ = clientConn.SetWriteDeadline(time.Now().Add(timeout))
 			if err := clientConn.WriteMessage(messageType, message); err != nil {
 				logger.Errorf(ctx, "Error writing to client: %v", err)
 				return
@@ -297,8 +322,21 @@ func (h *webSocketHandler) proxyServerToClient(ctx context.Context, serverConn,
 	}
 }
 
+// addConnection adds a connection to the active connections list
+func (h *webSocketHandler) addConnection(endpointID string, conn *websocket.Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	connections, _ := h.activeConnections.Load(endpointID)
+	connectionsList, _ := connections.([]*websocket.Conn)
+	h.activeConnections.Store(endpointID, append(connectionsList, conn))
+}
+
 // removeConnection removes a connection from the active connections list
 func (h *webSocketHandler) removeConnection(endpointID string, conn *websocket.Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	connectionsObj, exists := h.activeConnections.Load(endpointID)
 	if !exists {
 		return
@@ -307,10 +345,48 @@ func (h *webSocketHandler) removeConnection(endpointID string, conn *websocket.C
 	connections := connectionsObj.([]*websocket.Conn)
 	for i, c := range connections {
 		if c == conn {
-			// Remove the connection from the slice
-			connections = append(connections[:i], connections[i+1:]...)
-			h.activeConnections.Store(endpointID, connections)
+			// Remove the connection from a copy, the old slice may still be read
+			remaining := make([]*websocket.Conn, 0, len(connections)-1)
+			remaining = append(remaining, connections[:i]...)
+			remaining = append(remaining, connections[i+1:]...)
+			h.activeConnections.Store(endpointID, remaining)
 			return
 		}
 	}
 }
+
+// CloseAll closes every proxied client connection with going away, e.g. when the server
+// drains. The pumps see the close and close the upstream side in turn.
+func (h *webSocketHandler) CloseAll() {
+	h.mu.Lock()
+	var conns []*websocket.Conn
+	h.activeConnections.Range(func(_, value any) bool {
+		list, _ := value.([]*websocket.Conn)
+		conns = append(conns, list...)
+		return true
+	})
+	h.mu.Unlock()
+
+	msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+	for _, conn := range conns {
+		_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+		_ = conn.Close()
+	}
+}
+
+// forwardClose passes the close of one side on to the other side, with its close code when
+// the side closed cleanly and going away otherwise
+func forwardClose(ctx context.Context, to *websocket.Conn, err error, timeout time.Duration, from string) {
+	code, text := websocket.CloseGoingAway, ""
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) {
+		code, text = closeErr.Code, closeErr.Text
+	} else if !errors.Is(err, net.ErrClosed) {
+		logger.Warnf(ctx, "Error reading from %s: %v", from, err)
+	}
+	// No status is not a valid code to send, answer it with a normal close
+	if code == websocket.CloseNoStatusReceived || code == websocket.CloseAbnormalClosure {
+		code = websocket.CloseNormalClosure
+	}
+	_ = to.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(timeout))
+}
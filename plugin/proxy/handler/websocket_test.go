@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// echoUpstream echoes WebSocket frames until the client closes, reporting the close code
+type echoUpstream struct {
+	*httptest.Server
+	header chan http.Header
+	closed chan int
+}
+
+func newEchoUpstream(t *testing.T) *echoUpstream {
+	t.Helper()
+	u := &echoUpstream{header: make(chan http.Header, 1), closed: make(chan int, 1)}
+	upgrader := websocket.Upgrader{Subprotocols: []string{"chat"}}
+	u.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u.header <- r.Header.Clone()
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			messageType, message, err := conn.ReadMessage()
+			if err != nil {
+				var closeErr *websocket.CloseError
+				if errors.As(err, &closeErr) {
+					u.closed <- closeErr.Code
+				}
+				return
+			}
+			if string(message) == "bye" {
+				_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(4001, "done"), time.Now().Add(time.Second))
+				return
+			}
+			if err := conn.WriteMessage(messageType, message); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(u.Close)
+	return u
+}
+
+// newWebSocketProxy serves the WebSocket proxy of the fixture under /ws
+func newWebSocketProxy(t *testing.T, f *proxyFixture) (*webSocketHandler, string) {
+	t.Helper()
+	f.endpoint.Protocol = "WS"
+	h := NewWebSocketHandler(f.svc).(*webSocketHandler)
+	f.engine.GET("/ws/*path", h.HandleWebSocket)
+	server := httptest.NewServer(f.engine)
+	t.Cleanup(server.Close)
+	return h, "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+}
+
+// activeCount counts the client connections the handler tracks
+func (h *webSocketHandler) activeCount() int {
+	n := 0
+	h.activeConnections.Range(func(_, value any) bool {
+		list, _ := value.([]*websocket.Conn)
+		n += len(list)
+		return true
+	})
+	return n
+}
+
+func TestWebSocketProxyEchoesFrames(t *testing.T) {
+	upstream := newEchoUpstream(t)
+	f := newProxyFixture(t, upstream.URL, nil)
+	token := `{"token":"t1"}`
+	f.endpoint.AuthType, f.endpoint.AuthConfig = "Bearer", &token
+	h, base := newWebSocketProxy(t, f)
+
+	dialer := websocket.Dialer{Subprotocols: []string{"chat"}}
+	conn, _, err := dialer.Dial(base+"/chat?room=1", http.Header{"X-Client": {"c1"}})
+	if err != nil {
+		t.Fatalf("dial proxy: %v", err)
+	}
+	defer conn.Close()
+
+	header := <-upstream.header
+	if header.Get("Authorization") != "Bearer t1" || header.Get("X-Client") != "c1" {
+		t.Fatalf("upstream handshake headers %v, want the endpoint auth and client headers", header)
+	}
+	if conn.Subprotocol() != "chat" {
+		t.Fatalf("subprotocol %q, want the one the upstream selected", conn.Subprotocol())
+	}
+
+	frames := []struct {
+		messageType int
+		data        string
+	}{
+		{websocket.TextMessage, "hello"},
+		{websocket.BinaryMessage, "\x00\x01\x02"},
+		{websocket.TextMessage, `{"n":3}`},
+	}
+	for _, frame := range frames {
+		if err := conn.WriteMessage(frame.messageType, []byte(frame.data)); err != nil {
+			t.Fatalf("write %q: %v", frame.data, err)
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		messageType, data, err := conn.ReadMessage()
+		if err != nil || messageType != frame.messageType || string(data) != frame.data {
+			t.Fatalf("echo of %q = %d %q, %v", frame.data, messageType, data, err)
+		}
+	}
+	if h.activeCount() != 1 {
+		t.Fatalf("%d active connections, want 1", h.activeCount())
+	}
+
+	// the client closing is passed to the upstream
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	select {
+	case code := <-upstream.closed:
+		if code != websocket.CloseNormalClosure {
+			t.Fatalf("upstream close code %d", code)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("client close not passed to the upstream")
+	}
+	waitActive(t, h, 0)
+}
+
+func TestWebSocketProxyPassesUpstreamClose(t *testing.T) {
+	upstream := newEchoUpstream(t)
+	f := newProxyFixture(t, upstream.URL, nil)
+	h, base := newWebSocketProxy(t, f)
+
+	conn, _, err := websocket.DefaultDialer.Dial(base+"/chat", nil)
+	if err != nil {
+		t.Fatalf("dial proxy: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("bye")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != 4001 || closeErr.Text != "done" {
+		t.Fatalf("read after the upstream closed: %v, want its close frame", err)
+	}
+	waitActive(t, h, 0)
+}
+
+func TestWebSocketProxyRejectsUnusableUpstreams(t *testing.T) {
+	upstream := newEchoUpstream(t)
+	f := newProxyFixture(t, upstream.URL, nil)
+	_, base := newWebSocketProxy(t, f)
+
+	// an unreachable upstream is answered before the client is upgraded
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	f.endpoint.BaseURL = closed.URL
+	_, res, err := websocket.DefaultDialer.Dial(base+"/chat", nil)
+	if err == nil || res == nil || res.StatusCode != http.StatusBadGateway {
+		t.Fatalf("unreachable upstream: %v, want 502", err)
+	}
+
+	// HTTP endpoints are not proxied as WebSockets
+	f.endpoint.BaseURL, f.endpoint.Protocol = upstream.URL, "HTTP"
+	_, res, err = websocket.DefaultDialer.Dial(base+"/chat", nil)
+	if err == nil || res == nil || res.StatusCode != http.StatusBadRequest {
+		t.Fatalf("HTTP endpoint: %v, want 400", err)
+	}
+}
+
+// waitActive waits for the handler to track n connections
+func waitActive(t *testing.T, h *webSocketHandler, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for h.activeCount() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d active connections, want %d", h.activeCount(), n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCloseAllClosesProxiedConnections(t *testing.T) {
+	upstream := newEchoUpstream(t)
+	f := newProxyFixture(t, upstream.URL, nil)
+	h, base := newWebSocketProxy(t, f)
+
+	conn, _, err := websocket.DefaultDialer.Dial(base+"/chat", nil)
+	if err != nil {
+		t.Fatalf("dial proxy: %v", err)
+	}
+	defer conn.Close()
+	waitActive(t, h, 1)
+
+	h.CloseAll()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseGoingAway {
+		t.Fatalf("read after CloseAll: %v, want going away", err)
+	}
+
+	// the upstream side is closed in turn
+	select {
+	case <-upstream.closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("upstream connection left open")
+	}
+	waitActive(t, h, 0)
+}
@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"fmt"
+	"ncobase/internal/drain"
 	"ncobase/plugin/proxy/data"
 	"ncobase/plugin/proxy/event"
 	"ncobase/plugin/proxy/handler"
@@ -40,6 +41,7 @@ type Plugin struct {
 	s           *service.Service
 	d           *data.Data
 	cleanup     func(name ...string)
+	stopDrain   func() // unregisters the drain hook closing WebSocket connections
 
 	// Internal services
 	userService   *userService.Service
@@ -150,6 +152,9 @@ func (p *Plugin) PostInit() error {
 
 	p.h = handler.New(p.s)
 
+	// Proxied WebSocket connections are hijacked, close them when the server drains
+	p.stopDrain = drain.OnDrain(p.h.WebSocket.CloseAll)
+
 	// Register event handlers
 	p.registerEventHandlers()
 
@@ -195,6 +200,9 @@ func (p *Plugin) RegisterRoutes(r *gin.RouterGroup) {
 	proxyGroup.GET("/endpoints/:id", p.h.Endpoint.Get)
 	proxyGroup.PUT("/endpoints/:id", p.h.Endpoint.Update)
 	proxyGroup.DELETE("/endpoints/:id", p.h.Endpoint.Delete)
+	proxyGroup.GET("/endpoints/:id/metrics", p.h.Endpoint.Metrics)
+	proxyGroup.GET("/endpoints/:id/breaker", p.h.Endpoint.Breaker)
+	proxyGroup.POST("/endpoints/:id/breaker/reset", p.h.Endpoint.ResetBreaker)
 
 	// Proxy route management
 	proxyGroup.GET("/routes", p.h.Route.List)
@@ -231,6 +239,12 @@ func (p *Plugin) GetServices() ext.Service {
 
 // Cleanup cleans up the plugin
 func (p *Plugin) Cleanup() error {
+	if p.stopDrain != nil {
+		p.stopDrain()
+	}
+	if p.h != nil {
+		p.h.WebSocket.CloseAll()
+	}
 	if p.cleanup != nil {
 		p.cleanup(p.Name())
 	}
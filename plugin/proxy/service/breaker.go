@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ncobase/plugin/proxy/data"
+	"ncobase/plugin/proxy/structs"
+	"sync"
+	"time"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/redis/go-redis/v9"
+	"github.com/sony/gobreaker"
+)
+
+const (
+	// breakerKey holds the open state of the breaker of an endpoint until its timeout
+	breakerKey = "ncse_proxy:breaker:%s"
+	// breakerTimeout is how long a breaker stays open before letting a trial call through
+	breakerTimeout = 30 * time.Second
+)
+
+// BreakerServiceInterface runs proxied calls through the circuit breakers of endpoints
+type BreakerServiceInterface interface {
+	Register(ctx context.Context, endpoint *structs.ReadEndpoint)
+	Execute(ctx context.Context, endpoint *structs.ReadEndpoint, fn func() (any, error)) (any, error)
+	State(ctx context.Context, endpoint *structs.ReadEndpoint) *structs.BreakerState
+	Reset(ctx context.Context, endpoint *structs.ReadEndpoint) *structs.BreakerState
+	OnStateChange(fn func(endpointID string, from, to gobreaker.State))
+}
+
+// breaker is the circuit breaker of one endpoint
+type breaker struct {
+	cb *gobreaker.CircuitBreaker
+	// restoredUntil keeps a breaker found open at startup open until its original timeout,
+	// a fresh gobreaker cannot be created open
+	restoredUntil time.Time
+
+	// mu guards the trip details, written by the gobreaker callbacks
+	mu       sync.Mutex
+	trip     gobreaker.Counts
+	openedAt time.Time
+}
+
+// breakerService keeps one breaker per endpoint in memory. An open breaker is also stored
+// in Redis until its timeout, so a restart does not send calls to an upstream known to fail.
+type breakerService struct {
+	rc *redis.Client
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+	onChange func(endpointID string, from, to gobreaker.State)
+}
+
+// NewBreakerService creates a new breaker service
+func NewBreakerService(d *data.Data) BreakerServiceInterface {
+	rc, _ := d.GetRedis().(*redis.Client)
+	return &breakerService{rc: rc, breakers: make(map[string]*breaker)}
+}
+
+// OnStateChange sets the function called when a breaker changes state, including resets.
+// It is set once before calls are proxied.
+func (s *breakerService) OnStateChange(fn func(endpointID string, from, to gobreaker.State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = fn
+}
+
+// Register creates the breaker of an endpoint, restoring an open state stored before a restart
+func (s *breakerService) Register(ctx context.Context, endpoint *structs.ReadEndpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.get(ctx, endpoint)
+}
+
+// Execute runs fn through the breaker of the endpoint
+func (s *breakerService) Execute(ctx context.Context, endpoint *structs.ReadEndpoint, fn func() (any, error)) (any, error) {
+	s.mu.Lock()
+	b := s.get(ctx, endpoint)
+	restored := time.Now().Before(b.restoredUntil)
+	s.mu.Unlock()
+
+	if restored {
+		return nil, gobreaker.ErrOpenState
+	}
+	return b.cb.Execute(fn)
+}
+
+// State returns the state and counts of the breaker of an endpoint
+func (s *breakerService) State(ctx context.Context, endpoint *structs.ReadEndpoint) *structs.BreakerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.get(ctx, endpoint)
+	current := b.cb.State()
+	counts := b.cb.Counts()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state := &structs.BreakerState{
+		EndpointID:           endpoint.ID,
+		State:                current.String(),
+		Requests:             counts.Requests,
+		TotalFailures:        counts.TotalFailures,
+		ConsecutiveFailures:  counts.ConsecutiveFailures,
+		ConsecutiveSuccesses: counts.ConsecutiveSuccesses,
+		TripFailures:         b.trip.TotalFailures,
+		TripRequests:         b.trip.Requests,
+	}
+	if !b.openedAt.IsZero() {
+		state.OpenedAt = b.openedAt.UnixMilli()
+	}
+
+	switch {
+	case time.Now().Before(b.restoredUntil):
+		state.State = gobreaker.StateOpen.String()
+		state.OpenUntil = b.restoredUntil.UnixMilli()
+	case current == gobreaker.StateOpen:
+		state.OpenUntil = b.openedAt.Add(breakerTimeout).UnixMilli()
+	}
+	return state
+}
+
+// Reset closes the breaker of an endpoint, dropping its counts and stored open state
+func (s *breakerService) Reset(ctx context.Context, endpoint *structs.ReadEndpoint) *structs.BreakerState {
+	s.mu.Lock()
+	from := gobreaker.StateClosed
+	if b, ok := s.breakers[endpoint.ID]; ok {
+		from = b.cb.State()
+		if time.Now().Before(b.restoredUntil) {
+			from = gobreaker.StateOpen
+		}
+	}
+	s.breakers[endpoint.ID] = s.newBreaker(endpoint)
+	onChange := s.onChange
+	s.mu.Unlock()
+
+	s.forget(ctx, endpoint.ID)
+	logger.Infof(ctx, "Circuit breaker of endpoint %s reset from %s", endpoint.ID, from)
+	if onChange != nil && from != gobreaker.StateClosed {
+		onChange(endpoint.ID, from, gobreaker.StateClosed)
+	}
+
+	return s.State(ctx, endpoint)
+}
+
+// get returns the breaker of an endpoint, creating it on first use; the caller holds s.mu
+func (s *breakerService) get(ctx context.Context, endpoint *structs.ReadEndpoint) *breaker {
+	if b, ok := s.breakers[endpoint.ID]; ok {
+		return b
+	}
+
+	b := s.newBreaker(endpoint)
+	if stored := s.load(ctx, endpoint.ID); stored != nil && stored.OpenUntil > time.Now().UnixMilli() {
+		b.restoredUntil = time.UnixMilli(stored.OpenUntil)
+		b.openedAt = time.UnixMilli(stored.OpenedAt)
+		b.trip = gobreaker.Counts{Requests: stored.TripRequests, TotalFailures: stored.TripFailures}
+		logger.Infof(ctx, "Circuit breaker of endpoint %s restored open until %s", endpoint.ID, b.restoredUntil.Format(time.RFC3339))
+	}
+	s.breakers[endpoint.ID] = b
+	return b
+}
+
+// newBreaker creates a closed breaker tripping when most of at least 3 calls fail
+func (s *breakerService) newBreaker(endpoint *structs.ReadEndpoint) *breaker {
+	b := &breaker{}
+	endpointID := endpoint.ID
+	b.cb = gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        endpoint.Name,
+		MaxRequests: 100,
+		Interval:    5 * time.Second,
+		Timeout:     breakerTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
+			if counts.Requests >= 3 && failureRatio >= 0.6 {
+				b.mu.Lock()
+				b.trip = counts
+				b.mu.Unlock()
+				return true
+			}
+			return false
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			ctx := context.Background()
+			logger.Infof(ctx, "Circuit breaker %s state changed from %s to %s", name, from, to)
+
+			if to == gobreaker.StateOpen {
+				b.mu.Lock()
+				b.openedAt = time.Now()
+				b.mu.Unlock()
+				s.store(ctx, endpointID, b)
+			} else if from == gobreaker.StateOpen {
+				s.forget(ctx, endpointID)
+			}
+
+			if s.onChange != nil {
+				s.onChange(endpointID, from, to)
+			}
+		},
+	})
+	return b
+}
+
+// load reads the stored open state of a breaker
+func (s *breakerService) load(ctx context.Context, endpointID string) *structs.BreakerState {
+	if s.rc == nil {
+		return nil
+	}
+	raw, err := s.rc.Get(ctx, fmt.Sprintf(breakerKey, endpointID)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			logger.Warnf(ctx, "Failed to read circuit breaker state of endpoint %s: %v", endpointID, err)
+		}
+		return nil
+	}
+	state := &structs.BreakerState{}
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil
+	}
+	return state
+}
+
+// store keeps the open state of a breaker until its timeout
+func (s *breakerService) store(ctx context.Context, endpointID string, b *breaker) {
+	if s.rc == nil {
+		return
+	}
+	b.mu.Lock()
+	state := &structs.BreakerState{
+		EndpointID:   endpointID,
+		State:        gobreaker.StateOpen.String(),
+		TripFailures: b.trip.TotalFailures,
+		TripRequests: b.trip.Requests,
+		OpenedAt:     b.openedAt.UnixMilli(),
+		OpenUntil:    b.openedAt.Add(breakerTimeout).UnixMilli(),
+	}
+	b.mu.Unlock()
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	if err := s.rc.Set(ctx, fmt.Sprintf(breakerKey, endpointID), raw, breakerTimeout).Err(); err != nil {
+		logger.Warnf(ctx, "Failed to store circuit breaker state of endpoint %s: %v", endpointID, err)
+	}
+}
+
+// forget drops the stored open state of a breaker
+func (s *breakerService) forget(ctx context.Context, endpointID string) {
+	if s.rc == nil {
+		return
+	}
+	if err := s.rc.Del(ctx, fmt.Sprintf(breakerKey, endpointID)).Err(); err != nil {
+		logger.Warnf(ctx, "Failed to drop circuit breaker state of endpoint %s: %v", endpointID, err)
+	}
+}
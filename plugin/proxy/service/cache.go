@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ncobase/plugin/proxy/data"
+	"ncobase/plugin/proxy/structs"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// responseCacheKey holds one cached response
+	responseCacheKey = "ncse_proxy:response_cache:%s"
+	// responseCachePathKey holds the cached responses of one upstream path, to invalidate them on writes
+	responseCachePathKey = "ncse_proxy:response_cache_path:%s:%s"
+)
+
+// cacheKeyHeaders are the request headers that change the upstream response,
+// cached responses are only shared between requests agreeing on them
+var cacheKeyHeaders = []string{"Accept", "Accept-Encoding", "Accept-Language", "Authorization", "Cookie"}
+
+// ResponseCacheServiceInterface caches upstream responses of proxied GETs in Redis
+type ResponseCacheServiceInterface interface {
+	Key(endpointID, targetPath, rawQuery string, header http.Header) string
+	Get(ctx context.Context, key string) (*structs.CachedResponse, error)
+	Set(ctx context.Context, key, endpointID, targetPath string, res *structs.CachedResponse, ttl time.Duration) error
+	Invalidate(ctx context.Context, endpointID, targetPath string) error
+}
+
+// responseCacheService keeps responses in Redis so every instance serves them.
+// Without Redis nothing is cached.
+type responseCacheService struct {
+	rc *redis.Client
+}
+
+// NewResponseCacheService creates a new response cache service
+func NewResponseCacheService(d *data.Data) ResponseCacheServiceInterface {
+	rc, _ := d.GetRedis().(*redis.Client)
+	return &responseCacheService{rc: rc}
+}
+
+// Key returns the cache key of a GET to an upstream path, from its query and the headers in cacheKeyHeaders
+func (s *responseCacheService) Key(endpointID, targetPath, rawQuery string, header http.Header) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s\n", endpointID, http.MethodGet, targetPath, rawQuery)
+	for _, name := range cacheKeyHeaders {
+		fmt.Fprintf(h, "%s:%s\n", name, strings.Join(header.Values(name), ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns the cached response for key, nil when nothing is cached
+func (s *responseCacheService) Get(ctx context.Context, key string) (*structs.CachedResponse, error) {
+	if s.rc == nil {
+		return nil, nil
+	}
+
+	raw, err := s.rc.Get(ctx, fmt.Sprintf(responseCacheKey, key)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &structs.CachedResponse{}
+	if err := json.Unmarshal(raw, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Set caches a response for ttl and indexes it under its upstream path
+func (s *responseCacheService) Set(ctx context.Context, key, endpointID, targetPath string, res *structs.CachedResponse, ttl time.Duration) error {
+	if s.rc == nil || ttl <= 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	pathKey := pathIndexKey(endpointID, targetPath)
+	pipe := s.rc.TxPipeline()
+	pipe.Set(ctx, fmt.Sprintf(responseCacheKey, key), raw, ttl)
+	pipe.SAdd(ctx, pathKey, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	// The index outlives every entry it lists, stale members are dropped with it
+	if current, err := s.rc.TTL(ctx, pathKey).Result(); err == nil && current < ttl {
+		return s.rc.Expire(ctx, pathKey, ttl).Err()
+	}
+	return nil
+}
+
+// Invalidate drops the cached responses of an upstream path and of its parent collection,
+// so a write to /items/1 refreshes both /items/1 and /items
+func (s *responseCacheService) Invalidate(ctx context.Context, endpointID, targetPath string) error {
+	if s.rc == nil {
+		return nil
+	}
+
+	paths := []string{targetPath}
+	if parent := path.Dir(strings.TrimSuffix(targetPath, "/")); parent != targetPath && parent != "." {
+		paths = append(paths, parent)
+	}
+
+	for _, p := range paths {
+		pathKey := pathIndexKey(endpointID, p)
+		keys, err := s.rc.SMembers(ctx, pathKey).Result()
+		if err != nil {
+			return err
+		}
+		del := make([]string, 0, len(keys)+1)
+		for _, key := range keys {
+			del = append(del, fmt.Sprintf(responseCacheKey, key))
+		}
+		del = append(del, pathKey)
+		if err := s.rc.Del(ctx, del...).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pathIndexKey returns the index key of an upstream path
+func pathIndexKey(endpointID, targetPath string) string {
+	sum := sha256.Sum256([]byte(targetPath))
+	return fmt.Sprintf(responseCachePathKey, endpointID, hex.EncodeToString(sum[:]))
+}
+
+// ResponseCacheTTL returns how long an upstream response may be cached, at most routeTTL seconds.
+// Only 200 responses without cookies are cached; Cache-Control no-store, no-cache and private
+// forbid caching, and a shorter max-age or s-maxage wins over the route TTL.
+func ResponseCacheTTL(routeTTL, statusCode int, header http.Header) time.Duration {
+	if statusCode != http.StatusOK || routeTTL <= 0 || header.Get("Set-Cookie") != "" {
+		return 0
+	}
+
+	ttl := time.Duration(routeTTL) * time.Second
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(strings.ToLower(directive)), "=")
+		switch name {
+		case "no-store", "no-cache", "private":
+			return 0
+		case "max-age", "s-maxage":
+			seconds, err := strconv.Atoi(strings.Trim(value, `"`))
+			if err != nil {
+				continue
+			}
+			if maxAge := time.Duration(seconds) * time.Second; maxAge < ttl {
+				ttl = maxAge
+			}
+		}
+	}
+	return ttl
+}
@@ -42,6 +42,12 @@ func (s *endpointService) Create(ctx context.Context, body *structs.CreateEndpoi
 		return nil, errors.New("endpoint name is required")
 	}
 
+	if body.ResponseSchema != "" {
+		if _, err := CompileResponseSchema(body.ResponseSchema); err != nil {
+			return nil, err
+		}
+	}
+
 	row, err := s.endpoint.Create(ctx, body)
 	if err := handleEntError(ctx, "Endpoint", err); err != nil {
 		return nil, err
@@ -61,6 +67,13 @@ func (s *endpointService) Update(ctx context.Context, id string, updates types.J
 		return nil, errors.New(ecode.FieldIsEmpty("updates fields"))
 	}
 
+	// A schema that does not compile would silently disable validation
+	if schema, ok := updates["response_schema"].(string); ok && schema != "" {
+		if _, err := CompileResponseSchema(schema); err != nil {
+			return nil, err
+		}
+	}
+
 	row, err := s.endpoint.Update(ctx, id, updates)
 	if err := handleEntError(ctx, "Endpoint", err); err != nil {
 		return nil, err
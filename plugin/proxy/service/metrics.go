@@ -0,0 +1,135 @@
+package service
+
+import (
+	"math"
+	"ncobase/internal/metrics"
+	"ncobase/plugin/proxy/structs"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencyWindow is the number of recent calls per endpoint the latency percentiles are computed from
+const latencyWindow = 1024
+
+var (
+	proxyRequests = metrics.Default.CounterVec("proxy_requests_total",
+		"Total proxied calls by endpoint and outcome.", "endpoint", "outcome")
+	proxyDuration = metrics.Default.HistogramVec("proxy_request_duration_seconds",
+		"Proxied call latency in seconds by endpoint.", metrics.DefaultBuckets, "endpoint")
+)
+
+// MetricsServiceInterface collects call statistics per endpoint
+type MetricsServiceInterface interface {
+	Record(endpointID string, outcome structs.CallOutcome, duration time.Duration, err error)
+	Get(endpointID string) *structs.EndpointMetrics
+}
+
+// endpointStats are the counters and recent latencies of one endpoint
+type endpointStats struct {
+	requests       int64
+	upstreamErrors int64
+	proxyErrors    int64
+	rateLimited    int64
+	latencies      []float64 // ring buffer of the last latencyWindow calls, in milliseconds
+	next           int
+	lastError      *structs.EndpointError
+	since          int64
+}
+
+// metricsService keeps statistics in memory per instance and mirrors them
+// to the Prometheus registry, where instances are aggregated
+type metricsService struct {
+	mu    sync.Mutex
+	stats map[string]*endpointStats
+}
+
+// NewMetricsService creates a new metrics service
+func NewMetricsService() MetricsServiceInterface {
+	return &metricsService{stats: make(map[string]*endpointStats)}
+}
+
+// Record records a proxied call of an endpoint, err is kept as the last error of failed calls
+func (s *metricsService) Record(endpointID string, outcome structs.CallOutcome, duration time.Duration, err error) {
+	proxyRequests.Inc(endpointID, string(outcome))
+	if outcome != structs.CallRateLimited {
+		proxyDuration.Observe(duration.Seconds(), endpointID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.stats[endpointID]
+	if !ok {
+		st = &endpointStats{latencies: make([]float64, 0, latencyWindow), since: time.Now().UnixMilli()}
+		s.stats[endpointID] = st
+	}
+
+	st.requests++
+	switch outcome {
+	case structs.CallUpstreamError:
+		st.upstreamErrors++
+	case structs.CallProxyError:
+		st.proxyErrors++
+	case structs.CallRateLimited:
+		// Rejected before the upstream, neither an error nor a latency sample
+		st.rateLimited++
+		return
+	}
+	if outcome != structs.CallSuccess {
+		st.lastError = &structs.EndpointError{Outcome: outcome, At: time.Now().UnixMilli()}
+		if err != nil {
+			st.lastError.Message = err.Error()
+		}
+	}
+
+	ms := float64(duration) / float64(time.Millisecond)
+	if len(st.latencies) < latencyWindow {
+		st.latencies = append(st.latencies, ms)
+	} else {
+		st.latencies[st.next] = ms
+	}
+	st.next = (st.next + 1) % latencyWindow
+}
+
+// Get returns the statistics of an endpoint, zero for an endpoint without calls
+func (s *metricsService) Get(endpointID string) *structs.EndpointMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := &structs.EndpointMetrics{EndpointID: endpointID}
+	st, ok := s.stats[endpointID]
+	if !ok {
+		return m
+	}
+
+	m.Requests = st.requests
+	m.UpstreamErrors = st.upstreamErrors
+	m.ProxyErrors = st.proxyErrors
+	m.RateLimited = st.rateLimited
+	m.Errors = st.upstreamErrors + st.proxyErrors
+	if st.requests > 0 {
+		m.ErrorRate = float64(m.Errors) / float64(st.requests)
+	}
+	if st.lastError != nil {
+		lastError := *st.lastError
+		m.LastError = &lastError
+	}
+	m.Since = st.since
+
+	sorted := append([]float64(nil), st.latencies...)
+	sort.Float64s(sorted)
+	m.LatencySamples = len(sorted)
+	m.LatencyP50 = percentile(sorted, 0.50)
+	m.LatencyP95 = percentile(sorted, 0.95)
+	return m
+}
+
+// percentile returns the nearest-rank percentile p of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
@@ -11,6 +11,11 @@ type Service struct {
 	Transformer TransformerServiceInterface
 	Log         LogServiceInterface
 	Processor   ProcessorServiceInterface
+	Metrics     MetricsServiceInterface
+	Cache       ResponseCacheServiceInterface
+	Breaker     BreakerServiceInterface
+	RateLimit   RateLimitServiceInterface
+	Schema      SchemaServiceInterface
 }
 
 // New creates a new service.
@@ -24,5 +29,10 @@ func New(d *data.Data) *Service {
 		Transformer: NewTransformerService(d),
 		Log:         NewLogService(d),
 		Processor:   processorSvc,
+		Metrics:     NewMetricsService(),
+		Cache:       NewResponseCacheService(d),
+		Breaker:     NewBreakerService(d),
+		RateLimit:   NewRateLimitService(d),
+		Schema:      NewSchemaService(),
 	}
 }
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"ncobase/plugin/proxy/data"
+	"ncobase/plugin/proxy/structs"
+	"sync"
+	"time"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// rateLimitKey holds the token bucket of an endpoint, shared by all instances
+const rateLimitKey = "ncse_proxy:rate_limit:%s"
+
+// rateLimitScript takes a token from the bucket of an endpoint, refilled at the rate per second
+// up to the burst. It returns 1 and 0 when a token was taken, otherwise 0 and the milliseconds
+// until the next token. Time comes from Redis so instances with skewed clocks share one bucket.
+var rateLimitScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed, wait = 0, 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, wait}
+`)
+
+// RateLimitServiceInterface limits the calls sent to the upstream of an endpoint
+type RateLimitServiceInterface interface {
+	Acquire(ctx context.Context, endpoint *structs.ReadEndpoint) error
+}
+
+// tokenBucket is the in-memory bucket of an endpoint, used without Redis
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// rateLimitService keeps the token bucket of each endpoint in Redis, so the limit holds across
+// instances. Without Redis, or while it fails, every instance applies the limit on its own.
+type rateLimitService struct {
+	rc *redis.Client
+
+	mu    sync.Mutex
+	local map[string]*tokenBucket
+}
+
+// NewRateLimitService creates a new rate limit service
+func NewRateLimitService(d *data.Data) RateLimitServiceInterface {
+	rc, _ := d.GetRedis().(*redis.Client)
+	return newRateLimitService(rc)
+}
+
+// newRateLimitService creates a rate limit service sharing its buckets through rc, if set
+func newRateLimitService(rc *redis.Client) *rateLimitService {
+	return &rateLimitService{rc: rc, local: make(map[string]*tokenBucket)}
+}
+
+// Acquire takes a call slot of the endpoint. A call over the limit waits up to the rate limit
+// wait of the endpoint for a slot, and gets a RateLimitedError when none frees up in time.
+func (s *rateLimitService) Acquire(ctx context.Context, endpoint *structs.ReadEndpoint) error {
+	if endpoint.RateLimitRPS <= 0 {
+		return nil
+	}
+	rate, burst := endpoint.RateLimitRPS, rateLimitBurst(endpoint)
+	deadline := time.Now().Add(time.Duration(endpoint.RateLimitWait) * time.Millisecond)
+
+	for {
+		allowed, retryAfter := s.take(ctx, endpoint.ID, rate, burst)
+		if allowed {
+			return nil
+		}
+		if time.Now().Add(retryAfter).After(deadline) {
+			return &structs.RateLimitedError{EndpointID: endpoint.ID, RetryAfter: retryAfter}
+		}
+
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// take takes a token from the bucket of an endpoint, or returns the time until the next one
+func (s *rateLimitService) take(ctx context.Context, endpointID string, rate float64, burst int) (bool, time.Duration) {
+	if s.rc != nil {
+		res, err := rateLimitScript.Run(ctx, s.rc, []string{fmt.Sprintf(rateLimitKey, endpointID)}, rate, burst).Int64Slice()
+		if err == nil && len(res) == 2 {
+			return res[0] == 1, time.Duration(res[1]) * time.Millisecond
+		}
+		logger.Warnf(ctx, "Failed to apply shared rate limit of endpoint %s, limiting locally: %v", endpointID, err)
+	}
+	return s.takeLocal(endpointID, rate, burst, time.Now())
+}
+
+// takeLocal takes a token from the in-memory bucket of an endpoint
+func (s *rateLimitService) takeLocal(endpointID string, rate float64, burst int, now time.Time) (bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.local[endpointID]
+	if !ok {
+		b = &tokenBucket{tokens: float64(burst), last: now}
+		s.local[endpointID] = b
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(burst), b.tokens+elapsed.Seconds()*rate)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration(math.Ceil((1 - b.tokens) / rate * float64(time.Second)))
+}
+
+// rateLimitBurst returns the burst of an endpoint, the rate rounded up when not set
+func rateLimitBurst(endpoint *structs.ReadEndpoint) int {
+	if endpoint.RateLimitBurst > 0 {
+		return endpoint.RateLimitBurst
+	}
+	return max(1, int(math.Ceil(endpoint.RateLimitRPS)))
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ncobase/plugin/proxy/structs"
+)
+
+func TestTakeLocalRefillsUpToBurst(t *testing.T) {
+	s := newRateLimitService(nil)
+	now := time.Unix(1700000000, 0)
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := s.takeLocal("e1", 2, 3, now); !ok {
+			t.Fatalf("call %d within the burst refused", i+1)
+		}
+	}
+	ok, wait := s.takeLocal("e1", 2, 3, now)
+	if ok || wait != 500*time.Millisecond {
+		t.Fatalf("call over the burst = %v, wait %s, want refused for 500ms", ok, wait)
+	}
+
+	// half a second refills one token at 2 per second
+	if ok, _ := s.takeLocal("e1", 2, 3, now.Add(500*time.Millisecond)); !ok {
+		t.Fatal("call after the refill refused")
+	}
+
+	// a long pause refills the bucket to the burst, not beyond
+	later := now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		if ok, _ := s.takeLocal("e1", 2, 3, later); !ok {
+			t.Fatalf("call %d after a pause refused", i+1)
+		}
+	}
+	if ok, _ := s.takeLocal("e1", 2, 3, later); ok {
+		t.Fatal("bucket refilled beyond its burst")
+	}
+
+	// endpoints have their own buckets
+	if ok, _ := s.takeLocal("e2", 2, 3, later); !ok {
+		t.Fatal("call of another endpoint refused")
+	}
+}
+
+func TestAcquire(t *testing.T) {
+	ctx := context.Background()
+	s := newRateLimitService(nil)
+
+	unlimited := &structs.ReadEndpoint{ID: "unlimited"}
+	for i := 0; i < 100; i++ {
+		if err := s.Acquire(ctx, unlimited); err != nil {
+			t.Fatalf("endpoint without a limit: %v", err)
+		}
+	}
+
+	endpoint := &structs.ReadEndpoint{ID: "e1", RateLimitRPS: 1, RateLimitBurst: 1}
+	if err := s.Acquire(ctx, endpoint); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	var limited *structs.RateLimitedError
+	if err := s.Acquire(ctx, endpoint); !errors.As(err, &limited) || limited.RetryAfter <= 0 {
+		t.Fatalf("call over the limit = %v, want a RateLimitedError with a retry delay", err)
+	}
+
+	// with room to wait, a call over the limit gets the next token
+	fast := &structs.ReadEndpoint{ID: "e2", RateLimitRPS: 50, RateLimitBurst: 1, RateLimitWait: 1000}
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := s.Acquire(ctx, fast); err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+	}
+	if waited := time.Since(start); waited < 10*time.Millisecond {
+		t.Fatalf("second call waited %s, want about 20ms", waited)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	slow := &structs.ReadEndpoint{ID: "e3", RateLimitRPS: 1, RateLimitBurst: 1, RateLimitWait: 5000}
+	_ = s.Acquire(cancelled, slow)
+	if err := s.Acquire(cancelled, slow); !errors.Is(err, context.Canceled) {
+		t.Fatalf("waiting call with a cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestRateLimitBurst(t *testing.T) {
+	for _, tc := range []struct {
+		endpoint structs.ReadEndpoint
+		want     int
+	}{
+		{structs.ReadEndpoint{RateLimitRPS: 10, RateLimitBurst: 3}, 3},
+		{structs.ReadEndpoint{RateLimitRPS: 2.5}, 3},
+		{structs.ReadEndpoint{RateLimitRPS: 0.2}, 1},
+	} {
+		if got := rateLimitBurst(&tc.endpoint); got != tc.want {
+			t.Errorf("burst of %+v = %d, want %d", tc.endpoint, got, tc.want)
+		}
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"ncobase/plugin/proxy/data"
 	"ncobase/plugin/proxy/data/repository"
 	"ncobase/plugin/proxy/structs"
+	"net/url"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/ncobase/ncore/data/paging"
@@ -26,6 +28,7 @@ type RouteServiceInterface interface {
 	GetByID(ctx context.Context, id string) (*structs.ReadRoute, error)
 	GetByName(ctx context.Context, name string) (*structs.ReadRoute, error)
 	FindByPathAndMethod(ctx context.Context, path, method string) (*structs.ReadRoute, error)
+	Resolve(ctx context.Context, path, method string) (*structs.RouteMatch, error)
 	List(ctx context.Context, params *structs.ListRouteParams) (paging.Result[*structs.ReadRoute], error)
 }
 
@@ -60,8 +63,12 @@ func (s *routeService) Create(ctx context.Context, body *structs.CreateRouteBody
 		return nil, err
 	}
 
-	// Normalize route method to uppercase
-	body.Method = strings.ToUpper(body.Method)
+	// Normalize the route method set
+	method, err := normalizeMethods(body.Method)
+	if err != nil {
+		return nil, err
+	}
+	body.Method = method
 
 	// Check if input transformer exists if specified
 	if body.InputTransformerID != nil && *body.InputTransformerID != "" {
@@ -99,9 +106,13 @@ func (s *routeService) Update(ctx context.Context, id string, updates types.JSON
 		}
 	}
 
-	// Normalize route method to uppercase if it's being updated
+	// Normalize the route method set if it's being updated
 	if method, ok := updates["method"].(string); ok {
-		updates["method"] = strings.ToUpper(method)
+		normalized, err := normalizeMethods(method)
+		if err != nil {
+			return nil, err
+		}
+		updates["method"] = normalized
 	}
 
 	row, err := s.route.Update(ctx, id, updates)
@@ -144,25 +155,72 @@ func (s *routeService) GetByName(ctx context.Context, name string) (*structs.Rea
 
 // FindByPathAndMethod finds a route by path and method.
 func (s *routeService) FindByPathAndMethod(ctx context.Context, path, method string) (*structs.ReadRoute, error) {
-	// Normalize method to uppercase
+	match, err := s.Resolve(ctx, path, method)
+	if err != nil {
+		return nil, err
+	}
+	return match.Route, nil
+}
+
+// Resolve resolves an incoming path and method to the most specific enabled route.
+// Routes with more literal segments win, then routes without a trailing wildcard.
+func (s *routeService) Resolve(ctx context.Context, path, method string) (*structs.RouteMatch, error) {
 	method = strings.ToUpper(method)
 
-	// First try to find an exact match for the method
-	routes, err := s.route.FindByPathPattern(ctx, path)
+	routes, err := s.route.ListEnabled(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("no route found for path %s: %w", path, err)
 	}
 
-	// Check for method match
-	for _, route := range routes {
-		routeMethod := strings.ToUpper(route.Method)
-		if routeMethod == method || routeMethod == "ANY" || routeMethod == "*" {
-			return repository.SerializeRoute(route), nil
+	var (
+		best         *structs.RouteMatch
+		bestLiterals int
+		bestWildcard bool
+		pathMatched  bool
+	)
+	for _, row := range routes {
+		params, literals, ok := matchRoutePattern(row.PathPattern, path)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if !methodAllowed(row.Method, method) {
+			continue
+		}
+
+		_, wildcard := params["*"]
+		better := best == nil ||
+			literals > bestLiterals ||
+			literals == bestLiterals && !wildcard && bestWildcard ||
+			literals == bestLiterals && wildcard == bestWildcard && row.PathPattern < best.Route.PathPattern
+		if !better {
+			continue
 		}
+
+		route := repository.SerializeRoute(row)
+		best = &structs.RouteMatch{
+			Route:      route,
+			Params:     params,
+			TargetPath: rewriteTargetPath(route.TargetPath, path, params),
+		}
+		bestLiterals, bestWildcard = literals, wildcard
 	}
 
-	// If we get here, no route matched the method
-	return nil, fmt.Errorf("no route found for path %s and method %s", path, method)
+	if best != nil {
+		// The parameters must not move the rewritten path out of the target path of the route
+		target, err := confinePath(best.TargetPath, targetPrefix(best.Route.TargetPath))
+		if err != nil {
+			return nil, fmt.Errorf("route %s for path %s: %w", best.Route.ID, path, err)
+		}
+		best.TargetPath = target
+	}
+	if best == nil {
+		if pathMatched {
+			return nil, fmt.Errorf("no route found for path %s and method %s", path, method)
+		}
+		return nil, fmt.Errorf("no route found for path %s", path)
+	}
+	return best, nil
 }
 
 // List lists all routes.
@@ -231,3 +289,173 @@ func validatePathPattern(pathPattern string) error {
 
 	return nil
 }
+
+// routeMethods are the HTTP methods a route may be registered for
+var routeMethods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"PATCH":   true,
+	"DELETE":  true,
+	"HEAD":    true,
+	"OPTIONS": true,
+}
+
+// normalizeMethods normalizes a comma separated method set to sorted, unique upper case methods.
+// ANY or * anywhere in the set matches every method and is stored as ANY.
+func normalizeMethods(method string) (string, error) {
+	seen := make(map[string]bool)
+	var methods []string
+	for _, m := range strings.Split(method, ",") {
+		m = strings.ToUpper(strings.TrimSpace(m))
+		switch {
+		case m == "":
+			continue
+		case m == "ANY" || m == "*":
+			return "ANY", nil
+		case !routeMethods[m]:
+			return "", fmt.Errorf("invalid route method: %s", m)
+		case !seen[m]:
+			seen[m] = true
+			methods = append(methods, m)
+		}
+	}
+	if len(methods) == 0 {
+		return "", errors.New(ecode.FieldIsRequired("method"))
+	}
+	sort.Strings(methods)
+	return strings.Join(methods, ","), nil
+}
+
+// methodAllowed reports whether a route method set allows the method
+func methodAllowed(routeMethod, method string) bool {
+	for _, m := range strings.Split(routeMethod, ",") {
+		m = strings.ToUpper(strings.TrimSpace(m))
+		if m == method || m == "ANY" || m == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPath splits a path into its segments, the root path has none
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// matchRoutePattern matches a path against a route pattern. A :name segment captures one path
+// segment, a trailing * captures the rest of the path under "*", other * segments match any segment.
+// It returns the captured parameters and the number of literal segments matched.
+func matchRoutePattern(pattern, requestPath string) (map[string]string, int, bool) {
+	patternSegments := splitPath(pattern)
+	pathSegments := splitPath(requestPath)
+	params := make(map[string]string)
+	literals := 0
+
+	for i, seg := range patternSegments {
+		if seg == "*" && i == len(patternSegments)-1 {
+			rest := pathSegments[min(i, len(pathSegments)):]
+			for _, captured := range rest {
+				if isDotSegment(captured) {
+					return nil, 0, false
+				}
+			}
+			params["*"] = strings.Join(rest, "/")
+			return params, literals, true
+		}
+		if i >= len(pathSegments) {
+			return nil, 0, false
+		}
+		switch {
+		case strings.HasPrefix(seg, ":"):
+			if isDotSegment(pathSegments[i]) {
+				return nil, 0, false
+			}
+			params[seg[1:]] = pathSegments[i]
+		case seg == "*":
+			if isDotSegment(pathSegments[i]) {
+				return nil, 0, false
+			}
+		case seg == pathSegments[i]:
+			literals++
+		default:
+			return nil, 0, false
+		}
+	}
+
+	if len(pathSegments) != len(patternSegments) {
+		return nil, 0, false
+	}
+	return params, literals, true
+}
+
+// isDotSegment reports whether a path segment is, or decodes to, a "." or ".." segment,
+// including the ones hidden behind an encoded slash or backslash
+func isDotSegment(seg string) bool {
+	decoded, err := url.PathUnescape(seg)
+	if err != nil {
+		return true
+	}
+	for _, s := range []string{seg, decoded} {
+		for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '/' || r == '\\' }) {
+			if part == "." || part == ".." {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// targetPrefix returns the literal leading segments of a target path, the part parameters cannot change
+func targetPrefix(targetPath string) string {
+	var literals []string
+	for _, seg := range splitPath(targetPath) {
+		if seg == "*" || strings.HasPrefix(seg, ":") {
+			break
+		}
+		literals = append(literals, seg)
+	}
+	return "/" + strings.Join(literals, "/")
+}
+
+// confinePath cleans a rewritten path and checks it stays under prefix, keeping its trailing slash
+func confinePath(rewritten, prefix string) (string, error) {
+	cleaned := path.Clean("/" + rewritten)
+	if cleaned != prefix && !strings.HasPrefix(cleaned, strings.TrimSuffix(prefix, "/")+"/") {
+		return "", structs.ErrUnsafeRoutePath
+	}
+	if strings.HasSuffix(rewritten, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned, nil
+}
+
+// rewriteTargetPath fills the :name and * segments of a target path with the captured parameters.
+// Without a target path the request path is passed through unchanged.
+func rewriteTargetPath(targetPath, requestPath string, params map[string]string) string {
+	if targetPath == "" {
+		return requestPath
+	}
+
+	segments := strings.Split(targetPath, "/")
+	rewritten := segments[:0]
+	for i, seg := range segments {
+		switch {
+		case strings.HasPrefix(seg, ":"):
+			if value, ok := params[seg[1:]]; ok {
+				seg = value
+			}
+		case seg == "*":
+			seg = params["*"]
+			if seg == "" && i == len(segments)-1 {
+				continue
+			}
+		}
+		rewritten = append(rewritten, seg)
+	}
+	return strings.Join(rewritten, "/")
+}
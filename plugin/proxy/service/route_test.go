@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ncobase/plugin/proxy/data/ent"
+	"ncobase/plugin/proxy/data/repository"
+	"ncobase/plugin/proxy/structs"
+)
+
+// routeTable serves a fixed set of enabled routes
+type routeTable struct {
+	repository.RouteRepositoryInterface
+	routes []*ent.Route
+}
+
+func (r routeTable) ListEnabled(_ context.Context) ([]*ent.Route, error) {
+	return r.routes, nil
+}
+
+func TestResolvePicksMostSpecificRoute(t *testing.T) {
+	s := &routeService{route: routeTable{routes: []*ent.Route{
+		{ID: "catch-all", EndpointID: "legacy", PathPattern: "/api/*", Method: "ANY"},
+		{ID: "users", EndpointID: "accounts", PathPattern: "/api/users/:id", TargetPath: "/v2/users/:id", Method: "GET,PUT"},
+		{ID: "me", EndpointID: "profile", PathPattern: "/api/users/me", TargetPath: "/me", Method: "GET"},
+		{ID: "files", EndpointID: "storage", PathPattern: "/api/files/*", TargetPath: "/objects/*", Method: "GET"},
+	}}}
+	ctx := context.Background()
+
+	tests := []struct {
+		path, method      string
+		route, target, id string
+	}{
+		{"/api/users/42", "get", "users", "/v2/users/42", "42"},
+		{"/api/users/42", "PUT", "users", "/v2/users/42", "42"},
+		{"/api/users/me", "GET", "me", "/me", ""},
+		{"/api/users/42", "DELETE", "catch-all", "/api/users/42", ""},
+		{"/api/files/a/b.png", "GET", "files", "/objects/a/b.png", ""},
+		{"/api/files", "GET", "files", "/objects", ""},
+		{"/api/orders", "POST", "catch-all", "/api/orders", ""},
+	}
+	for _, tt := range tests {
+		match, err := s.Resolve(ctx, tt.path, tt.method)
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.method, tt.path, err)
+		}
+		if match.Route.ID != tt.route || match.TargetPath != tt.target || match.Params["id"] != tt.id {
+			t.Fatalf("%s %s resolved to %s -> %s (id %q), want %s -> %s (id %q)",
+				tt.method, tt.path, match.Route.ID, match.TargetPath, match.Params["id"], tt.route, tt.target, tt.id)
+		}
+	}
+}
+
+func TestResolveReportsUnmatchedRoutes(t *testing.T) {
+	s := &routeService{route: routeTable{routes: []*ent.Route{
+		{ID: "users", PathPattern: "/api/users/:id", Method: "GET"},
+	}}}
+	ctx := context.Background()
+
+	if _, err := s.Resolve(ctx, "/api/users/42/roles", "GET"); err == nil || err.Error() != "no route found for path /api/users/42/roles" {
+		t.Fatalf("longer path error = %v", err)
+	}
+	if _, err := s.Resolve(ctx, "/api/users/42", "POST"); err == nil || err.Error() != "no route found for path /api/users/42 and method POST" {
+		t.Fatalf("method mismatch error = %v", err)
+	}
+}
+
+func TestResolveRefusesTraversal(t *testing.T) {
+	s := &routeService{route: routeTable{routes: []*ent.Route{
+		{ID: "users", PathPattern: "/api/users/:id", TargetPath: "/v2/users/:id", Method: "GET"},
+		{ID: "files", PathPattern: "/api/files/*", TargetPath: "/objects/*", Method: "GET"},
+	}}}
+	ctx := context.Background()
+
+	for _, path := range []string{
+		"/api/users/..",
+		"/api/users/.",
+		"/api/users/%2e%2e",
+		"/api/users/%2E.",
+		"/api/users/..%2Fadmin",
+		"/api/users/..%5Cadmin",
+		"/api/files/..",
+		"/api/files/a/../../admin",
+		"/api/files/a/%2e%2e/%2e%2e/admin",
+		"/api/files/./secret",
+		"/api/files/a/%2e%2e%2fadmin",
+	} {
+		if match, err := s.Resolve(ctx, path, "GET"); err == nil {
+			t.Fatalf("GET %s resolved to %s -> %s, want it refused", path, match.Route.ID, match.TargetPath)
+		}
+	}
+
+	// a parameter may not leave the target prefix even without dot segments
+	s.route = routeTable{routes: []*ent.Route{
+		{ID: "raw", PathPattern: "/api/raw/:id", TargetPath: "/objects/:id/../../admin", Method: "GET"},
+	}}
+	if _, err := s.Resolve(ctx, "/api/raw/42", "GET"); !errors.Is(err, structs.ErrUnsafeRoutePath) {
+		t.Fatalf("rewrite out of the target prefix: %v", err)
+	}
+
+	// dots inside a segment are ordinary names
+	s.route = routeTable{routes: []*ent.Route{
+		{ID: "files", PathPattern: "/api/files/*", TargetPath: "/objects/*", Method: "GET"},
+	}}
+	match, err := s.Resolve(ctx, "/api/files/a/..b/c.tar.gz", "GET")
+	if err != nil || match.TargetPath != "/objects/a/..b/c.tar.gz" {
+		t.Fatalf("dotted names = %v, %v", match, err)
+	}
+}
+
+func TestNormalizeMethods(t *testing.T) {
+	tests := map[string]string{
+		"get":            "GET",
+		"put, GET ,get":  "GET,PUT",
+		"GET,*":          "ANY",
+		"any":            "ANY",
+		"DELETE,OPTIONS": "DELETE,OPTIONS",
+	}
+	for in, want := range tests {
+		if got, err := normalizeMethods(in); err != nil || got != want {
+			t.Fatalf("normalizeMethods(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", " , ", "GET,FETCH"} {
+		if _, err := normalizeMethods(in); err == nil {
+			t.Fatalf("normalizeMethods(%q) accepted", in)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/plugin/proxy/structs"
+	"strings"
+	"sync"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+const (
+	// responseSchemaURL is the resource a response schema is compiled as
+	responseSchemaURL = "urn:proxy:response-schema"
+	// maxSchemaViolations caps the violations reported for one response
+	maxSchemaViolations = 10
+)
+
+// SchemaServiceInterface validates upstream responses against the response schema of their endpoint
+type SchemaServiceInterface interface {
+	Validate(ctx context.Context, endpoint *structs.ReadEndpoint, body []byte) error
+}
+
+// compiledSchema is the compiled response schema of an endpoint, kept until the schema changes
+type compiledSchema struct {
+	source string
+	schema *jsonschema.Schema
+	err    error
+}
+
+// schemaService compiles each endpoint schema once and reuses it for every response
+type schemaService struct {
+	mu      sync.Mutex
+	schemas map[string]*compiledSchema
+}
+
+// NewSchemaService creates a new schema service
+func NewSchemaService() SchemaServiceInterface {
+	return &schemaService{schemas: make(map[string]*compiledSchema)}
+}
+
+// Validate checks a response body against the schema of an endpoint validating responses.
+// It returns a SchemaViolationError when the body does not match. An endpoint whose schema
+// does not compile is logged and its responses are let through.
+func (s *schemaService) Validate(ctx context.Context, endpoint *structs.ReadEndpoint, body []byte) error {
+	if !endpoint.ValidateResponse || strings.TrimSpace(endpoint.ResponseSchema) == "" {
+		return nil
+	}
+
+	schema, err := s.compiled(endpoint)
+	if err != nil {
+		logger.Warnf(ctx, "Skipping response validation of endpoint %s, invalid schema: %v", endpoint.ID, err)
+		return nil
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(body))
+	if err != nil {
+		return &structs.SchemaViolationError{EndpointID: endpoint.ID, Violations: []string{"response is not valid JSON"}}
+	}
+
+	err = schema.Validate(instance)
+	var verr *jsonschema.ValidationError
+	if errors.As(err, &verr) {
+		return &structs.SchemaViolationError{EndpointID: endpoint.ID, Violations: schemaViolations(verr)}
+	}
+	return err
+}
+
+// compiled returns the compiled schema of an endpoint, compiling it on first use or change
+func (s *schemaService) compiled(endpoint *structs.ReadEndpoint) (*jsonschema.Schema, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.schemas[endpoint.ID]
+	if !ok || c.source != endpoint.ResponseSchema {
+		schema, err := CompileResponseSchema(endpoint.ResponseSchema)
+		c = &compiledSchema{source: endpoint.ResponseSchema, schema: schema, err: err}
+		s.schemas[endpoint.ID] = c
+	}
+	return c.schema, c.err
+}
+
+// noSchemaLoader refuses to load referenced schemas, a response schema is self-contained
+type noSchemaLoader struct{}
+
+// Load implements jsonschema.URLLoader.
+func (noSchemaLoader) Load(url string) (any, error) {
+	return nil, fmt.Errorf("loading schema %s is not allowed", url)
+}
+
+// CompileResponseSchema compiles a response schema, failing on invalid JSON or an invalid schema
+func CompileResponseSchema(source string) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(source))
+	if err != nil {
+		return nil, fmt.Errorf("response schema is not valid JSON: %w", err)
+	}
+
+	c := jsonschema.NewCompiler()
+	c.UseLoader(noSchemaLoader{})
+	if err := c.AddResource(responseSchemaURL, doc); err != nil {
+		return nil, err
+	}
+	schema, err := c.Compile(responseSchemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response schema: %w", err)
+	}
+	return schema, nil
+}
+
+// schemaViolations flattens a validation error into its failed checks
+func schemaViolations(verr *jsonschema.ValidationError) []string {
+	var violations []string
+	for _, unit := range verr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		location := unit.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		violations = append(violations, location+": "+unit.Error.String())
+		if len(violations) == maxSchemaViolations {
+			break
+		}
+	}
+	if len(violations) == 0 {
+		violations = append(violations, verr.Error())
+	}
+	return violations
+}
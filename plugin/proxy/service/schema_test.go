@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"ncobase/plugin/proxy/structs"
+)
+
+const userSchema = `{
+	"type": "object",
+	"required": ["id", "name"],
+	"properties": {
+		"id": {"type": "integer"},
+		"name": {"type": "string"}
+	}
+}`
+
+func TestValidateResponseAgainstSchema(t *testing.T) {
+	s := NewSchemaService()
+	ctx := context.Background()
+	endpoint := &structs.ReadEndpoint{ID: "e1", ResponseSchema: userSchema, ValidateResponse: true}
+
+	if err := s.Validate(ctx, endpoint, []byte(`{"id":1,"name":"ada","extra":true}`)); err != nil {
+		t.Fatalf("matching response: %v", err)
+	}
+
+	err := s.Validate(ctx, endpoint, []byte(`{"id":"1"}`))
+	var violation *structs.SchemaViolationError
+	if !errors.As(err, &violation) || violation.EndpointID != "e1" || len(violation.Violations) != 2 {
+		t.Fatalf("violating response: %v, want the missing name and the wrong id type", err)
+	}
+	joined := strings.Join(violation.Violations, "\n")
+	if !strings.Contains(joined, "/id: ") || !strings.Contains(joined, "name") {
+		t.Fatalf("violations %q do not locate the failed checks", joined)
+	}
+
+	err = s.Validate(ctx, endpoint, []byte(`<html>`))
+	if !errors.As(err, &violation) || violation.Violations[0] != "response is not valid JSON" {
+		t.Fatalf("non JSON response: %v", err)
+	}
+
+	// validation is off unless enabled
+	endpoint.ValidateResponse = false
+	if err := s.Validate(ctx, endpoint, []byte(`{"id":"1"}`)); err != nil {
+		t.Fatalf("validation disabled: %v", err)
+	}
+}
+
+func TestValidateRecompilesChangedSchema(t *testing.T) {
+	s := NewSchemaService()
+	ctx := context.Background()
+	endpoint := &structs.ReadEndpoint{ID: "e1", ResponseSchema: userSchema, ValidateResponse: true}
+
+	body := []byte(`{"id":1,"name":"ada"}`)
+	if err := s.Validate(ctx, endpoint, body); err != nil {
+		t.Fatalf("matching response: %v", err)
+	}
+	endpoint.ResponseSchema = `{"type": "array"}`
+	if err := s.Validate(ctx, endpoint, body); err == nil {
+		t.Fatal("response validated against the stale schema")
+	}
+}
+
+func TestMalformedSchemaIsGuarded(t *testing.T) {
+	for _, source := range []string{
+		`{"type": "object"`,
+		`{"type": "nothing"}`,
+		`{"$ref": "https://example.com/schema.json"}`,
+	} {
+		if _, err := CompileResponseSchema(source); err == nil {
+			t.Fatalf("schema %s compiled", source)
+		}
+	}
+
+	// a stored schema that does not compile lets responses through
+	s := NewSchemaService()
+	endpoint := &structs.ReadEndpoint{ID: "e1", ResponseSchema: `{"type": "nothing"}`, ValidateResponse: true}
+	if err := s.Validate(context.Background(), endpoint, []byte(`{}`)); err != nil {
+		t.Fatalf("invalid stored schema: %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+package structs
+
+// BreakerState is the circuit breaker state of an endpoint
+type BreakerState struct {
+	EndpointID string `json:"endpoint_id"`
+	// State is closed, half-open or open
+	State                string `json:"state"`
+	Requests             uint32 `json:"requests"`
+	TotalFailures        uint32 `json:"total_failures"`
+	ConsecutiveFailures  uint32 `json:"consecutive_failures"`
+	ConsecutiveSuccesses uint32 `json:"consecutive_successes"`
+	// TripFailures and TripRequests are the counts that opened the breaker last
+	TripFailures uint32 `json:"trip_failures,omitempty"`
+	TripRequests uint32 `json:"trip_requests,omitempty"`
+	OpenedAt     int64  `json:"opened_at,omitempty"`
+	// OpenUntil is when an open breaker lets a trial call through
+	OpenUntil int64 `json:"open_until,omitempty"`
+}
@@ -0,0 +1,16 @@
+package structs
+
+import "net/http"
+
+// ProxyCacheHeader is the request header bypassing the response cache with "bypass",
+// and the response header reporting HIT, MISS or BYPASS on cached routes
+const ProxyCacheHeader = "X-Proxy-Cache"
+
+// CachedResponse is an upstream response stored in the response cache
+type CachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	ETag       string      `json:"etag,omitempty"`
+	StoredAt   int64       `json:"stored_at"`
+}
@@ -21,6 +21,12 @@ type EndpointBody struct {
 	ValidateSSL       bool        `json:"validate_ssl"`
 	LogRequests       bool        `json:"log_requests"`
 	LogResponses      bool        `json:"log_responses"`
+	RateLimitRPS      float64     `json:"rate_limit_rps" validate:"gte=0"`
+	RateLimitBurst    int         `json:"rate_limit_burst" validate:"gte=0"`
+	RateLimitWait     int         `json:"rate_limit_wait" validate:"gte=0"`
+	ResponseSchema    string      `json:"response_schema"`
+	ValidateResponse  bool        `json:"validate_response"`
+	RejectInvalid     bool        `json:"reject_invalid_response"`
 	Disabled          bool        `json:"disabled"`
 	Extras            *types.JSON `json:"extras,omitempty"`
 	CreatedBy         *string     `json:"created_by,omitempty"`
@@ -53,6 +59,12 @@ type ReadEndpoint struct {
 	ValidateSSL       bool        `json:"validate_ssl"`
 	LogRequests       bool        `json:"log_requests"`
 	LogResponses      bool        `json:"log_responses"`
+	RateLimitRPS      float64     `json:"rate_limit_rps"`
+	RateLimitBurst    int         `json:"rate_limit_burst"`
+	RateLimitWait     int         `json:"rate_limit_wait"`
+	ResponseSchema    string      `json:"response_schema,omitempty"`
+	ValidateResponse  bool        `json:"validate_response"`
+	RejectInvalid     bool        `json:"reject_invalid_response"`
 	Disabled          bool        `json:"disabled"`
 	Extras            *types.JSON `json:"extras,omitempty"`
 	CreatedBy         *string     `json:"created_by,omitempty"`
@@ -0,0 +1,41 @@
+package structs
+
+// CallOutcome classifies a proxied call
+type CallOutcome string
+
+// Call outcomes
+const (
+	// CallSuccess is a call the upstream answered below 500
+	CallSuccess CallOutcome = "success"
+	// CallUpstreamError is a call the upstream failed, unreachable or answering 5xx
+	CallUpstreamError CallOutcome = "upstream_error"
+	// CallProxyError is a call the proxy failed before or after forwarding,
+	// such as a transformer error or an open circuit breaker
+	CallProxyError CallOutcome = "proxy_error"
+	// CallRateLimited is a call rejected by the rate limit of the endpoint, it never reached the upstream
+	CallRateLimited CallOutcome = "rate_limited"
+)
+
+// EndpointError is the last failed call of an endpoint
+type EndpointError struct {
+	Outcome CallOutcome `json:"outcome"`
+	Message string      `json:"message"`
+	At      int64       `json:"at"`
+}
+
+// EndpointMetrics are the call statistics of an endpoint since the instance started.
+// Latency percentiles cover the most recent calls only.
+type EndpointMetrics struct {
+	EndpointID     string         `json:"endpoint_id"`
+	Requests       int64          `json:"requests"`
+	Errors         int64          `json:"errors"`
+	UpstreamErrors int64          `json:"upstream_errors"`
+	ProxyErrors    int64          `json:"proxy_errors"`
+	RateLimited    int64          `json:"rate_limited"`
+	ErrorRate      float64        `json:"error_rate"`
+	LatencyP50     float64        `json:"latency_p50_ms"`
+	LatencyP95     float64        `json:"latency_p95_ms"`
+	LatencySamples int            `json:"latency_samples"`
+	LastError      *EndpointError `json:"last_error,omitempty"`
+	Since          int64          `json:"since,omitempty"`
+}
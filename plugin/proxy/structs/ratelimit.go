@@ -0,0 +1,18 @@
+package structs
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RateLimitedError is returned when a call exceeds the rate limit of its endpoint
+type RateLimitedError struct {
+	EndpointID string
+	RetryAfter time.Duration
+}
+
+// Error implements error.
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limit of endpoint %s exceeded, retry in %d seconds", e.EndpointID, int64(math.Ceil(e.RetryAfter.Seconds())))
+}
@@ -1,6 +1,7 @@
 package structs
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ncobase/ncore/types"
@@ -14,13 +15,14 @@ type RouteBody struct {
 	EndpointID          string      `json:"endpoint_id" validate:"required"`
 	PathPattern         string      `json:"path_pattern" validate:"required"`
 	TargetPath          string      `json:"target_path" validate:"required"`
-	Method              string      `json:"method" validate:"required"`
+	Method              string      `json:"method" validate:"required"` // Comma separated method set, or ANY
 	InputTransformerID  *string     `json:"input_transformer_id,omitempty"`
 	OutputTransformerID *string     `json:"output_transformer_id,omitempty"`
 	CacheEnabled        bool        `json:"cache_enabled"`
 	CacheTTL            int         `json:"cache_ttl"`
 	RateLimit           *string     `json:"rate_limit,omitempty"`
 	StripAuthHeader     bool        `json:"strip_auth_header"`
+	AuthRequired        bool        `json:"auth_required"`
 	Disabled            bool        `json:"disabled"`
 	Extras              *types.JSON `json:"extras,omitempty"`
 	CreatedBy           *string     `json:"created_by,omitempty"`
@@ -53,6 +55,7 @@ type ReadRoute struct {
 	CacheTTL            int         `json:"cache_ttl"`
 	RateLimit           *string     `json:"rate_limit,omitempty"`
 	StripAuthHeader     bool        `json:"strip_auth_header"`
+	AuthRequired        bool        `json:"auth_required"`
 	Disabled            bool        `json:"disabled"`
 	Extras              *types.JSON `json:"extras,omitempty"`
 	CreatedBy           *string     `json:"created_by,omitempty"`
@@ -61,6 +64,19 @@ type ReadRoute struct {
 	UpdatedAt           *int64      `json:"updated_at,omitempty"`
 }
 
+// ErrUnsafeRoutePath is returned when a rewritten path would leave the target path of its route
+// or the base path of its endpoint
+var ErrUnsafeRoutePath = errors.New("proxy path leaves its target prefix")
+
+// RouteMatch is the route an incoming proxy path resolves to
+type RouteMatch struct {
+	Route *ReadRoute
+	// Params holds the path parameters of the pattern, "*" the path matched by a trailing wildcard
+	Params map[string]string
+	// TargetPath is the target path of the route with the parameters filled in
+	TargetPath string
+}
+
 // GetCursorValue returns the cursor value.
 func (r *ReadRoute) GetCursorValue() string {
 	return fmt.Sprintf("%s:%d", r.ID, convert.ToValue(r.CreatedAt))
@@ -0,0 +1,18 @@
+package structs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaViolationError is returned when an upstream response does not match the response schema of its endpoint
+type SchemaViolationError struct {
+	EndpointID string
+	// Violations lists the failed checks as "<instance location>: <message>"
+	Violations []string
+}
+
+// Error implements error.
+func (e *SchemaViolationError) Error() string {
+	return fmt.Sprintf("response of endpoint %s violates its schema: %s", e.EndpointID, strings.Join(e.Violations, "; "))
+}
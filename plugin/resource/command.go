@@ -0,0 +1,270 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"ncobase/internal/command"
+	rConfig "ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/service"
+	"ncobase/plugin/resource/structs"
+	"os"
+
+	"github.com/ncobase/ncore/config"
+	"github.com/ncobase/ncore/oss"
+)
+
+func init() {
+	command.Register(&command.Command{
+		Name:  "reconcile-storage",
+		Usage: "reconcile-storage --prefix=<path> [--dry-run=false] [--grace=1h]",
+		Run:   runReconcileStorage,
+	})
+	command.Register(&command.Command{
+		Name:  "reindex-files",
+		Usage: "reindex-files [--owner=<id>]",
+		Run:   runReindexFiles,
+	})
+	command.Register(&command.Command{
+		Name:  "index-outbox",
+		Usage: "index-outbox [--drain] [--requeue]",
+		Run:   runIndexOutbox,
+	})
+	command.Register(&command.Command{
+		Name:  "backfill-extras",
+		Usage: "backfill-extras [--owner=<id>] [--dry-run=false] [--batch=200]",
+		Run:   runBackfillExtras,
+	})
+	command.Register(&command.Command{
+		Name:  "snapshot-storage",
+		Usage: "snapshot-storage [--owner=<id>]",
+		Run:   runSnapshotStorage,
+	})
+	command.Register(&command.Command{
+		Name:  "migrate-files",
+		Usage: "migrate-files --from=<bucket> --to=<bucket> [--owner=<id>] [--cursor=<cursor>] [--concurrency=4] [--batch=100] [--delete-source]",
+		Run:   runMigrateFiles,
+	})
+}
+
+// runReconcileStorage reports and optionally cleans storage objects and file records that drifted apart
+func runReconcileStorage(ctx context.Context, conf *config.Config, args []string) error {
+	fs := flag.NewFlagSet("reconcile-storage", flag.ContinueOnError)
+	prefix := fs.String("prefix", "", "storage path prefix to reconcile, e.g. a space or owner prefix")
+	dryRun := fs.Bool("dry-run", true, "only report drift, pass --dry-run=false to clean up")
+	grace := fs.Duration("grace", service.DefaultReconcileGracePeriod, "skip objects and records younger than this")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *prefix == "" {
+		return fmt.Errorf("--prefix is required")
+	}
+
+	d, cleanup, err := data.New(conf.Data, conf.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to connect data: %w", err)
+	}
+	defer cleanup()
+
+	storage, err := oss.NewStorage(conf.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to connect storage: %w", err)
+	}
+
+	report, err := service.NewReconciler(d, storage).Reconcile(ctx, &structs.ReconcileOptions{
+		Prefix:      *prefix,
+		DryRun:      *dryRun,
+		GracePeriod: *grace,
+	})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// runReindexFiles rebuilds the file search index
+func runReindexFiles(ctx context.Context, conf *config.Config, args []string) error {
+	fs := flag.NewFlagSet("reindex-files", flag.ContinueOnError)
+	ownerID := fs.String("owner", "", "only reindex files of this owner (space or user), all files when empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	d, cleanup, err := data.New(conf.Data, conf.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to connect data: %w", err)
+	}
+	defer cleanup()
+
+	indexed, err := service.NewFileIndexer(d).Reindex(ctx, *ownerID)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&structs.ReindexReport{OwnerID: *ownerID, Indexed: indexed})
+}
+
+// runIndexOutbox reports the search index updates by status, optionally requeueing the
+// dead-lettered ones and delivering the due ones right away
+func runIndexOutbox(ctx context.Context, conf *config.Config, args []string) error {
+	fs := flag.NewFlagSet("index-outbox", flag.ContinueOnError)
+	requeue := fs.Bool("requeue", false, "make dead-lettered updates pending again")
+	drain := fs.Bool("drain", false, "deliver the due updates before reporting")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c := rConfig.New()
+	c.LoadFromViper(conf.Viper)
+
+	d, cleanup, err := data.New(conf.Data, conf.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to connect data: %w", err)
+	}
+	defer cleanup()
+
+	outbox := service.NewIndexOutbox(d, c.SearchOutbox)
+	if *requeue {
+		requeued, err := outbox.Requeue(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "requeued %d updates\n", requeued)
+	}
+	if *drain {
+		delivered, err := outbox.Drain(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "delivered %d updates\n", delivered)
+	}
+
+	status, err := outbox.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(status)
+}
+
+// runBackfillExtras normalizes stored file extras into their canonical shape
+func runBackfillExtras(ctx context.Context, conf *config.Config, args []string) error {
+	fs := flag.NewFlagSet("backfill-extras", flag.ContinueOnError)
+	ownerID := fs.String("owner", "", "only backfill files of this owner (space or user), all files when empty")
+	dryRun := fs.Bool("dry-run", true, "only count files to normalize, pass --dry-run=false to write")
+	batch := fs.Int("batch", 200, "number of files per batch")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	d, cleanup, err := data.New(conf.Data, conf.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to connect data: %w", err)
+	}
+	defer cleanup()
+
+	report, err := service.NewExtrasBackfiller(d).Backfill(ctx, &structs.BackfillOptions{
+		OwnerID:   *ownerID,
+		DryRun:    *dryRun,
+		BatchSize: *batch,
+		Progress: func(r *structs.BackfillReport) {
+			fmt.Fprintf(os.Stderr, "scanned %d, normalized %d, errors %d\n", r.Scanned, r.Changed, len(r.Errors))
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// runSnapshotStorage takes today's storage report snapshot without waiting for the schedule
+func runSnapshotStorage(ctx context.Context, conf *config.Config, args []string) error {
+	fs := flag.NewFlagSet("snapshot-storage", flag.ContinueOnError)
+	ownerID := fs.String("owner", "", "only snapshot this owner (space or user), all owners when empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c := rConfig.New()
+	c.LoadFromViper(conf.Viper)
+
+	d, cleanup, err := data.New(conf.Data, conf.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to connect data: %w", err)
+	}
+	defer cleanup()
+
+	result, err := service.NewReportService(d, nil, c.Reports).Snapshot(ctx, *ownerID)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// runMigrateFiles copies file objects between buckets and points their records at the new bucket
+func runMigrateFiles(ctx context.Context, conf *config.Config, args []string) error {
+	c := rConfig.New()
+	c.LoadFromViper(conf.Viper)
+	workers := service.BatchWorkers(c.Batch)
+
+	fs := flag.NewFlagSet("migrate-files", flag.ContinueOnError)
+	from := fs.String("from", "", "bucket to move files out of, the current or a legacy storage bucket")
+	to := fs.String("to", "", "bucket to move files into, the current storage bucket when empty")
+	ownerID := fs.String("owner", "", "only migrate files of this owner (space or user), all files when empty")
+	cursor := fs.String("cursor", "", "resume after the cursor printed by an interrupted run")
+	concurrency := fs.Int("concurrency", workers.Parallelism, "number of files copied at once, resource.batch.parallelism by default")
+	itemTimeout := fs.Duration("item-timeout", workers.ItemTimeout, "deadline per file, resource.batch.item_timeout by default")
+	batch := fs.Int("batch", 100, "number of files per batch")
+	deleteSource := fs.Bool("delete-source", false, "delete source objects once their copy is verified")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *from == "" {
+		return fmt.Errorf("--from is required")
+	}
+	if *to == "" {
+		*to = conf.Storage.Bucket
+	}
+
+	d, cleanup, err := data.New(conf.Data, conf.Environment)
+	if err != nil {
+		return fmt.Errorf("failed to connect data: %w", err)
+	}
+	defer cleanup()
+
+	migrator := service.NewFileMigrator(d, conf.Storage, c.LegacyStorages, &structs.MigrateOptions{
+		Concurrency:  *concurrency,
+		ItemTimeout:  *itemTimeout,
+		BatchSize:    *batch,
+		DeleteSource: *deleteSource,
+		Progress: func(r *structs.MigrateReport) {
+			fmt.Fprintf(os.Stderr, "scanned %d, migrated %d, errors %d, cursor %s\n", r.Scanned, r.Migrated, len(r.Errors), r.Cursor)
+		},
+	})
+	report, err := migrator.MigrateFiles(ctx, *from, *to, &structs.ListFileParams{
+		OwnerID: *ownerID,
+		Cursor:  *cursor,
+	})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -1,16 +1,30 @@
 package config
 
 import (
+	"encoding/json"
+
+	"github.com/ncobase/ncore/oss"
 	"github.com/spf13/viper"
 )
 
 // Config holds resource module configuration
 type Config struct {
-	MaxUploadSize   int64        `json:"max_upload_size"`
-	AllowedTypes    []string     `json:"allowed_types"`
-	DefaultStorage  string       `json:"default_storage"`
-	ImageProcessing *ImageConfig `json:"image_processing"`
-	QuotaManagement *QuotaConfig `json:"quota_management"`
+	MaxUploadSize   int64         `json:"max_upload_size"`
+	AllowedTypes    []string      `json:"allowed_types"`
+	DefaultStorage  string        `json:"default_storage"`
+	SigningSecret   string        `json:"-"`
+	StrictTypes     bool          `json:"strict_types"`
+	DedupeUploads   bool          `json:"dedupe_uploads"`
+	NameConflict    string        `json:"name_conflict"` // rename, reject or replace
+	ImageProcessing *ImageConfig  `json:"image_processing"`
+	QuotaManagement *QuotaConfig  `json:"quota_management"`
+	Reports         *ReportConfig `json:"reports"`
+	Events          *EventConfig  `json:"events"`
+	Batch           *BatchConfig  `json:"batch"`
+	SearchOutbox    *OutboxConfig `json:"search_outbox"`
+	// LegacyStorages are buckets files were written to before a bucket migration.
+	// They are only read from, when a file is missing from its recorded bucket.
+	LegacyStorages []*oss.Config `json:"legacy_storages"`
 }
 
 // ImageConfig holds image processing configuration
@@ -21,6 +35,9 @@ type ImageConfig struct {
 	EnableResizing         bool `json:"enable_resizing"`
 	MaxImageWidth          int  `json:"max_image_width"`
 	MaxImageHeight         int  `json:"max_image_height"`
+	// ThumbnailPathTemplate is the storage key of thumbnails. Tokens: {dir}, {name} and {ext}
+	// of the object, {owner}, {space}, {category}, {width}, {height} and {size} (WxH).
+	ThumbnailPathTemplate string `json:"thumbnail_path_template"`
 }
 
 // QuotaConfig holds quota management configuration
@@ -31,12 +48,48 @@ type QuotaConfig struct {
 	QuotaCheckInterval string  `json:"quota_check_interval"`
 }
 
+// ReportConfig holds storage report configuration
+type ReportConfig struct {
+	EnableReports    bool   `json:"enable_reports"`
+	SnapshotInterval string `json:"snapshot_interval"`
+	RetentionDays    int    `json:"retention_days"`
+	TopFolders       int    `json:"top_folders"`
+}
+
+// EventConfig holds event publishing configuration
+type EventConfig struct {
+	Async        bool   `json:"async"`
+	QueueSize    int    `json:"queue_size"`
+	Workers      int    `json:"workers"`
+	Overflow     string `json:"overflow"` // drop_oldest or block
+	BlockTimeout string `json:"block_timeout"`
+	// AccessSampleRate publishes 1 in N accesses to public files, 1 publishes all.
+	// Accesses to private and shared files are always published.
+	AccessSampleRate int `json:"access_sample_rate"`
+}
+
+// BatchConfig bounds batch file operations: uploads, deletes, bulk updates,
+// thumbnail regeneration and bucket migration
+type BatchConfig struct {
+	Parallelism int    `json:"parallelism"`  // files worked on at once
+	ItemTimeout string `json:"item_timeout"` // deadline per file, none when empty
+}
+
+// OutboxConfig holds the delivery of search index updates recorded with file writes
+type OutboxConfig struct {
+	Interval    string `json:"interval"`     // poll interval, also the first retry delay
+	MaxAttempts int    `json:"max_attempts"` // attempts before an update is dead-lettered
+	BatchSize   int    `json:"batch_size"`   // updates delivered per poll round
+	Retention   string `json:"retention"`    // how long delivered updates are kept
+}
+
 // New returns a new Config instance with default values
 func New() *Config {
 	return &Config{
 		MaxUploadSize:  5 * 1024 * 1024 * 1024, // 5GB default
 		AllowedTypes:   []string{"*"},          // All types by default
 		DefaultStorage: "filesystem",
+		NameConflict:   "rename",
 		ImageProcessing: &ImageConfig{
 			EnableThumbnails:       true,
 			DefaultThumbnailWidth:  300,
@@ -44,6 +97,7 @@ func New() *Config {
 			EnableResizing:         true,
 			MaxImageWidth:          2048,
 			MaxImageHeight:         2048,
+			ThumbnailPathTemplate:  "{dir}/thumbnails/{name}_thumb.jpg",
 		},
 		QuotaManagement: &QuotaConfig{
 			EnableQuotas:       true,
@@ -51,6 +105,30 @@ func New() *Config {
 			WarningThreshold:   0.8,                     // 80% warning
 			QuotaCheckInterval: "24h",                   // Daily check
 		},
+		Reports: &ReportConfig{
+			EnableReports:    true,
+			SnapshotInterval: "24h", // Daily snapshot
+			RetentionDays:    400,   // Keep a little over a year
+			TopFolders:       10,
+		},
+		Events: &EventConfig{
+			Async:            true,
+			QueueSize:        1024,
+			Workers:          4,
+			Overflow:         "drop_oldest",
+			BlockTimeout:     "1s",
+			AccessSampleRate: 1,
+		},
+		Batch: &BatchConfig{
+			Parallelism: 2,
+			ItemTimeout: "5m",
+		},
+		SearchOutbox: &OutboxConfig{
+			Interval:    "5s",
+			MaxAttempts: 10,
+			BatchSize:   100,
+			Retention:   "168h", // A week
+		},
 	}
 }
 
@@ -75,6 +153,38 @@ func (c *Config) LoadFromViper(viper *viper.Viper) {
 		c.DefaultStorage = viper.GetString("resource.default_storage")
 	}
 
+	// SigningSecret signs share links, falls back to the JWT secret
+	if viper.IsSet("resource.signing_secret") {
+		c.SigningSecret = viper.GetString("resource.signing_secret")
+	} else if viper.IsSet("auth.jwt.secret") {
+		c.SigningSecret = viper.GetString("auth.jwt.secret")
+	}
+
+	// StrictTypes rejects uploads whose content does not match their extension
+	if viper.IsSet("resource.strict_types") {
+		c.StrictTypes = viper.GetBool("resource.strict_types")
+	}
+
+	// DedupeUploads stores identical uploads of an owner once
+	if viper.IsSet("resource.dedupe_uploads") {
+		c.DedupeUploads = viper.GetBool("resource.dedupe_uploads")
+	}
+
+	// NameConflict handles uploads named like an existing file of the owner
+	if viper.IsSet("resource.name_conflict") {
+		c.NameConflict = viper.GetString("resource.name_conflict")
+	}
+
+	// LegacyStorages use the keys of the storage config
+	if viper.IsSet("resource.legacy_storages") {
+		if raw, err := json.Marshal(viper.Get("resource.legacy_storages")); err == nil {
+			var legacy []*oss.Config
+			if err := json.Unmarshal(raw, &legacy); err == nil {
+				c.LegacyStorages = legacy
+			}
+		}
+	}
+
 	// Load image processing config
 	if c.ImageProcessing == nil {
 		c.ImageProcessing = &ImageConfig{}
@@ -104,6 +214,10 @@ func (c *Config) LoadFromViper(viper *viper.Viper) {
 		c.ImageProcessing.MaxImageHeight = viper.GetInt("resource.image_processing.max_image_height")
 	}
 
+	if viper.IsSet("resource.image_processing.thumbnail_path_template") {
+		c.ImageProcessing.ThumbnailPathTemplate = viper.GetString("resource.image_processing.thumbnail_path_template")
+	}
+
 	// Load quota management config
 	if c.QuotaManagement == nil {
 		c.QuotaManagement = &QuotaConfig{}
@@ -124,4 +238,88 @@ func (c *Config) LoadFromViper(viper *viper.Viper) {
 	if viper.IsSet("resource.quota_management.quota_check_interval") {
 		c.QuotaManagement.QuotaCheckInterval = viper.GetString("resource.quota_management.quota_check_interval")
 	}
+
+	// Load storage report config
+	if c.Reports == nil {
+		c.Reports = &ReportConfig{}
+	}
+
+	if viper.IsSet("resource.reports.enable_reports") {
+		c.Reports.EnableReports = viper.GetBool("resource.reports.enable_reports")
+	}
+
+	if viper.IsSet("resource.reports.snapshot_interval") {
+		c.Reports.SnapshotInterval = viper.GetString("resource.reports.snapshot_interval")
+	}
+
+	if viper.IsSet("resource.reports.retention_days") {
+		c.Reports.RetentionDays = viper.GetInt("resource.reports.retention_days")
+	}
+
+	if viper.IsSet("resource.reports.top_folders") {
+		c.Reports.TopFolders = viper.GetInt("resource.reports.top_folders")
+	}
+
+	// Load event publishing config
+	if c.Events == nil {
+		c.Events = &EventConfig{}
+	}
+
+	if viper.IsSet("resource.events.async") {
+		c.Events.Async = viper.GetBool("resource.events.async")
+	}
+
+	if viper.IsSet("resource.events.queue_size") {
+		c.Events.QueueSize = viper.GetInt("resource.events.queue_size")
+	}
+
+	if viper.IsSet("resource.events.workers") {
+		c.Events.Workers = viper.GetInt("resource.events.workers")
+	}
+
+	if viper.IsSet("resource.events.overflow") {
+		c.Events.Overflow = viper.GetString("resource.events.overflow")
+	}
+
+	if viper.IsSet("resource.events.block_timeout") {
+		c.Events.BlockTimeout = viper.GetString("resource.events.block_timeout")
+	}
+
+	if viper.IsSet("resource.events.access_sample_rate") {
+		c.Events.AccessSampleRate = viper.GetInt("resource.events.access_sample_rate")
+	}
+
+	// Load batch operation config
+	if c.Batch == nil {
+		c.Batch = &BatchConfig{}
+	}
+
+	if viper.IsSet("resource.batch.parallelism") {
+		c.Batch.Parallelism = viper.GetInt("resource.batch.parallelism")
+	}
+
+	if viper.IsSet("resource.batch.item_timeout") {
+		c.Batch.ItemTimeout = viper.GetString("resource.batch.item_timeout")
+	}
+
+	// Load search outbox config
+	if c.SearchOutbox == nil {
+		c.SearchOutbox = &OutboxConfig{}
+	}
+
+	if viper.IsSet("resource.search_outbox.interval") {
+		c.SearchOutbox.Interval = viper.GetString("resource.search_outbox.interval")
+	}
+
+	if viper.IsSet("resource.search_outbox.max_attempts") {
+		c.SearchOutbox.MaxAttempts = viper.GetInt("resource.search_outbox.max_attempts")
+	}
+
+	if viper.IsSet("resource.search_outbox.batch_size") {
+		c.SearchOutbox.BatchSize = viper.GetInt("resource.search_outbox.batch_size")
+	}
+
+	if viper.IsSet("resource.search_outbox.retention") {
+		c.SearchOutbox.Retention = viper.GetString("resource.search_outbox.retention")
+	}
 }
@@ -12,6 +12,8 @@ import (
 	"ncobase/plugin/resource/data/ent/migrate"
 
 	"ncobase/plugin/resource/data/ent/file"
+	"ncobase/plugin/resource/data/ent/fileattachment"
+	"ncobase/plugin/resource/data/ent/indexoutbox"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect"
@@ -27,6 +29,10 @@ type Client struct {
 	Schema *migrate.Schema
 	// File is the client for interacting with the File builders.
 	File *FileClient
+	// FileAttachment is the client for interacting with the FileAttachment builders.
+	FileAttachment *FileAttachmentClient
+	// IndexOutbox is the client for interacting with the IndexOutbox builders.
+	IndexOutbox *IndexOutboxClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -39,6 +45,8 @@ func NewClient(opts ...Option) *Client {
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
 	c.File = NewFileClient(c.config)
+	c.FileAttachment = NewFileAttachmentClient(c.config)
+	c.IndexOutbox = NewIndexOutboxClient(c.config)
 }
 
 type (
@@ -129,9 +137,11 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:    ctx,
-		config: cfg,
-		File:   NewFileClient(cfg),
+		ctx:            ctx,
+		config:         cfg,
+		File:           NewFileClient(cfg),
+		FileAttachment: NewFileAttachmentClient(cfg),
+		IndexOutbox:    NewIndexOutboxClient(cfg),
 	}, nil
 }
 
@@ -149,9 +159,11 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:    ctx,
-		config: cfg,
-		File:   NewFileClient(cfg),
+		ctx:            ctx,
+		config:         cfg,
+		File:           NewFileClient(cfg),
+		FileAttachment: NewFileAttachmentClient(cfg),
+		IndexOutbox:    NewIndexOutboxClient(cfg),
 	}, nil
 }
 
@@ -181,12 +193,16 @@ func (c *Client) Close() error {
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
 	c.File.Use(hooks...)
+	c.FileAttachment.Use(hooks...)
+	c.IndexOutbox.Use(hooks...)
 }
 
 // Intercept adds the query interceptors to all the entity clients.
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	c.File.Intercept(interceptors...)
+	c.FileAttachment.Intercept(interceptors...)
+	c.IndexOutbox.Intercept(interceptors...)
 }
 
 // Mutate implements the ent.Mutator interface.
@@ -194,6 +210,10 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
 	case *FileMutation:
 		return c.File.mutate(ctx, m)
+	case *FileAttachmentMutation:
+		return c.FileAttachment.mutate(ctx, m)
+	case *IndexOutboxMutation:
+		return c.IndexOutbox.mutate(ctx, m)
 	default:
 		return nil, fmt.Errorf("ent: unknown mutation type %T", m)
 	}
@@ -332,13 +352,279 @@ func (c *FileClient) mutate(ctx context.Context, m *FileMutation) (Value, error)
 	}
 }
 
+// FileAttachmentClient is a client for the FileAttachment schema.
+type FileAttachmentClient struct {
+	config
+}
+
+// NewFileAttachmentClient returns a client for the FileAttachment from the given config.
+func NewFileAttachmentClient(c config) *FileAttachmentClient {
+	return &FileAttachmentClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `fileattachment.Hooks(f(g(h())))`.
+func (c *FileAttachmentClient) Use(hooks ...Hook) {
+	c.hooks.FileAttachment = append(c.hooks.FileAttachment, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `fileattachment.Intercept(f(g(h())))`.
+func (c *FileAttachmentClient) Intercept(interceptors ...Interceptor) {
+	c.inters.FileAttachment = append(c.inters.FileAttachment, interceptors...)
+}
+
+// Create returns a builder for creating a FileAttachment entity.
+func (c *FileAttachmentClient) Create() *FileAttachmentCreate {
+	mutation := newFileAttachmentMutation(c.config, OpCreate)
+	return &FileAttachmentCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of FileAttachment entities.
+func (c *FileAttachmentClient) CreateBulk(builders ...*FileAttachmentCreate) *FileAttachmentCreateBulk {
+	return &FileAttachmentCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *FileAttachmentClient) MapCreateBulk(slice any, setFunc func(*FileAttachmentCreate, int)) *FileAttachmentCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &FileAttachmentCreateBulk{err: fmt.Errorf("calling to FileAttachmentClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*FileAttachmentCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &FileAttachmentCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for FileAttachment.
+func (c *FileAttachmentClient) Update() *FileAttachmentUpdate {
+	mutation := newFileAttachmentMutation(c.config, OpUpdate)
+	return &FileAttachmentUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *FileAttachmentClient) UpdateOne(_m *FileAttachment) *FileAttachmentUpdateOne {
+	mutation := newFileAttachmentMutation(c.config, OpUpdateOne, withFileAttachment(_m))
+	return &FileAttachmentUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *FileAttachmentClient) UpdateOneID(id string) *FileAttachmentUpdateOne {
+	mutation := newFileAttachmentMutation(c.config, OpUpdateOne, withFileAttachmentID(id))
+	return &FileAttachmentUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for FileAttachment.
+func (c *FileAttachmentClient) Delete() *FileAttachmentDelete {
+	mutation := newFileAttachmentMutation(c.config, OpDelete)
+	return &FileAttachmentDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *FileAttachmentClient) DeleteOne(_m *FileAttachment) *FileAttachmentDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *FileAttachmentClient) DeleteOneID(id string) *FileAttachmentDeleteOne {
+	builder := c.Delete().Where(fileattachment.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &FileAttachmentDeleteOne{builder}
+}
+
+// Query returns a query builder for FileAttachment.
+func (c *FileAttachmentClient) Query() *FileAttachmentQuery {
+	return &FileAttachmentQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeFileAttachment},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a FileAttachment entity by its id.
+func (c *FileAttachmentClient) Get(ctx context.Context, id string) (*FileAttachment, error) {
+	return c.Query().Where(fileattachment.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *FileAttachmentClient) GetX(ctx context.Context, id string) *FileAttachment {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *FileAttachmentClient) Hooks() []Hook {
+	return c.hooks.FileAttachment
+}
+
+// Interceptors returns the client interceptors.
+func (c *FileAttachmentClient) Interceptors() []Interceptor {
+	return c.inters.FileAttachment
+}
+
+func (c *FileAttachmentClient) mutate(ctx context.Context, m *FileAttachmentMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&FileAttachmentCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&FileAttachmentUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&FileAttachmentUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&FileAttachmentDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown FileAttachment mutation op: %q", m.Op())
+	}
+}
+
+// IndexOutboxClient is a client for the IndexOutbox schema.
+type IndexOutboxClient struct {
+	config
+}
+
+// NewIndexOutboxClient returns a client for the IndexOutbox from the given config.
+func NewIndexOutboxClient(c config) *IndexOutboxClient {
+	return &IndexOutboxClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `indexoutbox.Hooks(f(g(h())))`.
+func (c *IndexOutboxClient) Use(hooks ...Hook) {
+	c.hooks.IndexOutbox = append(c.hooks.IndexOutbox, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `indexoutbox.Intercept(f(g(h())))`.
+func (c *IndexOutboxClient) Intercept(interceptors ...Interceptor) {
+	c.inters.IndexOutbox = append(c.inters.IndexOutbox, interceptors...)
+}
+
+// Create returns a builder for creating a IndexOutbox entity.
+func (c *IndexOutboxClient) Create() *IndexOutboxCreate {
+	mutation := newIndexOutboxMutation(c.config, OpCreate)
+	return &IndexOutboxCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of IndexOutbox entities.
+func (c *IndexOutboxClient) CreateBulk(builders ...*IndexOutboxCreate) *IndexOutboxCreateBulk {
+	return &IndexOutboxCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *IndexOutboxClient) MapCreateBulk(slice any, setFunc func(*IndexOutboxCreate, int)) *IndexOutboxCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &IndexOutboxCreateBulk{err: fmt.Errorf("calling to IndexOutboxClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*IndexOutboxCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &IndexOutboxCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for IndexOutbox.
+func (c *IndexOutboxClient) Update() *IndexOutboxUpdate {
+	mutation := newIndexOutboxMutation(c.config, OpUpdate)
+	return &IndexOutboxUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *IndexOutboxClient) UpdateOne(_m *IndexOutbox) *IndexOutboxUpdateOne {
+	mutation := newIndexOutboxMutation(c.config, OpUpdateOne, withIndexOutbox(_m))
+	return &IndexOutboxUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *IndexOutboxClient) UpdateOneID(id string) *IndexOutboxUpdateOne {
+	mutation := newIndexOutboxMutation(c.config, OpUpdateOne, withIndexOutboxID(id))
+	return &IndexOutboxUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for IndexOutbox.
+func (c *IndexOutboxClient) Delete() *IndexOutboxDelete {
+	mutation := newIndexOutboxMutation(c.config, OpDelete)
+	return &IndexOutboxDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *IndexOutboxClient) DeleteOne(_m *IndexOutbox) *IndexOutboxDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *IndexOutboxClient) DeleteOneID(id string) *IndexOutboxDeleteOne {
+	builder := c.Delete().Where(indexoutbox.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &IndexOutboxDeleteOne{builder}
+}
+
+// Query returns a query builder for IndexOutbox.
+func (c *IndexOutboxClient) Query() *IndexOutboxQuery {
+	return &IndexOutboxQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeIndexOutbox},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a IndexOutbox entity by its id.
+func (c *IndexOutboxClient) Get(ctx context.Context, id string) (*IndexOutbox, error) {
+	return c.Query().Where(indexoutbox.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *IndexOutboxClient) GetX(ctx context.Context, id string) *IndexOutbox {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *IndexOutboxClient) Hooks() []Hook {
+	return c.hooks.IndexOutbox
+}
+
+// Interceptors returns the client interceptors.
+func (c *IndexOutboxClient) Interceptors() []Interceptor {
+	return c.inters.IndexOutbox
+}
+
+func (c *IndexOutboxClient) mutate(ctx context.Context, m *IndexOutboxMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&IndexOutboxCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&IndexOutboxUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&IndexOutboxUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&IndexOutboxDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown IndexOutbox mutation op: %q", m.Op())
+	}
+}
+
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		File []ent.Hook
+		File, FileAttachment, IndexOutbox []ent.Hook
 	}
 	inters struct {
-		File []ent.Interceptor
+		File, FileAttachment, IndexOutbox []ent.Interceptor
 	}
 )
 
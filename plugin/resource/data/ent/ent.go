@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"ncobase/plugin/resource/data/ent/file"
+	"ncobase/plugin/resource/data/ent/fileattachment"
+	"ncobase/plugin/resource/data/ent/indexoutbox"
 	"reflect"
 	"sync"
 
@@ -73,7 +75,9 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			file.Table: file.ValidColumn,
+			file.Table:           file.ValidColumn,
+			fileattachment.Table: fileattachment.ValidColumn,
+			indexoutbox.Table:    indexoutbox.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
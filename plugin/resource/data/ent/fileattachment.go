@@ -0,0 +1,170 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"ncobase/plugin/resource/data/ent/fileattachment"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// FileAttachment is the model entity for the FileAttachment schema.
+type FileAttachment struct {
+	config `json:"-"`
+	// ID of the ent.
+	// primary key
+	ID string `json:"id,omitempty"`
+	// id of the creator
+	CreatedBy string `json:"created_by,omitempty"`
+	// created at
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// updated at
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+	// Attached file
+	FileID string `json:"file_id,omitempty"`
+	// Type of the entity the file is attached to, e.g. topic, task, space
+	EntityType string `json:"entity_type,omitempty"`
+	// ID of the entity the file is attached to
+	EntityID string `json:"entity_id,omitempty"`
+	// Relationship of the file to the entity, e.g. attachment, cover, avatar
+	Relation     string `json:"relation,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*FileAttachment) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case fileattachment.FieldCreatedAt, fileattachment.FieldUpdatedAt:
+			values[i] = new(sql.NullInt64)
+		case fileattachment.FieldID, fileattachment.FieldCreatedBy, fileattachment.FieldFileID, fileattachment.FieldEntityType, fileattachment.FieldEntityID, fileattachment.FieldRelation:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the FileAttachment fields.
+func (_m *FileAttachment) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case fileattachment.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				_m.ID = value.String
+			}
+		case fileattachment.FieldCreatedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field created_by", values[i])
+			} else if value.Valid {
+				_m.CreatedBy = value.String
+			}
+		case fileattachment.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Int64
+			}
+		case fileattachment.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Int64
+			}
+		case fileattachment.FieldFileID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field file_id", values[i])
+			} else if value.Valid {
+				_m.FileID = value.String
+			}
+		case fileattachment.FieldEntityType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field entity_type", values[i])
+			} else if value.Valid {
+				_m.EntityType = value.String
+			}
+		case fileattachment.FieldEntityID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field entity_id", values[i])
+			} else if value.Valid {
+				_m.EntityID = value.String
+			}
+		case fileattachment.FieldRelation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field relation", values[i])
+			} else if value.Valid {
+				_m.Relation = value.String
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the FileAttachment.
+// This includes values selected through modifiers, order, etc.
+func (_m *FileAttachment) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this FileAttachment.
+// Note that you need to call FileAttachment.Unwrap() before calling this method if this FileAttachment
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *FileAttachment) Update() *FileAttachmentUpdateOne {
+	return NewFileAttachmentClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the FileAttachment entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *FileAttachment) Unwrap() *FileAttachment {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: FileAttachment is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *FileAttachment) String() string {
+	var builder strings.Builder
+	builder.WriteString("FileAttachment(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("created_by=")
+	builder.WriteString(_m.CreatedBy)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CreatedAt))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UpdatedAt))
+	builder.WriteString(", ")
+	builder.WriteString("file_id=")
+	builder.WriteString(_m.FileID)
+	builder.WriteString(", ")
+	builder.WriteString("entity_type=")
+	builder.WriteString(_m.EntityType)
+	builder.WriteString(", ")
+	builder.WriteString("entity_id=")
+	builder.WriteString(_m.EntityID)
+	builder.WriteString(", ")
+	builder.WriteString("relation=")
+	builder.WriteString(_m.Relation)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// FileAttachments is a parsable slice of FileAttachment.
+type FileAttachments []*FileAttachment
@@ -0,0 +1,549 @@
+// Code generated by ent, DO NOT EDIT.
+
+package fileattachment
+
+import (
+	"ncobase/plugin/resource/data/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLTE(FieldID, id))
+}
+
+// IDEqualFold applies the EqualFold predicate on the ID field.
+func IDEqualFold(id string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEqualFold(FieldID, id))
+}
+
+// IDContainsFold applies the ContainsFold predicate on the ID field.
+func IDContainsFold(id string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContainsFold(FieldID, id))
+}
+
+// CreatedBy applies equality check predicate on the "created_by" field. It's identical to CreatedByEQ.
+func CreatedBy(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldCreatedBy, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// FileID applies equality check predicate on the "file_id" field. It's identical to FileIDEQ.
+func FileID(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldFileID, v))
+}
+
+// EntityType applies equality check predicate on the "entity_type" field. It's identical to EntityTypeEQ.
+func EntityType(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldEntityType, v))
+}
+
+// EntityID applies equality check predicate on the "entity_id" field. It's identical to EntityIDEQ.
+func EntityID(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldEntityID, v))
+}
+
+// Relation applies equality check predicate on the "relation" field. It's identical to RelationEQ.
+func Relation(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldRelation, v))
+}
+
+// CreatedByEQ applies the EQ predicate on the "created_by" field.
+func CreatedByEQ(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldCreatedBy, v))
+}
+
+// CreatedByNEQ applies the NEQ predicate on the "created_by" field.
+func CreatedByNEQ(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNEQ(FieldCreatedBy, v))
+}
+
+// CreatedByIn applies the In predicate on the "created_by" field.
+func CreatedByIn(vs ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIn(FieldCreatedBy, vs...))
+}
+
+// CreatedByNotIn applies the NotIn predicate on the "created_by" field.
+func CreatedByNotIn(vs ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotIn(FieldCreatedBy, vs...))
+}
+
+// CreatedByGT applies the GT predicate on the "created_by" field.
+func CreatedByGT(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGT(FieldCreatedBy, v))
+}
+
+// CreatedByGTE applies the GTE predicate on the "created_by" field.
+func CreatedByGTE(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGTE(FieldCreatedBy, v))
+}
+
+// CreatedByLT applies the LT predicate on the "created_by" field.
+func CreatedByLT(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLT(FieldCreatedBy, v))
+}
+
+// CreatedByLTE applies the LTE predicate on the "created_by" field.
+func CreatedByLTE(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLTE(FieldCreatedBy, v))
+}
+
+// CreatedByContains applies the Contains predicate on the "created_by" field.
+func CreatedByContains(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContains(FieldCreatedBy, v))
+}
+
+// CreatedByHasPrefix applies the HasPrefix predicate on the "created_by" field.
+func CreatedByHasPrefix(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldHasPrefix(FieldCreatedBy, v))
+}
+
+// CreatedByHasSuffix applies the HasSuffix predicate on the "created_by" field.
+func CreatedByHasSuffix(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldHasSuffix(FieldCreatedBy, v))
+}
+
+// CreatedByIsNil applies the IsNil predicate on the "created_by" field.
+func CreatedByIsNil() predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIsNull(FieldCreatedBy))
+}
+
+// CreatedByNotNil applies the NotNil predicate on the "created_by" field.
+func CreatedByNotNil() predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotNull(FieldCreatedBy))
+}
+
+// CreatedByEqualFold applies the EqualFold predicate on the "created_by" field.
+func CreatedByEqualFold(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEqualFold(FieldCreatedBy, v))
+}
+
+// CreatedByContainsFold applies the ContainsFold predicate on the "created_by" field.
+func CreatedByContainsFold(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContainsFold(FieldCreatedBy, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// CreatedAtIsNil applies the IsNil predicate on the "created_at" field.
+func CreatedAtIsNil() predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIsNull(FieldCreatedAt))
+}
+
+// CreatedAtNotNil applies the NotNil predicate on the "created_at" field.
+func CreatedAtNotNil() predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotNull(FieldCreatedAt))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v int64) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIsNil applies the IsNil predicate on the "updated_at" field.
+func UpdatedAtIsNil() predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIsNull(FieldUpdatedAt))
+}
+
+// UpdatedAtNotNil applies the NotNil predicate on the "updated_at" field.
+func UpdatedAtNotNil() predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotNull(FieldUpdatedAt))
+}
+
+// FileIDEQ applies the EQ predicate on the "file_id" field.
+func FileIDEQ(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldFileID, v))
+}
+
+// FileIDNEQ applies the NEQ predicate on the "file_id" field.
+func FileIDNEQ(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNEQ(FieldFileID, v))
+}
+
+// FileIDIn applies the In predicate on the "file_id" field.
+func FileIDIn(vs ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIn(FieldFileID, vs...))
+}
+
+// FileIDNotIn applies the NotIn predicate on the "file_id" field.
+func FileIDNotIn(vs ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotIn(FieldFileID, vs...))
+}
+
+// FileIDGT applies the GT predicate on the "file_id" field.
+func FileIDGT(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGT(FieldFileID, v))
+}
+
+// FileIDGTE applies the GTE predicate on the "file_id" field.
+func FileIDGTE(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGTE(FieldFileID, v))
+}
+
+// FileIDLT applies the LT predicate on the "file_id" field.
+func FileIDLT(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLT(FieldFileID, v))
+}
+
+// FileIDLTE applies the LTE predicate on the "file_id" field.
+func FileIDLTE(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLTE(FieldFileID, v))
+}
+
+// FileIDContains applies the Contains predicate on the "file_id" field.
+func FileIDContains(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContains(FieldFileID, v))
+}
+
+// FileIDHasPrefix applies the HasPrefix predicate on the "file_id" field.
+func FileIDHasPrefix(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldHasPrefix(FieldFileID, v))
+}
+
+// FileIDHasSuffix applies the HasSuffix predicate on the "file_id" field.
+func FileIDHasSuffix(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldHasSuffix(FieldFileID, v))
+}
+
+// FileIDEqualFold applies the EqualFold predicate on the "file_id" field.
+func FileIDEqualFold(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEqualFold(FieldFileID, v))
+}
+
+// FileIDContainsFold applies the ContainsFold predicate on the "file_id" field.
+func FileIDContainsFold(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContainsFold(FieldFileID, v))
+}
+
+// EntityTypeEQ applies the EQ predicate on the "entity_type" field.
+func EntityTypeEQ(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldEntityType, v))
+}
+
+// EntityTypeNEQ applies the NEQ predicate on the "entity_type" field.
+func EntityTypeNEQ(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNEQ(FieldEntityType, v))
+}
+
+// EntityTypeIn applies the In predicate on the "entity_type" field.
+func EntityTypeIn(vs ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIn(FieldEntityType, vs...))
+}
+
+// EntityTypeNotIn applies the NotIn predicate on the "entity_type" field.
+func EntityTypeNotIn(vs ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotIn(FieldEntityType, vs...))
+}
+
+// EntityTypeGT applies the GT predicate on the "entity_type" field.
+func EntityTypeGT(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGT(FieldEntityType, v))
+}
+
+// EntityTypeGTE applies the GTE predicate on the "entity_type" field.
+func EntityTypeGTE(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGTE(FieldEntityType, v))
+}
+
+// EntityTypeLT applies the LT predicate on the "entity_type" field.
+func EntityTypeLT(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLT(FieldEntityType, v))
+}
+
+// EntityTypeLTE applies the LTE predicate on the "entity_type" field.
+func EntityTypeLTE(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLTE(FieldEntityType, v))
+}
+
+// EntityTypeContains applies the Contains predicate on the "entity_type" field.
+func EntityTypeContains(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContains(FieldEntityType, v))
+}
+
+// EntityTypeHasPrefix applies the HasPrefix predicate on the "entity_type" field.
+func EntityTypeHasPrefix(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldHasPrefix(FieldEntityType, v))
+}
+
+// EntityTypeHasSuffix applies the HasSuffix predicate on the "entity_type" field.
+func EntityTypeHasSuffix(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldHasSuffix(FieldEntityType, v))
+}
+
+// EntityTypeEqualFold applies the EqualFold predicate on the "entity_type" field.
+func EntityTypeEqualFold(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEqualFold(FieldEntityType, v))
+}
+
+// EntityTypeContainsFold applies the ContainsFold predicate on the "entity_type" field.
+func EntityTypeContainsFold(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContainsFold(FieldEntityType, v))
+}
+
+// EntityIDEQ applies the EQ predicate on the "entity_id" field.
+func EntityIDEQ(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldEntityID, v))
+}
+
+// EntityIDNEQ applies the NEQ predicate on the "entity_id" field.
+func EntityIDNEQ(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNEQ(FieldEntityID, v))
+}
+
+// EntityIDIn applies the In predicate on the "entity_id" field.
+func EntityIDIn(vs ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIn(FieldEntityID, vs...))
+}
+
+// EntityIDNotIn applies the NotIn predicate on the "entity_id" field.
+func EntityIDNotIn(vs ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotIn(FieldEntityID, vs...))
+}
+
+// EntityIDGT applies the GT predicate on the "entity_id" field.
+func EntityIDGT(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGT(FieldEntityID, v))
+}
+
+// EntityIDGTE applies the GTE predicate on the "entity_id" field.
+func EntityIDGTE(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGTE(FieldEntityID, v))
+}
+
+// EntityIDLT applies the LT predicate on the "entity_id" field.
+func EntityIDLT(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLT(FieldEntityID, v))
+}
+
+// EntityIDLTE applies the LTE predicate on the "entity_id" field.
+func EntityIDLTE(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLTE(FieldEntityID, v))
+}
+
+// EntityIDContains applies the Contains predicate on the "entity_id" field.
+func EntityIDContains(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContains(FieldEntityID, v))
+}
+
+// EntityIDHasPrefix applies the HasPrefix predicate on the "entity_id" field.
+func EntityIDHasPrefix(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldHasPrefix(FieldEntityID, v))
+}
+
+// EntityIDHasSuffix applies the HasSuffix predicate on the "entity_id" field.
+func EntityIDHasSuffix(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldHasSuffix(FieldEntityID, v))
+}
+
+// EntityIDEqualFold applies the EqualFold predicate on the "entity_id" field.
+func EntityIDEqualFold(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEqualFold(FieldEntityID, v))
+}
+
+// EntityIDContainsFold applies the ContainsFold predicate on the "entity_id" field.
+func EntityIDContainsFold(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContainsFold(FieldEntityID, v))
+}
+
+// RelationEQ applies the EQ predicate on the "relation" field.
+func RelationEQ(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEQ(FieldRelation, v))
+}
+
+// RelationNEQ applies the NEQ predicate on the "relation" field.
+func RelationNEQ(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNEQ(FieldRelation, v))
+}
+
+// RelationIn applies the In predicate on the "relation" field.
+func RelationIn(vs ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldIn(FieldRelation, vs...))
+}
+
+// RelationNotIn applies the NotIn predicate on the "relation" field.
+func RelationNotIn(vs ...string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldNotIn(FieldRelation, vs...))
+}
+
+// RelationGT applies the GT predicate on the "relation" field.
+func RelationGT(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGT(FieldRelation, v))
+}
+
+// RelationGTE applies the GTE predicate on the "relation" field.
+func RelationGTE(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldGTE(FieldRelation, v))
+}
+
+// RelationLT applies the LT predicate on the "relation" field.
+func RelationLT(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLT(FieldRelation, v))
+}
+
+// RelationLTE applies the LTE predicate on the "relation" field.
+func RelationLTE(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldLTE(FieldRelation, v))
+}
+
+// RelationContains applies the Contains predicate on the "relation" field.
+func RelationContains(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContains(FieldRelation, v))
+}
+
+// RelationHasPrefix applies the HasPrefix predicate on the "relation" field.
+func RelationHasPrefix(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldHasPrefix(FieldRelation, v))
+}
+
+// RelationHasSuffix applies the HasSuffix predicate on the "relation" field.
+func RelationHasSuffix(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldHasSuffix(FieldRelation, v))
+}
+
+// RelationEqualFold applies the EqualFold predicate on the "relation" field.
+func RelationEqualFold(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldEqualFold(FieldRelation, v))
+}
+
+// RelationContainsFold applies the ContainsFold predicate on the "relation" field.
+func RelationContainsFold(v string) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.FieldContainsFold(FieldRelation, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.FileAttachment) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.FileAttachment) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.FileAttachment) predicate.FileAttachment {
+	return predicate.FileAttachment(sql.NotPredicates(p))
+}
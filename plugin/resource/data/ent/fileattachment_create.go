@@ -0,0 +1,891 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/plugin/resource/data/ent/fileattachment"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// FileAttachmentCreate is the builder for creating a FileAttachment entity.
+type FileAttachmentCreate struct {
+	config
+	mutation *FileAttachmentMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_c *FileAttachmentCreate) SetCreatedBy(v string) *FileAttachmentCreate {
+	_c.mutation.SetCreatedBy(v)
+	return _c
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_c *FileAttachmentCreate) SetNillableCreatedBy(v *string) *FileAttachmentCreate {
+	if v != nil {
+		_c.SetCreatedBy(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *FileAttachmentCreate) SetCreatedAt(v int64) *FileAttachmentCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *FileAttachmentCreate) SetNillableCreatedAt(v *int64) *FileAttachmentCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *FileAttachmentCreate) SetUpdatedAt(v int64) *FileAttachmentCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *FileAttachmentCreate) SetNillableUpdatedAt(v *int64) *FileAttachmentCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetFileID sets the "file_id" field.
+func (_c *FileAttachmentCreate) SetFileID(v string) *FileAttachmentCreate {
+	_c.mutation.SetFileID(v)
+	return _c
+}
+
+// SetEntityType sets the "entity_type" field.
+func (_c *FileAttachmentCreate) SetEntityType(v string) *FileAttachmentCreate {
+	_c.mutation.SetEntityType(v)
+	return _c
+}
+
+// SetEntityID sets the "entity_id" field.
+func (_c *FileAttachmentCreate) SetEntityID(v string) *FileAttachmentCreate {
+	_c.mutation.SetEntityID(v)
+	return _c
+}
+
+// SetRelation sets the "relation" field.
+func (_c *FileAttachmentCreate) SetRelation(v string) *FileAttachmentCreate {
+	_c.mutation.SetRelation(v)
+	return _c
+}
+
+// SetNillableRelation sets the "relation" field if the given value is not nil.
+func (_c *FileAttachmentCreate) SetNillableRelation(v *string) *FileAttachmentCreate {
+	if v != nil {
+		_c.SetRelation(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *FileAttachmentCreate) SetID(v string) *FileAttachmentCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *FileAttachmentCreate) SetNillableID(v *string) *FileAttachmentCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the FileAttachmentMutation object of the builder.
+func (_c *FileAttachmentCreate) Mutation() *FileAttachmentMutation {
+	return _c.mutation
+}
+
+// Save creates the FileAttachment in the database.
+func (_c *FileAttachmentCreate) Save(ctx context.Context) (*FileAttachment, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *FileAttachmentCreate) SaveX(ctx context.Context) *FileAttachment {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *FileAttachmentCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *FileAttachmentCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *FileAttachmentCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := fileattachment.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := fileattachment.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.Relation(); !ok {
+		v := fileattachment.DefaultRelation
+		_c.mutation.SetRelation(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := fileattachment.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *FileAttachmentCreate) check() error {
+	if _, ok := _c.mutation.FileID(); !ok {
+		return &ValidationError{Name: "file_id", err: errors.New(`ent: missing required field "FileAttachment.file_id"`)}
+	}
+	if _, ok := _c.mutation.EntityType(); !ok {
+		return &ValidationError{Name: "entity_type", err: errors.New(`ent: missing required field "FileAttachment.entity_type"`)}
+	}
+	if _, ok := _c.mutation.EntityID(); !ok {
+		return &ValidationError{Name: "entity_id", err: errors.New(`ent: missing required field "FileAttachment.entity_id"`)}
+	}
+	if _, ok := _c.mutation.Relation(); !ok {
+		return &ValidationError{Name: "relation", err: errors.New(`ent: missing required field "FileAttachment.relation"`)}
+	}
+	if v, ok := _c.mutation.ID(); ok {
+		if err := fileattachment.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "FileAttachment.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *FileAttachmentCreate) sqlSave(ctx context.Context) (*FileAttachment, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected FileAttachment.ID type: %T", _spec.ID.Value)
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *FileAttachmentCreate) createSpec() (*FileAttachment, *sqlgraph.CreateSpec) {
+	var (
+		_node = &FileAttachment{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(fileattachment.Table, sqlgraph.NewFieldSpec(fileattachment.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = _c.conflict
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.CreatedBy(); ok {
+		_spec.SetField(fileattachment.FieldCreatedBy, field.TypeString, value)
+		_node.CreatedBy = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(fileattachment.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(fileattachment.FieldUpdatedAt, field.TypeInt64, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := _c.mutation.FileID(); ok {
+		_spec.SetField(fileattachment.FieldFileID, field.TypeString, value)
+		_node.FileID = value
+	}
+	if value, ok := _c.mutation.EntityType(); ok {
+		_spec.SetField(fileattachment.FieldEntityType, field.TypeString, value)
+		_node.EntityType = value
+	}
+	if value, ok := _c.mutation.EntityID(); ok {
+		_spec.SetField(fileattachment.FieldEntityID, field.TypeString, value)
+		_node.EntityID = value
+	}
+	if value, ok := _c.mutation.Relation(); ok {
+		_spec.SetField(fileattachment.FieldRelation, field.TypeString, value)
+		_node.Relation = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.FileAttachment.Create().
+//		SetCreatedBy(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.FileAttachmentUpsert) {
+//			SetCreatedBy(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *FileAttachmentCreate) OnConflict(opts ...sql.ConflictOption) *FileAttachmentUpsertOne {
+	_c.conflict = opts
+	return &FileAttachmentUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.FileAttachment.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *FileAttachmentCreate) OnConflictColumns(columns ...string) *FileAttachmentUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &FileAttachmentUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// FileAttachmentUpsertOne is the builder for "upsert"-ing
+	//  one FileAttachment node.
+	FileAttachmentUpsertOne struct {
+		create *FileAttachmentCreate
+	}
+
+	// FileAttachmentUpsert is the "OnConflict" setter.
+	FileAttachmentUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetCreatedBy sets the "created_by" field.
+func (u *FileAttachmentUpsert) SetCreatedBy(v string) *FileAttachmentUpsert {
+	u.Set(fileattachment.FieldCreatedBy, v)
+	return u
+}
+
+// UpdateCreatedBy sets the "created_by" field to the value that was provided on create.
+func (u *FileAttachmentUpsert) UpdateCreatedBy() *FileAttachmentUpsert {
+	u.SetExcluded(fileattachment.FieldCreatedBy)
+	return u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (u *FileAttachmentUpsert) ClearCreatedBy() *FileAttachmentUpsert {
+	u.SetNull(fileattachment.FieldCreatedBy)
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *FileAttachmentUpsert) SetUpdatedAt(v int64) *FileAttachmentUpsert {
+	u.Set(fileattachment.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *FileAttachmentUpsert) UpdateUpdatedAt() *FileAttachmentUpsert {
+	u.SetExcluded(fileattachment.FieldUpdatedAt)
+	return u
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *FileAttachmentUpsert) AddUpdatedAt(v int64) *FileAttachmentUpsert {
+	u.Add(fileattachment.FieldUpdatedAt, v)
+	return u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (u *FileAttachmentUpsert) ClearUpdatedAt() *FileAttachmentUpsert {
+	u.SetNull(fileattachment.FieldUpdatedAt)
+	return u
+}
+
+// SetFileID sets the "file_id" field.
+func (u *FileAttachmentUpsert) SetFileID(v string) *FileAttachmentUpsert {
+	u.Set(fileattachment.FieldFileID, v)
+	return u
+}
+
+// UpdateFileID sets the "file_id" field to the value that was provided on create.
+func (u *FileAttachmentUpsert) UpdateFileID() *FileAttachmentUpsert {
+	u.SetExcluded(fileattachment.FieldFileID)
+	return u
+}
+
+// SetEntityType sets the "entity_type" field.
+func (u *FileAttachmentUpsert) SetEntityType(v string) *FileAttachmentUpsert {
+	u.Set(fileattachment.FieldEntityType, v)
+	return u
+}
+
+// UpdateEntityType sets the "entity_type" field to the value that was provided on create.
+func (u *FileAttachmentUpsert) UpdateEntityType() *FileAttachmentUpsert {
+	u.SetExcluded(fileattachment.FieldEntityType)
+	return u
+}
+
+// SetEntityID sets the "entity_id" field.
+func (u *FileAttachmentUpsert) SetEntityID(v string) *FileAttachmentUpsert {
+	u.Set(fileattachment.FieldEntityID, v)
+	return u
+}
+
+// UpdateEntityID sets the "entity_id" field to the value that was provided on create.
+func (u *FileAttachmentUpsert) UpdateEntityID() *FileAttachmentUpsert {
+	u.SetExcluded(fileattachment.FieldEntityID)
+	return u
+}
+
+// SetRelation sets the "relation" field.
+func (u *FileAttachmentUpsert) SetRelation(v string) *FileAttachmentUpsert {
+	u.Set(fileattachment.FieldRelation, v)
+	return u
+}
+
+// UpdateRelation sets the "relation" field to the value that was provided on create.
+func (u *FileAttachmentUpsert) UpdateRelation() *FileAttachmentUpsert {
+	u.SetExcluded(fileattachment.FieldRelation)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.FileAttachment.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(fileattachment.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *FileAttachmentUpsertOne) UpdateNewValues() *FileAttachmentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(fileattachment.FieldID)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(fileattachment.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.FileAttachment.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *FileAttachmentUpsertOne) Ignore() *FileAttachmentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *FileAttachmentUpsertOne) DoNothing() *FileAttachmentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the FileAttachmentCreate.OnConflict
+// documentation for more info.
+func (u *FileAttachmentUpsertOne) Update(set func(*FileAttachmentUpsert)) *FileAttachmentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&FileAttachmentUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (u *FileAttachmentUpsertOne) SetCreatedBy(v string) *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetCreatedBy(v)
+	})
+}
+
+// UpdateCreatedBy sets the "created_by" field to the value that was provided on create.
+func (u *FileAttachmentUpsertOne) UpdateCreatedBy() *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateCreatedBy()
+	})
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (u *FileAttachmentUpsertOne) ClearCreatedBy() *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.ClearCreatedBy()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *FileAttachmentUpsertOne) SetUpdatedAt(v int64) *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *FileAttachmentUpsertOne) AddUpdatedAt(v int64) *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.AddUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *FileAttachmentUpsertOne) UpdateUpdatedAt() *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (u *FileAttachmentUpsertOne) ClearUpdatedAt() *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.ClearUpdatedAt()
+	})
+}
+
+// SetFileID sets the "file_id" field.
+func (u *FileAttachmentUpsertOne) SetFileID(v string) *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetFileID(v)
+	})
+}
+
+// UpdateFileID sets the "file_id" field to the value that was provided on create.
+func (u *FileAttachmentUpsertOne) UpdateFileID() *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateFileID()
+	})
+}
+
+// SetEntityType sets the "entity_type" field.
+func (u *FileAttachmentUpsertOne) SetEntityType(v string) *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetEntityType(v)
+	})
+}
+
+// UpdateEntityType sets the "entity_type" field to the value that was provided on create.
+func (u *FileAttachmentUpsertOne) UpdateEntityType() *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateEntityType()
+	})
+}
+
+// SetEntityID sets the "entity_id" field.
+func (u *FileAttachmentUpsertOne) SetEntityID(v string) *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetEntityID(v)
+	})
+}
+
+// UpdateEntityID sets the "entity_id" field to the value that was provided on create.
+func (u *FileAttachmentUpsertOne) UpdateEntityID() *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateEntityID()
+	})
+}
+
+// SetRelation sets the "relation" field.
+func (u *FileAttachmentUpsertOne) SetRelation(v string) *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetRelation(v)
+	})
+}
+
+// UpdateRelation sets the "relation" field to the value that was provided on create.
+func (u *FileAttachmentUpsertOne) UpdateRelation() *FileAttachmentUpsertOne {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateRelation()
+	})
+}
+
+// Exec executes the query.
+func (u *FileAttachmentUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for FileAttachmentCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *FileAttachmentUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *FileAttachmentUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: FileAttachmentUpsertOne.ID is not supported by MySQL driver. Use FileAttachmentUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *FileAttachmentUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// FileAttachmentCreateBulk is the builder for creating many FileAttachment entities in bulk.
+type FileAttachmentCreateBulk struct {
+	config
+	err      error
+	builders []*FileAttachmentCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the FileAttachment entities in the database.
+func (_c *FileAttachmentCreateBulk) Save(ctx context.Context) ([]*FileAttachment, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*FileAttachment, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*FileAttachmentMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *FileAttachmentCreateBulk) SaveX(ctx context.Context) []*FileAttachment {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *FileAttachmentCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *FileAttachmentCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.FileAttachment.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.FileAttachmentUpsert) {
+//			SetCreatedBy(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *FileAttachmentCreateBulk) OnConflict(opts ...sql.ConflictOption) *FileAttachmentUpsertBulk {
+	_c.conflict = opts
+	return &FileAttachmentUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.FileAttachment.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *FileAttachmentCreateBulk) OnConflictColumns(columns ...string) *FileAttachmentUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &FileAttachmentUpsertBulk{
+		create: _c,
+	}
+}
+
+// FileAttachmentUpsertBulk is the builder for "upsert"-ing
+// a bulk of FileAttachment nodes.
+type FileAttachmentUpsertBulk struct {
+	create *FileAttachmentCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.FileAttachment.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(fileattachment.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *FileAttachmentUpsertBulk) UpdateNewValues() *FileAttachmentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(fileattachment.FieldID)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(fileattachment.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.FileAttachment.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *FileAttachmentUpsertBulk) Ignore() *FileAttachmentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *FileAttachmentUpsertBulk) DoNothing() *FileAttachmentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the FileAttachmentCreateBulk.OnConflict
+// documentation for more info.
+func (u *FileAttachmentUpsertBulk) Update(set func(*FileAttachmentUpsert)) *FileAttachmentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&FileAttachmentUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (u *FileAttachmentUpsertBulk) SetCreatedBy(v string) *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetCreatedBy(v)
+	})
+}
+
+// UpdateCreatedBy sets the "created_by" field to the value that was provided on create.
+func (u *FileAttachmentUpsertBulk) UpdateCreatedBy() *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateCreatedBy()
+	})
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (u *FileAttachmentUpsertBulk) ClearCreatedBy() *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.ClearCreatedBy()
+	})
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *FileAttachmentUpsertBulk) SetUpdatedAt(v int64) *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *FileAttachmentUpsertBulk) AddUpdatedAt(v int64) *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.AddUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *FileAttachmentUpsertBulk) UpdateUpdatedAt() *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (u *FileAttachmentUpsertBulk) ClearUpdatedAt() *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.ClearUpdatedAt()
+	})
+}
+
+// SetFileID sets the "file_id" field.
+func (u *FileAttachmentUpsertBulk) SetFileID(v string) *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetFileID(v)
+	})
+}
+
+// UpdateFileID sets the "file_id" field to the value that was provided on create.
+func (u *FileAttachmentUpsertBulk) UpdateFileID() *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateFileID()
+	})
+}
+
+// SetEntityType sets the "entity_type" field.
+func (u *FileAttachmentUpsertBulk) SetEntityType(v string) *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetEntityType(v)
+	})
+}
+
+// UpdateEntityType sets the "entity_type" field to the value that was provided on create.
+func (u *FileAttachmentUpsertBulk) UpdateEntityType() *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateEntityType()
+	})
+}
+
+// SetEntityID sets the "entity_id" field.
+func (u *FileAttachmentUpsertBulk) SetEntityID(v string) *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetEntityID(v)
+	})
+}
+
+// UpdateEntityID sets the "entity_id" field to the value that was provided on create.
+func (u *FileAttachmentUpsertBulk) UpdateEntityID() *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateEntityID()
+	})
+}
+
+// SetRelation sets the "relation" field.
+func (u *FileAttachmentUpsertBulk) SetRelation(v string) *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.SetRelation(v)
+	})
+}
+
+// UpdateRelation sets the "relation" field to the value that was provided on create.
+func (u *FileAttachmentUpsertBulk) UpdateRelation() *FileAttachmentUpsertBulk {
+	return u.Update(func(s *FileAttachmentUpsert) {
+		s.UpdateRelation()
+	})
+}
+
+// Exec executes the query.
+func (u *FileAttachmentUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the FileAttachmentCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for FileAttachmentCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *FileAttachmentUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
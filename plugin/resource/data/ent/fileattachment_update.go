@@ -0,0 +1,443 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/plugin/resource/data/ent/fileattachment"
+	"ncobase/plugin/resource/data/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// FileAttachmentUpdate is the builder for updating FileAttachment entities.
+type FileAttachmentUpdate struct {
+	config
+	hooks    []Hook
+	mutation *FileAttachmentMutation
+}
+
+// Where appends a list predicates to the FileAttachmentUpdate builder.
+func (_u *FileAttachmentUpdate) Where(ps ...predicate.FileAttachment) *FileAttachmentUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *FileAttachmentUpdate) SetCreatedBy(v string) *FileAttachmentUpdate {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *FileAttachmentUpdate) SetNillableCreatedBy(v *string) *FileAttachmentUpdate {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *FileAttachmentUpdate) ClearCreatedBy() *FileAttachmentUpdate {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *FileAttachmentUpdate) SetUpdatedAt(v int64) *FileAttachmentUpdate {
+	_u.mutation.ResetUpdatedAt()
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddUpdatedAt adds value to the "updated_at" field.
+func (_u *FileAttachmentUpdate) AddUpdatedAt(v int64) *FileAttachmentUpdate {
+	_u.mutation.AddUpdatedAt(v)
+	return _u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (_u *FileAttachmentUpdate) ClearUpdatedAt() *FileAttachmentUpdate {
+	_u.mutation.ClearUpdatedAt()
+	return _u
+}
+
+// SetFileID sets the "file_id" field.
+func (_u *FileAttachmentUpdate) SetFileID(v string) *FileAttachmentUpdate {
+	_u.mutation.SetFileID(v)
+	return _u
+}
+
+// SetNillableFileID sets the "file_id" field if the given value is not nil.
+func (_u *FileAttachmentUpdate) SetNillableFileID(v *string) *FileAttachmentUpdate {
+	if v != nil {
+		_u.SetFileID(*v)
+	}
+	return _u
+}
+
+// SetEntityType sets the "entity_type" field.
+func (_u *FileAttachmentUpdate) SetEntityType(v string) *FileAttachmentUpdate {
+	_u.mutation.SetEntityType(v)
+	return _u
+}
+
+// SetNillableEntityType sets the "entity_type" field if the given value is not nil.
+func (_u *FileAttachmentUpdate) SetNillableEntityType(v *string) *FileAttachmentUpdate {
+	if v != nil {
+		_u.SetEntityType(*v)
+	}
+	return _u
+}
+
+// SetEntityID sets the "entity_id" field.
+func (_u *FileAttachmentUpdate) SetEntityID(v string) *FileAttachmentUpdate {
+	_u.mutation.SetEntityID(v)
+	return _u
+}
+
+// SetNillableEntityID sets the "entity_id" field if the given value is not nil.
+func (_u *FileAttachmentUpdate) SetNillableEntityID(v *string) *FileAttachmentUpdate {
+	if v != nil {
+		_u.SetEntityID(*v)
+	}
+	return _u
+}
+
+// SetRelation sets the "relation" field.
+func (_u *FileAttachmentUpdate) SetRelation(v string) *FileAttachmentUpdate {
+	_u.mutation.SetRelation(v)
+	return _u
+}
+
+// SetNillableRelation sets the "relation" field if the given value is not nil.
+func (_u *FileAttachmentUpdate) SetNillableRelation(v *string) *FileAttachmentUpdate {
+	if v != nil {
+		_u.SetRelation(*v)
+	}
+	return _u
+}
+
+// Mutation returns the FileAttachmentMutation object of the builder.
+func (_u *FileAttachmentUpdate) Mutation() *FileAttachmentMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *FileAttachmentUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *FileAttachmentUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *FileAttachmentUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *FileAttachmentUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *FileAttachmentUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok && !_u.mutation.UpdatedAtCleared() {
+		v := fileattachment.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *FileAttachmentUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(fileattachment.Table, fileattachment.Columns, sqlgraph.NewFieldSpec(fileattachment.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CreatedBy(); ok {
+		_spec.SetField(fileattachment.FieldCreatedBy, field.TypeString, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(fileattachment.FieldCreatedBy, field.TypeString)
+	}
+	if _u.mutation.CreatedAtCleared() {
+		_spec.ClearField(fileattachment.FieldCreatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(fileattachment.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(fileattachment.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.UpdatedAtCleared() {
+		_spec.ClearField(fileattachment.FieldUpdatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.FileID(); ok {
+		_spec.SetField(fileattachment.FieldFileID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.EntityType(); ok {
+		_spec.SetField(fileattachment.FieldEntityType, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.EntityID(); ok {
+		_spec.SetField(fileattachment.FieldEntityID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Relation(); ok {
+		_spec.SetField(fileattachment.FieldRelation, field.TypeString, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{fileattachment.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// FileAttachmentUpdateOne is the builder for updating a single FileAttachment entity.
+type FileAttachmentUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *FileAttachmentMutation
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *FileAttachmentUpdateOne) SetCreatedBy(v string) *FileAttachmentUpdateOne {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *FileAttachmentUpdateOne) SetNillableCreatedBy(v *string) *FileAttachmentUpdateOne {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *FileAttachmentUpdateOne) ClearCreatedBy() *FileAttachmentUpdateOne {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *FileAttachmentUpdateOne) SetUpdatedAt(v int64) *FileAttachmentUpdateOne {
+	_u.mutation.ResetUpdatedAt()
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddUpdatedAt adds value to the "updated_at" field.
+func (_u *FileAttachmentUpdateOne) AddUpdatedAt(v int64) *FileAttachmentUpdateOne {
+	_u.mutation.AddUpdatedAt(v)
+	return _u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (_u *FileAttachmentUpdateOne) ClearUpdatedAt() *FileAttachmentUpdateOne {
+	_u.mutation.ClearUpdatedAt()
+	return _u
+}
+
+// SetFileID sets the "file_id" field.
+func (_u *FileAttachmentUpdateOne) SetFileID(v string) *FileAttachmentUpdateOne {
+	_u.mutation.SetFileID(v)
+	return _u
+}
+
+// SetNillableFileID sets the "file_id" field if the given value is not nil.
+func (_u *FileAttachmentUpdateOne) SetNillableFileID(v *string) *FileAttachmentUpdateOne {
+	if v != nil {
+		_u.SetFileID(*v)
+	}
+	return _u
+}
+
+// SetEntityType sets the "entity_type" field.
+func (_u *FileAttachmentUpdateOne) SetEntityType(v string) *FileAttachmentUpdateOne {
+	_u.mutation.SetEntityType(v)
+	return _u
+}
+
+// SetNillableEntityType sets the "entity_type" field if the given value is not nil.
+func (_u *FileAttachmentUpdateOne) SetNillableEntityType(v *string) *FileAttachmentUpdateOne {
+	if v != nil {
+		_u.SetEntityType(*v)
+	}
+	return _u
+}
+
+// SetEntityID sets the "entity_id" field.
+func (_u *FileAttachmentUpdateOne) SetEntityID(v string) *FileAttachmentUpdateOne {
+	_u.mutation.SetEntityID(v)
+	return _u
+}
+
+// SetNillableEntityID sets the "entity_id" field if the given value is not nil.
+func (_u *FileAttachmentUpdateOne) SetNillableEntityID(v *string) *FileAttachmentUpdateOne {
+	if v != nil {
+		_u.SetEntityID(*v)
+	}
+	return _u
+}
+
+// SetRelation sets the "relation" field.
+func (_u *FileAttachmentUpdateOne) SetRelation(v string) *FileAttachmentUpdateOne {
+	_u.mutation.SetRelation(v)
+	return _u
+}
+
+// SetNillableRelation sets the "relation" field if the given value is not nil.
+func (_u *FileAttachmentUpdateOne) SetNillableRelation(v *string) *FileAttachmentUpdateOne {
+	if v != nil {
+		_u.SetRelation(*v)
+	}
+	return _u
+}
+
+// Mutation returns the FileAttachmentMutation object of the builder.
+func (_u *FileAttachmentUpdateOne) Mutation() *FileAttachmentMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the FileAttachmentUpdate builder.
+func (_u *FileAttachmentUpdateOne) Where(ps ...predicate.FileAttachment) *FileAttachmentUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *FileAttachmentUpdateOne) Select(field string, fields ...string) *FileAttachmentUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated FileAttachment entity.
+func (_u *FileAttachmentUpdateOne) Save(ctx context.Context) (*FileAttachment, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *FileAttachmentUpdateOne) SaveX(ctx context.Context) *FileAttachment {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *FileAttachmentUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *FileAttachmentUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *FileAttachmentUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok && !_u.mutation.UpdatedAtCleared() {
+		v := fileattachment.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *FileAttachmentUpdateOne) sqlSave(ctx context.Context) (_node *FileAttachment, err error) {
+	_spec := sqlgraph.NewUpdateSpec(fileattachment.Table, fileattachment.Columns, sqlgraph.NewFieldSpec(fileattachment.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "FileAttachment.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, fileattachment.FieldID)
+		for _, f := range fields {
+			if !fileattachment.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != fileattachment.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CreatedBy(); ok {
+		_spec.SetField(fileattachment.FieldCreatedBy, field.TypeString, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(fileattachment.FieldCreatedBy, field.TypeString)
+	}
+	if _u.mutation.CreatedAtCleared() {
+		_spec.ClearField(fileattachment.FieldCreatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(fileattachment.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(fileattachment.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.UpdatedAtCleared() {
+		_spec.ClearField(fileattachment.FieldUpdatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.FileID(); ok {
+		_spec.SetField(fileattachment.FieldFileID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.EntityType(); ok {
+		_spec.SetField(fileattachment.FieldEntityType, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.EntityID(); ok {
+		_spec.SetField(fileattachment.FieldEntityID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Relation(); ok {
+		_spec.SetField(fileattachment.FieldRelation, field.TypeString, value)
+	}
+	_node = &FileAttachment{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{fileattachment.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -20,6 +20,30 @@ func (f FileFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error)
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.FileMutation", m)
 }
 
+// The FileAttachmentFunc type is an adapter to allow the use of ordinary
+// function as FileAttachment mutator.
+type FileAttachmentFunc func(context.Context, *ent.FileAttachmentMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f FileAttachmentFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.FileAttachmentMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.FileAttachmentMutation", m)
+}
+
+// The IndexOutboxFunc type is an adapter to allow the use of ordinary
+// function as IndexOutbox mutator.
+type IndexOutboxFunc func(context.Context, *ent.IndexOutboxMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f IndexOutboxFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.IndexOutboxMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.IndexOutboxMutation", m)
+}
+
 // Condition is a hook condition function.
 type Condition func(context.Context, ent.Mutation) bool
 
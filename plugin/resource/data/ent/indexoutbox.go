@@ -0,0 +1,181 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"ncobase/plugin/resource/data/ent/indexoutbox"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// IndexOutbox is the model entity for the IndexOutbox schema.
+type IndexOutbox struct {
+	config `json:"-"`
+	// ID of the ent.
+	// primary key
+	ID string `json:"id,omitempty"`
+	// created at
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// updated at
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+	// File whose index document is updated
+	FileID string `json:"file_id,omitempty"`
+	// Index operation: index, delete
+	Operation string `json:"operation,omitempty"`
+	// Delivery status: pending, done, dead
+	Status string `json:"status,omitempty"`
+	// Delivery attempts made
+	Attempts int `json:"attempts,omitempty"`
+	// Error of the last failed attempt
+	LastError string `json:"last_error,omitempty"`
+	// Earliest time of the next attempt, in milliseconds
+	NextAttemptAt int64 `json:"next_attempt_at,omitempty"`
+	selectValues  sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*IndexOutbox) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case indexoutbox.FieldCreatedAt, indexoutbox.FieldUpdatedAt, indexoutbox.FieldAttempts, indexoutbox.FieldNextAttemptAt:
+			values[i] = new(sql.NullInt64)
+		case indexoutbox.FieldID, indexoutbox.FieldFileID, indexoutbox.FieldOperation, indexoutbox.FieldStatus, indexoutbox.FieldLastError:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the IndexOutbox fields.
+func (_m *IndexOutbox) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case indexoutbox.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				_m.ID = value.String
+			}
+		case indexoutbox.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Int64
+			}
+		case indexoutbox.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Int64
+			}
+		case indexoutbox.FieldFileID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field file_id", values[i])
+			} else if value.Valid {
+				_m.FileID = value.String
+			}
+		case indexoutbox.FieldOperation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field operation", values[i])
+			} else if value.Valid {
+				_m.Operation = value.String
+			}
+		case indexoutbox.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				_m.Status = value.String
+			}
+		case indexoutbox.FieldAttempts:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field attempts", values[i])
+			} else if value.Valid {
+				_m.Attempts = int(value.Int64)
+			}
+		case indexoutbox.FieldLastError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field last_error", values[i])
+			} else if value.Valid {
+				_m.LastError = value.String
+			}
+		case indexoutbox.FieldNextAttemptAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field next_attempt_at", values[i])
+			} else if value.Valid {
+				_m.NextAttemptAt = value.Int64
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the IndexOutbox.
+// This includes values selected through modifiers, order, etc.
+func (_m *IndexOutbox) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this IndexOutbox.
+// Note that you need to call IndexOutbox.Unwrap() before calling this method if this IndexOutbox
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *IndexOutbox) Update() *IndexOutboxUpdateOne {
+	return NewIndexOutboxClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the IndexOutbox entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *IndexOutbox) Unwrap() *IndexOutbox {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: IndexOutbox is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *IndexOutbox) String() string {
+	var builder strings.Builder
+	builder.WriteString("IndexOutbox(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CreatedAt))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UpdatedAt))
+	builder.WriteString(", ")
+	builder.WriteString("file_id=")
+	builder.WriteString(_m.FileID)
+	builder.WriteString(", ")
+	builder.WriteString("operation=")
+	builder.WriteString(_m.Operation)
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(_m.Status)
+	builder.WriteString(", ")
+	builder.WriteString("attempts=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Attempts))
+	builder.WriteString(", ")
+	builder.WriteString("last_error=")
+	builder.WriteString(_m.LastError)
+	builder.WriteString(", ")
+	builder.WriteString("next_attempt_at=")
+	builder.WriteString(fmt.Sprintf("%v", _m.NextAttemptAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// IndexOutboxes is a parsable slice of IndexOutbox.
+type IndexOutboxes []*IndexOutbox
@@ -0,0 +1,1008 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/plugin/resource/data/ent/indexoutbox"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// IndexOutboxCreate is the builder for creating a IndexOutbox entity.
+type IndexOutboxCreate struct {
+	config
+	mutation *IndexOutboxMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *IndexOutboxCreate) SetCreatedAt(v int64) *IndexOutboxCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *IndexOutboxCreate) SetNillableCreatedAt(v *int64) *IndexOutboxCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *IndexOutboxCreate) SetUpdatedAt(v int64) *IndexOutboxCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *IndexOutboxCreate) SetNillableUpdatedAt(v *int64) *IndexOutboxCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetFileID sets the "file_id" field.
+func (_c *IndexOutboxCreate) SetFileID(v string) *IndexOutboxCreate {
+	_c.mutation.SetFileID(v)
+	return _c
+}
+
+// SetOperation sets the "operation" field.
+func (_c *IndexOutboxCreate) SetOperation(v string) *IndexOutboxCreate {
+	_c.mutation.SetOperation(v)
+	return _c
+}
+
+// SetStatus sets the "status" field.
+func (_c *IndexOutboxCreate) SetStatus(v string) *IndexOutboxCreate {
+	_c.mutation.SetStatus(v)
+	return _c
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_c *IndexOutboxCreate) SetNillableStatus(v *string) *IndexOutboxCreate {
+	if v != nil {
+		_c.SetStatus(*v)
+	}
+	return _c
+}
+
+// SetAttempts sets the "attempts" field.
+func (_c *IndexOutboxCreate) SetAttempts(v int) *IndexOutboxCreate {
+	_c.mutation.SetAttempts(v)
+	return _c
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (_c *IndexOutboxCreate) SetNillableAttempts(v *int) *IndexOutboxCreate {
+	if v != nil {
+		_c.SetAttempts(*v)
+	}
+	return _c
+}
+
+// SetLastError sets the "last_error" field.
+func (_c *IndexOutboxCreate) SetLastError(v string) *IndexOutboxCreate {
+	_c.mutation.SetLastError(v)
+	return _c
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_c *IndexOutboxCreate) SetNillableLastError(v *string) *IndexOutboxCreate {
+	if v != nil {
+		_c.SetLastError(*v)
+	}
+	return _c
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (_c *IndexOutboxCreate) SetNextAttemptAt(v int64) *IndexOutboxCreate {
+	_c.mutation.SetNextAttemptAt(v)
+	return _c
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (_c *IndexOutboxCreate) SetNillableNextAttemptAt(v *int64) *IndexOutboxCreate {
+	if v != nil {
+		_c.SetNextAttemptAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *IndexOutboxCreate) SetID(v string) *IndexOutboxCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *IndexOutboxCreate) SetNillableID(v *string) *IndexOutboxCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// Mutation returns the IndexOutboxMutation object of the builder.
+func (_c *IndexOutboxCreate) Mutation() *IndexOutboxMutation {
+	return _c.mutation
+}
+
+// Save creates the IndexOutbox in the database.
+func (_c *IndexOutboxCreate) Save(ctx context.Context) (*IndexOutbox, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *IndexOutboxCreate) SaveX(ctx context.Context) *IndexOutbox {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *IndexOutboxCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *IndexOutboxCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *IndexOutboxCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := indexoutbox.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := indexoutbox.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		v := indexoutbox.DefaultStatus
+		_c.mutation.SetStatus(v)
+	}
+	if _, ok := _c.mutation.Attempts(); !ok {
+		v := indexoutbox.DefaultAttempts
+		_c.mutation.SetAttempts(v)
+	}
+	if _, ok := _c.mutation.NextAttemptAt(); !ok {
+		v := indexoutbox.DefaultNextAttemptAt
+		_c.mutation.SetNextAttemptAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := indexoutbox.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *IndexOutboxCreate) check() error {
+	if _, ok := _c.mutation.FileID(); !ok {
+		return &ValidationError{Name: "file_id", err: errors.New(`ent: missing required field "IndexOutbox.file_id"`)}
+	}
+	if _, ok := _c.mutation.Operation(); !ok {
+		return &ValidationError{Name: "operation", err: errors.New(`ent: missing required field "IndexOutbox.operation"`)}
+	}
+	if _, ok := _c.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "IndexOutbox.status"`)}
+	}
+	if _, ok := _c.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "IndexOutbox.attempts"`)}
+	}
+	if _, ok := _c.mutation.NextAttemptAt(); !ok {
+		return &ValidationError{Name: "next_attempt_at", err: errors.New(`ent: missing required field "IndexOutbox.next_attempt_at"`)}
+	}
+	if v, ok := _c.mutation.ID(); ok {
+		if err := indexoutbox.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "IndexOutbox.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *IndexOutboxCreate) sqlSave(ctx context.Context) (*IndexOutbox, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected IndexOutbox.ID type: %T", _spec.ID.Value)
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *IndexOutboxCreate) createSpec() (*IndexOutbox, *sqlgraph.CreateSpec) {
+	var (
+		_node = &IndexOutbox{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(indexoutbox.Table, sqlgraph.NewFieldSpec(indexoutbox.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = _c.conflict
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(indexoutbox.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(indexoutbox.FieldUpdatedAt, field.TypeInt64, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := _c.mutation.FileID(); ok {
+		_spec.SetField(indexoutbox.FieldFileID, field.TypeString, value)
+		_node.FileID = value
+	}
+	if value, ok := _c.mutation.Operation(); ok {
+		_spec.SetField(indexoutbox.FieldOperation, field.TypeString, value)
+		_node.Operation = value
+	}
+	if value, ok := _c.mutation.Status(); ok {
+		_spec.SetField(indexoutbox.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
+	if value, ok := _c.mutation.Attempts(); ok {
+		_spec.SetField(indexoutbox.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	if value, ok := _c.mutation.LastError(); ok {
+		_spec.SetField(indexoutbox.FieldLastError, field.TypeString, value)
+		_node.LastError = value
+	}
+	if value, ok := _c.mutation.NextAttemptAt(); ok {
+		_spec.SetField(indexoutbox.FieldNextAttemptAt, field.TypeInt64, value)
+		_node.NextAttemptAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.IndexOutbox.Create().
+//		SetCreatedAt(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.IndexOutboxUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *IndexOutboxCreate) OnConflict(opts ...sql.ConflictOption) *IndexOutboxUpsertOne {
+	_c.conflict = opts
+	return &IndexOutboxUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.IndexOutbox.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *IndexOutboxCreate) OnConflictColumns(columns ...string) *IndexOutboxUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &IndexOutboxUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// IndexOutboxUpsertOne is the builder for "upsert"-ing
+	//  one IndexOutbox node.
+	IndexOutboxUpsertOne struct {
+		create *IndexOutboxCreate
+	}
+
+	// IndexOutboxUpsert is the "OnConflict" setter.
+	IndexOutboxUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *IndexOutboxUpsert) SetUpdatedAt(v int64) *IndexOutboxUpsert {
+	u.Set(indexoutbox.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *IndexOutboxUpsert) UpdateUpdatedAt() *IndexOutboxUpsert {
+	u.SetExcluded(indexoutbox.FieldUpdatedAt)
+	return u
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *IndexOutboxUpsert) AddUpdatedAt(v int64) *IndexOutboxUpsert {
+	u.Add(indexoutbox.FieldUpdatedAt, v)
+	return u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (u *IndexOutboxUpsert) ClearUpdatedAt() *IndexOutboxUpsert {
+	u.SetNull(indexoutbox.FieldUpdatedAt)
+	return u
+}
+
+// SetFileID sets the "file_id" field.
+func (u *IndexOutboxUpsert) SetFileID(v string) *IndexOutboxUpsert {
+	u.Set(indexoutbox.FieldFileID, v)
+	return u
+}
+
+// UpdateFileID sets the "file_id" field to the value that was provided on create.
+func (u *IndexOutboxUpsert) UpdateFileID() *IndexOutboxUpsert {
+	u.SetExcluded(indexoutbox.FieldFileID)
+	return u
+}
+
+// SetOperation sets the "operation" field.
+func (u *IndexOutboxUpsert) SetOperation(v string) *IndexOutboxUpsert {
+	u.Set(indexoutbox.FieldOperation, v)
+	return u
+}
+
+// UpdateOperation sets the "operation" field to the value that was provided on create.
+func (u *IndexOutboxUpsert) UpdateOperation() *IndexOutboxUpsert {
+	u.SetExcluded(indexoutbox.FieldOperation)
+	return u
+}
+
+// SetStatus sets the "status" field.
+func (u *IndexOutboxUpsert) SetStatus(v string) *IndexOutboxUpsert {
+	u.Set(indexoutbox.FieldStatus, v)
+	return u
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *IndexOutboxUpsert) UpdateStatus() *IndexOutboxUpsert {
+	u.SetExcluded(indexoutbox.FieldStatus)
+	return u
+}
+
+// SetAttempts sets the "attempts" field.
+func (u *IndexOutboxUpsert) SetAttempts(v int) *IndexOutboxUpsert {
+	u.Set(indexoutbox.FieldAttempts, v)
+	return u
+}
+
+// UpdateAttempts sets the "attempts" field to the value that was provided on create.
+func (u *IndexOutboxUpsert) UpdateAttempts() *IndexOutboxUpsert {
+	u.SetExcluded(indexoutbox.FieldAttempts)
+	return u
+}
+
+// AddAttempts adds v to the "attempts" field.
+func (u *IndexOutboxUpsert) AddAttempts(v int) *IndexOutboxUpsert {
+	u.Add(indexoutbox.FieldAttempts, v)
+	return u
+}
+
+// SetLastError sets the "last_error" field.
+func (u *IndexOutboxUpsert) SetLastError(v string) *IndexOutboxUpsert {
+	u.Set(indexoutbox.FieldLastError, v)
+	return u
+}
+
+// UpdateLastError sets the "last_error" field to the value that was provided on create.
+func (u *IndexOutboxUpsert) UpdateLastError() *IndexOutboxUpsert {
+	u.SetExcluded(indexoutbox.FieldLastError)
+	return u
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (u *IndexOutboxUpsert) ClearLastError() *IndexOutboxUpsert {
+	u.SetNull(indexoutbox.FieldLastError)
+	return u
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (u *IndexOutboxUpsert) SetNextAttemptAt(v int64) *IndexOutboxUpsert {
+	u.Set(indexoutbox.FieldNextAttemptAt, v)
+	return u
+}
+
+// UpdateNextAttemptAt sets the "next_attempt_at" field to the value that was provided on create.
+func (u *IndexOutboxUpsert) UpdateNextAttemptAt() *IndexOutboxUpsert {
+	u.SetExcluded(indexoutbox.FieldNextAttemptAt)
+	return u
+}
+
+// AddNextAttemptAt adds v to the "next_attempt_at" field.
+func (u *IndexOutboxUpsert) AddNextAttemptAt(v int64) *IndexOutboxUpsert {
+	u.Add(indexoutbox.FieldNextAttemptAt, v)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.IndexOutbox.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(indexoutbox.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *IndexOutboxUpsertOne) UpdateNewValues() *IndexOutboxUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(indexoutbox.FieldID)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(indexoutbox.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.IndexOutbox.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *IndexOutboxUpsertOne) Ignore() *IndexOutboxUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *IndexOutboxUpsertOne) DoNothing() *IndexOutboxUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the IndexOutboxCreate.OnConflict
+// documentation for more info.
+func (u *IndexOutboxUpsertOne) Update(set func(*IndexOutboxUpsert)) *IndexOutboxUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&IndexOutboxUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *IndexOutboxUpsertOne) SetUpdatedAt(v int64) *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *IndexOutboxUpsertOne) AddUpdatedAt(v int64) *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.AddUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *IndexOutboxUpsertOne) UpdateUpdatedAt() *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (u *IndexOutboxUpsertOne) ClearUpdatedAt() *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.ClearUpdatedAt()
+	})
+}
+
+// SetFileID sets the "file_id" field.
+func (u *IndexOutboxUpsertOne) SetFileID(v string) *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetFileID(v)
+	})
+}
+
+// UpdateFileID sets the "file_id" field to the value that was provided on create.
+func (u *IndexOutboxUpsertOne) UpdateFileID() *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateFileID()
+	})
+}
+
+// SetOperation sets the "operation" field.
+func (u *IndexOutboxUpsertOne) SetOperation(v string) *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetOperation(v)
+	})
+}
+
+// UpdateOperation sets the "operation" field to the value that was provided on create.
+func (u *IndexOutboxUpsertOne) UpdateOperation() *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateOperation()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *IndexOutboxUpsertOne) SetStatus(v string) *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *IndexOutboxUpsertOne) UpdateStatus() *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetAttempts sets the "attempts" field.
+func (u *IndexOutboxUpsertOne) SetAttempts(v int) *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetAttempts(v)
+	})
+}
+
+// AddAttempts adds v to the "attempts" field.
+func (u *IndexOutboxUpsertOne) AddAttempts(v int) *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.AddAttempts(v)
+	})
+}
+
+// UpdateAttempts sets the "attempts" field to the value that was provided on create.
+func (u *IndexOutboxUpsertOne) UpdateAttempts() *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateAttempts()
+	})
+}
+
+// SetLastError sets the "last_error" field.
+func (u *IndexOutboxUpsertOne) SetLastError(v string) *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetLastError(v)
+	})
+}
+
+// UpdateLastError sets the "last_error" field to the value that was provided on create.
+func (u *IndexOutboxUpsertOne) UpdateLastError() *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateLastError()
+	})
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (u *IndexOutboxUpsertOne) ClearLastError() *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.ClearLastError()
+	})
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (u *IndexOutboxUpsertOne) SetNextAttemptAt(v int64) *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetNextAttemptAt(v)
+	})
+}
+
+// AddNextAttemptAt adds v to the "next_attempt_at" field.
+func (u *IndexOutboxUpsertOne) AddNextAttemptAt(v int64) *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.AddNextAttemptAt(v)
+	})
+}
+
+// UpdateNextAttemptAt sets the "next_attempt_at" field to the value that was provided on create.
+func (u *IndexOutboxUpsertOne) UpdateNextAttemptAt() *IndexOutboxUpsertOne {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateNextAttemptAt()
+	})
+}
+
+// Exec executes the query.
+func (u *IndexOutboxUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for IndexOutboxCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *IndexOutboxUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *IndexOutboxUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: IndexOutboxUpsertOne.ID is not supported by MySQL driver. Use IndexOutboxUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *IndexOutboxUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// IndexOutboxCreateBulk is the builder for creating many IndexOutbox entities in bulk.
+type IndexOutboxCreateBulk struct {
+	config
+	err      error
+	builders []*IndexOutboxCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the IndexOutbox entities in the database.
+func (_c *IndexOutboxCreateBulk) Save(ctx context.Context) ([]*IndexOutbox, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*IndexOutbox, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*IndexOutboxMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *IndexOutboxCreateBulk) SaveX(ctx context.Context) []*IndexOutbox {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *IndexOutboxCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *IndexOutboxCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.IndexOutbox.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.IndexOutboxUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *IndexOutboxCreateBulk) OnConflict(opts ...sql.ConflictOption) *IndexOutboxUpsertBulk {
+	_c.conflict = opts
+	return &IndexOutboxUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.IndexOutbox.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *IndexOutboxCreateBulk) OnConflictColumns(columns ...string) *IndexOutboxUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &IndexOutboxUpsertBulk{
+		create: _c,
+	}
+}
+
+// IndexOutboxUpsertBulk is the builder for "upsert"-ing
+// a bulk of IndexOutbox nodes.
+type IndexOutboxUpsertBulk struct {
+	create *IndexOutboxCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.IndexOutbox.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(indexoutbox.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *IndexOutboxUpsertBulk) UpdateNewValues() *IndexOutboxUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(indexoutbox.FieldID)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(indexoutbox.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.IndexOutbox.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *IndexOutboxUpsertBulk) Ignore() *IndexOutboxUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *IndexOutboxUpsertBulk) DoNothing() *IndexOutboxUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the IndexOutboxCreateBulk.OnConflict
+// documentation for more info.
+func (u *IndexOutboxUpsertBulk) Update(set func(*IndexOutboxUpsert)) *IndexOutboxUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&IndexOutboxUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *IndexOutboxUpsertBulk) SetUpdatedAt(v int64) *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// AddUpdatedAt adds v to the "updated_at" field.
+func (u *IndexOutboxUpsertBulk) AddUpdatedAt(v int64) *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.AddUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *IndexOutboxUpsertBulk) UpdateUpdatedAt() *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (u *IndexOutboxUpsertBulk) ClearUpdatedAt() *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.ClearUpdatedAt()
+	})
+}
+
+// SetFileID sets the "file_id" field.
+func (u *IndexOutboxUpsertBulk) SetFileID(v string) *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetFileID(v)
+	})
+}
+
+// UpdateFileID sets the "file_id" field to the value that was provided on create.
+func (u *IndexOutboxUpsertBulk) UpdateFileID() *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateFileID()
+	})
+}
+
+// SetOperation sets the "operation" field.
+func (u *IndexOutboxUpsertBulk) SetOperation(v string) *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetOperation(v)
+	})
+}
+
+// UpdateOperation sets the "operation" field to the value that was provided on create.
+func (u *IndexOutboxUpsertBulk) UpdateOperation() *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateOperation()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *IndexOutboxUpsertBulk) SetStatus(v string) *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *IndexOutboxUpsertBulk) UpdateStatus() *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetAttempts sets the "attempts" field.
+func (u *IndexOutboxUpsertBulk) SetAttempts(v int) *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetAttempts(v)
+	})
+}
+
+// AddAttempts adds v to the "attempts" field.
+func (u *IndexOutboxUpsertBulk) AddAttempts(v int) *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.AddAttempts(v)
+	})
+}
+
+// UpdateAttempts sets the "attempts" field to the value that was provided on create.
+func (u *IndexOutboxUpsertBulk) UpdateAttempts() *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateAttempts()
+	})
+}
+
+// SetLastError sets the "last_error" field.
+func (u *IndexOutboxUpsertBulk) SetLastError(v string) *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetLastError(v)
+	})
+}
+
+// UpdateLastError sets the "last_error" field to the value that was provided on create.
+func (u *IndexOutboxUpsertBulk) UpdateLastError() *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateLastError()
+	})
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (u *IndexOutboxUpsertBulk) ClearLastError() *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.ClearLastError()
+	})
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (u *IndexOutboxUpsertBulk) SetNextAttemptAt(v int64) *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.SetNextAttemptAt(v)
+	})
+}
+
+// AddNextAttemptAt adds v to the "next_attempt_at" field.
+func (u *IndexOutboxUpsertBulk) AddNextAttemptAt(v int64) *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.AddNextAttemptAt(v)
+	})
+}
+
+// UpdateNextAttemptAt sets the "next_attempt_at" field to the value that was provided on create.
+func (u *IndexOutboxUpsertBulk) UpdateNextAttemptAt() *IndexOutboxUpsertBulk {
+	return u.Update(func(s *IndexOutboxUpsert) {
+		s.UpdateNextAttemptAt()
+	})
+}
+
+// Exec executes the query.
+func (u *IndexOutboxUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the IndexOutboxCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for IndexOutboxCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *IndexOutboxUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
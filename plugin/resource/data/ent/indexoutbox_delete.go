@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"ncobase/plugin/resource/data/ent/indexoutbox"
+	"ncobase/plugin/resource/data/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// IndexOutboxDelete is the builder for deleting a IndexOutbox entity.
+type IndexOutboxDelete struct {
+	config
+	hooks    []Hook
+	mutation *IndexOutboxMutation
+}
+
+// Where appends a list predicates to the IndexOutboxDelete builder.
+func (_d *IndexOutboxDelete) Where(ps ...predicate.IndexOutbox) *IndexOutboxDelete {
+	_d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (_d *IndexOutboxDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *IndexOutboxDelete) ExecX(ctx context.Context) int {
+	n, err := _d.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (_d *IndexOutboxDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(indexoutbox.Table, sqlgraph.NewFieldSpec(indexoutbox.FieldID, field.TypeString))
+	if ps := _d.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, _d.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	_d.mutation.done = true
+	return affected, err
+}
+
+// IndexOutboxDeleteOne is the builder for deleting a single IndexOutbox entity.
+type IndexOutboxDeleteOne struct {
+	_d *IndexOutboxDelete
+}
+
+// Where appends a list predicates to the IndexOutboxDelete builder.
+func (_d *IndexOutboxDeleteOne) Where(ps ...predicate.IndexOutbox) *IndexOutboxDeleteOne {
+	_d._d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query.
+func (_d *IndexOutboxDeleteOne) Exec(ctx context.Context) error {
+	n, err := _d._d.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{indexoutbox.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *IndexOutboxDeleteOne) ExecX(ctx context.Context) {
+	if err := _d.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
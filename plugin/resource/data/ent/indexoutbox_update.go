@@ -0,0 +1,517 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/plugin/resource/data/ent/indexoutbox"
+	"ncobase/plugin/resource/data/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// IndexOutboxUpdate is the builder for updating IndexOutbox entities.
+type IndexOutboxUpdate struct {
+	config
+	hooks    []Hook
+	mutation *IndexOutboxMutation
+}
+
+// Where appends a list predicates to the IndexOutboxUpdate builder.
+func (_u *IndexOutboxUpdate) Where(ps ...predicate.IndexOutbox) *IndexOutboxUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *IndexOutboxUpdate) SetUpdatedAt(v int64) *IndexOutboxUpdate {
+	_u.mutation.ResetUpdatedAt()
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddUpdatedAt adds value to the "updated_at" field.
+func (_u *IndexOutboxUpdate) AddUpdatedAt(v int64) *IndexOutboxUpdate {
+	_u.mutation.AddUpdatedAt(v)
+	return _u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (_u *IndexOutboxUpdate) ClearUpdatedAt() *IndexOutboxUpdate {
+	_u.mutation.ClearUpdatedAt()
+	return _u
+}
+
+// SetFileID sets the "file_id" field.
+func (_u *IndexOutboxUpdate) SetFileID(v string) *IndexOutboxUpdate {
+	_u.mutation.SetFileID(v)
+	return _u
+}
+
+// SetNillableFileID sets the "file_id" field if the given value is not nil.
+func (_u *IndexOutboxUpdate) SetNillableFileID(v *string) *IndexOutboxUpdate {
+	if v != nil {
+		_u.SetFileID(*v)
+	}
+	return _u
+}
+
+// SetOperation sets the "operation" field.
+func (_u *IndexOutboxUpdate) SetOperation(v string) *IndexOutboxUpdate {
+	_u.mutation.SetOperation(v)
+	return _u
+}
+
+// SetNillableOperation sets the "operation" field if the given value is not nil.
+func (_u *IndexOutboxUpdate) SetNillableOperation(v *string) *IndexOutboxUpdate {
+	if v != nil {
+		_u.SetOperation(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *IndexOutboxUpdate) SetStatus(v string) *IndexOutboxUpdate {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *IndexOutboxUpdate) SetNillableStatus(v *string) *IndexOutboxUpdate {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetAttempts sets the "attempts" field.
+func (_u *IndexOutboxUpdate) SetAttempts(v int) *IndexOutboxUpdate {
+	_u.mutation.ResetAttempts()
+	_u.mutation.SetAttempts(v)
+	return _u
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (_u *IndexOutboxUpdate) SetNillableAttempts(v *int) *IndexOutboxUpdate {
+	if v != nil {
+		_u.SetAttempts(*v)
+	}
+	return _u
+}
+
+// AddAttempts adds value to the "attempts" field.
+func (_u *IndexOutboxUpdate) AddAttempts(v int) *IndexOutboxUpdate {
+	_u.mutation.AddAttempts(v)
+	return _u
+}
+
+// SetLastError sets the "last_error" field.
+func (_u *IndexOutboxUpdate) SetLastError(v string) *IndexOutboxUpdate {
+	_u.mutation.SetLastError(v)
+	return _u
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_u *IndexOutboxUpdate) SetNillableLastError(v *string) *IndexOutboxUpdate {
+	if v != nil {
+		_u.SetLastError(*v)
+	}
+	return _u
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (_u *IndexOutboxUpdate) ClearLastError() *IndexOutboxUpdate {
+	_u.mutation.ClearLastError()
+	return _u
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (_u *IndexOutboxUpdate) SetNextAttemptAt(v int64) *IndexOutboxUpdate {
+	_u.mutation.ResetNextAttemptAt()
+	_u.mutation.SetNextAttemptAt(v)
+	return _u
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (_u *IndexOutboxUpdate) SetNillableNextAttemptAt(v *int64) *IndexOutboxUpdate {
+	if v != nil {
+		_u.SetNextAttemptAt(*v)
+	}
+	return _u
+}
+
+// AddNextAttemptAt adds value to the "next_attempt_at" field.
+func (_u *IndexOutboxUpdate) AddNextAttemptAt(v int64) *IndexOutboxUpdate {
+	_u.mutation.AddNextAttemptAt(v)
+	return _u
+}
+
+// Mutation returns the IndexOutboxMutation object of the builder.
+func (_u *IndexOutboxUpdate) Mutation() *IndexOutboxMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *IndexOutboxUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *IndexOutboxUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *IndexOutboxUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *IndexOutboxUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *IndexOutboxUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok && !_u.mutation.UpdatedAtCleared() {
+		v := indexoutbox.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *IndexOutboxUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(indexoutbox.Table, indexoutbox.Columns, sqlgraph.NewFieldSpec(indexoutbox.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.CreatedAtCleared() {
+		_spec.ClearField(indexoutbox.FieldCreatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(indexoutbox.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(indexoutbox.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.UpdatedAtCleared() {
+		_spec.ClearField(indexoutbox.FieldUpdatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.FileID(); ok {
+		_spec.SetField(indexoutbox.FieldFileID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Operation(); ok {
+		_spec.SetField(indexoutbox.FieldOperation, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(indexoutbox.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Attempts(); ok {
+		_spec.SetField(indexoutbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedAttempts(); ok {
+		_spec.AddField(indexoutbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.LastError(); ok {
+		_spec.SetField(indexoutbox.FieldLastError, field.TypeString, value)
+	}
+	if _u.mutation.LastErrorCleared() {
+		_spec.ClearField(indexoutbox.FieldLastError, field.TypeString)
+	}
+	if value, ok := _u.mutation.NextAttemptAt(); ok {
+		_spec.SetField(indexoutbox.FieldNextAttemptAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedNextAttemptAt(); ok {
+		_spec.AddField(indexoutbox.FieldNextAttemptAt, field.TypeInt64, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{indexoutbox.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// IndexOutboxUpdateOne is the builder for updating a single IndexOutbox entity.
+type IndexOutboxUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *IndexOutboxMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *IndexOutboxUpdateOne) SetUpdatedAt(v int64) *IndexOutboxUpdateOne {
+	_u.mutation.ResetUpdatedAt()
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// AddUpdatedAt adds value to the "updated_at" field.
+func (_u *IndexOutboxUpdateOne) AddUpdatedAt(v int64) *IndexOutboxUpdateOne {
+	_u.mutation.AddUpdatedAt(v)
+	return _u
+}
+
+// ClearUpdatedAt clears the value of the "updated_at" field.
+func (_u *IndexOutboxUpdateOne) ClearUpdatedAt() *IndexOutboxUpdateOne {
+	_u.mutation.ClearUpdatedAt()
+	return _u
+}
+
+// SetFileID sets the "file_id" field.
+func (_u *IndexOutboxUpdateOne) SetFileID(v string) *IndexOutboxUpdateOne {
+	_u.mutation.SetFileID(v)
+	return _u
+}
+
+// SetNillableFileID sets the "file_id" field if the given value is not nil.
+func (_u *IndexOutboxUpdateOne) SetNillableFileID(v *string) *IndexOutboxUpdateOne {
+	if v != nil {
+		_u.SetFileID(*v)
+	}
+	return _u
+}
+
+// SetOperation sets the "operation" field.
+func (_u *IndexOutboxUpdateOne) SetOperation(v string) *IndexOutboxUpdateOne {
+	_u.mutation.SetOperation(v)
+	return _u
+}
+
+// SetNillableOperation sets the "operation" field if the given value is not nil.
+func (_u *IndexOutboxUpdateOne) SetNillableOperation(v *string) *IndexOutboxUpdateOne {
+	if v != nil {
+		_u.SetOperation(*v)
+	}
+	return _u
+}
+
+// SetStatus sets the "status" field.
+func (_u *IndexOutboxUpdateOne) SetStatus(v string) *IndexOutboxUpdateOne {
+	_u.mutation.SetStatus(v)
+	return _u
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (_u *IndexOutboxUpdateOne) SetNillableStatus(v *string) *IndexOutboxUpdateOne {
+	if v != nil {
+		_u.SetStatus(*v)
+	}
+	return _u
+}
+
+// SetAttempts sets the "attempts" field.
+func (_u *IndexOutboxUpdateOne) SetAttempts(v int) *IndexOutboxUpdateOne {
+	_u.mutation.ResetAttempts()
+	_u.mutation.SetAttempts(v)
+	return _u
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (_u *IndexOutboxUpdateOne) SetNillableAttempts(v *int) *IndexOutboxUpdateOne {
+	if v != nil {
+		_u.SetAttempts(*v)
+	}
+	return _u
+}
+
+// AddAttempts adds value to the "attempts" field.
+func (_u *IndexOutboxUpdateOne) AddAttempts(v int) *IndexOutboxUpdateOne {
+	_u.mutation.AddAttempts(v)
+	return _u
+}
+
+// SetLastError sets the "last_error" field.
+func (_u *IndexOutboxUpdateOne) SetLastError(v string) *IndexOutboxUpdateOne {
+	_u.mutation.SetLastError(v)
+	return _u
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (_u *IndexOutboxUpdateOne) SetNillableLastError(v *string) *IndexOutboxUpdateOne {
+	if v != nil {
+		_u.SetLastError(*v)
+	}
+	return _u
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (_u *IndexOutboxUpdateOne) ClearLastError() *IndexOutboxUpdateOne {
+	_u.mutation.ClearLastError()
+	return _u
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (_u *IndexOutboxUpdateOne) SetNextAttemptAt(v int64) *IndexOutboxUpdateOne {
+	_u.mutation.ResetNextAttemptAt()
+	_u.mutation.SetNextAttemptAt(v)
+	return _u
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (_u *IndexOutboxUpdateOne) SetNillableNextAttemptAt(v *int64) *IndexOutboxUpdateOne {
+	if v != nil {
+		_u.SetNextAttemptAt(*v)
+	}
+	return _u
+}
+
+// AddNextAttemptAt adds value to the "next_attempt_at" field.
+func (_u *IndexOutboxUpdateOne) AddNextAttemptAt(v int64) *IndexOutboxUpdateOne {
+	_u.mutation.AddNextAttemptAt(v)
+	return _u
+}
+
+// Mutation returns the IndexOutboxMutation object of the builder.
+func (_u *IndexOutboxUpdateOne) Mutation() *IndexOutboxMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the IndexOutboxUpdate builder.
+func (_u *IndexOutboxUpdateOne) Where(ps ...predicate.IndexOutbox) *IndexOutboxUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *IndexOutboxUpdateOne) Select(field string, fields ...string) *IndexOutboxUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated IndexOutbox entity.
+func (_u *IndexOutboxUpdateOne) Save(ctx context.Context) (*IndexOutbox, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *IndexOutboxUpdateOne) SaveX(ctx context.Context) *IndexOutbox {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *IndexOutboxUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *IndexOutboxUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *IndexOutboxUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok && !_u.mutation.UpdatedAtCleared() {
+		v := indexoutbox.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *IndexOutboxUpdateOne) sqlSave(ctx context.Context) (_node *IndexOutbox, err error) {
+	_spec := sqlgraph.NewUpdateSpec(indexoutbox.Table, indexoutbox.Columns, sqlgraph.NewFieldSpec(indexoutbox.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "IndexOutbox.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, indexoutbox.FieldID)
+		for _, f := range fields {
+			if !indexoutbox.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != indexoutbox.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if _u.mutation.CreatedAtCleared() {
+		_spec.ClearField(indexoutbox.FieldCreatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(indexoutbox.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(indexoutbox.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if _u.mutation.UpdatedAtCleared() {
+		_spec.ClearField(indexoutbox.FieldUpdatedAt, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.FileID(); ok {
+		_spec.SetField(indexoutbox.FieldFileID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Operation(); ok {
+		_spec.SetField(indexoutbox.FieldOperation, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Status(); ok {
+		_spec.SetField(indexoutbox.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Attempts(); ok {
+		_spec.SetField(indexoutbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedAttempts(); ok {
+		_spec.AddField(indexoutbox.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.LastError(); ok {
+		_spec.SetField(indexoutbox.FieldLastError, field.TypeString, value)
+	}
+	if _u.mutation.LastErrorCleared() {
+		_spec.ClearField(indexoutbox.FieldLastError, field.TypeString)
+	}
+	if value, ok := _u.mutation.NextAttemptAt(); ok {
+		_spec.SetField(indexoutbox.FieldNextAttemptAt, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedNextAttemptAt(); ok {
+		_spec.AddField(indexoutbox.FieldNextAttemptAt, field.TypeInt64, value)
+	}
+	_node = &IndexOutbox{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{indexoutbox.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
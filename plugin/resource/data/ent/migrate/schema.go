@@ -152,9 +152,80 @@ var (
 			},
 		},
 	}
+	// NcseResFileAttachmentColumns holds the columns for the "ncse_res_file_attachment" table.
+	NcseResFileAttachmentColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, Unique: true, Size: 16, Comment: "primary key"},
+		{Name: "created_by", Type: field.TypeString, Nullable: true, Comment: "id of the creator"},
+		{Name: "created_at", Type: field.TypeInt64, Nullable: true, Comment: "created at"},
+		{Name: "updated_at", Type: field.TypeInt64, Nullable: true, Comment: "updated at"},
+		{Name: "file_id", Type: field.TypeString, Comment: "Attached file"},
+		{Name: "entity_type", Type: field.TypeString, Comment: "Type of the entity the file is attached to, e.g. topic, task, space"},
+		{Name: "entity_id", Type: field.TypeString, Comment: "ID of the entity the file is attached to"},
+		{Name: "relation", Type: field.TypeString, Comment: "Relationship of the file to the entity, e.g. attachment, cover, avatar", Default: "attachment"},
+	}
+	// NcseResFileAttachmentTable holds the schema information for the "ncse_res_file_attachment" table.
+	NcseResFileAttachmentTable = &schema.Table{
+		Name:       "ncse_res_file_attachment",
+		Columns:    NcseResFileAttachmentColumns,
+		PrimaryKey: []*schema.Column{NcseResFileAttachmentColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "fileattachment_id",
+				Unique:  true,
+				Columns: []*schema.Column{NcseResFileAttachmentColumns[0]},
+			},
+			{
+				Name:    "fileattachment_file_id_entity_type_entity_id_relation",
+				Unique:  true,
+				Columns: []*schema.Column{NcseResFileAttachmentColumns[4], NcseResFileAttachmentColumns[5], NcseResFileAttachmentColumns[6], NcseResFileAttachmentColumns[7]},
+			},
+			{
+				Name:    "fileattachment_entity_type_entity_id",
+				Unique:  false,
+				Columns: []*schema.Column{NcseResFileAttachmentColumns[5], NcseResFileAttachmentColumns[6]},
+			},
+		},
+	}
+	// NcseResIndexOutboxColumns holds the columns for the "ncse_res_index_outbox" table.
+	NcseResIndexOutboxColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString, Unique: true, Size: 16, Comment: "primary key"},
+		{Name: "created_at", Type: field.TypeInt64, Nullable: true, Comment: "created at"},
+		{Name: "updated_at", Type: field.TypeInt64, Nullable: true, Comment: "updated at"},
+		{Name: "file_id", Type: field.TypeString, Comment: "File whose index document is updated"},
+		{Name: "operation", Type: field.TypeString, Comment: "Index operation: index, delete"},
+		{Name: "status", Type: field.TypeString, Comment: "Delivery status: pending, done, dead", Default: "pending"},
+		{Name: "attempts", Type: field.TypeInt, Comment: "Delivery attempts made", Default: 0},
+		{Name: "last_error", Type: field.TypeString, Nullable: true, Comment: "Error of the last failed attempt"},
+		{Name: "next_attempt_at", Type: field.TypeInt64, Comment: "Earliest time of the next attempt, in milliseconds", Default: 0},
+	}
+	// NcseResIndexOutboxTable holds the schema information for the "ncse_res_index_outbox" table.
+	NcseResIndexOutboxTable = &schema.Table{
+		Name:       "ncse_res_index_outbox",
+		Columns:    NcseResIndexOutboxColumns,
+		PrimaryKey: []*schema.Column{NcseResIndexOutboxColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "indexoutbox_id",
+				Unique:  true,
+				Columns: []*schema.Column{NcseResIndexOutboxColumns[0]},
+			},
+			{
+				Name:    "indexoutbox_status_next_attempt_at",
+				Unique:  false,
+				Columns: []*schema.Column{NcseResIndexOutboxColumns[5], NcseResIndexOutboxColumns[8]},
+			},
+			{
+				Name:    "indexoutbox_file_id",
+				Unique:  false,
+				Columns: []*schema.Column{NcseResIndexOutboxColumns[3]},
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
 		NcseResFileTable,
+		NcseResFileAttachmentTable,
+		NcseResIndexOutboxTable,
 	}
 )
 
@@ -162,4 +233,10 @@ func init() {
 	NcseResFileTable.Annotation = &entsql.Annotation{
 		Table: "ncse_res_file",
 	}
+	NcseResFileAttachmentTable.Annotation = &entsql.Annotation{
+		Table: "ncse_res_file_attachment",
+	}
+	NcseResIndexOutboxTable.Annotation = &entsql.Annotation{
+		Table: "ncse_res_index_outbox",
+	}
 }
@@ -8,3 +8,9 @@ import (
 
 // File is the predicate function for file builders.
 type File func(*sql.Selector)
+
+// FileAttachment is the predicate function for fileattachment builders.
+type FileAttachment func(*sql.Selector)
+
+// IndexOutbox is the predicate function for indexoutbox builders.
+type IndexOutbox func(*sql.Selector)
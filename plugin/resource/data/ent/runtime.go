@@ -4,6 +4,8 @@ package ent
 
 import (
 	"ncobase/plugin/resource/data/ent/file"
+	"ncobase/plugin/resource/data/ent/fileattachment"
+	"ncobase/plugin/resource/data/ent/indexoutbox"
 	"ncobase/plugin/resource/data/schema"
 )
 
@@ -58,4 +60,66 @@ func init() {
 	file.DefaultID = fileDescID.Default.(func() string)
 	// file.IDValidator is a validator for the "id" field. It is called by the builders before save.
 	file.IDValidator = fileDescID.Validators[0].(func(string) error)
+	fileattachmentMixin := schema.FileAttachment{}.Mixin()
+	fileattachmentMixinFields0 := fileattachmentMixin[0].Fields()
+	_ = fileattachmentMixinFields0
+	fileattachmentMixinFields2 := fileattachmentMixin[2].Fields()
+	_ = fileattachmentMixinFields2
+	fileattachmentFields := schema.FileAttachment{}.Fields()
+	_ = fileattachmentFields
+	// fileattachmentDescCreatedAt is the schema descriptor for created_at field.
+	fileattachmentDescCreatedAt := fileattachmentMixinFields2[0].Descriptor()
+	// fileattachment.DefaultCreatedAt holds the default value on creation for the created_at field.
+	fileattachment.DefaultCreatedAt = fileattachmentDescCreatedAt.Default.(func() int64)
+	// fileattachmentDescUpdatedAt is the schema descriptor for updated_at field.
+	fileattachmentDescUpdatedAt := fileattachmentMixinFields2[1].Descriptor()
+	// fileattachment.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	fileattachment.DefaultUpdatedAt = fileattachmentDescUpdatedAt.Default.(func() int64)
+	// fileattachment.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	fileattachment.UpdateDefaultUpdatedAt = fileattachmentDescUpdatedAt.UpdateDefault.(func() int64)
+	// fileattachmentDescRelation is the schema descriptor for relation field.
+	fileattachmentDescRelation := fileattachmentFields[3].Descriptor()
+	// fileattachment.DefaultRelation holds the default value on creation for the relation field.
+	fileattachment.DefaultRelation = fileattachmentDescRelation.Default.(string)
+	// fileattachmentDescID is the schema descriptor for id field.
+	fileattachmentDescID := fileattachmentMixinFields0[0].Descriptor()
+	// fileattachment.DefaultID holds the default value on creation for the id field.
+	fileattachment.DefaultID = fileattachmentDescID.Default.(func() string)
+	// fileattachment.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	fileattachment.IDValidator = fileattachmentDescID.Validators[0].(func(string) error)
+	indexoutboxMixin := schema.IndexOutbox{}.Mixin()
+	indexoutboxMixinFields0 := indexoutboxMixin[0].Fields()
+	_ = indexoutboxMixinFields0
+	indexoutboxMixinFields1 := indexoutboxMixin[1].Fields()
+	_ = indexoutboxMixinFields1
+	indexoutboxFields := schema.IndexOutbox{}.Fields()
+	_ = indexoutboxFields
+	// indexoutboxDescCreatedAt is the schema descriptor for created_at field.
+	indexoutboxDescCreatedAt := indexoutboxMixinFields1[0].Descriptor()
+	// indexoutbox.DefaultCreatedAt holds the default value on creation for the created_at field.
+	indexoutbox.DefaultCreatedAt = indexoutboxDescCreatedAt.Default.(func() int64)
+	// indexoutboxDescUpdatedAt is the schema descriptor for updated_at field.
+	indexoutboxDescUpdatedAt := indexoutboxMixinFields1[1].Descriptor()
+	// indexoutbox.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	indexoutbox.DefaultUpdatedAt = indexoutboxDescUpdatedAt.Default.(func() int64)
+	// indexoutbox.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	indexoutbox.UpdateDefaultUpdatedAt = indexoutboxDescUpdatedAt.UpdateDefault.(func() int64)
+	// indexoutboxDescStatus is the schema descriptor for status field.
+	indexoutboxDescStatus := indexoutboxFields[2].Descriptor()
+	// indexoutbox.DefaultStatus holds the default value on creation for the status field.
+	indexoutbox.DefaultStatus = indexoutboxDescStatus.Default.(string)
+	// indexoutboxDescAttempts is the schema descriptor for attempts field.
+	indexoutboxDescAttempts := indexoutboxFields[3].Descriptor()
+	// indexoutbox.DefaultAttempts holds the default value on creation for the attempts field.
+	indexoutbox.DefaultAttempts = indexoutboxDescAttempts.Default.(int)
+	// indexoutboxDescNextAttemptAt is the schema descriptor for next_attempt_at field.
+	indexoutboxDescNextAttemptAt := indexoutboxFields[5].Descriptor()
+	// indexoutbox.DefaultNextAttemptAt holds the default value on creation for the next_attempt_at field.
+	indexoutbox.DefaultNextAttemptAt = indexoutboxDescNextAttemptAt.Default.(int64)
+	// indexoutboxDescID is the schema descriptor for id field.
+	indexoutboxDescID := indexoutboxMixinFields0[0].Descriptor()
+	// indexoutbox.DefaultID holds the default value on creation for the id field.
+	indexoutbox.DefaultID = indexoutboxDescID.Default.(func() string)
+	// indexoutbox.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	indexoutbox.IDValidator = indexoutboxDescID.Validators[0].(func(string) error)
 }
@@ -16,6 +16,10 @@ type Tx struct {
 	config
 	// File is the client for interacting with the File builders.
 	File *FileClient
+	// FileAttachment is the client for interacting with the FileAttachment builders.
+	FileAttachment *FileAttachmentClient
+	// IndexOutbox is the client for interacting with the IndexOutbox builders.
+	IndexOutbox *IndexOutboxClient
 
 	// lazily loaded.
 	client     *Client
@@ -148,6 +152,8 @@ func (tx *Tx) Client() *Client {
 
 func (tx *Tx) init() {
 	tx.File = NewFileClient(tx.config)
+	tx.FileAttachment = NewFileAttachmentClient(tx.config)
+	tx.IndexOutbox = NewIndexOutboxClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/ent"
+	attachmentEnt "ncobase/plugin/resource/data/ent/fileattachment"
+	"ncobase/plugin/resource/structs"
+)
+
+// AttachmentRepositoryInterface stores the attachments of files to entities of any module
+type AttachmentRepositoryInterface interface {
+	Attach(ctx context.Context, fileID, createdBy string, body *structs.AttachFileBody) (*ent.FileAttachment, error)
+	Detach(ctx context.Context, fileID string, body *structs.AttachFileBody) (int, error)
+	ListByEntity(ctx context.Context, params *structs.ListAttachmentParams) ([]*ent.FileAttachment, error)
+	DeleteByFile(ctx context.Context, fileID string) (int, error)
+}
+
+type attachmentRepository struct {
+	ec  *ent.Client
+	ecr *ent.Client
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(d *data.Data) AttachmentRepositoryInterface {
+	return &attachmentRepository{
+		ec:  d.GetMasterEntClient(),
+		ecr: d.GetSlaveEntClient(),
+	}
+}
+
+// Attach attaches a file to an entity with a relation. Attaching it again returns the
+// existing attachment, also when a concurrent request created it first.
+func (r *attachmentRepository) Attach(ctx context.Context, fileID, createdBy string, body *structs.AttachFileBody) (*ent.FileAttachment, error) {
+	if row, err := r.find(ctx, fileID, body); err == nil {
+		return row, nil
+	} else if !ent.IsNotFound(err) {
+		return nil, err
+	}
+
+	builder := r.ec.FileAttachment.Create().
+		SetFileID(fileID).
+		SetEntityType(body.EntityType).
+		SetEntityID(body.EntityID).
+		SetRelation(body.Relation)
+	if createdBy != "" {
+		builder.SetCreatedBy(createdBy)
+	}
+
+	row, err := builder.Save(ctx)
+	if ent.IsConstraintError(err) {
+		return r.find(ctx, fileID, body)
+	}
+	return row, err
+}
+
+// Detach removes the attachment of a file to an entity with a relation and returns
+// the number removed, 0 when the file was not attached that way
+func (r *attachmentRepository) Detach(ctx context.Context, fileID string, body *structs.AttachFileBody) (int, error) {
+	return r.ec.FileAttachment.Delete().
+		Where(
+			attachmentEnt.FileIDEQ(fileID),
+			attachmentEnt.EntityTypeEQ(body.EntityType),
+			attachmentEnt.EntityIDEQ(body.EntityID),
+			attachmentEnt.RelationEQ(body.Relation),
+		).
+		Exec(ctx)
+}
+
+// ListByEntity returns the attachments of an entity, of one relation when set, oldest first
+func (r *attachmentRepository) ListByEntity(ctx context.Context, params *structs.ListAttachmentParams) ([]*ent.FileAttachment, error) {
+	query := r.ecr.FileAttachment.Query().
+		Where(
+			attachmentEnt.EntityTypeEQ(params.EntityType),
+			attachmentEnt.EntityIDEQ(params.EntityID),
+		)
+	if params.Relation != "" {
+		query = query.Where(attachmentEnt.RelationEQ(params.Relation))
+	}
+
+	return query.
+		Order(ent.Asc(attachmentEnt.FieldCreatedAt), ent.Asc(attachmentEnt.FieldID)).
+		All(ctx)
+}
+
+// DeleteByFile removes every attachment of a file, in the transaction of ctx when there is one
+func (r *attachmentRepository) DeleteByFile(ctx context.Context, fileID string) (int, error) {
+	return r.ec.FileAttachment.Delete().
+		Where(attachmentEnt.FileIDEQ(fileID)).
+		Exec(ctx)
+}
+
+// find returns the attachment of a file to an entity with a relation, read from the master
+func (r *attachmentRepository) find(ctx context.Context, fileID string, body *structs.AttachFileBody) (*ent.FileAttachment, error) {
+	return r.ec.FileAttachment.Query().
+		Where(
+			attachmentEnt.FileIDEQ(fileID),
+			attachmentEnt.EntityTypeEQ(body.EntityType),
+			attachmentEnt.EntityIDEQ(body.EntityID),
+			attachmentEnt.RelationEQ(body.Relation),
+		).
+		Only(ctx)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"ncobase/plugin/resource/data/ent"
+	fileEnt "ncobase/plugin/resource/data/ent/file"
+
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// AnonymizeByUser clears a user as creator and last editor of the files they touched,
+// in one transaction with the outbox entries reindexing them. It returns the number of files changed.
+func (r *fileRepository) AnonymizeByUser(ctx context.Context, userID string) (int, error) {
+	var rows []*ent.File
+	err := r.withIndexOutboxBatch(ctx, OutboxIndex, func(ctx context.Context) ([]string, error) {
+		err := r.data.WithEntTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+			rows = rows[:0]
+
+			current, err := tx.File.Query().
+				Where(fileEnt.Or(fileEnt.CreatedByEQ(userID), fileEnt.UpdatedByEQ(userID))).
+				Select(fileEnt.FieldID, fileEnt.FieldCreatedBy, fileEnt.FieldUpdatedBy, fileEnt.FieldUpdatedAt).
+				All(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, file := range current {
+				update := tx.File.UpdateOneID(file.ID).SetUpdatedAt(NextVersion(file.UpdatedAt))
+				if file.CreatedBy == userID {
+					update.ClearCreatedBy()
+				}
+				if file.UpdatedBy == userID {
+					update.ClearUpdatedBy()
+				}
+				row, err := update.Save(ctx)
+				if err != nil {
+					return err
+				}
+				rows = append(rows, row)
+			}
+			return nil
+		})
+		return fileIDs(rows), err
+	})
+	if err != nil {
+		logger.Errorf(ctx, "fileRepo.AnonymizeByUser error: %v", err)
+		return 0, err
+	}
+
+	if r.c != nil {
+		for _, row := range rows {
+			if err := r.c.Delete(ctx, row.ID); err != nil {
+				logger.Errorf(ctx, "fileRepo.AnonymizeByUser cache error: %v", err)
+			}
+		}
+	}
+
+	return len(rows), nil
+}
@@ -1,10 +1,26 @@
 package repository
 
-import "ncobase/plugin/resource/data/ent"
+import (
+	"errors"
+	"ncobase/internal/dberr"
+	"ncobase/plugin/resource/data/ent"
+	"time"
+)
+
+// ErrVersionConflict is returned when an update carries a stale version.
+var ErrVersionConflict = errors.New("file has been modified, reload and retry")
+
+// ErrNotFound is returned when the requested row does not exist.
+var ErrNotFound = dberr.ErrNotFound
+
+// mapError converts an ent not-found error into ErrNotFound, other errors pass through.
+func mapError(err error) error {
+	return dberr.Map(err, ent.IsNotFound)
+}
 
 // IsNotFound reports whether the error means the row does not exist.
 func IsNotFound(err error) bool {
-	return ent.IsNotFound(err)
+	return dberr.IsNotFound(err) || ent.IsNotFound(err)
 }
 
 // IsConstraintError reports whether the error is a constraint violation.
@@ -16,3 +32,17 @@ func IsConstraintError(err error) bool {
 func IsNotSingular(err error) bool {
 	return ent.IsNotSingular(err)
 }
+
+// IsVersionConflict reports whether the error is an optimistic concurrency conflict.
+func IsVersionConflict(err error) bool {
+	return errors.Is(err, ErrVersionConflict)
+}
+
+// NextVersion returns the version to store after prev, always strictly greater.
+func NextVersion(prev int64) int64 {
+	now := time.Now().UnixMilli()
+	if now <= prev {
+		return prev + 1
+	}
+	return now
+}
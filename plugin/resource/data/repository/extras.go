@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ncobase/ncore/types"
+)
+
+// nestedExtrasKeys are the legacy containers top-level extras were written under
+var nestedExtrasKeys = []string{"custom_fields", "metadata"}
+
+// hoistedExtrasKeys are read from the top level only and moved out of nested containers
+var hoistedExtrasKeys = []string{"thumbnail_path", "path_prefix"}
+
+// NormalizeExtras rewrites loosely typed extras into their canonical shape:
+//
+//   - thumbnail_path and path_prefix live at the top level, not under custom_fields or metadata
+//   - path_prefix is a folder path without leading or trailing slashes
+//   - versions is a list of unique file IDs
+//   - shared_with is a list of {user_id, expires_at} objects
+//   - is_public is a bool and expires_at an integer timestamp in milliseconds
+//
+// It returns the normalized copy and whether anything changed.
+func NormalizeExtras(extras types.JSON) (types.JSON, bool) {
+	normalized := CloneExtras(extras)
+	changed := false
+
+	for _, container := range nestedExtrasKeys {
+		nested, ok := normalized[container].(map[string]any)
+		if !ok {
+			continue
+		}
+		hoisted := false
+		for _, key := range hoistedExtrasKeys {
+			v, ok := nested[key]
+			if !ok {
+				continue
+			}
+			if _, exists := normalized[key]; !exists {
+				normalized[key] = v
+			}
+			if !hoisted {
+				nested = cloneMap(nested)
+				hoisted = true
+			}
+			delete(nested, key)
+		}
+		if !hoisted {
+			continue
+		}
+		if len(nested) == 0 && container == "custom_fields" {
+			delete(normalized, container)
+		} else {
+			normalized[container] = nested
+		}
+		changed = true
+	}
+
+	if v, ok := normalized["path_prefix"].(string); ok {
+		if folder := FolderPath(v); folder != v {
+			normalized["path_prefix"] = folder
+			changed = true
+		}
+	}
+
+	if v, ok := normalized["versions"]; ok {
+		versions := ExtrasStrings(normalized, "versions")
+		if !sameStrings(v, versions) {
+			normalized["versions"] = versions
+			changed = true
+		}
+	}
+
+	if v, ok := normalized["shared_with"]; ok {
+		shares := ParseShares(normalized)
+		canonical := make([]any, 0, len(shares))
+		for _, share := range shares {
+			entry := map[string]any{"user_id": share.UserID}
+			if share.ExpiresAt != nil {
+				entry["expires_at"] = *share.ExpiresAt
+			}
+			canonical = append(canonical, entry)
+		}
+		if !sameShares(v, canonical) {
+			normalized["shared_with"] = canonical
+			changed = true
+		}
+	}
+
+	if v, ok := normalized["is_public"]; ok {
+		if _, isBool := v.(bool); !isBool {
+			s, _ := v.(string)
+			normalized["is_public"] = strings.EqualFold(s, "true") || s == "1"
+			changed = true
+		}
+	}
+
+	if v, ok := normalized["expires_at"]; ok {
+		ms, ok := toMillis(v)
+		switch {
+		case !ok:
+			delete(normalized, "expires_at")
+			changed = true
+		case !isWholeNumber(v):
+			normalized["expires_at"] = ms
+			changed = true
+		}
+	}
+
+	return normalized, changed
+}
+
+// FolderPath returns the folder of a path prefix as stored in extras.path_prefix,
+// with forward slashes and no leading or trailing slash
+func FolderPath(prefix string) string {
+	return strings.Trim(strings.ReplaceAll(prefix, "\\", "/"), "/")
+}
+
+// SpaceID returns the space a file was created in, as stored in extras.space_id.
+// Files stored before the space was recorded have none.
+func SpaceID(extras types.JSON) string {
+	spaceID, _ := extras["space_id"].(string)
+	return spaceID
+}
+
+// ExtrasInt reads a positive whole number from extras, as stored or after JSON decoding
+func ExtrasInt(extras types.JSON, key string) (int, bool) {
+	v, ok := extras[key]
+	if !ok || !isWholeNumber(v) {
+		return 0, false
+	}
+	n, _ := toMillis(v)
+	return int(n), n > 0
+}
+
+// ExtrasStrings reads a string list from extras, accepting a single string,
+// []string or the []any produced by JSON decoding. Empty and duplicate entries are dropped.
+func ExtrasStrings(extras types.JSON, key string) []string {
+	var raw []string
+	switch v := extras[key].(type) {
+	case string:
+		raw = []string{v}
+	case []string:
+		raw = v
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				raw = append(raw, s)
+			}
+		}
+	}
+
+	result := make([]string, 0, len(raw))
+	seen := make(map[string]struct{}, len(raw))
+	for _, s := range raw {
+		if s == "" {
+			continue
+		}
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		result = append(result, s)
+	}
+	return result
+}
+
+// toMillis converts a stored timestamp to int64 milliseconds
+func toMillis(v any) (int64, bool) {
+	switch val := v.(type) {
+	case int64:
+		return val, true
+	case int:
+		return int64(val), true
+	case float64:
+		return int64(val), true
+	case string:
+		ms, err := strconv.ParseInt(val, 10, 64)
+		return ms, err == nil
+	}
+	return 0, false
+}
+
+// isWholeNumber reports whether v is a number without a fractional part,
+// JSON decoding turns stored integers into float64
+func isWholeNumber(v any) bool {
+	switch val := v.(type) {
+	case int, int64:
+		return true
+	case float64:
+		return val == float64(int64(val))
+	}
+	return false
+}
+
+// sameStrings reports whether v already is exactly the string list want
+func sameStrings(v any, want []string) bool {
+	switch list := v.(type) {
+	case []string:
+		if len(list) != len(want) {
+			return false
+		}
+		for i := range list {
+			if list[i] != want[i] {
+				return false
+			}
+		}
+		return true
+	case []any:
+		if len(list) != len(want) {
+			return false
+		}
+		for i := range list {
+			if s, ok := list[i].(string); !ok || s != want[i] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// sameShares reports whether v already holds exactly the canonical share objects
+func sameShares(v any, want []any) bool {
+	list, ok := v.([]any)
+	if !ok || len(list) != len(want) {
+		return false
+	}
+	for i := range list {
+		got, ok := list[i].(map[string]any)
+		if !ok || len(got) != len(want[i].(map[string]any)) {
+			return false
+		}
+		for key, wantValue := range want[i].(map[string]any) {
+			gotValue := got[key]
+			if key == "expires_at" {
+				ms, ok := toMillis(gotValue)
+				if !ok || ms != wantValue.(int64) {
+					return false
+				}
+				continue
+			}
+			if gotValue != wantValue {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// cloneMap returns a shallow copy of m
+func cloneMap(m map[string]any) map[string]any {
+	cloned := make(map[string]any, len(m))
+	for k, v := range m {
+		cloned[k] = v
+	}
+	return cloned
+}
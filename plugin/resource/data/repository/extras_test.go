@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ncobase/ncore/types"
+)
+
+func TestNormalizeExtrasMessyBlob(t *testing.T) {
+	// extras as written by older releases and read back through JSON
+	var messy types.JSON
+	if err := json.Unmarshal([]byte(`{
+		"custom_fields": {"thumbnail_path": "thumbs/a.jpg", "path_prefix": "\\docs\\2024\\"},
+		"metadata": {"thumbnail_path": "thumbs/old.jpg", "width": 640},
+		"versions": ["f1", "", "f2", "f1"],
+		"shared_with": ["u1", {"user_id": "u2", "expires_at": 1700000000000}, {"expires_at": 1}],
+		"is_public": "true",
+		"expires_at": "1800000000000",
+		"description": "kept"
+	}`), &messy); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	normalized, changed := NormalizeExtras(messy)
+	if !changed {
+		t.Fatal("messy extras reported unchanged")
+	}
+	want := types.JSON{
+		"thumbnail_path": "thumbs/a.jpg",
+		"path_prefix":    "docs/2024",
+		"metadata":       map[string]any{"width": float64(640)},
+		"versions":       []string{"f1", "f2"},
+		"shared_with": []any{
+			map[string]any{"user_id": "u1"},
+			map[string]any{"user_id": "u2", "expires_at": int64(1700000000000)},
+		},
+		"is_public":   true,
+		"expires_at":  int64(1800000000000),
+		"description": "kept",
+	}
+	if !reflect.DeepEqual(normalized, want) {
+		t.Fatalf("normalized = %#v\nwant %#v", normalized, want)
+	}
+	if _, ok := messy["thumbnail_path"]; ok {
+		t.Fatal("input extras modified")
+	}
+
+	// the canonical shape survives a round trip through storage unchanged
+	stored, err := json.Marshal(normalized)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	var reloaded types.JSON
+	if err := json.Unmarshal(stored, &reloaded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if again, changed := NormalizeExtras(reloaded); changed {
+		t.Fatalf("normalized extras changed again after reload: %#v", again)
+	}
+}
+
+func TestNormalizeExtrasDropsInvalidExpiry(t *testing.T) {
+	normalized, changed := NormalizeExtras(types.JSON{"expires_at": "tomorrow", "is_public": false})
+	if _, ok := normalized["expires_at"]; !changed || ok {
+		t.Fatalf("NormalizeExtras = %v, %v, want the unparsable expiry dropped", normalized, changed)
+	}
+}
@@ -3,6 +3,9 @@ package repository
 import (
 	"context"
 	"fmt"
+	"ncobase/internal/fieldcrypt"
+	"ncobase/internal/page"
+	"ncobase/internal/utils"
 	"ncobase/plugin/resource/data"
 	"ncobase/plugin/resource/data/ent"
 	fileEnt "ncobase/plugin/resource/data/ent/file"
@@ -13,7 +16,6 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqljson"
 	"github.com/ncobase/ncore/data/cache"
-	"github.com/ncobase/ncore/data/paging"
 	"github.com/ncobase/ncore/logging/logger"
 	"github.com/ncobase/ncore/types"
 	"github.com/ncobase/ncore/utils/nanoid"
@@ -28,30 +30,48 @@ import (
 type FileRepositoryInterface interface {
 	Create(ctx context.Context, body *structs.CreateFileBody) (*ent.File, error)
 	GetByID(ctx context.Context, slug string) (*ent.File, error)
+	GetByIDs(ctx context.Context, slugs []string) ([]*ent.File, error)
 	GetByHash(ctx context.Context, ownerID, hash string) (*ent.File, error)
+	CountPathRefs(ctx context.Context, path, excludeID string) (int, error)
 	Update(ctx context.Context, slug string, updates types.JSON) (*ent.File, error)
 	Delete(ctx context.Context, slug string) error
 	List(ctx context.Context, params *structs.ListFileParams) ([]*ent.File, error)
 	CountX(ctx context.Context, params *structs.ListFileParams) int
 	SumSizeByOwner(ctx context.Context, ownerID string) (int64, error)
+	SumSizeByScope(ctx context.Context, ownerID, category, pathPrefix string) (int64, error)
 	GetAllOwners(ctx context.Context) ([]string, error)
 	SearchByTags(ctx context.Context, ownerID string, tags []string, limit int) ([]*ent.File, error)
 	GetTagsByOwner(ctx context.Context, ownerID string) ([]string, error)
+	CountTagsByOwner(ctx context.Context, ownerID string) (map[string]int, error)
+	FindByTagAfter(ctx context.Context, ownerID, tag, afterID string, limit int) ([]*ent.File, error)
+	SetTagsBatch(ctx context.Context, tags map[string][]string) ([]*ent.File, error)
 	CheckNameExists(ctx context.Context, ownerID, name string) (bool, error)
+	GetByName(ctx context.Context, ownerID, name string) (*ent.File, error)
+	AnonymizeByUser(ctx context.Context, userID string) (int, error)
 
 	// Cleanup queries
 	FindExpiredFiles(ctx context.Context, filters *structs.CleanupFilters, limit int) ([]*ent.File, error)
 	FindOrphanedFiles(ctx context.Context, filters *structs.CleanupFilters, limit int) ([]*ent.File, error)
 	FindDuplicateFiles(ctx context.Context) (map[string][]*ent.File, error)
+	FindByObjectPrefix(ctx context.Context, prefix, afterID string, limit int) ([]*ent.File, error)
+	FindByOwnerAfter(ctx context.Context, ownerID, afterID string, limit int) ([]*ent.File, error)
+
+	// Search index maintenance
+	ConfigureSearchIndex(ctx context.Context) error
+	BulkIndex(ctx context.Context, rows []*ent.File) error
+	SyncIndex(ctx context.Context, fileID string) error
+	SearchWithFacets(ctx context.Context, params *structs.FileSearchParams) ([]*ent.File, int64, map[string]map[string]int64, error)
 }
 
 type fileRepository struct {
-	data *data.Data
-	sc   *search.Client
-	ec   *ent.Client
-	ecr  *ent.Client
-	rc   *redis.Client
-	c    *cache.Cache[ent.File]
+	data        *data.Data
+	sc          *search.Client
+	ec          *ent.Client
+	ecr         *ent.Client
+	rc          *redis.Client
+	c           cache.ICache[ent.File]
+	outbox      IndexOutboxRepositoryInterface
+	attachments AttachmentRepositoryInterface
 }
 
 func NewFileRepository(d *data.Data) FileRepositoryInterface {
@@ -60,12 +80,14 @@ func NewFileRepository(d *data.Data) FileRepositoryInterface {
 	rc := d.GetRedis().(*redis.Client)
 	sc := nd.NewSearchClient(d.Data)
 	return &fileRepository{
-		data: d,
-		sc:   sc,
-		ec:   ec,
-		ecr:  ecr,
-		rc:   rc,
-		c:    cache.NewCache[ent.File](rc, "ncse_file"),
+		data:        d,
+		sc:          sc,
+		ec:          ec,
+		ecr:         ecr,
+		rc:          rc,
+		c:           utils.NewRetryCache(cache.NewCache[ent.File](rc, "ncse_file")),
+		outbox:      NewIndexOutboxRepository(d),
+		attachments: NewAttachmentRepository(d),
 	}
 }
 
@@ -157,8 +179,8 @@ func (r *fileRepository) Create(ctx context.Context, body *structs.CreateFileBod
 	}
 
 	// Add additional metadata
-	if body.PathPrefix != "" {
-		extras["path_prefix"] = body.PathPrefix
+	if folder := FolderPath(body.PathPrefix); folder != "" {
+		extras["path_prefix"] = folder
 	}
 
 	// Set computed hash if available
@@ -168,26 +190,33 @@ func (r *fileRepository) Create(ctx context.Context, body *structs.CreateFileBod
 
 	// Set the complete extras
 	if len(extras) > 0 {
-		builder.SetExtras(extras)
+		encrypted, err := fieldcrypt.For(EncryptionSchema).EncryptJSON("extras", extras)
+		if err != nil {
+			return nil, err
+		}
+		builder.SetExtras(encrypted)
 	}
 
-	row, err := builder.Save(ctx)
+	// Index in Meilisearch through the outbox
+	var row *ent.File
+	err := r.withIndexOutbox(ctx, OutboxIndex, func(ctx context.Context) (string, error) {
+		var err error
+		row, err = builder.Save(ctx)
+		if err != nil {
+			return "", err
+		}
+		return row.ID, nil
+	})
 	if err != nil {
 		logger.Errorf(ctx, "fileRepo.Create error: %v", err)
 		return nil, err
 	}
 
-	// Index in Meilisearch
-	if r.sc != nil {
-		if err = r.sc.Index(ctx, &search.IndexRequest{Index: "files", Document: row}); err != nil {
-			logger.Errorf(ctx, "fileRepo.Create index error: %v", err)
-		}
-	}
-
 	return row, nil
 }
 
-// GetByHash returns a file for the given owner and hash.
+// GetByHash returns the oldest file for the given owner and hash.
+// Deduplicated uploads share a hash, so several records may match.
 func (r *fileRepository) GetByHash(ctx context.Context, ownerID, hash string) (*ent.File, error) {
 	if ownerID == "" || hash == "" {
 		return nil, fmt.Errorf("ownerID and hash are required")
@@ -198,7 +227,18 @@ func (r *fileRepository) GetByHash(ctx context.Context, ownerID, hash string) (*
 			fileEnt.OwnerIDEQ(ownerID),
 			fileEnt.HashEQ(hash),
 		).
-		Only(ctx)
+		Order(ent.Asc(fileEnt.FieldCreatedAt), ent.Asc(fileEnt.FieldID)).
+		First(ctx)
+}
+
+// CountPathRefs counts the records referencing a stored object, ignoring excludeID.
+// Reads go to the master so a concurrent delete is not missed.
+func (r *fileRepository) CountPathRefs(ctx context.Context, path, excludeID string) (int, error) {
+	query := r.ec.File.Query().Where(fileEnt.PathEQ(path))
+	if excludeID != "" {
+		query = query.Where(fileEnt.IDNEQ(excludeID))
+	}
+	return query.Count(ctx)
 }
 
 // Update updates file by ID with complete field mapping
@@ -210,8 +250,19 @@ func (r *fileRepository) Update(ctx context.Context, slug string, updates types.
 
 	builder := r.ec.File.UpdateOne(file)
 
+	// Optimistic concurrency, the stored updated_at acts as the version
+	expected, guarded := toVersion(updates["version"])
+	if guarded {
+		builder.Where(fileEnt.UpdatedAtEQ(expected))
+	}
+	if _, ok := updates["updated_at"]; !ok {
+		builder.SetUpdatedAt(NextVersion(file.UpdatedAt))
+	}
+
 	for field, value := range updates {
 		switch field {
+		case "version":
+			// Handled above
 		case "name":
 			if v, ok := value.(string); ok && v != "" {
 				builder.SetName(v)
@@ -255,7 +306,9 @@ func (r *fileRepository) Update(ctx context.Context, slug string, updates types.
 				builder.SetAccessLevel(v)
 			}
 		case "expires_at":
-			if v, ok := value.(int64); ok {
+			if value == nil {
+				builder.ClearExpiresAt()
+			} else if v, ok := value.(int64); ok {
 				builder.SetExpiresAt(v)
 			} else if v, ok := value.(*int64); ok && v != nil {
 				builder.SetExpiresAt(*v)
@@ -284,7 +337,11 @@ func (r *fileRepository) Update(ctx context.Context, slug string, updates types.
 			}
 		case "extras":
 			if v, ok := value.(types.JSON); ok {
-				builder.SetExtras(v)
+				encrypted, err := fieldcrypt.For(EncryptionSchema).EncryptJSON("extras", v)
+				if err != nil {
+					return nil, err
+				}
+				builder.SetExtras(encrypted)
 			}
 		case "created_by":
 			if v, ok := value.(string); ok {
@@ -308,8 +365,20 @@ func (r *fileRepository) Update(ctx context.Context, slug string, updates types.
 		}
 	}
 
-	row, err := builder.Save(ctx)
+	// Update in Meilisearch through the outbox
+	var row *ent.File
+	err = r.withIndexOutbox(ctx, OutboxIndex, func(ctx context.Context) (string, error) {
+		var err error
+		row, err = builder.Save(ctx)
+		if err != nil {
+			return "", err
+		}
+		return row.ID, nil
+	})
 	if err != nil {
+		if guarded && IsNotFound(err) {
+			return nil, ErrVersionConflict
+		}
 		logger.Errorf(ctx, "fileRepo.Update error: %v", err)
 		return nil, err
 	}
@@ -322,14 +391,24 @@ func (r *fileRepository) Update(ctx context.Context, slug string, updates types.
 		}
 	}
 
-	// Update in Meilisearch
-	if r.sc != nil {
-		if err = r.sc.Index(ctx, &search.IndexRequest{Index: "files", Document: row, DocumentID: row.ID}); err != nil {
-			logger.Errorf(ctx, "fileRepo.Update index error: %v", err)
+	return row, nil
+}
+
+// toVersion converts a version precondition value to int64
+func toVersion(v any) (int64, bool) {
+	switch val := v.(type) {
+	case int64:
+		return val, val > 0
+	case *int64:
+		if val != nil {
+			return *val, *val > 0
 		}
+	case int:
+		return int64(val), val > 0
+	case float64:
+		return int64(val), val > 0
 	}
-
-	return row, nil
+	return 0, false
 }
 
 // CheckNameExists checks if a file name already exists for an owner
@@ -349,6 +428,21 @@ func (r *fileRepository) CheckNameExists(ctx context.Context, ownerID, name stri
 	return count > 0, nil
 }
 
+// GetByName returns the file of an owner with the given name. Owner and name are
+// unique together; reads go to the master so a file just created is found.
+func (r *fileRepository) GetByName(ctx context.Context, ownerID, name string) (*ent.File, error) {
+	row, err := r.ec.File.Query().
+		Where(
+			fileEnt.OwnerIDEQ(ownerID),
+			fileEnt.NameEQ(name),
+		).
+		Only(ctx)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return row, nil
+}
+
 // GetByID gets file by ID with improved caching
 func (r *fileRepository) GetByID(ctx context.Context, slug string) (*ent.File, error) {
 	cacheKey := fmt.Sprintf("%s", slug)
@@ -374,6 +468,26 @@ func (r *fileRepository) GetByID(ctx context.Context, slug string) (*ent.File, e
 	return row, nil
 }
 
+// GetByIDs gets the files matching any of the IDs or names in one query
+func (r *fileRepository) GetByIDs(ctx context.Context, slugs []string) ([]*ent.File, error) {
+	if len(slugs) == 0 {
+		return []*ent.File{}, nil
+	}
+
+	rows, err := r.ecr.File.Query().
+		Where(fileEnt.Or(
+			fileEnt.IDIn(slugs...),
+			fileEnt.NameIn(slugs...),
+		)).
+		All(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "fileRepo.GetByIDs error: %v", err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
 // Delete deletes file by ID
 func (r *fileRepository) Delete(ctx context.Context, slug string) error {
 	file, err := r.FindFile(ctx, &structs.FindFile{File: slug})
@@ -383,7 +497,17 @@ func (r *fileRepository) Delete(ctx context.Context, slug string) error {
 
 	builder := r.ec.File.Delete()
 
-	if _, err = builder.Where(fileEnt.IDEQ(slug)).Exec(ctx); err != nil {
+	// Delete with the file's attachments, and from Meilisearch through the outbox
+	err = utils.WithTx(ctx, r.data.Data, func(ctx context.Context) error {
+		return r.withIndexOutbox(ctx, OutboxDelete, func(ctx context.Context) (string, error) {
+			if _, err := r.attachments.DeleteByFile(ctx, file.ID); err != nil {
+				return "", err
+			}
+			_, err := builder.Where(fileEnt.IDEQ(slug)).Exec(ctx)
+			return file.ID, err
+		})
+	})
+	if err != nil {
 		logger.Errorf(ctx, "fileRepo.Delete error: %v", err)
 		return err
 	}
@@ -396,13 +520,6 @@ func (r *fileRepository) Delete(ctx context.Context, slug string) error {
 		}
 	}
 
-	// Delete from Meilisearch
-	if r.sc != nil {
-		if err = r.sc.Delete(ctx, "files", file.ID); err != nil {
-			logger.Errorf(ctx, "fileRepo.Delete index error: %v", err)
-		}
-	}
-
 	return nil
 }
 
@@ -427,7 +544,7 @@ func (r *fileRepository) FindFile(ctx context.Context, params *structs.FindFile)
 
 	row, err := builder.Only(ctx)
 	if validator.IsNotNil(err) {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return row, nil
@@ -441,13 +558,14 @@ func (r *fileRepository) List(ctx context.Context, params *structs.ListFileParam
 	}
 
 	if params.Cursor != "" {
-		id, timestamp, err := paging.DecodeCursor(params.Cursor)
+		cursor, err := page.DecodeCursor(params.Cursor)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor: %v", err)
+			return nil, err
 		}
+		id, timestamp := cursor.ID, cursor.Key
 
 		if !nanoid.IsPrimaryKey(id) {
-			return nil, fmt.Errorf("invalid id in cursor: %s", id)
+			return nil, page.ErrInvalidCursor
 		}
 
 		if params.Direction == "backward" {
@@ -514,6 +632,11 @@ func (r *fileRepository) ListBuilder(params *structs.ListFileParams) (*ent.FileQ
 		builder = builder.Where(fileEnt.StorageEQ(params.Storage))
 	}
 
+	// Filter by bucket
+	if params.Bucket != "" {
+		builder = builder.Where(fileEnt.BucketEQ(params.Bucket))
+	}
+
 	// Filter by category
 	if params.Category != "" {
 		builder = builder.Where(fileEnt.CategoryEQ(string(params.Category)))
@@ -584,9 +707,43 @@ func (r *fileRepository) ListBuilder(params *structs.ListFileParams) (*ent.FileQ
 		}
 	}
 
+	// Filter by custom fields of the user metadata, all must match
+	filters, err := params.CustomFieldFilters()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range filters {
+		builder = builder.Where(customFieldPredicate(f))
+	}
+
 	return builder, nil
 }
 
+// customFieldPredicate matches a custom field filter against extras.metadata
+func customFieldPredicate(f structs.CustomFieldFilter) func(*sql.Selector) {
+	path := sqljson.Path("metadata", f.Key)
+	return func(s *sql.Selector) {
+		switch f.Op {
+		case structs.CustomFieldEQ:
+			s.Where(sqljson.ValueEQ(fileEnt.FieldExtras, f.Value, path))
+		case structs.CustomFieldNE:
+			s.Where(sqljson.ValueNEQ(fileEnt.FieldExtras, f.Value, path))
+		case structs.CustomFieldGT:
+			s.Where(sqljson.ValueGT(fileEnt.FieldExtras, f.Value, path))
+		case structs.CustomFieldGTE:
+			s.Where(sqljson.ValueGTE(fileEnt.FieldExtras, f.Value, path))
+		case structs.CustomFieldLT:
+			s.Where(sqljson.ValueLT(fileEnt.FieldExtras, f.Value, path))
+		case structs.CustomFieldLTE:
+			s.Where(sqljson.ValueLTE(fileEnt.FieldExtras, f.Value, path))
+		case structs.CustomFieldContains:
+			s.Where(sqljson.StringContains(fileEnt.FieldExtras, f.Value.(string), path))
+		case structs.CustomFieldExists:
+			s.Where(sqljson.HasKey(fileEnt.FieldExtras, path))
+		}
+	}
+}
+
 // CountX counts files
 func (r *fileRepository) CountX(ctx context.Context, params *structs.ListFileParams) int {
 	builder, err := r.ListBuilder(params)
@@ -620,6 +777,39 @@ func (r *fileRepository) SumSizeByOwner(ctx context.Context, ownerID string) (in
 	return totalSize, nil
 }
 
+// SumSizeByScope sums the size of an owner's files in a category and/or in a folder and its
+// subfolders. Folders are matched on the folder the file was uploaded to, extras.path_prefix,
+// not on the storage path, which a deduplicated file shares with a file of another folder.
+func (r *fileRepository) SumSizeByScope(ctx context.Context, ownerID, category, pathPrefix string) (int64, error) {
+	builder := r.ecr.File.Query().Where(fileEnt.OwnerIDEQ(ownerID))
+
+	if category != "" {
+		builder = builder.Where(fileEnt.CategoryEQ(category))
+	}
+	if folder := FolderPath(pathPrefix); folder != "" {
+		builder = builder.Where(func(s *sql.Selector) {
+			path := sqljson.Path("path_prefix")
+			s.Where(sql.Or(
+				sqljson.ValueEQ(fileEnt.FieldExtras, folder, path),
+				sqljson.StringHasPrefix(fileEnt.FieldExtras, folder+"/", path),
+			))
+		})
+	}
+
+	files, err := builder.Select(fileEnt.FieldSize).All(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "Error querying files for scoped size calculation for owner %s: %v", ownerID, err)
+		return 0, err
+	}
+
+	var totalSize int64
+	for _, file := range files {
+		totalSize += int64(file.Size)
+	}
+
+	return totalSize, nil
+}
+
 // GetAllOwners gets all unique owners
 func (r *fileRepository) GetAllOwners(ctx context.Context) ([]string, error) {
 	owners, err := r.ecr.File.Query().
@@ -760,3 +950,22 @@ func (r *fileRepository) FindDuplicateFiles(ctx context.Context) (map[string][]*
 
 	return groups, nil
 }
+
+// FindByObjectPrefix pages through files whose storage path or thumbnail path starts with prefix, ordered by ID
+func (r *fileRepository) FindByObjectPrefix(ctx context.Context, prefix, afterID string, limit int) ([]*ent.File, error) {
+	query := r.ecr.File.Query().Where(fileEnt.Or(
+		fileEnt.PathHasPrefix(prefix),
+		func(s *sql.Selector) {
+			s.Where(sqljson.StringHasPrefix(fileEnt.FieldExtras, prefix, sqljson.Path("thumbnail_path")))
+		},
+	))
+	if afterID != "" {
+		query = query.Where(fileEnt.IDGT(afterID))
+	}
+
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	return query.Order(ent.Asc(fileEnt.FieldID)).Limit(limit).All(ctx)
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/ncobase/ncore/types"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestClient(t *testing.T) *ent.Client {
+	t.Helper()
+	client, err := ent.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return client
+}
+
+func TestSumSizeByScopeUsesUploadFolder(t *testing.T) {
+	client := openTestClient(t)
+	ctx := context.Background()
+
+	files := []struct {
+		name, path, folder, category string
+		size                         int
+	}{
+		{"a.pdf", "o1/docs/a.pdf", "docs", "document", 100},
+		{"b.pdf", "o1/docs/2024/b.pdf", "docs/2024", "document", 20},
+		// deduplicated into docs, its storage path is the one of a.pdf
+		{"c.pdf", "o1/docs/a.pdf", "photos", "document", 100},
+		{"d.png", "o1/docsx/d.png", "docsx", "image", 7},
+		{"e.png", "o1/e.png", "", "image", 3},
+	}
+	for _, f := range files {
+		extras := types.JSON{}
+		if f.folder != "" {
+			extras["path_prefix"] = f.folder
+		}
+		if _, err := client.File.Create().
+			SetName(f.name).SetPath(f.path).SetOwnerID("o1").SetSize(f.size).
+			SetCategory(f.category).SetExtras(extras).
+			Save(ctx); err != nil {
+			t.Fatalf("create %s: %v", f.name, err)
+		}
+	}
+
+	r := &fileRepository{ecr: client}
+	for _, tc := range []struct {
+		category, folder string
+		want             int64
+	}{
+		{"", "docs", 120},
+		{"", "/docs/", 120},
+		{"", "docs/2024", 20},
+		{"", "photos", 100},
+		{"image", "", 10},
+		{"document", "docs", 120},
+	} {
+		got, err := r.SumSizeByScope(ctx, "o1", tc.category, tc.folder)
+		if err != nil {
+			t.Fatalf("SumSizeByScope(%q, %q): %v", tc.category, tc.folder, err)
+		}
+		if got != tc.want {
+			t.Errorf("SumSizeByScope(%q, %q) = %d, want %d", tc.category, tc.folder, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeExtrasTrimsFolder(t *testing.T) {
+	extras, changed := NormalizeExtras(types.JSON{"path_prefix": "/docs/2024/"})
+	if !changed || extras["path_prefix"] != "docs/2024" {
+		t.Fatalf("NormalizeExtras = %v, %v, want docs/2024", extras, changed)
+	}
+	if _, changed := NormalizeExtras(types.JSON{"path_prefix": "docs"}); changed {
+		t.Fatal("canonical path_prefix reported as changed")
+	}
+}
+
+func TestUpdateRejectsStaleVersion(t *testing.T) {
+	client := openTestClient(t)
+	ctx := context.Background()
+	r := &fileRepository{ec: client, ecr: client}
+
+	created, err := client.File.Create().SetName("a.txt").SetPath("o1/a.txt").SetOwnerID("o1").SetUpdatedAt(1000).Save(ctx)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// both editors read version 1000, the first update wins
+	first, err := r.Update(ctx, created.ID, types.JSON{"version": int64(1000), "tags": []string{"first"}})
+	if err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if first.UpdatedAt <= 1000 {
+		t.Fatalf("version after update = %d, want it bumped", first.UpdatedAt)
+	}
+	if _, err := r.Update(ctx, created.ID, types.JSON{"version": int64(1000), "tags": []string{"second"}}); !IsVersionConflict(err) {
+		t.Fatalf("stale update = %v, want ErrVersionConflict", err)
+	}
+
+	// after reloading, the second editor's update goes through
+	second, err := r.Update(ctx, created.ID, types.JSON{"version": float64(first.UpdatedAt), "tags": []string{"second"}})
+	if err != nil {
+		t.Fatalf("update after reload: %v", err)
+	}
+	if len(second.Tags) != 1 || second.Tags[0] != "second" || second.UpdatedAt <= first.UpdatedAt {
+		t.Fatalf("file after reload = tags %v, version %d", second.Tags, second.UpdatedAt)
+	}
+
+	// updates without a version are not guarded
+	if _, err := r.Update(ctx, created.ID, types.JSON{"tags": []string{"unguarded"}}); err != nil {
+		t.Fatalf("unguarded update: %v", err)
+	}
+}
+
+func TestNextVersionIsStrictlyGreater(t *testing.T) {
+	future := int64(1) << 60
+	if got := NextVersion(future); got != future+1 {
+		t.Fatalf("NextVersion of a future version = %d, want %d", got, future+1)
+	}
+	if got := NextVersion(1000); got <= 1000 {
+		t.Fatalf("NextVersion(1000) = %d", got)
+	}
+}
+
+func TestUpdatePartialFields(t *testing.T) {
+	client := openTestClient(t)
+	ctx := context.Background()
+	r := &fileRepository{ec: client, ecr: client}
+
+	created, err := client.File.Create().
+		SetName("a.txt").SetPath("o1/a.txt").SetOwnerID("o1").
+		SetTags([]string{"old"}).SetAccessLevel(string(structs.AccessLevelPrivate)).SetExpiresAt(1700000000000).
+		Save(ctx)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	tags := []string{"new"}
+	level := structs.AccessLevelShared
+	noExpiry := int64(0)
+	for _, body := range []*structs.UpdateFileBody{{Tags: &tags}, {AccessLevel: &level}, {ExpiresAt: &noExpiry}} {
+		if _, err := r.Update(ctx, created.ID, body.ToUpdates()); err != nil {
+			t.Fatalf("update %+v: %v", body, err)
+		}
+	}
+
+	row, err := client.File.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(row.Tags) != 1 || row.Tags[0] != "new" || row.AccessLevel != string(structs.AccessLevelShared) {
+		t.Fatalf("tags %v, access level %s", row.Tags, row.AccessLevel)
+	}
+	if row.ExpiresAt != nil {
+		t.Fatalf("expiry = %d, want cleared", *row.ExpiresAt)
+	}
+	if row.Name != "a.txt" || row.Path != "o1/a.txt" {
+		t.Fatalf("untouched fields changed: %s %s", row.Name, row.Path)
+	}
+}
+
+func TestListBuilderFiltersCustomFields(t *testing.T) {
+	client := openTestClient(t)
+	ctx := context.Background()
+	for id, metadata := range map[string]map[string]any{
+		"f1": {"department": "finance", "pages": 12, "reviewed": true},
+		"f2": {"department": "finance", "pages": 3},
+		"f3": {"department": "legal", "pages": 40, "reviewed": false},
+		"f4": nil,
+	} {
+		extras := types.JSON{}
+		if metadata != nil {
+			extras["metadata"] = metadata
+		}
+		if _, err := client.File.Create().SetID(id).SetName(id).SetOwnerID("o1").SetExtras(extras).Save(ctx); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+
+	r := &fileRepository{ecr: client}
+	for _, tc := range []struct {
+		filters []string
+		want    string
+	}{
+		{[]string{"department:eq:finance"}, "[f1 f2]"},
+		{[]string{"department:eq:finance", "pages:gte:10"}, "[f1]"},
+		{[]string{"pages:lt:40"}, "[f1 f2]"},
+		{[]string{"reviewed:exists"}, "[f1 f3]"},
+		{[]string{"reviewed:eq:true"}, "[f1]"},
+		{[]string{"department:contains:ega"}, "[f3]"},
+		{[]string{"department:ne:finance"}, "[f3]"},
+	} {
+		builder, err := r.ListBuilder(&structs.ListFileParams{OwnerID: "o1", CustomFields: tc.filters})
+		if err != nil {
+			t.Fatalf("ListBuilder(%v): %v", tc.filters, err)
+		}
+		ids, err := builder.Order(ent.Asc("id")).IDs(ctx)
+		if err != nil {
+			t.Fatalf("list %v: %v", tc.filters, err)
+		}
+		if fmt.Sprint(ids) != tc.want {
+			t.Errorf("files matching %v = %v, want %s", tc.filters, ids, tc.want)
+		}
+	}
+
+	if _, err := r.ListBuilder(&structs.ListFileParams{CustomFields: []string{"pages:gt:many"}}); err == nil {
+		t.Fatal("filter with a non numeric bound accepted")
+	}
+}
+
+func TestFindByObjectPrefixMatchesThumbnails(t *testing.T) {
+	client := openTestClient(t)
+	ctx := context.Background()
+
+	for _, f := range []struct{ path, thumb string }{
+		{"o1/a.png", "thumbs/s1/a_64x48.jpg"},
+		{"thumbs/s1/b.png", ""},
+		{"o1/c.png", "o1/c_thumb.jpg"},
+		{"o1/d.txt", ""},
+	} {
+		extras := types.JSON{}
+		if f.thumb != "" {
+			extras["thumbnail_path"] = f.thumb
+		}
+		client.File.Create().SetName(f.path).SetPath(f.path).SetOwnerID("o1").SetExtras(extras).SaveX(ctx)
+	}
+
+	r := &fileRepository{ecr: client}
+	rows, err := r.FindByObjectPrefix(ctx, "thumbs/", "", 10)
+	if err != nil {
+		t.Fatalf("FindByObjectPrefix: %v", err)
+	}
+	var paths []string
+	for _, row := range rows {
+		paths = append(paths, row.Path)
+	}
+	sort.Strings(paths)
+	if fmt.Sprint(paths) != "[o1/a.png thumbs/s1/b.png]" {
+		t.Fatalf("files under thumbs/ = %v, want the file and the one with a thumbnail there", paths)
+	}
+}
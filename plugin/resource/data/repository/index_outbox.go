@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/ent"
+	outboxEnt "ncobase/plugin/resource/data/ent/indexoutbox"
+	"time"
+)
+
+// Index outbox operations
+const (
+	OutboxIndex  = "index"  // the file document is written to the index
+	OutboxDelete = "delete" // the file document is removed from the index
+)
+
+// Index outbox statuses
+const (
+	OutboxPending = "pending"
+	OutboxDone    = "done"
+	OutboxDead    = "dead" // given up after too many attempts
+)
+
+// maxOutboxErrorLength bounds the stored error of a failed attempt
+const maxOutboxErrorLength = 1000
+
+// IndexOutboxRepositoryInterface stores the search index updates waiting to be delivered
+type IndexOutboxRepositoryInterface interface {
+	Enqueue(ctx context.Context, fileID, operation string) (*ent.IndexOutbox, error)
+	Due(ctx context.Context, now time.Time, limit int) ([]*ent.IndexOutbox, error)
+	MarkDone(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, attempts int, cause error, next time.Time) error
+	MarkDead(ctx context.Context, id string, attempts int, cause error) error
+	Requeue(ctx context.Context) (int, error)
+	PurgeDone(ctx context.Context, before time.Time) (int, error)
+	CountByStatus(ctx context.Context) (map[string]int, error)
+}
+
+type indexOutboxRepository struct {
+	ec *ent.Client
+}
+
+// NewIndexOutboxRepository creates a new index outbox repository
+func NewIndexOutboxRepository(d *data.Data) IndexOutboxRepositoryInterface {
+	return &indexOutboxRepository{ec: d.GetMasterEntClient()}
+}
+
+// Enqueue records an index update of fileID, in the transaction of ctx when there is one
+func (r *indexOutboxRepository) Enqueue(ctx context.Context, fileID, operation string) (*ent.IndexOutbox, error) {
+	return r.ec.IndexOutbox.Create().
+		SetFileID(fileID).
+		SetOperation(operation).
+		SetStatus(OutboxPending).
+		SetNextAttemptAt(0).
+		Save(ctx)
+}
+
+// Due returns up to limit pending entries whose next attempt is due, oldest first
+func (r *indexOutboxRepository) Due(ctx context.Context, now time.Time, limit int) ([]*ent.IndexOutbox, error) {
+	return r.ec.IndexOutbox.Query().
+		Where(
+			outboxEnt.StatusEQ(OutboxPending),
+			outboxEnt.NextAttemptAtLTE(now.UnixMilli()),
+		).
+		Order(ent.Asc(outboxEnt.FieldCreatedAt), ent.Asc(outboxEnt.FieldID)).
+		Limit(limit).
+		All(ctx)
+}
+
+// MarkDone records that an entry was delivered
+func (r *indexOutboxRepository) MarkDone(ctx context.Context, id string) error {
+	return r.ec.IndexOutbox.UpdateOneID(id).
+		SetStatus(OutboxDone).
+		ClearLastError().
+		Exec(ctx)
+}
+
+// MarkFailed records a failed attempt, the entry is tried again from next
+func (r *indexOutboxRepository) MarkFailed(ctx context.Context, id string, attempts int, cause error, next time.Time) error {
+	return r.ec.IndexOutbox.UpdateOneID(id).
+		SetAttempts(attempts).
+		SetLastError(outboxError(cause)).
+		SetNextAttemptAt(next.UnixMilli()).
+		Exec(ctx)
+}
+
+// MarkDead records the last failed attempt of an entry that is given up
+func (r *indexOutboxRepository) MarkDead(ctx context.Context, id string, attempts int, cause error) error {
+	return r.ec.IndexOutbox.UpdateOneID(id).
+		SetStatus(OutboxDead).
+		SetAttempts(attempts).
+		SetLastError(outboxError(cause)).
+		Exec(ctx)
+}
+
+// Requeue makes the dead entries pending again with their attempts reset
+func (r *indexOutboxRepository) Requeue(ctx context.Context) (int, error) {
+	return r.ec.IndexOutbox.Update().
+		Where(outboxEnt.StatusEQ(OutboxDead)).
+		SetStatus(OutboxPending).
+		SetAttempts(0).
+		SetNextAttemptAt(0).
+		Save(ctx)
+}
+
+// PurgeDone deletes the delivered entries last updated before the given time
+func (r *indexOutboxRepository) PurgeDone(ctx context.Context, before time.Time) (int, error) {
+	return r.ec.IndexOutbox.Delete().
+		Where(
+			outboxEnt.StatusEQ(OutboxDone),
+			outboxEnt.UpdatedAtLT(before.UnixMilli()),
+		).
+		Exec(ctx)
+}
+
+// CountByStatus counts the entries of each status
+func (r *indexOutboxRepository) CountByStatus(ctx context.Context) (map[string]int, error) {
+	var rows []struct {
+		Status string `json:"status"`
+		Count  int    `json:"count"`
+	}
+	if err := r.ec.IndexOutbox.Query().
+		GroupBy(outboxEnt.FieldStatus).
+		Aggregate(ent.Count()).
+		Scan(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// outboxError returns the message of cause cut to the stored length
+func outboxError(cause error) string {
+	if cause == nil {
+		return ""
+	}
+	msg := cause.Error()
+	if len(msg) > maxOutboxErrorLength {
+		msg = msg[:maxOutboxErrorLength]
+	}
+	return msg
+}
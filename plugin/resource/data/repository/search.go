@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ncobase/internal/utils"
+	"ncobase/plugin/resource/data/ent"
+	fileEnt "ncobase/plugin/resource/data/ent/file"
+	"ncobase/plugin/resource/structs"
+	"strings"
+	"time"
+
+	msClient "github.com/ncobase/ncore/data/meilisearch/client"
+	"github.com/ncobase/ncore/data/search"
+	"github.com/ncobase/ncore/logging/logger"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"github.com/meilisearch/meilisearch-go"
+)
+
+// FileIndex is the search index holding file documents
+const FileIndex = "files"
+
+// fileIndexSettings are applied when the file index is rebuilt
+var fileIndexSettings = &meilisearch.Settings{
+	SearchableAttributes: []string{"name", "original_name", "tags", "category", "type"},
+	FilterableAttributes: []string{"owner_id", "tags", "category", "type", "access_level", "is_public", "created_by"},
+	SortableAttributes:   []string{"created_at", "updated_at", "size", "name"},
+}
+
+// ConfigureSearchIndex applies searchable, filterable and sortable attributes to the file index.
+// It is a no-op when Meilisearch is not configured.
+func (r *fileRepository) ConfigureSearchIndex(ctx context.Context) error {
+	ms, ok := r.data.GetMeilisearch().(*msClient.Client)
+	if !ok || ms == nil || r.sc == nil {
+		return nil
+	}
+
+	indexName := r.indexName()
+	if _, err := ms.UpdateSettings(indexName, fileIndexSettings); err != nil {
+		return fmt.Errorf("failed to configure %s index: %w", indexName, err)
+	}
+	return nil
+}
+
+// BulkIndex indexes rows into the file index in one request
+func (r *fileRepository) BulkIndex(ctx context.Context, rows []*ent.File) error {
+	if r.sc == nil || len(rows) == 0 {
+		return nil
+	}
+
+	documents := make([]any, 0, len(rows))
+	for _, row := range rows {
+		documents = append(documents, row)
+	}
+	return r.sc.BulkIndex(ctx, FileIndex, documents)
+}
+
+// SyncIndex brings the index document of fileID in line with the database: the file is
+// indexed as it is now, or removed from the index when it no longer exists. It reads the
+// master so a write that just committed is seen. It is a no-op without Meilisearch.
+func (r *fileRepository) SyncIndex(ctx context.Context, fileID string) error {
+	if r.sc == nil {
+		return nil
+	}
+
+	row, err := r.ec.File.Get(ctx, fileID)
+	if IsNotFound(err) {
+		return r.sc.Delete(ctx, FileIndex, fileID)
+	}
+	if err != nil {
+		return err
+	}
+	return r.sc.Index(ctx, &search.IndexRequest{Index: FileIndex, Document: row, DocumentID: row.ID})
+}
+
+// withIndexOutbox runs write, which returns the ID of the file it changed, in a transaction
+// together with the outbox entry updating the index of that file, so the index update is
+// recorded if and only if the write commits. The entry is delivered right after the commit;
+// when that fails it stays pending for the outbox worker. Without Meilisearch write runs alone.
+func (r *fileRepository) withIndexOutbox(ctx context.Context, operation string, write func(ctx context.Context) (string, error)) error {
+	return r.withIndexOutboxBatch(ctx, operation, func(ctx context.Context) ([]string, error) {
+		fileID, err := write(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []string{fileID}, nil
+	})
+}
+
+// withIndexOutboxBatch is withIndexOutbox for a write changing several files,
+// it records one outbox entry per file returned by write
+func (r *fileRepository) withIndexOutboxBatch(ctx context.Context, operation string, write func(ctx context.Context) ([]string, error)) error {
+	if r.sc == nil {
+		_, err := write(ctx)
+		return err
+	}
+
+	return utils.WithTx(ctx, r.data.Data, func(ctx context.Context) error {
+		fileIDs, err := write(ctx)
+		if err != nil {
+			return err
+		}
+
+		entries := make([]*ent.IndexOutbox, 0, len(fileIDs))
+		for _, fileID := range fileIDs {
+			entry, err := r.outbox.Enqueue(ctx, fileID, operation)
+			if err != nil {
+				return fmt.Errorf("failed to record index update: %w", err)
+			}
+			entries = append(entries, entry)
+		}
+
+		utils.OnCommit(ctx, func() {
+			ctx := utils.WithoutTx(ctx)
+			for _, entry := range entries {
+				r.deliver(ctx, entry)
+			}
+		})
+		return nil
+	})
+}
+
+// deliver makes the first attempt at an outbox entry, failures are left to the outbox worker
+func (r *fileRepository) deliver(ctx context.Context, entry *ent.IndexOutbox) {
+	if err := r.SyncIndex(ctx, entry.FileID); err != nil {
+		logger.Warnf(ctx, "Index update of file %s deferred to the outbox: %v", entry.FileID, err)
+		if err := r.outbox.MarkFailed(ctx, entry.ID, entry.Attempts+1, err, time.Now()); err != nil {
+			logger.Errorf(ctx, "Failed to record index update attempt of file %s: %v", entry.FileID, err)
+		}
+		return
+	}
+
+	if err := r.outbox.MarkDone(ctx, entry.ID); err != nil {
+		logger.Errorf(ctx, "Failed to mark index update of file %s done: %v", entry.FileID, err)
+	}
+}
+
+// FindByOwnerAfter pages through an owner's files ordered by ID, empty ownerID pages all files
+func (r *fileRepository) FindByOwnerAfter(ctx context.Context, ownerID, afterID string, limit int) ([]*ent.File, error) {
+	query := r.ecr.File.Query()
+	if ownerID != "" {
+		query = query.Where(fileEnt.OwnerIDEQ(ownerID))
+	}
+	if afterID != "" {
+		query = query.Where(fileEnt.IDGT(afterID))
+	}
+
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	return query.Order(ent.Asc(fileEnt.FieldID)).Limit(limit).All(ctx)
+}
+
+// SearchWithFacets searches files and counts tag, category and access level facets.
+// Without Meilisearch it queries the database and returns no facets.
+func (r *fileRepository) SearchWithFacets(ctx context.Context, params *structs.FileSearchParams) ([]*ent.File, int64, map[string]map[string]int64, error) {
+	limit := params.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset := max(params.Offset, 0)
+
+	if ms, ok := r.data.GetMeilisearch().(*msClient.Client); ok && ms != nil && r.sc != nil {
+		rows, total, facets, err := r.searchMeilisearch(ctx, ms, params, limit, offset)
+		if err == nil {
+			return rows, total, facets, nil
+		}
+		logger.Warnf(ctx, "Faceted search failed, falling back to database: %v", err)
+	}
+
+	query := r.ecr.File.Query().Where(fileEnt.OwnerIDEQ(params.OwnerID))
+	if tags := params.TagList(); len(tags) > 0 {
+		query = query.Where(func(s *sql.Selector) {
+			for _, tag := range tags {
+				s.Where(sqljson.ValueContains(fileEnt.FieldTags, tag))
+			}
+		})
+	}
+	if params.Category != "" {
+		query = query.Where(fileEnt.CategoryEQ(string(params.Category)))
+	}
+	if params.AccessLevel != "" {
+		query = query.Where(fileEnt.AccessLevelEQ(string(params.AccessLevel)))
+	}
+	if params.Query != "" {
+		query = query.Where(fileEnt.Or(
+			fileEnt.NameContainsFold(params.Query),
+			fileEnt.OriginalNameContainsFold(params.Query),
+		))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	rows, err := query.Order(ent.Desc(fileEnt.FieldCreatedAt)).Offset(offset).Limit(limit).All(ctx)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return rows, int64(total), map[string]map[string]int64{}, nil
+}
+
+// searchMeilisearch runs a faceted query against the file index
+func (r *fileRepository) searchMeilisearch(ctx context.Context, ms *msClient.Client, params *structs.FileSearchParams, limit, offset int) ([]*ent.File, int64, map[string]map[string]int64, error) {
+	filters := []string{fmt.Sprintf("owner_id = %s", quoteFilter(params.OwnerID))}
+	for _, tag := range params.TagList() {
+		filters = append(filters, fmt.Sprintf("tags = %s", quoteFilter(tag)))
+	}
+	if params.Category != "" {
+		filters = append(filters, fmt.Sprintf("category = %s", quoteFilter(string(params.Category))))
+	}
+	if params.AccessLevel != "" {
+		filters = append(filters, fmt.Sprintf("access_level = %s", quoteFilter(string(params.AccessLevel))))
+	}
+
+	res, err := ms.SearchWithContext(ctx, r.indexName(), params.Query, &meilisearch.SearchRequest{
+		Filter: strings.Join(filters, " AND "),
+		Facets: structs.FileSearchFacets,
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var rows []*ent.File
+	if err := res.Hits.DecodeInto(&rows); err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to decode hits: %w", err)
+	}
+
+	facets := map[string]map[string]int64{}
+	if len(res.FacetDistribution) > 0 {
+		if err := json.Unmarshal(res.FacetDistribution, &facets); err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to decode facets: %w", err)
+		}
+	}
+
+	total := res.EstimatedTotalHits
+	if res.TotalHits > 0 {
+		total = res.TotalHits
+	}
+
+	return rows, total, facets, nil
+}
+
+// indexName returns the file index name including the configured prefix
+func (r *fileRepository) indexName() string {
+	if prefix := r.sc.GetIndexPrefix(); prefix != "" {
+		return fmt.Sprintf("%s-%s", prefix, FileIndex)
+	}
+	return FileIndex
+}
+
+// quoteFilter quotes a value for a Meilisearch filter expression
+func quoteFilter(v string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+
+	nd "github.com/ncobase/ncore/data"
+	msClient "github.com/ncobase/ncore/data/meilisearch/client"
+	"github.com/ncobase/ncore/data/search"
+)
+
+// searchDocs are the seeded files of owner o1
+var searchDocs = []*ent.File{
+	{ID: "f1", Name: "q1.pdf", OwnerID: "o1", Category: "document", AccessLevel: "private", Tags: []string{"report", "finance"}},
+	{ID: "f2", Name: "q2.pdf", OwnerID: "o1", Category: "document", AccessLevel: "public", Tags: []string{"report"}},
+	{ID: "f3", Name: "team.png", OwnerID: "o1", Category: "image", AccessLevel: "private", Tags: []string{"team"}},
+}
+
+// meiliStub answers file index searches with every seeded document and facet counts computed from them
+func meiliStub(t *testing.T, filter *string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/indexes/files/search" {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Filter string   `json:"filter"`
+			Facets []string `json:"facets"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		*filter = req.Filter
+
+		facets := map[string]map[string]int{}
+		for _, facet := range req.Facets {
+			facets[facet] = map[string]int{}
+		}
+		for _, doc := range searchDocs {
+			for _, tag := range doc.Tags {
+				facets["tags"][tag]++
+			}
+			facets["category"][doc.Category]++
+			facets["access_level"][doc.AccessLevel]++
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"hits":               searchDocs,
+			"facetDistribution":  facets,
+			"estimatedTotalHits": len(searchDocs),
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSearchMeilisearchReturnsFacets(t *testing.T) {
+	var filter string
+	srv := meiliStub(t, &filter)
+	r := &fileRepository{sc: search.NewClient(nil)}
+
+	rows, total, facets, err := r.searchMeilisearch(context.Background(), msClient.NewMeilisearch(srv.URL, ""),
+		&structs.FileSearchParams{OwnerID: "o1", Tags: "report, finance", Category: "document"}, 20, 0)
+	if err != nil {
+		t.Fatalf("searchMeilisearch: %v", err)
+	}
+	if len(rows) != 3 || total != 3 || rows[0].ID != "f1" {
+		t.Fatalf("hits = %d, total %d, want the 3 seeded files", len(rows), total)
+	}
+	want := map[string]map[string]int64{
+		"tags":         {"report": 2, "finance": 1, "team": 1},
+		"category":     {"document": 2, "image": 1},
+		"access_level": {"private": 2, "public": 1},
+	}
+	for field, counts := range want {
+		for value, n := range counts {
+			if facets[field][value] != n {
+				t.Errorf("facet %s=%s = %d, want %d", field, value, facets[field][value], n)
+			}
+		}
+	}
+	if filter != `owner_id = "o1" AND tags = "report" AND tags = "finance" AND category = "document"` {
+		t.Fatalf("filter = %s", filter)
+	}
+}
+
+func TestQuoteFilterEscapes(t *testing.T) {
+	if got := quoteFilter(`a"b\c`); got != `"a\"b\\c"` {
+		t.Fatalf("quoteFilter = %s", got)
+	}
+}
+
+func TestSearchWithFacetsFallsBackToDatabase(t *testing.T) {
+	client := openTestClient(t)
+	ctx := context.Background()
+	for _, doc := range append(searchDocs, &ent.File{ID: "f4", Name: "other.pdf", OwnerID: "o2", Tags: []string{"report"}}) {
+		if _, err := client.File.Create().SetID(doc.ID).SetName(doc.Name).SetOwnerID(doc.OwnerID).
+			SetCategory(doc.Category).SetAccessLevel(doc.AccessLevel).SetTags(doc.Tags).Save(ctx); err != nil {
+			t.Fatalf("create %s: %v", doc.ID, err)
+		}
+	}
+	r := &fileRepository{data: &data.Data{Data: &nd.Data{}}, ecr: client}
+
+	rows, total, facets, err := r.SearchWithFacets(ctx, &structs.FileSearchParams{OwnerID: "o1", Tags: "report", Query: "Q"})
+	if err != nil {
+		t.Fatalf("SearchWithFacets: %v", err)
+	}
+	var ids []string
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	if total != 2 || len(facets) != 0 {
+		t.Fatalf("hits %v, total %d, facets %v, want f1 and f2 without facets", ids, total, facets)
+	}
+	if strings.Join(ids, ",") != "f2,f1" && strings.Join(ids, ",") != "f1,f2" {
+		t.Fatalf("hits = %v, want f1 and f2", ids)
+	}
+}
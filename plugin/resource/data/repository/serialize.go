@@ -2,6 +2,7 @@ package repository
 
 import (
 	"fmt"
+	"ncobase/internal/fieldcrypt"
 	"ncobase/plugin/resource/data/ent"
 	"ncobase/plugin/resource/structs"
 	"time"
@@ -9,6 +10,13 @@ import (
 	"github.com/ncobase/ncore/types"
 )
 
+// EncryptionSchema is the schema name of files in encryption.schemas
+const EncryptionSchema = "file"
+
+func init() {
+	fieldcrypt.RegisterJSON(EncryptionSchema, "extras")
+}
+
 // CloneExtras returns a safe copy of extras.
 func CloneExtras(extras types.JSON) types.JSON {
 	if extras == nil {
@@ -35,7 +43,7 @@ func SerializeFile(row *ent.File) *structs.ReadFile {
 		return nil
 	}
 
-	extras := CloneExtras(row.Extras)
+	extras := fieldcrypt.For(EncryptionSchema).DecryptJSON("extras", CloneExtras(row.Extras))
 
 	file := &structs.ReadFile{
 		ID:           row.ID,
@@ -54,11 +62,13 @@ func SerializeFile(row *ent.File) *structs.ReadFile {
 		Category:     structs.FileCategory(row.Category),
 		Hash:         row.Hash,
 		OwnerID:      row.OwnerID,
-		Extras:       &row.Extras,
+		SharedWith:   ParseShares(extras),
+		Extras:       &extras,
 		CreatedBy:    &row.CreatedBy,
 		CreatedAt:    &row.CreatedAt,
 		UpdatedBy:    &row.UpdatedBy,
 		UpdatedAt:    &row.UpdatedAt,
+		Version:      row.UpdatedAt,
 	}
 
 	if file.ExpiresAt != nil {
@@ -75,6 +85,41 @@ func SerializeFile(row *ent.File) *structs.ReadFile {
 	return file
 }
 
+// ParseShares reads the share list stored under extras["shared_with"].
+// Entries are either share objects or, for older rows, plain user IDs.
+func ParseShares(extras types.JSON) []structs.FileShare {
+	var shares []structs.FileShare
+	switch v := extras["shared_with"].(type) {
+	case []structs.FileShare:
+		shares = append(shares, v...)
+	case []string:
+		for _, id := range v {
+			shares = append(shares, structs.FileShare{UserID: id})
+		}
+	case []any:
+		for _, item := range v {
+			switch entry := item.(type) {
+			case string:
+				shares = append(shares, structs.FileShare{UserID: entry})
+			case map[string]any:
+				share := structs.FileShare{}
+				share.UserID, _ = entry["user_id"].(string)
+				switch exp := entry["expires_at"].(type) {
+				case float64:
+					ms := int64(exp)
+					share.ExpiresAt = &ms
+				case int64:
+					share.ExpiresAt = &exp
+				}
+				if share.UserID != "" {
+					shares = append(shares, share)
+				}
+			}
+		}
+	}
+	return shares
+}
+
 // SerializeFiles converts ent.File list to structs.ReadFile list.
 func SerializeFiles(rows []*ent.File) []*structs.ReadFile {
 	results := make([]*structs.ReadFile, 0, len(rows))
@@ -83,3 +128,16 @@ func SerializeFiles(rows []*ent.File) []*structs.ReadFile {
 	}
 	return results
 }
+
+// SerializeAttachment converts an attachment row to its API shape, without the file
+func SerializeAttachment(row *ent.FileAttachment) *structs.ReadAttachment {
+	return &structs.ReadAttachment{
+		ID:         row.ID,
+		FileID:     row.FileID,
+		EntityType: row.EntityType,
+		EntityID:   row.EntityID,
+		Relation:   row.Relation,
+		CreatedBy:  row.CreatedBy,
+		CreatedAt:  row.CreatedAt,
+	}
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"ncobase/plugin/resource/data/ent"
+	fileEnt "ncobase/plugin/resource/data/ent/file"
+
+	"github.com/ncobase/ncore/logging/logger"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+)
+
+// CountTagsByOwner returns how many of an owner's files carry each tag
+func (r *fileRepository) CountTagsByOwner(ctx context.Context, ownerID string) (map[string]int, error) {
+	files, err := r.ecr.File.Query().
+		Where(fileEnt.OwnerIDEQ(ownerID)).
+		Select(fileEnt.FieldTags).
+		All(ctx)
+	if err != nil {
+		logger.Errorf(ctx, "Error getting files for tag counts: %v", err)
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, file := range files {
+		seen := make(map[string]struct{}, len(file.Tags))
+		for _, tag := range file.Tags {
+			if tag == "" {
+				continue
+			}
+			if _, ok := seen[tag]; ok {
+				continue
+			}
+			seen[tag] = struct{}{}
+			counts[tag]++
+		}
+	}
+
+	return counts, nil
+}
+
+// FindByTagAfter pages through an owner's files carrying tag, ordered by ID.
+// It reads from the master so batches see the previous batch's writes.
+func (r *fileRepository) FindByTagAfter(ctx context.Context, ownerID, tag, afterID string, limit int) ([]*ent.File, error) {
+	query := r.ec.File.Query().
+		Where(
+			fileEnt.OwnerIDEQ(ownerID),
+			func(s *sql.Selector) {
+				s.Where(sqljson.ValueContains(fileEnt.FieldTags, tag))
+			},
+		)
+	if afterID != "" {
+		query = query.Where(fileEnt.IDGT(afterID))
+	}
+
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	return query.Order(ent.Asc(fileEnt.FieldID)).Limit(limit).All(ctx)
+}
+
+// SetTagsBatch replaces the tags of several files in one transaction, together with
+// the outbox entries reindexing them, then evicts them from the cache.
+func (r *fileRepository) SetTagsBatch(ctx context.Context, tags map[string][]string) ([]*ent.File, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	var rows []*ent.File
+	err := r.withIndexOutboxBatch(ctx, OutboxIndex, func(ctx context.Context) ([]string, error) {
+		rows = make([]*ent.File, 0, len(tags))
+		err := r.data.WithEntTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+			current, err := tx.File.Query().
+				Where(fileEnt.IDIn(mapKeys(tags)...)).
+				Select(fileEnt.FieldID, fileEnt.FieldUpdatedAt).
+				All(ctx)
+			if err != nil {
+				return err
+			}
+
+			for _, file := range current {
+				row, err := tx.File.UpdateOneID(file.ID).
+					SetTags(tags[file.ID]).
+					SetUpdatedAt(NextVersion(file.UpdatedAt)).
+					Save(ctx)
+				if err != nil {
+					return err
+				}
+				rows = append(rows, row)
+			}
+			return nil
+		})
+		return fileIDs(rows), err
+	})
+	if err != nil {
+		logger.Errorf(ctx, "fileRepo.SetTagsBatch error: %v", err)
+		return nil, err
+	}
+
+	if r.c != nil {
+		for _, row := range rows {
+			if err := r.c.Delete(ctx, row.ID); err != nil {
+				logger.Errorf(ctx, "fileRepo.SetTagsBatch cache error: %v", err)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// fileIDs returns the IDs of rows
+func fileIDs(rows []*ent.File) []string {
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids
+}
+
+// mapKeys returns the keys of m in no particular order
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ncobase/plugin/resource/data"
+
+	nd "github.com/ncobase/ncore/data"
+)
+
+func TestSetTagsBatchRewritesMatchingFiles(t *testing.T) {
+	client := openTestClient(t)
+	ctx := context.Background()
+	for id, tags := range map[string][]string{
+		"f1": {"draft", "q1"}, "f2": {"draft"}, "f3": {"review"}, "f4": {"drafts"},
+	} {
+		if _, err := client.File.Create().SetID(id).SetName(id).SetOwnerID("o1").SetTags(tags).Save(ctx); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+	r := &fileRepository{data: &data.Data{Data: &nd.Data{}, EC: client}, ec: client, ecr: client}
+
+	rows, err := r.FindByTagAfter(ctx, "o1", "draft", "", 10)
+	if err != nil {
+		t.Fatalf("FindByTagAfter: %v", err)
+	}
+	if fmt.Sprint(fileIDs(rows)) != "[f1 f2]" {
+		t.Fatalf("files tagged draft = %v, want [f1 f2]", fileIDs(rows))
+	}
+	if rows, _ := r.FindByTagAfter(ctx, "o1", "draft", "f1", 10); fmt.Sprint(fileIDs(rows)) != "[f2]" {
+		t.Fatalf("files after f1 = %v, want [f2]", fileIDs(rows))
+	}
+
+	before := rows[0].UpdatedAt
+	updated, err := r.SetTagsBatch(ctx, map[string][]string{"f1": {"final", "q1"}, "f2": {"final"}})
+	if err != nil {
+		t.Fatalf("SetTagsBatch: %v", err)
+	}
+	if len(updated) != 2 || updated[0].UpdatedAt <= before {
+		t.Fatalf("updated %d files, want 2 with a new version", len(updated))
+	}
+
+	counts, err := r.CountTagsByOwner(ctx, "o1")
+	if err != nil {
+		t.Fatalf("CountTagsByOwner: %v", err)
+	}
+	if fmt.Sprint(counts) != "map[drafts:1 final:2 q1:1 review:1]" {
+		t.Fatalf("tag counts = %v", counts)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/ncobase/ncore/data/entgo/mixin"
 	"github.com/ncobase/ncore/types"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
@@ -32,7 +33,7 @@ func (File) Annotations() []schema.Annotation {
 // Mixin for File
 func (File) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.Name,
 		mixin.Path,
 		mixin.Type,
@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"strings"
+
+	"entgo.io/ent/schema/field"
+	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/index"
+)
+
+// FileAttachment holds the schema definition for the FileAttachment entity.
+// Each row attaches a file to an entity of any module, e.g. a topic or a task,
+// so a file can belong to more entities than its owner.
+type FileAttachment struct {
+	ent.Schema
+}
+
+// Annotations for FileAttachment
+func (FileAttachment) Annotations() []schema.Annotation {
+	table := strings.Join([]string{"ncse", "res", "file_attachment"}, "_")
+	return []schema.Annotation{
+		entsql.Annotation{Table: table},
+		entsql.WithComments(true),
+	}
+}
+
+// Mixin for FileAttachment
+func (FileAttachment) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		idgen.PrimaryKey,
+		mixin.CreatedBy,
+		mixin.TimeAt{},
+	}
+}
+
+// Fields for FileAttachment
+func (FileAttachment) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("file_id").
+			Comment("Attached file"),
+
+		field.String("entity_type").
+			Comment("Type of the entity the file is attached to, e.g. topic, task, space"),
+
+		field.String("entity_id").
+			Comment("ID of the entity the file is attached to"),
+
+		field.String("relation").
+			Default("attachment").
+			Comment("Relationship of the file to the entity, e.g. attachment, cover, avatar"),
+	}
+}
+
+// Edges for FileAttachment
+func (FileAttachment) Edges() []ent.Edge {
+	return []ent.Edge{}
+}
+
+// Indexes for FileAttachment
+func (FileAttachment) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("file_id", "entity_type", "entity_id", "relation").Unique(),
+		index.Fields("entity_type", "entity_id"),
+	}
+}
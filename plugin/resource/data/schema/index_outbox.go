@@ -0,0 +1,77 @@
+package schema
+
+import (
+	"strings"
+
+	"entgo.io/ent/schema/field"
+	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/index"
+)
+
+// IndexOutbox holds the schema definition for the IndexOutbox entity.
+// Each row is a search index update recorded with the file write that caused it.
+type IndexOutbox struct {
+	ent.Schema
+}
+
+// Annotations for IndexOutbox
+func (IndexOutbox) Annotations() []schema.Annotation {
+	table := strings.Join([]string{"ncse", "res", "index_outbox"}, "_")
+	return []schema.Annotation{
+		entsql.Annotation{Table: table},
+		entsql.WithComments(true),
+	}
+}
+
+// Mixin for IndexOutbox
+func (IndexOutbox) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		idgen.PrimaryKey,
+		mixin.TimeAt{},
+	}
+}
+
+// Fields for IndexOutbox
+func (IndexOutbox) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("file_id").
+			Comment("File whose index document is updated"),
+
+		field.String("operation").
+			Comment("Index operation: index, delete"),
+
+		field.String("status").
+			Default("pending").
+			Comment("Delivery status: pending, done, dead"),
+
+		field.Int("attempts").
+			Default(0).
+			Comment("Delivery attempts made"),
+
+		field.String("last_error").
+			Optional().
+			Comment("Error of the last failed attempt"),
+
+		field.Int64("next_attempt_at").
+			Default(0).
+			Comment("Earliest time of the next attempt, in milliseconds"),
+	}
+}
+
+// Edges for IndexOutbox
+func (IndexOutbox) Edges() []ent.Edge {
+	return []ent.Edge{}
+}
+
+// Indexes for IndexOutbox
+func (IndexOutbox) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("status", "next_attempt_at"),
+		index.Fields("file_id"),
+	}
+}
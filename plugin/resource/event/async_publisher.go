@@ -0,0 +1,254 @@
+package event
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// Overflow policies of the async publisher, see AsyncOptions
+const (
+	OverflowDropOldest = "drop_oldest"
+	OverflowBlock      = "block"
+)
+
+// AsyncOptions configures an async publisher
+type AsyncOptions struct {
+	// QueueSize bounds the events waiting to be published, across all workers
+	QueueSize int
+	// Workers is the number of queues; events with the same key share one, keeping their order
+	Workers int
+	// Overflow decides what happens when a queue is full: OverflowDropOldest discards
+	// the oldest queued event, OverflowBlock waits up to BlockTimeout and then drops the new one
+	Overflow string
+	// BlockTimeout bounds how long OverflowBlock waits for room
+	BlockTimeout time.Duration
+}
+
+// AsyncPublisherInterface is a publisher that queues events and publishes them in the background
+type AsyncPublisherInterface interface {
+	PublisherInterface
+	// Close stops accepting events and publishes the queued ones,
+	// giving up on those left when ctx is done
+	Close(ctx context.Context) error
+	// Dropped returns the number of events dropped because a queue was full or closed
+	Dropped() uint64
+}
+
+// queuedEvent is an event waiting to be published
+type queuedEvent struct {
+	name    string
+	publish func()
+}
+
+// asyncPublisher hands events to worker queues, so publishing never waits for subscribers.
+// Events are sharded by key (file, batch operation or space) so that the events
+// of one key are published in the order they were emitted.
+type asyncPublisher struct {
+	next PublisherInterface
+	opts AsyncOptions
+
+	queues  []chan queuedEvent
+	wg      sync.WaitGroup
+	mu      sync.RWMutex
+	closed  bool
+	dropped atomic.Uint64
+}
+
+// NewAsyncPublisher wraps a publisher with bounded background queues
+func NewAsyncPublisher(next PublisherInterface, opts AsyncOptions) AsyncPublisherInterface {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize < opts.Workers {
+		opts.QueueSize = opts.Workers
+	}
+	if opts.Overflow != OverflowBlock {
+		opts.Overflow = OverflowDropOldest
+	}
+	if opts.BlockTimeout <= 0 {
+		opts.BlockTimeout = time.Second
+	}
+
+	p := &asyncPublisher{
+		next:   next,
+		opts:   opts,
+		queues: make([]chan queuedEvent, opts.Workers),
+	}
+	for i := range p.queues {
+		p.queues[i] = make(chan queuedEvent, opts.QueueSize/opts.Workers)
+		p.wg.Add(1)
+		go p.work(p.queues[i])
+	}
+	return p
+}
+
+// work publishes the events of one queue in order until it is closed
+func (p *asyncPublisher) work(queue chan queuedEvent) {
+	defer p.wg.Done()
+	for ev := range queue {
+		p.run(ev)
+	}
+}
+
+// run publishes one event, a panicking subscriber must not stop the worker
+func (p *asyncPublisher) run(ev queuedEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf(context.Background(), "Publishing event %s panicked: %v", ev.name, r)
+		}
+	}()
+	ev.publish()
+}
+
+// enqueue queues an event on the queue of its key, applying the overflow policy when full
+func (p *asyncPublisher) enqueue(key, name string, publish func()) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ev := queuedEvent{name: name, publish: publish}
+	if p.closed {
+		p.drop(ev, "publisher closed")
+		return
+	}
+
+	queue := p.queues[shard(key, len(p.queues))]
+	select {
+	case queue <- ev:
+		return
+	default:
+	}
+
+	if p.opts.Overflow == OverflowBlock {
+		timer := time.NewTimer(p.opts.BlockTimeout)
+		defer timer.Stop()
+		select {
+		case queue <- ev:
+		case <-timer.C:
+			p.drop(ev, "queue full")
+		}
+		return
+	}
+
+	for {
+		select {
+		case queue <- ev:
+			return
+		default:
+		}
+		select {
+		case oldest := <-queue:
+			p.drop(oldest, "queue full")
+		default:
+		}
+	}
+}
+
+// drop counts and logs an event that will not be published
+func (p *asyncPublisher) drop(ev queuedEvent, reason string) {
+	n := p.dropped.Add(1)
+	logger.Warnf(context.Background(), "Dropped event %s: %s (%d dropped)", ev.name, reason, n)
+}
+
+// Close stops accepting events and waits for the queues to drain or ctx to be done
+func (p *asyncPublisher) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	for _, queue := range p.queues {
+		close(queue)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dropped returns the number of dropped events
+func (p *asyncPublisher) Dropped() uint64 {
+	return p.dropped.Load()
+}
+
+// shard maps a key to a queue index
+func shard(key string, n int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(n))
+}
+
+// detach keeps the values of a request context without its cancellation,
+// the event is usually published after the request has finished
+func detach(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return context.WithoutCancel(ctx)
+}
+
+// File events are ordered per file
+
+func (p *asyncPublisher) PublishFileCreated(ctx context.Context, data *FileEventData) {
+	ctx = detach(ctx)
+	p.enqueue(data.ID, FileCreated, func() { p.next.PublishFileCreated(ctx, data) })
+}
+
+func (p *asyncPublisher) PublishFileUpdated(ctx context.Context, data *FileEventData) {
+	ctx = detach(ctx)
+	p.enqueue(data.ID, FileUpdated, func() { p.next.PublishFileUpdated(ctx, data) })
+}
+
+func (p *asyncPublisher) PublishFileDeleted(ctx context.Context, data *FileEventData) {
+	ctx = detach(ctx)
+	p.enqueue(data.ID, FileDeleted, func() { p.next.PublishFileDeleted(ctx, data) })
+}
+
+func (p *asyncPublisher) PublishFileAccessed(ctx context.Context, data *FileEventData) {
+	ctx = detach(ctx)
+	p.enqueue(data.ID, FileAccessed, func() { p.next.PublishFileAccessed(ctx, data) })
+}
+
+// Batch operation events are ordered per operation
+
+func (p *asyncPublisher) PublishBatchUploadStarted(ctx context.Context, data *BatchOperationEventData) {
+	ctx = detach(ctx)
+	p.enqueue(data.OperationID, BatchUploadStarted, func() { p.next.PublishBatchUploadStarted(ctx, data) })
+}
+
+func (p *asyncPublisher) PublishBatchUploadComplete(ctx context.Context, data *BatchOperationEventData) {
+	ctx = detach(ctx)
+	p.enqueue(data.OperationID, BatchUploadComplete, func() { p.next.PublishBatchUploadComplete(ctx, data) })
+}
+
+func (p *asyncPublisher) PublishBatchUploadFailed(ctx context.Context, data *BatchOperationEventData) {
+	ctx = detach(ctx)
+	p.enqueue(data.OperationID, BatchUploadFailed, func() { p.next.PublishBatchUploadFailed(ctx, data) })
+}
+
+// Storage quota events are ordered per space
+
+func (p *asyncPublisher) PublishStorageQuotaWarning(ctx context.Context, data *StorageQuotaEventData) {
+	ctx = detach(ctx)
+	p.enqueue(data.SpaceID, StorageQuotaWarning, func() { p.next.PublishStorageQuotaWarning(ctx, data) })
+}
+
+func (p *asyncPublisher) PublishStorageQuotaExceeded(ctx context.Context, data *StorageQuotaEventData) {
+	ctx = detach(ctx)
+	p.enqueue(data.SpaceID, StorageQuotaExceeded, func() { p.next.PublishStorageQuotaExceeded(ctx, data) })
+}
@@ -0,0 +1,127 @@
+package event
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedPublisher records published file names, holding each publish until the gate opens
+type gatedPublisher struct {
+	PublisherInterface
+	gate    chan struct{}
+	started chan string
+	mu      sync.Mutex
+	names   []string
+}
+
+func newGatedPublisher() *gatedPublisher {
+	return &gatedPublisher{gate: make(chan struct{}), started: make(chan string, 100)}
+}
+
+func (p *gatedPublisher) PublishFileCreated(_ context.Context, data *FileEventData) {
+	p.started <- data.Name
+	<-p.gate
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.names = append(p.names, data.Name)
+}
+
+func (p *gatedPublisher) published() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.names...)
+}
+
+// fill publishes "busy" and waits until the only worker holds it, then queues names behind it
+func fill(t *testing.T, p AsyncPublisherInterface, next *gatedPublisher, names ...string) {
+	t.Helper()
+	p.PublishFileCreated(context.Background(), &FileEventData{ID: "f1", Name: "busy"})
+	select {
+	case <-next.started:
+	case <-time.After(time.Second):
+		t.Fatal("worker did not pick up the first event")
+	}
+	for _, name := range names {
+		p.PublishFileCreated(context.Background(), &FileEventData{ID: "f1", Name: name})
+	}
+}
+
+func closePublisher(t *testing.T, p AsyncPublisherInterface) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := p.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestAsyncPublisherDropsOldestWhenFull(t *testing.T) {
+	next := newGatedPublisher()
+	p := NewAsyncPublisher(next, AsyncOptions{QueueSize: 2, Workers: 1, Overflow: OverflowDropOldest})
+
+	fill(t, p, next, "a", "b", "c", "d")
+	if p.Dropped() != 2 {
+		t.Fatalf("dropped %d, want 2", p.Dropped())
+	}
+
+	close(next.gate)
+	closePublisher(t, p)
+	if got, want := next.published(), []string{"busy", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("published %v, want %v", got, want)
+	}
+}
+
+func TestAsyncPublisherBlockDropsNewAfterTimeout(t *testing.T) {
+	next := newGatedPublisher()
+	p := NewAsyncPublisher(next, AsyncOptions{QueueSize: 2, Workers: 1, Overflow: OverflowBlock, BlockTimeout: 20 * time.Millisecond})
+
+	start := time.Now()
+	fill(t, p, next, "a", "b", "c")
+	if waited := time.Since(start); waited < 20*time.Millisecond {
+		t.Fatalf("full queue returned after %s, want it to wait for room", waited)
+	}
+	if p.Dropped() != 1 {
+		t.Fatalf("dropped %d, want 1", p.Dropped())
+	}
+
+	close(next.gate)
+	closePublisher(t, p)
+	if got, want := next.published(), []string{"busy", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("published %v, want %v", got, want)
+	}
+}
+
+func TestAsyncPublisherBlockWaitsForRoom(t *testing.T) {
+	next := newGatedPublisher()
+	p := NewAsyncPublisher(next, AsyncOptions{QueueSize: 1, Workers: 1, Overflow: OverflowBlock, BlockTimeout: time.Second})
+
+	fill(t, p, next, "a")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(next.gate)
+	}()
+	p.PublishFileCreated(context.Background(), &FileEventData{ID: "f1", Name: "b"})
+
+	closePublisher(t, p)
+	if p.Dropped() != 0 {
+		t.Fatalf("dropped %d, want none", p.Dropped())
+	}
+	if got, want := next.published(), []string{"busy", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("published %v, want %v", got, want)
+	}
+}
+
+func TestAsyncPublisherDropsAfterClose(t *testing.T) {
+	next := newGatedPublisher()
+	close(next.gate)
+	p := NewAsyncPublisher(next, AsyncOptions{QueueSize: 4, Workers: 2})
+
+	closePublisher(t, p)
+	p.PublishFileCreated(context.Background(), &FileEventData{ID: "f1", Name: "late"})
+	if p.Dropped() != 1 || len(next.published()) != 0 {
+		t.Fatalf("dropped %d, published %v, want the late event dropped", p.Dropped(), next.published())
+	}
+}
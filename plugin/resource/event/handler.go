@@ -2,6 +2,7 @@ package event
 
 import (
 	"context"
+	"time"
 
 	ext "github.com/ncobase/ncore/extension/types"
 	"github.com/ncobase/ncore/logging/logger"
@@ -12,6 +13,11 @@ type QuotaUpdaterInterface interface {
 	UpdateUsage(ctx context.Context, spaceID string, quotaType string, delta int64) error
 }
 
+// AccessRecorderInterface abstracts recording file accesses for event handler
+type AccessRecorderInterface interface {
+	RecordAccess(ctx context.Context, spaceID, userID, fileID string, at time.Time) error
+}
+
 // HandlerInterface defines event handler methods
 type HandlerInterface interface {
 	HandleFileCreated(data any)
@@ -24,13 +30,15 @@ type HandlerInterface interface {
 	HandleBatchUploadComplete(data any)
 	HandleBatchUploadFailed(data any)
 	SetQuotaUpdater(updater QuotaUpdaterInterface)
+	SetAccessRecorder(recorder AccessRecorderInterface)
 }
 
 // handler handles various resource events
 type handler struct {
-	quotaUpdater QuotaUpdaterInterface
-	notifier     NotifierInterface
-	em           ext.ManagerInterface
+	quotaUpdater   QuotaUpdaterInterface
+	accessRecorder AccessRecorderInterface
+	notifier       NotifierInterface
+	em             ext.ManagerInterface
 }
 
 // NewHandler creates new event handler
@@ -49,6 +57,11 @@ func (h *handler) SetQuotaUpdater(updater QuotaUpdaterInterface) {
 	h.quotaUpdater = updater
 }
 
+// SetAccessRecorder sets the access recorder dependency
+func (h *handler) SetAccessRecorder(recorder AccessRecorderInterface) {
+	h.accessRecorder = recorder
+}
+
 // HandleFileCreated handles file creation events
 func (h *handler) HandleFileCreated(data any) {
 	eventData, ok := data.(*FileEventData)
@@ -115,6 +128,17 @@ func (h *handler) HandleFileAccessed(data any) {
 	logger.Debugf(context.Background(), "File accessed: %s, space: %s, user: %s",
 		eventData.Name, eventData.SpaceID, eventData.UserID)
 
+	// Remember the access for the user's recent files
+	if h.accessRecorder != nil && eventData.UserID != "" {
+		at := eventData.Timestamp
+		if at.IsZero() {
+			at = time.Now()
+		}
+		if err := h.accessRecorder.RecordAccess(context.Background(), eventData.SpaceID, eventData.UserID, eventData.ID, at); err != nil {
+			logger.Errorf(context.Background(), "Failed to record access to %s: %v", eventData.ID, err)
+		}
+	}
+
 	// Update access analytics
 	h.updateAccessAnalytics(eventData)
 }
@@ -83,6 +83,7 @@ type StorageQuotaEventData struct {
 	CurrentUsage int64     `json:"current_usage"` // in bytes
 	Quota        int64     `json:"quota"`         // in bytes
 	UsagePercent float64   `json:"usage_percent"`
+	Threshold    float64   `json:"threshold,omitempty"` // percent level crossed, warnings only
 	StorageType  string    `json:"storage_type"`
 }
 
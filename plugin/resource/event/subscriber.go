@@ -10,6 +10,7 @@ type SubscriberInterface interface {
 	Subscribe(em ext.ManagerInterface)
 	Unsubscribe(em ext.ManagerInterface)
 	SetQuotaUpdater(updater QuotaUpdaterInterface)
+	SetAccessRecorder(recorder AccessRecorderInterface)
 }
 
 // subscriber manages event subscriptions for the resource plugin
@@ -31,6 +32,13 @@ func (s *subscriber) SetQuotaUpdater(updater QuotaUpdaterInterface) {
 	}
 }
 
+// SetAccessRecorder sets the access recorder for the event handler
+func (s *subscriber) SetAccessRecorder(recorder AccessRecorderInterface) {
+	if s.handler != nil {
+		s.handler.SetAccessRecorder(recorder)
+	}
+}
+
 // Subscribe subscribes to all relevant events
 func (s *subscriber) Subscribe(em ext.ManagerInterface) {
 	if em == nil || s.handler == nil {
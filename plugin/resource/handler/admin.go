@@ -1,12 +1,12 @@
 package handler
 
 import (
+	"ncobase/internal/validation"
 	"ncobase/plugin/resource/service"
 	"ncobase/plugin/resource/structs"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ncobase/ncore/net/resp"
-	"github.com/ncobase/ncore/validation"
 )
 
 // AdminHandlerInterface defines admin handler methods
@@ -23,6 +23,7 @@ type AdminHandlerInterface interface {
 	SetQuota(c *gin.Context)
 	GetQuota(c *gin.Context)
 	DeleteQuota(c *gin.Context)
+	SetScopedQuota(c *gin.Context)
 
 	BatchCleanup(c *gin.Context)
 	ListBatchJobs(c *gin.Context)
@@ -249,6 +250,36 @@ func (h *adminHandler) SetQuota(c *gin.Context) {
 	resp.Success(c.Writer, result)
 }
 
+// SetScopedQuota sets a folder or category sub-limit for a user
+//
+// @Summary Admin set user scoped quota
+// @Description Cap storage of a user within a folder (folder:<path>) or category (category:<name>), zero removes the cap
+// @Tags Resource Admin
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param quota body structs.ScopedQuotaSetRequest true "Scoped quota settings"
+// @Success 200 {object} structs.ScopedQuotaInfo "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /res/admin/quotas/{user_id}/scopes [post]
+// @Security Bearer
+func (h *adminHandler) SetScopedQuota(c *gin.Context) {
+	userID := c.Param("user_id")
+
+	var body structs.ScopedQuotaSetRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.adminService.SetScopedQuota(c.Request.Context(), userID, &body)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
+
 // GetQuota gets quota for a user
 //
 // @Summary Admin get user quota
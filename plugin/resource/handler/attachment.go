@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"ncobase/plugin/resource/service"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// AttachFile handles attaching a file to an entity
+//
+// @Summary Attach file
+// @Description Attach a file to an entity of any module (e.g. a topic or a task) with a relation, default attachment
+// @Tags Resource
+// @Accept json
+// @Produce json
+// @Param slug path string true "File slug"
+// @Param body body structs.AttachFileBody true "Attach request"
+// @Success 200 {object} structs.ReadAttachment "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 403 {object} resp.Exception "forbidden"
+// @Router /res/{slug}/attachments [post]
+// @Security Bearer
+func (h *fileHandler) AttachFile(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("slug")))
+		return
+	}
+
+	result, err := h.s.File.Get(c.Request.Context(), slug)
+	if err != nil {
+		resp.Fail(c.Writer, resp.NotFound("File not found"))
+		return
+	}
+	if err := h.authorizeFileAccess(c.Request.Context(), result); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	var body structs.AttachFileBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid request body"))
+		return
+	}
+	if err := body.Validate(); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	attachment, err := h.s.File.AttachFile(c.Request.Context(), slug, &body)
+	if err != nil {
+		logger.Errorf(c.Request.Context(), "Error attaching file: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to attach file"))
+		return
+	}
+
+	resp.Success(c.Writer, attachment)
+}
+
+// DetachFile handles removing a file from an entity
+//
+// @Summary Detach file
+// @Description Remove the attachment of a file to an entity with a relation, other attachments of the file are kept
+// @Tags Resource
+// @Accept json
+// @Produce json
+// @Param slug path string true "File slug"
+// @Param body body structs.AttachFileBody true "Detach request"
+// @Success 200 {object} resp.Exception "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 403 {object} resp.Exception "forbidden"
+// @Failure 404 {object} resp.Exception "not attached"
+// @Router /res/{slug}/attachments [delete]
+// @Security Bearer
+func (h *fileHandler) DetachFile(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("slug")))
+		return
+	}
+
+	result, err := h.s.File.Get(c.Request.Context(), slug)
+	if err != nil {
+		resp.Fail(c.Writer, resp.NotFound("File not found"))
+		return
+	}
+	if err := h.authorizeFileAccess(c.Request.Context(), result); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	var body structs.AttachFileBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid request body"))
+		return
+	}
+	if err := body.Validate(); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	if err := h.s.File.DetachFile(c.Request.Context(), slug, &body); err != nil {
+		if errors.Is(err, service.ErrAttachmentNotFound) {
+			resp.Fail(c.Writer, resp.NotFound(err.Error()))
+			return
+		}
+		logger.Errorf(c.Request.Context(), "Error detaching file: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to detach file"))
+		return
+	}
+
+	resp.Success(c.Writer)
+}
+
+// ListAttachments handles listing the files attached to an entity
+//
+// @Summary List attachments
+// @Description List the files attached to an entity, oldest first, leaving out files the requester may not read
+// @Tags Resource
+// @Produce json
+// @Param entity_type query string true "Entity type, e.g. topic"
+// @Param entity_id query string true "Entity ID"
+// @Param relation query string false "Relation, all relations when empty"
+// @Success 200 {array} structs.ReadAttachment "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /res/attachments [get]
+// @Security Bearer
+func (h *fileHandler) ListAttachments(c *gin.Context) {
+	var params structs.ListAttachmentParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest("entity_type and entity_id are required"))
+		return
+	}
+
+	attachments, err := h.s.File.ListAttachments(c.Request.Context(), &params)
+	if err != nil {
+		logger.Errorf(c.Request.Context(), "Error listing attachments: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to list attachments"))
+		return
+	}
+
+	resp.Success(c.Writer, attachments)
+}
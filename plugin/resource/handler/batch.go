@@ -106,11 +106,19 @@ func (h *batchHandler) BatchUpload(c *gin.Context) {
 		params.Extras = &extras
 	}
 
-	params.ProcessingOptions = &structs.ProcessingOptions{
-		CreateThumbnail: true,
-		MaxWidth:        300,
-		MaxHeight:       300,
+	options, err := structs.ParseProcessingOptions(c.PostForm("processing_options"), c.Request.URL.Query())
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	if options == nil {
+		options = &structs.ProcessingOptions{
+			CreateThumbnail: true,
+			MaxWidth:        structs.DefaultThumbnailSize,
+			MaxHeight:       structs.DefaultThumbnailSize,
+		}
 	}
+	params.ProcessingOptions = options
 
 	result, err := h.batchService.BatchUpload(c.Request.Context(), files, params)
 	if err != nil {
@@ -155,6 +163,14 @@ func (h *batchHandler) BatchProcess(c *gin.Context) {
 		return
 	}
 
+	if body.Options != nil {
+		body.Options.ApplyDefaults()
+		if err := body.Options.Validate(); err != nil {
+			resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+			return
+		}
+	}
+
 	// Get files
 	files := make([]*structs.ReadFile, 0, len(body.IDs))
 	for _, id := range body.IDs {
@@ -3,9 +3,12 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"ncobase/internal/page"
+	"ncobase/internal/validation"
 	"ncobase/plugin/resource/service"
 	"ncobase/plugin/resource/structs"
 	"net/http"
@@ -21,27 +24,42 @@ import (
 	"github.com/ncobase/ncore/net/resp"
 	"github.com/ncobase/ncore/types"
 	"github.com/ncobase/ncore/utils"
-	"github.com/ncobase/ncore/validation"
 )
 
 // FileHandlerInterface defines file handler methods
 type FileHandlerInterface interface {
 	Create(c *gin.Context)
 	Update(c *gin.Context)
+	BulkUpdate(c *gin.Context)
 	Get(c *gin.Context)
+	BatchGet(c *gin.Context)
 	List(c *gin.Context)
+	Export(c *gin.Context)
+	RecentlyAccessed(c *gin.Context)
 	Delete(c *gin.Context)
 	Search(c *gin.Context)
+	FacetedSearch(c *gin.Context)
 	ListCategories(c *gin.Context)
 	ListTags(c *gin.Context)
+	TagCounts(c *gin.Context)
+	RenameTag(c *gin.Context)
+	DeleteTag(c *gin.Context)
 	GetVersions(c *gin.Context)
 	CreateVersion(c *gin.Context)
 	CreateThumbnail(c *gin.Context)
+	RegenerateThumbnails(c *gin.Context)
+	Copy(c *gin.Context)
 	SetAccessLevel(c *gin.Context)
+	ShareFile(c *gin.Context)
+	UnshareFile(c *gin.Context)
+	AttachFile(c *gin.Context)
+	DetachFile(c *gin.Context)
+	ListAttachments(c *gin.Context)
 	GenerateShareURL(c *gin.Context)
 	Download(c *gin.Context)
 	GetPublic(c *gin.Context)
 	GetShared(c *gin.Context)
+	DownloadShared(c *gin.Context)
 	GetThumbnail(c *gin.Context)
 	DownloadPublic(c *gin.Context)
 }
@@ -68,6 +86,8 @@ var maxFileSize int64 = 2048 << 20 // 2048 MB
 // @Param path_prefix formData string false "Custom path prefix (e.g., avatars, documents, public)"
 // @Param access_level formData string false "Access level" Enums(public, private, shared)
 // @Param is_public formData boolean false "Public access flag"
+// @Param dedupe formData boolean false "Reuse an identical stored file of the owner"
+// @Param on_conflict formData string false "Handling of a file of the owner with the same name, defaults to resource.name_conflict" Enums(rename, reject, replace)
 // @Param tags formData string false "Comma-separated tags"
 // @Param processing_options formData string false "Processing options (JSON)"
 // @Param expires_at formData integer false "Expiration timestamp"
@@ -75,6 +95,7 @@ var maxFileSize int64 = 2048 << 20 // 2048 MB
 // @Success 200 {object} structs.ReadFile "File created successfully"
 // @Success 200 {object} object{files=[]structs.ReadFile,total=int,success=int,failed=int,errors=[]string} "Batch upload result"
 // @Failure 400 {object} resp.Exception "Bad request"
+// @Failure 409 {object} resp.Exception "File name already taken"
 // @Failure 413 {object} resp.Exception "File too large"
 // @Failure 500 {object} resp.Exception "Internal server error"
 // @Router /res [post]
@@ -132,6 +153,19 @@ func (h *fileHandler) handleFormDataUpload(c *gin.Context) {
 		result, err := h.s.File.Create(c.Request.Context(), body)
 		if err != nil {
 			logger.Errorf(c.Request.Context(), "Failed to create file %s: %v", header.Filename, err)
+			if errors.Is(err, service.ErrContentTypeMismatch) {
+				resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+				return
+			}
+			if errors.Is(err, service.ErrFileNameConflict) {
+				resp.Fail(c.Writer, resp.Conflict(err.Error()))
+				return
+			}
+			var scopedErr *service.ScopedQuotaError
+			if errors.As(err, &scopedErr) {
+				resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+				return
+			}
 			resp.Fail(c.Writer, resp.InternalServer(fmt.Sprintf("Failed to create file: %v", err)))
 			return
 		}
@@ -267,6 +301,16 @@ func (h *fileHandler) bindFileFields(c *gin.Context, body *structs.CreateFileBod
 			}
 		case "is_public":
 			body.IsPublic = values[0] == "true" || values[0] == "1"
+		case "dedupe":
+			body.Dedupe = values[0] == "true" || values[0] == "1"
+		case "on_conflict":
+			if values[0] != "" {
+				mode := structs.NameConflict(values[0])
+				if !mode.IsValid() {
+					return nil, fmt.Errorf("invalid on_conflict: %s", values[0])
+				}
+				body.OnConflict = mode
+			}
 		case "tags":
 			if values[0] != "" {
 				tagList := strings.Split(values[0], ",")
@@ -279,18 +323,6 @@ func (h *fileHandler) bindFileFields(c *gin.Context, body *structs.CreateFileBod
 				}
 				body.Tags = cleanTags
 			}
-		case "processing_options":
-			if values[0] != "" {
-				var options structs.ProcessingOptions
-				if err := json.Unmarshal([]byte(values[0]), &options); err != nil {
-					return nil, fmt.Errorf("invalid processing options format: %w", err)
-				}
-				// Validate compression quality
-				if options.CompressionQuality <= 0 || options.CompressionQuality > 100 {
-					options.CompressionQuality = 80
-				}
-				body.ProcessingOptions = &options
-			}
 		case "expires_at":
 			if values[0] != "" {
 				if expiresAtInt, err := strconv.ParseInt(values[0], 10, 64); err == nil {
@@ -338,6 +370,14 @@ func (h *fileHandler) bindFileFields(c *gin.Context, body *structs.CreateFileBod
 		}
 	}
 
+	options, err := structs.ParseProcessingOptions(c.Request.Form.Get("processing_options"), c.Request.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+	if options != nil {
+		body.ProcessingOptions = options
+	}
+
 	return body, nil
 }
 
@@ -372,6 +412,44 @@ func (h *fileHandler) Get(c *gin.Context) {
 	resp.Success(c.Writer, result.InternalView())
 }
 
+// BatchGet handles retrieving several files at once
+//
+// @Summary Batch get files
+// @Description Get the details of up to 100 files by ID or name; missing and inaccessible files are reported in errors
+// @Tags Resource
+// @Accept json
+// @Produce json
+// @Param body body structs.BatchGetFilesBody true "File IDs"
+// @Success 200 {object} structs.BatchGetFilesResult "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /res/batch-get [post]
+// @Security Bearer
+func (h *fileHandler) BatchGet(c *gin.Context) {
+	body := &structs.BatchGetFilesBody{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	files, errs := h.s.File.GetBatch(c.Request.Context(), body.IDs)
+
+	result := &structs.BatchGetFilesResult{Files: make([]*structs.ReadFile, 0, len(files))}
+	for _, file := range files {
+		result.Files = append(result.Files, file.InternalView())
+	}
+	if len(errs) > 0 {
+		result.Errors = make(map[string]string, len(errs))
+		for slug, err := range errs {
+			result.Errors[slug] = err.Error()
+		}
+	}
+
+	resp.Success(c.Writer, result)
+}
+
 // GetPublic handles public file viewing
 //
 // @Summary Get public file
@@ -408,7 +486,8 @@ func (h *fileHandler) GetPublic(c *gin.Context) {
 // @Param token path string true "Share token"
 // @Success 200 {object} structs.ReadFile "success"
 // @Failure 400 {object} resp.Exception "bad request"
-// @Failure 404 {object} resp.Exception "invalid or expired share token"
+// @Failure 403 {object} resp.Exception "invalid or expired share token"
+// @Failure 404 {object} resp.Exception "file not found"
 // @Router /res/share/{token} [get]
 func (h *fileHandler) GetShared(c *gin.Context) {
 	token := c.Param("token")
@@ -419,19 +498,64 @@ func (h *fileHandler) GetShared(c *gin.Context) {
 
 	file, err := h.s.File.GetByShareToken(c.Request.Context(), token)
 	if err != nil {
-		resp.Fail(c.Writer, resp.NotFound("Invalid or expired share token"))
+		if service.IsSignatureError(err) {
+			resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+			return
+		}
+		resp.Fail(c.Writer, resp.NotFound("File not found"))
 		return
 	}
 
 	resp.Success(c.Writer, file.PublicView())
 }
 
+// DownloadShared handles shared file download
+//
+// @Summary Download shared file
+// @Description Download a file using a signed share token
+// @Tags Resource Public
+// @Produce application/octet-stream
+// @Param token path string true "Share token"
+// @Success 200 "File content"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 403 {object} resp.Exception "invalid or expired share token"
+// @Failure 404 {object} resp.Exception "file not found"
+// @Router /res/share/{token}/dl [get]
+func (h *fileHandler) DownloadShared(c *gin.Context) {
+	token := c.Param("token")
+	if token == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("token")))
+		return
+	}
+
+	fileStream, row, err := h.s.File.GetFileStreamByToken(c.Request.Context(), token)
+	if err != nil {
+		if service.IsSignatureError(err) {
+			resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+			return
+		}
+		resp.Fail(c.Writer, resp.NotFound("File not found"))
+		return
+	}
+	defer fileStream.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", row.GetFilename()))
+	if row.Type == "" {
+		c.Header("Content-Type", "application/octet-stream")
+	} else {
+		c.Header("Content-Type", row.Type)
+	}
+
+	io.Copy(c.Writer, fileStream)
+}
+
 // Update handles file updates
 //
 // @Summary Update file
-// @Description Update an existing file
+// @Description Update an existing file, accepts multipart form or a JSON structs.UpdateFileBody
 // @Tags Resource
 // @Accept multipart/form-data
+// @Accept json
 // @Produce json
 // @Param slug path string true "File slug"
 // @Param name formData string false "File name"
@@ -441,8 +565,11 @@ func (h *fileHandler) GetShared(c *gin.Context) {
 // @Param tags formData string false "Comma-separated tags"
 // @Param processing_options formData string false "Processing options (JSON)"
 // @Param extras formData string false "Additional properties (JSON)"
+// @Param version formData integer false "Version the update is based on"
+// @Param If-Match header string false "Version the update is based on"
 // @Success 200 {object} structs.ReadFile "success"
 // @Failure 400 {object} resp.Exception "bad request"
+// @Failure 409 {object} resp.Exception "version conflict"
 // @Router /res/{slug} [put]
 // @Security Bearer
 func (h *fileHandler) Update(c *gin.Context) {
@@ -462,6 +589,12 @@ func (h *fileHandler) Update(c *gin.Context) {
 		return
 	}
 
+	// JSON bodies use the typed partial update
+	if c.ContentType() == "application/json" {
+		h.updateWithBody(c, slug)
+		return
+	}
+
 	updates := make(types.JSON)
 
 	if err := c.Request.ParseMultipartForm(maxFileSize); err != nil {
@@ -543,6 +676,13 @@ func (h *fileHandler) Update(c *gin.Context) {
 			case "hash":
 				// Allow manual hash update (admin only - should add permission check)
 				updates["hash"] = values[0]
+			case "version":
+				version, err := strconv.ParseInt(values[0], 10, 64)
+				if err != nil {
+					resp.Fail(c.Writer, resp.BadRequest("Invalid version format"))
+					return
+				}
+				updates["version"] = version
 			default:
 				// Log unknown fields
 				logger.Debugf(c.Request.Context(), "Unknown update field: %s = %s", key, values[0])
@@ -586,21 +726,70 @@ func (h *fileHandler) Update(c *gin.Context) {
 		updates["file"] = file
 
 		// Handle processing options for new file
-		if processingOptionsStr := c.PostForm("processing_options"); processingOptionsStr != "" {
-			var options structs.ProcessingOptions
-			if err := json.Unmarshal([]byte(processingOptionsStr), &options); err != nil {
-				resp.Fail(c.Writer, resp.BadRequest("Invalid processing options format"))
+		options, err := structs.ParseProcessingOptions(c.PostForm("processing_options"), c.Request.URL.Query())
+		if err != nil {
+			resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+			return
+		}
+		if options != nil {
+			updates["processing_options"] = options
+		}
+	}
+
+	// If-Match header works as an alternative to the version field
+	if _, ok := updates["version"]; !ok {
+		if ifMatch := strings.Trim(c.GetHeader("If-Match"), `"`); ifMatch != "" {
+			version, err := strconv.ParseInt(ifMatch, 10, 64)
+			if err != nil {
+				resp.Fail(c.Writer, resp.BadRequest("Invalid If-Match header"))
 				return
 			}
-			if options.CompressionQuality <= 0 || options.CompressionQuality > 100 {
-				options.CompressionQuality = 80
-			}
-			updates["processing_options"] = &options
+			updates["version"] = version
 		}
 	}
 
 	result, err := h.s.File.Update(c.Request.Context(), slug, updates)
 	if err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			resp.Fail(c.Writer, resp.Conflict(err.Error()))
+			return
+		}
+		logger.Errorf(c.Request.Context(), "Failed to update file %s: %v", slug, err)
+		resp.Fail(c.Writer, resp.InternalServer(err.Error()))
+		return
+	}
+
+	resp.Success(c.Writer, result.InternalView())
+}
+
+// updateWithBody handles JSON partial updates
+func (h *fileHandler) updateWithBody(c *gin.Context, slug string) {
+	body := &structs.UpdateFileBody{}
+	if err := c.ShouldBindJSON(body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid request body"))
+		return
+	}
+	if body.Version == 0 {
+		if ifMatch := strings.Trim(c.GetHeader("If-Match"), `"`); ifMatch != "" {
+			version, err := strconv.ParseInt(ifMatch, 10, 64)
+			if err != nil {
+				resp.Fail(c.Writer, resp.BadRequest("Invalid If-Match header"))
+				return
+			}
+			body.Version = version
+		}
+	}
+	if err := body.Validate(); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.s.File.UpdateWithBody(c.Request.Context(), slug, body)
+	if err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			resp.Fail(c.Writer, resp.Conflict(err.Error()))
+			return
+		}
 		logger.Errorf(c.Request.Context(), "Failed to update file %s: %v", slug, err)
 		resp.Fail(c.Writer, resp.InternalServer(err.Error()))
 		return
@@ -609,6 +798,56 @@ func (h *fileHandler) Update(c *gin.Context) {
 	resp.Success(c.Writer, result.InternalView())
 }
 
+// BulkUpdate handles applying one patch to all files matching a filter
+//
+// @Summary Bulk update files
+// @Description Apply a patch to every file of an owner matching the filter, adding tags rather than replacing them. Files the requester may not change are reported in errors.
+// @Tags Resource
+// @Accept json
+// @Produce json
+// @Param body body structs.BulkUpdateFilesBody true "Filter and patch"
+// @Success 200 {object} structs.BulkUpdateResult "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 403 {object} resp.Exception "forbidden"
+// @Router /res/batch/update [post]
+// @Security Bearer
+func (h *fileHandler) BulkUpdate(c *gin.Context) {
+	body := &structs.BulkUpdateFilesBody{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+	if err := body.Patch.Validate(); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	if err := h.authorizeOwnerAccess(c.Request.Context(), body.Filter.OwnerID); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	affected, err := h.s.File.BulkUpdate(c.Request.Context(), &body.Filter, &body.Patch)
+	result := &structs.BulkUpdateResult{Affected: affected}
+
+	var bulkErr *service.BulkUpdateError
+	switch {
+	case errors.As(err, &bulkErr):
+		result.Errors = make(map[string]string, len(bulkErr.Failed))
+		for id, e := range bulkErr.Failed {
+			result.Errors[id] = e.Error()
+		}
+	case err != nil:
+		logger.Errorf(c.Request.Context(), "Failed to bulk update files of %s: %v", body.Filter.OwnerID, err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to update files"))
+		return
+	}
+
+	resp.Success(c.Writer, result)
+}
+
 // Delete handles file deletion
 //
 // @Summary Delete file
@@ -667,7 +906,8 @@ func (h *fileHandler) Delete(c *gin.Context) {
 // @Param size_max query integer false "Maximum file size in bytes"
 // @Param is_public query boolean false "Public flag filter"
 // @Param q query string false "Search query"
-// @Success 200 {object} structs.Result[structs.ReadFile] "Paginated file list"
+// @Param custom_field query []string false "Metadata filter key:op:value, op one of eq, ne, gt, gte, lt, lte, contains, exists" collectionFormat(multi)
+// @Success 200 {object} page.Page[structs.ReadFile] "Paginated file list"
 // @Failure 400 {object} resp.Exception "Bad request"
 // @Router /res [get]
 func (h *fileHandler) List(c *gin.Context) {
@@ -679,6 +919,10 @@ func (h *fileHandler) List(c *gin.Context) {
 		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
 		return
 	}
+	if _, err := params.CustomFieldFilters(); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
 
 	if err := h.authorizeOwnerAccess(c.Request.Context(), params.OwnerID); err != nil {
 		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
@@ -686,6 +930,10 @@ func (h *fileHandler) List(c *gin.Context) {
 	}
 
 	files, err := h.s.File.List(c.Request.Context(), params)
+	if errors.Is(err, page.ErrInvalidCursor) || errors.Is(err, page.ErrInvalidLimit) {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
 	if err != nil {
 		resp.Fail(c.Writer, resp.InternalServer(err.Error()))
 		return
@@ -695,7 +943,98 @@ func (h *fileHandler) List(c *gin.Context) {
 		*file = *file.InternalView()
 	}
 
-	resp.Success(c.Writer, files)
+	resp.Success(c.Writer, page.New(files))
+}
+
+// Export handles exporting the filtered file listing as CSV
+//
+// @Summary Export files
+// @Description Stream the files matching the list filters as CSV, admin only
+// @Tags Resource
+// @Produce text/csv
+// @Param owner_id query string true "Owner ID for filtering"
+// @Param user query string false "Created by user filter"
+// @Param type query string false "Content type filter"
+// @Param category query string false "File category filter"
+// @Param tags query string false "Comma-separated tags filter"
+// @Param access_level query string false "Access level filter"
+// @Param path_prefix query string false "Path prefix filter"
+// @Param created_after query integer false "Created after timestamp"
+// @Param created_before query integer false "Created before timestamp"
+// @Param q query string false "Search query"
+// @Param custom_field query []string false "Metadata filter key:op:value" collectionFormat(multi)
+// @Success 200 {file} file "CSV file"
+// @Failure 400 {object} resp.Exception "Bad request"
+// @Router /res/export.csv [get]
+// @Security Bearer
+func (h *fileHandler) Export(c *gin.Context) {
+	params := &structs.ListFileParams{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, params); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+	if _, err := params.CustomFieldFilters(); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=files-%s.csv", params.OwnerID))
+	c.Status(http.StatusOK)
+
+	// Headers are already sent once rows stream, so failures can only be logged
+	if err := h.s.File.ExportFilesCSV(c.Request.Context(), params, c.Writer); err != nil {
+		logger.Errorf(c.Request.Context(), "Error exporting files: %v", err)
+	}
+}
+
+// FacetedSearch handles file search with facet counts
+//
+// @Summary Faceted file search
+// @Description Search files and return tag, category and access level counts for filtering
+// @Tags Resource
+// @Produce json
+// @Param owner_id query string true "Owner ID"
+// @Param q query string false "Search query"
+// @Param tags query string false "Comma-separated tags"
+// @Param category query string false "File category"
+// @Param access_level query string false "Access level"
+// @Param limit query int false "Number of results"
+// @Param offset query int false "Result offset"
+// @Success 200 {object} structs.FileSearchResult "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /res/search/facets [get]
+// @Security Bearer
+func (h *fileHandler) FacetedSearch(c *gin.Context) {
+	var params structs.FileSearchParams
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, &params); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	if err := h.authorizeOwnerAccess(c.Request.Context(), params.OwnerID); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	result, err := h.s.File.SearchWithFacets(c.Request.Context(), &params)
+	if err != nil {
+		logger.Errorf(c.Request.Context(), "Error searching files: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to search files"))
+		return
+	}
+
+	for i, file := range result.Hits {
+		result.Hits[i] = file.InternalView()
+	}
+
+	resp.Success(c.Writer, result)
 }
 
 // Search handles file searching
@@ -710,7 +1049,7 @@ func (h *fileHandler) List(c *gin.Context) {
 // @Param tags query string false "Comma-separated tags"
 // @Param is_public query boolean false "Public flag"
 // @Param limit query int false "Number of results"
-// @Success 200 {array} structs.ReadFile "success"
+// @Success 200 {object} page.Page[structs.ReadFile] "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /res/search [get]
 // @Security Bearer
@@ -736,9 +1075,10 @@ func (h *fileHandler) Search(c *gin.Context) {
 	// Tag-based search
 	if params.Tags != "" {
 		tags := strings.Split(params.Tags, ",")
-		limit := 100
-		if params.Limit > 0 {
-			limit = params.Limit
+		limit, err := page.Limit("file", params.Limit)
+		if err != nil {
+			resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+			return
 		}
 
 		results, err := h.s.File.SearchByTags(c.Request.Context(), params.OwnerID, tags, limit)
@@ -753,12 +1093,16 @@ func (h *fileHandler) Search(c *gin.Context) {
 			internalResults[i] = file.InternalView()
 		}
 
-		resp.Success(c.Writer, internalResults)
+		resp.Success(c.Writer, page.FromSlice(internalResults))
 		return
 	}
 
 	// Standard list with filtering
 	results, err := h.s.File.List(c.Request.Context(), &params)
+	if errors.Is(err, page.ErrInvalidCursor) || errors.Is(err, page.ErrInvalidLimit) {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
 	if err != nil {
 		logger.Errorf(c.Request.Context(), "Error searching files: %v", err)
 		resp.Fail(c.Writer, resp.InternalServer("Failed to search files"))
@@ -769,7 +1113,7 @@ func (h *fileHandler) Search(c *gin.Context) {
 		*file = *file.InternalView()
 	}
 
-	resp.Success(c.Writer, results)
+	resp.Success(c.Writer, page.New(results))
 }
 
 // ListCategories handles listing file categories
@@ -794,6 +1138,37 @@ func (h *fileHandler) ListCategories(c *gin.Context) {
 	resp.Success(c.Writer, categories)
 }
 
+// RecentlyAccessed handles listing the current user's recently accessed files
+//
+// @Summary List recently accessed files
+// @Description List the files the current user accessed most recently in the current space, newest first
+// @Tags Resource
+// @Produce json
+// @Param limit query int false "Maximum number of files"
+// @Success 200 {array} structs.ReadFile "success"
+// @Failure 401 {object} resp.Exception "unauthorized"
+// @Router /res/recent [get]
+// @Security Bearer
+func (h *fileHandler) RecentlyAccessed(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := ctxutil.GetUserID(ctx)
+	if userID == "" {
+		resp.Fail(c.Writer, resp.UnAuthorized("Authentication required"))
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	files, err := h.s.File.ListRecentlyAccessed(ctx, ctxutil.GetSpaceID(ctx), userID, limit)
+	if err != nil {
+		logger.Errorf(ctx, "Error listing recently accessed files: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to list recent files"))
+		return
+	}
+
+	resp.Success(c.Writer, files)
+}
+
 // ListTags handles listing file tags
 //
 // @Summary List file tags
@@ -825,6 +1200,113 @@ func (h *fileHandler) ListTags(c *gin.Context) {
 	resp.Success(c.Writer, tags)
 }
 
+// TagCounts handles listing an owner's tags with file counts
+//
+// @Summary List tag counts
+// @Description List the distinct tags of an owner with the number of files carrying each
+// @Tags Resource
+// @Produce json
+// @Param owner_id query string true "Owner ID"
+// @Success 200 {array} structs.TagCount "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /res/tags/counts [get]
+// @Security Bearer
+func (h *fileHandler) TagCounts(c *gin.Context) {
+	ownerID := c.Query("owner_id")
+	if ownerID == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("owner_id")))
+		return
+	}
+	if err := h.authorizeOwnerAccess(c.Request.Context(), ownerID); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	tags, err := h.s.File.ListTags(c.Request.Context(), ownerID)
+	if err != nil {
+		resp.Fail(c.Writer, resp.InternalServer("Failed to get tags"))
+		return
+	}
+
+	resp.Success(c.Writer, tags)
+}
+
+// RenameTag handles renaming a tag across an owner's files
+//
+// @Summary Rename tag
+// @Description Rename a tag on all files of an owner, merging it into an existing tag of the same name
+// @Tags Resource
+// @Accept json
+// @Produce json
+// @Param tag path string true "Tag"
+// @Param body body structs.RenameTagBody true "Rename request"
+// @Success 200 {object} map[string]int "affected files"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 403 {object} resp.Exception "forbidden"
+// @Router /res/tags/{tag} [put]
+// @Security Bearer
+func (h *fileHandler) RenameTag(c *gin.Context) {
+	tag := c.Param("tag")
+	if tag == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("tag")))
+		return
+	}
+
+	var body structs.RenameTagBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	if err := h.authorizeOwnerAccess(c.Request.Context(), body.OwnerID); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	affected, err := h.s.File.RenameTag(c.Request.Context(), body.OwnerID, tag, body.Name)
+	if err != nil {
+		logger.Errorf(c.Request.Context(), "Error renaming tag %s: %v", tag, err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to rename tag"))
+		return
+	}
+
+	resp.Success(c.Writer, map[string]int{"affected": affected})
+}
+
+// DeleteTag handles removing a tag from an owner's files
+//
+// @Summary Delete tag
+// @Description Remove a tag from all files of an owner
+// @Tags Resource
+// @Produce json
+// @Param tag path string true "Tag"
+// @Param owner_id query string true "Owner ID"
+// @Success 200 {object} map[string]int "affected files"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 403 {object} resp.Exception "forbidden"
+// @Router /res/tags/{tag} [delete]
+// @Security Bearer
+func (h *fileHandler) DeleteTag(c *gin.Context) {
+	tag := c.Param("tag")
+	ownerID := c.Query("owner_id")
+	if tag == "" || ownerID == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("tag, owner_id")))
+		return
+	}
+	if err := h.authorizeOwnerAccess(c.Request.Context(), ownerID); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	affected, err := h.s.File.DeleteTag(c.Request.Context(), ownerID, tag)
+	if err != nil {
+		logger.Errorf(c.Request.Context(), "Error deleting tag %s: %v", tag, err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to delete tag"))
+		return
+	}
+
+	resp.Success(c.Writer, map[string]int{"affected": affected})
+}
+
 // GetVersions handles file version retrieval
 //
 // @Summary Get file versions
@@ -923,7 +1405,10 @@ func (h *fileHandler) CreateVersion(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param slug path string true "File slug"
-// @Param options body structs.ProcessingOptions true "Processing options"
+// @Param options body structs.ProcessingOptions false "Processing options"
+// @Param w query int false "Maximum width"
+// @Param h query int false "Maximum height"
+// @Param quality query int false "Compression quality (1-100)"
 // @Success 200 {object} structs.ReadFile "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /res/{slug}/thumbnail [post]
@@ -945,15 +1430,23 @@ func (h *fileHandler) CreateThumbnail(c *gin.Context) {
 		return
 	}
 
-	var options structs.ProcessingOptions
-	if err := c.ShouldBindJSON(&options); err != nil {
+	raw, err := c.GetRawData()
+	if err != nil {
 		resp.Fail(c.Writer, resp.BadRequest("Invalid processing options"))
 		return
 	}
-
+	options, err := structs.ParseProcessingOptions(string(raw), c.Request.URL.Query())
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	if options == nil {
+		options = &structs.ProcessingOptions{}
+	}
 	options.CreateThumbnail = true
+	options.ApplyDefaults()
 
-	file, err := h.s.File.CreateThumbnail(c.Request.Context(), slug, &options)
+	file, err := h.s.File.CreateThumbnail(c.Request.Context(), slug, options)
 	if err != nil {
 		logger.Errorf(c.Request.Context(), "Error creating thumbnail: %v", err)
 		resp.Fail(c.Writer, resp.InternalServer("Failed to create thumbnail"))
@@ -963,6 +1456,110 @@ func (h *fileHandler) CreateThumbnail(c *gin.Context) {
 	resp.Success(c.Writer, file.InternalView())
 }
 
+// RegenerateThumbnails handles rebuilding thumbnails of existing images
+//
+// @Summary Regenerate thumbnails
+// @Description Rebuild the thumbnails of the image files matching the list filters, admin only
+// @Tags Resource Admin
+// @Accept json
+// @Produce json
+// @Param owner_id query string true "Owner ID for filtering"
+// @Param path_prefix query string false "Path prefix filter"
+// @Param tags query string false "Comma-separated tags filter"
+// @Param w query int false "Maximum width"
+// @Param h query int false "Maximum height"
+// @Param options body structs.ProcessingOptions false "Processing options"
+// @Success 200 {object} map[string]int "regenerated thumbnails"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /res/admin/thumbnails/regenerate [post]
+// @Security Bearer
+func (h *fileHandler) RegenerateThumbnails(c *gin.Context) {
+	// Filters come from the query, the body holds the processing options
+	params := &structs.ListFileParams{}
+	if err := c.ShouldBindQuery(params); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	if params.OwnerID == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("owner_id")))
+		return
+	}
+
+	raw, err := c.GetRawData()
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid processing options"))
+		return
+	}
+	options, err := structs.ParseProcessingOptions(string(raw), c.Request.URL.Query())
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	processed, err := h.s.File.RegenerateThumbnails(c.Request.Context(), params, options)
+	if err != nil {
+		logger.Errorf(c.Request.Context(), "Error regenerating thumbnails: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to regenerate thumbnails"))
+		return
+	}
+
+	resp.Success(c.Writer, map[string]int{"processed": processed})
+}
+
+// Copy handles server-side file duplication
+//
+// @Summary Copy file
+// @Description Duplicate a file without re-uploading, optionally for another owner
+// @Tags Resource
+// @Accept json
+// @Produce json
+// @Param slug path string true "File slug"
+// @Param body body object{owner_id=string} false "Copy request"
+// @Success 200 {object} structs.ReadFile "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /res/{slug}/copy [post]
+// @Security Bearer
+func (h *fileHandler) Copy(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("slug")))
+		return
+	}
+
+	source, err := h.s.File.Get(c.Request.Context(), slug)
+	if err != nil {
+		resp.Fail(c.Writer, resp.NotFound(err.Error()))
+		return
+	}
+	if err := h.authorizeFileAccess(c.Request.Context(), source); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	var body struct {
+		OwnerID string `json:"owner_id"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			resp.Fail(c.Writer, resp.BadRequest("Invalid request body"))
+			return
+		}
+	}
+	if err := h.authorizeOwnerAccess(c.Request.Context(), body.OwnerID); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	file, err := h.s.File.Copy(c.Request.Context(), slug, body.OwnerID)
+	if err != nil {
+		logger.Errorf(c.Request.Context(), "Error copying file %s: %v", slug, err)
+		resp.Fail(c.Writer, resp.InternalServer(err.Error()))
+		return
+	}
+
+	resp.Success(c.Writer, file.InternalView())
+}
+
 // SetAccessLevel handles access level setting
 //
 // @Summary Set access level
@@ -1019,6 +1616,104 @@ func (h *fileHandler) SetAccessLevel(c *gin.Context) {
 	resp.Success(c.Writer, file.InternalView())
 }
 
+// ShareFile handles sharing a file with users
+//
+// @Summary Share file
+// @Description Share a file with users, optionally until an expiry
+// @Tags Resource
+// @Accept json
+// @Produce json
+// @Param slug path string true "File slug"
+// @Param body body structs.ShareFileBody true "Share request"
+// @Success 200 {object} structs.ReadFile "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 403 {object} resp.Exception "forbidden"
+// @Router /res/{slug}/shares [post]
+// @Security Bearer
+func (h *fileHandler) ShareFile(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("slug")))
+		return
+	}
+
+	result, err := h.s.File.Get(c.Request.Context(), slug)
+	if err != nil {
+		resp.Fail(c.Writer, resp.NotFound("File not found"))
+		return
+	}
+	if err := h.authorizeFileAccess(c.Request.Context(), result); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	var body structs.ShareFileBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid request body"))
+		return
+	}
+	if err := body.Validate(); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+
+	file, err := h.s.File.ShareFile(c.Request.Context(), slug, &body)
+	if err != nil {
+		logger.Errorf(c.Request.Context(), "Error sharing file: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to share file"))
+		return
+	}
+
+	resp.Success(c.Writer, file.InternalView())
+}
+
+// UnshareFile handles removing users from a file's share list
+//
+// @Summary Unshare file
+// @Description Remove users from a file's share list
+// @Tags Resource
+// @Accept json
+// @Produce json
+// @Param slug path string true "File slug"
+// @Param body body structs.UnshareFileBody true "Unshare request"
+// @Success 200 {object} structs.ReadFile "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Failure 403 {object} resp.Exception "forbidden"
+// @Router /res/{slug}/shares [delete]
+// @Security Bearer
+func (h *fileHandler) UnshareFile(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("slug")))
+		return
+	}
+
+	result, err := h.s.File.Get(c.Request.Context(), slug)
+	if err != nil {
+		resp.Fail(c.Writer, resp.NotFound("File not found"))
+		return
+	}
+	if err := h.authorizeFileAccess(c.Request.Context(), result); err != nil {
+		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+		return
+	}
+
+	var body structs.UnshareFileBody
+	if err := c.ShouldBindJSON(&body); err != nil || len(body.UserIDs) == 0 {
+		resp.Fail(c.Writer, resp.BadRequest(ecode.FieldIsRequired("user_ids")))
+		return
+	}
+
+	file, err := h.s.File.UnshareFile(c.Request.Context(), slug, body.UserIDs)
+	if err != nil {
+		logger.Errorf(c.Request.Context(), "Error unsharing file: %v", err)
+		resp.Fail(c.Writer, resp.InternalServer("Failed to unshare file"))
+		return
+	}
+
+	resp.Success(c.Writer, file.InternalView())
+}
+
 // GenerateShareURL handles share URL generation
 //
 // @Summary Generate share URL
@@ -1027,7 +1722,7 @@ func (h *fileHandler) SetAccessLevel(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param slug path string true "File slug"
-// @Param body body object{expiration_hours=int} true "Expiration settings"
+// @Param body body object{expiration_hours=int,bind_ip=bool} true "Expiration settings, bind_ip restricts the link to the caller's IP"
 // @Success 200 {object} object{url=string,expires_in=string,expires_at=string} "success"
 // @Failure 400 {object} resp.Exception "bad request"
 // @Router /res/{slug}/share [post]
@@ -1050,13 +1745,20 @@ func (h *fileHandler) GenerateShareURL(c *gin.Context) {
 	}
 
 	var body struct {
-		ExpirationHours int `json:"expiration_hours"`
+		ExpirationHours int  `json:"expiration_hours"`
+		BindIP          bool `json:"bind_ip"`
 	}
 	if err := c.ShouldBindJSON(&body); err != nil {
 		body.ExpirationHours = 24 // Default to 24 hours
 	}
 
-	shareURL, err := h.s.File.GeneratePublicURL(c.Request.Context(), slug, body.ExpirationHours)
+	var scope []string
+	if body.BindIP {
+		ip, _, _ := ctxutil.GetClientInfo(c.Request.Context())
+		scope = append(scope, fmt.Sprintf("ip:%s", ip))
+	}
+
+	shareURL, err := h.s.File.GeneratePublicURL(c.Request.Context(), slug, body.ExpirationHours, scope...)
 	if err != nil {
 		logger.Errorf(c.Request.Context(), "Error generating share URL: %v", err)
 		resp.Fail(c.Writer, resp.InternalServer("Failed to generate share URL"))
@@ -1078,6 +1780,7 @@ func (h *fileHandler) GenerateShareURL(c *gin.Context) {
 // @Param slug path string true "File slug"
 // @Success 200 "File content"
 // @Failure 400 {object} resp.Exception "bad request"
+// @Failure 403 {object} resp.Exception "file access denied"
 // @Router /res/{slug}/download [get]
 // @Security Bearer
 func (h *fileHandler) Download(c *gin.Context) {
@@ -1149,14 +1852,13 @@ func (h *fileHandler) downloadFile(c *gin.Context, dispositionType string) {
 
 	fileStream, row, err := h.s.File.GetFileStream(c.Request.Context(), slug)
 	if err != nil {
+		if errors.Is(err, service.ErrAccessDenied) {
+			resp.Fail(c.Writer, resp.Forbidden(err.Error()))
+			return
+		}
 		resp.Fail(c.Writer, resp.InternalServer(err.Error()))
 		return
 	}
-	if err := h.authorizeFileAccess(c.Request.Context(), row); err != nil {
-		fileStream.Close()
-		resp.Fail(c.Writer, resp.Forbidden(err.Error()))
-		return
-	}
 
 	filename := row.GetFilename()
 	c.Header("Content-Disposition", fmt.Sprintf("%s; filename=%s", dispositionType, filename))
@@ -6,18 +6,20 @@ import (
 
 // Handler represents resource handler
 type Handler struct {
-	File  FileHandlerInterface
-	Batch BatchHandlerInterface
-	Quota QuotaHandlerInterface
-	Admin AdminHandlerInterface
+	File   FileHandlerInterface
+	Batch  BatchHandlerInterface
+	Quota  QuotaHandlerInterface
+	Admin  AdminHandlerInterface
+	Report ReportHandlerInterface
 }
 
 // New creates new resource handler
 func New(svc *service.Service) *Handler {
 	return &Handler{
-		File:  NewFileHandler(svc),
-		Batch: NewBatchHandler(svc.File, svc.Batch, svc.Space),
-		Quota: NewQuotaHandler(svc.Quota),
-		Admin: NewAdminHandler(svc.Admin),
+		File:   NewFileHandler(svc),
+		Batch:  NewBatchHandler(svc.File, svc.Batch, svc.Space),
+		Quota:  NewQuotaHandler(svc.Quota),
+		Admin:  NewAdminHandler(svc.Admin),
+		Report: NewReportHandler(svc.Report),
 	}
 }
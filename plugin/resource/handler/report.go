@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"ncobase/internal/validation"
+	"ncobase/plugin/resource/service"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ncobase/ncore/net/resp"
+)
+
+// ReportHandlerInterface defines storage report handler methods
+type ReportHandlerInterface interface {
+	StorageReport(c *gin.Context)
+}
+
+type reportHandler struct {
+	service service.ReportServiceInterface
+}
+
+// NewReportHandler creates new storage report handler
+func NewReportHandler(service service.ReportServiceInterface) ReportHandlerInterface {
+	return &reportHandler{
+		service: service,
+	}
+}
+
+// StorageReport handles retrieving the storage history of an owner
+//
+// @Summary Get storage report
+// @Description Get daily storage snapshots of an owner within a date range, with its current usage
+// @Tags Resource Admin
+// @Produce json
+// @Param owner_id query string false "Owner (space or user), defaults to the current space"
+// @Param from query string false "Start date (YYYY-MM-DD), defaults to 30 days before to"
+// @Param to query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} structs.StorageReport "success"
+// @Failure 400 {object} resp.Exception "bad request"
+// @Router /res/reports/storage [get]
+// @Security Bearer
+func (h *reportHandler) StorageReport(c *gin.Context) {
+	params := &structs.StorageReportParams{}
+	if validationErrors, err := validation.ShouldBindAndValidateStruct(c, params); err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	} else if len(validationErrors) > 0 {
+		resp.Fail(c.Writer, resp.BadRequest("Invalid parameters", validationErrors))
+		return
+	}
+
+	result, err := h.service.StorageReport(c.Request.Context(), params)
+	if err != nil {
+		resp.Fail(c.Writer, resp.BadRequest(err.Error()))
+		return
+	}
+	resp.Success(c.Writer, result)
+}
@@ -3,6 +3,8 @@ package resource
 import (
 	"context"
 	"fmt"
+	"ncobase/internal/manifest"
+	"ncobase/internal/servicereg"
 	rConfig "ncobase/plugin/resource/config"
 	"ncobase/plugin/resource/data"
 	"ncobase/plugin/resource/event"
@@ -29,6 +31,16 @@ var (
 	group        = "res"
 )
 
+const (
+	// FileServiceName is the name the file service is published under, see servicereg.Resolve
+	FileServiceName = "resource.File"
+	// FileServiceVersion is the version of the published file service interface
+	FileServiceVersion = "1.1.0"
+)
+
+// loopStopTimeout bounds how long Cleanup waits for the background loops to return
+const loopStopTimeout = 10 * time.Second
+
 // Plugin represents resource plugin
 type Plugin struct {
 	ext.OptionalImpl
@@ -38,6 +50,11 @@ type Plugin struct {
 	em              ext.ManagerInterface
 	cleanup         func(name ...string)
 	eventSubscriber event.SubscriberInterface
+	asyncPublisher  event.AsyncPublisherInterface
+
+	// stopLoops cancels the background loops started in PostInit, loops tracks them
+	stopLoops context.CancelFunc
+	loops     sync.WaitGroup
 
 	c *rConfig.Config
 	d *data.Data
@@ -117,11 +134,25 @@ func (p *Plugin) Init(conf *config.Config, em ext.ManagerInterface) (err error)
 
 // PostInit performs setup after initialization
 func (p *Plugin) PostInit() error {
-	// Create event publisher
-	publisher := event.NewPublisher(p.em)
+	// Create event publisher, queued so slow subscribers do not hold up requests
+	var publisher event.PublisherInterface = event.NewPublisher(p.em)
+	if p.c.Events != nil && p.c.Events.Async {
+		blockTimeout, err := time.ParseDuration(p.c.Events.BlockTimeout)
+		if err != nil {
+			logger.Warnf(context.Background(), "Invalid event block timeout, using default 1s: %v", err)
+			blockTimeout = time.Second
+		}
+		p.asyncPublisher = event.NewAsyncPublisher(publisher, event.AsyncOptions{
+			QueueSize:    p.c.Events.QueueSize,
+			Workers:      p.c.Events.Workers,
+			Overflow:     p.c.Events.Overflow,
+			BlockTimeout: blockTimeout,
+		})
+		publisher = p.asyncPublisher
+	}
 
 	// Create services
-	p.s = service.New(p.em, p.d, publisher)
+	p.s = service.New(p.em, p.c, p.d, publisher)
 
 	// Create handlers
 	p.h = handler.New(p.s)
@@ -132,11 +163,26 @@ func (p *Plugin) PostInit() error {
 	// Set quota updater for event handler
 	p.eventSubscriber.SetQuotaUpdater(p.s.Quota)
 
+	// Record file accesses for recent file lists
+	p.eventSubscriber.SetAccessRecorder(p.s.Access)
+
+	// Background loops run until Cleanup
+	var loopCtx context.Context
+	loopCtx, p.stopLoops = context.WithCancel(context.Background())
+
 	// Start quota monitor if enabled
 	if p.c.QuotaManagement.EnableQuotas {
-		go p.startQuotaMonitor(p.s.Quota, p.c.QuotaManagement.QuotaCheckInterval)
+		p.goLoop(func() { p.startQuotaMonitor(loopCtx, p.s.Quota, p.c.QuotaManagement.QuotaCheckInterval) })
+	}
+
+	// Start storage report snapshots if enabled
+	if p.c.Reports != nil && p.c.Reports.EnableReports {
+		p.goLoop(func() { p.startStorageReports(loopCtx, p.s.Report, p.c.Reports.SnapshotInterval) })
 	}
 
+	// Deliver the search index updates the file writes left pending
+	p.goLoop(func() { p.s.Outbox.Run(loopCtx) })
+
 	// Subscribe to events
 	p.subscribeEvents()
 
@@ -145,13 +191,25 @@ func (p *Plugin) PostInit() error {
 
 	p.r = router.New(p.h)
 
+	// Publish the file service for other extensions, e.g. content media
+	if err := servicereg.Publish(p.em, FileServiceName, FileServiceVersion, p.s.File); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// startQuotaMonitor starts background quota monitoring
-func (p *Plugin) startQuotaMonitor(quotaService service.QuotaServiceInterface, intervalStr string) {
-	ctx := context.Background()
+// goLoop runs a background loop, tracked so Cleanup can wait for it to return
+func (p *Plugin) goLoop(loop func()) {
+	p.loops.Add(1)
+	go func() {
+		defer p.loops.Done()
+		loop()
+	}()
+}
 
+// startQuotaMonitor monitors quotas on an interval until ctx is done
+func (p *Plugin) startQuotaMonitor(ctx context.Context, quotaService service.QuotaServiceInterface, intervalStr string) {
 	interval, err := time.ParseDuration(intervalStr)
 	if err != nil {
 		logger.Warnf(ctx, "Invalid quota check interval, using default 24h: %v", err)
@@ -159,18 +217,46 @@ func (p *Plugin) startQuotaMonitor(quotaService service.QuotaServiceInterface, i
 	}
 
 	ticker := time.NewTicker(interval)
-
-	go func() {
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ticker.C:
-				if err := quotaService.MonitorQuota(ctx); err != nil {
-					logger.Errorf(ctx, "Error in quota monitoring: %v", err)
-				}
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := quotaService.MonitorQuota(ctx); err != nil && ctx.Err() == nil {
+				logger.Errorf(ctx, "Error in quota monitoring: %v", err)
 			}
 		}
-	}()
+	}
+}
+
+// startStorageReports takes storage snapshots on an interval until ctx is done.
+// A snapshot is taken at startup too, so restarts do not leave gaps in the daily history.
+func (p *Plugin) startStorageReports(ctx context.Context, reportService service.ReportServiceInterface, intervalStr string) {
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil || interval <= 0 {
+		logger.Warnf(ctx, "Invalid storage snapshot interval, using default 24h: %v", err)
+		interval = 24 * time.Hour
+	}
+
+	snapshot := func() {
+		if _, err := reportService.Snapshot(ctx, ""); err != nil && ctx.Err() == nil {
+			logger.Errorf(ctx, "Error taking storage snapshots: %v", err)
+		}
+	}
+
+	snapshot()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			snapshot()
+		}
+	}
 }
 
 // RegisterRoutes registers plugin routes
@@ -190,6 +276,34 @@ func (p *Plugin) GetServices() ext.Service {
 
 // Cleanup cleans up plugin resources
 func (p *Plugin) Cleanup() error {
+	// Stop the background loops, waiting for a run in progress to give up
+	if p.stopLoops != nil {
+		p.stopLoops()
+		done := make(chan struct{})
+		go func() {
+			p.loops.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(loopStopTimeout):
+			logger.Warnf(context.Background(), "Resource background loops did not stop within %s", loopStopTimeout)
+		}
+	}
+
+	if p.em != nil {
+		servicereg.Withdraw(p.em, FileServiceName)
+	}
+
+	// Publish the queued events before the subscribers go away
+	if p.asyncPublisher != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := p.asyncPublisher.Close(ctx); err != nil {
+			logger.Warnf(ctx, "Failed to flush resource events: %v", err)
+		}
+		cancel()
+	}
+
 	// Unsubscribe from events
 	if p.eventSubscriber != nil && p.em != nil {
 		p.eventSubscriber.Unsubscribe(p.em)
@@ -213,6 +327,64 @@ func (p *Plugin) GetMetadata() ext.Metadata {
 	}
 }
 
+// Manifest describes the permissions, events and configuration of the plugin,
+// routes are read from the router
+func (p *Plugin) Manifest() manifest.PluginManifest {
+	defaults := rConfig.New()
+	return manifest.PluginManifest{
+		Permissions: []string{"read:resources", "manage:resources"},
+		Events: manifest.Events{
+			Publishes: []string{
+				event.FileCreated, event.FileUpdated, event.FileDeleted, event.FileAccessed,
+				event.BatchUploadStarted, event.BatchUploadComplete, event.BatchUploadFailed,
+				event.StorageQuotaWarning, event.StorageQuotaExceeded,
+			},
+			Subscribes: []string{
+				event.FileCreated, event.FileUpdated, event.FileDeleted, event.FileAccessed,
+				event.StorageQuotaWarning, event.StorageQuotaExceeded,
+				event.BatchUploadStarted, event.BatchUploadComplete, event.BatchUploadFailed,
+				"exts.space.ready", "exts.all.registered",
+			},
+		},
+		Config: []manifest.ConfigField{
+			{Key: "resource.max_upload_size", Type: "int", Default: defaults.MaxUploadSize, Description: "Largest upload in bytes"},
+			{Key: "resource.allowed_types", Type: "[]string", Default: defaults.AllowedTypes, Description: "Allowed file types, * for any"},
+			{Key: "resource.default_storage", Type: "string", Default: defaults.DefaultStorage, Description: "Storage provider for new files"},
+			{Key: "resource.signing_secret", Type: "string", Description: "Secret signing public links, defaults to auth.jwt.secret"},
+			{Key: "resource.strict_types", Type: "bool", Default: defaults.StrictTypes, Description: "Reject images and PDFs whose content does not match their extension"},
+			{Key: "resource.dedupe_uploads", Type: "bool", Default: defaults.DedupeUploads, Description: "Reuse stored objects for identical uploads"},
+			{Key: "resource.name_conflict", Type: "string", Default: defaults.NameConflict, Description: "Handling of uploads named like an existing file of the owner: rename, reject or replace"},
+			{Key: "resource.image_processing.enable_thumbnails", Type: "bool", Default: defaults.ImageProcessing.EnableThumbnails},
+			{Key: "resource.image_processing.default_thumbnail_width", Type: "int", Default: defaults.ImageProcessing.DefaultThumbnailWidth},
+			{Key: "resource.image_processing.default_thumbnail_height", Type: "int", Default: defaults.ImageProcessing.DefaultThumbnailHeight},
+			{Key: "resource.image_processing.enable_resizing", Type: "bool", Default: defaults.ImageProcessing.EnableResizing},
+			{Key: "resource.image_processing.max_image_width", Type: "int", Default: defaults.ImageProcessing.MaxImageWidth},
+			{Key: "resource.image_processing.max_image_height", Type: "int", Default: defaults.ImageProcessing.MaxImageHeight},
+			{Key: "resource.image_processing.thumbnail_path_template", Type: "string", Default: defaults.ImageProcessing.ThumbnailPathTemplate, Description: "Storage key of thumbnails, with {dir}, {name}, {ext}, {owner}, {space}, {category}, {width}, {height} and {size} tokens"},
+			{Key: "resource.quota_management.enable_quotas", Type: "bool", Default: defaults.QuotaManagement.EnableQuotas},
+			{Key: "resource.quota_management.default_quota", Type: "int", Default: defaults.QuotaManagement.DefaultQuota, Description: "Storage quota of a space in bytes"},
+			{Key: "resource.quota_management.warning_threshold", Type: "float", Default: defaults.QuotaManagement.WarningThreshold, Description: "Quota usage ratio that raises a warning"},
+			{Key: "resource.quota_management.quota_check_interval", Type: "duration", Default: defaults.QuotaManagement.QuotaCheckInterval},
+			{Key: "resource.reports.enable_reports", Type: "bool", Default: defaults.Reports.EnableReports},
+			{Key: "resource.reports.snapshot_interval", Type: "duration", Default: defaults.Reports.SnapshotInterval},
+			{Key: "resource.reports.retention_days", Type: "int", Default: defaults.Reports.RetentionDays},
+			{Key: "resource.reports.top_folders", Type: "int", Default: defaults.Reports.TopFolders},
+			{Key: "resource.events.async", Type: "bool", Default: defaults.Events.Async, Description: "Publish events from background queues"},
+			{Key: "resource.events.queue_size", Type: "int", Default: defaults.Events.QueueSize, Description: "Events waiting to be published, across all workers"},
+			{Key: "resource.events.workers", Type: "int", Default: defaults.Events.Workers},
+			{Key: "resource.events.overflow", Type: "string", Default: defaults.Events.Overflow, Description: "drop_oldest or block when a queue is full"},
+			{Key: "resource.events.block_timeout", Type: "duration", Default: defaults.Events.BlockTimeout, Description: "Longest wait for room with the block policy"},
+			{Key: "resource.events.access_sample_rate", Type: "int", Default: defaults.Events.AccessSampleRate, Description: "Publish 1 in N accesses to public files"},
+			{Key: "resource.search_outbox.interval", Type: "duration", Default: defaults.SearchOutbox.Interval, Description: "Poll interval of pending search index updates"},
+			{Key: "resource.search_outbox.max_attempts", Type: "int", Default: defaults.SearchOutbox.MaxAttempts, Description: "Attempts before a search index update is dead-lettered"},
+			{Key: "resource.search_outbox.batch_size", Type: "int", Default: defaults.SearchOutbox.BatchSize},
+			{Key: "resource.search_outbox.retention", Type: "duration", Default: defaults.SearchOutbox.Retention, Description: "How long delivered search index updates are kept"},
+			{Key: "resource.batch.parallelism", Type: "int", Default: defaults.Batch.Parallelism, Description: "Files a batch operation works on at once"},
+			{Key: "resource.batch.item_timeout", Type: "duration", Default: defaults.Batch.ItemTimeout, Description: "Deadline per file of a batch operation"},
+		},
+	}
+}
+
 // Version returns plugin version
 func (p *Plugin) Version() string {
 	return version
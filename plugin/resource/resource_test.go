@@ -0,0 +1,113 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ncobase/internal/manifest"
+	"ncobase/plugin/resource/handler"
+	"ncobase/plugin/resource/router"
+	"ncobase/plugin/resource/service"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestManifestReportsRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/health", gin.WrapF(http.NotFound))
+
+	p := New()
+	p.r = router.New(handler.New(&service.Service{}))
+	p.RegisterRoutes(engine.Group("/plug"))
+	manifest.SetRouteSource(engine.Routes)
+	t.Cleanup(func() { manifest.SetRouteSource(nil) })
+
+	got := manifest.Of(p)
+	routes := map[manifest.Route]bool{}
+	for _, r := range got.Routes {
+		routes[r] = true
+	}
+	for _, want := range []manifest.Route{
+		{Method: http.MethodGet, Path: "/plug/res"},
+		{Method: http.MethodPost, Path: "/plug/res/:slug/attachments"},
+		{Method: http.MethodGet, Path: "/plug/res/share/:token"},
+		{Method: http.MethodPost, Path: "/plug/res/batch/upload"},
+	} {
+		if !routes[want] {
+			t.Errorf("route %s %s missing from %v", want.Method, want.Path, got.Routes)
+		}
+	}
+	if routes[manifest.Route{Method: http.MethodGet, Path: "/health"}] {
+		t.Error("route of another package reported")
+	}
+
+	if got.Name != p.Name() || len(got.Permissions) != 2 || len(got.Events.Publishes) == 0 || len(got.Config) == 0 {
+		t.Fatalf("manifest %+v, want the plugin metadata, permissions, events and config", got)
+	}
+}
+
+// blockingQuota counts monitor runs, each run waits for ctx like a slow scan would
+type blockingQuota struct {
+	service.QuotaServiceInterface
+	runs atomic.Int32
+}
+
+func (q *blockingQuota) MonitorQuota(ctx context.Context) error {
+	q.runs.Add(1)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// countingReports counts snapshots
+type countingReports struct {
+	service.ReportServiceInterface
+	snapshots atomic.Int32
+}
+
+func (r *countingReports) Snapshot(context.Context, string) (*structs.StorageSnapshotResult, error) {
+	r.snapshots.Add(1)
+	return &structs.StorageSnapshotResult{}, nil
+}
+
+func TestCleanupStopsBackgroundLoops(t *testing.T) {
+	before := runtime.NumGoroutine()
+	quota, reports := &blockingQuota{}, &countingReports{}
+
+	p := New()
+	var ctx context.Context
+	ctx, p.stopLoops = context.WithCancel(context.Background())
+	p.goLoop(func() { p.startQuotaMonitor(ctx, quota, "1ms") })
+	p.goLoop(func() { p.startStorageReports(ctx, reports, "1ms") })
+
+	deadline := time.Now().Add(time.Second)
+	for quota.runs.Load() == 0 || reports.snapshots.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("loops did not run: %d monitor runs, %d snapshots", quota.runs.Load(), reports.snapshots.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// a monitor run in progress gives up with the loop
+	start := time.Now()
+	if err := p.Cleanup(); err != nil {
+		t.Fatalf("cleanup: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("cleanup took %s", elapsed)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Fatalf("%d goroutines left after cleanup, want %d", n, before)
+	}
+
+	runs, snapshots := quota.runs.Load(), reports.snapshots.Load()
+	time.Sleep(10 * time.Millisecond)
+	if quota.runs.Load() != runs || reports.snapshots.Load() != snapshots {
+		t.Fatal("loops kept running after cleanup")
+	}
+}
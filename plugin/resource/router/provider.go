@@ -28,6 +28,7 @@ func (r *Router) Register(rg *gin.RouterGroup, prefix ...string) {
 	// Public routes (no authentication required)
 	rg.GET("/view/:slug", r.h.File.GetPublic)
 	rg.GET("/share/:token", r.h.File.GetShared)
+	rg.GET("/share/:token/dl", r.h.File.DownloadShared)
 	rg.GET("/thumb/:slug", r.h.File.GetThumbnail)
 	rg.GET("/dl/:slug", r.h.File.DownloadPublic)
 
@@ -42,20 +43,32 @@ func (r *Router) Register(rg *gin.RouterGroup, prefix ...string) {
 	read.GET("", r.h.File.List)
 	manage.POST("", r.h.File.Create)
 	read.GET("/:slug", r.h.File.Get)
+	read.POST("/batch-get", r.h.File.BatchGet)
 	manage.PUT("/:slug", r.h.File.Update)
 	manage.DELETE("/:slug", r.h.File.Delete)
 
 	// File search and discovery
 	read.GET("/search", r.h.File.Search)
+	read.GET("/recent", r.h.File.RecentlyAccessed)
+	read.GET("/search/facets", r.h.File.FacetedSearch)
 	read.GET("/categories", r.h.File.ListCategories)
 	read.GET("/tags", r.h.File.ListTags)
+	read.GET("/tags/counts", r.h.File.TagCounts)
+	read.GET("/attachments", r.h.File.ListAttachments)
+	manage.PUT("/tags/:tag", r.h.File.RenameTag)
+	manage.DELETE("/tags/:tag", r.h.File.DeleteTag)
 
 	// File operations
 	read.GET("/:slug/versions", r.h.File.GetVersions)
 	manage.POST("/:slug/versions", r.h.File.CreateVersion)
 	manage.POST("/:slug/thumbnail", r.h.File.CreateThumbnail)
+	manage.POST("/:slug/copy", r.h.File.Copy)
 	manage.PUT("/:slug/access", r.h.File.SetAccessLevel)
 	manage.POST("/:slug/share", r.h.File.GenerateShareURL)
+	manage.POST("/:slug/shares", r.h.File.ShareFile)
+	manage.DELETE("/:slug/shares", r.h.File.UnshareFile)
+	manage.POST("/:slug/attachments", r.h.File.AttachFile)
+	manage.DELETE("/:slug/attachments", r.h.File.DetachFile)
 	read.GET("/:slug/download", r.h.File.Download)
 
 	// User quota and usage
@@ -66,6 +79,7 @@ func (r *Router) Register(rg *gin.RouterGroup, prefix ...string) {
 	manage.POST("/batch/upload", r.h.Batch.BatchUpload)
 	manage.POST("/batch/process", r.h.Batch.BatchProcess)
 	manage.POST("/batch/delete", r.h.Batch.BatchDelete)
+	manage.POST("/batch/update", r.h.File.BulkUpdate)
 	read.GET("/status/:job_id", r.h.Batch.GetBatchStatus)
 
 	// Admin routes (admin access required)
@@ -73,6 +87,8 @@ func (r *Router) Register(rg *gin.RouterGroup, prefix ...string) {
 
 	// Admin file management
 	admin.GET("/admin/files", r.h.Admin.ListFiles)
+	admin.GET("/export.csv", r.h.File.Export)
+	admin.POST("/admin/thumbnails/regenerate", r.h.File.RegenerateThumbnails)
 	admin.DELETE("/admin/files/:slug", r.h.Admin.DeleteFile)
 	admin.PUT("/admin/files/:slug/status", r.h.Admin.SetFileStatus)
 
@@ -80,12 +96,14 @@ func (r *Router) Register(rg *gin.RouterGroup, prefix ...string) {
 	admin.GET("/admin/stats", r.h.Admin.GetStorageStats)
 	admin.GET("/admin/stats/usage", r.h.Admin.GetUsageStats)
 	admin.GET("/admin/stats/activity", r.h.Admin.GetActivityStats)
+	admin.GET("/reports/storage", r.h.Report.StorageReport)
 
 	// Admin quota management
 	admin.GET("/admin/quotas", r.h.Admin.ListQuotas)
 	admin.POST("/admin/quotas/:user_id", r.h.Admin.SetQuota)
 	admin.GET("/admin/quotas/:user_id", r.h.Admin.GetQuota)
 	admin.DELETE("/admin/quotas/:user_id", r.h.Admin.DeleteQuota)
+	admin.POST("/admin/quotas/:user_id/scopes", r.h.Admin.SetScopedQuota)
 
 	// Admin batch operations
 	admin.POST("/admin/batch/cleanup", r.h.Admin.BatchCleanup)
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ncobase/plugin/resource/data"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Recent access retention
+const (
+	recentAccessLimit = 100                 // entries kept per user
+	recentAccessTTL   = 30 * 24 * time.Hour // idle lists expire after this
+)
+
+// AccessLog keeps each user's most recently accessed files in a Redis sorted set
+// scored by access time, so repeated accesses move a file to the front.
+type AccessLog struct {
+	redis *redis.Client
+}
+
+// NewAccessLog creates a new access log
+func NewAccessLog(d *data.Data) *AccessLog {
+	rc, _ := d.GetRedis().(*redis.Client)
+	return &AccessLog{redis: rc}
+}
+
+// RecordAccess records that userID accessed fileID within spaceID at the given time
+func (l *AccessLog) RecordAccess(ctx context.Context, spaceID, userID, fileID string, at time.Time) error {
+	if l == nil || l.redis == nil || userID == "" || fileID == "" {
+		return nil
+	}
+
+	key := recentAccessKey(spaceID, userID)
+	pipe := l.redis.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(at.UnixMilli()), Member: fileID})
+	pipe.ZRemRangeByRank(ctx, key, 0, -recentAccessLimit-1)
+	pipe.Expire(ctx, key, recentAccessTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Recent returns up to limit file IDs userID accessed within spaceID, most recent first
+func (l *AccessLog) Recent(ctx context.Context, spaceID, userID string, limit int) ([]string, error) {
+	if l == nil || l.redis == nil || userID == "" {
+		return nil, nil
+	}
+	if limit <= 0 || limit > recentAccessLimit {
+		limit = recentAccessLimit
+	}
+	return l.redis.ZRevRange(ctx, recentAccessKey(spaceID, userID), 0, int64(limit-1)).Result()
+}
+
+// Forget drops fileIDs from a user's recent list
+func (l *AccessLog) Forget(ctx context.Context, spaceID, userID string, fileIDs ...string) error {
+	if l == nil || l.redis == nil || len(fileIDs) == 0 {
+		return nil
+	}
+	members := make([]any, len(fileIDs))
+	for i, id := range fileIDs {
+		members[i] = id
+	}
+	return l.redis.ZRem(ctx, recentAccessKey(spaceID, userID), members...).Err()
+}
+
+// recentAccessKey returns the Redis key of a user's recent list
+func recentAccessKey(spaceID, userID string) string {
+	return fmt.Sprintf("resource:recent:%s:%s", spaceID, userID)
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"math/rand/v2"
+	"ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+	"ncobase/plugin/resource/wrapper"
+	"sync"
+	"time"
+
+	"github.com/ncobase/ncore/utils/convert"
+)
+
+// AccessSampleRateSetting is the space setting overriding the access sample rate of public files
+const AccessSampleRateSetting = "resource.access_sample_rate"
+
+// accessSampleRateTTL is how long the sample rate of a space is cached,
+// so reads do not query the space settings each time
+const accessSampleRateTTL = time.Minute
+
+// AccessSampler decides which file accesses are published as events. Accesses to
+// private and shared files are always published so their audit trail is complete,
+// while accesses to public files are published 1 in N, N being the sample rate of
+// the space or else the configured one.
+type AccessSampler struct {
+	rate  int
+	space *wrapper.SpaceServiceWrapper
+	intn  func(n int) int
+
+	mu    sync.Mutex
+	rates map[string]cachedSampleRate
+}
+
+// cachedSampleRate is the sample rate of a space until it expires
+type cachedSampleRate struct {
+	rate    int
+	expires time.Time
+}
+
+// NewAccessSampler creates a sampler using the configured rate when spaces set none
+func NewAccessSampler(conf *config.EventConfig, space *wrapper.SpaceServiceWrapper) *AccessSampler {
+	rate := 1
+	if conf != nil && conf.AccessSampleRate > 1 {
+		rate = conf.AccessSampleRate
+	}
+	return &AccessSampler{
+		rate:  rate,
+		space: space,
+		intn:  rand.IntN,
+		rates: map[string]cachedSampleRate{},
+	}
+}
+
+// Sample reports whether the access to row within spaceID is published, with the
+// sample rate it was drawn at so consumers can weight sampled accesses
+func (s *AccessSampler) Sample(ctx context.Context, spaceID string, row *ent.File) (bool, int) {
+	if s == nil || structs.AccessLevel(row.AccessLevel) != structs.AccessLevelPublic {
+		return true, 1
+	}
+
+	rate := s.Rate(ctx, spaceID)
+	if rate <= 1 {
+		return true, 1
+	}
+	return s.intn(rate) == 0, rate
+}
+
+// Rate returns the sample rate of public file accesses within spaceID
+func (s *AccessSampler) Rate(ctx context.Context, spaceID string) int {
+	if spaceID == "" || s.space == nil {
+		return s.rate
+	}
+
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.rates[spaceID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.rate
+	}
+
+	rate := s.rate
+	if value, err := s.space.GetSetting(ctx, spaceID, AccessSampleRateSetting); err == nil && value != nil {
+		if n, err := convert.ToInt(value); err == nil && n >= 1 {
+			rate = int(n)
+		}
+	}
+
+	s.mu.Lock()
+	s.rates[spaceID] = cachedSampleRate{rate: rate, expires: now.Add(accessSampleRateTTL)}
+	s.mu.Unlock()
+
+	return rate
+}
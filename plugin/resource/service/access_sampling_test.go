@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/event"
+	"ncobase/plugin/resource/structs"
+	"ncobase/plugin/resource/wrapper"
+
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+// accessEvents records the access events published
+type accessEvents struct {
+	event.PublisherInterface
+	accessed []*event.FileEventData
+}
+
+func (p *accessEvents) PublishFileAccessed(_ context.Context, data *event.FileEventData) {
+	p.accessed = append(p.accessed, data)
+}
+
+// countingSettings counts the setting reads
+type countingSettings struct {
+	spaceSettings
+	reads int
+}
+
+func (s *countingSettings) GetSettingValue(ctx context.Context, spaceID, key string) (any, error) {
+	s.reads++
+	return s.spaceSettings.GetSettingValue(ctx, spaceID, key)
+}
+
+// newTestSampler returns a sampler at rate whose draws cycle through 0..n-1,
+// so public accesses are published exactly 1 in n
+func newTestSampler(rate int, settings wrapper.SpaceSettingServiceInterface) *AccessSampler {
+	var services map[string]any
+	if settings != nil {
+		services = map[string]any{"space/SpaceSetting": settings}
+	}
+	s := NewAccessSampler(&config.EventConfig{AccessSampleRate: rate}, wrapper.NewSpaceServiceWrapper(crossServices{services: services}))
+	draws := 0
+	s.intn = func(n int) int {
+		draws++
+		return draws % n
+	}
+	return s
+}
+
+// published counts the accesses to row out of n that the sampler publishes
+func published(s *AccessSampler, spaceID string, row *ent.File, n int) int {
+	count := 0
+	for i := 0; i < n; i++ {
+		if ok, _ := s.Sample(context.Background(), spaceID, row); ok {
+			count++
+		}
+	}
+	return count
+}
+
+func TestPrivateAccessesAreAlwaysPublished(t *testing.T) {
+	s := newTestSampler(10, nil)
+	for _, level := range []structs.AccessLevel{structs.AccessLevelPrivate, structs.AccessLevelShared, ""} {
+		row := &ent.File{ID: "f1", AccessLevel: string(level)}
+		if n := published(s, "s1", row, 100); n != 100 {
+			t.Fatalf("%d of 100 accesses to a %q file published, want all", n, level)
+		}
+		if _, rate := s.Sample(context.Background(), "s1", row); rate != 1 {
+			t.Fatalf("access to a %q file drawn at rate %d", level, rate)
+		}
+	}
+}
+
+func TestPublicAccessesAreSampledAtSpaceRate(t *testing.T) {
+	settings := &countingSettings{spaceSettings: spaceSettings{
+		"hot":  {AccessSampleRateSetting: 100},
+		"off":  {AccessSampleRateSetting: "1"},
+		"typo": {AccessSampleRateSetting: "often"},
+	}}
+	s := newTestSampler(10, settings)
+	row := &ent.File{ID: "f1", AccessLevel: string(structs.AccessLevelPublic)}
+
+	for spaceID, want := range map[string]int{"hot": 10, "off": 1000, "typo": 100, "": 100} {
+		if n := published(s, spaceID, row, 1000); n != want {
+			t.Fatalf("%d of 1000 public accesses in space %q published, want %d", n, spaceID, want)
+		}
+	}
+	if _, rate := s.Sample(context.Background(), "hot", row); rate != 100 {
+		t.Fatalf("access drawn at rate %d, want the rate of the space", rate)
+	}
+	// the rate of each space is read once and cached
+	if settings.reads != 3 {
+		t.Fatalf("%d setting reads, want one per space", settings.reads)
+	}
+
+	// the draws of a real sampler publish about 1 in N
+	s = NewAccessSampler(&config.EventConfig{AccessSampleRate: 10}, nil)
+	if n := published(s, "", row, 10000); n < 800 || n > 1200 {
+		t.Fatalf("%d of 10000 public accesses published at rate 10", n)
+	}
+}
+
+func TestAccessSamplerDefaultsToAll(t *testing.T) {
+	row := &ent.File{ID: "f1", AccessLevel: string(structs.AccessLevelPublic)}
+	for _, conf := range []*config.EventConfig{nil, {}, {AccessSampleRate: -3}} {
+		if n := published(NewAccessSampler(conf, nil), "s1", row, 50); n != 50 {
+			t.Fatalf("%d of 50 accesses published with %+v, want all", n, conf)
+		}
+	}
+	var s *AccessSampler
+	if ok, rate := s.Sample(context.Background(), "s1", row); !ok || rate != 1 {
+		t.Fatal("nil sampler drops accesses")
+	}
+}
+
+func TestSampledOutAccessesStayRecent(t *testing.T) {
+	events := &accessEvents{}
+	log := newTestAccessLog()
+	public := &ent.File{ID: "pub", OwnerID: "s1", AccessLevel: string(structs.AccessLevelPublic), IsPublic: true}
+	private := &ent.File{ID: "priv", OwnerID: "s1", AccessLevel: string(structs.AccessLevelPrivate)}
+	s := &fileService{
+		fileRepo:  newMemoryFiles(public, private),
+		publisher: events,
+		access:    log,
+		sampler:   newTestSampler(4, nil),
+	}
+	ctx := ctxutil.SetSpaceID(ctxutil.SetUserID(context.Background(), "u1"), "s1")
+
+	for i := 0; i < 8; i++ {
+		for _, id := range []string{"pub", "priv"} {
+			if _, err := s.Get(ctx, id); err != nil {
+				t.Fatalf("Get(%s): %v", id, err)
+			}
+		}
+	}
+
+	counts := map[string]int{}
+	for _, data := range events.accessed {
+		counts[data.ID]++
+		rate := 0
+		if data.Extras != nil {
+			rate, _ = (*data.Extras)["sample_rate"].(int)
+		}
+		if data.ID == "pub" && rate != 4 || data.ID == "priv" && rate != 0 {
+			t.Fatalf("event of %s carries sample rate %d", data.ID, rate)
+		}
+	}
+	if counts["priv"] != 8 || counts["pub"] != 2 {
+		t.Fatalf("published %v, want all 8 private and 2 of 8 public accesses", counts)
+	}
+
+	// published accesses reach the recent files through the event subscriber
+	recent, err := log.Recent(ctx, "s1", "u1", 10)
+	if err != nil || len(recent) != 1 || recent[0] != "pub" {
+		t.Fatalf("recent files %v, %v, want the sampled out public file", recent, err)
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+	"ncobase/plugin/resource/wrapper"
+
+	"github.com/ncobase/ncore/ctxutil"
+	ext "github.com/ncobase/ncore/extension/types"
+)
+
+func newStreamService(rows ...*ent.File) (*fileService, *memoryBucket) {
+	bucket := newMemoryBucket(nil)
+	for _, row := range rows {
+		row.Path = row.ID + ".txt"
+		bucket.objects[row.Path] = []byte("content of " + row.ID)
+	}
+	return &fileService{fileRepo: newMemoryFiles(rows...)}, bucket
+}
+
+// stream streams slug as userID, empty for an anonymous request
+func stream(bucket *memoryBucket, s *fileService, slug, userID string) error {
+	ctx := withBucket(bucket)
+	if userID != "" {
+		ctx = ctxutil.SetUserID(ctx, userID)
+	}
+	rc, _, err := s.GetFileStream(ctx, slug)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.ReadAll(rc)
+	return err
+}
+
+func TestGetFileStreamEnforcesAccessLevel(t *testing.T) {
+	s, bucket := newStreamService(
+		&ent.File{ID: "private", OwnerID: "u1", AccessLevel: string(structs.AccessLevelPrivate)},
+		&ent.File{ID: "public", OwnerID: "u1", AccessLevel: string(structs.AccessLevelPublic)},
+		&ent.File{ID: "flagged", OwnerID: "u1", AccessLevel: string(structs.AccessLevelPrivate), IsPublic: true},
+	)
+
+	for _, tc := range []struct {
+		slug, user string
+		allowed    bool
+	}{
+		{"private", "u1", true},
+		{"private", "u2", false},
+		{"private", "", false},
+		{"public", "", true},
+		{"public", "u2", true},
+		{"flagged", "", true},
+	} {
+		err := stream(bucket, s, tc.slug, tc.user)
+		if tc.allowed && err != nil {
+			t.Errorf("%s as %q: %v", tc.slug, tc.user, err)
+		}
+		if !tc.allowed && !errors.Is(err, ErrAccessDenied) {
+			t.Errorf("%s as %q: err = %v, want ErrAccessDenied", tc.slug, tc.user, err)
+		}
+	}
+}
+
+func TestGetFileStreamAllowsAdminAndSpaceMember(t *testing.T) {
+	s, bucket := newStreamService(&ent.File{ID: "f1", OwnerID: "space1", AccessLevel: string(structs.AccessLevelPrivate)})
+	ctx := withBucket(bucket)
+
+	admin := ctxutil.SetUserIsAdmin(ctxutil.SetUserID(ctx, "root"), true)
+	if _, _, err := s.GetFileStream(admin, "f1"); err != nil {
+		t.Fatalf("admin: %v", err)
+	}
+	member := ctxutil.SetUserSpaceIDs(ctxutil.SetUserID(ctx, "u2"), []string{"space1"})
+	if _, _, err := s.GetFileStream(member, "f1"); err != nil {
+		t.Fatalf("space member: %v", err)
+	}
+	other := ctxutil.SetUserSpaceIDs(ctxutil.SetUserID(ctx, "u3"), []string{"space2"})
+	if _, _, err := s.GetFileStream(other, "f1"); !errors.Is(err, ErrAccessDenied) {
+		t.Fatalf("member of another space: err = %v, want ErrAccessDenied", err)
+	}
+}
+
+func TestGetFileStreamMissingFile(t *testing.T) {
+	s, bucket := newStreamService()
+	if err := stream(bucket, s, "missing", "u1"); err == nil || errors.Is(err, ErrAccessDenied) {
+		t.Fatalf("err = %v, want not found", err)
+	}
+}
+
+// crossServices is an extension manager serving cross-module services by "extension/path"
+type crossServices struct {
+	ext.ManagerInterface
+	services map[string]any
+}
+
+func (m crossServices) GetCrossService(extensionName, servicePath string) (any, error) {
+	if service, ok := m.services[extensionName+"/"+servicePath]; ok {
+		return service, nil
+	}
+	return nil, fmt.Errorf("service %s/%s not found", extensionName, servicePath)
+}
+
+// spaceSettings holds setting values per space and key
+type spaceSettings map[string]map[string]any
+
+func (s spaceSettings) GetSettingValue(_ context.Context, spaceID, key string) (any, error) {
+	return s[spaceID][key], nil
+}
+
+func TestCreateUsesSpaceDefaultAccessLevel(t *testing.T) {
+	settings := spaceSettings{
+		"media": {DefaultAccessLevelSetting: string(structs.AccessLevelPublic)},
+		"docs":  {DefaultAccessLevelSetting: string(structs.AccessLevelPrivate)},
+		"typo":  {DefaultAccessLevelSetting: "everyone"},
+	}
+	space := wrapper.NewSpaceServiceWrapper(crossServices{services: map[string]any{"space/SpaceSetting": settings}})
+	s := &fileService{fileRepo: newMemoryFiles(), space: space}
+
+	create := func(ctx context.Context, owner string, level structs.AccessLevel) *structs.ReadFile {
+		t.Helper()
+		file, err := s.Create(ctx, &structs.CreateFileBody{
+			Name: "a", Path: "a.txt", Type: "text/plain", OwnerID: owner, AccessLevel: level,
+			File: &readCloser{bytes.NewReader([]byte("content"))},
+		})
+		if err != nil {
+			t.Fatalf("Create for %s: %v", owner, err)
+		}
+		return file
+	}
+	ctx := withBucket(newMemoryBucket(nil))
+
+	for owner, want := range map[string]structs.AccessLevel{
+		"media":   structs.AccessLevelPublic,
+		"docs":    structs.AccessLevelPrivate,
+		"typo":    structs.AccessLevelPrivate,
+		"unknown": structs.AccessLevelPrivate,
+	} {
+		file := create(ctx, owner, "")
+		if file.AccessLevel != want || file.IsPublic != (want == structs.AccessLevelPublic) {
+			t.Errorf("file of %s = %s (public %v), want %s", owner, file.AccessLevel, file.IsPublic, want)
+		}
+	}
+
+	// the requested level wins over the space default, the request's space over the owner's
+	if file := create(ctx, "media", structs.AccessLevelPrivate); file.AccessLevel != structs.AccessLevelPrivate {
+		t.Errorf("explicit level = %s, want private", file.AccessLevel)
+	}
+	if file := create(ctxutil.SetSpaceID(ctx, "media"), "u1", ""); file.AccessLevel != structs.AccessLevelPublic {
+		t.Errorf("file uploaded in the media space = %s, want public", file.AccessLevel)
+	}
+}
+
+func TestDefaultAccessLevelWithoutSpaceService(t *testing.T) {
+	s := &fileService{space: wrapper.NewSpaceServiceWrapper(crossServices{})}
+	if level := s.defaultAccessLevel(context.Background(), "media"); level != structs.AccessLevelPrivate {
+		t.Fatalf("default = %s, want private", level)
+	}
+}
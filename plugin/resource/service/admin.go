@@ -30,6 +30,7 @@ type AdminServiceInterface interface {
 	SetQuota(ctx context.Context, userID string, req *structs.QuotaSetRequest) (*structs.QuotaInfo, error)
 	GetQuota(ctx context.Context, userID string) (*structs.QuotaInfo, error)
 	DeleteQuota(ctx context.Context, userID string) error
+	SetScopedQuota(ctx context.Context, userID string, req *structs.ScopedQuotaSetRequest) (*structs.ScopedQuotaInfo, error)
 
 	// Batch operations
 	BatchCleanup(ctx context.Context, req *structs.BatchCleanupRequest) (*structs.BatchCleanupResult, error)
@@ -274,6 +275,22 @@ func (s *adminService) DeleteQuota(ctx context.Context, userID string) error {
 	return s.quotaService.SetQuota(ctx, userID, defaultQuota)
 }
 
+// SetScopedQuota sets a folder or category sub-limit for a user
+func (s *adminService) SetScopedQuota(ctx context.Context, userID string, req *structs.ScopedQuotaSetRequest) (*structs.ScopedQuotaInfo, error) {
+	if err := s.quotaService.SetScopedQuota(ctx, userID, req.Scope, req.Quota); err != nil {
+		return nil, fmt.Errorf("failed to set scoped quota: %w", err)
+	}
+
+	usage, _ := s.quotaService.GetScopedUsage(ctx, userID, req.Scope)
+
+	return &structs.ScopedQuotaInfo{
+		UserID: userID,
+		Scope:  req.Scope,
+		Quota:  max(req.Quota, 0),
+		Usage:  usage,
+	}, nil
+}
+
 // BatchCleanup performs batch cleanup operations
 func (s *adminService) BatchCleanup(ctx context.Context, req *structs.BatchCleanupRequest) (*structs.BatchCleanupResult, error) {
 	jobID := uuid.New().String()
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// ErrAttachmentNotFound is returned when detaching a file that is not attached to the entity
+var ErrAttachmentNotFound = errors.New("file is not attached to the entity")
+
+// AttachFile attaches a file to an entity of any module with a relation, e.g. a task
+// attachment or a topic cover. A file may be attached to any number of entities;
+// attaching it again the same way returns the existing attachment.
+func (s *fileService) AttachFile(ctx context.Context, slug string, body *structs.AttachFileBody) (*structs.ReadAttachment, error) {
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+
+	row, err := s.fileRepo.GetByID(ctx, slug)
+	if err != nil {
+		return nil, handleEntError(ctx, "File", err)
+	}
+
+	attachment, err := s.attachmentRepo.Attach(ctx, row.ID, ctxutil.GetUserID(ctx), body)
+	if err != nil {
+		return nil, handleEntError(ctx, "Attachment", err)
+	}
+
+	result := repository.SerializeAttachment(attachment)
+	result.File = repository.SerializeFile(row)
+	return result, nil
+}
+
+// DetachFile removes the attachment of a file to an entity with a relation,
+// leaving its other attachments and the file itself in place
+func (s *fileService) DetachFile(ctx context.Context, slug string, body *structs.AttachFileBody) error {
+	if err := body.Validate(); err != nil {
+		return err
+	}
+
+	row, err := s.fileRepo.GetByID(ctx, slug)
+	if err != nil {
+		return handleEntError(ctx, "File", err)
+	}
+
+	removed, err := s.attachmentRepo.Detach(ctx, row.ID, body)
+	if err != nil {
+		return handleEntError(ctx, "Attachment", err)
+	}
+	if removed == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// ListAttachments returns the files attached to an entity, oldest attachment first,
+// skipping files the requester may not read
+func (s *fileService) ListAttachments(ctx context.Context, params *structs.ListAttachmentParams) ([]*structs.ReadAttachment, error) {
+	if params.EntityType == "" || params.EntityID == "" {
+		return nil, errors.New("entity_type and entity_id are required")
+	}
+
+	rows, err := s.attachmentRepo.ListByEntity(ctx, params)
+	if err != nil {
+		return nil, handleEntError(ctx, "Attachment", err)
+	}
+	if len(rows) == 0 {
+		return []*structs.ReadAttachment{}, nil
+	}
+
+	fileIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		fileIDs = append(fileIDs, row.FileID)
+	}
+	files, err := s.fileRepo.GetByIDs(ctx, fileIDs)
+	if err != nil {
+		return nil, handleEntError(ctx, "File", err)
+	}
+	byID := make(map[string]*ent.File, len(files))
+	for _, file := range files {
+		byID[file.ID] = file
+	}
+
+	results := make([]*structs.ReadAttachment, 0, len(rows))
+	for _, row := range rows {
+		file, ok := byID[row.FileID]
+		if !ok {
+			logger.Warnf(ctx, "Attachment %s points to missing file %s", row.ID, row.FileID)
+			continue
+		}
+		if s.authorizeRead(ctx, file) != nil {
+			continue
+		}
+		result := repository.SerializeAttachment(row)
+		result.File = repository.SerializeFile(file)
+		results = append(results, result)
+	}
+	return results, nil
+}
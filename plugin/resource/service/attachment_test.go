@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+// newAttachmentService returns a file service with files kept in memory and their
+// attachments in an in-memory database
+func newAttachmentService(t *testing.T, rows ...*ent.File) *fileService {
+	t.Helper()
+	client, err := ent.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	return &fileService{
+		fileRepo:       newMemoryFiles(rows...),
+		attachmentRepo: repository.NewAttachmentRepository(&data.Data{EC: client}),
+	}
+}
+
+// attachedFiles lists the IDs of the files attached to a topic in ID order, attachments
+// made within the same millisecond have no fixed order
+func attachedFiles(t *testing.T, ctx context.Context, s *fileService, topicID string) string {
+	t.Helper()
+	attachments, err := s.ListAttachments(ctx, &structs.ListAttachmentParams{EntityType: "topic", EntityID: topicID})
+	if err != nil {
+		t.Fatalf("attachments of %s: %v", topicID, err)
+	}
+	ids := make([]string, len(attachments))
+	for i, attachment := range attachments {
+		if attachment.File == nil || attachment.File.ID != attachment.FileID {
+			t.Fatalf("attachment %s of %s without its file", attachment.ID, topicID)
+		}
+		ids[i] = attachment.FileID
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, " ")
+}
+
+func TestAttachFileToTwoTopics(t *testing.T) {
+	s := newAttachmentService(t,
+		&ent.File{ID: "f1", Name: "spec", OwnerID: "u1"},
+		&ent.File{ID: "f2", Name: "notes", OwnerID: "u1"},
+	)
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+
+	for _, topicID := range []string{"t1", "t2"} {
+		attachment, err := s.AttachFile(ctx, "f1", &structs.AttachFileBody{EntityType: "topic", EntityID: topicID})
+		if err != nil {
+			t.Fatalf("attach f1 to %s: %v", topicID, err)
+		}
+		if attachment.Relation != structs.DefaultAttachmentRelation || attachment.CreatedBy != "u1" {
+			t.Fatalf("attachment to %s = %+v", topicID, attachment)
+		}
+	}
+	if _, err := s.AttachFile(ctx, "f2", &structs.AttachFileBody{EntityType: "topic", EntityID: "t2"}); err != nil {
+		t.Fatalf("attach f2 to t2: %v", err)
+	}
+
+	// attaching again the same way keeps the existing attachment
+	first, _ := s.AttachFile(ctx, "f1", &structs.AttachFileBody{EntityType: "topic", EntityID: "t1"})
+	again, err := s.AttachFile(ctx, "f1", &structs.AttachFileBody{EntityType: " topic ", EntityID: "t1"})
+	if err != nil || again.ID != first.ID {
+		t.Fatalf("attach again = %+v, %v, want attachment %s", again, err, first.ID)
+	}
+
+	if got := attachedFiles(t, ctx, s, "t1"); got != "f1" {
+		t.Fatalf("files of t1 = %s, want f1", got)
+	}
+	if got := attachedFiles(t, ctx, s, "t2"); got != "f1 f2" {
+		t.Fatalf("files of t2 = %s, want f1 f2", got)
+	}
+
+	// detaching from one topic leaves the other and the file alone
+	if err := s.DetachFile(ctx, "f1", &structs.AttachFileBody{EntityType: "topic", EntityID: "t1"}); err != nil {
+		t.Fatalf("detach f1 from t1: %v", err)
+	}
+	if got := attachedFiles(t, ctx, s, "t1"); got != "" {
+		t.Fatalf("files of t1 after detaching = %s", got)
+	}
+	if got := attachedFiles(t, ctx, s, "t2"); got != "f1 f2" {
+		t.Fatalf("files of t2 after detaching from t1 = %s, want f1 f2", got)
+	}
+	if err := s.DetachFile(ctx, "f1", &structs.AttachFileBody{EntityType: "topic", EntityID: "t1"}); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Fatalf("detach again: %v, want ErrAttachmentNotFound", err)
+	}
+
+	// deleting a file removes its remaining attachments
+	if n, err := s.attachmentRepo.DeleteByFile(ctx, "f1"); err != nil || n != 1 {
+		t.Fatalf("delete attachments of f1 = %d, %v, want 1", n, err)
+	}
+	if got := attachedFiles(t, ctx, s, "t2"); got != "f2" {
+		t.Fatalf("files of t2 after deleting f1 = %s, want f2", got)
+	}
+}
+
+func TestListAttachmentsSkipsUnreadableFiles(t *testing.T) {
+	s := newAttachmentService(t,
+		&ent.File{ID: "mine", OwnerID: "u1", AccessLevel: string(structs.AccessLevelPrivate)},
+		&ent.File{ID: "theirs", OwnerID: "u2", AccessLevel: string(structs.AccessLevelPrivate)},
+		&ent.File{ID: "open", OwnerID: "u2", AccessLevel: string(structs.AccessLevelPublic)},
+	)
+	owner := ctxutil.SetUserID(context.Background(), "u2")
+	for _, id := range []string{"mine", "theirs", "open"} {
+		if _, err := s.AttachFile(owner, id, &structs.AttachFileBody{EntityType: "topic", EntityID: "t1"}); err != nil {
+			t.Fatalf("attach %s: %v", id, err)
+		}
+	}
+
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+	if got := attachedFiles(t, ctx, s, "t1"); got != "mine open" {
+		t.Fatalf("files of t1 read by u1 = %s, want mine open", got)
+	}
+	if _, err := s.ListAttachments(ctx, &structs.ListAttachmentParams{EntityType: "topic"}); err == nil {
+		t.Fatal("attachments listed without an entity ID")
+	}
+	if _, err := s.AttachFile(ctx, "missing", &structs.AttachFileBody{EntityType: "topic", EntityID: "t1"}); err == nil {
+		t.Fatal("missing file attached")
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/ncobase/ncore/types"
+)
+
+// backfillBatchSize is the default number of files normalized per batch
+const backfillBatchSize = 200
+
+// ExtrasBackfiller rewrites stored file extras into their canonical shape
+type ExtrasBackfiller struct {
+	fileRepo repository.FileRepositoryInterface
+}
+
+// NewExtrasBackfiller creates a new extras backfiller
+func NewExtrasBackfiller(d *data.Data) *ExtrasBackfiller {
+	return &ExtrasBackfiller{fileRepo: repository.NewFileRepository(d)}
+}
+
+// Backfill walks the files of opts.OwnerID, or every file when empty, and
+// normalizes their extras, only counting the changes when opts.DryRun is set.
+// Updates keep the stored version so clients holding it are not invalidated.
+func (b *ExtrasBackfiller) Backfill(ctx context.Context, opts *structs.BackfillOptions) (*structs.BackfillReport, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = backfillBatchSize
+	}
+
+	report := &structs.BackfillReport{DryRun: opts.DryRun}
+	afterID := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		rows, err := b.fileRepo.FindByOwnerAfter(ctx, opts.OwnerID, afterID, batchSize)
+		if err != nil {
+			return report, fmt.Errorf("failed to query files: %w", err)
+		}
+		if len(rows) == 0 {
+			return report, nil
+		}
+
+		for _, row := range rows {
+			report.Scanned++
+			extras, changed := repository.NormalizeExtras(row.Extras)
+			if !changed {
+				continue
+			}
+			report.Changed++
+			if opts.DryRun {
+				continue
+			}
+			if _, err := b.fileRepo.Update(ctx, row.ID, types.JSON{
+				"extras":     extras,
+				"updated_at": row.UpdatedAt,
+			}); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", row.ID, err))
+			}
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(report)
+		}
+
+		if len(rows) < batchSize {
+			return report, nil
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+}
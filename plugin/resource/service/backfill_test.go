@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+)
+
+func newBackfillFiles() *memoryFiles {
+	return newMemoryFiles(
+		&ent.File{ID: "f1", OwnerID: "o1", Extras: map[string]any{"custom_fields": map[string]any{"thumbnail_path": "t/a.jpg"}}},
+		&ent.File{ID: "f2", OwnerID: "o1", Extras: map[string]any{"path_prefix": "docs"}},
+		&ent.File{ID: "f3", OwnerID: "o1", Extras: map[string]any{"is_public": "1"}},
+		&ent.File{ID: "f4", OwnerID: "o2", Extras: map[string]any{"path_prefix": "/x/"}},
+	)
+}
+
+func TestBackfillNormalizesInBatches(t *testing.T) {
+	files := newBackfillFiles()
+	b := &ExtrasBackfiller{fileRepo: files}
+
+	var progress []int
+	report, err := b.Backfill(context.Background(), &structs.BackfillOptions{
+		OwnerID: "o1", BatchSize: 2,
+		Progress: func(r *structs.BackfillReport) { progress = append(progress, r.Scanned) },
+	})
+	if err != nil {
+		t.Fatalf("Backfill: %v", err)
+	}
+	if report.Scanned != 3 || report.Changed != 2 || len(report.Errors) != 0 {
+		t.Fatalf("report = %+v, want 3 scanned, 2 changed", *report)
+	}
+	if len(progress) != 2 || progress[1] != 3 {
+		t.Fatalf("progress = %v, want one report per batch", progress)
+	}
+	if files.rows["f1"].Extras["thumbnail_path"] != "t/a.jpg" || files.rows["f3"].Extras["is_public"] != true {
+		t.Fatalf("extras not normalized: %v, %v", files.rows["f1"].Extras, files.rows["f3"].Extras)
+	}
+	if files.rows["f4"].Extras["path_prefix"] != "/x/" {
+		t.Fatal("file of another owner normalized")
+	}
+}
+
+func TestBackfillDryRunChangesNothing(t *testing.T) {
+	files := newBackfillFiles()
+	report, err := (&ExtrasBackfiller{fileRepo: files}).Backfill(context.Background(), &structs.BackfillOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Backfill: %v", err)
+	}
+	if report.Scanned != 4 || report.Changed != 3 {
+		t.Fatalf("report = %+v, want 4 scanned, 3 to change", *report)
+	}
+	if files.rows["f3"].Extras["is_public"] != "1" || files.rows["f4"].Extras["path_prefix"] != "/x/" {
+		t.Fatal("dry run changed extras")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"mime/multipart"
+	"ncobase/internal/utils"
 	"ncobase/plugin/resource/event"
 	"ncobase/plugin/resource/structs"
 	"path/filepath"
@@ -27,19 +28,28 @@ type batchService struct {
 	file           FileServiceInterface
 	imageProcessor ImageProcessorInterface
 	publisher      event.PublisherInterface
+	workers        utils.WorkerOptions
 	jobs           map[string]*structs.BatchStatus
 	jobsMutex      sync.RWMutex
 }
 
+// batchUpload is a file of a batch upload with its position in the request
+type batchUpload struct {
+	index  int
+	header *multipart.FileHeader
+}
+
 func NewBatchService(
 	fileService FileServiceInterface,
 	imageProcessor ImageProcessorInterface,
 	publisher event.PublisherInterface,
+	workers utils.WorkerOptions,
 ) BatchServiceInterface {
 	return &batchService{
 		file:           fileService,
 		imageProcessor: imageProcessor,
 		publisher:      publisher,
+		workers:        workers,
 		jobs:           make(map[string]*structs.BatchStatus),
 	}
 }
@@ -93,105 +103,104 @@ func (s *batchService) BatchUpload(
 		s.publisher.PublishBatchUploadStarted(ctx, eventData)
 	}
 
-	var wg sync.WaitGroup
 	var mu sync.Mutex
 
-	// Control concurrency
-	maxConcurrent := 5
-	sem := make(chan struct{}, maxConcurrent)
+	uploads := make([]batchUpload, len(files))
+	for i, fileHeader := range files {
+		uploads[i] = batchUpload{index: i, header: fileHeader}
+	}
+
+	// Report progress on the job as files finish
+	workers := s.workers
+	workers.Progress = func(done, total int) {
+		s.jobsMutex.Lock()
+		if job, exists := s.jobs[operationID]; exists {
+			job.Progress = done * 100 / total
+		}
+		s.jobsMutex.Unlock()
+	}
 
 	// Process each file
-	for i, fileHeader := range files {
-		wg.Add(1)
-		sem <- struct{}{}
-
-		go func(index int, header *multipart.FileHeader) {
-			defer wg.Done()
-			defer func() { <-sem }()
-
-			// Validate header
-			if header == nil || header.Filename == "" {
-				mu.Lock()
-				batchResult.FailureCount++
-				batchResult.FailedFiles = append(batchResult.FailedFiles, fmt.Sprintf("file_%d", index))
-				batchResult.Errors = append(batchResult.Errors, fmt.Sprintf("File %d: invalid header", index))
-				mu.Unlock()
-				return
-			}
-
-			file, err := header.Open()
-			if err != nil {
-				mu.Lock()
-				batchResult.FailureCount++
-				batchResult.FailedFiles = append(batchResult.FailedFiles, header.Filename)
-				batchResult.Errors = append(batchResult.Errors, fmt.Sprintf("Failed to open file %s: %v", header.Filename, err))
-				mu.Unlock()
-				return
-			}
-			defer file.Close()
-
-			// Create file body with improved naming
-			body := &structs.CreateFileBody{}
-
-			// Extract file info
-			ext := filepath.Ext(header.Filename)
-			nameWithoutExt := strings.TrimSuffix(header.Filename, ext)
-			if nameWithoutExt == "" {
-				nameWithoutExt = "file"
-			}
-
-			body.Name = nameWithoutExt
-			body.Path = header.Filename // Will be replaced with storage path
-			body.Type = header.Header.Get("Content-Type")
-			if body.Type == "" {
-				body.Type = "application/octet-stream"
-			}
-
-			fileSize := int(header.Size)
-			body.Size = &fileSize
-			body.OwnerID = params.OwnerID
-			body.File = file
-
-			// Add extended fields
-			if params.AccessLevel != "" {
-				body.AccessLevel = params.AccessLevel
-			} else {
-				body.AccessLevel = structs.AccessLevelPrivate
-			}
-
-			body.Tags = params.Tags
-			body.Extras = params.Extras
-			body.ProcessingOptions = params.ProcessingOptions
-
-			// Create the file
-			f, err := s.file.Create(ctx, body)
-			if err != nil {
-				mu.Lock()
-				batchResult.FailureCount++
-				batchResult.FailedFiles = append(batchResult.FailedFiles, header.Filename)
-				batchResult.Errors = append(batchResult.Errors, fmt.Sprintf("Failed to create file for %s: %v", header.Filename, err))
-				mu.Unlock()
-				return
-			}
-
-			// Add to successful uploads
+	err := utils.ForEach(ctx, uploads, workers, func(ctx context.Context, upload batchUpload) {
+		index, header := upload.index, upload.header
+
+		// Validate header
+		if header == nil || header.Filename == "" {
 			mu.Lock()
-			batchResult.SuccessCount++
-			batchResult.Files = append(batchResult.Files, f)
-
-			// Update progress
-			progress := int((float64(batchResult.SuccessCount+batchResult.FailureCount) / float64(batchResult.TotalFiles)) * 100)
-			s.jobsMutex.Lock()
-			if job, exists := s.jobs[operationID]; exists {
-				job.Progress = progress
-			}
-			s.jobsMutex.Unlock()
+			batchResult.FailureCount++
+			batchResult.FailedFiles = append(batchResult.FailedFiles, fmt.Sprintf("file_%d", index))
+			batchResult.Errors = append(batchResult.Errors, fmt.Sprintf("File %d: invalid header", index))
 			mu.Unlock()
-		}(i, fileHeader)
-	}
+			return
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			mu.Lock()
+			batchResult.FailureCount++
+			batchResult.FailedFiles = append(batchResult.FailedFiles, header.Filename)
+			batchResult.Errors = append(batchResult.Errors, fmt.Sprintf("Failed to open file %s: %v", header.Filename, err))
+			mu.Unlock()
+			return
+		}
+		defer file.Close()
+
+		// Create file body with improved naming
+		body := &structs.CreateFileBody{}
+
+		// Extract file info
+		ext := filepath.Ext(header.Filename)
+		nameWithoutExt := strings.TrimSuffix(header.Filename, ext)
+		if nameWithoutExt == "" {
+			nameWithoutExt = "file"
+		}
+
+		body.Name = nameWithoutExt
+		body.Path = header.Filename // Will be replaced with storage path
+		body.Type = header.Header.Get("Content-Type")
+		if body.Type == "" {
+			body.Type = "application/octet-stream"
+		}
+
+		fileSize := int(header.Size)
+		body.Size = &fileSize
+		body.OwnerID = params.OwnerID
+		body.File = file
+
+		// Add extended fields
+		if params.AccessLevel != "" {
+			body.AccessLevel = params.AccessLevel
+		} else {
+			body.AccessLevel = structs.AccessLevelPrivate
+		}
+
+		body.Tags = params.Tags
+		body.Extras = params.Extras
+		body.ProcessingOptions = params.ProcessingOptions
+
+		// Create the file
+		f, err := s.file.Create(ctx, body)
+		if err != nil {
+			mu.Lock()
+			batchResult.FailureCount++
+			batchResult.FailedFiles = append(batchResult.FailedFiles, header.Filename)
+			batchResult.Errors = append(batchResult.Errors, fmt.Sprintf("Failed to create file for %s: %v", header.Filename, err))
+			mu.Unlock()
+			return
+		}
 
-	// Wait for all goroutines
-	wg.Wait()
+		// Add to successful uploads
+		mu.Lock()
+		batchResult.SuccessCount++
+		batchResult.Files = append(batchResult.Files, f)
+		mu.Unlock()
+	})
+	if err != nil {
+		// Files not started by then are counted as failed
+		skipped := batchResult.TotalFiles - batchResult.SuccessCount - batchResult.FailureCount
+		batchResult.FailureCount += skipped
+		batchResult.Errors = append(batchResult.Errors, fmt.Sprintf("Batch stopped, %d files not uploaded: %v", skipped, err))
+	}
 
 	// Update final status
 	s.jobsMutex.Lock()
@@ -261,32 +270,43 @@ func (s *batchService) BatchDelete(
 		Errors:       make([]string, 0),
 	}
 
-	for _, fileID := range fileIDs {
+	var mu sync.Mutex
+	fail := func(fileID, message string) {
+		mu.Lock()
+		defer mu.Unlock()
+		result.FailureCount++
+		result.FailedIDs = append(result.FailedIDs, fileID)
+		result.Errors = append(result.Errors, message)
+	}
+
+	err := utils.ForEach(ctx, fileIDs, s.workers, func(ctx context.Context, fileID string) {
 		// Verify ownership before deletion
 		file, err := s.file.Get(ctx, fileID)
 		if err != nil {
-			result.FailureCount++
-			result.FailedIDs = append(result.FailedIDs, fileID)
-			result.Errors = append(result.Errors, fmt.Sprintf("File %s not found: %v", fileID, err))
-			continue
+			fail(fileID, fmt.Sprintf("File %s not found: %v", fileID, err))
+			return
 		}
 
 		if file.OwnerID != ownerID {
-			result.FailureCount++
-			result.FailedIDs = append(result.FailedIDs, fileID)
-			result.Errors = append(result.Errors, fmt.Sprintf("Access denied for file %s", fileID))
-			continue
+			fail(fileID, fmt.Sprintf("Access denied for file %s", fileID))
+			return
 		}
 
-		err = s.file.Delete(ctx, fileID)
-		if err != nil {
-			result.FailureCount++
-			result.FailedIDs = append(result.FailedIDs, fileID)
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to delete file %s: %v", fileID, err))
-		} else {
-			result.SuccessCount++
-			result.DeletedIDs = append(result.DeletedIDs, fileID)
+		if err := s.file.Delete(ctx, fileID); err != nil {
+			fail(fileID, fmt.Sprintf("Failed to delete file %s: %v", fileID, err))
+			return
 		}
+
+		mu.Lock()
+		result.SuccessCount++
+		result.DeletedIDs = append(result.DeletedIDs, fileID)
+		mu.Unlock()
+	})
+	if err != nil {
+		// Files not started by then are kept
+		skipped := result.TotalFiles - result.SuccessCount - result.FailureCount
+		result.FailureCount += skipped
+		result.Errors = append(result.Errors, fmt.Sprintf("Batch stopped, %d files not deleted: %v", skipped, err))
 	}
 
 	return result, nil
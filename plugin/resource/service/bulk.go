@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/internal/page"
+	"ncobase/internal/tracing"
+	"ncobase/internal/utils"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/types"
+)
+
+// bulkUpdateBatchSize is the number of files listed per bulk update batch
+const bulkUpdateBatchSize = 100
+
+// BulkUpdateError reports the files a bulk update left unchanged, keyed by file ID
+type BulkUpdateError struct {
+	Failed map[string]error
+}
+
+// Error implements error
+func (e *BulkUpdateError) Error() string {
+	ids := make([]string, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	if len(ids) > 3 {
+		ids = append(ids[:3], "...")
+	}
+	return fmt.Sprintf("failed to update %d files: %s", len(e.Failed), strings.Join(ids, ", "))
+}
+
+// BulkUpdate applies patch to every file matching params, as Update does for one file, so
+// each file is versioned, reindexed and announced on its own. Tags of the patch are added to
+// the tags of each file instead of replacing them. Files the requester may not change are
+// left untouched and reported with the files that failed in a *BulkUpdateError, next to the
+// number of files updated.
+func (s *fileService) BulkUpdate(ctx context.Context, params *structs.ListFileParams, patch *structs.UpdateFileBody) (int, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.BulkUpdate")
+	defer span.End()
+
+	if params == nil || params.OwnerID == "" {
+		return 0, errors.New(ecode.FieldIsRequired("owner_id"))
+	}
+	if patch == nil || patch.IsEmpty() {
+		return 0, errors.New(ecode.FieldIsEmpty("updates fields"))
+	}
+	if patch.Name != nil {
+		return 0, errors.New("name cannot be bulk updated")
+	}
+	if err := patch.Validate(); err != nil {
+		return 0, err
+	}
+
+	lp := *params
+	lp.Cursor = ""
+	lp.Direction = ""
+	lp.Limit = bulkUpdateBatchSize
+
+	var (
+		mu       sync.Mutex
+		affected int
+		failed   = map[string]error{}
+	)
+	workers := s.workers()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return affected, err
+		}
+
+		rows, err := s.fileRepo.List(ctx, &lp)
+		if err != nil {
+			return affected, fmt.Errorf("failed to list files: %w", err)
+		}
+
+		err = utils.ForEach(ctx, rows, workers, func(ctx context.Context, row *ent.File) {
+			err := s.authorizeWrite(ctx, row)
+			if err == nil {
+				_, err = s.Update(ctx, row.ID, bulkUpdates(row, patch))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				logger.Warnf(ctx, "Bulk update skipped file %s: %v", row.ID, err)
+				failed[row.ID] = err
+				return
+			}
+			affected++
+		})
+		if err != nil {
+			return affected, err
+		}
+
+		if len(rows) < bulkUpdateBatchSize {
+			break
+		}
+
+		last := rows[len(rows)-1]
+		lp.Cursor = page.EncodeCursor(page.Cursor{Key: last.CreatedAt, ID: last.ID})
+	}
+
+	if len(failed) > 0 {
+		return affected, &BulkUpdateError{Failed: failed}
+	}
+	return affected, nil
+}
+
+// bulkUpdates translates patch into the updates of row, adding the patch tags to its tags
+func bulkUpdates(row *ent.File, patch *structs.UpdateFileBody) types.JSON {
+	updates := patch.ToUpdates()
+	// The version of a single file means nothing across many
+	delete(updates, "version")
+	if tags, ok := updates["tags"].([]string); ok {
+		merged := make([]string, 0, len(row.Tags)+len(tags))
+		merged = append(merged, row.Tags...)
+		merged = append(merged, tags...)
+		// Replacing nothing only drops duplicates
+		updates["tags"] = replaceTag(merged, "", "")
+	}
+	return updates
+}
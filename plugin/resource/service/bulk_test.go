@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/ncobase/ncore/ctxutil"
+)
+
+func TestBulkUpdateTagsFolder(t *testing.T) {
+	files := newMemoryFiles(
+		&ent.File{ID: "f900", Path: "docs/notes.txt", OwnerID: "s1", CreatedBy: "u2"},
+		&ent.File{ID: "f901", Path: "photos/cat.png", OwnerID: "s1", CreatedBy: "u1"},
+		&ent.File{ID: "f902", Path: "docs/other.txt", OwnerID: "s2", CreatedBy: "u1"},
+	)
+	// more files than one batch so the update pages through the folder
+	for i := 0; i < bulkUpdateBatchSize+5; i++ {
+		files.rows[fmt.Sprintf("f%03d", i)] = &ent.File{
+			ID: fmt.Sprintf("f%03d", i), Path: fmt.Sprintf("docs/%d.txt", i), OwnerID: "s1", CreatedBy: "u1",
+			Tags: []string{"draft", "q3"},
+		}
+	}
+	s := &fileService{fileRepo: files}
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+
+	tags := []string{"q3", " reviewed ", ""}
+	affected, err := s.BulkUpdate(ctx, &structs.ListFileParams{OwnerID: "s1", PathPrefix: "docs/"}, &structs.UpdateFileBody{Tags: &tags})
+
+	var bulkErr *BulkUpdateError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Failed) != 1 || !errors.Is(bulkErr.Failed["f900"], ErrAccessDenied) {
+		t.Fatalf("error = %v, want only f900 refused", err)
+	}
+	if affected != bulkUpdateBatchSize+5 {
+		t.Fatalf("%d files updated, want %d", affected, bulkUpdateBatchSize+5)
+	}
+
+	for id, row := range files.rows {
+		switch id {
+		case "f900", "f901", "f902":
+			if len(row.Tags) != 0 {
+				t.Fatalf("file %s outside the update tagged %v", id, row.Tags)
+			}
+		default:
+			if fmt.Sprint(row.Tags) != "[draft q3 reviewed]" || row.UpdatedBy != "u1" {
+				t.Fatalf("file %s tags %v, updated by %q", id, row.Tags, row.UpdatedBy)
+			}
+		}
+	}
+}
+
+func TestBulkUpdateRejectsInvalidRequests(t *testing.T) {
+	s := &fileService{fileRepo: newMemoryFiles()}
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+	name, tags := "same", []string{"q3"}
+
+	for what, tt := range map[string]struct {
+		params *structs.ListFileParams
+		patch  *structs.UpdateFileBody
+	}{
+		"no owner":   {&structs.ListFileParams{}, &structs.UpdateFileBody{Tags: &tags}},
+		"no changes": {&structs.ListFileParams{OwnerID: "s1"}, &structs.UpdateFileBody{}},
+		"a name":     {&structs.ListFileParams{OwnerID: "s1"}, &structs.UpdateFileBody{Name: &name}},
+	} {
+		if _, err := s.BulkUpdate(ctx, tt.params, tt.patch); err == nil {
+			t.Fatalf("bulk update with %s accepted", what)
+		}
+	}
+}
+
+func TestBulkUpdatesMergeTags(t *testing.T) {
+	tags := []string{"b", "c"}
+	updates := bulkUpdates(&ent.File{Tags: []string{"a", "b"}}, &structs.UpdateFileBody{Tags: &tags, Version: 7})
+	if fmt.Sprint(updates["tags"]) != "[a b c]" {
+		t.Fatalf("tags = %v, want the file tags with the new ones", updates["tags"])
+	}
+	if _, ok := updates["version"]; ok {
+		t.Fatal("version of the patch kept")
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/oss"
+)
+
+// dedupeEnabled reports whether an upload may reuse an identical stored object
+func (s *fileService) dedupeEnabled(body *structs.CreateFileBody) bool {
+	if body.OwnerID == "" {
+		return false
+	}
+	return body.Dedupe || (s.conf != nil && s.conf.DedupeUploads)
+}
+
+// findSharedObject returns an existing file of the owner with the same content
+// in the same storage, nil if the upload has to be stored
+func (s *fileService) findSharedObject(ctx context.Context, ownerID, hash string, storageConfig *oss.Config) *ent.File {
+	if hash == "" {
+		return nil
+	}
+
+	row, err := s.fileRepo.GetByHash(ctx, ownerID, hash)
+	if err != nil {
+		if !repository.IsNotFound(err) {
+			logger.Warnf(ctx, "Error looking up file by hash: %v", err)
+		}
+		return nil
+	}
+	if row.Storage != storageConfig.Provider || row.Bucket != storageConfig.Bucket || row.Path == "" {
+		return nil
+	}
+
+	logger.Infof(ctx, "Reusing stored object of file %s for identical upload", row.ID)
+	return row
+}
+
+// releaseObject deletes a stored object and its thumbnail once no record other
+// than excludeID references it. Storage errors are logged, not returned.
+func (s *fileService) releaseObject(ctx context.Context, storageClient oss.Interface, path, thumbnailPath, excludeID string) {
+	if storageClient == nil || path == "" {
+		return
+	}
+
+	refs, err := s.fileRepo.CountPathRefs(ctx, path, excludeID)
+	if err != nil {
+		logger.Errorf(ctx, "Error counting references to %s, keeping object: %v", path, err)
+		return
+	}
+	if refs > 0 {
+		logger.Debugf(ctx, "Keeping %s, still referenced by %d files", path, refs)
+		return
+	}
+
+	if err := storageClient.Delete(path); err != nil {
+		logger.Errorf(ctx, "Error deleting file from storage: %v", err)
+	}
+	if thumbnailPath != "" {
+		if err := storageClient.Delete(thumbnailPath); err != nil {
+			logger.Warnf(ctx, "Error deleting thumbnail: %v", err)
+		}
+	}
+}
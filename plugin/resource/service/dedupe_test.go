@@ -0,0 +1,100 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/structs"
+)
+
+func uploadText(ctx context.Context, s *fileService, name, ownerID, content string, dedupe bool) (*structs.ReadFile, error) {
+	return s.Create(ctx, &structs.CreateFileBody{
+		Name: name, Path: name + ".txt", Type: "text/plain", OwnerID: ownerID, Dedupe: dedupe,
+		File: &readCloser{bytes.NewReader([]byte(content))},
+	})
+}
+
+func TestCreateDedupesIdenticalUploads(t *testing.T) {
+	bucket := newMemoryBucket(nil)
+	ctx := withBucket(bucket)
+	s := &fileService{fileRepo: newMemoryFiles()}
+
+	first, err := uploadText(ctx, s, "report", "u1", "same bytes", true)
+	if err != nil {
+		t.Fatalf("Create first: %v", err)
+	}
+	second, err := uploadText(ctx, s, "report copy", "u1", "same bytes", true)
+	if err != nil {
+		t.Fatalf("Create second: %v", err)
+	}
+
+	if first.ID == second.ID || second.Name != "report copy" {
+		t.Fatalf("records %s and %s (%s), want two records with their own metadata", first.ID, second.ID, second.Name)
+	}
+	if first.Path != second.Path || len(bucket.objects) != 1 || bucket.puts != 1 {
+		t.Fatalf("paths %s and %s, %d objects stored, want one shared object", first.Path, second.Path, len(bucket.objects))
+	}
+
+	// another owner or different content is stored on its own
+	if other, err := uploadText(ctx, s, "report", "u2", "same bytes", true); err != nil || other.Path == first.Path {
+		t.Fatalf("other owner stored at %v, %v, want its own object", other, err)
+	}
+	if changed, err := uploadText(ctx, s, "draft", "u1", "other bytes", true); err != nil || changed.Path == first.Path {
+		t.Fatalf("different content stored at %v, %v, want its own object", changed, err)
+	}
+	if len(bucket.objects) != 3 {
+		t.Fatalf("%d objects stored, want 3", len(bucket.objects))
+	}
+}
+
+func TestDeleteKeepsSharedObjectUntilLastReference(t *testing.T) {
+	bucket := newMemoryBucket(nil)
+	ctx := withBucket(bucket)
+	s := &fileService{fileRepo: newMemoryFiles(), conf: &config.Config{DedupeUploads: true}}
+
+	first, err := uploadText(ctx, s, "report", "u1", "same bytes", false)
+	if err != nil {
+		t.Fatalf("Create first: %v", err)
+	}
+	second, err := uploadText(ctx, s, "report copy", "u1", "same bytes", false)
+	if err != nil {
+		t.Fatalf("Create second: %v", err)
+	}
+	if first.Path != second.Path {
+		t.Fatal("configured dedupe did not share the object")
+	}
+
+	if err := s.Delete(ctx, first.ID); err != nil {
+		t.Fatalf("Delete first: %v", err)
+	}
+	if !bucket.has(first.Path) {
+		t.Fatal("shared object deleted while still referenced")
+	}
+	if got := readObject(t, ctx, s, second.ID); got != "same bytes" {
+		t.Fatalf("remaining record reads %q", got)
+	}
+
+	if err := s.Delete(ctx, second.ID); err != nil {
+		t.Fatalf("Delete second: %v", err)
+	}
+	if bucket.has(first.Path) {
+		t.Fatal("object kept after its last reference was deleted")
+	}
+}
+
+func TestCreateWithoutDedupeStoresCopies(t *testing.T) {
+	bucket := newMemoryBucket(nil)
+	ctx := withBucket(bucket)
+	s := &fileService{fileRepo: newMemoryFiles()}
+
+	for _, name := range []string{"a", "b"} {
+		if _, err := uploadText(ctx, s, name, "u1", "same bytes", false); err != nil {
+			t.Fatalf("Create %s: %v", name, err)
+		}
+	}
+	if len(bucket.objects) != 2 {
+		t.Fatalf("%d objects stored, want a copy per upload", len(bucket.objects))
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/internal/tracing"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/validation/validator"
+)
+
+// EraseUserData erases the files a user created or owns. Files are deleted with their stored objects,
+// or with anonymize kept without the user as creator or last editor.
+// Files already erased are not found again, so an interrupted erasure continues where it stopped.
+func (s *fileService) EraseUserData(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error) {
+	ctx, span := tracing.Start(ctx, "resource.file.EraseUserData")
+	defer span.End()
+
+	if validator.IsEmpty(userID) {
+		return 0, 0, errors.New(ecode.FieldIsRequired("user_id"))
+	}
+
+	if anonymize {
+		n, err := s.fileRepo.AnonymizeByUser(ctx, userID)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to anonymize files: %w", err)
+		}
+		return 0, n, nil
+	}
+
+	// Files the user created, then files owned by the user that others uploaded
+	passes := []*structs.ListFileParams{
+		{User: userID, Limit: exportBatchSize},
+		{OwnerID: userID, Limit: exportBatchSize},
+	}
+	for _, lp := range passes {
+		n, err := s.deleteListed(ctx, lp)
+		deleted += n
+		if err != nil {
+			return deleted, 0, err
+		}
+	}
+	return deleted, 0, nil
+}
+
+// deleteListed deletes the files of a list, batch by batch until none are left
+func (s *fileService) deleteListed(ctx context.Context, lp *structs.ListFileParams) (deleted int, err error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+
+		rows, err := s.fileRepo.List(ctx, lp)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list files: %w", err)
+		}
+
+		for _, row := range rows {
+			if err := s.Delete(ctx, row.ID); err != nil {
+				return deleted, fmt.Errorf("failed to delete file %s: %w", row.ID, err)
+			}
+			deleted++
+		}
+
+		if len(rows) < exportBatchSize {
+			return deleted, nil
+		}
+	}
+}
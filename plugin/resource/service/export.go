@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"ncobase/internal/page"
+	"ncobase/internal/tracing"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportBatchSize is the number of files loaded per page while exporting
+const exportBatchSize = 500
+
+// FileExportHeader is the header row of the file CSV export
+var FileExportHeader = []string{"id", "name", "path", "size", "type", "tags", "access_level", "created_at"}
+
+// ExportFilesCSV streams the files matching params to w as CSV, page by page,
+// using the same filters as List. Cursor, limit and direction are ignored.
+func (s *fileService) ExportFilesCSV(ctx context.Context, params *structs.ListFileParams, w io.Writer) error {
+	ctx, span := tracing.Start(ctx, "resource.file.ExportFilesCSV")
+	defer span.End()
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(FileExportHeader); err != nil {
+		return err
+	}
+
+	lp := *params
+	lp.Cursor = ""
+	lp.Direction = ""
+	lp.Limit = exportBatchSize
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rows, err := s.fileRepo.List(ctx, &lp)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+
+		for _, row := range rows {
+			if err := cw.Write(fileExportRecord(row)); err != nil {
+				return err
+			}
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+
+		if len(rows) < exportBatchSize {
+			return nil
+		}
+
+		last := rows[len(rows)-1]
+		lp.Cursor = page.EncodeCursor(page.Cursor{Key: last.CreatedAt, ID: last.ID})
+	}
+}
+
+// ExportUserData writes the metadata of every file a user created to w as JSON lines,
+// across all owners. File contents are not included.
+func (s *fileService) ExportUserData(ctx context.Context, userID string, w io.Writer) error {
+	ctx, span := tracing.Start(ctx, "resource.file.ExportUserData")
+	defer span.End()
+
+	enc := json.NewEncoder(w)
+	lp := &structs.ListFileParams{User: userID, Limit: exportBatchSize}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rows, err := s.fileRepo.List(ctx, lp)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+
+		for _, row := range rows {
+			if err := enc.Encode(repository.SerializeFile(row).InternalView()); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < exportBatchSize {
+			return nil
+		}
+
+		last := rows[len(rows)-1]
+		lp.Cursor = page.EncodeCursor(page.Cursor{Key: last.CreatedAt, ID: last.ID})
+	}
+}
+
+// fileExportRecord formats a file as a CSV record matching FileExportHeader
+func fileExportRecord(row *ent.File) []string {
+	created := ""
+	if row.CreatedAt > 0 {
+		created = time.UnixMilli(row.CreatedAt).UTC().Format(time.RFC3339)
+	}
+	return []string{
+		row.ID,
+		row.Name,
+		row.Path,
+		strconv.Itoa(row.Size),
+		row.Type,
+		strings.Join(row.Tags, ";"),
+		row.AccessLevel,
+		created,
+	}
+}
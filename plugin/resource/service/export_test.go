@@ -0,0 +1,81 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+)
+
+func TestExportFilesCSV(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	files := newMemoryFiles(
+		&ent.File{ID: "f1", Name: "q1", Path: "o1/q1.pdf", Size: 1024, Type: "application/pdf", OwnerID: "o1",
+			Category: "document", Tags: []string{"report", "finance"}, AccessLevel: "private", CreatedAt: created.UnixMilli()},
+		&ent.File{ID: "f2", Name: "team, 2026", Path: "o1/team.png", Size: 2048, Type: "image/png", OwnerID: "o1",
+			Category: "image", AccessLevel: "public"},
+		&ent.File{ID: "f3", Name: "other", Path: "o2/other.pdf", OwnerID: "o2", Category: "document"},
+	)
+	s := &fileService{fileRepo: files}
+
+	var out bytes.Buffer
+	if err := s.ExportFilesCSV(context.Background(), &structs.ListFileParams{OwnerID: "o1", Limit: 1}, &out); err != nil {
+		t.Fatalf("ExportFilesCSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	want := [][]string{
+		FileExportHeader,
+		{"f1", "q1", "o1/q1.pdf", "1024", "application/pdf", "report;finance", "private", "2026-03-01T12:00:00Z"},
+		{"f2", "team, 2026", "o1/team.png", "2048", "image/png", "", "public", ""},
+	}
+	if fmt.Sprint(records) != fmt.Sprint(want) {
+		t.Fatalf("csv = %v, want %v", records, want)
+	}
+}
+
+func TestExportFilesCSVStreamsPages(t *testing.T) {
+	files := newMemoryFiles()
+	for i := 0; i < exportBatchSize+2; i++ {
+		id := fmt.Sprintf("f%04d", i)
+		files.rows[id] = &ent.File{ID: id, OwnerID: "o1", Category: "image"}
+	}
+	files.rows["doc"] = &ent.File{ID: "doc", OwnerID: "o1", Category: "document"}
+	s := &fileService{fileRepo: files}
+
+	var out bytes.Buffer
+	params := &structs.ListFileParams{OwnerID: "o1", Category: structs.FileCategoryImage}
+	if err := s.ExportFilesCSV(context.Background(), params, &out); err != nil {
+		t.Fatalf("ExportFilesCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != exportBatchSize+3 {
+		t.Fatalf("%d lines, want the header and %d files", len(lines), exportBatchSize+2)
+	}
+	if strings.Contains(out.String(), "doc,") {
+		t.Fatal("file outside the filter exported")
+	}
+	if params.Cursor != "" || params.Limit != 0 {
+		t.Fatal("export changed the caller's params")
+	}
+}
+
+func TestExportFilesCSVStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := &fileService{fileRepo: newMemoryFiles(&ent.File{ID: "f1", OwnerID: "o1"})}
+
+	var out bytes.Buffer
+	if err := s.ExportFilesCSV(ctx, &structs.ListFileParams{OwnerID: "o1"}, &out); err == nil {
+		t.Fatal("export of a cancelled request succeeded")
+	}
+}
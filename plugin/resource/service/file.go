@@ -3,13 +3,19 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
+	"ncobase/internal/page"
+	"ncobase/internal/tracing"
+	"ncobase/plugin/resource/config"
 	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/ent"
 	"ncobase/plugin/resource/data/repository"
 	"ncobase/plugin/resource/event"
 	"ncobase/plugin/resource/structs"
+	"ncobase/plugin/resource/wrapper"
 	"path/filepath"
 	"strings"
 	"time"
@@ -18,7 +24,9 @@ import (
 	"github.com/ncobase/ncore/data/paging"
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/oss"
 	"github.com/ncobase/ncore/types"
+	"github.com/ncobase/ncore/utils"
 	"github.com/ncobase/ncore/utils/nanoid"
 	"github.com/ncobase/ncore/validation/validator"
 )
@@ -26,28 +34,60 @@ import (
 type FileServiceInterface interface {
 	Create(ctx context.Context, body *structs.CreateFileBody) (*structs.ReadFile, error)
 	Update(ctx context.Context, slug string, updates types.JSON) (*structs.ReadFile, error)
+	UpdateWithBody(ctx context.Context, slug string, body *structs.UpdateFileBody) (*structs.ReadFile, error)
+	BulkUpdate(ctx context.Context, params *structs.ListFileParams, patch *structs.UpdateFileBody) (int, error)
 	Get(ctx context.Context, slug string) (*structs.ReadFile, error)
+	GetBatch(ctx context.Context, slugs []string) ([]*structs.ReadFile, map[string]error)
+	ListRecentlyAccessed(ctx context.Context, spaceID, userID string, limit int) ([]*structs.ReadFile, error)
 	GetPublic(ctx context.Context, slug string) (*structs.ReadFile, error)
 	GetByShareToken(ctx context.Context, token string) (*structs.ReadFile, error)
+	GetFileStreamByToken(ctx context.Context, token string) (io.ReadCloser, *structs.ReadFile, error)
 	Delete(ctx context.Context, slug string) error
 	List(ctx context.Context, params *structs.ListFileParams) (paging.Result[*structs.ReadFile], error)
 	GetFileStream(ctx context.Context, slug string) (io.ReadCloser, *structs.ReadFile, error)
 	GetFileStreamByID(ctx context.Context, id string) (io.ReadCloser, error)
 	GetThumbnail(ctx context.Context, slug string) (io.ReadCloser, error)
 	SearchByTags(ctx context.Context, ownerID string, tags []string, limit int) ([]*structs.ReadFile, error)
-	GeneratePublicURL(ctx context.Context, slug string, expirationHours int) (string, error)
+	SearchWithFacets(ctx context.Context, params *structs.FileSearchParams) (*structs.FileSearchResult, error)
+	Search(ctx context.Context, query string, limit int) ([]*structs.ReadFile, error)
+	GeneratePublicURL(ctx context.Context, slug string, expirationHours int, scope ...string) (string, error)
 	CreateVersion(ctx context.Context, slug string, file io.Reader, filename string) (*structs.ReadFile, error)
+	Copy(ctx context.Context, slug string, newObjectID string) (*structs.ReadFile, error)
 	GetVersions(ctx context.Context, slug string) ([]*structs.ReadFile, error)
 	SetAccessLevel(ctx context.Context, slug string, accessLevel structs.AccessLevel) (*structs.ReadFile, error)
+	ShareFile(ctx context.Context, slug string, body *structs.ShareFileBody) (*structs.ReadFile, error)
+	UnshareFile(ctx context.Context, slug string, userIDs []string) (*structs.ReadFile, error)
+	AttachFile(ctx context.Context, slug string, body *structs.AttachFileBody) (*structs.ReadAttachment, error)
+	DetachFile(ctx context.Context, slug string, body *structs.AttachFileBody) error
+	ListAttachments(ctx context.Context, params *structs.ListAttachmentParams) ([]*structs.ReadAttachment, error)
 	CreateThumbnail(ctx context.Context, slug string, options *structs.ProcessingOptions) (*structs.ReadFile, error)
+	RegenerateThumbnails(ctx context.Context, params *structs.ListFileParams, options *structs.ProcessingOptions) (int, error)
 	GetTagsByOwner(ctx context.Context, ownerID string) ([]string, error)
+	ListTags(ctx context.Context, ownerID string) ([]*structs.TagCount, error)
+	RenameTag(ctx context.Context, ownerID, oldTag, newTag string) (int, error)
+	DeleteTag(ctx context.Context, ownerID, tag string) (int, error)
+	ReindexFiles(ctx context.Context, ownerID string) (int, error)
+	ExportFilesCSV(ctx context.Context, params *structs.ListFileParams, w io.Writer) error
+	ExportUserData(ctx context.Context, userID string, w io.Writer) error
+	EraseUserData(ctx context.Context, userID string, anonymize bool) (deleted, anonymized int, err error)
 }
 
 type fileService struct {
 	fileRepo       repository.FileRepositoryInterface
+	attachmentRepo repository.AttachmentRepositoryInterface
 	imageProcessor ImageProcessorInterface
 	quotaService   QuotaServiceInterface
 	publisher      event.PublisherInterface
+	signer         *URLSigner
+	space          *wrapper.SpaceServiceWrapper
+	indexer        *FileIndexer
+	conf           *config.Config
+	access         *AccessLog
+	sampler        *AccessSampler
+	storages       *StorageFallback
+	// thumbnailTemplate is the storage key template of new thumbnails, existing
+	// thumbnails are always found by the path recorded in the file extras
+	thumbnailTemplate string
 }
 
 func NewFileService(
@@ -55,34 +95,42 @@ func NewFileService(
 	imageProcessor ImageProcessorInterface,
 	quotaService QuotaServiceInterface,
 	publisher event.PublisherInterface,
+	signer *URLSigner,
+	space *wrapper.SpaceServiceWrapper,
+	conf *config.Config,
+	access *AccessLog,
 ) FileServiceInterface {
+	fileRepo := repository.NewFileRepository(d)
 	return &fileService{
-		fileRepo:       repository.NewFileRepository(d),
-		imageProcessor: imageProcessor,
-		quotaService:   quotaService,
-		publisher:      publisher,
+		fileRepo:          fileRepo,
+		attachmentRepo:    repository.NewAttachmentRepository(d),
+		imageProcessor:    imageProcessor,
+		quotaService:      quotaService,
+		publisher:         publisher,
+		signer:            signer,
+		space:             space,
+		indexer:           &FileIndexer{fileRepo: fileRepo},
+		conf:              conf,
+		access:            access,
+		sampler:           NewAccessSampler(conf.Events, space),
+		storages:          NewStorageFallback(conf.LegacyStorages),
+		thumbnailTemplate: thumbnailTemplate(conf),
 	}
 }
 
-func (s *fileService) findFileByHash(ctx context.Context, ownerID, hash string) (*structs.ReadFile, error) {
-	file, err := s.fileRepo.GetByHash(ctx, ownerID, hash)
-	if repository.IsNotFound(err) {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, err
-	}
-	return repository.SerializeFile(file), nil
-}
-
 // Create creates a new file
 func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody) (*structs.ReadFile, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.Create")
+	defer span.End()
+
 	// Get ownerID from context if not provided
 	if body.OwnerID == "" {
 		if userID := ctxutil.GetUserID(ctx); userID != "" {
 			body.OwnerID = userID
 		}
 	}
+	// The file belongs to the space it is uploaded to, paths derived later read it from the record
+	spaceID := ctxutil.GetSpaceID(ctx)
 
 	// Check quota only if ownerID is provided
 	if body.OwnerID != "" && s.quotaService != nil && body.Size != nil {
@@ -95,7 +143,7 @@ func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody)
 	}
 
 	// Get storage
-	storageClient, storageConfig := ctxutil.GetStorage(ctx)
+	storageClient, storageConfig := getStorage(ctx)
 	if storageClient == nil || storageConfig == nil {
 		return nil, errors.New("storage not configured")
 	}
@@ -119,14 +167,6 @@ func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody)
 	// Calculate file hash for deduplication
 	hash := calculateFileHash(fileBytes)
 
-	// Check for existing file with same hash (optional deduplication).
-	if body.OwnerID != "" && hash != "" {
-		existing, err := s.findFileByHash(ctx, body.OwnerID, hash)
-		if err == nil && existing != nil {
-			logger.Infof(ctx, "File with same hash already exists: %s", existing.ID)
-		}
-	}
-
 	// Generate storage path with optional parameters
 	ext := filepath.Ext(body.Path)
 	if ext == "" && body.Name != "" {
@@ -135,26 +175,73 @@ func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody)
 		}
 	}
 
-	var ownerIDPtr, pathPrefixPtr *string
-	if body.OwnerID != "" {
-		ownerIDPtr = &body.OwnerID
+	// Check the content against the declared type and extension
+	sniffed := sniffContentType(fileBytes, body.Type, ext)
+	if sniffed.Mismatch {
+		logger.Warnf(ctx, "Content of %s detected as %s, does not match extension %s", body.Name, sniffed.Detected, ext)
+		if s.conf != nil && s.conf.StrictTypes {
+			return nil, ErrContentTypeMismatch
+		}
+	}
+	body.Type = sniffed.Type
+
+	// Check folder and category sub-limits on top of the owner quota
+	if body.OwnerID != "" && s.quotaService != nil {
+		scopes := append(structs.FolderQuotaScopes(body.PathPrefix), structs.CategoryQuotaScope(structs.GetFileCategory(ext)))
+		for _, scope := range scopes {
+			if ok, err := s.quotaService.CheckScopedQuota(ctx, body.OwnerID, scope, len(fileBytes)); !ok {
+				return nil, err
+			}
+		}
+	}
+
+	// Reject or replace a file of the owner with the same name, unless renaming
+	onConflict := s.nameConflictMode(body)
+	if onConflict != structs.NameConflictRename {
+		existing, err := s.findNameConflict(ctx, body)
+		if err != nil {
+			logger.Errorf(ctx, "Error looking up file %s of owner %s: %v", body.Name, body.OwnerID, err)
+			return nil, errors.New("failed to create file record")
+		}
+		if existing != nil {
+			if onConflict == structs.NameConflictReject {
+				return nil, ErrFileNameConflict
+			}
+			return s.replaceContent(ctx, existing, body, fileBytes, sniffed)
+		}
 	}
-	if body.PathPrefix != "" {
-		pathPrefixPtr = &body.PathPrefix
+
+	// Reuse an identical object of the owner instead of storing it twice
+	var shared *ent.File
+	if s.dedupeEnabled(body) {
+		shared = s.findSharedObject(ctx, body.OwnerID, hash, storageConfig)
 	}
 
-	storagePath := s.generateUniqueStoragePath(body.Name, ext, ownerIDPtr, pathPrefixPtr)
+	var storagePath string
+	if shared != nil {
+		storagePath = shared.Path
+	} else {
+		var ownerIDPtr, pathPrefixPtr *string
+		if body.OwnerID != "" {
+			ownerIDPtr = &body.OwnerID
+		}
+		if body.PathPrefix != "" {
+			pathPrefixPtr = &body.PathPrefix
+		}
+
+		storagePath = s.generateUniqueStoragePath(body.Name, ext, ownerIDPtr, pathPrefixPtr)
 
-	// Store file
-	_, storeErr := storageClient.Put(storagePath, bytes.NewReader(fileBytes))
-	if storeErr != nil {
-		logger.Errorf(ctx, "Error storing file to %s: %v", storageConfig.Provider, storeErr)
-		return nil, fmt.Errorf("failed to store file: %w", storeErr)
+		// Store file
+		_, storeErr := storageClient.Put(storagePath, bytes.NewReader(fileBytes))
+		if storeErr != nil {
+			logger.Errorf(ctx, "Error storing file to %s: %v", storageConfig.Provider, storeErr)
+			return nil, fmt.Errorf("failed to store file: %w", storeErr)
+		}
 	}
 
-	// Cleanup on error
+	// Cleanup on error, a shared object stays with its other records
 	defer func() {
-		if err != nil {
+		if err != nil && shared == nil {
 			if deleteErr := storageClient.Delete(storagePath); deleteErr != nil {
 				logger.Errorf(ctx, "Failed to cleanup file after error: %v", deleteErr)
 			}
@@ -163,7 +250,8 @@ func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody)
 
 	// Set defaults and computed values
 	if body.AccessLevel == "" {
-		body.AccessLevel = structs.AccessLevelPrivate
+		body.AccessLevel = s.defaultAccessLevel(ctx, body.OwnerID)
+		body.IsPublic = body.IsPublic || body.AccessLevel == structs.AccessLevelPublic
 	}
 
 	// Set storage info
@@ -178,11 +266,13 @@ func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody)
 		body.CreatedBy = &userID
 	}
 
-	// Process image if needed
+	// Process image if needed, a shared object already has its thumbnail
 	thumbnailPath := ""
 	category := structs.GetFileCategory(filepath.Ext(storagePath))
 
-	if category == structs.FileCategoryImage && s.imageProcessor != nil {
+	if shared != nil {
+		thumbnailPath, _ = shared.Extras["thumbnail_path"].(string)
+	} else if category == structs.FileCategoryImage && s.imageProcessor != nil {
 		if body.ProcessingOptions == nil {
 			body.ProcessingOptions = &structs.ProcessingOptions{
 				CreateThumbnail: true,
@@ -200,7 +290,7 @@ func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody)
 		)
 
 		if err == nil {
-			thumbnailPath = s.generateThumbnailPath(storagePath)
+			thumbnailPath = s.thumbnailPath(body.OwnerID, spaceID, storagePath, body.ProcessingOptions.MaxWidth, body.ProcessingOptions.MaxHeight)
 			_, err = storageClient.Put(thumbnailPath, bytes.NewReader(thumbnailBytes))
 			if err != nil {
 				logger.Warnf(ctx, "Error storing thumbnail: %v", err)
@@ -221,8 +311,12 @@ func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody)
 	if thumbnailPath != "" {
 		extendedData["thumbnail_path"] = thumbnailPath
 	}
-	if body.PathPrefix != "" {
-		extendedData["path_prefix"] = body.PathPrefix
+	if folder := repository.FolderPath(body.PathPrefix); folder != "" {
+		extendedData["path_prefix"] = folder
+	}
+	delete(extendedData, "space_id")
+	if spaceID != "" {
+		extendedData["space_id"] = spaceID
 	}
 	if body.OwnerID == "" {
 		extendedData["anonymous"] = true
@@ -230,6 +324,14 @@ func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody)
 	if hash != "" {
 		extendedData["hash"] = hash // Also store in extras for backward compatibility
 	}
+	extendedData["declared_type"] = sniffed.Declared
+	extendedData["detected_type"] = sniffed.Detected
+	if sniffed.Mismatch {
+		extendedData["type_mismatch"] = true
+	}
+	if shared != nil {
+		extendedData["shared_from"] = shared.ID
+	}
 
 	body.Extras = &extendedData
 
@@ -238,40 +340,32 @@ func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody)
 	for retry := 0; retry < maxRetries; retry++ {
 		row, err := s.fileRepo.Create(ctx, body)
 		if err != nil {
-			if strings.Contains(err.Error(), "unique") || strings.Contains(err.Error(), "duplicate") {
+			// Drivers report unique violations as "UNIQUE constraint failed", "Duplicate entry" or "duplicate key"
+			if msg := strings.ToLower(err.Error()); strings.Contains(msg, "unique") || strings.Contains(msg, "duplicate") {
+				// The name was taken since it was looked up
+				if onConflict != structs.NameConflictRename {
+					if thumbnailPath != "" && shared == nil {
+						_ = storageClient.Delete(thumbnailPath)
+					}
+					if shared == nil {
+						_ = storageClient.Delete(storagePath)
+					}
+					return nil, ErrFileNameConflict
+				}
 				body.Name = s.generateUniqueName(body.Name)
 				logger.Warnf(ctx, "Name conflict, retrying with new name: %s", body.Name)
 				continue
 			}
 
 			logger.Errorf(ctx, "Error creating file record: %v", err)
-			if thumbnailPath != "" {
+			if thumbnailPath != "" && shared == nil {
 				_ = storageClient.Delete(thumbnailPath)
 			}
 			return nil, errors.New("failed to create file record")
 		}
 
 		// Success - publish event
-		if s.publisher != nil {
-			eventUserID := userID
-			if eventUserID == "" {
-				eventUserID = body.OwnerID
-			}
-
-			eventData := &event.FileEventData{
-				ID:      row.ID,
-				Name:    row.Name,
-				Path:    row.Path,
-				Type:    row.Type,
-				Size:    row.Size,
-				Storage: row.Storage,
-				Bucket:  row.Bucket,
-				OwnerID: row.OwnerID,
-				UserID:  eventUserID,
-				Extras:  &row.Extras,
-			}
-			s.publisher.PublishFileCreated(ctx, eventData)
-		}
+		s.publishCreated(ctx, row, userID)
 
 		return repository.SerializeFile(row), nil
 	}
@@ -279,8 +373,35 @@ func (s *fileService) Create(ctx context.Context, body *structs.CreateFileBody)
 	return nil, errors.New("failed to create file record after retries")
 }
 
+// publishCreated publishes the created event of row, by userID or else its owner
+func (s *fileService) publishCreated(ctx context.Context, row *ent.File, userID string) {
+	if s.publisher == nil {
+		return
+	}
+	if userID == "" {
+		userID = row.OwnerID
+	}
+
+	eventData := &event.FileEventData{
+		ID:      row.ID,
+		Name:    row.Name,
+		Path:    row.Path,
+		Type:    row.Type,
+		Size:    row.Size,
+		Storage: row.Storage,
+		Bucket:  row.Bucket,
+		OwnerID: row.OwnerID,
+		UserID:  userID,
+		Extras:  &row.Extras,
+	}
+	s.publisher.PublishFileCreated(ctx, eventData)
+}
+
 // Update updates file
 func (s *fileService) Update(ctx context.Context, slug string, updates types.JSON) (*structs.ReadFile, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.Update")
+	defer span.End()
+
 	if validator.IsEmpty(slug) {
 		return nil, errors.New(ecode.FieldIsRequired("slug"))
 	}
@@ -295,9 +416,17 @@ func (s *fileService) Update(ctx context.Context, slug string, updates types.JSO
 		return nil, handleEntError(ctx, "File", err)
 	}
 
+	// Reject stale writes before touching storage
+	if version, ok := updates["version"].(int64); ok && version > 0 && version != existing.UpdatedAt {
+		return nil, ErrVersionConflict
+	}
+
+	// Extras updates are merged into these
+	baseExtras := existing.Extras
+
 	// Handle file update with hash calculation
 	if fileReader, ok := updates["file"].(io.Reader); ok {
-		storageClient, storageConfig := ctxutil.GetStorage(ctx)
+		storageClient, storageConfig := getStorage(ctx)
 		if storageClient == nil || storageConfig == nil {
 			return nil, errors.New("storage not configured")
 		}
@@ -339,9 +468,17 @@ func (s *fileService) Update(ctx context.Context, slug string, updates types.JSO
 			return nil, errors.New("error updating file")
 		}
 
-		// Delete old file
-		if err := storageClient.Delete(existing.Path); err != nil {
-			logger.Warnf(ctx, "Error deleting old file: %v", err)
+		// Delete old file and its thumbnail unless other records share them
+		thumbnailPath, _ := extras["thumbnail_path"].(string)
+		s.releaseObject(ctx, storageClient, existing.Path, thumbnailPath, existing.ID)
+		if thumbnailPath != "" {
+			for _, key := range []string{"thumbnail_path", "thumbnail_max_width", "thumbnail_max_height"} {
+				delete(extras, key)
+			}
+			baseExtras = extras
+			if _, ok := updates["extras"]; !ok {
+				updates["extras"] = types.JSON{}
+			}
 		}
 
 		// Update file metadata
@@ -361,7 +498,7 @@ func (s *fileService) Update(ctx context.Context, slug string, updates types.JSO
 
 	// Process extras updates - merge with existing
 	if extrasUpdate, ok := updates["extras"].(types.JSON); ok {
-		existingExtras := repository.CloneExtras(existing.Extras)
+		existingExtras := repository.CloneExtras(baseExtras)
 
 		// Merge extras
 		for k, v := range extrasUpdate {
@@ -380,6 +517,9 @@ func (s *fileService) Update(ctx context.Context, slug string, updates types.JSO
 	// Update file
 	row, err := s.fileRepo.Update(ctx, slug, updates)
 	if err != nil {
+		if repository.IsVersionConflict(err) {
+			return nil, ErrVersionConflict
+		}
 		return nil, handleEntError(ctx, "File", err)
 	}
 
@@ -403,6 +543,20 @@ func (s *fileService) Update(ctx context.Context, slug string, updates types.JSO
 	return repository.SerializeFile(row), nil
 }
 
+// UpdateWithBody applies a typed partial update
+func (s *fileService) UpdateWithBody(ctx context.Context, slug string, body *structs.UpdateFileBody) (*structs.ReadFile, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.UpdateWithBody")
+	defer span.End()
+
+	if body == nil || body.IsEmpty() {
+		return nil, errors.New(ecode.FieldIsEmpty("updates fields"))
+	}
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+	return s.Update(ctx, slug, body.ToUpdates())
+}
+
 // Get retrieves file by ID
 func (s *fileService) Get(ctx context.Context, slug string) (*structs.ReadFile, error) {
 	if validator.IsEmpty(slug) {
@@ -417,9 +571,69 @@ func (s *fileService) Get(ctx context.Context, slug string) (*structs.ReadFile,
 		return nil, errors.New("error retrieving file")
 	}
 
+	s.publishAccessed(ctx, row)
+
 	return repository.SerializeFile(row), nil
 }
 
+// GetBatch retrieves files by ID or name with a single query.
+// Files are returned in request order; missing files and files the requester
+// may not read are reported per requested slug instead.
+func (s *fileService) GetBatch(ctx context.Context, slugs []string) ([]*structs.ReadFile, map[string]error) {
+	errs := make(map[string]error)
+	slugs = utils.RemoveDuplicates(slugs)
+	if len(slugs) > structs.MaxBatchGetFiles {
+		for _, slug := range slugs[structs.MaxBatchGetFiles:] {
+			errs[slug] = fmt.Errorf("batch is limited to %d files", structs.MaxBatchGetFiles)
+		}
+		slugs = slugs[:structs.MaxBatchGetFiles]
+	}
+
+	rows, err := s.fileRepo.GetByIDs(ctx, slugs)
+	if err != nil {
+		for _, slug := range slugs {
+			errs[slug] = errors.New("error retrieving file")
+		}
+		return []*structs.ReadFile{}, errs
+	}
+
+	byID := make(map[string]*ent.File, len(rows))
+	byName := make(map[string][]*ent.File)
+	for _, row := range rows {
+		byID[row.ID] = row
+		byName[row.Name] = append(byName[row.Name], row)
+	}
+
+	files := make([]*structs.ReadFile, 0, len(slugs))
+	for _, slug := range slugs {
+		if validator.IsEmpty(slug) {
+			continue
+		}
+
+		row, ok := byID[slug]
+		if !ok {
+			switch named := byName[slug]; len(named) {
+			case 0:
+				errs[slug] = errors.New(ecode.NotExist(fmt.Sprintf("File %s", slug)))
+				continue
+			case 1:
+				row = named[0]
+			default:
+				errs[slug] = fmt.Errorf("file name %s is ambiguous, use the file ID", slug)
+				continue
+			}
+		}
+
+		if err := s.authorizeRead(ctx, row); err != nil {
+			errs[slug] = err
+			continue
+		}
+		files = append(files, repository.SerializeFile(row))
+	}
+
+	return files, errs
+}
+
 // GetPublic retrieves public file
 func (s *fileService) GetPublic(ctx context.Context, slug string) (*structs.ReadFile, error) {
 	file, err := s.Get(ctx, slug)
@@ -439,35 +653,58 @@ func (s *fileService) GetPublic(ctx context.Context, slug string) (*structs.Read
 	return file, nil
 }
 
-// GetByShareToken retrieves file by share token
+// GetByShareToken retrieves file by a signed share token
 func (s *fileService) GetByShareToken(ctx context.Context, token string) (*structs.ReadFile, error) {
-	if len(token) < 10 {
-		return nil, errors.New("invalid share token")
+	claims, err := s.verifyShareToken(ctx, token)
+	if err != nil {
+		return nil, err
 	}
 
-	// Extract file ID from token (simplified)
-	fileID := token[:len(token)-10] // Remove timestamp suffix
+	return s.Get(ctx, claims.FileID)
+}
 
-	file, err := s.Get(ctx, fileID)
+// GetFileStreamByToken verifies a signed share token and returns the file stream
+func (s *fileService) GetFileStreamByToken(ctx context.Context, token string) (io.ReadCloser, *structs.ReadFile, error) {
+	claims, err := s.verifyShareToken(ctx, token)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Verify token validity
-	if file.AccessLevel != structs.AccessLevelShared {
-		return nil, errors.New("file is not shared")
+	// A valid signature grants access regardless of the access level
+	row, err := s.getStreamable(ctx, claims.FileID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return file, nil
+	return s.openStream(ctx, row)
+}
+
+// verifyShareToken checks signature, expiry and client scope of a share token
+func (s *fileService) verifyShareToken(ctx context.Context, token string) (*SignedToken, error) {
+	if validator.IsEmpty(token) {
+		return nil, ErrSignatureInvalid
+	}
+
+	ip, _, _ := ctxutil.GetClientInfo(ctx)
+	claims, err := s.signer.Verify(token, ip)
+	if err != nil {
+		logger.Warnf(ctx, "Rejected share token: %v", err)
+		return nil, err
+	}
+
+	return claims, nil
 }
 
 // Delete deletes file
 func (s *fileService) Delete(ctx context.Context, slug string) error {
+	ctx, span := tracing.Start(ctx, "resource.file.Delete")
+	defer span.End()
+
 	if validator.IsEmpty(slug) {
 		return errors.New(ecode.FieldIsRequired("slug"))
 	}
 
-	storageClient, _ := ctxutil.GetStorage(ctx)
+	storageClient, _ := getStorage(ctx)
 
 	// Get file details
 	row, err := s.fileRepo.GetByID(ctx, slug)
@@ -488,19 +725,9 @@ func (s *fileService) Delete(ctx context.Context, slug string) error {
 		return errors.New("error deleting file record")
 	}
 
-	// Delete from storage (don't fail if storage deletion fails)
-	if storageClient != nil {
-		if err := storageClient.Delete(row.Path); err != nil {
-			logger.Errorf(ctx, "Error deleting file from storage: %v", err)
-		}
-
-		// Delete thumbnail
-		if thumbnailPath != "" {
-			if err := storageClient.Delete(thumbnailPath); err != nil {
-				logger.Warnf(ctx, "Error deleting thumbnail: %v", err)
-			}
-		}
-	}
+	// Delete from storage once the last record referencing it is gone
+	// (don't fail if storage deletion fails)
+	s.releaseObject(ctx, storageClient, row.Path, thumbnailPath, row.ID)
 
 	// Publish event
 	if s.publisher != nil {
@@ -524,13 +751,24 @@ func (s *fileService) Delete(ctx context.Context, slug string) error {
 
 // List lists files with pagination
 func (s *fileService) List(ctx context.Context, params *structs.ListFileParams) (paging.Result[*structs.ReadFile], error) {
+	if params.Cursor != "" {
+		if _, err := page.DecodeCursor(params.Cursor); err != nil {
+			return paging.Result[*structs.ReadFile]{}, err
+		}
+	}
+
+	limit, err := page.Limit("file", params.Limit)
+	if err != nil {
+		return paging.Result[*structs.ReadFile]{}, err
+	}
+
 	pp := paging.Params{
 		Cursor:    params.Cursor,
-		Limit:     params.Limit,
+		Limit:     limit,
 		Direction: params.Direction,
 	}
 
-	return paging.Paginate(pp, func(cursor string, limit int, direction string) ([]*structs.ReadFile, int, error) {
+	return page.SignCursors(paging.Paginate(pp, func(cursor string, limit int, direction string) ([]*structs.ReadFile, int, error) {
 		lp := *params
 		lp.Cursor = cursor
 		lp.Limit = limit
@@ -549,37 +787,54 @@ func (s *fileService) List(ctx context.Context, params *structs.ListFileParams)
 		}
 
 		return results, total, nil
-	})
+	}))
 }
 
-// GetFileStream gets file stream
+// GetFileStream gets file stream, enforcing the file's access level
 func (s *fileService) GetFileStream(ctx context.Context, slug string) (io.ReadCloser, *structs.ReadFile, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.GetFileStream")
+	defer span.End()
+
 	if validator.IsEmpty(slug) {
 		return nil, nil, errors.New(ecode.FieldIsRequired("slug"))
 	}
 
-	storageClient, _ := ctxutil.GetStorage(ctx)
-	if storageClient == nil {
-		return nil, nil, errors.New("storage not configured")
+	row, err := s.getStreamable(ctx, slug)
+	if err != nil {
+		return nil, nil, err
 	}
 
+	if err := s.authorizeRead(ctx, row); err != nil {
+		return nil, nil, err
+	}
+
+	return s.openStream(ctx, row)
+}
+
+// getStreamable loads a file for streaming and checks it has not expired
+func (s *fileService) getStreamable(ctx context.Context, slug string) (*ent.File, error) {
 	row, err := s.fileRepo.GetByID(ctx, slug)
 	if err != nil {
 		if repository.IsNotFound(err) {
-			return nil, nil, errors.New(ecode.NotExist(fmt.Sprintf("File %s", slug)))
+			return nil, errors.New(ecode.NotExist(fmt.Sprintf("File %s", slug)))
 		}
-		return nil, nil, errors.New("error retrieving file")
+		return nil, errors.New("error retrieving file")
 	}
 
 	// Check expiration
 	extras := repository.CloneExtras(row.Extras)
 	if exp, ok := extras["expires_at"].(int64); ok {
 		if time.Now().UnixMilli() > exp {
-			return nil, nil, errors.New("file access has expired")
+			return nil, errors.New("file access has expired")
 		}
 	}
 
-	fileStream, err := storageClient.GetStream(row.Path)
+	return row, nil
+}
+
+// openStream opens the storage stream of row
+func (s *fileService) openStream(ctx context.Context, row *ent.File) (io.ReadCloser, *structs.ReadFile, error) {
+	fileStream, err := s.openObject(ctx, row, row.Path)
 	if err != nil {
 		logger.Errorf(ctx, "Error retrieving file stream: %v", err)
 		return nil, nil, errors.New("error retrieving file stream")
@@ -590,26 +845,27 @@ func (s *fileService) GetFileStream(ctx context.Context, slug string) (io.ReadCl
 
 // GetFileStreamByID gets file stream by ID
 func (s *fileService) GetFileStreamByID(ctx context.Context, id string) (io.ReadCloser, error) {
-	storageClient, _ := ctxutil.GetStorage(ctx)
-	if storageClient == nil {
-		return nil, errors.New("storage not configured")
-	}
-
 	row, err := s.fileRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, errors.New("error retrieving file")
 	}
 
-	return storageClient.GetStream(row.Path)
+	return s.openObject(ctx, row, row.Path)
 }
 
-// GetThumbnail gets thumbnail stream
-func (s *fileService) GetThumbnail(ctx context.Context, slug string) (io.ReadCloser, error) {
-	storageClient, _ := ctxutil.GetStorage(ctx)
+// openObject opens an object of a file, the file itself or one derived from it such as its
+// thumbnail, from the bucket it is in
+func (s *fileService) openObject(ctx context.Context, row *ent.File, path string) (io.ReadCloser, error) {
+	storageClient, storageConfig := getStorage(ctx)
 	if storageClient == nil {
 		return nil, errors.New("storage not configured")
 	}
 
+	return s.storages.OpenStream(ctx, storageClient, storageConfig, row.Storage, row.Bucket, path)
+}
+
+// GetThumbnail gets thumbnail stream
+func (s *fileService) GetThumbnail(ctx context.Context, slug string) (io.ReadCloser, error) {
 	row, err := s.fileRepo.GetByID(ctx, slug)
 	if err != nil {
 		return nil, errors.New("error retrieving file")
@@ -621,7 +877,7 @@ func (s *fileService) GetThumbnail(ctx context.Context, slug string) (io.ReadClo
 		return nil, errors.New("thumbnail not found")
 	}
 
-	return storageClient.GetStream(thumbnailPath)
+	return s.openObject(ctx, row, thumbnailPath)
 }
 
 // SearchByTags searches files by tags
@@ -643,8 +899,53 @@ func (s *fileService) SearchByTags(ctx context.Context, ownerID string, tags []s
 	return results, nil
 }
 
-// GeneratePublicURL generates public URL
-func (s *fileService) GeneratePublicURL(ctx context.Context, slug string, expirationHours int) (string, error) {
+// SearchWithFacets searches files and returns hits with facet counts
+func (s *fileService) SearchWithFacets(ctx context.Context, params *structs.FileSearchParams) (*structs.FileSearchResult, error) {
+	if params.OwnerID == "" {
+		return nil, errors.New(ecode.FieldIsRequired("owner_id"))
+	}
+
+	rows, total, facets, err := s.fileRepo.SearchWithFacets(ctx, params)
+	if err != nil {
+		return nil, handleEntError(ctx, "File", err)
+	}
+
+	return &structs.FileSearchResult{
+		Hits:   repository.SerializeFiles(rows),
+		Total:  total,
+		Facets: facets,
+	}, nil
+}
+
+// Search finds files of the current space by name, leaving out files the requester may not read
+func (s *fileService) Search(ctx context.Context, query string, limit int) ([]*structs.ReadFile, error) {
+	spaceID := ctxutil.GetSpaceID(ctx)
+	if query == "" || spaceID == "" {
+		return []*structs.ReadFile{}, nil
+	}
+
+	rows, _, _, err := s.fileRepo.SearchWithFacets(ctx, &structs.FileSearchParams{
+		OwnerID: spaceID,
+		Query:   query,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, handleEntError(ctx, "File", err)
+	}
+
+	files := make([]*structs.ReadFile, 0, len(rows))
+	for _, row := range rows {
+		if s.authorizeRead(ctx, row) != nil {
+			continue
+		}
+		files = append(files, repository.SerializeFile(row).InternalView())
+	}
+	return files, nil
+}
+
+// GeneratePublicURL generates a signed public URL.
+// scope optionally binds the link to a client, e.g. "ip:203.0.113.7".
+func (s *fileService) GeneratePublicURL(ctx context.Context, slug string, expirationHours int, scope ...string) (string, error) {
 	row, err := s.fileRepo.GetByID(ctx, slug)
 	if err != nil {
 		return "", handleEntError(ctx, "File", err)
@@ -655,31 +956,27 @@ func (s *fileService) GeneratePublicURL(ctx context.Context, slug string, expira
 	}
 	expiresAt := time.Now().Add(time.Duration(expirationHours) * time.Hour).UnixMilli()
 
-	extras := repository.CloneExtras(row.Extras)
-	extras["is_public"] = true
-	extras["expires_at"] = expiresAt
-
-	_, err = s.fileRepo.Update(ctx, slug, types.JSON{
-		"extras": extras,
-	})
-	if err != nil {
-		return "", handleEntError(ctx, "File", err)
+	var tokenScope string
+	if len(scope) > 0 {
+		tokenScope = scope[0]
 	}
 
-	// Generate share token (simplified)
-	shareToken := fmt.Sprintf("%s%d", row.ID, expiresAt)
+	shareToken := s.signer.Sign(row.ID, expiresAt, tokenScope)
 	downloadURL := fmt.Sprintf("/res/share/%s", shareToken)
 	return downloadURL, nil
 }
 
 // CreateVersion creates file version
 func (s *fileService) CreateVersion(ctx context.Context, slug string, file io.Reader, filename string) (*structs.ReadFile, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.CreateVersion")
+	defer span.End()
+
 	existing, err := s.fileRepo.GetByID(ctx, slug)
 	if err != nil {
 		return nil, handleEntError(ctx, "File", err)
 	}
 
-	storageClient, storageConfig := ctxutil.GetStorage(ctx)
+	storageClient, storageConfig := getStorage(ctx)
 	if storageClient == nil || storageConfig == nil {
 		return nil, errors.New("storage not configured")
 	}
@@ -726,6 +1023,7 @@ func (s *fileService) CreateVersion(ctx context.Context, slug string, file io.Re
 		Bucket:       storageConfig.Bucket,
 		Endpoint:     storageConfig.Endpoint,
 		OwnerID:      existing.OwnerID,
+		OnConflict:   structs.NameConflictRename,
 	}
 
 	// Copy extended properties
@@ -742,6 +1040,170 @@ func (s *fileService) CreateVersion(ctx context.Context, slug string, file io.Re
 	return s.Create(ctx, createBody)
 }
 
+// Copy duplicates a file into a new storage object and record.
+// newObjectID is the owner of the copy, empty keeps the original owner.
+// The copy starts its own version history and is charged to the new owner's quota.
+func (s *fileService) Copy(ctx context.Context, slug string, newObjectID string) (*structs.ReadFile, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.Copy")
+	defer span.End()
+
+	if validator.IsEmpty(slug) {
+		return nil, errors.New(ecode.FieldIsRequired("slug"))
+	}
+
+	existing, err := s.fileRepo.GetByID(ctx, slug)
+	if err != nil {
+		return nil, handleEntError(ctx, "File", err)
+	}
+
+	storageClient, storageConfig := getStorage(ctx)
+	if storageClient == nil || storageConfig == nil {
+		return nil, errors.New("storage not configured")
+	}
+
+	ownerID := existing.OwnerID
+	if newObjectID != "" {
+		ownerID = newObjectID
+	}
+
+	// Carry over user metadata, drop anything tied to the source object
+	extras := repository.CloneExtras(existing.Extras)
+	pathPrefix, _ := extras["path_prefix"].(string)
+	sourceThumbnail, _ := extras["thumbnail_path"].(string)
+	for _, key := range []string{"versions", "thumbnail_path", "hash", "path_prefix", "anonymous", "share_tokens", "shared_from", "space_id"} {
+		delete(extras, key)
+	}
+	extras["copied_from"] = existing.ID
+
+	ext := filepath.Ext(existing.Path)
+	if ownerID != "" && s.quotaService != nil {
+		canProceed, err := s.quotaService.CheckAndUpdateQuota(ctx, ownerID, existing.Size)
+		if err != nil {
+			logger.Warnf(ctx, "Error checking quota: %v", err)
+		} else if !canProceed {
+			return nil, errors.New("storage quota exceeded")
+		}
+		scopes := append(structs.FolderQuotaScopes(pathPrefix), structs.CategoryQuotaScope(structs.GetFileCategory(ext)))
+		for _, scope := range scopes {
+			if ok, err := s.quotaService.CheckScopedQuota(ctx, ownerID, scope, existing.Size); !ok {
+				return nil, err
+			}
+		}
+	}
+
+	src, err := s.openObject(ctx, existing, existing.Path)
+	if err != nil {
+		logger.Errorf(ctx, "Error reading source file %s: %v", existing.Path, err)
+		return nil, errors.New("failed to read source file")
+	}
+	defer src.Close()
+
+	// The source is hashed as storage consumes it, the object is never held in memory
+	storagePath := s.generateUniqueStoragePath(existing.Name, ext, &ownerID, &pathPrefix)
+	h := sha256.New()
+	counted := &countingReader{r: io.TeeReader(src, h)}
+	if _, err := storageClient.Put(storagePath, counted); err != nil {
+		logger.Errorf(ctx, "Error storing copy of %s to %s: %v", existing.Path, storageConfig.Provider, err)
+		return nil, fmt.Errorf("failed to store file: %w", err)
+	}
+	extras["hash"] = fmt.Sprintf("sha256:%x", h.Sum(nil))
+
+	if folder := repository.FolderPath(pathPrefix); folder != "" {
+		extras["path_prefix"] = folder
+	}
+	if spaceID := ctxutil.GetSpaceID(ctx); spaceID != "" {
+		extras["space_id"] = spaceID
+	}
+	if ownerID == "" {
+		extras["anonymous"] = true
+	}
+
+	// The thumbnail is copied as it is, the copy has the same content
+	thumbnailPath := ""
+	if sourceThumbnail != "" {
+		thumbnailPath = s.copyThumbnail(ctx, storageClient, existing, sourceThumbnail, ownerID, storagePath)
+		if thumbnailPath != "" {
+			extras["thumbnail_path"] = thumbnailPath
+		}
+	}
+
+	size := int(counted.n)
+	body := &structs.CreateFileBody{
+		Name:         existing.Name,
+		OriginalName: existing.OriginalName,
+		Path:         storagePath,
+		PathPrefix:   pathPrefix,
+		Type:         existing.Type,
+		Size:         &size,
+		Storage:      storageConfig.Provider,
+		Bucket:       storageConfig.Bucket,
+		Endpoint:     storageConfig.Endpoint,
+		AccessLevel:  structs.AccessLevel(existing.AccessLevel),
+		ExpiresAt:    existing.ExpiresAt,
+		Tags:         existing.Tags,
+		IsPublic:     existing.IsPublic,
+		OwnerID:      ownerID,
+		Extras:       &extras,
+	}
+	userID := ctxutil.GetUserID(ctx)
+	if userID != "" {
+		body.CreatedBy = &userID
+	}
+
+	// The copy is renamed on a name conflict
+	for retry := 0; retry < 3; retry++ {
+		row, err := s.fileRepo.Create(ctx, body)
+		if err != nil {
+			if msg := strings.ToLower(err.Error()); strings.Contains(msg, "unique") || strings.Contains(msg, "duplicate") {
+				body.Name = s.generateUniqueName(body.Name)
+				logger.Warnf(ctx, "Name conflict, retrying copy with new name: %s", body.Name)
+				continue
+			}
+			logger.Errorf(ctx, "Error creating record of copy of %s: %v", existing.ID, err)
+			break
+		}
+
+		s.publishCreated(ctx, row, userID)
+		return repository.SerializeFile(row), nil
+	}
+
+	for _, path := range []string{storagePath, thumbnailPath} {
+		if path == "" {
+			continue
+		}
+		if err := storageClient.Delete(path); err != nil {
+			logger.Errorf(ctx, "Failed to cleanup copy %s after error: %v", path, err)
+		}
+	}
+	return nil, errors.New("failed to create file record")
+}
+
+// copyThumbnail streams the thumbnail at sourcePath of existing to the thumbnail key of
+// the copy at objectPath, returning the new key or empty if the thumbnail was not copied
+func (s *fileService) copyThumbnail(ctx context.Context, storageClient oss.Interface, existing *ent.File, sourcePath, ownerID, objectPath string) string {
+	width, height := 300, 300
+	if w, ok := repository.ExtrasInt(existing.Extras, "thumbnail_max_width"); ok {
+		width = w
+	}
+	if h, ok := repository.ExtrasInt(existing.Extras, "thumbnail_max_height"); ok {
+		height = h
+	}
+
+	reader, err := s.openObject(ctx, existing, sourcePath)
+	if err != nil {
+		logger.Warnf(ctx, "Error reading thumbnail %s: %v", sourcePath, err)
+		return ""
+	}
+	defer reader.Close()
+
+	thumbnailPath := s.thumbnailPath(ownerID, ctxutil.GetSpaceID(ctx), objectPath, width, height)
+	if _, err := storageClient.Put(thumbnailPath, reader); err != nil {
+		logger.Warnf(ctx, "Error storing thumbnail: %v", err)
+		return ""
+	}
+	return thumbnailPath
+}
+
 // GetVersions gets file versions
 func (s *fileService) GetVersions(ctx context.Context, slug string) ([]*structs.ReadFile, error) {
 	current, err := s.Get(ctx, slug)
@@ -750,8 +1212,8 @@ func (s *fileService) GetVersions(ctx context.Context, slug string) ([]*structs.
 	}
 
 	extras := repository.CloneExtrasPtr(current.Extras)
-	versions, ok := extras["versions"].([]string)
-	if !ok || len(versions) == 0 {
+	versions := repository.ExtrasStrings(extras, "versions")
+	if len(versions) == 0 {
 		return []*structs.ReadFile{current}, nil
 	}
 
@@ -788,7 +1250,9 @@ func (s *fileService) SetAccessLevel(ctx context.Context, slug string, accessLev
 	extras["is_public"] = accessLevel == structs.AccessLevelPublic
 
 	updated, err := s.fileRepo.Update(ctx, slug, types.JSON{
-		"extras": extras,
+		"access_level": accessLevel,
+		"is_public":    accessLevel == structs.AccessLevelPublic,
+		"extras":       extras,
 	})
 	if err != nil {
 		return nil, handleEntError(ctx, "File", err)
@@ -797,68 +1261,110 @@ func (s *fileService) SetAccessLevel(ctx context.Context, slug string, accessLev
 	return repository.SerializeFile(updated), nil
 }
 
-// CreateThumbnail creates thumbnail
-func (s *fileService) CreateThumbnail(ctx context.Context, slug string, options *structs.ProcessingOptions) (*structs.ReadFile, error) {
+// ShareFile adds users to the file's share list, replacing existing entries for them.
+// Private files are switched to shared so the list takes effect.
+func (s *fileService) ShareFile(ctx context.Context, slug string, body *structs.ShareFileBody) (*structs.ReadFile, error) {
+	if err := body.Validate(); err != nil {
+		return nil, err
+	}
+
 	row, err := s.fileRepo.GetByID(ctx, slug)
 	if err != nil {
 		return nil, handleEntError(ctx, "File", err)
 	}
 
-	if !validator.IsImageFile(row.Path) {
-		return nil, fmt.Errorf("file is not an image")
+	shares := make([]structs.FileShare, 0, len(body.UserIDs))
+	for _, share := range repository.ParseShares(row.Extras) {
+		if !utils.Contains(body.UserIDs, share.UserID) && !share.IsExpired() {
+			shares = append(shares, share)
+		}
 	}
-
-	if options == nil {
-		options = &structs.ProcessingOptions{
-			CreateThumbnail: true,
-			MaxWidth:        300,
-			MaxHeight:       300,
+	for _, userID := range body.UserIDs {
+		if userID == "" {
+			continue
 		}
+		shares = append(shares, structs.FileShare{UserID: userID, ExpiresAt: body.ExpiresAt})
 	}
 
-	storageClient, _ := ctxutil.GetStorage(ctx)
-	if storageClient == nil {
-		return nil, errors.New("storage not configured")
+	updates := types.JSON{}
+	if structs.AccessLevel(row.AccessLevel) == structs.AccessLevelPrivate {
+		updates["access_level"] = structs.AccessLevelShared
 	}
 
-	file, err := storageClient.GetStream(row.Path)
-	if err != nil {
-		return nil, fmt.Errorf("error retrieving file: %w", err)
+	return s.saveShares(ctx, row, shares, updates)
+}
+
+// UnshareFile removes users from the file's share list
+func (s *fileService) UnshareFile(ctx context.Context, slug string, userIDs []string) (*structs.ReadFile, error) {
+	if len(userIDs) == 0 {
+		return nil, errors.New(ecode.FieldIsRequired("user_ids"))
 	}
-	defer file.Close()
 
-	fileBytes, err := io.ReadAll(file)
+	row, err := s.fileRepo.GetByID(ctx, slug)
 	if err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+		return nil, handleEntError(ctx, "File", err)
 	}
 
-	thumbnailBytes, err := s.imageProcessor.CreateThumbnail(
-		ctx,
-		bytes.NewReader(fileBytes),
-		row.Name,
-		options.MaxWidth,
-		options.MaxHeight,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error creating thumbnail: %w", err)
+	shares := make([]structs.FileShare, 0)
+	for _, share := range repository.ParseShares(row.Extras) {
+		if !utils.Contains(userIDs, share.UserID) && !share.IsExpired() {
+			shares = append(shares, share)
+		}
+	}
+
+	return s.saveShares(ctx, row, shares, types.JSON{})
+}
+
+// saveShares stores the share list of row along with any extra updates
+func (s *fileService) saveShares(ctx context.Context, row *ent.File, shares []structs.FileShare, updates types.JSON) (*structs.ReadFile, error) {
+	extras := repository.CloneExtras(row.Extras)
+	if len(shares) == 0 {
+		delete(extras, "shared_with")
+	} else {
+		extras["shared_with"] = shares
 	}
+	updates["extras"] = extras
 
-	thumbnailPath := s.generateThumbnailPath(row.Path)
-	_, err = storageClient.Put(thumbnailPath, bytes.NewReader(thumbnailBytes))
+	updated, err := s.fileRepo.Update(ctx, row.ID, updates)
 	if err != nil {
-		return nil, fmt.Errorf("error storing thumbnail: %w", err)
+		return nil, handleEntError(ctx, "File", err)
 	}
 
-	extras := repository.CloneExtras(row.Extras)
-	extras["thumbnail_path"] = thumbnailPath
+	return repository.SerializeFile(updated), nil
+}
 
-	updated, err := s.fileRepo.Update(ctx, slug, types.JSON{
-		"extras": extras,
-	})
+// CreateThumbnail creates thumbnail
+func (s *fileService) CreateThumbnail(ctx context.Context, slug string, options *structs.ProcessingOptions) (*structs.ReadFile, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.CreateThumbnail")
+	defer span.End()
+
+	row, err := s.fileRepo.GetByID(ctx, slug)
 	if err != nil {
 		return nil, handleEntError(ctx, "File", err)
 	}
 
+	if !validator.IsImageFile(row.Path) {
+		return nil, fmt.Errorf("file is not an image")
+	}
+
+	if options == nil {
+		options = &structs.ProcessingOptions{
+			CreateThumbnail: true,
+			MaxWidth:        300,
+			MaxHeight:       300,
+		}
+	}
+
+	storageClient, _ := getStorage(ctx)
+	if storageClient == nil {
+		return nil, errors.New("storage not configured")
+	}
+
+	updated, err := s.storeThumbnail(ctx, storageClient, row, options)
+	if err != nil {
+		return nil, err
+	}
+
 	return repository.SerializeFile(updated), nil
 }
 
@@ -909,16 +1415,6 @@ func (s *fileService) generateUniqueName(originalName string) string {
 	return fmt.Sprintf("%s_%d_%s", originalName, timestamp, randomID)
 }
 
-// generateThumbnailPath generates thumbnail storage path
-func (s *fileService) generateThumbnailPath(originalPath string) string {
-	dir := filepath.Dir(originalPath)
-	fileName := filepath.Base(originalPath)
-	ext := filepath.Ext(fileName)
-	nameWithoutExt := strings.TrimSuffix(fileName, ext)
-
-	return fmt.Sprintf("%s/thumbnails/%s_thumb.jpg", dir, nameWithoutExt)
-}
-
 // generateVersionPath generates version storage path
 func (s *fileService) generateVersionPath(ownerID, parentSlug, fileName string) string {
 	timestamp := time.Now().Unix()
@@ -962,3 +1458,8 @@ func (s *fileService) getExtensionFromMimeType(mimeType string) string {
 	}
 	return ""
 }
+
+// ReindexFiles rebuilds the search index for an owner's files, empty ownerID reindexes all
+func (s *fileService) ReindexFiles(ctx context.Context, ownerID string) (int, error) {
+	return s.indexer.Reindex(ctx, ownerID)
+}
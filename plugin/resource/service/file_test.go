@@ -0,0 +1,638 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ncobase/internal/middleware"
+	"ncobase/internal/page"
+	"ncobase/internal/tracing"
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/ncobase/ncore/config"
+	"github.com/ncobase/ncore/ctxutil"
+	nd "github.com/ncobase/ncore/data"
+	"github.com/ncobase/ncore/data/connection"
+	"github.com/ncobase/ncore/oss"
+	"github.com/ncobase/ncore/types"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// batchFiles serves files from memory and counts the queries it answers
+type batchFiles struct {
+	repository.FileRepositoryInterface
+	rows    []*ent.File
+	queries int
+}
+
+func (f *batchFiles) GetByIDs(_ context.Context, slugs []string) ([]*ent.File, error) {
+	f.queries++
+	var found []*ent.File
+	for _, row := range f.rows {
+		for _, slug := range slugs {
+			if row.ID == slug || row.Name == slug {
+				found = append(found, row)
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+func TestGetBatchReportsMissingAndForbidden(t *testing.T) {
+	files := &batchFiles{rows: []*ent.File{
+		{ID: "f1", Name: "mine.txt", OwnerID: "u1", AccessLevel: string(structs.AccessLevelPrivate)},
+		{ID: "f2", Name: "theirs.txt", OwnerID: "u2", AccessLevel: string(structs.AccessLevelPrivate)},
+		{ID: "f3", Name: "open.txt", OwnerID: "u2", IsPublic: true},
+		{ID: "f4", Name: "copy.txt", OwnerID: "u1"},
+		{ID: "f5", Name: "copy.txt", OwnerID: "u1"},
+	}}
+	s := &fileService{fileRepo: files}
+	ctx := ctxutil.SetUserID(context.Background(), "u1")
+
+	got, errs := s.GetBatch(ctx, []string{"open.txt", "missing", "f2", "f1", "copy.txt", "f1"})
+	if files.queries != 1 {
+		t.Fatalf("%d queries, want one", files.queries)
+	}
+
+	var ids []string
+	for _, file := range got {
+		ids = append(ids, file.ID)
+	}
+	if fmt.Sprint(ids) != "[f3 f1]" {
+		t.Fatalf("files = %v, want [f3 f1] in request order", ids)
+	}
+
+	if len(errs) != 3 {
+		t.Fatalf("errors = %v, want missing, forbidden and ambiguous", errs)
+	}
+	if !errors.Is(errs["f2"], ErrAccessDenied) {
+		t.Errorf("f2 error = %v, want ErrAccessDenied", errs["f2"])
+	}
+	if errs["missing"] == nil || errors.Is(errs["missing"], ErrAccessDenied) {
+		t.Errorf("missing error = %v, want not found", errs["missing"])
+	}
+	if errs["copy.txt"] == nil {
+		t.Error("ambiguous name not reported")
+	}
+}
+
+func TestGetBatchLimitsSize(t *testing.T) {
+	s := &fileService{fileRepo: &batchFiles{}}
+	slugs := make([]string, structs.MaxBatchGetFiles+2)
+	for i := range slugs {
+		slugs[i] = fmt.Sprintf("f%d", i)
+	}
+
+	_, errs := s.GetBatch(ctxutil.SetUserID(context.Background(), "u1"), slugs)
+	if len(errs) != len(slugs) {
+		t.Fatalf("%d errors, want one per slug", len(errs))
+	}
+	over := slugs[structs.MaxBatchGetFiles]
+	if errs[over] == nil || errs[over].Error() != fmt.Sprintf("batch is limited to %d files", structs.MaxBatchGetFiles) {
+		t.Fatalf("error past the limit = %v", errs[over])
+	}
+}
+
+// memoryFiles keeps file records in memory for the service flows that create and delete them
+type memoryFiles struct {
+	repository.FileRepositoryInterface
+	rows map[string]*ent.File
+	seq  int
+}
+
+func newMemoryFiles(rows ...*ent.File) *memoryFiles {
+	f := &memoryFiles{rows: map[string]*ent.File{}}
+	for _, row := range rows {
+		f.rows[row.ID] = row
+	}
+	return f
+}
+
+func (f *memoryFiles) Create(_ context.Context, body *structs.CreateFileBody) (*ent.File, error) {
+	f.seq++
+	row := &ent.File{
+		ID:           fmt.Sprintf("new-%d", f.seq),
+		Name:         body.Name,
+		OriginalName: body.OriginalName,
+		Path:         body.Path,
+		Type:         body.Type,
+		Storage:      body.Storage,
+		Bucket:       body.Bucket,
+		OwnerID:      body.OwnerID,
+		AccessLevel:  string(body.AccessLevel),
+		Tags:         body.Tags,
+		IsPublic:     body.IsPublic,
+	}
+	if body.Size != nil {
+		row.Size = *body.Size
+	}
+	if body.Extras != nil {
+		row.Extras = *body.Extras
+		row.Hash, _ = row.Extras["hash"].(string)
+	}
+	f.rows[row.ID] = row
+	return row, nil
+}
+
+func (f *memoryFiles) GetByID(_ context.Context, slug string) (*ent.File, error) {
+	if row, ok := f.rows[slug]; ok {
+		return row, nil
+	}
+	return nil, &ent.NotFoundError{}
+}
+
+// GetByIDs returns the stored files among slugs, skipping missing ones
+func (f *memoryFiles) GetByIDs(_ context.Context, slugs []string) ([]*ent.File, error) {
+	var found []*ent.File
+	for _, slug := range slugs {
+		if row, ok := f.rows[slug]; ok {
+			found = append(found, row)
+		}
+	}
+	return found, nil
+}
+
+// GetByHash returns the file of ownerID with hash and the lowest ID
+func (f *memoryFiles) GetByHash(_ context.Context, ownerID, hash string) (*ent.File, error) {
+	var found *ent.File
+	for _, row := range f.rows {
+		if row.OwnerID == ownerID && row.Hash == hash && (found == nil || row.ID < found.ID) {
+			found = row
+		}
+	}
+	if found == nil {
+		return nil, &ent.NotFoundError{}
+	}
+	return found, nil
+}
+
+func (f *memoryFiles) FindByObjectPrefix(_ context.Context, prefix, afterID string, limit int) ([]*ent.File, error) {
+	var found []*ent.File
+	for _, row := range f.rows {
+		thumb, _ := row.Extras["thumbnail_path"].(string)
+		if (strings.HasPrefix(row.Path, prefix) || strings.HasPrefix(thumb, prefix)) && row.ID > afterID {
+			found = append(found, row)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found, nil
+}
+
+func (f *memoryFiles) FindByOwnerAfter(_ context.Context, ownerID, afterID string, limit int) ([]*ent.File, error) {
+	var found []*ent.File
+	for _, row := range f.rows {
+		if (ownerID == "" || row.OwnerID == ownerID) && row.ID > afterID {
+			found = append(found, row)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found, nil
+}
+
+// List pages through the files of params.OwnerID in ID order, params.Category and
+// params.PathPrefix filter by category and storage path
+func (f *memoryFiles) List(_ context.Context, params *structs.ListFileParams) ([]*ent.File, error) {
+	afterID := ""
+	if params.Cursor != "" {
+		cursor, err := page.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		afterID = cursor.ID
+	}
+	var found []*ent.File
+	for _, row := range f.rows {
+		if row.ID <= afterID || (params.OwnerID != "" && row.OwnerID != params.OwnerID) ||
+			(params.Category != "" && row.Category != string(params.Category)) ||
+			!strings.HasPrefix(row.Path, params.PathPrefix) {
+			continue
+		}
+		found = append(found, row)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+	if params.Limit > 0 && len(found) > params.Limit {
+		found = found[:params.Limit]
+	}
+	return found, nil
+}
+
+// CountX counts the files of params.OwnerID
+func (f *memoryFiles) CountX(_ context.Context, params *structs.ListFileParams) int {
+	n := 0
+	for _, row := range f.rows {
+		if params.OwnerID == "" || row.OwnerID == params.OwnerID {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *memoryFiles) Update(_ context.Context, slug string, updates types.JSON) (*ent.File, error) {
+	row, ok := f.rows[slug]
+	if !ok {
+		return nil, &ent.NotFoundError{}
+	}
+	for key, value := range updates {
+		switch key {
+		case "name":
+			row.Name = value.(string)
+		case "access_level":
+			row.AccessLevel = fmt.Sprint(value)
+		case "tags":
+			row.Tags = value.([]string)
+		case "updated_by":
+			row.UpdatedBy = value.(string)
+		case "extras":
+			row.Extras = value.(types.JSON)
+		}
+	}
+	return row, nil
+}
+
+func (f *memoryFiles) Delete(_ context.Context, slug string) error {
+	if _, ok := f.rows[slug]; !ok {
+		return &ent.NotFoundError{}
+	}
+	delete(f.rows, slug)
+	return nil
+}
+
+func (f *memoryFiles) CountPathRefs(_ context.Context, path, excludeID string) (int, error) {
+	refs := 0
+	for _, row := range f.rows {
+		if row.Path == path && row.ID != excludeID {
+			refs++
+		}
+	}
+	return refs, nil
+}
+
+// memoryBucket is an object store in memory, corrupt flips the first byte of every object put.
+// modified holds the modification times List reports, objects missing from it report none.
+type memoryBucket struct {
+	oss.Interface
+	mu       sync.Mutex
+	objects  map[string][]byte
+	modified map[string]time.Time
+	puts     int
+	corrupt  bool
+}
+
+func newMemoryBucket(objects map[string]string) *memoryBucket {
+	b := &memoryBucket{objects: map[string][]byte{}}
+	for path, content := range objects {
+		b.objects[path] = []byte(content)
+	}
+	return b
+}
+
+func (b *memoryBucket) GetStream(path string) (io.ReadCloser, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	content, ok := b.objects[path]
+	if !ok {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (b *memoryBucket) Put(path string, reader io.Reader) (*oss.Object, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if b.corrupt && len(content) > 0 {
+		content[0] ^= 0xff
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.objects[path] = content
+	b.puts++
+	return &oss.Object{}, nil
+}
+
+func (b *memoryBucket) Delete(path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.objects, path)
+	return nil
+}
+
+func (b *memoryBucket) Exists(path string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.objects[path]
+	return ok, nil
+}
+
+func (b *memoryBucket) List(prefix string) ([]*oss.Object, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var objects []*oss.Object
+	for path, content := range b.objects {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		object := &oss.Object{Path: path, Size: int64(len(content))}
+		if modified, ok := b.modified[path]; ok {
+			object.LastModified = &modified
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
+func (b *memoryBucket) has(path string) bool {
+	ok, _ := b.Exists(path)
+	return ok
+}
+
+// withBucket returns a context whose storage is the given bucket
+func withBucket(b *memoryBucket) context.Context {
+	ctx := ctxutil.SetConfig(context.Background(), &config.Config{Storage: &oss.Config{Provider: "memory", Bucket: "test"}})
+	return ctxutil.SetStorage(ctx, b)
+}
+
+func readObject(t *testing.T, ctx context.Context, s *fileService, slug string) string {
+	t.Helper()
+	row, err := s.fileRepo.GetByID(ctx, slug)
+	if err != nil {
+		t.Fatalf("GetByID(%s): %v", slug, err)
+	}
+	stream, err := s.openObject(ctx, row, row.Path)
+	if err != nil {
+		t.Fatalf("open %s: %v", row.Path, err)
+	}
+	defer stream.Close()
+	content, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("read %s: %v", row.Path, err)
+	}
+	return string(content)
+}
+
+func TestCopyStoresIndependentObject(t *testing.T) {
+	content := strings.Repeat("report ", 100)
+	bucket := newMemoryBucket(map[string]string{"u1/report.txt": content})
+	files := newMemoryFiles(&ent.File{
+		ID: "f1", Name: "report", OriginalName: "report.txt", Path: "u1/report.txt", Type: "text/plain",
+		Size: len(content), Storage: "memory", Bucket: "test", OwnerID: "u1", Tags: []string{"q3"},
+		Extras: map[string]any{
+			"path_prefix": "docs", "hash": "old", "versions": []string{"f0"}, "share_tokens": []string{"t1"},
+			"description": "quarterly",
+		},
+	})
+	s := &fileService{fileRepo: files}
+	ctx := withBucket(bucket)
+
+	copied, err := s.Copy(ctx, "f1", "")
+	if err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+	row := files.rows[copied.ID]
+	if row.Path == "u1/report.txt" || !strings.HasPrefix(row.Path, "docs/u1/") {
+		t.Fatalf("copy stored at %q, want a new object in the source folder", row.Path)
+	}
+	if got := readObject(t, ctx, s, copied.ID); got != content {
+		t.Fatal("copy content differs from the source")
+	}
+	if row.OwnerID != "u1" || fmt.Sprint(row.Tags) != "[q3]" {
+		t.Fatalf("copy owner %q, tags %v, want the source's", row.OwnerID, row.Tags)
+	}
+	if row.Extras["copied_from"] != "f1" || row.Extras["description"] != "quarterly" {
+		t.Fatalf("copy extras = %v, want the user metadata and its source", row.Extras)
+	}
+	for _, key := range []string{"versions", "share_tokens"} {
+		if _, ok := row.Extras[key]; ok {
+			t.Errorf("copy inherited %s of the source", key)
+		}
+	}
+	if row.Extras["hash"] != calculateFileHash([]byte(content)) {
+		t.Errorf("copy hash = %v, want the hash of its content", row.Extras["hash"])
+	}
+
+	if err := s.Delete(ctx, copied.ID); err != nil {
+		t.Fatalf("Delete copy: %v", err)
+	}
+	if bucket.has(row.Path) {
+		t.Fatal("object of the deleted copy left in storage")
+	}
+	if got := readObject(t, ctx, s, "f1"); got != content {
+		t.Fatal("deleting the copy changed the source")
+	}
+}
+
+func TestCopyToNewOwner(t *testing.T) {
+	bucket := newMemoryBucket(map[string]string{"u1/a.txt": "content"})
+	files := newMemoryFiles(&ent.File{ID: "f1", Name: "a", Path: "u1/a.txt", Type: "text/plain", OwnerID: "u1"})
+	s := &fileService{fileRepo: files}
+
+	copied, err := s.Copy(withBucket(bucket), "f1", "u2")
+	if err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+	if copied.OwnerID != "u2" || !strings.HasPrefix(files.rows[copied.ID].Path, "u2/") {
+		t.Fatalf("copy owned by %q at %q, want u2", copied.OwnerID, files.rows[copied.ID].Path)
+	}
+}
+
+func TestCopyStreamsThumbnail(t *testing.T) {
+	bucket := newMemoryBucket(map[string]string{"u1/pic.png": "image", "u1/thumbnails/pic_thumb.png": "thumb"})
+	files := newMemoryFiles(&ent.File{
+		ID: "f1", Name: "pic", Path: "u1/pic.png", Type: "image/png", Size: 5, OwnerID: "u1",
+		Extras: map[string]any{"thumbnail_path": "u1/thumbnails/pic_thumb.png", "thumbnail_max_width": float64(64), "thumbnail_max_height": float64(64)},
+	})
+	s := &fileService{fileRepo: files, thumbnailTemplate: "{dir}/thumbnails/{name}_{size}.{ext}"}
+	ctx := withBucket(bucket)
+
+	copied, err := s.Copy(ctx, "f1", "")
+	if err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+	row := files.rows[copied.ID]
+	if row.Size != 5 {
+		t.Errorf("copy size = %d, want the streamed 5 bytes", row.Size)
+	}
+	thumb, _ := row.Extras["thumbnail_path"].(string)
+	if thumb == "" || thumb == "u1/thumbnails/pic_thumb.png" || !strings.HasSuffix(thumb, "_64x64.png") {
+		t.Fatalf("copy thumbnail = %q, want its own key at the source size", thumb)
+	}
+	if got := string(bucket.objects[thumb]); got != "thumb" {
+		t.Fatalf("copied thumbnail = %q, want the source thumbnail", got)
+	}
+}
+
+func TestCopyMissingSource(t *testing.T) {
+	s := &fileService{fileRepo: newMemoryFiles()}
+	if _, err := s.Copy(withBucket(newMemoryBucket(nil)), "missing", ""); err == nil {
+		t.Fatal("copy of a missing file succeeded")
+	}
+}
+
+func TestShareFileGrantsStreaming(t *testing.T) {
+	s, bucket := newStreamService(&ent.File{ID: "f1", OwnerID: "u1", AccessLevel: string(structs.AccessLevelPrivate)})
+	ctx := ctxutil.SetUserID(withBucket(bucket), "u1")
+
+	shared, err := s.ShareFile(ctx, "f1", &structs.ShareFileBody{UserIDs: []string{"u2", "u3"}})
+	if err != nil {
+		t.Fatalf("ShareFile: %v", err)
+	}
+	if shared.AccessLevel != structs.AccessLevelShared || len(shared.SharedWith) != 2 {
+		t.Fatalf("shared file = %s with %v, want shared with u2 and u3", shared.AccessLevel, shared.SharedWith)
+	}
+	if err := stream(bucket, s, "f1", "u2"); err != nil {
+		t.Fatalf("shared user: %v", err)
+	}
+	if err := stream(bucket, s, "f1", "u4"); !errors.Is(err, ErrAccessDenied) {
+		t.Fatalf("other user: err = %v, want ErrAccessDenied", err)
+	}
+
+	unshared, err := s.UnshareFile(ctx, "f1", []string{"u2"})
+	if err != nil {
+		t.Fatalf("UnshareFile: %v", err)
+	}
+	if len(unshared.SharedWith) != 1 || unshared.SharedWith[0].UserID != "u3" {
+		t.Fatalf("share list = %v, want only u3", unshared.SharedWith)
+	}
+	if err := stream(bucket, s, "f1", "u2"); !errors.Is(err, ErrAccessDenied) {
+		t.Fatalf("unshared user: err = %v, want ErrAccessDenied", err)
+	}
+	if err := stream(bucket, s, "f1", "u3"); err != nil {
+		t.Fatalf("remaining shared user: %v", err)
+	}
+}
+
+func TestShareFileExpiry(t *testing.T) {
+	s, bucket := newStreamService(&ent.File{ID: "f1", OwnerID: "u1", AccessLevel: string(structs.AccessLevelShared)})
+	ctx := withBucket(bucket)
+
+	past := time.Now().Add(-time.Minute).UnixMilli()
+	if _, err := s.ShareFile(ctx, "f1", &structs.ShareFileBody{UserIDs: []string{"u2"}, ExpiresAt: &past}); err == nil {
+		t.Fatal("share expiring in the past accepted")
+	}
+
+	// a share that has run out no longer grants access and is dropped on the next change
+	files := s.fileRepo.(*memoryFiles)
+	files.rows["f1"].Extras = types.JSON{"shared_with": []structs.FileShare{{UserID: "u2", ExpiresAt: &past}}}
+	if err := stream(bucket, s, "f1", "u2"); !errors.Is(err, ErrAccessDenied) {
+		t.Fatalf("expired share: err = %v, want ErrAccessDenied", err)
+	}
+	future := time.Now().Add(time.Hour).UnixMilli()
+	shared, err := s.ShareFile(ctx, "f1", &structs.ShareFileBody{UserIDs: []string{"u3"}, ExpiresAt: &future})
+	if err != nil {
+		t.Fatalf("ShareFile: %v", err)
+	}
+	if len(shared.SharedWith) != 1 || shared.SharedWith[0].UserID != "u3" || *shared.SharedWith[0].ExpiresAt != future {
+		t.Fatalf("share list = %v, want only u3 until %d", shared.SharedWith, future)
+	}
+}
+
+func TestCreateSpanTree(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	// Files are stored in an in-memory database traced as in production
+	client, err := ent.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	client.Use(tracing.EntHook())
+	client.Intercept(tracing.EntInterceptor())
+	d := &data.Data{Data: &nd.Data{Conn: &connection.Connections{RC: (*redis.Client)(nil)}}, EC: client}
+	s := &fileService{fileRepo: repository.NewFileRepository(d)}
+
+	// The request goes through the trace middleware of the server
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.Trace)
+	engine.POST("/res", func(c *gin.Context) {
+		size := 5
+		_, err := s.Create(c.Request.Context(), &structs.CreateFileBody{
+			Name: "notes", Path: "notes.txt", Type: "text/plain", Size: &size, OwnerID: "u1",
+			File: &readCloser{bytes.NewReader([]byte("notes"))},
+		})
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/res", nil).WithContext(withBucket(newMemoryBucket(nil))))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Create = %d %s", w.Code, w.Body.String())
+	}
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	request, create, insert, put := spans["/res"], spans["resource.file.Create"], spans["ent.File.OpCreate"], spans["storage.put"]
+	if request == nil || create == nil || insert == nil || put == nil {
+		t.Fatalf("spans = %v, want the request, its create, the insert and the storage put", spans)
+	}
+	if request.Parent().IsValid() {
+		t.Error("request span has a parent")
+	}
+	for name, span := range map[string]sdktrace.ReadOnlySpan{"create": create, "insert": insert, "storage put": put} {
+		if span.SpanContext().TraceID() != request.SpanContext().TraceID() {
+			t.Errorf("%s is not in the trace of the request", name)
+		}
+	}
+	if create.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Error("create span is not a child of the request span")
+	}
+	if insert.Parent().SpanID() != create.SpanContext().SpanID() {
+		t.Error("file insert is not a child of the create span")
+	}
+	if put.Parent().SpanID() != create.SpanContext().SpanID() {
+		t.Error("storage put is not a child of the create span")
+	}
+}
+
+func TestListAppliesPageSizes(t *testing.T) {
+	files := newMemoryFiles()
+	for i := 0; i < 30; i++ {
+		files.rows[fmt.Sprintf("f%02d", i)] = &ent.File{ID: fmt.Sprintf("f%02d", i), OwnerID: "o1"}
+	}
+	s := &fileService{fileRepo: files}
+
+	result, err := s.List(context.Background(), &structs.ListFileParams{OwnerID: "o1"})
+	if err != nil || len(result.Items) != 20 || !result.HasNext {
+		t.Fatalf("List without a limit = %d items, %v, want the default of 20", len(result.Items), err)
+	}
+	if _, err := s.List(context.Background(), &structs.ListFileParams{OwnerID: "o1", Limit: 101}); !errors.Is(err, page.ErrInvalidLimit) {
+		t.Fatalf("List with limit 101 = %v, want ErrInvalidLimit", err)
+	}
+}
@@ -6,13 +6,23 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"ncobase/internal/dberr"
+	"ncobase/internal/tracing"
+	"ncobase/internal/utils"
+	"ncobase/plugin/resource/config"
 	"ncobase/plugin/resource/data/repository"
+	"time"
 
+	"github.com/ncobase/ncore/ctxutil"
 	"github.com/ncobase/ncore/ecode"
 	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/oss"
 	"github.com/ncobase/ncore/validation/validator"
 )
 
+// ErrVersionConflict is returned when an update was based on a stale version
+var ErrVersionConflict = repository.ErrVersionConflict
+
 // readCloser wrapper for bytes.Reader
 type readCloser struct {
 	*bytes.Reader
@@ -22,11 +32,17 @@ func (r *readCloser) Close() error {
 	return nil
 }
 
+// getStorage returns the request's storage client, traced under ctx
+func getStorage(ctx context.Context) (oss.Interface, *oss.Config) {
+	storageClient, storageConfig := ctxutil.GetStorage(ctx)
+	return tracing.Storage(ctx, storageClient), storageConfig
+}
+
 // handleEntError handles ent errors consistently
 func handleEntError(ctx context.Context, k string, err error) error {
 	if repository.IsNotFound(err) {
 		logger.Errorf(ctx, "Error not found in %s: %v", k, err)
-		return errors.New(ecode.NotExist(k))
+		return dberr.NotFound(ecode.NotExist(k))
 	}
 	if repository.IsConstraintError(err) {
 		logger.Errorf(ctx, "Error constraint in %s: %v", k, err)
@@ -43,6 +59,28 @@ func handleEntError(ctx context.Context, k string, err error) error {
 	return err
 }
 
+// BatchWorkers returns the worker options of batch file operations from conf,
+// an invalid item timeout leaves items without deadline
+func BatchWorkers(conf *config.BatchConfig) utils.WorkerOptions {
+	opts := utils.WorkerOptions{}
+	if conf == nil {
+		return opts
+	}
+	opts.Parallelism = conf.Parallelism
+	if d, err := time.ParseDuration(conf.ItemTimeout); err == nil {
+		opts.ItemTimeout = d
+	}
+	return opts
+}
+
+// workers returns the worker options of the batch operations of the file service
+func (s *fileService) workers() utils.WorkerOptions {
+	if s.conf == nil {
+		return utils.WorkerOptions{}
+	}
+	return BatchWorkers(s.conf.Batch)
+}
+
 // calculateFileHash calculates SHA256 hash of file content
 func calculateFileHash(content []byte) string {
 	hash := sha256.Sum256(content)
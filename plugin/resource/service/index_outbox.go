@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+	"time"
+
+	nd "github.com/ncobase/ncore/data"
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// Index outbox defaults, used when the config leaves a value unset or invalid
+const (
+	defaultOutboxInterval    = 5 * time.Second
+	defaultOutboxMaxAttempts = 10
+	defaultOutboxBatchSize   = 100
+	defaultOutboxRetention   = 7 * 24 * time.Hour
+)
+
+// Index outbox pacing
+const (
+	maxOutboxRetryDelay = 10 * time.Minute // cap of the exponential retry delay
+	outboxPurgeInterval = time.Hour        // how often delivered updates are purged
+)
+
+// IndexOutbox delivers the search index updates recorded with file writes. An update
+// whose delivery fails is retried with an exponential delay and dead-lettered after
+// the configured number of attempts. Updates re-read the file when delivered, so
+// delivering one twice or out of order leaves the index right.
+type IndexOutbox struct {
+	outbox      repository.IndexOutboxRepositoryInterface
+	files       repository.FileRepositoryInterface
+	interval    time.Duration
+	maxAttempts int
+	batchSize   int
+	retention   time.Duration
+	enabled     bool // a search engine is configured, otherwise nothing is recorded
+}
+
+// NewIndexOutbox creates a new index outbox
+func NewIndexOutbox(d *data.Data, conf *config.OutboxConfig) *IndexOutbox {
+	o := &IndexOutbox{
+		outbox:      repository.NewIndexOutboxRepository(d),
+		files:       repository.NewFileRepository(d),
+		interval:    defaultOutboxInterval,
+		maxAttempts: defaultOutboxMaxAttempts,
+		batchSize:   defaultOutboxBatchSize,
+		retention:   defaultOutboxRetention,
+		enabled:     nd.NewSearchClient(d.Data) != nil,
+	}
+	if conf == nil {
+		return o
+	}
+
+	if interval, err := time.ParseDuration(conf.Interval); err == nil && interval > 0 {
+		o.interval = interval
+	}
+	if conf.MaxAttempts > 0 {
+		o.maxAttempts = conf.MaxAttempts
+	}
+	if conf.BatchSize > 0 {
+		o.batchSize = conf.BatchSize
+	}
+	if retention, err := time.ParseDuration(conf.Retention); err == nil && retention > 0 {
+		o.retention = retention
+	}
+	return o
+}
+
+// Run delivers due updates every interval and purges delivered ones hourly, until ctx is done
+func (o *IndexOutbox) Run(ctx context.Context) {
+	if !o.enabled {
+		return
+	}
+
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	var purgedAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := o.Drain(ctx); err != nil && ctx.Err() == nil {
+				logger.Errorf(ctx, "Error delivering index updates: %v", err)
+			}
+
+			if now.Sub(purgedAt) >= outboxPurgeInterval {
+				purgedAt = now
+				if _, err := o.Purge(ctx); err != nil && ctx.Err() == nil {
+					logger.Errorf(ctx, "Error purging delivered index updates: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// Drain delivers the updates that are due, batch after batch, and returns the number delivered.
+// A failed update is rescheduled, so it is not tried again within the same drain.
+func (o *IndexOutbox) Drain(ctx context.Context) (int, error) {
+	delivered := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return delivered, err
+		}
+
+		entries, err := o.outbox.Due(ctx, time.Now(), o.batchSize)
+		if err != nil {
+			return delivered, fmt.Errorf("failed to query index updates: %w", err)
+		}
+
+		for _, entry := range entries {
+			if o.deliver(ctx, entry) {
+				delivered++
+			}
+		}
+
+		if len(entries) < o.batchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// deliver makes an attempt at an update and records its outcome, it reports whether it was delivered
+func (o *IndexOutbox) deliver(ctx context.Context, entry *ent.IndexOutbox) bool {
+	err := o.files.SyncIndex(ctx, entry.FileID)
+	if err == nil {
+		if err := o.outbox.MarkDone(ctx, entry.ID); err != nil {
+			logger.Errorf(ctx, "Failed to mark index update of file %s done: %v", entry.FileID, err)
+		}
+		return true
+	}
+
+	attempts := entry.Attempts + 1
+	if attempts >= o.maxAttempts {
+		logger.Errorf(ctx, "Giving up index update of file %s after %d attempts: %v", entry.FileID, attempts, err)
+		if err := o.outbox.MarkDead(ctx, entry.ID, attempts, err); err != nil {
+			logger.Errorf(ctx, "Failed to dead-letter index update of file %s: %v", entry.FileID, err)
+		}
+		return false
+	}
+
+	logger.Warnf(ctx, "Index update of file %s failed, attempt %d of %d: %v", entry.FileID, attempts, o.maxAttempts, err)
+	if err := o.outbox.MarkFailed(ctx, entry.ID, attempts, err, time.Now().Add(o.retryDelay(attempts))); err != nil {
+		logger.Errorf(ctx, "Failed to reschedule index update of file %s: %v", entry.FileID, err)
+	}
+	return false
+}
+
+// retryDelay returns the wait after the given number of failed attempts, doubling
+// from the poll interval up to maxOutboxRetryDelay
+func (o *IndexOutbox) retryDelay(attempts int) time.Duration {
+	delay := o.interval
+	for i := 1; i < attempts && delay < maxOutboxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxOutboxRetryDelay)
+}
+
+// Purge deletes the delivered updates older than the retention and returns their number
+func (o *IndexOutbox) Purge(ctx context.Context) (int, error) {
+	return o.outbox.PurgeDone(ctx, time.Now().Add(-o.retention))
+}
+
+// Requeue makes the dead-lettered updates pending again and returns their number
+func (o *IndexOutbox) Requeue(ctx context.Context) (int, error) {
+	return o.outbox.Requeue(ctx)
+}
+
+// Status counts the updates by status: pending, done and dead
+func (o *IndexOutbox) Status(ctx context.Context) (map[string]int, error) {
+	return o.outbox.CountByStatus(ctx)
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+)
+
+// memoryOutbox keeps outbox entries in memory
+type memoryOutbox struct {
+	mu      sync.Mutex
+	entries map[string]*ent.IndexOutbox
+}
+
+func newMemoryOutbox(fileIDs ...string) *memoryOutbox {
+	o := &memoryOutbox{entries: map[string]*ent.IndexOutbox{}}
+	for _, fileID := range fileIDs {
+		_, _ = o.Enqueue(context.Background(), fileID, repository.OutboxIndex)
+	}
+	return o
+}
+
+func (o *memoryOutbox) Enqueue(_ context.Context, fileID, operation string) (*ent.IndexOutbox, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	entry := &ent.IndexOutbox{
+		ID:        fileID + "-" + operation,
+		FileID:    fileID,
+		Operation: operation,
+		Status:    repository.OutboxPending,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	o.entries[entry.ID] = entry
+	return entry, nil
+}
+
+func (o *memoryOutbox) Due(_ context.Context, now time.Time, limit int) ([]*ent.IndexOutbox, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var due []*ent.IndexOutbox
+	for _, entry := range o.entries {
+		if entry.Status == repository.OutboxPending && entry.NextAttemptAt <= now.UnixMilli() {
+			copied := *entry
+			due = append(due, &copied)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (o *memoryOutbox) MarkDone(_ context.Context, id string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.entries[id].Status = repository.OutboxDone
+	o.entries[id].LastError = ""
+	return nil
+}
+
+func (o *memoryOutbox) MarkFailed(_ context.Context, id string, attempts int, cause error, next time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.entries[id].Attempts = attempts
+	o.entries[id].LastError = cause.Error()
+	o.entries[id].NextAttemptAt = next.UnixMilli()
+	return nil
+}
+
+func (o *memoryOutbox) MarkDead(_ context.Context, id string, attempts int, cause error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.entries[id].Status = repository.OutboxDead
+	o.entries[id].Attempts = attempts
+	o.entries[id].LastError = cause.Error()
+	return nil
+}
+
+func (o *memoryOutbox) Requeue(_ context.Context) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	n := 0
+	for _, entry := range o.entries {
+		if entry.Status == repository.OutboxDead {
+			entry.Status, entry.Attempts, entry.NextAttemptAt = repository.OutboxPending, 0, 0
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (o *memoryOutbox) PurgeDone(_ context.Context, _ time.Time) (int, error) { return 0, nil }
+
+func (o *memoryOutbox) CountByStatus(_ context.Context) (map[string]int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	counts := map[string]int{}
+	for _, entry := range o.entries {
+		counts[entry.Status]++
+	}
+	return counts, nil
+}
+
+func (o *memoryOutbox) entry(fileID string) ent.IndexOutbox {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return *o.entries[fileID+"-"+repository.OutboxIndex]
+}
+
+// flakyIndex fails the index updates of a file a given number of times
+type flakyIndex struct {
+	repository.FileRepositoryInterface
+	mu       sync.Mutex
+	failures map[string]int
+	synced   map[string]int
+}
+
+func (f *flakyIndex) SyncIndex(_ context.Context, fileID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failures[fileID] > 0 {
+		f.failures[fileID]--
+		return errors.New("search engine unavailable")
+	}
+	f.synced[fileID]++
+	return nil
+}
+
+func newTestOutbox(outbox *memoryOutbox, files *flakyIndex, maxAttempts int) *IndexOutbox {
+	return &IndexOutbox{
+		outbox:      outbox,
+		files:       files,
+		interval:    time.Millisecond,
+		maxAttempts: maxAttempts,
+		batchSize:   2,
+		retention:   time.Hour,
+		enabled:     true,
+	}
+}
+
+func TestIndexOutboxRedeliversFailedUpdates(t *testing.T) {
+	outbox := newMemoryOutbox("a", "b", "c")
+	files := &flakyIndex{failures: map[string]int{"b": 2}, synced: map[string]int{}}
+	o := newTestOutbox(outbox, files, 5)
+	ctx := context.Background()
+
+	delivered, err := o.Drain(ctx)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if delivered != 2 {
+		t.Fatalf("first drain delivered %d, want 2", delivered)
+	}
+	if got := outbox.entry("b"); got.Status != repository.OutboxPending || got.Attempts != 1 || got.LastError == "" {
+		t.Fatalf("failed entry = %+v, want pending after 1 attempt", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+		if _, err := o.Drain(ctx); err != nil {
+			t.Fatalf("Drain: %v", err)
+		}
+	}
+	if got := outbox.entry("b"); got.Status != repository.OutboxDone {
+		t.Fatalf("entry after retries = %+v, want done", got)
+	}
+	for _, fileID := range []string{"a", "b", "c"} {
+		if files.synced[fileID] != 1 {
+			t.Fatalf("file %s indexed %d times, want once", fileID, files.synced[fileID])
+		}
+	}
+}
+
+func TestIndexOutboxDeadLettersAndRequeues(t *testing.T) {
+	outbox := newMemoryOutbox("a")
+	files := &flakyIndex{failures: map[string]int{"a": 2}, synced: map[string]int{}}
+	o := newTestOutbox(outbox, files, 2)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := o.Drain(ctx); err != nil {
+			t.Fatalf("Drain: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := outbox.entry("a"); got.Status != repository.OutboxDead || got.Attempts != 2 {
+		t.Fatalf("entry = %+v, want dead after 2 attempts", got)
+	}
+
+	if n, err := o.Requeue(ctx); err != nil || n != 1 {
+		t.Fatalf("Requeue = %d, %v, want 1", n, err)
+	}
+	if delivered, err := o.Drain(ctx); err != nil || delivered != 1 {
+		t.Fatalf("Drain after requeue = %d, %v, want 1", delivered, err)
+	}
+	if got := outbox.entry("a"); got.Status != repository.OutboxDone {
+		t.Fatalf("entry after requeue = %+v, want done", got)
+	}
+}
+
+func TestIndexOutboxRetryDelay(t *testing.T) {
+	o := &IndexOutbox{interval: time.Second}
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 4: 8 * time.Second, 30: maxOutboxRetryDelay} {
+		if got := o.retryDelay(attempts); got != want {
+			t.Errorf("retryDelay(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/repository"
+
+	"github.com/ncobase/ncore/logging/logger"
+)
+
+// reindexBatchSize is the number of files sent to the index per request
+const reindexBatchSize = 500
+
+// FileIndexer rebuilds the file search index from the database
+type FileIndexer struct {
+	fileRepo repository.FileRepositoryInterface
+}
+
+// NewFileIndexer creates a new file indexer
+func NewFileIndexer(d *data.Data) *FileIndexer {
+	return &FileIndexer{fileRepo: repository.NewFileRepository(d)}
+}
+
+// Reindex configures the index attributes and streams all files of ownerID
+// into it in batches, empty ownerID reindexes every file.
+// It returns the number of files indexed.
+func (i *FileIndexer) Reindex(ctx context.Context, ownerID string) (int, error) {
+	if err := i.fileRepo.ConfigureSearchIndex(ctx); err != nil {
+		return 0, err
+	}
+
+	indexed := 0
+	afterID := ""
+	for {
+		rows, err := i.fileRepo.FindByOwnerAfter(ctx, ownerID, afterID, reindexBatchSize)
+		if err != nil {
+			return indexed, fmt.Errorf("failed to query files: %w", err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		if err := i.fileRepo.BulkIndex(ctx, rows); err != nil {
+			return indexed, fmt.Errorf("failed to index batch after %s: %w", afterID, err)
+		}
+
+		indexed += len(rows)
+		afterID = rows[len(rows)-1].ID
+	}
+
+	logger.Infof(ctx, "Reindexed %d files for owner %q", indexed, ownerID)
+	return indexed, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"ncobase/plugin/resource/data/ent"
+)
+
+// memoryIndex is a search index in memory fed by a file repository
+type memoryIndex struct {
+	*memoryFiles
+	configured bool
+	batches    int
+	docs       map[string]*ent.File
+	failAfter  int
+}
+
+func (x *memoryIndex) ConfigureSearchIndex(_ context.Context) error {
+	x.configured = true
+	return nil
+}
+
+func (x *memoryIndex) BulkIndex(_ context.Context, rows []*ent.File) error {
+	if !x.configured {
+		return errors.New("index not configured")
+	}
+	if x.failAfter > 0 && x.batches == x.failAfter {
+		return errors.New("index unavailable")
+	}
+	x.batches++
+	for _, row := range rows {
+		x.docs[row.ID] = row
+	}
+	return nil
+}
+
+// search counts the indexed files carrying tag
+func (x *memoryIndex) search(tag string) int {
+	hits := 0
+	for _, doc := range x.docs {
+		for _, t := range doc.Tags {
+			if t == tag {
+				hits++
+			}
+		}
+	}
+	return hits
+}
+
+func newMemoryIndex(owners map[string]int) *memoryIndex {
+	files := newMemoryFiles()
+	for owner, n := range owners {
+		for i := 0; i < n; i++ {
+			id := fmt.Sprintf("%s-%04d", owner, i)
+			files.rows[id] = &ent.File{ID: id, OwnerID: owner, Tags: []string{"report"}}
+		}
+	}
+	return &memoryIndex{memoryFiles: files, docs: map[string]*ent.File{}}
+}
+
+func TestReindexStreamsOwnerInBatches(t *testing.T) {
+	index := newMemoryIndex(map[string]int{"t1": 2*reindexBatchSize + 1, "t2": 3})
+	indexer := &FileIndexer{fileRepo: index}
+
+	n, err := indexer.Reindex(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if n != 2*reindexBatchSize+1 || index.batches != 3 {
+		t.Fatalf("indexed %d files in %d batches, want %d in 3", n, index.batches, 2*reindexBatchSize+1)
+	}
+	if hits := index.search("report"); hits != n {
+		t.Fatalf("%d searchable files, want %d", hits, n)
+	}
+	if _, ok := index.docs["t2-0000"]; ok {
+		t.Fatal("file of another owner indexed")
+	}
+}
+
+func TestReindexAllOwners(t *testing.T) {
+	index := newMemoryIndex(map[string]int{"t1": 2, "t2": 3})
+	n, err := (&FileIndexer{fileRepo: index}).Reindex(context.Background(), "")
+	if err != nil || n != 5 {
+		t.Fatalf("Reindex = %d, %v, want 5 files", n, err)
+	}
+}
+
+func TestReindexReportsProgressOnFailure(t *testing.T) {
+	index := newMemoryIndex(map[string]int{"t1": reindexBatchSize + 1})
+	index.failAfter = 1
+
+	n, err := (&FileIndexer{fileRepo: index}).Reindex(context.Background(), "t1")
+	if err == nil {
+		t.Fatal("index failure swallowed")
+	}
+	if n != reindexBatchSize {
+		t.Fatalf("reported %d files indexed, want the %d of the first batch", n, reindexBatchSize)
+	}
+}
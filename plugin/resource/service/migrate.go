@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"ncobase/internal/page"
+	"ncobase/internal/utils"
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+	"sync"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/oss"
+	"github.com/ncobase/ncore/types"
+)
+
+// migrateBatchSize is the number of files listed per batch of a bucket migration run
+const migrateBatchSize = 100
+
+// FileMigrator moves file objects from one bucket to another, a legacy bucket to the
+// current one usually, and points the file records at their new bucket
+type FileMigrator struct {
+	fileRepo repository.FileRepositoryInterface
+	buckets  []*oss.Config
+	opts     *structs.MigrateOptions
+	connect  func(cfg *oss.Config) (oss.Interface, error)
+
+	paths sync.Map // storage path -> *sync.Mutex, records sharing an object migrate one at a time
+}
+
+// NewFileMigrator creates a file migrator between the current storage and the legacy buckets
+func NewFileMigrator(d *data.Data, current *oss.Config, legacy []*oss.Config, opts *structs.MigrateOptions) *FileMigrator {
+	return newFileMigrator(repository.NewFileRepository(d), append([]*oss.Config{current}, legacy...), opts)
+}
+
+// newFileMigrator creates a file migrator over the given file repository and bucket configs
+func newFileMigrator(fileRepo repository.FileRepositoryInterface, buckets []*oss.Config, opts *structs.MigrateOptions) *FileMigrator {
+	if opts == nil {
+		opts = &structs.MigrateOptions{}
+	}
+	return &FileMigrator{
+		fileRepo: fileRepo,
+		buckets:  buckets,
+		opts:     opts,
+		connect:  oss.NewStorage,
+	}
+}
+
+// MigrateFiles copies the objects of the files recorded in fromBucket and matching params to
+// toBucket. A record is only moved to toBucket once the checksum of the copy matches the
+// source, and the source object is kept unless opts.DeleteSource is set. Files are walked in
+// list order, the report cursor after each batch resumes an interrupted run through params.
+func (m *FileMigrator) MigrateFiles(ctx context.Context, fromBucket, toBucket string, params *structs.ListFileParams) (*structs.MigrateReport, error) {
+	if fromBucket == "" || toBucket == "" {
+		return nil, fmt.Errorf("source and target buckets are required")
+	}
+	if fromBucket == toBucket {
+		return nil, fmt.Errorf("source and target bucket are both %s", fromBucket)
+	}
+
+	fromConfig, src, err := m.open(fromBucket)
+	if err != nil {
+		return nil, err
+	}
+	toConfig, dst, err := m.open(toBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	lp := structs.ListFileParams{}
+	if params != nil {
+		lp = *params
+	}
+	lp.Bucket = fromConfig.Bucket
+	lp.Direction = ""
+	if lp.Limit <= 0 {
+		lp.Limit = m.opts.BatchSize
+	}
+	if lp.Limit <= 0 {
+		lp.Limit = migrateBatchSize
+	}
+	workers := utils.WorkerOptions{Parallelism: m.opts.Concurrency, ItemTimeout: m.opts.ItemTimeout}
+
+	report := &structs.MigrateReport{FromBucket: fromBucket, ToBucket: toBucket, Cursor: lp.Cursor}
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		rows, err := m.fileRepo.List(ctx, &lp)
+		if err != nil {
+			return report, fmt.Errorf("failed to list files: %w", err)
+		}
+
+		var mu sync.Mutex
+		err = utils.ForEach(ctx, rows, workers, func(ctx context.Context, row *ent.File) {
+			size, err := m.migrateFile(ctx, src, dst, toConfig, row)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Scanned++
+			if err != nil {
+				logger.Errorf(ctx, "Failed to migrate file %s to bucket %s: %v", row.ID, toBucket, err)
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", row.ID, err))
+				return
+			}
+			report.Migrated++
+			report.Bytes += size
+		})
+		if err != nil {
+			// The cursor of the last finished batch resumes the run
+			return report, err
+		}
+
+		if len(rows) > 0 {
+			last := rows[len(rows)-1]
+			lp.Cursor = page.EncodeCursor(page.Cursor{Key: last.CreatedAt, ID: last.ID})
+			report.Cursor = lp.Cursor
+		}
+		if m.opts.Progress != nil {
+			m.opts.Progress(report)
+		}
+
+		if len(rows) < lp.Limit {
+			report.Cursor = ""
+			return report, nil
+		}
+	}
+}
+
+// migrateFile copies the object and thumbnail of a file to dst, then records the new bucket.
+// It returns the number of bytes of the object.
+func (m *FileMigrator) migrateFile(ctx context.Context, src, dst oss.Interface, toConfig *oss.Config, row *ent.File) (int64, error) {
+	unlock := m.lock(row.Path)
+	defer unlock()
+
+	hash, size, err := copyVerified(ctx, src, dst, row.Path, row.Hash)
+	if err != nil {
+		return 0, err
+	}
+
+	thumb, _ := row.Extras["thumbnail_path"].(string)
+	if thumb != "" {
+		if _, _, err := copyVerified(ctx, src, dst, thumb, ""); err != nil {
+			return 0, fmt.Errorf("thumbnail %s: %w", thumb, err)
+		}
+	}
+
+	updates := types.JSON{
+		"storage":    toConfig.Provider,
+		"bucket":     toConfig.Bucket,
+		"endpoint":   toConfig.Endpoint,
+		"updated_at": row.UpdatedAt,
+	}
+	if row.Hash == "" {
+		updates["hash"] = hash
+	}
+	if _, err := m.fileRepo.Update(ctx, row.ID, updates); err != nil {
+		return 0, fmt.Errorf("failed to update record: %w", err)
+	}
+
+	if m.opts.DeleteSource {
+		m.deleteSource(ctx, src, row, row.Path)
+		if thumb != "" {
+			m.deleteSource(ctx, src, row, thumb)
+		}
+	}
+	return size, nil
+}
+
+// deleteSource removes a migrated object from the source bucket. Objects that files still
+// recorded in the source bucket may reference, by a path under the same prefix, are kept.
+func (m *FileMigrator) deleteSource(ctx context.Context, src oss.Interface, row *ent.File, path string) {
+	shared := m.fileRepo.CountX(ctx, &structs.ListFileParams{Bucket: row.Bucket, PathPrefix: path})
+	if shared > 0 {
+		logger.Infof(ctx, "Keeping %s in bucket %s, still referenced by %d files", path, row.Bucket, shared)
+		return
+	}
+	if err := src.Delete(path); err != nil {
+		logger.Warnf(ctx, "Failed to delete migrated object %s from bucket %s: %v", path, row.Bucket, err)
+	}
+}
+
+// lock serializes the migration of records sharing the object at path
+func (m *FileMigrator) lock(path string) func() {
+	v, _ := m.paths.LoadOrStore(path, &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// open connects the configured bucket named bucket
+func (m *FileMigrator) open(bucket string) (*oss.Config, oss.Interface, error) {
+	for _, cfg := range m.buckets {
+		if cfg == nil || cfg.Bucket != bucket {
+			continue
+		}
+		// Validation fills in defaults, keep the configured values untouched
+		conf := *cfg
+		client, err := m.connect(&conf)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect bucket %s: %w", bucket, err)
+		}
+		return cfg, client, nil
+	}
+	return nil, nil, fmt.Errorf("bucket %s is neither the current storage nor a legacy storage", bucket)
+}
+
+// copyVerified streams the object at path from src to dst and checks the checksum of the copy
+// against the source, and the source against expected when set. A copy failing either check is
+// deleted from dst. An object already in dst with the right checksum is not copied again, so a
+// resumed run skips finished work.
+func copyVerified(ctx context.Context, src, dst oss.Interface, path, expected string) (string, int64, error) {
+	if ok, err := dst.Exists(path); err == nil && ok {
+		if hash, size, err := objectHash(dst, path); err == nil {
+			if expected == "" {
+				if srcHash, _, err := objectHash(src, path); err == nil && srcHash == hash {
+					return hash, size, nil
+				}
+			} else if hash == expected {
+				return hash, size, nil
+			}
+		}
+	}
+
+	reader, err := src.GetStream(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read source: %w", err)
+	}
+	defer reader.Close()
+
+	// The source is hashed as the target consumes it, the object is never held in memory
+	h := sha256.New()
+	counted := &countingReader{r: io.TeeReader(reader, h)}
+	if _, err := dst.Put(path, counted); err != nil {
+		return "", 0, fmt.Errorf("failed to write target: %w", err)
+	}
+	hash := fmt.Sprintf("sha256:%x", h.Sum(nil))
+
+	if expected != "" && hash != expected {
+		discardCopy(ctx, dst, path)
+		return "", 0, fmt.Errorf("source checksum %s does not match recorded %s", hash, expected)
+	}
+
+	copied, _, err := objectHash(dst, path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to verify target: %w", err)
+	}
+	if copied != hash {
+		discardCopy(ctx, dst, path)
+		return "", 0, fmt.Errorf("target checksum %s does not match source %s", copied, hash)
+	}
+	return hash, counted.n, nil
+}
+
+// discardCopy deletes a copy that failed verification, the source is left as it is
+func discardCopy(ctx context.Context, dst oss.Interface, path string) {
+	if err := dst.Delete(path); err != nil {
+		logger.Warnf(ctx, "Failed to delete unverified copy of %s: %v", path, err)
+	}
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// objectHash returns the checksum and size of the object at path, as calculateFileHash formats it
+func objectHash(storage oss.Interface, path string) (string, int64, error) {
+	reader, err := storage.GetStream(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer reader.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, reader)
+	if err != nil {
+		return "", 0, err
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), size, nil
+}
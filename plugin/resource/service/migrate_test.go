@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCopyVerified(t *testing.T) {
+	ctx := context.Background()
+	content := strings.Repeat("object ", 10000)
+	src := newMemoryBucket(map[string]string{"a/b.txt": content})
+	dst := newMemoryBucket(nil)
+
+	expected := calculateFileHash([]byte(content))
+	hash, size, err := copyVerified(ctx, src, dst, "a/b.txt", expected)
+	if err != nil {
+		t.Fatalf("copyVerified: %v", err)
+	}
+	if hash != expected || size != int64(len(content)) {
+		t.Fatalf("copy = %s, %d bytes, want %s, %d bytes", hash, size, expected, len(content))
+	}
+	if string(dst.objects["a/b.txt"]) != content {
+		t.Fatal("target content differs from source")
+	}
+
+	// a resumed run finds the verified copy and does not write it again
+	if _, _, err := copyVerified(ctx, src, dst, "a/b.txt", expected); err != nil {
+		t.Fatalf("copyVerified again: %v", err)
+	}
+	if dst.puts != 1 {
+		t.Fatalf("object written %d times, want once", dst.puts)
+	}
+}
+
+func TestCopyVerifiedDeletesCorruptCopy(t *testing.T) {
+	src := newMemoryBucket(map[string]string{"a/b.txt": "content"})
+	dst := newMemoryBucket(nil)
+	dst.corrupt = true
+
+	if _, _, err := copyVerified(context.Background(), src, dst, "a/b.txt", ""); err == nil {
+		t.Fatal("corrupt copy passed verification")
+	}
+	if dst.has("a/b.txt") {
+		t.Fatal("corrupt copy left in the target bucket")
+	}
+	if !src.has("a/b.txt") {
+		t.Fatal("source deleted")
+	}
+}
+
+func TestCopyVerifiedRejectsChangedSource(t *testing.T) {
+	src := newMemoryBucket(map[string]string{"a/b.txt": "changed content"})
+	dst := newMemoryBucket(nil)
+
+	recorded := calculateFileHash([]byte("content"))
+	if _, _, err := copyVerified(context.Background(), src, dst, "a/b.txt", recorded); err == nil {
+		t.Fatal("source not matching the recorded checksum was copied")
+	}
+	if dst.has("a/b.txt") {
+		t.Fatal("copy of a changed source left in the target bucket")
+	}
+}
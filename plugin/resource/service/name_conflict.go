@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/types"
+	"github.com/ncobase/ncore/validation/validator"
+)
+
+// ErrFileNameConflict is returned when an upload is named like an existing file
+// of its owner and name conflicts are rejected
+var ErrFileNameConflict = errors.New("a file with this name already exists for the owner")
+
+// nameConflictMode returns how an upload named like an existing file of its owner is handled,
+// the mode of the upload first, then the configured one
+func (s *fileService) nameConflictMode(body *structs.CreateFileBody) structs.NameConflict {
+	if body.OnConflict.IsValid() {
+		return body.OnConflict
+	}
+	if s.conf != nil {
+		if mode := structs.NameConflict(s.conf.NameConflict); mode.IsValid() {
+			return mode
+		}
+	}
+	return structs.NameConflictRename
+}
+
+// findNameConflict returns the file of the owner named like the upload, nil when there is none
+func (s *fileService) findNameConflict(ctx context.Context, body *structs.CreateFileBody) (*ent.File, error) {
+	if body.OwnerID == "" || body.Name == "" {
+		return nil, nil
+	}
+
+	row, err := s.fileRepo.GetByName(ctx, body.OwnerID, body.Name)
+	if err != nil {
+		if repository.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return row, nil
+}
+
+// replaceContent stores an upload as the new content of an existing file. The record keeps
+// its ID, name and metadata; the previous object is released and the thumbnail rebuilt.
+func (s *fileService) replaceContent(ctx context.Context, existing *ent.File, body *structs.CreateFileBody, fileBytes []byte, sniffed sniffResult) (*structs.ReadFile, error) {
+	extras := types.JSON{}
+	if body.Extras != nil {
+		for k, v := range *body.Extras {
+			extras[k] = v
+		}
+	}
+	extras["hash"] = calculateFileHash(fileBytes)
+	extras["declared_type"] = sniffed.Declared
+	extras["detected_type"] = sniffed.Detected
+	extras["type_mismatch"] = sniffed.Mismatch
+
+	updates := types.JSON{
+		"file":   bytes.NewReader(fileBytes),
+		"type":   body.Type,
+		"extras": extras,
+	}
+	if body.OriginalName != "" {
+		updates["original_name"] = body.OriginalName
+	}
+
+	result, err := s.Update(ctx, existing.ID, updates)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof(ctx, "Replaced content of file %s with upload of the same name", existing.ID)
+
+	if s.imageProcessor == nil || !validator.IsImageFile(result.Path) {
+		return result, nil
+	}
+	withThumbnail, err := s.CreateThumbnail(ctx, existing.ID, body.ProcessingOptions)
+	if err != nil {
+		logger.Warnf(ctx, "Error creating thumbnail of replaced file %s: %v", existing.ID, err)
+		return result, nil
+	}
+	return withThumbnail, nil
+}
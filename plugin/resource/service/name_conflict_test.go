@@ -0,0 +1,165 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/ncobase/ncore/types"
+)
+
+// uniqueFiles keeps names unique per owner like the files table does. With stale set,
+// name lookups miss, as they do when another upload takes the name meanwhile.
+type uniqueFiles struct {
+	*memoryFiles
+	stale bool
+}
+
+func (f *uniqueFiles) GetByName(_ context.Context, ownerID, name string) (*ent.File, error) {
+	for _, row := range f.rows {
+		if !f.stale && row.OwnerID == ownerID && row.Name == name {
+			return row, nil
+		}
+	}
+	return nil, &ent.NotFoundError{}
+}
+
+func (f *uniqueFiles) Create(ctx context.Context, body *structs.CreateFileBody) (*ent.File, error) {
+	for _, row := range f.rows {
+		if row.OwnerID == body.OwnerID && row.Name == body.Name {
+			return nil, errors.New("UNIQUE constraint failed: files.owner_id, files.name")
+		}
+	}
+	return f.memoryFiles.Create(ctx, body)
+}
+
+// Update also stores the content fields of a content update
+func (f *uniqueFiles) Update(ctx context.Context, slug string, updates types.JSON) (*ent.File, error) {
+	if row, ok := f.rows[slug]; ok {
+		for key, value := range updates {
+			switch key {
+			case "path":
+				row.Path = value.(string)
+			case "type":
+				row.Type = value.(string)
+			case "original_name":
+				row.OriginalName = value.(string)
+			case "size":
+				row.Size = value.(int)
+			case "hash":
+				row.Hash = value.(string)
+			}
+		}
+	}
+	return f.memoryFiles.Update(ctx, slug, updates)
+}
+
+// newConflictService returns a file service handling name conflicts by mode, with the
+// file "report" of u1 stored at u1/report.txt
+func newConflictService(mode string) (*fileService, *uniqueFiles, *memoryBucket) {
+	files := &uniqueFiles{memoryFiles: newMemoryFiles(&ent.File{
+		ID: "f1", Name: "report", OriginalName: "report.txt", Path: "u1/report.txt", Type: "text/plain",
+		Size: 3, Storage: "memory", Bucket: "test", OwnerID: "u1", Tags: []string{"q3"},
+		Extras: types.JSON{"description": "quarterly", "hash": "old"},
+	})}
+	bucket := newMemoryBucket(map[string]string{"u1/report.txt": "old"})
+	return &fileService{fileRepo: files, conf: &config.Config{NameConflict: mode}}, files, bucket
+}
+
+func upload(s *fileService, bucket *memoryBucket, owner, name, content string, mode structs.NameConflict) (*structs.ReadFile, error) {
+	return s.Create(withBucket(bucket), &structs.CreateFileBody{
+		Name: name, OriginalName: name + ".txt", Path: name + ".txt", Type: "text/plain", OwnerID: owner,
+		OnConflict: mode, File: &readCloser{bytes.NewReader([]byte(content))},
+	})
+}
+
+func TestRejectNameConflict(t *testing.T) {
+	s, files, bucket := newConflictService("reject")
+
+	if _, err := upload(s, bucket, "u1", "report", "new", ""); !errors.Is(err, ErrFileNameConflict) {
+		t.Fatalf("upload of a taken name: %v, want ErrFileNameConflict", err)
+	}
+	if bucket.puts != 0 || len(files.rows) != 1 {
+		t.Fatalf("rejected upload stored %d objects, %d files", bucket.puts, len(files.rows))
+	}
+
+	// the name is free for other owners, and an upload may ask for a rename
+	if _, err := upload(s, bucket, "u2", "report", "theirs", ""); err != nil {
+		t.Fatalf("upload of another owner: %v", err)
+	}
+	renamed, err := upload(s, bucket, "u1", "report", "new", structs.NameConflictRename)
+	if err != nil {
+		t.Fatalf("upload asking for a rename: %v", err)
+	}
+	if renamed.Name == "report" || renamed.ID == "f1" {
+		t.Fatalf("renamed upload = %s named %q", renamed.ID, renamed.Name)
+	}
+}
+
+func TestRejectNameTakenConcurrently(t *testing.T) {
+	s, files, bucket := newConflictService("reject")
+	files.stale = true
+
+	if _, err := upload(s, bucket, "u1", "report", "new", ""); !errors.Is(err, ErrFileNameConflict) {
+		t.Fatalf("upload racing for a name: %v, want ErrFileNameConflict", err)
+	}
+	if len(bucket.objects) != 1 || len(files.rows) != 1 {
+		t.Fatalf("objects %d, files %d left after the conflict, want only the existing file", len(bucket.objects), len(files.rows))
+	}
+}
+
+func TestReplaceNameConflict(t *testing.T) {
+	s, files, bucket := newConflictService("replace")
+	ctx := withBucket(bucket)
+
+	replaced, err := upload(s, bucket, "u1", "report", "new content", "")
+	if err != nil {
+		t.Fatalf("upload replacing report: %v", err)
+	}
+	if replaced.ID != "f1" || len(files.rows) != 1 {
+		t.Fatalf("upload created %s, %d files, want f1 replaced", replaced.ID, len(files.rows))
+	}
+	if got := readObject(t, ctx, s, "f1"); got != "new content" {
+		t.Fatalf("content of f1 = %q, want the upload", got)
+	}
+	if _, ok := bucket.objects["u1/report.txt"]; ok {
+		t.Fatal("previous object kept")
+	}
+
+	row := files.rows["f1"]
+	if fmt.Sprint(row.Tags) != "[q3]" || row.Extras["description"] != "quarterly" {
+		t.Fatalf("replaced file tags %v, extras %v, want its metadata kept", row.Tags, row.Extras)
+	}
+	if row.Extras["hash"] != calculateFileHash([]byte("new content")) {
+		t.Fatalf("hash %v, want the hash of the upload", row.Extras["hash"])
+	}
+}
+
+func TestNameConflictMode(t *testing.T) {
+	for _, tt := range []struct {
+		conf   *config.Config
+		upload structs.NameConflict
+		want   structs.NameConflict
+	}{
+		{nil, "", structs.NameConflictRename},
+		{&config.Config{NameConflict: "overwrite"}, "", structs.NameConflictRename},
+		{&config.Config{NameConflict: "reject"}, "", structs.NameConflictReject},
+		{&config.Config{NameConflict: "reject"}, structs.NameConflictReplace, structs.NameConflictReplace},
+	} {
+		s := &fileService{conf: tt.conf}
+		if got := s.nameConflictMode(&structs.CreateFileBody{OnConflict: tt.upload}); got != tt.want {
+			t.Fatalf("mode with %+v and upload %q = %s, want %s", tt.conf, tt.upload, got, tt.want)
+		}
+	}
+
+	body := &structs.CreateFileBody{Name: "a", Path: "a.txt", File: &readCloser{bytes.NewReader(nil)}, OnConflict: "overwrite"}
+	if err := body.Validate(); err == nil {
+		t.Fatal("unknown on_conflict accepted")
+	}
+}
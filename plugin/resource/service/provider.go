@@ -1,6 +1,7 @@
 package service
 
 import (
+	"ncobase/plugin/resource/config"
 	"ncobase/plugin/resource/data"
 	"ncobase/plugin/resource/event"
 	"ncobase/plugin/resource/wrapper"
@@ -10,15 +11,18 @@ import (
 
 // Service contains all resource services
 type Service struct {
-	File  FileServiceInterface
-	Batch BatchServiceInterface
-	Quota QuotaServiceInterface
-	Admin AdminServiceInterface
-	Space *wrapper.SpaceServiceWrapper
+	File   FileServiceInterface
+	Batch  BatchServiceInterface
+	Quota  QuotaServiceInterface
+	Admin  AdminServiceInterface
+	Report ReportServiceInterface
+	Space  *wrapper.SpaceServiceWrapper
+	Access *AccessLog
+	Outbox *IndexOutbox
 }
 
 // New creates new resource service
-func New(em ext.ManagerInterface, d *data.Data, publisher event.PublisherInterface) *Service {
+func New(em ext.ManagerInterface, conf *config.Config, d *data.Data, publisher event.PublisherInterface) *Service {
 	// Create image processor
 	imageProcessor := NewImageProcessor()
 
@@ -26,29 +30,39 @@ func New(em ext.ManagerInterface, d *data.Data, publisher event.PublisherInterfa
 	quotaConfig := &QuotaConfig{
 		DefaultQuota:      10 * 1024 * 1024 * 1024, // 10GB default
 		WarningThreshold:  0.8,                     // 80% warning
+		WarningLevels:     []float64{0.9},          // 90% warning
 		EnableEnforcement: true,                    // Enforce quotas
 		CheckInterval:     24 * 60 * 60,            // 24 hours in seconds
 	}
 	quotaService := NewQuotaService(d, publisher, quotaConfig)
 
+	// Create space service wrapper
+	spaceWrapper := wrapper.NewSpaceServiceWrapper(em)
+
+	// Create access log for recently accessed files
+	accessLog := NewAccessLog(d)
+
 	// Create file service
-	fileService := NewFileService(d, imageProcessor, quotaService, publisher)
+	fileService := NewFileService(d, imageProcessor, quotaService, publisher, NewURLSigner(conf.SigningSecret), spaceWrapper, conf, accessLog)
 
 	// Create batch service
-	batchService := NewBatchService(fileService, imageProcessor, publisher)
+	batchService := NewBatchService(fileService, imageProcessor, publisher, BatchWorkers(conf.Batch))
 
 	// Create admin service
 	adminService := NewAdminService(d, quotaService)
 
-	// Create space service wrapper
-	spaceWrapper := wrapper.NewSpaceServiceWrapper(em)
+	// Create storage report service
+	reportService := NewReportService(d, quotaService, conf.Reports)
 
 	return &Service{
-		File:  fileService,
-		Batch: batchService,
-		Quota: quotaService,
-		Admin: adminService,
-		Space: spaceWrapper,
+		File:   fileService,
+		Batch:  batchService,
+		Quota:  quotaService,
+		Admin:  adminService,
+		Report: reportService,
+		Space:  spaceWrapper,
+		Access: accessLog,
+		Outbox: NewIndexOutbox(d, conf.SearchOutbox),
 	}
 }
 
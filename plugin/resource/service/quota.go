@@ -22,6 +22,10 @@ type QuotaServiceInterface interface {
 	IsQuotaExceeded(ctx context.Context, ownerID string) (bool, error)
 	MonitorQuota(ctx context.Context) error
 	UpdateUsage(ctx context.Context, ownerID string, quotaType string, delta int64) error
+	CheckScopedQuota(ctx context.Context, ownerID, scopeKey string, size int) (bool, error)
+	SetScopedQuota(ctx context.Context, ownerID, scopeKey string, quota int64) error
+	GetScopedQuota(ctx context.Context, ownerID, scopeKey string) (int64, error)
+	GetScopedUsage(ctx context.Context, ownerID, scopeKey string) (int64, error)
 	RefreshSpaceServices()
 }
 
@@ -29,6 +33,7 @@ type QuotaServiceInterface interface {
 type QuotaConfig struct {
 	DefaultQuota      int64         `json:"default_quota"`
 	WarningThreshold  float64       `json:"warning_threshold"`
+	WarningLevels     []float64     `json:"warning_levels"` // extra soft limits above WarningThreshold, e.g. 0.9
 	CheckInterval     time.Duration `json:"check_interval"`
 	EnableEnforcement bool          `json:"enable_enforcement"`
 }
@@ -49,6 +54,7 @@ func NewQuotaService(d *data.Data, publisher event.PublisherInterface, config *Q
 		config = &QuotaConfig{
 			DefaultQuota:      10 * 1024 * 1024 * 1024, // 10GB default
 			WarningThreshold:  0.8,                     // 80% warning
+			WarningLevels:     []float64{0.9},          // 90% warning
 			CheckInterval:     24 * time.Hour,          // Daily check
 			EnableEnforcement: true,                    // Enforce quotas
 		}
@@ -107,13 +113,14 @@ func (s *quotaService) CheckAndUpdateQuota(ctx context.Context, ownerID string,
 		s.redis.Set(ctx, key, newUsage, 0)
 	}
 
-	// Check warning threshold
-	if float64(newUsage)/float64(quota) >= s.config.WarningThreshold && s.publisher != nil {
+	// Warn once when the upload crosses a soft limit, not on every upload above it
+	if threshold, crossed := s.crossedWarningLevel(currentUsage, newUsage, quota); crossed && s.publisher != nil {
 		eventData := &event.StorageQuotaEventData{
 			SpaceID:      ownerID,
 			CurrentUsage: newUsage,
 			Quota:        quota,
 			UsagePercent: float64(newUsage) / float64(quota) * 100,
+			Threshold:    threshold * 100,
 			StorageType:  "file",
 		}
 		s.publisher.PublishStorageQuotaWarning(ctx, eventData)
@@ -122,6 +129,29 @@ func (s *quotaService) CheckAndUpdateQuota(ctx context.Context, ownerID string,
 	return true, nil
 }
 
+// crossedWarningLevel returns the highest warning level that usage moved
+// past going from prev to next, levels are fractions of quota
+func (s *quotaService) crossedWarningLevel(prev, next, quota int64) (float64, bool) {
+	if quota <= 0 || next <= prev {
+		return 0, false
+	}
+
+	levels := append([]float64{s.config.WarningThreshold}, s.config.WarningLevels...)
+	prevRatio := float64(prev) / float64(quota)
+	nextRatio := float64(next) / float64(quota)
+
+	crossed, found := 0.0, false
+	for _, level := range levels {
+		if level <= 0 {
+			continue
+		}
+		if prevRatio < level && nextRatio >= level && level > crossed {
+			crossed, found = level, true
+		}
+	}
+	return crossed, found
+}
+
 // GetUsage returns current storage usage
 func (s *quotaService) GetUsage(ctx context.Context, ownerID string) (int64, error) {
 	if ownerID == "" {
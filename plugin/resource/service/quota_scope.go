@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ncobase/plugin/resource/structs"
+	"strings"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// ScopedQuotaError reports an exhausted folder or category sub-limit
+type ScopedQuotaError struct {
+	Scope string
+	Usage int64
+	Quota int64
+}
+
+func (e *ScopedQuotaError) Error() string {
+	return fmt.Sprintf("storage quota exceeded for %s", e.Scope)
+}
+
+// CheckScopedQuota checks an upload of size against the owner's sub-limit for scopeKey,
+// scopes without a configured limit always pass
+func (s *quotaService) CheckScopedQuota(ctx context.Context, ownerID, scopeKey string, size int) (bool, error) {
+	if ownerID == "" || scopeKey == "" {
+		return true, nil
+	}
+
+	quota, err := s.GetScopedQuota(ctx, ownerID, scopeKey)
+	if err != nil {
+		logger.Errorf(ctx, "Error getting %s quota for owner %s: %v", scopeKey, ownerID, err)
+		return true, nil // Allow if can't get quota
+	}
+	if quota <= 0 {
+		return true, nil
+	}
+
+	usage, err := s.GetScopedUsage(ctx, ownerID, scopeKey)
+	if err != nil {
+		logger.Errorf(ctx, "Error getting %s usage for owner %s: %v", scopeKey, ownerID, err)
+		return true, nil // Allow if can't get usage
+	}
+
+	if s.config.EnableEnforcement && usage+int64(size) > quota {
+		return false, &ScopedQuotaError{Scope: scopeKey, Usage: usage, Quota: quota}
+	}
+
+	return true, nil
+}
+
+// SetScopedQuota sets an owner's sub-limit for scopeKey, a quota of zero or less removes it
+func (s *quotaService) SetScopedQuota(ctx context.Context, ownerID, scopeKey string, quota int64) error {
+	if ownerID == "" {
+		return fmt.Errorf("owner ID is required")
+	}
+	if !isQuotaScope(scopeKey) {
+		return fmt.Errorf("invalid quota scope %q", scopeKey)
+	}
+
+	cacheKey := scopedQuotaKey(ownerID, scopeKey)
+	if quota < 0 {
+		quota = 0
+	}
+
+	s.mu.Lock()
+	s.quotaCache[cacheKey] = quota
+	s.mu.Unlock()
+
+	if s.redis != nil {
+		var err error
+		if quota == 0 {
+			err = s.redis.Del(ctx, cacheKey).Err()
+		} else {
+			err = s.redis.Set(ctx, cacheKey, quota, 0).Err()
+		}
+		if err != nil {
+			logger.Errorf(ctx, "Error setting %s quota in Redis for owner %s: %v", scopeKey, ownerID, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetScopedQuota returns an owner's sub-limit for scopeKey, zero when none is configured
+func (s *quotaService) GetScopedQuota(ctx context.Context, ownerID, scopeKey string) (int64, error) {
+	cacheKey := scopedQuotaKey(ownerID, scopeKey)
+
+	s.mu.RLock()
+	if quota, found := s.quotaCache[cacheKey]; found {
+		s.mu.RUnlock()
+		return quota, nil
+	}
+	s.mu.RUnlock()
+
+	var quota int64
+	if s.redis != nil {
+		val, err := s.redis.Get(ctx, cacheKey).Int64()
+		if err != nil && err != redis.Nil {
+			return 0, err
+		}
+		quota = val
+	}
+
+	s.mu.Lock()
+	s.quotaCache[cacheKey] = quota
+	s.mu.Unlock()
+
+	return quota, nil
+}
+
+// GetScopedUsage returns the storage an owner uses within scopeKey
+func (s *quotaService) GetScopedUsage(ctx context.Context, ownerID, scopeKey string) (int64, error) {
+	switch {
+	case strings.HasPrefix(scopeKey, structs.QuotaScopeCategory):
+		return s.fileRepo.SumSizeByScope(ctx, ownerID, strings.TrimPrefix(scopeKey, structs.QuotaScopeCategory), "")
+	case strings.HasPrefix(scopeKey, structs.QuotaScopeFolder):
+		return s.fileRepo.SumSizeByScope(ctx, ownerID, "", strings.TrimPrefix(scopeKey, structs.QuotaScopeFolder))
+	default:
+		return 0, fmt.Errorf("invalid quota scope %q", scopeKey)
+	}
+}
+
+// scopedQuotaKey returns the cache and Redis key of a sub-limit
+func scopedQuotaKey(ownerID, scopeKey string) string {
+	return fmt.Sprintf("resource_storage:quota:%s:%s", ownerID, scopeKey)
+}
+
+// isQuotaScope reports whether scopeKey names a category or folder
+func isQuotaScope(scopeKey string) bool {
+	for _, prefix := range []string{structs.QuotaScopeCategory, structs.QuotaScopeFolder} {
+		if strings.HasPrefix(scopeKey, prefix) && len(scopeKey) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
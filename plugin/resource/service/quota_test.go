@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ncobase/plugin/resource/event"
+)
+
+// quotaEvents records the quota events published
+type quotaEvents struct {
+	event.PublisherInterface
+	warnings []*event.StorageQuotaEventData
+	exceeded []*event.StorageQuotaEventData
+}
+
+func (p *quotaEvents) PublishStorageQuotaWarning(_ context.Context, data *event.StorageQuotaEventData) {
+	p.warnings = append(p.warnings, data)
+}
+
+func (p *quotaEvents) PublishStorageQuotaExceeded(_ context.Context, data *event.StorageQuotaEventData) {
+	p.exceeded = append(p.exceeded, data)
+}
+
+// newTestQuota returns a quota service limiting every owner to 100 bytes, warning at 80% and 90%
+func newTestQuota() (*quotaService, *quotaEvents) {
+	events := &quotaEvents{}
+	return &quotaService{
+		config:     &QuotaConfig{DefaultQuota: 100, WarningThreshold: 0.8, WarningLevels: []float64{0.9}, EnableEnforcement: true},
+		publisher:  events,
+		quotaCache: map[string]int64{},
+		usageCache: map[string]int64{"o1": 0},
+	}, events
+}
+
+func TestQuotaWarnsOncePerCrossing(t *testing.T) {
+	s, events := newTestQuota()
+	ctx := context.Background()
+
+	for _, size := range []int{50, 20, 5, 5, 3, 2, 1} {
+		ok, err := s.CheckAndUpdateQuota(ctx, "o1", size)
+		if !ok || err != nil {
+			t.Fatalf("upload of %d bytes = %v, %v, want it allowed", size, ok, err)
+		}
+	}
+	var thresholds []float64
+	for _, w := range events.warnings {
+		thresholds = append(thresholds, w.Threshold)
+	}
+	// usage 50, 70, 75, 80 (80%), 83, 85, 86: one warning at 80%, none above it
+	if fmt.Sprint(thresholds) != "[80]" {
+		t.Fatalf("warnings at %v, want one at 80%%", thresholds)
+	}
+
+	if ok, _ := s.CheckAndUpdateQuota(ctx, "o1", 5); !ok {
+		t.Fatal("upload to 91% blocked")
+	}
+	last := events.warnings[len(events.warnings)-1]
+	if len(events.warnings) != 2 || last.Threshold != 90 || last.CurrentUsage != 91 || last.Quota != 100 {
+		t.Fatalf("warnings = %d, last %+v, want a second one at 90%% with usage 91", len(events.warnings), *last)
+	}
+}
+
+func TestQuotaJumpPastBothLevelsWarnsOnce(t *testing.T) {
+	s, events := newTestQuota()
+
+	if ok, _ := s.CheckAndUpdateQuota(context.Background(), "o1", 95); !ok {
+		t.Fatal("upload blocked")
+	}
+	if len(events.warnings) != 1 || events.warnings[0].Threshold != 90 {
+		t.Fatalf("warnings = %d, want one for the highest level crossed", len(events.warnings))
+	}
+}
+
+func TestQuotaBlocksAtHardLimit(t *testing.T) {
+	s, events := newTestQuota()
+	ctx := context.Background()
+
+	if ok, _ := s.CheckAndUpdateQuota(ctx, "o1", 70); !ok {
+		t.Fatal("upload below the limit blocked")
+	}
+	ok, err := s.CheckAndUpdateQuota(ctx, "o1", 31)
+	if ok || err == nil {
+		t.Fatal("upload past the hard limit allowed")
+	}
+	if len(events.exceeded) != 1 || len(events.warnings) != 0 {
+		t.Fatalf("%d exceeded and %d warning events, want one exceeded only", len(events.exceeded), len(events.warnings))
+	}
+	if usage, _ := s.GetUsage(ctx, "o1"); usage != 70 {
+		t.Fatalf("usage = %d after a blocked upload, want 70", usage)
+	}
+}
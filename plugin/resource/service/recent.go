@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/event"
+	"ncobase/plugin/resource/structs"
+	"time"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/ecode"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/types"
+)
+
+// ListRecentlyAccessed returns the files userID accessed most recently within spaceID,
+// skipping files that were deleted or are no longer readable
+func (s *fileService) ListRecentlyAccessed(ctx context.Context, spaceID, userID string, limit int) ([]*structs.ReadFile, error) {
+	if userID == "" {
+		return nil, errors.New(ecode.FieldIsRequired("user_id"))
+	}
+
+	ids, err := s.access.Recent(ctx, spaceID, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*structs.ReadFile, 0, len(ids))
+	var stale []string
+	for _, id := range ids {
+		row, err := s.fileRepo.GetByID(ctx, id)
+		if err != nil {
+			if repository.IsNotFound(err) {
+				stale = append(stale, id)
+			}
+			continue
+		}
+		if s.authorizeRead(ctx, row) != nil {
+			continue
+		}
+		files = append(files, repository.SerializeFile(row))
+	}
+
+	if len(stale) > 0 {
+		if err := s.access.Forget(ctx, spaceID, userID, stale...); err != nil {
+			logger.Warnf(ctx, "Failed to drop deleted files from recent list: %v", err)
+		}
+	}
+
+	return files, nil
+}
+
+// publishAccessed publishes a file access by the requesting user. Accesses to public
+// files may be sampled out, they are then only added to the user's recent files.
+func (s *fileService) publishAccessed(ctx context.Context, row *ent.File) {
+	userID := ctxutil.GetUserID(ctx)
+	if s.publisher == nil || userID == "" {
+		return
+	}
+
+	spaceID := ctxutil.GetSpaceID(ctx)
+	publish, rate := s.sampler.Sample(ctx, spaceID, row)
+	if !publish {
+		if err := s.access.RecordAccess(ctx, spaceID, userID, row.ID, time.Now()); err != nil {
+			logger.Warnf(ctx, "Failed to record access to %s: %v", row.ID, err)
+		}
+		return
+	}
+
+	var extras *types.JSON
+	if rate > 1 {
+		// Each published access stands for rate accesses
+		extras = &types.JSON{"sample_rate": rate}
+	}
+
+	s.publisher.PublishFileAccessed(ctx, event.NewFileEventData(
+		row.ID, row.Name, row.Path, row.Type, row.Size,
+		row.Storage, row.Bucket, row.OwnerID, spaceID, userID,
+		extras,
+	))
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ncobase/plugin/resource/data/ent"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/redis/go-redis/v9"
+)
+
+// sortedSets answers the sorted set commands of the access log from memory, in place of a Redis server
+type sortedSets struct {
+	mu   sync.Mutex
+	sets map[string]map[string]float64
+}
+
+func (s *sortedSets) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (s *sortedSets) ProcessHook(_ redis.ProcessHook) redis.ProcessHook {
+	return func(_ context.Context, cmd redis.Cmder) error {
+		return s.process(cmd)
+	}
+}
+
+func (s *sortedSets) ProcessPipelineHook(_ redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(_ context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := s.process(cmd); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// members returns the members of key, highest score first
+func (s *sortedSets) members(key string) []string {
+	set := s.sets[key]
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool { return set[members[i]] > set[members[j]] })
+	return members
+}
+
+// rank resolves a Redis range index against n members
+func rank(index any, n int) int {
+	i := int(index.(int64))
+	if i < 0 {
+		i += n
+	}
+	return i
+}
+
+func (s *sortedSets) process(cmd redis.Cmder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	args := cmd.Args()
+	switch strings.ToLower(cmd.Name()) {
+	case "multi", "exec":
+	case "zadd":
+		key := args[1].(string)
+		if s.sets[key] == nil {
+			s.sets[key] = map[string]float64{}
+		}
+		s.sets[key][fmt.Sprint(args[3])] = args[2].(float64)
+	case "zrem":
+		for _, member := range args[2:] {
+			delete(s.sets[args[1].(string)], fmt.Sprint(member))
+		}
+	case "zremrangebyrank":
+		// ranks count from the lowest score, members lists the highest first
+		key := args[1].(string)
+		members := s.members(key)
+		n := len(members)
+		for i := rank(args[2], n); i <= rank(args[3], n) && i < n; i++ {
+			if i >= 0 {
+				delete(s.sets[key], members[n-1-i])
+			}
+		}
+	case "zrevrange":
+		members := s.members(args[1].(string))
+		start, stop := rank(args[2], len(members)), rank(args[3], len(members))
+		if stop >= len(members) {
+			stop = len(members) - 1
+		}
+		if start > stop {
+			members = nil
+		} else {
+			members = members[start : stop+1]
+		}
+		cmd.(*redis.StringSliceCmd).SetVal(members)
+	case "expire":
+	default:
+		return fmt.Errorf("unexpected command %s", cmd.Name())
+	}
+	return nil
+}
+
+func newTestAccessLog() *AccessLog {
+	client := redis.NewClient(&redis.Options{Addr: "memory:6379"})
+	client.AddHook(&sortedSets{sets: map[string]map[string]float64{}})
+	return &AccessLog{redis: client}
+}
+
+func TestAccessLogOrdersAndDedupes(t *testing.T) {
+	ctx := context.Background()
+	log := newTestAccessLog()
+	start := time.Now()
+
+	for i, id := range []string{"a", "b", "c", "a", "d", "b"} {
+		if err := log.RecordAccess(ctx, "s1", "u1", id, start.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatalf("RecordAccess(%s): %v", id, err)
+		}
+	}
+	if err := log.RecordAccess(ctx, "s1", "u2", "e", start); err != nil {
+		t.Fatalf("RecordAccess(e): %v", err)
+	}
+
+	recent, err := log.Recent(ctx, "s1", "u1", 10)
+	if err != nil {
+		t.Fatalf("Recent: %v", err)
+	}
+	if want := []string{"b", "d", "a", "c"}; !reflect.DeepEqual(recent, want) {
+		t.Fatalf("recent = %v, want %v", recent, want)
+	}
+	if recent, _ := log.Recent(ctx, "s1", "u1", 2); !reflect.DeepEqual(recent, []string{"b", "d"}) {
+		t.Fatalf("recent with limit 2 = %v, want [b d]", recent)
+	}
+	if recent, _ := log.Recent(ctx, "s2", "u1", 10); len(recent) != 0 {
+		t.Fatalf("recent in another space = %v, want none", recent)
+	}
+}
+
+func TestAccessLogCapsRetention(t *testing.T) {
+	ctx := context.Background()
+	log := newTestAccessLog()
+	start := time.Now()
+
+	for i := 0; i < recentAccessLimit+20; i++ {
+		if err := log.RecordAccess(ctx, "s1", "u1", fmt.Sprintf("f%03d", i), start.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatalf("RecordAccess: %v", err)
+		}
+	}
+
+	recent, err := log.Recent(ctx, "s1", "u1", 0)
+	if err != nil {
+		t.Fatalf("Recent: %v", err)
+	}
+	if len(recent) != recentAccessLimit {
+		t.Fatalf("%d entries kept, want %d", len(recent), recentAccessLimit)
+	}
+	if newest, oldest := recent[0], recent[len(recent)-1]; newest != fmt.Sprintf("f%03d", recentAccessLimit+19) || oldest != "f020" {
+		t.Fatalf("kept %s..%s, want the newest %d", newest, oldest, recentAccessLimit)
+	}
+}
+
+func TestListRecentlyAccessedSkipsDeletedAndForbidden(t *testing.T) {
+	log := newTestAccessLog()
+	s := &fileService{
+		fileRepo: newMemoryFiles(
+			&ent.File{ID: "a", OwnerID: "u1"},
+			&ent.File{ID: "b", OwnerID: "u2"},
+			&ent.File{ID: "c", OwnerID: "u2", IsPublic: true},
+		),
+		access: log,
+	}
+	ctx := ctxutil.SetUserID(ctxutil.SetSpaceID(context.Background(), "s1"), "u1")
+	start := time.Now()
+	for i, id := range []string{"a", "gone", "b", "c"} {
+		if err := log.RecordAccess(ctx, "s1", "u1", id, start.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatalf("RecordAccess(%s): %v", id, err)
+		}
+	}
+
+	files, err := s.ListRecentlyAccessed(ctx, "s1", "u1", 10)
+	if err != nil {
+		t.Fatalf("ListRecentlyAccessed: %v", err)
+	}
+	var ids []string
+	for _, file := range files {
+		ids = append(ids, file.ID)
+	}
+	if want := []string{"c", "a"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("files = %v, want %v", ids, want)
+	}
+
+	// the deleted file is dropped from the list, the forbidden one is only hidden
+	recent, _ := log.Recent(ctx, "s1", "u1", 10)
+	if want := []string{"c", "b", "a"}; !reflect.DeepEqual(recent, want) {
+		t.Fatalf("recent after listing = %v, want %v", recent, want)
+	}
+
+	if _, err := s.ListRecentlyAccessed(ctx, "s1", "", 10); err == nil {
+		t.Fatal("listed without a user")
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+	"strings"
+	"time"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/oss"
+)
+
+// DefaultReconcileGracePeriod protects in-progress uploads from reconciliation
+const DefaultReconcileGracePeriod = time.Hour
+
+// reconcilePageSize is the number of file records loaded per query
+const reconcilePageSize = 500
+
+// Reconciler cross-checks storage objects against file records
+type Reconciler struct {
+	fileRepo repository.FileRepositoryInterface
+	storage  oss.Interface
+}
+
+// NewReconciler creates a new reconciler
+func NewReconciler(d *data.Data, storage oss.Interface) *Reconciler {
+	return &Reconciler{
+		fileRepo: repository.NewFileRepository(d),
+		storage:  storage,
+	}
+}
+
+// Reconcile reports objects under opts.Prefix without a record and records
+// whose object is missing, removing both unless opts.DryRun is set.
+func (r *Reconciler) Reconcile(ctx context.Context, opts *structs.ReconcileOptions) (*structs.ReconcileReport, error) {
+	if opts.Prefix == "" {
+		return nil, fmt.Errorf("prefix is required")
+	}
+	grace := opts.GracePeriod
+	if grace <= 0 {
+		grace = DefaultReconcileGracePeriod
+	}
+	cutoff := time.Now().Add(-grace)
+
+	report := &structs.ReconcileReport{Prefix: opts.Prefix, DryRun: opts.DryRun}
+
+	// Collect every storage path referenced by a record. Thumbnail templates may place a thumbnail
+	// under the prefix while its file lives elsewhere, so records are matched on both paths.
+	known := make(map[string]struct{})
+	afterID := ""
+	for {
+		rows, err := r.fileRepo.FindByObjectPrefix(ctx, opts.Prefix, afterID, reconcilePageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query files: %w", err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			if thumb, ok := row.Extras["thumbnail_path"].(string); ok && thumb != "" {
+				known[thumb] = struct{}{}
+			}
+			// Only records under the prefix are checked for a missing object
+			if !strings.HasPrefix(row.Path, opts.Prefix) {
+				continue
+			}
+			report.RecordsScanned++
+			known[row.Path] = struct{}{}
+
+			exists, err := r.storage.Exists(row.Path)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to check %s: %v", row.Path, err))
+				continue
+			}
+			if exists {
+				continue
+			}
+			if time.UnixMilli(row.CreatedAt).After(cutoff) {
+				report.Skipped = append(report.Skipped, row.ID)
+				continue
+			}
+
+			report.DanglingRecords = append(report.DanglingRecords, row.ID)
+			if !opts.DryRun {
+				if err := r.fileRepo.Delete(ctx, row.ID); err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("failed to delete record %s: %v", row.ID, err))
+					continue
+				}
+				report.Cleaned++
+			}
+		}
+
+		afterID = rows[len(rows)-1].ID
+	}
+
+	// Walk storage for objects nobody references
+	objects, err := r.storage.List(opts.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage: %w", err)
+	}
+
+	for _, object := range objects {
+		report.ObjectsScanned++
+		if _, ok := known[object.Path]; ok {
+			continue
+		}
+		// Objects without a modification time can't be aged, leave them alone
+		if object.LastModified == nil || object.LastModified.After(cutoff) {
+			report.Skipped = append(report.Skipped, object.Path)
+			continue
+		}
+
+		report.OrphanedObjects = append(report.OrphanedObjects, object.Path)
+		if !opts.DryRun {
+			if err := r.storage.Delete(object.Path); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to delete object %s: %v", object.Path, err))
+				continue
+			}
+			report.Cleaned++
+		}
+	}
+
+	logger.Infof(ctx, "Storage reconcile of %s: %d orphaned objects, %d dangling records, %d cleaned (dry run: %v)",
+		opts.Prefix, len(report.OrphanedObjects), len(report.DanglingRecords), report.Cleaned, opts.DryRun)
+
+	return report, nil
+}
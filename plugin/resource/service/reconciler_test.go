@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+)
+
+// newReconcileFixture seeds tenant t1 with a healthy file, an orphan object and a dangling record,
+// each old enough to reconcile, plus a fresh orphan object and a fresh dangling record
+func newReconcileFixture() (*Reconciler, *memoryBucket, *memoryFiles) {
+	old := time.Now().Add(-2 * time.Hour)
+	bucket := newMemoryBucket(map[string]string{
+		"t1/ok.txt":        "ok",
+		"t1/ok_thumb.jpg":  "thumb",
+		"t1/orphan.txt":    "orphan",
+		"t1/uploading.txt": "uploading",
+		"t2/orphan.txt":    "other tenant",
+	})
+	bucket.modified = map[string]time.Time{
+		"t1/ok.txt":        old,
+		"t1/ok_thumb.jpg":  old,
+		"t1/orphan.txt":    old,
+		"t1/uploading.txt": time.Now(),
+		"t2/orphan.txt":    old,
+	}
+	files := newMemoryFiles(
+		&ent.File{ID: "f1", Path: "t1/ok.txt", CreatedAt: old.UnixMilli(), Extras: map[string]any{"thumbnail_path": "t1/ok_thumb.jpg"}},
+		&ent.File{ID: "f2", Path: "t1/gone.txt", CreatedAt: old.UnixMilli()},
+		&ent.File{ID: "f3", Path: "t1/pending.txt", CreatedAt: time.Now().UnixMilli()},
+	)
+	return &Reconciler{fileRepo: files, storage: bucket}, bucket, files
+}
+
+func TestReconcileDryRunReports(t *testing.T) {
+	r, bucket, files := newReconcileFixture()
+
+	report, err := r.Reconcile(context.Background(), &structs.ReconcileOptions{Prefix: "t1/", DryRun: true})
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if fmt.Sprint(report.OrphanedObjects) != "[t1/orphan.txt]" {
+		t.Errorf("orphaned objects = %v, want [t1/orphan.txt]", report.OrphanedObjects)
+	}
+	if fmt.Sprint(report.DanglingRecords) != "[f2]" {
+		t.Errorf("dangling records = %v, want [f2]", report.DanglingRecords)
+	}
+	if len(report.Skipped) != 2 {
+		t.Errorf("skipped = %v, want the fresh upload and the fresh record", report.Skipped)
+	}
+	if report.RecordsScanned != 3 || report.ObjectsScanned != 4 || report.Cleaned != 0 {
+		t.Errorf("scanned %d records, %d objects, cleaned %d", report.RecordsScanned, report.ObjectsScanned, report.Cleaned)
+	}
+	if !bucket.has("t1/orphan.txt") || files.rows["f2"] == nil {
+		t.Fatal("dry run removed data")
+	}
+}
+
+func TestReconcileCleans(t *testing.T) {
+	r, bucket, files := newReconcileFixture()
+
+	report, err := r.Reconcile(context.Background(), &structs.ReconcileOptions{Prefix: "t1/"})
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if report.Cleaned != 2 {
+		t.Fatalf("cleaned %d, want 2", report.Cleaned)
+	}
+	if bucket.has("t1/orphan.txt") || files.rows["f2"] != nil {
+		t.Fatal("orphan object or dangling record left")
+	}
+	for _, path := range []string{"t1/ok.txt", "t1/ok_thumb.jpg", "t1/uploading.txt", "t2/orphan.txt"} {
+		if !bucket.has(path) {
+			t.Errorf("%s deleted", path)
+		}
+	}
+	if files.rows["f1"] == nil || files.rows["f3"] == nil {
+		t.Fatal("live or pending record deleted")
+	}
+}
+
+func TestReconcileKeepsTemplatedThumbnails(t *testing.T) {
+	old := time.Now().Add(-2 * time.Hour)
+	// thumbnails rendered from "thumbs/{space}/{name}_{size}.jpg" live outside the prefix of their file
+	bucket := newMemoryBucket(map[string]string{
+		"t1/photo.png":                "photo",
+		"thumbs/s1/photo_64x48.jpg":   "thumb",
+		"thumbs/s1/deleted_64x48.jpg": "stray",
+	})
+	bucket.modified = map[string]time.Time{
+		"t1/photo.png":                old,
+		"thumbs/s1/photo_64x48.jpg":   old,
+		"thumbs/s1/deleted_64x48.jpg": old,
+	}
+	files := newMemoryFiles(
+		&ent.File{ID: "f1", Path: "t1/photo.png", CreatedAt: old.UnixMilli(), Extras: map[string]any{"thumbnail_path": "thumbs/s1/photo_64x48.jpg"}},
+	)
+	r := &Reconciler{fileRepo: files, storage: bucket}
+
+	report, err := r.Reconcile(context.Background(), &structs.ReconcileOptions{Prefix: "thumbs/"})
+	if err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+	if fmt.Sprint(report.OrphanedObjects) != "[thumbs/s1/deleted_64x48.jpg]" {
+		t.Fatalf("orphaned objects = %v, want only the thumbnail without a file", report.OrphanedObjects)
+	}
+	if !bucket.has("thumbs/s1/photo_64x48.jpg") || bucket.has("thumbs/s1/deleted_64x48.jpg") {
+		t.Fatal("live thumbnail deleted or stray thumbnail kept")
+	}
+	// the file itself lies outside the prefix, it is neither scanned nor removed
+	if report.RecordsScanned != 0 || len(report.DanglingRecords) != 0 || files.rows["f1"] == nil {
+		t.Fatalf("scanned %d records, dangling %v", report.RecordsScanned, report.DanglingRecords)
+	}
+}
+
+func TestReconcileRequiresPrefix(t *testing.T) {
+	r, _, _ := newReconcileFixture()
+	if _, err := r.Reconcile(context.Background(), &structs.ReconcileOptions{}); err == nil {
+		t.Fatal("reconcile of the whole bucket accepted")
+	}
+}
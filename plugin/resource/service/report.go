@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/data"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// storageReportKey holds the daily snapshots of an owner, one hash field per date
+const storageReportKey = "ncse_res:storage_report:%s"
+
+// Storage report defaults
+const (
+	defaultReportRange      = 30 // days shown when no range is given
+	defaultReportRetention  = 400
+	defaultReportTopFolders = 10
+	reportScanBatch         = 1000
+)
+
+// ReportServiceInterface defines storage report methods
+type ReportServiceInterface interface {
+	Snapshot(ctx context.Context, ownerID string) (*structs.StorageSnapshotResult, error)
+	StorageReport(ctx context.Context, params *structs.StorageReportParams) (*structs.StorageReport, error)
+}
+
+// reportService summarizes the files of each owner once a day and keeps the
+// summaries in Redis, so storage history does not require scanning old data.
+type reportService struct {
+	fileRepo repository.FileRepositoryInterface
+	quota    QuotaServiceInterface
+	redis    *redis.Client
+	conf     *config.ReportConfig
+}
+
+// NewReportService creates a new storage report service, quota may be nil when only snapshots are taken
+func NewReportService(d *data.Data, quota QuotaServiceInterface, conf *config.ReportConfig) ReportServiceInterface {
+	if conf == nil {
+		conf = &config.ReportConfig{}
+	}
+	rc, _ := d.GetRedis().(*redis.Client)
+	return &reportService{
+		fileRepo: repository.NewFileRepository(d),
+		quota:    quota,
+		redis:    rc,
+		conf:     conf,
+	}
+}
+
+// Snapshot stores today's storage summary of an owner, or of every owner when ownerID is empty.
+// Taking a snapshot again on the same day replaces it.
+func (s *reportService) Snapshot(ctx context.Context, ownerID string) (*structs.StorageSnapshotResult, error) {
+	if s.redis == nil {
+		return nil, errors.New("redis is required for storage reports")
+	}
+
+	owners := []string{ownerID}
+	if ownerID == "" {
+		var err error
+		owners, err = s.fileRepo.GetAllOwners(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list owners: %w", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	result := &structs.StorageSnapshotResult{Date: now.Format(structs.StorageReportDateLayout)}
+	for _, owner := range owners {
+		if owner == "" {
+			continue
+		}
+
+		snapshot, err := s.summarize(ctx, owner, now)
+		if err == nil {
+			err = s.store(ctx, snapshot)
+		}
+		if err != nil {
+			logger.Errorf(ctx, "Failed to snapshot storage of owner %s: %v", owner, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", owner, err))
+			continue
+		}
+		result.Owners++
+	}
+
+	return result, nil
+}
+
+// StorageReport returns the daily snapshots of an owner within a date range,
+// each with its change since the previous snapshot of the range
+func (s *reportService) StorageReport(ctx context.Context, params *structs.StorageReportParams) (*structs.StorageReport, error) {
+	if params == nil {
+		params = &structs.StorageReportParams{}
+	}
+	if s.redis == nil {
+		return nil, errors.New("redis is required for storage reports")
+	}
+
+	ownerID := params.OwnerID
+	if ownerID == "" {
+		ownerID = ctxutil.GetSpaceID(ctx)
+	}
+	if ownerID == "" {
+		return nil, errors.New("owner ID is required")
+	}
+
+	from, to, err := reportRange(params.From, params.To)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &structs.StorageReport{
+		OwnerID: ownerID,
+		From:    from,
+		To:      to,
+		Series:  make([]*structs.StorageSnapshot, 0),
+	}
+
+	values, err := s.redis.HGetAll(ctx, fmt.Sprintf(storageReportKey, ownerID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load storage snapshots: %w", err)
+	}
+	for date, raw := range values {
+		// Dates sort lexically, so the range is a string comparison
+		if date < from || date > to {
+			continue
+		}
+		var snapshot structs.StorageSnapshot
+		if err := json.Unmarshal([]byte(raw), &snapshot); err != nil {
+			logger.Warnf(ctx, "Invalid storage snapshot %s of owner %s: %v", date, ownerID, err)
+			continue
+		}
+		report.Series = append(report.Series, &snapshot)
+	}
+	sort.Slice(report.Series, func(i, j int) bool { return report.Series[i].Date < report.Series[j].Date })
+	for i := 1; i < len(report.Series); i++ {
+		prev, cur := report.Series[i-1], report.Series[i]
+		cur.SizeDelta = cur.TotalSize - prev.TotalSize
+		cur.CountDelta = cur.TotalCount - prev.TotalCount
+	}
+
+	if s.quota != nil {
+		if usage, err := s.quota.GetUsage(ctx, ownerID); err != nil {
+			logger.Warnf(ctx, "Error getting usage for owner %s: %v", ownerID, err)
+		} else {
+			report.CurrentSize = usage
+		}
+	}
+
+	return report, nil
+}
+
+// summarize totals the files of an owner by category and folder
+func (s *reportService) summarize(ctx context.Context, ownerID string, at time.Time) (*structs.StorageSnapshot, error) {
+	snapshot := &structs.StorageSnapshot{
+		OwnerID:    ownerID,
+		Date:       at.Format(structs.StorageReportDateLayout),
+		ByCategory: make(map[string]int),
+		CreatedAt:  at.UnixMilli(),
+	}
+	folders := make(map[string]*structs.FolderUsage)
+
+	afterID := ""
+	for {
+		files, err := s.fileRepo.FindByOwnerAfter(ctx, ownerID, afterID, reportScanBatch)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			snapshot.TotalSize += int64(file.Size)
+			snapshot.TotalCount++
+
+			category := file.Category
+			if category == "" {
+				category = string(structs.FileCategoryOther)
+			}
+			snapshot.ByCategory[category]++
+
+			folder := path.Dir("/" + file.Path)
+			usage, ok := folders[folder]
+			if !ok {
+				usage = &structs.FolderUsage{Path: folder}
+				folders[folder] = usage
+			}
+			usage.Size += int64(file.Size)
+			usage.Count++
+		}
+
+		if len(files) < reportScanBatch {
+			break
+		}
+		afterID = files[len(files)-1].ID
+	}
+
+	snapshot.TopFolders = topFolders(folders, s.topFolderLimit())
+	return snapshot, nil
+}
+
+// store saves a snapshot and drops the snapshots of the owner past retention
+func (s *reportService) store(ctx context.Context, snapshot *structs.StorageSnapshot) error {
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf(storageReportKey, snapshot.OwnerID)
+	if err := s.redis.HSet(ctx, key, snapshot.Date, raw).Err(); err != nil {
+		return err
+	}
+
+	dates, err := s.redis.HKeys(ctx, key).Result()
+	if err != nil {
+		logger.Warnf(ctx, "Failed to list storage snapshots of owner %s: %v", snapshot.OwnerID, err)
+		return nil
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -s.retentionDays()).Format(structs.StorageReportDateLayout)
+	var expired []string
+	for _, date := range dates {
+		if date < cutoff {
+			expired = append(expired, date)
+		}
+	}
+	if len(expired) > 0 {
+		if err := s.redis.HDel(ctx, key, expired...).Err(); err != nil {
+			logger.Warnf(ctx, "Failed to prune storage snapshots of owner %s: %v", snapshot.OwnerID, err)
+		}
+	}
+
+	return nil
+}
+
+// retentionDays returns how many days of snapshots are kept
+func (s *reportService) retentionDays() int {
+	if s.conf.RetentionDays > 0 {
+		return s.conf.RetentionDays
+	}
+	return defaultReportRetention
+}
+
+// topFolderLimit returns how many folders a snapshot keeps
+func (s *reportService) topFolderLimit() int {
+	if s.conf.TopFolders > 0 {
+		return s.conf.TopFolders
+	}
+	return defaultReportTopFolders
+}
+
+// topFolders returns the largest folders, biggest first
+func topFolders(folders map[string]*structs.FolderUsage, limit int) []structs.FolderUsage {
+	list := make([]structs.FolderUsage, 0, len(folders))
+	for _, usage := range folders {
+		list = append(list, *usage)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Size != list[j].Size {
+			return list[i].Size > list[j].Size
+		}
+		return list[i].Path < list[j].Path
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// reportRange validates a report date range, filling in the defaults
+func reportRange(from, to string) (string, string, error) {
+	end := time.Now().UTC()
+	if to != "" {
+		t, err := time.Parse(structs.StorageReportDateLayout, to)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid to date %q, expected YYYY-MM-DD", to)
+		}
+		end = t
+	}
+
+	start := end.AddDate(0, 0, -defaultReportRange)
+	if from != "" {
+		t, err := time.Parse(structs.StorageReportDateLayout, from)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid from date %q, expected YYYY-MM-DD", from)
+		}
+		start = t
+	}
+
+	if start.After(end) {
+		return "", "", errors.New("from date must not be after to date")
+	}
+
+	return start.Format(structs.StorageReportDateLayout), end.Format(structs.StorageReportDateLayout), nil
+}
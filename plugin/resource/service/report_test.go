@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// hashes answers the hash commands of the storage report from memory, in place of a Redis server
+type hashes struct {
+	mu     sync.Mutex
+	fields map[string]map[string]string
+}
+
+func (h *hashes) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *hashes) ProcessHook(_ redis.ProcessHook) redis.ProcessHook {
+	return func(_ context.Context, cmd redis.Cmder) error {
+		return h.process(cmd)
+	}
+}
+
+func (h *hashes) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func (h *hashes) process(cmd redis.Cmder) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	args := cmd.Args()
+	key := fmt.Sprint(args[1])
+	switch strings.ToLower(cmd.Name()) {
+	case "hset":
+		if h.fields[key] == nil {
+			h.fields[key] = map[string]string{}
+		}
+		for i := 2; i+1 < len(args); i += 2 {
+			value, ok := args[i+1].([]byte)
+			if !ok {
+				value = []byte(fmt.Sprint(args[i+1]))
+			}
+			h.fields[key][fmt.Sprint(args[i])] = string(value)
+		}
+	case "hkeys":
+		var fields []string
+		for field := range h.fields[key] {
+			fields = append(fields, field)
+		}
+		cmd.(*redis.StringSliceCmd).SetVal(fields)
+	case "hdel":
+		for _, field := range args[2:] {
+			delete(h.fields[key], fmt.Sprint(field))
+		}
+	case "hgetall":
+		values := map[string]string{}
+		for field, value := range h.fields[key] {
+			values[field] = value
+		}
+		cmd.(*redis.MapStringStringCmd).SetVal(values)
+	default:
+		return fmt.Errorf("unexpected command %s", cmd.Name())
+	}
+	return nil
+}
+
+func newTestReports(files *memoryFiles) (*reportService, *hashes) {
+	h := &hashes{fields: map[string]map[string]string{}}
+	client := redis.NewClient(&redis.Options{Addr: "memory:6379"})
+	client.AddHook(h)
+	return &reportService{fileRepo: files, redis: client, conf: &config.ReportConfig{}}, h
+}
+
+func TestStorageReportSeriesAndDeltas(t *testing.T) {
+	ctx := context.Background()
+	files := newMemoryFiles(
+		&ent.File{ID: "f1", OwnerID: "s1", Path: "docs/a.pdf", Size: 100, Category: "document"},
+		&ent.File{ID: "f2", OwnerID: "s1", Path: "img/b.png", Size: 300, Category: "image"},
+		&ent.File{ID: "f9", OwnerID: "s2", Path: "docs/other.pdf", Size: 5000, Category: "document"},
+	)
+	s, _ := newTestReports(files)
+
+	// yesterday's snapshot, then today's after more uploads
+	now := time.Now().UTC()
+	yesterday, err := s.summarize(ctx, "s1", now.AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatalf("summarize: %v", err)
+	}
+	if err := s.store(ctx, yesterday); err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	files.rows["f3"] = &ent.File{ID: "f3", OwnerID: "s1", Path: "img/c.png", Size: 250, Category: "image"}
+	files.rows["f4"] = &ent.File{ID: "f4", OwnerID: "s1", Path: "img/d.png", Size: 50}
+	if result, err := s.Snapshot(ctx, "s1"); err != nil || result.Owners != 1 {
+		t.Fatalf("Snapshot = %+v, %v", result, err)
+	}
+
+	report, err := s.StorageReport(ctx, &structs.StorageReportParams{OwnerID: "s1"})
+	if err != nil {
+		t.Fatalf("StorageReport: %v", err)
+	}
+	if len(report.Series) != 2 {
+		t.Fatalf("%d snapshots in the series, want 2", len(report.Series))
+	}
+	first, second := report.Series[0], report.Series[1]
+	if first.Date != yesterday.Date || first.TotalSize != 400 || first.TotalCount != 2 || first.SizeDelta != 0 {
+		t.Fatalf("first snapshot %+v", first)
+	}
+	if second.TotalSize != 700 || second.SizeDelta != 300 || second.CountDelta != 2 {
+		t.Fatalf("second snapshot %+v, want 700 bytes, +300 and +2 files", second)
+	}
+	if second.ByCategory["image"] != 2 || second.ByCategory["other"] != 1 {
+		t.Fatalf("categories %v", second.ByCategory)
+	}
+	if top := second.TopFolders[0]; top.Path != "/img" || top.Size != 600 || top.Count != 3 {
+		t.Fatalf("top folder %+v, want /img", top)
+	}
+
+	// the range excludes yesterday
+	report, err = s.StorageReport(ctx, &structs.StorageReportParams{OwnerID: "s1", From: second.Date})
+	if err != nil || len(report.Series) != 1 || report.Series[0].SizeDelta != 0 {
+		t.Fatalf("report from today = %+v, %v, want today only", report, err)
+	}
+}
+
+func TestStorageReportPrunesAndValidates(t *testing.T) {
+	ctx := context.Background()
+	s, h := newTestReports(newMemoryFiles(&ent.File{ID: "f1", OwnerID: "s1", Path: "a.txt", Size: 1}))
+	s.conf.RetentionDays = 7
+
+	old, _ := s.summarize(ctx, "s1", time.Now().UTC().AddDate(0, 0, -30))
+	if err := s.store(ctx, old); err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	if _, err := s.Snapshot(ctx, "s1"); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if _, kept := h.fields[fmt.Sprintf(storageReportKey, "s1")][old.Date]; kept {
+		t.Fatal("snapshot past retention kept")
+	}
+
+	for _, params := range []*structs.StorageReportParams{
+		{OwnerID: "s1", From: "yesterday"},
+		{OwnerID: "s1", From: "2024-02-01", To: "2024-01-01"},
+		{},
+	} {
+		if _, err := s.StorageReport(ctx, params); err == nil {
+			t.Errorf("report %+v accepted", params)
+		}
+	}
+}
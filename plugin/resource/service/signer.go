@@ -0,0 +1,107 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrSignatureInvalid is returned for malformed or tampered share tokens
+	ErrSignatureInvalid = errors.New("invalid share signature")
+	// ErrSignatureExpired is returned when a share token is past its expiry
+	ErrSignatureExpired = errors.New("share link has expired")
+	// ErrSignatureScope is returned when a share token is used outside its scope
+	ErrSignatureScope = errors.New("share link is not valid for this client")
+)
+
+// SignedToken holds the verified claims of a share token
+type SignedToken struct {
+	FileID    string
+	ExpiresAt int64
+	Scope     string
+}
+
+// URLSigner issues and verifies HMAC signed share tokens.
+// A token is base64url(file_id|expires_at|scope) "." base64url(hmac_sha256).
+type URLSigner struct {
+	secret []byte
+}
+
+// NewURLSigner creates a new signer.
+// An empty secret falls back to a random per-process key, so links
+// stop working after a restart until a secret is configured.
+func NewURLSigner(secret string) *URLSigner {
+	if secret == "" {
+		key := make([]byte, 32)
+		_, _ = rand.Read(key)
+		return &URLSigner{secret: key}
+	}
+	return &URLSigner{secret: []byte(secret)}
+}
+
+// Sign returns a token for fileID valid until expiresAt (unix millis).
+// scope optionally binds the token to a client IP, use "" for any client.
+func (s *URLSigner) Sign(fileID string, expiresAt int64, scope string) string {
+	payload := strings.Join([]string{fileID, strconv.FormatInt(expiresAt, 10), scope}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(s.mac(payload))
+}
+
+// Verify checks the signature, expiry and scope of token
+func (s *URLSigner) Verify(token, clientIP string) (*SignedToken, error) {
+	encPayload, encSig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrSignatureInvalid
+	}
+	rawPayload, err := base64.RawURLEncoding.DecodeString(encPayload)
+	if err != nil {
+		return nil, ErrSignatureInvalid
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encSig)
+	if err != nil {
+		return nil, ErrSignatureInvalid
+	}
+
+	payload := string(rawPayload)
+	if !hmac.Equal(sig, s.mac(payload)) {
+		return nil, ErrSignatureInvalid
+	}
+
+	parts := strings.Split(payload, "|")
+	if len(parts) != 3 || parts[0] == "" {
+		return nil, ErrSignatureInvalid
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrSignatureInvalid
+	}
+
+	claims := &SignedToken{FileID: parts[0], ExpiresAt: expiresAt, Scope: parts[2]}
+	if time.Now().UnixMilli() > claims.ExpiresAt {
+		return nil, ErrSignatureExpired
+	}
+	if claims.Scope != "" && claims.Scope != fmt.Sprintf("ip:%s", clientIP) {
+		return nil, ErrSignatureScope
+	}
+
+	return claims, nil
+}
+
+// mac computes the HMAC of payload
+func (s *URLSigner) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
+
+// IsSignatureError reports whether err is a share token verification failure
+func IsSignatureError(err error) bool {
+	return errors.Is(err, ErrSignatureInvalid) || errors.Is(err, ErrSignatureExpired) || errors.Is(err, ErrSignatureScope)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignerAcceptsValidToken(t *testing.T) {
+	s := NewURLSigner("secret")
+	expiresAt := time.Now().Add(time.Hour).UnixMilli()
+
+	claims, err := s.Verify(s.Sign("f1", expiresAt, ""), "10.0.0.1")
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims.FileID != "f1" || claims.ExpiresAt != expiresAt {
+		t.Fatalf("claims = %+v, want f1 until %d", *claims, expiresAt)
+	}
+}
+
+func TestSignerRejectsExpiredToken(t *testing.T) {
+	s := NewURLSigner("secret")
+	token := s.Sign("f1", time.Now().Add(-time.Second).UnixMilli(), "")
+
+	if _, err := s.Verify(token, ""); !errors.Is(err, ErrSignatureExpired) {
+		t.Fatalf("err = %v, want ErrSignatureExpired", err)
+	}
+}
+
+func TestSignerRejectsTamperedToken(t *testing.T) {
+	s := NewURLSigner("secret")
+	expiresAt := time.Now().Add(time.Hour).UnixMilli()
+	token := s.Sign("f1", expiresAt, "")
+	payload, sig, _ := strings.Cut(token, ".")
+
+	// flip one bit of the signature
+	raw, _ := base64.RawURLEncoding.DecodeString(sig)
+	raw[0] ^= 1
+	mutatedSig := payload + "." + base64.RawURLEncoding.EncodeToString(raw)
+
+	// point the signed payload at another file
+	mutatedPayload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("f2|%d|", expiresAt))) + "." + sig
+
+	for name, token := range map[string]string{
+		"mutated signature": mutatedSig,
+		"mutated payload":   mutatedPayload,
+		"other secret":      NewURLSigner("other").Sign("f1", expiresAt, ""),
+		"no signature":      payload,
+		"garbage":           "not-a-token",
+	} {
+		if _, err := s.Verify(token, ""); !errors.Is(err, ErrSignatureInvalid) {
+			t.Errorf("%s: err = %v, want ErrSignatureInvalid", name, err)
+		}
+	}
+}
+
+func TestSignerBindsScope(t *testing.T) {
+	s := NewURLSigner("secret")
+	token := s.Sign("f1", time.Now().Add(time.Hour).UnixMilli(), "ip:10.0.0.1")
+
+	if _, err := s.Verify(token, "10.0.0.1"); err != nil {
+		t.Fatalf("Verify from the bound IP: %v", err)
+	}
+	if _, err := s.Verify(token, "10.0.0.2"); !errors.Is(err, ErrSignatureScope) {
+		t.Fatalf("err = %v, want ErrSignatureScope", err)
+	}
+}
+
+func TestIsSignatureError(t *testing.T) {
+	if !IsSignatureError(ErrSignatureExpired) || IsSignatureError(errors.New("storage down")) {
+		t.Fatal("IsSignatureError misclassifies errors")
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ErrContentTypeMismatch is returned in strict mode when a file's content
+// does not match the type implied by its extension
+var ErrContentTypeMismatch = errors.New("file content does not match its extension")
+
+// sniffableTypes are the extension types http.DetectContentType recognizes
+// reliably, only these are checked for a mismatch
+var sniffableTypes = map[string]bool{
+	"image/png":       true,
+	"image/jpeg":      true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"image/bmp":       true,
+	"application/pdf": true,
+}
+
+// sniffResult is the outcome of checking uploaded bytes against their declared type
+type sniffResult struct {
+	Declared string // type sent by the client
+	Detected string // type sniffed from the content
+	Type     string // type to store
+	Mismatch bool   // content contradicts the extension
+}
+
+// sniffContentType detects the type of data and compares it with the declared
+// type and the type implied by ext
+func sniffContentType(data []byte, declared, ext string) sniffResult {
+	result := sniffResult{
+		Declared: declared,
+		Detected: baseMediaType(http.DetectContentType(data)),
+		Type:     declared,
+	}
+
+	expected := baseMediaType(mime.TypeByExtension(strings.ToLower(ext)))
+	if sniffableTypes[expected] && result.Detected != expected {
+		result.Mismatch = true
+	}
+
+	// Trust the content over the client when it was identified precisely
+	switch {
+	case declared == "" || baseMediaType(declared) == "application/octet-stream":
+		result.Type = result.Detected
+	case sniffableTypes[result.Detected] && baseMediaType(declared) != result.Detected:
+		result.Type = result.Detected
+	}
+
+	return result
+}
+
+// baseMediaType strips parameters such as charset from a media type
+func baseMediaType(t string) string {
+	if i := strings.IndexByte(t, ';'); i >= 0 {
+		t = t[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(t))
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/structs"
+)
+
+// pngHeader is the signature of a PNG image
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// exeHeader is the start of a Windows executable
+var exeHeader = []byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00\x00\x00\xff\xff\x00\x00")
+
+func TestSniffContentType(t *testing.T) {
+	for _, tc := range []struct {
+		name, declared, ext string
+		data                []byte
+		wantType            string
+		mismatch            bool
+	}{
+		{"png", "image/png", ".png", pngHeader, "image/png", false},
+		{"png sent as jpeg", "image/jpeg", ".png", pngHeader, "image/png", false},
+		{"png without type", "", ".png", pngHeader, "image/png", false},
+		{"executable named png", "image/png", ".png", exeHeader, "image/png", true},
+		{"text named pdf", "application/pdf", ".pdf", []byte("hello"), "application/pdf", true},
+		{"text", "text/plain", ".txt", []byte("hello"), "text/plain", false},
+		{"csv as octet stream", "application/octet-stream", ".csv", []byte("a,b\n1,2\n"), "text/plain", false},
+	} {
+		got := sniffContentType(tc.data, tc.declared, tc.ext)
+		if got.Type != tc.wantType || got.Mismatch != tc.mismatch || got.Declared != tc.declared {
+			t.Errorf("%s: %+v, want type %s, mismatch %v", tc.name, got, tc.wantType, tc.mismatch)
+		}
+	}
+}
+
+func uploadImage(s *fileService, content []byte) (*structs.ReadFile, error) {
+	return s.Create(withBucket(newMemoryBucket(nil)), &structs.CreateFileBody{
+		Name: "photo", Path: "photo.png", Type: "image/png", OwnerID: "u1",
+		File: &readCloser{bytes.NewReader(content)},
+	})
+}
+
+func TestCreateRecordsSniffedType(t *testing.T) {
+	s := &fileService{fileRepo: newMemoryFiles()}
+
+	file, err := uploadImage(s, pngHeader)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	extras := *file.Extras
+	if file.Type != "image/png" || extras["detected_type"] != "image/png" || extras["type_mismatch"] != nil {
+		t.Fatalf("image stored as %s with extras %v", file.Type, extras)
+	}
+
+	spoofed, err := uploadImage(s, exeHeader)
+	if err != nil {
+		t.Fatalf("Create spoofed file without strict types: %v", err)
+	}
+	extras = *spoofed.Extras
+	if extras["declared_type"] != "image/png" || extras["detected_type"] != "application/octet-stream" || extras["type_mismatch"] != true {
+		t.Fatalf("spoofed file extras = %v, want the mismatch flagged", extras)
+	}
+}
+
+func TestCreateRejectsMismatchInStrictMode(t *testing.T) {
+	files := newMemoryFiles()
+	bucket := newMemoryBucket(nil)
+	s := &fileService{fileRepo: files, conf: &config.Config{StrictTypes: true}}
+
+	_, err := s.Create(withBucket(bucket), &structs.CreateFileBody{
+		Name: "photo", Path: "photo.png", Type: "image/png", OwnerID: "u1",
+		File: &readCloser{bytes.NewReader(exeHeader)},
+	})
+	if !errors.Is(err, ErrContentTypeMismatch) {
+		t.Fatalf("err = %v, want ErrContentTypeMismatch", err)
+	}
+	if len(files.rows) != 0 || len(bucket.objects) != 0 {
+		t.Fatal("rejected upload was stored")
+	}
+
+	if _, err := uploadImage(s, pngHeader); err != nil {
+		t.Fatalf("matching image rejected: %v", err)
+	}
+}
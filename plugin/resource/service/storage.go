@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"ncobase/internal/tracing"
+	"sync"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/oss"
+)
+
+// ErrObjectNotFound is returned when a file object is missing from every bucket it may be in
+var ErrObjectNotFound = errors.New("file object not found in any storage bucket")
+
+// storageBucket is a bucket an object may be read from
+type storageBucket struct {
+	key    string
+	client oss.Interface
+}
+
+// StorageFallback reads file objects across a bucket migration. Writes always go to the
+// current bucket; reads try the bucket recorded on the file, then the current bucket,
+// then the configured legacy buckets in order.
+type StorageFallback struct {
+	legacy  []*oss.Config
+	connect func(cfg *oss.Config) (oss.Interface, error)
+
+	mu      sync.Mutex
+	clients map[string]oss.Interface // legacy bucket clients by bucketKey
+}
+
+// NewStorageFallback creates a storage fallback over the given legacy buckets
+func NewStorageFallback(legacy []*oss.Config) *StorageFallback {
+	return &StorageFallback{
+		legacy:  legacy,
+		connect: oss.NewStorage,
+		clients: make(map[string]oss.Interface),
+	}
+}
+
+// OpenStream opens the object at path of a file recorded in provider and bucket.
+// Without legacy buckets the current storage is read directly, as before a migration.
+func (f *StorageFallback) OpenStream(ctx context.Context, current oss.Interface, currentConfig *oss.Config, provider, bucket, path string) (io.ReadCloser, error) {
+	if f == nil || len(f.legacy) == 0 {
+		return current.GetStream(path)
+	}
+
+	recorded := bucketKey(provider, bucket)
+	var lastErr error
+	for _, b := range f.candidates(ctx, current, currentConfig, recorded) {
+		exists, err := b.client.Exists(path)
+		if err != nil {
+			logger.Warnf(ctx, "Error checking object %s in bucket %s: %v", path, b.key, err)
+			lastErr = err
+			continue
+		}
+		if !exists {
+			continue
+		}
+		if b.key != recorded {
+			logger.Infof(ctx, "Object %s missing from recorded bucket %s, reading it from %s", path, recorded, b.key)
+		}
+		return b.client.GetStream(path)
+	}
+
+	if lastErr != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrObjectNotFound, path, lastErr)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, path)
+}
+
+// candidates returns the buckets to read from in order, each once. A recorded bucket
+// that is neither current nor configured as legacy cannot be read and is skipped.
+func (f *StorageFallback) candidates(ctx context.Context, current oss.Interface, currentConfig *oss.Config, recorded string) []storageBucket {
+	currentKey := ""
+	if currentConfig != nil {
+		currentKey = bucketKey(currentConfig.Provider, currentConfig.Bucket)
+	}
+	// Files recorded without a bucket predate bucket tracking and live in the current one
+	if recorded == bucketKey("", "") {
+		recorded = currentKey
+	}
+
+	var buckets []storageBucket
+	seen := make(map[string]bool)
+	add := func(key string, client oss.Interface) {
+		if client == nil || seen[key] {
+			return
+		}
+		seen[key] = true
+		buckets = append(buckets, storageBucket{key: key, client: client})
+	}
+
+	if recorded != currentKey {
+		for _, cfg := range f.legacy {
+			if bucketKey(cfg.Provider, cfg.Bucket) == recorded {
+				add(recorded, f.client(ctx, cfg))
+				break
+			}
+		}
+	}
+	add(currentKey, current)
+	for _, cfg := range f.legacy {
+		key := bucketKey(cfg.Provider, cfg.Bucket)
+		if !seen[key] {
+			add(key, f.client(ctx, cfg))
+		}
+	}
+	return buckets
+}
+
+// client returns the client of a legacy bucket, connecting on first use
+func (f *StorageFallback) client(ctx context.Context, cfg *oss.Config) oss.Interface {
+	key := bucketKey(cfg.Provider, cfg.Bucket)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c, ok := f.clients[key]; ok {
+		return tracing.Storage(ctx, c)
+	}
+
+	// Validation fills in defaults, keep the configured values untouched
+	conf := *cfg
+	c, err := f.connect(&conf)
+	if err != nil {
+		// Not cached, the bucket may be reachable on a later read
+		logger.Errorf(ctx, "Failed to connect legacy storage bucket %s: %v", key, err)
+		return nil
+	}
+	f.clients[key] = c
+	return tracing.Storage(ctx, c)
+}
+
+// bucketKey identifies a bucket of a storage provider
+func bucketKey(provider, bucket string) string {
+	if provider == "local" {
+		provider = "filesystem"
+	}
+	return provider + "/" + bucket
+}
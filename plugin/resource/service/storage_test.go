@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/ncobase/ncore/oss"
+)
+
+// newFallbackFixture serves the current bucket memory/test and the legacy buckets
+// memory/old and memory/older, counting the legacy connections by bucket
+func newFallbackFixture(rows ...*ent.File) (*fileService, map[string]*memoryBucket, map[string]int) {
+	buckets := map[string]*memoryBucket{
+		"test":  newMemoryBucket(nil),
+		"old":   newMemoryBucket(nil),
+		"older": newMemoryBucket(nil),
+	}
+	connects := make(map[string]int)
+	fallback := NewStorageFallback([]*oss.Config{
+		{Provider: "memory", Bucket: "old"},
+		{Provider: "memory", Bucket: "older"},
+	})
+	fallback.connect = func(cfg *oss.Config) (oss.Interface, error) {
+		connects[cfg.Bucket]++
+		return buckets[cfg.Bucket], nil
+	}
+	for _, row := range rows {
+		row.AccessLevel = string(structs.AccessLevelPublic)
+	}
+	return &fileService{fileRepo: newMemoryFiles(rows...), storages: fallback}, buckets, connects
+}
+
+// readFile reads slug from the current bucket of the fixture
+func readFile(s *fileService, current *memoryBucket, slug string) (string, error) {
+	rc, _, err := s.GetFileStream(withBucket(current), slug)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	content, err := io.ReadAll(rc)
+	return string(content), err
+}
+
+func TestReadFallsBackToLegacyBuckets(t *testing.T) {
+	s, buckets, connects := newFallbackFixture(
+		&ent.File{ID: "recorded", Path: "a.txt", Storage: "memory", Bucket: "old"},
+		&ent.File{ID: "moved", Path: "b.txt", Storage: "memory", Bucket: "old"},
+		&ent.File{ID: "older", Path: "c.txt", Storage: "memory", Bucket: "test"},
+		&ent.File{ID: "untracked", Path: "d.txt"},
+	)
+	buckets["old"].objects["a.txt"] = []byte("old a")
+	buckets["test"].objects["a.txt"] = []byte("current a")
+	buckets["test"].objects["b.txt"] = []byte("current b")
+	buckets["older"].objects["c.txt"] = []byte("older c")
+	buckets["test"].objects["d.txt"] = []byte("current d")
+
+	for slug, want := range map[string]string{
+		// the recorded bucket wins over the current one
+		"recorded": "old a",
+		// an object copied away from its recorded bucket is read from the current one
+		"moved": "current b",
+		// an object missing from the current bucket is read from a legacy one
+		"older": "older c",
+		// files recorded without a bucket live in the current one
+		"untracked": "current d",
+	} {
+		got, err := readFile(s, buckets["test"], slug)
+		if err != nil || got != want {
+			t.Fatalf("read %s = %q, %v, want %q", slug, got, err, want)
+		}
+	}
+
+	// legacy buckets are connected once, on first use
+	if connects["old"] != 1 || connects["older"] != 1 {
+		t.Fatalf("legacy connections %v, want one per bucket", connects)
+	}
+}
+
+func TestReadFailsWhenObjectIsMissingEverywhere(t *testing.T) {
+	s, buckets, _ := newFallbackFixture(&ent.File{ID: "lost", Path: "lost.txt", Storage: "memory", Bucket: "old"})
+
+	if _, err := readFile(s, buckets["test"], "lost"); err == nil {
+		t.Fatal("read of a missing object succeeded")
+	}
+
+	current := &oss.Config{Provider: "memory", Bucket: "test"}
+	_, err := s.storages.OpenStream(context.Background(), buckets["test"], current, "memory", "old", "lost.txt")
+	if !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("open of a missing object: %v, want ErrObjectNotFound", err)
+	}
+}
+
+func TestReadWithoutLegacyBucketsUsesCurrentStorage(t *testing.T) {
+	current := newMemoryBucket(map[string]string{"a.txt": "current a"})
+	fallback := NewStorageFallback(nil)
+	fallback.connect = func(cfg *oss.Config) (oss.Interface, error) {
+		t.Fatalf("connected bucket %s without legacy buckets", cfg.Bucket)
+		return nil, nil
+	}
+
+	rc, err := fallback.OpenStream(context.Background(), current, nil, "memory", "old", "a.txt")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer rc.Close()
+	if content, _ := io.ReadAll(rc); string(content) != "current a" {
+		t.Fatalf("read %q from the current storage", content)
+	}
+}
+
+func TestBucketKeyAliasesLocalProvider(t *testing.T) {
+	if bucketKey("local", "b") != bucketKey("filesystem", "b") {
+		t.Fatal("local and filesystem providers are different buckets")
+	}
+	if bucketKey("memory", "b") == bucketKey("memory", "c") {
+		t.Fatal("buckets of one provider share a key")
+	}
+}
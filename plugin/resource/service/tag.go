@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ncobase/internal/tracing"
+	"ncobase/plugin/resource/structs"
+	"sort"
+	"strings"
+
+	"github.com/ncobase/ncore/ecode"
+)
+
+// tagBatchSize is the number of files rewritten per transaction
+const tagBatchSize = 200
+
+// ListTags returns the distinct tags of an owner with their file counts,
+// most used first
+func (s *fileService) ListTags(ctx context.Context, ownerID string) ([]*structs.TagCount, error) {
+	if ownerID == "" {
+		return nil, errors.New(ecode.FieldIsRequired("owner_id"))
+	}
+
+	counts, err := s.fileRepo.CountTagsByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*structs.TagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, &structs.TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+
+	return tags, nil
+}
+
+// RenameTag renames a tag on every file of an owner. Files that already
+// carry the new tag end up with it once, so renaming also merges tags.
+// It returns the number of files changed.
+func (s *fileService) RenameTag(ctx context.Context, ownerID, oldTag, newTag string) (int, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.RenameTag")
+	defer span.End()
+
+	oldTag, newTag = strings.TrimSpace(oldTag), strings.TrimSpace(newTag)
+	if newTag == "" {
+		return 0, errors.New(ecode.FieldIsRequired("name"))
+	}
+	if oldTag == newTag {
+		return 0, nil
+	}
+
+	return s.rewriteTag(ctx, ownerID, oldTag, func(tags []string) []string {
+		return replaceTag(tags, oldTag, newTag)
+	})
+}
+
+// DeleteTag removes a tag from every file of an owner.
+// It returns the number of files changed.
+func (s *fileService) DeleteTag(ctx context.Context, ownerID, tag string) (int, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.DeleteTag")
+	defer span.End()
+
+	tag = strings.TrimSpace(tag)
+	return s.rewriteTag(ctx, ownerID, tag, func(tags []string) []string {
+		return replaceTag(tags, tag, "")
+	})
+}
+
+// rewriteTag applies rewrite to the tags of every owner file carrying tag,
+// one transaction per batch so a failure only loses the current batch
+func (s *fileService) rewriteTag(ctx context.Context, ownerID, tag string, rewrite func([]string) []string) (int, error) {
+	if ownerID == "" {
+		return 0, errors.New(ecode.FieldIsRequired("owner_id"))
+	}
+	if tag == "" {
+		return 0, errors.New(ecode.FieldIsRequired("tag"))
+	}
+
+	affected := 0
+	afterID := ""
+	for {
+		rows, err := s.fileRepo.FindByTagAfter(ctx, ownerID, tag, afterID, tagBatchSize)
+		if err != nil {
+			return affected, fmt.Errorf("failed to query files: %w", err)
+		}
+		if len(rows) == 0 {
+			return affected, nil
+		}
+
+		updates := make(map[string][]string, len(rows))
+		for _, row := range rows {
+			updates[row.ID] = rewrite(row.Tags)
+		}
+
+		updated, err := s.fileRepo.SetTagsBatch(ctx, updates)
+		if err != nil {
+			return affected, fmt.Errorf("failed to update tags: %w", err)
+		}
+		affected += len(updated)
+
+		if len(rows) < tagBatchSize {
+			return affected, nil
+		}
+		afterID = rows[len(rows)-1].ID
+	}
+}
+
+// replaceTag swaps from for to in tags, dropping from when to is empty
+// and keeping the first occurrence of duplicates
+func replaceTag(tags []string, from, to string) []string {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		if tag == from {
+			tag = to
+		}
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+	return result
+}
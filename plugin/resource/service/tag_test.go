@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"ncobase/plugin/resource/data/ent"
+
+	"github.com/ncobase/ncore/utils"
+)
+
+// taggedFiles serves tag queries and batch updates from memory
+type taggedFiles struct {
+	*memoryFiles
+	batches int
+}
+
+func (f *taggedFiles) CountTagsByOwner(_ context.Context, ownerID string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, row := range f.rows {
+		if row.OwnerID == ownerID {
+			for _, tag := range row.Tags {
+				counts[tag]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+func (f *taggedFiles) FindByTagAfter(_ context.Context, ownerID, tag, afterID string, limit int) ([]*ent.File, error) {
+	var found []*ent.File
+	for _, row := range f.rows {
+		if row.OwnerID == ownerID && row.ID > afterID && utils.Contains(row.Tags, tag) {
+			found = append(found, row)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found, nil
+}
+
+func (f *taggedFiles) SetTagsBatch(_ context.Context, tags map[string][]string) ([]*ent.File, error) {
+	f.batches++
+	var rows []*ent.File
+	for id, t := range tags {
+		f.rows[id].Tags = t
+		rows = append(rows, f.rows[id])
+	}
+	return rows, nil
+}
+
+func newTaggedFiles() *taggedFiles {
+	return &taggedFiles{memoryFiles: newMemoryFiles(
+		&ent.File{ID: "f1", OwnerID: "o1", Tags: []string{"draft", "q1"}},
+		&ent.File{ID: "f2", OwnerID: "o1", Tags: []string{"draft"}},
+		&ent.File{ID: "f3", OwnerID: "o1", Tags: []string{"review", "draft"}},
+		&ent.File{ID: "f4", OwnerID: "o1", Tags: []string{"q1"}},
+		&ent.File{ID: "f5", OwnerID: "o2", Tags: []string{"draft"}},
+	)}
+}
+
+func tagCounts(t *testing.T, s *fileService, ownerID string) string {
+	t.Helper()
+	tags, err := s.ListTags(context.Background(), ownerID)
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	var out []string
+	for _, tag := range tags {
+		out = append(out, fmt.Sprintf("%s:%d", tag.Tag, tag.Count))
+	}
+	return fmt.Sprint(out)
+}
+
+func TestRenameTag(t *testing.T) {
+	files := newTaggedFiles()
+	s := &fileService{fileRepo: files}
+
+	if got := tagCounts(t, s, "o1"); got != "[draft:3 q1:2 review:1]" {
+		t.Fatalf("tags before = %s", got)
+	}
+
+	affected, err := s.RenameTag(context.Background(), "o1", "draft", "final")
+	if err != nil {
+		t.Fatalf("RenameTag: %v", err)
+	}
+	if affected != 3 {
+		t.Fatalf("%d files changed, want 3", affected)
+	}
+	for id, want := range map[string]string{"f1": "[final q1]", "f2": "[final]", "f3": "[review final]", "f4": "[q1]", "f5": "[draft]"} {
+		if got := fmt.Sprint(files.rows[id].Tags); got != want {
+			t.Errorf("%s tags = %s, want %s", id, got, want)
+		}
+	}
+	if got := tagCounts(t, s, "o1"); got != "[final:3 q1:2 review:1]" {
+		t.Fatalf("tags after = %s, want draft gone", got)
+	}
+}
+
+func TestRenameTagMerges(t *testing.T) {
+	files := newTaggedFiles()
+	s := &fileService{fileRepo: files}
+
+	if _, err := s.RenameTag(context.Background(), "o1", "q1", "draft"); err != nil {
+		t.Fatalf("RenameTag: %v", err)
+	}
+	if got := fmt.Sprint(files.rows["f1"].Tags); got != "[draft]" {
+		t.Fatalf("f1 tags = %s, want the merged tag once", got)
+	}
+	if got := tagCounts(t, s, "o1"); got != "[draft:4 review:1]" {
+		t.Fatalf("tags after merge = %s", got)
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	files := newTaggedFiles()
+	s := &fileService{fileRepo: files}
+
+	affected, err := s.DeleteTag(context.Background(), "o1", " draft ")
+	if err != nil || affected != 3 {
+		t.Fatalf("DeleteTag = %d, %v, want 3 files", affected, err)
+	}
+	if got := tagCounts(t, s, "o1"); got != "[q1:2 review:1]" {
+		t.Fatalf("tags after delete = %s", got)
+	}
+	if len(files.rows["f2"].Tags) != 0 {
+		t.Fatalf("f2 tags = %v, want none", files.rows["f2"].Tags)
+	}
+}
+
+func TestRenameTagBatches(t *testing.T) {
+	files := &taggedFiles{memoryFiles: newMemoryFiles()}
+	for i := 0; i < tagBatchSize+1; i++ {
+		id := fmt.Sprintf("f%04d", i)
+		files.rows[id] = &ent.File{ID: id, OwnerID: "o1", Tags: []string{"old"}}
+	}
+	s := &fileService{fileRepo: files}
+
+	affected, err := s.RenameTag(context.Background(), "o1", "old", "new")
+	if err != nil || affected != tagBatchSize+1 {
+		t.Fatalf("RenameTag = %d, %v, want %d files", affected, err, tagBatchSize+1)
+	}
+	if files.batches != 2 {
+		t.Fatalf("%d batches, want 2", files.batches)
+	}
+}
+
+func TestRenameTagRequiresNames(t *testing.T) {
+	s := &fileService{fileRepo: newTaggedFiles()}
+	for _, tc := range [][3]string{{"", "draft", "final"}, {"o1", "", "final"}, {"o1", "draft", " "}} {
+		if _, err := s.RenameTag(context.Background(), tc[0], tc[1], tc[2]); err == nil {
+			t.Errorf("RenameTag(%q, %q, %q) accepted", tc[0], tc[1], tc[2])
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"ncobase/internal/page"
+	"ncobase/internal/tracing"
+	"ncobase/internal/utils"
+	"ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/data/repository"
+	"ncobase/plugin/resource/structs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ncobase/ncore/logging/logger"
+	"github.com/ncobase/ncore/oss"
+	"github.com/ncobase/ncore/types"
+	"github.com/ncobase/ncore/validation/validator"
+)
+
+// regenerateBatchSize is the number of files listed per thumbnail regeneration batch
+const regenerateBatchSize = 100
+
+// defaultThumbnailPathTemplate stores a thumbnail in a thumbnails folder next to its object
+const defaultThumbnailPathTemplate = "{dir}/thumbnails/{name}_thumb.jpg"
+
+// errSourceMissing marks a file whose storage object cannot be read
+var errSourceMissing = errors.New("source object missing")
+
+// RegenerateThumbnails rebuilds the thumbnails of the image files matching params
+// with options, replacing the stored thumbnails. Non-images and files whose
+// source object is missing are skipped and logged.
+// It returns the number of thumbnails regenerated.
+func (s *fileService) RegenerateThumbnails(ctx context.Context, params *structs.ListFileParams, options *structs.ProcessingOptions) (int, error) {
+	ctx, span := tracing.Start(ctx, "resource.file.RegenerateThumbnails")
+	defer span.End()
+
+	if s.imageProcessor == nil {
+		return 0, errors.New("image processing not configured")
+	}
+
+	storageClient, _ := getStorage(ctx)
+	if storageClient == nil {
+		return 0, errors.New("storage not configured")
+	}
+
+	if options == nil {
+		options = &structs.ProcessingOptions{}
+	}
+	options.CreateThumbnail = true
+	options.ApplyDefaults()
+	if err := options.Validate(); err != nil {
+		return 0, err
+	}
+
+	lp := *params
+	lp.Cursor = ""
+	lp.Direction = ""
+	lp.Limit = regenerateBatchSize
+	lp.Category = structs.FileCategoryImage
+
+	var (
+		mu        sync.Mutex
+		processed int
+	)
+	workers := s.workers()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return processed, err
+		}
+
+		rows, err := s.fileRepo.List(ctx, &lp)
+		if err != nil {
+			return processed, fmt.Errorf("failed to list files: %w", err)
+		}
+
+		images := make([]*ent.File, 0, len(rows))
+		for _, row := range rows {
+			if !validator.IsImageFile(row.Path) {
+				logger.Infof(ctx, "Skipping thumbnail regeneration for %s: not an image", row.ID)
+				continue
+			}
+			images = append(images, row)
+		}
+
+		err = utils.ForEach(ctx, images, workers, func(ctx context.Context, row *ent.File) {
+			if _, err := s.storeThumbnail(ctx, storageClient, row, options); err != nil {
+				if errors.Is(err, errSourceMissing) {
+					logger.Warnf(ctx, "Skipping thumbnail regeneration for %s: %v", row.ID, err)
+				} else {
+					logger.Errorf(ctx, "Failed to regenerate thumbnail for %s: %v", row.ID, err)
+				}
+				return
+			}
+
+			mu.Lock()
+			processed++
+			mu.Unlock()
+		})
+		if err != nil {
+			return processed, err
+		}
+
+		if len(rows) < regenerateBatchSize {
+			return processed, nil
+		}
+
+		last := rows[len(rows)-1]
+		lp.Cursor = page.EncodeCursor(page.Cursor{Key: last.CreatedAt, ID: last.ID})
+	}
+}
+
+// storeThumbnail renders a thumbnail of row's object with options, stores it
+// next to the object and records its path and size in the file extras
+func (s *fileService) storeThumbnail(ctx context.Context, storageClient oss.Interface, row *ent.File, options *structs.ProcessingOptions) (*ent.File, error) {
+	file, err := s.openObject(ctx, row, row.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errSourceMissing, err)
+	}
+	defer file.Close()
+
+	fileBytes, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	thumbnailBytes, err := s.imageProcessor.CreateThumbnail(
+		ctx,
+		bytes.NewReader(fileBytes),
+		row.Name,
+		options.MaxWidth,
+		options.MaxHeight,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating thumbnail: %w", err)
+	}
+
+	thumbnailPath := s.thumbnailPath(row.OwnerID, repository.SpaceID(row.Extras), row.Path, options.MaxWidth, options.MaxHeight)
+	_, err = storageClient.Put(thumbnailPath, bytes.NewReader(thumbnailBytes))
+	if err != nil {
+		return nil, fmt.Errorf("error storing thumbnail: %w", err)
+	}
+
+	extras := repository.CloneExtras(row.Extras)
+	previous, _ := extras["thumbnail_path"].(string)
+	extras["thumbnail_path"] = thumbnailPath
+	extras["thumbnail_max_width"] = options.MaxWidth
+	extras["thumbnail_max_height"] = options.MaxHeight
+
+	updated, err := s.fileRepo.Update(ctx, row.ID, types.JSON{
+		"extras": extras,
+	})
+	if err != nil {
+		return nil, handleEntError(ctx, "File", err)
+	}
+
+	// A thumbnail stored under another template or size is replaced,
+	// unless records sharing the object still point at it
+	if previous != "" && previous != thumbnailPath {
+		if refs, err := s.fileRepo.CountPathRefs(ctx, row.Path, row.ID); err != nil || refs > 0 {
+			logger.Debugf(ctx, "Keeping thumbnail %s of shared object %s", previous, row.Path)
+		} else if err := storageClient.Delete(previous); err != nil {
+			logger.Warnf(ctx, "Error deleting previous thumbnail %s: %v", previous, err)
+		}
+	}
+
+	return updated, nil
+}
+
+// thumbnailTemplate returns the configured thumbnail path template. A template without
+// the {name} token would store the thumbnails of different objects under one key, the
+// default template is used instead.
+func thumbnailTemplate(conf *config.Config) string {
+	if conf == nil || conf.ImageProcessing == nil || conf.ImageProcessing.ThumbnailPathTemplate == "" {
+		return defaultThumbnailPathTemplate
+	}
+	template := conf.ImageProcessing.ThumbnailPathTemplate
+	if !strings.Contains(template, "{name}") {
+		logger.Warnf(context.Background(), "Thumbnail path template %q lacks {name}, using %q", template, defaultThumbnailPathTemplate)
+		return defaultThumbnailPathTemplate
+	}
+	return template
+}
+
+// thumbnailPath returns the storage key of a thumbnail of the object at objectPath,
+// rendered at most width by height, from the thumbnail path template. ownerID and spaceID
+// are the ones stored on the file, so a regenerated thumbnail keeps its key.
+func (s *fileService) thumbnailPath(ownerID, spaceID, objectPath string, width, height int) string {
+	template := s.thumbnailTemplate
+	if template == "" {
+		template = defaultThumbnailPathTemplate
+	}
+
+	fileName := path.Base(objectPath)
+	ext := path.Ext(fileName)
+	replacer := strings.NewReplacer(
+		"{dir}", path.Dir(objectPath),
+		"{name}", strings.TrimSuffix(fileName, ext),
+		"{ext}", strings.TrimPrefix(ext, "."),
+		"{owner}", ownerID,
+		"{space}", spaceID,
+		"{category}", string(structs.GetFileCategory(ext)),
+		"{width}", strconv.Itoa(width),
+		"{height}", strconv.Itoa(height),
+		"{size}", fmt.Sprintf("%dx%d", width, height),
+	)
+
+	// Empty tokens leave empty segments behind
+	return strings.TrimPrefix(path.Clean(replacer.Replace(template)), "/")
+}
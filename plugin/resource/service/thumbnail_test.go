@@ -0,0 +1,194 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"path"
+	"strings"
+	"testing"
+
+	"ncobase/plugin/resource/config"
+	"ncobase/plugin/resource/data/ent"
+	"ncobase/plugin/resource/structs"
+
+	"github.com/ncobase/ncore/ctxutil"
+	"github.com/ncobase/ncore/types"
+)
+
+// pngImage encodes a plain width by height PNG
+func pngImage(t *testing.T, width, height int) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 200, A: 255})
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.String()
+}
+
+func imageFile(id, path string) *ent.File {
+	return &ent.File{ID: id, Name: id, Path: path, OwnerID: "u1", Category: string(structs.FileCategoryImage)}
+}
+
+func TestRegenerateThumbnailsResizesImages(t *testing.T) {
+	bucket := newMemoryBucket(map[string]string{
+		"img/wide.png":                  pngImage(t, 400, 200),
+		"img/tall.png":                  pngImage(t, 100, 300),
+		"img/small.png":                 pngImage(t, 40, 30),
+		"img/notes.txt":                 "not an image",
+		"img/thumbnails/wide_thumb.jpg": "stale",
+	})
+	files := newMemoryFiles(
+		imageFile("a", "img/wide.png"),
+		imageFile("b", "img/tall.png"),
+		imageFile("c", "img/small.png"),
+		imageFile("d", "img/notes.txt"),
+		imageFile("e", "img/gone.png"),
+	)
+	files.rows["a"].Extras = types.JSON{"thumbnail_path": "img/thumbnails/wide_thumb.jpg", "thumbnail_max_width": 50}
+	s := &fileService{fileRepo: files, imageProcessor: NewImageProcessor()}
+
+	processed, err := s.RegenerateThumbnails(withBucket(bucket), &structs.ListFileParams{OwnerID: "u1"}, &structs.ProcessingOptions{MaxWidth: 100, MaxHeight: 100})
+	if err != nil {
+		t.Fatalf("RegenerateThumbnails: %v", err)
+	}
+	if processed != 3 {
+		t.Fatalf("processed %d, want 3 (the text file and the missing object skipped)", processed)
+	}
+
+	for id, want := range map[string]image.Point{"a": {100, 50}, "b": {33, 100}, "c": {40, 30}} {
+		row := files.rows[id]
+		thumbnail, _ := row.Extras["thumbnail_path"].(string)
+		if !bucket.has(thumbnail) {
+			t.Errorf("%s: thumbnail %q not stored", id, thumbnail)
+			continue
+		}
+		config, _, err := image.DecodeConfig(bytes.NewReader(bucket.objects[thumbnail]))
+		if err != nil {
+			t.Errorf("%s: decode thumbnail: %v", id, err)
+			continue
+		}
+		if got := (image.Point{config.Width, config.Height}); got != want {
+			t.Errorf("%s: thumbnail %v, want %v", id, got, want)
+		}
+		if row.Extras["thumbnail_max_width"] != 100 || row.Extras["thumbnail_max_height"] != 100 {
+			t.Errorf("%s: extras %v, want the new size recorded", id, row.Extras)
+		}
+	}
+	if string(bucket.objects["img/thumbnails/wide_thumb.jpg"]) == "stale" {
+		t.Fatal("stale thumbnail not replaced")
+	}
+	if files.rows["d"].Extras != nil || files.rows["e"].Extras != nil {
+		t.Fatal("skipped files recorded a thumbnail")
+	}
+}
+
+func TestRegenerateThumbnailsRequiresProcessor(t *testing.T) {
+	s := &fileService{fileRepo: newMemoryFiles()}
+	if _, err := s.RegenerateThumbnails(withBucket(newMemoryBucket(nil)), &structs.ListFileParams{}, nil); err == nil {
+		t.Fatal("regenerated without an image processor")
+	}
+}
+
+func TestThumbnailPathTemplate(t *testing.T) {
+	for _, tt := range []struct {
+		template, owner, object, want string
+	}{
+		{"", "u1", "u1/img/cat.png", "u1/img/thumbnails/cat_thumb.jpg"},
+		{defaultThumbnailPathTemplate, "u1", "cat.png", "thumbnails/cat_thumb.jpg"},
+		{"derived/{space}/{owner}/{category}/{size}/{name}.{ext}", "u1", "u1/cat.png", "derived/s1/u1/image/64x48/cat.png"},
+		{"{width}w/{height}h/{name}.jpg", "", "a/b/cat.jpeg", "64w/48h/cat.jpg"},
+		// empty tokens leave no empty segments
+		{"/{owner}/thumbs/{name}.jpg", "", "cat.png", "thumbs/cat.jpg"},
+	} {
+		s := &fileService{thumbnailTemplate: tt.template}
+		if got := s.thumbnailPath(tt.owner, "s1", tt.object, 64, 48); got != tt.want {
+			t.Fatalf("path of %s with %q = %s, want %s", tt.object, tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestRegenerateThumbnailUsesStoredSpace(t *testing.T) {
+	bucket := newMemoryBucket(map[string]string{"u1/cat.png": pngImage(t, 40, 30)})
+	files := newMemoryFiles(imageFile("a", "u1/cat.png"), imageFile("b", "u1/cat.png"))
+	files.rows["a"].Extras = types.JSON{"space_id": "s1"}
+	s := &fileService{fileRepo: files, imageProcessor: NewImageProcessor(), thumbnailTemplate: "thumbs/{space}/{owner}/{name}_{size}.jpg"}
+
+	// an admin regenerating from another space keeps each file's own space in the key
+	ctx := ctxutil.SetSpaceID(withBucket(bucket), "admin-space")
+	if _, err := s.RegenerateThumbnails(ctx, &structs.ListFileParams{OwnerID: "u1"}, &structs.ProcessingOptions{MaxWidth: 64, MaxHeight: 48}); err != nil {
+		t.Fatalf("RegenerateThumbnails: %v", err)
+	}
+	for id, want := range map[string]string{"a": "thumbs/s1/u1/cat_64x48.jpg", "b": "thumbs/u1/cat_64x48.jpg"} {
+		if got := files.rows[id].Extras["thumbnail_path"]; got != want || !bucket.has(want) {
+			t.Errorf("%s: thumbnail %v, want %s stored", id, got, want)
+		}
+	}
+}
+
+func TestThumbnailTemplateNeedsName(t *testing.T) {
+	for template, want := range map[string]string{
+		"":                         defaultThumbnailPathTemplate,
+		"thumbs/{size}.jpg":        defaultThumbnailPathTemplate,
+		"thumbs/{name}_{size}.jpg": "thumbs/{name}_{size}.jpg",
+	} {
+		conf := &config.Config{ImageProcessing: &config.ImageConfig{ThumbnailPathTemplate: template}}
+		if got := thumbnailTemplate(conf); got != want {
+			t.Fatalf("template of %q = %q, want %q", template, got, want)
+		}
+	}
+	if got := thumbnailTemplate(nil); got != defaultThumbnailPathTemplate {
+		t.Fatalf("template without config = %q", got)
+	}
+}
+
+func TestTemplatedThumbnailLifecycle(t *testing.T) {
+	bucket := newMemoryBucket(nil)
+	files := newMemoryFiles()
+	s := &fileService{
+		fileRepo:          files,
+		imageProcessor:    NewImageProcessor(),
+		thumbnailTemplate: "derived/{space}/{category}/{size}/{name}.jpg",
+	}
+	ctx := ctxutil.SetSpaceID(withBucket(bucket), "s1")
+
+	created, err := s.Create(ctx, &structs.CreateFileBody{
+		Name: "cat", Path: "cat.png", Type: "image/png", OwnerID: "u1",
+		File:              &readCloser{bytes.NewReader([]byte(pngImage(t, 200, 100)))},
+		ProcessingOptions: &structs.ProcessingOptions{CreateThumbnail: true, MaxWidth: 64, MaxHeight: 64},
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	row := files.rows[created.ID]
+	want := "derived/s1/image/64x64/" + strings.TrimSuffix(path.Base(row.Path), path.Ext(row.Path)) + ".jpg"
+	if got, _ := row.Extras["thumbnail_path"].(string); got != want || !bucket.has(want) {
+		t.Fatalf("thumbnail recorded at %q, stored %v, want %q", got, bucket.has(want), want)
+	}
+
+	// reads follow the recorded key even once the template changes
+	s.thumbnailTemplate = defaultThumbnailPathTemplate
+	stream, err := s.GetThumbnail(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetThumbnail: %v", err)
+	}
+	content, _ := io.ReadAll(stream)
+	stream.Close()
+	if !bytes.Equal(content, bucket.objects[want]) {
+		t.Fatal("thumbnail read from another key")
+	}
+
+	if err := s.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if bucket.has(want) || bucket.has(row.Path) {
+		t.Fatalf("objects left after delete: %v", bucket.objects)
+	}
+}
@@ -1,6 +1,11 @@
 package structs
 
-import "github.com/ncobase/ncore/types"
+import (
+	"strings"
+	"time"
+
+	"github.com/ncobase/ncore/types"
+)
 
 // AdminFileListParams for admin file listing
 type AdminFileListParams struct {
@@ -142,6 +147,47 @@ type QuotaSetRequest struct {
 	Quota int64 `json:"quota" binding:"required"`
 }
 
+// ScopedQuotaSetRequest for setting a folder or category sub-limit, zero quota removes it
+type ScopedQuotaSetRequest struct {
+	Scope string `json:"scope" binding:"required"` // category:<category> or folder:<path prefix>
+	Quota int64  `json:"quota"`
+}
+
+// ScopedQuotaInfo for sub-limit information
+type ScopedQuotaInfo struct {
+	UserID string `json:"user_id"`
+	Scope  string `json:"scope"`
+	Quota  int64  `json:"quota"`
+	Usage  int64  `json:"usage"`
+}
+
+// Quota scope prefixes
+const (
+	QuotaScopeCategory = "category:"
+	QuotaScopeFolder   = "folder:"
+)
+
+// CategoryQuotaScope returns the quota scope key of a file category
+func CategoryQuotaScope(category FileCategory) string {
+	return QuotaScopeCategory + string(category)
+}
+
+// FolderQuotaScopes returns the quota scope keys of a folder and its parents,
+// innermost first
+func FolderQuotaScopes(prefix string) []string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return nil
+	}
+
+	parts := strings.Split(prefix, "/")
+	scopes := make([]string, 0, len(parts))
+	for i := len(parts); i > 0; i-- {
+		scopes = append(scopes, QuotaScopeFolder+strings.Join(parts[:i], "/"))
+	}
+	return scopes
+}
+
 // BatchCleanupRequest for batch cleanup
 type BatchCleanupRequest struct {
 	Type     string          `json:"type" binding:"required"` // expired, orphaned, duplicates
@@ -250,3 +296,65 @@ type BackupResult struct {
 	StartedAt   int64  `json:"started_at"`
 	CompletedAt *int64 `json:"completed_at,omitempty"`
 }
+
+// ReconcileOptions for storage reconciliation
+type ReconcileOptions struct {
+	Prefix      string        `json:"prefix"`
+	DryRun      bool          `json:"dry_run"`
+	GracePeriod time.Duration `json:"grace_period"` // objects and records younger than this are left alone
+}
+
+// ReconcileReport describes the drift between storage and file records
+type ReconcileReport struct {
+	Prefix          string   `json:"prefix"`
+	DryRun          bool     `json:"dry_run"`
+	ObjectsScanned  int      `json:"objects_scanned"`
+	RecordsScanned  int      `json:"records_scanned"`
+	OrphanedObjects []string `json:"orphaned_objects,omitempty"` // storage paths without a record
+	DanglingRecords []string `json:"dangling_records,omitempty"` // record IDs whose object is missing
+	Skipped         []string `json:"skipped,omitempty"`          // candidates inside the grace window
+	Cleaned         int      `json:"cleaned"`
+	Errors          []string `json:"errors,omitempty"`
+}
+
+// ReindexReport summarizes a search index rebuild
+type ReindexReport struct {
+	OwnerID string `json:"owner_id,omitempty"` // empty when every file was reindexed
+	Indexed int    `json:"indexed"`
+}
+
+// BackfillOptions for normalizing stored file extras
+type BackfillOptions struct {
+	OwnerID   string                       `json:"owner_id,omitempty"`
+	DryRun    bool                         `json:"dry_run"`
+	BatchSize int                          `json:"batch_size,omitempty"`
+	Progress  func(report *BackfillReport) `json:"-"` // called after each batch
+}
+
+// BackfillReport summarizes an extras backfill run
+type BackfillReport struct {
+	DryRun  bool     `json:"dry_run"`
+	Scanned int      `json:"scanned"`
+	Changed int      `json:"changed"` // files whose extras were (or would be) rewritten
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// MigrateOptions for moving file objects between buckets
+type MigrateOptions struct {
+	Concurrency  int                         `json:"concurrency,omitempty"`
+	ItemTimeout  time.Duration               `json:"item_timeout,omitempty"` // deadline per file, none when not positive
+	BatchSize    int                         `json:"batch_size,omitempty"`
+	DeleteSource bool                        `json:"delete_source"` // remove source objects once the copy is verified
+	Progress     func(report *MigrateReport) `json:"-"`             // called after each batch
+}
+
+// MigrateReport summarizes a bucket migration run
+type MigrateReport struct {
+	FromBucket string   `json:"from_bucket"`
+	ToBucket   string   `json:"to_bucket"`
+	Scanned    int      `json:"scanned"`
+	Migrated   int      `json:"migrated"`
+	Bytes      int64    `json:"bytes"`
+	Cursor     string   `json:"cursor,omitempty"` // resumes after the last completed batch
+	Errors     []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,52 @@
+package structs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultAttachmentRelation is the relation of a file attached without one
+const DefaultAttachmentRelation = "attachment"
+
+// AttachFileBody attaches a file to an entity of any module, e.g. a topic or a task
+type AttachFileBody struct {
+	EntityType string `json:"entity_type" binding:"required"`
+	EntityID   string `json:"entity_id" binding:"required"`
+	Relation   string `json:"relation,omitempty"` // e.g. attachment, cover, avatar; defaults to attachment
+}
+
+// Validate trims the body, defaults its relation and checks the entity is set
+func (b *AttachFileBody) Validate() error {
+	b.EntityType = strings.TrimSpace(b.EntityType)
+	b.EntityID = strings.TrimSpace(b.EntityID)
+	b.Relation = strings.TrimSpace(b.Relation)
+	if b.EntityType == "" {
+		return fmt.Errorf("entity_type is required")
+	}
+	if b.EntityID == "" {
+		return fmt.Errorf("entity_id is required")
+	}
+	if b.Relation == "" {
+		b.Relation = DefaultAttachmentRelation
+	}
+	return nil
+}
+
+// ListAttachmentParams selects the files attached to an entity
+type ListAttachmentParams struct {
+	EntityType string `form:"entity_type" json:"entity_type" binding:"required"`
+	EntityID   string `form:"entity_id" json:"entity_id" binding:"required"`
+	Relation   string `form:"relation" json:"relation,omitempty"` // all relations when empty
+}
+
+// ReadAttachment is a file attached to an entity
+type ReadAttachment struct {
+	ID         string    `json:"id"`
+	FileID     string    `json:"file_id"`
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Relation   string    `json:"relation"`
+	CreatedBy  string    `json:"created_by,omitempty"`
+	CreatedAt  int64     `json:"created_at"`
+	File       *ReadFile `json:"file,omitempty"`
+}
@@ -43,3 +43,30 @@ type BatchStatus struct {
 	StartedAt   int64  `json:"started_at"`
 	CompletedAt *int64 `json:"completed_at,omitempty"`
 }
+
+// MaxBatchGetFiles is the most files one batch get may request
+const MaxBatchGetFiles = 100
+
+// BatchGetFilesBody for fetching several files at once
+type BatchGetFilesBody struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// BatchGetFilesResult for batch get results
+type BatchGetFilesResult struct {
+	Files  []*ReadFile       `json:"files"`
+	Errors map[string]string `json:"errors,omitempty"` // Keyed by requested ID, for missing and inaccessible files
+}
+
+// BulkUpdateFilesBody for applying one patch to every file matching a filter.
+// Tags of the patch are added to the tags of each file rather than replacing them.
+type BulkUpdateFilesBody struct {
+	Filter ListFileParams `json:"filter"`
+	Patch  UpdateFileBody `json:"patch"`
+}
+
+// BulkUpdateResult for bulk update results
+type BulkUpdateResult struct {
+	Affected int               `json:"affected"`
+	Errors   map[string]string `json:"errors,omitempty"` // Keyed by file ID, for files left unchanged
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"mime/multipart"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ncobase/ncore/types"
 	"github.com/ncobase/ncore/utils/convert"
@@ -31,6 +33,24 @@ const (
 	AccessLevelShared  AccessLevel = "shared"
 )
 
+// NameConflict is how an upload is handled when its owner already has a file of the same name
+type NameConflict string
+
+const (
+	NameConflictRename  NameConflict = "rename"  // the upload gets a generated name
+	NameConflictReject  NameConflict = "reject"  // the upload fails with a conflict
+	NameConflictReplace NameConflict = "replace" // the upload replaces the content of the existing file
+)
+
+// IsValid reports whether the name conflict mode is known
+func (m NameConflict) IsValid() bool {
+	switch m {
+	case NameConflictRename, NameConflictReject, NameConflictReplace:
+		return true
+	}
+	return false
+}
+
 // ProcessingOptions for file processing
 type ProcessingOptions struct {
 	CreateThumbnail    bool   `json:"create_thumbnail,omitempty"`
@@ -58,6 +78,8 @@ type CreateFileBody struct {
 	ExpiresAt         *int64             `json:"expires_at,omitempty"`
 	Tags              []string           `json:"tags,omitempty"`
 	IsPublic          bool               `json:"is_public,omitempty"`
+	Dedupe            bool               `json:"dedupe,omitempty"`
+	OnConflict        NameConflict       `json:"on_conflict,omitempty"` // empty uses the configured mode
 	ProcessingOptions *ProcessingOptions `json:"processing_options,omitempty"`
 	OwnerID           string             `json:"owner_id,omitempty"`
 	Extras            *types.JSON        `json:"extras,omitempty"`
@@ -76,6 +98,9 @@ func (c *CreateFileBody) Validate() error {
 	if c.File == nil {
 		return fmt.Errorf("file content is required")
 	}
+	if c.OnConflict != "" && !c.OnConflict.IsValid() {
+		return fmt.Errorf("invalid on_conflict: %s", c.OnConflict)
+	}
 	return nil
 }
 
@@ -91,6 +116,147 @@ func (c *CreateFileBody) GetFolderPath() string {
 	return filepath.ToSlash(dir)
 }
 
+// FileShare is an entry of a file's share list
+type FileShare struct {
+	UserID    string `json:"user_id"`
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
+}
+
+// IsExpired reports whether the share has expired
+func (s FileShare) IsExpired() bool {
+	return s.ExpiresAt != nil && time.Now().UnixMilli() > *s.ExpiresAt
+}
+
+// ShareFileBody for sharing a file with users
+type ShareFileBody struct {
+	UserIDs   []string `json:"user_ids" binding:"required"`
+	ExpiresAt *int64   `json:"expires_at,omitempty"`
+}
+
+// Validate validates the share file body
+func (b *ShareFileBody) Validate() error {
+	if len(b.UserIDs) == 0 {
+		return fmt.Errorf("at least one user is required")
+	}
+	if b.ExpiresAt != nil && *b.ExpiresAt <= time.Now().UnixMilli() {
+		return fmt.Errorf("expires_at must be in the future")
+	}
+	return nil
+}
+
+// UnshareFileBody for removing users from a file's share list
+type UnshareFileBody struct {
+	UserIDs []string `json:"user_ids" binding:"required"`
+}
+
+// TagCount is a tag with the number of files carrying it
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// RenameTagBody for renaming or merging a tag across an owner's files
+type RenameTagBody struct {
+	OwnerID string `json:"owner_id" binding:"required"`
+	Name    string `json:"name" binding:"required"`
+}
+
+// UpdateFileBody for partial file updates, nil fields are left untouched
+type UpdateFileBody struct {
+	Name        *string       `json:"name,omitempty"`
+	FolderPath  *string       `json:"folder_path,omitempty"`
+	AccessLevel *AccessLevel  `json:"access_level,omitempty"`
+	Category    *FileCategory `json:"category,omitempty"`
+	Tags        *[]string     `json:"tags,omitempty"`
+	Metadata    *types.JSON   `json:"metadata,omitempty"`
+	IsPublic    *bool         `json:"is_public,omitempty"`
+	// ExpiresAt set to 0 clears the expiry
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
+	Version   int64  `json:"version,omitempty"`
+}
+
+// Validate validates the update file body
+func (b *UpdateFileBody) Validate() error {
+	if b.Name != nil && strings.TrimSpace(*b.Name) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if b.AccessLevel != nil {
+		switch *b.AccessLevel {
+		case AccessLevelPublic, AccessLevelPrivate, AccessLevelShared:
+		default:
+			return fmt.Errorf("invalid access level: %s", *b.AccessLevel)
+		}
+	}
+	if b.Category != nil {
+		switch *b.Category {
+		case FileCategoryImage, FileCategoryDocument, FileCategoryVideo,
+			FileCategoryAudio, FileCategoryArchive, FileCategoryOther:
+		default:
+			return fmt.Errorf("invalid category: %s", *b.Category)
+		}
+	}
+	if b.ExpiresAt != nil && *b.ExpiresAt < 0 {
+		return fmt.Errorf("expires_at cannot be negative")
+	}
+	return nil
+}
+
+// IsEmpty reports whether the body changes nothing
+func (b *UpdateFileBody) IsEmpty() bool {
+	return b.Name == nil && b.FolderPath == nil && b.AccessLevel == nil && b.Category == nil &&
+		b.Tags == nil && b.Metadata == nil && b.IsPublic == nil && b.ExpiresAt == nil
+}
+
+// ToUpdates translates the body into the column and extras update map.
+// Folder path and metadata live in extras and are merged with the existing extras.
+func (b *UpdateFileBody) ToUpdates() types.JSON {
+	updates := types.JSON{}
+	extras := types.JSON{}
+
+	if b.Name != nil {
+		updates["name"] = strings.TrimSpace(*b.Name)
+	}
+	if b.AccessLevel != nil {
+		updates["access_level"] = *b.AccessLevel
+	}
+	if b.Category != nil {
+		updates["category"] = *b.Category
+	}
+	if b.Tags != nil {
+		tags := make([]string, 0, len(*b.Tags))
+		for _, tag := range *b.Tags {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		updates["tags"] = tags
+	}
+	if b.IsPublic != nil {
+		updates["is_public"] = *b.IsPublic
+	}
+	if b.ExpiresAt != nil {
+		if *b.ExpiresAt == 0 {
+			updates["expires_at"] = nil
+		} else {
+			updates["expires_at"] = *b.ExpiresAt
+		}
+	}
+	if b.FolderPath != nil {
+		extras["path_prefix"] = strings.Trim(strings.ReplaceAll(*b.FolderPath, "\\", "/"), "/")
+	}
+	if b.Metadata != nil {
+		extras["metadata"] = *b.Metadata
+	}
+	if len(extras) > 0 {
+		updates["extras"] = extras
+	}
+	if b.Version > 0 {
+		updates["version"] = b.Version
+	}
+
+	return updates
+}
+
 // ReadFile represents file output with context-aware serialization
 type ReadFile struct {
 	ID           string `json:"id"`
@@ -115,15 +281,19 @@ type ReadFile struct {
 	IsExpired    bool         `json:"is_expired,omitempty"`
 
 	// Sensitive fields
-	Hash      string      `json:"hash,omitempty"`
-	OwnerID   string      `json:"owner_id,omitempty"`
-	Extras    *types.JSON `json:"extras,omitempty"`
-	CreatedBy *string     `json:"created_by,omitempty"`
-	UpdatedBy *string     `json:"updated_by,omitempty"`
+	Hash       string      `json:"hash,omitempty"`
+	OwnerID    string      `json:"owner_id,omitempty"`
+	SharedWith []FileShare `json:"shared_with,omitempty"`
+	Extras     *types.JSON `json:"extras,omitempty"`
+	CreatedBy  *string     `json:"created_by,omitempty"`
+	UpdatedBy  *string     `json:"updated_by,omitempty"`
 
 	CreatedAt *int64 `json:"created_at,omitempty"`
 	UpdatedAt *int64 `json:"updated_at,omitempty"`
 
+	// Version must be echoed back on update to detect concurrent writes
+	Version int64 `json:"version,omitempty"`
+
 	// Virtual fields
 	FullPath string `json:"full_path,omitempty"`
 
@@ -324,6 +494,7 @@ type ListFileParams struct {
 	User          string       `form:"user,omitempty" json:"user,omitempty"`
 	Type          string       `form:"type,omitempty" json:"type,omitempty"`
 	Storage       string       `form:"storage,omitempty" json:"storage,omitempty"`
+	Bucket        string       `form:"bucket,omitempty" json:"bucket,omitempty"`
 	Category      FileCategory `form:"category,omitempty" json:"category,omitempty"`
 	Tags          string       `form:"tags,omitempty" json:"tags,omitempty"`
 	AccessLevel   AccessLevel  `form:"access_level,omitempty" json:"access_level,omitempty"`
@@ -334,6 +505,123 @@ type ListFileParams struct {
 	SizeMax       int64        `form:"size_max,omitempty" json:"size_max,omitempty"`
 	IsPublic      *bool        `form:"is_public,omitempty" json:"is_public,omitempty"`
 	SearchQuery   string       `form:"q,omitempty" json:"q,omitempty"`
+	// CustomFields filter on the user metadata of files, "key:op:value" each, all must match
+	CustomFields []string `form:"custom_field,omitempty" json:"custom_field,omitempty"`
+}
+
+// Custom field filter operators
+const (
+	CustomFieldEQ       = "eq"
+	CustomFieldNE       = "ne"
+	CustomFieldGT       = "gt"
+	CustomFieldGTE      = "gte"
+	CustomFieldLT       = "lt"
+	CustomFieldLTE      = "lte"
+	CustomFieldContains = "contains"
+	CustomFieldExists   = "exists"
+)
+
+// CustomFieldFilter is a constraint on one key of the file metadata
+type CustomFieldFilter struct {
+	Key   string
+	Op    string
+	Value any // bool, float64 or string, nil for exists
+}
+
+// CustomFieldFilters parses the custom field filters, e.g. "department:eq:finance",
+// "pages:gte:10" or "reviewed:exists". Values of eq and ne are typed like JSON,
+// so "true" matches a boolean and "10" a number.
+func (p *ListFileParams) CustomFieldFilters() ([]CustomFieldFilter, error) {
+	filters := make([]CustomFieldFilter, 0, len(p.CustomFields))
+	for _, raw := range p.CustomFields {
+		parts := strings.SplitN(raw, ":", 3)
+		if len(parts) < 2 || !isCustomFieldKey(parts[0]) {
+			return nil, fmt.Errorf("invalid custom_field %q, want key:op:value", raw)
+		}
+		f := CustomFieldFilter{Key: parts[0], Op: parts[1]}
+		if f.Op == CustomFieldExists {
+			filters = append(filters, f)
+			continue
+		}
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid custom_field %q, %s needs a value", raw, f.Op)
+		}
+
+		value := parts[2]
+		switch f.Op {
+		case CustomFieldEQ, CustomFieldNE:
+			f.Value = customFieldValue(value)
+		case CustomFieldGT, CustomFieldGTE, CustomFieldLT, CustomFieldLTE:
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid custom_field %q, %s needs a number", raw, f.Op)
+			}
+			f.Value = n
+		case CustomFieldContains:
+			f.Value = value
+		default:
+			return nil, fmt.Errorf("invalid custom_field %q, unknown operator %s", raw, f.Op)
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// isCustomFieldKey reports whether key is a plain metadata key, it ends up in a JSON path
+func isCustomFieldKey(key string) bool {
+	if key == "" || len(key) > 64 {
+		return false
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// customFieldValue types a filter value like its JSON form
+func customFieldValue(value string) any {
+	if value == "true" || value == "false" {
+		return value == "true"
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return n
+	}
+	return value
+}
+
+// FileSearchFacets are the fields counted by faceted search
+var FileSearchFacets = []string{"tags", "category", "access_level"}
+
+// FileSearchParams for faceted file search
+type FileSearchParams struct {
+	OwnerID     string       `form:"owner_id,omitempty" json:"owner_id,omitempty" validate:"required"`
+	Query       string       `form:"q,omitempty" json:"q,omitempty"`
+	Tags        string       `form:"tags,omitempty" json:"tags,omitempty"` // comma separated, all must match
+	Category    FileCategory `form:"category,omitempty" json:"category,omitempty"`
+	AccessLevel AccessLevel  `form:"access_level,omitempty" json:"access_level,omitempty"`
+	Limit       int          `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset      int          `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// TagList returns the requested tags
+func (p *FileSearchParams) TagList() []string {
+	var tags []string
+	for _, tag := range strings.Split(p.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// FileSearchResult carries search hits with facet counts.
+// Facets maps a field to value counts and is empty when search runs against the database.
+type FileSearchResult struct {
+	Hits   []*ReadFile                 `json:"hits"`
+	Total  int64                       `json:"total"`
+	Facets map[string]map[string]int64 `json:"facets"`
 }
 
 // FindFile for finding files
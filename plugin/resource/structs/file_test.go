@@ -0,0 +1,86 @@
+package structs
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ncobase/ncore/types"
+)
+
+func decodeUpdate(t *testing.T, body string) *UpdateFileBody {
+	t.Helper()
+	b := &UpdateFileBody{}
+	if err := json.Unmarshal([]byte(body), b); err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	if err := b.Validate(); err != nil {
+		t.Fatalf("validate %s: %v", body, err)
+	}
+	return b
+}
+
+func TestUpdateFileBodyChangesOnlyGivenFields(t *testing.T) {
+	for body, want := range map[string]types.JSON{
+		`{"tags": [" a ", "", "b"]}`:                   {"tags": []string{"a", "b"}},
+		`{"access_level": "shared"}`:                   {"access_level": AccessLevelShared},
+		`{"expires_at": 0}`:                            {"expires_at": nil},
+		`{"expires_at": 1700000000000}`:                {"expires_at": int64(1700000000000)},
+		`{"folder_path": "/docs\\2024/"}`:              {"extras": types.JSON{"path_prefix": "docs/2024"}},
+		`{"tags": [], "version": 42}`:                  {"tags": []string{}, "version": int64(42)},
+		`{"name": " report.pdf ", "is_public": false}`: {"name": "report.pdf", "is_public": false},
+	} {
+		if got := decodeUpdate(t, body).ToUpdates(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: updates = %#v, want %#v", body, got, want)
+		}
+	}
+}
+
+func TestUpdateFileBodyIsEmpty(t *testing.T) {
+	if !decodeUpdate(t, `{"version": 42}`).IsEmpty() {
+		t.Error("body with only a version is not empty")
+	}
+	if decodeUpdate(t, `{"tags": []}`).IsEmpty() {
+		t.Error("body clearing tags is empty")
+	}
+}
+
+func TestUpdateFileBodyValidate(t *testing.T) {
+	for _, body := range []string{
+		`{"name": "  "}`,
+		`{"access_level": "secret"}`,
+		`{"category": "spreadsheet"}`,
+		`{"expires_at": -1}`,
+	} {
+		b := &UpdateFileBody{}
+		if err := json.Unmarshal([]byte(body), b); err != nil {
+			t.Fatalf("decode %s: %v", body, err)
+		}
+		if err := b.Validate(); err == nil {
+			t.Errorf("%s accepted", body)
+		}
+	}
+}
+
+func TestCustomFieldFilters(t *testing.T) {
+	p := &ListFileParams{CustomFields: []string{"pages:gte:10", "reviewed:eq:true", "code:eq:a:b", "owner:exists"}}
+	filters, err := p.CustomFieldFilters()
+	if err != nil {
+		t.Fatalf("CustomFieldFilters: %v", err)
+	}
+	want := []CustomFieldFilter{
+		{Key: "pages", Op: CustomFieldGTE, Value: 10.0},
+		{Key: "reviewed", Op: CustomFieldEQ, Value: true},
+		{Key: "code", Op: CustomFieldEQ, Value: "a:b"},
+		{Key: "owner", Op: CustomFieldExists},
+	}
+	if !reflect.DeepEqual(filters, want) {
+		t.Fatalf("filters %+v, want %+v", filters, want)
+	}
+
+	for _, raw := range []string{"pages", "pages:between:1", "pages:gt", "a.b:eq:1", "$.x:exists", ":eq:1"} {
+		if _, err := (&ListFileParams{CustomFields: []string{raw}}).CustomFieldFilters(); err == nil {
+			t.Errorf("filter %q accepted", raw)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package structs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Processing option limits and defaults
+const (
+	DefaultThumbnailSize      = 300
+	DefaultCompressionQuality = 80
+	MaxProcessingDimension    = 8192
+)
+
+// processingFormats are the formats images can be converted to
+var processingFormats = map[string]bool{"jpeg": true, "jpg": true, "png": true, "gif": true, "webp": true}
+
+// processingQueryKeys are the query parameters understood by ParseProcessingOptions
+var processingQueryKeys = []string{"thumbnail", "resize", "compress", "w", "h", "quality", "format"}
+
+// ParseProcessingOptions builds processing options from a JSON document and
+// query parameters (?thumbnail=true&w=300&h=300&quality=85), query values
+// taking precedence. It returns nil when neither requests any processing.
+func ParseProcessingOptions(raw string, query url.Values) (*ProcessingOptions, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" && !hasProcessingQuery(query) {
+		return nil, nil
+	}
+
+	options := &ProcessingOptions{}
+	if raw != "" {
+		if err := json.Unmarshal([]byte(raw), options); err != nil {
+			return nil, fmt.Errorf("invalid processing options format: %w", err)
+		}
+	}
+
+	if err := options.applyQuery(query); err != nil {
+		return nil, err
+	}
+
+	options.ApplyDefaults()
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+// ApplyDefaults fills in sizes and quality left unset by the requested operations
+func (o *ProcessingOptions) ApplyDefaults() {
+	if o.CreateThumbnail || o.ResizeImage {
+		if o.MaxWidth == 0 {
+			o.MaxWidth = DefaultThumbnailSize
+		}
+		if o.MaxHeight == 0 {
+			o.MaxHeight = DefaultThumbnailSize
+		}
+	}
+	if o.CompressionQuality == 0 {
+		o.CompressionQuality = DefaultCompressionQuality
+	}
+	o.ConvertFormat = strings.ToLower(o.ConvertFormat)
+}
+
+// Validate checks the options are within supported ranges, a width or height of 0
+// is unset and only allowed when no thumbnail or resize fills it in
+func (o *ProcessingOptions) Validate() error {
+	if o.MaxWidth < 0 || o.MaxWidth > MaxProcessingDimension {
+		return fmt.Errorf("max_width must be between 0 (unset) and %d", MaxProcessingDimension)
+	}
+	if o.MaxHeight < 0 || o.MaxHeight > MaxProcessingDimension {
+		return fmt.Errorf("max_height must be between 0 (unset) and %d", MaxProcessingDimension)
+	}
+	if o.CompressionQuality < 1 || o.CompressionQuality > 100 {
+		return fmt.Errorf("compression_quality must be between 1 and 100")
+	}
+	if o.ConvertFormat != "" && !processingFormats[o.ConvertFormat] {
+		return fmt.Errorf("unsupported convert_format: %s", o.ConvertFormat)
+	}
+	return nil
+}
+
+// applyQuery overrides options with the processing query parameters present in query
+func (o *ProcessingOptions) applyQuery(query url.Values) error {
+	for _, key := range processingQueryKeys {
+		value := strings.TrimSpace(query.Get(key))
+		if value == "" {
+			continue
+		}
+
+		switch key {
+		case "thumbnail", "resize", "compress":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s", key, value)
+			}
+			switch key {
+			case "thumbnail":
+				o.CreateThumbnail = b
+			case "resize":
+				o.ResizeImage = b
+			default:
+				o.CompressImage = b
+			}
+		case "w", "h", "quality":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s", key, value)
+			}
+			switch key {
+			case "w":
+				o.MaxWidth = n
+			case "h":
+				o.MaxHeight = n
+			default:
+				o.CompressionQuality = n
+			}
+		case "format":
+			o.ConvertFormat = value
+		}
+	}
+	return nil
+}
+
+// hasProcessingQuery reports whether query carries any processing parameter
+func hasProcessingQuery(query url.Values) bool {
+	for _, key := range processingQueryKeys {
+		if query.Get(key) != "" {
+			return true
+		}
+	}
+	return false
+}
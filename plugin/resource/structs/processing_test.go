@@ -0,0 +1,50 @@
+package structs
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParseProcessingOptions(t *testing.T) {
+	options, err := ParseProcessingOptions(`{"create_thumbnail": true, "max_width": 100}`, url.Values{"w": {"200"}, "format": {"PNG"}})
+	if err != nil {
+		t.Fatalf("ParseProcessingOptions: %v", err)
+	}
+	if options.MaxWidth != 200 || options.MaxHeight != DefaultThumbnailSize {
+		t.Fatalf("size = %dx%d, want 200x%d", options.MaxWidth, options.MaxHeight, DefaultThumbnailSize)
+	}
+	if options.CompressionQuality != DefaultCompressionQuality || options.ConvertFormat != "png" {
+		t.Fatalf("quality %d, format %q", options.CompressionQuality, options.ConvertFormat)
+	}
+
+	if options, err := ParseProcessingOptions("", url.Values{}); options != nil || err != nil {
+		t.Fatalf("no processing = %+v, %v, want nil", options, err)
+	}
+}
+
+func TestParseProcessingOptionsLeavesSizeUnset(t *testing.T) {
+	options, err := ParseProcessingOptions("", url.Values{"compress": {"true"}})
+	if err != nil {
+		t.Fatalf("compress only: %v", err)
+	}
+	if options.MaxWidth != 0 || options.MaxHeight != 0 {
+		t.Fatalf("size = %dx%d, want unset", options.MaxWidth, options.MaxHeight)
+	}
+}
+
+func TestParseProcessingOptionsRejects(t *testing.T) {
+	for query, want := range map[string]string{
+		"w=-1":            "max_width must be between 0 (unset) and 8192",
+		"resize=1&h=9000": "max_height must be between 0 (unset) and 8192",
+		"quality=101":     "compression_quality",
+		"format=tiff":     "unsupported convert_format",
+		"thumbnail=yes":   "invalid thumbnail",
+	} {
+		values, _ := url.ParseQuery(query)
+		_, err := ParseProcessingOptions("", values)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", query, err, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package structs
+
+// StorageReportDateLayout is the layout of snapshot dates and report ranges
+const StorageReportDateLayout = "2006-01-02"
+
+// StorageSnapshot is the storage summary of an owner (space or user) on one day
+type StorageSnapshot struct {
+	OwnerID    string         `json:"owner_id"`
+	Date       string         `json:"date"` // UTC day, YYYY-MM-DD
+	TotalSize  int64          `json:"total_size"`
+	TotalCount int            `json:"total_count"`
+	ByCategory map[string]int `json:"by_category"`
+	TopFolders []FolderUsage  `json:"top_folders"`
+	SizeDelta  int64          `json:"size_delta"`  // Change of TotalSize since the previous snapshot in a report
+	CountDelta int            `json:"count_delta"` // Change of TotalCount since the previous snapshot in a report
+	CreatedAt  int64          `json:"created_at"`
+}
+
+// FolderUsage is the storage used by the files directly inside a folder
+type FolderUsage struct {
+	Path  string `json:"path"`
+	Size  int64  `json:"size"`
+	Count int    `json:"count"`
+}
+
+// StorageReportParams selects the snapshots of a storage report
+type StorageReportParams struct {
+	OwnerID string `form:"owner_id,omitempty" json:"owner_id,omitempty"` // Defaults to the current space
+	From    string `form:"from,omitempty" json:"from,omitempty"`         // YYYY-MM-DD, defaults to 30 days before to
+	To      string `form:"to,omitempty" json:"to,omitempty"`             // YYYY-MM-DD, defaults to today
+}
+
+// StorageReport is the storage history of an owner
+type StorageReport struct {
+	OwnerID     string             `json:"owner_id"`
+	From        string             `json:"from"`
+	To          string             `json:"to"`
+	CurrentSize int64              `json:"current_size"` // Live usage from the quota service
+	Series      []*StorageSnapshot `json:"series"`       // Daily snapshots within the range, oldest first
+}
+
+// StorageSnapshotResult reports a snapshot run
+type StorageSnapshotResult struct {
+	Date   string   `json:"date"`
+	Owners int      `json:"owners"`
+	Errors []string `json:"errors,omitempty"`
+}
@@ -20,11 +20,17 @@ type UserSpaceServiceInterface interface {
 	IsSpaceInUser(ctx context.Context, spaceID, userID string) (bool, error)
 }
 
+// SpaceSettingServiceInterface defines space setting service interface for resource plugin
+type SpaceSettingServiceInterface interface {
+	GetSettingValue(ctx context.Context, spaceID, key string) (any, error)
+}
+
 // SpaceServiceWrapper wraps space service access with fallback behavior
 type SpaceServiceWrapper struct {
 	em                ext.ManagerInterface
 	spaceQuotaService SpaceQuotaServiceInterface
 	userSpaceService  UserSpaceServiceInterface
+	settingService    SpaceSettingServiceInterface
 }
 
 // NewSpaceServiceWrapper creates a new space service wrapper
@@ -48,6 +54,12 @@ func (w *SpaceServiceWrapper) loadServices() {
 			w.userSpaceService = service
 		}
 	}
+
+	if settingSvc, err := w.em.GetCrossService("space", "SpaceSetting"); err == nil {
+		if service, ok := settingSvc.(SpaceSettingServiceInterface); ok {
+			w.settingService = service
+		}
+	}
 }
 
 // RefreshServices refreshes service references
@@ -122,3 +134,13 @@ func (w *SpaceServiceWrapper) IsUserInSpace(ctx context.Context, spaceID, userID
 func (w *SpaceServiceWrapper) HasUserSpaceService() bool {
 	return w.userSpaceService != nil
 }
+
+// GetSetting gets a space setting value, nil if the setting or service is not available
+func (w *SpaceServiceWrapper) GetSetting(ctx context.Context, spaceID, key string) (any, error) {
+	if w.settingService != nil {
+		return w.settingService.GetSettingValue(ctx, spaceID, key)
+	}
+
+	// Fallback: no setting if service not available
+	return nil, nil
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/ncobase/ncore/data/entgo/mixin"
+	"ncobase/internal/idgen"
 
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent/dialect/entsql"
@@ -31,7 +32,7 @@ func (Sample) Annotations() []schema.Annotation {
 // Mixin of the Sample.
 func (Sample) Mixin() []ent.Mixin {
 	return []ent.Mixin{
-		mixin.PrimaryKey,
+		idgen.PrimaryKey,
 		mixin.OperatorBy{},
 		mixin.TimeAt{},
 	}